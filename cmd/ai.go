@@ -12,8 +12,10 @@ import (
 )
 
 var (
-	aiGenerateNoBuild bool
-	aiGenerateServe   bool
+	aiGenerateNoBuild  bool
+	aiGenerateServe    bool
+	aiGenerateExamples int
+	aiGenerateSection  string
 )
 
 // aiCmd represents the root AI command group.
@@ -26,8 +28,15 @@ Supports OpenAI and OpenRouter providers. Configure your API credentials first u
 
 Examples:
   walgo ai configure          # Set up AI provider credentials
+  walgo ai rotate-key         # Replace a stored API key after validating it
+  walgo ai config show        # Show the active provider and model (key redacted)
+  walgo ai config import      # Import keys from the environment and dotfiles
   walgo ai generate           # Generate new content with auto-detection
   walgo ai update <file>      # Update existing content with AI
+  walgo ai rewrite <file>     # Revise content with theme-aware instructions
+  walgo ai audit              # Find pages missing theme-expected frontmatter
+  walgo ai summarize          # Write meta descriptions for pages missing one
+  walgo ai translate          # Translate content into another language
   walgo ai pipeline           # Create a complete site using AI pipeline`,
 }
 
@@ -55,22 +64,46 @@ func init() {
 	aiCmd.AddCommand(aiConfigureCmd)
 	aiCmd.AddCommand(aiGenerateCmd)
 	aiCmd.AddCommand(aiUpdateCmd)
+	aiCmd.AddCommand(aiRewriteCmd)
 	aiCmd.AddCommand(aiGetCmd)
+	aiCmd.AddCommand(aiConfigCmd)
+	aiConfigCmd.AddCommand(aiConfigShowCmd)
+	aiConfigCmd.AddCommand(aiConfigImportCmd)
 	aiCmd.AddCommand(aiRemoveCmd)
+	aiCmd.AddCommand(aiSetModelCmd)
+	aiCmd.AddCommand(aiRotateKeyCmd)
 	aiCmd.AddCommand(aiPipelineCmd)
 	aiCmd.AddCommand(aiPlanCmd)
 	aiCmd.AddCommand(aiResumeCmd)
+	aiCmd.AddCommand(aiAuditCmd)
+	aiCmd.AddCommand(aiSummarizeCmd)
+	aiCmd.AddCommand(aiTranslateCmd)
+	aiCmd.AddCommand(aiUsageCmd)
+
+	aiSetModelCmd.Flags().StringVar(&aiSetModelProvider, "provider", "", "Provider to update (default: the only configured provider)")
+	aiSetModelCmd.Flags().BoolVar(&aiSetModelForce, "force", false, "Accept models not in the known-models list")
+
+	aiConfigShowCmd.Flags().Bool("json", false, "Print the configuration as JSON (the API key stays redacted)")
+	aiConfigShowCmd.Flags().Bool("redacted", true, "Redact the API key; it is never shown in full")
+	aiConfigImportCmd.Flags().Bool("overwrite", false, "Replace the key of providers that are already configured")
+
+	aiRotateKeyCmd.Flags().StringVar(&aiRotateKeyProvider, "provider", "", "Provider to update (default: the only configured provider)")
+	aiRotateKeyCmd.Flags().BoolVar(&aiRotateKeyFromEnv, "from-env", false, "Read the new key from OPENAI_API_KEY or OPENROUTER_API_KEY instead of prompting")
 
 	aiGenerateCmd.Flags().BoolVar(&aiGenerateNoBuild, "no-build", false, "Skip automatic build after generating")
 	aiGenerateCmd.Flags().BoolVar(&aiGenerateServe, "serve", false, "Start development server after generating")
+	aiGenerateCmd.Flags().IntVar(&aiGenerateExamples, "examples", 0, "Include this many existing pages (at most 2) as style examples")
+	aiGenerateCmd.Flags().StringVar(&aiGenerateSection, "section", "", "Section to take --examples from (default: the main content section)")
 
 	aiPipelineCmd.Flags().BoolVarP(&aiPipelineVerbose, "verbose", "v", false, "Show verbose output")
 	aiPipelineCmd.Flags().BoolVar(&aiPipelineDryRun, "dry-run", false, "Plan and generate without writing files")
 	aiPipelineCmd.Flags().StringVar(&aiPipelineParallel, "parallel", "auto", "Parallel mode: auto, sequential, parallel")
 	aiPipelineCmd.Flags().IntVar(&aiPipelineConcurrent, "concurrent", 5, "Max concurrent page generations (default: 5)")
 	aiPipelineCmd.Flags().IntVar(&aiPipelineRPM, "rpm", 30, "Rate limit: requests per minute (default: 30)")
+	aiPipelineCmd.Flags().StringVar(&aiPipelinePlanner, "planner", "ai", "Content planner: ai, template (fixed pages, no AI call)")
 
 	aiPlanCmd.Flags().BoolVarP(&aiPipelineVerbose, "verbose", "v", false, "Show verbose output")
+	aiPlanCmd.Flags().StringVar(&aiPipelinePlanner, "planner", "ai", "Content planner: ai, template (fixed pages, no AI call)")
 
 	aiResumeCmd.Flags().BoolVarP(&aiPipelineVerbose, "verbose", "v", false, "Show verbose output")
 	aiResumeCmd.Flags().BoolVar(&aiPipelineDryRun, "dry-run", false, "Generate without writing files")
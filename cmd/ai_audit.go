@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// aiAuditCmd reports content files missing theme-expected frontmatter.
+var aiAuditCmd = &cobra.Command{
+	Use:   "audit [path]",
+	Short: "Find pages missing frontmatter the theme expects",
+	Long: `Check content files for frontmatter fields the site's theme expects,
+such as a featured_image a blog theme renders on each post.
+
+Expected fields come from the theme's archetypes, or are inferred from the
+section name and the page params the theme's templates use. Each missing field
+is reported with a severity:
+
+  required  a theme template renders the field unconditionally (and every
+            page needs a title)
+  optional  the field is recommended, but the theme renders without it
+
+Fields set to an empty string count as missing. Section list pages (_index.md)
+are not audited. No AI provider is needed.
+
+Examples:
+  walgo ai audit
+  walgo ai audit content/posts
+  walgo ai audit --strict   # exit non-zero if a required field is missing`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		strict, _ := cmd.Flags().GetBool("strict")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		target := filepath.Join(sitePath, "content")
+		if len(args) > 0 {
+			target = args[0]
+		}
+		if _, err := os.Stat(target); err != nil {
+			return fmt.Errorf("content path not found: %w", err)
+		}
+
+		audits, err := ai.AuditContent(sitePath, hugo.GetThemeName(sitePath), target)
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			if audits == nil {
+				audits = []*ai.FileAudit{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(audits); err != nil {
+				return fmt.Errorf("encoding audit: %w", err)
+			}
+		} else {
+			printContentAudit(os.Stdout, sitePath, audits)
+		}
+
+		if strict {
+			for _, a := range audits {
+				if a.HasRequired() {
+					return fmt.Errorf("content is missing required theme fields")
+				}
+			}
+		}
+		return nil
+	},
+}
+
+// printContentAudit writes a per-file report of missing fields.
+func printContentAudit(out io.Writer, sitePath string, audits []*ai.FileAudit) {
+	icons := ui.GetIcons()
+
+	if len(audits) == 0 {
+		fmt.Fprintf(out, "%s All pages have the frontmatter the theme expects\n", icons.Check)
+		return
+	}
+
+	required, optional := 0, 0
+	for _, a := range audits {
+		display := a.Path
+		if rel, err := filepath.Rel(sitePath, a.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			display = rel
+		}
+		fmt.Fprintf(out, "%s %s (%s)\n", icons.File, display, a.Section)
+		for _, m := range a.Missing {
+			icon := icons.Info
+			if m.Severity == ai.SeverityRequired {
+				icon = icons.Warning
+				required++
+			} else {
+				optional++
+			}
+			fmt.Fprintf(out, "    %s %-9s %s\n", icon, m.Severity, m.Field)
+		}
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "%s %d page(s) missing fields: %d required, %d optional\n",
+		icons.Lightbulb, len(audits), required, optional)
+}
+
+func init() {
+	aiAuditCmd.Flags().Bool("strict", false, "Exit with an error when a required field is missing")
+	aiAuditCmd.Flags().Bool("json", false, "Print the audit as JSON")
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+func TestAIAuditCommand(t *testing.T) {
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name: "AI audit help",
+			Args: []string{"ai", "audit", "--help"},
+			Contains: []string{
+				"frontmatter fields the site's theme expects",
+				"--strict",
+				"--json",
+			},
+		},
+	})
+}
+
+func TestPrintContentAudit(t *testing.T) {
+	site := t.TempDir()
+
+	t.Run("clean site", func(t *testing.T) {
+		var out bytes.Buffer
+		printContentAudit(&out, site, nil)
+		if !strings.Contains(out.String(), "All pages have the frontmatter") {
+			t.Errorf("unexpected output: %s", out.String())
+		}
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		audits := []*ai.FileAudit{{
+			Path:    filepath.Join(site, "content", "posts", "a.md"),
+			Section: "posts",
+			Missing: []ai.MissingField{
+				{Field: "featured_image", Severity: ai.SeverityRequired},
+				{Field: "tags", Severity: ai.SeverityOptional},
+			},
+		}}
+		var out bytes.Buffer
+		printContentAudit(&out, site, audits)
+		got := out.String()
+		for _, want := range []string{
+			filepath.Join("content", "posts", "a.md"),
+			"required  featured_image",
+			"optional  tags",
+			"1 page(s) missing fields: 1 required, 1 optional",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("output missing %q:\n%s", want, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// aiConfigCmd groups commands that inspect or import the AI configuration.
+var aiConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect or import the AI configuration",
+	Long: `Inspect the AI provider configuration stored in
+~/.walgo/ai-credentials.yaml, or import keys that are already on this machine.
+
+Examples:
+  walgo ai config show
+  walgo ai config show --json
+  walgo ai config import`,
+}
+
+// aiConfigShowCmd prints the active AI configuration with the key redacted.
+var aiConfigShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the active AI provider, model and base URL",
+	Long: `Show the provider, model and base URL that AI commands use, and every
+configured provider.
+
+The API key is always redacted to its prefix and last four characters
+(sk-...abcd), enough to tell keys apart without exposing them, so the
+output is safe to paste into bug reports. --json redacts it the same way.
+
+Examples:
+  walgo ai config show
+  walgo ai config show --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		redacted, _ := cmd.Flags().GetBool("redacted")
+		if !redacted {
+			return fmt.Errorf("the API key is never shown in full; read ~/.walgo/ai-credentials.yaml to see it")
+		}
+
+		return showAIConfig(os.Stdout, asJSON)
+	},
+}
+
+// showAIConfig writes the AI configuration to out, as JSON when asJSON is set.
+func showAIConfig(out io.Writer, asJSON bool) error {
+	view, err := loadAIConfigView()
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(view); err != nil {
+			return fmt.Errorf("encoding AI config: %w", err)
+		}
+		return nil
+	}
+	printAIConfigView(out, view)
+	return nil
+}
+
+// aiConfigView is the AI configuration as shown by 'walgo ai config show'.
+type aiConfigView struct {
+	Configured          bool     `json:"configured"`
+	Provider            string   `json:"provider,omitempty"`
+	Model               string   `json:"model,omitempty"`
+	BaseURL             string   `json:"baseURL,omitempty"`
+	APIKey              string   `json:"apiKey,omitempty"` // Always redacted
+	ConfiguredProviders []string `json:"configuredProviders"`
+	CredentialsFile     string   `json:"credentialsFile,omitempty"`
+}
+
+// loadAIConfigView reads the stored credentials. The active provider is the
+// one AI commands pick (see ai.LoadClient).
+func loadAIConfigView() (*aiConfigView, error) {
+	providers, err := ai.ListProviders()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list providers: %w", err)
+	}
+	sort.Strings(providers)
+
+	view := &aiConfigView{ConfiguredProviders: providers}
+	view.CredentialsFile, _ = ai.GetCredentialsPath()
+
+	creds, provider, err := ai.ActiveCredentials()
+	if err != nil {
+		// No usable key, which the empty view reports
+		return view, nil
+	}
+	view.Configured = true
+	view.Provider = provider
+	view.Model = creds.Model
+	view.BaseURL = creds.BaseURL
+	if view.BaseURL == "" {
+		view.BaseURL = ai.GetDefaultBaseURL(provider)
+	}
+	view.APIKey = ai.RedactKey(creds.APIKey)
+	return view, nil
+}
+
+// printAIConfigView writes view in the human-readable form.
+func printAIConfigView(out io.Writer, view *aiConfigView) {
+	icons := ui.GetIcons()
+
+	if !view.Configured {
+		fmt.Fprintf(out, "%s No AI credentials configured\n", icons.Warning)
+		if len(view.ConfiguredProviders) > 0 {
+			fmt.Fprintf(out, "   Configured providers: %s (none with a key AI commands can use)\n", strings.Join(view.ConfiguredProviders, ", "))
+		}
+		fmt.Fprintf(out, "\n%s Run 'walgo ai configure' to set up AI credentials\n", icons.Lightbulb)
+		return
+	}
+
+	fmt.Fprintf(out, "%s AI Configuration\n", icons.Robot)
+	fmt.Fprintln(out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(out, "   Provider: %s\n", view.Provider)
+	fmt.Fprintf(out, "   Model:    %s\n", view.Model)
+	fmt.Fprintf(out, "   Base URL: %s\n", view.BaseURL)
+	fmt.Fprintf(out, "   API Key:  %s\n", view.APIKey)
+	fmt.Fprintf(out, "\n%s Configured providers: %s\n", icons.Info, strings.Join(view.ConfiguredProviders, ", "))
+	if view.CredentialsFile != "" {
+		fmt.Fprintf(out, "%s Credentials file: %s\n", icons.File, view.CredentialsFile)
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// aiConfigImportCmd stores provider keys found in the environment or dotfiles.
+var aiConfigImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import API keys from the environment and common dotfiles",
+	Long: `Find provider API keys that are already on this machine and store the
+working ones, so they don't have to be entered again. Checked, in order:
+
+  - OPENAI_API_KEY and OPENROUTER_API_KEY
+  - ~/.config/openai/api_key and ~/.openai/api_key
+  - ~/.config/openrouter/api_key
+  - ~/.config/io.datasette.llm/keys.json (keys stored by the llm CLI)
+
+Each key is checked with a test call to the provider; rejected keys are
+never stored. The first working key of each provider is imported with the
+provider's default base URL and model. Providers that are already
+configured are left alone unless --overwrite is given, which replaces only
+their key.
+
+Examples:
+  walgo ai config import
+  walgo ai config import --overwrite`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+		home, _ := os.UserHomeDir()
+		sources := ai.DetectKeySources(home)
+		return importAIKeys(cmd.Context(), sources, overwrite, os.Stdout)
+	},
+}
+
+// importAIKeys validates each source and stores the first working key per
+// provider, reporting every source to out.
+func importAIKeys(ctx context.Context, sources []ai.KeySource, overwrite bool, out io.Writer) error {
+	icons := ui.GetIcons()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if len(sources) == 0 {
+		fmt.Fprintf(out, "%s No API keys found in the environment or known dotfiles\n", icons.Info)
+		fmt.Fprintf(out, "\n%s Run 'walgo ai configure' to enter a key\n", icons.Lightbulb)
+		return nil
+	}
+
+	creds, err := ai.LoadCredentials()
+	if err != nil {
+		return err
+	}
+
+	imported := make(map[string]string) // provider -> origin of the stored key
+	rejected := 0
+	for _, src := range sources {
+		if origin, ok := imported[src.Provider]; ok {
+			fmt.Fprintf(out, "%s %-10s %s: skipped, already imported from %s\n", icons.Info, src.Provider, src.Origin, origin)
+			continue
+		}
+
+		current, exists := creds.Providers[src.Provider]
+		if exists && current.APIKey == src.APIKey {
+			fmt.Fprintf(out, "%s %-10s %s: already stored\n", icons.Check, src.Provider, src.Origin)
+			imported[src.Provider] = "walgo"
+			continue
+		}
+		if exists && !overwrite {
+			fmt.Fprintf(out, "%s %-10s %s: skipped, provider already configured (use --overwrite)\n", icons.Info, src.Provider, src.Origin)
+			continue
+		}
+
+		baseURL := current.BaseURL
+		if baseURL == "" {
+			baseURL = ai.GetDefaultBaseURL(src.Provider)
+		}
+		if err := validateAIKey(ctx, src.Provider, src.APIKey, baseURL); err != nil {
+			fmt.Fprintf(out, "%s %-10s %s: rejected, not stored: %v\n", icons.Cross, src.Provider, src.Origin, err)
+			rejected++
+			continue
+		}
+
+		if exists {
+			err = ai.SetProviderAPIKey(src.Provider, src.APIKey)
+		} else {
+			err = ai.SetProviderCredentials(src.Provider, src.APIKey, baseURL, ai.GetDefaultModel(src.Provider))
+		}
+		if err != nil {
+			return fmt.Errorf("saving credentials: %w", err)
+		}
+		fmt.Fprintf(out, "%s %-10s %s: imported (%s)\n", icons.Success, src.Provider, src.Origin, ai.RedactKey(src.APIKey))
+		imported[src.Provider] = src.Origin
+	}
+
+	if len(imported) == 0 && rejected > 0 {
+		return fmt.Errorf("none of the %d keys found were accepted", rejected)
+	}
+	if credPath, err := ai.GetCredentialsPath(); err == nil {
+		fmt.Fprintf(out, "\n%s Credentials file: %s\n", icons.File, credPath)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+// stubKeyValidation makes validateAIKey accept only the given keys.
+func stubKeyValidation(t *testing.T, valid ...string) {
+	t.Helper()
+	orig := validateAIKey
+	t.Cleanup(func() { validateAIKey = orig })
+	validateAIKey = func(_ context.Context, provider, apiKey, baseURL string) error {
+		for _, k := range valid {
+			if apiKey == k {
+				return nil
+			}
+		}
+		return errors.New("401 Unauthorized")
+	}
+}
+
+func TestImportAIKeysFromEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("OPENAI_API_KEY", "sk-env-openai")
+	t.Setenv("OPENROUTER_API_KEY", "sk-or-env")
+	stubKeyValidation(t, "sk-env-openai", "sk-or-env")
+
+	var out bytes.Buffer
+	if err := importAIKeys(context.Background(), ai.DetectKeySources(home), false, &out); err != nil {
+		t.Fatalf("importAIKeys() error = %v", err)
+	}
+
+	for provider, key := range map[string]string{"openai": "sk-env-openai", "openrouter": "sk-or-env"} {
+		creds, err := ai.GetProviderCredentials(provider)
+		if err != nil {
+			t.Fatalf("%s not stored: %v", provider, err)
+		}
+		if creds.APIKey != key {
+			t.Errorf("%s key = %q, want %q", provider, creds.APIKey, key)
+		}
+		if creds.BaseURL != ai.GetDefaultBaseURL(provider) || creds.Model != ai.GetDefaultModel(provider) {
+			t.Errorf("%s defaults not applied: %+v", provider, creds)
+		}
+	}
+	if !strings.Contains(out.String(), "$OPENAI_API_KEY: imported") {
+		t.Errorf("output does not report the import:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "sk-env-openai") {
+		t.Errorf("output contains the full key:\n%s", out.String())
+	}
+}
+
+func TestImportAIKeysSkipsInvalid(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	stubKeyValidation(t, "sk-good")
+
+	sources := []ai.KeySource{
+		{Provider: "openai", Origin: "$OPENAI_API_KEY", APIKey: "sk-bad"},
+		{Provider: "openai", Origin: "~/.openai/api_key", APIKey: "sk-good"},
+		{Provider: "openrouter", Origin: "$OPENROUTER_API_KEY", APIKey: "sk-or-bad"},
+	}
+	var out bytes.Buffer
+	if err := importAIKeys(context.Background(), sources, false, &out); err != nil {
+		t.Fatalf("importAIKeys() error = %v", err)
+	}
+
+	creds, err := ai.GetProviderCredentials("openai")
+	if err != nil || creds.APIKey != "sk-good" {
+		t.Errorf("openai credentials = %+v, %v; want the valid key", creds, err)
+	}
+	if _, err := ai.GetProviderCredentials("openrouter"); err == nil {
+		t.Error("rejected openrouter key was stored")
+	}
+	if !strings.Contains(out.String(), "rejected, not stored") {
+		t.Errorf("output does not report the rejection:\n%s", out.String())
+	}
+}
+
+func TestImportAIKeysAllInvalid(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	stubKeyValidation(t)
+
+	sources := []ai.KeySource{{Provider: "openai", Origin: "$OPENAI_API_KEY", APIKey: "sk-bad"}}
+	var out bytes.Buffer
+	if err := importAIKeys(context.Background(), sources, false, &out); err == nil {
+		t.Fatal("importAIKeys() succeeded with no valid keys")
+	}
+	path, _ := ai.GetCredentialsPath()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("credentials file written for rejected keys: %v", err)
+	}
+}
+
+func TestImportAIKeysKeepsConfiguredProvider(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	stubKeyValidation(t, "sk-new")
+	if err := ai.SetProviderCredentials("openai", "sk-old", "https://proxy.example.com/v1", "gpt-4o"); err != nil {
+		t.Fatal(err)
+	}
+	sources := []ai.KeySource{{Provider: "openai", Origin: "$OPENAI_API_KEY", APIKey: "sk-new"}}
+
+	var out bytes.Buffer
+	if err := importAIKeys(context.Background(), sources, false, &out); err != nil {
+		t.Fatal(err)
+	}
+	if creds, _ := ai.GetProviderCredentials("openai"); creds.APIKey != "sk-old" {
+		t.Errorf("configured key replaced without --overwrite: %+v", creds)
+	}
+
+	if err := importAIKeys(context.Background(), sources, true, &out); err != nil {
+		t.Fatal(err)
+	}
+	creds, _ := ai.GetProviderCredentials("openai")
+	if creds.APIKey != "sk-new" || creds.BaseURL != "https://proxy.example.com/v1" || creds.Model != "gpt-4o" {
+		t.Errorf("--overwrite credentials = %+v, want new key with base URL and model kept", creds)
+	}
+}
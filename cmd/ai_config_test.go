@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+const aiConfigTestKey = "sk-proj-9f8e7d6c5b4a3210secretWXYZ"
+
+func TestShowAIConfig_RedactsKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := ai.SetProviderCredentials("openai", aiConfigTestKey, "", "gpt-4o"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ai.SetProviderCredentials("openrouter", "sk-or-v1-0000111122223333", "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, asJSON := range []bool{false, true} {
+		var out bytes.Buffer
+		if err := showAIConfig(&out, asJSON); err != nil {
+			t.Fatalf("showAIConfig(json=%v) error = %v", asJSON, err)
+		}
+		got := out.String()
+		if strings.Contains(got, aiConfigTestKey) || strings.Contains(got, "secret") {
+			t.Errorf("json=%v: output leaks the API key:\n%s", asJSON, got)
+		}
+		if !strings.Contains(got, "sk-...WXYZ") {
+			t.Errorf("json=%v: output = %q, want the redacted key sk-...WXYZ", asJSON, got)
+		}
+		for _, want := range []string{"openai", "openrouter", "gpt-4o", "https://api.openai.com/v1"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("json=%v: output missing %q:\n%s", asJSON, want, got)
+			}
+		}
+	}
+}
+
+func TestShowAIConfig_JSON(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := ai.SetProviderCredentials("openrouter", "sk-or-v1-abcdef0123456789", "https://proxy.example.com/v1", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := showAIConfig(&out, true); err != nil {
+		t.Fatal(err)
+	}
+	var view aiConfigView
+	if err := json.Unmarshal(out.Bytes(), &view); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if !view.Configured || view.Provider != "openrouter" || view.BaseURL != "https://proxy.example.com/v1" {
+		t.Errorf("view = %+v", view)
+	}
+	if view.Model != "openai/gpt-4" {
+		t.Errorf("model = %q, want the provider default", view.Model)
+	}
+	if view.APIKey != "sk-...6789" {
+		t.Errorf("apiKey = %q, want sk-...6789", view.APIKey)
+	}
+}
+
+func TestShowAIConfig_NotConfigured(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var out bytes.Buffer
+	if err := showAIConfig(&out, true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"configured": false`) || !strings.Contains(out.String(), `"configuredProviders": []`) {
+		t.Errorf("output = %s", out.String())
+	}
+}
+
+func TestAIConfigShowCommand(t *testing.T) {
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name:     "help",
+			Args:     []string{"ai", "config", "show", "--help"},
+			Contains: []string{"redacted", "--json"},
+		},
+	})
+}
@@ -66,10 +66,9 @@ Credentials are stored securely in ~/.walgo/ai-credentials.yaml`,
 		}
 
 		fmt.Println()
-		defaultModel := "gpt-4"
+		defaultModel := ai.GetDefaultModel(provider)
 		modelExamples := "gpt-4, gpt-4o, gpt-4-turbo, gpt-3.5-turbo"
 		if provider == "openrouter" {
-			defaultModel = "openai/gpt-4"
 			modelExamples = "openai/gpt-4, anthropic/claude-3.5-sonnet, google/gemini-pro"
 		}
 		fmt.Printf("Enter model name (e.g., %s)\n", modelExamples)
@@ -77,6 +76,10 @@ Credentials are stored securely in ~/.walgo/ai-credentials.yaml`,
 		if err != nil {
 			return fmt.Errorf("reading input: %w", err)
 		}
+		if err := ai.ValidateModel(provider, modelName, false); err != nil {
+			fmt.Printf("%s %v\n", icons.Warning, err)
+			fmt.Printf("   Saving anyway; change it later with 'walgo ai set-model'\n")
+		}
 
 		if err := ai.SetProviderCredentials(provider, apiKey, baseURL, modelName); err != nil {
 			return fmt.Errorf("saving credentials: %w", err)
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/selimozten/walgo/internal/ai"
@@ -48,12 +50,7 @@ Example:
 			fmt.Printf("\n%s Provider: %s\n", icons.Check, provider)
 			fmt.Printf("   Model:    %s\n", creds.Model)
 			fmt.Printf("   Base URL: %s\n", creds.BaseURL)
-
-			maskedKey := "****"
-			if len(creds.APIKey) > 8 {
-				maskedKey = creds.APIKey[:4] + "..." + creds.APIKey[len(creds.APIKey)-4:]
-			}
-			fmt.Printf("   API Key:  %s\n", maskedKey)
+			fmt.Printf("   API Key:  %s\n", ai.RedactKey(creds.APIKey))
 		}
 
 		credPath, _ := ai.GetCredentialsPath()
@@ -132,3 +129,74 @@ Examples:
 		return nil
 	},
 }
+
+var (
+	aiSetModelProvider string
+	aiSetModelForce    bool
+)
+
+// aiSetModelCmd changes the model for a configured provider.
+var aiSetModelCmd = &cobra.Command{
+	Use:   "set-model <name>",
+	Short: "Set the AI model for a configured provider",
+	Long: `Change the model used for AI generation without re-entering credentials.
+
+The model is checked against the list of known models for the provider.
+Use --force to set a model that is not in the list yet (e.g. a newly released one).
+
+Examples:
+  walgo ai set-model gpt-4o
+  walgo ai set-model anthropic/claude-3.5-sonnet --provider openrouter
+  walgo ai set-model gpt-6-preview --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAISetModel(aiSetModelProvider, args[0], aiSetModelForce)
+	},
+}
+
+// runAISetModel validates model and stores it for provider. When provider is
+// empty, the only configured provider is used.
+func runAISetModel(provider, model string, force bool) error {
+	icons := ui.GetIcons()
+
+	provider, err := resolveAIProvider(provider)
+	if err != nil {
+		return err
+	}
+
+	if err := ai.ValidateModel(provider, model, force); err != nil {
+		var unknown *ai.UnknownModelError
+		if errors.As(err, &unknown) {
+			return fmt.Errorf("%w (use --force if the model is new)", err)
+		}
+		return err
+	}
+
+	if err := ai.SetProviderModel(provider, model); err != nil {
+		return fmt.Errorf("failed to set model: %w", err)
+	}
+
+	fmt.Printf("%s Model for %s set to %s\n", icons.Success, provider, model)
+	return nil
+}
+
+// resolveAIProvider returns provider, or the only configured provider when it
+// is empty.
+func resolveAIProvider(provider string) (string, error) {
+	if provider != "" {
+		return provider, nil
+	}
+	providers, err := ai.ListProviders()
+	if err != nil {
+		return "", fmt.Errorf("failed to list providers: %w", err)
+	}
+	switch len(providers) {
+	case 0:
+		return "", fmt.Errorf("no AI credentials configured, run 'walgo ai configure' first")
+	case 1:
+		return providers[0], nil
+	default:
+		sort.Strings(providers)
+		return "", fmt.Errorf("multiple providers configured (%s), specify one with --provider", strings.Join(providers, ", "))
+	}
+}
@@ -21,14 +21,24 @@ var aiGenerateCmd = &cobra.Command{
 The AI will create properly formatted Hugo markdown files with frontmatter based on your instructions.
 Content type is automatically detected from your Hugo site structure.
 
+--examples includes up to 2 existing pages from the site as examples of its
+style, picked from --section (default: the site's main content section):
+those with the most complete frontmatter, then the longest. They are cut to
+fit a fixed size budget.
+
 Examples:
   walgo ai generate                    # Interactive generation with auto-detect
+  walgo ai generate --examples 2       # Show the model two of your best posts
   walgo ai generate --serve            # Generate and start dev server
   walgo ai generate --no-build         # Generate without building`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		icons := ui.GetIcons()
 		reader := bufio.NewReader(os.Stdin)
 
+		if aiGenerateExamples < 0 || aiGenerateExamples > ai.MaxContentExamples {
+			return fmt.Errorf("--examples must be between 0 and %d", ai.MaxContentExamples)
+		}
+
 		client, provider, model, err := ai.LoadClient(ai.LongRequestTimeout)
 		if err != nil {
 			fmt.Printf("\n%s Run 'walgo ai configure' to set up AI features\n", icons.Lightbulb)
@@ -95,6 +105,8 @@ Examples:
 			SitePath:     sitePath,
 			Instructions: instructions,
 			Context:      context.Background(),
+			Examples:     aiGenerateExamples,
+			Section:      aiGenerateSection,
 		})
 
 		if !result.Success {
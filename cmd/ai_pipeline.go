@@ -23,6 +23,7 @@ var (
 	aiPipelineParallel   string // auto, sequential, parallel
 	aiPipelineConcurrent int
 	aiPipelineRPM        int
+	aiPipelinePlanner    string // ai, template
 )
 
 // aiPipelineCmd executes the full AI content generation pipeline: plan then generate.
@@ -214,7 +215,13 @@ Example:
 			pipelineConfig.RequestsPerMinute = aiPipelineRPM
 		}
 
+		planner, err := ai.NewContentPlanner(aiPipelinePlanner, client, pipelineConfig)
+		if err != nil {
+			return err
+		}
+
 		pipeline := ai.NewPipeline(client, pipelineConfig)
+		pipeline.SetContentPlanner(planner)
 		pipeline.SetProgressHandler(ai.ConsoleProgressHandler(aiPipelineVerbose))
 
 		input := &ai.PlannerInput{
@@ -126,7 +126,13 @@ Example:
 		pipelineConfig.ContentDir = filepath.Join(sitePath, "content")
 		pipelineConfig.PlanPath = filepath.Join(sitePath, ".walgo", "plan.json")
 
+		planner, err := ai.NewContentPlanner(aiPipelinePlanner, client, pipelineConfig)
+		if err != nil {
+			return err
+		}
+
 		pipeline := ai.NewPipeline(client, pipelineConfig)
+		pipeline.SetContentPlanner(planner)
 		pipeline.SetProgressHandler(ai.ConsoleProgressHandler(aiPipelineVerbose))
 
 		input := &ai.PlannerInput{
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// aiRewriteCmd revises an existing content file according to instructions.
+var aiRewriteCmd = &cobra.Command{
+	Use:   "rewrite <file>",
+	Short: "Revise existing content with instructions",
+	Long: `Revise an existing Hugo content file using AI.
+
+The file is sent to your AI provider together with the site's theme analysis,
+so the revision keeps the fields and structure your theme expects. Existing
+frontmatter is preserved; fields returned by the AI are merged on top.
+
+Examples:
+  walgo ai rewrite content/posts/intro.md --instructions "Make it more concise"
+  walgo ai rewrite content/about.md --instructions "Add a team section" --diff`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		filePath := args[0]
+
+		instructions, _ := cmd.Flags().GetString("instructions")
+		showDiff, _ := cmd.Flags().GetBool("diff")
+		noBuild, _ := cmd.Flags().GetBool("no-build")
+
+		if instructions == "" {
+			return fmt.Errorf("--instructions is required")
+		}
+
+		client, provider, model, err := ai.LoadClient(ai.LongRequestTimeout)
+		if err != nil {
+			fmt.Printf("\n%s Run 'walgo ai configure' to set up AI features\n", icons.Lightbulb)
+			return err
+		}
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		fmt.Printf("%s AI Content Rewriter (%s: %s)\n", icons.Robot, provider, model)
+		fmt.Printf("%s File: %s\n", icons.File, filePath)
+		fmt.Printf("\n%s Rewriting content...\n", icons.Spinner)
+
+		changed, err := runAIRewrite(cmd.Context(), client, sitePath, filePath, instructions, showDiff, os.Stdout)
+		if err != nil {
+			return err
+		}
+
+		if showDiff || !changed {
+			return nil
+		}
+
+		fmt.Printf("\n%s File updated: %s\n", icons.Success, filePath)
+
+		if noBuild {
+			return nil
+		}
+		if err := hugo.BuildSite(sitePath); err != nil {
+			return fmt.Errorf("failed to build site: %w", err)
+		}
+
+		fmt.Printf("\n%s Next steps:\n", icons.Lightbulb)
+		fmt.Println("   - Preview: walgo serve")
+		fmt.Println("   - Deploy: walgo launch")
+
+		return nil
+	},
+}
+
+// runAIRewrite rewrites filePath with the given instructions. When showDiff is
+// true the changes are printed to out and the file is left untouched.
+// Returns whether the revised content differs from the original.
+func runAIRewrite(ctx context.Context, client *ai.Client, sitePath, filePath, instructions string, showDiff bool, out io.Writer) (bool, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return false, fmt.Errorf("reading file: %w", err)
+	}
+
+	existing, err := os.ReadFile(filePath) // #nosec G304 - user-specified content file
+	if err != nil {
+		return false, fmt.Errorf("reading file: %w", err)
+	}
+
+	req := ai.RewriteRequest{
+		Content:      string(existing),
+		Instructions: instructions,
+		ThemeContext: ai.BuildDynamicThemeContext(sitePath, hugo.GetThemeName(sitePath)),
+		Section:      ai.ContentSection(sitePath, filePath),
+	}
+
+	revised, err := ai.RewriteContent(ctx, client, req)
+	if err != nil {
+		return false, err
+	}
+
+	icons := ui.GetIcons()
+	diff := ui.LineDiff(string(existing), revised)
+	if diff == "" {
+		fmt.Fprintf(out, "%s No changes suggested\n", icons.Info)
+		return false, nil
+	}
+
+	if showDiff {
+		fmt.Fprintf(out, "\n%s Proposed changes (file not modified):\n\n", icons.Info)
+		fmt.Fprint(out, diff)
+		return true, nil
+	}
+
+	if err := os.WriteFile(filePath, []byte(revised), info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("saving file: %w", err)
+	}
+
+	return true, nil
+}
+
+func init() {
+	aiRewriteCmd.Flags().String("instructions", "", "What to change in the content (required)")
+	aiRewriteCmd.Flags().Bool("diff", false, "Show the proposed changes without writing the file")
+	aiRewriteCmd.Flags().Bool("no-build", false, "Skip automatic build after rewriting")
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+func newMockAIClient(t *testing.T, reply string) *ai.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": reply}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return ai.NewClient("openai", "test-key", server.URL, "gpt-4")
+}
+
+func writeRewriteFixture(t *testing.T) (string, string, string) {
+	t.Helper()
+	sitePath := t.TempDir()
+	filePath := filepath.Join(sitePath, "content", "posts", "hello.md")
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	original := "---\ntitle: Hello\nauthor: Jane\n---\n\nOriginal body.\n"
+	if err := os.WriteFile(filePath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return sitePath, filePath, original
+}
+
+func TestAIRewriteCommandFlags(t *testing.T) {
+	for _, name := range []string{"instructions", "diff", "no-build"} {
+		if aiRewriteCmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag --%s not found", name)
+		}
+	}
+}
+
+func TestRunAIRewriteDiffDoesNotModifyFile(t *testing.T) {
+	sitePath, filePath, original := writeRewriteFixture(t)
+	client := newMockAIClient(t, "---\ntitle: Hello World\n---\n\nRevised body.\n")
+
+	var out bytes.Buffer
+	changed, err := runAIRewrite(context.Background(), client, sitePath, filePath, "Expand the title", true, &out)
+	if err != nil {
+		t.Fatalf("runAIRewrite failed: %v", err)
+	}
+	if !changed {
+		t.Error("expected changes to be reported")
+	}
+
+	data, _ := os.ReadFile(filePath)
+	if string(data) != original {
+		t.Errorf("--diff must not modify the file, got:\n%s", data)
+	}
+	if !strings.Contains(out.String(), "+ Revised body.") || !strings.Contains(out.String(), "- Original body.") {
+		t.Errorf("diff output missing expected lines:\n%s", out.String())
+	}
+}
+
+func TestRunAIRewriteWritesMergedFrontmatter(t *testing.T) {
+	sitePath, filePath, _ := writeRewriteFixture(t)
+	client := newMockAIClient(t, "---\ntitle: Hello World\n---\n\nRevised body.\n")
+
+	var out bytes.Buffer
+	if _, err := runAIRewrite(context.Background(), client, sitePath, filePath, "Expand the title", false, &out); err != nil {
+		t.Fatalf("runAIRewrite failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(filePath)
+	content := string(data)
+	for _, want := range []string{"title: Hello World", "author: Jane", "Revised body."} {
+		if !strings.Contains(content, want) {
+			t.Errorf("rewritten file missing %q:\n%s", want, content)
+		}
+	}
+}
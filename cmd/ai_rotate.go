@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	aiRotateKeyProvider string
+	aiRotateKeyFromEnv  bool
+)
+
+// validateAIKey checks a key against the provider before it is stored;
+// replaced in tests.
+var validateAIKey = func(ctx context.Context, provider, apiKey, baseURL string) error {
+	return ai.NewClient(provider, apiKey, baseURL, "").ValidateKey(ctx)
+}
+
+// aiRotateKeyCmd replaces the API key of a configured provider.
+var aiRotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key",
+	Short: "Replace the API key of a configured provider",
+	Long: `Replace the stored API key of a configured AI provider.
+
+The new key is checked with a test call to the provider before anything is
+written; if the provider rejects it, the old key stays in place. The base URL
+and model are kept. Credentials are written to ~/.walgo/ai-credentials.yaml
+with owner-only (0600) permissions.
+
+The new key is read from a prompt, or with --from-env from the provider's
+environment variable (OPENAI_API_KEY or OPENROUTER_API_KEY).
+
+Examples:
+  walgo ai rotate-key --provider openai
+  OPENROUTER_API_KEY=sk-or-... walgo ai rotate-key --provider openrouter --from-env`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+
+		provider, err := resolveAIProvider(aiRotateKeyProvider)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+
+		var newKey string
+		if aiRotateKeyFromEnv {
+			envVar := aiKeyEnvVar(provider)
+			newKey = strings.TrimSpace(os.Getenv(envVar))
+			if newKey == "" {
+				return fmt.Errorf("%s is not set", envVar)
+			}
+		} else {
+			newKey, err = ui.PromptLine(bufio.NewReader(os.Stdin), fmt.Sprintf("Enter the new %s API key: ", strings.ToUpper(provider)))
+			if err != nil {
+				return fmt.Errorf("reading input: %w", err)
+			}
+		}
+
+		if err := rotateAIKey(cmd.Context(), provider, newKey, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		return nil
+	},
+}
+
+// aiKeyEnvVar is the environment variable holding a provider's API key.
+func aiKeyEnvVar(provider string) string {
+	return strings.ToUpper(provider) + "_API_KEY"
+}
+
+// rotateAIKey validates newKey against provider's stored base URL and only
+// then replaces the stored key. The old key is untouched when validation
+// fails.
+func rotateAIKey(ctx context.Context, provider, newKey string, out io.Writer) error {
+	icons := ui.GetIcons()
+
+	newKey = strings.TrimSpace(newKey)
+	if newKey == "" {
+		return fmt.Errorf("API key cannot be empty")
+	}
+
+	creds, err := ai.LoadCredentials()
+	if err != nil {
+		return err
+	}
+	current, ok := creds.Providers[provider]
+	if !ok {
+		return fmt.Errorf("no credentials found for provider %s, run 'walgo ai configure' first", provider)
+	}
+	if newKey == current.APIKey {
+		return fmt.Errorf("the new key is the same as the stored key")
+	}
+
+	fmt.Fprintf(out, "%s Validating the new %s key...\n", icons.Info, provider)
+	if err := validateAIKey(ctx, provider, newKey, current.BaseURL); err != nil {
+		return fmt.Errorf("new key rejected, stored key left unchanged: %w", err)
+	}
+
+	if err := ai.SetProviderAPIKey(provider, newKey); err != nil {
+		return fmt.Errorf("saving credentials: %w", err)
+	}
+
+	credPath, _ := ai.GetCredentialsPath()
+	fmt.Fprintf(out, "%s API key for %s rotated\n", icons.Success, provider)
+	fmt.Fprintf(out, "   Credentials: %s\n", credPath)
+	fmt.Fprintf(out, "\n%s Revoke the old key in your %s dashboard\n", icons.Lightbulb, provider)
+	return nil
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+// newKeyCheckServer is a mock provider that accepts only validKey.
+func newKeyCheckServer(t *testing.T, validKey string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+validKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAIRotateKeyCommandFlags(t *testing.T) {
+	for _, name := range []string{"provider", "from-env"} {
+		if aiRotateKeyCmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag --%s not found", name)
+		}
+	}
+}
+
+func TestRotateAIKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		newKey  string
+		wantKey string
+		wantErr string
+	}{
+		{name: "valid key replaces old", newKey: "sk-new", wantKey: "sk-new"},
+		{name: "rejected key leaves old intact", newKey: "sk-bad", wantKey: "sk-old", wantErr: "stored key left unchanged"},
+		{name: "empty key", newKey: "  ", wantKey: "sk-old", wantErr: "cannot be empty"},
+		{name: "same key", newKey: "sk-old", wantKey: "sk-old", wantErr: "same as the stored key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			server := newKeyCheckServer(t, "sk-new")
+			if err := ai.SetProviderCredentials("openai", "sk-old", server.URL, "gpt-4o"); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			err := rotateAIKey(context.Background(), "openai", tt.newKey, &out)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("rotateAIKey() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("rotateAIKey() error = %v, want %q", err, tt.wantErr)
+			}
+
+			creds, err := ai.GetProviderCredentials("openai")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if creds.APIKey != tt.wantKey {
+				t.Errorf("stored key = %q, want %q", creds.APIKey, tt.wantKey)
+			}
+			if creds.BaseURL != server.URL || creds.Model != "gpt-4o" {
+				t.Errorf("base URL and model not kept: %+v", creds)
+			}
+
+			path, _ := ai.GetCredentialsPath()
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0600 {
+				t.Errorf("credentials mode = %o, want 0600", info.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestRotateAIKeyValidationUnreachable(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := ai.SetProviderCredentials("openrouter", "sk-or-old", "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	orig := validateAIKey
+	t.Cleanup(func() { validateAIKey = orig })
+	validateAIKey = func(ctx context.Context, provider, apiKey, baseURL string) error {
+		return errors.New("failed to make request: connection refused")
+	}
+
+	err := rotateAIKey(context.Background(), "openrouter", "sk-or-new", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Fatalf("rotateAIKey() error = %v, want validation failure", err)
+	}
+	creds, err := ai.GetProviderCredentials("openrouter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.APIKey != "sk-or-old" {
+		t.Errorf("stored key = %q, want the old key kept", creds.APIKey)
+	}
+}
+
+func TestRotateAIKeyUnconfiguredProvider(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	err := rotateAIKey(context.Background(), "openai", "sk-new", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "walgo ai configure") {
+		t.Fatalf("rotateAIKey() error = %v, want configure hint", err)
+	}
+	if providers, _ := ai.ListProviders(); len(providers) != 0 {
+		t.Errorf("providers = %v, want none created", providers)
+	}
+}
+
+func TestAIKeyEnvVar(t *testing.T) {
+	if got := aiKeyEnvVar("openrouter"); got != "OPENROUTER_API_KEY" {
+		t.Errorf("aiKeyEnvVar(openrouter) = %q", got)
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// aiSummarizeCmd writes AI-generated meta descriptions into content frontmatter.
+var aiSummarizeCmd = &cobra.Command{
+	Use:   "summarize [path]",
+	Short: "Generate meta descriptions for pages missing one",
+	Long: `Generate an SEO meta description for each content file that lacks a
+description frontmatter field, and write it into the file.
+
+Each page is summarized by your AI provider together with the site's theme
+analysis, so the description matches the tone the theme is built for.
+Descriptions are kept to 160 characters. Pages that already have a description
+are skipped unless --overwrite is given.
+
+Examples:
+  walgo ai summarize --dry-run
+  walgo ai summarize content/posts
+  walgo ai summarize content/about.md --overwrite`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		target := filepath.Join(sitePath, "content")
+		if len(args) > 0 {
+			target = args[0]
+		}
+
+		client, provider, model, err := ai.LoadClient(ai.LongRequestTimeout)
+		if err != nil {
+			fmt.Printf("\n%s Run 'walgo ai configure' to set up AI features\n", icons.Lightbulb)
+			return err
+		}
+
+		fmt.Printf("%s AI Meta Descriptions (%s: %s)\n", icons.Robot, provider, model)
+		if dryRun {
+			fmt.Printf("%s Dry-run mode: no files will be modified\n", icons.Info)
+		}
+		fmt.Println()
+
+		updated, err := runAISummarize(cmd.Context(), client, sitePath, target, dryRun, overwrite, os.Stdout)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		switch {
+		case len(updated) == 0:
+			fmt.Printf("%s No files need a description\n", icons.Check)
+		case dryRun:
+			fmt.Printf("%s %d file(s) would be updated. Run without --dry-run to apply.\n", icons.Lightbulb, len(updated))
+		default:
+			fmt.Printf("%s Updated %d file(s)\n", icons.Success, len(updated))
+		}
+		return nil
+	},
+}
+
+// runAISummarize generates descriptions for the Markdown files under target
+// that lack one (or for every file when overwrite is set) and, unless dryRun
+// is set, writes them into the frontmatter. A file that cannot be summarized
+// is reported and skipped. Returns the files that were, or would be, updated.
+func runAISummarize(ctx context.Context, client *ai.Client, sitePath, target string, dryRun, overwrite bool, out io.Writer) ([]string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	icons := ui.GetIcons()
+
+	files, err := collectMarkdownFiles(target)
+	if err != nil {
+		return nil, err
+	}
+
+	themeContext := ai.BuildDynamicThemeContext(sitePath, hugo.GetThemeName(sitePath))
+
+	var updated []string
+	for _, path := range files {
+		data, err := os.ReadFile(path) // #nosec G304 - path is a content file under the target directory
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		content := string(data)
+
+		if !overwrite && ai.HasDescription(content) {
+			continue
+		}
+
+		description, err := ai.GenerateDescription(ctx, client, ai.SummarizeRequest{
+			Content:      content,
+			ThemeContext: themeContext,
+			Section:      ai.ContentSection(sitePath, path),
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return updated, ctx.Err()
+			}
+			fmt.Fprintf(out, "%s %s\n    %s Skipped: %v\n", icons.File, path, icons.Warning, err)
+			continue
+		}
+
+		fmt.Fprintf(out, "%s %s\n    description: %s\n", icons.File, path, description)
+
+		if !dryRun {
+			revised, err := ai.ApplyDescription(content, description)
+			if err != nil {
+				fmt.Fprintf(out, "    %s Skipped: %v\n", icons.Warning, err)
+				continue
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				return updated, fmt.Errorf("reading %s: %w", path, err)
+			}
+			if err := os.WriteFile(path, []byte(revised), info.Mode().Perm()); err != nil {
+				return updated, fmt.Errorf("saving %s: %w", path, err)
+			}
+		}
+		updated = append(updated, path)
+	}
+
+	return updated, nil
+}
+
+func init() {
+	aiSummarizeCmd.Flags().Bool("dry-run", false, "Show generated descriptions without modifying files")
+	aiSummarizeCmd.Flags().Bool("overwrite", false, "Replace descriptions that are already set")
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+func writeSummarizeFixture(t *testing.T) (sitePath, missing, described string) {
+	t.Helper()
+	sitePath = t.TempDir()
+	dir := filepath.Join(sitePath, "content", "posts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	missing = filepath.Join(dir, "missing.md")
+	described = filepath.Join(dir, "described.md")
+	if err := os.WriteFile(missing, []byte("---\ntitle: Missing\n---\n\nA post about Walrus storage.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(described, []byte("---\ntitle: Described\ndescription: Hand written.\n---\n\nAnother post.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return sitePath, missing, described
+}
+
+// newCountingAIClient is a mock provider that replies with reply and counts calls.
+func newCountingAIClient(t *testing.T, reply string, calls *int32) *ai.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		resp := map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": reply}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return ai.NewClient("openai", "test-key", server.URL, "gpt-4")
+}
+
+func TestAISummarizeCommandFlags(t *testing.T) {
+	for _, name := range []string{"dry-run", "overwrite"} {
+		if aiSummarizeCmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag --%s not found", name)
+		}
+	}
+}
+
+func TestRunAISummarizeAddsMissingDescriptions(t *testing.T) {
+	sitePath, missing, described := writeSummarizeFixture(t)
+	var calls int32
+	client := newCountingAIClient(t, "How Walrus stores sites.", &calls)
+
+	var out bytes.Buffer
+	updated, err := runAISummarize(context.Background(), client, sitePath, filepath.Join(sitePath, "content"), false, false, &out)
+	if err != nil {
+		t.Fatalf("runAISummarize failed: %v", err)
+	}
+	if len(updated) != 1 || updated[0] != missing {
+		t.Errorf("updated = %v, want [%s]", updated, missing)
+	}
+	if calls != 1 {
+		t.Errorf("provider calls = %d, want 1 (described file must be skipped)", calls)
+	}
+
+	data, _ := os.ReadFile(missing)
+	if !strings.Contains(string(data), `description: "How Walrus stores sites."`) {
+		t.Errorf("description not written:\n%s", data)
+	}
+	data, _ = os.ReadFile(described)
+	if !strings.Contains(string(data), "description: Hand written.") {
+		t.Errorf("existing description changed:\n%s", data)
+	}
+}
+
+func TestRunAISummarizeDryRunAndOverwrite(t *testing.T) {
+	sitePath, missing, described := writeSummarizeFixture(t)
+	var calls int32
+	client := newCountingAIClient(t, "Generated summary.", &calls)
+	original, _ := os.ReadFile(missing)
+
+	var out bytes.Buffer
+	updated, err := runAISummarize(context.Background(), client, sitePath, filepath.Join(sitePath, "content"), true, true, &out)
+	if err != nil {
+		t.Fatalf("runAISummarize failed: %v", err)
+	}
+	if len(updated) != 2 {
+		t.Errorf("updated = %v, want both files with --overwrite", updated)
+	}
+	if data, _ := os.ReadFile(missing); string(data) != string(original) {
+		t.Errorf("--dry-run must not modify files, got:\n%s", data)
+	}
+	if !strings.Contains(out.String(), "description: Generated summary.") {
+		t.Errorf("dry-run output missing description:\n%s", out.String())
+	}
+
+	if _, err := runAISummarize(context.Background(), client, sitePath, described, false, true, &out); err != nil {
+		t.Fatalf("runAISummarize failed: %v", err)
+	}
+	data, _ := os.ReadFile(described)
+	if !strings.Contains(string(data), `description: "Generated summary."`) || strings.Contains(string(data), "Hand written.") {
+		t.Errorf("--overwrite did not replace the description:\n%s", data)
+	}
+}
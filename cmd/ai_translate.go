@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// aiTranslateCmd writes an AI translation of a content file for a multilingual site.
+var aiTranslateCmd = &cobra.Command{
+	Use:   "translate <file> <lang>",
+	Short: "Translate a content file into another language",
+	Long: `Translate a Hugo content file into another language using AI.
+
+Only the title, description and body are translated; every other frontmatter
+field is copied unchanged. Where the translation is written follows the site's
+multilingual layout from its Hugo config:
+
+  - Translation by filename (default): content/posts/hello.md is translated
+    to content/posts/hello.<lang>.md
+  - Translation by content directory (languages.<lang>.contentDir set): the
+    file is written to the same relative path under that language's
+    contentDir, or content/<lang> when it has none
+
+An existing translation is not replaced unless --overwrite is given.
+
+Examples:
+  walgo ai translate content/posts/hello.md fr
+  walgo ai translate content/about.md pt-br --dry-run
+  walgo ai translate content/posts/hello.md de --overwrite`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		source, lang := args[0], args[1]
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+		if err := hugo.ValidateLanguageCode(lang); err != nil {
+			return err
+		}
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		client, provider, model, err := ai.LoadClient(ai.LongRequestTimeout)
+		if err != nil {
+			fmt.Printf("\n%s Run 'walgo ai configure' to set up AI features\n", icons.Lightbulb)
+			return err
+		}
+
+		fmt.Printf("%s AI Translator (%s: %s)\n", icons.Robot, provider, model)
+		fmt.Printf("%s File: %s\n", icons.File, source)
+		fmt.Printf("\n%s Translating to %s...\n", icons.Spinner, lang)
+
+		target, err := runAITranslate(cmd.Context(), client, sitePath, source, lang, overwrite, dryRun, os.Stdout)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return nil
+		}
+
+		fmt.Printf("\n%s Translation saved: %s\n", icons.Success, target)
+		fmt.Printf("\n%s Next steps:\n", icons.Lightbulb)
+		fmt.Println("   - Review the translation")
+		fmt.Println("   - Preview: walgo serve")
+		return nil
+	},
+}
+
+// runAITranslate translates source into lang and writes it where the site's
+// multilingual layout expects it. An existing translation is only replaced
+// when overwrite is set. With dryRun the translation is printed to out
+// instead of written. Returns the translation's path.
+func runAITranslate(ctx context.Context, client *ai.Client, sitePath, source, lang string, overwrite, dryRun bool, out io.Writer) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	icons := ui.GetIcons()
+
+	layout, err := hugo.DetectLanguageLayout(sitePath)
+	if err != nil {
+		return "", err
+	}
+	target, err := layout.TranslationPath(sitePath, source, lang)
+	if err != nil {
+		return "", err
+	}
+	if filepath.Clean(target) == filepath.Clean(source) {
+		return "", fmt.Errorf("%s is already the %s translation", source, lang)
+	}
+	if !overwrite {
+		if _, err := os.Stat(target); err == nil {
+			return "", fmt.Errorf("%s already exists (use --overwrite to replace it)", target)
+		}
+	}
+
+	data, err := os.ReadFile(source) // #nosec G304 - source is a content file named by the user
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", source, err)
+	}
+
+	if len(layout.Languages) > 0 && !layout.HasLanguage(lang) {
+		fmt.Fprintf(out, "%s Language %q is not configured in the site's [languages]; Hugo will not render it until it is\n", icons.Warning, lang)
+	}
+
+	translated, err := ai.TranslateContent(ctx, client, ai.TranslateRequest{Content: string(data), Language: lang})
+	if err != nil {
+		return "", err
+	}
+
+	if dryRun {
+		fmt.Fprintf(out, "%s Would write %s:\n\n%s", icons.Info, target, translated)
+		return target, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", filepath.Dir(target), err)
+	}
+	if err := os.WriteFile(target, []byte(translated), 0644); err != nil {
+		return "", fmt.Errorf("saving %s: %w", target, err)
+	}
+	return target, nil
+}
+
+func init() {
+	aiTranslateCmd.Flags().Bool("dry-run", false, "Print the translation without writing it")
+	aiTranslateCmd.Flags().Bool("overwrite", false, "Replace an existing translation")
+}
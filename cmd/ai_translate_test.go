@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAITranslateCommandFlags(t *testing.T) {
+	for _, name := range []string{"dry-run", "overwrite"} {
+		if aiTranslateCmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag --%s not found", name)
+		}
+	}
+}
+
+func writeTranslateFixture(t *testing.T, config string) (sitePath, source string) {
+	t.Helper()
+	sitePath = t.TempDir()
+	if config != "" {
+		if err := os.WriteFile(filepath.Join(sitePath, "hugo.toml"), []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dir := filepath.Join(sitePath, "content", "posts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	source = filepath.Join(dir, "hello.md")
+	if err := os.WriteFile(source, []byte("---\ntitle: Hello\ndate: 2024-01-01\n---\n\nWelcome.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return sitePath, source
+}
+
+func TestRunAITranslateByFilename(t *testing.T) {
+	sitePath, source := writeTranslateFixture(t, "[languages.en]\nweight = 1\n[languages.fr]\nweight = 2\n")
+	var calls int32
+	client := newCountingAIClient(t, "Bonjour", &calls)
+
+	var out bytes.Buffer
+	target, err := runAITranslate(context.Background(), client, sitePath, source, "fr", false, false, &out)
+	if err != nil {
+		t.Fatalf("runAITranslate failed: %v", err)
+	}
+	if want := filepath.Join(sitePath, "content", "posts", "hello.fr.md"); target != want {
+		t.Errorf("target = %s, want %s", target, want)
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("translation not written: %v", err)
+	}
+	if !strings.Contains(string(data), `title: "Bonjour"`) || !strings.Contains(string(data), "date: 2024-01-01") {
+		t.Errorf("unexpected translation:\n%s", data)
+	}
+	if strings.Contains(out.String(), "not configured") {
+		t.Errorf("unexpected warning for a configured language:\n%s", out.String())
+	}
+
+	// An existing translation is kept without --overwrite
+	if _, err := runAITranslate(context.Background(), client, sitePath, source, "fr", false, false, &out); err == nil {
+		t.Error("expected an error when the translation already exists")
+	}
+	if _, err := runAITranslate(context.Background(), client, sitePath, source, "fr", true, false, &out); err != nil {
+		t.Errorf("--overwrite failed: %v", err)
+	}
+}
+
+func TestRunAITranslateByDirectory(t *testing.T) {
+	sitePath, source := writeTranslateFixture(t, "[languages.en]\ncontentDir = \"content\"\n[languages.fr]\ncontentDir = \"content/fr\"\n")
+	var calls int32
+	client := newCountingAIClient(t, "Bonjour", &calls)
+
+	var out bytes.Buffer
+	target, err := runAITranslate(context.Background(), client, sitePath, source, "fr", false, false, &out)
+	if err != nil {
+		t.Fatalf("runAITranslate failed: %v", err)
+	}
+	if want := filepath.Join(sitePath, "content", "fr", "posts", "hello.md"); target != want {
+		t.Errorf("target = %s, want %s", target, want)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("translation not written: %v", err)
+	}
+}
+
+func TestRunAITranslateDryRunWarnsUnconfiguredLanguage(t *testing.T) {
+	sitePath, source := writeTranslateFixture(t, "[languages.en]\nweight = 1\n")
+	var calls int32
+	client := newCountingAIClient(t, "Hallo", &calls)
+
+	var out bytes.Buffer
+	target, err := runAITranslate(context.Background(), client, sitePath, source, "de", false, true, &out)
+	if err != nil {
+		t.Fatalf("runAITranslate failed: %v", err)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("--dry-run must not write %s", target)
+	}
+	if !strings.Contains(out.String(), "not configured") {
+		t.Errorf("expected a warning for an unconfigured language:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "Hallo") {
+		t.Errorf("dry-run output missing translation:\n%s", out.String())
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// aiUsageCmd summarizes recorded AI token usage and estimated cost.
+var aiUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Summarize AI token usage and estimated cost",
+	Long: `Summarize the tokens AI commands used and what they cost, per provider
+and model.
+
+Every AI request walgo makes records the tokens the provider reports in
+~/.walgo/ai-usage.jsonl. Costs are estimated from list prices for known
+models; calls to models without a known price are counted but left out of
+the cost, and marked in the output.
+
+Examples:
+  walgo ai usage
+  walgo ai usage --since 7d
+  walgo ai usage --since 1y --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		window, err := config.ParseHumanDuration(sinceFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: invalid --since: %v\n", icons.Error, err)
+			return err
+		}
+		since := time.Now().Add(-window)
+
+		path, err := ai.UsageLogPath()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		entries, err := ai.LoadUsage(path, since)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		summaries := ai.SummarizeUsage(entries)
+
+		if asJSON {
+			if summaries == nil {
+				summaries = []ai.UsageSummary{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(summaries); err != nil {
+				return fmt.Errorf("encoding usage: %w", err)
+			}
+			return nil
+		}
+		printAIUsage(os.Stdout, summaries, since, sinceFlag)
+		return nil
+	},
+}
+
+// printAIUsage prints one row per provider and model, then the totals.
+func printAIUsage(out io.Writer, summaries []ai.UsageSummary, since time.Time, window string) {
+	icons := ui.GetIcons()
+	if len(summaries) == 0 {
+		fmt.Fprintf(out, "%s No AI usage recorded since %s\n", icons.Info, since.Format("2006-01-02"))
+		return
+	}
+
+	providerWidth, modelWidth := len("PROVIDER"), len("MODEL")
+	for _, s := range summaries {
+		providerWidth = max(providerWidth, len(s.Provider))
+		modelWidth = max(modelWidth, len(s.Model))
+	}
+
+	fmt.Fprintf(out, "AI usage since %s (%s):\n\n", since.Format("2006-01-02"), window)
+	fmt.Fprintf(out, "  %-*s  %-*s  %6s  %12s  %12s  %12s  %10s\n",
+		providerWidth, "PROVIDER", modelWidth, "MODEL", "CALLS", "PROMPT", "COMPLETION", "TOTAL", "EST. COST")
+
+	var total ai.UsageSummary
+	for _, s := range summaries {
+		cost := fmt.Sprintf("$%.4f", s.Cost)
+		if s.UnpricedCalls == s.Calls {
+			cost = "unpriced"
+		} else if s.UnpricedCalls > 0 {
+			cost += "*"
+		}
+		fmt.Fprintf(out, "  %-*s  %-*s  %6d  %12d  %12d  %12d  %10s\n",
+			providerWidth, s.Provider, modelWidth, s.Model, s.Calls, s.PromptTokens, s.CompletionTokens, s.TotalTokens, cost)
+
+		total.Calls += s.Calls
+		total.PromptTokens += s.PromptTokens
+		total.CompletionTokens += s.CompletionTokens
+		total.TotalTokens += s.TotalTokens
+		total.Cost += s.Cost
+		total.UnpricedCalls += s.UnpricedCalls
+	}
+	fmt.Fprintf(out, "  %-*s  %6d  %12d  %12d  %12d  %10s\n",
+		providerWidth+modelWidth+2, "Total", total.Calls, total.PromptTokens, total.CompletionTokens, total.TotalTokens, fmt.Sprintf("$%.4f", total.Cost))
+
+	if total.UnpricedCalls > 0 {
+		fmt.Fprintf(out, "\n%s %d call(s) used models without a known price and are not in the cost\n", icons.Warning, total.UnpricedCalls)
+	}
+}
+
+func init() {
+	aiUsageCmd.Flags().String("since", "30d", "Only count usage from this long ago on, e.g. 7d, 30d, 6mo, 1y")
+	aiUsageCmd.Flags().Bool("json", false, "Print the summary as JSON")
+}
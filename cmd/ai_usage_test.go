@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+func TestAIUsageCommand(t *testing.T) {
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name:     "AI usage help",
+			Args:     []string{"ai", "usage", "--help"},
+			Contains: []string{"Costs are estimated", "--since", "--json"},
+		},
+	})
+}
+
+func TestPrintAIUsage(t *testing.T) {
+	since := time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC)
+
+	var out bytes.Buffer
+	printAIUsage(&out, nil, since, "30d")
+	if !strings.Contains(out.String(), "No AI usage recorded since 2026-09-15") {
+		t.Errorf("empty output = %q", out.String())
+	}
+
+	out.Reset()
+	printAIUsage(&out, []ai.UsageSummary{
+		{Provider: "openai", Model: "gpt-4o", Calls: 2, PromptTokens: 4000, CompletionTokens: 1000, TotalTokens: 5000, Cost: 0.02},
+		{Provider: "openrouter", Model: "someone/new-model", Calls: 1, PromptTokens: 300, CompletionTokens: 200, TotalTokens: 500, UnpricedCalls: 1},
+	}, since, "30d")
+	got := out.String()
+	for _, want := range []string{"AI usage since 2026-09-15 (30d)", "$0.0200", "unpriced", "Total", "5500", "1 call(s) used models without a known price"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
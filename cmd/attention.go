@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// defaultAttentionWindow is how far ahead walgo attention looks for expiry.
+const defaultAttentionWindow = 7 * 24 * time.Hour
+
+var attentionCmd = &cobra.Command{
+	Use:   "attention",
+	Short: "List projects that need action",
+	Long: `List the projects that need action, most urgent first:
+
+  - expired         storage has expired (critical)
+  - object_missing  the site object is no longer on-chain (critical)
+  - expiring_soon   storage expires within --within (warning)
+  - deploy_failed   the last deploy failed (warning)
+
+Archived and destroyed projects are skipped. Site objects that cannot be
+looked up, for example when offline, are not reported as missing. The
+command exits non-zero when any critical item is found.
+
+Examples:
+  walgo attention
+  walgo attention --within 72h
+  walgo attention --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		within, _ := cmd.Flags().GetDuration("within")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if within <= 0 {
+			return fmt.Errorf("--within must be greater than 0")
+		}
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		items, err := pm.GetProjectsNeedingAttention(within)
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			if items == nil {
+				items = []projects.AttentionItem{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(items); err != nil {
+				return fmt.Errorf("encoding attention items: %w", err)
+			}
+		} else {
+			printAttention(os.Stdout, items)
+		}
+
+		if critical := countCritical(items); critical > 0 {
+			return fmt.Errorf("%d critical item(s) need attention", critical)
+		}
+		return nil
+	},
+}
+
+// printAttention lists attention items, one per line, with the project's
+// network and a severity icon.
+func printAttention(w io.Writer, items []projects.AttentionItem) {
+	icons := ui.GetIcons()
+	if len(items) == 0 {
+		fmt.Fprintf(w, "%s No projects need attention\n", icons.Success)
+		return
+	}
+
+	nameWidth := len("PROJECT")
+	for _, item := range items {
+		if len(item.Project.Name) > nameWidth {
+			nameWidth = len(item.Project.Name)
+		}
+	}
+	for _, item := range items {
+		icon := icons.Warning
+		if item.Severity == projects.SeverityCritical {
+			icon = icons.Error
+		}
+		fmt.Fprintf(w, "%s %-*s  %-8s  %-14s  %s\n", icon, nameWidth, item.Project.Name, item.Project.Network, item.Reason, item.Message)
+	}
+}
+
+// countCritical returns the number of critical attention items.
+func countCritical(items []projects.AttentionItem) int {
+	n := 0
+	for _, item := range items {
+		if item.Severity == projects.SeverityCritical {
+			n++
+		}
+	}
+	return n
+}
+
+func init() {
+	rootCmd.AddCommand(attentionCmd)
+	attentionCmd.Flags().Duration("within", defaultAttentionWindow, "Report storage expiring within this window")
+	attentionCmd.Flags().Bool("json", false, "Print the items as JSON")
+}
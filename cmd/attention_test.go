@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/projects"
+)
+
+func TestPrintAttention(t *testing.T) {
+	items := []projects.AttentionItem{
+		{Project: &projects.Project{Name: "blog", Network: "mainnet"}, Reason: projects.AttentionExpired, Severity: projects.SeverityCritical, Message: "storage expired on 2026-01-02"},
+		{Project: &projects.Project{Name: "docs", Network: "testnet"}, Reason: projects.AttentionDeployFailed, Severity: projects.SeverityWarning, Message: "last deploy failed"},
+	}
+
+	var buf bytes.Buffer
+	printAttention(&buf, items)
+	out := buf.String()
+	for _, want := range []string{"blog", "expired", "storage expired on 2026-01-02", "docs", "deploy_failed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if got := countCritical(items); got != 1 {
+		t.Errorf("countCritical() = %d, want 1", got)
+	}
+
+	buf.Reset()
+	printAttention(&buf, nil)
+	if !strings.Contains(buf.String(), "No projects need attention") {
+		t.Errorf("empty output = %q", buf.String())
+	}
+}
@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/hugo"
 	"github.com/selimozten/walgo/internal/ui"
 
@@ -15,7 +19,40 @@ var buildCmd = &cobra.Command{
 	Short: "Build the Hugo site.",
 	Long: `Builds the Hugo site using the configuration found in the current directory
 (or the directory specified by global --config flag if walgo.yaml is there).
-This command runs the 'hugo' command to generate static files typically into the 'public' directory.`,
+This command runs the 'hugo' command to generate static files typically into the 'public' directory.
+
+By default Hugo's own --minify is used and the generated HTML is sampled to
+confirm minification happened; a warning is printed if the theme or site
+config disabled it. When Hugo minified the HTML, walgo's optimizer skips its
+HTML pass so pages are not processed twice.
+
+Hugo's content scheduling is always honored: pages with a future publishDate
+(or date) and pages whose expiryDate has passed are left out of the build,
+even if the site config sets buildFuture or buildExpired. Use --future /
+--expired to build them anyway. Drafts are likewise left out unless --drafts
+is given. These flags match 'walgo serve', so the built site is the one you
+previewed.
+
+A site can pin its Hugo release with hugo.version in walgo.yaml, or per
+build with --hugo-version. The build then runs the hugo on PATH when it is
+that version, or a copy cached in ~/.cache/walgo/hugo, and stops if there is
+neither. With --auto-install, walgo downloads the pinned release from GitHub,
+verifies its checksum, keeps it in the cache and builds with it. deploy,
+update and serve run the pinned release too.
+
+--reproducible (or hugo.reproducible in walgo.yaml) makes the output
+byte-reproducible for audits. The build time is SOURCE_DATE_EPOCH, or the
+time of the site's last git commit: Hugo's clock (what now returns in
+templates) is pinned to it, every built file is stamped with it, and file
+times and modes are not copied from the source tree. Page dates from
+:fileModTime are ignored. --verify-reproducible builds the site twice and
+fails listing any file whose content differs between the two builds.
+
+Examples:
+  walgo build
+  walgo build --drafts
+  walgo build --hugo-version 0.125.0 --auto-install
+  walgo build --reproducible --verify-reproducible`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		icons := ui.GetIcons()
 
@@ -24,10 +61,39 @@ This command runs the 'hugo' command to generate static files typically into the
 			return fmt.Errorf("cannot determine current directory: %w", err)
 		}
 
+		opts := scheduleBuildOptions(cmd)
+		opts.HugoMinify, _ = cmd.Flags().GetBool("minify-hugo")
+		verifyReproducible, _ := cmd.Flags().GetBool("verify-reproducible")
+
+		walgoCfg, cfgErr := config.LoadConfigFrom(sitePath)
+		publishDir := filepath.Join(sitePath, "public")
+		if cfgErr == nil {
+			opts.Reproducible = opts.Reproducible || walgoCfg.HugoConfig.Reproducible
+			if walgoCfg.HugoConfig.PublishDir != "" {
+				publishDir = filepath.Join(sitePath, walgoCfg.HugoConfig.PublishDir)
+			}
+		}
+		if verifyReproducible {
+			opts.Reproducible = true
+		}
+
+		pinned, _ := cmd.Flags().GetString("hugo-version")
+		if !cmd.Flags().Changed("hugo-version") && cfgErr == nil {
+			pinned = walgoCfg.HugoConfig.Version
+		}
+		if pinned = strings.TrimSpace(pinned); pinned != "" {
+			autoInstall, _ := cmd.Flags().GetBool("auto-install")
+			opts.HugoPath, err = hugo.ResolveHugoBinary(cmd.Context(), pinned, autoInstall, os.Stderr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return err
+			}
+		}
+
 		fmt.Printf("%s Building site...\n", icons.Package)
 
 		fmt.Printf("Running Hugo build...\n")
-		if err := hugo.BuildSite(sitePath); err != nil {
+		if err := hugo.BuildSiteWithOptions(sitePath, opts); err != nil {
 			fmt.Fprintf(os.Stderr, "\n%s Troubleshooting:\n", icons.Lightbulb)
 			fmt.Fprintf(os.Stderr, "  - Check that Hugo is installed: hugo version\n")
 			fmt.Fprintf(os.Stderr, "  - Check hugo.toml for syntax errors\n")
@@ -35,6 +101,13 @@ This command runs the 'hugo' command to generate static files typically into the
 			return fmt.Errorf("hugo build failed: %w", err)
 		}
 
+		if verifyReproducible {
+			if err := verifyReproducibleBuild(sitePath, publishDir, opts, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return err
+			}
+		}
+
 		fmt.Printf("\n%s Build complete! Output: %s\n", icons.Success, sitePath+"/public")
 		fmt.Printf("\n%s Next steps:\n", icons.Lightbulb)
 		fmt.Printf("  - Preview: walgo serve\n")
@@ -44,6 +117,56 @@ This command runs the 'hugo' command to generate static files typically into the
 	},
 }
 
+// addScheduleFlags registers the draft and scheduling overrides on a command
+// that builds the site. The names match 'walgo serve' so a previewed site can
+// be built the same way; --include-future and --include-expired are kept as
+// hidden aliases. --default-lang is registered here too since every command
+// that builds also generates the site's routes, and --reproducible since
+// every build can be made deterministic.
+func addScheduleFlags(c *cobra.Command) {
+	c.Flags().Bool("drafts", false, "Build pages marked as draft")
+	c.Flags().Bool("future", false, "Build pages whose publishDate is in the future")
+	c.Flags().Bool("expired", false, "Build pages whose expiryDate has passed")
+	c.Flags().Bool("include-future", false, "Alias for --future")
+	c.Flags().Bool("include-expired", false, "Alias for --expired")
+	_ = c.Flags().MarkHidden("include-future")
+	_ = c.Flags().MarkHidden("include-expired")
+	c.Flags().String("default-lang", "", "Serve this language (published under /<code>/) at the site root")
+	c.Flags().Bool("reproducible", false, "Build byte-reproducible output: clock and timestamps from SOURCE_DATE_EPOCH or the last commit, no source file times or modes (default: hugo.reproducible)")
+}
+
+// scheduleBuildOptions returns the default build options with the overrides
+// from addScheduleFlags applied.
+func scheduleBuildOptions(cmd *cobra.Command) hugo.BuildOptions {
+	flag := func(name string) bool {
+		v, _ := cmd.Flags().GetBool(name)
+		return v
+	}
+	opts := hugo.DefaultBuildOptions()
+	opts.IncludeDrafts = flag("drafts")
+	opts.IncludeFuture = flag("future") || flag("include-future")
+	opts.IncludeExpired = flag("expired") || flag("include-expired")
+	opts.DefaultLanguage, _ = cmd.Flags().GetString("default-lang")
+	opts.Reproducible = flag("reproducible")
+	return opts
+}
+
+// warnDraftsDeploy warns that a deploy built with --drafts publishes draft
+// pages. Returns whether a warning was printed.
+func warnDraftsDeploy(opts hugo.BuildOptions, out io.Writer) bool {
+	if !opts.IncludeDrafts {
+		return false
+	}
+	icons := ui.GetIcons()
+	fmt.Fprintf(out, "%s Warning: deploying with --drafts publishes draft pages to the public site\n", icons.Warning)
+	return true
+}
+
 func init() {
 	rootCmd.AddCommand(buildCmd)
+	buildCmd.Flags().Bool("minify-hugo", true, "Pass --minify to Hugo and verify the HTML output is minified")
+	buildCmd.Flags().String("hugo-version", "", "Build with this Hugo release (default: hugo.version from walgo.yaml)")
+	buildCmd.Flags().Bool("verify-reproducible", false, "Build twice with --reproducible and fail if any output file differs")
+	buildCmd.Flags().Bool("auto-install", false, "Download the pinned Hugo release into the walgo cache when it is not installed")
+	addScheduleFlags(buildCmd)
 }
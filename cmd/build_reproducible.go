@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/selimozten/walgo/internal/deployment"
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// maxReproducibleDiffs is how many differing files are listed before the
+// rest are summarized.
+const maxReproducibleDiffs = 20
+
+// reproducibleRebuild is a test hook for the second build run by
+// verifyReproducibleBuild.
+var reproducibleRebuild = hugo.BuildSiteWithOptions
+
+// verifyReproducibleBuild builds the site at sitePath again and compares the
+// file hashes in publishDir with those of the build already there.
+func verifyReproducibleBuild(sitePath, publishDir string, opts hugo.BuildOptions, out io.Writer) error {
+	icons := ui.GetIcons()
+
+	first, err := deployment.PublishDirHashes(publishDir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\n%s Rebuilding to check reproducibility...\n", icons.Info)
+	if err := reproducibleRebuild(sitePath, opts); err != nil {
+		return fmt.Errorf("second build failed: %w", err)
+	}
+	second, err := deployment.PublishDirHashes(publishDir)
+	if err != nil {
+		return err
+	}
+
+	diff := deployment.DiffPublishDirHashes(first, second)
+	if len(diff) == 0 {
+		fmt.Fprintf(out, "%s Reproducible: both builds produced the same %d file(s)\n", icons.Check, len(second))
+		return nil
+	}
+	fmt.Fprintf(out, "%s %d file(s) differ between the two builds:\n", icons.Cross, len(diff))
+	for i, p := range diff {
+		if i == maxReproducibleDiffs {
+			fmt.Fprintf(out, "  ... and %d more\n", len(diff)-i)
+			break
+		}
+		fmt.Fprintf(out, "  - %s\n", p)
+	}
+	return fmt.Errorf("build is not reproducible: %d file(s) differ", len(diff))
+}
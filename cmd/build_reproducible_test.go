@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/hugo"
+)
+
+func stubReproducibleRebuild(t *testing.T, build func(sitePath string, opts hugo.BuildOptions) error) {
+	t.Helper()
+	orig := reproducibleRebuild
+	t.Cleanup(func() { reproducibleRebuild = orig })
+	reproducibleRebuild = build
+}
+
+func writeBuildOutput(t *testing.T, publishDir, index string) {
+	t.Helper()
+	if err := os.MkdirAll(publishDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"index.html": index, "style.css": "body{}"} {
+		if err := os.WriteFile(filepath.Join(publishDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestVerifyReproducibleBuild(t *testing.T) {
+	publishDir := filepath.Join(t.TempDir(), "public")
+	writeBuildOutput(t, publishDir, "<html>same</html>")
+	stubReproducibleRebuild(t, func(string, hugo.BuildOptions) error {
+		writeBuildOutput(t, publishDir, "<html>same</html>")
+		return nil
+	})
+
+	var out bytes.Buffer
+	if err := verifyReproducibleBuild("", publishDir, hugo.BuildOptions{Reproducible: true}, &out); err != nil {
+		t.Fatalf("verifyReproducibleBuild() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "same 2 file(s)") {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestVerifyReproducibleBuild_Differs(t *testing.T) {
+	publishDir := filepath.Join(t.TempDir(), "public")
+	writeBuildOutput(t, publishDir, "<html>built at 10:00</html>")
+	stubReproducibleRebuild(t, func(string, hugo.BuildOptions) error {
+		writeBuildOutput(t, publishDir, "<html>built at 10:01</html>")
+		return nil
+	})
+
+	var out bytes.Buffer
+	err := verifyReproducibleBuild("", publishDir, hugo.BuildOptions{Reproducible: true}, &out)
+	if err == nil {
+		t.Fatal("verifyReproducibleBuild() should fail when the builds differ")
+	}
+	if !strings.Contains(out.String(), "- index.html") || strings.Contains(out.String(), "style.css") {
+		t.Errorf("output should list only index.html:\n%s", out.String())
+	}
+}
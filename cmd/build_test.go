@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
+	"github.com/selimozten/walgo/internal/hugo"
 	"github.com/spf13/cobra"
 )
 
@@ -42,4 +45,114 @@ func TestBuildCommandFlags(t *testing.T) {
 	if buildCommand == nil {
 		t.Fatal("build command not found")
 	}
+
+	flag := buildCommand.Flags().Lookup("minify-hugo")
+	if flag == nil {
+		t.Fatal("expected --minify-hugo flag")
+	}
+	if flag.DefValue != "true" {
+		t.Errorf("--minify-hugo default = %s, want true", flag.DefValue)
+	}
+
+	for name, def := range map[string]string{"hugo-version": "", "auto-install": "false"} {
+		flag := buildCommand.Flags().Lookup(name)
+		if flag == nil {
+			t.Errorf("expected --%s flag", name)
+			continue
+		}
+		if flag.DefValue != def {
+			t.Errorf("--%s default = %q, want %q", name, flag.DefValue, def)
+		}
+	}
+}
+
+func TestScheduleBuildOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantDrafts  bool
+		wantFuture  bool
+		wantExpired bool
+	}{
+		{"defaults exclude drafts and scheduled pages", nil, false, false, false},
+		{"drafts", []string{"--drafts"}, true, false, false},
+		{"future", []string{"--future"}, false, true, false},
+		{"expired", []string{"--expired"}, false, false, true},
+		{"all three", []string{"--drafts", "--future", "--expired"}, true, true, true},
+		{"include-future alias", []string{"--include-future"}, false, true, false},
+		{"include-expired alias", []string{"--include-expired"}, false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &cobra.Command{Use: "test"}
+			addScheduleFlags(c)
+			if err := c.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			opts := scheduleBuildOptions(c)
+			if opts.IncludeDrafts != tt.wantDrafts {
+				t.Errorf("IncludeDrafts = %v, want %v", opts.IncludeDrafts, tt.wantDrafts)
+			}
+			if opts.IncludeFuture != tt.wantFuture {
+				t.Errorf("IncludeFuture = %v, want %v", opts.IncludeFuture, tt.wantFuture)
+			}
+			if opts.IncludeExpired != tt.wantExpired {
+				t.Errorf("IncludeExpired = %v, want %v", opts.IncludeExpired, tt.wantExpired)
+			}
+			if !opts.HugoMinify {
+				t.Error("HugoMinify should keep its default")
+			}
+			if opts.DefaultLanguage != "" {
+				t.Errorf("DefaultLanguage = %q, want empty", opts.DefaultLanguage)
+			}
+		})
+	}
+}
+
+func TestScheduleBuildOptionsDefaultLang(t *testing.T) {
+	c := &cobra.Command{Use: "test"}
+	addScheduleFlags(c)
+	if err := c.ParseFlags([]string{"--default-lang", "en"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if got := scheduleBuildOptions(c).DefaultLanguage; got != "en" {
+		t.Errorf("DefaultLanguage = %q, want en", got)
+	}
+}
+
+func TestWarnDraftsDeploy(t *testing.T) {
+	var out bytes.Buffer
+	if warnDraftsDeploy(hugo.BuildOptions{IncludeFuture: true}, &out) || out.Len() != 0 {
+		t.Errorf("warned without --drafts: %q", out.String())
+	}
+
+	if !warnDraftsDeploy(hugo.BuildOptions{IncludeDrafts: true}, &out) {
+		t.Fatal("expected a warning with --drafts")
+	}
+	if !strings.Contains(out.String(), "publishes draft pages") {
+		t.Errorf("warning = %q, want it to mention draft pages", out.String())
+	}
+}
+
+func TestScheduleFlagsRegistered(t *testing.T) {
+	for _, c := range []*cobra.Command{buildCmd, deployCmd, deployHTTPCmd, updateCmd, watchCmd} {
+		for _, name := range []string{"drafts", "future", "expired", "include-future", "include-expired", "default-lang"} {
+			if c.Flags().Lookup(name) == nil {
+				t.Errorf("%s: missing --%s flag", c.Name(), name)
+			}
+		}
+	}
+}
+
+func TestScheduleBuildOptionsReproducible(t *testing.T) {
+	c := &cobra.Command{Use: "test"}
+	addScheduleFlags(c)
+	if err := c.ParseFlags([]string{"--reproducible"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if !scheduleBuildOptions(c).Reproducible {
+		t.Error("Reproducible should be set by --reproducible")
+	}
 }
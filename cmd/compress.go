@@ -7,6 +7,7 @@ import (
 
 	"github.com/selimozten/walgo/internal/compress"
 	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/hugo"
 	"github.com/selimozten/walgo/internal/projects"
 
 	"github.com/selimozten/walgo/internal/ui"
@@ -26,7 +27,8 @@ You can specify a different directory as an argument.
 Example:
   walgo compress              # Compress public/ directory
   walgo compress ./dist       # Compress ./dist directory
-  walgo compress --level 11   # Maximum compression`,
+  walgo compress --level 11   # Maximum compression
+  walgo compress --default-lang en  # Serve /en/ pages at the site root`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		icons := ui.GetIcons()
@@ -79,6 +81,14 @@ Example:
 			return fmt.Errorf("error reading generate-ws-resources flag: %w", err)
 		}
 
+		defaultLang, _ := cmd.Flags().GetString("default-lang")
+		if defaultLang != "" {
+			if err := hugo.ValidateLanguageCode(defaultLang); err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return err
+			}
+		}
+
 		fmt.Printf("%s Compressing files in: %s\n", icons.Package, targetDir)
 		fmt.Printf("  Compression level: %d\n", level)
 		if inPlace {
@@ -98,6 +108,7 @@ Example:
 		compressor := compress.New(compressConfig)
 
 		var stats *compress.DirectoryCompressionStats
+		var explicitRoutes map[string]string
 		if inPlace {
 			stats, err = compressor.CompressInPlace(targetDir)
 		} else {
@@ -138,16 +149,20 @@ Example:
 
 			customRoutes := make(map[string]string)
 			var customIgnore []string
+			var dirHeaders map[string]map[string]string
 			if cfg != nil {
 				customRoutes = cfg.CompressConfig.CustomRoutes
 				customIgnore = cfg.CompressConfig.IgnorePatterns
+				dirHeaders = cfg.CompressConfig.DirHeaders
 			}
+			explicitRoutes = customRoutes
 
 			wsOptions := compress.WSResourcesOptions{
 				CompressionStats: stats,
 				CacheConfig:      cacheConfig,
 				CustomRoutes:     customRoutes,
 				CustomIgnore:     customIgnore,
+				DirHeaders:       dirHeaders,
 			}
 
 			pm, err := projects.NewManager()
@@ -195,7 +210,10 @@ Example:
 				}
 			}
 		}
-		routes, err := compress.GenerateRoutesFromPublic(targetDir)
+		routes, err := compress.GenerateRoutesFromPublicWithOptions(targetDir, compress.RouteOptions{
+			DefaultLanguage: defaultLang,
+			ExplicitRoutes:  explicitRoutes,
+		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%s Warning: Failed to generate routes: %v\n", icons.Warning, err)
 		} else {
@@ -220,4 +238,5 @@ func init() {
 	compressCmd.Flags().BoolP("verbose", "v", false, "Show detailed file-by-file statistics")
 	compressCmd.Flags().Bool("in-place", false, "Replace original files with compressed versions (use with caution)")
 	compressCmd.Flags().Bool("generate-ws-resources", false, "Generate ws-resources.json for Walrus Sites")
+	compressCmd.Flags().String("default-lang", "", "Alias / and root-relative routes to this language (published under /<code>/)")
 }
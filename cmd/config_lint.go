@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// configCmd groups commands that work on the site's configuration.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and change the site's configuration",
+	Long: `Inspect the Hugo configuration (hugo.toml, hugo.yaml, config.toml, ...)
+of the site in the current directory, describe walgo.yaml, or switch the
+network the site deploys to.
+
+Examples:
+  walgo config lint
+  walgo config schema > walgo.schema.json
+  walgo config set-network mainnet`,
+}
+
+// configLintCmd checks the site's Hugo config for common mistakes.
+var configLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check the Hugo config for common mistakes",
+	Long: `Check the site's Hugo config file for mistakes that otherwise surface as
+cryptic build errors:
+
+  - syntax errors, with the offending line
+  - keys or tables defined twice (Hugo keys are case-insensitive, so
+    baseURL and baseurl clash)
+  - a missing or empty baseURL
+  - a theme that is not installed in themes/
+  - menu entries whose pageRef or url matches no page in content/
+
+Errors make the command exit non-zero; warnings do not.
+
+Examples:
+  walgo config lint
+  walgo config lint --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		result, err := hugo.LintConfig(sitePath)
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			if result.Issues == nil {
+				result.Issues = []hugo.ConfigIssue{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(result); err != nil {
+				return fmt.Errorf("encoding lint result: %w", err)
+			}
+		} else {
+			printConfigLint(os.Stdout, result)
+		}
+
+		if result.HasErrors() {
+			return fmt.Errorf("%s has errors", result.File)
+		}
+		return nil
+	},
+}
+
+// printConfigLint writes each issue as file:line with the offending line.
+func printConfigLint(out io.Writer, result *hugo.ConfigLintResult) {
+	icons := ui.GetIcons()
+
+	if len(result.Issues) == 0 {
+		fmt.Fprintf(out, "%s %s looks good\n", icons.Check, result.File)
+		return
+	}
+
+	errs, warnings := 0, 0
+	for _, issue := range result.Issues {
+		icon := icons.Warning
+		if issue.Severity == hugo.LintError {
+			icon = icons.Error
+			errs++
+		} else {
+			warnings++
+		}
+
+		location := result.File
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", result.File, issue.Line)
+		}
+		fmt.Fprintf(out, "%s %s: %s: %s\n", icon, location, issue.Severity, issue.Message)
+		if issue.Context != "" {
+			fmt.Fprintf(out, "    %4d | %s\n", issue.Line, issue.Context)
+		}
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "%s %d error(s), %d warning(s)\n", icons.Lightbulb, errs, warnings)
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configLintCmd)
+
+	configLintCmd.Flags().Bool("json", false, "Print the issues as JSON")
+}
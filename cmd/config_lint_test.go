@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/hugo"
+)
+
+func TestConfigLintCommand(t *testing.T) {
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name: "Config lint help",
+			Args: []string{"config", "lint", "--help"},
+			Contains: []string{
+				"a missing or empty baseURL",
+				"--json",
+			},
+		},
+	})
+}
+
+func TestPrintConfigLint(t *testing.T) {
+	t.Run("clean config", func(t *testing.T) {
+		var out bytes.Buffer
+		printConfigLint(&out, &hugo.ConfigLintResult{File: "hugo.toml"})
+		if !strings.Contains(out.String(), "hugo.toml looks good") {
+			t.Errorf("unexpected output: %s", out.String())
+		}
+	})
+
+	t.Run("issues", func(t *testing.T) {
+		result := &hugo.ConfigLintResult{
+			File: "hugo.toml",
+			Issues: []hugo.ConfigIssue{
+				{Severity: hugo.LintWarning, Message: "baseURL is not set"},
+				{Severity: hugo.LintError, Line: 3, Message: `theme "ananke" is not installed`, Context: `theme = "ananke"`},
+			},
+		}
+		var out bytes.Buffer
+		printConfigLint(&out, result)
+		got := out.String()
+		for _, want := range []string{
+			"hugo.toml: warning: baseURL is not set",
+			`hugo.toml:3: error: theme "ananke" is not installed`,
+			`   3 | theme = "ananke"`,
+			"1 error(s), 1 warning(s)",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("output missing %q:\n%s", want, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// configSchemaCmd prints a JSON Schema for walgo.yaml.
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print a JSON Schema for walgo.yaml",
+	Long: `Print a JSON Schema describing every walgo.yaml field, its type and its
+allowed values (such as networks and project categories).
+
+Editors use the schema to validate and autocomplete walgo.yaml. With the
+YAML language server (VS Code, Neovim, ...), save it next to your config and
+reference it from the first line of walgo.yaml:
+
+  # yaml-language-server: $schema=./walgo.schema.json
+
+Examples:
+  walgo config schema > walgo.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeConfigSchema(os.Stdout)
+	},
+}
+
+// writeConfigSchema writes the walgo.yaml JSON Schema to out.
+func writeConfigSchema(out io.Writer) error {
+	schema, err := config.JSONSchema()
+	if err != nil {
+		return fmt.Errorf("generating schema: %w", err)
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(schema); err != nil {
+		return fmt.Errorf("encoding schema: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	configCmd.AddCommand(configSchemaCmd)
+}
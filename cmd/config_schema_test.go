@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestConfigSchemaCommand(t *testing.T) {
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name:     "Config schema help",
+			Args:     []string{"config", "schema", "--help"},
+			Contains: []string{"yaml-language-server", "walgo config schema > walgo.schema.json"},
+		},
+	})
+}
+
+func TestWriteConfigSchema(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeConfigSchema(&out); err != nil {
+		t.Fatalf("writeConfigSchema failed: %v", err)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	props, _ := schema["properties"].(map[string]interface{})
+	for _, key := range []string{"hugo", "walrus", "obsidian", "optimizer", "compress", "cache"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema missing top-level property %q", key)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/walrus"
+	"github.com/spf13/cobra"
+)
+
+// configSetNetworkCmd switches every part of the deploy setup to one network.
+var configSetNetworkCmd = &cobra.Command{
+	Use:   "set-network <testnet|mainnet>",
+	Short: "Switch walgo.yaml, site-builder and the Sui client to a network",
+	Long: `Switch the network the site deploys to in one step. Three things have to
+agree for a deploy to land on the right network:
+
+  - walrus.network in walgo.yaml
+  - default_context in ~/.config/walrus/sites-config.yaml
+  - the Sui client's active environment (sui client active-env)
+
+Before changing anything, set-network checks that sui, walrus and
+site-builder are installed, that sites-config.yaml has a context for the
+network and that the Sui client has an environment for it. The three are
+then updated in order; if one fails, the ones already changed are put
+back. The state read back afterwards is shown.
+
+Examples:
+  walgo config set-network mainnet
+  walgo config set-network testnet`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"testnet", "mainnet"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		network := args[0]
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		fmt.Printf("%s Switching to %s...\n", icons.Wrench, network)
+		state, err := walrus.SetNetwork(sitePath, network)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			printNetworkState(os.Stdout, walrus.CurrentNetworkState(sitePath), network)
+			return err
+		}
+		printNetworkState(os.Stdout, state, network)
+		if !state.Consistent(network) {
+			return fmt.Errorf("network settings do not all point at %s", network)
+		}
+		fmt.Printf("%s Now deploying to %s\n", icons.Success, network)
+		return nil
+	},
+}
+
+// printNetworkState lists the network each part of the setup points at,
+// marking the ones that differ from network.
+func printNetworkState(out io.Writer, state *walrus.NetworkState, network string) {
+	icons := ui.GetIcons()
+	fmt.Fprintln(out)
+	for _, row := range []struct{ name, value string }{
+		{"walgo.yaml (walrus.network)", state.WalgoYAML},
+		{"sites-config.yaml (default_context)", state.SitesConfig},
+		{"Sui client (active-env)", state.SuiActiveEnv},
+	} {
+		value, icon := row.value, icons.Check
+		if value == "" {
+			value = "(unknown)"
+		}
+		if row.value != network {
+			icon = icons.Cross
+		}
+		fmt.Fprintf(out, "  %s %-38s %s\n", icon, row.name, value)
+	}
+	fmt.Fprintln(out)
+}
+
+func init() {
+	configCmd.AddCommand(configSetNetworkCmd)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// contentCmd groups commands that work on a site's existing content.
+var contentCmd = &cobra.Command{
+	Use:   "content",
+	Short: "Manage and enrich existing site content",
+	Long: `Tools for working with the Markdown content of a Hugo site.
+
+Examples:
+  walgo content list --section posts # List posts with their frontmatter
+  walgo content taxonomy --dry-run    # Suggest tags/categories for posts
+  walgo content new-series guides/go --parts 5 --title "Go" # Scaffold a series
+  walgo content check-links --external # Find broken links
+  walgo content frontmatter-convert --to toml # Rewrite frontmatter as TOML`,
+}
+
+func init() {
+	rootCmd.AddCommand(contentCmd)
+	contentCmd.AddCommand(contentListCmd)
+	contentCmd.AddCommand(contentTaxonomyCmd)
+	contentCmd.AddCommand(contentNewSeriesCmd)
+	contentCmd.AddCommand(contentCheckLinksCmd)
+	contentCmd.AddCommand(contentThumbnailsCmd)
+	contentCmd.AddCommand(contentFrontmatterConvertCmd)
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/linkcheck"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// contentCheckLinksCmd reports broken links in the site's content.
+var contentCheckLinksCmd = &cobra.Command{
+	Use:   "check-links",
+	Short: "Find broken links in content",
+	Long: `Check the links in content/ before publishing.
+
+Root-relative links such as /posts/intro/ are checked against the files of
+the last build in the publish directory, so run 'walgo build' first; without
+a build they are skipped. Relative links are not checked.
+
+With --external, every http(s) URL is also requested (HEAD, then GET when
+the server refuses HEAD) and reported when it fails, times out or answers
+4xx/5xx. Requests are rate limited and run a few at a time; links that
+worked are cached for a day in ~/.cache/walgo/links.json. Use
+--skip-domains for hosts known to block or flake on automated requests.
+
+Exits with an error when a broken link is found.
+
+Examples:
+  walgo content check-links
+  walgo content check-links --external
+  walgo content check-links --external --skip-domains twitter.com,linkedin.com
+  walgo content check-links --external --rate 2 --timeout 20s --no-cache`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		external, _ := cmd.Flags().GetBool("external")
+		skipDomains, _ := cmd.Flags().GetStringSlice("skip-domains")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		ratePerSecond, _ := cmd.Flags().GetFloat64("rate")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		opts := linkcheck.ExternalOptions{
+			Timeout:     timeout,
+			Concurrency: concurrency,
+			Rate:        ratePerSecond,
+			SkipDomains: skipDomains,
+		}
+		var cache *linkcheck.Cache
+		if external && !noCache {
+			if cachePath, err := linkcheck.DefaultCachePath(); err == nil {
+				cache = linkcheck.LoadCache(cachePath, linkcheck.DefaultCacheTTL)
+				opts.Cache = cache
+			}
+		}
+
+		publishDir := filepath.Join(sitePath, "public")
+		if cfg, err := config.LoadConfigFrom(sitePath); err == nil {
+			publishDir = filepath.Join(sitePath, cfg.HugoConfig.PublishDir)
+		}
+
+		broken, err := checkContentLinks(cmd.Context(), sitePath, publishDir, external, opts, os.Stdout)
+		if cache != nil {
+			if saveErr := cache.Save(); saveErr != nil {
+				fmt.Fprintf(os.Stderr, "%s Warning: %v\n", icons.Warning, saveErr)
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		if broken > 0 {
+			return fmt.Errorf("found %d broken link(s)", broken)
+		}
+		return nil
+	},
+}
+
+// checkContentLinks checks the links in sitePath's content against the
+// build in publishDir, external ones too when external is set, prints the
+// broken ones to out and returns how many there are.
+func checkContentLinks(ctx context.Context, sitePath, publishDir string, external bool, opts linkcheck.ExternalOptions, out io.Writer) (int, error) {
+	icons := ui.GetIcons()
+
+	links, err := linkcheck.ExtractSiteLinks(sitePath)
+	if err != nil {
+		return 0, err
+	}
+
+	internalCount, externalCount := 0, 0
+	for _, link := range links {
+		switch {
+		case link.IsInternal():
+			internalCount++
+		case link.IsExternal():
+			externalCount++
+		}
+	}
+
+	var findings []linkcheck.Finding
+	idx, err := linkcheck.BuildSiteIndex(publishDir)
+	switch {
+	case errors.Is(err, linkcheck.ErrNotBuilt):
+		fmt.Fprintf(out, "%s Skipped %d internal link(s): %s has no build; run 'walgo build' first\n", icons.Warning, internalCount, publishDir)
+	case err != nil:
+		return 0, err
+	default:
+		findings = linkcheck.CheckInternal(idx, links)
+		fmt.Fprintf(out, "%s Checked %d internal link(s)\n", icons.Info, internalCount)
+	}
+
+	if external {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		start := time.Now()
+		results := linkcheck.CheckExternal(ctx, links, opts)
+		cached, skipped := 0, 0
+		for _, res := range results {
+			if res.Cached {
+				cached++
+			}
+			if res.Skipped {
+				skipped++
+			}
+		}
+		fmt.Fprintf(out, "%s Checked %d external link(s) to %d URL(s) in %s (%d cached, %d skipped)\n",
+			icons.Info, externalCount, len(results), time.Since(start).Round(time.Millisecond), cached, skipped)
+		findings = append(findings, linkcheck.ExternalFindings(links, results)...)
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintf(out, "%s No broken links found\n", icons.Success)
+		return 0, nil
+	}
+
+	fmt.Fprintf(out, "\n%s %d broken link(s):\n", icons.Warning, len(findings))
+	for _, f := range findings {
+		fmt.Fprintf(out, "  %s %s:%d  %s  (%s)\n", icons.Cross, f.File, f.Line, f.URL, f.Reason)
+	}
+	return len(findings), nil
+}
+
+func init() {
+	contentCheckLinksCmd.Flags().Bool("external", false, "Also request every http(s) link and report dead ones")
+	contentCheckLinksCmd.Flags().StringSlice("skip-domains", nil, "Hosts (and their subdomains) not to request with --external")
+	contentCheckLinksCmd.Flags().Duration("timeout", linkcheck.DefaultTimeout, "Timeout for each external request")
+	contentCheckLinksCmd.Flags().Int("concurrency", linkcheck.DefaultConcurrency, "External requests in flight at once")
+	contentCheckLinksCmd.Flags().Float64("rate", linkcheck.DefaultRate, "Maximum external requests per second")
+	contentCheckLinksCmd.Flags().Bool("no-cache", false, "Request every external link even if it worked recently")
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/linkcheck"
+)
+
+func TestContentCheckLinksCommand(t *testing.T) {
+	for _, name := range []string{"external", "skip-domains", "timeout", "concurrency", "rate", "no-cache"} {
+		if contentCheckLinksCmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name:     "Content check-links help",
+			Args:     []string{"content", "check-links", "--help"},
+			Contains: []string{"--external", "--skip-domains", "4xx/5xx"},
+		},
+	})
+}
+
+func TestCheckContentLinks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	sitePath := t.TempDir()
+	files := map[string]string{
+		"content/about.md": "[home](/) [post](/posts/hello/) [nope](/posts/missing/)\n",
+		"content/posts/hello.md": "[live](" + srv.URL + "/live) [dead](" + srv.URL + "/gone)\n" +
+			"[flaky](https://flaky.example.com/x)\n",
+	}
+	for name, body := range files {
+		p := filepath.Join(sitePath, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	publishDir := filepath.Join(sitePath, "public")
+	var out bytes.Buffer
+	broken, err := checkContentLinks(context.Background(), sitePath, publishDir, false, linkcheck.ExternalOptions{}, &out)
+	if err != nil {
+		t.Fatalf("checkContentLinks() error = %v", err)
+	}
+	if broken != 0 || !strings.Contains(out.String(), "Skipped 3 internal link(s)") {
+		t.Errorf("before a build: broken = %d, output:\n%s", broken, out.String())
+	}
+
+	for _, name := range []string{"public/index.html", "public/posts/hello/index.html"} {
+		p := filepath.Join(sitePath, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("<html></html>"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out.Reset()
+	broken, err = checkContentLinks(context.Background(), sitePath, publishDir, false, linkcheck.ExternalOptions{}, &out)
+	if err != nil {
+		t.Fatalf("checkContentLinks() error = %v", err)
+	}
+	if broken != 1 || !strings.Contains(out.String(), "content/about.md:1  /posts/missing/") {
+		t.Errorf("internal only: broken = %d, output:\n%s", broken, out.String())
+	}
+	if strings.Contains(out.String(), "/gone") {
+		t.Error("external links must not be requested without --external")
+	}
+
+	out.Reset()
+	opts := linkcheck.ExternalOptions{Rate: 100, SkipDomains: []string{"flaky.example.com"}}
+	broken, err = checkContentLinks(context.Background(), sitePath, publishDir, true, opts, &out)
+	if err != nil {
+		t.Fatalf("checkContentLinks() error = %v", err)
+	}
+	if broken != 2 {
+		t.Errorf("broken = %d, want 2:\n%s", broken, out.String())
+	}
+	for _, want := range []string{
+		"content/posts/hello.md:1  " + srv.URL + "/gone  (HTTP 404)",
+		"1 skipped",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
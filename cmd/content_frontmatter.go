@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// contentFrontmatterConvertCmd rewrites content frontmatter in another format.
+var contentFrontmatterConvertCmd = &cobra.Command{
+	Use:   "frontmatter-convert",
+	Short: "Convert content frontmatter between YAML, TOML and JSON",
+	Long: `Rewrite the frontmatter of every Markdown file under content/ in another
+format: YAML (---), TOML (+++) or JSON ({ ... }).
+
+Field order, values and the page body are kept. Files already in the target
+format and files without frontmatter are left alone. A file whose frontmatter
+cannot be parsed is reported and skipped; the others are still converted.
+
+TOML has no null, so null fields are dropped when converting to TOML, and
+nested tables are written after a table's plain fields as TOML requires.
+Comments in the frontmatter are not carried over.
+
+Examples:
+  walgo content frontmatter-convert --to toml
+  walgo content frontmatter-convert --to yaml --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		to, _ := cmd.Flags().GetString("to")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		report, err := hugo.ConvertContentFrontmatter(filepath.Join(sitePath, "content"), strings.ToLower(to), dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		printFrontmatterConvertReport(os.Stdout, report, strings.ToUpper(to), dryRun)
+		if len(report.Failed) > 0 {
+			return fmt.Errorf("%d file(s) could not be converted", len(report.Failed))
+		}
+		return nil
+	},
+}
+
+// printFrontmatterConvertReport summarizes a ConvertContentFrontmatter run.
+func printFrontmatterConvertReport(out io.Writer, report *hugo.FrontmatterConvertReport, format string, dryRun bool) {
+	icons := ui.GetIcons()
+	converted := "Converted"
+	if dryRun {
+		converted = "Would convert"
+	}
+
+	for _, p := range report.Converted {
+		fmt.Fprintf(out, "  %s %s %s\n", icons.File, converted, p)
+	}
+	for _, f := range report.Failed {
+		fmt.Fprintf(out, "  %s %s\n", icons.Warning, f)
+	}
+	if len(report.Converted) == 0 && len(report.Failed) == 0 {
+		fmt.Fprintf(out, "%s All frontmatter is already %s\n", icons.Success, format)
+		return
+	}
+	fmt.Fprintf(out, "%s %s %d file(s) to %s; %d already %s\n", icons.Success, converted, len(report.Converted), format, len(report.Skipped), format)
+}
+
+func init() {
+	contentFrontmatterConvertCmd.Flags().String("to", "", "Target format: yaml, toml or json")
+	contentFrontmatterConvertCmd.Flags().Bool("dry-run", false, "List the files that would change without writing them")
+	_ = contentFrontmatterConvertCmd.MarkFlagRequired("to")
+}
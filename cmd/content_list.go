@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// defaultContentListColumns are the frontmatter fields shown when --columns is not set.
+var defaultContentListColumns = []string{"title", "date", "draft"}
+
+// contentListCmd lists content files, filtered by frontmatter.
+var contentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List content files filtered by frontmatter",
+	Long: `Walk content/ and list Markdown files whose frontmatter matches the given
+filters. YAML, TOML and JSON frontmatter are supported.
+
+Field names and values are compared case-insensitively; a list field such as
+tags matches when any of its entries equals the value. All filters must match.
+
+Examples:
+  walgo content list
+  walgo content list --section posts --draft
+  walgo content list --field author=jane --field tags=go
+  walgo content list --columns title,author,tags --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		section, _ := cmd.Flags().GetString("section")
+		fieldArgs, _ := cmd.Flags().GetStringArray("field")
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		fields, err := parseFieldFilters(fieldArgs)
+		if err != nil {
+			return err
+		}
+		filter := contentListFilter{Section: section, Fields: fields}
+		if cmd.Flags().Changed("draft") {
+			draft, _ := cmd.Flags().GetBool("draft")
+			filter.Draft = &draft
+		}
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		entries, err := listContent(filepath.Join(sitePath, "content"), filter)
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			return writeContentListJSON(os.Stdout, entries, columns)
+		}
+		printContentList(os.Stdout, entries, columns)
+		return nil
+	},
+}
+
+// contentListFilter selects content files by frontmatter. Zero values match everything.
+type contentListFilter struct {
+	Section string            // First directory under content/
+	Draft   *bool             // Required draft state, nil for any
+	Fields  map[string]string // Field name to required value
+}
+
+// contentEntry is one content file and its parsed frontmatter.
+type contentEntry struct {
+	Path        string // Relative to content/, slash-separated
+	Section     string
+	Frontmatter map[string]interface{}
+}
+
+// listContent returns the Markdown files under contentDir matching filter,
+// sorted by path.
+func listContent(contentDir string, filter contentListFilter) ([]contentEntry, error) {
+	files, err := collectMarkdownFiles(contentDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []contentEntry
+	for _, file := range files {
+		rel, err := filepath.Rel(contentDir, file)
+		if err != nil {
+			return nil, err
+		}
+		rel = filepath.ToSlash(rel)
+
+		data, err := os.ReadFile(file) // #nosec G304 - file comes from walking the content directory
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", file, err)
+		}
+		entry := contentEntry{
+			Path:        rel,
+			Section:     contentSection(rel),
+			Frontmatter: ai.ParseFrontmatterFields(string(data)),
+		}
+		if filter.matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// matches reports whether entry passes every filter.
+func (f contentListFilter) matches(entry contentEntry) bool {
+	if f.Section != "" && !strings.EqualFold(entry.Section, strings.Trim(f.Section, "/")) {
+		return false
+	}
+	if f.Draft != nil {
+		draft, _ := frontmatterValue(entry.Frontmatter, "draft")
+		if strings.EqualFold(formatFrontmatterValue(draft), "true") != *f.Draft {
+			return false
+		}
+	}
+	for key, want := range f.Fields {
+		value, ok := frontmatterValue(entry.Frontmatter, key)
+		if !ok || !frontmatterValueEquals(value, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// contentSection returns the section of a content path: its first directory,
+// or "" for files at the top of content/.
+func contentSection(rel string) string {
+	if i := strings.Index(rel, "/"); i >= 0 {
+		return rel[:i]
+	}
+	return ""
+}
+
+// parseFieldFilters turns key=value arguments into a filter map.
+func parseFieldFilters(args []string) (map[string]string, error) {
+	fields := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --field %q: expected key=value", arg)
+		}
+		fields[key] = strings.TrimSpace(value)
+	}
+	return fields, nil
+}
+
+// frontmatterValue looks up key case-insensitively, as Hugo does.
+func frontmatterValue(fm map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := fm[key]; ok {
+		return v, true
+	}
+	for k, v := range fm {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// frontmatterValueEquals compares a frontmatter value with want. Lists match
+// when any element does.
+func frontmatterValueEquals(value interface{}, want string) bool {
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			if frontmatterValueEquals(item, want) {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.EqualFold(formatFrontmatterValue(value), want)
+}
+
+// formatFrontmatterValue renders a decoded frontmatter value for display and
+// comparison. Dates are shortened to YYYY-MM-DD and lists are comma-joined.
+func formatFrontmatterValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format("2006-01-02")
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatFrontmatterValue(item)
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// printContentList writes entries as aligned columns, path first.
+func printContentList(out io.Writer, entries []contentEntry, columns []string) {
+	icons := ui.GetIcons()
+
+	if len(entries) == 0 {
+		fmt.Fprintf(out, "%s No matching content\n", icons.Info)
+		return
+	}
+
+	header := append([]string{"path"}, columns...)
+	rows := [][]string{header}
+	for _, e := range entries {
+		row := []string{e.Path}
+		for _, col := range columns {
+			v, _ := frontmatterValue(e.Frontmatter, col)
+			row = append(row, formatFrontmatterValue(v))
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+	for _, row := range rows {
+		var b strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				b.WriteString(cell)
+			} else {
+				fmt.Fprintf(&b, "%-*s  ", widths[i], cell)
+			}
+		}
+		fmt.Fprintln(out, strings.TrimRight(b.String(), " "))
+	}
+	fmt.Fprintf(out, "\n%s %d file(s)\n", icons.File, len(entries))
+}
+
+// contentListItem is the JSON form of a listed file.
+type contentListItem struct {
+	Path    string                 `json:"path"`
+	Section string                 `json:"section"`
+	Fields  map[string]interface{} `json:"fields"`
+}
+
+// writeContentListJSON writes entries with the selected frontmatter fields as
+// a JSON array. Values keep their decoded types.
+func writeContentListJSON(out io.Writer, entries []contentEntry, columns []string) error {
+	items := make([]contentListItem, 0, len(entries))
+	for _, e := range entries {
+		fields := make(map[string]interface{}, len(columns))
+		for _, col := range columns {
+			if v, ok := frontmatterValue(e.Frontmatter, col); ok {
+				fields[col] = v
+			}
+		}
+		items = append(items, contentListItem{Path: e.Path, Section: e.Section, Fields: fields})
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(items); err != nil {
+		return fmt.Errorf("encoding content list: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	contentListCmd.Flags().String("section", "", "Only list files in this section (first directory under content/)")
+	contentListCmd.Flags().Bool("draft", false, "Only list drafts (--draft=false lists published files only)")
+	contentListCmd.Flags().StringArray("field", nil, "Only list files whose frontmatter field equals a value, as key=value (repeatable)")
+	contentListCmd.Flags().StringSlice("columns", defaultContentListColumns, "Frontmatter fields to show")
+	contentListCmd.Flags().Bool("json", false, "Output as JSON")
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeContentFixture creates a small content/ tree and returns its path.
+func writeContentFixture(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "content")
+	files := map[string]string{
+		"_index.md": "---\ntitle: Home\n---\n",
+		"about.md":  "+++\ntitle = \"About\"\nauthor = \"Jane\"\n+++\n",
+		"posts/first.md": `---
+title: First
+date: 2024-01-15
+author: jane
+tags: [go, walrus]
+---
+`,
+		"posts/draft.md": `---
+title: Work in progress
+draft: true
+author: bob
+tags: [hugo]
+---
+`,
+		"posts/json.md": `{
+  "title": "From JSON",
+  "Author": "Jane",
+  "draft": false
+}
+`,
+		"docs/guide.md": "---\ntitle: Guide\ndraft: true\nauthor: jane\n---\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func entryPaths(entries []contentEntry) []string {
+	paths := []string{}
+	for _, e := range entries {
+		paths = append(paths, e.Path)
+	}
+	return paths
+}
+
+func TestListContent(t *testing.T) {
+	dir := writeContentFixture(t)
+	yes, no := true, false
+
+	tests := []struct {
+		name   string
+		filter contentListFilter
+		want   []string
+	}{
+		{
+			name:   "no filter lists everything",
+			filter: contentListFilter{},
+			want:   []string{"_index.md", "about.md", "docs/guide.md", "posts/draft.md", "posts/first.md", "posts/json.md"},
+		},
+		{
+			name:   "section",
+			filter: contentListFilter{Section: "posts"},
+			want:   []string{"posts/draft.md", "posts/first.md", "posts/json.md"},
+		},
+		{
+			name:   "section with trailing slash",
+			filter: contentListFilter{Section: "docs/"},
+			want:   []string{"docs/guide.md"},
+		},
+		{
+			name:   "drafts only",
+			filter: contentListFilter{Draft: &yes},
+			want:   []string{"docs/guide.md", "posts/draft.md"},
+		},
+		{
+			name:   "published only in section",
+			filter: contentListFilter{Section: "posts", Draft: &no},
+			want:   []string{"posts/first.md", "posts/json.md"},
+		},
+		{
+			name:   "field equality is case-insensitive across formats",
+			filter: contentListFilter{Fields: map[string]string{"author": "jane"}},
+			want:   []string{"about.md", "docs/guide.md", "posts/first.md", "posts/json.md"},
+		},
+		{
+			name:   "list field matches any element",
+			filter: contentListFilter{Fields: map[string]string{"tags": "walrus"}},
+			want:   []string{"posts/first.md"},
+		},
+		{
+			name:   "date field",
+			filter: contentListFilter{Fields: map[string]string{"date": "2024-01-15"}},
+			want:   []string{"posts/first.md"},
+		},
+		{
+			name:   "all filters combine",
+			filter: contentListFilter{Section: "posts", Draft: &yes, Fields: map[string]string{"author": "jane"}},
+			want:   []string{},
+		},
+		{
+			name:   "missing field does not match",
+			filter: contentListFilter{Fields: map[string]string{"series": ""}},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := listContent(dir, tt.filter)
+			if err != nil {
+				t.Fatalf("listContent() error = %v", err)
+			}
+			if got := entryPaths(entries); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("listContent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListContentMissingDir(t *testing.T) {
+	if _, err := listContent(filepath.Join(t.TempDir(), "content"), contentListFilter{}); err == nil {
+		t.Error("expected error for missing content directory")
+	}
+}
+
+func TestParseFieldFilters(t *testing.T) {
+	got, err := parseFieldFilters([]string{"author=jane", " series = Go Basics ", "empty="})
+	if err != nil {
+		t.Fatalf("parseFieldFilters() error = %v", err)
+	}
+	want := map[string]string{"author": "jane", "series": "Go Basics", "empty": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseFieldFilters() = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"author", "=jane"} {
+		if _, err := parseFieldFilters([]string{bad}); err == nil {
+			t.Errorf("parseFieldFilters(%q) expected error", bad)
+		}
+	}
+}
+
+func TestPrintContentList(t *testing.T) {
+	entries, err := listContent(writeContentFixture(t), contentListFilter{Section: "posts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	printContentList(&out, entries, []string{"title", "tags"})
+	lines := strings.Split(out.String(), "\n")
+	if !strings.HasPrefix(lines[0], "path") || !strings.Contains(lines[0], "title") {
+		t.Errorf("expected header row, got %q", lines[0])
+	}
+	if !strings.Contains(out.String(), "go, walrus") {
+		t.Errorf("expected joined tags in output, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "3 file(s)") {
+		t.Errorf("expected file count, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	printContentList(&out, nil, defaultContentListColumns)
+	if !strings.Contains(out.String(), "No matching content") {
+		t.Errorf("expected empty message, got: %s", out.String())
+	}
+}
+
+func TestWriteContentListJSON(t *testing.T) {
+	yes := true
+	entries, err := listContent(writeContentFixture(t), contentListFilter{Draft: &yes})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := writeContentListJSON(&out, entries, []string{"title", "draft", "series"}); err != nil {
+		t.Fatalf("writeContentListJSON() error = %v", err)
+	}
+
+	var items []contentListItem
+	if err := json.Unmarshal(out.Bytes(), &items); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	first := items[0]
+	if first.Path != "docs/guide.md" || first.Section != "docs" {
+		t.Errorf("unexpected first item: %+v", first)
+	}
+	if first.Fields["title"] != "Guide" || first.Fields["draft"] != true {
+		t.Errorf("unexpected fields: %v", first.Fields)
+	}
+	if _, ok := first.Fields["series"]; ok {
+		t.Error("missing fields should be omitted")
+	}
+}
+
+func TestContentListFlags(t *testing.T) {
+	for _, name := range []string{"section", "draft", "field", "columns", "json"} {
+		if contentListCmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+}
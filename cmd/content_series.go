@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// contentNewSeriesCmd scaffolds a multi-part content series.
+var contentNewSeriesCmd = &cobra.Command{
+	Use:   "new-series <section>",
+	Short: "Scaffold a multi-part content series",
+	Long: `Create a series under content/<section>: a branch bundle (_index.md)
+and one leaf bundle per part (part-1/index.md, part-2/index.md, ...).
+
+Each part is weighted in order, tagged with the series name (series
+taxonomy) and records its neighbours as prev/next frontmatter, so themes
+can link the parts together. Frontmatter fields the theme expects for the
+top-level section are added with placeholder values.
+
+Nothing is written if any of the files already exist.
+
+Examples:
+  walgo content new-series tutorials/go-basics --parts 5 --title "Go Basics"
+  walgo content new-series posts/walrus-101 --parts 3 --title "Walrus 101" --draft`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		title, _ := cmd.Flags().GetString("title")
+		parts, _ := cmd.Flags().GetInt("parts")
+		draft, _ := cmd.Flags().GetBool("draft")
+
+		if strings.TrimSpace(title) == "" {
+			return fmt.Errorf("--title is required")
+		}
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		section := filepath.ToSlash(args[0])
+		topSection := strings.Split(strings.Trim(section, "/"), "/")[0]
+		fields := ai.GetDynamicFrontmatterFields(sitePath, hugo.GetThemeName(sitePath), topSection)
+
+		created, err := hugo.NewSeries(sitePath, section, hugo.SeriesOptions{
+			Title:  title,
+			Parts:  parts,
+			Fields: fields,
+			Draft:  draft,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create series: %w", err)
+		}
+
+		fmt.Printf("%s Created series %q with %d part(s):\n", icons.Success, title, parts)
+		for _, p := range created {
+			if rel, err := filepath.Rel(sitePath, p); err == nil {
+				p = rel
+			}
+			fmt.Printf("  %s %s\n", icons.Pencil, p)
+		}
+		fmt.Printf("\n%s Next steps:\n", icons.Lightbulb)
+		fmt.Println("   - Write each part, then preview with: walgo serve")
+		return nil
+	},
+}
+
+func init() {
+	contentNewSeriesCmd.Flags().String("title", "", "Series title (required)")
+	contentNewSeriesCmd.Flags().Int("parts", 3, "Number of parts to create")
+	contentNewSeriesCmd.Flags().Bool("draft", false, "Mark the series and its parts as drafts")
+}
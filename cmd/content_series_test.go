@@ -0,0 +1,26 @@
+package cmd
+
+import "testing"
+
+func TestContentNewSeriesCommand(t *testing.T) {
+	for _, name := range []string{"title", "parts", "draft"} {
+		if contentNewSeriesCmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+
+	tests := []TestCase{
+		{
+			Name:     "Content new-series help",
+			Args:     []string{"content", "new-series", "--help"},
+			Contains: []string{"part-1/index.md", "--parts", "--title"},
+		},
+		{
+			Name:        "Content new-series requires a title",
+			Args:        []string{"content", "new-series", "guides/go", "--title", ""},
+			ExpectError: true,
+			Contains:    []string{"--title is required"},
+		},
+	}
+	runTestCases(t, rootCmd, tests)
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// contentTaxonomyCmd suggests taxonomy terms for content files.
+var contentTaxonomyCmd = &cobra.Command{
+	Use:   "taxonomy [path]",
+	Short: "Suggest tags and categories from content",
+	Long: `Scan content files and suggest taxonomy terms (tags, categories, ...)
+based on the keywords in each page.
+
+Only taxonomies supported by the site's theme are used, with the names the
+theme declares. Terms are written into the frontmatter of files that lack them;
+taxonomies a file already sets are never changed. Terms already used elsewhere
+on the site are preferred to keep the vocabulary consistent.
+
+Each suggestion comes with a confidence score (0-100%) based on how strongly
+the keywords stand out and how much text they were drawn from.
+
+Examples:
+  walgo content taxonomy --dry-run
+  walgo content taxonomy content/posts
+  walgo content taxonomy --max-terms 3`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		maxTerms, _ := cmd.Flags().GetInt("max-terms")
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		target := filepath.Join(sitePath, "content")
+		if len(args) > 0 {
+			target = args[0]
+		}
+
+		themeName := hugo.GetThemeName(sitePath)
+		if themeName != "" && !ai.AnalyzeTheme(sitePath, themeName).HasTaxonomies {
+			return fmt.Errorf("theme '%s' does not support taxonomies", themeName)
+		}
+		taxonomies := ai.TaxonomyNames(ai.AnalyzeThemeConfig(sitePath, themeName))
+
+		fmt.Printf("%s Suggesting %s for %s\n", icons.Search, strings.Join(taxonomies, ", "), target)
+		if dryRun {
+			fmt.Printf("%s Dry-run mode: no files will be modified\n", icons.Info)
+		}
+		fmt.Println()
+
+		suggestions, err := runContentTaxonomy(target, taxonomies, maxTerms, dryRun, os.Stdout)
+		if err != nil {
+			return err
+		}
+
+		updated := 0
+		for _, s := range suggestions {
+			if s.HasSuggestions() {
+				updated++
+			}
+		}
+
+		fmt.Println()
+		switch {
+		case updated == 0:
+			fmt.Printf("%s No files need taxonomy terms\n", icons.Check)
+		case dryRun:
+			fmt.Printf("%s %d file(s) would be updated. Run without --dry-run to apply.\n", icons.Lightbulb, updated)
+		default:
+			fmt.Printf("%s Updated %d file(s)\n", icons.Success, updated)
+		}
+		return nil
+	},
+}
+
+// runContentTaxonomy suggests taxonomy terms for every Markdown file under
+// target and, unless dryRun is set, writes them into files lacking them.
+func runContentTaxonomy(target string, taxonomies []string, maxTerms int, dryRun bool, out io.Writer) ([]*ai.TaxonomySuggestion, error) {
+	icons := ui.GetIcons()
+
+	files, err := collectMarkdownFiles(target)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make(map[string]string, len(files))
+	for _, path := range files {
+		data, err := os.ReadFile(path) // #nosec G304 - path is a content file under the target directory
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		contents[path] = string(data)
+	}
+
+	opts := ai.TaxonomyOptions{
+		Taxonomies: taxonomies,
+		MaxTerms:   maxTerms,
+		Vocabulary: siteTaxonomyVocabulary(contents, taxonomies),
+	}
+
+	var suggestions []*ai.TaxonomySuggestion
+	for _, path := range files {
+		s := ai.SuggestTaxonomies(contents[path], opts)
+		s.Path = path
+		suggestions = append(suggestions, s)
+
+		if !s.HasSuggestions() {
+			continue
+		}
+
+		fmt.Fprintf(out, "%s %s (confidence %.0f%%)\n", icons.File, path, s.Confidence*100)
+		for _, taxonomy := range taxonomies {
+			if terms := s.Suggested[taxonomy]; len(terms) > 0 {
+				fmt.Fprintf(out, "    %s: %s\n", taxonomy, strings.Join(terms, ", "))
+			}
+		}
+
+		if dryRun {
+			continue
+		}
+
+		updated, err := ai.ApplyTaxonomies(contents[path], s.Suggested)
+		if err != nil {
+			fmt.Fprintf(out, "    %s Skipped: %v\n", icons.Warning, err)
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, []byte(updated), info.Mode().Perm()); err != nil {
+			return nil, fmt.Errorf("saving %s: %w", path, err)
+		}
+	}
+
+	return suggestions, nil
+}
+
+// siteTaxonomyVocabulary collects the terms already used for each taxonomy,
+// most frequently used first.
+func siteTaxonomyVocabulary(contents map[string]string, taxonomies []string) map[string][]string {
+	counts := make(map[string]map[string]int)
+	for _, content := range contents {
+		for taxonomy, terms := range ai.ReadTaxonomyTerms(content, taxonomies) {
+			if counts[taxonomy] == nil {
+				counts[taxonomy] = make(map[string]int)
+			}
+			for _, term := range terms {
+				counts[taxonomy][term]++
+			}
+		}
+	}
+
+	vocabulary := make(map[string][]string, len(counts))
+	for taxonomy, termCounts := range counts {
+		terms := make([]string, 0, len(termCounts))
+		for term := range termCounts {
+			terms = append(terms, term)
+		}
+		sort.Slice(terms, func(i, j int) bool {
+			if termCounts[terms[i]] != termCounts[terms[j]] {
+				return termCounts[terms[i]] > termCounts[terms[j]]
+			}
+			return terms[i] < terms[j]
+		})
+		vocabulary[taxonomy] = terms
+	}
+	return vocabulary
+}
+
+// collectMarkdownFiles returns the Markdown files at or under target, sorted.
+func collectMarkdownFiles(target string) ([]string, error) {
+	info, err := os.Stat(target)
+	if err != nil {
+		return nil, fmt.Errorf("content path not found: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{target}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(target, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".md") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", target, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func init() {
+	contentTaxonomyCmd.Flags().Bool("dry-run", false, "Show suggestions without modifying files")
+	contentTaxonomyCmd.Flags().Int("max-terms", ai.DefaultMaxTaxonomyTerms, "Maximum terms to suggest per taxonomy")
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+func TestRunContentTaxonomy(t *testing.T) {
+	dir := t.TempDir()
+	post := `---
+title: "Golang on Kubernetes"
+---
+
+Running golang services on kubernetes is straightforward. Build the golang
+binary, ship it in a container, and let kubernetes schedule it.
+`
+	tagged := `---
+title: "Tagged"
+tags: [devops]
+---
+
+Golang and kubernetes again: golang builds, kubernetes runs.
+`
+	postPath := filepath.Join(dir, "post.md")
+	taggedPath := filepath.Join(dir, "tagged.md")
+	if err := os.WriteFile(postPath, []byte(post), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(taggedPath, []byte(tagged), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	taxonomies := []string{"categories", "tags"}
+
+	t.Run("dry run leaves files untouched", func(t *testing.T) {
+		var out bytes.Buffer
+		suggestions, err := runContentTaxonomy(dir, taxonomies, 5, true, &out)
+		if err != nil {
+			t.Fatalf("runContentTaxonomy() error = %v", err)
+		}
+		if len(suggestions) != 2 {
+			t.Fatalf("expected 2 suggestions, got %d", len(suggestions))
+		}
+		data, _ := os.ReadFile(postPath)
+		if string(data) != post {
+			t.Error("dry run modified the file")
+		}
+		if !strings.Contains(out.String(), "confidence") {
+			t.Errorf("expected confidence in output, got: %s", out.String())
+		}
+	})
+
+	t.Run("writes missing taxonomies", func(t *testing.T) {
+		if _, err := runContentTaxonomy(dir, taxonomies, 5, false, &bytes.Buffer{}); err != nil {
+			t.Fatalf("runContentTaxonomy() error = %v", err)
+		}
+
+		data, _ := os.ReadFile(postPath)
+		terms := ai.ReadTaxonomyTerms(string(data), taxonomies)
+		for _, want := range []string{"golang", "kubernetes"} {
+			if !containsTerm(terms["tags"], want) {
+				t.Errorf("expected %q in tags, got %v", want, terms["tags"])
+			}
+		}
+
+		data, _ = os.ReadFile(taggedPath)
+		terms = ai.ReadTaxonomyTerms(string(data), taxonomies)
+		if len(terms["tags"]) != 1 || terms["tags"][0] != "devops" {
+			t.Errorf("existing tags not preserved: %v", terms["tags"])
+		}
+	})
+}
+
+func TestContentTaxonomyFlags(t *testing.T) {
+	for _, name := range []string{"dry-run", "max-terms"} {
+		if contentTaxonomyCmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+}
+
+func containsTerm(terms []string, want string) bool {
+	for _, term := range terms {
+		if term == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// contentThumbnailsCmd generates responsive variants of content images.
+var contentThumbnailsCmd = &cobra.Command{
+	Use:   "thumbnails",
+	Short: "Generate responsive image variants for content images",
+	Long: `Generate smaller variants of the images referenced in content/ so
+browsers can download the size they need.
+
+Images referenced from markdown, <img> tags and the image, cover,
+featured_image and thumbnail frontmatter fields are resized to each width
+(400, 800 and 1200 pixels by default). Variants are written next to the
+image, in the page bundle or static/, as <name>-<width>w.<ext>. Images are
+never upscaled, and images narrower than the smallest width are skipped.
+
+<img> tags get a srcset attribute listing the variants, and frontmatter
+image fields get a <field>_srcset field (for example cover_srcset) that
+templates can use. Markdown images are left as they are; a render-image
+hook can find their variants by name.
+
+Variants are webp by default, encoded with cwebp from libwebp. Use
+--format original to keep the source format without cwebp. Variants
+newer than their source image are not regenerated.
+
+Examples:
+  walgo content thumbnails
+  walgo content thumbnails --widths 480,960,1440
+  walgo content thumbnails --format original --quality 85
+  walgo content thumbnails --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		widths, _ := cmd.Flags().GetIntSlice("widths")
+		format, _ := cmd.Flags().GetString("format")
+		quality, _ := cmd.Flags().GetInt("quality")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		report, err := hugo.GenerateThumbnails(sitePath, hugo.ThumbnailOptions{
+			Widths:  widths,
+			Format:  format,
+			Quality: quality,
+			DryRun:  dryRun,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		printThumbnailReport(os.Stdout, report, dryRun)
+		return nil
+	},
+}
+
+// printThumbnailReport summarizes a GenerateThumbnails run.
+func printThumbnailReport(out io.Writer, report *hugo.ThumbnailReport, dryRun bool) {
+	icons := ui.GetIcons()
+	created, updated := "Created", "Updated"
+	if dryRun {
+		created, updated = "Would create", "Would update"
+	}
+
+	fmt.Fprintf(out, "%s Found %d image(s) in content\n", icons.Info, report.Images)
+	for _, p := range report.Skipped {
+		fmt.Fprintf(out, "  %s Skipped %s (smaller than the smallest width)\n", icons.Info, p)
+	}
+	for _, m := range report.Missing {
+		fmt.Fprintf(out, "  %s Missing %s\n", icons.Warning, m)
+	}
+	for _, p := range report.Created {
+		fmt.Fprintf(out, "  %s %s %s\n", icons.File, created, p)
+	}
+	for _, p := range report.Updated {
+		fmt.Fprintf(out, "  %s %s %s\n", icons.Check, updated, p)
+	}
+	if len(report.Created) == 0 && len(report.Updated) == 0 {
+		fmt.Fprintf(out, "%s Thumbnails are up to date\n", icons.Success)
+		return
+	}
+	fmt.Fprintf(out, "%s %s %d variant(s) and %s %d page(s)\n", icons.Success, created, len(report.Created), strings.ToLower(updated), len(report.Updated))
+}
+
+func init() {
+	contentThumbnailsCmd.Flags().IntSlice("widths", hugo.DefaultThumbnailWidths, "Variant widths in pixels")
+	contentThumbnailsCmd.Flags().String("format", hugo.ThumbnailFormatWebP, "Variant format: webp or original")
+	contentThumbnailsCmd.Flags().Int("quality", hugo.DefaultThumbnailQuality, "Encoder quality for webp and JPEG variants (1-100)")
+	contentThumbnailsCmd.Flags().Bool("dry-run", false, "Show what would be generated without writing files")
+}
@@ -1,12 +1,14 @@
 package cmd
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/selimozten/walgo/internal/compress"
 	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/deployment"
 	"github.com/selimozten/walgo/internal/hugo"
@@ -26,10 +28,97 @@ var deployCmd = &cobra.Command{
 This command builds your site and uploads it to the Walrus network.
 
 The site will be stored for the specified number of epochs (default: 1).
+--duration (or walrus.duration) takes a
+length of time such as 6mo instead and converts it to epochs on the active
+network, rounding up and capping at the network maximum of 53 epochs.
 After deployment, you'll receive an object ID that you can use to access
 your site and configure domain names.
 
-Example: walgo deploy --epochs 5`,
+To cap spend instead of choosing epochs, pass a WAL budget with
+--max-epochs-cost. Walgo picks the most epochs the budget covers for the
+built site and aborts if even one epoch costs more.
+
+For a site you update regularly, --epochs-auto looks at the project's past
+deployments and picks enough epochs to cover twice the usual gap between
+updates. New projects without that history use --epochs.
+
+Unknown paths are answered with the site's 404.html when the build has
+one. Use --404 to point the fallback at a different page.
+
+Site metadata (site_name, description, image, category) is written to
+ws-resources.json only when it changes. Use --skip-metadata to leave the
+file's metadata exactly as it is.
+
+With --verify-build-manifest, Hugo's output is checked against a manifest
+of file hashes (hugo.buildManifest in walgo.yaml, default
+build-manifest.json in the site root) before the optimizer runs and before
+anything is uploaded, and the deploy aborts listing any missing or modified
+files. The manifest must live outside the publish directory, so it is not
+uploaded as part of the site.
+
+--target-dir deploys an already built subdirectory, such as one site of a
+monorepo built into dist/siteA, as the root of its own Walrus Site. It must
+contain the walrus.entrypoint page and its own ws-resources.json identifies
+the site, so each target updates its own site object. Walgo does not run Hugo
+for a target directory, and walgo.yaml's projectID is neither used nor
+updated.
+
+Before uploading, resource paths are checked against portal and blob-key
+limits: paths longer than --max-path-length (compress.maxPathLength in
+walgo.yaml, default 200) or containing control characters, ?, # or \ abort
+the deploy with a list of offenders; files ignored by ws-resources.json are
+not checked. --sanitize moves those files to safe paths instead and adds
+routes so the original URLs still resolve. It only touches Hugo's publish
+directory and cannot be used with --target-dir.
+
+A deploy that failed part way can leave ws-resources.json with an object_id
+that walgo.yaml or the project's deploy history do not back up, or with
+routes to files that no longer exist. Deploy checks for this first and asks
+whether to repair the file, deploy as a new site or abort; --repair repairs
+it without asking.
+
+--report-prometheus writes walgo_site_size_bytes, walgo_deploy_cost_wal and
+walgo_site_expiry_timestamp, labeled with the project and network, to a .prom
+file that node_exporter's textfile collector can pick up.
+
+robots.txt and sitemap.xml get the right Content-Type and a cache policy of
+at most an hour, so crawlers see updates soon after a deploy. --seo-strict
+warns when either is missing from the publish directory.
+
+--timing prints how long each deploy phase took (size calculation, hashing,
+metadata, upload and object ID write) to help find where a deploy spends
+its time.
+
+--open opens the site in the browser after a successful deploy: the
+wal.app URL of the project's SuiNS domain when it has one, otherwise the
+URL site-builder reports. It does nothing in CI or when stdout is not a
+terminal; --force-open opens the browser regardless.
+
+--canary deploys the build to a new throwaway site object instead of the
+production one, verifies it (resource count and portal reachability) and
+prints its URL for inspection. walgo.yaml, ws-resources.json and the
+project are left alone. --promote then updates production with that same
+build, without rebuilding, once the canary has passed verification and the
+publish directory is unchanged. --auto-promote does both in one run.
+
+Examples:
+  walgo deploy --epochs 5
+  walgo deploy --duration 6mo
+  walgo deploy --max-epochs-cost 0.5
+  walgo deploy --epochs-auto
+  walgo deploy --404 errors/not-found.html
+  walgo deploy --skip-metadata
+  walgo deploy --verify-build-manifest
+  walgo deploy --target-dir dist/siteA
+  walgo deploy --sanitize
+  walgo deploy --report-prometheus /var/lib/node_exporter/textfile/walgo.prom
+  walgo deploy --seo-strict
+  walgo deploy --timing
+  walgo deploy --open
+  walgo deploy --canary
+  walgo deploy --promote
+  walgo deploy --canary --auto-promote
+  walgo deploy --env testnet-preview`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		quiet, _ := cmd.Flags().GetBool("quiet")
 
@@ -63,7 +152,26 @@ Example: walgo deploy --epochs 5`,
 			return fmt.Errorf("error loading config: %w", err)
 		}
 
+		envName, _ := cmd.Flags().GetString("env")
+		var deployEnv config.DeployEnv
+		if envName != "" {
+			deployEnv, err = walgoCfg.DeployEnv(envName)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return err
+			}
+			applied, err := applyDeployEnv(cmd.Flags(), deployEnv)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return err
+			}
+			if !quiet && len(applied) > 0 {
+				fmt.Printf("%s Env %s: %s\n", icons.Info, envName, strings.Join(applied, " "))
+			}
+		}
+
 		epochs, _ := cmd.Flags().GetInt("epochs")
+		duration, _ := cmd.Flags().GetString("duration")
 		force, _ := cmd.Flags().GetBool("force")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
@@ -74,6 +182,82 @@ Example: walgo deploy --epochs 5`,
 		category, _ := cmd.Flags().GetString("category")
 		description, _ := cmd.Flags().GetString("description")
 		imageURL, _ := cmd.Flags().GetString("image-url")
+		summaryPath, _ := cmd.Flags().GetString("summary")
+		prometheusPath, _ := cmd.Flags().GetString("report-prometheus")
+		timing, _ := cmd.Flags().GetBool("timing")
+		seoStrict, _ := cmd.Flags().GetBool("seo-strict")
+		canary, _ := cmd.Flags().GetBool("canary")
+		promote, _ := cmd.Flags().GetBool("promote")
+		autoPromote, _ := cmd.Flags().GetBool("auto-promote")
+		maxEpochsCost, _ := cmd.Flags().GetFloat64("max-epochs-cost")
+		epochsAuto, _ := cmd.Flags().GetBool("epochs-auto")
+		verify, _ := cmd.Flags().GetBool("verify")
+		verifyURL, _ := cmd.Flags().GetString("verify-url")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+		allowCustomCategory, _ := cmd.Flags().GetBool("allow-custom-category")
+		notFoundFlag, _ := cmd.Flags().GetString("404")
+		skipMetadata, _ := cmd.Flags().GetBool("skip-metadata")
+		verifyManifest, _ := cmd.Flags().GetBool("verify-build-manifest")
+		targetDir, _ := cmd.Flags().GetString("target-dir")
+		useTargetDir := cmd.Flags().Changed("target-dir")
+		sanitize, _ := cmd.Flags().GetBool("sanitize")
+		repairState, _ := cmd.Flags().GetBool("repair")
+		openSite, _ := cmd.Flags().GetBool("open")
+		forceOpen, _ := cmd.Flags().GetBool("force-open")
+		syncConfig, _ := cmd.Flags().GetBool("sync-config")
+		deletable, _ := cmd.Flags().GetBool("deletable")
+		maxPathFlag, _ := cmd.Flags().GetInt("max-path-length")
+		maxPathLength, err := maxPathLengthFor(maxPathFlag, cmd.Flags().Changed("max-path-length"), walgoCfg.CompressConfig)
+		if err != nil {
+			return err
+		}
+
+		// walgo.yaml can set a default for --duration
+		if cmd.Flags().Changed("duration") {
+			if cmd.Flags().Changed("epochs") {
+				return fmt.Errorf("--epochs and --duration cannot be used together")
+			}
+		} else if !cmd.Flags().Changed("epochs") {
+			duration = walgoCfg.WalrusConfig.Duration
+		}
+
+		if err := validateMetadataFlags(imageURL, category, allowCustomCategory); err != nil {
+			return err
+		}
+
+		if cmd.Flags().Changed("max-epochs-cost") {
+			if cmd.Flags().Changed("epochs") {
+				return fmt.Errorf("--epochs and --max-epochs-cost cannot be used together")
+			}
+			if maxEpochsCost <= 0 {
+				return fmt.Errorf("--max-epochs-cost must be greater than 0")
+			}
+		}
+		if epochsAuto && cmd.Flags().Changed("max-epochs-cost") {
+			return fmt.Errorf("--epochs-auto and --max-epochs-cost cannot be used together")
+		}
+		if cmd.Flags().Changed("duration") && (epochsAuto || cmd.Flags().Changed("max-epochs-cost")) {
+			return fmt.Errorf("--duration cannot be used with --epochs-auto or --max-epochs-cost")
+		}
+		// A target directory is not the walgo.yaml project, so neither its
+		// history nor its project entry apply
+		if useTargetDir {
+			if saveProject || cmd.Flags().Changed("project-name") {
+				return fmt.Errorf("--target-dir cannot be used with --save-project or --project-name")
+			}
+			if epochsAuto {
+				return fmt.Errorf("--epochs-auto and --target-dir cannot be used together")
+			}
+			// --sanitize moves files, which is only safe in the publish
+			// directory Hugo regenerates
+			if sanitize {
+				return fmt.Errorf("--sanitize and --target-dir cannot be used together; rename the files in the target directory instead")
+			}
+		}
+
+		if err := validateCanaryFlags(canary, promote, autoPromote, dryRun, forceNew, useTargetDir); err != nil {
+			return err
+		}
 
 		if saveProject || projectName != "" {
 			if projectName == "" {
@@ -94,7 +278,13 @@ Example: walgo deploy --epochs 5`,
 		}
 
 		publishDir := filepath.Join(sitePath, walgoCfg.HugoConfig.PublishDir)
-		if _, err := os.Stat(publishDir); os.IsNotExist(err) {
+		if useTargetDir {
+			publishDir, err = resolveTargetDir(sitePath, targetDir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return err
+			}
+		} else if _, err := os.Stat(publishDir); os.IsNotExist(err) {
 			fmt.Fprintf(os.Stderr, "%s Error: Build directory '%s' not found\n\n", icons.Error, publishDir)
 			fmt.Fprintf(os.Stderr, "%s Run this first:\n", icons.Lightbulb)
 			fmt.Fprintf(os.Stderr, "   walgo build\n")
@@ -103,6 +293,10 @@ Example: walgo deploy --epochs 5`,
 			}
 		}
 
+		if err := ensureOnline(cmd.Context()); err != nil {
+			return err
+		}
+
 		if !quiet {
 			fmt.Printf("%s Deploying to Walrus Sites...\n", icons.Rocket)
 			fmt.Println("  [1/5] Verifying site...")
@@ -118,9 +312,92 @@ Example: walgo deploy --epochs 5`,
 			}
 		}
 
-		err = hugo.BuildSite(sitePath)
+		network := walgoCfg.WalrusConfig.Network
+		if info, err := activeAddressDetails(cmd.Context()); err != nil {
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "%s Warning: Could not read active address: %v\n", icons.Warning, err)
+			}
+		} else {
+			network = info.Network
+			if !quiet {
+				printWalletPreflight(os.Stdout, info)
+			}
+		}
+		if err := checkEnvNetwork(envName, deployEnv, network); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		if !dryRun {
+			if err := confirmMainnetSpend(network, assumeYes, os.Stdin, os.Stdout); err != nil {
+				return err
+			}
+		}
+
+		// A promote uploads the build the canary was checked against
+		if !useTargetDir && !promote {
+			buildOpts := scheduleBuildOptions(cmd)
+			buildOpts.Reproducible = buildOpts.Reproducible || walgoCfg.HugoConfig.Reproducible
+			warnDraftsDeploy(buildOpts, os.Stderr)
+			// The manifest describes Hugo's output, so check it before the
+			// optimizer rewrites the publish directory
+			var manifestErr error
+			if verifyManifest {
+				buildOpts.VerifyOutput = func(string) error {
+					manifestErr = checkBuildManifest(sitePath, publishDir, walgoCfg.HugoConfig, quiet, os.Stdout)
+					return manifestErr
+				}
+			}
+			err = hugo.BuildSiteWithOptions(sitePath, buildOpts)
+			if manifestErr != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, manifestErr)
+				return fmt.Errorf("deployment aborted: %w", manifestErr)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to build site: %w", err)
+			}
+		} else if verifyManifest {
+			if err := checkBuildManifest(sitePath, publishDir, walgoCfg.HugoConfig, quiet, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return fmt.Errorf("deployment aborted: %w", err)
+			}
+		}
+
+		if err := checkResourcePaths(publishDir, maxPathLength, sanitize, quiet, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("deployment aborted: %w", err)
+		}
+
+		siteState := deployment.SiteState{}
+		if !useTargetDir {
+			siteState = loadSiteState(sitePath, walgoCfg)
+		}
+		forceNew, err = checkWSResourcesState(publishDir, siteState, forceNew, repairState, dryRun, os.Stdin, os.Stdout)
 		if err != nil {
-			return fmt.Errorf("failed to build site: %w", err)
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("deployment aborted: %w", err)
+		}
+		if !useTargetDir && !forceNew {
+			syncConfigObjectID(sitePath, publishDir, walgoCfg, syncConfig, dryRun, quiet, os.Stdin, os.Stdout)
+		}
+
+		if duration != "" {
+			epochs, err = durationEpochs(duration, network, quiet, os.Stdout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return err
+			}
+		}
+
+		if epochsAuto {
+			epochs = epochsFromHistory(sitePath, walgoCfg.WalrusConfig.ProjectID, network, epochs, quiet, os.Stdout)
+		}
+
+		if maxEpochsCost > 0 {
+			epochs, err = epochsFromBudget(publishDir, maxEpochsCost, walgoCfg.WalrusConfig.Network, quiet)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return fmt.Errorf("deployment aborted: %w", err)
+			}
 		}
 
 		opts := deployment.DeploymentOptions{
@@ -137,18 +414,94 @@ Example: walgo deploy --epochs 5`,
 			Category:    category,
 			Description: description,
 			ImageURL:    imageURL,
+			TargetDir:   useTargetDir,
+			Deletable:   deletable,
+
+			AllowCustomCategory: allowCustomCategory,
+			SkipMetadata:        skipMetadata,
+			NotFoundPage:        notFoundPageForDeploy(notFoundFlag, cmd.Flags().Changed("404"), publishDir),
+			SEOStrict:           seoStrict,
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		ctx, cancel := newDeployContext(30 * time.Minute)
 		defer cancel()
 
-		result, err := deployment.PerformDeployment(ctx, opts)
+		var result *deployment.DeploymentResult
+		if canary || promote || autoPromote {
+			result, err = runCanary(ctx, opts, promote, autoPromote, os.Stdout)
+			if err == nil && result == nil {
+				// Canary deployed; production waits for --promote
+				success = true
+				return nil
+			}
+		} else {
+			result, err = deployment.PerformDeployment(ctx, opts)
+		}
+		if isInterrupted(ctx, err) {
+			if errors.Is(err, deployment.ErrRestoreFailed) {
+				fmt.Fprintf(os.Stderr, "\n%s Deployment interrupted - %v\n", icons.Warning, err)
+			} else {
+				fmt.Fprintf(os.Stderr, "\n%s Deployment interrupted - local files were left unchanged\n", icons.Warning)
+			}
+			return fmt.Errorf("deployment interrupted: %w", err)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\nDeployment failed: %v\n", err)
+			printEntrypointTip(err, os.Stderr)
 			return fmt.Errorf("deployment failed: %w", err)
 		}
 
 		success = result.Success
+
+		if timing {
+			fmt.Println()
+			deployment.PrintTimings(os.Stdout, result.Timings)
+		}
+		if summaryPath != "" && !dryRun {
+			if err := deployment.WriteSummary(summaryPath, result); err != nil {
+				fmt.Fprintf(os.Stderr, "%s Warning: Failed to write deploy summary: %v\n", icons.Warning, err)
+			} else if !quiet {
+				fmt.Printf("%s Deploy summary written to %s\n", icons.Check, summaryPath)
+			}
+		}
+		if prometheusPath != "" && !dryRun {
+			labels := deployment.PrometheusLabels{
+				Project: prometheusProjectLabel(sitePath, publishDir, projectName, useTargetDir),
+				Network: result.Network,
+			}
+			if labels.Network == "" {
+				labels.Network = network
+			}
+			if err := deployment.WritePrometheusReport(prometheusPath, result, labels); err != nil {
+				fmt.Fprintf(os.Stderr, "%s Warning: Failed to write Prometheus report: %v\n", icons.Warning, err)
+			} else if !quiet {
+				fmt.Printf("%s Prometheus metrics written to %s\n", icons.Check, prometheusPath)
+			}
+		}
+		if verify && !dryRun {
+			if verifyURL == "" {
+				verifyURL = result.PortalURL
+			}
+			if !quiet {
+				fmt.Printf("%s Verifying deployment...\n", icons.Info)
+			}
+			verifyCtx, verifyCancel := newDeployContext(5 * time.Minute)
+			report, err := deployment.VerifyDeployment(verifyCtx, deployment.VerifyOptions{
+				ObjectID:   result.ObjectID,
+				PublishDir: publishDir,
+				PortalURL:  verifyURL,
+			})
+			verifyCancel()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Verification failed: %v\n", icons.Error, err)
+				return fmt.Errorf("deployment verification failed: %w", err)
+			}
+			if !quiet {
+				fmt.Printf("  %s %d/%d resources on-chain\n", icons.Check, report.ActualResources, report.ExpectedResources)
+				fmt.Printf("  %s %s returned HTTP %d\n", icons.Check, report.EntrypointURL, report.EntrypointStatus)
+			}
+		}
+
 		if telemetry {
 			deployMetrics.TotalFiles = 0
 			deployMetrics.ChangedFiles = 0
@@ -203,24 +556,59 @@ Example: walgo deploy --epochs 5`,
 			fmt.Printf("Site Object ID: %s\n", result.ObjectID)
 		}
 
+		if (openSite || forceOpen) && !dryRun {
+			openNetwork := result.Network
+			if openNetwork == "" {
+				openNetwork = network
+			}
+			openDeployedSite(os.Stdout, sitePath, openNetwork, result, forceOpen)
+		}
+
 		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(deployCmd)
+	addScheduleFlags(deployCmd)
 
 	deployCmd.Flags().IntP("epochs", "e", 1, "Number of epochs to store the site")
+	deployCmd.Flags().String("duration", "", "Store the site for this long instead of --epochs, e.g. 30d, 6mo, 1y")
 	deployCmd.Flags().BoolP("force", "f", false, "Deploy even if public directory doesn't exist")
 	deployCmd.Flags().BoolP("verbose", "v", false, "Show detailed output for debugging")
 	deployCmd.Flags().BoolP("quiet", "q", false, "Suppress output (used internally by quickstart)")
-	deployCmd.Flags().Bool("dry-run", false, "Preview deployment plan without actually deploying")
+	deployCmd.Flags().Bool("dry-run", false, "Preview deployment plan and update cost without actually deploying")
 	deployCmd.Flags().Bool("telemetry", false, "Record deployment metrics to local JSON file (~/.walgo/metrics.json)")
 	deployCmd.Flags().Bool("skip-version-check", false, "Skip version checking and updating (not recommended for mainnet)")
 	deployCmd.Flags().Bool("save-project", false, "Save deployment as a project with default name (directory name)")
 	deployCmd.Flags().String("project-name", "", "Custom project name (default: directory name)")
 	deployCmd.Flags().String("category", "", "Project category (default: website)")
+	deployCmd.Flags().Bool("allow-custom-category", false, "Accept a --category outside the known set")
 	deployCmd.Flags().String("description", "", "Site description for metadata")
 	deployCmd.Flags().String("image-url", "", "Site image URL for metadata")
+	deployCmd.Flags().Bool("skip-metadata", false, "Leave site_name and metadata in ws-resources.json untouched")
 	deployCmd.Flags().Bool("force-new", false, "Force deployment as new site (ignore existing objectID)")
+	deployCmd.Flags().Float64("max-epochs-cost", 0, "Maximum total WAL to spend; deploys with the most epochs this budget covers")
+	deployCmd.Flags().Bool("epochs-auto", false, "Pick epochs from how often this project is usually redeployed (falls back to --epochs)")
+	deployCmd.Flags().String("target-dir", "", "Deploy this built subdirectory (e.g. dist/siteA) as the site root instead of the Hugo publish directory")
+	deployCmd.Flags().Bool("verify-build-manifest", false, "Before uploading, check the publish directory against the build manifest (hugo.buildManifest) and abort on mismatch")
+	deployCmd.Flags().Int("max-path-length", compress.DefaultMaxPathLength, "Longest resource path to accept before aborting (overrides compress.maxPathLength)")
+	deployCmd.Flags().Bool("sanitize", false, "Move files with overlong or unsafe paths to safe paths and route the old URLs to them")
+	deployCmd.Flags().Bool("repair", false, "Fix a ws-resources.json left inconsistent by a failed deploy without asking")
+	deployCmd.Flags().Bool("verify", false, "After deploying, check the on-chain resource count and that the portal serves the site")
+	deployCmd.Flags().String("verify-url", "", "URL to check with --verify (default: portal URL reported by site-builder)")
+	deployCmd.Flags().BoolP("yes", "y", false, "Skip the mainnet spend confirmation prompt")
+	deployCmd.Flags().String("404", "", "Page to serve for unknown paths, relative to the publish directory (default: 404.html if present)")
+	deployCmd.Flags().String("summary", "", "Write a post-deploy report to this path (.json for JSON, otherwise Markdown)")
+	deployCmd.Flags().String("report-prometheus", "", "Write site size, cost and expiry metrics to this .prom file for the node_exporter textfile collector")
+	deployCmd.Flags().Bool("seo-strict", false, "Warn when robots.txt or sitemap.xml is missing from the publish directory")
+	deployCmd.Flags().Bool("timing", false, "Print how long each deploy phase took")
+	deployCmd.Flags().Bool("open", false, "Open the site in the browser after a successful deploy (skipped in CI or without a terminal)")
+	deployCmd.Flags().Bool("force-open", false, "Open the site in the browser even in CI or without a terminal")
+	deployCmd.Flags().Bool("sync-config", false, "Write ws-resources.json's object_id into walgo.yaml when walgo.yaml has no projectID")
+	deployCmd.Flags().Bool("deletable", false, "Store the site's blobs as deletable so their storage can be reclaimed before the epochs end")
+	deployCmd.Flags().String("env", "", "Apply a named preset from walgo.yaml's envs section; explicit flags still win")
+	deployCmd.Flags().Bool("canary", false, "Deploy to a new throwaway site object and verify it, leaving production untouched")
+	deployCmd.Flags().Bool("promote", false, "Update production with the build of the last verified canary")
+	deployCmd.Flags().Bool("auto-promote", false, "With --canary, promote as soon as the canary passes verification")
 }
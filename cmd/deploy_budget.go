@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// publishDirSize returns the total size in bytes of all files under dir.
+func publishDirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// epochsFromBudget picks the largest number of epochs whose estimated WAL cost
+// for the built site stays within budgetWAL, and reports the choice.
+func epochsFromBudget(publishDir string, budgetWAL float64, network string, quiet bool) (int, error) {
+	icons := ui.GetIcons()
+
+	siteSize, err := publishDirSize(publishDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure site size: %w", err)
+	}
+	if siteSize == 0 {
+		return 0, fmt.Errorf("publish directory is empty: %s", publishDir)
+	}
+
+	budget, err := walrus.EstimateEpochsForBudget(siteSize, budgetWAL, network, "")
+	if err != nil {
+		return 0, err
+	}
+
+	if !quiet {
+		fmt.Printf("%s Budget: %.4f WAL for %.2f MB\n", icons.Money, budgetWAL, float64(siteSize)/(1024*1024))
+		fmt.Printf("  %s Epochs: %d (~%.4f WAL, +%.4f WAL per extra epoch)\n", icons.Check, budget.Epochs, budget.TotalWAL, budget.PerEpochWAL)
+		if budget.Capped {
+			fmt.Printf("  %s Capped at the network maximum of %d epochs\n", icons.Info, budget.Epochs)
+		}
+		if budget.EpochDuration > 0 {
+			fmt.Printf("  %s Expires: ~%s\n", icons.Hourglass, budget.Expiry(time.Now()).Format("2006-01-02"))
+		}
+	}
+
+	return budget.Epochs, nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+func TestPublishDirSize(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "css"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "css", "app.css"), make([]byte, 50), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := publishDirSize(dir)
+	if err != nil {
+		t.Fatalf("publishDirSize failed: %v", err)
+	}
+	if size != 150 {
+		t.Errorf("size = %d, want 150", size)
+	}
+
+	if _, err := publishDirSize(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+func TestEpochsFromBudget(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), make([]byte, 1024*1024), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("affordable budget", func(t *testing.T) {
+		epochs, err := epochsFromBudget(dir, 100, "testnet", true)
+		if err != nil {
+			t.Fatalf("epochsFromBudget failed: %v", err)
+		}
+		if epochs < 1 {
+			t.Errorf("epochs = %d, want at least 1", epochs)
+		}
+	})
+
+	t.Run("budget below one epoch", func(t *testing.T) {
+		_, err := epochsFromBudget(dir, 1e-9, "testnet", true)
+		var shortErr *walrus.BudgetShortfallError
+		if !errors.As(err, &shortErr) {
+			t.Fatalf("expected BudgetShortfallError, got %v", err)
+		}
+	})
+
+	t.Run("empty publish directory", func(t *testing.T) {
+		if _, err := epochsFromBudget(t.TempDir(), 1, "testnet", true); err == nil {
+			t.Error("expected error for empty publish directory")
+		}
+	})
+}
+
+func TestDeployBudgetFlagValidation(t *testing.T) {
+	tempDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(originalWd) }()
+
+	if err := os.WriteFile("walgo.yaml", []byte("hugo:\n  publishDir: public\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := executeCommand(rootCmd, "deploy", "--epochs", "2", "--max-epochs-cost", "1")
+	if err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+		t.Errorf("expected mutual exclusion error, got %v", err)
+	}
+
+	_, err = executeCommand(rootCmd, "deploy", "--max-epochs-cost", "-1")
+	if err == nil || !strings.Contains(err.Error(), "greater than 0") {
+		t.Errorf("expected positive budget error, got %v", err)
+	}
+}
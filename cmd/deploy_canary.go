@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/selimozten/walgo/internal/deployment"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// canaryVerify is a test hook for checking a canary after it is deployed.
+var canaryVerify deployment.CanaryVerifier = deployment.VerifyCanary
+
+// validateCanaryFlags rejects --canary, --promote and --auto-promote
+// combinations that have no meaning.
+func validateCanaryFlags(canary, promote, autoPromote, dryRun, forceNew, targetDir bool) error {
+	if !canary && !promote && !autoPromote {
+		return nil
+	}
+	if promote && (canary || autoPromote) {
+		return fmt.Errorf("--promote cannot be used with --canary or --auto-promote; use --canary --auto-promote to deploy and promote in one run")
+	}
+	switch {
+	case dryRun:
+		return fmt.Errorf("--canary and --promote cannot be used with --dry-run")
+	case forceNew:
+		return fmt.Errorf("--canary and --promote cannot be used with --force-new")
+	case targetDir:
+		return fmt.Errorf("--canary and --promote cannot be used with --target-dir")
+	}
+	return nil
+}
+
+// runCanary deploys a canary of opts.PublishDir and, with autoPromote,
+// promotes it, or with promote only promotes the canary deployed earlier.
+// It returns the production deploy result, or nil when the canary is left
+// waiting for walgo deploy --promote.
+func runCanary(ctx context.Context, opts deployment.DeploymentOptions, promote, autoPromote bool, out io.Writer) (*deployment.DeploymentResult, error) {
+	icons := ui.GetIcons()
+
+	if !promote {
+		if !opts.Quiet {
+			fmt.Fprintf(out, "%s Deploying canary to a new site object...\n", icons.Rocket)
+		}
+		state, _, err := deployment.DeployCanary(ctx, opts, canaryVerify)
+		if state != nil {
+			printCanaryState(out, state)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !autoPromote {
+			fmt.Fprintf(out, "\n%s Check the canary, then run 'walgo deploy --promote' to update production\n", icons.Lightbulb)
+			return nil, nil
+		}
+	}
+
+	if !opts.Quiet {
+		fmt.Fprintf(out, "\n%s Promoting canary to production...\n", icons.Rocket)
+	}
+	_, result, err := deployment.PromoteCanary(ctx, opts)
+	return result, err
+}
+
+// printCanaryState shows where a canary can be inspected and whether it
+// passed verification.
+func printCanaryState(out io.Writer, state *deployment.CanaryState) {
+	icons := ui.GetIcons()
+	fmt.Fprintf(out, "\n%s Canary Object ID: %s\n", icons.File, state.ObjectID)
+	if state.URL != "" {
+		fmt.Fprintf(out, "%s Canary URL: %s\n", icons.Globe, state.URL)
+	}
+	switch state.Stage {
+	case deployment.CanaryVerified:
+		fmt.Fprintf(out, "%s Canary verified\n", icons.Check)
+	case deployment.CanaryFailed:
+		fmt.Fprintf(out, "%s Canary verification failed: %s\n", icons.Cross, state.Error)
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployer"
+	"github.com/selimozten/walgo/internal/deployment"
+)
+
+// canaryDeployer publishes new sites as 0xcanary and records updates.
+type canaryDeployer struct {
+	updated []string
+}
+
+func (d *canaryDeployer) Deploy(context.Context, string, deployer.DeployOptions) (*deployer.Result, error) {
+	return &deployer.Result{Success: true, ObjectID: "0xcanary", BrowseURLs: []string{"https://canary.example"}}, nil
+}
+
+func (d *canaryDeployer) Update(_ context.Context, _ string, objectID string, _ deployer.DeployOptions) (*deployer.Result, error) {
+	d.updated = append(d.updated, objectID)
+	return &deployer.Result{Success: true, ObjectID: objectID}, nil
+}
+
+func (d *canaryDeployer) Status(context.Context, string, deployer.DeployOptions) (*deployer.Result, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (d *canaryDeployer) Destroy(context.Context, string, deployer.DestroyOptions) error {
+	return errors.New("not implemented")
+}
+
+func canaryTestOptions(t *testing.T, d deployer.WalrusDeployer) deployment.DeploymentOptions {
+	t.Helper()
+	sitePath := t.TempDir()
+	t.Setenv("HOME", sitePath)
+	publishDir := filepath.Join(sitePath, "public")
+	if err := os.MkdirAll(publishDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(publishDir, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(publishDir, "ws-resources.json"), []byte(`{"object_id": "0xprod"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sitePath, "walgo.yaml"), []byte("walrus:\n  projectID: 0xprod\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.NewDefaultWalgoConfig()
+	cfg.WalrusConfig.ProjectID = "0xprod"
+	return deployment.DeploymentOptions{
+		SitePath:   sitePath,
+		PublishDir: publishDir,
+		Epochs:     1,
+		WalgoCfg:   &cfg,
+		Quiet:      true,
+		Deployer:   d,
+	}
+}
+
+func stubCanaryVerify(t *testing.T, err error) {
+	t.Helper()
+	orig := canaryVerify
+	t.Cleanup(func() { canaryVerify = orig })
+	canaryVerify = func(context.Context, deployment.VerifyOptions) error { return err }
+}
+
+func TestRunCanaryWaitsForPromote(t *testing.T) {
+	stubCanaryVerify(t, nil)
+	d := &canaryDeployer{}
+	opts := canaryTestOptions(t, d)
+
+	var out bytes.Buffer
+	result, err := runCanary(context.Background(), opts, false, false, &out)
+	if err != nil {
+		t.Fatalf("runCanary() error = %v", err)
+	}
+	if result != nil || len(d.updated) != 0 {
+		t.Fatalf("production should not be touched without --promote (updated %v)", d.updated)
+	}
+	for _, want := range []string{"0xcanary", "https://canary.example", "walgo deploy --promote"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	result, err = runCanary(context.Background(), opts, true, false, &out)
+	if err != nil {
+		t.Fatalf("runCanary(promote) error = %v", err)
+	}
+	if result == nil || result.ObjectID != "0xprod" || len(d.updated) != 1 {
+		t.Errorf("promote should update 0xprod, updated %v", d.updated)
+	}
+}
+
+func TestRunCanaryAutoPromote(t *testing.T) {
+	stubCanaryVerify(t, nil)
+	d := &canaryDeployer{}
+	opts := canaryTestOptions(t, d)
+
+	result, err := runCanary(context.Background(), opts, false, true, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("runCanary() error = %v", err)
+	}
+	if result == nil || len(d.updated) != 1 || d.updated[0] != "0xprod" {
+		t.Errorf("auto-promote should update 0xprod, updated %v", d.updated)
+	}
+}
+
+func TestRunCanaryAutoPromoteFailedVerification(t *testing.T) {
+	stubCanaryVerify(t, errors.New("entrypoint returned HTTP 502"))
+	d := &canaryDeployer{}
+	opts := canaryTestOptions(t, d)
+
+	var out bytes.Buffer
+	if _, err := runCanary(context.Background(), opts, false, true, &out); err == nil {
+		t.Fatal("runCanary() should fail when the canary does not verify")
+	}
+	if len(d.updated) != 0 {
+		t.Errorf("a failed canary must not be promoted, updated %v", d.updated)
+	}
+	if !strings.Contains(out.String(), "HTTP 502") || !strings.Contains(out.String(), "https://canary.example") {
+		t.Errorf("output should show the failure and the canary URL:\n%s", out.String())
+	}
+}
+
+func TestValidateCanaryFlags(t *testing.T) {
+	tests := []struct {
+		name                                                   string
+		canary, promote, autoPromote, dryRun, forceNew, target bool
+		wantErr                                                bool
+	}{
+		{name: "plain deploy", dryRun: true, forceNew: true},
+		{name: "canary", canary: true},
+		{name: "canary auto-promote", canary: true, autoPromote: true},
+		{name: "promote", promote: true},
+		{name: "canary and promote", canary: true, promote: true, wantErr: true},
+		{name: "promote and auto-promote", promote: true, autoPromote: true, wantErr: true},
+		{name: "canary dry-run", canary: true, dryRun: true, wantErr: true},
+		{name: "promote force-new", promote: true, forceNew: true, wantErr: true},
+		{name: "canary target-dir", canary: true, target: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCanaryFlags(tt.canary, tt.promote, tt.autoPromote, tt.dryRun, tt.forceNew, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCanaryFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
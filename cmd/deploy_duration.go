@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// durationEpochs converts a --duration or walrus.duration value such as
+// "6mo" into epochs on network. A duration longer than the network allows
+// is clamped to config.MaxEpochs with a warning on out.
+func durationEpochs(duration, network string, quiet bool, out io.Writer) (int, error) {
+	icons := ui.GetIcons()
+
+	epochs, err := config.DurationToEpochs(duration, network)
+	switch {
+	case errors.Is(err, config.ErrEpochsClamped):
+		fmt.Fprintf(out, "%s Warning: %v\n", icons.Warning, err)
+	case err != nil:
+		return 0, err
+	case !quiet:
+		fmt.Fprintf(out, "%s Storing for %s: %d epoch(s) on %s\n", icons.Info, duration, epochs, networkOrTestnet(network))
+	}
+	return epochs, nil
+}
+
+// networkOrTestnet names the network epochs are counted on; an unknown
+// network uses testnet's epoch length.
+func networkOrTestnet(network string) string {
+	if network == "mainnet" {
+		return network
+	}
+	return "testnet"
+}
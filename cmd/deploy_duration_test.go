@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/config"
+)
+
+func TestDurationEpochs(t *testing.T) {
+	var out bytes.Buffer
+	epochs, err := durationEpochs("6mo", "mainnet", false, &out)
+	if err != nil || epochs != 13 {
+		t.Fatalf("durationEpochs(6mo, mainnet) = %d, %v, want 13", epochs, err)
+	}
+	if !strings.Contains(out.String(), "6mo: 13 epoch(s) on mainnet") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	out.Reset()
+	epochs, err = durationEpochs("6mo", "testnet", true, &out)
+	if err != nil || epochs != config.MaxEpochs {
+		t.Fatalf("durationEpochs(6mo, testnet) = %d, %v, want the clamped %d", epochs, err, config.MaxEpochs)
+	}
+	if !strings.Contains(out.String(), "Warning") || !strings.Contains(out.String(), "180 epochs") {
+		t.Errorf("clamping should warn even when quiet, got %q", out.String())
+	}
+
+	if _, err := durationEpochs("forever", "testnet", true, &out); err == nil {
+		t.Error("durationEpochs() should reject an invalid duration")
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/spf13/pflag"
+)
+
+// applyDeployEnv sets the deploy flags a preset defines, leaving alone any
+// the user gave on the command line. It returns the settings it applied.
+func applyDeployEnv(flags *pflag.FlagSet, env config.DeployEnv) ([]string, error) {
+	changed := func(names ...string) bool {
+		for _, name := range names {
+			if flags.Changed(name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var applied []string
+	set := func(name, value string) error {
+		if err := flags.Set(name, value); err != nil {
+			return fmt.Errorf("cannot apply --%s %s: %w", name, value, err)
+		}
+		applied = append(applied, fmt.Sprintf("--%s %s", name, value))
+		return nil
+	}
+
+	if env.Epochs != 0 && !changed("epochs", "duration", "max-epochs-cost", "epochs-auto") {
+		if err := set("epochs", strconv.Itoa(env.Epochs)); err != nil {
+			return nil, err
+		}
+	}
+	if env.Canary && !changed("canary", "promote") {
+		if err := set("canary", "true"); err != nil {
+			return nil, err
+		}
+	}
+	if env.DryRun && !changed("dry-run") {
+		if err := set("dry-run", "true"); err != nil {
+			return nil, err
+		}
+	}
+	if env.Verify && !changed("verify") {
+		if err := set("verify", "true"); err != nil {
+			return nil, err
+		}
+	}
+	return applied, nil
+}
+
+// checkEnvNetwork fails when the preset is pinned to a network other than the
+// one the active wallet is on.
+func checkEnvNetwork(name string, env config.DeployEnv, network string) error {
+	if env.Network == "" || env.Network == network {
+		return nil
+	}
+	return fmt.Errorf("env %q deploys to %s but the active network is %s; run 'sui client switch --env %s' first",
+		name, env.Network, network, env.Network)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/spf13/pflag"
+)
+
+// newDeployEnvFlags mirrors the deploy flags a preset can set.
+func newDeployEnvFlags(t *testing.T, args ...string) *pflag.FlagSet {
+	t.Helper()
+	flags := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+	flags.IntP("epochs", "e", 1, "")
+	flags.String("duration", "", "")
+	flags.Float64("max-epochs-cost", 0, "")
+	flags.Bool("epochs-auto", false, "")
+	flags.Bool("canary", false, "")
+	flags.Bool("promote", false, "")
+	flags.Bool("dry-run", false, "")
+	flags.Bool("verify", false, "")
+	if err := flags.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return flags
+}
+
+var testnetPreview = config.DeployEnv{Network: "testnet", Epochs: 1, Verify: true}
+
+func TestApplyDeployEnvExpands(t *testing.T) {
+	flags := newDeployEnvFlags(t)
+	applied, err := applyDeployEnv(flags, config.DeployEnv{Epochs: 3, Canary: true, Verify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"--epochs 3", "--canary true", "--verify true"}
+	if !reflect.DeepEqual(applied, want) {
+		t.Errorf("applied = %v, want %v", applied, want)
+	}
+	epochs, _ := flags.GetInt("epochs")
+	canary, _ := flags.GetBool("canary")
+	verify, _ := flags.GetBool("verify")
+	if epochs != 3 || !canary || !verify {
+		t.Errorf("epochs=%d canary=%v verify=%v", epochs, canary, verify)
+	}
+	if !flags.Changed("epochs") {
+		t.Error("preset epochs should count as set so walgo.yaml's epochs don't replace them")
+	}
+
+	flags = newDeployEnvFlags(t)
+	applied, err = applyDeployEnv(flags, config.DeployEnv{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dryRun, _ := flags.GetBool("dry-run"); !dryRun || !reflect.DeepEqual(applied, []string{"--dry-run true"}) {
+		t.Errorf("applied = %v, dry-run = %v", applied, dryRun)
+	}
+}
+
+func TestApplyDeployEnvExplicitFlagsWin(t *testing.T) {
+	flags := newDeployEnvFlags(t, "--epochs", "5", "--verify=false")
+	applied, err := applyDeployEnv(flags, testnetPreview)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("applied = %v", applied)
+	}
+	epochs, _ := flags.GetInt("epochs")
+	verify, _ := flags.GetBool("verify")
+	if epochs != 5 || verify {
+		t.Errorf("epochs = %d, verify = %v; want the explicit 5 and false", epochs, verify)
+	}
+}
+
+func TestApplyDeployEnvSkipsConflictingFlags(t *testing.T) {
+	flags := newDeployEnvFlags(t, "--duration", "30d", "--promote")
+	applied, err := applyDeployEnv(flags, config.DeployEnv{Epochs: 2, Canary: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 0 || flags.Changed("epochs") || flags.Changed("canary") {
+		t.Errorf("applied = %v; --duration and --promote should keep the preset's epochs and canary off", applied)
+	}
+}
+
+func TestCheckEnvNetwork(t *testing.T) {
+	if err := checkEnvNetwork("testnet-preview", testnetPreview, "testnet"); err != nil {
+		t.Errorf("matching network: %v", err)
+	}
+	if err := checkEnvNetwork("any", config.DeployEnv{}, "mainnet"); err != nil {
+		t.Errorf("unpinned env: %v", err)
+	}
+	err := checkEnvNetwork("testnet-preview", testnetPreview, "mainnet")
+	if err == nil || !strings.Contains(err.Error(), "sui client switch --env testnet") {
+		t.Errorf("mismatched network error = %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// epochsFromHistory picks epochs for --epochs-auto from the update cadence
+// of the project deployed from sitePath (or with objectID). It falls back to
+// fallback when there is no project or too little history, and explains the
+// choice on out unless quiet.
+func epochsFromHistory(sitePath, objectID, network string, fallback int, quiet bool, out io.Writer) int {
+	icons := ui.GetIcons()
+
+	var deployments []*projects.DeploymentRecord
+	if pm, err := projects.NewManager(); err == nil {
+		proj, _ := pm.GetProjectBySitePath(sitePath)
+		if proj == nil {
+			proj, _ = pm.GetProjectByObjectID(objectID)
+		}
+		if proj != nil {
+			deployments, _ = pm.GetProjectDeployments(proj.ID)
+		}
+		pm.Close()
+	}
+
+	suggestion, ok := projects.SuggestEpochs(deployments, network)
+	if !ok {
+		if !quiet {
+			fmt.Fprintf(out, "%s Auto epochs: not enough deploy history, using %d epoch(s)\n", icons.Info, fallback)
+		}
+		return fallback
+	}
+
+	if !quiet {
+		fmt.Fprintf(out, "%s Auto epochs: %s\n", icons.Hourglass, suggestion.Reason)
+	}
+	return suggestion.Epochs
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/projects"
+)
+
+func TestEpochsFromHistoryFallsBack(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	sitePath := t.TempDir()
+
+	var out bytes.Buffer
+	if got := epochsFromHistory(sitePath, "", "testnet", 3, false, &out); got != 3 {
+		t.Errorf("new project: epochs = %d, want fallback 3", got)
+	}
+	if !strings.Contains(out.String(), "not enough deploy history") {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj := &projects.Project{Name: "once", Network: "testnet", ObjectID: "0xonce", SitePath: sitePath}
+	if err := pm.CreateProject(proj); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.RecordDeployment(&projects.DeploymentRecord{ProjectID: proj.ID, ObjectID: proj.ObjectID, Network: "testnet", Epochs: 2, Success: true}); err != nil {
+		t.Fatal(err)
+	}
+	pm.Close()
+
+	out.Reset()
+	if got := epochsFromHistory(sitePath, "", "testnet", 3, true, &out); got != 3 {
+		t.Errorf("single deploy: epochs = %d, want fallback 3", got)
+	}
+	if out.Len() != 0 {
+		t.Errorf("quiet mode should print nothing, got %q", out.String())
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -55,16 +54,6 @@ Example (Mainnet):
 			fmt.Fprintf(os.Stderr, "%s Error: reading mode flag: %v\n", icons.Error, err)
 			return fmt.Errorf("error reading mode flag: %w", err)
 		}
-		workers, err := cmd.Flags().GetInt("workers")
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s Error: reading workers flag: %v\n", icons.Error, err)
-			return fmt.Errorf("error reading workers flag: %w", err)
-		}
-		retries, err := cmd.Flags().GetInt("retries")
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s Error: reading retries flag: %v\n", icons.Error, err)
-			return fmt.Errorf("error reading retries flag: %w", err)
-		}
 		jsonLogs, err := cmd.Flags().GetBool("json")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%s Error: reading json flag: %v\n", icons.Error, err)
@@ -76,6 +65,15 @@ Example (Mainnet):
 			return fmt.Errorf("error reading verbose flag: %w", err)
 		}
 
+		// Fall back to gateway endpoints from walgo.yaml when flags are omitted
+		if publisher == "" || aggregator == "" {
+			if cwd, err := os.Getwd(); err == nil {
+				if cfg, err := config.LoadConfigFrom(cwd); err == nil {
+					publisher, aggregator = resolveHTTPEndpoints(publisher, aggregator, cfg.WalrusConfig.Gateway)
+				}
+			}
+		}
+
 		if publisher == "" || aggregator == "" {
 			fmt.Fprintf(os.Stderr, "%s Error: --publisher and --aggregator are required\n", icons.Error)
 			fmt.Fprintln(os.Stderr, "")
@@ -91,6 +89,27 @@ Example (Mainnet):
 			fmt.Fprintln(os.Stderr, "  --aggregator https://aggregator.walrus-mainnet.walrus.space")
 			return fmt.Errorf("--publisher and --aggregator are required")
 		}
+		failedFilesPath, _ := cmd.Flags().GetString("failed-files")
+		retryPath, _ := cmd.Flags().GetString("retry-failed")
+		var retry *deployer.FailedFilesManifest
+		if retryPath != "" {
+			if cmd.Flags().Changed("mode") && !strings.EqualFold(mode, "blobs") {
+				return fmt.Errorf("--retry-failed uploads individual blobs and cannot be combined with --mode %s", mode)
+			}
+			retry, err = deployer.ReadFailedFiles(retryPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return err
+			}
+			mode = "blobs"
+			if !cmd.Flags().Changed("epochs") && retry.Epochs > 0 {
+				epochs = retry.Epochs
+			}
+			// Later failures replace the manifest, so repeated retries shrink it
+			if failedFilesPath == "" {
+				failedFilesPath = retryPath
+			}
+		}
 		if epochs <= 0 {
 			epochs = 1
 		}
@@ -114,27 +133,33 @@ Example (Mainnet):
 			return fmt.Errorf("publish directory not found: %s", publishDir)
 		}
 
-		err = hugo.BuildSite(sitePath)
-		if err != nil {
-			return fmt.Errorf("failed to build site: %w", err)
+		// A retry uploads the files of the build that failed, so it is not rebuilt
+		var retryFiles []string
+		if retry != nil {
+			retryFiles = retry.Paths()
+			fmt.Printf("%s Retrying %d failed files from %s\n", icons.Info, len(retryFiles), retryPath)
+		} else {
+			buildOpts := scheduleBuildOptions(cmd)
+			buildOpts.Reproducible = buildOpts.Reproducible || cfg.HugoConfig.Reproducible
+			warnDraftsDeploy(buildOpts, os.Stderr)
+			err = hugo.BuildSiteWithOptions(sitePath, buildOpts)
+			if err != nil {
+				return fmt.Errorf("failed to build site: %w", err)
+			}
 		}
 
 		// Use HTTP deployer; default to quilt (single request)
 		d := httpdep.New()
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		ctx, cancel := newDeployContext(30 * time.Minute)
 		defer cancel()
 		if mode == "" {
 			mode = "quilt"
 		}
-		if workers <= 0 {
-			workers = 10
-		}
+		tuning := resolveDeployTuning(cmd, cfg.WalrusConfig)
+		workers := tuning.Concurrency
 		if workers > 50 {
 			workers = 50 // Cap to prevent resource exhaustion
 		}
-		if retries <= 0 {
-			retries = 5
-		}
 
 		res, err := d.Deploy(ctx, publishDir, deployer.DeployOptions{
 			Epochs:            epochs,
@@ -142,12 +167,17 @@ Example (Mainnet):
 			AggregatorBaseURL: aggregator,
 			Mode:              mode,
 			Workers:           workers,
-			MaxRetries:        retries,
+			MaxRetries:        tuning.Retries,
+			RateLimit:         tuning.RateLimit,
 			JSONLogs:          jsonLogs,
 			Verbose:           verbose,
+			Files:             retryFiles,
 		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%s Error: HTTP deploy failed: %v\n", icons.Error, err)
+			if _, werr := recordFailedFiles(failedFilesPath, publishDir, epochs, err, os.Stdout); werr != nil {
+				fmt.Fprintf(os.Stderr, "%s Warning: %v\n", icons.Warning, werr)
+			}
 			return fmt.Errorf("HTTP deploy failed: %w", err)
 		}
 
@@ -155,6 +185,17 @@ Example (Mainnet):
 		if res.ObjectID != "" {
 			fmt.Printf("%s Quilt ID: %s\n", icons.Package, res.ObjectID)
 		}
+		if res.Concurrency > 0 && verbose {
+			fmt.Printf("%s Upload concurrency settled at %d (max %d)\n", icons.Info, res.Concurrency, workers)
+		}
+		if res.DedupedFiles > 0 {
+			fmt.Printf("%s Deduplicated %d identical files (%.2f MB not uploaded)\n",
+				icons.Money, res.DedupedFiles, float64(res.DedupedBytes)/(1024*1024))
+		}
+
+		if retry != nil {
+			printRetriedBlobs(os.Stdout, res.FileToBlobID)
+		}
 
 		// Show per-file info and aggregator fetch hints when available
 		if len(res.QuiltPatches) > 0 {
@@ -179,14 +220,53 @@ Example (Mainnet):
 	},
 }
 
+// resolveHTTPEndpoints returns the publisher and aggregator to use, preferring
+// explicit flag values over the gateway overrides in walgo.yaml.
+func resolveHTTPEndpoints(publisher, aggregator string, gw config.GatewayConfig) (string, string) {
+	if publisher == "" {
+		publisher = gw.PublisherURL
+	}
+	if aggregator == "" {
+		aggregator = gw.AggregatorURL
+	}
+	return publisher, aggregator
+}
+
+// resolveDeployTuning returns the upload settings for the configured network
+// from walrus.deploy, with any --workers, --retries or --rate-limit flag the
+// user passed taking precedence.
+func resolveDeployTuning(cmd *cobra.Command, walrusCfg config.WalrusConfig) config.NetworkDeployConfig {
+	tuning := walrusCfg.Deploy.ForNetwork(walrusCfg.Network)
+	if cmd.Flags().Changed("workers") {
+		if workers, _ := cmd.Flags().GetInt("workers"); workers > 0 {
+			tuning.Concurrency = workers
+		}
+	}
+	if cmd.Flags().Changed("retries") {
+		if retries, _ := cmd.Flags().GetInt("retries"); retries > 0 {
+			tuning.Retries = retries
+		}
+	}
+	if cmd.Flags().Changed("rate-limit") {
+		if rate, _ := cmd.Flags().GetFloat64("rate-limit"); rate >= 0 {
+			tuning.RateLimit = rate
+		}
+	}
+	return tuning
+}
+
 func init() {
 	rootCmd.AddCommand(deployHTTPCmd)
-	deployHTTPCmd.Flags().String("publisher", "", "Walrus publisher base URL (see https://docs.wal.app/docs/usage/web-api#public-services)")
-	deployHTTPCmd.Flags().String("aggregator", "", "Walrus aggregator base URL (see https://docs.wal.app/docs/usage/web-api#public-services)")
+	addScheduleFlags(deployHTTPCmd)
+	deployHTTPCmd.Flags().String("publisher", "", "Walrus publisher base URL (default: walrus.gateway.publisherURL; see https://docs.wal.app/docs/usage/web-api#public-services)")
+	deployHTTPCmd.Flags().String("aggregator", "", "Walrus aggregator base URL (default: walrus.gateway.aggregatorURL; see https://docs.wal.app/docs/usage/web-api#public-services)")
 	deployHTTPCmd.Flags().IntP("epochs", "e", 1, "Number of epochs to store the quilt")
 	deployHTTPCmd.Flags().String("mode", "quilt", "HTTP deploy mode: quilt or blobs")
-	deployHTTPCmd.Flags().Int("workers", 10, "Concurrent workers for blobs mode")
-	deployHTTPCmd.Flags().Int("retries", 5, "Max retries per file for transient errors")
+	deployHTTPCmd.Flags().Int("workers", config.DefaultDeployConcurrency, "Maximum concurrent uploads for blobs mode, tuned automatically up to this; overrides walrus.deploy.<network>.concurrency")
+	deployHTTPCmd.Flags().Int("retries", config.DefaultDeployRetries, "Max retries per file for transient errors; overrides walrus.deploy.<network>.retries")
+	deployHTTPCmd.Flags().Float64("rate-limit", 0, "Maximum new uploads started per second in blobs mode, 0 for no limit; overrides walrus.deploy.<network>.rateLimit")
+	deployHTTPCmd.Flags().String("failed-files", "", "In blobs mode, write the files that failed to upload to this JSON manifest (e.g. failed-files.json)")
+	deployHTTPCmd.Flags().String("retry-failed", "", "Upload only the files listed in a failed-files manifest, without rebuilding the site")
 	deployHTTPCmd.Flags().Bool("json", false, "Emit structured JSON logs")
 	deployHTTPCmd.Flags().BoolP("verbose", "v", false, "Verbose logging")
 }
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/selimozten/walgo/internal/deployer"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// recordFailedFiles writes the failed-files manifest to path when err is a
+// partial upload failure, and tells the user how to retry. It reports
+// whether a manifest was written.
+func recordFailedFiles(path, siteDir string, epochs int, err error, out io.Writer) (bool, error) {
+	var deployErr *deployer.DeployError
+	if path == "" || !errors.As(err, &deployErr) || len(deployErr.Files) == 0 {
+		return false, nil
+	}
+	if err := deployer.WriteFailedFiles(path, deployer.NewFailedFilesManifest(siteDir, epochs, deployErr)); err != nil {
+		return false, err
+	}
+
+	icons := ui.GetIcons()
+	fmt.Fprintf(out, "%s %d of %d files uploaded; the %d failed files are listed in %s\n",
+		icons.Info, deployErr.Total-len(deployErr.Files), deployErr.Total, len(deployErr.Files), path)
+	fmt.Fprintf(out, "%s Retry just those with: walgo deploy-http --retry-failed %s\n", icons.Lightbulb, path)
+	return true, nil
+}
+
+// printRetriedBlobs lists the blob ID each retried file got.
+func printRetriedBlobs(out io.Writer, fileToBlobID map[string]string) {
+	icons := ui.GetIcons()
+	paths := make([]string, 0, len(fileToBlobID))
+	for p := range fileToBlobID {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	fmt.Fprintf(out, "\n%s Retried files:\n", icons.Folder)
+	for _, p := range paths {
+		fmt.Fprintf(out, "  %s %s  %s\n", icons.Check, filepath.ToSlash(p), fileToBlobID[p])
+	}
+}
@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployer"
+
 	"github.com/spf13/cobra"
 )
 
@@ -249,3 +256,111 @@ func TestDeployHTTPCommandDescription(t *testing.T) {
 		}
 	})
 }
+
+func TestResolveHTTPEndpoints(t *testing.T) {
+	gw := config.GatewayConfig{
+		PublisherURL:  "https://publisher.corp.example",
+		AggregatorURL: "https://aggregator.corp.example",
+	}
+
+	pub, agg := resolveHTTPEndpoints("", "", gw)
+	if pub != gw.PublisherURL || agg != gw.AggregatorURL {
+		t.Errorf("expected gateway fallback, got %q %q", pub, agg)
+	}
+
+	pub, agg = resolveHTTPEndpoints("https://flag-pub", "", gw)
+	if pub != "https://flag-pub" || agg != gw.AggregatorURL {
+		t.Errorf("flags should win over gateway, got %q %q", pub, agg)
+	}
+
+	pub, agg = resolveHTTPEndpoints("", "", config.GatewayConfig{})
+	if pub != "" || agg != "" {
+		t.Errorf("unset gateway should leave endpoints empty, got %q %q", pub, agg)
+	}
+}
+
+func TestResolveDeployTuning(t *testing.T) {
+	walrusCfg := config.WalrusConfig{
+		Network: "mainnet",
+		Deploy: config.DeployConfig{
+			Testnet: config.NetworkDeployConfig{Concurrency: 20},
+			Mainnet: config.NetworkDeployConfig{Concurrency: 3, Retries: 8, RateLimit: 2},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		network string
+		args    []string
+		want    config.NetworkDeployConfig
+	}{
+		{"mainnet config", "mainnet", nil, config.NetworkDeployConfig{Concurrency: 3, Retries: 8, RateLimit: 2}},
+		{"testnet config with defaults", "testnet", nil, config.NetworkDeployConfig{Concurrency: 20, Retries: config.DefaultDeployRetries}},
+		{"flags override config", "mainnet", []string{"--workers", "6", "--retries", "2", "--rate-limit", "0"},
+			config.NetworkDeployConfig{Concurrency: 6, Retries: 2, RateLimit: 0}},
+		{"partial override", "mainnet", []string{"--workers", "5"}, config.NetworkDeployConfig{Concurrency: 5, Retries: 8, RateLimit: 2}},
+		{"non-positive flag keeps config", "mainnet", []string{"--workers", "0"}, config.NetworkDeployConfig{Concurrency: 3, Retries: 8, RateLimit: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &cobra.Command{Use: "test"}
+			c.Flags().Int("workers", config.DefaultDeployConcurrency, "")
+			c.Flags().Int("retries", config.DefaultDeployRetries, "")
+			c.Flags().Float64("rate-limit", 0, "")
+			if err := c.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := walrusCfg
+			cfg.Network = tt.network
+			if got := resolveDeployTuning(c, cfg); got != tt.want {
+				t.Errorf("resolveDeployTuning() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordFailedFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), deployer.FailedFilesName)
+	deployErr := fmt.Errorf("wrapped: %w", &deployer.DeployError{
+		Files: []deployer.FileError{{Path: "css/site.css", Err: errors.New("timeout")}},
+		Total: 4,
+	})
+
+	var out bytes.Buffer
+	written, err := recordFailedFiles(path, "/site/public", 2, deployErr, &out)
+	if err != nil || !written {
+		t.Fatalf("recordFailedFiles() = %v, %v", written, err)
+	}
+	m, err := deployer.ReadFailedFiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Files) != 1 || m.Files[0].Path != "css/site.css" || m.Epochs != 2 {
+		t.Errorf("manifest = %+v", m)
+	}
+	if !strings.Contains(out.String(), "--retry-failed "+path) {
+		t.Errorf("output = %q, want a retry hint", out.String())
+	}
+
+	// Other failures and an unset path write nothing
+	other := filepath.Join(t.TempDir(), deployer.FailedFilesName)
+	if written, _ := recordFailedFiles(other, "/site/public", 2, errors.New("publisher down"), &out); written {
+		t.Error("wrote a manifest for a non-partial failure")
+	}
+	if _, err := os.Stat(other); !os.IsNotExist(err) {
+		t.Errorf("manifest exists: %v", err)
+	}
+	if written, _ := recordFailedFiles("", "/site/public", 2, deployErr, &out); written {
+		t.Error("wrote a manifest without a path")
+	}
+}
+
+func TestDeployHTTPRetryFlags(t *testing.T) {
+	for _, name := range []string{"failed-files", "retry-failed"} {
+		if deployHTTPCmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag --%s not found", name)
+		}
+	}
+}
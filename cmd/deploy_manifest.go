@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployment"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// buildManifestPath returns the build manifest to check: hugo.buildManifest
+// or build-manifest.json, relative to the site root.
+func buildManifestPath(sitePath string, hugoCfg config.HugoConfig) string {
+	if hugoCfg.BuildManifest == "" {
+		return filepath.Join(sitePath, deployment.DefaultBuildManifest)
+	}
+	if filepath.IsAbs(hugoCfg.BuildManifest) {
+		return hugoCfg.BuildManifest
+	}
+	return filepath.Join(sitePath, hugoCfg.BuildManifest)
+}
+
+// checkBuildManifest verifies publishDir against the site's build manifest
+// and returns an error listing the offending files on a mismatch. A manifest
+// inside publishDir is refused, since it would be uploaded with the site.
+func checkBuildManifest(sitePath, publishDir string, hugoCfg config.HugoConfig, quiet bool, out io.Writer) error {
+	icons := ui.GetIcons()
+	manifestPath := buildManifestPath(sitePath, hugoCfg)
+	if rel, err := filepath.Rel(publishDir, manifestPath); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("build manifest %s is inside the publish directory and would be uploaded with the site; move it and set hugo.buildManifest", manifestPath)
+	}
+
+	manifest, err := deployment.LoadBuildManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	report, err := deployment.VerifyBuildManifest(publishDir, manifest)
+	if err != nil {
+		return err
+	}
+	if err := report.Err(); err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Fprintf(out, "  %s %d file(s) match the build manifest\n", icons.Check, report.Checked)
+	}
+	return nil
+}
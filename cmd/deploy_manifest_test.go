@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployment"
+)
+
+func TestBuildManifestPath(t *testing.T) {
+	site := filepath.Join("/sites", "blog")
+
+	tests := []struct {
+		setting string
+		want    string
+	}{
+		{"", filepath.Join(site, "build-manifest.json")},
+		{"dist/hashes.json", filepath.Join(site, "dist", "hashes.json")},
+		{filepath.Join("/ci", "hashes.json"), filepath.Join("/ci", "hashes.json")},
+	}
+	for _, tt := range tests {
+		got := buildManifestPath(site, config.HugoConfig{BuildManifest: tt.setting})
+		if got != tt.want {
+			t.Errorf("buildManifestPath(%q) = %q, want %q", tt.setting, got, tt.want)
+		}
+	}
+}
+
+func TestCheckBuildManifest(t *testing.T) {
+	site := t.TempDir()
+	publish := filepath.Join(site, "public")
+	files := map[string]string{
+		"index.html":   "<html>home</html>",
+		"css/main.css": "body{}",
+	}
+	manifest := map[string]string{}
+	for name, content := range files {
+		path := filepath.Join(publish, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		sum := sha256.Sum256([]byte(content))
+		manifest[name] = "sha256-" + base64.StdEncoding.EncodeToString(sum[:])
+	}
+	data, _ := json.Marshal(manifest)
+	if err := os.WriteFile(filepath.Join(site, "build-manifest.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Matching manifest: deploy proceeds
+	var out bytes.Buffer
+	if err := checkBuildManifest(site, publish, config.HugoConfig{}, false, &out); err != nil {
+		t.Fatalf("checkBuildManifest() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "2 file(s) match the build manifest") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	// Modified and missing files: deploy aborts listing them
+	if err := os.WriteFile(filepath.Join(publish, "index.html"), []byte("<html>changed</html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(publish, "css", "main.css")); err != nil {
+		t.Fatal(err)
+	}
+	err := checkBuildManifest(site, publish, config.HugoConfig{}, false, &out)
+	if !errors.Is(err, deployment.ErrBuildManifestMismatch) {
+		t.Fatalf("checkBuildManifest() error = %v, want ErrBuildManifestMismatch", err)
+	}
+	for _, want := range []string{"modified: index.html", "missing:  css/main.css"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not list %q", err, want)
+		}
+	}
+
+	// A manifest in the publish directory would be uploaded with the site
+	err = checkBuildManifest(site, publish, config.HugoConfig{BuildManifest: filepath.Join("public", "hashes.json")}, true, &out)
+	if err == nil || !strings.Contains(err.Error(), "inside the publish directory") {
+		t.Errorf("checkBuildManifest() error = %v, want manifest inside publish directory error", err)
+	}
+
+	// No manifest at the configured path
+	err = checkBuildManifest(site, publish, config.HugoConfig{BuildManifest: "nope.json"}, true, &out)
+	if err == nil || !strings.Contains(err.Error(), "failed to read build manifest") {
+		t.Errorf("checkBuildManifest() error = %v, want read error", err)
+	}
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/selimozten/walgo/internal/compress"
+)
+
+// notFoundPageForDeploy returns the page to configure as the site's 404
+// fallback. An explicit --404 value always wins; otherwise 404.html is used
+// when the build produced one and ws-resources.json has no fallback route
+// yet ("*" or "/*"), so an existing SPA catch-all is left alone.
+func notFoundPageForDeploy(flagValue string, flagChanged bool, publishDir string) string {
+	if flagChanged {
+		return flagValue
+	}
+
+	page := compress.DetectNotFoundPage(publishDir)
+	if page == "" {
+		return ""
+	}
+	cfg, err := compress.ReadWSResourcesConfig(filepath.Join(publishDir, compress.WSResourcesFile))
+	if err == nil && compress.FallbackRoute(cfg.Routes) != "" {
+		return ""
+	}
+	return page
+}
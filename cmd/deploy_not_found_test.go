@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/compress"
+)
+
+func TestNotFoundPageForDeploy(t *testing.T) {
+	publishDir := t.TempDir()
+
+	if got := notFoundPageForDeploy("", false, publishDir); got != "" {
+		t.Errorf("no 404.html: got %q, want empty", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(publishDir, "404.html"), []byte("404"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := notFoundPageForDeploy("", false, publishDir); got != compress.DefaultNotFoundPage {
+		t.Errorf("with 404.html: got %q, want %q", got, compress.DefaultNotFoundPage)
+	}
+
+	if got := notFoundPageForDeploy("errors/missing.html", true, publishDir); got != "errors/missing.html" {
+		t.Errorf("explicit flag: got %q, want errors/missing.html", got)
+	}
+
+	wsPath := filepath.Join(publishDir, compress.WSResourcesFile)
+	cfg := &compress.WSResourcesConfig{Routes: map[string]string{"*": "/index.html"}}
+	if err := compress.WriteWSResourcesConfig(cfg, wsPath); err != nil {
+		t.Fatal(err)
+	}
+	if got := notFoundPageForDeploy("", false, publishDir); got != "" {
+		t.Errorf("existing catch-all route: got %q, want empty", got)
+	}
+
+	cfg.Routes = map[string]string{"/*": "/index.html"}
+	if err := compress.WriteWSResourcesConfig(cfg, wsPath); err != nil {
+		t.Fatal(err)
+	}
+	if got := notFoundPageForDeploy("", false, publishDir); got != "" {
+		t.Errorf("existing \"/*\" catch-all route: got %q, want empty", got)
+	}
+}
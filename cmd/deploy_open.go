@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/selimozten/walgo/internal/deployment"
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// openInBrowser opens url in the default browser; replaced in tests.
+var openInBrowser = func(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// stdoutIsTerminal reports whether stdout is an interactive terminal;
+// replaced in tests.
+var stdoutIsTerminal = func() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// deployOpenURL picks the URL `walgo deploy --open` opens: the project's
+// wal.app portal URL when it has a SuiNS domain (like 'walgo projects show'),
+// otherwise the browse URL site-builder reported. proj may be nil.
+func deployOpenURL(proj *projects.Project, result *deployment.DeploymentResult) string {
+	if proj != nil {
+		if url := proj.PortalURL(); url != "" {
+			return url
+		}
+	}
+	if result == nil {
+		return ""
+	}
+	return result.PortalURL
+}
+
+// browserOpenSuppressed returns why the browser should not be opened
+// automatically, or "" when it may be. force overrides the checks.
+func browserOpenSuppressed(force bool) string {
+	switch {
+	case force:
+		return ""
+	case ui.IsCI():
+		return "running in CI"
+	case !stdoutIsTerminal():
+		return "not running in a terminal"
+	}
+	return ""
+}
+
+// openDeployedSite opens the deployed site for `walgo deploy --open`. The
+// project is looked up by site path; without one, walgo.yaml's SuiNS domain
+// is still used. Failures are reported as warnings: the deploy itself
+// already succeeded.
+func openDeployedSite(out io.Writer, sitePath, network string, result *deployment.DeploymentResult, force bool) {
+	icons := ui.GetIcons()
+
+	if reason := browserOpenSuppressed(force); reason != "" {
+		fmt.Fprintf(out, "%s Not opening the browser: %s (use --force-open to override)\n", icons.Info, reason)
+		return
+	}
+
+	proj := &projects.Project{Network: network, SitePath: sitePath}
+	if pm, err := projects.NewManager(); err == nil {
+		if found, err := pm.GetProjectBySitePath(sitePath); err == nil && found != nil {
+			proj = found
+		}
+		pm.Close()
+	}
+
+	url := deployOpenURL(proj, result)
+	if url == "" {
+		fmt.Fprintf(out, "%s No URL to open: link a SuiNS domain with 'walgo projects set-suins'\n", icons.Info)
+		return
+	}
+	if err := openInBrowser(url); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Warning: Failed to open %s: %v\n", icons.Warning, url, err)
+		return
+	}
+	fmt.Fprintf(out, "%s Opened %s\n", icons.Globe, url)
+}
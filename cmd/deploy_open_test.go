@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/deployment"
+	"github.com/selimozten/walgo/internal/projects"
+)
+
+func TestDeployOpenURL(t *testing.T) {
+	result := &deployment.DeploymentResult{PortalURL: "http://1abc.localhost:3000"}
+
+	tests := []struct {
+		name   string
+		proj   *projects.Project
+		result *deployment.DeploymentResult
+		want   string
+	}{
+		{"mainnet project with SuiNS", &projects.Project{Network: "mainnet", SuiNS: "myblog.sui"}, result, "https://myblog.wal.app"},
+		{"testnet project with SuiNS", &projects.Project{Network: "testnet", SuiNS: "myblog.sui"}, result, "http://1abc.localhost:3000"},
+		{"project without SuiNS", &projects.Project{Network: "mainnet"}, result, "http://1abc.localhost:3000"},
+		{"no project", nil, result, "http://1abc.localhost:3000"},
+		{"nothing known", nil, &deployment.DeploymentResult{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deployOpenURL(tt.proj, tt.result); got != tt.want {
+				t.Errorf("deployOpenURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// stubBrowser records the URLs openInBrowser is asked to open.
+func stubBrowser(t *testing.T, terminal bool) *[]string {
+	t.Helper()
+	origOpen, origTerminal := openInBrowser, stdoutIsTerminal
+	t.Cleanup(func() { openInBrowser, stdoutIsTerminal = origOpen, origTerminal })
+
+	var opened []string
+	openInBrowser = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+	stdoutIsTerminal = func() bool { return terminal }
+	return &opened
+}
+
+// clearCIEnv unsets the variables ui.IsCI looks at.
+func clearCIEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"CI", "CONTINUOUS_INTEGRATION", "JENKINS_URL", "TRAVIS", "CIRCLECI", "GITHUB_ACTIONS", "GITLAB_CI", "BUILDKITE", "DRONE"} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestOpenDeployedSite(t *testing.T) {
+	tests := []struct {
+		name     string
+		ci       bool
+		terminal bool
+		force    bool
+		wantOpen bool
+		wantMsg  string
+	}{
+		{name: "interactive terminal", terminal: true, wantOpen: true, wantMsg: "Opened http://1abc.localhost:3000"},
+		{name: "CI=true", ci: true, terminal: true, wantMsg: "running in CI"},
+		{name: "no TTY", terminal: false, wantMsg: "not running in a terminal"},
+		{name: "forced in CI without TTY", ci: true, force: true, wantOpen: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			clearCIEnv(t)
+			if tt.ci {
+				t.Setenv("CI", "true")
+			}
+			opened := stubBrowser(t, tt.terminal)
+
+			var out bytes.Buffer
+			result := &deployment.DeploymentResult{PortalURL: "http://1abc.localhost:3000"}
+			openDeployedSite(&out, t.TempDir(), "testnet", result, tt.force)
+
+			if tt.wantOpen && (len(*opened) != 1 || (*opened)[0] != result.PortalURL) {
+				t.Errorf("opened %v, want [%s]", *opened, result.PortalURL)
+			}
+			if !tt.wantOpen && len(*opened) != 0 {
+				t.Errorf("opened %v, want nothing", *opened)
+			}
+			if !strings.Contains(out.String(), tt.wantMsg) {
+				t.Errorf("output %q does not contain %q", out.String(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestDeployOpenFlags(t *testing.T) {
+	for _, name := range []string{"open", "force-open"} {
+		if deployCmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag --%s not found", name)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// maxListedPaths caps how many offending paths a failed preflight prints.
+const maxListedPaths = 20
+
+// maxPathLengthFor returns the path limit for a deploy: --max-path-length
+// when given, else compress.maxPathLength from walgo.yaml, else the default.
+func maxPathLengthFor(flagValue int, flagChanged bool, compressCfg config.CompressConfig) (int, error) {
+	if flagChanged {
+		if flagValue < 1 {
+			return 0, fmt.Errorf("--max-path-length must be at least 1")
+		}
+		return flagValue, nil
+	}
+	if compressCfg.MaxPathLength < 0 {
+		return 0, fmt.Errorf("invalid compress.maxPathLength %d in walgo.yaml: must not be negative", compressCfg.MaxPathLength)
+	}
+	if compressCfg.MaxPathLength > 0 {
+		return compressCfg.MaxPathLength, nil
+	}
+	return compress.DefaultMaxPathLength, nil
+}
+
+// checkResourcePaths is the deploy preflight for resource paths. Paths that
+// are too long or contain unsafe characters fail the deploy, listing them,
+// unless sanitize is set; then the files are moved to safe paths and the old
+// paths are routed to the new ones in ws-resources.json.
+func checkResourcePaths(publishDir string, maxLen int, sanitize, quiet bool, out io.Writer) error {
+	icons := ui.GetIcons()
+
+	problems, err := compress.CheckResourcePaths(publishDir, maxLen)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	if !sanitize {
+		fmt.Fprintf(out, "%s %d resource path(s) cannot be deployed safely:\n", icons.Error, len(problems))
+		for i, problem := range problems {
+			if i == maxListedPaths {
+				fmt.Fprintf(out, "   ... and %d more\n", len(problems)-maxListedPaths)
+				break
+			}
+			fmt.Fprintf(out, "   %s %s\n", problem.Path, problem.Reason)
+		}
+		fmt.Fprintf(out, "\n%s Rename these files, raise --max-path-length, or deploy with --sanitize to remap them\n", icons.Lightbulb)
+		return fmt.Errorf("%d resource path(s) exceed %d characters or contain unsafe characters", len(problems), maxLen)
+	}
+
+	remaps, err := compress.SanitizeResourcePaths(publishDir, problems, maxLen)
+	if err != nil {
+		return err
+	}
+	if err := compress.AddPathAliases(filepath.Join(publishDir, compress.WSResourcesFile), remaps); err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Fprintf(out, "  %s Remapped %d resource path(s); the old URLs route to the new paths:\n", icons.Check, len(remaps))
+		for _, remap := range remaps {
+			fmt.Fprintf(out, "     %s -> %s\n", remap.From, remap.To)
+		}
+	}
+	return nil
+}
+
+// printEntrypointTip explains how to fix a deploy that PerformDeployment
+// stopped because the publish directory has no site entrypoint
+// (walrus.entrypoint).
+func printEntrypointTip(err error, out io.Writer) {
+	if errors.Is(err, compress.ErrEntrypointMissing) {
+		fmt.Fprintf(out, "%s Tip: Run 'walgo build' (or 'hugo') to generate the site, or set walrus.entrypoint in walgo.yaml\n", ui.GetIcons().Lightbulb)
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/config"
+)
+
+func TestMaxPathLengthFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		flagValue   int
+		flagChanged bool
+		configured  int
+		want        int
+		wantErr     bool
+	}{
+		{"default", compress.DefaultMaxPathLength, false, 0, compress.DefaultMaxPathLength, false},
+		{"from walgo.yaml", compress.DefaultMaxPathLength, false, 120, 120, false},
+		{"flag overrides walgo.yaml", 90, true, 120, 90, false},
+		{"zero flag", 0, true, 0, 0, true},
+		{"negative config", compress.DefaultMaxPathLength, false, -1, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := maxPathLengthFor(tt.flagValue, tt.flagChanged, config.CompressConfig{MaxPathLength: tt.configured})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("maxPathLengthFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("maxPathLengthFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func writeDeployPathsSite(t *testing.T) string {
+	t.Helper()
+	publishDir := t.TempDir()
+	for _, name := range []string{"index.html", "faq?.html"} {
+		if err := os.WriteFile(filepath.Join(publishDir, name), []byte("<html></html>"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return publishDir
+}
+
+func TestCheckResourcePaths_FailsListingPaths(t *testing.T) {
+	publishDir := writeDeployPathsSite(t)
+
+	var out bytes.Buffer
+	err := checkResourcePaths(publishDir, compress.DefaultMaxPathLength, false, false, &out)
+	if err == nil {
+		t.Fatal("checkResourcePaths() should fail on an unsafe path")
+	}
+	if !strings.Contains(out.String(), "/faq?.html") || !strings.Contains(out.String(), "--sanitize") {
+		t.Errorf("output = %q, want the offending path and the --sanitize hint", out.String())
+	}
+	if _, err := os.Stat(filepath.Join(publishDir, "faq?.html")); err != nil {
+		t.Error("files should be left in place without --sanitize")
+	}
+}
+
+func TestCheckResourcePaths_Sanitize(t *testing.T) {
+	publishDir := writeDeployPathsSite(t)
+
+	var out bytes.Buffer
+	if err := checkResourcePaths(publishDir, compress.DefaultMaxPathLength, true, false, &out); err != nil {
+		t.Fatalf("checkResourcePaths() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "/faq?.html -> /faq-.html") {
+		t.Errorf("output = %q, want the remap listed", out.String())
+	}
+
+	cfg, err := compress.ReadWSResourcesConfig(filepath.Join(publishDir, compress.WSResourcesFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Routes["/faq?.html"] != "/faq-.html" {
+		t.Errorf("routes = %v, want an alias for the old path", cfg.Routes)
+	}
+}
+
+func TestCheckResourcePaths_CleanSite(t *testing.T) {
+	publishDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(publishDir, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := checkResourcePaths(publishDir, compress.DefaultMaxPathLength, false, false, &out); err != nil {
+		t.Fatalf("checkResourcePaths() error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output = %q, want nothing for a clean site", out.String())
+	}
+	if _, err := os.Stat(filepath.Join(publishDir, compress.WSResourcesFile)); !os.IsNotExist(err) {
+		t.Error("ws-resources.json should not be created for a clean site")
+	}
+}
+
+func TestPrintEntrypointTip(t *testing.T) {
+	var out bytes.Buffer
+	printEntrypointTip(errors.New("walrus: out of gas"), &out)
+	if out.Len() != 0 {
+		t.Errorf("unrelated error printed %q", out.String())
+	}
+
+	err := compress.CheckEntrypointFile(t.TempDir(), "index.html")
+	printEntrypointTip(fmt.Errorf("deploy: %w", err), &out)
+	if !strings.Contains(out.String(), "walgo build") {
+		t.Errorf("output = %q, want a hint to build the site", out.String())
+	}
+}
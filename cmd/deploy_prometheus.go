@@ -0,0 +1,17 @@
+package cmd
+
+import "path/filepath"
+
+// prometheusProjectLabel names the site in --report-prometheus metrics:
+// --project-name when given, else the directory name a saved project would
+// get. A --target-dir deploy is named after the target directory, so each
+// site of a monorepo gets its own series.
+func prometheusProjectLabel(sitePath, publishDir, projectName string, useTargetDir bool) string {
+	if projectName != "" {
+		return projectName
+	}
+	if useTargetDir {
+		return filepath.Base(publishDir)
+	}
+	return filepath.Base(sitePath)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestPrometheusProjectLabel(t *testing.T) {
+	sitePath := filepath.Join("home", "me", "my-blog")
+	publishDir := filepath.Join(sitePath, "dist", "siteA")
+
+	tests := []struct {
+		name         string
+		projectName  string
+		useTargetDir bool
+		want         string
+	}{
+		{"project name wins", "blog-prod", false, "blog-prod"},
+		{"project name wins for target dir", "blog-prod", true, "blog-prod"},
+		{"site directory", "", false, "my-blog"},
+		{"target directory", "", true, "siteA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prometheusProjectLabel(sitePath, publishDir, tt.projectName, tt.useTargetDir); got != tt.want {
+				t.Errorf("prometheusProjectLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
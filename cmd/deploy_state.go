@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployment"
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// loadSiteState gathers what the ws-resources.json consistency check
+// compares against; replaced in tests.
+var loadSiteState = func(sitePath string, walgoCfg *config.WalgoConfig) deployment.SiteState {
+	var state deployment.SiteState
+	if id := walgoCfg.WalrusConfig.ProjectID; !config.IsPlaceholderProjectID(id) {
+		state.ConfigObjectID = strings.TrimSpace(id)
+	}
+
+	pm, err := projects.NewManager()
+	if err != nil {
+		return state
+	}
+	defer pm.Close()
+	if proj, _ := pm.GetProjectBySitePath(sitePath); proj != nil {
+		if deployments, err := pm.GetProjectDeployments(proj.ID); err == nil {
+			state.Tracked = true
+			state.ProjectObjectID = proj.ObjectID
+			state.Deployments = deployments
+		}
+	}
+	return state
+}
+
+// checkWSResourcesState runs the deploy-start check for a ws-resources.json
+// left inconsistent by a failed deploy. On issues it repairs the file when
+// repair is set, and otherwise asks on in whether to repair it, deploy as a
+// new site or abort; a dry run only lists the issues. It returns whether the
+// deploy must create a new site. With forceNew an untrusted object_id does
+// not matter, since it is ignored.
+func checkWSResourcesState(publishDir string, state deployment.SiteState, forceNew, repair, dryRun bool, in io.Reader, out io.Writer) (bool, error) {
+	icons := ui.GetIcons()
+
+	report, err := deployment.CheckWSResourcesState(publishDir, state)
+	if err != nil {
+		return forceNew, err
+	}
+	var issues []deployment.StateIssue
+	for _, issue := range report.Issues {
+		if forceNew && issue.Kind != deployment.StateMissingRouteTarget {
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	if len(issues) == 0 {
+		return forceNew, nil
+	}
+	report.Issues = issues
+
+	fmt.Fprintf(out, "%s ws-resources.json looks left over from a failed deploy:\n", icons.Warning)
+	for _, issue := range issues {
+		fmt.Fprintf(out, "   - %s\n", issue.Detail)
+	}
+	if dryRun {
+		fmt.Fprintf(out, "%s Run with --repair to fix it or --force-new to deploy a new site\n", icons.Lightbulb)
+		return forceNew, nil
+	}
+
+	trusted := state.TrustedObjectID()
+	if !repair {
+		choices := "[r]epair"
+		if report.HasObjectIDIssue() {
+			choices += ", deploy as a [n]ew site"
+		}
+		fmt.Fprintf(out, "%s %s or [a]bort? [a]: ", icons.Info, choices)
+
+		answer, err := readLine(bufio.NewReader(in))
+		if err != nil {
+			fmt.Fprintln(out)
+			return forceNew, fmt.Errorf("inconsistent ws-resources.json; re-run with --repair to fix it or --force-new to deploy a new site")
+		}
+		switch strings.ToLower(answer) {
+		case "r", "repair":
+		case "n", "new":
+			if !report.HasObjectIDIssue() {
+				return forceNew, fmt.Errorf("deployment cancelled")
+			}
+			trusted, forceNew = "", true
+		default:
+			return forceNew, fmt.Errorf("deployment cancelled")
+		}
+	}
+
+	if err := deployment.RepairWSResourcesState(publishDir, report, trusted); err != nil {
+		return forceNew, err
+	}
+	if report.HasObjectIDIssue() {
+		if trusted == "" {
+			fmt.Fprintf(out, "  %s Removed object_id; this deploy creates a new site\n", icons.Check)
+		} else {
+			fmt.Fprintf(out, "  %s Reset object_id to %s\n", icons.Check, trusted)
+		}
+	}
+	if n := len(issues) - countObjectIDIssues(issues); n > 0 {
+		fmt.Fprintf(out, "  %s Removed %d route(s) to missing files\n", icons.Check, n)
+	}
+	return forceNew, nil
+}
+
+// countObjectIDIssues counts the issues about object_id.
+func countObjectIDIssues(issues []deployment.StateIssue) int {
+	n := 0
+	for _, issue := range issues {
+		if issue.Kind != deployment.StateMissingRouteTarget {
+			n++
+		}
+	}
+	return n
+}
+
+// syncConfigObjectID reconciles a walgo.yaml without a projectID with the
+// object_id ws-resources.json already has, so both name the same site. With
+// sync it writes the ID straight away; otherwise it asks on in when running
+// interactively and just suggests --sync-config when not (quiet stays
+// silent). A dry run never writes. Declining or failing to write does not
+// stop the deploy.
+func syncConfigObjectID(sitePath, publishDir string, walgoCfg *config.WalgoConfig, sync, dryRun, quiet bool, in io.Reader, out io.Writer) {
+	objectID := deployment.UnsyncedObjectID(publishDir, walgoCfg)
+	if objectID == "" || (quiet && (!sync || dryRun)) {
+		return
+	}
+	icons := ui.GetIcons()
+
+	if !sync {
+		fmt.Fprintf(out, "%s walgo.yaml has no projectID, but ws-resources.json has object_id %s\n", icons.Info, objectID)
+		if dryRun || ui.IsCI() || !stdoutIsTerminal() {
+			fmt.Fprintf(out, "%s Run with --sync-config to write it into walgo.yaml\n", icons.Lightbulb)
+			return
+		}
+		fmt.Fprintf(out, "%s Write it into walgo.yaml? [y/N]: ", icons.Info)
+		answer, err := readLine(bufio.NewReader(in))
+		if err != nil {
+			fmt.Fprintln(out)
+			return
+		}
+		if answer = strings.ToLower(answer); answer != "y" && answer != "yes" {
+			return
+		}
+	} else if dryRun {
+		fmt.Fprintf(out, "%s Would write object_id %s from ws-resources.json into walgo.yaml\n", icons.Info, objectID)
+		return
+	}
+
+	if err := deployment.SyncConfigObjectID(sitePath, walgoCfg, objectID); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Warning: Failed to update walgo.yaml: %v\n", icons.Warning, err)
+		return
+	}
+	if !quiet {
+		fmt.Fprintf(out, "  %s Set walgo.yaml projectID to %s\n", icons.Check, objectID)
+	}
+}
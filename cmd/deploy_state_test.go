@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployment"
+)
+
+// writeStaleWSResources writes a publish directory whose ws-resources.json
+// has an object_id walgo.yaml does not know and a route to a missing page.
+func writeStaleWSResources(t *testing.T) string {
+	t.Helper()
+	publishDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(publishDir, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ws := `{"routes": {"/gone": "/gone/index.html"}, "object_id": "0xpartial"}`
+	if err := os.WriteFile(filepath.Join(publishDir, compress.WSResourcesFile), []byte(ws), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return publishDir
+}
+
+func readWSObjectID(t *testing.T, publishDir string) string {
+	t.Helper()
+	cfg, err := compress.ReadWSResourcesConfig(filepath.Join(publishDir, compress.WSResourcesFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg.ObjectID
+}
+
+func TestCheckWSResourcesStateCommand(t *testing.T) {
+	state := deployment.SiteState{ConfigObjectID: "0xold"}
+
+	tests := []struct {
+		name         string
+		forceNew     bool
+		repair       bool
+		dryRun       bool
+		input        string
+		wantErr      string
+		wantForceNew bool
+		wantObjectID string
+	}{
+		{name: "repair flag", repair: true, wantObjectID: "0xold"},
+		{name: "answer repair", input: "r\n", wantObjectID: "0xold"},
+		{name: "answer new site", input: "n\n", wantForceNew: true, wantObjectID: ""},
+		{name: "default aborts", input: "\n", wantErr: "cancelled", wantObjectID: "0xpartial"},
+		{name: "no terminal", input: "", wantErr: "--repair", wantObjectID: "0xpartial"},
+		{name: "dry run only reports", dryRun: true, wantObjectID: "0xpartial"},
+		{name: "force-new still fixes routes", forceNew: true, input: "r\n", wantForceNew: true, wantObjectID: "0xpartial"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publishDir := writeStaleWSResources(t)
+
+			var out bytes.Buffer
+			forceNew, err := checkWSResourcesState(publishDir, state, tt.forceNew, tt.repair, tt.dryRun, strings.NewReader(tt.input), &out)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("error = %v\n%s", err, out.String())
+			}
+			if forceNew != tt.wantForceNew {
+				t.Errorf("forceNew = %v, want %v", forceNew, tt.wantForceNew)
+			}
+			if got := readWSObjectID(t, publishDir); got != tt.wantObjectID {
+				t.Errorf("object_id = %q, want %q", got, tt.wantObjectID)
+			}
+			if !strings.Contains(out.String(), "/gone") {
+				t.Errorf("output = %q, want the stale route listed", out.String())
+			}
+		})
+	}
+}
+
+func TestCheckWSResourcesStateCommand_Consistent(t *testing.T) {
+	publishDir := writeStaleWSResources(t)
+	if err := os.MkdirAll(filepath.Join(publishDir, "gone"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(publishDir, "gone", "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	state := deployment.SiteState{ConfigObjectID: "0xpartial"}
+	if _, err := checkWSResourcesState(publishDir, state, false, false, false, strings.NewReader(""), &out); err != nil {
+		t.Fatalf("error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output = %q, want nothing for a consistent file", out.String())
+	}
+}
+
+func TestSyncConfigObjectID(t *testing.T) {
+	tests := []struct {
+		name     string
+		sync     bool
+		dryRun   bool
+		terminal bool
+		input    string
+		want     string
+	}{
+		{name: "sync flag", sync: true, want: "0xpartial"},
+		{name: "answer yes", terminal: true, input: "y\n", want: "0xpartial"},
+		{name: "default declines", terminal: true, input: "\n", want: "YOUR_WALRUS_PROJECT_ID"},
+		{name: "no terminal only suggests", input: "y\n", want: "YOUR_WALRUS_PROJECT_ID"},
+		{name: "dry run never writes", sync: true, dryRun: true, want: "YOUR_WALRUS_PROJECT_ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearCIEnv(t)
+			stubBrowser(t, tt.terminal)
+			publishDir := writeStaleWSResources(t)
+			sitePath := t.TempDir()
+			if err := os.WriteFile(filepath.Join(sitePath, "walgo.yaml"), []byte("walrus:\n  projectID: YOUR_WALRUS_PROJECT_ID\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := config.LoadConfigFrom(sitePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			syncConfigObjectID(sitePath, publishDir, cfg, tt.sync, tt.dryRun, false, strings.NewReader(tt.input), &out)
+
+			reloaded, err := config.LoadConfigFrom(sitePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reloaded.WalrusConfig.ProjectID != tt.want {
+				t.Errorf("walgo.yaml projectID = %q, want %q\n%s", reloaded.WalrusConfig.ProjectID, tt.want, out.String())
+			}
+			if tt.want == "0xpartial" && cfg.WalrusConfig.ProjectID != "0xpartial" {
+				t.Errorf("in-memory projectID = %q", cfg.WalrusConfig.ProjectID)
+			}
+			if !tt.sync && !tt.terminal && !strings.Contains(out.String(), "--sync-config") {
+				t.Errorf("output = %q, want a --sync-config hint", out.String())
+			}
+		})
+	}
+}
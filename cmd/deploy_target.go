@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// resolveTargetDir returns the publish directory for --target-dir. Relative
+// paths are taken from the site root, so "dist/siteA" deploys
+// <site>/dist/siteA as the root of its own Walrus Site. Like any publish
+// directory it must hold the site entrypoint, which PerformDeployment
+// checks.
+func resolveTargetDir(sitePath, targetDir string) (string, error) {
+	if targetDir == "" {
+		return "", fmt.Errorf("--target-dir must not be empty")
+	}
+	dir := filepath.Clean(targetDir)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(sitePath, dir)
+	}
+
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("target directory not found: %s", dir)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot read target directory: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("target %s is not a directory", dir)
+	}
+	return dir, nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveTargetDir(t *testing.T) {
+	sitePath := t.TempDir()
+	siteA := filepath.Join(sitePath, "dist", "siteA")
+	if err := os.MkdirAll(siteA, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(siteA, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		targetDir string
+	}{
+		{"relative to the site root", "dist/siteA"},
+		{"unclean relative path", "./dist/../dist/siteA/"},
+		{"absolute path", siteA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTargetDir(sitePath, filepath.FromSlash(tt.targetDir))
+			if err != nil {
+				t.Fatalf("resolveTargetDir(%q) error = %v", tt.targetDir, err)
+			}
+			if got != siteA {
+				t.Errorf("resolveTargetDir(%q) = %q, want %q", tt.targetDir, got, siteA)
+			}
+		})
+	}
+}
+
+func TestResolveTargetDirValidation(t *testing.T) {
+	sitePath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(sitePath, "dist"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sitePath, "dist", "file.html"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		targetDir string
+		wantErr   string
+	}{
+		{"empty", "", "must not be empty"},
+		{"missing directory", "dist/siteZ", "not found"},
+		{"file instead of directory", "dist/file.html", "not a directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveTargetDir(sitePath, filepath.FromSlash(tt.targetDir))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("resolveTargetDir(%q) error = %v, want %q", tt.targetDir, err, tt.wantErr)
+			}
+		})
+	}
+}
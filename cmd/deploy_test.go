@@ -69,6 +69,25 @@ func TestDeployCommandFlags(t *testing.T) {
 		{"description flag", "description", "", "", true},
 		{"image-url flag", "image-url", "", "", true},
 		{"force-new flag", "force-new", "", "false", true},
+		{"summary flag", "summary", "", "", true},
+		{"report-prometheus flag", "report-prometheus", "", "", true},
+		{"timing flag", "timing", "", "false", true},
+		{"canary flag", "canary", "", "false", true},
+		{"promote flag", "promote", "", "false", true},
+		{"auto-promote flag", "auto-promote", "", "false", true},
+		{"seo-strict flag", "seo-strict", "", "false", true},
+		{"duration flag", "duration", "", "", true},
+		{"max-epochs-cost flag", "max-epochs-cost", "", "0", true},
+		{"epochs-auto flag", "epochs-auto", "", "false", true},
+		{"target-dir flag", "target-dir", "", "", true},
+		{"verify-build-manifest flag", "verify-build-manifest", "", "false", true},
+		{"max-path-length flag", "max-path-length", "", "200", true},
+		{"sanitize flag", "sanitize", "", "false", true},
+		{"repair flag", "repair", "", "false", true},
+		{"verify flag", "verify", "", "false", true},
+		{"verify-url flag", "verify-url", "", "", true},
+		{"404 flag", "404", "", "", true},
+		{"skip-metadata flag", "skip-metadata", "", "false", true},
 	}
 
 	for _, tt := range flagTests {
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/sui"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// activeAddressDetails is replaced in tests.
+var activeAddressDetails = sui.GetActiveAddressDetails
+
+// printWalletPreflight shows which address is about to pay for a deploy.
+func printWalletPreflight(out io.Writer, info sui.WalletInfo) {
+	icons := ui.GetIcons()
+
+	address := info.Address
+	if info.Alias != "" {
+		address = fmt.Sprintf("%s (%s)", info.Address, info.Alias)
+	}
+	fmt.Fprintf(out, "%s Deploying from:\n", icons.Key)
+	fmt.Fprintf(out, "    Address:  %s\n", address)
+	fmt.Fprintf(out, "    Network:  %s\n", info.Network)
+	fmt.Fprintf(out, "    Balance:  %.4f SUI, %.4f WAL\n", info.SuiBalance, info.WalBalance)
+}
+
+// confirmMainnetSpend gates spending on mainnet: it passes on other networks
+// or when assumeYes is set, and otherwise asks for an explicit "y"/"yes".
+// Closed input (no terminal) is treated as a refusal.
+func confirmMainnetSpend(network string, assumeYes bool, in io.Reader, out io.Writer) error {
+	if !strings.EqualFold(strings.TrimSpace(network), "mainnet") || assumeYes {
+		return nil
+	}
+
+	icons := ui.GetIcons()
+	fmt.Fprintf(out, "%s This deploy spends real SUI and WAL on mainnet. Continue? [y/N]: ", icons.Warning)
+
+	answer, err := readLine(bufio.NewReader(in))
+	if err != nil {
+		fmt.Fprintln(out)
+		return fmt.Errorf("mainnet deploy needs confirmation; re-run with --yes to skip the prompt")
+	}
+	answer = strings.ToLower(answer)
+	if answer != "y" && answer != "yes" {
+		return fmt.Errorf("deployment cancelled")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/sui"
+)
+
+func TestConfirmMainnetSpend(t *testing.T) {
+	tests := []struct {
+		name       string
+		network    string
+		assumeYes  bool
+		input      string
+		wantErr    string
+		wantPrompt bool
+	}{
+		{name: "testnet never prompts", network: "testnet"},
+		{name: "devnet never prompts", network: "devnet", input: "n\n"},
+		{name: "mainnet with --yes", network: "mainnet", assumeYes: true},
+		{name: "mainnet confirmed", network: "mainnet", input: "y\n", wantPrompt: true},
+		{name: "mainnet confirmed with yes", network: "Mainnet", input: "YES\n", wantPrompt: true},
+		{name: "mainnet declined", network: "mainnet", input: "n\n", wantErr: "cancelled", wantPrompt: true},
+		{name: "mainnet default is no", network: "mainnet", input: "\n", wantErr: "cancelled", wantPrompt: true},
+		{name: "mainnet without input", network: "mainnet", input: "", wantErr: "--yes", wantPrompt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := confirmMainnetSpend(tt.network, tt.assumeYes, strings.NewReader(tt.input), &out)
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+			if prompted := strings.Contains(out.String(), "Continue?"); prompted != tt.wantPrompt {
+				t.Errorf("prompted = %v, want %v (output %q)", prompted, tt.wantPrompt, out.String())
+			}
+		})
+	}
+}
+
+func TestPrintWalletPreflight(t *testing.T) {
+	var out bytes.Buffer
+	printWalletPreflight(&out, sui.WalletInfo{
+		Address:    "0xabc",
+		Alias:      "deployer",
+		SuiBalance: 1.25,
+		WalBalance: 3,
+		Network:    "mainnet",
+	})
+	for _, want := range []string{"0xabc (deployer)", "mainnet", "1.2500 SUI, 3.0000 WAL"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
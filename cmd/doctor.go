@@ -11,6 +11,7 @@ import (
 	"github.com/selimozten/walgo/internal/deps"
 	"github.com/selimozten/walgo/internal/sui"
 	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/walrus"
 	"gopkg.in/yaml.v3"
 
 	"github.com/spf13/cobra"
@@ -40,12 +41,15 @@ This command checks:
 - Sui client configuration and active address
 - Wallet token balances (SUI, WAL, and others)
 - Configuration files
+- Network connectivity to Sui RPC and Walrus endpoints (with --network)
 - Provides auto-fix suggestions
 
 Examples:
   walgo doctor              # Run diagnostics
+  walgo doctor --network    # Also probe RPC, aggregator, and publisher
   walgo doctor --fix-paths  # Fix tilde paths in config
-  walgo doctor --fix-all    # Auto-fix all issues`,
+  walgo doctor --fix-all    # Auto-fix all issues
+  walgo doctor --json       # Machine-readable report; exits non-zero unless ok`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		icons := ui.GetIcons()
 		fixAll, err := cmd.Flags().GetBool("fix-all")
@@ -63,6 +67,16 @@ Examples:
 			fmt.Fprintf(os.Stderr, "%s Error: reading verbose flag: %v\n", icons.Error, err)
 			return fmt.Errorf("error getting verbose flag: %w", err)
 		}
+		checkNetwork, err := cmd.Flags().GetBool("network")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: reading network flag: %v\n", icons.Error, err)
+			return fmt.Errorf("error getting network flag: %w", err)
+		}
+
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			return writeDoctorJSON(os.Stdout, buildDoctorReport(doctorSystemHealth()))
+		}
 
 		fmt.Println("╔═══════════════════════════════════════════════════════════╗")
 		fmt.Println("║                     Walgo Doctor                          ║")
@@ -168,7 +182,12 @@ Examples:
 			address, _ := sui.GetActiveAddress()
 			if address != "" {
 				fmt.Printf("  %s Active address: %s\n", icons.Check, address)
-
+			}
+			if address != "" && onlineCheck(cmd.Context()) != nil {
+				fmt.Printf("  %s You appear to be offline; skipping the balance check\n", icons.Warning)
+				fmt.Printf("    %s\n", offlineHint)
+				warnings++
+			} else if address != "" {
 				// Check token balances (SUI and WAL)
 				balance, err := sui.GetBalance()
 
@@ -268,6 +287,18 @@ Examples:
 
 		fmt.Println()
 
+		if checkNetwork {
+			network, endpoints := doctorNetworkEndpoints()
+			fmt.Printf("%s Checking network connectivity (%s)...\n", icons.Globe, network)
+			fmt.Println()
+
+			netIssues, netWarnings := runNetworkDiagnostics(cmd.Context(), os.Stdout, endpoints, walrus.ProbeOptions{})
+			issues += netIssues
+			warnings += netWarnings
+
+			fmt.Println()
+		}
+
 		// Show deployment options
 		fmt.Printf("%s Deployment options:\n", icons.Rocket)
 		fmt.Println()
@@ -366,4 +397,6 @@ func init() {
 	doctorCmd.Flags().Bool("fix-paths", false, "Rewrite tildes in sites-config.yaml to absolute paths")
 	doctorCmd.Flags().Bool("fix-all", false, "Automatically fix all detected issues")
 	doctorCmd.Flags().BoolP("verbose", "v", false, "Show detailed output including versions and paths")
+	doctorCmd.Flags().Bool("network", false, "Probe Sui RPC and Walrus aggregator/publisher connectivity")
+	doctorCmd.Flags().Bool("json", false, "Print health, tool versions and fixes as JSON (exit code reflects \"ok\")")
 }
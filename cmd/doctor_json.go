@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/deps"
+	"github.com/selimozten/walgo/pkg/api"
+)
+
+// Hooks used by the JSON doctor report; replaced in tests.
+var (
+	doctorSystemHealth = api.GetSystemHealth
+	doctorLookPath     = deps.LookPath
+	doctorToolVersion  = func(tool string) string {
+		if tool == "hugo" {
+			return strings.TrimSpace(runQuiet(tool, "version"))
+		}
+		return strings.TrimSpace(runQuiet(tool, "--version"))
+	}
+)
+
+// doctorTools lists the tools the JSON report covers, in output order.
+var doctorTools = []struct {
+	name     string
+	required bool
+}{
+	{"hugo", true},
+	{"site-builder", false},
+	{"walrus", false},
+	{"sui", false},
+	{"suiup", false},
+}
+
+// doctorToolReport is one tool's entry in `walgo doctor --json`.
+type doctorToolReport struct {
+	Name      string `json:"name"`
+	Required  bool   `json:"required"`
+	Installed bool   `json:"installed"`
+	Version   string `json:"version,omitempty"`
+	Path      string `json:"path,omitempty"`
+	// Fix is the remediation for a missing or misconfigured tool.
+	Fix string `json:"fix,omitempty"`
+}
+
+// doctorReport is the machine-readable output of `walgo doctor --json`.
+// OK is false when a critical check fails: a required tool is missing (or
+// Hugo is not the Extended build), or sui is installed without an active
+// address.
+type doctorReport struct {
+	OK     bool               `json:"ok"`
+	Health api.SystemHealth   `json:"health"`
+	Tools  []doctorToolReport `json:"tools"`
+}
+
+// buildDoctorReport combines the system health with per-tool detection.
+func buildDoctorReport(health api.SystemHealth) doctorReport {
+	report := doctorReport{OK: true, Health: health}
+
+	for _, tool := range doctorTools {
+		entry := doctorToolReport{Name: tool.name, Required: tool.required}
+
+		path, err := doctorLookPath(tool.name)
+		if err != nil {
+			entry.Fix = installHint(tool.name)
+			if tool.name == "suiup" {
+				entry.Fix = "curl -sSfL https://raw.githubusercontent.com/MystenLabs/suiup/main/install.sh | sh"
+			}
+			if tool.required {
+				report.OK = false
+			}
+			report.Tools = append(report.Tools, entry)
+			continue
+		}
+
+		entry.Installed = true
+		entry.Path = path
+		entry.Version = firstLine(doctorToolVersion(tool.name))
+
+		switch tool.name {
+		case "hugo":
+			if !health.HugoInstalled {
+				entry.Fix = "Hugo Extended is required: " + installHint("hugo")
+				report.OK = false
+			}
+		case "sui":
+			if !health.SuiConfigured {
+				entry.Fix = "Run: sui client (to create an active address)"
+				report.OK = false
+			}
+		}
+		report.Tools = append(report.Tools, entry)
+	}
+
+	return report
+}
+
+// writeDoctorJSON prints the report and returns an error when it is not OK,
+// so the exit code reflects the result.
+func writeDoctorJSON(out io.Writer, report doctorReport) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("encoding doctor report: %w", err)
+	}
+	if !report.OK {
+		return fmt.Errorf("doctor found critical issues")
+	}
+	return nil
+}
+
+// firstLine returns the first line of s, trimmed.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/selimozten/walgo/pkg/api"
+)
+
+// stubDoctorTools makes the given tools look installed at /usr/bin/<tool>
+// with version "<tool> 1.0".
+func stubDoctorTools(t *testing.T, installed ...string) {
+	t.Helper()
+	origLook, origVersion := doctorLookPath, doctorToolVersion
+	t.Cleanup(func() { doctorLookPath, doctorToolVersion = origLook, origVersion })
+
+	present := make(map[string]bool)
+	for _, tool := range installed {
+		present[tool] = true
+	}
+	doctorLookPath = func(tool string) (string, error) {
+		if present[tool] {
+			return "/usr/bin/" + tool, nil
+		}
+		return "", fmt.Errorf("%s not found", tool)
+	}
+	doctorToolVersion = func(tool string) string { return tool + " 1.0\nextra build info" }
+}
+
+func decodeDoctorReport(t *testing.T, report doctorReport) (map[string]any, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	err := writeDoctorJSON(&buf, report)
+
+	var decoded map[string]any
+	if jsonErr := json.Unmarshal(buf.Bytes(), &decoded); jsonErr != nil {
+		t.Fatalf("invalid JSON: %v\n%s", jsonErr, buf.String())
+	}
+	return decoded, err
+}
+
+func TestDoctorJSON_ToolFields(t *testing.T) {
+	stubDoctorTools(t, "hugo", "sui", "walrus", "site-builder")
+	health := api.SystemHealth{HugoInstalled: true, SuiInstalled: true, SuiConfigured: true, NetOnline: true}
+
+	decoded, err := decodeDoctorReport(t, buildDoctorReport(health))
+	if err != nil {
+		t.Fatalf("healthy report should not error: %v", err)
+	}
+	if decoded["ok"] != true {
+		t.Errorf("ok = %v, want true", decoded["ok"])
+	}
+	if h, _ := decoded["health"].(map[string]any); h["hugoInstalled"] != true {
+		t.Errorf("health not embedded: %v", decoded["health"])
+	}
+
+	tools, _ := decoded["tools"].([]any)
+	if len(tools) != len(doctorTools) {
+		t.Fatalf("got %d tools, want %d", len(tools), len(doctorTools))
+	}
+	byName := make(map[string]map[string]any)
+	for _, raw := range tools {
+		tool := raw.(map[string]any)
+		byName[tool["name"].(string)] = tool
+	}
+
+	hugo := byName["hugo"]
+	if hugo["installed"] != true || hugo["version"] != "hugo 1.0" || hugo["required"] != true {
+		t.Errorf("hugo entry = %v", hugo)
+	}
+	if _, ok := hugo["fix"]; ok {
+		t.Errorf("healthy tool should have no fix: %v", hugo)
+	}
+
+	suiup := byName["suiup"]
+	if suiup["installed"] != false || suiup["fix"] == nil || suiup["fix"] == "" {
+		t.Errorf("missing tool should report a fix: %v", suiup)
+	}
+}
+
+func TestDoctorJSON_CriticalFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		installed []string
+		health    api.SystemHealth
+		wantOK    bool
+		wantFix   string
+	}{
+		{
+			name:      "missing optional tools are not critical",
+			installed: []string{"hugo"},
+			health:    api.SystemHealth{HugoInstalled: true},
+			wantOK:    true,
+		},
+		{
+			name:    "missing hugo",
+			health:  api.SystemHealth{},
+			wantOK:  false,
+			wantFix: "hugo",
+		},
+		{
+			name:      "hugo without extended",
+			installed: []string{"hugo"},
+			health:    api.SystemHealth{HugoInstalled: false},
+			wantOK:    false,
+			wantFix:   "hugo",
+		},
+		{
+			name:      "sui without active address",
+			installed: []string{"hugo", "sui"},
+			health:    api.SystemHealth{HugoInstalled: true, SuiInstalled: true},
+			wantOK:    false,
+			wantFix:   "sui",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubDoctorTools(t, tt.installed...)
+			report := buildDoctorReport(tt.health)
+
+			decoded, err := decodeDoctorReport(t, report)
+			if decoded["ok"] != tt.wantOK {
+				t.Errorf("ok = %v, want %v", decoded["ok"], tt.wantOK)
+			}
+			if (err == nil) != tt.wantOK {
+				t.Errorf("error = %v, want error only when not ok", err)
+			}
+			if tt.wantFix != "" {
+				for _, tool := range report.Tools {
+					if tool.Name == tt.wantFix && tool.Fix == "" {
+						t.Errorf("%s should carry a fix", tool.Name)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/netcheck"
+	"github.com/selimozten/walgo/internal/sui"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// onlineCheck probes the Sui RPC and Walrus endpoints of the current site;
+// replaced in tests.
+var onlineCheck = func(ctx context.Context) error {
+	_, endpoints := doctorNetworkEndpoints()
+	return netcheck.RequireOnline(ctx, endpoints)
+}
+
+// offlineHint is printed when a command stops because the machine is offline.
+const offlineHint = "Check your internet connection, VPN, or proxy settings."
+
+// ensureOnline fails fast when none of the site's Sui RPC and Walrus
+// endpoints can be reached, before a command runs CLI tools whose network
+// errors are hard to read.
+func ensureOnline(ctx context.Context) error {
+	if err := onlineCheck(ctx); err != nil {
+		icons := ui.GetIcons()
+		fmt.Fprintf(os.Stderr, "%s You appear to be offline. %s\n", icons.Error, offlineHint)
+		fmt.Fprintf(os.Stderr, "  If the endpoints are only blocked for this check, set %s=1 to skip it.\n", netcheck.SkipEnv)
+		return err
+	}
+	return nil
+}
+
+// doctorNetworkEndpoints returns the endpoints to probe for the current site,
+// using the network and gateway overrides from walgo.yaml when present and
+// falling back to the active Sui environment.
+func doctorNetworkEndpoints() (string, []walrus.Endpoint) {
+	var network string
+	var gw config.GatewayConfig
+
+	if cwd, err := os.Getwd(); err == nil {
+		if cfg, err := config.LoadConfigFrom(cwd); err == nil {
+			network = cfg.WalrusConfig.Network
+			gw = cfg.WalrusConfig.Gateway
+		}
+	}
+	if network == "" {
+		if env, err := sui.GetActiveEnv(); err == nil && env != "" {
+			network = env
+		}
+	}
+	if network == "" {
+		network = "testnet"
+	}
+
+	return network, walrus.NetworkEndpoints(network, gw)
+}
+
+// runNetworkDiagnostics probes each endpoint and prints reachability and
+// latency. Returns the number of issues and warnings found.
+func runNetworkDiagnostics(ctx context.Context, out io.Writer, endpoints []walrus.Endpoint, opts walrus.ProbeOptions) (issues, warnings int) {
+	icons := ui.GetIcons()
+
+	report := walrus.CheckConnectivity(ctx, endpoints, opts)
+
+	for _, res := range report.Results {
+		latency := res.Latency.Round(time.Millisecond)
+		switch {
+		case res.Reachable && res.Slow:
+			fmt.Fprintf(out, "  %s %s reachable but slow (%s)\n", icons.Warning, res.Name, latency)
+			fmt.Fprintf(out, "    URL: %s\n", res.URL)
+			warnings++
+		case res.Reachable:
+			fmt.Fprintf(out, "  %s %s reachable (%s)\n", icons.Check, res.Name, latency)
+			fmt.Fprintf(out, "    URL: %s\n", res.URL)
+		default:
+			fmt.Fprintf(out, "  %s %s unreachable\n", icons.Cross, res.Name)
+			fmt.Fprintf(out, "    URL: %s\n", res.URL)
+			fmt.Fprintf(out, "    Error: %v\n", res.Err)
+			issues++
+		}
+	}
+
+	fmt.Fprintln(out)
+
+	down := report.Unreachable()
+	switch {
+	case report.Offline():
+		fmt.Fprintf(out, "  %s You appear to be offline: no endpoint could be reached.\n", icons.Error)
+		fmt.Fprintf(out, "    %s\n", offlineHint)
+	case len(down) > 0:
+		for _, res := range down {
+			fmt.Fprintf(out, "  %s %s is down or rejecting requests: %s\n", icons.Error, res.Name, res.URL)
+		}
+		fmt.Fprintln(out, "    Your connection works; try again later or set walrus.gateway in walgo.yaml to use another endpoint.")
+	}
+
+	return issues, warnings
+}
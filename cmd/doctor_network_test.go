@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/netcheck"
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+func TestRunNetworkDiagnostics(t *testing.T) {
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"4c78adac"}`))
+	}))
+	defer rpc.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(150 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	opts := walrus.ProbeOptions{Timeout: time.Second, SlowThreshold: 100 * time.Millisecond}
+
+	t.Run("endpoint down", func(t *testing.T) {
+		var out bytes.Buffer
+		endpoints := []walrus.Endpoint{
+			{Name: "Sui RPC", Kind: walrus.EndpointSuiRPC, URL: rpc.URL},
+			{Name: "Walrus aggregator", Kind: walrus.EndpointAggregator, URL: slow.URL},
+			{Name: "Walrus publisher", Kind: walrus.EndpointPublisher, URL: downURL},
+		}
+
+		issues, warnings := runNetworkDiagnostics(context.Background(), &out, endpoints, opts)
+		if issues != 1 || warnings != 1 {
+			t.Errorf("issues/warnings = %d/%d, want 1/1", issues, warnings)
+		}
+
+		output := out.String()
+		for _, want := range []string{
+			"Sui RPC reachable",
+			"Walrus aggregator reachable but slow",
+			"Walrus publisher unreachable",
+			"Walrus publisher is down",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output missing %q:\n%s", want, output)
+			}
+		}
+		if strings.Contains(output, "offline") {
+			t.Errorf("output should not report offline:\n%s", output)
+		}
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		var out bytes.Buffer
+		endpoints := []walrus.Endpoint{
+			{Name: "Sui RPC", Kind: walrus.EndpointSuiRPC, URL: downURL},
+			{Name: "Walrus aggregator", Kind: walrus.EndpointAggregator, URL: downURL},
+		}
+
+		issues, _ := runNetworkDiagnostics(context.Background(), &out, endpoints, opts)
+		if issues != 2 {
+			t.Errorf("issues = %d, want 2", issues)
+		}
+		if !strings.Contains(out.String(), "You appear to be offline") {
+			t.Errorf("output missing offline message:\n%s", out.String())
+		}
+	})
+}
+
+// stubOnlineCheck makes ensureOnline report err for one test.
+func stubOnlineCheck(t *testing.T, err error) {
+	t.Helper()
+	old := onlineCheck
+	onlineCheck = func(context.Context) error { return err }
+	t.Cleanup(func() { onlineCheck = old })
+}
+
+func TestEnsureOnline(t *testing.T) {
+	stubOnlineCheck(t, nil)
+	if err := ensureOnline(context.Background()); err != nil {
+		t.Errorf("ensureOnline() online error = %v", err)
+	}
+
+	stubOnlineCheck(t, netcheck.ErrOffline)
+	if err := ensureOnline(context.Background()); !errors.Is(err, netcheck.ErrOffline) {
+		t.Errorf("ensureOnline() offline error = %v, want ErrOffline", err)
+	}
+}
+
+func TestOnlineCheckProbesSiteEndpoints(t *testing.T) {
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"4c78adac"}`))
+	}))
+	defer rpc.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	tempDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(originalWd) }()
+
+	writeGateway := func(rpcURL string) {
+		t.Helper()
+		content := fmt.Sprintf("walrus:\n  network: testnet\n  gateway:\n    suiRPCURL: %s\n    aggregatorURL: %s\n    publisherURL: %s\n", rpcURL, downURL, downURL)
+		if err := os.WriteFile("walgo.yaml", []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeGateway(rpc.URL)
+	if err := onlineCheck(context.Background()); err != nil {
+		t.Errorf("onlineCheck() with the configured RPC up error = %v", err)
+	}
+
+	writeGateway(downURL)
+	if err := onlineCheck(context.Background()); !errors.Is(err, netcheck.ErrOffline) {
+		t.Errorf("onlineCheck() with every endpoint down error = %v, want ErrOffline", err)
+	}
+}
+
+func TestCommandsFailFastOffline(t *testing.T) {
+	stubOnlineCheck(t, fmt.Errorf("%w: probe failed", netcheck.ErrOffline))
+
+	tempDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(originalWd) }()
+
+	configContent := `
+walrus:
+  network: testnet
+hugo:
+  publishDir: public
+`
+	if err := os.WriteFile("walgo.yaml", []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tempDir, "public"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"deploy", "--dry-run", "--skip-version-check"},
+		{"status", "0x1234567890abcdef1234567890abcdef12345678"},
+	} {
+		t.Run(args[0], func(t *testing.T) {
+			_, err := executeCommand(rootCmd, args...)
+			if !errors.Is(err, netcheck.ErrOffline) {
+				t.Errorf("%v error = %v, want ErrOffline", args, err)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ func TestDoctorCommand(t *testing.T) {
 				"environment",
 				"--fix-paths",
 				"--verbose",
+				"--network",
 			},
 		},
 		{
@@ -59,7 +59,7 @@ For Mainnet: https://suins.io`,
 			return nil
 		}
 
-		if cfg.WalrusConfig.ProjectID == "" || cfg.WalrusConfig.ProjectID == "YOUR_WALRUS_PROJECT_ID" {
+		if config.IsPlaceholderProjectID(cfg.WalrusConfig.ProjectID) {
 			fmt.Fprintf(os.Stderr, "%s Error: Walrus ProjectID is not set in walgo.yaml\n", icons.Error)
 			fmt.Fprintf(os.Stderr, "\n%s Deploy your site first to get an object ID:\n", icons.Lightbulb)
 			fmt.Fprintf(os.Stderr, "   walgo launch\n")
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -228,10 +227,13 @@ Example:
 		}
 
 		// Perform deployment using common function
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		ctx, cancel := newDeployContext(30 * time.Minute)
 		defer cancel()
 
 		result, err := deployment.PerformDeployment(ctx, opts)
+		if isInterrupted(ctx, err) {
+			return fmt.Errorf("deployment interrupted: %w", err)
+		}
 		if err != nil {
 			return fmt.Errorf("deployment failed: %w", err)
 		}
@@ -44,24 +44,28 @@ func resolveProject(cmd *cobra.Command, args []string) (*projects.Project, error
 
 	// Priority 3: positional argument (backward compatibility)
 	if len(args) > 0 {
-		nameOrID := args[0]
-		// Try parsing as ID first
-		if id, err := strconv.ParseInt(nameOrID, 10, 64); err == nil {
-			proj, err := pm.GetProject(id)
-			if err != nil {
-				return nil, fmt.Errorf("project with ID %d not found: %w", id, err)
-			}
-			return proj, nil
-		}
-		// Try as name
-		proj, err := pm.GetProjectByName(nameOrID)
+		return lookupProject(pm, args[0])
+	}
+
+	return nil, fmt.Errorf("please specify a project using --name, --id, or as a positional argument")
+}
+
+// lookupProject finds a project by numeric ID or, failing that, by name.
+func lookupProject(pm *projects.Manager, nameOrID string) (*projects.Project, error) {
+	// Try parsing as ID first
+	if id, err := strconv.ParseInt(nameOrID, 10, 64); err == nil {
+		proj, err := pm.GetProject(id)
 		if err != nil {
-			return nil, fmt.Errorf("project '%s' not found: %w", nameOrID, err)
+			return nil, fmt.Errorf("project with ID %d not found: %w", id, err)
 		}
 		return proj, nil
 	}
-
-	return nil, fmt.Errorf("please specify a project using --name, --id, or as a positional argument")
+	// Try as name
+	proj, err := pm.GetProjectByName(nameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("project '%s' not found: %w", nameOrID, err)
+	}
+	return proj, nil
 }
 
 // addProjectIdentifierFlags adds --name and --id flags to a command
@@ -77,9 +81,17 @@ var projectsCmd = &cobra.Command{
 
 The projects command shows all your deployed sites and allows you to:
   • View project history and statistics
+  • See what each deployment actually cost
   • Edit project metadata locally (name, category, description, etc.)
   • Update the site on Walrus (push changes on-chain)
-  • Archive or delete projects
+  • Clone a project as the starting point for a new site
+  • Tag projects with freeform labels
+  • Keep freeform notes on projects
+  • Compare two projects and their latest deployments
+  • Discover sites you own on-chain that are not tracked yet
+  • Record the SuiNS domain linked to a site
+  • Watch for expiring storage and renew it automatically
+  • Archive or delete projects, and restore them later
 
 Project Identification:
   You can identify projects using:
@@ -95,10 +107,21 @@ Examples:
   walgo projects                              # List all projects (default)
   walgo projects list                         # List all projects
   walgo projects list --network mainnet       # Filter by network
+  walgo projects list --tag client-acme       # Filter by tag
   walgo projects show --name="My Site"        # Show project with spaces in name
   walgo projects show --id=5                  # Show project by ID
   walgo projects show mysite                  # Show project (legacy syntax)
   walgo projects edit --id=5 --description="New description"
+  walgo projects clone 5 --name="My Other Site"
+  walgo projects tag 5 client-acme archive-2024
+  walgo projects annotate 5 cadence="weekly updates"
+  walgo projects diff 5 7
+  walgo projects cost 5
+  walgo projects discover
+  walgo projects import-from-git
+  walgo projects set-suins 5 myblog.sui
+  walgo projects watch-expiry --auto-renew
+  walgo projects restore 5
   walgo projects update --name="My Site" --epochs 10`,
 }
 
@@ -109,8 +132,11 @@ var projectsListCmd = &cobra.Command{
 		icons := ui.GetIcons()
 		network, _ := cmd.Flags().GetString("network")
 		status, _ := cmd.Flags().GetString("status")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		deleted, _ := cmd.Flags().GetBool("deleted")
 
-		if err := listProjects(network, status); err != nil {
+		filter := projects.ProjectFilter{Network: network, Status: status, Tags: tags, Deleted: deleted}
+		if err := listProjects(filter); err != nil {
 			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
 			return fmt.Errorf("failed to list projects: %w", err)
 		}
@@ -191,15 +217,24 @@ var projectsDeleteCmd = &cobra.Command{
 	Short: "Delete a project",
 	Long: `Delete a project from Walrus blockchain and local database.
 
+The local record is kept for 30 days and can be brought back with
+'walgo projects restore'. 'walgo projects prune' removes it for good
+afterwards. The site folder is not deleted.
+
 Project Identification:
   --id=<number>     Project ID (unambiguous)
   --name="<name>"   Project name (supports spaces)
   <name|id>         Positional argument (legacy, no spaces)
 
+A site that a SuiNS domain still points at (the project's SuiNS domain,
+walrus.suinsDomain in its walgo.yaml, or a name found on-chain) is not
+destroyed unless --force is given, since the domain would stop working.
+
 Examples:
   walgo projects delete --name="My Site"    # Delete by name with spaces
   walgo projects delete --id=5              # Delete by ID
-  walgo projects delete mysite              # Legacy syntax`,
+  walgo projects delete mysite              # Legacy syntax
+  walgo projects delete --id=5 --force      # Delete even if a SuiNS domain is linked`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		icons := ui.GetIcons()
@@ -208,7 +243,8 @@ Examples:
 			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
 			return err
 		}
-		if err := deleteProjectByRef(proj); err != nil {
+		force, _ := cmd.Flags().GetBool("force")
+		if err := deleteProjectByRef(proj, force); err != nil {
 			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
 			return fmt.Errorf("failed to delete project: %w", err)
 		}
@@ -256,6 +292,7 @@ Then push to Walrus:
 		description, _ := cmd.Flags().GetString("description")
 		imageURL, _ := cmd.Flags().GetString("image-url")
 		suins, _ := cmd.Flags().GetString("suins")
+		allowCustomCategory, _ := cmd.Flags().GetBool("allow-custom-category")
 
 		opts := editProjectOptions{
 			Name:        newName,
@@ -263,6 +300,8 @@ Then push to Walrus:
 			Description: description,
 			ImageURL:    imageURL,
 			SuiNS:       suins,
+
+			AllowCustomCategory: allowCustomCategory,
 		}
 
 		if err := editProjectByRef(proj, opts); err != nil {
@@ -274,6 +313,153 @@ Then push to Walrus:
 	},
 }
 
+var projectsCloneCmd = &cobra.Command{
+	Use:   "clone <name|id>",
+	Short: "Duplicate a project for a new deployment",
+	Long: `Create a new project from an existing one.
+
+The clone copies the project's category, network, epochs, description, and
+image URL, and scaffolds a new site directory seeded from the source site's
+content and configuration. Build output, caches, and the site object ID are
+not copied, so the clone deploys as a brand new site.
+
+Examples:
+  walgo projects clone 5 --name="My Other Site"
+  walgo projects clone mysite --name=mysite-eu --dir ~/sites/mysite-eu`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		name, _ := cmd.Flags().GetString("name")
+		dir, _ := cmd.Flags().GetString("dir")
+
+		if name == "" {
+			return fmt.Errorf("--name is required")
+		}
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		source, err := lookupProject(pm, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+
+		if _, err := cloneProjectByRef(pm, source, name, dir); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("failed to clone project: %w", err)
+		}
+
+		return nil
+	},
+}
+
+var projectsTagCmd = &cobra.Command{
+	Use:   "tag [name|id] <tag>...",
+	Short: "Add or remove project tags",
+	Long: `Label a project with freeform tags such as "client-acme" or "archive-2024".
+
+Tags are lowercased and may contain letters, digits, '.', '_' and '-'.
+Adding a tag the project already has is a no-op. Filter listings with
+'walgo projects list --tag <tag>'.
+
+Project Identification:
+  --id=<number>     Project ID (all arguments are then tags)
+  --name="<name>"   Project name (all arguments are then tags)
+  <name|id>         First positional argument
+
+Examples:
+  walgo projects tag mysite client-acme
+  walgo projects tag --id=5 client-acme archive-2024
+  walgo projects tag --name="My Site" --remove archive-2024`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		remove, _ := cmd.Flags().GetBool("remove")
+
+		projectArgs, tags := args[:1], args[1:]
+		if cmd.Flags().Changed("id") || cmd.Flags().Changed("name") {
+			projectArgs, tags = nil, args
+		}
+		if len(tags) == 0 {
+			return fmt.Errorf("please specify at least one tag")
+		}
+
+		proj, err := resolveProject(cmd, projectArgs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		if err := tagProjectByRef(proj, tags, remove); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("failed to tag project: %w", err)
+		}
+
+		return nil
+	},
+}
+
+var projectsAnnotateCmd = &cobra.Command{
+	Use:   "annotate [name|id] [key=value]...",
+	Short: "Attach notes to a project",
+	Long: `Keep operational notes on a project as key=value pairs, such as
+cadence="client prefers weekly updates". Setting a key again replaces its
+value. With no pairs, the project's notes are listed.
+
+Keys may contain letters, digits, '.', '_' and '-'. Notes are stored in the
+local project database only; they are never deployed.
+
+Project Identification:
+  --id=<number>     Project ID (all arguments are then notes)
+  --name="<name>"   Project name (all arguments are then notes)
+  <name|id>         First positional argument
+
+Examples:
+  walgo projects annotate 5 cadence="client prefers weekly updates"
+  walgo projects annotate --name="My Site" contact=ops@example.com
+  walgo projects annotate 5 --remove cadence
+  walgo projects annotate 5`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		remove, _ := cmd.Flags().GetBool("remove")
+
+		var projectArgs, notes []string
+		if cmd.Flags().Changed("id") || cmd.Flags().Changed("name") {
+			notes = args
+		} else if len(args) > 0 {
+			projectArgs, notes = args[:1], args[1:]
+		}
+		if remove && len(notes) == 0 {
+			return fmt.Errorf("please specify at least one key to remove")
+		}
+		pairs, err := parseAnnotations(notes, remove)
+		if err != nil {
+			return err
+		}
+
+		proj, err := resolveProject(cmd, projectArgs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		if err := annotateProject(pm, proj, pairs, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("failed to annotate project: %w", err)
+		}
+		return nil
+	},
+}
+
 var projectsArchiveCmd = &cobra.Command{
 	Use:   "archive [name|id]",
 	Short: "Archive a project",
@@ -305,6 +491,145 @@ Examples:
 	},
 }
 
+var projectsRestoreCmd = &cobra.Command{
+	Use:   "restore [name|id]",
+	Short: "Restore a deleted or archived project",
+	Long: `Restore a project removed with 'walgo projects delete' or archived with
+'walgo projects archive'.
+
+Deleted projects can be restored for 30 days; after that 'walgo projects
+prune' removes them for good. Restoring does not bring back a site that was
+destroyed on-chain.
+
+Project Identification:
+  --id=<number>     Project ID (unambiguous)
+  --name="<name>"   Project name (supports spaces)
+  <name|id>         Positional argument (legacy, no spaces)
+
+Examples:
+  walgo projects restore 5                  # Restore by ID
+  walgo projects restore --name="My Site"   # Restore by name
+  walgo projects list --deleted             # Find deleted projects`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		proj, err := resolveRestorableProject(cmd, args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		if err := restoreProject(pm, proj, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("failed to restore project: %w", err)
+		}
+
+		return nil
+	},
+}
+
+var projectsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Permanently remove projects deleted more than 30 days ago",
+	Long: `Permanently remove deleted projects whose 30-day recovery window has
+passed, along with their deployment history. Site folders are left in place.
+
+Examples:
+  walgo projects prune`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		if err := pruneDeletedProjects(pm, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("failed to prune projects: %w", err)
+		}
+
+		return nil
+	},
+}
+
+var projectsDiffCmd = &cobra.Command{
+	Use:   "diff <name|id> <name|id>",
+	Short: "Compare two projects and their latest deployments",
+	Long: `Compare two projects field by field: network, epochs, metadata (key by
+key), tags, the size of the built site and their latest deployments (epochs,
+gas fee, deployed file count, ...). Only fields that differ are printed.
+
+Examples:
+  walgo projects diff 3 7                  # Compare projects by ID
+  walgo projects diff my-site my-fork      # Compare projects by name
+  walgo projects diff 3 7 --json           # Machine-readable output`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		a, err := lookupProject(pm, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		b, err := lookupProject(pm, args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+
+		if err := diffProjects(pm, a, b, asJSON, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("failed to compare projects: %w", err)
+		}
+		return nil
+	},
+}
+
+var projectsExportSiteConfigCmd = &cobra.Command{
+	Use:   "export-site-config",
+	Short: "Regenerate site-builder's sites-config.yaml",
+	Long: `Write a fresh sites-config.yaml for site-builder from walgo's known network
+defaults (package ID, RPC URL and portal). Use it after a reinstall wipes
+~/.config/walrus/sites-config.yaml.
+
+When run inside a walgo site, the network defaults to walrus.network and
+walrus.gateway.suiRPCURL replaces the default RPC URL. An existing file is
+backed up next to it before it is overwritten.
+
+Examples:
+  walgo projects export-site-config
+  walgo projects export-site-config --network mainnet
+  walgo projects export-site-config --output ./sites-config.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		network, _ := cmd.Flags().GetString("network")
+		output, _ := cmd.Flags().GetString("output")
+
+		if err := exportSiteConfig(network, output); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("failed to export sites-config: %w", err)
+		}
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(projectsCmd)
 
@@ -314,13 +639,29 @@ func init() {
 	projectsCmd.AddCommand(projectsEditCmd)
 	projectsCmd.AddCommand(projectsDeleteCmd)
 	projectsCmd.AddCommand(projectsArchiveCmd)
+	projectsCmd.AddCommand(projectsRestoreCmd)
+	projectsCmd.AddCommand(projectsPruneCmd)
+	projectsCmd.AddCommand(projectsCloneCmd)
+	projectsCmd.AddCommand(projectsTagCmd)
+	projectsCmd.AddCommand(projectsAnnotateCmd)
+	projectsCmd.AddCommand(projectsDiffCmd)
+	projectsCmd.AddCommand(projectsMergeCmd)
+	projectsCmd.AddCommand(projectsDiscoverCmd)
+	projectsCmd.AddCommand(projectsSetSuiNSCmd)
+	projectsCmd.AddCommand(projectsExportSiteConfigCmd)
+	projectsCmd.AddCommand(projectsWatchExpiryCmd)
+	projectsCmd.AddCommand(projectsImportFromGitCmd)
+	projectsCmd.AddCommand(projectsCostCmd)
 
 	projectsCmd.RunE = func(cmd *cobra.Command, args []string) error {
 		icons := ui.GetIcons()
 		network, _ := cmd.Flags().GetString("network")
 		status, _ := cmd.Flags().GetString("status")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		deleted, _ := cmd.Flags().GetBool("deleted")
 
-		if err := listProjects(network, status); err != nil {
+		filter := projects.ProjectFilter{Network: network, Status: status, Tags: tags, Deleted: deleted}
+		if err := listProjects(filter); err != nil {
 			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
 			return fmt.Errorf("failed to list projects: %w", err)
 		}
@@ -333,6 +674,10 @@ func init() {
 	projectsCmd.Flags().StringP("status", "s", "", "Filter by status (active/archived)")
 	projectsListCmd.Flags().StringP("network", "n", "", "Filter by network (testnet/mainnet)")
 	projectsListCmd.Flags().StringP("status", "s", "", "Filter by status (active/archived)")
+	projectsCmd.Flags().StringSlice("tag", nil, "Filter by tag (repeatable; projects must have every tag)")
+	projectsListCmd.Flags().StringSlice("tag", nil, "Filter by tag (repeatable; projects must have every tag)")
+	projectsCmd.Flags().Bool("deleted", false, "List deleted projects that can still be restored")
+	projectsListCmd.Flags().Bool("deleted", false, "List deleted projects that can still be restored")
 
 	// Add project identifier flags to all subcommands
 	addProjectIdentifierFlags(projectsShowCmd)
@@ -340,14 +685,60 @@ func init() {
 	addProjectIdentifierFlags(projectsDeleteCmd)
 	addProjectIdentifierFlags(projectsEditCmd)
 	addProjectIdentifierFlags(projectsArchiveCmd)
+	addProjectIdentifierFlags(projectsRestoreCmd)
+	addProjectIdentifierFlags(projectsTagCmd)
+	addProjectIdentifierFlags(projectsAnnotateCmd)
+	addProjectIdentifierFlags(projectsCostCmd)
+
+	// Tag command specific flags
+	projectsTagCmd.Flags().Bool("remove", false, "Remove the given tags instead of adding them")
+
+	// Annotate command specific flags
+	projectsAnnotateCmd.Flags().Bool("remove", false, "Remove the given keys instead of setting them")
 
 	// Update command specific flags
 	projectsUpdateCmd.Flags().IntP("epochs", "e", 0, "Number of epochs for storage duration")
 
+	// Delete command specific flags
+	projectsDeleteCmd.Flags().Bool("force", false, "Destroy the site even if a SuiNS domain points at it")
+
 	// Edit command specific flags
 	projectsEditCmd.Flags().String("new-name", "", "New project name (rename)")
 	projectsEditCmd.Flags().String("category", "", "New project category")
+	projectsEditCmd.Flags().Bool("allow-custom-category", false, "Accept a --category outside the known set")
 	projectsEditCmd.Flags().String("description", "", "New project description")
 	projectsEditCmd.Flags().String("image-url", "", "New image URL for the site")
 	projectsEditCmd.Flags().String("suins", "", "New SuiNS domain")
+
+	// Clone command specific flags
+	projectsCloneCmd.Flags().String("name", "", "Name for the new project (required)")
+	projectsCloneCmd.Flags().String("dir", "", "Directory for the new site (default: next to the source site)")
+
+	// Diff command specific flags
+	projectsDiffCmd.Flags().Bool("json", false, "Print the differences as JSON")
+
+	// Cost command specific flags
+	projectsCostCmd.Flags().Bool("json", false, "Print the costs as JSON")
+
+	// Discover command specific flags
+	projectsDiscoverCmd.Flags().Bool("attach", false, "Attach every orphaned site without prompting")
+	projectsDiscoverCmd.Flags().Bool("json", false, "Print the orphaned sites as JSON")
+
+	// Set-suins command specific flags
+	projectsSetSuiNSCmd.Flags().Bool("verify", false, "Check on-chain that the name points at the project's site object")
+
+	// Export-site-config command specific flags
+	projectsExportSiteConfigCmd.Flags().StringP("network", "n", "", "Network to configure (testnet or mainnet; default: walrus.network or testnet)")
+	projectsExportSiteConfigCmd.Flags().StringP("output", "o", "", "Where to write the config (default: ~/.config/walrus/sites-config.yaml)")
+
+	// Watch-expiry command specific flags
+	projectsWatchExpiryCmd.Flags().Duration("interval", defaultExpiryInterval, "Time between checks")
+	projectsWatchExpiryCmd.Flags().Duration("within", defaultExpiryThreshold, "Act on projects expiring within this long")
+	projectsWatchExpiryCmd.Flags().String("webhook", "", "POST a JSON event to this URL for each expiring, expired or renewed project")
+	projectsWatchExpiryCmd.Flags().Bool("auto-renew", false, "Extend expiring projects' storage with site-builder update --check-extend (spends WAL and SUI)")
+	projectsWatchExpiryCmd.Flags().IntP("epochs", "e", 0, "Epochs per renewal (default: each project's own)")
+
+	// Import-from-git command specific flags
+	projectsImportFromGitCmd.Flags().Int("depth", projects.DefaultImportDepth, "How many directory levels below the root to search")
+	projectsImportFromGitCmd.Flags().Bool("dry-run", false, "List the walgo sites found without importing them")
 }
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// parseAnnotations splits key=value arguments. With remove set the arguments
+// are bare keys, returned with empty values so SetMetadata deletes them.
+func parseAnnotations(args []string, remove bool) ([][2]string, error) {
+	pairs := make([][2]string, 0, len(args))
+	for _, arg := range args {
+		if remove {
+			pairs = append(pairs, [2]string{strings.TrimSpace(arg), ""})
+			continue
+		}
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid annotation %q: use key=value", arg)
+		}
+		if value == "" {
+			return nil, fmt.Errorf("annotation %q has no value; use --remove %s to delete it", arg, key)
+		}
+		pairs = append(pairs, [2]string{strings.TrimSpace(key), value})
+	}
+	return pairs, nil
+}
+
+// annotateProject applies pairs to the project's metadata, then prints what
+// it holds. With no pairs it only prints.
+func annotateProject(pm *projects.Manager, proj *projects.Project, pairs [][2]string, out io.Writer) error {
+	icons := ui.GetIcons()
+	for _, p := range pairs {
+		if err := pm.SetMetadata(proj.ID, p[0], p[1]); err != nil {
+			return err
+		}
+	}
+
+	metadata, err := pm.GetMetadata(proj.ID)
+	if err != nil {
+		return err
+	}
+	if len(pairs) > 0 {
+		fmt.Fprintf(out, "%s Updated %d note(s) on '%s'\n", icons.Check, len(pairs), proj.Name)
+	}
+	printMetadata(out, proj.Name, metadata)
+	return nil
+}
+
+// printMetadata lists metadata sorted by key.
+func printMetadata(out io.Writer, name string, metadata map[string]string) {
+	if len(metadata) == 0 {
+		fmt.Fprintf(out, "No notes on '%s'\n", name)
+		return
+	}
+	keys := make([]string, 0, len(metadata))
+	width := 0
+	for k := range metadata {
+		keys = append(keys, k)
+		width = max(width, len(k))
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(out, "Notes on '%s':\n", name)
+	for _, k := range keys {
+		fmt.Fprintf(out, "  %-*s  %s\n", width, k, metadata[k])
+	}
+}
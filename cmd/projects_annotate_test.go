@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/projects"
+)
+
+func TestParseAnnotations(t *testing.T) {
+	pairs, err := parseAnnotations([]string{"cadence=weekly updates", "url=https://x.io/?a=b"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairs[0] != [2]string{"cadence", "weekly updates"} || pairs[1] != [2]string{"url", "https://x.io/?a=b"} {
+		t.Errorf("pairs = %v", pairs)
+	}
+
+	for _, bad := range []string{"no-equals", "empty="} {
+		if _, err := parseAnnotations([]string{bad}, false); err == nil {
+			t.Errorf("parseAnnotations(%q) should fail", bad)
+		}
+	}
+
+	pairs, err = parseAnnotations([]string{"cadence"}, true)
+	if err != nil || pairs[0] != [2]string{"cadence", ""} {
+		t.Errorf("remove pairs = %v, %v", pairs, err)
+	}
+}
+
+func TestAnnotateProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	defer pm.Close()
+
+	proj := &projects.Project{Name: "client-site", Network: "testnet", ObjectID: "0xsite", SitePath: t.TempDir()}
+	if err := pm.CreateProject(proj); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := annotateProject(pm, proj, [][2]string{{"owner", "Sam"}, {"cadence", "weekly"}}, &out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 || strings.Fields(lines[2])[0] != "cadence" || strings.Fields(lines[3])[0] != "owner" {
+		t.Errorf("output not sorted by key:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := annotateProject(pm, proj, [][2]string{{"owner", ""}, {"cadence", ""}}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "No notes on 'client-site'") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -24,7 +24,7 @@ func archiveProjectByRef(proj *projects.Project) error {
 	fmt.Println()
 	fmt.Printf("%s Project '%s' archived\n", icons.Check, proj.Name)
 	fmt.Println()
-	fmt.Printf("%s To restore: walgo projects restore <name>\n", icons.Lightbulb)
+	fmt.Printf("%s To restore: walgo projects restore %d\n", icons.Lightbulb, proj.ID)
 	fmt.Println()
 
 	return nil
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/cache"
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/utils"
+)
+
+// cloneProjectByRef creates a new project from source and scaffolds its site
+// directory at dir. When dir is empty the site is created next to the source
+// site, named after the new project.
+func cloneProjectByRef(pm *projects.Manager, source *projects.Project, name, dir string) (*projects.Project, error) {
+	icons := ui.GetIcons()
+
+	exists, err := pm.ProjectNameExists(name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("project name '%s' already exists. Choose a different name", name)
+	}
+
+	if dir == "" {
+		parent := filepath.Dir(source.SitePath)
+		if source.SitePath == "" {
+			if parent, err = os.Getwd(); err != nil {
+				return nil, fmt.Errorf("cannot determine current directory: %w", err)
+			}
+		}
+		dir = filepath.Join(parent, utils.SanitizeSiteName(name))
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid directory: %w", err)
+	}
+
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("directory already exists: %s", dir)
+	}
+	if source.SitePath != "" {
+		if rel, err := filepath.Rel(source.SitePath, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("clone directory cannot be inside the source site: %s", dir)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%s Cloning project: %s → %s\n", icons.Package, source.Name, name)
+
+	seeded := false
+	if source.SitePath != "" {
+		if info, err := os.Stat(source.SitePath); err == nil && info.IsDir() {
+			if err := copySiteForClone(source.SitePath, dir); err != nil {
+				_ = os.RemoveAll(dir)
+				return nil, fmt.Errorf("failed to copy site: %w", err)
+			}
+			seeded = true
+		}
+	}
+	if !seeded {
+		fmt.Printf("  %s Source site not found at %s, creating an empty directory\n", icons.Warning, source.SitePath)
+		// #nosec G301 - site directory needs standard permissions
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create site directory: %w", err)
+		}
+	}
+
+	// Drop the source's site object so the clone deploys fresh
+	if _, err := os.Stat(filepath.Join(dir, config.DefaultConfigFileName)); err == nil {
+		if err := config.UpdateWalgoYAMLProjectID(dir, ""); err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+
+	clone, err := pm.CloneProject(source.ID, name, dir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	fmt.Printf("  %s Project created (ID: %d)\n", icons.Check, clone.ID)
+	fmt.Printf("  %s Site directory: %s\n", icons.Folder, dir)
+	fmt.Println()
+	fmt.Printf("%s Next steps:\n", icons.Lightbulb)
+	fmt.Printf("   cd %s\n", dir)
+	fmt.Println("   walgo build && walgo launch")
+	fmt.Println()
+
+	return clone, nil
+}
+
+// copySiteForClone copies a site directory, skipping build output, caches,
+// and version control metadata that belong to the source deployment.
+func copySiteForClone(src, dst string) error {
+	publishDir := "public"
+	if cfg, err := config.LoadConfigFrom(src); err == nil && cfg.HugoConfig.PublishDir != "" {
+		publishDir = cfg.HugoConfig.PublishDir
+	}
+
+	skip := map[string]bool{
+		filepath.Clean(publishDir):         true,
+		cache.CacheDir:                     true,
+		".git":                             true,
+		filepath.Join("resources", "_gen"): true,
+		".hugo_build.lock":                 true,
+	}
+
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if skip[relPath] {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		dstPath := filepath.Join(dst, relPath)
+		if info.IsDir() {
+			return os.MkdirAll(dstPath, info.Mode().Perm())
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		srcFile, err := os.Open(path) // #nosec G304 - path is within the source site directory
+		if err != nil {
+			return err
+		}
+		defer srcFile.Close()
+
+		dstFile, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm()) // #nosec G304 - path is within the new site directory
+		if err != nil {
+			return err
+		}
+		defer dstFile.Close()
+
+		_, err = io.Copy(dstFile, srcFile)
+		return err
+	})
+}
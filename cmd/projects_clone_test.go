@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/projects"
+)
+
+func TestCloneProjectByRef(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	sitesDir := t.TempDir()
+	sourceDir := filepath.Join(sitesDir, "microsite-a")
+	files := map[string]string{
+		"walgo.yaml":                "walrus:\n  projectID: \"0xsource\"\n  network: mainnet\n",
+		"hugo.toml":                 "title = 'Microsite A'\n",
+		"content/_index.md":         "---\ntitle: Home\n---\nHello\n",
+		"public/index.html":         "<html></html>",
+		"public/ws-resources.json":  `{"object_id":"0xsource"}`,
+		".walgo/cache.db":           "cache",
+		"resources/_gen/images/a":   "generated",
+		"static/images/logo.png":    "png",
+		"themes/mytheme/theme.toml": "name = 'mytheme'\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(sourceDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	defer pm.Close()
+
+	source := &projects.Project{
+		Name:        "microsite-a",
+		Category:    "portfolio",
+		Network:     "mainnet",
+		ObjectID:    "0xsource",
+		Epochs:      12,
+		SitePath:    sourceDir,
+		Description: "First microsite",
+		ImageURL:    "https://example.com/logo.png",
+	}
+	if err := pm.CreateProject(source); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := cloneProjectByRef(pm, source, "Microsite B", "")
+	if err != nil {
+		t.Fatalf("cloneProjectByRef failed: %v", err)
+	}
+
+	wantDir := filepath.Join(sitesDir, "microsite-b")
+	if clone.SitePath != wantDir {
+		t.Errorf("SitePath = %q, want %q", clone.SitePath, wantDir)
+	}
+	if clone.ID == source.ID {
+		t.Error("clone should have a new ID")
+	}
+	if clone.ObjectID != "" {
+		t.Errorf("ObjectID = %q, want empty", clone.ObjectID)
+	}
+	if clone.Category != "portfolio" || clone.Description != "First microsite" || clone.ImageURL != source.ImageURL {
+		t.Errorf("metadata not copied: %+v", clone)
+	}
+
+	for _, rel := range []string{"hugo.toml", "content/_index.md", "static/images/logo.png", "themes/mytheme/theme.toml"} {
+		if _, err := os.Stat(filepath.Join(wantDir, rel)); err != nil {
+			t.Errorf("expected %s to be copied: %v", rel, err)
+		}
+	}
+	for _, rel := range []string{"public", ".walgo", "resources/_gen"} {
+		if _, err := os.Stat(filepath.Join(wantDir, rel)); !os.IsNotExist(err) {
+			t.Errorf("expected %s not to be copied", rel)
+		}
+	}
+
+	cfg, err := config.LoadConfigFrom(wantDir)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom(clone) failed: %v", err)
+	}
+	if cfg.WalrusConfig.ProjectID != "" {
+		t.Errorf("clone walgo.yaml projectID = %q, want empty", cfg.WalrusConfig.ProjectID)
+	}
+	if cfg.WalrusConfig.Network != "mainnet" {
+		t.Errorf("clone walgo.yaml network = %q, want mainnet", cfg.WalrusConfig.Network)
+	}
+
+	t.Run("existing directory is rejected", func(t *testing.T) {
+		if _, err := cloneProjectByRef(pm, source, "Microsite C", wantDir); err == nil {
+			t.Error("Expected error when target directory exists")
+		}
+	})
+
+	t.Run("duplicate name is rejected", func(t *testing.T) {
+		_, err := cloneProjectByRef(pm, source, "Microsite B", filepath.Join(sitesDir, "other"))
+		if err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("Expected duplicate name error, got %v", err)
+		}
+	})
+
+	t.Run("directory inside source is rejected", func(t *testing.T) {
+		if _, err := cloneProjectByRef(pm, source, "Microsite D", filepath.Join(sourceDir, "nested")); err == nil {
+			t.Error("Expected error for clone directory inside source site")
+		}
+	})
+}
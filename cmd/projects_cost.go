@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var projectsCostCmd = &cobra.Command{
+	Use:   "cost [name|id]",
+	Short: "Show what a project's deployments cost",
+	Long: `List a project's successful deployments with the storage epochs and the
+WAL and SUI each one spent, followed by the totals.
+
+Costs reported by site-builder or read from the chain are marked "actual".
+Deployments recorded with only an estimate are marked "estimate", show the
+estimate, and are left out of the totals.
+
+Examples:
+  walgo projects cost my-site
+  walgo projects cost --id 3
+  walgo projects cost my-site --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		proj, err := resolveProject(cmd, args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		deployments, err := pm.GetProjectDeployments(proj.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("failed to get deployments: %w", err)
+		}
+
+		report := newProjectCostReport(proj, deployments)
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				return fmt.Errorf("encoding costs: %w", err)
+			}
+			return nil
+		}
+		printProjectCostReport(os.Stdout, report)
+		return nil
+	},
+}
+
+// projectCostReport is what walgo projects cost prints.
+type projectCostReport struct {
+	Project          string           `json:"project"`
+	Network          string           `json:"network"`
+	TotalWAL         float64          `json:"totalWal"`         // Sum of the actual WAL costs
+	TotalSUI         float64          `json:"totalSui"`         // Sum of the actual SUI costs
+	EstimatedDeploys int              `json:"estimatedDeploys"` // Deployments left out of the totals
+	Deployments      []deploymentCost `json:"deployments"`      // Newest first
+}
+
+// deploymentCost is one successful deployment of a projectCostReport.
+type deploymentCost struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Epochs    int       `json:"epochs"`
+	WAL       float64   `json:"wal"`
+	SUI       float64   `json:"sui"`
+	Actual    bool      `json:"actual"`
+	GasFee    string    `json:"gasFee"` // As recorded; the estimate when Actual is false
+}
+
+// newProjectCostReport totals the costs of proj's successful deployments.
+func newProjectCostReport(proj *projects.Project, deployments []*projects.DeploymentRecord) projectCostReport {
+	report := projectCostReport{Project: proj.Name, Network: proj.Network, Deployments: []deploymentCost{}}
+	for _, d := range deployments {
+		if !d.Success {
+			continue
+		}
+		report.Deployments = append(report.Deployments, deploymentCost{
+			CreatedAt: d.CreatedAt,
+			Epochs:    d.Epochs,
+			WAL:       d.CostWAL,
+			SUI:       d.CostSUI,
+			Actual:    d.CostActual,
+			GasFee:    d.GasFee,
+		})
+		if d.CostActual {
+			report.TotalWAL += d.CostWAL
+			report.TotalSUI += d.CostSUI
+		} else {
+			report.EstimatedDeploys++
+		}
+	}
+	return report
+}
+
+// printProjectCostReport lists the deployments of report, one per line, and
+// the totals.
+func printProjectCostReport(out io.Writer, report projectCostReport) {
+	icons := ui.GetIcons()
+
+	if len(report.Deployments) == 0 {
+		fmt.Fprintf(out, "%s %s has no successful deployments\n", icons.Info, report.Project)
+		return
+	}
+
+	fmt.Fprintf(out, "%s Deployment costs of %s (%s):\n\n", icons.Chart, report.Project, report.Network)
+	for _, d := range report.Deployments {
+		kind, cost := "actual  ", projects.FormatCost(d.WAL, d.SUI)
+		if !d.Actual {
+			kind, cost = "estimate", d.GasFee
+		}
+		if cost == "" {
+			cost = "-"
+		}
+		fmt.Fprintf(out, "  %s  %3d epochs  %s  %s\n", d.CreatedAt.Format("2006-01-02 15:04"), d.Epochs, kind, cost)
+	}
+
+	total := projects.FormatCost(report.TotalWAL, report.TotalSUI)
+	if total == "" {
+		total = "nothing recorded"
+	}
+	fmt.Fprintf(out, "\n%s Total spent: %s\n", icons.Info, total)
+	if report.EstimatedDeploys > 0 {
+		fmt.Fprintf(out, "  %s %d deployment(s) with only an estimate are not in the total\n", icons.Warning, report.EstimatedDeploys)
+	}
+}
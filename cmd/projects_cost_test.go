@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/projects"
+)
+
+func TestProjectCostReport(t *testing.T) {
+	proj := &projects.Project{Name: "blog", Network: "testnet"}
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	deployments := []*projects.DeploymentRecord{
+		{Success: true, Epochs: 5, CostWAL: 0.05, CostSUI: 0.004, CostActual: true, CreatedAt: now},
+		{Success: false, Epochs: 5, CreatedAt: now.Add(-time.Hour)},
+		{Success: true, Epochs: 3, GasFee: "~0.0300 WAL + ~0.0040 SUI", CreatedAt: now.Add(-48 * time.Hour)},
+		{Success: true, Epochs: 2, CostWAL: 0.02, CostSUI: 0.001, CostActual: true, CreatedAt: now.Add(-72 * time.Hour)},
+	}
+
+	report := newProjectCostReport(proj, deployments)
+	if len(report.Deployments) != 3 {
+		t.Fatalf("Deployments = %d, want 3 (failed deploys are left out)", len(report.Deployments))
+	}
+	if math.Abs(report.TotalWAL-0.07) > 1e-9 || math.Abs(report.TotalSUI-0.005) > 1e-9 {
+		t.Errorf("totals = %v WAL + %v SUI, want 0.07 WAL + 0.005 SUI", report.TotalWAL, report.TotalSUI)
+	}
+	if report.EstimatedDeploys != 1 {
+		t.Errorf("EstimatedDeploys = %d, want 1", report.EstimatedDeploys)
+	}
+
+	var out bytes.Buffer
+	printProjectCostReport(&out, report)
+	for _, want := range []string{
+		"0.050000 WAL + 0.004000 SUI",
+		"estimate  ~0.0300 WAL + ~0.0040 SUI",
+		"Total spent: 0.070000 WAL + 0.005000 SUI",
+		"1 deployment(s) with only an estimate",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestProjectCostReportNoDeployments(t *testing.T) {
+	var out bytes.Buffer
+	printProjectCostReport(&out, newProjectCostReport(&projects.Project{Name: "blog"}, nil))
+	if !strings.Contains(out.String(), "blog has no successful deployments") {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestProjectsCostCommand(t *testing.T) {
+	tests := []TestCase{
+		{
+			Name:        "Projects cost help",
+			Args:        []string{"projects", "cost", "--help"},
+			ExpectError: false,
+			Contains:    []string{"left out of the totals", "--json", "--id"},
+		},
+		{
+			Name:        "Projects cost takes at most one project",
+			Args:        []string{"projects", "cost", "1", "2"},
+			ExpectError: true,
+			Contains:    []string{"accepts at most 1 arg(s)"},
+		},
+	}
+
+	runTestCases(t, rootCmd, tests)
+}
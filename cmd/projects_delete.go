@@ -2,17 +2,22 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/selimozten/walgo/internal/deployer"
 	sb "github.com/selimozten/walgo/internal/deployer/sitebuilder"
 	"github.com/selimozten/walgo/internal/projects"
 	"github.com/selimozten/walgo/internal/ui"
 )
 
-// deleteProjectByRef removes a project from Walrus blockchain and local database.
-func deleteProjectByRef(proj *projects.Project) error {
+// deleteProjectByRef removes a project from Walrus blockchain and soft-deletes
+// its local record, which can be restored within projects.DefaultRecoveryWindow.
+// Unless force is set, a site that a SuiNS domain still points at is not destroyed.
+func deleteProjectByRef(proj *projects.Project, force bool) error {
 	icons := ui.GetIcons()
 	pm, err := projects.NewManager()
 	if err != nil {
@@ -25,11 +30,16 @@ func deleteProjectByRef(proj *projects.Project) error {
 	fmt.Println()
 	fmt.Println("This will:")
 	fmt.Println("  - Delete the site from Walrus blockchain (on-chain deletion)")
-	fmt.Println("  - Delete the project record from local database")
-	fmt.Println("  - Delete all deployment history")
-	fmt.Printf("  - Delete the site folder: %s\n", proj.SitePath)
+	fmt.Println("  - Move the project record and deployment history to the trash")
+	fmt.Printf("  - Keep the site folder at %s (it is not deleted, even by 'walgo projects prune')\n", proj.SitePath)
 	fmt.Println()
 	fmt.Printf("Object ID to destroy: %s\n", proj.ObjectID)
+	if domain := proj.LinkedSuiNSDomain(); domain != "" && proj.ObjectID != "" {
+		fmt.Printf("%s SuiNS domain %s points at this site and will stop working\n", icons.Warning, domain)
+		if !force {
+			return fmt.Errorf("site is linked to SuiNS domain %s; unlink it first or re-run with --force", domain)
+		}
+	}
 
 	destroyCost := projects.EstimateDestroyCost(proj.Network)
 	fmt.Printf("Estimated gas cost: %s\n", destroyCost)
@@ -66,11 +76,15 @@ func deleteProjectByRef(proj *projects.Project) error {
 		fmt.Println()
 		fmt.Printf("%s Step 1/2: Destroying site on Walrus blockchain...\n", icons.Garbage)
 
-		d := sb.New()
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 		defer cancel()
 
-		if err := d.Destroy(ctx, proj.ObjectID); err != nil {
+		if err := destroyProjectSite(ctx, sb.New(), pm, proj, force); err != nil {
+			var linked *deployer.LinkedDomainError
+			if errors.As(err, &linked) {
+				fmt.Printf("\n%s %v\n", icons.Error, err)
+				return err
+			}
 			fmt.Printf("\n%s Warning: Failed to destroy site on-chain: %v\n", icons.Warning, err)
 			fmt.Println()
 			fmt.Print("Continue with local deletion anyway? [y/N]: ")
@@ -92,15 +106,36 @@ func deleteProjectByRef(proj *projects.Project) error {
 	}
 
 	fmt.Println()
-	fmt.Printf("%s Step 2/2: Deleting local project record and site folder...\n", icons.Garbage)
+	fmt.Printf("%s Step 2/2: Deleting local project record...\n", icons.Garbage)
 
-	if err := pm.DeleteProject(proj.ID); err != nil {
+	if err := pm.SoftDeleteProject(proj.ID); err != nil {
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
 
 	fmt.Println()
-	fmt.Printf("%s Project deleted successfully (including site folder)\n", icons.Check)
+	fmt.Printf("%s Project deleted successfully\n", icons.Check)
+	fmt.Println()
+	fmt.Printf("%s To restore within %d days: walgo projects restore %d\n", icons.Lightbulb, int(projects.DefaultRecoveryWindow.Hours()/24), proj.ID)
 	fmt.Println()
 
 	return nil
 }
+
+// destroyProjectSite destroys the project's site object and marks the project
+// destroyed. The SuiNS guard in d.Destroy applies unless force is set.
+func destroyProjectSite(ctx context.Context, d deployer.WalrusDeployer, pm *projects.Manager, proj *projects.Project, force bool) error {
+	err := d.Destroy(ctx, proj.ObjectID, deployer.DestroyOptions{
+		Network:     proj.Network,
+		SuiNSDomain: proj.LinkedSuiNSDomain(),
+		Force:       force,
+	})
+	if err != nil {
+		return err
+	}
+
+	proj.Status = "destroyed"
+	if err := pm.MarkDestroyed(proj.ID); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Warning: %v\n", ui.GetIcons().Warning, err)
+	}
+	return nil
+}
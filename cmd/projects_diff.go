@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// projectsDiffResult is the --json form of 'walgo projects diff'.
+type projectsDiffResult struct {
+	A           int64                `json:"a"`
+	B           int64                `json:"b"`
+	Differences []projects.FieldDiff `json:"differences"`
+}
+
+// latestDeployment returns the most recent deployment of a project with its
+// deployed files loaded, or nil when it was never deployed.
+func latestDeployment(pm *projects.Manager, projectID int64) (*projects.DeploymentRecord, error) {
+	deployments, err := pm.GetProjectDeployments(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(deployments) == 0 {
+		return nil, nil
+	}
+	latest := deployments[0]
+	if latest.FileToBlobID, err = pm.GetDeploymentBlobs(latest.ID); err != nil {
+		return nil, err
+	}
+	return latest, nil
+}
+
+// diffProjects prints the fields that differ between projects a and b and
+// their latest deployments.
+func diffProjects(pm *projects.Manager, a, b *projects.Project, asJSON bool, out io.Writer) error {
+	latestA, err := latestDeployment(pm, a.ID)
+	if err != nil {
+		return err
+	}
+	latestB, err := latestDeployment(pm, b.ID)
+	if err != nil {
+		return err
+	}
+
+	diffs := projects.DiffProjects(a, b, latestA, latestB)
+
+	if asJSON {
+		result := projectsDiffResult{A: a.ID, B: b.ID, Differences: diffs}
+		if result.Differences == nil {
+			result.Differences = []projects.FieldDiff{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("encoding diff: %w", err)
+		}
+		return nil
+	}
+
+	icons := ui.GetIcons()
+	fmt.Fprintf(out, "\n%s Comparing [%d] %s with [%d] %s\n\n", icons.Info, a.ID, a.Name, b.ID, b.Name)
+	if len(diffs) == 0 {
+		fmt.Fprintf(out, "%s No differences\n\n", icons.Check)
+		return nil
+	}
+
+	width := 0
+	for _, d := range diffs {
+		if len(d.Field) > width {
+			width = len(d.Field)
+		}
+	}
+	for _, d := range diffs {
+		fmt.Fprintf(out, "  %-*s  %s  →  %s\n", width, d.Field, diffValue(d.A), diffValue(d.B))
+	}
+	fmt.Fprintf(out, "\n%d field(s) differ\n\n", len(diffs))
+	return nil
+}
+
+// diffValue shows empty values explicitly so a missing field stands out.
+func diffValue(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
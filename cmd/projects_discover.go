@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/sui"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// listWalrusSiteObjects lists the sites an address owns; replaced in tests.
+var listWalrusSiteObjects = sui.ListWalrusSiteObjects
+
+var projectsDiscoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Find site objects you own that are not tracked as projects",
+	Long: `List the Walrus Site objects owned by the active address on the active
+network and compare them with your local projects. Sites deployed from another
+machine or tool show up as orphans, and you are asked whether to attach each
+one as a project so it can be listed, edited and updated with walgo.
+
+Attached projects have no local site folder; use 'walgo projects edit' to fill
+in details such as the category and description.
+
+Examples:
+  walgo projects discover                  # Review orphans one by one
+  walgo projects discover --attach         # Attach every orphan without asking
+  walgo projects discover --json           # List orphans as JSON`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		attachAll, _ := cmd.Flags().GetBool("attach")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		info, err := activeAddressDetails(cmd.Context())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("failed to read active address: %w", err)
+		}
+
+		sites, err := listWalrusSiteObjects(cmd.Context(), info.Address)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		orphans, err := findOrphanSites(pm, sites)
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			if orphans == nil {
+				orphans = []sui.SiteObjectSummary{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(orphans); err != nil {
+				return fmt.Errorf("encoding orphans: %w", err)
+			}
+			return nil
+		}
+
+		fmt.Printf("%s %d site object(s) owned by %s on %s\n", icons.Info, len(sites), info.Address, info.Network)
+		_, err = attachOrphanSites(pm, orphans, info.Network, info.Address, attachAll, os.Stdin, os.Stdout)
+		return err
+	},
+}
+
+// findOrphanSites returns the sites that no project refers to. Soft-deleted
+// projects count as known, since they can still be restored.
+func findOrphanSites(pm *projects.Manager, sites []sui.SiteObjectSummary) ([]sui.SiteObjectSummary, error) {
+	live, err := pm.ListProjectsWithFilter(projects.ProjectFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	deleted, err := pm.ListProjectsWithFilter(projects.ProjectFilter{Deleted: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted projects: %w", err)
+	}
+	return projects.OrphanSiteObjects(sites, append(live, deleted...)), nil
+}
+
+// attachOrphanSites prints the orphans and attaches them as projects: all of
+// them when attachAll is set, otherwise those confirmed on in. Returns the
+// projects created.
+func attachOrphanSites(pm *projects.Manager, orphans []sui.SiteObjectSummary, network, walletAddr string, attachAll bool, in io.Reader, out io.Writer) ([]*projects.Project, error) {
+	icons := ui.GetIcons()
+
+	if len(orphans) == 0 {
+		fmt.Fprintf(out, "%s Every site object is tracked as a project\n", icons.Check)
+		return nil, nil
+	}
+
+	fmt.Fprintf(out, "%s %d site object(s) are not tracked as projects:\n\n", icons.Warning, len(orphans))
+	reader := bufio.NewReader(in)
+	var attached []*projects.Project
+	for _, site := range orphans {
+		name := site.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		fmt.Fprintf(out, "  %s  %s\n", site.ObjectID, name)
+
+		if !attachAll {
+			fmt.Fprintf(out, "    Attach as a project? [y/N]: ")
+			answer, err := readLine(reader)
+			if err != nil {
+				fmt.Fprintln(out)
+				fmt.Fprintf(out, "\n%s Re-run with --attach to attach without prompting\n", icons.Lightbulb)
+				return attached, nil
+			}
+			if answer = strings.ToLower(answer); answer != "y" && answer != "yes" {
+				continue
+			}
+		}
+
+		project, err := attachSiteObject(pm, site, network, walletAddr)
+		if err != nil {
+			return attached, err
+		}
+		fmt.Fprintf(out, "    %s Attached as project %q (ID: %d)\n", icons.Check, project.Name, project.ID)
+		attached = append(attached, project)
+	}
+
+	fmt.Fprintf(out, "\n%s Attached %d of %d site(s)\n", icons.Info, len(attached), len(orphans))
+	return attached, nil
+}
+
+// attachSiteObject records site as a project. The project is named after the
+// site, or its object ID when it has no name, with a numeric suffix when the
+// name is already taken.
+func attachSiteObject(pm *projects.Manager, site sui.SiteObjectSummary, network, walletAddr string) (*projects.Project, error) {
+	base := site.Name
+	if base == "" {
+		id := strings.TrimPrefix(site.ObjectID, "0x")
+		if len(id) > 8 {
+			id = id[:8]
+		}
+		base = "site-" + id
+	}
+
+	name, err := pm.UniqueProjectName(base)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &projects.Project{
+		Name:       name,
+		Network:    network,
+		ObjectID:   site.ObjectID,
+		WalletAddr: walletAddr,
+	}
+	if err := pm.CreateProject(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,6 +10,7 @@ import (
 	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/projects"
 	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/walrus"
 )
 
 // editProjectOptions contains metadata fields for project editing.
@@ -18,6 +20,21 @@ type editProjectOptions struct {
 	Description string
 	ImageURL    string
 	SuiNS       string
+	// AllowCustomCategory accepts a Category outside compress.KnownCategories.
+	AllowCustomCategory bool
+}
+
+// validateMetadataFlags checks --image-url and --category before anything is
+// written, pointing at --allow-custom-category for unknown categories.
+func validateMetadataFlags(imageURL, category string, allowCustomCategory bool) error {
+	metadata := compress.MetadataOptions{ImageURL: imageURL, Category: category, AllowCustomCategory: allowCustomCategory}
+	if err := metadata.Validate(); err != nil {
+		if errors.Is(err, compress.ErrUnknownCategory) {
+			return fmt.Errorf("%w; use --allow-custom-category to keep it", err)
+		}
+		return err
+	}
+	return nil
 }
 
 // editProjectByRef updates project metadata in database and ws-resources.json.
@@ -33,6 +50,12 @@ func editProjectByRef(proj *projects.Project, opts editProjectOptions) error {
 		return fmt.Errorf("no changes specified. Use --new-name, --category, --description, --image-url, or --suins flags")
 	}
 
+	if opts.SuiNS != "" {
+		if opts.SuiNS, err = walrus.NormalizeSuiNSName(opts.SuiNS); err != nil {
+			return err
+		}
+	}
+
 	fmt.Println()
 	fmt.Printf("%s Editing project: %s\n", icons.Pencil, proj.Name)
 	fmt.Println()
@@ -100,6 +123,12 @@ func editProjectByRef(proj *projects.Project, opts editProjectOptions) error {
 		return nil
 	}
 
+	// Stored values from before validation existed can still be refused by
+	// ws-resources.json, so check the merged values before writing anything
+	if err := validateMetadataFlags(proj.ImageURL, proj.Category, opts.AllowCustomCategory); err != nil {
+		return err
+	}
+
 	fmt.Println("Changes to apply:")
 	for _, change := range changes {
 		fmt.Printf("  %s %s\n", icons.Pencil, change)
@@ -132,6 +161,8 @@ func editProjectByRef(proj *projects.Project, opts editProjectOptions) error {
 				ImageURL:    proj.ImageURL,
 				Category:    proj.Category,
 				Creator:     compress.DefaultCreator,
+
+				AllowCustomCategory: opts.AllowCustomCategory,
 			}
 
 			if proj.ObjectID != "" {
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// exportSiteConfig regenerates site-builder's sites-config.yaml for network at
+// output (the standard location when empty). Gateway overrides and the
+// default network come from walgo.yaml in the current directory, if any.
+func exportSiteConfig(network, output string) error {
+	icons := ui.GetIcons()
+
+	var gw config.GatewayConfig
+	if cwd, err := os.Getwd(); err == nil {
+		if cfg, err := config.LoadConfigFrom(cwd); err == nil {
+			gw = cfg.WalrusConfig.Gateway
+			if network == "" {
+				network = cfg.WalrusConfig.Network
+			}
+		}
+	}
+	if network == "" {
+		network = "testnet"
+	}
+
+	if output == "" {
+		path, err := walrus.SitesConfigPath()
+		if err != nil {
+			return err
+		}
+		output = path
+	}
+
+	sc, err := walrus.NewSitesConfig(network, gw)
+	if err != nil {
+		return err
+	}
+
+	backup, err := walrus.WriteSitesConfig(output, sc)
+	if err != nil {
+		return err
+	}
+
+	if backup != "" {
+		fmt.Printf("%s Backed up existing config to %s\n", icons.Info, backup)
+	}
+	fmt.Printf("%s Wrote %s sites-config to %s\n", icons.Check, network, output)
+	if gw.SuiRPCURL != "" {
+		fmt.Printf("   RPC URL: %s (from walrus.gateway.suiRPCURL)\n", gw.SuiRPCURL)
+	}
+	return nil
+}
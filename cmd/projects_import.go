@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var projectsImportFromGitCmd = &cobra.Command{
+	Use:   "import-from-git [root]",
+	Short: "Register every walgo site in a repository as a project",
+	Long: `Walk a repository (default: the current directory) for folders containing a
+walgo.yaml and register each one as a project in a single pass. Sites that are
+already projects, matched by folder or, for a moved folder, by the object ID
+in walgo.yaml, are brought up to date with their walgo.yaml instead.
+
+node_modules and .git directories are skipped, and the search stops --depth
+levels below the root.
+
+Examples:
+  walgo projects import-from-git                # Scan the current repository
+  walgo projects import-from-git ~/src/sites    # Scan another checkout
+  walgo projects import-from-git --depth 2      # Only look two levels deep
+  walgo projects import-from-git --dry-run      # List the sites without importing`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		depth, _ := cmd.Flags().GetInt("depth")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		if depth < 0 {
+			return fmt.Errorf("--depth must not be negative")
+		}
+
+		sites, err := projects.FindWalgoSites(root, depth)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		if len(sites) == 0 {
+			fmt.Printf("%s No walgo.yaml found within %d level(s) of %s\n", icons.Info, depth, root)
+			return nil
+		}
+
+		if dryRun {
+			fmt.Printf("%s Found %d walgo site(s):\n", icons.Info, len(sites))
+			for _, site := range sites {
+				fmt.Printf("  %s\n", site)
+			}
+			return nil
+		}
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		return importSites(pm, root, sites, os.Stdout)
+	},
+}
+
+// importSites imports each site folder as a project and prints what happened
+// to it, paths relative to root. Fails when any site could not be imported.
+func importSites(pm *projects.Manager, root string, sites []string, out io.Writer) error {
+	icons := ui.GetIcons()
+	absRoot, _ := filepath.Abs(root)
+
+	counts := map[projects.ImportOutcome]int{}
+	for _, site := range sites {
+		res := pm.ImportSite(site)
+		counts[res.Outcome]++
+
+		display := site
+		if rel, err := filepath.Rel(absRoot, site); err == nil {
+			display = rel
+		}
+		switch res.Outcome {
+		case projects.ImportAdded:
+			fmt.Fprintf(out, "  %s added      %s → %q (ID: %d)\n", icons.Check, display, res.Project.Name, res.Project.ID)
+		case projects.ImportUpdated:
+			fmt.Fprintf(out, "  %s updated    %s → %q (ID: %d)\n", icons.Pencil, display, res.Project.Name, res.Project.ID)
+		case projects.ImportUnchanged:
+			fmt.Fprintf(out, "  %s unchanged  %s → %q (ID: %d)\n", icons.Info, display, res.Project.Name, res.Project.ID)
+		default:
+			fmt.Fprintf(out, "  %s failed     %s: %v\n", icons.Error, display, res.Err)
+		}
+	}
+
+	fmt.Fprintf(out, "\n%s %d site(s): %d added, %d updated, %d unchanged, %d failed\n", icons.Info, len(sites),
+		counts[projects.ImportAdded], counts[projects.ImportUpdated], counts[projects.ImportUnchanged], counts[projects.ImportFailed])
+	if n := counts[projects.ImportFailed]; n > 0 {
+		return fmt.Errorf("failed to import %d site(s)", n)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/projects"
+)
+
+func TestImportSites(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	for rel, content := range map[string]string{
+		"blog/walgo.yaml":             "walrus:\n  projectID: \"0xb10g\"\n",
+		"apps/docs/walgo.yaml":        "walrus: {}\n",
+		"broken/walgo.yaml":           "walrus: [not a map\n",
+		"node_modules/pkg/walgo.yaml": "walrus: {}\n",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+
+	sites, err := projects.FindWalgoSites(root, projects.DefaultImportDepth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	err = importSites(pm, root, sites, &out)
+	if err == nil || !strings.Contains(err.Error(), "failed to import 1 site(s)") {
+		t.Errorf("importSites() error = %v, want the broken site reported", err)
+	}
+	for _, want := range []string{
+		`added      blog → "blog"`,
+		`added      ` + filepath.Join("apps", "docs") + ` → "docs"`,
+		"failed     broken",
+		"3 site(s): 2 added, 0 updated, 0 unchanged, 1 failed",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	_ = importSites(pm, root, sites, &out)
+	if !strings.Contains(out.String(), "0 added, 0 updated, 2 unchanged, 1 failed") {
+		t.Errorf("second pass output:\n%s", out.String())
+	}
+}
+
+func TestProjectsImportFromGitCommand(t *testing.T) {
+	tests := []TestCase{
+		{
+			Name:     "Projects import-from-git help",
+			Args:     []string{"projects", "import-from-git", "--help"},
+			Contains: []string{"walgo.yaml", "--depth", "--dry-run", "node_modules"},
+		},
+		{
+			Name:        "Projects import-from-git rejects a negative depth",
+			Args:        []string{"projects", "import-from-git", "--depth", "-1"},
+			ExpectError: true,
+			Contains:    []string{"--depth must not be negative"},
+		},
+	}
+	runTestCases(t, rootCmd, tests)
+}
@@ -2,14 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/selimozten/walgo/internal/projects"
 	"github.com/selimozten/walgo/internal/ui"
 )
 
-// listProjects displays all projects matching the given filters.
-func listProjects(network, status string) error {
+// listProjects displays all projects matching the given filter.
+func listProjects(filter projects.ProjectFilter) error {
 	icons := ui.GetIcons()
 	pm, err := projects.NewManager()
 	if err != nil {
@@ -17,7 +19,7 @@ func listProjects(network, status string) error {
 	}
 	defer pm.Close()
 
-	projectList, err := pm.ListProjects(network, status)
+	projectList, err := pm.ListProjectsWithFilter(filter)
 	if err != nil {
 		return fmt.Errorf("failed to list projects: %w", err)
 	}
@@ -51,6 +53,12 @@ func listProjects(network, status string) error {
 		fmt.Printf("   ID:           %d\n", proj.ID)
 		fmt.Printf("   Network:      %s\n", proj.Network)
 		fmt.Printf("   Status:       %s\n", proj.Status)
+		if proj.DeletedAt != nil {
+			fmt.Printf("   Deleted:      %s\n", proj.DeletedAt.Format("2006-01-02 15:04"))
+		}
+		if len(proj.Tags) > 0 {
+			fmt.Printf("   Tags:         %s\n", strings.Join(proj.Tags, ", "))
+		}
 		fmt.Printf("   Object ID:    %s\n", proj.ObjectID)
 
 		if proj.SuiNS != "" {
@@ -83,6 +91,10 @@ func listProjects(network, status string) error {
 		} else {
 			fmt.Printf("   Updated:      %d days ago\n", int(since.Hours()/24))
 		}
+
+		if proj.MetadataError != "" {
+			fmt.Fprintf(os.Stderr, "%s Warning: project %d (%s) has unreadable metadata: %s\n", icons.Warning, proj.ID, proj.Name, proj.MetadataError)
+		}
 	}
 
 	fmt.Println()
@@ -91,6 +103,7 @@ func listProjects(network, status string) error {
 	fmt.Println("   • Update site:   walgo projects update <name>")
 	fmt.Println("   • Edit metadata: walgo projects edit <name> --name 'New Name'")
 	fmt.Println("   • Archive:       walgo projects archive <name>")
+	fmt.Println("   • Tag:           walgo projects tag <name> <tag>")
 	fmt.Println()
 
 	return nil
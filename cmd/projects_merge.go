@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var projectsMergeCmd = &cobra.Command{
+	Use:   "merge <keep name|id> <merge name|id>",
+	Short: "Combine two project records into one history",
+	Long: `Merge a second project record for the same site into the first, for example
+when the site was deployed from two machines and tracked as two projects.
+
+The deployment history and tags of the second project move to the first,
+keeping their dates so the combined history stays in order, and the second
+project is deleted. Like 'walgo projects delete', the deleted record can be
+brought back with 'walgo projects restore' for 30 days, though its history
+stays with the project it was merged into.
+
+Projects tracking different site objects are refused unless --force is given;
+the kept project then points at the object of the newest deployment.
+
+Examples:
+  walgo projects merge 3 7              # Fold project 7 into project 3
+  walgo projects merge my-site my-site-2
+  walgo projects merge 3 7 --force      # Merge even if the object IDs differ`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		force, _ := cmd.Flags().GetBool("force")
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		keep, err := lookupProject(pm, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		merge, err := lookupProject(pm, args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+
+		if err := mergeProjects(pm, keep, merge, force, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("failed to merge projects: %w", err)
+		}
+		return nil
+	},
+}
+
+// mergeProjects folds merge into keep and reports what moved.
+func mergeProjects(pm *projects.Manager, keep, merge *projects.Project, force bool, out io.Writer) error {
+	icons := ui.GetIcons()
+
+	moved, err := pm.MergeProjects(keep.ID, merge.ID, force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%s Merged [%d] %s into [%d] %s\n", icons.Check, merge.ID, merge.Name, keep.ID, keep.Name)
+	fmt.Fprintf(out, "    %d deployment record(s) moved\n", moved)
+	fmt.Fprintf(out, "\n%s [%d] %s was deleted; to restore it: walgo projects restore %d\n\n", icons.Info, merge.ID, merge.Name, merge.ID)
+	return nil
+}
+
+func init() {
+	projectsMergeCmd.Flags().Bool("force", false, "Merge even if the projects track different site objects")
+}
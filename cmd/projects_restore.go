@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// resolveRestorableProject resolves a project like resolveProject, falling
+// back to soft-deleted projects when looking up by ID or name.
+func resolveRestorableProject(cmd *cobra.Command, args []string) (*projects.Project, error) {
+	proj, err := resolveProject(cmd, args)
+	if err == nil {
+		return proj, nil
+	}
+
+	id, _ := cmd.Flags().GetInt64("id")
+	name, _ := cmd.Flags().GetString("name")
+	if id == 0 && name == "" && len(args) > 0 {
+		name = args[0]
+		if n, parseErr := strconv.ParseInt(args[0], 10, 64); parseErr == nil {
+			id = n
+		}
+	}
+	if id == 0 && name == "" {
+		return nil, err
+	}
+
+	pm, pmErr := projects.NewManager()
+	if pmErr != nil {
+		return nil, fmt.Errorf("failed to initialize project manager: %w", pmErr)
+	}
+	defer pm.Close()
+
+	deleted, listErr := pm.ListProjectsWithFilter(projects.ProjectFilter{Deleted: true})
+	if listErr != nil {
+		return nil, listErr
+	}
+	for _, p := range deleted {
+		if (id > 0 && p.ID == id) || (name != "" && p.Name == name) {
+			return p, nil
+		}
+	}
+	return nil, err
+}
+
+// restoreProject brings back a soft-deleted or archived project.
+func restoreProject(pm *projects.Manager, proj *projects.Project, out io.Writer) error {
+	icons := ui.GetIcons()
+
+	switch {
+	case proj.DeletedAt != nil:
+		if err := pm.RestoreDeletedProject(proj.ID, projects.DefaultRecoveryWindow); err != nil {
+			return err
+		}
+	case proj.Status == "archived":
+		if err := pm.RestoreProject(proj.ID); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("project '%s' is neither deleted nor archived", proj.Name)
+	}
+
+	fmt.Fprintf(out, "%s Project '%s' restored\n", icons.Check, proj.Name)
+	return nil
+}
+
+// pruneDeletedProjects permanently removes projects deleted longer ago than
+// projects.DefaultRecoveryWindow.
+func pruneDeletedProjects(pm *projects.Manager, out io.Writer) error {
+	icons := ui.GetIcons()
+
+	pruned, err := pm.PruneDeletedProjects(projects.DefaultRecoveryWindow)
+	if err != nil {
+		return err
+	}
+
+	if pruned == 0 {
+		fmt.Fprintf(out, "%s No deleted projects past the recovery window\n", icons.Info)
+		return nil
+	}
+	fmt.Fprintf(out, "%s Permanently removed %d deleted project(s)\n", icons.Check, pruned)
+	return nil
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -41,6 +42,9 @@ func showProjectDetails(proj *projects.Project) error {
 	if proj.Category != "" {
 		fmt.Printf("  Category:        %s\n", proj.Category)
 	}
+	if len(proj.Tags) > 0 {
+		fmt.Printf("  Tags:            %s\n", strings.Join(proj.Tags, ", "))
+	}
 	if proj.Description != "" {
 		desc := proj.Description
 		if len(desc) > 60 {
@@ -55,12 +59,25 @@ func showProjectDetails(proj *projects.Project) error {
 		}
 		fmt.Printf("  Image URL:       %s\n", url)
 	}
+	if len(proj.Metadata) > 0 {
+		keys := make([]string, 0, len(proj.Metadata))
+		for k := range proj.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Println("  Notes:")
+		for _, k := range keys {
+			fmt.Printf("    %s: %s\n", k, proj.Metadata[k])
+		}
+	}
 	fmt.Printf("  Network:         %s\n", proj.Network)
 	fmt.Printf("  Status:          %s\n", proj.Status)
 	fmt.Printf("  Object ID:       %s\n", proj.ObjectID)
 	if proj.SuiNS != "" {
 		fmt.Printf("  SuiNS:           %s\n", proj.SuiNS)
-		fmt.Printf("  URL:             https://%s.wal.app\n", proj.SuiNS)
+	}
+	if url := proj.PortalURL(); url != "" {
+		fmt.Printf("  URL:             %s\n", url)
 	}
 	fmt.Printf("  Wallet:          %s\n", proj.WalletAddr)
 	fmt.Printf("  Site path:       %s\n", proj.SitePath)
@@ -102,6 +119,10 @@ func showProjectDetails(proj *projects.Project) error {
 		}
 	}
 
+	if proj.Deletable {
+		fmt.Printf("  Blobs:                 deletable\n")
+	}
+
 	// Gas Fee (actual cost from last deployment)
 	if proj.GasFee != "" {
 		fmt.Printf("  Gas Fee:               %s\n", proj.GasFee)
@@ -120,6 +141,13 @@ func showProjectDetails(proj *projects.Project) error {
 	if !stats.FirstDeployment.IsZero() {
 		fmt.Printf("  First Deployment:      %s\n", stats.FirstDeployment.Format("2006-01-02 15:04"))
 	}
+	if stats.TotalGasSpent != "" {
+		fmt.Printf("  Total Spent:           %s", stats.TotalGasSpent)
+		if stats.EstimatedDeploys > 0 {
+			fmt.Printf(" (excludes %d deploy(s) with only an estimate)", stats.EstimatedDeploys)
+		}
+		fmt.Println()
+	}
 	fmt.Println()
 
 	if len(deployments) > 0 {
@@ -187,9 +215,11 @@ func calculateExpiryDate(lastDeploy time.Time, epochs int, network string) time.
 
 // formatExpiryDuration formats the time until expiry in a human-readable format
 func formatExpiryDuration(expiryDate time.Time) string {
-	now := time.Now()
-	diff := expiryDate.Sub(now)
+	return formatRemaining(time.Until(expiryDate))
+}
 
+// formatRemaining formats the storage time left, diff, in a human-readable format
+func formatRemaining(diff time.Duration) string {
 	if diff < 0 {
 		return "Expired"
 	}
@@ -1,10 +1,24 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployer"
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/sui"
+	"github.com/selimozten/walgo/internal/walrus"
 )
 
 // findCommand searches rootCmd's subcommands (and nested subcommands) for the
@@ -103,6 +117,7 @@ func TestProjectsCommandFlags(t *testing.T) {
 	}{
 		{"network flag", "network", "n", ""},
 		{"status flag", "status", "s", ""},
+		{"tag flag", "tag", "", "[]"},
 	}
 
 	for _, tt := range flagTests {
@@ -176,6 +191,7 @@ func TestProjectsListCommandFlags(t *testing.T) {
 	}{
 		{"network flag", "network", "n", ""},
 		{"status flag", "status", "s", ""},
+		{"tag flag", "tag", "", "[]"},
 	}
 
 	for _, tt := range flagTests {
@@ -364,6 +380,33 @@ func TestProjectsEditCommandDescription(t *testing.T) {
 	})
 }
 
+func TestEditProjectByRefRejectsLegacyCategory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+
+	proj := &projects.Project{Name: "legacy", Category: "my-old-category", Network: "testnet", ObjectID: "0xlegacy", SitePath: t.TempDir()}
+	if err := pm.CreateProject(proj); err != nil {
+		t.Fatal(err)
+	}
+
+	err = editProjectByRef(proj, editProjectOptions{Description: "new description"})
+	if err == nil || !strings.Contains(err.Error(), "--allow-custom-category") {
+		t.Fatalf("editProjectByRef() error = %v, want the unknown category refused", err)
+	}
+
+	stored, err := pm.GetProject(proj.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Description != "" {
+		t.Errorf("description = %q, want the project left unchanged", stored.Description)
+	}
+}
+
 func TestProjectsEditCommandFlags(t *testing.T) {
 	editCmd := findCommand(rootCmd, "projects", "edit")
 	if editCmd == nil {
@@ -635,6 +678,189 @@ func TestProjectsArchiveCommandArgsValidation(t *testing.T) {
 	})
 }
 
+// --- Projects restore and prune subcommands ---
+
+func TestProjectsRestoreCommand(t *testing.T) {
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name:     "Projects restore help",
+			Args:     []string{"projects", "restore", "--help"},
+			Contains: []string{"walgo projects restore 5", "--id", "--name"},
+		},
+		{
+			Name:     "Projects prune help",
+			Args:     []string{"projects", "prune", "--help"},
+			Contains: []string{"recovery window"},
+		},
+	})
+}
+
+func TestRestoreProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+
+	proj := &projects.Project{Name: "undo-me", Network: "testnet", ObjectID: "0xundo", SitePath: t.TempDir()}
+	if err := pm.CreateProject(proj); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := restoreProject(pm, proj, &out); err == nil {
+		t.Error("restoring a live project should fail")
+	}
+
+	if err := pm.SoftDeleteProject(proj.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pm.GetProject(proj.ID); err == nil {
+		t.Error("GetProject() should not find a deleted project")
+	}
+	deleted, err := pm.ListProjectsWithFilter(projects.ProjectFilter{Deleted: true})
+	if err != nil || len(deleted) != 1 {
+		t.Fatalf("deleted projects = %v, err = %v", deleted, err)
+	}
+	if err := restoreProject(pm, deleted[0], &out); err != nil {
+		t.Fatalf("restoreProject() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Project 'undo-me' restored") {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+	if _, err := pm.GetProjectByName("undo-me"); err != nil {
+		t.Errorf("restored project not found by name: %v", err)
+	}
+}
+
+// --- Projects diff subcommand ---
+
+func TestProjectsDiffCommand(t *testing.T) {
+	tests := []TestCase{
+		{
+			Name:        "Projects diff help",
+			Args:        []string{"projects", "diff", "--help"},
+			ExpectError: false,
+			Contains:    []string{"Only fields that differ are printed", "--json"},
+		},
+		{
+			Name:        "Projects diff needs two projects",
+			Args:        []string{"projects", "diff", "1"},
+			ExpectError: true,
+			Contains:    []string{"accepts 2 arg(s)"},
+		},
+	}
+
+	runTestCases(t, rootCmd, tests)
+}
+
+func TestDiffProjectsOutput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+
+	a := &projects.Project{Name: "site", Category: "blog", Network: "testnet", ObjectID: "0xa", Epochs: 5, SitePath: t.TempDir()}
+	b := &projects.Project{Name: "site-fork", Category: "blog", Network: "testnet", ObjectID: "0xb", Epochs: 10, SitePath: t.TempDir()}
+	for _, p := range []*projects.Project{a, b} {
+		if err := pm.CreateProject(p); err != nil {
+			t.Fatal(err)
+		}
+		if err := pm.RecordDeployment(&projects.DeploymentRecord{ProjectID: p.ID, ObjectID: p.ObjectID, Network: p.Network, Epochs: p.Epochs, Success: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := diffProjects(pm, a, b, true, &out); err != nil {
+		t.Fatalf("diffProjects() error = %v", err)
+	}
+
+	var result projectsDiffResult
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	fields := make(map[string]bool)
+	for _, d := range result.Differences {
+		fields[d.Field] = true
+	}
+	for _, f := range []string{"name", "object_id", "epochs", "latest_deployment.epochs"} {
+		if !fields[f] {
+			t.Errorf("expected %s in differences: %+v", f, result.Differences)
+		}
+	}
+	for _, f := range []string{"category", "network", "latest_deployment.network"} {
+		if fields[f] {
+			t.Errorf("identical field %s should not be listed", f)
+		}
+	}
+
+	out.Reset()
+	if err := diffProjects(pm, a, a, false, &out); err != nil {
+		t.Fatalf("diffProjects() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "No differences") {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+}
+
+// --- Projects merge subcommand ---
+
+func TestProjectsMergeCommand(t *testing.T) {
+	tests := []TestCase{
+		{
+			Name:        "Projects merge help",
+			Args:        []string{"projects", "merge", "--help"},
+			ExpectError: false,
+			Contains:    []string{"keeping their dates", "--force"},
+		},
+		{
+			Name:        "Projects merge needs two projects",
+			Args:        []string{"projects", "merge", "1"},
+			ExpectError: true,
+			Contains:    []string{"accepts 2 arg(s)"},
+		},
+	}
+
+	runTestCases(t, rootCmd, tests)
+}
+
+func TestMergeProjectsOutput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+
+	keep := &projects.Project{Name: "site", Network: "testnet", ObjectID: "0xa", SitePath: t.TempDir()}
+	merge := &projects.Project{Name: "site-2", Network: "testnet", ObjectID: "0xb", SitePath: t.TempDir()}
+	for _, p := range []*projects.Project{keep, merge} {
+		if err := pm.CreateProject(p); err != nil {
+			t.Fatal(err)
+		}
+		if err := pm.RecordDeployment(&projects.DeploymentRecord{ProjectID: p.ID, ObjectID: p.ObjectID, Network: p.Network, Success: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := mergeProjects(pm, keep, merge, false, &out); !errors.Is(err, projects.ErrObjectIDMismatch) {
+		t.Fatalf("mergeProjects() error = %v, want ErrObjectIDMismatch", err)
+	}
+	if err := mergeProjects(pm, keep, merge, true, &out); err != nil {
+		t.Fatalf("mergeProjects(force) error = %v", err)
+	}
+	for _, want := range []string{"Merged [2] site-2 into [1] site", "1 deployment record(s) moved", "walgo projects restore 2"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
 // --- Projects update subcommand ---
 
 func TestProjectsUpdateCommand(t *testing.T) {
@@ -761,7 +987,7 @@ func TestProjectsSubcommandsRegistered(t *testing.T) {
 		t.Fatal("projects command not found")
 	}
 
-	expectedSubcommands := []string{"list", "show", "edit", "update", "delete", "archive"}
+	expectedSubcommands := []string{"list", "show", "edit", "update", "delete", "archive", "restore", "prune", "clone"}
 
 	subcommands := make(map[string]bool)
 	for _, child := range projectsCommand.Commands() {
@@ -802,7 +1028,7 @@ func TestProjectsSubcommandsHaveRunE(t *testing.T) {
 
 func TestProjectIdentifierFlagsConsistency(t *testing.T) {
 	// All subcommands that take project identifiers should have both --id and --name
-	subcommandsWithIdentifiers := []string{"show", "edit", "update", "delete", "archive"}
+	subcommandsWithIdentifiers := []string{"show", "edit", "update", "delete", "archive", "restore"}
 
 	for _, subcmdName := range subcommandsWithIdentifiers {
 		subcmd := findCommand(rootCmd, "projects", subcmdName)
@@ -967,3 +1193,358 @@ func stringContains(s, substr string) bool {
 	}
 	return false
 }
+
+// --- Projects tag subcommand ---
+
+func TestProjectsTagCommand(t *testing.T) {
+	tests := []TestCase{
+		{
+			Name:        "Projects tag help",
+			Args:        []string{"projects", "tag", "--help"},
+			ExpectError: false,
+			Contains: []string{
+				"Label a project with freeform tags",
+				"--remove",
+				"--id",
+				"--name",
+			},
+		},
+		{
+			Name:        "Projects tag requires arguments",
+			Args:        []string{"projects", "tag"},
+			ExpectError: true,
+			Contains: []string{
+				"requires at least 1 arg",
+			},
+		},
+	}
+
+	runTestCases(t, rootCmd, tests)
+}
+
+// --- Export site config ---
+
+func TestExportSiteConfigUsesSiteSettings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	siteDir := t.TempDir()
+	walgoYAML := `walrus:
+  network: mainnet
+  gateway:
+    suiRPCURL: https://rpc.corp.example
+`
+	if err := os.WriteFile(filepath.Join(siteDir, "walgo.yaml"), []byte(walgoYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalWd, _ := os.Getwd()
+	if err := os.Chdir(siteDir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(originalWd) }()
+
+	output := filepath.Join(t.TempDir(), "sites-config.yaml")
+	var err error
+	captureOutput(func() { err = exportSiteConfig("", output) })
+	if err != nil {
+		t.Fatalf("exportSiteConfig() error = %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sc walrus.SitesConfig
+	if err := yaml.Unmarshal(data, &sc); err != nil {
+		t.Fatalf("generated config does not parse: %v", err)
+	}
+	if sc.DefaultContext != "mainnet" {
+		t.Errorf("default_context = %q, want mainnet", sc.DefaultContext)
+	}
+	if got := sc.Contexts["mainnet"].General.RPCURL; got != "https://rpc.corp.example" {
+		t.Errorf("rpc_url = %q, want gateway override", got)
+	}
+	if got := sc.Contexts["mainnet"].Portal; got != "wal.app" {
+		t.Errorf("portal = %q, want wal.app", got)
+	}
+
+	// An explicit --network wins over walgo.yaml.
+	captureOutput(func() { err = exportSiteConfig("testnet", output) })
+	if err != nil {
+		t.Fatalf("exportSiteConfig(testnet) error = %v", err)
+	}
+	data, _ = os.ReadFile(output)
+	sc = walrus.SitesConfig{}
+	if err := yaml.Unmarshal(data, &sc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sc.Contexts["testnet"]; !ok || sc.DefaultContext != "testnet" {
+		t.Errorf("expected testnet context, got %+v", sc)
+	}
+	backups, _ := filepath.Glob(output + ".bak-*")
+	if len(backups) != 1 {
+		t.Errorf("expected one backup, got %v", backups)
+	}
+}
+
+func TestExportSiteConfigRejectsUnknownNetwork(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "sites-config.yaml")
+	var err error
+	captureOutput(func() { err = exportSiteConfig("devnet", output) })
+	if err == nil {
+		t.Fatal("expected error for unsupported network")
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Error("no config should be written for an unsupported network")
+	}
+}
+
+// --- Delete ---
+
+// guardDeployer refuses to destroy when a SuiNS domain is passed without Force,
+// mirroring the site-builder adapter.
+type guardDeployer struct {
+	deployer.WalrusDeployer
+	destroyed []deployer.DestroyOptions
+}
+
+func (g *guardDeployer) Destroy(_ context.Context, objectID string, opts deployer.DestroyOptions) error {
+	if opts.SuiNSDomain != "" && !opts.Force {
+		return &deployer.LinkedDomainError{ObjectID: objectID, Domains: []string{opts.SuiNSDomain}}
+	}
+	g.destroyed = append(g.destroyed, opts)
+	return nil
+}
+
+func TestDestroyProjectSite(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+
+	proj := &projects.Project{Name: "linked", Network: "mainnet", ObjectID: "0xabc", SuiNS: "linked.sui", SitePath: t.TempDir()}
+	if err := pm.CreateProject(proj); err != nil {
+		t.Fatal(err)
+	}
+	d := &guardDeployer{}
+
+	err = destroyProjectSite(context.Background(), d, pm, proj, false)
+	var linked *deployer.LinkedDomainError
+	if !errors.As(err, &linked) {
+		t.Fatalf("destroyProjectSite() error = %v, want *LinkedDomainError", err)
+	}
+	if got, _ := pm.GetProject(proj.ID); got.Status != "active" {
+		t.Errorf("blocked destroy changed status to %q", got.Status)
+	}
+
+	if err := destroyProjectSite(context.Background(), d, pm, proj, true); err != nil {
+		t.Fatalf("destroyProjectSite(force) error = %v", err)
+	}
+	if len(d.destroyed) != 1 || d.destroyed[0].Network != "mainnet" || d.destroyed[0].SuiNSDomain != "linked.sui" {
+		t.Errorf("destroy options = %+v", d.destroyed)
+	}
+	if got, _ := pm.GetProject(proj.ID); got.Status != "destroyed" {
+		t.Errorf("status after destroy = %q, want destroyed", got.Status)
+	}
+}
+
+func TestProjectsDeleteForceFlag(t *testing.T) {
+	if projectsDeleteCmd.Flags().Lookup("force") == nil {
+		t.Error("flag --force not found")
+	}
+}
+
+// --- Projects discover subcommand ---
+
+func TestProjectsDiscoverCommand(t *testing.T) {
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name:     "Projects discover help",
+			Args:     []string{"projects", "discover", "--help"},
+			Contains: []string{"owned by the active address", "--attach", "--json"},
+		},
+	})
+}
+
+func TestDiscoverAndAttachOrphanSites(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+
+	tracked := &projects.Project{Name: "tracked", Network: "testnet", ObjectID: "0xaa", SitePath: t.TempDir()}
+	deleted := &projects.Project{Name: "deleted", Network: "testnet", ObjectID: "0xdd", SitePath: t.TempDir()}
+	taken := &projects.Project{Name: "Blog", Network: "testnet", ObjectID: "0xee", SitePath: t.TempDir()}
+	for _, p := range []*projects.Project{tracked, deleted, taken} {
+		if err := pm.CreateProject(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pm.SoftDeleteProject(deleted.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	sites := []sui.SiteObjectSummary{
+		{ObjectID: "0xAA", Name: "tracked"},
+		{ObjectID: "0xbb", Name: "Blog"},
+		{ObjectID: "0x0123456789abcdef"},
+		{ObjectID: "0xdd", Name: "deleted"},
+		{ObjectID: "0xee", Name: "Blog"},
+	}
+	orphans, err := findOrphanSites(pm, sites)
+	if err != nil {
+		t.Fatalf("findOrphanSites() error = %v", err)
+	}
+	if len(orphans) != 2 || orphans[0].ObjectID != "0xbb" || orphans[1].ObjectID != "0x0123456789abcdef" {
+		t.Fatalf("orphans = %+v, want 0xbb and 0x0123456789abcdef", orphans)
+	}
+
+	// Decline the first orphan, accept the second
+	var out bytes.Buffer
+	attached, err := attachOrphanSites(pm, orphans, "testnet", "0xwallet", false, strings.NewReader("n\ny\n"), &out)
+	if err != nil {
+		t.Fatalf("attachOrphanSites() error = %v", err)
+	}
+	if len(attached) != 1 || attached[0].Name != "site-01234567" {
+		t.Fatalf("attached = %+v, want one project named site-01234567", attached)
+	}
+	proj, err := pm.GetProjectByObjectID("0x0123456789abcdef")
+	if err != nil || proj.Network != "testnet" || proj.WalletAddr != "0xwallet" {
+		t.Errorf("attached project = %+v, %v", proj, err)
+	}
+
+	// --attach takes the rest; the name clash gets a suffix
+	out.Reset()
+	orphans, _ = findOrphanSites(pm, sites)
+	attached, err = attachOrphanSites(pm, orphans, "testnet", "0xwallet", true, strings.NewReader(""), &out)
+	if err != nil {
+		t.Fatalf("attachOrphanSites() error = %v", err)
+	}
+	if len(attached) != 1 || attached[0].Name != "Blog-2" {
+		t.Errorf("attached = %+v, want Blog-2", attached)
+	}
+
+	out.Reset()
+	orphans, _ = findOrphanSites(pm, sites)
+	if _, err := attachOrphanSites(pm, orphans, "testnet", "0xwallet", false, strings.NewReader(""), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Every site object is tracked") {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+}
+
+// --- Projects set-suins subcommand ---
+
+func TestProjectsSetSuiNSCommand(t *testing.T) {
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name:     "Projects set-suins help",
+			Args:     []string{"projects", "set-suins", "--help"},
+			Contains: []string{"letters and numbers", "--verify"},
+		},
+		{
+			Name:        "Projects set-suins requires two arguments",
+			Args:        []string{"projects", "set-suins", "5"},
+			ExpectError: true,
+		},
+	})
+}
+
+func TestSetProjectSuiNS(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+
+	const wallet = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+	siteDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(siteDir, "walgo.yaml"), []byte("walrus:\n  network: mainnet\n  projectID: \"0xabc\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	proj := &projects.Project{Name: "blog", Network: "mainnet", ObjectID: "0xabc", WalletAddr: wallet, SitePath: siteDir}
+	if err := pm.CreateProject(proj); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("rejects invalid names", func(t *testing.T) {
+		for _, name := range []string{"my-blog.sui", "my_blog", "blog!.sui", "docs.myblog.sui"} {
+			if err := setProjectSuiNS(context.Background(), pm, proj, name, false, &bytes.Buffer{}); err == nil {
+				t.Errorf("setProjectSuiNS(%q) accepted an invalid name", name)
+			}
+		}
+		if got, _ := pm.GetProject(proj.ID); got.SuiNS != "" {
+			t.Errorf("rejected name was stored: %q", got.SuiNS)
+		}
+	})
+
+	t.Run("rejects testnet projects", func(t *testing.T) {
+		testnet := &projects.Project{Name: "testnet-blog", Network: "testnet"}
+		if err := setProjectSuiNS(context.Background(), pm, testnet, "myblog.sui", false, &bytes.Buffer{}); err == nil {
+			t.Error("expected an error for a testnet project")
+		}
+	})
+
+	t.Run("stores the name on the project and in walgo.yaml", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := setProjectSuiNS(context.Background(), pm, proj, "MyBlog", false, &out); err != nil {
+			t.Fatalf("setProjectSuiNS() error = %v", err)
+		}
+
+		got, err := pm.GetProject(proj.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.SuiNS != "myblog.sui" {
+			t.Errorf("project SuiNS = %q, want myblog.sui", got.SuiNS)
+		}
+		if url := got.PortalURL(); url != "https://myblog.wal.app" {
+			t.Errorf("PortalURL() = %q, want https://myblog.wal.app", url)
+		}
+
+		cfg, err := config.LoadConfigFrom(siteDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.WalrusConfig.SuiNSDomain != "myblog.sui" {
+			t.Errorf("walgo.yaml suinsDomain = %q, want myblog.sui", cfg.WalrusConfig.SuiNSDomain)
+		}
+		if cfg.WalrusConfig.ProjectID != "0xabc" {
+			t.Errorf("walgo.yaml projectID = %q, want it kept", cfg.WalrusConfig.ProjectID)
+		}
+		if !strings.Contains(out.String(), "https://myblog.wal.app") {
+			t.Errorf("output = %q, want the portal URL", out.String())
+		}
+	})
+
+	t.Run("verify checks the name targets the site object", func(t *testing.T) {
+		linked := false
+		old := suinsNameTargets
+		suinsNameTargets = func(ctx context.Context, network, name, objectID string) (bool, error) {
+			if network != "mainnet" || name != "newblog.sui" || objectID != "0xabc" {
+				t.Errorf("lookup of %s on %s for %s", name, network, objectID)
+			}
+			return linked, nil
+		}
+		t.Cleanup(func() { suinsNameTargets = old })
+
+		if err := setProjectSuiNS(context.Background(), pm, proj, "newblog.sui", true, &bytes.Buffer{}); err == nil {
+			t.Error("expected an error for a name not pointing at the site")
+		}
+		if got, _ := pm.GetProject(proj.ID); got.SuiNS != "myblog.sui" {
+			t.Errorf("failed verification changed SuiNS to %q", got.SuiNS)
+		}
+
+		linked = true
+		if err := setProjectSuiNS(context.Background(), pm, proj, "newblog.sui", true, &bytes.Buffer{}); err != nil {
+			t.Fatalf("setProjectSuiNS(verify) error = %v", err)
+		}
+		if got, _ := pm.GetProject(proj.ID); got.SuiNS != "newblog.sui" {
+			t.Errorf("project SuiNS = %q, want newblog.sui", got.SuiNS)
+		}
+	})
+}
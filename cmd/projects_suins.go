@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/walrus"
+	"github.com/spf13/cobra"
+)
+
+// suinsNameTargets checks on-chain that a SuiNS name points at a site object;
+// replaced in tests.
+var suinsNameTargets = walrus.SuiNSNameTargets
+
+var projectsSetSuiNSCmd = &cobra.Command{
+	Use:   "set-suins <name|id> <name.sui>",
+	Short: "Record the SuiNS domain linked to a project",
+	Long: `Record the SuiNS domain that points at a project's site.
+
+The domain is stored on the project and as walrus.suinsDomain in the site's
+walgo.yaml, and the project's URL becomes https://<name>.wal.app. Linking the
+name to the site itself is done on https://suins.io (see 'walgo domain').
+
+SuiNS names may contain only letters and numbers; the .sui suffix is optional.
+SuiNS is only available on mainnet.
+
+With --verify, the name is looked up on-chain and must point at the project's
+site object, which is what the portal serves.
+
+Examples:
+  walgo projects set-suins 5 myblog.sui
+  walgo projects set-suins mysite myblog --verify`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		verify, _ := cmd.Flags().GetBool("verify")
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		proj, err := lookupProject(pm, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+
+		if err := setProjectSuiNS(cmd.Context(), pm, proj, args[1], verify, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+		return nil
+	},
+}
+
+// setProjectSuiNS validates name, optionally checks on-chain that it points
+// at the project's site object, and records it on the project and in
+// walgo.yaml.
+func setProjectSuiNS(ctx context.Context, pm *projects.Manager, proj *projects.Project, name string, verify bool, out io.Writer) error {
+	icons := ui.GetIcons()
+
+	domain, err := walrus.NormalizeSuiNSName(name)
+	if err != nil {
+		return err
+	}
+	if proj.Network != "mainnet" {
+		return fmt.Errorf("SuiNS is only available on mainnet; project '%s' is on %s", proj.Name, proj.Network)
+	}
+
+	if verify {
+		if proj.ObjectID == "" {
+			return fmt.Errorf("project '%s' has no site object to verify %s against", proj.Name, domain)
+		}
+		linked, err := suinsNameTargets(ctx, proj.Network, domain, proj.ObjectID)
+		if err != nil {
+			return fmt.Errorf("failed to look up %s: %w", domain, err)
+		}
+		if !linked {
+			return fmt.Errorf("%s does not point at the project's site %s (link it on https://suins.io)", domain, proj.ObjectID)
+		}
+		fmt.Fprintf(out, "%s %s points at the project's site\n", icons.Check, domain)
+	}
+
+	proj.SuiNS = domain
+	if err := pm.UpdateProject(proj); err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	if proj.SitePath != "" {
+		if _, err := os.Stat(filepath.Join(proj.SitePath, "walgo.yaml")); err == nil {
+			if err := config.UpdateWalgoYAMLSuiNSDomain(proj.SitePath, domain); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "%s SuiNS domain for '%s' set to %s\n", icons.Success, proj.Name, domain)
+	fmt.Fprintf(out, "   URL: %s\n", proj.PortalURL())
+	return nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// tagProjectByRef adds tags to, or with remove set removes tags from, a project.
+func tagProjectByRef(proj *projects.Project, tags []string, remove bool) error {
+	icons := ui.GetIcons()
+	pm, err := projects.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize project manager: %w", err)
+	}
+	defer pm.Close()
+
+	for _, tag := range tags {
+		if remove {
+			err = pm.RemoveTag(proj.ID, tag)
+		} else {
+			err = pm.AddTag(proj.ID, tag)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	current, err := pm.GetTags(proj.ID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if remove {
+		fmt.Printf("%s Removed %d tag(s) from '%s'\n", icons.Check, len(tags), proj.Name)
+	} else {
+		fmt.Printf("%s Tagged '%s'\n", icons.Check, proj.Name)
+	}
+	if len(current) > 0 {
+		fmt.Printf("   Tags: %s\n", strings.Join(current, ", "))
+	} else {
+		fmt.Println("   Tags: (none)")
+	}
+	fmt.Println()
+
+	return nil
+}
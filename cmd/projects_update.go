@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -18,7 +17,29 @@ import (
 
 // updateProjectByRef pushes local site changes to Walrus blockchain.
 func updateProjectByRef(proj *projects.Project, epochs int) error {
+	return pushProjectUpdate(proj, epochs, projectUpdateOptions{Build: true})
+}
+
+// renewProjectStorage extends every blob of proj's site to epochs (0 keeps
+// the project's own) with site-builder update --check-extend, re-uploading
+// the site's last build without rebuilding it.
+func renewProjectStorage(proj *projects.Project, epochs int) error {
+	return pushProjectUpdate(proj, epochs, projectUpdateOptions{CheckExtend: true})
+}
+
+// projectUpdateOptions selects how pushProjectUpdate runs.
+type projectUpdateOptions struct {
+	Build       bool // Rebuild the site before uploading
+	CheckExtend bool // Extend unchanged blobs too, see deployer.DeployOptions
+}
+
+// pushProjectUpdate updates proj's site on Walrus from its local publish
+// directory and records the deployment.
+func pushProjectUpdate(proj *projects.Project, epochs int, opts projectUpdateOptions) error {
 	icons := ui.GetIcons()
+	if proj.SitePath == "" {
+		return fmt.Errorf("project %s has no local site path", proj.Name)
+	}
 	pm, err := projects.NewManager()
 	if err != nil {
 		return fmt.Errorf("failed to initialize project manager: %w", err)
@@ -48,19 +69,21 @@ func updateProjectByRef(proj *projects.Project, epochs int) error {
 
 	fmt.Printf("%s Pushing changes to Walrus...\n", icons.Spinner)
 
-	err = hugo.BuildSite(proj.SitePath)
-	if err != nil {
-		return fmt.Errorf("failed to build site: %w", err)
+	if opts.Build {
+		if err := hugo.BuildSite(proj.SitePath); err != nil {
+			return fmt.Errorf("failed to build site: %w", err)
+		}
 	}
 
 	d := sb.New()
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	ctx, cancel := newDeployContext(30 * time.Minute)
 	defer cancel()
 
 	output, err := d.Update(ctx, publishDir, proj.ObjectID, deployer.DeployOptions{
-		Epochs:    epochs,
-		Verbose:   true,
-		WalrusCfg: walgoCfg.WalrusConfig,
+		Epochs:      epochs,
+		Verbose:     true,
+		WalrusCfg:   walgoCfg.WalrusConfig,
+		CheckExtend: opts.CheckExtend,
 	})
 
 	if err != nil {
@@ -90,22 +113,22 @@ func updateProjectByRef(proj *projects.Project, epochs int) error {
 		return nil
 	})
 
-	// Try to get actual gas from blockchain, fallback to estimate
-	var gasFee string
-	gasInfo, err := walrus.GetLatestTransactionGas(proj.WalletAddr, proj.Network)
-	if err == nil && gasInfo != nil {
-		if gasInfo.TotalWAL > 0 && gasInfo.TotalGasSUI > 0 {
-			gasFee = fmt.Sprintf("%.6f WAL + %.6f SUI", gasInfo.TotalWAL, gasInfo.TotalGasSUI)
-		} else if gasInfo.TotalWAL > 0 {
-			gasFee = fmt.Sprintf("%.6f WAL", gasInfo.TotalWAL)
-		} else if gasInfo.TotalGasSUI > 0 {
-			gasFee = fmt.Sprintf("%.6f SUI", gasInfo.TotalGasSUI)
-		}
+	// Prefer the costs site-builder reported, then the chain, then an estimate
+	if output.StorageEpochs > 0 {
+		epochs = output.StorageEpochs
 	}
-	// Fallback to estimate if actual gas not available
-	if gasFee == "" {
-		gasFee = projects.EstimateGasFeeWithEpochs(proj.Network, siteSize, epochs)
+	costWAL, costSUI := output.CostWAL, output.GasSUI
+	if costWAL == 0 || costSUI == 0 {
+		if gasInfo, err := walrus.GetLatestTransactionGas(proj.WalletAddr, proj.Network); err == nil && gasInfo != nil {
+			if costWAL == 0 {
+				costWAL = gasInfo.TotalWAL
+			}
+			if costSUI == 0 {
+				costSUI = gasInfo.TotalGasSUI
+			}
+		}
 	}
+	gasFee, costActual := projects.DeployCostFee(costWAL, costSUI, proj.Network, siteSize, epochs)
 
 	proj.Epochs = epochs
 	proj.LastDeployAt = time.Now()
@@ -116,12 +139,15 @@ func updateProjectByRef(proj *projects.Project, epochs int) error {
 	}
 
 	deployment := &projects.DeploymentRecord{
-		ProjectID: proj.ID,
-		ObjectID:  proj.ObjectID,
-		Network:   proj.Network,
-		Epochs:    epochs,
-		GasFee:    gasFee,
-		Success:   true,
+		ProjectID:  proj.ID,
+		ObjectID:   proj.ObjectID,
+		Network:    proj.Network,
+		Epochs:     epochs,
+		GasFee:     gasFee,
+		Success:    true,
+		CostWAL:    costWAL,
+		CostSUI:    costSUI,
+		CostActual: costActual,
 	}
 	if err := pm.RecordDeployment(deployment); err != nil {
 		fmt.Fprintf(os.Stderr, "%s Warning: Failed to record deployment: %v\n", icons.Warning, err)
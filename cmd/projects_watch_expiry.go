@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Defaults for walgo projects watch-expiry.
+const (
+	defaultExpiryInterval  = 6 * time.Hour
+	defaultExpiryThreshold = 7 * 24 * time.Hour
+	expiryWebhookTimeout   = 15 * time.Second
+)
+
+// Events sent to the --webhook URL.
+const (
+	expiryEventExpiring    = "expiring"
+	expiryEventExpired     = "expired"
+	expiryEventRenewed     = "renewed"
+	expiryEventRenewFailed = "renew_failed"
+)
+
+// renewProject extends a project's storage; replaced in tests.
+var renewProject = renewProjectStorage
+
+var projectsWatchExpiryCmd = &cobra.Command{
+	Use:   "watch-expiry",
+	Short: "Keep checking projects for upcoming storage expiry",
+	Long: `Run in the foreground and check every active project for upcoming storage
+expiry, once at start and then every --interval. Each cycle is logged.
+
+Projects expiring within --within are reported, and POSTed as JSON to
+--webhook when one is given. With --auto-renew they are renewed instead:
+their last build is uploaded with 'site-builder update --check-extend', which
+extends every blob of the site, changed or not. Renewals spend WAL and SUI
+from the active wallet. Projects without a local site path, such as those
+found on chain, cannot be renewed and are reported instead. Projects that
+already expired are only reported, since their storage can no longer be
+extended.
+
+A project is reported once per expiry date, not every cycle. Errors in a
+cycle, such as a failed renewal or an unreachable webhook, are logged and
+retried on the next cycle. Press Ctrl+C to stop.
+
+Webhook payload:
+  {"event": "expiring|expired|renewed|renew_failed", "project": "...",
+   "project_id": 1, "object_id": "0x...", "network": "mainnet",
+   "expires_at": "2026-01-02T15:04:05Z", "error": "..."}
+
+Examples:
+  walgo projects watch-expiry
+  walgo projects watch-expiry --interval 6h --webhook https://hooks.example.com/walgo
+  walgo projects watch-expiry --within 72h --auto-renew --epochs 5`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		interval, _ := cmd.Flags().GetDuration("interval")
+		within, _ := cmd.Flags().GetDuration("within")
+		webhook, _ := cmd.Flags().GetString("webhook")
+		autoRenew, _ := cmd.Flags().GetBool("auto-renew")
+		epochs, _ := cmd.Flags().GetInt("epochs")
+
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be greater than 0")
+		}
+		if within <= 0 {
+			return fmt.Errorf("--within must be greater than 0")
+		}
+		if epochs < 0 {
+			return fmt.Errorf("--epochs cannot be negative")
+		}
+		if webhook != "" {
+			if err := validateWebhookURL(webhook); err != nil {
+				return err
+			}
+		}
+
+		pm, err := projects.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to initialize project manager: %w", err)
+		}
+		defer pm.Close()
+
+		w := &expiryWatcher{
+			src:        pm,
+			now:        time.Now,
+			threshold:  within,
+			autoRenew:  autoRenew,
+			epochs:     epochs,
+			webhookURL: webhook,
+			renew:      renewProject,
+			notify:     postExpiryWebhook,
+			out:        os.Stdout,
+		}
+
+		fmt.Printf("%s Watching project expiry every %s (threshold %s", icons.Info, interval, within)
+		if autoRenew {
+			fmt.Printf(", auto-renew on")
+		}
+		fmt.Println(")")
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return w.run(ctx, interval)
+	},
+}
+
+// expiryEvent is the JSON body POSTed to the webhook.
+type expiryEvent struct {
+	Event     string    `json:"event"`
+	Project   string    `json:"project"`
+	ProjectID int64     `json:"project_id"`
+	ObjectID  string    `json:"object_id"`
+	Network   string    `json:"network"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// expiryCycleSummary counts what one cycle did.
+type expiryCycleSummary struct {
+	Checked       int
+	Renewed       int
+	RenewFailed   int
+	Notified      int
+	WebhookFailed int
+}
+
+// expiryWatcher runs the expiry checks of watch-expiry. The clock, project
+// source, renewal and webhook are fields so a cycle can be tested alone.
+type expiryWatcher struct {
+	src        projects.ExpirySource
+	now        func() time.Time
+	threshold  time.Duration
+	autoRenew  bool
+	epochs     int // Epochs per renewal; 0 keeps each project's own
+	webhookURL string
+	renew      func(proj *projects.Project, epochs int) error
+	notify     func(ctx context.Context, webhookURL string, event expiryEvent) error
+	out        io.Writer
+
+	// notified holds the expiry each project was last reported for, so a
+	// project is reported again only when its expiry changes.
+	notified map[int64]time.Time
+}
+
+// run checks once, then every interval until ctx is done. Cycle errors are
+// logged and do not stop the loop.
+func (w *expiryWatcher) run(ctx context.Context, interval time.Duration) error {
+	icons := ui.GetIcons()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.runCycle(ctx); err != nil {
+			fmt.Fprintf(w.out, "%s %s cycle failed: %v (retrying in %s)\n", icons.Warning, w.now().Format(time.RFC3339), err, interval)
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Fprintf(w.out, "%s Stopped watching project expiry\n", icons.Info)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// runCycle checks every project once, renewing or reporting those that need
+// it, and logs a summary line.
+func (w *expiryWatcher) runCycle(ctx context.Context) (expiryCycleSummary, error) {
+	icons := ui.GetIcons()
+	if w.notified == nil {
+		w.notified = make(map[int64]time.Time)
+	}
+
+	now := w.now()
+	decisions, err := projects.PlanExpiryActions(w.src, now, w.threshold, w.autoRenew)
+	if err != nil {
+		return expiryCycleSummary{}, err
+	}
+
+	summary := expiryCycleSummary{Checked: len(decisions)}
+	for _, d := range decisions {
+		proj := d.Project
+		event := expiryEvent{
+			Project:   proj.Name,
+			ProjectID: proj.ID,
+			ObjectID:  proj.ObjectID,
+			Network:   proj.Network,
+			ExpiresAt: d.ExpiresAt,
+		}
+
+		action := d.Action
+		if action == projects.ExpiryRenew && proj.SitePath == "" {
+			// Nothing local to renew from; report it like --auto-renew was off
+			action = projects.ExpiryNotify
+			event.Error = "no local site path to renew from"
+		}
+
+		switch action {
+		case projects.ExpiryNone:
+			delete(w.notified, proj.ID)
+		case projects.ExpiryRenew:
+			fmt.Fprintf(w.out, "%s %s expires in %s, renewing\n", icons.Hourglass, proj.Name, formatRemaining(d.Remaining))
+			if err := w.renew(proj, w.epochs); err != nil {
+				summary.RenewFailed++
+				event.Event = expiryEventRenewFailed
+				event.Error = err.Error()
+				fmt.Fprintf(w.out, "%s Renewing %s failed: %v\n", icons.Error, proj.Name, err)
+			} else {
+				summary.Renewed++
+				event.Event = expiryEventRenewed
+				delete(w.notified, proj.ID)
+				fmt.Fprintf(w.out, "%s Renewed %s\n", icons.Check, proj.Name)
+			}
+			w.send(ctx, event, &summary)
+		case projects.ExpiryNotify:
+			if last, ok := w.notified[proj.ID]; ok && last.Equal(d.ExpiresAt) {
+				continue
+			}
+			event.Event = expiryEventExpiring
+			if d.Expired() {
+				event.Event = expiryEventExpired
+				fmt.Fprintf(w.out, "%s %s (%s) expired on %s\n", icons.Error, proj.Name, proj.ObjectID, d.ExpiresAt.Format("2006-01-02 15:04"))
+			} else {
+				fmt.Fprintf(w.out, "%s %s (%s) expires in %s\n", icons.Warning, proj.Name, proj.ObjectID, formatRemaining(d.Remaining))
+			}
+			if event.Error != "" {
+				fmt.Fprintf(w.out, "  %s Not renewed: %s\n", icons.Info, event.Error)
+			}
+			if w.send(ctx, event, &summary) {
+				w.notified[proj.ID] = d.ExpiresAt
+				summary.Notified++
+			}
+		}
+	}
+
+	fmt.Fprintf(w.out, "%s %s checked %d project(s): %d renewed, %d renewal(s) failed, %d notified",
+		icons.Info, now.Format(time.RFC3339), summary.Checked, summary.Renewed, summary.RenewFailed, summary.Notified)
+	if summary.WebhookFailed > 0 {
+		fmt.Fprintf(w.out, ", %d webhook(s) failed", summary.WebhookFailed)
+	}
+	fmt.Fprintln(w.out)
+	return summary, nil
+}
+
+// send posts event to the webhook, if one is configured. It returns false
+// when the webhook could not be reached, so the event is retried next cycle.
+func (w *expiryWatcher) send(ctx context.Context, event expiryEvent, summary *expiryCycleSummary) bool {
+	if w.webhookURL == "" {
+		return true
+	}
+	if err := w.notify(ctx, w.webhookURL, event); err != nil {
+		summary.WebhookFailed++
+		fmt.Fprintf(w.out, "%s Webhook for %s failed: %v\n", ui.GetIcons().Warning, event.Project, err)
+		return false
+	}
+	return true
+}
+
+// validateWebhookURL accepts absolute http and https URLs.
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid --webhook URL %q: must be an http or https URL", raw)
+	}
+	return nil
+}
+
+// postExpiryWebhook POSTs event as JSON to webhookURL and fails on any
+// non-2xx response.
+func postExpiryWebhook(ctx context.Context, webhookURL string, event expiryEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding webhook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, expiryWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/projects"
+)
+
+// fakeExpiryManager serves projects and epoch info from memory.
+type fakeExpiryManager struct {
+	projects []*projects.Project
+	epochs   map[int64]*projects.EpochInfo
+	listErr  error
+}
+
+func (f *fakeExpiryManager) ListProjectsWithFilter(projects.ProjectFilter) ([]*projects.Project, error) {
+	return f.projects, f.listErr
+}
+
+func (f *fakeExpiryManager) GetEpochInfo(projectID int64) (*projects.EpochInfo, error) {
+	if info, ok := f.epochs[projectID]; ok {
+		return info, nil
+	}
+	return &projects.EpochInfo{}, nil
+}
+
+// expiryFixture has, at now on testnet (one-day epochs), a healthy project,
+// one expiring in two days and one that expired a day ago.
+func expiryFixture(now time.Time) *fakeExpiryManager {
+	day := 24 * time.Hour
+	return &fakeExpiryManager{
+		projects: []*projects.Project{
+			{ID: 1, Name: "healthy", ObjectID: "0x1", Network: "testnet", SitePath: "/sites/healthy"},
+			{ID: 2, Name: "soon", ObjectID: "0x2", Network: "testnet", SitePath: "/sites/soon"},
+			{ID: 3, Name: "expired", ObjectID: "0x3", Network: "testnet", SitePath: "/sites/expired"},
+		},
+		epochs: map[int64]*projects.EpochInfo{
+			1: {TotalEpochs: 30, FirstDeploymentAt: now.Add(-10 * day)},
+			2: {TotalEpochs: 5, FirstDeploymentAt: now.Add(-3 * day)},
+			3: {TotalEpochs: 2, FirstDeploymentAt: now.Add(-3 * day)},
+		},
+	}
+}
+
+// newTestExpiryWatcher returns a watcher over src with a fixed clock that
+// records renewals and webhook events.
+func newTestExpiryWatcher(src projects.ExpirySource, now *time.Time, renewed *[]string, events *[]expiryEvent) *expiryWatcher {
+	return &expiryWatcher{
+		src:        src,
+		now:        func() time.Time { return *now },
+		threshold:  3 * 24 * time.Hour,
+		webhookURL: "https://hooks.example.com/walgo",
+		renew: func(proj *projects.Project, epochs int) error {
+			*renewed = append(*renewed, proj.Name)
+			return nil
+		},
+		notify: func(ctx context.Context, webhookURL string, event expiryEvent) error {
+			*events = append(*events, event)
+			return nil
+		},
+		out: &bytes.Buffer{},
+	}
+}
+
+func eventSummary(events []expiryEvent) string {
+	parts := make([]string, len(events))
+	for i, e := range events {
+		parts[i] = e.Project + ":" + e.Event
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestExpiryWatcherNotifies(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	src := expiryFixture(now)
+	var renewed []string
+	var events []expiryEvent
+	w := newTestExpiryWatcher(src, &now, &renewed, &events)
+
+	summary, err := w.runCycle(context.Background())
+	if err != nil {
+		t.Fatalf("runCycle() error = %v", err)
+	}
+	if got := eventSummary(events); got != "expired:expired,soon:expiring" {
+		t.Errorf("events = %s, want expired and expiring", got)
+	}
+	if len(renewed) != 0 {
+		t.Errorf("renewed %v without --auto-renew", renewed)
+	}
+	if summary.Checked != 3 || summary.Notified != 2 {
+		t.Errorf("summary = %+v, want 3 checked and 2 notified", summary)
+	}
+
+	// Same expiry a cycle later: nothing is reported twice
+	now = now.Add(6 * time.Hour)
+	events = nil
+	if _, err := w.runCycle(context.Background()); err != nil {
+		t.Fatalf("runCycle() error = %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("events repeated: %s", eventSummary(events))
+	}
+
+	// The site is redeployed elsewhere and its expiry moves: reported again
+	// only once it comes close to the new expiry
+	src.epochs[2].TotalEpochs = 30
+	if _, err := w.runCycle(context.Background()); err != nil {
+		t.Fatalf("runCycle() error = %v", err)
+	}
+	src.epochs[2].TotalEpochs = 6
+	events = nil
+	if _, err := w.runCycle(context.Background()); err != nil {
+		t.Fatalf("runCycle() error = %v", err)
+	}
+	if got := eventSummary(events); got != "soon:expiring" {
+		t.Errorf("events = %s, want soon reported again", got)
+	}
+}
+
+func TestExpiryWatcherAutoRenew(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var renewed []string
+	var events []expiryEvent
+	w := newTestExpiryWatcher(expiryFixture(now), &now, &renewed, &events)
+	w.autoRenew = true
+	w.epochs = 5
+
+	var gotEpochs int
+	fail := true
+	w.renew = func(proj *projects.Project, epochs int) error {
+		renewed = append(renewed, proj.Name)
+		gotEpochs = epochs
+		if fail {
+			return errors.New("insufficient WAL balance")
+		}
+		return nil
+	}
+
+	summary, err := w.runCycle(context.Background())
+	if err != nil {
+		t.Fatalf("runCycle() error = %v", err)
+	}
+	if strings.Join(renewed, ",") != "soon" || gotEpochs != 5 {
+		t.Errorf("renewed %v with %d epochs, want only soon with 5", renewed, gotEpochs)
+	}
+	if got := eventSummary(events); got != "expired:expired,soon:renew_failed" {
+		t.Errorf("events = %s", got)
+	}
+	if events[1].Error != "insufficient WAL balance" {
+		t.Errorf("renew_failed event error = %q", events[1].Error)
+	}
+	if summary.RenewFailed != 1 || summary.Renewed != 0 {
+		t.Errorf("summary = %+v, want one failed renewal", summary)
+	}
+
+	// A failed renewal is retried on the next cycle
+	fail = false
+	renewed, events = nil, nil
+	summary, err = w.runCycle(context.Background())
+	if err != nil {
+		t.Fatalf("runCycle() error = %v", err)
+	}
+	if strings.Join(renewed, ",") != "soon" || summary.Renewed != 1 {
+		t.Errorf("renewed %v (summary %+v), want soon renewed on retry", renewed, summary)
+	}
+	if got := eventSummary(events); got != "soon:renewed" {
+		t.Errorf("events = %s, want only the renewal", got)
+	}
+}
+
+func TestExpiryWatcherAutoRenewWithoutSitePath(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	src := expiryFixture(now)
+	src.projects[1].SitePath = ""
+	var renewed []string
+	var events []expiryEvent
+	w := newTestExpiryWatcher(src, &now, &renewed, &events)
+	w.autoRenew = true
+
+	for cycle := 0; cycle < 2; cycle++ {
+		summary, err := w.runCycle(context.Background())
+		if err != nil {
+			t.Fatalf("runCycle() error = %v", err)
+		}
+		if len(renewed) != 0 || summary.RenewFailed != 0 {
+			t.Errorf("cycle %d: renewed %v (summary %+v), want no renewal without a site path", cycle, renewed, summary)
+		}
+	}
+	if got := eventSummary(events); got != "expired:expired,soon:expiring" {
+		t.Fatalf("events = %s, want soon reported once", got)
+	}
+	if events[1].Error != "no local site path to renew from" {
+		t.Errorf("expiring event error = %q", events[1].Error)
+	}
+}
+
+func TestExpiryWatcherWebhookFailureRetried(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var renewed []string
+	var events []expiryEvent
+	w := newTestExpiryWatcher(expiryFixture(now), &now, &renewed, &events)
+
+	down := true
+	w.notify = func(ctx context.Context, webhookURL string, event expiryEvent) error {
+		if down {
+			return errors.New("connection refused")
+		}
+		events = append(events, event)
+		return nil
+	}
+
+	summary, err := w.runCycle(context.Background())
+	if err != nil {
+		t.Fatalf("runCycle() error = %v", err)
+	}
+	if summary.WebhookFailed != 2 || summary.Notified != 0 {
+		t.Errorf("summary = %+v, want 2 webhook failures", summary)
+	}
+	if out := w.out.(*bytes.Buffer).String(); !strings.Contains(out, "2 webhook(s) failed") {
+		t.Errorf("cycle log missing webhook failures:\n%s", out)
+	}
+
+	down = false
+	if _, err := w.runCycle(context.Background()); err != nil {
+		t.Fatalf("runCycle() error = %v", err)
+	}
+	if got := eventSummary(events); got != "expired:expired,soon:expiring" {
+		t.Errorf("events = %s, want both delivered on retry", got)
+	}
+}
+
+func TestExpiryWatcherWithoutWebhook(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var renewed []string
+	var events []expiryEvent
+	w := newTestExpiryWatcher(expiryFixture(now), &now, &renewed, &events)
+	w.webhookURL = ""
+
+	summary, err := w.runCycle(context.Background())
+	if err != nil {
+		t.Fatalf("runCycle() error = %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("webhook called without a URL: %s", eventSummary(events))
+	}
+	if summary.Notified != 2 {
+		t.Errorf("summary = %+v, want 2 notified", summary)
+	}
+	out := w.out.(*bytes.Buffer).String()
+	for _, want := range []string{"soon (0x2) expires in 2 days", "expired (0x3) expired on", "checked 3 project(s)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExpiryWatcherListError(t *testing.T) {
+	now := time.Now()
+	var renewed []string
+	var events []expiryEvent
+	w := newTestExpiryWatcher(&fakeExpiryManager{listErr: errors.New("database is locked")}, &now, &renewed, &events)
+
+	if _, err := w.runCycle(context.Background()); err == nil || !strings.Contains(err.Error(), "database is locked") {
+		t.Fatalf("runCycle() error = %v, want list error", err)
+	}
+}
+
+func TestPostExpiryWebhook(t *testing.T) {
+	var got expiryEvent
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	event := expiryEvent{Event: expiryEventExpiring, Project: "blog", ProjectID: 2, ObjectID: "0x2", Network: "mainnet"}
+	if err := postExpiryWebhook(context.Background(), server.URL, event); err != nil {
+		t.Fatalf("postExpiryWebhook() error = %v", err)
+	}
+	if got != event {
+		t.Errorf("payload = %+v, want %+v", got, event)
+	}
+
+	status = http.StatusInternalServerError
+	if err := postExpiryWebhook(context.Background(), server.URL, event); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("postExpiryWebhook() error = %v, want status error", err)
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	for _, raw := range []string{"https://hooks.example.com/x", "http://localhost:8080/hook"} {
+		if err := validateWebhookURL(raw); err != nil {
+			t.Errorf("validateWebhookURL(%q) error = %v", raw, err)
+		}
+	}
+	for _, raw := range []string{"hooks.example.com", "ftp://example.com", "https://"} {
+		if err := validateWebhookURL(raw); err == nil {
+			t.Errorf("validateWebhookURL(%q) accepted", raw)
+		}
+	}
+}
+
+func TestProjectsWatchExpiryCommand(t *testing.T) {
+	tests := []TestCase{
+		{
+			Name:     "Projects watch-expiry help",
+			Args:     []string{"projects", "watch-expiry", "--help"},
+			Contains: []string{"--interval", "--within", "--webhook", "--auto-renew", "--epochs"},
+		},
+		{
+			Name:        "Projects watch-expiry rejects a bad webhook",
+			Args:        []string{"projects", "watch-expiry", "--webhook", "not-a-url"},
+			ExpectError: true,
+			Contains:    []string{"invalid --webhook URL"},
+		},
+		{
+			Name:        "Projects watch-expiry rejects a zero interval",
+			Args:        []string{"projects", "watch-expiry", "--interval", "0s"},
+			ExpectError: true,
+			Contains:    []string{"--interval must be greater than 0"},
+		},
+	}
+	runTestCases(t, rootCmd, tests)
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// resourcesCmd groups commands that edit the site's ws-resources.json.
+var resourcesCmd = &cobra.Command{
+	Use:   "resources",
+	Short: "Manage the site's ws-resources.json",
+	Long: `Inspect and edit ws-resources.json in the publish directory.
+
+Edits are validated and written in the same deterministic format walgo uses
+everywhere, so the file stays diff-friendly. Note that 'walgo build'
+regenerates the default headers, so run these commands after building.
+
+Examples:
+  walgo resources headers list
+  walgo resources headers set "/*.html" X-Frame-Options DENY`,
+}
+
+// resourcesHeadersCmd groups the per-path header commands.
+var resourcesHeadersCmd = &cobra.Command{
+	Use:   "headers",
+	Short: "List, set and remove per-path HTTP headers",
+	Long: `Manage the HTTP response headers Walrus Sites serves for each resource.
+
+Paths are resource paths such as /index.html. A path containing '*' is a glob
+expanded against the files in the publish directory; '*' matches any run of
+characters, including '/'. Quote globs so the shell does not expand them.`,
+}
+
+var resourcesHeadersListCmd = &cobra.Command{
+	Use:   "list [path|glob]",
+	Short: "List headers per path",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		wsPath, publishDir, err := resolveWSResources(cmd)
+		if err != nil {
+			return err
+		}
+
+		headers, err := compress.ListHeaders(wsPath)
+		if err != nil {
+			return err
+		}
+
+		if len(args) > 0 {
+			headers, err = filterHeaders(headers, publishDir, args[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(headers); err != nil {
+				return fmt.Errorf("encoding headers: %w", err)
+			}
+			return nil
+		}
+
+		printResourceHeaders(os.Stdout, headers)
+		return nil
+	},
+}
+
+var resourcesHeadersSetCmd = &cobra.Command{
+	Use:   "set <path|glob> <name> <value>",
+	Short: "Set a header on a path or glob",
+	Long: `Set a header on every matching path, replacing any existing header with
+the same name (names are compared case-insensitively).
+
+Examples:
+  walgo resources headers set /index.html Cache-Control "no-cache"
+  walgo resources headers set "/images/*" Cache-Control "public, max-age=86400"`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+
+		wsPath, publishDir, err := resolveWSResources(cmd)
+		if err != nil {
+			return err
+		}
+
+		paths, err := compress.SetHeader(wsPath, publishDir, args[0], args[1], args[2])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s Set %s on %d path(s)\n", icons.Success, args[1], len(paths))
+		for _, p := range paths {
+			fmt.Printf("  %s %s\n", icons.File, p)
+		}
+		return nil
+	},
+}
+
+var resourcesHeadersRmCmd = &cobra.Command{
+	Use:     "rm <path|glob> <name>",
+	Aliases: []string{"remove"},
+	Short:   "Remove a header from a path or glob",
+	Long: `Remove a header from every matching path. Paths left without any headers
+are dropped. Globs also match configured paths whose files no longer exist.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+
+		wsPath, publishDir, err := resolveWSResources(cmd)
+		if err != nil {
+			return err
+		}
+
+		paths, err := compress.RemoveHeader(wsPath, publishDir, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		if len(paths) == 0 {
+			fmt.Printf("%s No path matching %s has header %s\n", icons.Info, args[0], args[1])
+			return nil
+		}
+
+		fmt.Printf("%s Removed %s from %d path(s)\n", icons.Success, args[1], len(paths))
+		for _, p := range paths {
+			fmt.Printf("  %s %s\n", icons.File, p)
+		}
+		return nil
+	},
+}
+
+// resolveWSResources returns the ws-resources.json path and publish directory,
+// from --dir or the site's walgo.yaml.
+func resolveWSResources(cmd *cobra.Command) (string, string, error) {
+	publishDir, _ := cmd.Flags().GetString("dir")
+	if publishDir == "" {
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return "", "", fmt.Errorf("cannot determine current directory: %w", err)
+		}
+		cfg, err := config.LoadConfigFrom(sitePath)
+		if err != nil {
+			return "", "", fmt.Errorf("error loading config (use --dir to point at the publish directory): %w", err)
+		}
+		publishDir = filepath.Join(sitePath, cfg.HugoConfig.PublishDir)
+	}
+
+	wsPath := filepath.Join(publishDir, compress.WSResourcesFile)
+	if _, err := os.Stat(wsPath); err != nil {
+		if os.IsNotExist(err) {
+			return "", "", fmt.Errorf("%s not found; run 'walgo build' first", wsPath)
+		}
+		return "", "", fmt.Errorf("cannot access %s: %w", wsPath, err)
+	}
+	return wsPath, publishDir, nil
+}
+
+// filterHeaders keeps the configured paths matching pattern.
+func filterHeaders(headers map[string]map[string]string, publishDir, pattern string) (map[string]map[string]string, error) {
+	paths, err := compress.ExpandResourcePaths(publishDir, pattern)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make(map[string]map[string]string)
+	for _, p := range paths {
+		if h, ok := headers[p]; ok {
+			filtered[p] = h
+		}
+	}
+	return filtered, nil
+}
+
+// printResourceHeaders writes each path followed by its headers, both sorted.
+func printResourceHeaders(out io.Writer, headers map[string]map[string]string) {
+	icons := ui.GetIcons()
+
+	if len(headers) == 0 {
+		fmt.Fprintf(out, "%s No headers configured\n", icons.Info)
+		return
+	}
+
+	paths := make([]string, 0, len(headers))
+	for p := range headers {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		fmt.Fprintln(out, p)
+		names := make([]string, 0, len(headers[p]))
+		for name := range headers[p] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(out, "    %s: %s\n", name, headers[p][name])
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(resourcesCmd)
+	resourcesCmd.AddCommand(resourcesHeadersCmd)
+	resourcesHeadersCmd.AddCommand(resourcesHeadersListCmd)
+	resourcesHeadersCmd.AddCommand(resourcesHeadersSetCmd)
+	resourcesHeadersCmd.AddCommand(resourcesHeadersRmCmd)
+
+	resourcesCmd.PersistentFlags().String("dir", "", "Publish directory containing ws-resources.json (default: from walgo.yaml)")
+	resourcesHeadersListCmd.Flags().Bool("json", false, "Print headers as JSON")
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/compress"
+)
+
+func TestResourcesHeadersCommand(t *testing.T) {
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name:     "Headers help",
+			Args:     []string{"resources", "headers", "--help"},
+			Contains: []string{"list", "set", "rm", "--dir"},
+		},
+		{
+			Name:        "Set requires three args",
+			Args:        []string{"resources", "headers", "set", "/index.html", "X-Test"},
+			ExpectError: true,
+			Contains:    []string{"accepts 3 arg(s)"},
+		},
+		{
+			Name:        "Missing ws-resources.json",
+			Args:        []string{"resources", "headers", "list", "--dir", t.TempDir()},
+			ExpectError: true,
+			Contains:    []string{"run 'walgo build' first"},
+		},
+	})
+}
+
+func TestPrintResourceHeaders(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		var out bytes.Buffer
+		printResourceHeaders(&out, nil)
+		if !strings.Contains(out.String(), "No headers configured") {
+			t.Errorf("unexpected output: %s", out.String())
+		}
+	})
+
+	t.Run("sorted paths and names", func(t *testing.T) {
+		var out bytes.Buffer
+		printResourceHeaders(&out, map[string]map[string]string{
+			"/index.html":  {"X-Frame-Options": "DENY", "Cache-Control": "no-cache"},
+			"/css/app.css": {"Content-Type": "text/css"},
+		})
+		want := "/css/app.css\n" +
+			"    Content-Type: text/css\n" +
+			"/index.html\n" +
+			"    Cache-Control: no-cache\n" +
+			"    X-Frame-Options: DENY\n"
+		if out.String() != want {
+			t.Errorf("output =\n%s\nwant\n%s", out.String(), want)
+		}
+	})
+}
+
+func TestResourcesHeadersSetAndRm(t *testing.T) {
+	dir := t.TempDir()
+	wsPath := filepath.Join(dir, compress.WSResourcesFile)
+	if err := os.WriteFile(wsPath, []byte(`{"site_name": "s"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := executeCommand(rootCmd, "resources", "headers", "set", "--dir", dir, "/index.html", "X-Test", "1"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	headers, err := compress.ListHeaders(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers["/index.html"]["X-Test"] != "1" {
+		t.Fatalf("header not set: %v", headers)
+	}
+
+	if _, err := executeCommand(rootCmd, "resources", "headers", "rm", "--dir", dir, "/index.html", "x-test"); err != nil {
+		t.Fatalf("rm failed: %v", err)
+	}
+	data, err := os.ReadFile(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "headers") {
+		t.Errorf("empty headers section should be dropped:\n%s", data)
+	}
+}
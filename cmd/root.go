@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/ui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -86,6 +89,7 @@ func initConfig() {
 		viper.SetConfigName("walgo")
 	}
 
+	loadSiteEnvFile()
 	viper.AutomaticEnv()
 
 	if err := viper.ReadInConfig(); err != nil {
@@ -100,3 +104,15 @@ func initConfig() {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 }
+
+// loadSiteEnvFile populates the process environment from .walgo.env in the
+// site root so ${VAR} references in walgo.yaml resolve.
+func loadSiteEnvFile() {
+	siteRoot := "."
+	if cfgFile != "" {
+		siteRoot = filepath.Dir(cfgFile)
+	}
+	if _, err := config.LoadEnvFile(siteRoot); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Warning: %v\n", ui.GetIcons().Warning, err)
+	}
+}
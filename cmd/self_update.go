@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/selfupdate"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Release lookup, download and install used by self-update; replaced in tests.
+var (
+	fetchLatestRelease = func(ctx context.Context) (*selfupdate.Release, error) {
+		return selfupdate.FetchLatestRelease(ctx, &http.Client{Timeout: 30 * time.Second}, selfupdate.LatestReleaseURL)
+	}
+	downloadRelease = func(ctx context.Context, rel *selfupdate.Release) ([]byte, error) {
+		return selfupdate.DownloadVerified(ctx, &http.Client{Timeout: 10 * time.Minute}, rel, runtime.GOOS, runtime.GOARCH)
+	}
+	currentExecutable = func() (string, error) {
+		exe, err := os.Executable()
+		if err != nil {
+			return "", err
+		}
+		return filepath.EvalSymlinks(exe)
+	}
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update walgo to the latest release",
+	Long: `Download the latest walgo release for this OS and architecture, verify it
+against the release's published SHA-256 checksums and replace the running
+binary.
+
+The new binary is swapped in atomically and run once ('walgo version
+--short') before the old one is discarded; if anything fails, the previous
+binary is restored. Installations managed by a package manager (Homebrew,
+Nix, Snap, Scoop, Chocolatey or the system package manager) are left alone:
+update those with the package manager.
+
+Examples:
+  walgo self-update --check   # Only report whether an update is available
+  walgo self-update
+  walgo self-update --force   # Reinstall even if already up to date`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		checkOnly, _ := cmd.Flags().GetBool("check")
+		force, _ := cmd.Flags().GetBool("force")
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Minute)
+		defer cancel()
+		return runSelfUpdate(ctx, checkOnly, force, os.Stdout)
+	},
+}
+
+// runSelfUpdate checks for a newer release and, unless checkOnly is set,
+// installs it over the running binary.
+func runSelfUpdate(ctx context.Context, checkOnly, force bool, out io.Writer) error {
+	icons := ui.GetIcons()
+
+	exePath, err := currentExecutable()
+	if err != nil {
+		return fmt.Errorf("cannot locate the walgo binary: %w", err)
+	}
+	if manager, ok := selfupdate.PackageManager(exePath); ok {
+		return fmt.Errorf("walgo at %s is managed by %s; update it with %s instead", exePath, manager, manager)
+	}
+
+	fmt.Fprintf(out, "%s Checking for updates...\n", icons.Info)
+	rel, err := fetchLatestRelease(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := strings.TrimPrefix(Version, "v")
+	newer := compareSemver(rel.Version, current) > 0
+	if !newer && !force {
+		fmt.Fprintf(out, "%s walgo v%s is the latest version\n", icons.Check, current)
+		return nil
+	}
+	if checkOnly {
+		if newer {
+			fmt.Fprintf(out, "%s New version available: v%s (you have v%s)\n", icons.Lightbulb, rel.Version, current)
+			fmt.Fprintf(out, "   Install it with: walgo self-update\n")
+		} else {
+			fmt.Fprintf(out, "%s walgo v%s is the latest version\n", icons.Check, current)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s Downloading walgo v%s for %s/%s...\n", icons.Download, rel.Version, runtime.GOOS, runtime.GOARCH)
+	binary, err := downloadRelease(ctx, rel)
+	if err != nil {
+		return fmt.Errorf("update aborted: %w", err)
+	}
+	fmt.Fprintf(out, "%s Checksum verified\n", icons.Check)
+
+	if err := selfupdate.ReplaceExecutable(exePath, binary, runNewBinary); err != nil {
+		return fmt.Errorf("update failed, v%s is still installed: %w", current, err)
+	}
+
+	fmt.Fprintf(out, "%s Updated walgo v%s → v%s (%s)\n", icons.Success, current, rel.Version, exePath)
+	if rel.URL != "" {
+		fmt.Fprintf(out, "   Release notes: %s\n", rel.URL)
+	}
+	return nil
+}
+
+// runNewBinary checks that a freshly installed binary starts.
+func runNewBinary(path string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path, "version", "--short") // #nosec G204 - path is the binary just installed
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(selfUpdateCmd)
+	selfUpdateCmd.Flags().Bool("check", false, "Only check whether an update is available")
+	selfUpdateCmd.Flags().Bool("force", false, "Reinstall the latest release even if already up to date")
+}
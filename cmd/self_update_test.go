@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/selfupdate"
+)
+
+// stubSelfUpdate replaces the release hooks with a latest release of
+// version and an executable at exePath, restoring them after the test.
+func stubSelfUpdate(t *testing.T, exePath, version string, download func() ([]byte, error)) {
+	t.Helper()
+	origFetch, origDownload, origExe := fetchLatestRelease, downloadRelease, currentExecutable
+	t.Cleanup(func() {
+		fetchLatestRelease, downloadRelease, currentExecutable = origFetch, origDownload, origExe
+	})
+
+	currentExecutable = func() (string, error) { return exePath, nil }
+	fetchLatestRelease = func(context.Context) (*selfupdate.Release, error) {
+		return &selfupdate.Release{Version: version, URL: "https://example.com/v" + version}, nil
+	}
+	downloadRelease = func(context.Context, *selfupdate.Release) ([]byte, error) {
+		if download == nil {
+			t.Fatal("release downloaded unexpectedly")
+		}
+		return download()
+	}
+}
+
+func TestRunSelfUpdateRefusesPackageManagerInstall(t *testing.T) {
+	stubSelfUpdate(t, "/opt/homebrew/Cellar/walgo/0.1.0/bin/walgo", "99.0.0", nil)
+
+	err := runSelfUpdate(context.Background(), false, false, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "managed by Homebrew") {
+		t.Fatalf("runSelfUpdate() error = %v, want package manager refusal", err)
+	}
+}
+
+func TestRunSelfUpdateUpToDate(t *testing.T) {
+	stubSelfUpdate(t, filepath.Join(t.TempDir(), "walgo"), strings.TrimPrefix(Version, "v"), nil)
+
+	var out bytes.Buffer
+	if err := runSelfUpdate(context.Background(), false, false, &out); err != nil {
+		t.Fatalf("runSelfUpdate() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "is the latest version") {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestRunSelfUpdateCheckOnly(t *testing.T) {
+	stubSelfUpdate(t, filepath.Join(t.TempDir(), "walgo"), "99.0.0", nil)
+
+	var out bytes.Buffer
+	if err := runSelfUpdate(context.Background(), true, false, &out); err != nil {
+		t.Fatalf("runSelfUpdate() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "New version available: v99.0.0") {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestRunSelfUpdateKeepsBinaryOnBadDownload(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "walgo")
+	if err := os.WriteFile(exe, []byte("current"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	stubSelfUpdate(t, exe, "99.0.0", func() ([]byte, error) {
+		return nil, selfupdate.ErrChecksumMismatch
+	})
+
+	err := runSelfUpdate(context.Background(), false, false, &bytes.Buffer{})
+	if !errors.Is(err, selfupdate.ErrChecksumMismatch) {
+		t.Fatalf("runSelfUpdate() error = %v, want checksum mismatch", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "current" {
+		t.Errorf("binary = %q, want it untouched", data)
+	}
+}
+
+func TestSelfUpdateCommand(t *testing.T) {
+	tests := []TestCase{
+		{
+			Name:     "Self-update help",
+			Args:     []string{"self-update", "--help"},
+			Contains: []string{"checksums", "--check", "--force"},
+		},
+	}
+	runTestCases(t, rootCmd, tests)
+}
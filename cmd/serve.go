@@ -6,10 +6,8 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"strconv"
 	"strings"
 
-	"github.com/selimozten/walgo/internal/deps"
 	"github.com/selimozten/walgo/internal/hugo"
 	"github.com/selimozten/walgo/internal/ui"
 	"github.com/spf13/cobra"
@@ -25,7 +23,17 @@ var serveCmd = &cobra.Command{
 This command is a wrapper around 'hugo server' and supports common flags.
 The server will typically be available at http://localhost:1313 (or the port you specify).
 Any unrecognized flags will be passed directly to 'hugo server'.
-Press Ctrl+C to stop the server.`,
+Press Ctrl+C to stop the server.
+
+By default the server only listens on 127.0.0.1. To preview on a phone or
+another device on your network, bind to all interfaces with --bind 0.0.0.0.
+Add --tls to serve over HTTPS with a throwaway self-signed certificate, which
+service workers and some browser APIs require off localhost.
+
+Examples:
+  walgo serve
+  walgo serve --port 8080 -D
+  walgo serve --bind 0.0.0.0 --tls`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		icons := ui.GetIcons()
 		fmt.Printf("%s Starting local Hugo development server...\n", icons.Rocket)
@@ -35,55 +43,36 @@ Press Ctrl+C to stop the server.`,
 			fmt.Fprintf(os.Stderr, "%s Warning: Error cleaning up existing Hugo processes: %v\n", icons.Warning, err)
 		}
 
-		// Check if Hugo is installed
-		hugoPath, err := deps.LookPath("hugo")
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s Error: Hugo is not installed or not found in PATH\n", icons.Error)
-			fmt.Fprintf(os.Stderr, "\n%s Install Hugo: https://gohugo.io/installation/\n", icons.Lightbulb)
-			return fmt.Errorf("hugo is not installed or not found in PATH")
-		}
-
 		sitePath, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%s Error: Cannot determine current directory: %v\n", icons.Error, err)
 			return fmt.Errorf("cannot determine current directory: %w", err)
 		}
 
-		hugoArgs := []string{"server"}
-
-		// Append flags recognized by walgo serve
-		drafts, err := cmd.Flags().GetBool("drafts")
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading drafts flag: %v\n", err)
-			return fmt.Errorf("error reading drafts flag: %w", err)
-		}
-		if drafts {
-			hugoArgs = append(hugoArgs, "-D")
-		}
-		expired, err := cmd.Flags().GetBool("expired")
+		// Serve with the Hugo release the site pins, or the hugo on PATH
+		hugoPath, err := hugo.SiteHugoBinary(sitePath, os.Stderr)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading expired flag: %v\n", err)
-			return fmt.Errorf("error reading expired flag: %w", err)
-		}
-		if expired {
-			hugoArgs = append(hugoArgs, "-E")
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			fmt.Fprintf(os.Stderr, "\n%s Install Hugo: https://gohugo.io/installation/\n", icons.Lightbulb)
+			return err
 		}
-		future, err := cmd.Flags().GetBool("future")
+
+		opts, err := serveOptionsFromFlags(cmd)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading future flag: %v\n", err)
-			return fmt.Errorf("error reading future flag: %w", err)
-		}
-		if future {
-			hugoArgs = append(hugoArgs, "-F")
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
 		}
-		port, err := cmd.Flags().GetInt("port")
+		warnExposedBind(os.Stderr, opts.Bind)
+
+		cleanup, err := hugo.PrepareTLS(&opts)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading port flag: %v\n", err)
-			return fmt.Errorf("error reading port flag: %w", err)
+			return fmt.Errorf("failed to prepare TLS certificate: %w", err)
 		}
-		if port != 0 {
-			hugoArgs = append(hugoArgs, "--port", strconv.Itoa(port))
+		defer cleanup()
+		if opts.TLS {
+			fmt.Printf("%s Serving over HTTPS with a self-signed certificate; your browser will ask you to trust it\n", icons.Lock)
 		}
+		hugoArgs := hugo.ServerArgs(opts)
 
 		err = hugo.BuildSite(sitePath)
 		if err != nil {
@@ -131,11 +120,59 @@ func init() {
 	serveCmd.Flags().BoolP("expired", "E", false, "Include content with expiry date in the past (passed to 'hugo server -E')")
 	serveCmd.Flags().BoolP("future", "F", false, "Include content with publishdate in the future (passed to 'hugo server -F')")
 	serveCmd.Flags().IntP("port", "p", 0, "Port for Hugo server (e.g., 1313). If 0 or not set, Hugo's default (usually 1313) is used.")
+	serveCmd.Flags().String("bind", hugo.DefaultServeBind, "Interface to listen on (use 0.0.0.0 to preview from other devices on your network)")
+	serveCmd.Flags().Bool("tls", false, "Serve over HTTPS with a generated self-signed certificate")
 
 	// Allow unknown flags to be passed through to hugo server
 	serveCmd.FParseErrWhitelist.UnknownFlags = true
 }
 
+// serveOptionsFromFlags reads and validates the serve flags.
+func serveOptionsFromFlags(cmd *cobra.Command) (hugo.ServeOptions, error) {
+	var opts hugo.ServeOptions
+	var err error
+
+	if opts.Drafts, err = cmd.Flags().GetBool("drafts"); err != nil {
+		return opts, fmt.Errorf("error reading drafts flag: %w", err)
+	}
+	if opts.Expired, err = cmd.Flags().GetBool("expired"); err != nil {
+		return opts, fmt.Errorf("error reading expired flag: %w", err)
+	}
+	if opts.Future, err = cmd.Flags().GetBool("future"); err != nil {
+		return opts, fmt.Errorf("error reading future flag: %w", err)
+	}
+	if opts.Port, err = cmd.Flags().GetInt("port"); err != nil {
+		return opts, fmt.Errorf("error reading port flag: %w", err)
+	}
+	if opts.Port < 0 || opts.Port > 65535 {
+		return opts, fmt.Errorf("invalid port %d: must be between 1 and 65535", opts.Port)
+	}
+	if opts.TLS, err = cmd.Flags().GetBool("tls"); err != nil {
+		return opts, fmt.Errorf("error reading tls flag: %w", err)
+	}
+
+	bind, err := cmd.Flags().GetString("bind")
+	if err != nil {
+		return opts, fmt.Errorf("error reading bind flag: %w", err)
+	}
+	if opts.Bind, err = hugo.ParseBindAddress(bind); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// warnExposedBind warns when the preview server is reachable from other machines.
+func warnExposedBind(w io.Writer, bind string) {
+	if !hugo.IsExposedBind(bind) {
+		return
+	}
+	icons := ui.GetIcons()
+	fmt.Fprintf(w, "%s Listening on %s: anyone on your network can reach this preview, drafts included\n", icons.Warning, bind)
+	if lan := hugo.LANAddresses(); len(lan) > 0 {
+		fmt.Fprintf(w, "%s Other devices can use: %s\n", icons.Lightbulb, strings.Join(lan, ", "))
+	}
+}
+
 // filterHugoOutput filters Hugo server output to show only essential info
 func filterHugoOutput(r io.Reader, w io.Writer, icons *ui.Icons) {
 	scanner := bufio.NewScanner(r)
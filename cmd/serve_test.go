@@ -21,6 +21,8 @@ func TestServeCommand(t *testing.T) {
 				"hugo server",
 				"--port",
 				"--drafts",
+				"--bind",
+				"--tls",
 			},
 		},
 	}
@@ -28,6 +30,51 @@ func TestServeCommand(t *testing.T) {
 	runTestCases(t, rootCmd, tests)
 }
 
+func TestServeOptionsFromFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		t.Helper()
+		c := &cobra.Command{Use: "serve"}
+		c.Flags().BoolP("drafts", "D", false, "")
+		c.Flags().BoolP("expired", "E", false, "")
+		c.Flags().BoolP("future", "F", false, "")
+		c.Flags().IntP("port", "p", 0, "")
+		c.Flags().String("bind", "127.0.0.1", "")
+		c.Flags().Bool("tls", false, "")
+		if err := c.Flags().Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	opts, err := serveOptionsFromFlags(newCmd("--bind", "0.0.0.0", "--tls", "-D", "-p", "8080"))
+	if err != nil {
+		t.Fatalf("serveOptionsFromFlags() error = %v", err)
+	}
+	if opts.Bind != "0.0.0.0" || !opts.TLS || !opts.Drafts || opts.Port != 8080 {
+		t.Errorf("serveOptionsFromFlags() = %+v", opts)
+	}
+
+	if _, err := serveOptionsFromFlags(newCmd("--bind", "not-an-ip")); err == nil {
+		t.Error("expected error for invalid bind address")
+	}
+	if _, err := serveOptionsFromFlags(newCmd("--port", "70000")); err == nil {
+		t.Error("expected error for out-of-range port")
+	}
+}
+
+func TestWarnExposedBind(t *testing.T) {
+	var buf bytes.Buffer
+	warnExposedBind(&buf, "127.0.0.1")
+	if buf.Len() != 0 {
+		t.Errorf("no warning expected for loopback, got %q", buf.String())
+	}
+
+	warnExposedBind(&buf, "0.0.0.0")
+	if !strings.Contains(buf.String(), "anyone on your network") {
+		t.Errorf("expected exposure warning, got %q", buf.String())
+	}
+}
+
 func TestServeCommandFlags(t *testing.T) {
 	// Find the serve command
 	var serveCommand *cobra.Command
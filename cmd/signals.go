@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// newDeployContext returns a context that is cancelled when the timeout elapses
+// or when the user interrupts the process (Ctrl-C / SIGTERM). Cancelling it
+// terminates any running site-builder subprocess.
+func newDeployContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := context.WithTimeout(sigCtx, timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// isInterrupted reports whether err was caused by a user interrupt rather than a failure.
+func isInterrupted(ctx context.Context, err error) bool {
+	return err != nil && errors.Is(ctx.Err(), context.Canceled)
+}
@@ -20,15 +20,49 @@ var statusCmd = &cobra.Command{
 	Long: `Checks and displays the current status and resources of your site on Walrus Sites.
 This command uses the site-builder's 'sitemap' command to show the resources that compose the site.
 
-You can provide the object ID as an argument, or the command will look for it in walgo.yaml.`,
+You can provide the object ID as an argument, or the command will look for it in walgo.yaml.
+
+With --all, every active project with a deployed site is queried concurrently
+and summarized in one table: name, network, expiry countdown, resource count,
+and whether the site object could be read. Unreachable sites are listed, not
+omitted, and make the command exit non-zero.
+
+With --resources, every resource stored on the site object is listed, sorted
+by path: path, blob ID, content type and size. Content type and size come
+from the local build when walgo.yaml in the current directory is for the
+site. Add --json for machine-readable output.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		icons := ui.GetIcons()
+
+		all, _ := cmd.Flags().GetBool("all")
+		if all {
+			if len(args) > 0 {
+				return fmt.Errorf("--all cannot be combined with an object ID")
+			}
+			if r, _ := cmd.Flags().GetBool("resources"); r {
+				return fmt.Errorf("--all cannot be combined with --resources")
+			}
+			concurrency, _ := cmd.Flags().GetInt("concurrency")
+			if err := ensureOnline(cmd.Context()); err != nil {
+				return err
+			}
+			return runStatusAll(concurrency)
+		}
+
+		resources, _ := cmd.Flags().GetBool("resources")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON && !resources {
+			return fmt.Errorf("--json requires --resources")
+		}
+
 		var objectID string
 
 		if len(args) > 0 {
 			objectID = args[0]
-			fmt.Printf("Checking status for object ID: %s\n", objectID)
+			if !asJSON {
+				fmt.Printf("Checking status for object ID: %s\n", objectID)
+			}
 		} else {
 
 			sitePath, err := os.Getwd()
@@ -43,7 +77,7 @@ You can provide the object ID as an argument, or the command will look for it in
 				return fmt.Errorf("error loading config: %w", err)
 			}
 
-			if cfg.WalrusConfig.ProjectID == "" || cfg.WalrusConfig.ProjectID == "YOUR_WALRUS_PROJECT_ID" {
+			if config.IsPlaceholderProjectID(cfg.WalrusConfig.ProjectID) {
 				fmt.Fprintf(os.Stderr, "No object ID provided and no valid ProjectID in walgo.yaml.\n")
 				fmt.Fprintf(os.Stderr, "Usage: walgo status <object-id>\n")
 				fmt.Fprintf(os.Stderr, "Or configure the ProjectID in walgo.yaml if it represents a site object ID.\n")
@@ -51,7 +85,21 @@ You can provide the object ID as an argument, or the command will look for it in
 			}
 
 			objectID = cfg.WalrusConfig.ProjectID
-			fmt.Printf("Using object ID from walgo.yaml: %s\n", objectID)
+			if !asJSON {
+				fmt.Printf("Using object ID from walgo.yaml: %s\n", objectID)
+			}
+		}
+
+		if err := ensureOnline(cmd.Context()); err != nil {
+			return err
+		}
+
+		if resources {
+			if err := runStatusResources(os.Stdout, objectID, localPublishDir(objectID), asJSON); err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return err
+			}
+			return nil
 		}
 
 		d := sb.New()
@@ -76,5 +124,9 @@ You can provide the object ID as an argument, or the command will look for it in
 }
 
 func init() {
+	statusCmd.Flags().Bool("all", false, "Show the on-chain status of every deployed project")
+	statusCmd.Flags().Int("concurrency", defaultStatusConcurrency, "Maximum sites queried at once with --all")
+	statusCmd.Flags().Bool("resources", false, "List every resource stored on the site object")
+	statusCmd.Flags().Bool("json", false, "Print --resources output as JSON")
 	rootCmd.AddCommand(statusCmd)
 }
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// defaultStatusConcurrency bounds how many sitemap queries run at once.
+const defaultStatusConcurrency = 4
+
+// querySiteStatus is a test hook for fetching one site's on-chain status.
+var querySiteStatus = walrus.QuerySiteStatus
+
+// statusTarget is a project to query, with its expiry computed up front from
+// the local deployment history.
+type statusTarget struct {
+	Project projects.Project
+	Expires string
+}
+
+// statusRow is one line of the `walgo status --all` table.
+type statusRow struct {
+	Name      string
+	Network   string
+	ObjectID  string
+	Expires   string
+	Resources int
+	Reachable bool
+	Err       error
+}
+
+// buildStatusRow turns the result of a status query into a table row. A query
+// error or unsuccessful result marks the row unreachable rather than dropping it.
+func buildStatusRow(target statusTarget, out *walrus.SiteBuilderOutput, err error) statusRow {
+	row := statusRow{
+		Name:     target.Project.Name,
+		Network:  target.Project.Network,
+		ObjectID: target.Project.ObjectID,
+		Expires:  target.Expires,
+	}
+	if row.Expires == "" {
+		row.Expires = "unknown"
+	}
+	switch {
+	case err != nil:
+		row.Err = err
+	case out == nil || !out.Success:
+		row.Err = fmt.Errorf("site object could not be read")
+	default:
+		row.Reachable = true
+		row.Resources = len(out.Resources)
+	}
+	return row
+}
+
+// collectStatuses queries every target with at most concurrency queries in
+// flight. Rows come back in target order; one failing site never affects another.
+func collectStatuses(ctx context.Context, targets []statusTarget, concurrency int) []statusRow {
+	if concurrency <= 0 {
+		concurrency = defaultStatusConcurrency
+	}
+
+	rows := make([]statusRow, len(targets))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target statusTarget) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				rows[i] = buildStatusRow(target, nil, ctx.Err())
+				return
+			}
+
+			out, err := querySiteStatus(ctx, target.Project.ObjectID, target.Project.Network)
+			rows[i] = buildStatusRow(target, out, err)
+		}(i, target)
+	}
+
+	wg.Wait()
+	return rows
+}
+
+// printStatusTable writes the aggregated status table and returns the number
+// of unreachable sites.
+func printStatusTable(w io.Writer, rows []statusRow) int {
+	icons := ui.GetIcons()
+
+	nameWidth := len("NAME")
+	for _, r := range rows {
+		if len(r.Name) > nameWidth {
+			nameWidth = len(r.Name)
+		}
+	}
+
+	fmt.Fprintf(w, "%-*s  %-8s  %-16s  %9s  %s\n", nameWidth, "NAME", "NETWORK", "EXPIRES", "RESOURCES", "REACHABLE")
+	unreachable := 0
+	for _, r := range rows {
+		resources := "-"
+		reachable := icons.Check + " yes"
+		if r.Reachable {
+			resources = fmt.Sprintf("%d", r.Resources)
+		} else {
+			unreachable++
+			reachable = icons.Cross + " UNREACHABLE"
+		}
+		fmt.Fprintf(w, "%-*s  %-8s  %-16s  %9s  %s\n", nameWidth, r.Name, r.Network, r.Expires, resources, reachable)
+	}
+
+	if unreachable > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%s Unreachable sites:\n", icons.Warning)
+		for _, r := range rows {
+			if r.Reachable {
+				continue
+			}
+			msg := "unknown error"
+			if r.Err != nil {
+				msg = strings.SplitN(r.Err.Error(), "\n", 2)[0]
+			}
+			fmt.Fprintf(w, "   • %s (%s): %s\n", r.Name, r.ObjectID, msg)
+		}
+	}
+	return unreachable
+}
+
+// runStatusAll reports the on-chain status of every active project.
+func runStatusAll(concurrency int) error {
+	icons := ui.GetIcons()
+
+	pm, err := projects.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to initialize project manager: %w", err)
+	}
+	defer pm.Close()
+
+	projectList, err := pm.ListProjectsWithFilter(projects.ProjectFilter{Status: "active"})
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	var targets []statusTarget
+	for _, proj := range projectList {
+		if proj.ObjectID == "" {
+			continue
+		}
+		target := statusTarget{Project: *proj}
+		if info, err := pm.GetEpochInfo(proj.ID); err == nil && info.TotalEpochs > 0 && !info.FirstDeploymentAt.IsZero() {
+			target.Expires = formatExpiryDuration(calculateExpiryDate(info.FirstDeploymentAt, info.TotalEpochs, proj.Network))
+		} else if proj.Epochs > 0 && !proj.LastDeployAt.IsZero() {
+			target.Expires = formatExpiryDuration(calculateExpiryDate(proj.LastDeployAt, proj.Epochs, proj.Network))
+		}
+		targets = append(targets, target)
+	}
+
+	if len(targets) == 0 {
+		fmt.Println("No deployed projects found.")
+		fmt.Printf("%s Deploy your first site with: walgo launch\n", icons.Lightbulb)
+		return nil
+	}
+
+	if err := walrus.CheckSiteBuilderSetup(); err != nil {
+		return fmt.Errorf("site-builder setup issue: %w", err)
+	}
+
+	fmt.Printf("%s Checking %d sites...\n\n", icons.Info, len(targets))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	rows := collectStatuses(ctx, targets, concurrency)
+	if unreachable := printStatusTable(os.Stdout, rows); unreachable > 0 {
+		return fmt.Errorf("%d of %d sites unreachable", unreachable, len(rows))
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+func statusTargetFor(name, objectID, network, expires string) statusTarget {
+	return statusTarget{
+		Project: projects.Project{Name: name, ObjectID: objectID, Network: network},
+		Expires: expires,
+	}
+}
+
+func TestBuildStatusRow(t *testing.T) {
+	target := statusTargetFor("blog", "0xabc", "mainnet", "3 weeks")
+
+	tests := []struct {
+		name          string
+		out           *walrus.SiteBuilderOutput
+		err           error
+		wantReachable bool
+		wantResources int
+	}{
+		{
+			name:          "parsed status",
+			out:           &walrus.SiteBuilderOutput{Success: true, Resources: make([]walrus.Resource, 12)},
+			wantReachable: true,
+			wantResources: 12,
+		},
+		{
+			name: "query error",
+			err:  errors.New("object not found"),
+		},
+		{
+			name: "unsuccessful output",
+			out:  &walrus.SiteBuilderOutput{Success: false},
+		},
+		{
+			name: "nil output",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := buildStatusRow(target, tt.out, tt.err)
+			if row.Name != "blog" || row.Network != "mainnet" || row.Expires != "3 weeks" {
+				t.Errorf("row lost project fields: %+v", row)
+			}
+			if row.Reachable != tt.wantReachable {
+				t.Errorf("Reachable = %v, want %v", row.Reachable, tt.wantReachable)
+			}
+			if row.Resources != tt.wantResources {
+				t.Errorf("Resources = %d, want %d", row.Resources, tt.wantResources)
+			}
+			if !row.Reachable && row.Err == nil {
+				t.Error("unreachable row should carry an error")
+			}
+		})
+	}
+
+	t.Run("missing expiry is shown as unknown", func(t *testing.T) {
+		row := buildStatusRow(statusTargetFor("docs", "0x1", "testnet", ""), nil, errors.New("x"))
+		if row.Expires != "unknown" {
+			t.Errorf("Expires = %q, want unknown", row.Expires)
+		}
+	})
+}
+
+func TestCollectStatuses(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	orig := querySiteStatus
+	querySiteStatus = func(ctx context.Context, objectID, network string) (*walrus.SiteBuilderOutput, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		switch objectID {
+		case "0xbad":
+			return nil, errors.New("sitemap failed")
+		case "0xempty":
+			return &walrus.SiteBuilderOutput{Success: false}, nil
+		}
+		return &walrus.SiteBuilderOutput{Success: true, Resources: make([]walrus.Resource, 3)}, nil
+	}
+	t.Cleanup(func() { querySiteStatus = orig })
+
+	targets := []statusTarget{
+		statusTargetFor("a", "0x1", "testnet", "1 day"),
+		statusTargetFor("b", "0xbad", "mainnet", "2 weeks"),
+		statusTargetFor("c", "0x3", "testnet", "5 days"),
+		statusTargetFor("d", "0xempty", "testnet", "Expired"),
+		statusTargetFor("e", "0x5", "mainnet", "4 weeks"),
+	}
+
+	rows := collectStatuses(context.Background(), targets, 2)
+
+	if len(rows) != len(targets) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(targets))
+	}
+	for i, r := range rows {
+		if r.Name != targets[i].Project.Name {
+			t.Errorf("row %d = %q, want %q (rows must keep target order)", i, r.Name, targets[i].Project.Name)
+		}
+	}
+	wantReachable := map[string]bool{"a": true, "b": false, "c": true, "d": false, "e": true}
+	for _, r := range rows {
+		if r.Reachable != wantReachable[r.Name] {
+			t.Errorf("%s: Reachable = %v, want %v", r.Name, r.Reachable, wantReachable[r.Name])
+		}
+	}
+	if maxInFlight > 2 {
+		t.Errorf("max concurrent queries = %d, want <= 2", maxInFlight)
+	}
+}
+
+func TestPrintStatusTable(t *testing.T) {
+	rows := []statusRow{
+		{Name: "blog", Network: "mainnet", ObjectID: "0x1", Expires: "3 weeks", Resources: 42, Reachable: true},
+		{Name: "old-docs", Network: "testnet", ObjectID: "0x2", Expires: "Expired", Err: errors.New("object deleted\nstderr: details")},
+	}
+
+	var buf bytes.Buffer
+	unreachable := printStatusTable(&buf, rows)
+	out := buf.String()
+
+	if unreachable != 1 {
+		t.Errorf("unreachable = %d, want 1", unreachable)
+	}
+	for _, want := range []string{"NAME", "NETWORK", "EXPIRES", "RESOURCES", "REACHABLE", "blog", "42", "old-docs", "UNREACHABLE", "object deleted"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "stderr: details") {
+		t.Errorf("only the first line of an error should be shown:\n%s", out)
+	}
+}
+
+func TestStatusAllFlags(t *testing.T) {
+	statusCommand := findCommand(rootCmd, "status")
+	if statusCommand == nil {
+		t.Fatal("status command not found")
+	}
+	for _, name := range []string{"all", "concurrency"} {
+		if statusCommand.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+
+	t.Run("--all rejects an object ID", func(t *testing.T) {
+		defer func() { _ = statusCommand.Flags().Set("all", "false") }()
+		_, err := executeCommand(rootCmd, "status", "--all", "0x123")
+		if err == nil || !strings.Contains(err.Error(), "--all") {
+			t.Errorf("expected --all conflict error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// getResourceList is a test hook for reading a site's on-chain resources.
+var getResourceList = walrus.GetResourceList
+
+// resourceRow is one resource as printed by `walgo status --resources --json`.
+type resourceRow struct {
+	Path        string `json:"path"`
+	BlobID      string `json:"blob_id"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+}
+
+// sortedResourceRows converts resources to rows sorted by path.
+func sortedResourceRows(resources []walrus.Resource) []resourceRow {
+	rows := make([]resourceRow, 0, len(resources))
+	for _, r := range resources {
+		rows = append(rows, resourceRow{Path: r.Path, BlobID: r.BlobID, ContentType: r.ContentType, Size: r.Size})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Path < rows[j].Path })
+	return rows
+}
+
+// fillLocalResourceInfo sets the content type and size of rows from the
+// local build in publishDir, which site-builder's sitemap does not report.
+// The content type is the Content-Type header in ws-resources.json, else the
+// one site-builder infers from the file extension. Rows with no local file
+// are left as they are.
+func fillLocalResourceInfo(rows []resourceRow, publishDir string) {
+	headers, _ := compress.ListHeaders(filepath.Join(publishDir, compress.WSResourcesFile))
+	for i := range rows {
+		r := &rows[i]
+		info, err := os.Stat(filepath.Join(publishDir, filepath.FromSlash(strings.TrimPrefix(r.Path, "/"))))
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if r.Size == 0 {
+			r.Size = info.Size()
+		}
+		if r.ContentType == "" {
+			for name, value := range headers[r.Path] {
+				if strings.EqualFold(name, "Content-Type") {
+					r.ContentType = value
+				}
+			}
+		}
+		if r.ContentType == "" {
+			r.ContentType = mime.TypeByExtension(path.Ext(r.Path))
+		}
+	}
+}
+
+// localPublishDir returns the publish directory of the site in the current
+// directory when its walgo.yaml is for objectID, or "" otherwise.
+func localPublishDir(objectID string) string {
+	sitePath, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	cfg, err := config.LoadConfigFrom(sitePath)
+	if err != nil || cfg.WalrusConfig.ProjectID != objectID {
+		return ""
+	}
+	return filepath.Join(sitePath, cfg.HugoConfig.PublishDir)
+}
+
+// printResourceTable writes one line per resource: path, blob ID, content
+// type and size in bytes. Values not known locally show as "-".
+func printResourceTable(w io.Writer, rows []resourceRow) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No resources found on the site object.")
+		return
+	}
+
+	pathWidth, blobWidth, typeWidth := len("PATH"), len("BLOB ID"), len("CONTENT TYPE")
+	for _, r := range rows {
+		pathWidth = max(pathWidth, len(r.Path))
+		blobWidth = max(blobWidth, len(r.BlobID))
+		typeWidth = max(typeWidth, len(orDash(r.ContentType)))
+	}
+
+	fmt.Fprintf(w, "%-*s  %-*s  %-*s  %10s\n", pathWidth, "PATH", blobWidth, "BLOB ID", typeWidth, "CONTENT TYPE", "SIZE")
+	var total int64
+	for _, r := range rows {
+		size := "-"
+		if r.Size > 0 {
+			size = strconv.FormatInt(r.Size, 10)
+			total += r.Size
+		}
+		fmt.Fprintf(w, "%-*s  %-*s  %-*s  %10s\n", pathWidth, r.Path, blobWidth, r.BlobID, typeWidth, orDash(r.ContentType), size)
+	}
+	fmt.Fprintf(w, "\n%d resources", len(rows))
+	if total > 0 {
+		fmt.Fprintf(w, ", %d bytes", total)
+	}
+	fmt.Fprintln(w)
+}
+
+// orDash returns s, or "-" when it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// runStatusResources lists every resource of the site at objectID, with
+// content types and sizes from publishDir when it is set.
+func runStatusResources(w io.Writer, objectID, publishDir string, asJSON bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	resources, err := getResourceList(ctx, objectID, "")
+	if err != nil {
+		return fmt.Errorf("error listing site resources: %w", err)
+	}
+	rows := sortedResourceRows(resources)
+	if publishDir != "" {
+		fillLocalResourceInfo(rows, publishDir)
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return fmt.Errorf("encoding resources: %w", err)
+		}
+		return nil
+	}
+	printResourceTable(w, rows)
+	return nil
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// stubResourceList makes getResourceList return resources.
+func stubResourceList(t *testing.T, resources []walrus.Resource) {
+	t.Helper()
+	orig := getResourceList
+	t.Cleanup(func() { getResourceList = orig })
+	getResourceList = func(ctx context.Context, objectID, network string) ([]walrus.Resource, error) {
+		return resources, nil
+	}
+}
+
+func TestRunStatusResourcesTable(t *testing.T) {
+	stubResourceList(t, []walrus.Resource{
+		{Path: "/style.css", BlobID: "blobB", ContentType: "text/css", Size: 812},
+		{Path: "/index.html", BlobID: "blobA", ContentType: "text/html", Size: 5120},
+		{Path: "/about/index.html", BlobID: "blobC"},
+	})
+
+	var out bytes.Buffer
+	if err := runStatusResources(&out, "0xsite", "", false); err != nil {
+		t.Fatalf("runStatusResources() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("got %d lines, want 6:\n%s", len(lines), out.String())
+	}
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "PATH BLOB ID CONTENT TYPE SIZE" {
+		t.Errorf("header = %q", lines[0])
+	}
+
+	want := [][]string{
+		{"/about/index.html", "blobC", "-", "-"},
+		{"/index.html", "blobA", "text/html", "5120"},
+		{"/style.css", "blobB", "text/css", "812"},
+	}
+	for i, w := range want {
+		if got := strings.Fields(lines[i+1]); strings.Join(got, " ") != strings.Join(w, " ") {
+			t.Errorf("row %d = %v, want %v", i, got, w)
+		}
+	}
+	if lines[5] != "3 resources, 5932 bytes" {
+		t.Errorf("summary = %q", lines[5])
+	}
+}
+
+func TestRunStatusResourcesJSON(t *testing.T) {
+	stubResourceList(t, []walrus.Resource{
+		{Path: "/b.html", BlobID: "blob2", ContentType: "text/html", Size: 10},
+		{Path: "/a.html", BlobID: "blob1"},
+	})
+
+	var out bytes.Buffer
+	if err := runStatusResources(&out, "0xsite", "", true); err != nil {
+		t.Fatalf("runStatusResources() error = %v", err)
+	}
+
+	var rows []resourceRow
+	if err := json.Unmarshal(out.Bytes(), &rows); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(rows) != 2 || rows[0].Path != "/a.html" || rows[1].Path != "/b.html" {
+		t.Fatalf("rows = %+v, want sorted by path", rows)
+	}
+	if rows[1].BlobID != "blob2" || rows[1].ContentType != "text/html" || rows[1].Size != 10 {
+		t.Errorf("row = %+v", rows[1])
+	}
+}
+
+func TestRunStatusResourcesFromPublishDir(t *testing.T) {
+	stubResourceList(t, []walrus.Resource{
+		{Path: "/index.html", BlobID: "blobA"},
+		{Path: "/feed", BlobID: "blobB"},
+		{Path: "/gone.css", BlobID: "blobC"},
+	})
+	publishDir := t.TempDir()
+	files := map[string]string{
+		"index.html":        "<html></html>",
+		"feed":              "<rss/>",
+		"ws-resources.json": `{"headers": {"/feed": {"content-type": "application/rss+xml"}}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(publishDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runStatusResources(&out, "0xsite", publishDir, true); err != nil {
+		t.Fatalf("runStatusResources() error = %v", err)
+	}
+	var rows []resourceRow
+	if err := json.Unmarshal(out.Bytes(), &rows); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	want := []resourceRow{
+		{Path: "/feed", BlobID: "blobB", ContentType: "application/rss+xml", Size: 6},
+		{Path: "/gone.css", BlobID: "blobC"},
+		{Path: "/index.html", BlobID: "blobA", ContentType: "text/html; charset=utf-8", Size: 13},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %+v", rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestRunStatusResourcesEmpty(t *testing.T) {
+	stubResourceList(t, nil)
+
+	var out bytes.Buffer
+	if err := runStatusResources(&out, "0xsite", "", false); err != nil {
+		t.Fatalf("runStatusResources() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "No resources found") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	out.Reset()
+	if err := runStatusResources(&out, "0xsite", "", true); err != nil {
+		t.Fatalf("runStatusResources() error = %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "[]" {
+		t.Errorf("JSON output = %q, want []", out.String())
+	}
+}
@@ -26,8 +26,13 @@ This command will:
 2. Download the new theme from GitHub
 3. Update hugo.toml with the new theme name
 
+Archives for pinned refs (version tags or commit SHAs) are cached under
+$XDG_CACHE_HOME/walgo/themes and reused on later installs. Branches are
+always downloaded again. Use --no-cache to bypass the cache.
+
 Examples:
   walgo theme install https://github.com/theNewDynamic/gohugo-theme-ananke
+  walgo theme install https://github.com/alex-shpak/hugo-book --ref v13
   walgo theme install https://github.com/alex-shpak/hugo-book
   walgo theme install https://github.com/panr/hugo-theme-terminal`,
 	Args: cobra.ExactArgs(1),
@@ -50,7 +55,13 @@ Examples:
 		fmt.Printf("%s Installing theme from: %s\n", icons.Package, githubURL)
 		fmt.Println()
 
-		themeName, err := hugo.InstallThemeFromURL(sitePath, githubURL)
+		ref, _ := cmd.Flags().GetString("ref")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+
+		themeName, err := hugo.InstallThemeFromURLWithOptions(sitePath, githubURL, hugo.ThemeDownloadOptions{
+			Ref:     ref,
+			NoCache: noCache,
+		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
 			return err
@@ -163,8 +174,12 @@ func isHugoSite(sitePath string) bool {
 }
 
 func init() {
+	themeInstallCmd.Flags().String("ref", "", "Branch, tag, or commit to install (default: main, then master)")
+	themeInstallCmd.Flags().Bool("no-cache", false, "Download the theme even if a cached archive exists")
+
 	themeCmd.AddCommand(themeInstallCmd)
 	themeCmd.AddCommand(themeListCmd)
 	themeCmd.AddCommand(themeNewCmd)
+	themeCmd.AddCommand(themeParamsCmd)
 	rootCmd.AddCommand(themeCmd)
 }
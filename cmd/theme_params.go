@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var themeParamsCmd = &cobra.Command{
+	Use:   "params [theme]",
+	Short: "Show the params a theme expects",
+	Long: `Analyze a theme's templates and example site and list the params it uses:
+
+  required  site params templates render without an if/with guard
+  optional  site params templates only use when set
+  page      frontmatter params read by page templates
+
+Site params are checked against the [params] table of the site config
+(hugo.toml, config.toml, hugo.yaml or config.yaml) to show which are
+already set. Defaults to the site's configured theme.
+
+Examples:
+  walgo theme params
+  walgo theme params ananke
+  walgo theme params --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("cannot determine current directory: %w", err)
+		}
+
+		themeName := hugo.GetThemeName(sitePath)
+		if len(args) > 0 {
+			themeName = args[0]
+		}
+		if themeName == "" {
+			return fmt.Errorf("no theme configured; pass a theme name")
+		}
+		if _, err := os.Stat(filepath.Join(sitePath, "themes", themeName)); err != nil {
+			return fmt.Errorf("theme '%s' is not installed in themes/", themeName)
+		}
+
+		siteParams, configFile, err := readSiteParams(sitePath)
+		if err != nil {
+			return err
+		}
+
+		report := buildThemeParamsReport(ai.AnalyzeThemeConfig(sitePath, themeName), siteParams)
+		report.ConfigFile = configFile
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				return fmt.Errorf("encoding params: %w", err)
+			}
+			return nil
+		}
+
+		printThemeParamsReport(os.Stdout, report)
+		return nil
+	},
+}
+
+// themeParam is a site param and whether the site config sets it.
+type themeParam struct {
+	Name string `json:"name"`
+	Set  bool   `json:"set"`
+}
+
+// themeParamsReport is what `walgo theme params` prints.
+type themeParamsReport struct {
+	Theme      string       `json:"theme"`
+	ConfigFile string       `json:"configFile,omitempty"`
+	Required   []themeParam `json:"required"`
+	Optional   []themeParam `json:"optional"`
+	Page       []string     `json:"page"`
+}
+
+// buildThemeParamsReport marks each required and optional param of analysis
+// as set when siteParams has it. Hugo param names are case-insensitive.
+func buildThemeParamsReport(analysis *ai.ThemeConfigAnalysis, siteParams map[string]interface{}) themeParamsReport {
+	set := make(map[string]bool, len(siteParams))
+	for k := range siteParams {
+		set[strings.ToLower(k)] = true
+	}
+	mark := func(names []string) []themeParam {
+		params := make([]themeParam, 0, len(names))
+		for _, name := range names {
+			params = append(params, themeParam{Name: name, Set: set[strings.ToLower(name)]})
+		}
+		return params
+	}
+
+	page := append([]string{}, analysis.PageParams...)
+	return themeParamsReport{
+		Theme:    analysis.Theme,
+		Required: mark(analysis.RequiredParams),
+		Optional: mark(analysis.OptionalParams),
+		Page:     page,
+	}
+}
+
+// readSiteParams returns the [params] table of the site config and the config
+// file name. A site without a config file has no params.
+func readSiteParams(sitePath string) (map[string]interface{}, string, error) {
+	path, err := hugo.FindConfigFile(sitePath)
+	if err != nil {
+		return nil, "", nil
+	}
+	name := filepath.Base(path)
+	data, err := os.ReadFile(path) // #nosec G304 - fixed config file names in the site directory
+	if err != nil {
+		return nil, "", fmt.Errorf("reading %s: %w", name, err)
+	}
+
+	var cfg map[string]interface{}
+	if strings.HasSuffix(name, ".toml") {
+		err = toml.Unmarshal(data, &cfg)
+	} else {
+		err = yaml.Unmarshal(data, &cfg)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing %s: %w", name, err)
+	}
+
+	params, _ := cfg["params"].(map[string]interface{})
+	return params, name, nil
+}
+
+// printThemeParamsReport writes the report grouped by kind, marking site
+// params that are already set.
+func printThemeParamsReport(out io.Writer, r themeParamsReport) {
+	icons := ui.GetIcons()
+
+	fmt.Fprintf(out, "%s Params for theme '%s'\n", icons.Search, r.Theme)
+	if r.ConfigFile != "" {
+		fmt.Fprintf(out, "   Checked against %s [params]\n", r.ConfigFile)
+	}
+
+	printSiteParams := func(title string, params []themeParam) {
+		fmt.Fprintf(out, "\n%s:\n", title)
+		if len(params) == 0 {
+			fmt.Fprintln(out, "   (none)")
+			return
+		}
+		for _, p := range params {
+			status := icons.Cross + " not set"
+			if p.Set {
+				status = icons.Check + " set"
+			}
+			fmt.Fprintf(out, "   %-24s %s\n", p.Name, status)
+		}
+	}
+	printSiteParams("Required site params", r.Required)
+	printSiteParams("Optional site params", r.Optional)
+
+	fmt.Fprintf(out, "\nPage params (frontmatter):\n")
+	if len(r.Page) == 0 {
+		fmt.Fprintln(out, "   (none)")
+	} else {
+		fmt.Fprintf(out, "   %s\n", strings.Join(r.Page, ", "))
+	}
+
+	missing := 0
+	for _, p := range r.Required {
+		if !p.Set {
+			missing++
+		}
+	}
+	if missing > 0 {
+		fmt.Fprintf(out, "\n%s %d required param(s) not set; add them under [params] in your site config\n", icons.Lightbulb, missing)
+	}
+}
+
+func init() {
+	themeParamsCmd.Flags().Bool("json", false, "Output as JSON")
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+func TestReadSiteParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		wantKeys []string
+		wantErr  bool
+	}{
+		{
+			name: "hugo.toml",
+			file: "hugo.toml",
+			content: `title = "Site"
+theme = "ananke"
+
+[params]
+  description = "A site"
+  mainSections = ["posts"]
+`,
+			wantKeys: []string{"description", "mainSections"},
+		},
+		{
+			name:     "config.yaml",
+			file:     "config.yaml",
+			content:  "title: Site\nparams:\n  author: Jane\n",
+			wantKeys: []string{"author"},
+		},
+		{
+			name:    "config without params",
+			file:    "hugo.toml",
+			content: "title = \"Site\"\n",
+		},
+		{
+			name:    "invalid config",
+			file:    "hugo.toml",
+			content: "title = \n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			params, file, err := readSiteParams(dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readSiteParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if file != tt.file {
+				t.Errorf("config file = %q, want %q", file, tt.file)
+			}
+			for _, k := range tt.wantKeys {
+				if _, ok := params[k]; !ok {
+					t.Errorf("expected param %q in %v", k, params)
+				}
+			}
+			if len(params) != len(tt.wantKeys) {
+				t.Errorf("got %d params, want %d", len(params), len(tt.wantKeys))
+			}
+		})
+	}
+
+	t.Run("no config", func(t *testing.T) {
+		params, file, err := readSiteParams(t.TempDir())
+		if err != nil || file != "" || params != nil {
+			t.Errorf("readSiteParams() = %v, %q, %v; want nil, \"\", nil", params, file, err)
+		}
+	})
+}
+
+func TestBuildThemeParamsReport(t *testing.T) {
+	dir := t.TempDir()
+	config := `theme = "demo"
+
+[params]
+  Description = "A site"
+  author = "Jane"
+`
+	if err := os.WriteFile(filepath.Join(dir, "hugo.toml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	siteParams, _, err := readSiteParams(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analysis := &ai.ThemeConfigAnalysis{
+		Theme:          "demo",
+		RequiredParams: []string{"description", "logo"},
+		OptionalParams: []string{"author", "twitter"},
+		PageParams:     []string{"cover", "summary"},
+	}
+	report := buildThemeParamsReport(analysis, siteParams)
+
+	wantRequired := []themeParam{{Name: "description", Set: true}, {Name: "logo", Set: false}}
+	wantOptional := []themeParam{{Name: "author", Set: true}, {Name: "twitter", Set: false}}
+	if !reflect.DeepEqual(report.Required, wantRequired) {
+		t.Errorf("Required = %+v, want %+v", report.Required, wantRequired)
+	}
+	if !reflect.DeepEqual(report.Optional, wantOptional) {
+		t.Errorf("Optional = %+v, want %+v", report.Optional, wantOptional)
+	}
+	if !reflect.DeepEqual(report.Page, analysis.PageParams) {
+		t.Errorf("Page = %v, want %v", report.Page, analysis.PageParams)
+	}
+
+	t.Run("text output", func(t *testing.T) {
+		var out bytes.Buffer
+		printThemeParamsReport(&out, report)
+		text := out.String()
+		for _, want := range []string{"theme 'demo'", "Required site params", "logo", "not set", "cover, summary", "1 required param(s) not set"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("output missing %q:\n%s", want, text)
+			}
+		}
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		data, err := json.Marshal(report)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded themeParamsReport
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(decoded, report) {
+			t.Errorf("JSON round trip = %+v, want %+v", decoded, report)
+		}
+	})
+}
+
+func TestBuildThemeParamsReportEmpty(t *testing.T) {
+	report := buildThemeParamsReport(&ai.ThemeConfigAnalysis{Theme: "bare"}, nil)
+
+	var out bytes.Buffer
+	printThemeParamsReport(&out, report)
+	if strings.Count(out.String(), "(none)") != 3 {
+		t.Errorf("expected three empty groups, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "required param(s) not set") {
+		t.Errorf("unexpected missing-params hint:\n%s", out.String())
+	}
+
+	data, _ := json.Marshal(report)
+	if !strings.Contains(string(data), `"required":[]`) {
+		t.Errorf("empty groups should encode as [], got %s", data)
+	}
+}
@@ -86,6 +86,14 @@ func TestThemeInstallCommandDescription(t *testing.T) {
 			t.Error("Long description should mention GitHub")
 		}
 	})
+
+	t.Run("Has ref and no-cache flags", func(t *testing.T) {
+		for _, name := range []string{"ref", "no-cache"} {
+			if installCmd.Flags().Lookup(name) == nil {
+				t.Errorf("Expected --%s flag", name)
+			}
+		}
+	})
 }
 
 func TestThemeInstallCommandArgsValidation(t *testing.T) {
@@ -1,7 +1,7 @@
 package cmd
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -27,7 +27,13 @@ var updateCmd = &cobra.Command{
 This is more efficient than deploying a new site when you want to update existing content.
 
 You can provide the object ID as an argument, or the command will use the ProjectID from walgo.yaml.
-Assumes the site has been built using 'walgo build'.`,
+Passing an object ID updates that site object even if it is not the latest one recorded.
+Before uploading, walgo checks that the object exists on the active network and is
+owned by the active address.
+Assumes the site has been built using 'walgo build'.
+
+Use --duration (e.g. 6mo) instead of --epochs to give the storage period as a
+length of time; it is converted to epochs on walrus.network.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		icons := ui.GetIcons()
@@ -78,7 +84,7 @@ Assumes the site has been built using 'walgo build'.`,
 			if err == nil && wsConfig.ObjectID != "" {
 				objectID = wsConfig.ObjectID
 				fmt.Printf("%s Using object ID from ws-resources.json: %s\n", icons.Info, objectID)
-			} else if cfg.WalrusConfig.ProjectID != "" && cfg.WalrusConfig.ProjectID != "YOUR_WALRUS_PROJECT_ID" {
+			} else if !config.IsPlaceholderProjectID(cfg.WalrusConfig.ProjectID) {
 				objectID = cfg.WalrusConfig.ProjectID
 				fmt.Printf("%s Using object ID from walgo.yaml: %s\n", icons.Info, objectID)
 			} else {
@@ -156,24 +162,48 @@ Assumes the site has been built using 'walgo build'.`,
 		if epochs <= 0 {
 			epochs = 1 // Default to 1 epoch
 		}
+		if duration, _ := cmd.Flags().GetString("duration"); cmd.Flags().Changed("duration") {
+			if cmd.Flags().Changed("epochs") {
+				return fmt.Errorf("--epochs and --duration cannot be used together")
+			}
+			epochs, err = durationEpochs(duration, cfg.WalrusConfig.Network, true, os.Stdout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return err
+			}
+		}
 
 		fmt.Printf("\n%s Storing for %d epoch(s)\n", icons.Database, epochs)
 
-		err = hugo.BuildSite(sitePath)
+		buildOpts := scheduleBuildOptions(cmd)
+		buildOpts.Reproducible = buildOpts.Reproducible || cfg.HugoConfig.Reproducible
+		warnDraftsDeploy(buildOpts, os.Stderr)
+		err = hugo.BuildSiteWithOptions(sitePath, buildOpts)
 		if err != nil {
 			return fmt.Errorf("failed to build site: %w", err)
 		}
 
 		uploadStart := time.Now()
 		d := sb.New()
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		ctx, cancel := newDeployContext(30 * time.Minute)
 		defer cancel()
 		output, err := d.Update(ctx, deployDir, objectID, deployer.DeployOptions{Epochs: epochs, WalrusCfg: cfg.WalrusConfig})
 		if telemetry {
 			deployMetrics.UploadDuration = time.Since(uploadStart).Milliseconds()
 		}
+		if isInterrupted(ctx, err) {
+			fmt.Fprintf(os.Stderr, "\n%s Update interrupted\n", icons.Warning)
+			return fmt.Errorf("update interrupted: %w", err)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%s Error: updating Walrus Site: %v\n", icons.Error, err)
+			var ownErr *deployer.OwnershipError
+			switch {
+			case errors.Is(err, deployer.ErrSiteNotFound):
+				fmt.Fprintf(os.Stderr, "%s Check the object ID and that the active network (sui client active-env) matches the site\n", icons.Lightbulb)
+			case errors.As(err, &ownErr):
+				fmt.Fprintf(os.Stderr, "%s Only the owning address can update a site; use 'walgo deploy --force-new' to publish a new one\n", icons.Lightbulb)
+			}
 			return fmt.Errorf("error updating Walrus Site: %w", err)
 		}
 
@@ -228,15 +258,18 @@ Assumes the site has been built using 'walgo build'.`,
 						if err := pm.UpdateProject(existingProj); err != nil {
 							fmt.Fprintf(os.Stderr, "%s Warning: Failed to update project in database: %v\n", icons.Warning, err)
 						} else {
-							// Use epoch-aware cost estimation
-							estimatedGas := projects.EstimateGasFeeWithEpochs(existingProj.Network, siteSize, epochs)
+							// Costs site-builder reported, or an epoch-aware estimate
+							gasFee, costActual := projects.DeployCostFee(output.CostWAL, output.GasSUI, existingProj.Network, siteSize, epochs)
 							deployment := &projects.DeploymentRecord{
-								ProjectID: existingProj.ID,
-								ObjectID:  objectID,
-								Network:   existingProj.Network,
-								Epochs:    epochs,
-								GasFee:    estimatedGas,
-								Success:   true,
+								ProjectID:  existingProj.ID,
+								ObjectID:   objectID,
+								Network:    existingProj.Network,
+								Epochs:     epochs,
+								GasFee:     gasFee,
+								Success:    true,
+								CostWAL:    output.CostWAL,
+								CostSUI:    output.GasSUI,
+								CostActual: costActual,
 							}
 							_ = pm.RecordDeployment(deployment)
 
@@ -258,8 +291,10 @@ Assumes the site has been built using 'walgo build'.`,
 
 func init() {
 	rootCmd.AddCommand(updateCmd)
+	addScheduleFlags(updateCmd)
 
 	updateCmd.Flags().IntP("epochs", "e", 1, "Number of epochs to store the site data (default: 1)")
+	updateCmd.Flags().String("duration", "", "Store the site data for this long instead of --epochs, e.g. 30d, 6mo, 1y")
 	updateCmd.Flags().BoolP("verbose", "v", false, "Show detailed change summary")
 	updateCmd.Flags().Bool("dry-run", false, "Preview update plan without actually updating")
 	updateCmd.Flags().Bool("telemetry", false, "Record update metrics to local JSON file (~/.walgo/metrics.json)")
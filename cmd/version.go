@@ -110,6 +110,8 @@ func checkForUpdates() {
 		fmt.Println(icons.Check)
 		fmt.Printf("\n%s New version available: v%s (you have v%s)\n", icons.Warning, latestVersion, currentVersion)
 		fmt.Printf("\nUpdate with:\n")
+		fmt.Printf("  walgo self-update\n")
+		fmt.Printf("or:\n")
 		fmt.Printf("  curl -fsSL https://raw.githubusercontent.com/selimozten/walgo/main/install.sh | bash\n")
 		fmt.Printf("\nRelease notes: %s\n", release.HTMLURL)
 	default:
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// walletCmd groups commands that inspect the active Sui wallet.
+var walletCmd = &cobra.Command{
+	Use:   "wallet",
+	Short: "Inspect the active Sui wallet",
+	Long: `Commands for looking at the Sui wallet walgo deploys from.
+
+Examples:
+  walgo wallet history            # Recent transactions of the active address
+  walgo wallet history --limit 25 # Show more of them`,
+}
+
+func init() {
+	rootCmd.AddCommand(walletCmd)
+	walletCmd.AddCommand(walletHistoryCmd)
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/sui"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Wallet lookups behind walgo wallet history; replaced in tests.
+var (
+	activeWalletAddress  = sui.GetActiveAddress
+	recentWalletActivity = sui.GetRecentTransactions
+)
+
+var walletHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List recent transactions sent by the wallet",
+	Long: `List the most recent transactions sent by the active address (or
+--address) on the active network, newest first, with their kind, the Move
+functions they called, their status and the gas they cost. Failed
+transactions show the abort reason, which helps when a deploy fails on chain.
+In a site whose walgo.yaml sets walrus.gateway.suiRPCURL, that fullnode is
+queried instead of the active environment's.
+
+Examples:
+  walgo wallet history
+  walgo wallet history --limit 25
+  walgo wallet history --address 0x1234... --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+		limit, _ := cmd.Flags().GetInt("limit")
+		address, _ := cmd.Flags().GetString("address")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		if address == "" {
+			active, err := activeWalletAddress()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+				return fmt.Errorf("failed to read active address: %w", err)
+			}
+			address = strings.TrimSpace(active)
+		}
+
+		// Query the site's gateway fullnode when walgo.yaml overrides it
+		ctx := cmd.Context()
+		if cwd, err := os.Getwd(); err == nil {
+			if cfg, err := config.LoadConfigFrom(cwd); err == nil {
+				ctx = sui.WithRPCURL(ctx, cfg.WalrusConfig.Gateway.SuiRPCURL)
+			}
+		}
+
+		txs, err := recentWalletActivity(ctx, address, limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return err
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(txs); err != nil {
+				return fmt.Errorf("encoding transactions: %w", err)
+			}
+			return nil
+		}
+		printWalletHistory(os.Stdout, address, txs)
+		return nil
+	},
+}
+
+// printWalletHistory lists txs sent by address, one per line.
+func printWalletHistory(out io.Writer, address string, txs []sui.TxSummary) {
+	icons := ui.GetIcons()
+
+	if len(txs) == 0 {
+		fmt.Fprintf(out, "%s No transactions found for %s\n", icons.Info, address)
+		return
+	}
+
+	fmt.Fprintf(out, "%s %d recent transaction(s) from %s:\n\n", icons.Info, len(txs), address)
+	for _, tx := range txs {
+		status := icons.Check
+		if tx.Failed() {
+			status = icons.Cross
+		}
+		when := "unknown time"
+		if !tx.Timestamp.IsZero() {
+			when = tx.Timestamp.Local().Format("2006-01-02 15:04:05")
+		}
+		what := tx.Kind
+		if len(tx.Calls) > 0 {
+			what = strings.Join(tx.Calls, ", ")
+		}
+		fmt.Fprintf(out, "  %s %s  %s  %s\n", status, when, tx.Digest, what)
+		fmt.Fprintf(out, "      %s, gas %.6f SUI\n", tx.Status, tx.GasSUI)
+		if tx.Error != "" {
+			fmt.Fprintf(out, "      error: %s\n", tx.Error)
+		}
+	}
+}
+
+func init() {
+	walletHistoryCmd.Flags().Int("limit", 10, fmt.Sprintf("Number of transactions to show (1-%d)", sui.MaxTransactionsLimit))
+	walletHistoryCmd.Flags().String("address", "", "Address to list (default: active address)")
+	walletHistoryCmd.Flags().Bool("json", false, "Output transactions as JSON")
+}
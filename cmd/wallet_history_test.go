@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/sui"
+)
+
+func TestWalletHistoryCommand(t *testing.T) {
+	for _, name := range []string{"limit", "address", "json"} {
+		if walletHistoryCmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+
+	runTestCases(t, rootCmd, []TestCase{
+		{
+			Name:     "Wallet history help",
+			Args:     []string{"wallet", "history", "--help"},
+			Contains: []string{"--limit", "--address", "abort reason"},
+		},
+	})
+}
+
+func TestPrintWalletHistory(t *testing.T) {
+	var out bytes.Buffer
+	printWalletHistory(&out, "0xabc", []sui.TxSummary{
+		{
+			Digest:    "7xFailed",
+			Kind:      "ProgrammableTransaction",
+			Calls:     []string{"site::new_site"},
+			Status:    "failure",
+			Error:     "InsufficientGas",
+			Timestamp: time.Now(),
+		},
+		{Digest: "9xTransfer", Kind: "ProgrammableTransaction", Status: "success", GasSUI: 0.002},
+	})
+
+	got := out.String()
+	for _, want := range []string{"2 recent transaction(s) from 0xabc", "7xFailed", "site::new_site", "error: InsufficientGas", "9xTransfer  ProgrammableTransaction", "gas 0.002000 SUI"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+
+	out.Reset()
+	printWalletHistory(&out, "0xabc", []sui.TxSummary{})
+	if !strings.Contains(out.String(), "No transactions found for 0xabc") {
+		t.Errorf("empty history output = %q", out.String())
+	}
+}
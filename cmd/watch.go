@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployment"
+	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/watch"
+
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Rebuild and redeploy automatically when content changes.",
+	Long: `Watches the site's content and static directories and, once edits settle,
+rebuilds the site and runs an incremental deploy (only changed files are
+uploaded). Each cycle logs the changed files and what the deploy cost.
+
+Edits are batched: a deploy starts only after --debounce passes with no new
+changes, and never sooner than --min-interval after the previous deploy
+started, so a burst of saves cannot turn into a burst of transactions.
+Press Ctrl+C to stop; a deploy in progress is interrupted and local files are
+left unchanged.
+
+Examples:
+  walgo watch
+  walgo watch --min-interval 15m --epochs 5
+  walgo watch --dir content --dir static --dir data`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		icons := ui.GetIcons()
+
+		sitePath, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: Cannot determine current directory: %v\n", icons.Error, err)
+			return fmt.Errorf("error getting current directory: %w", err)
+		}
+		walgoCfg, err := config.LoadConfigFrom(sitePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", icons.Error, err)
+			return fmt.Errorf("error loading config: %w", err)
+		}
+
+		epochs, _ := cmd.Flags().GetInt("epochs")
+		dirs, _ := cmd.Flags().GetStringSlice("dir")
+		debounce, _ := cmd.Flags().GetDuration("debounce")
+		minInterval, _ := cmd.Flags().GetDuration("min-interval")
+		poll, _ := cmd.Flags().GetDuration("poll")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+
+		if epochs <= 0 {
+			return fmt.Errorf("--epochs must be greater than 0")
+		}
+
+		network := walgoCfg.WalrusConfig.Network
+		if info, err := activeAddressDetails(cmd.Context()); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Warning: Could not read active address: %v\n", icons.Warning, err)
+		} else {
+			network = info.Network
+			printWalletPreflight(os.Stdout, info)
+		}
+		if err := confirmMainnetSpend(network, assumeYes, os.Stdin, os.Stdout); err != nil {
+			return err
+		}
+
+		buildOpts := scheduleBuildOptions(cmd)
+		buildOpts.Reproducible = buildOpts.Reproducible || walgoCfg.HugoConfig.Reproducible
+		warnDraftsDeploy(buildOpts, os.Stderr)
+
+		w, err := watch.New(watch.Options{
+			SitePath:     sitePath,
+			Dirs:         dirs,
+			PollInterval: poll,
+			Debounce:     debounce,
+			MinInterval:  minInterval,
+			Cycle:        watchDeployCycle(sitePath, epochs, buildOpts, verbose),
+		})
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return w.Run(ctx)
+	},
+}
+
+// watchDeployCycle rebuilds the site and deploys it incrementally. The config
+// is reloaded every cycle so the object ID saved by the first deploy turns
+// later cycles into updates.
+func watchDeployCycle(sitePath string, epochs int, buildOpts hugo.BuildOptions, verbose bool) watch.CycleFunc {
+	return func(ctx context.Context, _ watch.Changes) (watch.CycleReport, error) {
+		walgoCfg, err := config.LoadConfigFrom(sitePath)
+		if err != nil {
+			return watch.CycleReport{}, fmt.Errorf("error loading config: %w", err)
+		}
+		if err := hugo.BuildSiteWithOptions(sitePath, buildOpts); err != nil {
+			return watch.CycleReport{}, fmt.Errorf("failed to build site: %w", err)
+		}
+
+		deployCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+		defer cancel()
+		result, err := deployment.PerformDeployment(deployCtx, deployment.DeploymentOptions{
+			SitePath:   sitePath,
+			PublishDir: filepath.Join(sitePath, walgoCfg.HugoConfig.PublishDir),
+			Epochs:     epochs,
+			WalgoCfg:   walgoCfg,
+			Quiet:      !verbose,
+			Verbose:    verbose,
+		})
+		if err != nil {
+			return watch.CycleReport{}, err
+		}
+		return watch.CycleReport{
+			ObjectID:      result.ObjectID,
+			FilesUploaded: result.FilesUploaded,
+			WAL:           result.ActualWAL,
+			SUI:           result.ActualGasSUI,
+		}, nil
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	addScheduleFlags(watchCmd)
+
+	watchCmd.Flags().IntP("epochs", "e", 1, "Number of epochs to store the site on each deploy")
+	watchCmd.Flags().StringSlice("dir", watch.DefaultDirs, "Site directory to watch (repeatable)")
+	watchCmd.Flags().Duration("debounce", watch.DefaultDebounce, "Wait this long after the last change before deploying")
+	watchCmd.Flags().Duration("min-interval", watch.DefaultMinInterval, "Minimum time between two deploys, to cap spend")
+	watchCmd.Flags().Duration("poll", watch.DefaultPollInterval, "How often to check for changes")
+	watchCmd.Flags().BoolP("verbose", "v", false, "Show full build and deploy output for each cycle")
+	watchCmd.Flags().BoolP("yes", "y", false, "Skip the mainnet confirmation prompt")
+}
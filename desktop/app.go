@@ -7,9 +7,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
-	"strconv"
 	"strings"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/selimozten/walgo/pkg/api"
 )
@@ -29,15 +29,38 @@ type AIProgressState struct {
 	TotalPages   int     `json:"totalPages"`
 	FilesCreated int     `json:"filesCreated"`
 	Error        string  `json:"error"`
+	// Previews holds the content streamed so far for each page being
+	// written, keyed by page path. Pages are generated concurrently.
+	Previews map[string]string `json:"previews"`
+}
+
+// aiPreviewLimit caps each page preview to its last bytes, so polling stays
+// cheap while long pages stream.
+const aiPreviewLimit = 4000
+
+// appendPreview adds token to preview and keeps at most aiPreviewLimit bytes,
+// cut at a rune boundary.
+func appendPreview(preview, token string) string {
+	preview += token
+	if len(preview) <= aiPreviewLimit {
+		return preview
+	}
+	cut := len(preview) - aiPreviewLimit
+	for cut < len(preview) && !utf8.RuneStart(preview[cut]) {
+		cut++
+	}
+	return preview[cut:]
 }
 
 // App represents the main desktop application structure.
 type App struct {
 	ctx           context.Context
-	mu            sync.Mutex // protects serveCmd, serverPort, serveSitePath
+	mu            sync.Mutex // protects serveCmd, serverPort, serverURL, serveSitePath, serveCleanup
 	serveCmd      *exec.Cmd
 	serverPort    int
+	serverURL     string
 	serveSitePath string
+	serveCleanup  func() // removes the generated TLS certificate, if any
 	aiProgress    *AIProgressState
 	aiProgressMu  sync.Mutex
 	aiCancel      context.CancelFunc // cancels the running AI pipeline
@@ -399,11 +422,30 @@ func (a *App) ListProjects() ([]Project, error) {
 	return api.ListProjects()
 }
 
+// ProjectListFilter narrows ListProjectsPage
+type ProjectListFilter = api.ProjectListFilter
+
+// ProjectPage holds one page of projects and the total count
+type ProjectPage = api.ProjectPage
+
+// ListProjectsPage returns one page of projects matching filter
+func (a *App) ListProjectsPage(offset, limit int, filter ProjectListFilter) (*ProjectPage, error) {
+	return api.ListProjectsPage(offset, limit, filter)
+}
+
 // GetProject returns a single project by ID
 func (a *App) GetProject(projectID int64) (*Project, error) {
 	return api.GetProject(projectID)
 }
 
+// DeploymentPage holds one page of a project's deployments and the total count
+type DeploymentPage = api.DeploymentPage
+
+// ListDeployments returns one page of a project's deployments, newest first
+func (a *App) ListDeployments(projectID int64, offset, limit int) (*DeploymentPage, error) {
+	return api.ListDeployments(projectID, offset, limit)
+}
+
 // DeleteProjectParams holds delete project parameters
 type DeleteProjectParams = api.DeleteProjectParams
 
@@ -463,12 +505,6 @@ func (a *App) Serve(params ServeParams) ServeResult {
 		fmt.Printf("⚠️  Warning: Error cleaning up existing Hugo processes: %v\n", err)
 	}
 
-	// Check if hugo is installed
-	hugoPath, err := lookPath("hugo")
-	if err != nil {
-		return ServeResult{Error: "hugo is not installed or not found in PATH"}
-	}
-
 	// Get the site path
 	sitePath := params.SitePath
 	if sitePath == "" {
@@ -479,6 +515,12 @@ func (a *App) Serve(params ServeParams) ServeResult {
 		sitePath = cwd
 	}
 
+	// Use the Hugo release the site pins, or the hugo on PATH
+	hugoPath, err := api.HugoBinary(sitePath)
+	if err != nil {
+		return ServeResult{Error: err.Error(), Code: api.CodeToolMissing}
+	}
+
 	// Build the site before serving
 	buildCmd := exec.Command(hugoPath, "--source", sitePath)
 	hideWindow(buildCmd)
@@ -487,23 +529,15 @@ func (a *App) Serve(params ServeParams) ServeResult {
 	}
 
 	// Build hugo arguments
-	hugoArgs := []string{"server"}
-	if params.Drafts {
-		hugoArgs = append(hugoArgs, "-D")
-	}
-	if params.Expired {
-		hugoArgs = append(hugoArgs, "-E")
-	}
-	if params.Future {
-		hugoArgs = append(hugoArgs, "-F")
-	}
-
-	// Set port
 	port := params.Port
 	if port == 0 {
 		port = 1313
 	}
-	hugoArgs = append(hugoArgs, "--port", strconv.Itoa(port))
+	params.Port = port
+	hugoArgs, url, warning, cleanup, err := api.ServeCommandArgs(params)
+	if err != nil {
+		return ServeResult{Error: err.Error(), Code: api.CodeValidation}
+	}
 
 	// Create and start the command
 	cmd := exec.Command(hugoPath, hugoArgs...)
@@ -513,6 +547,7 @@ func (a *App) Serve(params ServeParams) ServeResult {
 	hideWindow(cmd)
 
 	if err := cmd.Start(); err != nil {
+		cleanup()
 		return ServeResult{Error: fmt.Sprintf("failed to start hugo server: %v", err)}
 	}
 
@@ -520,12 +555,15 @@ func (a *App) Serve(params ServeParams) ServeResult {
 	a.mu.Lock()
 	a.serveCmd = cmd
 	a.serverPort = port
+	a.serverURL = url
 	a.serveSitePath = sitePath
+	a.serveCleanup = cleanup
 	a.mu.Unlock()
 
 	return ServeResult{
 		Success: true,
-		URL:     fmt.Sprintf("http://localhost:%d", port),
+		URL:     url,
+		Warning: warning,
 	}
 }
 
@@ -543,6 +581,11 @@ func (a *App) StopServe() bool {
 	a.serveCmd = nil
 	a.serveSitePath = ""
 	a.serverPort = 0
+	a.serverURL = ""
+	if a.serveCleanup != nil {
+		a.serveCleanup()
+		a.serveCleanup = nil
+	}
 	return true
 }
 
@@ -551,10 +594,7 @@ func (a *App) GetServerURL() string {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	if a.serverPort > 0 {
-		return fmt.Sprintf("http://localhost:%d", a.serverPort)
-	}
-	return ""
+	return a.serverURL
 }
 
 // ====================
@@ -591,6 +631,14 @@ func (a *App) ArchiveProject(projectID int64) ArchiveProjectResult {
 	return api.ArchiveProject(projectID)
 }
 
+// RestoreProjectResult holds restore results
+type RestoreProjectResult = api.RestoreProjectResult
+
+// RestoreDeletedProject brings back a deleted project within its recovery window
+func (a *App) RestoreDeletedProject(projectID int64) RestoreProjectResult {
+	return api.RestoreDeletedProject(projectID)
+}
+
 // ====================
 // Update Site (Re-deploy)
 // ====================
@@ -635,9 +683,15 @@ func (a *App) EstimateGasFee(params GasEstimateParams) GasEstimateResult {
 type LaunchWizardParams = api.LaunchWizardParams
 type LaunchWizardResult = api.LaunchWizardResult
 
-// LaunchWizard executes full launch wizard flow
+// DeployOutputEvent is emitted with each line of deploy output during LaunchWizard
+const DeployOutputEvent = "deploy:output"
+
+// LaunchWizard executes full launch wizard flow, emitting DeployOutputEvent
+// for each line of site-builder output so the UI can show live logs
 func (a *App) LaunchWizard(params LaunchWizardParams) LaunchWizardResult {
-	return api.LaunchWizard(params)
+	return api.LaunchWizardWithOutput(params, func(line string) {
+		eventsEmit(a.ctx, DeployOutputEvent, line)
+	})
 }
 
 // ====================
@@ -655,7 +709,13 @@ func (a *App) GetAIProgress() AIProgressState {
 	if a.aiProgress == nil {
 		return AIProgressState{}
 	}
-	return *a.aiProgress
+	state := *a.aiProgress
+	// The pipeline keeps writing to the map after this returns
+	state.Previews = make(map[string]string, len(a.aiProgress.Previews))
+	for page, preview := range a.aiProgress.Previews {
+		state.Previews[page] = preview
+	}
+	return state
 }
 
 // AICreateSite creates a complete Hugo site with AI-generated content.
@@ -671,37 +731,14 @@ func (a *App) AICreateSite(params AICreateSiteParams) {
 	done := make(chan struct{})
 
 	a.aiProgressMu.Lock()
-	a.aiProgress = &AIProgressState{IsActive: true}
+	a.aiProgress = &AIProgressState{IsActive: true, Previews: make(map[string]string)}
 	a.aiCancel = cancel
 	a.aiDone = done
 	a.aiProgressMu.Unlock()
 
-	progressHandler := func(event api.ProgressEvent) {
-		a.aiProgressMu.Lock()
-		defer a.aiProgressMu.Unlock()
-		if a.aiProgress == nil {
-			return
-		}
-		a.aiProgress.Phase = event.Phase
-		a.aiProgress.PagePath = event.PagePath
-		a.aiProgress.Progress = event.Progress
-		a.aiProgress.Current = event.Current
-		a.aiProgress.Total = event.Total
-
-		// Show user-friendly messages for retries and errors
-		switch event.EventType {
-		case "retry":
-			a.aiProgress.Message = fmt.Sprintf("Retrying %s (rate limited, waiting...)", event.PagePath)
-		case "error":
-			a.aiProgress.Message = fmt.Sprintf("Failed: %s", event.PagePath)
-		default:
-			a.aiProgress.Message = event.Message
-		}
-	}
-
 	go func() {
 		defer close(done)
-		result := api.AICreateSiteWithProgress(ctx, params, progressHandler)
+		result := api.AICreateSiteWithProgress(ctx, params, a.recordAIProgress)
 
 		a.aiProgressMu.Lock()
 		defer a.aiProgressMu.Unlock()
@@ -720,6 +757,44 @@ func (a *App) AICreateSite(params AICreateSiteParams) {
 	}()
 }
 
+// recordAIProgress updates the polled progress state with a pipeline event.
+func (a *App) recordAIProgress(event api.ProgressEvent) {
+	a.aiProgressMu.Lock()
+	defer a.aiProgressMu.Unlock()
+	if a.aiProgress == nil {
+		return
+	}
+	if a.aiProgress.Previews == nil {
+		a.aiProgress.Previews = make(map[string]string)
+	}
+	// Streamed tokens only grow the preview of their own page
+	switch event.EventType {
+	case "token":
+		a.aiProgress.Previews[event.PagePath] = appendPreview(a.aiProgress.Previews[event.PagePath], event.Message)
+		return
+	case "page_start", "retry":
+		a.aiProgress.Previews[event.PagePath] = ""
+	case "page_done", "skip", "error":
+		delete(a.aiProgress.Previews, event.PagePath)
+	}
+
+	a.aiProgress.Phase = event.Phase
+	a.aiProgress.PagePath = event.PagePath
+	a.aiProgress.Progress = event.Progress
+	a.aiProgress.Current = event.Current
+	a.aiProgress.Total = event.Total
+
+	// Show user-friendly messages for retries and errors
+	switch event.EventType {
+	case "retry":
+		a.aiProgress.Message = fmt.Sprintf("Retrying %s (rate limited, waiting...)", event.PagePath)
+	case "error":
+		a.aiProgress.Message = fmt.Sprintf("Failed: %s", event.PagePath)
+	default:
+		a.aiProgress.Message = event.Message
+	}
+}
+
 // cancelAI cancels any running AI pipeline and waits for it to finish.
 func (a *App) cancelAI() {
 	a.aiProgressMu.Lock()
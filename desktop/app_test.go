@@ -6,6 +6,9 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"github.com/selimozten/walgo/pkg/api"
 )
 
 // =============================================================================
@@ -915,6 +918,42 @@ func TestValidateFilePath_WithRealFile(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// recordAIProgress — per-page streaming previews
+// =============================================================================
+
+func TestRecordAIProgress_PreviewPerPage(t *testing.T) {
+	a := &App{aiProgress: &AIProgressState{IsActive: true, Previews: make(map[string]string)}}
+
+	a.recordAIProgress(api.ProgressEvent{EventType: "page_start", PagePath: "content/a.md"})
+	a.recordAIProgress(api.ProgressEvent{EventType: "page_start", PagePath: "content/b.md"})
+	a.recordAIProgress(api.ProgressEvent{EventType: "token", PagePath: "content/a.md", Message: "Hello "})
+	a.recordAIProgress(api.ProgressEvent{EventType: "token", PagePath: "content/b.md", Message: "Other"})
+	a.recordAIProgress(api.ProgressEvent{EventType: "token", PagePath: "content/a.md", Message: "world"})
+
+	state := a.GetAIProgress()
+	if state.Previews["content/a.md"] != "Hello world" || state.Previews["content/b.md"] != "Other" {
+		t.Errorf("previews = %q, want tokens kept apart per page", state.Previews)
+	}
+
+	a.recordAIProgress(api.ProgressEvent{EventType: "page_done", PagePath: "content/b.md"})
+	a.recordAIProgress(api.ProgressEvent{EventType: "retry", PagePath: "content/a.md"})
+	state = a.GetAIProgress()
+	if _, ok := state.Previews["content/b.md"]; ok {
+		t.Error("a finished page should leave the previews")
+	}
+	if preview, ok := state.Previews["content/a.md"]; !ok || preview != "" {
+		t.Errorf("a retried page should restart its preview, got %q", preview)
+	}
+}
+
+func TestAppendPreview_KeepsTail(t *testing.T) {
+	preview := appendPreview(strings.Repeat("a", aiPreviewLimit-1), "é!")
+	if len(preview) > aiPreviewLimit || !utf8.ValidString(preview) || !strings.HasSuffix(preview, "é!") {
+		t.Errorf("appendPreview() = %d bytes ending %q, want at most %d valid bytes ending with the new token", len(preview), preview[len(preview)-4:], aiPreviewLimit)
+	}
+}
+
 // =============================================================================
 // Benchmarks
 // =============================================================================
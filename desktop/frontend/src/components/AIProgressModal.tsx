@@ -17,6 +17,9 @@ export const AIProgressModal: React.FC = () => {
   if (!isModalOpen) return null;
 
   const progressPercentage = Math.round(progressState.progress * 100);
+  const previews = Object.entries(progressState.previews).filter(
+    ([, text]) => text.length > 0
+  );
 
   return (
     <AnimatePresence>
@@ -139,6 +142,25 @@ export const AIProgressModal: React.FC = () => {
               </div>
             )}
 
+            {/* Live previews of the pages being written */}
+            {progressState.isActive && previews.length > 0 && (
+              <div className="space-y-3 max-h-64 overflow-y-auto">
+                {previews.map(([page, text]) => (
+                  <div
+                    key={page}
+                    className="p-3 bg-black/40 border border-white/5 rounded-sm"
+                  >
+                    <div className="text-xs text-zinc-500 mb-2 font-mono break-all">
+                      {page}
+                    </div>
+                    <pre className="text-xs text-zinc-300 font-mono whitespace-pre-wrap break-words max-h-32 overflow-hidden">
+                      {text.slice(-600)}
+                    </pre>
+                  </div>
+                ))}
+              </div>
+            )}
+
             {/* Message */}
             {progressState.message &&
               progressState.message !== progressState.phase && (
@@ -10,6 +10,7 @@ interface AIProgressState {
   current: number;
   total: number;
   progress: number;
+  previews: Record<string, string>;
 }
 
 interface AICompleteEvent {
@@ -56,6 +57,7 @@ export const AIProgressProvider: React.FC<{ children: React.ReactNode }> = ({
     current: 0,
     total: 0,
     progress: 0,
+    previews: {},
   });
 
   const [isModalOpen, setIsModalOpen] = useState(false);
@@ -88,6 +90,7 @@ export const AIProgressProvider: React.FC<{ children: React.ReactNode }> = ({
             ...prev,
             isActive: false,
             phase: "Completed!",
+            previews: {},
           }));
 
           // Auto-close after 2 seconds
@@ -123,6 +126,8 @@ export const AIProgressProvider: React.FC<{ children: React.ReactNode }> = ({
               updates.currentFile = data.pagePath;
             }
 
+            updates.previews = data.previews || {};
+
             if (data.current !== undefined && data.total !== undefined) {
               updates.current = data.current;
               updates.total = data.total;
@@ -151,6 +156,7 @@ export const AIProgressProvider: React.FC<{ children: React.ReactNode }> = ({
       current: 0,
       total: 0,
       progress: 0,
+      previews: {},
     });
     setIsModalOpen(true);
     setIsMinimized(false);
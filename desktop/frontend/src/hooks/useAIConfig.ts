@@ -66,6 +66,7 @@ export const useAIConfig = () => {
         apiKey?: string;
         baseURL?: string;
         model?: string;
+        force?: boolean;
     }) => {
         setLoading(true);
         setError(null);
@@ -75,6 +76,7 @@ export const useAIConfig = () => {
                 apiKey: credentials.apiKey || '',
                 baseURL: credentials.baseURL,
                 model: credentials.model,
+                force: credentials.force,
             });
             await loadConfig();
             return { success: true };
@@ -32,6 +32,7 @@ export const AIConfig: React.FC<AIConfigProps> = ({ onConfigChange }) => {
     } = useAIConfig();
 
     const [showApiKey, setShowApiKey] = useState(false);
+    const [allowUnlistedModel, setAllowUnlistedModel] = useState(false);
     const [hasChanges, setHasChanges] = useState(false);
     const [status, setStatus] = useState<{ type: 'success' | 'error', message: string } | null>(null);
     const [isProcessing, setIsProcessing] = useState(false);
@@ -138,7 +139,7 @@ export const AIConfig: React.FC<AIConfigProps> = ({ onConfigChange }) => {
 
             // Now save the new configuration
             setStatus({ type: 'success', message: 'Saving configuration...' });
-            const result = await updateConfig(provider, { apiKey, baseURL: baseUrl, model });
+            const result = await updateConfig(provider, { apiKey, baseURL: baseUrl, model, force: allowUnlistedModel });
             
             if (result.success) {
                 setStatus({ type: 'success', message: 'Configuration saved successfully! Only one provider can be active at a time.' });
@@ -387,7 +388,7 @@ export const AIConfig: React.FC<AIConfigProps> = ({ onConfigChange }) => {
                                     type="text"
                                     value={model}
                                     onChange={(e) => setModel(e.target.value)}
-                                    placeholder={provider === 'openrouter' ? 'openai/gpt-4, anthropic/claude-3, etc.' : 'gpt-4o, gpt-4-turbo, gpt-3.5-turbo'}
+                                    placeholder={provider === 'openrouter' ? 'openai/gpt-4o, anthropic/claude-sonnet-4, etc.' : 'gpt-4o, gpt-4.1, gpt-4o-mini'}
                                     autoComplete="off"
                                     autoCapitalize="off"
                                     autoCorrect="off"
@@ -399,6 +400,18 @@ export const AIConfig: React.FC<AIConfigProps> = ({ onConfigChange }) => {
                                             : "border-zinc-700 focus:border-accent"
                                     )}
                                 />
+                                <div className="flex items-center gap-2 mt-2">
+                                    <input
+                                        type="checkbox"
+                                        id="allow-unlisted-model"
+                                        checked={allowUnlistedModel}
+                                        onChange={(e) => setAllowUnlistedModel(e.target.checked)}
+                                        className="w-4 h-4 bg-zinc-900 border-zinc-600 rounded text-accent focus:ring-accent focus:ring-2"
+                                    />
+                                    <label htmlFor="allow-unlisted-model" className="text-xs font-mono text-zinc-400 cursor-pointer select-none">
+                                        Allow unlisted model (for newly released models)
+                                    </label>
+                                </div>
                             </div>
 
                             <div className="flex gap-2 pt-4">
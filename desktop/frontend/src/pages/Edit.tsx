@@ -892,6 +892,8 @@ export const Edit: React.FC<EditProps> = ({
         imageUrl: launchConfig?.imageUrl || "",
         epochs: params.epochs,
         skipConfirm: true,
+        // The category field is free text
+        allowCustomCategory: true,
       });
 
       // Build comprehensive logs from steps
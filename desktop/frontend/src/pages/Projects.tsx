@@ -16,6 +16,7 @@ import {
     Check,
     Eye,
     RefreshCw,
+    RotateCcw,
     ExternalLink
 } from 'lucide-react';
 import { Card } from '../components/ui/Card';
@@ -74,6 +75,8 @@ export const Projects: React.FC<ProjectsProps> = ({
         }
     };
     const [deleteConfirm, setDeleteConfirm] = useState<Project | null>(null);
+    const [linkedDomains, setLinkedDomains] = useState<string[]>([]);
+    const [recentlyDeleted, setRecentlyDeleted] = useState<Project | null>(null);
     const [isDeleting, setIsDeleting] = useState(false);
     const [editProject, setEditProject] = useState<Project | null>(null);
     const [editForm, setEditForm] = useState({
@@ -241,9 +244,15 @@ export const Projects: React.FC<ProjectsProps> = ({
     };
 
     const handleDelete = (project: Project) => {
+        setLinkedDomains([]);
         setDeleteConfirm(project);
     };
 
+    const closeDeleteConfirm = () => {
+        setDeleteConfirm(null);
+        setLinkedDomains([]);
+    };
+
     const confirmDelete = async () => {
         if (!deleteConfirm) return;
 
@@ -251,10 +260,18 @@ export const Projects: React.FC<ProjectsProps> = ({
         try {
             const { DeleteProject } = await import('../../wailsjs/go/main/App');
 
+            // A second confirmation after a linked SuiNS domain blocked the
+            // delete destroys the site anyway
             const result = await DeleteProject({
                 projectId: deleteConfirm.id || 0,
+                force: linkedDomains.length > 0,
             });
 
+            if (!result.success && result.linkedDomains && result.linkedDomains.length > 0) {
+                setLinkedDomains(result.linkedDomains);
+                return;
+            }
+
             if (result.success) {
                 // Clear from localStorage if this was the selected project
                 const selectedProjectStr = localStorage.getItem('selectedProject');
@@ -275,8 +292,9 @@ export const Projects: React.FC<ProjectsProps> = ({
                         ? `Deleted: ${deleteConfirm.name} (including on-chain destruction)`
                         : `Deleted: ${deleteConfirm.name}`,
                 });
+                setRecentlyDeleted(deleteConfirm);
                 await onRefresh?.();
-                setDeleteConfirm(null);
+                closeDeleteConfirm();
             } else {
                 onStatusChange?.({
                     type: 'error',
@@ -293,6 +311,33 @@ export const Projects: React.FC<ProjectsProps> = ({
         }
     };
 
+    const restoreDeleted = async () => {
+        if (!recentlyDeleted) return;
+
+        try {
+            const { RestoreDeletedProject } = await import('../../wailsjs/go/main/App');
+            const result = await RestoreDeletedProject(recentlyDeleted.id || 0);
+            if (result.success) {
+                onStatusChange?.({
+                    type: 'success',
+                    message: `Restored: ${recentlyDeleted.name}`,
+                });
+                setRecentlyDeleted(null);
+                await onRefresh?.();
+            } else {
+                onStatusChange?.({
+                    type: 'error',
+                    message: `Restore failed: ${result.error}`,
+                });
+            }
+        } catch (err) {
+            onStatusChange?.({
+                type: 'error',
+                message: `Restore failed: ${err?.toString()}`,
+            });
+        }
+    };
+
     const handleEdit = (project: Project) => {
         setEditProject(project);
         setEditForm({
@@ -318,6 +363,8 @@ export const Projects: React.FC<ProjectsProps> = ({
                 category: editForm.category,
                 imageUrl: editForm.imageUrl,
                 suins: editProject.suins || '',
+                // The category field is free text
+                allowCustomCategory: true,
             });
 
             if (!result.success) {
@@ -483,6 +530,36 @@ export const Projects: React.FC<ProjectsProps> = ({
                 </div>
             </div>
 
+            {/* Undo the last delete */}
+            {recentlyDeleted && (
+                <div className="flex items-center justify-between gap-4 px-4 py-3 bg-zinc-900 border border-zinc-800 rounded-sm">
+                    <span className="text-sm font-mono text-zinc-400">
+                        Deleted <span className="text-white">{recentlyDeleted.name}</span>. It can be restored for 30 days.
+                    </span>
+                    <div className="flex items-center gap-2">
+                        <motion.button
+                            onClick={restoreDeleted}
+                            variants={buttonVariants}
+                            whileHover="hover"
+                            whileTap="tap"
+                            className="px-3 py-1.5 bg-accent/10 hover:bg-accent/20 text-accent border border-accent/30 rounded-sm text-xs font-mono transition-all flex items-center gap-2"
+                        >
+                            <RotateCcw size={12} />
+                            Restore
+                        </motion.button>
+                        <motion.button
+                            onClick={() => setRecentlyDeleted(null)}
+                            variants={iconButtonVariants}
+                            whileHover="hover"
+                            whileTap="tap"
+                            className="p-1.5 text-zinc-500 hover:text-white transition-colors"
+                        >
+                            <X size={14} />
+                        </motion.button>
+                    </div>
+                </div>
+            )}
+
             {/* Filters */}
             <Card className="border-white/10 bg-zinc-900/20">
                 <div className="flex items-center gap-4">
@@ -924,7 +1001,7 @@ export const Projects: React.FC<ProjectsProps> = ({
                         animate={{ opacity: 1 }}
                         exit={{ opacity: 0 }}
                         className="fixed inset-0 bg-black/80 backdrop-blur-sm z-50 flex items-center justify-center p-4"
-                        onClick={() => !isDeleting && setDeleteConfirm(null)}
+                        onClick={() => !isDeleting && closeDeleteConfirm()}
                     >
                         <motion.div
                             initial={{ scale: 0.9, opacity: 0 }}
@@ -947,15 +1024,22 @@ export const Projects: React.FC<ProjectsProps> = ({
                                     <p className="text-sm text-zinc-400 mb-2">
                                         Are you sure you want to delete <span className="font-mono text-white">{deleteConfirm.name}</span>?
                                     </p>
-                                    <p className="text-xs text-red-400 font-mono">
-                                        This action cannot be undone.
+                                    <p className="text-xs text-zinc-500 font-mono">
+                                        {deleteConfirm.objectId
+                                            ? 'The site will be destroyed on-chain; that cannot be undone. The project record can be restored for 30 days, and the site folder is kept.'
+                                            : 'The project record can be restored for 30 days, and the site folder is kept.'}
                                     </p>
+                                    {linkedDomains.length > 0 && (
+                                        <p className="text-xs text-yellow-400 font-mono mt-2">
+                                            SuiNS domain {linkedDomains.join(', ')} still points at this site. Destroying the site will break the domain until you link it to another site.
+                                        </p>
+                                    )}
                                 </div>
                             </div>
 
                             <div className="flex gap-3">
                                 <motion.button
-                                    onClick={() => setDeleteConfirm(null)}
+                                    onClick={closeDeleteConfirm}
                                     disabled={isDeleting}
                                     className="flex-1 px-4 py-2 bg-zinc-800 hover:bg-zinc-700 text-white rounded-sm text-sm font-semibold transition-colors disabled:opacity-50 disabled:cursor-not-allowed"
                                     variants={buttonVariants}
@@ -972,7 +1056,7 @@ export const Projects: React.FC<ProjectsProps> = ({
                                     whileHover="hover"
                                     whileTap="tap"
                                 >
-                                    Delete
+                                    {linkedDomains.length > 0 ? 'Delete Anyway' : 'Delete'}
                                 </motion.button>
                             </div>
                         </motion.div>
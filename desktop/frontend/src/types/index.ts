@@ -64,6 +64,7 @@ export interface AIConfig {
 
 export interface WalletInfo {
     address: string;
+    alias?: string;
     suiBalance: number;
     walBalance: number;
     network: string;
@@ -3,6 +3,7 @@
 import {api} from '../models';
 import {main} from '../models';
 import {ai} from '../models';
+import {sui} from '../models';
 
 export function AICreateSite(arg1:api.AICreateSiteParams):Promise<void>;
 
@@ -64,7 +65,7 @@ export function GetSystemHealth():Promise<api.SystemHealth>;
 
 export function GetVersion():Promise<api.VersionResult>;
 
-export function GetWalletInfo():Promise<api.WalletInfo>;
+export function GetWalletInfo():Promise<sui.WalletInfo>;
 
 export function ImportAddress(arg1:api.ImportAddressParams):Promise<api.ImportAddressResult>;
 
@@ -102,6 +103,8 @@ export function RemoveAICredentials(arg1:string):Promise<void>;
 
 export function RenameFile(arg1:string,arg2:string):Promise<main.RenameFileResult>;
 
+export function RestoreDeletedProject(arg1:number):Promise<api.RestoreProjectResult>;
+
 export function SelectDirectory(arg1:string):Promise<string>;
 
 export function Serve(arg1:api.ServeParams):Promise<api.ServeResult>;
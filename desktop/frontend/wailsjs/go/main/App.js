@@ -198,6 +198,10 @@ export function RenameFile(arg1, arg2) {
   return window['go']['main']['App']['RenameFile'](arg1, arg2);
 }
 
+export function RestoreDeletedProject(arg1) {
+  return window['go']['main']['App']['RestoreDeletedProject'](arg1);
+}
+
 export function SelectDirectory(arg1) {
   return window['go']['main']['App']['SelectDirectory'](arg1);
 }
@@ -192,6 +192,7 @@ export namespace api {
 	    configuredProviders?: string[];
 	    success: boolean;
 	    error?: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new AIConfigResult(source);
@@ -208,6 +209,7 @@ export namespace api {
 	        this.configuredProviders = source["configuredProviders"];
 	        this.success = source["success"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class AIConfigureParams {
@@ -215,6 +217,7 @@ export namespace api {
 	    apiKey: string;
 	    baseURL?: string;
 	    model?: string;
+	    force?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new AIConfigureParams(source);
@@ -226,6 +229,7 @@ export namespace api {
 	        this.apiKey = source["apiKey"];
 	        this.baseURL = source["baseURL"];
 	        this.model = source["model"];
+	        this.force = source["force"];
 	    }
 	}
 	export class AICreateSiteParams {
@@ -251,6 +255,7 @@ export namespace api {
 	export class AddressListResult {
 	    addresses: string[];
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new AddressListResult(source);
@@ -260,12 +265,14 @@ export namespace api {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.addresses = source["addresses"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class ArchiveProjectResult {
 	    success: boolean;
 	    message: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ArchiveProjectResult(source);
@@ -276,6 +283,25 @@ export namespace api {
 	        this.success = source["success"];
 	        this.message = source["message"];
 	        this.error = source["error"];
+	        this.code = source["code"];
+	    }
+	}
+	export class RestoreProjectResult {
+	    success: boolean;
+	    message: string;
+	    error: string;
+	    code?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RestoreProjectResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class ToolVersionInfo {
@@ -303,6 +329,7 @@ export namespace api {
 	    tools: ToolVersionInfo[];
 	    message: string;
 	    error?: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new CheckToolVersionsResult(source);
@@ -314,6 +341,7 @@ export namespace api {
 	        this.tools = this.convertValues(source["tools"], ToolVersionInfo);
 	        this.message = source["message"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -340,6 +368,7 @@ export namespace api {
 	    alias: string;
 	    recoveryPhrase: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new CreateAddressResult(source);
@@ -352,10 +381,12 @@ export namespace api {
 	        this.alias = source["alias"];
 	        this.recoveryPhrase = source["recoveryPhrase"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class DeleteProjectParams {
 	    projectId: number;
+	    force: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new DeleteProjectParams(source);
@@ -364,14 +395,17 @@ export namespace api {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.projectId = source["projectId"];
+	        this.force = source["force"];
 	    }
 	}
 	export class DeleteProjectResult {
 	    success: boolean;
 	    message: string;
 	    error: string;
+	    code?: string;
 	    onChainDestroyed: boolean;
 	    estimatedGasCost?: string;
+	    linkedDomains?: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new DeleteProjectResult(source);
@@ -382,8 +416,10 @@ export namespace api {
 	        this.success = source["success"];
 	        this.message = source["message"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	        this.onChainDestroyed = source["onChainDestroyed"];
 	        this.estimatedGasCost = source["estimatedGasCost"];
+	        this.linkedDomains = source["linkedDomains"];
 	    }
 	}
 	export class DeploymentRecord {
@@ -425,6 +461,7 @@ export namespace api {
 	    description: string;
 	    imageUrl: string;
 	    suins: string;
+	    allowCustomCategory?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new EditProjectParams(source);
@@ -438,12 +475,14 @@ export namespace api {
 	        this.description = source["description"];
 	        this.imageUrl = source["imageUrl"];
 	        this.suins = source["suins"];
+	        this.allowCustomCategory = source["allowCustomCategory"];
 	    }
 	}
 	export class EditProjectResult {
 	    success: boolean;
 	    message: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new EditProjectResult(source);
@@ -454,6 +493,7 @@ export namespace api {
 	        this.success = source["success"];
 	        this.message = source["message"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class GasEstimateParams {
@@ -481,7 +521,9 @@ export namespace api {
 	    summary: string;
 	    siteSize: number;
 	    fileCount: number;
+	    gasDryRun: boolean;
 	    error?: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new GasEstimateResult(source);
@@ -497,7 +539,9 @@ export namespace api {
 	        this.summary = source["summary"];
 	        this.siteSize = source["siteSize"];
 	        this.fileCount = source["fileCount"];
+	        this.gasDryRun = source["gasDryRun"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class GenerateContentParams {
@@ -527,6 +571,7 @@ export namespace api {
 	    content: string;
 	    filePath: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new GenerateContentResult(source);
@@ -538,12 +583,14 @@ export namespace api {
 	        this.content = source["content"];
 	        this.filePath = source["filePath"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class GetInstalledThemesResult {
 	    success: boolean;
 	    themes: string[];
 	    error?: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new GetInstalledThemesResult(source);
@@ -554,6 +601,7 @@ export namespace api {
 	        this.success = source["success"];
 	        this.themes = source["themes"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class ImportAddressParams {
@@ -576,6 +624,7 @@ export namespace api {
 	    success: boolean;
 	    address: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ImportAddressResult(source);
@@ -586,6 +635,7 @@ export namespace api {
 	        this.success = source["success"];
 	        this.address = source["address"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class ImportObsidianParams {
@@ -619,6 +669,7 @@ export namespace api {
 	    filesImported: number;
 	    sitePath: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ImportObsidianResult(source);
@@ -630,12 +681,14 @@ export namespace api {
 	        this.filesImported = source["filesImported"];
 	        this.sitePath = source["sitePath"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class InitSiteResult {
 	    success: boolean;
 	    sitePath: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new InitSiteResult(source);
@@ -646,11 +699,14 @@ export namespace api {
 	        this.success = source["success"];
 	        this.sitePath = source["sitePath"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class InstallThemeParams {
 	    sitePath: string;
 	    githubUrl: string;
+	    ref?: string;
+	    noCache?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new InstallThemeParams(source);
@@ -660,6 +716,8 @@ export namespace api {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.sitePath = source["sitePath"];
 	        this.githubUrl = source["githubUrl"];
+	        this.ref = source["ref"];
+	        this.noCache = source["noCache"];
 	    }
 	}
 	export class InstallThemeResult {
@@ -667,6 +725,7 @@ export namespace api {
 	    themeName: string;
 	    removedThemes?: string[];
 	    error?: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new InstallThemeResult(source);
@@ -678,6 +737,7 @@ export namespace api {
 	        this.themeName = source["themeName"];
 	        this.removedThemes = source["removedThemes"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class LaunchStep {
@@ -707,6 +767,7 @@ export namespace api {
 	    imageUrl: string;
 	    epochs: number;
 	    skipConfirm: boolean;
+	    allowCustomCategory?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new LaunchWizardParams(source);
@@ -722,6 +783,7 @@ export namespace api {
 	        this.imageUrl = source["imageUrl"];
 	        this.epochs = source["epochs"];
 	        this.skipConfirm = source["skipConfirm"];
+	        this.allowCustomCategory = source["allowCustomCategory"];
 	    }
 	}
 	export class LaunchWizardResult {
@@ -729,6 +791,7 @@ export namespace api {
 	    objectId: string;
 	    steps: LaunchStep[];
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new LaunchWizardResult(source);
@@ -740,6 +803,7 @@ export namespace api {
 	        this.objectId = source["objectId"];
 	        this.steps = this.convertValues(source["steps"], LaunchStep);
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -785,6 +849,7 @@ export namespace api {
 	    path: string;
 	    filePath: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new NewContentResult(source);
@@ -796,6 +861,7 @@ export namespace api {
 	        this.path = source["path"];
 	        this.filePath = source["filePath"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class Project {
@@ -809,6 +875,7 @@ export namespace api {
 	    sitePath: string;
 	    imageUrl: string;
 	    suins: string;
+	    url?: string;
 	    createdAt: string;
 	    updatedAt: string;
 	    lastDeployAt: string;
@@ -844,6 +911,7 @@ export namespace api {
 	        this.sitePath = source["sitePath"];
 	        this.imageUrl = source["imageUrl"];
 	        this.suins = source["suins"];
+	        this.url = source["url"];
 	        this.createdAt = source["createdAt"];
 	        this.updatedAt = source["updatedAt"];
 	        this.lastDeployAt = source["lastDeployAt"];
@@ -888,6 +956,7 @@ export namespace api {
 	    baseURL: string;
 	    model: string;
 	    error?: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ProviderCredentialsResult(source);
@@ -900,6 +969,7 @@ export namespace api {
 	        this.baseURL = source["baseURL"];
 	        this.model = source["model"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class QuickStartParams {
@@ -926,6 +996,7 @@ export namespace api {
 	    success: boolean;
 	    sitePath: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new QuickStartResult(source);
@@ -936,6 +1007,7 @@ export namespace api {
 	        this.success = source["success"];
 	        this.sitePath = source["sitePath"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class ServeParams {
@@ -944,6 +1016,8 @@ export namespace api {
 	    drafts: boolean;
 	    expired: boolean;
 	    future: boolean;
+	    bind?: string;
+	    tls: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new ServeParams(source);
@@ -956,12 +1030,16 @@ export namespace api {
 	        this.drafts = source["drafts"];
 	        this.expired = source["expired"];
 	        this.future = source["future"];
+	        this.bind = source["bind"];
+	        this.tls = source["tls"];
 	    }
 	}
 	export class ServeResult {
 	    success: boolean;
 	    url: string;
+	    warning?: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ServeResult(source);
@@ -971,7 +1049,9 @@ export namespace api {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.url = source["url"];
+	        this.warning = source["warning"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class SetStatusParams {
@@ -992,6 +1072,7 @@ export namespace api {
 	    success: boolean;
 	    message: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new SetStatusResult(source);
@@ -1002,12 +1083,14 @@ export namespace api {
 	        this.success = source["success"];
 	        this.message = source["message"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class SetupDepsResult {
 	    success: boolean;
 	    message: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new SetupDepsResult(source);
@@ -1018,12 +1101,14 @@ export namespace api {
 	        this.success = source["success"];
 	        this.message = source["message"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class SwitchAddressResult {
 	    success: boolean;
 	    address: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new SwitchAddressResult(source);
@@ -1034,12 +1119,14 @@ export namespace api {
 	        this.success = source["success"];
 	        this.address = source["address"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class SwitchNetworkResult {
 	    success: boolean;
 	    network: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new SwitchNetworkResult(source);
@@ -1050,6 +1137,7 @@ export namespace api {
 	        this.success = source["success"];
 	        this.network = source["network"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class SystemHealth {
@@ -1097,6 +1185,7 @@ export namespace api {
 	    success: boolean;
 	    updatedContent: string;
 	    error: string;
+	    code?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new UpdateContentResult(source);
@@ -1107,6 +1196,7 @@ export namespace api {
 	        this.success = source["success"];
 	        this.updatedContent = source["updatedContent"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	    }
 	}
 	export class UpdateSiteParams {
@@ -1129,6 +1219,7 @@ export namespace api {
 	    gasFee: string;
 	    message: string;
 	    error: string;
+	    code?: string;
 	    logs?: string[];
 	
 	    static createFrom(source: any = {}) {
@@ -1142,6 +1233,7 @@ export namespace api {
 	        this.gasFee = source["gasFee"];
 	        this.message = source["message"];
 	        this.error = source["error"];
+	        this.code = source["code"];
 	        this.logs = source["logs"];
 	    }
 	}
@@ -1193,26 +1285,6 @@ export namespace api {
 	        this.buildDate = source["buildDate"];
 	    }
 	}
-	export class WalletInfo {
-	    address: string;
-	    suiBalance: number;
-	    walBalance: number;
-	    network: string;
-	    active: boolean;
-	
-	    static createFrom(source: any = {}) {
-	        return new WalletInfo(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.address = source["address"];
-	        this.suiBalance = source["suiBalance"];
-	        this.walBalance = source["walBalance"];
-	        this.network = source["network"];
-	        this.active = source["active"];
-	    }
-	}
 
 }
 
@@ -1232,6 +1304,7 @@ export namespace main {
 	    totalPages: number;
 	    filesCreated: number;
 	    error: string;
+	    previews: Record<string, string>;
 	
 	    static createFrom(source: any = {}) {
 	        return new AIProgressState(source);
@@ -1252,6 +1325,7 @@ export namespace main {
 	        this.totalPages = source["totalPages"];
 	        this.filesCreated = source["filesCreated"];
 	        this.error = source["error"];
+	        this.previews = source["previews"];
 	    }
 	}
 	export class CheckDirectoryDepthResult {
@@ -1479,3 +1553,31 @@ export namespace main {
 
 }
 
+export namespace sui {
+	
+	export class WalletInfo {
+	    address: string;
+	    alias?: string;
+	    suiBalance: number;
+	    walBalance: number;
+	    network: string;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WalletInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = source["address"];
+	        this.alias = source["alias"];
+	        this.suiBalance = source["suiBalance"];
+	        this.walBalance = source["walBalance"];
+	        this.network = source["network"];
+	        this.active = source["active"];
+	    }
+	}
+
+
+}
+
@@ -30,3 +30,10 @@ func openDirectoryDialog(ctx context.Context, title, defaultDir string) (string,
 func browserOpenURL(ctx context.Context, url string) {
 	wruntime.BrowserOpenURL(ctx, url)
 }
+
+func eventsEmit(ctx context.Context, name string, data ...interface{}) {
+	if ctx == nil {
+		return
+	}
+	wruntime.EventsEmit(ctx, name, data...)
+}
@@ -9,6 +9,8 @@ func windowToggleMaximise(_ context.Context)     {}
 func appQuit(_ context.Context)                  {}
 func browserOpenURL(_ context.Context, _ string) {}
 
+func eventsEmit(_ context.Context, _ string, _ ...interface{}) {}
+
 func openDirectoryDialog(_ context.Context, _, _ string) (string, error) {
 	return "", nil
 }
@@ -0,0 +1,164 @@
+package ai
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// CONTENT AUDIT
+// =============================================================================
+//
+// Reports content files missing frontmatter the theme expects, so gaps such as
+// an absent featured_image are caught before publishing.
+
+// AuditSeverity ranks how much a missing field affects the rendered page.
+type AuditSeverity string
+
+const (
+	// SeverityRequired means a theme template renders the field unconditionally.
+	SeverityRequired AuditSeverity = "required"
+	// SeverityOptional means the field is recommended but the theme copes without it.
+	SeverityOptional AuditSeverity = "optional"
+)
+
+// auditIgnoredFields are archetype fields that control publishing rather than
+// page content, so their absence is never reported.
+var auditIgnoredFields = map[string]bool{
+	"draft": true,
+}
+
+// MissingField is a recommended frontmatter field absent from a content file.
+type MissingField struct {
+	Field    string        `json:"field"`
+	Severity AuditSeverity `json:"severity"`
+}
+
+// FileAudit lists the fields missing from one content file.
+type FileAudit struct {
+	Path    string         `json:"path"`
+	Section string         `json:"section"`
+	Missing []MissingField `json:"missing"`
+}
+
+// HasRequired reports whether any missing field is required.
+func (f *FileAudit) HasRequired() bool {
+	for _, m := range f.Missing {
+		if m.Severity == SeverityRequired {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentAuditor checks content files against a theme's expected frontmatter.
+type ContentAuditor struct {
+	sitePath  string
+	themeName string
+	required  map[string]bool
+	fields    map[string][]string // section -> recommended fields
+}
+
+// NewContentAuditor analyzes the theme once for use across many files.
+func NewContentAuditor(sitePath, themeName string) *ContentAuditor {
+	a := &ContentAuditor{
+		sitePath:  sitePath,
+		themeName: themeName,
+		required:  map[string]bool{"title": true},
+		fields:    make(map[string][]string),
+	}
+	for _, p := range AnalyzeThemeConfig(sitePath, themeName).RequiredPageParams {
+		a.required[strings.ToLower(p)] = true
+	}
+	return a
+}
+
+// Severity returns how serious it is for a page to lack field.
+func (a *ContentAuditor) Severity(field string) AuditSeverity {
+	if a.required[strings.ToLower(field)] {
+		return SeverityRequired
+	}
+	return SeverityOptional
+}
+
+// sectionFields returns the recommended fields for section, computed once.
+func (a *ContentAuditor) sectionFields(section string) []string {
+	if fields, ok := a.fields[section]; ok {
+		return fields
+	}
+	fields := GetDynamicFrontmatterFields(a.sitePath, a.themeName, section)
+	a.fields[section] = fields
+	return fields
+}
+
+// AuditFile reports the recommended fields missing from content. A field set
+// to an empty string counts as missing.
+func (a *ContentAuditor) AuditFile(path, section, content string) *FileAudit {
+	audit := &FileAudit{Path: path, Section: section}
+
+	present := make(map[string]bool)
+	for key, value := range ParseFrontmatterFields(content) {
+		if s, ok := value.(string); ok && strings.TrimSpace(s) == "" {
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		present[strings.ToLower(key)] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range a.sectionFields(section) {
+		key := strings.ToLower(field)
+		if auditIgnoredFields[key] || present[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		audit.Missing = append(audit.Missing, MissingField{Field: field, Severity: a.Severity(field)})
+	}
+
+	// Required fields first, then alphabetical
+	sort.SliceStable(audit.Missing, func(i, j int) bool {
+		if audit.Missing[i].Severity != audit.Missing[j].Severity {
+			return audit.Missing[i].Severity == SeverityRequired
+		}
+		return audit.Missing[i].Field < audit.Missing[j].Field
+	})
+	return audit
+}
+
+// AuditContent audits every Markdown page at or under target, skipping section
+// list pages (_index.md). Only files with missing fields are returned.
+func AuditContent(sitePath, themeName, target string) ([]*FileAudit, error) {
+	auditor := NewContentAuditor(sitePath, themeName)
+
+	var audits []*FileAudit
+	err := filepath.Walk(target, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.EqualFold(filepath.Ext(path), ".md") || info.Name() == "_index.md" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path) // #nosec G304 - path is a content file under target
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		audit := auditor.AuditFile(path, ContentSection(sitePath, path), string(data))
+		if len(audit.Missing) > 0 {
+			audits = append(audits, audit)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("auditing %s: %w", target, err)
+	}
+
+	sort.Slice(audits, func(i, j int) bool { return audits[i].Path < audits[j].Path })
+	return audits, nil
+}
@@ -0,0 +1,141 @@
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeAuditSite creates a site whose theme renders featured_image using the
+// given single-page template, plus a posts section with mixed frontmatter.
+func writeAuditSite(t *testing.T, singleTemplate string) string {
+	t.Helper()
+	site := t.TempDir()
+	files := map[string]string{
+		"themes/shiny/layouts/_default/single.html": singleTemplate,
+		"content/posts/_index.md":                   "---\ntitle: Posts\n---\n",
+		"content/posts/complete.md": `---
+title: "Complete"
+description: "Has everything"
+date: 2024-01-01
+tags: [go]
+featured_image: /images/cover.png
+---
+Body`,
+		"content/posts/no-image.md": `---
+title: "No image"
+description: "Missing the cover"
+date: 2024-01-02
+tags: [go]
+---
+Body`,
+		"content/posts/empty-image.md": `+++
+title = "Empty image"
+description = "Cover left blank"
+date = 2024-01-03
+tags = ["go"]
+featured_image = ""
++++
+Body`,
+	}
+	for rel, body := range files {
+		path := filepath.Join(site, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return site
+}
+
+func auditsByName(audits []*FileAudit) map[string]*FileAudit {
+	m := make(map[string]*FileAudit, len(audits))
+	for _, a := range audits {
+		m[filepath.Base(a.Path)] = a
+	}
+	return m
+}
+
+func TestAuditContent_MissingFeaturedImage(t *testing.T) {
+	tests := []struct {
+		name         string
+		template     string
+		wantSeverity AuditSeverity
+	}{
+		{
+			name:         "unguarded template param is required",
+			template:     `<img src="{{ .Params.featured_image }}">{{ .Content }}`,
+			wantSeverity: SeverityRequired,
+		},
+		{
+			name:         "guarded template param is optional",
+			template:     `{{ with .Params.featured_image }}<img src="{{ . }}">{{ end }}{{ .Content }}`,
+			wantSeverity: SeverityOptional,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := writeAuditSite(t, tt.template)
+
+			audits, err := AuditContent(site, "shiny", filepath.Join(site, "content"))
+			if err != nil {
+				t.Fatalf("AuditContent() error = %v", err)
+			}
+
+			byName := auditsByName(audits)
+			if _, ok := byName["complete.md"]; ok {
+				t.Error("complete.md should not be reported")
+			}
+			if _, ok := byName["_index.md"]; ok {
+				t.Error("_index.md should be skipped")
+			}
+
+			for _, name := range []string{"no-image.md", "empty-image.md"} {
+				a, ok := byName[name]
+				if !ok {
+					t.Fatalf("%s should be reported", name)
+				}
+				if a.Section != "posts" {
+					t.Errorf("%s section = %q, want posts", name, a.Section)
+				}
+				if len(a.Missing) != 1 || a.Missing[0].Field != "featured_image" {
+					t.Fatalf("%s missing = %+v, want only featured_image", name, a.Missing)
+				}
+				if a.Missing[0].Severity != tt.wantSeverity {
+					t.Errorf("%s severity = %s, want %s", name, a.Missing[0].Severity, tt.wantSeverity)
+				}
+				if a.HasRequired() != (tt.wantSeverity == SeverityRequired) {
+					t.Errorf("%s HasRequired() = %v", name, a.HasRequired())
+				}
+			}
+		})
+	}
+}
+
+func TestContentAuditor_AuditFile(t *testing.T) {
+	auditor := NewContentAuditor(t.TempDir(), "")
+
+	t.Run("title is always required", func(t *testing.T) {
+		a := auditor.AuditFile("x.md", "pages", "---\ndescription: d\n---\nBody")
+		if len(a.Missing) != 1 || a.Missing[0].Field != "title" || a.Missing[0].Severity != SeverityRequired {
+			t.Errorf("Missing = %+v, want required title", a.Missing)
+		}
+	})
+
+	t.Run("draft is never reported", func(t *testing.T) {
+		a := auditor.AuditFile("x.md", "pages", "---\ntitle: t\ndescription: d\n---\nBody")
+		if len(a.Missing) != 0 {
+			t.Errorf("Missing = %+v, want none", a.Missing)
+		}
+	})
+
+	t.Run("required fields sort first", func(t *testing.T) {
+		a := auditor.AuditFile("x.md", "pages", "Body without frontmatter")
+		if len(a.Missing) != 2 || a.Missing[0].Field != "title" || a.Missing[1].Field != "description" {
+			t.Errorf("Missing = %+v, want title then description", a.Missing)
+		}
+	})
+}
@@ -26,6 +26,9 @@ type Client struct {
 	Model    string
 	client   *http.Client
 	Timeout  time.Duration
+	// UsageLog, when set, is the file each completion's GenerationStats
+	// are appended to (see UsageLogPath)
+	UsageLog string
 }
 
 // Message represents a single chat message in the conversation.
@@ -36,9 +39,15 @@ type Message struct {
 
 // ChatRequest represents the API request payload for chat completion.
 type ChatRequest struct {
-	Model    string    `json:"model"`
-	Messages []Message `json:"messages"`
-	Stream   bool      `json:"stream,omitempty"`
+	Model         string         `json:"model"`
+	Messages      []Message      `json:"messages"`
+	Stream        bool           `json:"stream,omitempty"`
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+}
+
+// StreamOptions asks a streaming response to end with the token usage.
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
 }
 
 // ChatResponse represents the API response from chat completion endpoint.
@@ -55,11 +64,7 @@ type ChatResponse struct {
 		} `json:"message"`
 		FinishReason string `json:"finish_reason"`
 	} `json:"choices"`
-	Usage struct {
-		PromptTokens     int `json:"prompt_tokens"`
-		CompletionTokens int `json:"completion_tokens"`
-		TotalTokens      int `json:"total_tokens"`
-	} `json:"usage"`
+	Usage TokenUsage `json:"usage"`
 }
 
 // NewClient initializes and returns a new AI client instance.
@@ -151,11 +156,8 @@ func (c *Client) GenerateContentWithContext(ctx context.Context, systemPrompt, u
 
 // ChatWithContext sends a chat completion request with context support for cancellation.
 func (c *Client) ChatWithContext(ctx context.Context, messages []Message) (string, error) {
-	if c.APIKey == "" {
-		return "", fmt.Errorf("API key is not configured — run 'walgo ai config' to set up your AI provider")
-	}
-	if c.BaseURL == "" {
-		return "", fmt.Errorf("API base URL is not configured for provider %q", c.Provider)
+	if err := c.checkConfigured(); err != nil {
+		return "", err
 	}
 
 	reqBody := ChatRequest{
@@ -171,26 +173,34 @@ func (c *Client) ChatWithContext(ctx context.Context, messages []Message) (strin
 
 	endpoint := fmt.Sprintf("%s/chat/completions", c.BaseURL)
 
+	return withRetries(ctx, func() (string, error) {
+		return c.doRequestWithContext(ctx, endpoint, jsonData)
+	}, isRetryableError)
+}
+
+// withRetries runs attempt up to MaxRetries times, backing off between
+// failures that retryable accepts.
+func withRetries(ctx context.Context, attempt func() (string, error), retryable func(error) bool) (string, error) {
 	var lastErr error
-	for attempt := 1; attempt <= MaxRetries; attempt++ {
+	for n := 1; n <= MaxRetries; n++ {
 		// Check context before each attempt
 		if ctx.Err() != nil {
 			return "", ctx.Err()
 		}
 
-		result, err := c.doRequestWithContext(ctx, endpoint, jsonData)
+		result, err := attempt()
 		if err == nil {
 			return result, nil
 		}
 
 		lastErr = err
 
-		if !isRetryableError(err) {
+		if !retryable(err) {
 			return "", err
 		}
 
-		if attempt < MaxRetries {
-			backoff := time.Duration(attempt*attempt) * time.Second
+		if n < MaxRetries {
+			backoff := time.Duration(n*n) * time.Second
 			select {
 			case <-ctx.Done():
 				return "", ctx.Err()
@@ -202,6 +212,17 @@ func (c *Client) ChatWithContext(ctx context.Context, messages []Message) (strin
 	return "", fmt.Errorf("request failed after %d attempts: %w", MaxRetries, lastErr)
 }
 
+// checkConfigured reports a missing API key or base URL.
+func (c *Client) checkConfigured() error {
+	if c.APIKey == "" {
+		return fmt.Errorf("API key is not configured — run 'walgo ai config' to set up your AI provider")
+	}
+	if c.BaseURL == "" {
+		return fmt.Errorf("API base URL is not configured for provider %q", c.Provider)
+	}
+	return nil
+}
+
 // doRequestWithContext executes a single HTTP request with context for cancellation.
 func (c *Client) doRequestWithContext(ctx context.Context, endpoint string, jsonData []byte) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonData))
@@ -209,26 +230,11 @@ func (c *Client) doRequestWithContext(ctx context.Context, endpoint string, json
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
-
-	// OpenRouter-specific headers
-	if c.Provider == "openrouter" {
-		req.Header.Set("HTTP-Referer", "https://github.com/selimozten/walgo")
-		req.Header.Set("X-Title", "Walgo AI Content Generator")
-	}
+	c.setChatHeaders(req)
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		// Check for context cancellation
-		if ctx.Err() != nil {
-			return "", ctx.Err()
-		}
-		// Check for timeout
-		if strings.Contains(err.Error(), "timeout") || strings.Contains(err.Error(), "deadline exceeded") {
-			return "", fmt.Errorf("request timed out after %v - try increasing timeout or check your network", c.Timeout)
-		}
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return "", c.requestError(ctx, err)
 	}
 	defer resp.Body.Close()
 
@@ -237,30 +243,107 @@ func (c *Client) doRequestWithContext(ctx context.Context, endpoint string, json
 		return "", fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Handle different status codes
-	switch resp.StatusCode {
+	if err := statusError(resp.StatusCode, body); err != nil {
+		return "", err
+	}
+	content, usage, err := parseChatResponse(body)
+	if err != nil {
+		return "", err
+	}
+	c.recordUsage(usage)
+	return content, nil
+}
+
+// statusError maps a non-200 API response to an error; retryable failures
+// match retryablePatterns.
+func statusError(status int, body []byte) error {
+	switch status {
 	case http.StatusOK:
-		// Success, continue parsing
+		return nil
 	case http.StatusTooManyRequests:
-		return "", fmt.Errorf("rate limited - please wait and try again: %s", string(body))
+		return fmt.Errorf("rate limited - please wait and try again: %s", string(body))
 	case http.StatusUnauthorized:
-		return "", fmt.Errorf("invalid API key - run 'walgo ai configure' to update credentials")
+		return fmt.Errorf("invalid API key - run 'walgo ai configure' to update credentials")
 	case http.StatusBadRequest:
-		return "", fmt.Errorf("bad request (check model name): %s", string(body))
+		return fmt.Errorf("bad request (check model name): %s", string(body))
 	case http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
-		return "", fmt.Errorf("service temporarily unavailable (retryable): %s", string(body))
+		return fmt.Errorf("service temporarily unavailable (retryable): %s", string(body))
 	default:
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("API request failed with status %d: %s", status, string(body))
 	}
+}
 
+// parseChatResponse returns the content and token usage of a non-streaming
+// chat completion.
+func parseChatResponse(body []byte) (string, *TokenUsage, error) {
 	var chatResp ChatResponse
 	if err := json.Unmarshal(body, &chatResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return "", nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if len(chatResp.Choices) == 0 {
-		return "", fmt.Errorf("no response from AI - the model may have rejected the request")
+		return "", nil, fmt.Errorf("no response from AI - the model may have rejected the request")
 	}
 
-	return chatResp.Choices[0].Message.Content, nil
+	return chatResp.Choices[0].Message.Content, &chatResp.Usage, nil
+}
+
+// setChatHeaders sets the headers of a chat completion request.
+func (c *Client) setChatHeaders(req *http.Request) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+
+	// OpenRouter-specific headers
+	if c.Provider == "openrouter" {
+		req.Header.Set("HTTP-Referer", "https://github.com/selimozten/walgo")
+		req.Header.Set("X-Title", "Walgo AI Content Generator")
+	}
+}
+
+// requestError describes a failed HTTP round trip.
+func (c *Client) requestError(ctx context.Context, err error) error {
+	// Check for context cancellation
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	// Check for timeout
+	if strings.Contains(err.Error(), "timeout") || strings.Contains(err.Error(), "deadline exceeded") {
+		return fmt.Errorf("request timed out after %v - try increasing timeout or check your network", c.Timeout)
+	}
+	return fmt.Errorf("failed to make request: %w", err)
+}
+
+// ValidateKey checks that the client's API key is accepted by the provider,
+// using an endpoint that costs no tokens: /models for OpenAI-compatible APIs
+// and /key for OpenRouter, whose model list is public.
+func (c *Client) ValidateKey(ctx context.Context) error {
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + "/models"
+	if c.Provider == "openrouter" {
+		endpoint = strings.TrimSuffix(c.BaseURL, "/") + "/key"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("invalid API key (status %d)", resp.StatusCode)
+	default:
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
 }
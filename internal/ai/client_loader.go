@@ -21,41 +21,49 @@ import (
 //	Model: Model name being used
 //	error: Error if no valid credentials found
 func LoadClient(timeout time.Duration) (*Client, string, string, error) {
+	creds, provider, err := ActiveCredentials()
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	// Create client with or without timeout
+	var client *Client
+	if timeout > 0 {
+		client = NewClientWithTimeout(creds.Provider, creds.APIKey, creds.BaseURL, creds.Model, timeout)
+	} else {
+		client = NewClient(creds.Provider, creds.APIKey, creds.BaseURL, creds.Model)
+	}
+	// Usage tracking is best effort; without a home directory it is off
+	if usageLog, err := UsageLogPath(); err == nil {
+		client.UsageLog = usageLog
+	}
+
+	return client, provider, creds.Model, nil
+}
+
+// ActiveCredentials returns the credentials LoadClient uses and the provider
+// they belong to. The model is resolved to the provider default when none is
+// configured.
+func ActiveCredentials() (*Credentials, string, error) {
 	providers := []string{"openai", "openrouter"}
 
 	for _, provider := range providers {
 		creds, err := GetProviderCredentials(provider)
 		if err == nil && creds.APIKey != "" {
 			// Resolve model name (use default if not specified)
-			model := resolveModel(provider, creds.Model)
-
-			// Create client with or without timeout
-			var client *Client
-			if timeout > 0 {
-				client = NewClientWithTimeout(creds.Provider, creds.APIKey, creds.BaseURL, model, timeout)
-			} else {
-				client = NewClient(creds.Provider, creds.APIKey, creds.BaseURL, model)
-			}
-
-			return client, provider, model, nil
+			creds.Model = resolveModel(provider, creds.Model)
+			return creds, provider, nil
 		}
 	}
 
-	return nil, "", "", fmt.Errorf("no AI credentials found - run 'walgo ai configure' first")
+	return nil, "", fmt.Errorf("no AI credentials found - run 'walgo ai configure' first")
 }
 
 // resolveModel returns the appropriate model name based on provider and user configuration.
-// If no model is specified, it returns a sensible default.
+// If no model is specified, it returns GetDefaultModel(provider).
 func resolveModel(provider, configuredModel string) string {
 	if configuredModel != "" {
 		return configuredModel
 	}
-
-	// Return defaults if no model configured
-	switch provider {
-	case "openrouter":
-		return "openai/gpt-4"
-	default: // openai
-		return "gpt-4"
-	}
+	return GetDefaultModel(provider)
 }
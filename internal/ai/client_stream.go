@@ -0,0 +1,199 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// streamingProviders are the providers whose chat completions endpoint
+// streams server-sent events when the request sets "stream": true.
+var streamingProviders = map[string]bool{
+	"openai":     true,
+	"openrouter": true,
+}
+
+// maxStreamLine caps a single server-sent event line.
+const maxStreamLine = 1 << 20
+
+// SupportsStreaming reports whether provider can stream chat completions.
+func SupportsStreaming(provider string) bool {
+	return streamingProviders[provider]
+}
+
+// TokenHandler receives generated content as it arrives.
+type TokenHandler func(token string)
+
+// streamChunk is one server-sent event of a streamed chat completion.
+type streamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+	Usage *TokenUsage `json:"usage"`
+}
+
+// GenerateContentStreamWithContext creates content like
+// GenerateContentWithContext, forwarding it to onToken as it is generated.
+func (c *Client) GenerateContentStreamWithContext(ctx context.Context, systemPrompt, userPrompt string, onToken TokenHandler) (string, error) {
+	messages := []Message{
+		{
+			Role:    "system",
+			Content: systemPrompt,
+		},
+		{
+			Role:    "user",
+			Content: userPrompt,
+		},
+	}
+
+	return c.ChatStreamWithContext(ctx, messages, onToken)
+}
+
+// ChatStreamWithContext sends a streaming chat completion request, passes
+// each token to onToken and returns the assembled content. Providers that
+// cannot stream, and servers that answer with a regular response, deliver
+// the whole content as a single token.
+func (c *Client) ChatStreamWithContext(ctx context.Context, messages []Message, onToken TokenHandler) (string, error) {
+	if onToken == nil {
+		onToken = func(string) {}
+	}
+	if !SupportsStreaming(c.Provider) {
+		content, err := c.ChatWithContext(ctx, messages)
+		if err != nil {
+			return "", err
+		}
+		onToken(content)
+		return content, nil
+	}
+	if err := c.checkConfigured(); err != nil {
+		return "", err
+	}
+
+	jsonData, err := json.Marshal(ChatRequest{
+		Model:         c.Model,
+		Messages:      messages,
+		Stream:        true,
+		StreamOptions: &StreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/chat/completions", c.BaseURL)
+
+	// Once tokens have reached the caller a retry would repeat them
+	var forwarded bool
+	forward := func(token string) {
+		forwarded = true
+		onToken(token)
+	}
+	return withRetries(ctx, func() (string, error) {
+		forwarded = false
+		return c.doStreamRequestWithContext(ctx, endpoint, jsonData, forward)
+	}, func(err error) bool {
+		return !forwarded && isRetryableError(err)
+	})
+}
+
+// doStreamRequestWithContext executes a single streaming HTTP request.
+func (c *Client) doStreamRequestWithContext(ctx context.Context, endpoint string, jsonData []byte, onToken TokenHandler) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setChatHeaders(req)
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", c.requestError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		return "", statusError(resp.StatusCode, body)
+	}
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		// The server ignored "stream" and sent the whole completion
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", fmt.Errorf("failed to read response: %w", err)
+		}
+		content, usage, err := parseChatResponse(body)
+		if err != nil {
+			return "", err
+		}
+		c.recordUsage(usage)
+		onToken(content)
+		return content, nil
+	}
+
+	content, usage, err := readEventStream(resp.Body, onToken)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", err
+	}
+	c.recordUsage(usage)
+	return content, nil
+}
+
+// readEventStream reads an OpenAI-style server-sent event stream, passing
+// each content delta to onToken, and returns the assembled content and the
+// token usage, if the stream reported it.
+func readEventStream(r io.Reader, onToken TokenHandler) (string, *TokenUsage, error) {
+	var content strings.Builder
+	var usage *TokenUsage
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLine)
+
+	for scanner.Scan() {
+		// Blank separators, comments (": keep-alive") and event names carry no content
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk streamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", nil, fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return "", nil, fmt.Errorf("stream failed: %s", chunk.Error.Message)
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		token := chunk.Choices[0].Delta.Content
+		content.WriteString(token)
+		onToken(token)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, fmt.Errorf("stream interrupted: %w", err)
+	}
+
+	if content.Len() == 0 {
+		return "", nil, fmt.Errorf("no response from AI - the model may have rejected the request")
+	}
+	return content.String(), usage, nil
+}
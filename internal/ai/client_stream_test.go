@@ -0,0 +1,202 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// streamingServer is a mock provider that streams tokens as server-sent
+// events, with the keep-alive comments and role-only chunks real providers
+// send, and counts requests.
+func streamingServer(t *testing.T, tokens []string, requests *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests != nil {
+			atomic.AddInt32(requests, 1)
+		}
+		var req ChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Stream {
+			t.Errorf("request stream = %v, err = %v; want a streaming request", req.Stream, err)
+		}
+		if accept := r.Header.Get("Accept"); accept != "text/event-stream" {
+			t.Errorf("Accept = %q", accept)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, `data: {"choices":[{"delta":{"role":"assistant"}}]}`+"\n\n")
+		for _, token := range tokens {
+			data, _ := json.Marshal(map[string]any{
+				"choices": []any{map[string]any{"delta": map[string]string{"content": token}}},
+			})
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_ChatStream_ForwardsTokens(t *testing.T) {
+	tokens := []string{"---\ntitle: ", "Hello", "\n---\n\n", "Streamed ", "content ", "with \"quotes\"."}
+	server := streamingServer(t, tokens, nil)
+
+	client := NewClient("openai", "test-key", server.URL, "gpt-4")
+	var got []string
+	content, err := client.ChatStreamWithContext(context.Background(), []Message{{Role: "user", Content: "hi"}}, func(token string) {
+		got = append(got, token)
+	})
+	if err != nil {
+		t.Fatalf("ChatStreamWithContext() error = %v", err)
+	}
+	if strings.Join(got, "|") != strings.Join(tokens, "|") {
+		t.Errorf("tokens = %q, want %q", got, tokens)
+	}
+	if want := strings.Join(tokens, ""); content != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+}
+
+func TestClient_ChatStream_NonStreamingProviderFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ChatRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Stream {
+			t.Error("streaming requested from a provider without streaming support")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"whole answer"}}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("custom", "test-key", server.URL, "local-model")
+	var got []string
+	content, err := client.ChatStreamWithContext(context.Background(), []Message{{Role: "user", Content: "hi"}}, func(token string) {
+		got = append(got, token)
+	})
+	if err != nil {
+		t.Fatalf("ChatStreamWithContext() error = %v", err)
+	}
+	if content != "whole answer" || len(got) != 1 || got[0] != "whole answer" {
+		t.Errorf("content = %q, tokens = %q; want one event with the whole answer", content, got)
+	}
+}
+
+func TestClient_ChatStream_UnstreamedResponse(t *testing.T) {
+	// A streaming provider behind a proxy that ignores "stream"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"buffered"}}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("openrouter", "test-key", server.URL, "model")
+	var got []string
+	content, err := client.ChatStreamWithContext(context.Background(), []Message{{Role: "user", Content: "hi"}}, func(token string) {
+		got = append(got, token)
+	})
+	if err != nil {
+		t.Fatalf("ChatStreamWithContext() error = %v", err)
+	}
+	if content != "buffered" || strings.Join(got, "|") != "buffered" {
+		t.Errorf("content = %q, tokens = %q", content, got)
+	}
+}
+
+func TestClient_ChatStream_ErrorAfterTokensNotRetried(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"choices":[{"delta":{"content":"partial"}}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"error":{"message":"upstream timeout"}}`+"\n\n")
+	}))
+	defer server.Close()
+
+	client := NewClient("openai", "test-key", server.URL, "gpt-4")
+	_, err := client.ChatStreamWithContext(context.Background(), []Message{{Role: "user", Content: "hi"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "upstream timeout") {
+		t.Fatalf("ChatStreamWithContext() error = %v, want the stream error", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("requests = %d, want no retry once tokens were forwarded", n)
+	}
+}
+
+func TestClient_ChatStream_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient("openai", "bad-key", server.URL, "gpt-4")
+	_, err := client.ChatStreamWithContext(context.Background(), []Message{{Role: "user", Content: "hi"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid API key") {
+		t.Fatalf("ChatStreamWithContext() error = %v, want invalid API key", err)
+	}
+}
+
+func TestGenerator_GeneratePage_StreamsTokens(t *testing.T) {
+	tokens := []string{"---\ntitle: About\n", "draft: false\n---\n\n", "## About\n\n", "Hello from the stream."}
+	server := streamingServer(t, tokens, nil)
+
+	tempDir := t.TempDir()
+	client := NewClient("openai", "test-key", server.URL, "gpt-4")
+	config := DefaultPipelineConfig()
+	config.ContentDir = tempDir
+	generator := NewGenerator(client, config)
+
+	var streamed strings.Builder
+	var tokenEvents int
+	generator.SetProgressHandler(func(event ProgressEvent) {
+		if event.EventType != ProgressToken {
+			return
+		}
+		tokenEvents++
+		if event.PageID != "about" || event.PagePath != "content/about.md" || event.Phase != PhaseGenerating {
+			t.Errorf("token event = %+v, want it tagged with the page", event)
+		}
+		streamed.WriteString(event.Message)
+	})
+
+	plan := &SitePlan{SiteName: "Test Site", Stats: PlanStats{TotalPages: 1}}
+	page := &PageSpec{ID: "about", Path: "content/about.md", PageType: PageTypePage, Title: "About", Status: PageStatusPending}
+
+	output := generator.GeneratePage(context.Background(), plan, page)
+	if !output.Success {
+		t.Fatalf("GeneratePage() failed: %s", output.ErrorMsg)
+	}
+	if tokenEvents != len(tokens) {
+		t.Errorf("token events = %d, want %d", tokenEvents, len(tokens))
+	}
+
+	written, err := os.ReadFile(filepath.Join(tempDir, "about.md"))
+	if err != nil {
+		t.Fatalf("failed to read generated file: %v", err)
+	}
+	if want := strings.Join(tokens, ""); streamed.String() != want || string(written) != want {
+		t.Errorf("streamed = %q, written = %q, want %q", streamed.String(), written, want)
+	}
+}
+
+func TestProgressAggregator_IgnoresTokens(t *testing.T) {
+	agg := NewProgressAggregator()
+	handler := agg.Handler()
+	handler(ProgressEvent{EventType: ProgressPageStart, PageID: "p"})
+	handler(ProgressEvent{EventType: ProgressToken, PageID: "p", Message: "tok"})
+	handler(ProgressEvent{EventType: ProgressPageDone, PageID: "p"})
+
+	if n := len(agg.events); n != 2 {
+		t.Errorf("stored %d events, want tokens left out", n)
+	}
+}
@@ -568,3 +568,48 @@ func TestMaxRetries(t *testing.T) {
 		t.Errorf("expected MaxRetries to be 3, got %d", MaxRetries)
 	}
 }
+
+func TestClient_ValidateKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		wantPath string
+		status   int
+		wantErr  string
+	}{
+		{name: "openai accepted", provider: "openai", wantPath: "/models", status: http.StatusOK},
+		{name: "openrouter accepted", provider: "openrouter", wantPath: "/key", status: http.StatusOK},
+		{name: "unauthorized", provider: "openai", wantPath: "/models", status: http.StatusUnauthorized, wantErr: "invalid API key"},
+		{name: "forbidden", provider: "openai", wantPath: "/models", status: http.StatusForbidden, wantErr: "invalid API key"},
+		{name: "server error", provider: "openai", wantPath: "/models", status: http.StatusInternalServerError, wantErr: "status 500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("method = %s, want GET", r.Method)
+				}
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %s, want %s", r.URL.Path, tt.wantPath)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer new-key" {
+					t.Errorf("Authorization = %q, want bearer token", got)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewClient(tt.provider, "new-key", server.URL+"/", "").ValidateKey(context.Background())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateKey() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateKey() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
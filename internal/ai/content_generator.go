@@ -100,6 +100,12 @@ type ContentGenerationParams struct {
 	SitePath     string
 	Instructions string
 	Context      context.Context
+	// Examples is how many existing pages to include as few-shot examples
+	// (0 for none, at most MaxContentExamples).
+	Examples int
+	// Section the examples are drawn from; defaults to the site's default
+	// content type.
+	Section string
 }
 
 // ContentGenerationResult holds the result of content generation
@@ -545,8 +551,16 @@ func (cg *ContentGenerator) GenerateContent(params ContentGenerationParams) *Con
 		return result
 	}
 
-	// Build system prompt with content structure
-	systemPrompt := cg.buildSmartSystemPrompt(structure)
+	// Build system prompt with content structure and example pages
+	var examples []ContentExample
+	if params.Examples > 0 {
+		section := params.Section
+		if section == "" {
+			section = structure.DefaultType
+		}
+		examples = SelectContentExamples(params.SitePath, section, params.Examples)
+	}
+	systemPrompt := cg.buildSmartSystemPrompt(structure, examples...)
 
 	// Build user prompt
 	userPrompt := fmt.Sprintf("User instructions: %s", params.Instructions)
@@ -643,8 +657,9 @@ func (cg *ContentGenerator) GenerateContent(params ContentGenerationParams) *Con
 	return result
 }
 
-// buildSmartSystemPrompt creates a system prompt with comprehensive site context
-func (cg *ContentGenerator) buildSmartSystemPrompt(structure *ContentStructure) string {
+// buildSmartSystemPrompt creates a system prompt with comprehensive site
+// context, followed by any example pages within MaxExamplesChars.
+func (cg *ContentGenerator) buildSmartSystemPrompt(structure *ContentStructure, examples ...ContentExample) string {
 	var contextParts []string
 
 	// Add site configuration
@@ -787,6 +802,10 @@ func (cg *ContentGenerator) buildSmartSystemPrompt(structure *ContentStructure)
 		contextParts = append(contextParts, filesInfo)
 	}
 
+	if examplesInfo := BuildExamplesContext(examples, MaxExamplesChars); examplesInfo != "" {
+		contextParts = append(contextParts, examplesInfo)
+	}
+
 	// Combine all context
 	// Dynamic theme analysis already provides comprehensive Hugo/theme knowledge
 	fullContext := "=== SITE CONTEXT ===\n\n" + strings.Join(contextParts, "\n\n")
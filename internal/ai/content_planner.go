@@ -0,0 +1,145 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SiteSpec describes the site a ContentPlanner plans pages for.
+type SiteSpec = PlannerInput
+
+// PlannedPage is a single page produced by a ContentPlanner.
+type PlannedPage = PageSpec
+
+// ContentPlanner decides which pages a new site should have. Implementations
+// may ask an AI provider, expand a template, or return a fixed list, so the
+// planning step can be swapped without touching content generation.
+type ContentPlanner interface {
+	Plan(ctx context.Context, spec SiteSpec) ([]PlannedPage, error)
+}
+
+// sitePlanner is implemented by planners that produce a complete SitePlan,
+// including site-level details such as tone that a page list cannot carry.
+type sitePlanner interface {
+	PlanSite(ctx context.Context, input *PlannerInput) (*SitePlan, error)
+}
+
+// AIContentPlanner plans pages by asking the configured AI provider.
+type AIContentPlanner struct {
+	planner *Planner
+}
+
+// NewAIContentPlanner returns a ContentPlanner backed by the AI provider.
+func NewAIContentPlanner(client *Client, config PipelineConfig) *AIContentPlanner {
+	return &AIContentPlanner{planner: NewPlanner(client, config)}
+}
+
+// Plan returns the pages of an AI-generated site plan.
+func (p *AIContentPlanner) Plan(ctx context.Context, spec SiteSpec) ([]PlannedPage, error) {
+	plan, err := p.PlanSite(ctx, &spec)
+	if err != nil {
+		return nil, err
+	}
+	return plan.Pages, nil
+}
+
+// PlanSite returns the full AI-generated site plan.
+func (p *AIContentPlanner) PlanSite(ctx context.Context, input *PlannerInput) (*SitePlan, error) {
+	return p.planner.Plan(ctx, input)
+}
+
+// TemplatePlanner plans a fixed set of pages per site type without calling an
+// AI provider, for offline use and deterministic output.
+type TemplatePlanner struct{}
+
+// Plan returns the template pages for the spec's site type.
+func (TemplatePlanner) Plan(ctx context.Context, spec SiteSpec) ([]PlannedPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	page := func(id, path, title string, pageType PageType, description string) PlannedPage {
+		return PlannedPage{ID: id, Path: path, Title: title, PageType: pageType, Description: description}
+	}
+
+	switch spec.SiteType {
+	case SiteTypeDocs:
+		return []PlannedPage{
+			page("home", "content/_index.md", spec.SiteName, PageTypeHome, "Overview of "+spec.SiteName),
+			page("docs-index", "content/docs/_index.md", "Documentation", PageTypeSection, "Documentation section index"),
+			page("getting-started", "content/docs/getting-started.md", "Getting Started", PageTypeDocs, "Installation and first steps"),
+			page("configuration", "content/docs/configuration.md", "Configuration", PageTypeDocs, "Available configuration options"),
+			page("faq", "content/docs/faq.md", "FAQ", PageTypeDocs, "Frequently asked questions"),
+		}, nil
+	case SiteTypeBlog:
+		return []PlannedPage{
+			page("home", "content/_index.md", spec.SiteName, PageTypeHome, "Welcome to "+spec.SiteName),
+			page("about", "content/about.md", "About", PageTypePage, "Who is behind "+spec.SiteName),
+			page("posts-index", "content/posts/_index.md", "Posts", PageTypeSection, "Posts section index"),
+			page("welcome", "content/posts/welcome/index.md", "Welcome", PageTypePost, "Introductory first post"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("no template for site type: %s", spec.SiteType)
+	}
+}
+
+// NewSitePlan builds a validated SitePlan from the pages a ContentPlanner
+// returned. Missing IDs, page types, content types, and section indexes are
+// filled in.
+func NewSitePlan(spec SiteSpec, pages []PlannedPage) (*SitePlan, error) {
+	var p Planner
+	now := time.Now()
+
+	plan := &SitePlan{
+		ID:          uuid.New().String(),
+		Version:     "1.0",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		SiteName:    spec.SiteName,
+		SiteType:    spec.SiteType,
+		Description: spec.Description,
+		Audience:    spec.Audience,
+		Theme:       spec.Theme,
+		SitePath:    spec.SitePath,
+		BaseURL:     spec.BaseURL,
+		Tone:        spec.Tone,
+		Status:      PlanStatusPending,
+		Pages:       make([]PageSpec, 0, len(pages)),
+	}
+
+	for i, page := range pages {
+		if page.ID == "" {
+			page.ID = fmt.Sprintf("page_%d", i+1)
+		}
+		page.PageType = p.determinePageType(string(page.PageType), page.Path)
+		if page.ContentType == "" {
+			page.ContentType = p.determineContentType(page.Path)
+		}
+		page.Status = PageStatusPending
+		page.Attempts = 0
+		plan.Pages = append(plan.Pages, page)
+	}
+
+	// Validation may add missing section indexes, so count pages afterwards
+	if err := p.validatePlan(plan); err != nil {
+		return nil, NewPlannerError(&spec, err, "plan validation failed")
+	}
+	plan.Stats.TotalPages = len(plan.Pages)
+	return plan, nil
+}
+
+// NewContentPlanner returns the planner registered under name: "ai" (default)
+// or "template".
+func NewContentPlanner(name string, client *Client, config PipelineConfig) (ContentPlanner, error) {
+	switch name {
+	case "", "ai":
+		return NewAIContentPlanner(client, config), nil
+	case "template":
+		return TemplatePlanner{}, nil
+	default:
+		return nil, fmt.Errorf("unknown content planner: %s (use ai or template)", name)
+	}
+}
@@ -0,0 +1,149 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// fakePlanner returns a fixed page list and records the spec it was given.
+type fakePlanner struct {
+	pages []PlannedPage
+	err   error
+	spec  SiteSpec
+}
+
+func (f *fakePlanner) Plan(ctx context.Context, spec SiteSpec) ([]PlannedPage, error) {
+	f.spec = spec
+	return f.pages, f.err
+}
+
+func TestTemplatePlanner(t *testing.T) {
+	for _, siteType := range []SiteType{SiteTypeBlog, SiteTypeDocs} {
+		t.Run(string(siteType), func(t *testing.T) {
+			spec := SiteSpec{SiteName: "Demo", SiteType: siteType}
+			pages, err := TemplatePlanner{}.Plan(context.Background(), spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			plan, err := NewSitePlan(spec, pages)
+			if err != nil {
+				t.Fatalf("template pages should form a valid plan: %v", err)
+			}
+			if plan.Pages[0].PageType != PageTypeHome {
+				t.Errorf("expected home page first, got %s", plan.Pages[0].PageType)
+			}
+		})
+	}
+
+	if _, err := (TemplatePlanner{}).Plan(context.Background(), SiteSpec{SiteType: "wiki"}); err == nil {
+		t.Error("expected error for site type without template")
+	}
+}
+
+func TestNewSitePlan(t *testing.T) {
+	spec := SiteSpec{SiteName: "Demo", SiteType: SiteTypeBlog, Tone: "friendly"}
+	pages := []PlannedPage{
+		{Path: "content/_index.md", Title: "Home"},
+		{Path: "content/posts/_index.md", Title: "Posts"},
+		{Path: "content/posts/hello/index.md", Title: "Hello"},
+	}
+
+	plan, err := NewSitePlan(spec, pages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plan.ID == "" || plan.Status != PlanStatusPending || plan.Tone != "friendly" {
+		t.Errorf("unexpected plan metadata: %+v", plan)
+	}
+	if plan.Stats.TotalPages != 3 {
+		t.Errorf("expected 3 total pages, got %d", plan.Stats.TotalPages)
+	}
+
+	post := plan.Pages[2]
+	if post.ID != "page_3" || post.PageType != PageTypePost || post.ContentType != "posts" || post.Status != PageStatusPending {
+		t.Errorf("page defaults not filled in: %+v", post)
+	}
+
+	if _, err := NewSitePlan(spec, nil); err == nil {
+		t.Error("expected error for empty page list")
+	}
+}
+
+func TestNewContentPlanner(t *testing.T) {
+	client := NewClient("openai", "test-key", "", "gpt-4")
+	config := DefaultPipelineConfig()
+
+	for name, want := range map[string]string{"": "ai", "ai": "ai", "template": "template"} {
+		planner, err := NewContentPlanner(name, client, config)
+		if err != nil {
+			t.Fatalf("NewContentPlanner(%q) error: %v", name, err)
+		}
+		_, isTemplate := planner.(TemplatePlanner)
+		if (want == "template") != isTemplate {
+			t.Errorf("NewContentPlanner(%q) returned %T", name, planner)
+		}
+	}
+
+	if _, err := NewContentPlanner("magic", client, config); err == nil {
+		t.Error("expected error for unknown planner")
+	}
+}
+
+func TestPipeline_UsesContentPlanner(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	tempDir := t.TempDir()
+	config := DefaultPipelineConfig()
+	config.PlanPath = filepath.Join(tempDir, ".walgo", "plan.json")
+	config.ContentDir = tempDir
+
+	pipeline := NewPipeline(NewClient("openai", "test-key", server.URL, "gpt-4"), config)
+	fake := &fakePlanner{pages: []PlannedPage{
+		{ID: "home", Path: "content/_index.md", Title: "Home"},
+		{ID: "about", Path: "content/about.md", Title: "About"},
+	}}
+	pipeline.SetContentPlanner(fake)
+
+	input := &PlannerInput{SiteName: "Demo", SiteType: SiteTypeBlog}
+	plan, err := pipeline.PlanOnly(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.spec.SiteName != "Demo" {
+		t.Errorf("planner did not receive the site spec: %+v", fake.spec)
+	}
+	if len(plan.Pages) != 2 || plan.Pages[1].ID != "about" {
+		t.Errorf("plan does not contain the planner's pages: %+v", plan.Pages)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("expected no AI calls when planning with a custom planner, got %d", n)
+	}
+
+	fake.err = errors.New("offline")
+	if _, err := pipeline.PlanOnly(context.Background(), input); err == nil {
+		t.Error("expected planner error to be returned")
+	}
+}
+
+func TestNewSitePlan_AddsSectionIndex(t *testing.T) {
+	plan, err := NewSitePlan(SiteSpec{SiteName: "Demo", SiteType: SiteTypeBlog}, []PlannedPage{
+		{Path: "content/_index.md"},
+		{Path: "content/posts/hello/index.md"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Pages) != 3 || plan.Stats.TotalPages != 3 {
+		t.Errorf("expected posts section index to be added, got %d pages (stats %d)", len(plan.Pages), plan.Stats.TotalPages)
+	}
+}
@@ -0,0 +1,90 @@
+package ai
+
+import (
+	"sort"
+	"strings"
+)
+
+// MaxContextChars is the default size budget, in characters, for the theme
+// context added to prompts (roughly 3,000 tokens). Large themes and sites are
+// cut down to fit so the context leaves room for the rest of the prompt.
+const MaxContextChars = 12000
+
+// ContextTruncatedMarker ends a theme context that was cut to fit its budget.
+const ContextTruncatedMarker = "[truncated]\n"
+
+// Theme context blocks in the order they are kept when the budget is tight:
+// what the theme needs to render comes before examples of what it can do.
+const (
+	priorityHeader = iota
+	priorityRequiredParams
+	prioritySections
+	priorityFrontmatter
+	priorityPageParams
+	priorityFeatures
+	priorityExamples
+	priorityLearnedContent
+)
+
+// contextBlock is one section of the theme context. reduced, when set, is a
+// shorter form to use when text does not fit.
+type contextBlock struct {
+	priority int
+	text     string
+	reduced  string
+}
+
+// fitContextBlocks joins blocks in order. When they exceed maxChars, blocks
+// are admitted by priority (falling back to their reduced form) until the
+// budget, less room for ContextTruncatedMarker, runs out; the rest are
+// dropped and the marker is appended. maxChars <= 0 disables the budget.
+func fitContextBlocks(blocks []contextBlock, maxChars int) string {
+	total := 0
+	for _, b := range blocks {
+		total += len(b.text)
+	}
+	if maxChars <= 0 || total <= maxChars {
+		return joinContextBlocks(blocks, nil)
+	}
+	if maxChars < len(ContextTruncatedMarker) {
+		return ""
+	}
+
+	order := make([]int, len(blocks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return blocks[order[a]].priority < blocks[order[b]].priority })
+
+	available := maxChars - len(ContextTruncatedMarker)
+	chosen := make(map[int]string, len(blocks))
+	for _, i := range order {
+		switch b := blocks[i]; {
+		case len(b.text) <= available:
+			chosen[i] = b.text
+		case b.reduced != "" && len(b.reduced) <= available:
+			chosen[i] = b.reduced
+		default:
+			continue
+		}
+		available -= len(chosen[i])
+	}
+	return joinContextBlocks(blocks, chosen) + ContextTruncatedMarker
+}
+
+// joinContextBlocks concatenates blocks in their original order, using the
+// chosen text for each when chosen is non-nil and skipping blocks not in it.
+func joinContextBlocks(blocks []contextBlock, chosen map[int]string) string {
+	var sb strings.Builder
+	for i, b := range blocks {
+		text := b.text
+		if chosen != nil {
+			var ok bool
+			if text, ok = chosen[i]; !ok {
+				continue
+			}
+		}
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
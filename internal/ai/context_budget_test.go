@@ -0,0 +1,93 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// bigThemeAnalysis returns analysis results for a large theme and site whose
+// full context is several thousand characters, mostly learned content.
+func bigThemeAnalysis() (*ThemeAnalysis, *ThemeConfigAnalysis, *ContentPatterns) {
+	theme := &ThemeAnalysis{
+		Name:              "big",
+		Description:       "A theme with everything",
+		Sections:          []string{"posts", "docs"},
+		FrontmatterFields: map[string][]string{"posts": {"title", "date", "tags"}},
+		HasTaxonomies:     true,
+	}
+	config := &ThemeConfigAnalysis{
+		RequiredParams:    []string{"author", "logo"},
+		OptionalParams:    []string{strings.Repeat("optional_param_", 40)},
+		RecommendedParams: map[string]interface{}{"showToc": true},
+	}
+	patterns := &ContentPatterns{SectionPatterns: map[string]*SectionPattern{}}
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("section%03d", i)
+		patterns.SectionPatterns[name] = &SectionPattern{
+			Name:         name,
+			CommonFields: []string{"title", "date", "example_field_with_a_long_name"},
+		}
+	}
+	return theme, config, patterns
+}
+
+func TestBuildThemeContextWithBudget_Truncates(t *testing.T) {
+	theme, config, patterns := bigThemeAnalysis()
+	full := BuildThemeContextWithBudget("big", theme, config, patterns, 0)
+
+	const budget = 600
+	if len(full) <= budget {
+		t.Fatalf("fixture too small: %d chars", len(full))
+	}
+
+	got := BuildThemeContextWithBudget("big", theme, config, patterns, budget)
+	if len(got) > budget {
+		t.Errorf("context is %d chars, want at most %d", len(got), budget)
+	}
+	for _, want := range []string{"THEME: big", "SUPPORTED SECTIONS", "- posts/", "Required: author, logo"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("truncated context missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "LEARNED FROM EXISTING CONTENT") || strings.Contains(got, "section000") {
+		t.Errorf("learned content examples should be dropped first:\n%s", got)
+	}
+	if strings.Contains(got, "optional_param_") {
+		t.Errorf("optional params should give way to required ones:\n%s", got)
+	}
+	if !strings.HasSuffix(got, ContextTruncatedMarker) {
+		t.Errorf("truncated context should end with %q:\n%s", ContextTruncatedMarker, got)
+	}
+}
+
+func TestBuildThemeContextWithBudget_FitsUnchanged(t *testing.T) {
+	theme, config, patterns := bigThemeAnalysis()
+	full := BuildThemeContextWithBudget("big", theme, config, patterns, 0)
+
+	got := BuildThemeContextWithBudget("big", theme, config, patterns, len(full))
+	if strings.Contains(got, strings.TrimSpace(ContextTruncatedMarker)) {
+		t.Error("a context within budget should not be marked truncated")
+	}
+	if len(got) != len(full) {
+		t.Errorf("context within budget changed size: %d vs %d", len(got), len(full))
+	}
+}
+
+func TestFitContextBlocks(t *testing.T) {
+	blocks := []contextBlock{
+		{priority: priorityHeader, text: "HEAD\n"},
+		{priority: priorityLearnedContent, text: strings.Repeat("l", 50)},
+		{priority: priorityRequiredParams, text: "REQ+OPTIONAL\n", reduced: "REQ\n"},
+	}
+
+	if got := fitContextBlocks(blocks, 0); !strings.HasPrefix(got, "HEAD\n") || len(got) != 5+50+13 {
+		t.Errorf("unlimited budget = %q", got)
+	}
+	if got := fitContextBlocks(blocks, 5+4+len(ContextTruncatedMarker)); got != "HEAD\nREQ\n"+ContextTruncatedMarker {
+		t.Errorf("tight budget = %q, want header, reduced params and marker", got)
+	}
+	if got := fitContextBlocks(blocks, 3); got != "" {
+		t.Errorf("budget smaller than the marker = %q, want empty", got)
+	}
+}
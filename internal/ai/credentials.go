@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/selimozten/walgo/internal/config"
 	"gopkg.in/yaml.v3"
 )
 
@@ -78,8 +80,27 @@ func SaveCredentials(creds *CredentialsFile) error {
 		return fmt.Errorf("failed to marshal credentials: %w", err)
 	}
 
-	// #nosec G306 - credentials file should be restrictive
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	// Write to a private temp file and rename it into place, so the old file
+	// survives a failed write and an existing file never keeps looser modes.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".ai-credentials-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
 		return fmt.Errorf("failed to write credentials file: %w", err)
 	}
 
@@ -98,6 +119,9 @@ func GetProviderCredentials(provider string) (*Credentials, error) {
 		return nil, fmt.Errorf("no credentials found for provider: %s", provider)
 	}
 
+	// Allow keys like ${OPENAI_API_KEY} so secrets can live in .walgo.env
+	providerCreds.APIKey = config.ExpandEnvRefs(providerCreds.APIKey)
+
 	return &providerCreds, nil
 }
 
@@ -118,6 +142,43 @@ func SetProviderCredentials(provider, apiKey, baseURL, model string) error {
 	return SaveCredentials(creds)
 }
 
+// SetProviderModel changes the model for an already configured AI provider.
+func SetProviderModel(provider, model string) error {
+	creds, err := LoadCredentials()
+	if err != nil {
+		return err
+	}
+
+	providerCreds, exists := creds.Providers[provider]
+	if !exists {
+		return fmt.Errorf("no credentials found for provider: %s", provider)
+	}
+
+	providerCreds.Model = model
+	creds.Providers[provider] = providerCreds
+
+	return SaveCredentials(creds)
+}
+
+// SetProviderAPIKey replaces the API key of an already configured AI provider,
+// keeping its base URL and model.
+func SetProviderAPIKey(provider, apiKey string) error {
+	creds, err := LoadCredentials()
+	if err != nil {
+		return err
+	}
+
+	providerCreds, exists := creds.Providers[provider]
+	if !exists {
+		return fmt.Errorf("no credentials found for provider: %s", provider)
+	}
+
+	providerCreds.APIKey = apiKey
+	creds.Providers[provider] = providerCreds
+
+	return SaveCredentials(creds)
+}
+
 // RemoveProviderCredentials deletes credentials for specified AI provider.
 func RemoveProviderCredentials(provider string) error {
 	creds, err := LoadCredentials()
@@ -177,3 +238,33 @@ func GetDefaultBaseURL(provider string) string {
 		return ""
 	}
 }
+
+// GetDefaultModel returns the model suggested for a provider when none is chosen.
+func GetDefaultModel(provider string) string {
+	if provider == "openrouter" {
+		return "openai/gpt-4"
+	}
+	return "gpt-4"
+}
+
+// redactedKeySuffix is how many trailing characters RedactKey keeps.
+const redactedKeySuffix = 4
+
+// RedactKey masks an API key for display, keeping its type prefix (the text
+// up to the first "-", like "sk-") and last four characters so keys can be
+// told apart: "sk-...abcd". Keys too short to hide most of their characters
+// are masked completely.
+func RedactKey(apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+	if len(apiKey) < 3*redactedKeySuffix {
+		return "****"
+	}
+
+	prefix := ""
+	if i := strings.Index(apiKey, "-"); i > 0 && i < len(apiKey)/3 {
+		prefix = apiKey[:i+1]
+	}
+	return prefix + "..." + apiKey[len(apiKey)-redactedKeySuffix:]
+}
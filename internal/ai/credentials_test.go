@@ -3,6 +3,7 @@ package ai
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -413,3 +414,84 @@ func TestCredentials_EmptyProvidersMap(t *testing.T) {
 		t.Error("Providers map should be initialized, not nil")
 	}
 }
+
+func TestSetProviderAPIKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := SetProviderAPIKey("openai", "new-key"); err == nil {
+		t.Fatal("expected error for unconfigured provider")
+	}
+
+	if err := SetProviderCredentials("openai", "old-key", "https://custom.url", "gpt-4o"); err != nil {
+		t.Fatalf("SetProviderCredentials failed: %v", err)
+	}
+	if err := SetProviderAPIKey("openai", "new-key"); err != nil {
+		t.Fatalf("SetProviderAPIKey failed: %v", err)
+	}
+
+	provCreds, err := GetProviderCredentials("openai")
+	if err != nil {
+		t.Fatalf("failed to get credentials: %v", err)
+	}
+	if provCreds.APIKey != "new-key" {
+		t.Errorf("expected APIKey 'new-key', got %s", provCreds.APIKey)
+	}
+	if provCreds.BaseURL != "https://custom.url" || provCreds.Model != "gpt-4o" {
+		t.Errorf("base URL and model not kept: %+v", provCreds)
+	}
+}
+
+func TestSaveCredentials_TightensExistingFileMode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	path, err := GetCredentialsPath()
+	if err != nil {
+		t.Fatalf("GetCredentialsPath failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("providers: {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write credentials: %v", err)
+	}
+
+	if err := SetProviderCredentials("openai", "key", "", ""); err != nil {
+		t.Fatalf("SetProviderCredentials failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat credentials: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected file permissions 0600, got %o", info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the credentials file, found %d entries", len(entries))
+	}
+}
+
+func TestRedactKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"", ""},
+		{"short", "****"},
+		{"sk-proj-1234567890abcd", "sk-...abcd"},
+		{"sk-or-v1-0123456789abcdef9f3e", "sk-...9f3e"},
+		{"nodashkey1234567wxyz", "...wxyz"},
+		{"averylongprefixwithoutearlydash-1234wxyz", "...wxyz"},
+	}
+	for _, tt := range tests {
+		got := RedactKey(tt.key)
+		if got != tt.want {
+			t.Errorf("RedactKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+		if len(tt.key) > 4 && strings.Contains(got, tt.key) {
+			t.Errorf("RedactKey(%q) leaked the full key", tt.key)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package ai
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxContentExamples is the most example pages added to a generation prompt.
+const MaxContentExamples = 2
+
+// MaxExamplesChars is the size budget, in characters, for all example pages
+// together. Each example gets an equal share and is cut to fit it.
+const MaxExamplesChars = 6000
+
+// ContentExample is an existing page shown to the model as a few-shot
+// example of the site's own style.
+type ContentExample struct {
+	Path    string // Relative to the content directory
+	Content string // Frontmatter and body, possibly truncated
+}
+
+// SelectContentExamples returns up to n of the best existing pages in
+// section, as ranked in AnalyzeSiteContent's ExampleFiles. n is capped at
+// MaxContentExamples. A section without pages yields no examples.
+func SelectContentExamples(sitePath, section string, n int) []ContentExample {
+	if n > MaxContentExamples {
+		n = MaxContentExamples
+	}
+	if n <= 0 || section == "" {
+		return nil
+	}
+
+	sp := AnalyzeSiteContent(sitePath).SectionPatterns[section]
+	if sp == nil {
+		return nil
+	}
+
+	var examples []ContentExample
+	for _, rel := range sp.ExampleFiles {
+		if len(examples) == n {
+			break
+		}
+		data, err := os.ReadFile(filepath.Join(sitePath, "content", rel))
+		if err != nil {
+			continue
+		}
+		examples = append(examples, ContentExample{Path: filepath.ToSlash(rel), Content: string(data)})
+	}
+	return examples
+}
+
+// BuildExamplesContext formats examples for the system prompt, keeping the
+// result within maxChars (0 or less means no limit). Examples that would not
+// fit are cut and end with ContextTruncatedMarker.
+func BuildExamplesContext(examples []ContentExample, maxChars int) string {
+	if len(examples) == 0 {
+		return ""
+	}
+
+	header := "EXAMPLE PAGES FROM THIS SITE (match their style, tone and frontmatter; do not copy their content):\n"
+	share := 0
+	if maxChars > 0 {
+		share = (maxChars - len(header)) / len(examples)
+		if share <= 0 {
+			return ""
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header)
+	for i, ex := range examples {
+		entryHeader := fmt.Sprintf("\n--- Example %d: %s ---\n", i+1, ex.Path)
+		content := strings.TrimSpace(ex.Content) + "\n"
+		if share > 0 {
+			room := share - len(entryHeader)
+			if room <= len(ContextTruncatedMarker) {
+				break
+			}
+			if len(content) > room {
+				content = content[:room-len(ContextTruncatedMarker)-1]
+				if cut := strings.LastIndex(content, "\n"); cut > 0 {
+					content = content[:cut+1]
+				} else {
+					content += "\n"
+				}
+				content += ContextTruncatedMarker
+			}
+		}
+		sb.WriteString(entryHeader)
+		sb.WriteString(content)
+	}
+	return sb.String()
+}
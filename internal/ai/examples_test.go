@@ -0,0 +1,141 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeExamplesSite writes a site whose posts differ in frontmatter and
+// length, plus a docs section and an empty notes section.
+func writeExamplesSite(t *testing.T) string {
+	t.Helper()
+	siteDir := t.TempDir()
+	files := map[string]string{
+		"walgo.yaml":              "hugo:\n  contentDir: content\n",
+		"content/posts/_index.md": "---\ntitle: Posts\ndescription: All posts\ntags: [a]\ncategories: [b]\nauthor: x\n---\n" + strings.Repeat("listing ", 200),
+		"content/posts/short.md":  "---\ntitle: Short\n---\nA few words.",
+		"content/posts/full.md":   "---\ntitle: Full\ndate: 2024-01-01\ndescription: Complete\ntags: [go]\n---\nThe best post on the site.",
+		"content/posts/long.md":   "---\ntitle: Long\ndate: 2024-02-01\n---\n" + strings.Repeat("Plenty of words. ", 50),
+		"content/posts/longer.md": "---\ntitle: Longer\ndate: 2024-03-01\n---\n" + strings.Repeat("Even more words. ", 80),
+		"content/docs/guide.md":   "---\ntitle: Guide\ndate: 2024-01-01\ndescription: Docs\ntags: [x]\nweight: 1\n---\nDocs page.",
+		"content/notes/_index.md": "---\ntitle: Notes\n---\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(siteDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return siteDir
+}
+
+func TestSelectContentExamples(t *testing.T) {
+	siteDir := writeExamplesSite(t)
+
+	got := SelectContentExamples(siteDir, "posts", 2)
+	if len(got) != 2 {
+		t.Fatalf("got %d examples, want 2", len(got))
+	}
+	// Most frontmatter fields first, then the longest body
+	if got[0].Path != "posts/full.md" || got[1].Path != "posts/longer.md" {
+		t.Errorf("examples = %s, %s; want posts/full.md, posts/longer.md", got[0].Path, got[1].Path)
+	}
+	if !strings.Contains(got[0].Content, "The best post on the site.") {
+		t.Errorf("example content not loaded: %q", got[0].Content)
+	}
+
+	if got := SelectContentExamples(siteDir, "posts", 5); len(got) != MaxContentExamples {
+		t.Errorf("got %d examples, want at most %d", len(got), MaxContentExamples)
+	}
+	if got := SelectContentExamples(siteDir, "posts", 0); got != nil {
+		t.Errorf("n = 0 returned %v", got)
+	}
+}
+
+func TestSelectContentExamplesEmptySection(t *testing.T) {
+	siteDir := writeExamplesSite(t)
+
+	for _, section := range []string{"notes", "missing"} {
+		if got := SelectContentExamples(siteDir, section, 2); len(got) != 0 {
+			t.Errorf("section %q yielded %d examples, want none", section, len(got))
+		}
+	}
+	if got := BuildExamplesContext(nil, MaxExamplesChars); got != "" {
+		t.Errorf("BuildExamplesContext(nil) = %q, want empty", got)
+	}
+}
+
+func TestBuildExamplesContextBudget(t *testing.T) {
+	examples := []ContentExample{
+		{Path: "posts/a.md", Content: "---\ntitle: A\n---\n" + strings.Repeat("line of text\n", 500)},
+		{Path: "posts/b.md", Content: "---\ntitle: B\n---\nShort."},
+	}
+
+	got := BuildExamplesContext(examples, 1000)
+	if len(got) > 1000 {
+		t.Errorf("context is %d chars, budget 1000", len(got))
+	}
+	if !strings.Contains(got, "posts/a.md") || !strings.Contains(got, ContextTruncatedMarker) {
+		t.Errorf("long example not included and truncated:\n%s", got)
+	}
+	if !strings.Contains(got, "Short.") {
+		t.Errorf("short example lost:\n%s", got)
+	}
+}
+
+func TestGenerateContentIncludesExamples(t *testing.T) {
+	siteDir := writeExamplesSite(t)
+
+	var systemPrompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, m := range req.Messages {
+			if m.Role == "system" {
+				systemPrompt = m.Content
+			}
+		}
+		reply := "CONTENT_TYPE: posts\nFILENAME: new.md\n---\ntitle: New\n---\nBody"
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": reply}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	cg := NewContentGenerator(NewClient("openai", "sk-test", server.URL, "gpt-4"))
+	result := cg.GenerateContent(ContentGenerationParams{
+		SitePath:     siteDir,
+		Instructions: "Write a post",
+		Context:      context.Background(),
+		Examples:     2,
+		Section:      "posts",
+	})
+	if !result.Success {
+		t.Fatalf("GenerateContent() failed: %s", result.ErrorMessage)
+	}
+
+	for _, want := range []string{"EXAMPLE PAGES FROM THIS SITE", "posts/full.md", "The best post on the site.", "posts/longer.md"} {
+		if !strings.Contains(systemPrompt, want) {
+			t.Errorf("system prompt missing %q", want)
+		}
+	}
+	if strings.Contains(systemPrompt, "Docs page.") {
+		t.Error("system prompt includes an example from another section")
+	}
+}
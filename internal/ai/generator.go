@@ -196,8 +196,16 @@ func (g *Generator) generatePageContent(ctx context.Context, plan *SitePlan, pag
 	userPrompt := BuildSinglePageUserPrompt(plan, page, frontmatterFields)
 	systemPrompt := ComposePageGeneratorPrompt(themeContext)
 
-	// Generate via AI
-	content, err := g.client.GenerateContentWithContext(ctx, systemPrompt, userPrompt)
+	// Generate via AI, streaming tokens to the progress handler when one is set
+	var content string
+	var err error
+	if g.progress != nil {
+		content, err = g.client.GenerateContentStreamWithContext(ctx, systemPrompt, userPrompt, func(token string) {
+			g.emitToken(page, token)
+		})
+	} else {
+		content, err = g.client.GenerateContentWithContext(ctx, systemPrompt, userPrompt)
+	}
 	if err != nil {
 		return "", NewGeneratorError(page, page.Attempts, err, "AI generation failed")
 	}
@@ -504,3 +512,15 @@ func (g *Generator) emitProgress(eventType ProgressType, page *PageSpec, message
 
 	g.progress(event)
 }
+
+// emitToken forwards a piece of streamed page content as a ProgressToken event.
+func (g *Generator) emitToken(page *PageSpec, token string) {
+	g.progress(ProgressEvent{
+		Timestamp: time.Now(),
+		Phase:     PhaseGenerating,
+		EventType: ProgressToken,
+		PageID:    page.ID,
+		PagePath:  page.Path,
+		Message:   token,
+	})
+}
@@ -0,0 +1,81 @@
+package ai
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KeySource is an API key found outside walgo, ready to be imported.
+type KeySource struct {
+	Provider string // "openai" or "openrouter"
+	Origin   string // Where the key was found, e.g. "$OPENAI_API_KEY"
+	APIKey   string
+}
+
+// keyEnvVars are the environment variables checked for provider keys.
+var keyEnvVars = []struct{ provider, name string }{
+	{"openai", "OPENAI_API_KEY"},
+	{"openrouter", "OPENROUTER_API_KEY"},
+}
+
+// keyDotfiles are files, relative to the home directory, that hold a single
+// provider key as plain text.
+var keyDotfiles = []struct{ provider, path string }{
+	{"openai", ".config/openai/api_key"},
+	{"openai", ".openai/api_key"},
+	{"openrouter", ".config/openrouter/api_key"},
+}
+
+// llmKeysFile is where the llm CLI stores its keys, as a JSON object mapping
+// provider names to keys.
+const llmKeysFile = ".config/io.datasette.llm/keys.json"
+
+// DetectKeySources looks for provider keys in the environment and in known
+// dotfiles under home. Environment variables come first, then dotfiles; a key
+// found in more than one place is listed once, at its first origin.
+func DetectKeySources(home string) []KeySource {
+	var sources []KeySource
+	seen := make(map[string]bool)
+	add := func(provider, origin, key string) {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[provider+"\x00"+key] {
+			return
+		}
+		seen[provider+"\x00"+key] = true
+		sources = append(sources, KeySource{Provider: provider, Origin: origin, APIKey: key})
+	}
+
+	for _, env := range keyEnvVars {
+		add(env.provider, "$"+env.name, os.Getenv(env.name))
+	}
+	if home == "" {
+		return sources
+	}
+
+	for _, f := range keyDotfiles {
+		path := filepath.Join(home, f.path)
+		if data, err := os.ReadFile(path); err == nil {
+			add(f.provider, "~/"+f.path, firstLine(string(data)))
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(home, llmKeysFile)); err == nil {
+		var keys map[string]string
+		if json.Unmarshal(data, &keys) == nil {
+			for _, env := range keyEnvVars {
+				add(env.provider, "~/"+llmKeysFile, keys[env.provider])
+			}
+		}
+	}
+	return sources
+}
+
+// firstLine returns s up to its first newline.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,47 @@
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDetectKeySources(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("OPENAI_API_KEY", "sk-env")
+	t.Setenv("OPENROUTER_API_KEY", "")
+
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(home, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".config/openai/api_key", "sk-env\n") // same as env, listed once
+	write(".openai/api_key", "sk-file\n")       // second openai key
+	write(".config/openrouter/api_key", "  \n") // empty, ignored
+	write(llmKeysFile, `{"openrouter": "sk-or-llm", "other": "x"}`)
+
+	got := DetectKeySources(home)
+	want := []KeySource{
+		{Provider: "openai", Origin: "$OPENAI_API_KEY", APIKey: "sk-env"},
+		{Provider: "openai", Origin: "~/.openai/api_key", APIKey: "sk-file"},
+		{Provider: "openrouter", Origin: "~/" + llmKeysFile, APIKey: "sk-or-llm"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectKeySources() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDetectKeySourcesNone(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("OPENROUTER_API_KEY", "")
+	if got := DetectKeySources(t.TempDir()); len(got) != 0 {
+		t.Errorf("DetectKeySources() = %+v, want none", got)
+	}
+}
@@ -0,0 +1,95 @@
+package ai
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed models.yaml
+var knownModelsYAML []byte
+
+var (
+	knownModelsOnce sync.Once
+	knownModels     map[string][]string
+	modelPrices     map[string]ModelPrice
+)
+
+// ModelPrice is a model's list price in USD per million tokens.
+type ModelPrice struct {
+	Input  float64 `yaml:"input"`
+	Output float64 `yaml:"output"`
+}
+
+// loadKnownModels parses the embedded model allowlist and prices once.
+func loadKnownModels() map[string][]string {
+	knownModelsOnce.Do(func() {
+		var table struct {
+			Providers map[string][]string   `yaml:"providers"`
+			Prices    map[string]ModelPrice `yaml:"prices"`
+		}
+		if err := yaml.Unmarshal(knownModelsYAML, &table); err != nil {
+			panic(fmt.Sprintf("invalid embedded models.yaml: %v", err))
+		}
+		knownModels = table.Providers
+		modelPrices = table.Prices
+	})
+	return knownModels
+}
+
+// PriceFor returns the list price of model. OpenRouter names such as
+// "openai/gpt-4o" are priced by the part after the vendor prefix.
+func PriceFor(model string) (ModelPrice, bool) {
+	loadKnownModels()
+	model = strings.TrimSpace(model)
+	if price, ok := modelPrices[model]; ok {
+		return price, true
+	}
+	if _, name, ok := strings.Cut(model, "/"); ok {
+		price, ok := modelPrices[name]
+		return price, ok
+	}
+	return ModelPrice{}, false
+}
+
+// KnownModels returns the models known to work with a provider.
+func KnownModels(provider string) []string {
+	return loadKnownModels()[provider]
+}
+
+// UnknownModelError is returned when a model is not in a provider's allowlist.
+type UnknownModelError struct {
+	Provider string
+	Model    string
+}
+
+func (e *UnknownModelError) Error() string {
+	return fmt.Sprintf("unknown model %q for provider %s", e.Model, e.Provider)
+}
+
+// ValidateModel checks model against the provider's allowlist. Unknown models
+// are rejected unless force is set, so newly released models can still be used.
+func ValidateModel(provider, model string, force bool) error {
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return fmt.Errorf("model cannot be empty")
+	}
+
+	models, ok := loadKnownModels()[provider]
+	if !ok {
+		return fmt.Errorf("unsupported provider: %s", provider)
+	}
+	if force {
+		return nil
+	}
+
+	for _, m := range models {
+		if m == model {
+			return nil
+		}
+	}
+	return &UnknownModelError{Provider: provider, Model: model}
+}
@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestKnownModelsEmbedded(t *testing.T) {
+	for _, provider := range []string{"openai", "openrouter"} {
+		if len(KnownModels(provider)) == 0 {
+			t.Errorf("expected known models for %s", provider)
+		}
+	}
+	if models := KnownModels("unknown"); len(models) != 0 {
+		t.Errorf("expected no models for unknown provider, got %v", models)
+	}
+}
+
+func TestValidateModel(t *testing.T) {
+	tests := []struct {
+		name        string
+		provider    string
+		model       string
+		force       bool
+		wantErr     bool
+		wantUnknown bool
+	}{
+		{name: "valid openai model", provider: "openai", model: "gpt-4o"},
+		{name: "valid openrouter model", provider: "openrouter", model: "anthropic/claude-3.5-sonnet"},
+		{name: "unknown model rejected", provider: "openai", model: "gpt-99", wantErr: true, wantUnknown: true},
+		{name: "unknown model accepted with force", provider: "openai", model: "gpt-99", force: true},
+		{name: "model from other provider rejected", provider: "openai", model: "openai/gpt-4", wantErr: true, wantUnknown: true},
+		{name: "empty model", provider: "openai", model: " ", force: true, wantErr: true},
+		{name: "unsupported provider", provider: "acme", model: "gpt-4o", force: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateModel(tt.provider, tt.model, tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateModel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var unknown *UnknownModelError
+			if got := errors.As(err, &unknown); got != tt.wantUnknown {
+				t.Errorf("UnknownModelError = %v, want %v (err: %v)", got, tt.wantUnknown, err)
+			}
+		})
+	}
+}
+
+func TestSetProviderModel(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHome)
+
+	if err := SetProviderModel("openai", "gpt-4o"); err == nil {
+		t.Fatal("expected error for unconfigured provider")
+	}
+
+	if err := SetProviderCredentials("openai", "test-key", "", "gpt-4"); err != nil {
+		t.Fatalf("SetProviderCredentials failed: %v", err)
+	}
+	if err := SetProviderModel("openai", "gpt-4o"); err != nil {
+		t.Fatalf("SetProviderModel failed: %v", err)
+	}
+
+	creds, err := GetProviderCredentials("openai")
+	if err != nil {
+		t.Fatalf("GetProviderCredentials failed: %v", err)
+	}
+	if creds.Model != "gpt-4o" {
+		t.Errorf("expected model gpt-4o, got %s", creds.Model)
+	}
+	if creds.APIKey != "test-key" {
+		t.Errorf("API key should be preserved, got %s", creds.APIKey)
+	}
+}
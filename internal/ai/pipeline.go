@@ -14,7 +14,7 @@ import (
 type Pipeline struct {
 	client    *Client
 	config    PipelineConfig
-	planner   *Planner
+	planner   ContentPlanner
 	generator *Generator
 	progress  ProgressHandler
 }
@@ -24,7 +24,7 @@ func NewPipeline(client *Client, config PipelineConfig) *Pipeline {
 	p := &Pipeline{
 		client:    client,
 		config:    config,
-		planner:   NewPlanner(client, config),
+		planner:   NewAIContentPlanner(client, config),
 		generator: NewGenerator(client, config),
 	}
 	return p
@@ -36,6 +36,24 @@ func (p *Pipeline) SetProgressHandler(handler ProgressHandler) {
 	p.generator.SetProgressHandler(handler)
 }
 
+// SetContentPlanner replaces the planner used to decide which pages to
+// generate. The default asks the AI provider.
+func (p *Pipeline) SetContentPlanner(planner ContentPlanner) {
+	p.planner = planner
+}
+
+// planSite runs the content planner and returns a full site plan.
+func (p *Pipeline) planSite(ctx context.Context, input *PlannerInput) (*SitePlan, error) {
+	if sp, ok := p.planner.(sitePlanner); ok {
+		return sp.PlanSite(ctx, input)
+	}
+	pages, err := p.planner.Plan(ctx, *input)
+	if err != nil {
+		return nil, NewPlannerError(input, err, "content planner failed")
+	}
+	return NewSitePlan(*input, pages)
+}
+
 // Full Pipeline Execution
 
 // Run executes the complete pipeline: plan, then generate all pages.
@@ -56,7 +74,7 @@ func (p *Pipeline) Run(ctx context.Context, input *PlannerInput) (*PipelineResul
 		// Phase 1: Planning
 		p.emitProgress(ProgressStart, PhasePlanning, "creating site plan", nil, nil)
 
-		plan, err := p.planner.Plan(ctx, input)
+		plan, err := p.planSite(ctx, input)
 		if err != nil {
 			result.Error = err
 			result.ErrorMsg = err.Error()
@@ -140,7 +158,7 @@ func (p *Pipeline) Run(ctx context.Context, input *PlannerInput) (*PipelineResul
 func (p *Pipeline) PlanOnly(ctx context.Context, input *PlannerInput) (*SitePlan, error) {
 	p.emitProgress(ProgressStart, PhasePlanning, "creating site plan", nil, nil)
 
-	plan, err := p.planner.Plan(ctx, input)
+	plan, err := p.planSite(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -106,9 +106,12 @@ func NewProgressAggregator() *ProgressAggregator {
 }
 
 // Handler returns a ProgressHandler that collects and stores events.
-// Thread-safe for concurrent calls.
+// Streamed tokens are not stored. Thread-safe for concurrent calls.
 func (a *ProgressAggregator) Handler() ProgressHandler {
 	return func(event ProgressEvent) {
+		if event.EventType == ProgressToken {
+			return
+		}
 		a.mu.Lock()
 		defer a.mu.Unlock()
 		a.events = append(a.events, event)
@@ -404,6 +404,25 @@ UPDATE INSTRUCTIONS: %s
 Return the complete updated Hugo markdown file.`, existingContent, instruction)
 }
 
+// BuildRewritePrompt builds the user prompt for instruction-driven rewrites,
+// including theme context so the revision keeps theme-required fields.
+func BuildRewritePrompt(req RewriteRequest) string {
+	var sb strings.Builder
+
+	if req.ThemeContext != "" {
+		sb.WriteString(req.ThemeContext)
+		sb.WriteString("\n\n")
+	}
+	if req.Section != "" {
+		sb.WriteString(fmt.Sprintf("SECTION: %s\n\n", req.Section))
+	}
+
+	sb.WriteString(BuildUpdatePrompt(req.Instructions, req.Content))
+	sb.WriteString("\nKeep every existing frontmatter field unless the instructions say otherwise.")
+
+	return sb.String()
+}
+
 // BuildUserPrompt builds a user prompt for generic content generation
 func BuildUserPrompt(instruction, context string) string {
 	if context != "" {
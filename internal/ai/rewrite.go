@@ -0,0 +1,201 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RewriteRequest describes an instruction-driven revision of an existing content file.
+type RewriteRequest struct {
+	Content      string // Full file content including frontmatter
+	Instructions string // What the user wants changed
+	ThemeContext string // Theme analysis from BuildDynamicThemeContext (optional)
+	Section      string // Content section the file belongs to, e.g. "posts" (optional)
+}
+
+// RewriteContent asks the provider to revise existing content and returns the
+// new file. Frontmatter from the original file is preserved, with any fields
+// returned by the provider merged on top.
+func RewriteContent(ctx context.Context, client *Client, req RewriteRequest) (string, error) {
+	if client == nil {
+		return "", fmt.Errorf("AI client is required")
+	}
+	if strings.TrimSpace(req.Instructions) == "" {
+		return "", fmt.Errorf("instructions cannot be empty")
+	}
+
+	userPrompt := BuildRewritePrompt(req)
+	revised, err := client.GenerateContentWithContext(ctx, SystemPromptContentUpdate, userPrompt)
+	if err != nil {
+		return "", fmt.Errorf("rewriting content: %w", err)
+	}
+
+	revised = CleanMarkdownFences(revised)
+	if strings.TrimSpace(revised) == "" {
+		return "", fmt.Errorf("AI returned empty content")
+	}
+
+	return MergeFrontmatter(req.Content, revised), nil
+}
+
+// frontmatterDelimiters maps an opening line to its closing line.
+var frontmatterDelimiters = map[string]string{
+	"---": "---",
+	"+++": "+++",
+}
+
+// SplitFrontmatter separates a content file into its frontmatter delimiter,
+// raw frontmatter block, and body. The delimiter is "---" for YAML, "+++" for
+// TOML, "{" for JSON, or "" when the file has no frontmatter.
+func SplitFrontmatter(content string) (delim, frontmatter, body string) {
+	trimmed := strings.TrimPrefix(content, "\ufeff")
+	firstLine, rest, _ := strings.Cut(trimmed, "\n")
+	firstLine = strings.TrimRight(firstLine, " \t\r")
+
+	if closing, ok := frontmatterDelimiters[firstLine]; ok {
+		lines := strings.SplitAfter(rest, "\n")
+		for i, line := range lines {
+			if strings.TrimRight(line, " \t\r\n") == closing {
+				return firstLine, strings.Join(lines[:i], ""), strings.Join(lines[i+1:], "")
+			}
+		}
+		return "", "", content
+	}
+
+	if strings.HasPrefix(firstLine, "{") {
+		depth := 0
+		inString := false
+		escaped := false
+		for i, r := range trimmed {
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\' && inString:
+				escaped = true
+			case r == '"':
+				inString = !inString
+			case r == '{' && !inString:
+				depth++
+			case r == '}' && !inString:
+				depth--
+				if depth == 0 {
+					return "{", trimmed[:i+1], strings.TrimPrefix(trimmed[i+1:], "\n")
+				}
+			}
+		}
+	}
+
+	return "", "", content
+}
+
+// MergeFrontmatter combines an original content file with a revised version.
+// The revised body always wins. For YAML frontmatter, fields from the revision
+// overwrite matching fields and new fields are appended, while fields the
+// revision dropped are kept. TOML and JSON frontmatter is kept verbatim.
+func MergeFrontmatter(original, revised string) string {
+	origDelim, origFM, _ := SplitFrontmatter(original)
+	newDelim, newFM, newBody := SplitFrontmatter(revised)
+
+	if origDelim == "" {
+		return revised
+	}
+
+	body := newBody
+	if !strings.HasPrefix(body, "\n") {
+		body = "\n" + body
+	}
+
+	if origDelim != "---" || newDelim != "---" {
+		return joinFrontmatter(origDelim, origFM, body)
+	}
+
+	merged, err := mergeYAMLFrontmatter(origFM, newFM)
+	if err != nil {
+		return joinFrontmatter(origDelim, origFM, body)
+	}
+	return joinFrontmatter(origDelim, merged, body)
+}
+
+// joinFrontmatter reassembles a content file from its parts.
+func joinFrontmatter(delim, frontmatter, body string) string {
+	if delim == "{" {
+		return frontmatter + "\n" + body
+	}
+	if frontmatter != "" && !strings.HasSuffix(frontmatter, "\n") {
+		frontmatter += "\n"
+	}
+	return delim + "\n" + frontmatter + frontmatterDelimiters[delim] + "\n" + strings.TrimPrefix(body, "\n")
+}
+
+// mergeYAMLFrontmatter overlays the fields of revised onto original, keeping key order.
+func mergeYAMLFrontmatter(original, revised string) (string, error) {
+	var origDoc, newDoc yaml.Node
+	if err := yaml.Unmarshal([]byte(original), &origDoc); err != nil {
+		return "", err
+	}
+	if err := yaml.Unmarshal([]byte(revised), &newDoc); err != nil {
+		return "", err
+	}
+
+	if len(origDoc.Content) == 0 {
+		return revised, nil
+	}
+	if len(newDoc.Content) == 0 {
+		return original, nil
+	}
+
+	origMap := origDoc.Content[0]
+	newMap := newDoc.Content[0]
+	if origMap.Kind != yaml.MappingNode || newMap.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("frontmatter is not a mapping")
+	}
+
+	for i := 0; i+1 < len(newMap.Content); i += 2 {
+		key, value := newMap.Content[i], newMap.Content[i+1]
+		replaced := false
+		for j := 0; j+1 < len(origMap.Content); j += 2 {
+			if origMap.Content[j].Value == key.Value {
+				origMap.Content[j+1] = value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			origMap.Content = append(origMap.Content, key, value)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&origDoc); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ContentSection returns the Hugo section a content file belongs to, based on
+// its path relative to the site's content directory.
+func ContentSection(sitePath, filePath string) string {
+	absFile, err := filepath.Abs(filePath)
+	if err != nil {
+		return "default"
+	}
+	absContent, err := filepath.Abs(filepath.Join(sitePath, "content"))
+	if err != nil {
+		return "default"
+	}
+	rel, err := filepath.Rel(absContent, absFile)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "default"
+	}
+	return determineSectionFromPath(filepath.ToSlash(rel))
+}
@@ -0,0 +1,136 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newRewriteTestServer returns a mock provider that always answers with reply.
+func newRewriteTestServer(t *testing.T, reply string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": reply}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRewriteContentPreservesFrontmatter(t *testing.T) {
+	original := `---
+title: "Original Title"
+date: 2024-01-01
+tags: [go, walrus]
+draft: true
+---
+
+Old body text.
+`
+	reply := "```markdown\n---\ntitle: \"Better Title\"\ndescription: \"New summary\"\n---\n\nNew body text.\n```"
+
+	server := newRewriteTestServer(t, reply)
+	client := NewClient("openai", "test-key", server.URL, "gpt-4")
+
+	got, err := RewriteContent(context.Background(), client, RewriteRequest{
+		Content:      original,
+		Instructions: "Improve the title",
+	})
+	if err != nil {
+		t.Fatalf("RewriteContent failed: %v", err)
+	}
+
+	for _, want := range []string{
+		`title: "Better Title"`,
+		"date: 2024-01-01",
+		"tags: [go, walrus]",
+		"draft: true",
+		`description: "New summary"`,
+		"New body text.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rewritten content missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Old body text.") {
+		t.Errorf("old body should be replaced:\n%s", got)
+	}
+	if !strings.HasPrefix(got, "---\ntitle:") {
+		t.Errorf("frontmatter order not preserved:\n%s", got)
+	}
+}
+
+func TestRewriteContentRequiresInstructions(t *testing.T) {
+	client := NewClient("openai", "test-key", "http://127.0.0.1:0", "gpt-4")
+	if _, err := RewriteContent(context.Background(), client, RewriteRequest{Content: "x"}); err == nil {
+		t.Error("expected error for empty instructions")
+	}
+}
+
+func TestMergeFrontmatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		revised  string
+		contains []string
+	}{
+		{
+			name:     "revision without frontmatter keeps original",
+			original: "---\ntitle: Keep\n---\nold\n",
+			revised:  "brand new body\n",
+			contains: []string{"---\ntitle: Keep\n---\n", "brand new body"},
+		},
+		{
+			name:     "toml frontmatter kept verbatim",
+			original: "+++\ntitle = \"Keep\"\n+++\nold\n",
+			revised:  "---\ntitle: Changed\n---\nnew body\n",
+			contains: []string{"+++\ntitle = \"Keep\"\n+++\n", "new body"},
+		},
+		{
+			name:     "original without frontmatter takes revision",
+			original: "just text\n",
+			revised:  "---\ntitle: Added\n---\nbody\n",
+			contains: []string{"title: Added", "body"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeFrontmatter(tt.original, tt.revised)
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("MergeFrontmatter() missing %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitFrontmatter(t *testing.T) {
+	tests := []struct {
+		content   string
+		wantDelim string
+		wantBody  string
+	}{
+		{"---\ntitle: A\n---\nbody\n", "---", "body\n"},
+		{"+++\ntitle = \"A\"\n+++\nbody\n", "+++", "body\n"},
+		{"{\n\"title\": \"A {x}\"\n}\nbody\n", "{", "body\n"},
+		{"no frontmatter\n", "", "no frontmatter\n"},
+		{"---\nunterminated\n", "", "---\nunterminated\n"},
+	}
+
+	for _, tt := range tests {
+		delim, _, body := SplitFrontmatter(tt.content)
+		if delim != tt.wantDelim || body != tt.wantBody {
+			t.Errorf("SplitFrontmatter(%q) = (%q, %q), want (%q, %q)", tt.content, delim, body, tt.wantDelim, tt.wantBody)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxDescriptionLength is the longest meta description kept, in characters.
+// Search engines truncate snippets beyond roughly this length.
+const MaxDescriptionLength = 160
+
+// SummarizeRequest describes a content file to write a meta description for.
+type SummarizeRequest struct {
+	Content      string // Full file content including frontmatter
+	ThemeContext string // Theme analysis from BuildDynamicThemeContext (optional)
+	Section      string // Content section the file belongs to, e.g. "posts" (optional)
+}
+
+// SystemPromptSummarize is the system prompt for meta description generation
+const SystemPromptSummarize = `You are an SEO editor writing meta descriptions for Hugo pages.
+
+RULES:
+- One or two sentences, 120-160 characters
+- Summarize what the page offers the reader; lead with the main topic
+- Match the tone of the page and the site's theme
+- Plain text only: no Markdown, quotes, emoji, or hashtags
+- Do NOT start with "This page" or "In this article"
+
+OUTPUT:
+- Return ONLY the description text`
+
+// GenerateDescription asks the provider for a concise meta description of the
+// content and returns it cleaned and trimmed to MaxDescriptionLength.
+func GenerateDescription(ctx context.Context, client *Client, req SummarizeRequest) (string, error) {
+	if client == nil {
+		return "", fmt.Errorf("AI client is required")
+	}
+
+	_, _, body := SplitFrontmatter(req.Content)
+	if strings.TrimSpace(body) == "" {
+		return "", fmt.Errorf("content has no body to summarize")
+	}
+
+	reply, err := client.GenerateContentWithContext(ctx, SystemPromptSummarize, BuildSummarizePrompt(req))
+	if err != nil {
+		return "", fmt.Errorf("generating description: %w", err)
+	}
+
+	description := CleanDescription(reply)
+	if description == "" {
+		return "", fmt.Errorf("AI returned an empty description")
+	}
+	return description, nil
+}
+
+// BuildSummarizePrompt builds the user prompt for meta description generation.
+func BuildSummarizePrompt(req SummarizeRequest) string {
+	var sb strings.Builder
+
+	if req.ThemeContext != "" {
+		sb.WriteString(req.ThemeContext)
+		sb.WriteString("\n\n")
+	}
+	if req.Section != "" {
+		sb.WriteString(fmt.Sprintf("SECTION: %s\n\n", req.Section))
+	}
+	if title, ok := ParseFrontmatterFields(req.Content)["title"].(string); ok && title != "" {
+		sb.WriteString(fmt.Sprintf("TITLE: %s\n\n", title))
+	}
+
+	_, _, body := SplitFrontmatter(req.Content)
+	sb.WriteString(fmt.Sprintf(`PAGE CONTENT:
+---START---
+%s
+---END---
+
+Write the meta description for this page.`, strings.TrimSpace(body)))
+
+	return sb.String()
+}
+
+// CleanDescription normalizes an AI-generated description: fences, labels and
+// surrounding quotes are removed, whitespace is collapsed, and text longer than
+// MaxDescriptionLength is cut at a word boundary.
+func CleanDescription(s string) string {
+	s = CleanMarkdownFences(s)
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) >= len("description:") && strings.EqualFold(s[:len("description:")], "description:") {
+		s = strings.TrimSpace(s[len("description:"):])
+	}
+	s = strings.Trim(s, "\"'`")
+
+	runes := []rune(s)
+	if len(runes) <= MaxDescriptionLength {
+		return s
+	}
+	cut := string(runes[:MaxDescriptionLength])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:-")
+}
+
+// HasDescription reports whether the content's frontmatter sets a non-empty
+// description.
+func HasDescription(content string) bool {
+	description, ok := ParseFrontmatterFields(content)["description"].(string)
+	return ok && strings.TrimSpace(description) != ""
+}
+
+// ApplyDescription writes description into a content file's frontmatter,
+// replacing any existing value. Files without frontmatter get a new YAML block.
+func ApplyDescription(content, description string) (string, error) {
+	return setFrontmatterStrings(content, []frontmatterString{{Key: "description", Value: description}})
+}
+
+// SetFrontmatterString writes a top-level string field into a content file's
+// frontmatter, replacing any existing value, like ApplyDescription does for
+// description.
+func SetFrontmatterString(content, key, value string) (string, error) {
+	return setFrontmatterStrings(content, []frontmatterString{{Key: key, Value: value}})
+}
+
+// frontmatterString is a top-level string field to write into frontmatter.
+type frontmatterString struct {
+	Key   string
+	Value string
+}
+
+// setFrontmatterStrings writes each field into a content file's frontmatter,
+// replacing existing values in place and appending missing keys. Files
+// without frontmatter get a new YAML block. JSON frontmatter is not supported.
+func setFrontmatterStrings(content string, fields []frontmatterString) (string, error) {
+	delim, frontmatter, body := SplitFrontmatter(content)
+
+	switch delim {
+	case "", "---":
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(frontmatter), &doc); err != nil {
+			return "", fmt.Errorf("parsing frontmatter: %w", err)
+		}
+		if len(doc.Content) == 0 {
+			doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+		}
+		mapping := doc.Content[0]
+		if mapping.Kind != yaml.MappingNode {
+			return "", fmt.Errorf("frontmatter is not a mapping")
+		}
+		for _, field := range fields {
+			value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: field.Value, Style: yaml.DoubleQuotedStyle}
+			replaced := false
+			for i := 0; i+1 < len(mapping.Content); i += 2 {
+				if mapping.Content[i].Value == field.Key {
+					mapping.Content[i+1] = value
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: field.Key}, value)
+			}
+		}
+		out, err := encodeYAMLNode(&doc)
+		if err != nil {
+			return "", err
+		}
+		if delim == "" {
+			body = content
+		}
+		return joinFrontmatter("---", out, body), nil
+
+	case "+++":
+		// Only top-level keys are touched: anything after the first [table]
+		// header belongs to that table.
+		lineFor := make(map[string]string, len(fields))
+		for _, field := range fields {
+			lineFor[field.Key] = fmt.Sprintf("%s = %q\n", field.Key, field.Value)
+		}
+		written := make(map[string]bool, len(fields))
+		writeMissing := func(lines *strings.Builder) {
+			for _, field := range fields {
+				if !written[field.Key] {
+					lines.WriteString(lineFor[field.Key])
+					written[field.Key] = true
+				}
+			}
+		}
+
+		var lines strings.Builder
+		inTable := false
+		for _, l := range strings.SplitAfter(frontmatter, "\n") {
+			if l == "" {
+				continue
+			}
+			if !inTable && strings.HasPrefix(strings.TrimSpace(l), "[") {
+				inTable = true
+				writeMissing(&lines)
+			}
+			key, _, found := strings.Cut(l, "=")
+			if line, ok := lineFor[strings.TrimSpace(key)]; !inTable && found && ok {
+				if !written[strings.TrimSpace(key)] {
+					lines.WriteString(line)
+					written[strings.TrimSpace(key)] = true
+				}
+				continue
+			}
+			lines.WriteString(l)
+			if !strings.HasSuffix(l, "\n") {
+				lines.WriteString("\n")
+			}
+		}
+		writeMissing(&lines)
+		return joinFrontmatter("+++", lines.String(), body), nil
+
+	default:
+		return "", fmt.Errorf("writing %s to JSON frontmatter is not supported", fields[0].Key)
+	}
+}
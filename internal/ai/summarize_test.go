@@ -0,0 +1,128 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGenerateDescription(t *testing.T) {
+	server := newRewriteTestServer(t, "```\nDescription: \"Learn how Walrus stores   Hugo sites.\"\n```")
+	client := NewClient("openai", "test-key", server.URL, "gpt-4")
+
+	got, err := GenerateDescription(context.Background(), client, SummarizeRequest{
+		Content: "---\ntitle: Walrus\n---\n\nBody text.\n",
+	})
+	if err != nil {
+		t.Fatalf("GenerateDescription failed: %v", err)
+	}
+	if want := "Learn how Walrus stores Hugo sites."; got != want {
+		t.Errorf("GenerateDescription() = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateDescriptionRequiresBody(t *testing.T) {
+	client := NewClient("openai", "test-key", "http://127.0.0.1:0", "gpt-4")
+	if _, err := GenerateDescription(context.Background(), client, SummarizeRequest{Content: "---\ntitle: Empty\n---\n\n"}); err == nil {
+		t.Error("expected error for content without a body")
+	}
+}
+
+func TestBuildSummarizePrompt(t *testing.T) {
+	prompt := BuildSummarizePrompt(SummarizeRequest{
+		Content:      "---\ntitle: Launch Day\n---\n\nWe shipped it.\n",
+		ThemeContext: "THEME: ananke",
+		Section:      "posts",
+	})
+	for _, want := range []string{"THEME: ananke", "SECTION: posts", "TITLE: Launch Day", "We shipped it."} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "title: Launch Day") {
+		t.Errorf("prompt should not include raw frontmatter:\n%s", prompt)
+	}
+}
+
+func TestCleanDescriptionTruncates(t *testing.T) {
+	long := strings.Repeat("walrus storage ", 20)
+	got := CleanDescription(long)
+	if len(got) > MaxDescriptionLength {
+		t.Errorf("description length = %d, want at most %d", len(got), MaxDescriptionLength)
+	}
+	if strings.HasSuffix(got, " ") || !strings.HasSuffix(got, "walrus") && !strings.HasSuffix(got, "storage") {
+		t.Errorf("description not cut at a word boundary: %q", got)
+	}
+}
+
+func TestHasDescription(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"---\ndescription: Set\n---\nbody\n", true},
+		{"+++\ndescription = \"Set\"\n+++\nbody\n", true},
+		{"---\ndescription: \"\"\n---\nbody\n", false},
+		{"---\ntitle: No description\n---\nbody\n", false},
+		{"no frontmatter\n", false},
+	}
+	for _, tt := range tests {
+		if got := HasDescription(tt.content); got != tt.want {
+			t.Errorf("HasDescription(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestApplyDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "yaml appends field",
+			content:  "---\ntitle: Hello\n---\n\nBody.\n",
+			contains: []string{"---\ntitle: Hello\ndescription: \"A summary.\"\n---\n", "Body."},
+		},
+		{
+			name:     "yaml replaces existing field",
+			content:  "---\ndescription: Old\ntitle: Hello\n---\nBody.\n",
+			contains: []string{"description: \"A summary.\"\ntitle: Hello"},
+			excludes: []string{"Old"},
+		},
+		{
+			name:     "toml keeps tables intact",
+			content:  "+++\ntitle = \"Hello\"\n[params]\ndescription = \"Param\"\n+++\nBody.\n",
+			contains: []string{"title = \"Hello\"\ndescription = \"A summary.\"\n[params]\ndescription = \"Param\"\n+++\n"},
+		},
+		{
+			name:     "no frontmatter gets yaml block",
+			content:  "Just text.\n",
+			contains: []string{"---\ndescription: \"A summary.\"\n---\n", "Just text."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyDescription(tt.content, "A summary.")
+			if err != nil {
+				t.Fatalf("ApplyDescription failed: %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("result missing %q:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("result should not contain %q:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+
+	if _, err := ApplyDescription("{\"title\": \"x\"}\nbody\n", "A summary."); err == nil {
+		t.Error("expected error for JSON frontmatter")
+	}
+}
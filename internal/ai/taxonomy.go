@@ -0,0 +1,447 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	toml "github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// TAXONOMY SUGGESTIONS
+// =============================================================================
+//
+// Suggests taxonomy terms (tags, categories, ...) for content files by
+// extracting keywords from the page body. This is a local heuristic and does
+// not call the AI provider.
+// =============================================================================
+
+// DefaultMaxTaxonomyTerms is the default number of terms suggested per taxonomy.
+const DefaultMaxTaxonomyTerms = 5
+
+// minKeywordCount is how often a word must appear before it is suggested.
+const minKeywordCount = 2
+
+// titleKeywordWeight boosts words that appear in the page title.
+const titleKeywordWeight = 3
+
+// TermScore is a keyword found in content with its relative weight.
+type TermScore struct {
+	Term  string
+	Count int     // Weighted occurrences
+	Score float64 // Count relative to the strongest keyword (0-1]
+}
+
+// TaxonomyOptions controls taxonomy suggestions.
+type TaxonomyOptions struct {
+	Taxonomies []string            // Taxonomy names as used in frontmatter, e.g. "tags"
+	MaxTerms   int                 // Terms per taxonomy (default DefaultMaxTaxonomyTerms)
+	Vocabulary map[string][]string // Terms already used across the site, per taxonomy
+}
+
+// TaxonomySuggestion holds suggested terms for a single content file.
+type TaxonomySuggestion struct {
+	Path       string
+	Existing   map[string][]string // Terms already in the frontmatter
+	Suggested  map[string][]string // New terms, only for taxonomies the file lacks
+	Confidence float64             // 0-1, how reliable the suggestions are
+}
+
+// HasSuggestions reports whether any terms were suggested.
+func (s *TaxonomySuggestion) HasSuggestions() bool {
+	for _, terms := range s.Suggested {
+		if len(terms) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// TaxonomyNames returns the frontmatter names of the theme's taxonomies,
+// falling back to Hugo's defaults when the theme does not declare any.
+func TaxonomyNames(cfg *ThemeConfigAnalysis) []string {
+	var names []string
+	if cfg != nil {
+		for _, plural := range cfg.Taxonomies {
+			if plural != "" && !containsString(names, plural) {
+				names = append(names, plural)
+			}
+		}
+	}
+	if len(names) == 0 {
+		names = []string{"tags", "categories"}
+	}
+	sort.Strings(names)
+	return names
+}
+
+var (
+	codeFenceRegex  = regexp.MustCompile("(?s)```.*?```")
+	inlineCodeRegex = regexp.MustCompile("`[^`\n]*`")
+	urlRegex        = regexp.MustCompile(`https?://\S+`)
+	htmlTagRegex    = regexp.MustCompile(`<[^>]+>`)
+	shortcodeRegex  = regexp.MustCompile(`\{\{[<%].*?[>%]\}\}`)
+)
+
+// taxonomyStopwords are common English words that never make useful terms.
+var taxonomyStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true,
+	"you": true, "your": true, "all": true, "any": true, "can": true, "had": true,
+	"her": true, "was": true, "one": true, "our": true, "out": true, "has": true,
+	"have": true, "how": true, "its": true, "may": true, "new": true, "now": true,
+	"old": true, "see": true, "two": true, "way": true, "who": true, "did": true,
+	"get": true, "got": true, "let": true, "use": true, "used": true, "using": true,
+	"this": true, "that": true, "with": true, "from": true, "they": true, "them": true,
+	"then": true, "than": true, "there": true, "their": true, "these": true, "those": true,
+	"what": true, "when": true, "where": true, "which": true, "while": true, "will": true,
+	"would": true, "could": true, "should": true, "about": true, "after": true, "before": true,
+	"into": true, "just": true, "like": true, "make": true, "more": true, "most": true,
+	"much": true, "must": true, "only": true, "other": true, "over": true, "some": true,
+	"such": true, "also": true, "been": true, "being": true, "both": true, "each": true,
+	"even": true, "here": true, "many": true, "very": true, "were": true,
+	"does": true, "doing": true, "done": true, "every": true, "first": true, "well": true,
+	"because": true, "between": true, "through": true, "without": true, "within": true,
+	"again": true, "same": true, "still": true, "since": true, "until": true, "under": true,
+	"want": true, "need": true, "needs": true, "know": true, "take": true, "takes": true,
+	"thing": true, "things": true, "really": true, "good": true, "great": true,
+	"time": true, "year": true, "years": true, "day": true, "days": true, "post": true,
+	"page": true, "article": true, "example": true, "examples": true,
+	"yet": true, "via": true, "per": true, "etc": true, "able": true, "lot": true,
+	"lots": true, "might": true, "why": true, "own": true, "few": true, "too": true,
+	"his": true, "she": true, "him": true, "yes": true, "off": true,
+}
+
+// ExtractKeywords returns the most significant words in text, strongest
+// first. At most limit terms are returned; limit <= 0 returns all candidates.
+func ExtractKeywords(text string, limit int) []TermScore {
+	return rankKeywords(countKeywords(text, 1), limit)
+}
+
+// countKeywords tallies candidate keywords in markdown text, weighting each
+// occurrence by weight.
+func countKeywords(text string, weight int) map[string]int {
+	text = codeFenceRegex.ReplaceAllString(text, " ")
+	text = inlineCodeRegex.ReplaceAllString(text, " ")
+	text = shortcodeRegex.ReplaceAllString(text, " ")
+	text = urlRegex.ReplaceAllString(text, " ")
+	text = htmlTagRegex.ReplaceAllString(text, " ")
+
+	counts := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '-'
+	})
+	for _, w := range words {
+		w = strings.Trim(w, "'-")
+		if !isKeywordCandidate(w) {
+			continue
+		}
+		counts[w] += weight
+	}
+	return counts
+}
+
+func isKeywordCandidate(word string) bool {
+	if len(word) < 3 || taxonomyStopwords[word] {
+		return false
+	}
+	if strings.Contains(word, "'") {
+		return false
+	}
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// rankKeywords orders keyword counts by weight, then alphabetically.
+func rankKeywords(counts map[string]int, limit int) []TermScore {
+	terms := make([]TermScore, 0, len(counts))
+	for term, count := range counts {
+		terms = append(terms, TermScore{Term: term, Count: count})
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Term < terms[j].Term
+	})
+	if limit > 0 && len(terms) > limit {
+		terms = terms[:limit]
+	}
+	if len(terms) > 0 {
+		top := float64(terms[0].Count)
+		for i := range terms {
+			terms[i].Score = float64(terms[i].Count) / top
+		}
+	}
+	return terms
+}
+
+// SuggestTaxonomies suggests taxonomy terms for a content file. Taxonomies
+// already present in the frontmatter are left alone; only missing ones receive
+// suggestions. Terms already used across the site are preferred so the
+// site's vocabulary stays consistent.
+func SuggestTaxonomies(content string, opts TaxonomyOptions) *TaxonomySuggestion {
+	maxTerms := opts.MaxTerms
+	if maxTerms <= 0 {
+		maxTerms = DefaultMaxTaxonomyTerms
+	}
+
+	_, _, body := SplitFrontmatter(content)
+	fields := ParseFrontmatterFields(content)
+
+	suggestion := &TaxonomySuggestion{
+		Existing:  ReadTaxonomyTerms(content, opts.Taxonomies),
+		Suggested: make(map[string][]string),
+	}
+
+	counts := countKeywords(body, 1)
+	if title, ok := fields["title"].(string); ok {
+		for term, n := range countKeywords(title, titleKeywordWeight) {
+			counts[term] += n
+		}
+	}
+
+	ranked := rankKeywords(counts, 0)
+	var keywords []TermScore
+	for _, t := range ranked {
+		if t.Count >= minKeywordCount {
+			keywords = append(keywords, t)
+		}
+	}
+
+	var scores []float64
+	for _, taxonomy := range opts.Taxonomies {
+		if len(suggestion.Existing[taxonomy]) > 0 {
+			continue
+		}
+
+		limit := maxTerms
+		if isCategoryTaxonomy(taxonomy) {
+			limit = 1
+		}
+
+		var terms []string
+		// Prefer terms the site already uses for this taxonomy
+		for _, known := range opts.Vocabulary[taxonomy] {
+			if len(terms) >= limit {
+				break
+			}
+			if counts[strings.ToLower(known)] >= minKeywordCount && !containsString(terms, known) {
+				terms = append(terms, known)
+				scores = append(scores, 1)
+			}
+		}
+		for _, kw := range keywords {
+			if len(terms) >= limit {
+				break
+			}
+			if containsFold(terms, kw.Term) {
+				continue
+			}
+			terms = append(terms, kw.Term)
+			scores = append(scores, kw.Score)
+		}
+
+		if len(terms) > 0 {
+			suggestion.Suggested[taxonomy] = terms
+		}
+	}
+
+	suggestion.Confidence = taxonomyConfidence(scores, countWords(body))
+	return suggestion
+}
+
+// taxonomyConfidence combines how strongly the suggested terms stand out with
+// how much text they were drawn from.
+func taxonomyConfidence(scores []float64, wordCount int) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range scores {
+		sum += s
+	}
+	strength := sum / float64(len(scores))
+	length := math.Min(1, float64(wordCount)/200)
+	return math.Round(strength*(0.5+0.5*length)*100) / 100
+}
+
+func countWords(text string) int {
+	return len(strings.Fields(text))
+}
+
+func isCategoryTaxonomy(name string) bool {
+	return strings.HasPrefix(strings.ToLower(name), "categor")
+}
+
+func containsFold(slice []string, value string) bool {
+	for _, s := range slice {
+		if strings.EqualFold(s, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadTaxonomyTerms returns the terms set in the frontmatter for each of the
+// given taxonomies. Taxonomies that are not set are omitted.
+func ReadTaxonomyTerms(content string, taxonomies []string) map[string][]string {
+	fields := ParseFrontmatterFields(content)
+	terms := make(map[string][]string)
+	for _, taxonomy := range taxonomies {
+		switch v := fields[taxonomy].(type) {
+		case string:
+			if strings.TrimSpace(v) != "" {
+				terms[taxonomy] = []string{v}
+			}
+		case []interface{}:
+			var values []string
+			for _, item := range v {
+				if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+					values = append(values, s)
+				}
+			}
+			if len(values) > 0 {
+				terms[taxonomy] = values
+			}
+		}
+	}
+	return terms
+}
+
+// ParseFrontmatterFields decodes YAML, TOML, or JSON frontmatter into a map.
+// Content without valid frontmatter yields an empty map.
+func ParseFrontmatterFields(content string) map[string]interface{} {
+	delim, frontmatter, _ := SplitFrontmatter(content)
+	fields := make(map[string]interface{})
+
+	var err error
+	switch delim {
+	case "---":
+		err = yaml.Unmarshal([]byte(frontmatter), &fields)
+	case "+++":
+		err = toml.Unmarshal([]byte(frontmatter), &fields)
+	case "{":
+		err = json.Unmarshal([]byte(frontmatter), &fields)
+	}
+	if err != nil || fields == nil {
+		return map[string]interface{}{}
+	}
+	return fields
+}
+
+// ApplyTaxonomies writes the given terms into a content file's frontmatter.
+// Taxonomies already present in the file are never overwritten. Files without
+// frontmatter get a new YAML block.
+func ApplyTaxonomies(content string, terms map[string][]string) (string, error) {
+	if len(terms) == 0 {
+		return content, nil
+	}
+
+	names := make([]string, 0, len(terms))
+	for name, values := range terms {
+		if len(values) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return content, nil
+	}
+
+	existing := ParseFrontmatterFields(content)
+	delim, frontmatter, body := SplitFrontmatter(content)
+
+	switch delim {
+	case "", "---":
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(frontmatter), &doc); err != nil {
+			return "", fmt.Errorf("parsing frontmatter: %w", err)
+		}
+		if len(doc.Content) == 0 {
+			doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+		}
+		mapping := doc.Content[0]
+		if mapping.Kind != yaml.MappingNode {
+			return "", fmt.Errorf("frontmatter is not a mapping")
+		}
+		for _, name := range names {
+			if _, ok := existing[name]; ok {
+				continue
+			}
+			seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+			for _, term := range terms[name] {
+				seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: term})
+			}
+			mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, seq)
+		}
+		out, err := encodeYAMLNode(&doc)
+		if err != nil {
+			return "", err
+		}
+		if delim == "" {
+			body = content
+		}
+		return joinFrontmatter("---", out, body), nil
+
+	case "+++":
+		var added strings.Builder
+		for _, name := range names {
+			if _, ok := existing[name]; ok {
+				continue
+			}
+			quoted := make([]string, len(terms[name]))
+			for i, term := range terms[name] {
+				quoted[i] = fmt.Sprintf("%q", term)
+			}
+			fmt.Fprintf(&added, "%s = [%s]\n", name, strings.Join(quoted, ", "))
+		}
+
+		// Top-level keys must come before the first [table] header, or TOML
+		// reads them as keys of that table.
+		head, tables := frontmatter, ""
+		if loc := tomlTableHeaderRegex.FindStringIndex(frontmatter); loc != nil {
+			head, tables = frontmatter[:loc[0]], frontmatter[loc[0]:]
+		}
+		var lines strings.Builder
+		lines.WriteString(head)
+		if head != "" && !strings.HasSuffix(head, "\n") {
+			lines.WriteString("\n")
+		}
+		lines.WriteString(added.String())
+		lines.WriteString(tables)
+		if tables != "" && !strings.HasSuffix(tables, "\n") {
+			lines.WriteString("\n")
+		}
+		return joinFrontmatter("+++", lines.String(), body), nil
+
+	default:
+		return "", fmt.Errorf("writing taxonomies to JSON frontmatter is not supported")
+	}
+}
+
+// tomlTableHeaderRegex matches a TOML table or array-of-tables header line,
+// e.g. "[params]" or "[[menu.main]]".
+var tomlTableHeaderRegex = regexp.MustCompile(`(?m)^[ \t]*\[\[?[^\[\]\n]+\]\]?[ \t]*(?:#.*)?$`)
+
+func encodeYAMLNode(doc *yaml.Node) (string, error) {
+	var sb strings.Builder
+	enc := yaml.NewEncoder(&sb)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return "", fmt.Errorf("encoding frontmatter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("encoding frontmatter: %w", err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,166 @@
+package ai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const kubernetesPost = `---
+title: "Deploying Golang Services on Kubernetes"
+date: 2024-01-01
+---
+
+Kubernetes makes it simple to run golang services at scale. In this guide we
+build a small golang API, package it in a container, and deploy it to a
+kubernetes cluster.
+
+Golang compiles to a single static binary, which keeps container images small.
+Kubernetes then schedules the container, restarts it on failure, and scales the
+deployment when traffic grows.
+
+` + "```go\nfunc main() { http.ListenAndServe(\":8080\", nil) }\n```" + `
+
+Read more at https://kubernetes.io/docs/home/ about kubernetes deployments.
+`
+
+func TestTaxonomyNames(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *ThemeConfigAnalysis
+		want []string
+	}{
+		{name: "nil config", cfg: nil, want: []string{"categories", "tags"}},
+		{name: "no taxonomies", cfg: &ThemeConfigAnalysis{Taxonomies: map[string]string{}}, want: []string{"categories", "tags"}},
+		{
+			name: "theme taxonomies",
+			cfg:  &ThemeConfigAnalysis{Taxonomies: map[string]string{"tag": "tags", "series": "series"}},
+			want: []string{"series", "tags"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TaxonomyNames(tt.cfg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TaxonomyNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractKeywords(t *testing.T) {
+	keywords := ExtractKeywords(kubernetesPost, 3)
+	if len(keywords) != 3 {
+		t.Fatalf("expected 3 keywords, got %v", keywords)
+	}
+	if keywords[0].Term != "kubernetes" {
+		t.Errorf("expected kubernetes as top keyword, got %q", keywords[0].Term)
+	}
+	if keywords[0].Score != 1 {
+		t.Errorf("top keyword score = %v, want 1", keywords[0].Score)
+	}
+	for _, kw := range keywords {
+		if kw.Term == "https" || kw.Term == "the" || kw.Term == "listenandserve" {
+			t.Errorf("unexpected keyword %q", kw.Term)
+		}
+	}
+}
+
+func TestSuggestTaxonomies(t *testing.T) {
+	s := SuggestTaxonomies(kubernetesPost, TaxonomyOptions{Taxonomies: []string{"tags", "categories"}})
+
+	tags := s.Suggested["tags"]
+	for _, want := range []string{"kubernetes", "golang"} {
+		if !containsString(tags, want) {
+			t.Errorf("expected %q in suggested tags, got %v", want, tags)
+		}
+	}
+	if len(s.Suggested["categories"]) != 1 {
+		t.Errorf("expected a single category, got %v", s.Suggested["categories"])
+	}
+	if s.Confidence <= 0 || s.Confidence > 1 {
+		t.Errorf("confidence = %v, want (0, 1]", s.Confidence)
+	}
+}
+
+func TestSuggestTaxonomiesPreservesExistingTags(t *testing.T) {
+	content := strings.Replace(kubernetesPost, "date: 2024-01-01\n", "date: 2024-01-01\ntags: [devops]\n", 1)
+
+	s := SuggestTaxonomies(content, TaxonomyOptions{Taxonomies: []string{"tags", "categories"}})
+	if _, ok := s.Suggested["tags"]; ok {
+		t.Errorf("expected no tag suggestions for a file with tags, got %v", s.Suggested["tags"])
+	}
+	if !reflect.DeepEqual(s.Existing["tags"], []string{"devops"}) {
+		t.Errorf("Existing tags = %v, want [devops]", s.Existing["tags"])
+	}
+
+	updated, err := ApplyTaxonomies(content, map[string][]string{
+		"tags":       {"kubernetes"},
+		"categories": {"cloud"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyTaxonomies() error = %v", err)
+	}
+	terms := ReadTaxonomyTerms(updated, []string{"tags", "categories"})
+	if !reflect.DeepEqual(terms["tags"], []string{"devops"}) {
+		t.Errorf("tags = %v, want existing [devops] preserved", terms["tags"])
+	}
+	if !reflect.DeepEqual(terms["categories"], []string{"cloud"}) {
+		t.Errorf("categories = %v, want [cloud]", terms["categories"])
+	}
+	if !strings.Contains(updated, "Kubernetes makes it simple") {
+		t.Error("body was not preserved")
+	}
+}
+
+func TestSuggestTaxonomiesPrefersVocabulary(t *testing.T) {
+	s := SuggestTaxonomies(kubernetesPost, TaxonomyOptions{
+		Taxonomies: []string{"categories"},
+		Vocabulary: map[string][]string{"categories": {"Golang", "Rust"}},
+	})
+	if !reflect.DeepEqual(s.Suggested["categories"], []string{"Golang"}) {
+		t.Errorf("categories = %v, want [Golang]", s.Suggested["categories"])
+	}
+}
+
+func TestApplyTaxonomiesFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "toml",
+			content: "+++\ntitle = \"Post\"\n+++\nBody\n",
+			want:    "+++\ntitle = \"Post\"\ntags = [\"golang\", \"kubernetes\"]\n+++\nBody\n",
+		},
+		{
+			name:    "toml with nested table",
+			content: "+++\ntitle = \"Post\"\n\n[params]\n  featured = true\n\n[[menu.main]]\n  weight = 1\n+++\nBody\n",
+			want:    "+++\ntitle = \"Post\"\n\ntags = [\"golang\", \"kubernetes\"]\n[params]\n  featured = true\n\n[[menu.main]]\n  weight = 1\n+++\nBody\n",
+		},
+		{
+			name:    "no frontmatter",
+			content: "Body\n",
+			want:    "---\ntags: [golang, kubernetes]\n---\nBody\n",
+		},
+		{
+			name:    "json",
+			content: "{\"title\": \"Post\"}\nBody\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyTaxonomies(tt.content, map[string][]string{"tags": {"golang", "kubernetes"}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyTaxonomies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ApplyTaxonomies() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	toml "github.com/pelletier/go-toml/v2"
@@ -55,6 +56,9 @@ type ThemeConfigAnalysis struct {
 	Menus             []MenuConfig
 	Outputs           map[string][]string
 	ExampleConfig     string
+	// RequiredPageParams are page params some template renders without an
+	// if/with guard, so pages lacking them render incompletely.
+	RequiredPageParams []string
 }
 
 // MenuConfig holds menu configuration from theme
@@ -82,9 +86,21 @@ type SectionPattern struct {
 	FieldCounts  map[string]int
 	FileCount    int // Total files analyzed in this section
 	UsesBundle   bool
+	// ExampleFiles are up to maxExampleFiles pages of the section (never its
+	// _index.md), best example first: most frontmatter fields, then longest body.
 	ExampleFiles []string
 }
 
+// maxExampleFiles bounds SectionPattern.ExampleFiles.
+const maxExampleFiles = 3
+
+// exampleCandidate is a page considered for SectionPattern.ExampleFiles.
+type exampleCandidate struct {
+	path     string
+	fields   int
+	bodySize int
+}
+
 // =============================================================================
 // MAIN ANALYSIS FUNCTIONS
 // =============================================================================
@@ -184,6 +200,8 @@ func AnalyzeSiteContent(sitePath string) *ContentPatterns {
 		return patterns
 	}
 
+	candidates := make(map[string][]exampleCandidate)
+
 	// Walk content directory
 	_ = filepath.Walk(contentDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil || info.IsDir() {
@@ -236,9 +254,17 @@ func AnalyzeSiteContent(sitePath string) *ContentPatterns {
 						sp.UsesBundle = true
 					}
 
-					// Add example file
-					if len(sp.ExampleFiles) < 3 {
-						sp.ExampleFiles = append(sp.ExampleFiles, relPath)
+					// Section listings make poor examples of a page
+					if info.Name() != "_index.md" {
+						body := ""
+						if len(fmParts) == 2 {
+							body = strings.TrimSpace(fmParts[1])
+						}
+						candidates[section] = append(candidates[section], exampleCandidate{
+							path:     relPath,
+							fields:   len(fields),
+							bodySize: len(body),
+						})
 					}
 				}
 			}
@@ -247,6 +273,23 @@ func AnalyzeSiteContent(sitePath string) *ContentPatterns {
 		return nil
 	})
 
+	// Keep the best examples per section
+	for section, list := range candidates {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].fields != list[j].fields {
+				return list[i].fields > list[j].fields
+			}
+			if list[i].bodySize != list[j].bodySize {
+				return list[i].bodySize > list[j].bodySize
+			}
+			return list[i].path < list[j].path
+		})
+		sp := patterns.SectionPatterns[section]
+		for i := 0; i < len(list) && i < maxExampleFiles; i++ {
+			sp.ExampleFiles = append(sp.ExampleFiles, list[i].path)
+		}
+	}
+
 	// Calculate common fields per section
 	for _, sp := range patterns.SectionPatterns {
 		sp.CommonFields = []string{}
@@ -284,56 +327,81 @@ func BuildDynamicThemeContext(sitePath, themeName string) string {
 
 // BuildThemeContextFromAnalysis builds the theme context string from pre-computed analysis results.
 // Use this when you already have analysis results to avoid redundant filesystem scans.
+// The result is kept within MaxContextChars (see BuildThemeContextWithBudget).
 func BuildThemeContextFromAnalysis(themeName string, themeAnalysis *ThemeAnalysis, configAnalysis *ThemeConfigAnalysis, contentPatterns *ContentPatterns) string {
-	var sb strings.Builder
+	return BuildThemeContextWithBudget(themeName, themeAnalysis, configAnalysis, contentPatterns, MaxContextChars)
+}
 
-	sb.WriteString("=== DYNAMIC THEME ANALYSIS ===\n\n")
+// BuildThemeContextWithBudget is BuildThemeContextFromAnalysis with an
+// explicit size budget in characters; 0 or less means no limit. When the
+// full context does not fit, theme structure and required params are kept
+// ahead of exampleSite settings and learned content, and the result ends
+// with ContextTruncatedMarker.
+func BuildThemeContextWithBudget(themeName string, themeAnalysis *ThemeAnalysis, configAnalysis *ThemeConfigAnalysis, contentPatterns *ContentPatterns, maxChars int) string {
+	var blocks []contextBlock
+	add := func(priority int, text, reduced string) {
+		blocks = append(blocks, contextBlock{priority: priority, text: text, reduced: reduced})
+	}
 
 	// Theme info
+	var sb strings.Builder
+	sb.WriteString("=== DYNAMIC THEME ANALYSIS ===\n\n")
 	sb.WriteString(fmt.Sprintf("THEME: %s\n", themeName))
 	if themeAnalysis.Description != "" {
 		sb.WriteString(fmt.Sprintf("Description: %s\n", themeAnalysis.Description))
 	}
 	sb.WriteString("\n")
+	add(priorityHeader, sb.String(), "")
 
 	// Sections from layouts
 	if len(themeAnalysis.Sections) > 0 {
+		sb.Reset()
 		sb.WriteString("SUPPORTED SECTIONS (from theme layouts/):\n")
 		for _, section := range themeAnalysis.Sections {
 			sb.WriteString(fmt.Sprintf("- %s/\n", section))
 		}
 		sb.WriteString("\n")
+		add(prioritySections, sb.String(), "")
 	}
 
 	// Archetypes and frontmatter
 	if len(themeAnalysis.FrontmatterFields) > 0 {
+		sb.Reset()
 		sb.WriteString("FRONTMATTER BY SECTION (from archetypes/):\n")
 		for section, fields := range themeAnalysis.FrontmatterFields {
 			sb.WriteString(fmt.Sprintf("- %s: %s\n", section, strings.Join(fields, ", ")))
 		}
 		sb.WriteString("\n")
+		add(priorityFrontmatter, sb.String(), "")
 	}
 
-	// Required and optional params
+	// Required and optional params; optional ones are dropped first
 	if len(configAnalysis.RequiredParams) > 0 || len(configAnalysis.OptionalParams) > 0 {
-		sb.WriteString("SITE PARAMS (from theme templates):\n")
+		required := ""
 		if len(configAnalysis.RequiredParams) > 0 {
-			sb.WriteString(fmt.Sprintf("- Required: %s\n", strings.Join(configAnalysis.RequiredParams, ", ")))
+			required = fmt.Sprintf("- Required: %s\n", strings.Join(configAnalysis.RequiredParams, ", "))
 		}
+		optional := ""
 		if len(configAnalysis.OptionalParams) > 0 {
-			sb.WriteString(fmt.Sprintf("- Optional: %s\n", strings.Join(configAnalysis.OptionalParams, ", ")))
+			optional = fmt.Sprintf("- Optional: %s\n", strings.Join(configAnalysis.OptionalParams, ", "))
 		}
-		sb.WriteString("\n")
+		heading := "SITE PARAMS (from theme templates):\n"
+		reduced := ""
+		if required != "" && optional != "" {
+			reduced = heading + required + "\n"
+		}
+		add(priorityRequiredParams, heading+required+optional+"\n", reduced)
 	}
 
 	// Page params
 	if len(configAnalysis.PageParams) > 0 {
-		sb.WriteString(fmt.Sprintf("PAGE PARAMS (from .Params.X in templates): %s\n\n",
-			strings.Join(configAnalysis.PageParams, ", ")))
+		add(priorityPageParams, fmt.Sprintf("PAGE PARAMS (from .Params.X in templates): %s\n\n",
+			strings.Join(configAnalysis.PageParams, ", ")), "")
 	}
 
 	// Taxonomies
 	if themeAnalysis.HasTaxonomies {
+		sb.Reset()
 		sb.WriteString("TAXONOMIES: Supported (tags, categories)\n")
 		if len(configAnalysis.Taxonomies) > 0 {
 			for k, v := range configAnalysis.Taxonomies {
@@ -341,15 +409,17 @@ func BuildThemeContextFromAnalysis(themeName string, themeAnalysis *ThemeAnalysi
 			}
 		}
 		sb.WriteString("\n")
+		add(priorityFeatures, sb.String(), "")
 	}
 
 	// Search
 	if themeAnalysis.HasSearch {
-		sb.WriteString("SEARCH: Supported\n\n")
+		add(priorityFeatures, "SEARCH: Supported\n\n", "")
 	}
 
 	// Example menus from exampleSite
 	if len(configAnalysis.Menus) > 0 {
+		sb.Reset()
 		sb.WriteString("MENU STRUCTURE (from exampleSite):\n")
 		for _, menu := range configAnalysis.Menus {
 			sb.WriteString(fmt.Sprintf("- menu.%s:\n", menu.Name))
@@ -358,10 +428,12 @@ func BuildThemeContextFromAnalysis(themeName string, themeAnalysis *ThemeAnalysi
 			}
 		}
 		sb.WriteString("\n")
+		add(priorityExamples, sb.String(), "")
 	}
 
 	// Recommended params
 	if len(configAnalysis.RecommendedParams) > 0 {
+		sb.Reset()
 		sb.WriteString("RECOMMENDED PARAMS (from exampleSite):\n")
 		for key, value := range configAnalysis.RecommendedParams {
 			switch v := value.(type) {
@@ -374,10 +446,12 @@ func BuildThemeContextFromAnalysis(themeName string, themeAnalysis *ThemeAnalysi
 			}
 		}
 		sb.WriteString("\n")
+		add(priorityExamples, sb.String(), "")
 	}
 
 	// Learned patterns from existing content
 	if len(contentPatterns.SectionPatterns) > 0 {
+		sb.Reset()
 		sb.WriteString("LEARNED FROM EXISTING CONTENT:\n")
 		for _, sp := range contentPatterns.SectionPatterns {
 			bundleInfo := ""
@@ -390,9 +464,10 @@ func BuildThemeContextFromAnalysis(themeName string, themeAnalysis *ThemeAnalysi
 			}
 		}
 		sb.WriteString("\n")
+		add(priorityLearnedContent, sb.String(), "")
 	}
 
-	return sb.String()
+	return fitContextBlocks(blocks, maxChars)
 }
 
 // GetRecommendedFrontmatter returns recommended frontmatter for a section
@@ -768,13 +843,21 @@ func analyzeLayoutsForParams(themeDir string, analysis *ThemeConfigAnalysis) {
 		}
 
 		// Page params
-		matches = pageParamPattern.FindAllStringSubmatch(contentStr, -1)
-		for _, match := range matches {
-			if len(match) > 1 {
-				paramName := strings.ToLower(match[1])
-				if !containsString(analysis.PageParams, paramName) {
-					analysis.PageParams = append(analysis.PageParams, paramName)
-				}
+		for _, loc := range pageParamPattern.FindAllStringSubmatchIndex(contentStr, -1) {
+			name := contentStr[loc[2]:loc[3]]
+			paramName := strings.ToLower(name)
+			if !containsString(analysis.PageParams, paramName) {
+				analysis.PageParams = append(analysis.PageParams, paramName)
+			}
+
+			// Skip .Site.Params.X, which also matches the page pattern
+			if strings.HasSuffix(contentStr[:loc[0]], ".Site") {
+				continue
+			}
+			if !strings.Contains(contentStr, "if .Params."+name) &&
+				!strings.Contains(contentStr, "with .Params."+name) &&
+				!containsString(analysis.RequiredPageParams, paramName) {
+				analysis.RequiredPageParams = append(analysis.RequiredPageParams, paramName)
 			}
 		}
 
@@ -1369,6 +1452,12 @@ func generateDefaultArchetype(theme *ThemeAnalysis, config *ThemeConfigAnalysis)
 	return sb.String()
 }
 
+// FrontmatterFieldDefault returns the YAML placeholder value used for a
+// frontmatter field in generated archetypes and content.
+func FrontmatterFieldDefault(field string) string {
+	return getFieldDefaultValue(field)
+}
+
 // getFieldDefaultValue returns an appropriate default value for a frontmatter field
 func getFieldDefaultValue(field string) string {
 	fieldLower := strings.ToLower(field)
@@ -0,0 +1,111 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TranslatableFields are the frontmatter fields TranslateContent translates.
+// Everything else in the frontmatter (dates, tags, slugs, ...) is kept as is.
+var TranslatableFields = []string{"title", "description"}
+
+// TranslateRequest describes a content file to translate.
+type TranslateRequest struct {
+	Content  string // Full file content including frontmatter
+	Language string // Target language code, e.g. "fr"
+}
+
+// SystemPromptTranslate is the system prompt for content translation
+const SystemPromptTranslate = `You are a professional translator localizing Hugo website content.
+
+RULES:
+- Translate the text into the requested language, keeping its meaning and tone
+- Keep Markdown formatting, headings, lists and links exactly as they are
+- Do NOT translate code blocks, inline code, URLs, file paths or Hugo shortcodes ({{< ... >}}, {{% ... %}})
+- Do NOT add notes, explanations or a preamble
+
+OUTPUT:
+- Return ONLY the translated text`
+
+// TranslateContent translates a content file into req.Language. The title,
+// description and body are sent to the provider one at a time; the rest of
+// the frontmatter is preserved key for key.
+func TranslateContent(ctx context.Context, client *Client, req TranslateRequest) (string, error) {
+	if client == nil {
+		return "", fmt.Errorf("AI client is required")
+	}
+	if strings.TrimSpace(req.Language) == "" {
+		return "", fmt.Errorf("target language is required")
+	}
+
+	delim, frontmatterBlock, body := SplitFrontmatter(req.Content)
+	if delim == "{" {
+		return "", fmt.Errorf("translating JSON frontmatter is not supported")
+	}
+
+	frontmatter := ParseFrontmatterFields(req.Content)
+	var fields []frontmatterString
+	for _, key := range TranslatableFields {
+		value, ok := frontmatter[key].(string)
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+		translated, err := translateText(ctx, client, req.Language, key, value)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, frontmatterString{Key: key, Value: CleanTranslatedLine(translated)})
+	}
+
+	translatedBody := body
+	if strings.TrimSpace(body) != "" {
+		reply, err := translateText(ctx, client, req.Language, "body", body)
+		if err != nil {
+			return "", err
+		}
+		translatedBody = "\n" + CleanMarkdownFences(reply) + "\n"
+	}
+
+	if delim == "" {
+		return strings.TrimPrefix(translatedBody, "\n"), nil
+	}
+	translated := joinFrontmatter(delim, frontmatterBlock, translatedBody)
+	if len(fields) == 0 {
+		return translated, nil
+	}
+	return setFrontmatterStrings(translated, fields)
+}
+
+// translateText asks the provider to translate one field.
+func translateText(ctx context.Context, client *Client, language, field, text string) (string, error) {
+	reply, err := client.GenerateContentWithContext(ctx, SystemPromptTranslate, BuildTranslatePrompt(language, field, text))
+	if err != nil {
+		return "", fmt.Errorf("translating %s: %w", field, err)
+	}
+	if strings.TrimSpace(reply) == "" {
+		return "", fmt.Errorf("AI returned an empty translation for %s", field)
+	}
+	return reply, nil
+}
+
+// BuildTranslatePrompt builds the user prompt for translating one field.
+func BuildTranslatePrompt(language, field, text string) string {
+	return fmt.Sprintf(`TARGET LANGUAGE: %s
+FIELD: %s
+
+TEXT:
+---START---
+%s
+---END---
+
+Translate this text.`, language, field, strings.TrimSpace(text))
+}
+
+// CleanTranslatedLine normalizes a translated single-line field: fences and
+// surrounding quotes are removed and whitespace is collapsed.
+func CleanTranslatedLine(s string) string {
+	s = CleanMarkdownFences(s)
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.Trim(s, "\"'`")
+}
@@ -0,0 +1,157 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newTranslateTestServer returns a mock provider that "translates" the text
+// of each prompt by prefixing it with "[fr] ".
+func newTranslateTestServer(t *testing.T, calls *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		var req struct {
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		prompt := req.Messages[len(req.Messages)-1].Content
+		_, text, _ := strings.Cut(prompt, "---START---\n")
+		text, _, _ = strings.Cut(text, "\n---END---")
+
+		resp := map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": "[fr] " + text}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTranslateContentYAML(t *testing.T) {
+	original := `---
+title: "Hello World"
+date: 2024-01-01
+description: "A first post"
+tags: [go, walrus]
+draft: false
+---
+
+Welcome to the blog.
+`
+	var calls int32
+	server := newTranslateTestServer(t, &calls)
+	client := NewClient("openai", "test-key", server.URL, "gpt-4")
+
+	got, err := TranslateContent(context.Background(), client, TranslateRequest{Content: original, Language: "fr"})
+	if err != nil {
+		t.Fatalf("TranslateContent() error = %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("provider calls = %d, want 3 (title, description, body)", calls)
+	}
+	for _, want := range []string{
+		`title: "[fr] Hello World"`,
+		`description: "[fr] A first post"`,
+		"date: 2024-01-01",
+		"tags: [go, walrus]",
+		"draft: false",
+		"[fr] Welcome to the blog.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("translated content missing %q:\n%s", want, got)
+		}
+	}
+
+	// Keys keep their original order
+	_, frontmatter, _ := SplitFrontmatter(got)
+	var keys []string
+	for _, line := range strings.Split(frontmatter, "\n") {
+		if key, _, ok := strings.Cut(line, ":"); ok {
+			keys = append(keys, key)
+		}
+	}
+	if strings.Join(keys, ",") != "title,date,description,tags,draft" {
+		t.Errorf("frontmatter keys = %v, want original order", keys)
+	}
+}
+
+func TestTranslateContentTOML(t *testing.T) {
+	original := `+++
+title = "Hello"
+weight = 10
+
+[params]
+title = "Not translated"
++++
+
+Body text.
+`
+	var calls int32
+	server := newTranslateTestServer(t, &calls)
+	client := NewClient("openai", "test-key", server.URL, "gpt-4")
+
+	got, err := TranslateContent(context.Background(), client, TranslateRequest{Content: original, Language: "fr"})
+	if err != nil {
+		t.Fatalf("TranslateContent() error = %v", err)
+	}
+
+	for _, want := range []string{`title = "[fr] Hello"`, "weight = 10", `title = "Not translated"`, "[fr] Body text."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("translated content missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "description") {
+		t.Errorf("description was added although the source has none:\n%s", got)
+	}
+}
+
+func TestTranslateContentRejectsJSONFrontmatter(t *testing.T) {
+	var calls int32
+	server := newTranslateTestServer(t, &calls)
+	client := NewClient("openai", "test-key", server.URL, "gpt-4")
+
+	content := "{\n  \"title\": \"Hello\"\n}\n\nBody.\n"
+	if _, err := TranslateContent(context.Background(), client, TranslateRequest{Content: content, Language: "fr"}); err == nil {
+		t.Fatal("expected an error for JSON frontmatter")
+	}
+	if calls != 0 {
+		t.Errorf("provider called %d times, want 0", calls)
+	}
+}
+
+func TestTranslateContentRequiresLanguage(t *testing.T) {
+	client := NewClient("openai", "test-key", "http://127.0.0.1:0", "gpt-4")
+	if _, err := TranslateContent(context.Background(), client, TranslateRequest{Content: "Body"}); err == nil {
+		t.Fatal("expected an error without a target language")
+	}
+}
+
+func TestCleanTranslatedLine(t *testing.T) {
+	tests := map[string]string{
+		`"Bonjour le monde"`:    "Bonjour le monde",
+		"Bonjour\n  le monde  ": "Bonjour le monde",
+		"```\nBonjour\n```":     "Bonjour",
+		"'Titre'":               "Titre",
+	}
+	for in, want := range tests {
+		if got := CleanTranslatedLine(in); got != want {
+			t.Errorf("CleanTranslatedLine(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -346,6 +346,9 @@ const (
 	ProgressSkip      ProgressType = "skip"
 	ProgressComplete  ProgressType = "complete"
 	ProgressError     ProgressType = "error"
+	// ProgressToken carries a piece of streamed page content in Message. A
+	// ProgressRetry for the same page discards the tokens sent before it.
+	ProgressToken ProgressType = "token"
 )
 
 // ProgressEvent represents a progress update during pipeline execution.
@@ -0,0 +1,195 @@
+package ai
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// USAGE TRACKING
+// =============================================================================
+//
+// Clients from LoadClient append the token usage of every chat completion to
+// ~/.walgo/ai-usage.jsonl, one GenerationStats per line, so AI spend can be
+// summarized with walgo ai usage.
+
+// UsageLogFile is the name of the usage log in ~/.walgo.
+const UsageLogFile = "ai-usage.jsonl"
+
+// TokenUsage is the token count an API response reports.
+type TokenUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// GenerationStats records the tokens and estimated cost of one chat completion.
+type GenerationStats struct {
+	Time             time.Time `json:"time"`
+	Provider         string    `json:"provider"`
+	Model            string    `json:"model"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	TotalTokens      int       `json:"total_tokens"`
+	Cost             float64   `json:"cost_usd"`
+	Priced           bool      `json:"priced"` // false when the model has no known price
+}
+
+// NewGenerationStats prices usage of model at its list price.
+func NewGenerationStats(provider, model string, usage TokenUsage, at time.Time) GenerationStats {
+	if usage.TotalTokens == 0 {
+		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	}
+	stats := GenerationStats{
+		Time:             at.UTC(),
+		Provider:         provider,
+		Model:            model,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+	}
+	if price, ok := PriceFor(model); ok {
+		stats.Priced = true
+		stats.Cost = (float64(usage.PromptTokens)*price.Input + float64(usage.CompletionTokens)*price.Output) / 1e6
+	}
+	return stats
+}
+
+// UsageLogPath returns the path of the usage log, ~/.walgo/ai-usage.jsonl.
+func UsageLogPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".walgo", UsageLogFile), nil
+}
+
+// usageLogMu serializes appends from concurrent generations.
+var usageLogMu sync.Mutex
+
+// AppendUsage adds stats to the usage log at path.
+func AppendUsage(path string, stats GenerationStats) error {
+	line, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to encode usage: %w", err)
+	}
+
+	usageLogMu.Lock()
+	defer usageLogMu.Unlock()
+
+	// #nosec G301 - .walgo directory uses standard permissions
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create usage log directory: %w", err)
+	}
+	// #nosec G304 - path is the walgo usage log
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open usage log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write usage log: %w", err)
+	}
+	return f.Close()
+}
+
+// LoadUsage reads the usage log at path, keeping entries recorded at or
+// after since. A missing log holds no entries; unreadable lines are skipped.
+func LoadUsage(path string, since time.Time) ([]GenerationStats, error) {
+	// #nosec G304 - path is the walgo usage log
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open usage log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []GenerationStats
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var stats GenerationStats
+		if err := json.Unmarshal(scanner.Bytes(), &stats); err != nil {
+			continue
+		}
+		if stats.Time.Before(since) {
+			continue
+		}
+		entries = append(entries, stats)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage log: %w", err)
+	}
+	return entries, nil
+}
+
+// UsageSummary totals the usage of one provider and model.
+type UsageSummary struct {
+	Provider         string  `json:"provider"`
+	Model            string  `json:"model"`
+	Calls            int     `json:"calls"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	TotalTokens      int     `json:"total_tokens"`
+	Cost             float64 `json:"cost_usd"`
+	UnpricedCalls    int     `json:"unpriced_calls,omitempty"` // calls left out of Cost
+}
+
+// SummarizeUsage groups entries by provider and model, ordered by provider
+// then by descending cost and tokens.
+func SummarizeUsage(entries []GenerationStats) []UsageSummary {
+	index := make(map[[2]string]int)
+	var summaries []UsageSummary
+	for _, e := range entries {
+		key := [2]string{e.Provider, e.Model}
+		i, ok := index[key]
+		if !ok {
+			i = len(summaries)
+			index[key] = i
+			summaries = append(summaries, UsageSummary{Provider: e.Provider, Model: e.Model})
+		}
+		s := &summaries[i]
+		s.Calls++
+		s.PromptTokens += e.PromptTokens
+		s.CompletionTokens += e.CompletionTokens
+		s.TotalTokens += e.TotalTokens
+		s.Cost += e.Cost
+		if !e.Priced {
+			s.UnpricedCalls++
+		}
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		if a.Cost != b.Cost {
+			return a.Cost > b.Cost
+		}
+		if a.TotalTokens != b.TotalTokens {
+			return a.TotalTokens > b.TotalTokens
+		}
+		return a.Model < b.Model
+	})
+	return summaries
+}
+
+// recordUsage appends a completion's usage to the client's usage log. Failing
+// to record never fails the generation.
+func (c *Client) recordUsage(usage *TokenUsage) {
+	if c.UsageLog == "" || usage == nil {
+		return
+	}
+	if usage.PromptTokens == 0 && usage.CompletionTokens == 0 && usage.TotalTokens == 0 {
+		return
+	}
+	_ = AppendUsage(c.UsageLog, NewGenerationStats(c.Provider, c.Model, *usage, time.Now()))
+}
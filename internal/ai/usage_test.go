@@ -0,0 +1,154 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewGenerationStats(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	stats := NewGenerationStats("openai", "gpt-4o", TokenUsage{PromptTokens: 1000, CompletionTokens: 500}, at)
+	if !stats.Priced || stats.TotalTokens != 1500 {
+		t.Errorf("stats = %+v", stats)
+	}
+	// 1000 * $2.50/M + 500 * $10/M
+	if math.Abs(stats.Cost-0.0075) > 1e-12 {
+		t.Errorf("Cost = %v, want 0.0075", stats.Cost)
+	}
+
+	stats = NewGenerationStats("openrouter", "anthropic/claude-3.5-haiku", TokenUsage{PromptTokens: 1_000_000, TotalTokens: 1_000_000}, at)
+	if !stats.Priced || math.Abs(stats.Cost-0.8) > 1e-9 {
+		t.Errorf("OpenRouter model priced by its base name: %+v", stats)
+	}
+
+	stats = NewGenerationStats("openrouter", "someone/new-model", TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}, at)
+	if stats.Priced || stats.Cost != 0 {
+		t.Errorf("unknown model should be unpriced: %+v", stats)
+	}
+}
+
+func TestUsageLogRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".walgo", UsageLogFile)
+	now := time.Now().UTC()
+
+	if entries, err := LoadUsage(path, time.Time{}); err != nil || len(entries) != 0 {
+		t.Fatalf("LoadUsage on a missing log = %v, %v", entries, err)
+	}
+
+	old := NewGenerationStats("openai", "gpt-4o", TokenUsage{PromptTokens: 100, CompletionTokens: 50}, now.Add(-45*24*time.Hour))
+	recent := NewGenerationStats("openai", "gpt-4o-mini", TokenUsage{PromptTokens: 200, CompletionTokens: 80}, now.Add(-2*time.Hour))
+	for _, stats := range []GenerationStats{old, recent} {
+		if err := AppendUsage(path, stats); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(f, "not json")
+	f.Close()
+
+	entries, err := LoadUsage(path, now.Add(-30*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Model != "gpt-4o-mini" || entries[0].TotalTokens != 280 {
+		t.Errorf("entries since 30 days = %+v", entries)
+	}
+	all, _ := LoadUsage(path, time.Time{})
+	if len(all) != 2 {
+		t.Errorf("all entries = %d, want 2 (malformed line skipped)", len(all))
+	}
+}
+
+func TestSummarizeUsage(t *testing.T) {
+	at := time.Now()
+	entries := []GenerationStats{
+		NewGenerationStats("openrouter", "anthropic/claude-sonnet-4", TokenUsage{PromptTokens: 1000, CompletionTokens: 1000}, at),
+		NewGenerationStats("openai", "gpt-4o-mini", TokenUsage{PromptTokens: 4000, CompletionTokens: 1000}, at),
+		NewGenerationStats("openai", "gpt-4o", TokenUsage{PromptTokens: 1000, CompletionTokens: 500}, at),
+		NewGenerationStats("openrouter", "someone/new-model", TokenUsage{PromptTokens: 300, CompletionTokens: 200}, at),
+		NewGenerationStats("openai", "gpt-4o", TokenUsage{PromptTokens: 3000, CompletionTokens: 500}, at),
+		NewGenerationStats("openrouter", "anthropic/claude-sonnet-4", TokenUsage{PromptTokens: 2000, CompletionTokens: 0}, at),
+	}
+
+	got := SummarizeUsage(entries)
+	want := []UsageSummary{
+		{Provider: "openai", Model: "gpt-4o", Calls: 2, PromptTokens: 4000, CompletionTokens: 1000, TotalTokens: 5000, Cost: 0.02},
+		{Provider: "openai", Model: "gpt-4o-mini", Calls: 1, PromptTokens: 4000, CompletionTokens: 1000, TotalTokens: 5000, Cost: 0.0012},
+		{Provider: "openrouter", Model: "anthropic/claude-sonnet-4", Calls: 2, PromptTokens: 3000, CompletionTokens: 1000, TotalTokens: 4000, Cost: 0.024},
+		{Provider: "openrouter", Model: "someone/new-model", Calls: 1, PromptTokens: 300, CompletionTokens: 200, TotalTokens: 500, UnpricedCalls: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("SummarizeUsage() = %+v", got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if math.Abs(g.Cost-w.Cost) > 1e-9 {
+			t.Errorf("row %d cost = %v, want %v", i, g.Cost, w.Cost)
+		}
+		g.Cost, w.Cost = 0, 0
+		if g != w {
+			t.Errorf("row %d = %+v, want %+v", i, g, w)
+		}
+	}
+
+	if len(SummarizeUsage(nil)) != 0 {
+		t.Error("no entries should summarize to no rows")
+	}
+}
+
+func TestClientRecordsUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"hi"}}],"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("openai", "test-key", server.URL, "gpt-4o-mini")
+	if _, err := client.Chat([]Message{{Role: "user", Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	client.UsageLog = filepath.Join(t.TempDir(), UsageLogFile)
+	if _, err := client.Chat([]Message{{Role: "user", Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := LoadUsage(client.UsageLog, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Provider != "openai" || entries[0].Model != "gpt-4o-mini" || entries[0].TotalTokens != 15 || !entries[0].Priced {
+		t.Errorf("recorded usage = %+v", entries)
+	}
+}
+
+func TestClientRecordsStreamUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"choices":[{"delta":{"content":"Hello"}}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"choices":[],"usage":{"prompt_tokens":20,"completion_tokens":1,"total_tokens":21}}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	client := NewClient("openrouter", "test-key", server.URL, "openai/gpt-4o")
+	client.UsageLog = filepath.Join(t.TempDir(), UsageLogFile)
+	content, err := client.ChatStreamWithContext(context.Background(), []Message{{Role: "user", Content: "hi"}}, nil)
+	if err != nil || content != "Hello" {
+		t.Fatalf("ChatStreamWithContext() = %q, %v", content, err)
+	}
+	entries, _ := LoadUsage(client.UsageLog, time.Time{})
+	if len(entries) != 1 || entries[0].TotalTokens != 21 || entries[0].Provider != "openrouter" {
+		t.Errorf("recorded usage = %+v", entries)
+	}
+}
@@ -50,6 +50,7 @@ func (h *DeployHelper) PrepareDeployment(buildDir string) (*DeploymentPlan, erro
 	// Count total size
 	var totalSize int64
 	var changedSize int64
+	files := make([]FileRecord, 0, len(hashes))
 
 	// Build lookup sets for O(1) change detection
 	changedFiles := make(map[string]struct{}, len(changes.Added)+len(changes.Modified))
@@ -68,6 +69,7 @@ func (h *DeployHelper) PrepareDeployment(buildDir string) (*DeploymentPlan, erro
 		}
 
 		totalSize += info.Size()
+		files = append(files, FileRecord{Path: path, Hash: hashes[path], Size: info.Size()})
 
 		if _, isChanged := changedFiles[path]; isChanged {
 			changedSize += info.Size()
@@ -80,11 +82,18 @@ func (h *DeployHelper) PrepareDeployment(buildDir string) (*DeploymentPlan, erro
 		TotalSize:     totalSize,
 		ChangedSize:   changedSize,
 		IsIncremental: len(changes.Unchanged) > 0,
+		Files:         files,
 	}
 
 	return plan, nil
 }
 
+// StoredFiles returns the cached file records, including the blob IDs the
+// previous deployment stored them under.
+func (h *DeployHelper) StoredFiles() (map[string]FileRecord, error) {
+	return h.manager.GetAllFiles()
+}
+
 // FinalizeDeployment updates the cache after a successful deployment
 func (h *DeployHelper) FinalizeDeployment(buildDir, projectID, deployID string, fileToBlobID map[string]string) error {
 	// Compute hashes for all files
@@ -174,6 +183,7 @@ type DeploymentPlan struct {
 	TotalSize     int64
 	ChangedSize   int64
 	IsIncremental bool
+	Files         []FileRecord // Files of the build directory with their hash and size
 }
 
 // PrintSummary prints a human-readable deployment plan
@@ -872,3 +872,47 @@ func TestPrepareDeploymentWithSubdirectories(t *testing.T) {
 		t.Errorf("TotalFiles = %d, want 2 (files in subdirectories should be counted)", plan.TotalFiles)
 	}
 }
+
+func TestPrepareDeploymentFilesMatchStoredFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	buildDir := filepath.Join(tmpDir, "build")
+	if err := os.MkdirAll(buildDir, 0755); err != nil {
+		t.Fatalf("failed to create build dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(buildDir, "index.html"), []byte("<html>hello</html>"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	helper, err := NewDeployHelper(tmpDir)
+	if err != nil {
+		t.Fatalf("NewDeployHelper() error = %v", err)
+	}
+	defer helper.Close()
+
+	if err := helper.FinalizeDeployment(buildDir, "proj-1", "deploy-1", map[string]string{"index.html": "blob-index"}); err != nil {
+		t.Fatalf("FinalizeDeployment() error = %v", err)
+	}
+	stored, err := helper.StoredFiles()
+	if err != nil {
+		t.Fatalf("StoredFiles() error = %v", err)
+	}
+	plan, err := helper.PrepareDeployment(buildDir)
+	if err != nil {
+		t.Fatalf("PrepareDeployment() error = %v", err)
+	}
+
+	if len(plan.Files) != 1 {
+		t.Fatalf("plan.Files = %d, want 1", len(plan.Files))
+	}
+	f := plan.Files[0]
+	record, ok := stored[f.Path]
+	if !ok {
+		t.Fatalf("StoredFiles() has no record for %q", f.Path)
+	}
+	if record.BlobID != "blob-index" {
+		t.Errorf("BlobID = %q, want blob-index", record.BlobID)
+	}
+	if record.Hash != f.Hash || f.Size != int64(len("<html>hello</html>")) {
+		t.Errorf("plan file %+v does not match stored record %+v", f, record)
+	}
+}
@@ -0,0 +1,67 @@
+package compress
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/selimozten/walgo/internal/cache"
+)
+
+// DedupePlan groups byte-identical files so each distinct content is stored once.
+type DedupePlan struct {
+	// Unique lists one canonical path per distinct content, sorted.
+	Unique []string
+	// Aliases maps each duplicate path to the canonical path holding the same bytes.
+	Aliases map[string]string
+	// DuplicateFiles is the number of paths that don't need their own upload.
+	DuplicateFiles int
+	// DuplicateBytes is the total size of those paths.
+	DuplicateBytes int64
+}
+
+// FindDuplicates hashes the given files (paths relative to dir) and groups
+// identical ones. The lexically first path in each group is the canonical one,
+// so the plan is the same regardless of input order.
+func FindDuplicates(dir string, files []string) (*DedupePlan, error) {
+	sorted := append([]string(nil), files...)
+	sort.Strings(sorted)
+
+	plan := &DedupePlan{Aliases: make(map[string]string)}
+	canonical := make(map[string]string, len(sorted))
+
+	for _, rel := range sorted {
+		path := filepath.Join(dir, rel)
+		hash, err := cache.HashFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", rel, err)
+		}
+
+		first, seen := canonical[hash]
+		if !seen {
+			canonical[hash] = rel
+			plan.Unique = append(plan.Unique, rel)
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", rel, err)
+		}
+		plan.Aliases[rel] = first
+		plan.DuplicateFiles++
+		plan.DuplicateBytes += info.Size()
+	}
+
+	return plan, nil
+}
+
+// Expand copies the blob ID of each canonical path onto its duplicates.
+func (p *DedupePlan) Expand(fileToBlobID map[string]string) {
+	for dup, rel := range p.Aliases {
+		if id, ok := fileToBlobID[rel]; ok {
+			fileToBlobID[dup] = id
+		}
+	}
+}
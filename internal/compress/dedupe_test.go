@@ -0,0 +1,50 @@
+package compress
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"b.css":   "body{}",
+		"a.css":   "body{}",
+		"c.css":   "body{}",
+		"main.js": "console.log(1)",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plan, err := FindDuplicates(dir, []string{"main.js", "c.css", "b.css", "a.css"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"a.css", "main.js"}; !reflect.DeepEqual(plan.Unique, want) {
+		t.Errorf("Unique = %v, want %v", plan.Unique, want)
+	}
+	if want := map[string]string{"b.css": "a.css", "c.css": "a.css"}; !reflect.DeepEqual(plan.Aliases, want) {
+		t.Errorf("Aliases = %v, want %v", plan.Aliases, want)
+	}
+	if plan.DuplicateFiles != 2 || plan.DuplicateBytes != 12 {
+		t.Errorf("savings = %d files / %d bytes, want 2 / 12", plan.DuplicateFiles, plan.DuplicateBytes)
+	}
+
+	ids := map[string]string{"a.css": "blob-a", "main.js": "blob-m"}
+	plan.Expand(ids)
+	if ids["b.css"] != "blob-a" || ids["c.css"] != "blob-a" || len(ids) != 4 {
+		t.Errorf("Expand() = %v", ids)
+	}
+}
+
+func TestFindDuplicates_MissingFile(t *testing.T) {
+	if _, err := FindDuplicates(t.TempDir(), []string{"missing.html"}); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -0,0 +1,41 @@
+package compress
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultEntrypoint is the page Walrus Sites serve at the site root.
+const DefaultEntrypoint = "index.html"
+
+// ErrEntrypointMissing means the publish directory has no entrypoint page,
+// usually because the site was not built.
+var ErrEntrypointMissing = errors.New("entrypoint not found")
+
+// CheckEntrypointFile fails unless entrypoint, a resource path relative to
+// publishDir, is a file there. An empty entrypoint means DefaultEntrypoint.
+func CheckEntrypointFile(publishDir, entrypoint string) error {
+	if strings.TrimSpace(entrypoint) == "" {
+		entrypoint = DefaultEntrypoint
+	}
+	page := path.Clean(normalizeResourcePath(entrypoint))
+	if page == "/" {
+		return fmt.Errorf("entrypoint must be a file, not the site root")
+	}
+
+	info, err := os.Stat(filepath.Join(publishDir, filepath.FromSlash(strings.TrimPrefix(page, "/"))))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s is missing from %s", ErrEntrypointMissing, page, publishDir)
+		}
+		return fmt.Errorf("failed to check entrypoint: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("entrypoint %s is a directory, not a page", page)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package compress
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckEntrypointFileFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "app", "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "app", "home.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		entrypoint  string
+		wantErr     bool
+		wantMissing bool
+	}{
+		{"default entrypoint present", "", false, false},
+		{"configured index.html present", "index.html", false, false},
+		{"custom entrypoint present", "app/home.html", false, false},
+		{"leading slash", "/app/home.html", false, false},
+		{"custom entrypoint missing", "start.html", true, true},
+		{"directory", "app/docs", true, false},
+		{"site root", "/", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckEntrypointFile(dir, tt.entrypoint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckEntrypointFile(%q) error = %v, wantErr %v", tt.entrypoint, err, tt.wantErr)
+			}
+			if errors.Is(err, ErrEntrypointMissing) != tt.wantMissing {
+				t.Errorf("CheckEntrypointFile(%q) error = %v, want ErrEntrypointMissing %v", tt.entrypoint, err, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestCheckEntrypointFileUnbuiltSite(t *testing.T) {
+	err := CheckEntrypointFile(filepath.Join(t.TempDir(), "public"), "index.html")
+	if !errors.Is(err, ErrEntrypointMissing) {
+		t.Errorf("CheckEntrypoint on a missing publish dir = %v, want ErrEntrypointMissing", err)
+	}
+}
@@ -0,0 +1,107 @@
+package compress
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultNotFoundPage is the page Hugo renders from layouts/404.html.
+const DefaultNotFoundPage = "/404.html"
+
+// fallbackRoutePatterns are the two forms of the portal's catch-all route,
+// used for paths that match no resource or other route.
+var fallbackRoutePatterns = []string{"*", "/*"}
+
+// FallbackRoute returns the target of the catch-all route in routes, in
+// either form, or "" when there is none.
+func FallbackRoute(routes map[string]string) string {
+	for _, pattern := range fallbackRoutePatterns {
+		if target := routes[pattern]; target != "" {
+			return target
+		}
+	}
+	return ""
+}
+
+// DetectNotFoundPage returns DefaultNotFoundPage when publishDir contains it,
+// or "" when the site has no 404 page.
+func DetectNotFoundPage(publishDir string) string {
+	info, err := os.Stat(filepath.Join(publishDir, strings.TrimPrefix(DefaultNotFoundPage, "/")))
+	if err != nil || info.IsDir() {
+		return ""
+	}
+	return DefaultNotFoundPage
+}
+
+// SetNotFoundPage makes page the portal's fallback for paths that match no
+// resource or route, by pointing the "*" route of wsResourcesPath at it and
+// dropping an existing "/*" route. page
+// is a resource path relative to the publish directory holding
+// ws-resources.json and must exist there. The page also gets a Content-Type
+// header if it has none, so the fallback renders as HTML.
+func SetNotFoundPage(wsResourcesPath, page string) error {
+	page = path.Clean(normalizeResourcePath(page))
+	if page == "/" {
+		return fmt.Errorf("404 page must be a file, not the site root")
+	}
+
+	publishDir := filepath.Dir(wsResourcesPath)
+	filePath := filepath.Join(publishDir, filepath.FromSlash(strings.TrimPrefix(page, "/")))
+	info, err := os.Stat(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("404 page %s not found in %s", page, publishDir)
+		}
+		return fmt.Errorf("failed to check 404 page: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("404 page %s is a directory", page)
+	}
+
+	obj, err := readWSResourcesObject(wsResourcesPath)
+	if err != nil {
+		return err
+	}
+
+	routes := make(map[string]any)
+	if raw, ok := obj["routes"]; ok && raw != nil {
+		existing, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("invalid ws-resources.json: \"routes\" must be an object, got %s", jsonTypeName(raw))
+		}
+		routes = existing
+	}
+	for _, pattern := range fallbackRoutePatterns {
+		delete(routes, pattern)
+	}
+	routes["*"] = page
+	obj["routes"] = routes
+
+	headers, err := headersFromObject(obj)
+	if err != nil {
+		return err
+	}
+	if contentType := getContentType(filePath); contentType != "" {
+		h := headers[page]
+		if h == nil {
+			h = make(map[string]string)
+			headers[page] = h
+		}
+		hasContentType := false
+		for name := range h {
+			if strings.EqualFold(name, "Content-Type") {
+				hasContentType = true
+				break
+			}
+		}
+		if !hasContentType {
+			h["Content-Type"] = contentType
+		}
+		obj["headers"] = headers
+	}
+
+	return writeWSResourcesObject(wsResourcesPath, obj)
+}
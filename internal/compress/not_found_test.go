@@ -0,0 +1,144 @@
+package compress
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetNotFoundPage(t *testing.T) {
+	wsPath, publishDir := writeHeadersSite(t)
+	if err := os.MkdirAll(filepath.Join(publishDir, "errors"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(publishDir, "errors", "missing.html"), []byte("<h1>Not found</h1>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetNotFoundPage(wsPath, "errors/missing.html"); err != nil {
+		t.Fatalf("SetNotFoundPage() error = %v", err)
+	}
+
+	cfg, err := ReadWSResourcesConfig(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Routes["*"] != "/errors/missing.html" {
+		t.Errorf("fallback route = %q, want /errors/missing.html", cfg.Routes["*"])
+	}
+	if cfg.Routes["/about"] != "/about/index.html" {
+		t.Error("existing routes should be kept")
+	}
+	if cfg.Headers["/errors/missing.html"]["Content-Type"] == "" {
+		t.Error("404 page should get a Content-Type header")
+	}
+	if cfg.ObjectID != "0xold" || cfg.Headers["/index.html"]["Content-Type"] == "" {
+		t.Error("unrelated fields should be preserved")
+	}
+}
+
+func TestSetNotFoundPage_KeepsExistingContentType(t *testing.T) {
+	wsPath, _ := writeHeadersSite(t)
+	if err := SetNotFoundPage(wsPath, "/about/index.html"); err != nil {
+		t.Fatalf("SetNotFoundPage() error = %v", err)
+	}
+
+	headers, err := ListHeaders(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := headers["/about/index.html"]; len(got) != 1 || got["Content-Type"] != "text/html; charset=utf-8" {
+		t.Errorf("headers = %v, want the original Content-Type only", got)
+	}
+}
+
+func TestSetNotFoundPage_RejectsMissingPage(t *testing.T) {
+	wsPath, _ := writeHeadersSite(t)
+	before, err := os.ReadFile(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, page := range []string{"/nope.html", "/about", "/", "../outside.html"} {
+		if err := SetNotFoundPage(wsPath, page); err == nil {
+			t.Errorf("SetNotFoundPage(%q) should fail", page)
+		}
+	}
+
+	after, err := os.ReadFile(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(before) != string(after) {
+		t.Error("ws-resources.json should be unchanged after a rejected page")
+	}
+}
+
+func TestDetectNotFoundPage(t *testing.T) {
+	dir := t.TempDir()
+	if got := DetectNotFoundPage(dir); got != "" {
+		t.Errorf("DetectNotFoundPage() = %q, want empty without 404.html", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "404.html"), []byte("404"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := DetectNotFoundPage(dir); got != DefaultNotFoundPage {
+		t.Errorf("DetectNotFoundPage() = %q, want %q", got, DefaultNotFoundPage)
+	}
+}
+
+func TestFallbackRoute(t *testing.T) {
+	tests := []struct {
+		routes map[string]string
+		want   string
+	}{
+		{map[string]string{"/about": "/about/index.html"}, ""},
+		{map[string]string{"*": "/404.html"}, "/404.html"},
+		{map[string]string{"/*": "/index.html"}, "/index.html"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := FallbackRoute(tt.routes); got != tt.want {
+			t.Errorf("FallbackRoute(%v) = %q, want %q", tt.routes, got, tt.want)
+		}
+	}
+}
+
+func TestSetNotFoundPage_ReplacesSlashStarRoute(t *testing.T) {
+	wsPath, publishDir := writeHeadersSite(t)
+	if err := os.WriteFile(filepath.Join(publishDir, "404.html"), []byte("404"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := MergeRoutesIntoWSResources(wsPath, map[string]string{"/*": "/index.html"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetNotFoundPage(wsPath, "404.html"); err != nil {
+		t.Fatalf("SetNotFoundPage() error = %v", err)
+	}
+	cfg, err := ReadWSResourcesConfig(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cfg.Routes["/*"]; ok || cfg.Routes["*"] != "/404.html" {
+		t.Errorf("routes = %v, want only a \"*\" fallback to /404.html", cfg.Routes)
+	}
+}
+
+func TestGenerateRoutes_ExplicitSlashStarFallback(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"index.html", "404.html"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	routes, err := GenerateRoutesFromPublicWithOptions(dir, RouteOptions{ExplicitRoutes: map[string]string{"/*": "/index.html"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := routes["*"]; ok || routes["/*"] != "/index.html" {
+		t.Errorf("routes = %v, want the explicit \"/*\" fallback only", routes)
+	}
+}
@@ -0,0 +1,262 @@
+package compress
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultMaxPathLength is the longest resource path, in characters and with
+// its leading "/", that deploys accept unless configured otherwise. Longer paths
+// run into portal and blob-key limits.
+const DefaultMaxPathLength = 200
+
+// LongPathDir holds files moved by SanitizeResourcePaths when cleaning their
+// path is not enough to make it short enough.
+const LongPathDir = "_walgo"
+
+// unsafePathChars break resource paths: "?" and "#" end the path part of a
+// URL, and "\" is a separator on Windows.
+const unsafePathChars = `?#\`
+
+// PathProblem is a resource path that is likely to fail to deploy or load.
+type PathProblem struct {
+	Path   string // Resource path, e.g. "/posts/a-very-long-title/index.html"
+	Reason string
+}
+
+// PathRemap records a file moved to a safe resource path.
+type PathRemap struct {
+	From string // Original resource path
+	To   string // Resource path the file now has
+}
+
+// CheckResourcePaths returns, sorted by path, the resources under publishDir
+// whose path is longer than maxLen characters or contains control
+// characters, invalid UTF-8 or one of ? # \. ws-resources.json is not a
+// resource, and files its ignore patterns exclude from the upload are not
+// uploaded, so neither is checked.
+func CheckResourcePaths(publishDir string, maxLen int) ([]PathProblem, error) {
+	ignore, err := ReadIgnorePatterns(publishDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []PathProblem
+	err = filepath.WalkDir(publishDir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(publishDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == WSResourcesFile {
+			return nil
+		}
+
+		resourcePath := "/" + rel
+		if IsIgnoredResource(resourcePath, ignore) {
+			return nil
+		}
+		if reason := pathProblem(resourcePath, maxLen); reason != "" {
+			problems = append(problems, PathProblem{Path: resourcePath, Reason: reason})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check resource paths: %w", err)
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Path < problems[j].Path })
+	return problems, nil
+}
+
+// pathProblem describes what is wrong with resourcePath, or returns "".
+func pathProblem(resourcePath string, maxLen int) string {
+	if !utf8.ValidString(resourcePath) {
+		return "is not valid UTF-8"
+	}
+	for _, r := range resourcePath {
+		if unicode.IsControl(r) {
+			return "contains a control character"
+		}
+		if strings.ContainsRune(unsafePathChars, r) {
+			return fmt.Sprintf("contains %q", r)
+		}
+	}
+	if n := utf8.RuneCountInString(resourcePath); maxLen > 0 && n > maxLen {
+		return fmt.Sprintf("is %d characters long (max %d)", n, maxLen)
+	}
+	return ""
+}
+
+// ReadIgnorePatterns returns the ignore patterns of the ws-resources.json in
+// publishDir, or none when the file does not exist.
+func ReadIgnorePatterns(publishDir string) ([]string, error) {
+	wsResourcesPath := filepath.Join(publishDir, WSResourcesFile)
+	if _, err := os.Stat(wsResourcesPath); err != nil {
+		return nil, nil
+	}
+	cfg, err := ReadWSResourcesConfig(wsResourcesPath)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Ignore, nil
+}
+
+// IsIgnoredResource reports whether resourcePath matches one of the
+// ws-resources.json ignore patterns. As in site-builder, "*" matches any run
+// of characters, "/" included, and "?" any single character.
+func IsIgnoredResource(resourcePath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		var expr strings.Builder
+		expr.WriteString("^")
+		for _, r := range pattern {
+			switch r {
+			case '*':
+				expr.WriteString(".*")
+			case '?':
+				expr.WriteString(".")
+			default:
+				expr.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		expr.WriteString("$")
+		if matched, err := regexp.MatchString(expr.String(), resourcePath); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// SanitizeResourcePaths moves each problem file under publishDir to a safe
+// resource path and returns the moves. Unsafe characters are replaced with
+// "-"; a path still longer than maxLen moves to
+// /_walgo/<hash of the original path>/<name>, keeping its file name (and so
+// its content type) where it fits. Nothing is moved when any target is
+// already taken.
+func SanitizeResourcePaths(publishDir string, problems []PathProblem, maxLen int) ([]PathRemap, error) {
+	remaps := make([]PathRemap, 0, len(problems))
+	targets := make(map[string]string, len(problems))
+	for _, problem := range problems {
+		to := safeResourcePath(problem.Path, maxLen)
+		if other, dup := targets[to]; dup {
+			return nil, fmt.Errorf("cannot sanitize %s: %s maps to the same path %s", problem.Path, other, to)
+		}
+		if _, err := os.Lstat(resourceFile(publishDir, to)); err == nil {
+			return nil, fmt.Errorf("cannot sanitize %s: %s already exists", problem.Path, to)
+		}
+		targets[to] = problem.Path
+		remaps = append(remaps, PathRemap{From: problem.Path, To: to})
+	}
+
+	for _, remap := range remaps {
+		dst := resourceFile(publishDir, remap.To)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", remap.To, err)
+		}
+		if err := os.Rename(resourceFile(publishDir, remap.From), dst); err != nil {
+			return nil, fmt.Errorf("failed to move %s: %w", remap.From, err)
+		}
+	}
+	return remaps, nil
+}
+
+// safeResourcePath returns the path resourcePath is sanitized to.
+func safeResourcePath(resourcePath string, maxLen int) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || unicode.IsControl(r) || strings.ContainsRune(unsafePathChars, r) {
+			return '-'
+		}
+		return r
+	}, strings.ToValidUTF8(resourcePath, "-"))
+	if maxLen <= 0 || utf8.RuneCountInString(cleaned) <= maxLen {
+		return cleaned
+	}
+
+	sum := sha256.Sum256([]byte(resourcePath))
+	dir := "/" + LongPathDir + "/" + hex.EncodeToString(sum[:8]) + "/"
+	name := path.Base(cleaned)
+	if room := maxLen - len(dir); utf8.RuneCountInString(name) > room {
+		ext := path.Ext(name)
+		if utf8.RuneCountInString(ext) >= room {
+			ext = ""
+		}
+		name = string([]rune(name)[:max(room-utf8.RuneCountInString(ext), 1)]) + ext
+	}
+	return dir + name
+}
+
+// resourceFile is the file behind resourcePath in publishDir.
+func resourceFile(publishDir, resourcePath string) string {
+	return filepath.Join(publishDir, filepath.FromSlash(strings.TrimPrefix(resourcePath, "/")))
+}
+
+// AddPathAliases records remaps in ws-resources.json so the original URLs
+// still resolve: each original path becomes a route to the new path, routes
+// that targeted an original path follow it, and headers move with the file.
+// A missing ws-resources.json is created.
+func AddPathAliases(wsResourcesPath string, remaps []PathRemap) error {
+	if len(remaps) == 0 {
+		return nil
+	}
+
+	obj := make(map[string]any)
+	if _, err := os.Stat(wsResourcesPath); err == nil {
+		if obj, err = readWSResourcesObject(wsResourcesPath); err != nil {
+			return err
+		}
+	}
+
+	routes := make(map[string]any)
+	if raw, ok := obj["routes"]; ok && raw != nil {
+		existing, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("invalid ws-resources.json: \"routes\" must be an object, got %s", jsonTypeName(raw))
+		}
+		routes = existing
+	}
+	headers, err := headersFromObject(obj)
+	if err != nil {
+		return err
+	}
+
+	moved := make(map[string]string, len(remaps))
+	for _, remap := range remaps {
+		moved[remap.From] = remap.To
+	}
+	for pattern, target := range routes {
+		if s, ok := target.(string); ok {
+			if to, ok := moved[s]; ok {
+				routes[pattern] = to
+			}
+		}
+	}
+	for _, remap := range remaps {
+		routes[remap.From] = remap.To
+		if h, ok := headers[remap.From]; ok {
+			headers[remap.To] = h
+			delete(headers, remap.From)
+		}
+	}
+
+	obj["routes"] = routes
+	if len(headers) > 0 {
+		obj["headers"] = headers
+	}
+	return writeWSResourcesObject(wsResourcesPath, obj)
+}
@@ -0,0 +1,236 @@
+package compress
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// writePathsSite creates a publish directory holding files at the given
+// resource paths (without the leading "/").
+func writePathsSite(t *testing.T, files ...string) string {
+	t.Helper()
+	publishDir := t.TempDir()
+	for _, name := range files {
+		p := filepath.Join(publishDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("content of "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return publishDir
+}
+
+func TestCheckResourcePaths(t *testing.T) {
+	longName := "posts/" + strings.Repeat("a", 60) + "/index.html"
+	publishDir := writePathsSite(t,
+		"index.html",
+		"about us/index.html",
+		"100%/index.html",
+		longName,
+		"faq?/index.html",
+		"notes#1.html",
+		"tab\tname.txt",
+		WSResourcesFile,
+	)
+	if err := os.WriteFile(filepath.Join(publishDir, WSResourcesFile), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := CheckResourcePaths(publishDir, 50)
+	if err != nil {
+		t.Fatalf("CheckResourcePaths() error = %v", err)
+	}
+
+	want := map[string]string{
+		"/faq?/index.html": `contains '?'`,
+		"/notes#1.html":    `contains '#'`,
+		"/tab\tname.txt":   "contains a control character",
+		"/" + longName:     "is 78 characters long (max 50)",
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("problems = %+v, want %d", problems, len(want))
+	}
+	for i, problem := range problems {
+		if i > 0 && problems[i-1].Path >= problem.Path {
+			t.Errorf("problems not sorted: %q before %q", problems[i-1].Path, problem.Path)
+		}
+		if reason, ok := want[problem.Path]; !ok || reason != problem.Reason {
+			t.Errorf("problem %q: %q, want %q", problem.Path, problem.Reason, reason)
+		}
+	}
+}
+
+func TestCheckResourcePaths_NoLimit(t *testing.T) {
+	publishDir := writePathsSite(t, strings.Repeat("x", 120)+".html")
+	problems, err := CheckResourcePaths(publishDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("problems = %+v, want none without a length limit", problems)
+	}
+}
+
+func TestCheckResourcePaths_CountsCharacters(t *testing.T) {
+	// 20 characters, 40 bytes
+	publishDir := writePathsSite(t, strings.Repeat("é", 14)+".html")
+	problems, err := CheckResourcePaths(publishDir, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("problems = %+v, want none for a 20 character path", problems)
+	}
+
+	problems, err = CheckResourcePaths(publishDir, 19)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0].Reason != "is 20 characters long (max 19)" {
+		t.Errorf("problems = %+v, want one 20 character path", problems)
+	}
+}
+
+func TestCheckResourcePaths_SkipsIgnored(t *testing.T) {
+	longName := strings.Repeat("m", 60) + ".js.map"
+	publishDir := writePathsSite(t, "index.html", "js/"+longName, ".git/objects/"+longName, "notes#1.html")
+	wsResources := `{"ignore": ["/js/*.map", "/.git/*", "/notes?1.html"]}`
+	if err := os.WriteFile(filepath.Join(publishDir, WSResourcesFile), []byte(wsResources), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := CheckResourcePaths(publishDir, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("problems = %+v, want none for ignored files", problems)
+	}
+}
+
+func TestSanitizeResourcePaths_WithAliases(t *testing.T) {
+	longPage := "docs/" + strings.Repeat("very-long-segment-", 4) + "/index.html"
+	publishDir := writePathsSite(t, "index.html", "faq?/index.html", longPage)
+	wsPath := filepath.Join(publishDir, WSResourcesFile)
+	ws := `{
+  "headers": {"/` + longPage + `": {"Content-Type": "text/html; charset=utf-8"}},
+  "routes": {"/docs/long": "/` + longPage + `", "/about": "/about/index.html"},
+  "object_id": "0xsite"
+}`
+	if err := os.WriteFile(wsPath, []byte(ws), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	const maxLen = 60
+	problems, err := CheckResourcePaths(publishDir, maxLen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remaps, err := SanitizeResourcePaths(publishDir, problems, maxLen)
+	if err != nil {
+		t.Fatalf("SanitizeResourcePaths() error = %v", err)
+	}
+	if len(remaps) != 2 {
+		t.Fatalf("remaps = %+v, want 2", remaps)
+	}
+
+	moved := make(map[string]string)
+	for _, remap := range remaps {
+		moved[remap.From] = remap.To
+		if len(remap.To) > maxLen {
+			t.Errorf("%s remapped to %s, still longer than %d", remap.From, remap.To, maxLen)
+		}
+		if _, err := os.Stat(resourceFile(publishDir, remap.From)); !os.IsNotExist(err) {
+			t.Errorf("%s should have been moved away", remap.From)
+		}
+		data, err := os.ReadFile(resourceFile(publishDir, remap.To))
+		if err != nil || string(data) != "content of "+strings.TrimPrefix(remap.From, "/") {
+			t.Errorf("%s: content = %q, err = %v", remap.To, data, err)
+		}
+	}
+	if got := moved["/faq?/index.html"]; got != "/faq-/index.html" {
+		t.Errorf("unsafe path remapped to %q, want /faq-/index.html", got)
+	}
+	longTo := moved["/"+longPage]
+	if !strings.HasPrefix(longTo, "/"+LongPathDir+"/") || !strings.HasSuffix(longTo, "/index.html") {
+		t.Errorf("long path remapped to %q, want /%s/<hash>/index.html", longTo, LongPathDir)
+	}
+
+	if err := AddPathAliases(wsPath, remaps); err != nil {
+		t.Fatalf("AddPathAliases() error = %v", err)
+	}
+	cfg, err := ReadWSResourcesConfig(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for from, to := range moved {
+		if cfg.Routes[from] != to {
+			t.Errorf("route %s = %q, want alias to %s", from, cfg.Routes[from], to)
+		}
+	}
+	if cfg.Routes["/docs/long"] != longTo {
+		t.Errorf("route /docs/long = %q, want it to follow the file to %s", cfg.Routes["/docs/long"], longTo)
+	}
+	if cfg.Routes["/about"] != "/about/index.html" || cfg.ObjectID != "0xsite" {
+		t.Error("unrelated routes and fields should be preserved")
+	}
+	if _, ok := cfg.Headers["/"+longPage]; ok || cfg.Headers[longTo]["Content-Type"] == "" {
+		t.Errorf("headers = %v, want them moved with the file", cfg.Headers)
+	}
+
+	// The sanitized site passes the check
+	problems, err = CheckResourcePaths(publishDir, maxLen)
+	if err != nil || len(problems) != 0 {
+		t.Errorf("after sanitizing: problems = %+v, err = %v", problems, err)
+	}
+}
+
+func TestSanitizeResourcePaths_Collision(t *testing.T) {
+	publishDir := writePathsSite(t, "a?.html", "a-.html")
+	problems, err := CheckResourcePaths(publishDir, DefaultMaxPathLength)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := SanitizeResourcePaths(publishDir, problems, DefaultMaxPathLength); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("SanitizeResourcePaths() error = %v, want a collision error", err)
+	}
+	if _, err := os.Stat(filepath.Join(publishDir, "a?.html")); err != nil {
+		t.Error("nothing should be moved when a target is taken")
+	}
+}
+
+func TestAddPathAliases_CreatesWSResources(t *testing.T) {
+	wsPath := filepath.Join(t.TempDir(), WSResourcesFile)
+	remaps := []PathRemap{{From: "/faq?.html", To: "/faq-.html"}}
+
+	if err := AddPathAliases(wsPath, remaps); err != nil {
+		t.Fatalf("AddPathAliases() error = %v", err)
+	}
+	cfg, err := ReadWSResourcesConfig(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Routes["/faq?.html"] != "/faq-.html" {
+		t.Errorf("routes = %v", cfg.Routes)
+	}
+}
+
+func TestSafeResourcePath_TruncatesLongNames(t *testing.T) {
+	resourcePath := "/" + strings.Repeat("n", 300) + ".css"
+	got := safeResourcePath(resourcePath, 64)
+	if len(got) > 64 || !strings.HasSuffix(got, ".css") {
+		t.Errorf("safeResourcePath() = %q (%d bytes), want at most 64 bytes keeping .css", got, len(got))
+	}
+	if got := safeResourcePath("/"+strings.Repeat("ü", 300)+".css", 64); utf8.RuneCountInString(got) != 64 || !utf8.ValidString(got) || !strings.HasSuffix(got, ".css") {
+		t.Errorf("safeResourcePath() = %q, want 64 characters of valid UTF-8 keeping .css", got)
+	}
+	if got != safeResourcePath(resourcePath, 64) {
+		t.Error("safeResourcePath() should be deterministic")
+	}
+}
@@ -0,0 +1,172 @@
+package compress
+
+import (
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Crawler files served from the site root.
+const (
+	RobotsFile  = "/robots.txt"
+	SitemapFile = "/sitemap.xml"
+)
+
+// SEOMaxAge is the longest a crawler file may be cached, in seconds, so that
+// search engines see an updated robots.txt or sitemap soon after a deploy.
+const SEOMaxAge = 3600
+
+// SEOCacheControl is the Cache-Control header given to crawler files.
+var SEOCacheControl = "public, max-age=" + strconv.Itoa(SEOMaxAge) + ", must-revalidate"
+
+// seoContentTypes maps a crawler file name to the Content-Type it is served with.
+var seoContentTypes = map[string]string{
+	"robots.txt":  "text/plain; charset=utf-8",
+	"sitemap.xml": "application/xml; charset=utf-8",
+}
+
+var maxAgePattern = regexp.MustCompile(`(?i)max-age=(\d+)`)
+
+// ApplySEOHeaders gives robots.txt and every sitemap.xml in the publish
+// directory holding wsResourcesPath the right Content-Type and a short cache
+// policy. A Content-Type with the wrong media type is replaced, and a
+// Cache-Control header is replaced when it allows caching for longer than
+// SEOMaxAge. A missing ws-resources.json is left missing. It returns the
+// resource paths it found, and which of RobotsFile and SitemapFile are
+// missing from the site root.
+func ApplySEOHeaders(wsResourcesPath string) (found, missing []string, err error) {
+	publishDir := filepath.Dir(wsResourcesPath)
+	found, err = findSEOFiles(publishDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, root := range []string{RobotsFile, SitemapFile} {
+		if !containsString(found, root) {
+			missing = append(missing, root)
+		}
+	}
+	if len(found) == 0 {
+		return found, missing, nil
+	}
+	// Without a ws-resources.json (e.g. --skip-metadata) there is nothing to set
+	if _, err := os.Stat(wsResourcesPath); os.IsNotExist(err) {
+		return found, missing, nil
+	}
+
+	obj, err := readWSResourcesObject(wsResourcesPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	headers, err := headersFromObject(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changed := false
+	for _, resource := range found {
+		h := headers[resource]
+		if h == nil {
+			h = make(map[string]string)
+			headers[resource] = h
+		}
+		contentType := seoContentTypes[filepath.Base(resource)]
+		if current, ok := lookupHeader(h, "Content-Type"); !ok || !sameMediaType(current, contentType) {
+			deleteHeader(h, "Content-Type")
+			h["Content-Type"] = contentType
+			changed = true
+		}
+		if current, ok := lookupHeader(h, "Cache-Control"); !ok || !shortCachePolicy(current) {
+			deleteHeader(h, "Cache-Control")
+			h["Cache-Control"] = SEOCacheControl
+			changed = true
+		}
+	}
+	if !changed {
+		return found, missing, nil
+	}
+
+	obj["headers"] = headers
+	if err := writeWSResourcesObject(wsResourcesPath, obj); err != nil {
+		return nil, nil, err
+	}
+	return found, missing, nil
+}
+
+// findSEOFiles returns the resource paths of robots.txt at the root and of
+// sitemap.xml anywhere in publishDir (multilingual sites get one per
+// language), sorted.
+func findSEOFiles(publishDir string) ([]string, error) {
+	var found []string
+	err := filepath.WalkDir(publishDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(publishDir, path)
+		if err != nil {
+			return err
+		}
+		resource := normalizeResourcePath(rel)
+		if resource == RobotsFile || d.Name() == "sitemap.xml" {
+			found = append(found, resource)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	sort.Strings(found)
+	return found, nil
+}
+
+// lookupHeader returns the value of name in h, matched case-insensitively.
+func lookupHeader(h map[string]string, name string) (string, bool) {
+	for k, v := range h {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// sameMediaType reports whether two Content-Type values name the same media
+// type, ignoring parameters such as charset.
+func sameMediaType(a, b string) bool {
+	ma, _, errA := mime.ParseMediaType(a)
+	mb, _, errB := mime.ParseMediaType(b)
+	return errA == nil && errB == nil && ma == mb
+}
+
+// shortCachePolicy reports whether a Cache-Control value keeps a file cached
+// for no longer than SEOMaxAge.
+func shortCachePolicy(value string) bool {
+	lower := strings.ToLower(value)
+	if strings.Contains(lower, "no-cache") || strings.Contains(lower, "no-store") {
+		return true
+	}
+	if strings.Contains(lower, "immutable") {
+		return false
+	}
+	m := maxAgePattern.FindStringSubmatch(lower)
+	if m == nil {
+		return false
+	}
+	age, err := strconv.Atoi(m[1])
+	return err == nil && age <= SEOMaxAge
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
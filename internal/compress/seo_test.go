@@ -0,0 +1,135 @@
+package compress
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeSEOFile(t *testing.T, publishDir, rel string) {
+	t.Helper()
+	p := filepath.Join(publishDir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(rel), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplySEOHeaders(t *testing.T) {
+	wsPath, publishDir := writeHeadersSite(t)
+	writeSEOFile(t, publishDir, "robots.txt")
+	writeSEOFile(t, publishDir, "sitemap.xml")
+	writeSEOFile(t, publishDir, "fr/sitemap.xml")
+	if _, err := SetHeader(wsPath, publishDir, "/sitemap.xml", "Cache-Control", "public, max-age=31536000, immutable"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SetHeader(wsPath, publishDir, "/fr/sitemap.xml", "cache-control", "no-cache"); err != nil {
+		t.Fatal(err)
+	}
+
+	found, missing, err := ApplySEOHeaders(wsPath)
+	if err != nil {
+		t.Fatalf("ApplySEOHeaders() error = %v", err)
+	}
+	if want := []string{"/fr/sitemap.xml", "/robots.txt", "/sitemap.xml"}; !reflect.DeepEqual(found, want) {
+		t.Errorf("found = %v, want %v", found, want)
+	}
+	if len(missing) != 0 {
+		t.Errorf("missing = %v, want none", missing)
+	}
+
+	headers, err := ListHeaders(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := headers["/robots.txt"]; got["Content-Type"] != "text/plain; charset=utf-8" || got["Cache-Control"] != SEOCacheControl {
+		t.Errorf("robots.txt headers = %v", got)
+	}
+	if got := headers["/sitemap.xml"]; got["Content-Type"] != "application/xml; charset=utf-8" || got["Cache-Control"] != SEOCacheControl {
+		t.Errorf("sitemap.xml headers = %v, want the long cache policy replaced", got)
+	}
+	if got := headers["/fr/sitemap.xml"]; got["cache-control"] != "no-cache" || len(got) != 2 {
+		t.Errorf("fr/sitemap.xml headers = %v, want its shorter policy kept", got)
+	}
+	if headers["/index.html"]["Content-Type"] == "" {
+		t.Error("unrelated headers should be preserved")
+	}
+}
+
+func TestApplySEOHeaders_KeepsCorrectHeaders(t *testing.T) {
+	wsPath, publishDir := writeHeadersSite(t)
+	writeSEOFile(t, publishDir, "robots.txt")
+	if _, err := SetHeader(wsPath, publishDir, "/robots.txt", "Content-Type", "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SetHeader(wsPath, publishDir, "/robots.txt", "Cache-Control", "public, max-age=300"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := ApplySEOHeaders(wsPath); err != nil {
+		t.Fatalf("ApplySEOHeaders() error = %v", err)
+	}
+	after, err := os.ReadFile(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(before) != string(after) {
+		t.Errorf("ws-resources.json should be unchanged when headers are already right:\n%s", after)
+	}
+}
+
+func TestApplySEOHeaders_Missing(t *testing.T) {
+	wsPath, publishDir := writeHeadersSite(t)
+	writeSEOFile(t, publishDir, "en/sitemap.xml")
+
+	found, missing, err := ApplySEOHeaders(wsPath)
+	if err != nil {
+		t.Fatalf("ApplySEOHeaders() error = %v", err)
+	}
+	if !reflect.DeepEqual(found, []string{"/en/sitemap.xml"}) {
+		t.Errorf("found = %v", found)
+	}
+	if want := []string{RobotsFile, SitemapFile}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("missing = %v, want %v", missing, want)
+	}
+}
+
+func TestApplySEOHeaders_NoWSResources(t *testing.T) {
+	publishDir := t.TempDir()
+	writeSEOFile(t, publishDir, "robots.txt")
+	wsPath := filepath.Join(publishDir, "ws-resources.json")
+
+	found, missing, err := ApplySEOHeaders(wsPath)
+	if err != nil {
+		t.Fatalf("ApplySEOHeaders() error = %v, want a missing ws-resources.json ignored", err)
+	}
+	if !reflect.DeepEqual(found, []string{RobotsFile}) || !reflect.DeepEqual(missing, []string{SitemapFile}) {
+		t.Errorf("found = %v, missing = %v", found, missing)
+	}
+	if _, err := os.Stat(wsPath); !os.IsNotExist(err) {
+		t.Errorf("ws-resources.json should not be created, stat error = %v", err)
+	}
+}
+
+func TestShortCachePolicy(t *testing.T) {
+	tests := map[string]bool{
+		"public, max-age=300":                   true,
+		"public, max-age=3600, must-revalidate": true,
+		"no-store":                              true,
+		"public, max-age=86400":                 false,
+		"public, max-age=600, immutable":        false,
+		"public":                                false,
+	}
+	for value, want := range tests {
+		if got := shortCachePolicy(value); got != want {
+			t.Errorf("shortCachePolicy(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
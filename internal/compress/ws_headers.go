@@ -0,0 +1,374 @@
+package compress
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// WSResourcesFile is the name of the site-builder configuration file kept in
+// the publish directory.
+const WSResourcesFile = "ws-resources.json"
+
+// ListHeaders returns the per-path headers configured in ws-resources.json.
+// The file is validated first, so a header value that is not a string is
+// reported rather than silently dropped.
+func ListHeaders(wsResourcesPath string) (map[string]map[string]string, error) {
+	obj, err := readWSResourcesObject(wsResourcesPath)
+	if err != nil {
+		return nil, err
+	}
+	return headersFromObject(obj)
+}
+
+// SetHeader sets header name to value on every resource matching pattern,
+// replacing an existing header whose name differs only in case. pattern is a
+// resource path ("/index.html") or a glob expanded against the files in
+// publishDir. It returns the paths that were updated.
+func SetHeader(wsResourcesPath, publishDir, pattern, name, value string) ([]string, error) {
+	if err := ValidateHeader(name, value); err != nil {
+		return nil, err
+	}
+
+	obj, err := readWSResourcesObject(wsResourcesPath)
+	if err != nil {
+		return nil, err
+	}
+	headers, err := headersFromObject(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := ExpandResourcePaths(publishDir, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files in %s match %q", publishDir, pattern)
+	}
+
+	for _, p := range paths {
+		h := headers[p]
+		if h == nil {
+			h = make(map[string]string)
+			headers[p] = h
+		}
+		deleteHeader(h, name)
+		h[name] = value
+	}
+
+	obj["headers"] = headers
+	if err := writeWSResourcesObject(wsResourcesPath, obj); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// RemoveHeader removes header name (case-insensitively) from every resource
+// matching pattern. Globs match both the files in publishDir and the paths
+// already configured, so entries for deleted files can be cleaned up too.
+// Paths left without headers are dropped. It returns the paths that changed.
+func RemoveHeader(wsResourcesPath, publishDir, pattern, name string) ([]string, error) {
+	obj, err := readWSResourcesObject(wsResourcesPath)
+	if err != nil {
+		return nil, err
+	}
+	headers, err := headersFromObject(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := ExpandResourcePaths(publishDir, pattern)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		candidates[p] = true
+	}
+	normalized := normalizeResourcePath(pattern)
+	for p := range headers {
+		if matchPattern(p, normalized) {
+			candidates[p] = true
+		}
+	}
+
+	var removed []string
+	for p := range candidates {
+		h, ok := headers[p]
+		if !ok || !deleteHeader(h, name) {
+			continue
+		}
+		if len(h) == 0 {
+			delete(headers, p)
+		}
+		removed = append(removed, p)
+	}
+	sort.Strings(removed)
+
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	if len(headers) == 0 {
+		delete(obj, "headers")
+	} else {
+		obj["headers"] = headers
+	}
+	if err := writeWSResourcesObject(wsResourcesPath, obj); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// ExpandResourcePaths resolves pattern to resource paths. A pattern without
+// '*' is returned as-is (with a leading slash), whether or not the file exists
+// yet. Otherwise every file under publishDir is matched, where '*' matches any
+// run of characters including '/'. ws-resources.json itself is never matched.
+func ExpandResourcePaths(publishDir, pattern string) ([]string, error) {
+	pattern = normalizeResourcePath(pattern)
+	if !strings.Contains(pattern, "*") {
+		return []string{pattern}, nil
+	}
+
+	var paths []string
+	err := filepath.WalkDir(publishDir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(publishDir, p)
+		if err != nil {
+			return err
+		}
+		resource := "/" + filepath.ToSlash(rel)
+		if resource == "/"+WSResourcesFile {
+			return nil
+		}
+		if matchPattern(resource, pattern) {
+			paths = append(paths, resource)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("expanding %q: %w", pattern, err)
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// ResolveHeaders returns the effective headers of the resource at path. The
+// defaults of every directory in dirHeaders containing path are applied from
+// the least to the most specific prefix, then fileHeaders override them.
+// Header names are compared case-insensitively.
+func ResolveHeaders(path string, dirHeaders map[string]map[string]string, fileHeaders map[string]string) map[string]string {
+	path = normalizeResourcePath(path)
+
+	var dirs []string
+	for dir := range dirHeaders {
+		if strings.HasPrefix(path, normalizeDirPrefix(dir)) {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		a, b := normalizeDirPrefix(dirs[i]), normalizeDirPrefix(dirs[j])
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	resolved := make(map[string]string)
+	apply := func(h map[string]string) {
+		names := make([]string, 0, len(h))
+		for name := range h {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			deleteHeader(resolved, name)
+			resolved[name] = h[name]
+		}
+	}
+	for _, dir := range dirs {
+		apply(dirHeaders[dir])
+	}
+	apply(fileHeaders)
+	return resolved
+}
+
+// ResolvedHeaders returns the per-resource headers with DirHeaders applied
+// to every resource in Headers.
+func (c *WSResourcesConfig) ResolvedHeaders() map[string]map[string]string {
+	if len(c.DirHeaders) == 0 {
+		return c.Headers
+	}
+	resolved := make(map[string]map[string]string, len(c.Headers))
+	for path, h := range c.Headers {
+		resolved[path] = ResolveHeaders(path, c.DirHeaders, h)
+	}
+	return resolved
+}
+
+// normalizeDirPrefix turns a directory key such as "blog", "/blog" or
+// "/blog/" into the prefix "/blog/" that resource paths below it start with.
+func normalizeDirPrefix(dir string) string {
+	dir = normalizeResourcePath(dir)
+	if !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+	return dir
+}
+
+// validateDirHeaders checks every header in a dirHeaders map.
+func validateDirHeaders(dirHeaders map[string]map[string]string) error {
+	for dir, h := range dirHeaders {
+		for name, value := range h {
+			if err := ValidateHeader(name, value); err != nil {
+				return fmt.Errorf("directory headers for %q: %w", dir, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateHeader checks that name is a valid HTTP header field name and value
+// contains no line breaks.
+func ValidateHeader(name, value string) error {
+	if name == "" {
+		return fmt.Errorf("header name cannot be empty")
+	}
+	for _, r := range name {
+		if !isHeaderTokenChar(r) {
+			return fmt.Errorf("invalid header name %q: character %q not allowed", name, r)
+		}
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("invalid value for header %q: line breaks not allowed", name)
+	}
+	return nil
+}
+
+// isHeaderTokenChar reports whether r may appear in an HTTP header name
+// (an RFC 7230 token).
+func isHeaderTokenChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+}
+
+// normalizeResourcePath ensures a resource path starts with a slash.
+func normalizeResourcePath(p string) string {
+	p = strings.TrimSpace(filepath.ToSlash(p))
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return p
+}
+
+// deleteHeader removes name from h case-insensitively and reports whether
+// anything was removed.
+func deleteHeader(h map[string]string, name string) bool {
+	removed := false
+	for k := range h {
+		if strings.EqualFold(k, name) {
+			delete(h, k)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// headersFromObject extracts and validates the "headers" section of a parsed
+// ws-resources.json. A missing section yields an empty map.
+func headersFromObject(obj map[string]any) (map[string]map[string]string, error) {
+	headers := make(map[string]map[string]string)
+	raw, ok := obj["headers"]
+	if !ok || raw == nil {
+		return headers, nil
+	}
+
+	byPath, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid ws-resources.json: \"headers\" must be an object, got %s", jsonTypeName(raw))
+	}
+	for path, rawHeaders := range byPath {
+		fields, ok := rawHeaders.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid ws-resources.json: headers for %q must be an object, got %s", path, jsonTypeName(rawHeaders))
+		}
+		h := make(map[string]string, len(fields))
+		for name, v := range fields {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid ws-resources.json: header %q on %q must be a string, got %s", name, path, jsonTypeName(v))
+			}
+			h[name] = s
+		}
+		headers[path] = h
+	}
+	return headers, nil
+}
+
+// jsonTypeName describes a decoded JSON value for error messages.
+func jsonTypeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// readWSResourcesObject parses ws-resources.json into a generic map so that
+// fields this package does not model are preserved on write.
+func readWSResourcesObject(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is the site's ws-resources.json
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ws-resources.json: %w", err)
+	}
+
+	var obj map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("failed to parse ws-resources.json: %w", err)
+	}
+	if obj == nil {
+		obj = make(map[string]any)
+	}
+	return obj, nil
+}
+
+// writeWSResourcesObject writes obj in the canonical deterministic format.
+func writeWSResourcesObject(path string, obj map[string]any) error {
+	out, err := marshalDeterministicWSResources(obj)
+	if err != nil {
+		return err
+	}
+	// #nosec G306 - config file needs to be readable
+	if err := os.WriteFile(path, out, 0644); err != nil {
+		return fmt.Errorf("failed to write ws-resources.json: %w", err)
+	}
+	return nil
+}
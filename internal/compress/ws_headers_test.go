@@ -0,0 +1,365 @@
+package compress
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeHeadersSite writes a small publish dir next to the sample ws-resources.json.
+func writeHeadersSite(t *testing.T) (wsPath, publishDir string) {
+	t.Helper()
+	wsPath = writeSampleWSResources(t)
+	publishDir = filepath.Dir(wsPath)
+	for _, rel := range []string{"index.html", "about/index.html", "css/app.abc123.css", "css/print.css"} {
+		p := filepath.Join(publishDir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(rel), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return wsPath, publishDir
+}
+
+func TestSetHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		header    string
+		value     string
+		wantPaths []string
+		check     func(t *testing.T, h map[string]map[string]string)
+	}{
+		{
+			name:      "new header on existing path",
+			pattern:   "/index.html",
+			header:    "X-Frame-Options",
+			value:     "DENY",
+			wantPaths: []string{"/index.html"},
+			check: func(t *testing.T, h map[string]map[string]string) {
+				if h["/index.html"]["X-Frame-Options"] != "DENY" {
+					t.Errorf("header not set: %v", h["/index.html"])
+				}
+				if h["/index.html"]["Content-Type"] == "" {
+					t.Error("existing headers should be kept")
+				}
+			},
+		},
+		{
+			name:      "overwrite is case-insensitive",
+			pattern:   "index.html",
+			header:    "cache-control",
+			value:     "no-store",
+			wantPaths: []string{"/index.html"},
+			check: func(t *testing.T, h map[string]map[string]string) {
+				if _, ok := h["/index.html"]["Cache-Control"]; ok {
+					t.Error("old Cache-Control spelling should be replaced")
+				}
+				if h["/index.html"]["cache-control"] != "no-store" {
+					t.Errorf("header not overwritten: %v", h["/index.html"])
+				}
+			},
+		},
+		{
+			name:      "glob expands against publish dir",
+			pattern:   "/css/*.css",
+			header:    "X-Robots-Tag",
+			value:     "noindex",
+			wantPaths: []string{"/css/app.abc123.css", "/css/print.css"},
+			check: func(t *testing.T, h map[string]map[string]string) {
+				if h["/css/print.css"]["X-Robots-Tag"] != "noindex" {
+					t.Errorf("new path not created: %v", h["/css/print.css"])
+				}
+				if _, ok := h["/index.html"]["X-Robots-Tag"]; ok {
+					t.Error("non-matching path should be untouched")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wsPath, publishDir := writeHeadersSite(t)
+
+			paths, err := SetHeader(wsPath, publishDir, tt.pattern, tt.header, tt.value)
+			if err != nil {
+				t.Fatalf("SetHeader() error = %v", err)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("paths = %v, want %v", paths, tt.wantPaths)
+			}
+
+			headers, err := ListHeaders(wsPath)
+			if err != nil {
+				t.Fatalf("ListHeaders() error = %v", err)
+			}
+			tt.check(t, headers)
+
+			cfg, err := ReadWSResourcesConfig(wsPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.ObjectID != "0xold" || cfg.Routes["*"] != "/404.html" {
+				t.Error("unrelated fields should be preserved")
+			}
+		})
+	}
+}
+
+func TestSetHeader_Deterministic(t *testing.T) {
+	wsPath, publishDir := writeHeadersSite(t)
+
+	if _, err := SetHeader(wsPath, publishDir, "/index.html", "X-Test", "1"); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := os.ReadFile(wsPath)
+	if _, err := SetHeader(wsPath, publishDir, "/index.html", "X-Test", "1"); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := os.ReadFile(wsPath)
+	if string(first) != string(second) {
+		t.Errorf("repeated set changed the file:\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestSetHeader_NoMatch(t *testing.T) {
+	wsPath, publishDir := writeHeadersSite(t)
+	if _, err := SetHeader(wsPath, publishDir, "/js/*.js", "X-Test", "1"); err == nil {
+		t.Error("expected error when glob matches nothing")
+	}
+}
+
+func TestRemoveHeader(t *testing.T) {
+	wsPath, publishDir := writeHeadersSite(t)
+
+	removed, err := RemoveHeader(wsPath, publishDir, "/*.html", "content-type")
+	if err != nil {
+		t.Fatalf("RemoveHeader() error = %v", err)
+	}
+	if want := []string{"/about/index.html", "/index.html"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+
+	headers, err := ListHeaders(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := headers["/about/index.html"]; ok {
+		t.Error("path left without headers should be dropped")
+	}
+	if _, ok := headers["/index.html"]["Content-Type"]; ok {
+		t.Error("Content-Type should be removed from /index.html")
+	}
+	if headers["/index.html"]["Cache-Control"] == "" {
+		t.Error("other headers on /index.html should be kept")
+	}
+
+	removed, err = RemoveHeader(wsPath, publishDir, "/index.html", "X-Missing")
+	if err != nil || removed != nil {
+		t.Errorf("removing an absent header = (%v, %v), want (nil, nil)", removed, err)
+	}
+}
+
+func TestRemoveHeader_StalePath(t *testing.T) {
+	wsPath, publishDir := writeHeadersSite(t)
+	if _, err := SetHeader(wsPath, publishDir, "/gone/old.html", "X-Test", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := RemoveHeader(wsPath, publishDir, "/gone/*", "X-Test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 || removed[0] != "/gone/old.html" {
+		t.Errorf("removed = %v, want configured path not on disk", removed)
+	}
+}
+
+func TestListHeaders_RejectsNonStringValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{"number value", `{"headers": {"/index.html": {"Max-Age": 300}}}`, `header "Max-Age" on "/index.html" must be a string, got number`},
+		{"boolean value", `{"headers": {"/index.html": {"X-On": true}}}`, "got boolean"},
+		{"array value", `{"headers": {"/index.html": {"Vary": ["a"]}}}`, "got array"},
+		{"path not an object", `{"headers": {"/index.html": "text/html"}}`, `headers for "/index.html" must be an object`},
+		{"headers not an object", `{"headers": []}`, `"headers" must be an object`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), WSResourcesFile)
+			if err := os.WriteFile(path, []byte(tt.json), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := ListHeaders(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ListHeaders() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if _, err := SetHeader(path, filepath.Dir(path), "/index.html", "X-Test", "1"); err == nil {
+				t.Error("SetHeader() should refuse to rewrite an invalid file")
+			}
+		})
+	}
+}
+
+func TestValidateHeader(t *testing.T) {
+	tests := []struct {
+		name, header, value string
+		wantErr             bool
+	}{
+		{"valid", "Cache-Control", "no-cache", false},
+		{"empty name", "", "x", true},
+		{"space in name", "Cache Control", "x", true},
+		{"colon in name", "X-Test:", "x", true},
+		{"newline in value", "X-Test", "a\r\nSet-Cookie: x", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateHeader(tt.header, tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateHeader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveHeadersInheritance(t *testing.T) {
+	dirs := map[string]map[string]string{
+		"/":          {"X-Frame-Options": "DENY", "Cache-Control": "public, max-age=300"},
+		"blog":       {"Cache-Control": "public, max-age=3600"},
+		"/blog/img/": {"cache-control": "public, max-age=31536000, immutable"},
+		"/blogroll/": {"X-Robots-Tag": "noindex"},
+	}
+
+	tests := []struct {
+		path string
+		file map[string]string
+		want map[string]string
+	}{
+		{
+			path: "/index.html",
+			want: map[string]string{"X-Frame-Options": "DENY", "Cache-Control": "public, max-age=300"},
+		},
+		{
+			path: "/blog/post/index.html",
+			want: map[string]string{"X-Frame-Options": "DENY", "Cache-Control": "public, max-age=3600"},
+		},
+		{
+			// Most specific prefix wins, whatever the case of the header name
+			path: "/blog/img/cat.png",
+			want: map[string]string{"X-Frame-Options": "DENY", "cache-control": "public, max-age=31536000, immutable"},
+		},
+		{
+			// "/blog/" does not cover "/blogroll/"
+			path: "/blogroll/index.html",
+			want: map[string]string{"X-Frame-Options": "DENY", "Cache-Control": "public, max-age=300", "X-Robots-Tag": "noindex"},
+		},
+		{
+			// File-specific headers override every directory
+			path: "/blog/img/logo.svg",
+			file: map[string]string{"CACHE-CONTROL": "no-cache", "Content-Type": "image/svg+xml"},
+			want: map[string]string{"X-Frame-Options": "DENY", "CACHE-CONTROL": "no-cache", "Content-Type": "image/svg+xml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := ResolveHeaders(tt.path, dirs, tt.file); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveHeaders(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalResolvesDirHeaders(t *testing.T) {
+	cfg := &WSResourcesConfig{
+		Headers: map[string]map[string]string{
+			"/index.html":     {"Content-Type": "text/html; charset=utf-8"},
+			"/docs/api.html":  {"Content-Type": "text/html; charset=utf-8", "X-Frame-Options": "SAMEORIGIN"},
+			"/docs/guide.pdf": {"Content-Type": "application/pdf"},
+		},
+		DirHeaders: map[string]map[string]string{
+			"/docs/": {"X-Frame-Options": "DENY", "X-Robots-Tag": "noindex"},
+		},
+	}
+
+	data, err := MarshalWSResourcesConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "dir_headers") || strings.Contains(string(data), "DirHeaders") {
+		t.Errorf("directory headers serialized as their own field:\n%s", data)
+	}
+
+	path := filepath.Join(t.TempDir(), WSResourcesFile)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ListHeaders(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string]string{
+		"/index.html":     {"Content-Type": "text/html; charset=utf-8"},
+		"/docs/api.html":  {"Content-Type": "text/html; charset=utf-8", "X-Frame-Options": "SAMEORIGIN", "X-Robots-Tag": "noindex"},
+		"/docs/guide.pdf": {"Content-Type": "application/pdf", "X-Frame-Options": "DENY", "X-Robots-Tag": "noindex"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("serialized headers = %v, want %v", got, want)
+	}
+	if _, ok := cfg.Headers["/docs/guide.pdf"]["X-Robots-Tag"]; ok {
+		t.Error("MarshalWSResourcesConfig modified the config's own headers")
+	}
+}
+
+func TestGenerateWSResourcesConfigDirHeaders(t *testing.T) {
+	siteDir := t.TempDir()
+	for _, rel := range []string{"index.html", "blog/post.html", "blog/app.js"} {
+		p := filepath.Join(siteDir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(rel), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := GenerateWSResourcesConfig(siteDir, WSResourcesOptions{
+		CacheConfig: DefaultCacheControlConfig(),
+		CompressionStats: &DirectoryCompressionStats{Files: map[string]*CompressionResult{
+			"blog/app.js": {Compressed: true},
+		}},
+		DirHeaders: map[string]map[string]string{
+			"/blog/": {"cache-control": "no-store", "Content-Encoding": "identity"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post := cfg.Headers["/blog/post.html"]
+	if post["cache-control"] != "no-store" || post["Cache-Control"] != "" {
+		t.Errorf("directory Cache-Control did not replace the generated one: %v", post)
+	}
+	if post["Content-Type"] != "text/html; charset=utf-8" {
+		t.Errorf("generated Content-Type lost: %v", post)
+	}
+	if got := cfg.Headers["/blog/app.js"]["Content-Encoding"]; got != "br" {
+		t.Errorf("Content-Encoding of a compressed file = %q, want br", got)
+	}
+	if got := cfg.Headers["/index.html"]["Cache-Control"]; got == "" || got == "no-store" {
+		t.Errorf("file outside the directory got Cache-Control %q", got)
+	}
+
+	_, err = GenerateWSResourcesConfig(siteDir, WSResourcesOptions{
+		DirHeaders: map[string]map[string]string{"/": {"Bad Header": "x"}},
+	})
+	if err == nil {
+		t.Error("invalid directory header accepted")
+	}
+}
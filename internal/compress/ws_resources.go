@@ -3,8 +3,10 @@ package compress
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
@@ -38,6 +40,11 @@ type WSResourcesConfig struct {
 	ObjectID  string                       `json:"object_id,omitempty"` // Sui Object ID of deployed site
 	Ignore    []string                     `json:"ignore,omitempty"`    // Files/folders to exclude from upload
 	Resources []WSResource                 `json:"resources,omitempty"` // Legacy field for compatibility
+
+	// DirHeaders are default headers keyed by directory prefix ("/blog/"),
+	// inherited by every resource below it. site-builder has no such field:
+	// they are resolved into Headers when the config is serialized.
+	DirHeaders map[string]map[string]string `json:"-"`
 }
 
 // CacheControlConfig holds cache control settings
@@ -57,12 +64,8 @@ func DefaultCacheControlConfig() CacheControlConfig {
 		Enabled:         true,
 		ImmutableMaxAge: 31536000, // 1 year
 		MutableMaxAge:   300,      // 5 minutes
+		// Files with content hashes in their name are detected by IsFingerprinted
 		ImmutablePatterns: []string{
-			// Patterns for files with content hashes in filename
-			"*.*.min.js",  // e.g., app.abc123.min.js
-			"*.*.min.css", // e.g., style.def456.min.css
-			"*.*.js",
-			"*.*.css",
 			// Font files (rarely change)
 			"*.woff2",
 			"*.woff",
@@ -79,6 +82,7 @@ func DefaultIgnorePatterns() []string {
 		"/Thumbs.db",     // Windows thumbnails
 		"/.gitkeep",      // Git placeholder files
 		"/.gitignore",    // Git ignore file
+		"/.walgo.env",    // Local secrets file
 		"/.git/*",        // Git directory (if somehow in publish dir)
 		"/*.map",         // Source maps in root
 		"/js/*.map",      // JavaScript source maps
@@ -101,10 +105,17 @@ type WSResourcesOptions struct {
 	CacheConfig      CacheControlConfig
 	CustomRoutes     map[string]string
 	CustomIgnore     []string
+	DirHeaders       map[string]map[string]string // Default headers per directory prefix
 }
 
 // GenerateWSResourcesConfig creates a ws-resources.json configuration
+// Directory defaults from opts.DirHeaders replace the generated Content-Type
+// and Cache-Control of the resources they cover.
 func GenerateWSResourcesConfig(siteDir string, opts WSResourcesOptions) (*WSResourcesConfig, error) {
+	if err := validateDirHeaders(opts.DirHeaders); err != nil {
+		return nil, err
+	}
+
 	// Start with default ignore patterns and merge custom ones
 	ignorePatterns := DefaultIgnorePatterns()
 	if len(opts.CustomIgnore) > 0 {
@@ -130,9 +141,10 @@ func GenerateWSResourcesConfig(siteDir string, opts WSResourcesOptions) (*WSReso
 	}
 
 	config := &WSResourcesConfig{
-		Headers:  make(map[string]map[string]string),
-		Ignore:   ignorePatterns,
-		SiteName: opts.SiteName,
+		Headers:    make(map[string]map[string]string),
+		DirHeaders: opts.DirHeaders,
+		Ignore:     ignorePatterns,
+		SiteName:   opts.SiteName,
 		Metadata: &WSMetadata{
 			Description: opts.Description,
 			ImageURL:    imageURL,
@@ -197,6 +209,17 @@ func GenerateWSResourcesConfig(siteDir string, opts WSResourcesOptions) (*WSReso
 			}
 		}
 
+		// Directory defaults win over generated values, but not over the
+		// encoding, which must match the stored bytes
+		if len(opts.DirHeaders) > 0 {
+			for name := range ResolveHeaders(relPath, opts.DirHeaders, nil) {
+				if !strings.EqualFold(name, "Content-Encoding") {
+					deleteHeader(headers, name)
+				}
+			}
+			headers = ResolveHeaders(relPath, opts.DirHeaders, headers)
+		}
+
 		// Only add headers if there are any
 		if len(headers) > 0 {
 			config.Headers[relPath] = headers
@@ -221,11 +244,13 @@ func GenerateWSResourcesConfig(siteDir string, opts WSResourcesOptions) (*WSReso
 	return config, nil
 }
 
-// WriteWSResourcesConfig writes the configuration to ws-resources.json
+// WriteWSResourcesConfig writes the configuration to ws-resources.json.
+// Output is deterministic (fixed top-level order, sorted keys everywhere else)
+// so that committing the file after an update yields minimal diffs.
 func WriteWSResourcesConfig(config *WSResourcesConfig, outputPath string) error {
-	data, err := json.MarshalIndent(config, "", "  ")
+	data, err := MarshalWSResourcesConfig(config)
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return err
 	}
 
 	// #nosec G306 - config file needs to be readable
@@ -236,7 +261,26 @@ func WriteWSResourcesConfig(config *WSResourcesConfig, outputPath string) error
 	return nil
 }
 
+// RouteOptions controls the routes generated from a publish directory.
+type RouteOptions struct {
+	// DefaultLanguage is the language Hugo emitted under its own prefix
+	// (e.g. "en" for /en/). When set, "/" and the root-relative paths of that
+	// language's pages are aliased to it, so /about serves /en/about/.
+	// Callers validate it with hugo.ValidateLanguageCode.
+	DefaultLanguage string
+	// ExplicitRoutes are user-configured routes. They are applied last and
+	// always win over generated routes and aliases.
+	ExplicitRoutes map[string]string
+}
+
 func GenerateRoutesFromPublic(publicDir string) (map[string]string, error) {
+	return GenerateRoutesFromPublicWithOptions(publicDir, RouteOptions{})
+}
+
+// GenerateRoutesFromPublicWithOptions generates routes for every index.html
+// under publicDir, then applies the default-language aliases and explicit
+// routes from opts. Existing pages are never shadowed by an alias.
+func GenerateRoutesFromPublicWithOptions(publicDir string, opts RouteOptions) (map[string]string, error) {
 	routes := map[string]string{}
 
 	// Root routes: always useful
@@ -281,14 +325,68 @@ func GenerateRoutesFromPublic(publicDir string) (map[string]string, error) {
 		return nil, err
 	}
 
-	// Add fallback only if 404.html exists
-	if _, err := os.Stat(filepath.Join(publicDir, "404.html")); err == nil {
-		routes["*"] = "/404.html"
+	// Add fallback only if the site has a 404 page
+	if page := DetectNotFoundPage(publicDir); page != "" {
+		routes["*"] = page
+	}
+
+	if opts.DefaultLanguage != "" {
+		if err := addDefaultLanguageAliases(routes, publicDir, opts.DefaultLanguage); err != nil {
+			return nil, err
+		}
+	}
+
+	// An explicit catch-all replaces the generated one, whichever form it uses
+	if FallbackRoute(opts.ExplicitRoutes) != "" {
+		for _, pattern := range fallbackRoutePatterns {
+			delete(routes, pattern)
+		}
+	}
+	for pattern, target := range opts.ExplicitRoutes {
+		routes[pattern] = target
 	}
 
 	return routes, nil
 }
 
+// addDefaultLanguageAliases points "/" at lang's index and adds a
+// root-relative alias for each of lang's pages that has no route yet.
+func addDefaultLanguageAliases(routes map[string]string, publicDir, lang string) error {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" || lang == "." || lang == ".." || strings.ContainsAny(lang, `/\`) {
+		return fmt.Errorf("invalid default language %q", lang)
+	}
+	langDir := filepath.Join(publicDir, lang)
+	if _, err := os.Stat(filepath.Join(langDir, "index.html")); err != nil {
+		return fmt.Errorf("default language %q has no index.html in %s", lang, publicDir)
+	}
+
+	prefix := "/" + lang
+	routes["/"] = prefix + "/index.html"
+	routes["/index.html"] = prefix + "/index.html"
+
+	return filepath.WalkDir(langDir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || d.Name() != "index.html" {
+			return nil
+		}
+		rel, err := filepath.Rel(langDir, p)
+		if err != nil {
+			return err
+		}
+		dir := strings.TrimSuffix(filepath.ToSlash(rel), "/index.html")
+		if dir == "index.html" {
+			return nil
+		}
+		target := prefix + "/" + dir + "/index.html"
+		addRoute(routes, "/"+dir, target)
+		addRoute(routes, "/"+dir+"/*", target)
+		return nil
+	})
+}
+
 func addRoute(routes map[string]string, key, target string) {
 	// Normalize: no double slashes (except "http://", irrelevant here)
 	key = strings.ReplaceAll(key, "//", "/")
@@ -329,6 +427,68 @@ func MergeRoutesIntoWSResources(wsResourcesPath string, newRoutes map[string]str
 	return nil
 }
 
+// RemoveRoutes deletes the given route patterns from ws-resources.json,
+// leaving every other field as it is.
+func RemoveRoutes(wsResourcesPath string, patterns []string) error {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	obj, err := readWSResourcesObject(wsResourcesPath)
+	if err != nil {
+		return err
+	}
+	routes, ok := obj["routes"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	for _, pattern := range patterns {
+		delete(routes, pattern)
+	}
+	if len(routes) == 0 {
+		delete(obj, "routes")
+	}
+	return writeWSResourcesObject(wsResourcesPath, obj)
+}
+
+// MarshalWSResourcesConfig serializes a configuration in the canonical
+// ws-resources.json format used by every writer in this package.
+// Directory headers are resolved into the per-resource headers first.
+func MarshalWSResourcesConfig(config *WSResourcesConfig) ([]byte, error) {
+	if len(config.DirHeaders) > 0 {
+		resolved := *config
+		resolved.Headers = config.ResolvedHeaders()
+		config = &resolved
+	}
+
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var obj map[string]any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return marshalDeterministicWSResources(obj)
+}
+
+// marshalJSONIndent encodes v like json.MarshalIndent but without escaping
+// HTML characters, which keeps URLs and routes readable.
+func marshalJSONIndent(v any, prefix, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent(prefix, indent)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
 func marshalDeterministicWSResources(obj map[string]any) ([]byte, error) {
 	// Extract routes for special handling (sorted keys, "*" last)
 	var routes map[string]any
@@ -370,7 +530,13 @@ func marshalDeterministicWSResources(obj map[string]any) ([]byte, error) {
 	buf.WriteString("{\n")
 
 	for i, k := range orderedKeys {
-		buf.WriteString(fmt.Sprintf("  %q: ", k))
+		key, err := marshalJSONIndent(k, "", "")
+		if err != nil {
+			return nil, fmt.Errorf("marshal key %s: %w", k, err)
+		}
+		buf.WriteString("  ")
+		buf.Write(key)
+		buf.WriteString(": ")
 
 		if k == "routes" && routes != nil {
 			// Write routes with sorted keys and "*" last
@@ -388,7 +554,12 @@ func marshalDeterministicWSResources(obj map[string]any) ([]byte, error) {
 				if !ok {
 					return nil, fmt.Errorf("route value for %q is not a string", rk)
 				}
-				buf.WriteString(fmt.Sprintf("    %q: %q", rk, routeVal))
+				entry, err := marshalRouteEntry(rk, routeVal)
+				if err != nil {
+					return nil, err
+				}
+				buf.WriteString("    ")
+				buf.Write(entry)
 				if j < len(routeKeys)-1 || routes["*"] != nil {
 					buf.WriteString(",")
 				}
@@ -400,12 +571,18 @@ func marshalDeterministicWSResources(obj map[string]any) ([]byte, error) {
 				if !isString {
 					return nil, fmt.Errorf("route value for \"*\" is not a string")
 				}
-				buf.WriteString(fmt.Sprintf("    %q: %q\n", "*", starVal))
+				entry, err := marshalRouteEntry("*", starVal)
+				if err != nil {
+					return nil, err
+				}
+				buf.WriteString("    ")
+				buf.Write(entry)
+				buf.WriteString("\n")
 			}
 			buf.WriteString("  }")
 		} else {
 			// Marshal with indentation for other values
-			vBytes, err := json.MarshalIndent(obj[k], "  ", "  ")
+			vBytes, err := marshalJSONIndent(obj[k], "  ", "  ")
 			if err != nil {
 				return nil, fmt.Errorf("marshal field %s: %w", k, err)
 			}
@@ -423,6 +600,19 @@ func marshalDeterministicWSResources(obj map[string]any) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// marshalRouteEntry encodes a single `"pattern": "target"` routes entry.
+func marshalRouteEntry(pattern, target string) ([]byte, error) {
+	key, err := marshalJSONIndent(pattern, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("marshal route %q: %w", pattern, err)
+	}
+	value, err := marshalJSONIndent(target, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("marshal route %q: %w", pattern, err)
+	}
+	return append(append(key, ": "...), value...), nil
+}
+
 // wsResourcesLegacy is used for backward compatibility with old objectId field
 type wsResourcesLegacy struct {
 	ObjectIDLegacy string `json:"objectId,omitempty"` // Legacy camelCase field
@@ -459,6 +649,9 @@ func UpdateObjectID(wsResourcesPath string, objectID string) error {
 		return fmt.Errorf("failed to read ws-resources.json: %w", err)
 	}
 
+	if config.ObjectID == objectID {
+		return nil
+	}
 	config.ObjectID = objectID
 	return WriteWSResourcesConfig(config, wsResourcesPath)
 }
@@ -473,6 +666,8 @@ type MetadataOptions struct {
 	ProjectURL  string // GitHub or project page URL
 	Creator     string // Creator name (defaults to "Walgo")
 	Category    string // Site category (e.g., website, blog, portfolio)
+	// AllowCustomCategory accepts a Category outside KnownCategories.
+	AllowCustomCategory bool
 }
 
 // DefaultCreator is the default creator name for sites deployed with Walgo
@@ -482,12 +677,76 @@ const DefaultProjectURL = "https://github.com/selimozten/walgo"
 const DefaultImageURL = "https://cdn.jsdelivr.net/gh/selimozten/walgo@main/walgo-logo.svg"
 const DefaultCategory = "Walgo Site"
 
+// KnownCategories are the site categories accepted without AllowCustomCategory.
+var KnownCategories = []string{"website", "blog", "portfolio", "docs", "whitepaper", "biolink", DefaultCategory}
+
+// Errors returned by MetadataOptions.Validate.
+var (
+	ErrInvalidImageURL = errors.New("invalid image URL")
+	ErrUnknownCategory = errors.New("unknown category")
+)
+
+// Validate checks the fields that end up on-chain: ImageURL must be empty or
+// an absolute http(s) URL, and Category must be empty or one of
+// KnownCategories (case-insensitive) unless AllowCustomCategory is set.
+func (o MetadataOptions) Validate() error {
+	if o.ImageURL != "" {
+		if err := validateImageURL(o.ImageURL); err != nil {
+			return fmt.Errorf("%w %q: %v", ErrInvalidImageURL, o.ImageURL, err)
+		}
+	}
+	if o.Category != "" && !o.AllowCustomCategory && !IsKnownCategory(o.Category) {
+		return fmt.Errorf("%w %q (expected one of: %s)",
+			ErrUnknownCategory, o.Category, strings.Join(KnownCategories, ", "))
+	}
+	return nil
+}
+
+// IsKnownCategory reports whether category is in KnownCategories, ignoring case.
+func IsKnownCategory(category string) bool {
+	for _, known := range KnownCategories {
+		if strings.EqualFold(strings.TrimSpace(category), known) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateImageURL ensures raw is an absolute http or https URL with a host.
+func validateImageURL(raw string) error {
+	if strings.ContainsAny(raw, " \t\r\n") {
+		return errors.New("must not contain whitespace")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("scheme must be http or https")
+	}
+	if u.Host == "" {
+		return errors.New("missing host")
+	}
+	return nil
+}
+
 // UpdateMetadata updates all metadata fields in ws-resources.json
+// Invalid options are rejected before the file is read. The file is left
+// untouched when the resulting metadata equals what it already holds, so
+// repeated deploys do not churn it.
 func UpdateMetadata(wsResourcesPath string, opts MetadataOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
 	config, err := ReadWSResourcesConfig(wsResourcesPath)
 	if err != nil {
 		return fmt.Errorf("failed to read ws-resources.json: %w", err)
 	}
+	before, err := MarshalWSResourcesConfig(config)
+	if err != nil {
+		return err
+	}
 
 	// Update object_id if provided
 	if opts.ObjectID != "" {
@@ -534,6 +793,13 @@ func UpdateMetadata(wsResourcesPath string, opts MetadataOptions) error {
 		config.Metadata.Creator = DefaultCreator
 	}
 
+	after, err := MarshalWSResourcesConfig(config)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(before, after) {
+		return nil
+	}
 	return WriteWSResourcesConfig(config, wsResourcesPath)
 }
 
@@ -596,6 +862,17 @@ func getCacheControl(path string, config CacheControlConfig) string {
 		return ""
 	}
 
+	// HTML must always revalidate so new deployments are picked up,
+	// even when its filename happens to look fingerprinted
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".html" || ext == ".htm" {
+		return fmt.Sprintf("public, max-age=%d, must-revalidate", config.MutableMaxAge)
+	}
+
+	if IsFingerprinted(path) {
+		return fmt.Sprintf("public, max-age=%d, immutable", config.ImmutableMaxAge)
+	}
+
 	// Check if file matches immutable patterns
 	filename := filepath.Base(path)
 	for _, pattern := range config.ImmutablePatterns {
@@ -604,16 +881,56 @@ func getCacheControl(path string, config CacheControlConfig) string {
 		}
 	}
 
-	// HTML files and other mutable content
-	ext := strings.ToLower(filepath.Ext(path))
-	if ext == ".html" || ext == ".htm" {
-		return fmt.Sprintf("public, max-age=%d, must-revalidate", config.MutableMaxAge)
-	}
-
 	// Default: moderate caching for other files
 	return fmt.Sprintf("public, max-age=%d", config.MutableMaxAge)
 }
 
+// minFingerprintLength is the shortest hex segment treated as a content hash.
+const minFingerprintLength = 6
+
+// IsFingerprinted reports whether a file name contains a content hash, as
+// produced by Hugo's resources.Fingerprint (e.g. "app.abc123.css" or
+// "main.min.3f2a9c1e.js"). A segment separated by '.', '-' or '_' counts as a
+// hash when it is at least six hex characters long and mixes digits with hex
+// letters, so date stamps such as "IMG_20240115.jpg" are not mistaken for
+// one. The first segment is never treated as a hash.
+func IsFingerprinted(path string) bool {
+	name := filepath.Base(filepath.ToSlash(path))
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+
+	segments := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == '-' || r == '_'
+	})
+	if len(segments) < 2 {
+		return false
+	}
+
+	for _, seg := range segments[1:] {
+		if isHexHash(seg) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHexHash(s string) bool {
+	if len(s) < minFingerprintLength {
+		return false
+	}
+	hasDigit, hasLetter := false, false
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F'):
+			hasLetter = true
+		default:
+			return false
+		}
+	}
+	return hasDigit && hasLetter
+}
+
 // matchPattern checks if a filename matches a glob-like pattern
 // Simplified version - only supports * wildcard
 func matchPattern(filename, pattern string) bool {
@@ -0,0 +1,405 @@
+package compress
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsFingerprinted(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"app.abc123.css", true},
+		{"/css/app.abc123.css", true},
+		{"js/main.min.3f2a9c1e.js", true},
+		{"main.3f2a9c1e0b7d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f.css", true},
+		{"bundle-9f8e7d6c.js", true},
+		{"style.css", false},
+		{"jquery.min.js", false},
+		{"app.cafe.css", false},   // too short to be a hash
+		{"app.facade.css", false}, // hex letters only, looks like a word
+		{"abc123.css", false},     // first segment is the name, not a hash
+		{"IMG_20240115.jpg", false},
+		{"/files/report-20240101.pdf", false},
+		{"backup_20240101_120000.zip", false},
+		{"app.12345678.js", false}, // digits only, could be a date or version
+		{"index.html", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := IsFingerprinted(tt.path); got != tt.want {
+				t.Errorf("IsFingerprinted(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCacheControl(t *testing.T) {
+	cfg := DefaultCacheControlConfig()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/css/app.abc123.css", "public, max-age=31536000, immutable"},
+		{"/js/main.min.3f2a9c1e.js", "public, max-age=31536000, immutable"},
+		{"/fonts/inter.woff2", "public, max-age=31536000, immutable"},
+		{"/css/style.css", "public, max-age=300"},
+		{"/js/jquery.min.js", "public, max-age=300"},
+		{"/index.html", "public, max-age=300, must-revalidate"},
+		{"/posts/page.abc123.html", "public, max-age=300, must-revalidate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := getCacheControl(tt.path, cfg); got != tt.want {
+				t.Errorf("getCacheControl(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	cfg.Enabled = false
+	if got := getCacheControl("/css/app.abc123.css", cfg); got != "" {
+		t.Errorf("getCacheControl with caching disabled = %q, want empty", got)
+	}
+}
+
+func TestGenerateWSResourcesConfigFingerprintedHeaders(t *testing.T) {
+	siteDir := t.TempDir()
+	files := []string{"index.html", "css/app.abc123.css", "css/style.css"}
+	for _, f := range files {
+		path := filepath.Join(siteDir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := GenerateWSResourcesConfig(siteDir, WSResourcesOptions{CacheConfig: DefaultCacheControlConfig()})
+	if err != nil {
+		t.Fatalf("GenerateWSResourcesConfig failed: %v", err)
+	}
+
+	if got := cfg.Headers["/css/app.abc123.css"]["Cache-Control"]; !strings.Contains(got, "immutable") {
+		t.Errorf("fingerprinted asset Cache-Control = %q, want immutable", got)
+	}
+	if got := cfg.Headers["/css/style.css"]["Cache-Control"]; strings.Contains(got, "immutable") {
+		t.Errorf("plain asset Cache-Control = %q, want no immutable", got)
+	}
+	if got := cfg.Headers["/index.html"]["Cache-Control"]; strings.Contains(got, "immutable") {
+		t.Errorf("HTML Cache-Control = %q, want no immutable", got)
+	}
+}
+
+func writeSampleWSResources(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ws-resources.json")
+	cfg := &WSResourcesConfig{
+		Headers: map[string]map[string]string{
+			"/index.html":         {"Content-Type": "text/html; charset=utf-8", "Cache-Control": "public, max-age=300, must-revalidate"},
+			"/css/app.abc123.css": {"Content-Type": "text/css; charset=utf-8", "Cache-Control": "public, max-age=31536000, immutable"},
+			"/about/index.html":   {"Content-Type": "text/html; charset=utf-8"},
+		},
+		Routes:   map[string]string{"*": "/404.html", "/about": "/about/index.html", "/": "/index.html"},
+		Ignore:   DefaultIgnorePatterns(),
+		SiteName: "My Site",
+		ObjectID: "0xold",
+		Metadata: &WSMetadata{
+			Description: "Old description",
+			Link:        DefaultLink,
+			ProjectURL:  DefaultProjectURL,
+			ImageURL:    DefaultImageURL,
+			Creator:     DefaultCreator,
+			Category:    "blog",
+		},
+	}
+	if err := WriteWSResourcesConfig(cfg, path); err != nil {
+		t.Fatalf("WriteWSResourcesConfig failed: %v", err)
+	}
+	return path
+}
+
+// changedLines returns the line pairs that differ between two files of equal length.
+func changedLines(t *testing.T, before, after []byte) [][2]string {
+	t.Helper()
+	a := strings.Split(string(before), "\n")
+	b := strings.Split(string(after), "\n")
+	if len(a) != len(b) {
+		t.Fatalf("line count changed from %d to %d:\n%s\n---\n%s", len(a), len(b), before, after)
+	}
+	var diff [][2]string
+	for i := range a {
+		if a[i] != b[i] {
+			diff = append(diff, [2]string{a[i], b[i]})
+		}
+	}
+	return diff
+}
+
+func TestWSResourcesUpdatesMinimalDiff(t *testing.T) {
+	t.Run("UpdateMetadata changes only the updated line", func(t *testing.T) {
+		path := writeSampleWSResources(t)
+		before, _ := os.ReadFile(path)
+
+		if err := UpdateMetadata(path, MetadataOptions{Description: "New description"}); err != nil {
+			t.Fatalf("UpdateMetadata failed: %v", err)
+		}
+		after, _ := os.ReadFile(path)
+
+		diff := changedLines(t, before, after)
+		if len(diff) != 1 || !strings.Contains(diff[0][1], "New description") {
+			t.Errorf("expected exactly the description line to change, got %q", diff)
+		}
+	})
+
+	t.Run("UpdateObjectID changes only the object_id line", func(t *testing.T) {
+		path := writeSampleWSResources(t)
+		before, _ := os.ReadFile(path)
+
+		if err := UpdateObjectID(path, "0xnew"); err != nil {
+			t.Fatalf("UpdateObjectID failed: %v", err)
+		}
+		after, _ := os.ReadFile(path)
+
+		diff := changedLines(t, before, after)
+		if len(diff) != 1 || !strings.Contains(diff[0][1], `"object_id": "0xnew"`) {
+			t.Errorf("expected exactly the object_id line to change, got %q", diff)
+		}
+	})
+
+	t.Run("route merge and struct writes agree on format", func(t *testing.T) {
+		path := writeSampleWSResources(t)
+		before, _ := os.ReadFile(path)
+
+		routes := map[string]string{"*": "/404.html", "/about": "/about/index.html", "/": "/index.html"}
+		if err := MergeRoutesIntoWSResources(path, routes); err != nil {
+			t.Fatalf("MergeRoutesIntoWSResources failed: %v", err)
+		}
+		after, _ := os.ReadFile(path)
+
+		if string(before) != string(after) {
+			t.Errorf("rewriting identical routes changed the file:\n%s\n---\n%s", before, after)
+		}
+	})
+
+	t.Run("serialization is stable and unescaped", func(t *testing.T) {
+		path := writeSampleWSResources(t)
+		data, _ := os.ReadFile(path)
+		content := string(data)
+
+		if !strings.HasSuffix(content, "}\n") {
+			t.Error("expected trailing newline")
+		}
+		if strings.Contains(content, `\u0026`) || strings.Contains(content, `\u003c`) {
+			t.Error("expected HTML characters to be written unescaped")
+		}
+		if strings.Index(content, `"/about/index.html": {`) > strings.Index(content, `"/css/app.abc123.css": {`) {
+			t.Error("expected header paths in sorted order")
+		}
+		if strings.Index(content, `"*": "/404.html"`) < strings.Index(content, `"/about": "/about/index.html"`) {
+			t.Error("expected catch-all route last")
+		}
+
+		cfg, err := ReadWSResourcesConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := MarshalWSResourcesConfig(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(again) != content {
+			t.Errorf("re-marshalling changed the output:\n%s\n---\n%s", content, again)
+		}
+	})
+}
+
+func TestMetadataOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    MetadataOptions
+		wantErr error
+	}{
+		{"empty", MetadataOptions{}, nil},
+		{"valid https image", MetadataOptions{ImageURL: "https://example.com/logo.png"}, nil},
+		{"valid http image", MetadataOptions{ImageURL: "http://example.com/logo.png"}, nil},
+		{"missing scheme", MetadataOptions{ImageURL: "example.com/logo.png"}, ErrInvalidImageURL},
+		{"non-http scheme", MetadataOptions{ImageURL: "ipfs://bafy/logo.png"}, ErrInvalidImageURL},
+		{"missing host", MetadataOptions{ImageURL: "https:///logo.png"}, ErrInvalidImageURL},
+		{"whitespace", MetadataOptions{ImageURL: "https://example.com/my logo.png"}, ErrInvalidImageURL},
+		{"known category", MetadataOptions{Category: "blog"}, nil},
+		{"known category any case", MetadataOptions{Category: "Portfolio"}, nil},
+		{"default category", MetadataOptions{Category: DefaultCategory}, nil},
+		{"unknown category", MetadataOptions{Category: "asdf"}, ErrUnknownCategory},
+		{"unknown category allowed", MetadataOptions{Category: "asdf", AllowCustomCategory: true}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateMetadataRejectsInvalidOptions(t *testing.T) {
+	path := writeSampleWSResources(t)
+	before, _ := os.ReadFile(path)
+
+	err := UpdateMetadata(path, MetadataOptions{ImageURL: "not a url", Description: "changed"})
+	if !errors.Is(err, ErrInvalidImageURL) {
+		t.Fatalf("UpdateMetadata() error = %v, want ErrInvalidImageURL", err)
+	}
+	if !strings.Contains(err.Error(), `"not a url"`) {
+		t.Errorf("error should quote the bad value, got %v", err)
+	}
+
+	after, _ := os.ReadFile(path)
+	if string(before) != string(after) {
+		t.Error("ws-resources.json must not change when validation fails")
+	}
+}
+
+func TestUpdateMetadataSkipsUnchangedFile(t *testing.T) {
+	// Hand-formatted: any rewrite would reformat it
+	content := `{"site_name": "My Site", "object_id": "0xabc",
+  "metadata": {"description": "Same", "link": "` + DefaultLink + `", "project_url": "` + DefaultProjectURL + `",
+    "image_url": "` + DefaultImageURL + `", "creator": "` + DefaultCreator + `", "category": "blog"}}
+`
+	path := filepath.Join(t.TempDir(), "ws-resources.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := MetadataOptions{ObjectID: "0xabc", SiteName: "My Site", Description: "Same", Category: "blog"}
+	if err := UpdateMetadata(path, opts); err != nil {
+		t.Fatalf("UpdateMetadata failed: %v", err)
+	}
+	if err := UpdateObjectID(path, "0xabc"); err != nil {
+		t.Fatalf("UpdateObjectID failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Errorf("unchanged metadata rewrote the file:\n%s", data)
+	}
+
+	opts.Description = "Different"
+	if err := UpdateMetadata(path, opts); err != nil {
+		t.Fatalf("UpdateMetadata failed: %v", err)
+	}
+	cfg, err := ReadWSResourcesConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Metadata.Description != "Different" {
+		t.Errorf("description = %q, want the changed value written", cfg.Metadata.Description)
+	}
+}
+
+// writeMultilingualPublic creates a Hugo publish dir with English as the
+// default language served under /en/ and Spanish under /es/.
+func writeMultilingualPublic(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, file := range []string{
+		"index.html", // Hugo's redirect to /en/
+		"404.html",
+		"en/index.html",
+		"en/about/index.html",
+		"en/posts/hello/index.html",
+		"es/index.html",
+		"es/about/index.html",
+		"docs/index.html",
+		"en/docs/index.html",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("<html></html>"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGenerateRoutesFromPublicWithOptions_DefaultLanguage(t *testing.T) {
+	dir := writeMultilingualPublic(t)
+
+	routes, err := GenerateRoutesFromPublicWithOptions(dir, RouteOptions{
+		DefaultLanguage: "en",
+		ExplicitRoutes:  map[string]string{"/about": "/es/about/index.html"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateRoutesFromPublicWithOptions failed: %v", err)
+	}
+
+	want := map[string]string{
+		"/":              "/en/index.html",
+		"/index.html":    "/en/index.html",
+		"/posts/hello":   "/en/posts/hello/index.html",
+		"/posts/hello/*": "/en/posts/hello/index.html",
+		"/about/*":       "/en/about/index.html",
+		// Explicit routes win over aliases
+		"/about": "/es/about/index.html",
+		// A page that exists at the root is never shadowed
+		"/docs": "/docs/index.html",
+		// Prefixed routes keep working
+		"/en/about": "/en/about/index.html",
+		"/es/about": "/es/about/index.html",
+		"*":         "/404.html",
+	}
+	for pattern, target := range want {
+		if routes[pattern] != target {
+			t.Errorf("routes[%q] = %q, want %q", pattern, routes[pattern], target)
+		}
+	}
+}
+
+func TestGenerateRoutesFromPublicWithOptions_NoDefaultLanguage(t *testing.T) {
+	dir := writeMultilingualPublic(t)
+
+	routes, err := GenerateRoutesFromPublicWithOptions(dir, RouteOptions{})
+	if err != nil {
+		t.Fatalf("GenerateRoutesFromPublicWithOptions failed: %v", err)
+	}
+	if routes["/"] != "/index.html" {
+		t.Errorf(`routes["/"] = %q, want /index.html`, routes["/"])
+	}
+	if _, ok := routes["/posts/hello"]; ok {
+		t.Error("expected no root-relative aliases without a default language")
+	}
+
+	plain, err := GenerateRoutesFromPublic(dir)
+	if err != nil {
+		t.Fatalf("GenerateRoutesFromPublic failed: %v", err)
+	}
+	if len(plain) != len(routes) {
+		t.Errorf("GenerateRoutesFromPublic returned %d routes, want %d", len(plain), len(routes))
+	}
+}
+
+func TestGenerateRoutesFromPublicWithOptions_InvalidLanguage(t *testing.T) {
+	dir := writeMultilingualPublic(t)
+
+	for _, lang := range []string{"fr", "../en", "e"} {
+		if _, err := GenerateRoutesFromPublicWithOptions(dir, RouteOptions{DefaultLanguage: lang}); err == nil {
+			t.Errorf("DefaultLanguage %q: expected error", lang)
+		}
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/selimozten/walgo/internal/sui"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
@@ -58,7 +60,11 @@ func LoadConfig() (*WalgoConfig, error) {
 	}
 
 	var cfg WalgoConfig
-	if err := viper.Unmarshal(&cfg); err != nil {
+	if err := viper.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		expandEnvHook,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	))); err != nil {
 		return nil, fmt.Errorf("error unmarshaling configuration from %s: %w. Please check the file format and structure", viper.ConfigFileUsed(), err)
 	}
 
@@ -75,9 +81,50 @@ func LoadConfig() (*WalgoConfig, error) {
 		cfg.WalrusConfig.Entrypoint = "index.html"
 	}
 
+	if err := cfg.WalrusConfig.Gateway.Validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.WalrusConfig.Deploy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateEnvs(cfg.Envs); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
+// expandEnvHook interpolates ${NAME} references in string config values.
+func expandEnvHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String {
+		return data, nil
+	}
+	return ExpandEnvRefs(data.(string)), nil
+}
+
+// expandEnvNodes interpolates ${NAME} references in the scalar values under
+// n. Mapping keys are left alone. An expanded plain scalar is resolved again,
+// so "epochs: ${EPOCHS}" still decodes as a number.
+func expandEnvNodes(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if envRefPattern.MatchString(n.Value) {
+			n.Value = ExpandEnvRefs(n.Value)
+			if n.Style == 0 {
+				n.Tag = ""
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(n.Content); i += 2 {
+			expandEnvNodes(n.Content[i])
+		}
+	default:
+		for _, child := range n.Content {
+			expandEnvNodes(child)
+		}
+	}
+}
+
 // LoadConfigFrom reads and parses the Walgo configuration from a specific directory.
 // Useful when configuration needs to be loaded from a path different from the current working directory.
 func LoadConfigFrom(sitePath string) (*WalgoConfig, error) {
@@ -94,10 +141,19 @@ func LoadConfigFrom(sitePath string) (*WalgoConfig, error) {
 		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
 	}
 
-	var cfg WalgoConfig
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	// Expand ${NAME} references in the parsed values, not the raw text, so a
+	// variable's value cannot add keys
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
 		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
 	}
+	var cfg WalgoConfig
+	if doc.Kind != 0 {
+		expandEnvNodes(&doc)
+		if err := doc.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+		}
+	}
 
 	// Apply defaults
 	if cfg.HugoConfig.PublishDir == "" {
@@ -113,6 +169,16 @@ func LoadConfigFrom(sitePath string) (*WalgoConfig, error) {
 		cfg.WalrusConfig.Entrypoint = "index.html"
 	}
 
+	if err := cfg.WalrusConfig.Gateway.Validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.WalrusConfig.Deploy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateEnvs(cfg.Envs); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
@@ -606,3 +606,24 @@ futureFeature:
 		}
 	})
 }
+
+func TestUpdateWalgoYAMLAddsMissingField(t *testing.T) {
+	sitePath := t.TempDir()
+	initial := "# site settings\nhugo:\n    publishDir: public\n"
+	if err := os.WriteFile(filepath.Join(sitePath, "walgo.yaml"), []byte(initial), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UpdateWalgoYAMLProjectID(sitePath, "0xabc"); err != nil {
+		t.Fatalf("UpdateWalgoYAMLProjectID() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(sitePath, "walgo.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A hex-looking ID is quoted so it is not read back as a number
+	want := initial + "walrus:\n    projectID: \"0xabc\"\n"
+	if string(data) != want {
+		t.Errorf("walgo.yaml =\n%s\nwant\n%s", data, want)
+	}
+}
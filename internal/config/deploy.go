@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Defaults for HTTP uploads when walrus.deploy sets nothing for a network.
+const (
+	DefaultDeployConcurrency = 10
+	DefaultDeployRetries     = 5
+)
+
+// ForNetwork returns the upload settings for network ("mainnet" or
+// "testnet"; anything else is treated as testnet) with unset fields filled
+// from the defaults.
+func (d DeployConfig) ForNetwork(network string) NetworkDeployConfig {
+	settings := d.Testnet
+	if strings.EqualFold(strings.TrimSpace(network), "mainnet") {
+		settings = d.Mainnet
+	}
+	if settings.Concurrency <= 0 {
+		settings.Concurrency = DefaultDeployConcurrency
+	}
+	if settings.Retries <= 0 {
+		settings.Retries = DefaultDeployRetries
+	}
+	if settings.RateLimit < 0 {
+		settings.RateLimit = 0
+	}
+	return settings
+}
+
+// Validate rejects negative settings.
+func (d DeployConfig) Validate() error {
+	for _, n := range []struct {
+		name     string
+		settings NetworkDeployConfig
+	}{
+		{"testnet", d.Testnet},
+		{"mainnet", d.Mainnet},
+	} {
+		if n.settings.Concurrency < 0 {
+			return fmt.Errorf("invalid walrus.deploy.%s.concurrency %d: must not be negative", n.name, n.settings.Concurrency)
+		}
+		if n.settings.Retries < 0 {
+			return fmt.Errorf("invalid walrus.deploy.%s.retries %d: must not be negative", n.name, n.settings.Retries)
+		}
+		if n.settings.RateLimit < 0 {
+			return fmt.Errorf("invalid walrus.deploy.%s.rateLimit %g: must not be negative", n.name, n.settings.RateLimit)
+		}
+	}
+	return nil
+}
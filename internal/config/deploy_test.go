@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDeployConfigForNetwork(t *testing.T) {
+	cfg := DeployConfig{
+		Testnet: NetworkDeployConfig{Concurrency: 16, RateLimit: 0},
+		Mainnet: NetworkDeployConfig{Concurrency: 3, Retries: 8, RateLimit: 2.5},
+	}
+
+	tests := []struct {
+		name    string
+		cfg     DeployConfig
+		network string
+		want    NetworkDeployConfig
+	}{
+		{"testnet settings", cfg, "testnet", NetworkDeployConfig{Concurrency: 16, Retries: DefaultDeployRetries}},
+		{"mainnet settings", cfg, "mainnet", NetworkDeployConfig{Concurrency: 3, Retries: 8, RateLimit: 2.5}},
+		{"network is case-insensitive", cfg, " Mainnet ", NetworkDeployConfig{Concurrency: 3, Retries: 8, RateLimit: 2.5}},
+		{"empty network is testnet", cfg, "", NetworkDeployConfig{Concurrency: 16, Retries: DefaultDeployRetries}},
+		{"nothing configured", DeployConfig{}, "mainnet", NetworkDeployConfig{Concurrency: DefaultDeployConcurrency, Retries: DefaultDeployRetries}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.ForNetwork(tt.network); got != tt.want {
+				t.Errorf("ForNetwork(%q) = %+v, want %+v", tt.network, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeployConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     DeployConfig
+		wantErr string
+	}{
+		{"empty", DeployConfig{}, ""},
+		{"valid", DeployConfig{Mainnet: NetworkDeployConfig{Concurrency: 4, Retries: 3, RateLimit: 1}}, ""},
+		{"negative concurrency", DeployConfig{Testnet: NetworkDeployConfig{Concurrency: -1}}, "walrus.deploy.testnet.concurrency"},
+		{"negative retries", DeployConfig{Mainnet: NetworkDeployConfig{Retries: -2}}, "walrus.deploy.mainnet.retries"},
+		{"negative rate limit", DeployConfig{Mainnet: NetworkDeployConfig{RateLimit: -0.5}}, "walrus.deploy.mainnet.rateLimit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want mention of %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFromDeploy(t *testing.T) {
+	dir := t.TempDir()
+	content := `walrus:
+  network: mainnet
+  deploy:
+    testnet:
+      concurrency: 12
+    mainnet:
+      concurrency: 4
+      retries: 6
+      rateLimit: 2
+`
+	if err := os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfigFrom(dir)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom() error = %v", err)
+	}
+	got := cfg.WalrusConfig.Deploy.ForNetwork(cfg.WalrusConfig.Network)
+	want := NetworkDeployConfig{Concurrency: 4, Retries: 6, RateLimit: 2}
+	if got != want {
+		t.Errorf("mainnet settings = %+v, want %+v", got, want)
+	}
+
+	bad := "walrus:\n  deploy:\n    mainnet:\n      concurrency: -3\n"
+	if err := os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte(bad), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfigFrom(dir); err == nil {
+		t.Error("expected error for negative concurrency")
+	}
+}
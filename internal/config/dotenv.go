@@ -0,0 +1,132 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// EnvFileName is the optional per-site secrets file loaded at startup. It is
+// never uploaded with the site.
+const EnvFileName = ".walgo.env"
+
+// envKeyPattern matches valid environment variable names.
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// envRefPattern matches ${NAME} references used for interpolation.
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// EnvLineError describes a line in an env file that could not be parsed.
+type EnvLineError struct {
+	Line   int
+	Reason string
+}
+
+// EnvFileError reports all malformed lines found in an env file. Valid lines
+// are still loaded when this error is returned.
+type EnvFileError struct {
+	Path  string
+	Lines []EnvLineError
+}
+
+func (e *EnvFileError) Error() string {
+	parts := make([]string, 0, len(e.Lines))
+	for _, l := range e.Lines {
+		parts = append(parts, fmt.Sprintf("line %d: %s", l.Line, l.Reason))
+	}
+	return fmt.Sprintf("malformed lines in %s: %s", e.Path, strings.Join(parts, "; "))
+}
+
+// ParseEnvFile parses KEY=value lines. Blank lines and lines starting with #
+// are skipped, an optional "export " prefix is allowed, and values may be
+// wrapped in single or double quotes.
+func ParseEnvFile(data []byte, path string) (map[string]string, error) {
+	values := make(map[string]string)
+	var bad []EnvLineError
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			bad = append(bad, EnvLineError{Line: lineNum, Reason: "missing '='"})
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if !envKeyPattern.MatchString(key) {
+			bad = append(bad, EnvLineError{Line: lineNum, Reason: fmt.Sprintf("invalid variable name %q", key)})
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+			if value[len(value)-1] != value[0] {
+				bad = append(bad, EnvLineError{Line: lineNum, Reason: "unterminated quoted value"})
+				continue
+			}
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return values, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if len(bad) > 0 {
+		return values, &EnvFileError{Path: path, Lines: bad}
+	}
+	return values, nil
+}
+
+// LoadEnvFile reads .walgo.env from sitePath and sets each variable that is
+// not already present in the process environment, so real environment values
+// always win. It returns the names that were set. A missing file is not an
+// error; malformed lines are reported as an *EnvFileError after the valid
+// lines have been applied.
+func LoadEnvFile(sitePath string) ([]string, error) {
+	path := filepath.Join(sitePath, EnvFileName)
+	// #nosec G304 - path is the fixed env file name inside the site directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	values, parseErr := ParseEnvFile(data, path)
+
+	var loaded []string
+	for key, value := range values {
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return loaded, fmt.Errorf("failed to set %s: %w", key, err)
+		}
+		loaded = append(loaded, key)
+	}
+	sort.Strings(loaded)
+	return loaded, parseErr
+}
+
+// ExpandEnvRefs replaces ${NAME} references with the value of the environment
+// variable NAME. Unset variables expand to an empty string. Bare $NAME is left
+// untouched so literal dollar signs in config values survive.
+func ExpandEnvRefs(s string) string {
+	return envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		return os.Getenv(ref[2 : len(ref)-1])
+	})
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func writeEnvFile(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, EnvFileName), []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write env file: %v", err)
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	writeEnvFile(t, dir, `# walgo secrets
+WALGO_TEST_OBJECT_ID=0xabc123
+
+export WALGO_TEST_API_KEY="sk-test key"
+WALGO_TEST_SINGLE='quoted'
+WALGO_TEST_EMPTY=
+`)
+	for _, key := range []string{"WALGO_TEST_OBJECT_ID", "WALGO_TEST_API_KEY", "WALGO_TEST_SINGLE", "WALGO_TEST_EMPTY"} {
+		key := key
+		t.Cleanup(func() { os.Unsetenv(key) })
+	}
+
+	loaded, err := LoadEnvFile(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"WALGO_TEST_API_KEY", "WALGO_TEST_EMPTY", "WALGO_TEST_OBJECT_ID", "WALGO_TEST_SINGLE"}
+	if !reflect.DeepEqual(loaded, want) {
+		t.Errorf("loaded = %v, want %v", loaded, want)
+	}
+
+	expected := map[string]string{
+		"WALGO_TEST_OBJECT_ID": "0xabc123",
+		"WALGO_TEST_API_KEY":   "sk-test key",
+		"WALGO_TEST_SINGLE":    "quoted",
+		"WALGO_TEST_EMPTY":     "",
+	}
+	for key, value := range expected {
+		if got, ok := os.LookupEnv(key); !ok || got != value {
+			t.Errorf("%s = %q (set: %v), want %q", key, got, ok, value)
+		}
+	}
+}
+
+func TestLoadEnvFileMissing(t *testing.T) {
+	loaded, err := LoadEnvFile(t.TempDir())
+	if err != nil {
+		t.Fatalf("missing file should not be an error, got %v", err)
+	}
+	if len(loaded) != 0 {
+		t.Errorf("expected nothing loaded, got %v", loaded)
+	}
+}
+
+func TestLoadEnvFileRealEnvWins(t *testing.T) {
+	dir := t.TempDir()
+	writeEnvFile(t, dir, "WALGO_TEST_PRECEDENCE=from-file\nWALGO_TEST_FILE_ONLY=file\n")
+	t.Setenv("WALGO_TEST_PRECEDENCE", "from-env")
+	t.Cleanup(func() { os.Unsetenv("WALGO_TEST_FILE_ONLY") })
+
+	loaded, err := LoadEnvFile(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, []string{"WALGO_TEST_FILE_ONLY"}) {
+		t.Errorf("loaded = %v, want only WALGO_TEST_FILE_ONLY", loaded)
+	}
+	if got := os.Getenv("WALGO_TEST_PRECEDENCE"); got != "from-env" {
+		t.Errorf("real env should win, got %q", got)
+	}
+}
+
+func TestLoadEnvFileMalformedLines(t *testing.T) {
+	dir := t.TempDir()
+	writeEnvFile(t, dir, `WALGO_TEST_GOOD=ok
+this line has no equals
+1BAD=value
+WALGO_TEST_QUOTE="unterminated
+`)
+	t.Cleanup(func() { os.Unsetenv("WALGO_TEST_GOOD") })
+
+	_, err := LoadEnvFile(dir)
+	var envErr *EnvFileError
+	if !errors.As(err, &envErr) {
+		t.Fatalf("expected *EnvFileError, got %v", err)
+	}
+
+	var lines []int
+	for _, l := range envErr.Lines {
+		lines = append(lines, l.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{2, 3, 4}) {
+		t.Errorf("malformed lines = %v, want [2 3 4]", lines)
+	}
+	if got := os.Getenv("WALGO_TEST_GOOD"); got != "ok" {
+		t.Errorf("valid lines should still load, got %q", got)
+	}
+}
+
+func TestExpandEnvRefs(t *testing.T) {
+	t.Setenv("WALGO_TEST_REF", "0xfeed")
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"${WALGO_TEST_REF}", "0xfeed"},
+		{"id-${WALGO_TEST_REF}-end", "id-0xfeed-end"},
+		{"${WALGO_TEST_UNSET_REF}", ""},
+		{"$WALGO_TEST_REF", "$WALGO_TEST_REF"},
+		{"price $5", "price $5"},
+	}
+	for _, tt := range tests {
+		if got := ExpandEnvRefs(tt.in); got != tt.want {
+			t.Errorf("ExpandEnvRefs(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigFromInterpolatesEnv(t *testing.T) {
+	dir := t.TempDir()
+	writeEnvFile(t, dir, "WALGO_TEST_CFG_OBJECT_ID=0x42\n")
+	t.Cleanup(func() { os.Unsetenv("WALGO_TEST_CFG_OBJECT_ID") })
+
+	cfgYAML := "walrus:\n  projectID: ${WALGO_TEST_CFG_OBJECT_ID}\n"
+	if err := os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte(cfgYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadEnvFile(dir); err != nil {
+		t.Fatalf("LoadEnvFile failed: %v", err)
+	}
+	cfg, err := LoadConfigFrom(dir)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom failed: %v", err)
+	}
+	if cfg.WalrusConfig.ProjectID != "0x42" {
+		t.Errorf("ProjectID = %q, want 0x42", cfg.WalrusConfig.ProjectID)
+	}
+}
+
+func TestLoadConfigFromExpandsParsedValues(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("WALGO_TEST_CFG_INJECT", "0x42\n  network: mainnet")
+	t.Setenv("WALGO_TEST_CFG_MAX_PATH", "120")
+
+	cfgYAML := "walrus:\n  projectID: ${WALGO_TEST_CFG_INJECT}\n  network: testnet\ncompress:\n  maxPathLength: ${WALGO_TEST_CFG_MAX_PATH}\n"
+	if err := os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte(cfgYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfigFrom(dir)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom failed: %v", err)
+	}
+	if cfg.WalrusConfig.ProjectID != "0x42\n  network: mainnet" {
+		t.Errorf("ProjectID = %q, want the variable's value verbatim", cfg.WalrusConfig.ProjectID)
+	}
+	if cfg.WalrusConfig.Network != "testnet" {
+		t.Errorf("Network = %q, want testnet: a variable must not add keys", cfg.WalrusConfig.Network)
+	}
+	if cfg.CompressConfig.MaxPathLength != 120 {
+		t.Errorf("MaxPathLength = %d, want 120", cfg.CompressConfig.MaxPathLength)
+	}
+}
+
+func TestLoadConfigInterpolatesEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("WALGO_TEST_VIPER_OBJECT_ID", "0x99")
+
+	configPath := filepath.Join(dir, DefaultConfigFileName)
+	cfgYAML := "walrus:\n  projectID: ${WALGO_TEST_VIPER_OBJECT_ID}\n  network: testnet\n"
+	if err := os.WriteFile(configPath, []byte(cfgYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("ReadInConfig failed: %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.WalrusConfig.ProjectID != "0x99" {
+		t.Errorf("ProjectID = %q, want 0x99", cfg.WalrusConfig.ProjectID)
+	}
+	if cfg.WalrusConfig.Network != "testnet" {
+		t.Errorf("Network = %q, want testnet", cfg.WalrusConfig.Network)
+	}
+}
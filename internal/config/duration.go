@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Day is the unit human storage durations are built from.
+const Day = 24 * time.Hour
+
+// ErrEpochsClamped is wrapped by DurationToEpochs when a duration needs more
+// than MaxEpochs epochs. The returned count is MaxEpochs and still usable;
+// callers report the error as a warning.
+var ErrEpochsClamped = errors.New("storage duration exceeds the maximum")
+
+var (
+	durationPartPattern = regexp.MustCompile(`(\d+)\s*([a-z]+)`)
+	durationUnits       = map[string]time.Duration{
+		"d": Day, "day": Day, "days": Day,
+		"w": 7 * Day, "wk": 7 * Day, "week": 7 * Day, "weeks": 7 * Day,
+		"mo": 30 * Day, "month": 30 * Day, "months": 30 * Day,
+		"y": 365 * Day, "yr": 365 * Day, "year": 365 * Day, "years": 365 * Day,
+	}
+)
+
+// EpochLength returns the approximate wall-clock length of one epoch: two
+// weeks on mainnet and one day on testnet.
+func EpochLength(network string) time.Duration {
+	if network == "mainnet" {
+		return 14 * Day
+	}
+	return Day
+}
+
+// ParseHumanDuration parses a storage duration such as "30d", "2w", "6mo",
+// "1y" or "1y6mo". A month counts as 30 days and a year as 365.
+func ParseHumanDuration(s string) (time.Duration, error) {
+	input := strings.ToLower(strings.TrimSpace(s))
+	if input == "" {
+		return 0, fmt.Errorf("duration cannot be empty")
+	}
+
+	var total time.Duration
+	rest := input
+	for _, m := range durationPartPattern.FindAllStringSubmatch(input, -1) {
+		unit, ok := durationUnits[m[2]]
+		if !ok {
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q (use d, w, mo or y)", s, m[2])
+		}
+		n, err := strconv.Atoi(m[1])
+		if err != nil || n > 100*365 {
+			return 0, fmt.Errorf("invalid duration %q: %s is too large", s, m[1])
+		}
+		total += time.Duration(n) * unit
+		rest = strings.Replace(rest, m[0], "", 1)
+	}
+	if strings.TrimSpace(rest) != "" {
+		return 0, fmt.Errorf("invalid duration %q: want a number and unit, e.g. 30d, 6mo or 1y", s)
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: must be longer than zero", s)
+	}
+	return total, nil
+}
+
+// DurationToEpochs converts a human duration such as "6mo" into the number
+// of epochs on network that covers it, rounding up. A duration needing more
+// than MaxEpochs returns MaxEpochs with an error wrapping ErrEpochsClamped.
+func DurationToEpochs(d string, network string) (int, error) {
+	duration, err := ParseHumanDuration(d)
+	if err != nil {
+		return 0, err
+	}
+
+	epochLength := EpochLength(network)
+	epochs := int(math.Ceil(float64(duration) / float64(epochLength)))
+	if epochs < 1 {
+		epochs = 1
+	}
+	if epochs > MaxEpochs {
+		return MaxEpochs, fmt.Errorf("%w: %s is %d epochs of %s; using the maximum of %d (about %d days)",
+			ErrEpochsClamped, d, epochs, formatEpochLength(epochLength), MaxEpochs, MaxEpochs*int(epochLength/Day))
+	}
+	return epochs, nil
+}
+
+// formatEpochLength renders an epoch length as "1 day" or "14 days".
+func formatEpochLength(d time.Duration) string {
+	days := int(d / Day)
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
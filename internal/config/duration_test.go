@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseHumanDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30d":      30 * Day,
+		"2w":       14 * Day,
+		"6mo":      180 * Day,
+		"1y":       365 * Day,
+		"1y6mo":    545 * Day,
+		"6 months": 180 * Day,
+		" 1 Year ": 365 * Day,
+	}
+	for in, want := range tests {
+		if got, err := ParseHumanDuration(in); err != nil || got != want {
+			t.Errorf("ParseHumanDuration(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+
+	for _, bad := range []string{"", "6", "mo", "6m", "6mo extra", "-6mo", "0d", "1.5y"} {
+		if _, err := ParseHumanDuration(bad); err == nil {
+			t.Errorf("ParseHumanDuration(%q) should fail", bad)
+		}
+	}
+}
+
+func TestDurationToEpochs(t *testing.T) {
+	tests := []struct {
+		duration string
+		network  string
+		want     int
+	}{
+		// Testnet epochs are one day
+		{"1d", "testnet", 1},
+		{"2w", "testnet", 14},
+		{"30d", "testnet", 30},
+		{"1mo", "testnet", 30},
+		// Mainnet epochs are two weeks; partial epochs round up
+		{"1d", "mainnet", 1},
+		{"2w", "mainnet", 1},
+		{"30d", "mainnet", 3},
+		{"6mo", "mainnet", 13},
+		{"1y", "mainnet", 27},
+		{"2y", "mainnet", 53},
+		// An unknown network counts epochs like testnet
+		{"2w", "", 14},
+	}
+	for _, tt := range tests {
+		got, err := DurationToEpochs(tt.duration, tt.network)
+		if err != nil || got != tt.want {
+			t.Errorf("DurationToEpochs(%q, %q) = %d, %v, want %d", tt.duration, tt.network, got, err, tt.want)
+		}
+	}
+}
+
+func TestDurationToEpochs_ClampsAtMax(t *testing.T) {
+	tests := []struct {
+		duration string
+		network  string
+	}{
+		{"54d", "testnet"},
+		{"6mo", "testnet"},
+		{"1y", "testnet"},
+		{"3y", "mainnet"},
+	}
+	for _, tt := range tests {
+		got, err := DurationToEpochs(tt.duration, tt.network)
+		if got != MaxEpochs || !errors.Is(err, ErrEpochsClamped) {
+			t.Errorf("DurationToEpochs(%q, %q) = %d, %v, want %d with ErrEpochsClamped", tt.duration, tt.network, got, err, MaxEpochs)
+		}
+	}
+
+	// Exactly the maximum is not clamped
+	if got, err := DurationToEpochs("53d", "testnet"); got != MaxEpochs || err != nil {
+		t.Errorf("DurationToEpochs(53d, testnet) = %d, %v, want %d without an error", got, err, MaxEpochs)
+	}
+}
+
+func TestDurationToEpochs_Invalid(t *testing.T) {
+	if _, err := DurationToEpochs("soon", "mainnet"); err == nil || errors.Is(err, ErrEpochsClamped) {
+		t.Errorf("DurationToEpochs(soon) error = %v, want a parse error", err)
+	}
+}
+
+func TestEpochLength(t *testing.T) {
+	if got := EpochLength("mainnet"); got != 14*Day {
+		t.Errorf("EpochLength(mainnet) = %v", got)
+	}
+	if got := EpochLength("testnet"); got != Day {
+		t.Errorf("EpochLength(testnet) = %v", got)
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// DeployEnv returns the deploy preset called name, or an error listing the
+// presets walgo.yaml defines.
+func (c *WalgoConfig) DeployEnv(name string) (DeployEnv, error) {
+	if env, ok := c.Envs[name]; ok {
+		return env, nil
+	}
+	if len(c.Envs) == 0 {
+		return DeployEnv{}, fmt.Errorf("unknown env %q: walgo.yaml defines no envs", name)
+	}
+	names := make([]string, 0, len(c.Envs))
+	for n := range c.Envs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return DeployEnv{}, fmt.Errorf("unknown env %q: walgo.yaml defines %s", name, strings.Join(names, ", "))
+}
+
+// validateEnvs rejects presets with an unknown network or out-of-range
+// epochs, and dry-run presets that also canary or verify: deploy refuses
+// --canary with --dry-run, and a dry run has no site to verify.
+func validateEnvs(envs map[string]DeployEnv) error {
+	for name, env := range envs {
+		if env.Network != "" && !slices.Contains(Networks, env.Network) {
+			return fmt.Errorf("invalid envs.%s.network %q: must be one of %s", name, env.Network, strings.Join(Networks, ", "))
+		}
+		if env.Epochs < 0 || env.Epochs > MaxEpochs {
+			return fmt.Errorf("invalid envs.%s.epochs %d: must be between 1 and %d", name, env.Epochs, MaxEpochs)
+		}
+		if env.DryRun && env.Canary {
+			return fmt.Errorf("invalid envs.%s: dryRun cannot be combined with canary", name)
+		}
+		if env.DryRun && env.Verify {
+			return fmt.Errorf("invalid envs.%s: dryRun cannot be combined with verify, a dry run deploys nothing to verify", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigFromEnvs(t *testing.T) {
+	dir := t.TempDir()
+	content := `walrus:
+  network: testnet
+envs:
+  testnet-preview:
+    network: testnet
+    epochs: 1
+    verify: true
+  release:
+    network: mainnet
+    epochs: 26
+    canary: true
+`
+	if err := os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfigFrom(dir)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom() error = %v", err)
+	}
+	env, err := cfg.DeployEnv("testnet-preview")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env != (DeployEnv{Network: "testnet", Epochs: 1, Verify: true}) {
+		t.Errorf("testnet-preview = %+v", env)
+	}
+	if env, _ := cfg.DeployEnv("release"); env != (DeployEnv{Network: "mainnet", Epochs: 26, Canary: true}) {
+		t.Errorf("release = %+v", env)
+	}
+
+	_, err = cfg.DeployEnv("staging")
+	if err == nil || !strings.Contains(err.Error(), "release, testnet-preview") {
+		t.Errorf("unknown env error = %v, want the defined envs listed", err)
+	}
+	if _, err := (&WalgoConfig{}).DeployEnv("staging"); err == nil || !strings.Contains(err.Error(), "defines no envs") {
+		t.Errorf("DeployEnv without envs error = %v", err)
+	}
+}
+
+func TestValidateEnvs(t *testing.T) {
+	tests := []struct {
+		name    string
+		envs    map[string]DeployEnv
+		wantErr string
+	}{
+		{"none", nil, ""},
+		{"valid", map[string]DeployEnv{"preview": {Network: "testnet", Epochs: 2}}, ""},
+		{"unknown network", map[string]DeployEnv{"preview": {Network: "localnet"}}, "envs.preview.network"},
+		{"too many epochs", map[string]DeployEnv{"long": {Epochs: MaxEpochs + 1}}, "envs.long.epochs"},
+		{"negative epochs", map[string]DeployEnv{"neg": {Epochs: -1}}, "envs.neg.epochs"},
+		{"dry run", map[string]DeployEnv{"check": {Epochs: 1, DryRun: true}}, ""},
+		{"dry run canary", map[string]DeployEnv{"check": {DryRun: true, Canary: true}}, "envs.check: dryRun cannot be combined with canary"},
+		{"dry run verify", map[string]DeployEnv{"check": {DryRun: true, Verify: true}}, "envs.check: dryRun cannot be combined with verify"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEnvs(tt.envs)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateEnvs() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateEnvs() error = %v, want mention of %s", err, tt.wantErr)
+			}
+		})
+	}
+}
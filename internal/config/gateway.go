@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// IsZero reports whether no gateway overrides are configured.
+func (g GatewayConfig) IsZero() bool {
+	return g.SuiRPCURL == "" && g.AggregatorURL == "" && g.PublisherURL == ""
+}
+
+// Validate checks that every configured endpoint is an absolute http(s) URL.
+func (g GatewayConfig) Validate() error {
+	endpoints := []struct {
+		name  string
+		value string
+	}{
+		{"walrus.gateway.suiRPCURL", g.SuiRPCURL},
+		{"walrus.gateway.aggregatorURL", g.AggregatorURL},
+		{"walrus.gateway.publisherURL", g.PublisherURL},
+	}
+
+	for _, ep := range endpoints {
+		if ep.value == "" {
+			continue
+		}
+		if err := validateEndpointURL(ep.value); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", ep.name, ep.value, err)
+		}
+	}
+	return nil
+}
+
+// validateEndpointURL ensures raw is an absolute http or https URL with a host.
+func validateEndpointURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("missing host")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGatewayConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		gw      GatewayConfig
+		wantErr bool
+	}{
+		{"empty uses defaults", GatewayConfig{}, false},
+		{"all https", GatewayConfig{
+			SuiRPCURL:     "https://rpc.corp.example:443",
+			AggregatorURL: "https://aggregator.corp.example",
+			PublisherURL:  "http://publisher.corp.example:9000",
+		}, false},
+		{"bad scheme", GatewayConfig{SuiRPCURL: "ftp://rpc.corp.example"}, true},
+		{"missing host", GatewayConfig{AggregatorURL: "https://"}, true},
+		{"not a url", GatewayConfig{PublisherURL: "publisher.corp.example"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.gw.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFromGateway(t *testing.T) {
+	t.Run("valid overrides are loaded", func(t *testing.T) {
+		dir := t.TempDir()
+		content := `walrus:
+  projectID: test
+  gateway:
+    suiRPCURL: https://rpc.corp.example
+    aggregatorURL: https://aggregator.corp.example
+`
+		if err := os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := LoadConfigFrom(dir)
+		if err != nil {
+			t.Fatalf("LoadConfigFrom() error = %v", err)
+		}
+		gw := cfg.WalrusConfig.Gateway
+		if gw.SuiRPCURL != "https://rpc.corp.example" || gw.AggregatorURL != "https://aggregator.corp.example" {
+			t.Errorf("unexpected gateway: %+v", gw)
+		}
+		if gw.PublisherURL != "" {
+			t.Errorf("unset PublisherURL should stay empty, got %q", gw.PublisherURL)
+		}
+	})
+
+	t.Run("invalid override is rejected", func(t *testing.T) {
+		dir := t.TempDir()
+		content := `walrus:
+  gateway:
+    suiRPCURL: not-a-url
+`
+		if err := os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := LoadConfigFrom(dir); err == nil {
+			t.Error("expected error for invalid gateway URL")
+		}
+	})
+}
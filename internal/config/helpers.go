@@ -1,9 +1,11 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -11,38 +13,108 @@ import (
 // UpdateWalgoYAMLProjectID updates the projectID field in walgo.yaml
 // This function preserves the YAML structure and comments while updating specific field
 func UpdateWalgoYAMLProjectID(sitePath, objectID string) error {
-	// Read existing walgo.yaml
-	data, err := os.ReadFile(filepath.Join(sitePath, "walgo.yaml"))
+	return updateWalgoYAMLWalrusField(sitePath, "projectID", objectID)
+}
+
+// UpdateWalgoYAMLSuiNSDomain updates the suinsDomain field in walgo.yaml
+func UpdateWalgoYAMLSuiNSDomain(sitePath, domain string) error {
+	return updateWalgoYAMLWalrusField(sitePath, "suinsDomain", domain)
+}
+
+// UpdateWalgoYAMLNetwork updates the network field in walgo.yaml
+func UpdateWalgoYAMLNetwork(sitePath, network string) error {
+	return updateWalgoYAMLWalrusField(sitePath, "network", network)
+}
+
+// placeholderProjectID is the projectID new walgo.yaml files start with.
+const placeholderProjectID = "YOUR_WALRUS_PROJECT_ID"
+
+// IsPlaceholderProjectID reports whether id is unset: empty or the
+// placeholder new sites are created with.
+func IsPlaceholderProjectID(id string) bool {
+	id = strings.TrimSpace(id)
+	return id == "" || id == placeholderProjectID
+}
+
+// updateWalgoYAMLWalrusField sets walrus.<key> in walgo.yaml. The file is
+// edited as a YAML node tree, so other settings, their order and comments
+// are kept.
+func updateWalgoYAMLWalrusField(sitePath, key, value string) error {
+	path := filepath.Join(sitePath, "walgo.yaml")
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("failed to read walgo.yaml: %w", err)
 	}
 
-	// Parse YAML as a generic map to preserve comments and structure
-	var yamlMap map[string]interface{}
-	if err := yaml.Unmarshal(data, &yamlMap); err != nil {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
 		return fmt.Errorf("failed to parse walgo.yaml: %w", err)
 	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to parse walgo.yaml: top level is not a mapping")
+	}
 
-	// Navigate to walrus.projectID
-	walrusMap, ok := yamlMap["walrus"].(map[string]interface{})
-	if !ok {
-		walrusMap = make(map[string]interface{})
-		yamlMap["walrus"] = walrusMap
+	walrus := mappingValue(root, "walrus")
+	if walrus == nil || walrus.Kind != yaml.MappingNode {
+		if walrus != nil {
+			return fmt.Errorf("failed to parse walgo.yaml: walrus is not a mapping")
+		}
+		walrus = &yaml.Node{Kind: yaml.MappingNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "walrus"}, walrus)
 	}
 
-	// Update projectID
-	walrusMap["projectID"] = objectID
+	if node := mappingValue(walrus, key); node != nil && node.Kind == yaml.ScalarNode {
+		node.Value = value
+		node.Tag = "!!str"
+	} else if node != nil {
+		*node = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	} else {
+		walrus.Content = append(walrus.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+	}
 
-	// Marshal back to YAML
-	updatedData, err := yaml.Marshal(yamlMap)
-	if err != nil {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(yamlIndent(data))
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to marshal walgo.yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
 		return fmt.Errorf("failed to marshal walgo.yaml: %w", err)
 	}
 
-	// Write back to file
-	if err := os.WriteFile(filepath.Join(sitePath, "walgo.yaml"), updatedData, 0644); err != nil {
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
 		return fmt.Errorf("failed to write walgo.yaml: %w", err)
 	}
+	return nil
+}
 
+// mappingValue returns the value node of key in a mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
 	return nil
 }
+
+// yamlIndent guesses the indentation width of a YAML file from its first
+// indented key, defaulting to the 4 spaces yaml.Marshal writes.
+func yamlIndent(data []byte) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed == line || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+		if n := len(line) - len(trimmed); n >= 2 && n <= 8 {
+			return n
+		}
+	}
+	return 4
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/compress"
+)
+
+// SchemaDialect is the JSON Schema version JSONSchema describes walgo.yaml in.
+const SchemaDialect = "http://json-schema.org/draft-07/schema#"
+
+// schemaValueSets are the named value sets a schema:"enum=<name>" or
+// schema:"examples=<name>" tag refers to, so the schema follows the lists
+// walgo itself uses.
+var schemaValueSets = map[string]func() []string{
+	"networks":   func() []string { return Networks },
+	"categories": func() []string { return compress.KnownCategories },
+}
+
+// JSONSchema describes walgo.yaml as a JSON Schema, generated from WalgoConfig
+// so it stays in sync with the fields walgo reads. Property names come from
+// the yaml struct tags; allowed values and ranges come from schema tags:
+//
+//	schema:"enum=networks"           allowed values: a named set from schemaValueSets
+//	schema:"enum=yaml|toml|json"     allowed values: a literal set
+//	schema:"examples=categories"     suggested values, any string is allowed
+//	schema:"minimum=1,maximum=53"    numeric bounds
+//
+// Unknown keys are rejected, matching what editors flag as typos.
+func JSONSchema() (map[string]any, error) {
+	schema, err := typeSchema(reflect.TypeOf(WalgoConfig{}))
+	if err != nil {
+		return nil, err
+	}
+	schema["$schema"] = SchemaDialect
+	schema["title"] = DefaultConfigFileName
+	schema["description"] = "Walgo site configuration"
+	return schema, nil
+}
+
+// typeSchema returns the schema of a Go type used in the config structs.
+func typeSchema(t reflect.Type) (map[string]any, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Slice:
+		items, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return nil, fmt.Errorf("unsupported config field type %s", t)
+	}
+}
+
+// structSchema returns an object schema with a property per yaml-tagged field.
+func structSchema(t reflect.Type) (map[string]any, error) {
+	properties := make(map[string]any)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		prop, err := typeSchema(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		if err := applySchemaTag(prop, field.Tag.Get("schema")); err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		properties[name] = prop
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}, nil
+}
+
+// applySchemaTag adds the constraints of a schema struct tag to prop.
+func applySchemaTag(prop map[string]any, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	for _, part := range strings.Split(tag, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return fmt.Errorf("invalid schema tag %q", part)
+		}
+		switch key {
+		case "enum", "examples":
+			values := strings.Split(value, "|")
+			if named, ok := schemaValueSets[value]; ok {
+				values = named()
+			}
+			prop[key] = values
+		case "minimum", "maximum":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid schema %s %q", key, value)
+			}
+			prop[key] = n
+		default:
+			return fmt.Errorf("unknown schema tag key %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaFor returns the JSON Schema as decoded JSON, the form editors see.
+func schemaFor(t *testing.T) map[string]any {
+	t.Helper()
+	schema, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("encoding schema: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("decoding schema: %v", err)
+	}
+	return decoded
+}
+
+// configDocument renders cfg as walgo.yaml and decodes it as JSON would be.
+func configDocument(t *testing.T, cfg WalgoConfig) map[string]any {
+	t.Helper()
+	data, err := yaml.Marshal(&cfg)
+	if err != nil {
+		t.Fatalf("marshaling config: %v", err)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("parsing config: %v", err)
+	}
+	return doc
+}
+
+// validate checks value against the subset of JSON Schema JSONSchema emits.
+func validate(schema map[string]any, value any, path string) []string {
+	var errs []string
+	fail := func(format string, args ...any) {
+		errs = append(errs, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			fail("expected object, got %T", value)
+			return errs
+		}
+		props, _ := schema["properties"].(map[string]any)
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if prop, ok := props[key].(map[string]any); ok {
+				errs = append(errs, validate(prop, obj[key], path+"."+key)...)
+				continue
+			}
+			switch extra := schema["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					fail("unknown property %q", key)
+				}
+			case map[string]any:
+				errs = append(errs, validate(extra, obj[key], path+"."+key)...)
+			}
+		}
+		return errs
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			fail("expected array, got %T", value)
+			return errs
+		}
+		for i, item := range items {
+			errs = append(errs, validate(schema["items"].(map[string]any), item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return errs
+	case "string":
+		if _, ok := value.(string); !ok {
+			fail("expected string, got %T", value)
+			return errs
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("expected boolean, got %T", value)
+			return errs
+		}
+	case "integer", "number":
+		var n float64
+		switch v := value.(type) {
+		case int:
+			n = float64(v)
+		case float64:
+			n = v
+			if schema["type"] == "integer" && v != float64(int64(v)) {
+				fail("expected integer, got %v", v)
+			}
+		default:
+			fail("expected %s, got %T", schema["type"], value)
+			return errs
+		}
+		if min, ok := schema["minimum"].(float64); ok && n < min {
+			fail("%v is less than the minimum %v", n, min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && n > max {
+			fail("%v is greater than the maximum %v", n, max)
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		for _, allowed := range enum {
+			if allowed == value {
+				return errs
+			}
+		}
+		fail("%v is not one of %v", value, enum)
+	}
+	return errs
+}
+
+func TestJSONSchemaValidatesDefaultConfig(t *testing.T) {
+	schema := schemaFor(t)
+	if schema["$schema"] != SchemaDialect {
+		t.Errorf("$schema = %v, want %s", schema["$schema"], SchemaDialect)
+	}
+
+	cfg := NewDefaultWalgoConfig()
+	cfg.WalrusConfig.Network = "mainnet"
+	cfg.WalrusConfig.Category = "Photography Portfolio"
+	cfg.Envs = map[string]DeployEnv{"prod": {Network: "mainnet", Epochs: 5}}
+	cfg.CompressConfig.CustomRoutes = map[string]string{"/old": "/new.html"}
+
+	for _, c := range []WalgoConfig{NewDefaultWalgoConfig(), cfg} {
+		if errs := validate(schema, configDocument(t, c), "walgo.yaml"); len(errs) > 0 {
+			t.Errorf("valid config rejected:\n%s", strings.Join(errs, "\n"))
+		}
+	}
+}
+
+func TestJSONSchemaRejectsInvalidConfig(t *testing.T) {
+	schema := schemaFor(t)
+
+	tests := []struct {
+		name   string
+		modify func(doc map[string]any)
+		want   string
+	}{
+		{
+			name: "epochs above maximum",
+			modify: func(doc map[string]any) {
+				doc["envs"] = map[string]any{"prod": map[string]any{"epochs": MaxEpochs + 1}}
+			},
+			want: "walgo.yaml.envs.prod.epochs: 54 is greater than the maximum 53",
+		},
+		{
+			name: "zero epochs",
+			modify: func(doc map[string]any) {
+				doc["envs"] = map[string]any{"prod": map[string]any{"epochs": 0}}
+			},
+			want: "walgo.yaml.envs.prod.epochs: 0 is less than the minimum 1",
+		},
+		{
+			name: "unknown network",
+			modify: func(doc map[string]any) {
+				doc["walrus"].(map[string]any)["network"] = "devnet"
+			},
+			want: "walgo.yaml.walrus.network: devnet is not one of",
+		},
+		{
+			name: "category not a string",
+			modify: func(doc map[string]any) {
+				doc["walrus"].(map[string]any)["category"] = 3
+			},
+			want: "walgo.yaml.walrus.category: expected string",
+		},
+		{
+			name: "misspelled key",
+			modify: func(doc map[string]any) {
+				doc["hugo"].(map[string]any)["publishdir"] = "dist"
+			},
+			want: `walgo.yaml.hugo: unknown property "publishdir"`,
+		},
+		{
+			name: "wrong type",
+			modify: func(doc map[string]any) {
+				doc["cache"].(map[string]any)["enabled"] = "yes"
+			},
+			want: "walgo.yaml.cache.enabled: expected boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := configDocument(t, NewDefaultWalgoConfig())
+			tt.modify(doc)
+			errs := validate(schema, doc, "walgo.yaml")
+			if len(errs) == 0 {
+				t.Fatal("invalid config accepted")
+			}
+			if !strings.Contains(strings.Join(errs, "\n"), tt.want) {
+				t.Errorf("errors = %v, want one containing %q", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONSchemaEnumsFollowSources(t *testing.T) {
+	schema, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	walrus := schema["properties"].(map[string]any)["walrus"].(map[string]any)["properties"].(map[string]any)
+
+	network := walrus["network"].(map[string]any)
+	if fmt.Sprint(network["enum"]) != fmt.Sprint(Networks) {
+		t.Errorf("network enum = %v, want %v", network["enum"], Networks)
+	}
+	category := walrus["category"].(map[string]any)
+	if _, ok := category["enum"]; ok {
+		t.Errorf("category should accept custom values, got enum %v", category["enum"])
+	}
+	if fmt.Sprint(category["examples"]) != fmt.Sprint(compress.KnownCategories) {
+		t.Errorf("category examples = %v, want %v", category["examples"], compress.KnownCategories)
+	}
+	if _, ok := walrus["epochs"]; ok {
+		t.Error("walrus.epochs is not a walgo.yaml key")
+	}
+}
+
+func TestApplySchemaTagErrors(t *testing.T) {
+	for _, tag := range []string{"minimum", "minimum=abc", "pattern=.*"} {
+		if err := applySchemaTag(map[string]any{}, tag); err == nil {
+			t.Errorf("applySchemaTag(%q) accepted an invalid tag", tag)
+		}
+	}
+}
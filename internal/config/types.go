@@ -2,6 +2,12 @@ package config
 
 import "github.com/selimozten/walgo/internal/optimizer"
 
+// Networks are the Walrus networks walrus.network accepts.
+var Networks = []string{"testnet", "mainnet"}
+
+// MaxEpochs is the longest storage period, in epochs, Walrus accepts.
+const MaxEpochs = 53
+
 // WalgoConfig is the top-level configuration for Walgo.
 // It will be stored in walgo.yaml in the site root.
 type WalgoConfig struct {
@@ -11,6 +17,8 @@ type WalgoConfig struct {
 	OptimizerConfig optimizer.OptimizerConfig `mapstructure:"optimizer" yaml:"optimizer,omitempty"`
 	CompressConfig  CompressConfig            `mapstructure:"compress" yaml:"compress,omitempty"`
 	CacheConfig     CacheConfig               `mapstructure:"cache" yaml:"cache,omitempty"`
+	// Envs are named walgo deploy presets, selected with --env
+	Envs map[string]DeployEnv `mapstructure:"envs" yaml:"envs,omitempty"`
 	// Note: AI credentials are stored in ~/.walgo/ai-credentials.yaml, not in walgo.yaml
 }
 
@@ -23,6 +31,13 @@ type HugoConfig struct {
 	PublishDir  string `mapstructure:"publishDir" yaml:"publishDir,omitempty"`   // Default: "public"
 	ContentDir  string `mapstructure:"contentDir" yaml:"contentDir,omitempty"`   // Default: "content"
 	ResourceDir string `mapstructure:"resourceDir" yaml:"resourceDir,omitempty"` // Default: "resources"
+
+	// BuildManifest is the file hashes checked by deploy --verify-build-manifest,
+	// relative to the site root. Default: build-manifest.json in the site root.
+	BuildManifest string `mapstructure:"buildManifest" yaml:"buildManifest,omitempty"`
+
+	// Reproducible makes every build byte-reproducible, as with --reproducible.
+	Reproducible bool `mapstructure:"reproducible" yaml:"reproducible,omitempty"`
 }
 
 // WalrusConfig holds settings for deploying to Walrus Sites.
@@ -34,33 +49,74 @@ type WalrusConfig struct {
 
 	// Network selection (testnet or mainnet)
 	// Gas budget is managed in ~/.config/walrus/sites-config.yaml
-	Network string `mapstructure:"network" yaml:"network,omitempty"` // Default: testnet
+	Network string `mapstructure:"network" yaml:"network,omitempty" schema:"enum=networks"` // Default: testnet
+
+	Duration string `mapstructure:"duration" yaml:"duration,omitempty"`                              // Storage period for walgo deploy instead of --epochs, e.g. "6mo"
+	Category string `mapstructure:"category" yaml:"category,omitempty" schema:"examples=categories"` // Project category, e.g. "blog"
+
+	// Gateway overrides the public endpoints (for proxies or private infrastructure)
+	Gateway GatewayConfig `mapstructure:"gateway" yaml:"gateway,omitempty"`
+
+	// Deploy holds per-network upload tuning for HTTP deploys
+	Deploy DeployConfig `mapstructure:"deploy" yaml:"deploy,omitempty"`
+}
+
+// GatewayConfig holds optional endpoint overrides. Empty fields use the network defaults.
+type GatewayConfig struct {
+	SuiRPCURL     string `mapstructure:"suiRPCURL" yaml:"suiRPCURL,omitempty"`         // Sui full node JSON-RPC endpoint
+	AggregatorURL string `mapstructure:"aggregatorURL" yaml:"aggregatorURL,omitempty"` // Walrus aggregator base URL
+	PublisherURL  string `mapstructure:"publisherURL" yaml:"publisherURL,omitempty"`   // Walrus publisher base URL
+}
+
+// DeployConfig holds upload settings per network. Unset fields use walgo's defaults.
+type DeployConfig struct {
+	Testnet NetworkDeployConfig `mapstructure:"testnet" yaml:"testnet,omitempty"`
+	Mainnet NetworkDeployConfig `mapstructure:"mainnet" yaml:"mainnet,omitempty"`
+}
+
+// NetworkDeployConfig tunes HTTP uploads for one network.
+type NetworkDeployConfig struct {
+	Concurrency int     `mapstructure:"concurrency" yaml:"concurrency,omitempty" schema:"minimum=0"` // Maximum concurrent uploads
+	Retries     int     `mapstructure:"retries" yaml:"retries,omitempty" schema:"minimum=0"`         // Maximum retries per file
+	RateLimit   float64 `mapstructure:"rateLimit" yaml:"rateLimit,omitempty" schema:"minimum=0"`     // New uploads started per second (0 = unlimited)
+}
+
+// DeployEnv is a named preset of walgo deploy flags. Set fields stand in for
+// the flags of the same name unless those are given on the command line.
+type DeployEnv struct {
+	Network string `mapstructure:"network" yaml:"network,omitempty" schema:"enum=networks"` // Network the active wallet must be on
+	Epochs  int    `mapstructure:"epochs" yaml:"epochs,omitempty" schema:"minimum=1,maximum=53"`
+	Canary  bool   `mapstructure:"canary" yaml:"canary,omitempty"`
+	DryRun  bool   `mapstructure:"dryRun" yaml:"dryRun,omitempty"`
+	Verify  bool   `mapstructure:"verify" yaml:"verify,omitempty"`
 }
 
 // ObsidianConfig holds settings for importing from Obsidian vaults.
 type ObsidianConfig struct {
-	VaultPath         string `mapstructure:"vaultPath" yaml:"vaultPath,omitempty"`         // Default Obsidian vault path
-	AttachmentDir     string `mapstructure:"attachmentDir" yaml:"attachmentDir,omitempty"` // Where to put attachments (relative to static/)
-	ConvertWikilinks  bool   `mapstructure:"convertWikilinks" yaml:"convertWikilinks"`     // Convert [[wikilinks]] to [markdown](links)
-	IncludeDrafts     bool   `mapstructure:"includeDrafts" yaml:"includeDrafts"`           // Include files marked as drafts
-	FrontmatterFormat string `mapstructure:"frontmatterFormat" yaml:"frontmatterFormat"`   // yaml, toml, json
-	LinkStyle         string `mapstructure:"linkStyle" yaml:"linkStyle,omitempty"`         // "markdown" (default) or "relref" - markdown avoids REF_NOT_FOUND errors
+	VaultPath         string `mapstructure:"vaultPath" yaml:"vaultPath,omitempty"`                                    // Default Obsidian vault path
+	AttachmentDir     string `mapstructure:"attachmentDir" yaml:"attachmentDir,omitempty"`                            // Where to put attachments (relative to static/)
+	ConvertWikilinks  bool   `mapstructure:"convertWikilinks" yaml:"convertWikilinks"`                                // Convert [[wikilinks]] to [markdown](links)
+	IncludeDrafts     bool   `mapstructure:"includeDrafts" yaml:"includeDrafts"`                                      // Include files marked as drafts
+	FrontmatterFormat string `mapstructure:"frontmatterFormat" yaml:"frontmatterFormat" schema:"enum=yaml|toml|json"` // yaml, toml, json
+	LinkStyle         string `mapstructure:"linkStyle" yaml:"linkStyle,omitempty" schema:"enum=markdown|relref"`      // "markdown" (default) or "relref" - markdown avoids REF_NOT_FOUND errors
 }
 
 // CompressConfig holds settings for Brotli compression
 type CompressConfig struct {
-	Enabled             bool              `mapstructure:"enabled" yaml:"enabled"`                         // Enable compression
-	Level               int               `mapstructure:"level" yaml:"level,omitempty"`                   // Brotli level 0-11, default: 6
-	GenerateWSResources bool              `mapstructure:"generateWSResources" yaml:"generateWSResources"` // Generate ws-resources.json
-	CustomRoutes        map[string]string `mapstructure:"customRoutes" yaml:"customRoutes,omitempty"`     // Custom route mappings for ws-resources.json
-	IgnorePatterns      []string          `mapstructure:"ignorePatterns" yaml:"ignorePatterns,omitempty"` // Additional files/folders to ignore during upload
+	Enabled             bool                         `mapstructure:"enabled" yaml:"enabled"`                                          // Enable compression
+	Level               int                          `mapstructure:"level" yaml:"level,omitempty" schema:"minimum=0,maximum=11"`      // Brotli level 0-11, default: 6
+	GenerateWSResources bool                         `mapstructure:"generateWSResources" yaml:"generateWSResources"`                  // Generate ws-resources.json
+	CustomRoutes        map[string]string            `mapstructure:"customRoutes" yaml:"customRoutes,omitempty"`                      // Custom route mappings for ws-resources.json
+	IgnorePatterns      []string                     `mapstructure:"ignorePatterns" yaml:"ignorePatterns,omitempty"`                  // Additional files/folders to ignore during upload
+	MaxPathLength       int                          `mapstructure:"maxPathLength" yaml:"maxPathLength,omitempty" schema:"minimum=0"` // Longest resource path deploys accept (default: 200)
+	DirHeaders          map[string]map[string]string `mapstructure:"dirHeaders" yaml:"dirHeaders,omitempty"`                          // Default headers per directory prefix, inherited by the files below it
 }
 
 // CacheConfig holds settings for caching and cache-control headers
 type CacheConfig struct {
-	Enabled         bool `mapstructure:"enabled" yaml:"enabled"`                           // Enable cache-control headers
-	ImmutableMaxAge int  `mapstructure:"immutableMaxAge" yaml:"immutableMaxAge,omitempty"` // Max-age for immutable assets (default: 31536000)
-	MutableMaxAge   int  `mapstructure:"mutableMaxAge" yaml:"mutableMaxAge,omitempty"`     // Max-age for HTML (default: 300)
+	Enabled         bool `mapstructure:"enabled" yaml:"enabled"`                                              // Enable cache-control headers
+	ImmutableMaxAge int  `mapstructure:"immutableMaxAge" yaml:"immutableMaxAge,omitempty" schema:"minimum=0"` // Max-age for immutable assets (default: 31536000)
+	MutableMaxAge   int  `mapstructure:"mutableMaxAge" yaml:"mutableMaxAge,omitempty" schema:"minimum=0"`     // Max-age for HTML (default: 300)
 }
 
 // NewDefaultWalgoConfig creates a WalgoConfig with sensible defaults.
@@ -71,7 +127,7 @@ func NewDefaultWalgoConfig() WalgoConfig {
 			ContentDir: "content",
 		},
 		WalrusConfig: WalrusConfig{
-			ProjectID:  "YOUR_WALRUS_PROJECT_ID", // User needs to fill this
+			ProjectID:  placeholderProjectID, // User needs to fill this
 			Entrypoint: "index.html",
 		},
 		ObsidianConfig: ObsidianConfig{
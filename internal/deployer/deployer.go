@@ -2,17 +2,130 @@ package deployer
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/selimozten/walgo/internal/config"
 )
 
+// ErrSiteNotFound is returned by Update when the target site object does not
+// exist on the active network.
+var ErrSiteNotFound = errors.New("site object not found")
+
+// OwnershipError is returned by Update when the target site object is not
+// owned by the active address, so site-builder would fail to modify it.
+type OwnershipError struct {
+	ObjectID      string
+	OwnerKind     string // AddressOwner, Shared, Immutable, ...
+	Owner         string // Owning address, if any
+	ActiveAddress string
+}
+
+func (e *OwnershipError) Error() string {
+	if e.Owner == "" || (e.OwnerKind != "AddressOwner" && e.OwnerKind != "ConsensusAddressOwner") {
+		return fmt.Sprintf("site object %s has owner type %s and cannot be updated by the active address %s",
+			e.ObjectID, e.OwnerKind, e.ActiveAddress)
+	}
+	return fmt.Sprintf("site object %s is owned by %s, not the active address %s (switch with: sui client switch --address %s)",
+		e.ObjectID, e.Owner, e.ActiveAddress, e.Owner)
+}
+
+// LinkedDomainError is returned by Destroy when a SuiNS domain still points at
+// the site, so destroying it would break the domain. Pass DestroyOptions.Force
+// to destroy anyway.
+type LinkedDomainError struct {
+	ObjectID string
+	Domains  []string
+}
+
+func (e *LinkedDomainError) Error() string {
+	return fmt.Sprintf("site object %s is linked to SuiNS domain %s; destroying it would break the domain (unlink it first or use --force)",
+		e.ObjectID, strings.Join(e.Domains, ", "))
+}
+
+// IncompatibleWalrusError is returned by Deploy and Update when site-builder
+// failed because the installed walrus CLI does not understand the commands
+// it sends: walrus prints its system information, as for `walrus info`, and
+// exits, leaving site-builder nothing to read.
+type IncompatibleWalrusError struct {
+	WalrusVersion      string // Output of walrus --version, if known
+	SiteBuilderVersion string // Output of site-builder --version, if known
+	Network            string // Network to install matching versions for; default mainnet
+	Err                error  // The site-builder failure
+}
+
+func (e *IncompatibleWalrusError) Error() string {
+	network := e.Network
+	if network == "" {
+		network = "mainnet"
+	}
+	walrusVersion, builderVersion := "walrus CLI", "site-builder"
+	if e.WalrusVersion != "" {
+		walrusVersion += " (" + e.WalrusVersion + ")"
+	}
+	if e.SiteBuilderVersion != "" {
+		builderVersion += " (" + e.SiteBuilderVersion + ")"
+	}
+	return fmt.Sprintf("%s is not compatible with %s\n\n"+
+		"walrus printed its system information, as 'walrus info' does, and exited\n"+
+		"instead of storing the site's files, so site-builder could not continue.\n"+
+		"This happens when walrus and site-builder come from different releases.\n\n"+
+		"Install matching versions:\n"+
+		"  suiup install walrus@%s\n"+
+		"  suiup install site-builder@%s\n\n"+
+		"  Or run: walgo setup-deps\n\n"+
+		"Technical error: %v", walrusVersion, builderVersion, network, network, e.Err)
+}
+
+func (e *IncompatibleWalrusError) Unwrap() error { return e.Err }
+
+// FileError is one file a deploy could not upload.
+type FileError struct {
+	Path string // Relative to the site directory, slash-separated
+	Err  error
+}
+
+// DeployError is returned by Deploy when some files failed to upload (HTTP
+// per-blob uploads). Uploaded holds the blob IDs of the files that made it,
+// so only Files need to be uploaded again.
+type DeployError struct {
+	Files    []FileError
+	Total    int               // Files the deploy tried to upload
+	Uploaded map[string]string // Relative path -> blobId of the files that succeeded
+}
+
+func (e *DeployError) Error() string {
+	msgs := make([]string, 0, len(e.Files))
+	for _, f := range e.Files {
+		msgs = append(msgs, fmt.Sprintf("  - %s: %v", f.Path, f.Err))
+	}
+	return fmt.Sprintf("failed to upload %d of %d files:\n%s", len(e.Files), e.Total, strings.Join(msgs, "\n"))
+}
+
+// FailedPaths returns the paths of the files that failed.
+func (e *DeployError) FailedPaths() []string {
+	paths := make([]string, len(e.Files))
+	for i, f := range e.Files {
+		paths[i] = f.Path
+	}
+	return paths
+}
+
 // Result captures the outcome of a deployment/update/status operation.
 type Result struct {
 	Success       bool
 	ObjectID      string            // For site objects (site-builder path)
 	BrowseURLs    []string          // Optional links returned by underlying tool
-	FileToBlobID  map[string]string // For HTTP per-blob uploads: relative path -> blobId
+	FileToBlobID  map[string]string // Relative path -> blobId, from HTTP per-blob uploads or site-builder results that list resources
 	QuiltPatches  map[string]string // For HTTP quilt uploads: identifier -> quiltPatchId
 	ResourceCount int               // For site-builder status: number of resources
+	DedupedFiles  int               // For HTTP per-blob uploads: duplicate files that reused another file's blob
+	DedupedBytes  int64             // Bytes not uploaded thanks to DedupedFiles
+	Concurrency   int               // For HTTP per-blob uploads: upload concurrency the pool settled on
+	StorageEpochs int               // Epochs the deploy tool reported storing for; 0 when not reported
+	CostWAL       float64           // WAL the deploy tool reported spending; 0 when not reported
+	GasSUI        float64           // SUI gas the deploy tool reported spending; 0 when not reported
 	Message       string
 }
 
@@ -23,13 +136,31 @@ type DeployOptions struct {
 	Verbose   bool
 	JSONLogs  bool
 	WalrusCfg config.WalrusConfig
+	// Deletable stores the site's blobs as deletable so their storage can be
+	// reclaimed early (site-builder path; the HTTP path ignores it)
+	Deletable bool
+	// CheckExtend makes an update also extend every blob it leaves unchanged
+	// to Epochs, renewing the whole site (site-builder path)
+	CheckExtend bool
+	// OutputLine, when set, receives the deploy tool's output live, one
+	// ANSI-free line at a time (site-builder path)
+	OutputLine func(line string)
 
 	// HTTP-specific
-	PublisherBaseURL  string // e.g., https://publisher.walrus-testnet.walrus.space
-	AggregatorBaseURL string // e.g., https://aggregator.walrus-testnet.walrus.space
-	Mode              string // "quilt" or "blobs"
-	Workers           int    // number of concurrent workers for blobs mode
-	MaxRetries        int    // per-file max retries
+	PublisherBaseURL  string   // e.g., https://publisher.walrus-testnet.walrus.space
+	AggregatorBaseURL string   // e.g., https://aggregator.walrus-testnet.walrus.space
+	Mode              string   // "quilt" or "blobs"
+	Workers           int      // maximum concurrent uploads for blobs mode (auto-tuned up to this)
+	MaxRetries        int      // per-file max retries
+	RateLimit         float64  // blobs mode: new uploads started per second (0 = unlimited)
+	Files             []string // blobs mode: upload only these paths, relative to the site directory (nil = every file)
+}
+
+// DestroyOptions configures destroy behavior.
+type DestroyOptions struct {
+	Network     string // Network the site lives on, for the on-chain SuiNS lookup
+	SuiNSDomain string // SuiNS domain recorded for the site in config or the project (optional)
+	Force       bool   // Destroy even if a SuiNS domain is linked to the site
 }
 
 // WalrusDeployer provides a common interface across deployment backends.
@@ -37,5 +168,5 @@ type WalrusDeployer interface {
 	Deploy(ctx context.Context, siteDir string, opts DeployOptions) (*Result, error)
 	Update(ctx context.Context, siteDir string, objectID string, opts DeployOptions) (*Result, error)
 	Status(ctx context.Context, objectID string, opts DeployOptions) (*Result, error)
-	Destroy(ctx context.Context, objectID string) error
+	Destroy(ctx context.Context, objectID string, opts DestroyOptions) error
 }
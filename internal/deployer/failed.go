@@ -0,0 +1,93 @@
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// FailedFilesName is the default name of the failed-files manifest.
+const FailedFilesName = "failed-files.json"
+
+// FailedFile is one entry of a failed-files manifest.
+type FailedFile struct {
+	Path  string `json:"path"`
+	Error string `json:"error,omitempty"`
+}
+
+// FailedFilesManifest records the files a partially failed deploy could not
+// upload, so a later run can retry just those.
+type FailedFilesManifest struct {
+	CreatedAt time.Time    `json:"created_at"`
+	SiteDir   string       `json:"site_dir"`
+	Epochs    int          `json:"epochs"`
+	Total     int          `json:"total"`
+	Files     []FailedFile `json:"files"`
+}
+
+// NewFailedFilesManifest builds the manifest for a partially failed deploy
+// of siteDir.
+func NewFailedFilesManifest(siteDir string, epochs int, deployErr *DeployError) *FailedFilesManifest {
+	m := &FailedFilesManifest{
+		CreatedAt: time.Now().UTC(),
+		SiteDir:   siteDir,
+		Epochs:    epochs,
+		Total:     deployErr.Total,
+	}
+	for _, f := range deployErr.Files {
+		entry := FailedFile{Path: f.Path}
+		if f.Err != nil {
+			entry.Error = f.Err.Error()
+		}
+		m.Files = append(m.Files, entry)
+	}
+	return m
+}
+
+// Paths returns the paths of the failed files.
+func (m *FailedFilesManifest) Paths() []string {
+	paths := make([]string, len(m.Files))
+	for i, f := range m.Files {
+		paths[i] = f.Path
+	}
+	return paths
+}
+
+// WriteFailedFiles writes m to filePath as indented JSON.
+func WriteFailedFiles(filePath string, m *FailedFilesManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode failed-files manifest: %w", err)
+	}
+	if err := os.WriteFile(filePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write failed-files manifest: %w", err)
+	}
+	return nil
+}
+
+// ReadFailedFiles reads a manifest written by WriteFailedFiles. It fails when
+// the manifest lists no files or a path that leaves the site directory.
+func ReadFailedFiles(filePath string) (*FailedFilesManifest, error) {
+	// #nosec G304 - manifest path is provided by the user
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read failed-files manifest: %w", err)
+	}
+	var m FailedFilesManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse failed-files manifest %s: %w", filePath, err)
+	}
+	if len(m.Files) == 0 {
+		return nil, fmt.Errorf("failed-files manifest %s lists no files", filePath)
+	}
+	for _, f := range m.Files {
+		clean := path.Clean(f.Path)
+		if f.Path == "" || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return nil, fmt.Errorf("failed-files manifest %s has an invalid path %q", filePath, f.Path)
+		}
+	}
+	return &m, nil
+}
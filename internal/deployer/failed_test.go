@@ -0,0 +1,49 @@
+package deployer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFailedFilesManifestRoundTrip(t *testing.T) {
+	deployErr := &DeployError{
+		Files: []FileError{{Path: "a.css", Err: errors.New("publisher responded 500")}, {Path: "img/b.png"}},
+		Total: 10,
+	}
+	path := filepath.Join(t.TempDir(), FailedFilesName)
+	if err := WriteFailedFiles(path, NewFailedFilesManifest("/site/public", 3, deployErr)); err != nil {
+		t.Fatalf("WriteFailedFiles() error = %v", err)
+	}
+
+	m, err := ReadFailedFiles(path)
+	if err != nil {
+		t.Fatalf("ReadFailedFiles() error = %v", err)
+	}
+	if got := strings.Join(m.Paths(), ","); got != "a.css,img/b.png" {
+		t.Errorf("paths = %s", got)
+	}
+	if m.Epochs != 3 || m.Total != 10 || m.SiteDir != "/site/public" || m.Files[0].Error != "publisher responded 500" {
+		t.Errorf("manifest = %+v", m)
+	}
+}
+
+func TestReadFailedFilesRejectsBadManifests(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"empty":    `{"files": []}`,
+		"escaping": `{"files": [{"path": "../secret"}]}`,
+		"absolute": `{"files": [{"path": "/etc/passwd"}]}`,
+		"invalid":  `not json`,
+	} {
+		path := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadFailedFiles(path); err == nil {
+			t.Errorf("%s manifest: expected an error", name)
+		}
+	}
+}
@@ -10,10 +10,13 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/deployer"
 )
 
@@ -24,6 +27,9 @@ func New() *Adapter { return &Adapter{} }
 // Deploy supports two modes:
 // - quilt: single multipart PUT to /v1/quilts
 // - blobs: per-file PUTs to /v1/blobs using a worker pool with retries
+//
+// In blobs mode the pool starts small and tunes its concurrency up to Workers
+// from observed upload latency and errors.
 func (a *Adapter) Deploy(ctx context.Context, siteDir string, opts deployer.DeployOptions) (*deployer.Result, error) {
 	workers := opts.Workers
 	if workers <= 0 {
@@ -35,9 +41,68 @@ func (a *Adapter) Deploy(ctx context.Context, siteDir string, opts deployer.Depl
 	}
 
 	if strings.ToLower(opts.Mode) == "blobs" {
-		return a.deployBlobs(ctx, siteDir, opts.PublisherBaseURL, opts.Epochs, workers, maxRetries)
+		files, err := siteFilesFor(siteDir, opts.Files)
+		if err != nil {
+			return nil, err
+		}
+		return a.deployBlobs(ctx, siteDir, files, opts.PublisherBaseURL, opts.Epochs, workers, maxRetries, opts.RateLimit)
 	}
-	return a.deployQuilt(ctx, siteDir, opts.PublisherBaseURL, opts.Epochs)
+
+	files, err := listSiteFiles(siteDir)
+	if err != nil {
+		return nil, err
+	}
+	return a.deployQuilt(ctx, siteDir, files, opts.PublisherBaseURL, opts.Epochs)
+}
+
+// listSiteFiles returns the paths of all files under siteDir, relative to it.
+func listSiteFiles(siteDir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(siteDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(siteDir, path)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		if rel == config.EnvFileName {
+			return nil
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found in directory: %s", siteDir)
+	}
+	return files, nil
+}
+
+// siteFilesFor returns only, made OS-specific, after checking each exists
+// under siteDir; with no paths it lists every file in siteDir.
+func siteFilesFor(siteDir string, only []string) ([]string, error) {
+	if len(only) == 0 {
+		return listSiteFiles(siteDir)
+	}
+	files := make([]string, 0, len(only))
+	for _, p := range only {
+		rel := filepath.FromSlash(p)
+		info, err := os.Stat(filepath.Join(siteDir, rel))
+		if err != nil {
+			return nil, fmt.Errorf("file to upload not found: %s", p)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("file to upload is a directory: %s", p)
+		}
+		files = append(files, rel)
+	}
+	return files, nil
 }
 
 func (a *Adapter) Update(ctx context.Context, siteDir string, objectID string, opts deployer.DeployOptions) (*deployer.Result, error) {
@@ -97,7 +162,7 @@ func (a *Adapter) Status(ctx context.Context, objectID string, opts deployer.Dep
 	}, nil
 }
 
-func (a *Adapter) Destroy(ctx context.Context, objectID string) error {
+func (a *Adapter) Destroy(ctx context.Context, objectID string, opts deployer.DestroyOptions) error {
 	// HTTP deployment path does not support site destruction
 	// Files uploaded via HTTP cannot be deleted through the API
 	return fmt.Errorf("destroy operation not supported for HTTP deployment mode - files must be managed manually")
@@ -123,52 +188,37 @@ func calculateUploadTimeout(bodySize int) time.Duration {
 }
 
 // Quilt upload: single multipart request
-func (a *Adapter) deployQuilt(ctx context.Context, siteDir, publisher string, epochs int) (*deployer.Result, error) {
+func (a *Adapter) deployQuilt(ctx context.Context, siteDir string, files []string, publisher string, epochs int) (*deployer.Result, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found in directory: %s", siteDir)
+	}
+
 	var body bytes.Buffer
 	writer := multipart.NewWriter(&body)
-	var fileCount int
 
-	// Walk files and add to multipart
-	err := filepath.Walk(siteDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			return nil
-		}
-		rel, err := filepath.Rel(siteDir, path)
-		if err != nil {
-			return fmt.Errorf("failed to get relative path: %w", err)
-		}
+	// Add each file to the multipart body
+	for _, rel := range files {
+		path := filepath.Join(siteDir, rel)
 		field := strings.ReplaceAll(rel, string(os.PathSeparator), "__")
 		field = strings.ReplaceAll(field, " ", "_")
 
 		part, err := writer.CreateFormFile(field, filepath.Base(path))
 		if err != nil {
-			return err
+			return nil, err
 		}
-		// #nosec G304 - path comes from filepath.Walk which is already constrained to siteDir
+		// #nosec G304 - path is a file listed under siteDir
 		f, err := os.Open(filepath.Clean(path))
 		if err != nil {
-			return err
+			return nil, err
 		}
-		// Close immediately to prevent FD leak in Walk loop
+		// Close immediately to prevent FD leak in the loop
 		if _, copyErr := io.Copy(part, f); copyErr != nil {
 			f.Close()
-			return copyErr
+			return nil, copyErr
 		}
 		if closeErr := f.Close(); closeErr != nil {
-			return fmt.Errorf("failed to close file %s: %w", path, closeErr)
+			return nil, fmt.Errorf("failed to close file %s: %w", path, closeErr)
 		}
-		fileCount++
-		return nil
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	if fileCount == 0 {
-		return nil, fmt.Errorf("no files found in directory: %s", siteDir)
 	}
 
 	if err := writer.Close(); err != nil {
@@ -253,53 +303,45 @@ func parseQuiltResponse(respBytes []byte) (string, map[string]string, error) {
 	return quiltID, patches, nil
 }
 
-// uploadError tracks a failed file upload
-type uploadError struct {
-	file string
-	err  error
-}
+// Blobs upload: concurrent workers with exponential backoff.
+// Byte-identical files are uploaded once and share a blob ID. A positive
+// rateLimit caps how many files are handed to the workers per second. When
+// some files fail, a *deployer.DeployError lists them, duplicates included.
+func (a *Adapter) deployBlobs(ctx context.Context, siteDir string, paths []string, publisher string, epochs, workers, maxRetries int, rateLimit float64) (*deployer.Result, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files found in directory: %s", siteDir)
+	}
 
-// Blobs upload: concurrent workers with exponential backoff
-func (a *Adapter) deployBlobs(ctx context.Context, siteDir, publisher string, epochs, workers, maxRetries int) (*deployer.Result, error) {
-	type job struct{ rel, abs string }
-	files := make([]job, 0, 128)
-	if err := filepath.Walk(siteDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			return nil
-		}
-		rel, err := filepath.Rel(siteDir, path)
-		if err != nil {
-			return fmt.Errorf("failed to get relative path: %w", err)
-		}
-		files = append(files, job{rel: rel, abs: path})
-		return nil
-	}); err != nil {
-		return nil, err
+	dedupe, err := compress.FindDuplicates(siteDir, paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect duplicate files: %w", err)
 	}
 
-	if len(files) == 0 {
-		return nil, fmt.Errorf("no files found in directory: %s", siteDir)
+	type job struct{ rel, abs string }
+	files := make([]job, 0, len(dedupe.Unique))
+	for _, rel := range dedupe.Unique {
+		files = append(files, job{rel: rel, abs: filepath.Join(siteDir, rel)})
 	}
 
 	endpointBase := strings.TrimRight(publisher, "/") + "/v1/blobs?epochs=" + fmt.Sprint(epochs)
 	fileToBlob := make(map[string]string, len(files))
-	var uploadErrors []uploadError
+	var uploadErrors []deployer.FileError
 	var mu sync.Mutex
 	jobs := make(chan job)
 	wg := sync.WaitGroup{}
 
+	// workers is the ceiling; the controller decides how many upload at once.
+	ctrl := newConcurrencyController(initialConcurrency, workers)
+
 	workerFn := func() {
 		defer wg.Done()
 		for j := range jobs {
-			blobID, err := uploadWithRetry(ctx, endpointBase, j.abs, maxRetries)
+			blobID, err := uploadWithRetry(ctx, ctrl, endpointBase, j.abs, maxRetries)
 			mu.Lock()
 			if err != nil {
-				uploadErrors = append(uploadErrors, uploadError{file: j.rel, err: err})
+				uploadErrors = append(uploadErrors, deployer.FileError{Path: j.rel, Err: err})
 			} else if blobID == "" {
-				uploadErrors = append(uploadErrors, uploadError{file: j.rel, err: fmt.Errorf("empty blob ID returned")})
+				uploadErrors = append(uploadErrors, deployer.FileError{Path: j.rel, Err: fmt.Errorf("empty blob ID returned")})
 			} else {
 				fileToBlob[j.rel] = blobID
 			}
@@ -311,8 +353,24 @@ func (a *Adapter) deployBlobs(ctx context.Context, siteDir, publisher string, ep
 		wg.Add(1)
 		go workerFn()
 	}
+
+	// Rates above one file per nanosecond round the interval down to 0, which
+	// time.NewTicker rejects; they are as good as no limit.
+	var pace <-chan time.Time
+	if interval := time.Duration(float64(time.Second) / rateLimit); rateLimit > 0 && interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		pace = ticker.C
+	}
 send:
-	for _, f := range files {
+	for i, f := range files {
+		if pace != nil && i > 0 {
+			select {
+			case <-pace:
+			case <-ctx.Done():
+				break send
+			}
+		}
 		select {
 		case jobs <- f:
 		case <-ctx.Done():
@@ -331,24 +389,59 @@ send:
 	mu.Lock()
 	defer mu.Unlock()
 
+	dedupe.Expand(fileToBlob)
 	if len(uploadErrors) > 0 {
-		// Build error message with failed files
-		var errMsgs []string
-		for _, ue := range uploadErrors {
-			errMsgs = append(errMsgs, fmt.Sprintf("  - %s: %v", ue.file, ue.err))
+		return nil, newDeployError(uploadErrors, dedupe.Aliases, len(paths), fileToBlob)
+	}
+
+	return &deployer.Result{
+		Success:      true,
+		FileToBlobID: fileToBlob,
+		DedupedFiles: dedupe.DuplicateFiles,
+		DedupedBytes: dedupe.DuplicateBytes,
+		Concurrency:  ctrl.Limit(),
+	}, nil
+}
+
+// newDeployError reports failed uploads with slash-separated paths, adding
+// the duplicates that shared a failed file's blob, sorted by path.
+func newDeployError(failed []deployer.FileError, aliases map[string]string, total int, uploaded map[string]string) *deployer.DeployError {
+	errByPath := make(map[string]error, len(failed))
+	for _, f := range failed {
+		errByPath[f.Path] = f.Err
+	}
+	for dup, rel := range aliases {
+		if err, ok := errByPath[rel]; ok {
+			failed = append(failed, deployer.FileError{Path: dup, Err: err})
 		}
-		return nil, fmt.Errorf("failed to upload %d of %d files:\n%s",
-			len(uploadErrors), len(files), strings.Join(errMsgs, "\n"))
 	}
+	for i := range failed {
+		failed[i].Path = filepath.ToSlash(failed[i].Path)
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i].Path < failed[j].Path })
 
-	return &deployer.Result{Success: true, FileToBlobID: fileToBlob}, nil
+	done := make(map[string]string, len(uploaded))
+	for rel, id := range uploaded {
+		done[filepath.ToSlash(rel)] = id
+	}
+	return &deployer.DeployError{Files: failed, Total: total, Uploaded: done}
 }
 
-func uploadWithRetry(ctx context.Context, endpoint, filePath string, maxRetries int) (string, error) {
+// uploadWithRetry uploads one file, holding a slot from ctrl only while a
+// request is in flight and reporting each attempt's latency and outcome to it.
+func uploadWithRetry(ctx context.Context, ctrl *concurrencyController, endpoint, filePath string, maxRetries int) (string, error) {
 	backoff := 250 * time.Millisecond
 	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if err := ctrl.acquire(ctx); err != nil {
+			return "", err
+		}
+		start := time.Now()
 		blobID, status, err := putBlob(ctx, endpoint, filePath)
+		latency := time.Since(start)
+		ctrl.release()
+
 		if err == nil && blobID != "" {
+			ctrl.observe(latency, nil)
 			return blobID, nil
 		}
 		if ctx.Err() != nil {
@@ -359,6 +452,10 @@ func uploadWithRetry(ctx context.Context, endpoint, filePath string, maxRetries
 		if status > 0 && status < 500 && status != 429 {
 			return "", fmt.Errorf("non-retryable status %d: %v", status, err)
 		}
+		if err == nil {
+			err = fmt.Errorf("empty blob ID returned")
+		}
+		ctrl.observe(latency, err)
 		select {
 		case <-time.After(backoff + time.Duration(attempt)*50*time.Millisecond):
 			backoff *= 2
@@ -373,7 +470,7 @@ func uploadWithRetry(ctx context.Context, endpoint, filePath string, maxRetries
 }
 
 func putBlob(ctx context.Context, endpoint, filePath string) (string, int, error) {
-	// #nosec G304 - filePath comes from filepath.Walk which is already constrained to siteDir
+	// #nosec G304 - filePath is a file listed under siteDir
 	f, err := os.Open(filepath.Clean(filePath))
 	if err != nil {
 		return "", 0, err
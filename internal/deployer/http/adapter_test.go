@@ -3,11 +3,13 @@ package httpdeployer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -406,3 +408,256 @@ func dumpAttempts(m *sync.Map) map[string]int32 {
 	})
 	return out
 }
+
+// TestListSiteFiles_SkipsEnvFile verifies that a .walgo.env secrets file is never uploaded.
+func TestListSiteFiles_SkipsEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"index.html", ".walgo.env"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := listSiteFiles(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 1 || files[0] != "index.html" {
+		t.Errorf("expected only index.html, got %v", files)
+	}
+}
+
+func TestDeployBlobs_DedupesIdenticalFiles(t *testing.T) {
+	var puts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&puts, 1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"newlyCreated": map[string]any{
+				"blobObject": map[string]any{"blobId": "blob-" + string(body)},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	logo := []byte("shared-logo-bytes")
+	files := map[string][]byte{
+		"images/logo.png":      logo,
+		"blog/assets/logo.png": logo,
+		"index.html":           []byte("<h1>home</h1>"),
+		"about/index.html":     []byte("<h1>about</h1>"),
+	}
+	for rel, data := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := New().Deploy(context.Background(), dir, deployer.DeployOptions{
+		PublisherBaseURL: srv.URL,
+		Mode:             "blobs",
+		Workers:          2,
+		MaxRetries:       1,
+		Epochs:           1,
+	})
+	if err != nil {
+		t.Fatalf("deploy error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&puts); got != 3 {
+		t.Errorf("uploads = %d, want 3 (identical files uploaded once)", got)
+	}
+	if len(res.FileToBlobID) != 4 {
+		t.Fatalf("FileToBlobID has %d entries, want 4: %v", len(res.FileToBlobID), res.FileToBlobID)
+	}
+	a := res.FileToBlobID[filepath.Join("images", "logo.png")]
+	b := res.FileToBlobID[filepath.Join("blog", "assets", "logo.png")]
+	if a == "" || a != b {
+		t.Errorf("identical files should share a blob, got %q and %q", a, b)
+	}
+	if res.FileToBlobID["index.html"] == res.FileToBlobID[filepath.Join("about", "index.html")] {
+		t.Error("distinct files should not share a blob")
+	}
+	if res.Concurrency < 1 || res.Concurrency > 2 {
+		t.Errorf("Concurrency = %d, want within [1, 2]", res.Concurrency)
+	}
+	if res.DedupedFiles != 1 || res.DedupedBytes != int64(len(logo)) {
+		t.Errorf("dedupe savings = %d files / %d bytes, want 1 / %d", res.DedupedFiles, res.DedupedBytes, len(logo))
+	}
+}
+
+func TestDeployBlobs_RateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var starts []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		starts = append(starts, time.Now())
+		mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"newlyCreated": map[string]any{
+				"blobObject": map[string]any{"blobId": "blob-" + string(body)},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for i := 0; i < 4; i++ {
+		if err := os.WriteFile(filepath.Join(dir, "f"+strconv.Itoa(i)+".html"), []byte("file "+strconv.Itoa(i)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 20 uploads per second: four files need at least three 50ms gaps.
+	began := time.Now()
+	_, err := New().Deploy(context.Background(), dir, deployer.DeployOptions{
+		PublisherBaseURL: srv.URL,
+		Mode:             "blobs",
+		Workers:          4,
+		MaxRetries:       1,
+		Epochs:           1,
+		RateLimit:        20,
+	})
+	if err != nil {
+		t.Fatalf("deploy error: %v", err)
+	}
+	if elapsed := time.Since(began); elapsed < 150*time.Millisecond {
+		t.Errorf("rate-limited deploy took %s, want at least 150ms", elapsed)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(starts) != 4 {
+		t.Fatalf("uploads = %d, want 4", len(starts))
+	}
+}
+
+// TestDeployBlobs_HugeRateLimit checks that a rate too high to pace, whose
+// ticker interval rounds to zero, uploads without a limit instead of panicking.
+func TestDeployBlobs_HugeRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"newlyCreated": map[string]any{"blobObject": map[string]any{"blobId": "blob-ok"}},
+		})
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		if err := os.WriteFile(filepath.Join(dir, "f"+strconv.Itoa(i)+".html"), []byte("file "+strconv.Itoa(i)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := New().Deploy(context.Background(), dir, deployer.DeployOptions{
+		PublisherBaseURL: srv.URL,
+		Mode:             "blobs",
+		Workers:          2,
+		MaxRetries:       1,
+		Epochs:           1,
+		RateLimit:        2e9,
+	})
+	if err != nil {
+		t.Fatalf("deploy error: %v", err)
+	}
+	if len(res.FileToBlobID) != 2 {
+		t.Errorf("uploaded %d files, want 2", len(res.FileToBlobID))
+	}
+}
+
+// TestDeployBlobs_PartialFailureListsFiles checks the *deployer.DeployError
+// of a partial failure: the failed file and its duplicate are listed and the
+// uploaded file keeps its blob ID.
+func TestDeployBlobs_PartialFailureListsFiles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == "broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"newlyCreated": map[string]any{"blobObject": map[string]any{"blobId": "blob-ok"}},
+		})
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "img"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"index.html": "fine", "a.css": "broken", "img/b.css": "broken"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := New().Deploy(context.Background(), dir, deployer.DeployOptions{
+		PublisherBaseURL: srv.URL, Mode: "blobs", Workers: 1, MaxRetries: 1, Epochs: 1,
+	})
+	var deployErr *deployer.DeployError
+	if !errors.As(err, &deployErr) {
+		t.Fatalf("error = %v, want *deployer.DeployError", err)
+	}
+	if got := strings.Join(deployErr.FailedPaths(), ","); got != "a.css,img/b.css" {
+		t.Errorf("failed paths = %s, want a.css,img/b.css", got)
+	}
+	if deployErr.Total != 3 || deployErr.Uploaded["index.html"] != "blob-ok" || len(deployErr.Uploaded) != 1 {
+		t.Errorf("Total = %d, Uploaded = %v", deployErr.Total, deployErr.Uploaded)
+	}
+}
+
+// TestDeployBlobs_OnlyListedFiles checks that DeployOptions.Files limits the
+// upload to those files, as a retry of failed files needs.
+func TestDeployBlobs_OnlyListedFiles(t *testing.T) {
+	var mu sync.Mutex
+	var uploaded []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		uploaded = append(uploaded, string(body))
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"newlyCreated": map[string]any{"blobObject": map[string]any{"blobId": "blob-" + string(body)}},
+		})
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "css"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"index.html", "about.html", "css/site.css"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := New().Deploy(context.Background(), dir, deployer.DeployOptions{
+		PublisherBaseURL: srv.URL, Mode: "blobs", Workers: 2, MaxRetries: 1, Epochs: 1,
+		Files: []string{"css/site.css", "about.html"},
+	})
+	if err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	sort.Strings(uploaded)
+	if strings.Join(uploaded, ",") != "about.html,css/site.css" {
+		t.Errorf("uploaded %v, want only the listed files", uploaded)
+	}
+	if len(res.FileToBlobID) != 2 || res.FileToBlobID[filepath.FromSlash("css/site.css")] != "blob-css/site.css" {
+		t.Errorf("FileToBlobID = %v", res.FileToBlobID)
+	}
+
+	_, err = New().Deploy(context.Background(), dir, deployer.DeployOptions{
+		PublisherBaseURL: srv.URL, Mode: "blobs", Files: []string{"gone.html"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "gone.html") {
+		t.Errorf("missing listed file: error = %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package httpdeployer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// initialConcurrency is where the upload pool starts before tuning.
+	initialConcurrency = 2
+	// latencyFactor is how far above the latency baseline an upload must be
+	// to count as a congestion signal.
+	latencyFactor = 2
+	// latencyAlpha weights new samples in the latency baseline (EWMA).
+	latencyAlpha = 0.2
+	// errorStreakLimit consecutive transient errors drop the pool to its minimum.
+	errorStreakLimit = 3
+)
+
+// concurrencyController sizes the upload pool AIMD-style: the limit grows by
+// one after a full window of healthy uploads and halves on a transient error
+// or a latency spike. After a decrease, further decreases are ignored for one
+// window so a single burst of failures only counts once.
+type concurrencyController struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	min, max int
+	limit    int
+	inFlight int
+
+	successes int           // Healthy uploads since the last change
+	cooldown  int           // Observations left before another decrease
+	errStreak int           // Consecutive transient errors
+	baseline  time.Duration // EWMA of healthy upload latency
+}
+
+// newConcurrencyController returns a controller starting at start and
+// bounded to [1, max].
+func newConcurrencyController(start, max int) *concurrencyController {
+	if max < 1 {
+		max = 1
+	}
+	if start < 1 {
+		start = 1
+	}
+	if start > max {
+		start = max
+	}
+	c := &concurrencyController{min: 1, max: max, limit: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Limit returns the current concurrency limit.
+func (c *concurrencyController) Limit() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.limit
+}
+
+// acquire blocks until an upload slot is free under the current limit.
+func (c *concurrencyController) acquire(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		c.mu.Lock()
+		c.cond.Broadcast()
+		c.mu.Unlock()
+	})
+	defer stop()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.inFlight >= c.limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.cond.Wait()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.inFlight++
+	return nil
+}
+
+// release frees an upload slot.
+func (c *concurrencyController) release() {
+	c.mu.Lock()
+	c.inFlight--
+	c.cond.Broadcast()
+	c.mu.Unlock()
+}
+
+// observe records the outcome of one upload attempt. transientErr is non-nil
+// for failures worth retrying (timeouts, 5xx, 429); permanent failures say
+// nothing about congestion and should not be reported.
+func (c *concurrencyController) observe(latency time.Duration, transientErr error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cooldown > 0 {
+		c.cooldown--
+	}
+
+	if transientErr != nil {
+		c.errStreak++
+		if c.errStreak >= errorStreakLimit {
+			c.setLimit(c.min)
+			return
+		}
+		c.decrease()
+		return
+	}
+	c.errStreak = 0
+
+	spike := c.baseline > 0 && latency > latencyFactor*c.baseline
+	if c.baseline == 0 {
+		c.baseline = latency
+	} else {
+		c.baseline = time.Duration(latencyAlpha*float64(latency) + (1-latencyAlpha)*float64(c.baseline))
+	}
+	if spike {
+		c.decrease()
+		return
+	}
+
+	c.successes++
+	if c.successes >= c.limit && c.limit < c.max {
+		c.limit++
+		c.successes = 0
+		c.cond.Broadcast()
+	}
+}
+
+// decrease halves the limit unless a recent decrease is still cooling down.
+// Callers must hold c.mu.
+func (c *concurrencyController) decrease() {
+	if c.cooldown > 0 {
+		return
+	}
+	c.setLimit(c.limit / 2)
+}
+
+// setLimit lowers the limit to n (at least min) and starts a cooldown.
+// Callers must hold c.mu.
+func (c *concurrencyController) setLimit(n int) {
+	if n < c.min {
+		n = c.min
+	}
+	c.limit = n
+	c.successes = 0
+	c.cooldown = c.limit
+}
@@ -0,0 +1,188 @@
+package httpdeployer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// sample is one synthetic upload outcome fed to the controller.
+type sample struct {
+	latency time.Duration
+	err     error
+}
+
+func fast(n int) []sample {
+	s := make([]sample, n)
+	for i := range s {
+		s[i] = sample{latency: 100 * time.Millisecond}
+	}
+	return s
+}
+
+func seq(parts ...[]sample) []sample {
+	var out []sample
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+var (
+	errTransient = errors.New("publisher responded 503")
+	slow         = []sample{{latency: time.Second}}
+	failure      = []sample{{err: errTransient}}
+)
+
+func TestConcurrencyController(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     int
+		max       int
+		samples   []sample
+		wantLimit int
+	}{
+		{
+			name:      "healthy uploads grow additively",
+			start:     2,
+			max:       10,
+			samples:   fast(2 + 3), // 2 to reach 3, 3 more to reach 4
+			wantLimit: 4,
+		},
+		{
+			name:      "growth is capped at max",
+			start:     2,
+			max:       5,
+			samples:   fast(100),
+			wantLimit: 5,
+		},
+		{
+			name:      "latency spike halves the limit",
+			start:     8,
+			max:       8,
+			samples:   seq(fast(3), slow),
+			wantLimit: 4,
+		},
+		{
+			name:      "second spike within cooldown is ignored",
+			start:     8,
+			max:       8,
+			samples:   seq(fast(3), slow, slow),
+			wantLimit: 4,
+		},
+		{
+			name:      "spike after cooldown halves again",
+			start:     8,
+			max:       8,
+			samples:   seq(fast(3), slow, fast(3), slow),
+			wantLimit: 2,
+		},
+		{
+			name:      "transient error halves the limit",
+			start:     8,
+			max:       8,
+			samples:   failure,
+			wantLimit: 4,
+		},
+		{
+			name:      "repeated transient errors back off to the minimum",
+			start:     8,
+			max:       8,
+			samples:   seq(failure, failure, failure),
+			wantLimit: 1,
+		},
+		{
+			name:      "a success resets the error streak",
+			start:     8,
+			max:       8,
+			samples:   seq(failure, failure, fast(1), failure),
+			wantLimit: 4,
+		},
+		{
+			name:      "recovers after backing off",
+			start:     8,
+			max:       8,
+			samples:   seq(failure, failure, failure, fast(1+2+3)),
+			wantLimit: 4,
+		},
+		{
+			name:      "never drops below one",
+			start:     1,
+			max:       4,
+			samples:   seq(failure, failure, failure, failure, failure),
+			wantLimit: 1,
+		},
+		{
+			name:      "start is clamped to max",
+			start:     20,
+			max:       3,
+			wantLimit: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newConcurrencyController(tt.start, tt.max)
+			for _, s := range tt.samples {
+				c.observe(s.latency, s.err)
+				if l := c.Limit(); l < 1 || l > tt.max {
+					t.Fatalf("limit %d out of bounds [1, %d]", l, tt.max)
+				}
+			}
+			if got := c.Limit(); got != tt.wantLimit {
+				t.Errorf("Limit() = %d, want %d", got, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestConcurrencyController_AcquireHonorsLimit(t *testing.T) {
+	c := newConcurrencyController(1, 4)
+	ctx := context.Background()
+
+	if err := c.acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		if err := c.acquire(ctx); err == nil {
+			close(acquired)
+		}
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second acquire should block at limit 1")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	c.release()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("second acquire should proceed after release")
+	}
+}
+
+func TestConcurrencyController_AcquireCancelled(t *testing.T) {
+	c := newConcurrencyController(1, 1)
+	if err := c.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- c.acquire(ctx) }()
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("acquire() = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("acquire did not return after cancellation")
+	}
+}
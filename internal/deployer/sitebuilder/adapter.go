@@ -2,10 +2,35 @@ package sitebuilder
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/selimozten/walgo/internal/deployer"
+	"github.com/selimozten/walgo/internal/deps"
+	"github.com/selimozten/walgo/internal/sui"
 	"github.com/selimozten/walgo/internal/walrus"
 )
 
+// Lookups used to validate an update or destroy target; replaced in tests.
+var (
+	getObject        = sui.GetObject
+	activeAddress    = func(context.Context) (string, error) { return sui.GetActiveAddress() }
+	suinsNameTargets = walrus.SuiNSNameTargets
+	destroySite      = walrus.DestroySite
+	toolVersion      = deps.GetToolVersion
+)
+
+// walrusInfoSignatures are lines `walrus info` prints. When walrus does not
+// understand the command site-builder runs it with, it prints them instead
+// and exits, and site-builder fails on the unexpected output.
+var walrusInfoSignatures = []string{
+	"Walrus system information",
+	"Epochs and storage duration",
+	"Current epoch:",
+	"Price per encoded storage unit",
+}
+
 // Adapter implements deployer.WalrusDeployer via the site-builder CLI.
 type Adapter struct{}
 
@@ -13,37 +38,136 @@ func New() *Adapter { return &Adapter{} }
 
 func (a *Adapter) Deploy(ctx context.Context, siteDir string, opts deployer.DeployOptions) (*deployer.Result, error) {
 	walrus.SetVerbose(opts.Verbose)
-	out, err := walrus.DeploySite(ctx, siteDir, opts.WalrusCfg, opts.Epochs)
+	out, err := walrus.DeploySite(withOutput(ctx, opts), siteDir, opts.WalrusCfg, opts.Epochs, opts.Deletable)
 	if err != nil {
-		return nil, err
+		return nil, classifyDeployError(err, opts.WalrusCfg.Network)
 	}
 	return &deployer.Result{
-		Success:    out.Success,
-		ObjectID:   out.ObjectID,
-		BrowseURLs: out.BrowseURLs,
+		Success:       out.Success,
+		ObjectID:      out.ObjectID,
+		BrowseURLs:    out.BrowseURLs,
+		FileToBlobID:  out.FileToBlobID,
+		StorageEpochs: out.StorageEpochs,
+		CostWAL:       out.CostWAL,
+		GasSUI:        out.GasSUI,
 	}, nil
 }
 
+// Update updates the site object objectID, which may be any site the active
+// address owns rather than the latest one recorded. The object is checked
+// first so a missing or foreign site fails with deployer.ErrSiteNotFound or a
+// *deployer.OwnershipError instead of a nested site-builder error.
 func (a *Adapter) Update(ctx context.Context, siteDir string, objectID string, opts deployer.DeployOptions) (*deployer.Result, error) {
-	out, err := walrus.UpdateSite(ctx, siteDir, objectID, opts.Epochs)
-	if err != nil {
+	if err := verifyUpdateTarget(sui.WithRPCURL(ctx, opts.WalrusCfg.Gateway.SuiRPCURL), objectID); err != nil {
 		return nil, err
 	}
+
+	update := walrus.UpdateSiteWithConfig
+	if opts.CheckExtend {
+		update = walrus.ExtendSiteWithConfig
+	}
+	out, err := update(withOutput(ctx, opts), siteDir, objectID, opts.Epochs, opts.WalrusCfg, opts.Deletable)
+	if err != nil {
+		return nil, classifyDeployError(err, opts.WalrusCfg.Network)
+	}
 	return &deployer.Result{
-		Success:    out.Success,
-		ObjectID:   objectID,
-		BrowseURLs: out.BrowseURLs,
+		Success:       out.Success,
+		ObjectID:      objectID,
+		BrowseURLs:    out.BrowseURLs,
+		FileToBlobID:  out.FileToBlobID,
+		StorageEpochs: out.StorageEpochs,
+		CostWAL:       out.CostWAL,
+		GasSUI:        out.GasSUI,
 	}, nil
 }
 
-func (a *Adapter) Destroy(ctx context.Context, objectID string) error {
-
-	if err := walrus.DestroySite(ctx, objectID); err != nil {
+// classifyDeployError turns a site-builder failure whose output shows walrus
+// running `info` instead of the requested command into a
+// *deployer.IncompatibleWalrusError. Other errors are returned unchanged.
+func classifyDeployError(err error, network string) error {
+	if err == nil || !isWalrusInfoOutput(err.Error()) {
 		return err
 	}
+	incompatible := &deployer.IncompatibleWalrusError{Network: network, Err: err}
+	incompatible.WalrusVersion, _ = toolVersion("walrus")
+	incompatible.SiteBuilderVersion, _ = toolVersion("site-builder")
+	return incompatible
+}
+
+// isWalrusInfoOutput reports whether output contains what `walrus info`
+// prints: its header, or at least two of its other lines.
+func isWalrusInfoOutput(output string) bool {
+	matches := 0
+	for _, sig := range walrusInfoSignatures {
+		if strings.Contains(output, sig) {
+			matches++
+		}
+	}
+	return strings.Contains(output, walrusInfoSignatures[0]) || matches >= 2
+}
+
+// withOutput attaches opts.OutputLine to ctx so site-builder output is
+// streamed to it.
+func withOutput(ctx context.Context, opts deployer.DeployOptions) context.Context {
+	if opts.OutputLine == nil {
+		return ctx
+	}
+	return walrus.WithOutputHandler(ctx, opts.OutputLine)
+}
+
+// verifyUpdateTarget checks that objectID exists and is owned by the active address.
+func verifyUpdateTarget(ctx context.Context, objectID string) error {
+	obj, err := getObject(ctx, objectID)
+	if errors.Is(err, sui.ErrObjectNotFound) {
+		return fmt.Errorf("%w: %s does not exist on the active network", deployer.ErrSiteNotFound, objectID)
+	}
+	if err != nil {
+		return fmt.Errorf("could not look up site object %s: %w", objectID, err)
+	}
+
+	active, err := activeAddress(ctx)
+	if err != nil {
+		return fmt.Errorf("could not determine active address: %w", err)
+	}
+	if !obj.OwnedByAddress(active) {
+		return &deployer.OwnershipError{
+			ObjectID:      objectID,
+			OwnerKind:     obj.OwnerKind,
+			Owner:         obj.Owner,
+			ActiveAddress: active,
+		}
+	}
 	return nil
 }
 
+// Destroy deletes the site object objectID. Unless opts.Force is set, a site
+// that the SuiNS domain recorded in opts still resolves to is left alone and
+// a *deployer.LinkedDomainError is returned.
+func (a *Adapter) Destroy(ctx context.Context, objectID string, opts deployer.DestroyOptions) error {
+	if !opts.Force {
+		if domains := linkedDomains(ctx, objectID, opts); len(domains) > 0 {
+			return &deployer.LinkedDomainError{ObjectID: objectID, Domains: domains}
+		}
+	}
+
+	return destroySite(ctx, objectID)
+}
+
+// linkedDomains returns the recorded SuiNS domain when it still resolves to
+// objectID. SuiNS has no lookup from a site to the names pointing at it, so
+// only the recorded domain can be checked; if resolving it fails the domain
+// is assumed to be linked.
+func linkedDomains(ctx context.Context, objectID string, opts deployer.DestroyOptions) []string {
+	domain := strings.TrimSpace(opts.SuiNSDomain)
+	if domain == "" {
+		return nil
+	}
+	if linked, err := suinsNameTargets(ctx, opts.Network, domain, objectID); err == nil && !linked {
+		return nil
+	}
+	return []string{domain}
+}
+
 func (a *Adapter) Status(ctx context.Context, objectID string, opts deployer.DeployOptions) (*deployer.Result, error) {
 	out, err := walrus.GetSiteStatus(objectID)
 	if err != nil {
@@ -56,6 +180,7 @@ func (a *Adapter) Status(ctx context.Context, objectID string, opts deployer.Dep
 		Success:       out.Success,
 		ObjectID:      objectID,
 		BrowseURLs:    out.BrowseURLs,
+		FileToBlobID:  out.FileToBlobID,
 		ResourceCount: len(out.Resources),
 	}, nil
 }
@@ -2,9 +2,14 @@ package sitebuilder
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
 	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/deployer"
-	"testing"
+	"github.com/selimozten/walgo/internal/sui"
 )
 
 func TestNew(t *testing.T) {
@@ -392,3 +397,251 @@ func BenchmarkAdapter_Status(b *testing.B) {
 		_, _ = adapter.Status(ctx, "0x123", opts)
 	}
 }
+
+// stubUpdateTarget replaces the object and active-address lookups for one test.
+func stubUpdateTarget(t *testing.T, obj *sui.ObjectInfo, objErr error, active string, activeErr error) {
+	t.Helper()
+	origObject, origActive := getObject, activeAddress
+	t.Cleanup(func() { getObject, activeAddress = origObject, origActive })
+	getObject = func(context.Context, string) (*sui.ObjectInfo, error) { return obj, objErr }
+	activeAddress = func(context.Context) (string, error) { return active, activeErr }
+}
+
+func TestVerifyUpdateTarget(t *testing.T) {
+	const objectID = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	const me = "0xaaaa"
+
+	tests := []struct {
+		name      string
+		obj       *sui.ObjectInfo
+		objErr    error
+		activeErr error
+		check     func(t *testing.T, err error)
+	}{
+		{
+			name: "owned by active address",
+			obj:  &sui.ObjectInfo{ObjectID: objectID, OwnerKind: "AddressOwner", Owner: "0x000000AAAA"},
+			check: func(t *testing.T, err error) {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			},
+		},
+		{
+			name:   "object does not exist",
+			objErr: fmt.Errorf("%s: %w", objectID, sui.ErrObjectNotFound),
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, deployer.ErrSiteNotFound) {
+					t.Errorf("error = %v, want ErrSiteNotFound", err)
+				}
+			},
+		},
+		{
+			name: "owned by another address",
+			obj:  &sui.ObjectInfo{ObjectID: objectID, OwnerKind: "AddressOwner", Owner: "0xbbbb"},
+			check: func(t *testing.T, err error) {
+				var own *deployer.OwnershipError
+				if !errors.As(err, &own) {
+					t.Fatalf("error = %v, want *OwnershipError", err)
+				}
+				if own.Owner != "0xbbbb" || own.ActiveAddress != me {
+					t.Errorf("OwnershipError = %+v", own)
+				}
+				if !strings.Contains(err.Error(), "sui client switch --address 0xbbbb") {
+					t.Errorf("error should suggest switching: %v", err)
+				}
+			},
+		},
+		{
+			name: "shared object",
+			obj:  &sui.ObjectInfo{ObjectID: objectID, OwnerKind: "Shared"},
+			check: func(t *testing.T, err error) {
+				var own *deployer.OwnershipError
+				if !errors.As(err, &own) || !strings.Contains(err.Error(), "Shared") {
+					t.Errorf("error = %v, want ownership error naming Shared", err)
+				}
+			},
+		},
+		{
+			name:   "lookup failure is not reported as missing",
+			objErr: errors.New("rpc timeout"),
+			check: func(t *testing.T, err error) {
+				if err == nil || errors.Is(err, deployer.ErrSiteNotFound) || !strings.Contains(err.Error(), "rpc timeout") {
+					t.Errorf("error = %v, want wrapped lookup failure", err)
+				}
+			},
+		},
+		{
+			name:      "active address unavailable",
+			obj:       &sui.ObjectInfo{ObjectID: objectID, OwnerKind: "AddressOwner", Owner: me},
+			activeErr: errors.New("no sui config"),
+			check: func(t *testing.T, err error) {
+				if err == nil || !strings.Contains(err.Error(), "active address") {
+					t.Errorf("error = %v, want active address failure", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubUpdateTarget(t, tt.obj, tt.objErr, me, tt.activeErr)
+			tt.check(t, verifyUpdateTarget(context.Background(), objectID))
+		})
+	}
+}
+
+func TestAdapter_UpdateRejectsForeignSite(t *testing.T) {
+	stubUpdateTarget(t, &sui.ObjectInfo{OwnerKind: "AddressOwner", Owner: "0xbbbb"}, nil, "0xaaaa", nil)
+
+	_, err := New().Update(context.Background(), t.TempDir(), "0x1", deployer.DeployOptions{})
+	var own *deployer.OwnershipError
+	if !errors.As(err, &own) {
+		t.Errorf("Update() error = %v, want *OwnershipError before invoking site-builder", err)
+	}
+}
+
+// stubDestroy replaces the SuiNS resolution and site-builder destroy call.
+func stubDestroy(t *testing.T, targets bool, resolveErr error) (*[]string, *[]string) {
+	t.Helper()
+	origTargets, origDestroy := suinsNameTargets, destroySite
+	t.Cleanup(func() { suinsNameTargets, destroySite = origTargets, origDestroy })
+
+	var resolved, destroyed []string
+	suinsNameTargets = func(_ context.Context, _, name, _ string) (bool, error) {
+		resolved = append(resolved, name)
+		return targets, resolveErr
+	}
+	destroySite = func(_ context.Context, objectID string) error {
+		destroyed = append(destroyed, objectID)
+		return nil
+	}
+	return &resolved, &destroyed
+}
+
+func TestAdapter_DestroySuiNSGuard(t *testing.T) {
+	const objectID = "0x2222222222222222222222222222222222222222222222222222222222222222"
+
+	tests := []struct {
+		name        string
+		targets     bool
+		resolveErr  error
+		opts        deployer.DestroyOptions
+		wantDomains []string
+	}{
+		{"no recorded domain", true, nil, deployer.DestroyOptions{}, nil},
+		{"domain resolves to site", true, nil, deployer.DestroyOptions{SuiNSDomain: "mysite.sui"}, []string{"mysite.sui"}},
+		{"domain resolves elsewhere", false, nil, deployer.DestroyOptions{SuiNSDomain: "mysite.sui"}, nil},
+		{"resolve failure keeps guard", false, errors.New("rpc down"), deployer.DestroyOptions{SuiNSDomain: "mysite.sui"}, []string{"mysite.sui"}},
+		{"force skips guard", true, nil, deployer.DestroyOptions{SuiNSDomain: "mysite.sui", Force: true}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, destroyed := stubDestroy(t, tt.targets, tt.resolveErr)
+
+			err := New().Destroy(context.Background(), objectID, tt.opts)
+
+			if tt.opts.SuiNSDomain == "" && len(*resolved) != 0 {
+				t.Errorf("resolved %v without a recorded domain", *resolved)
+			}
+			if len(tt.wantDomains) == 0 {
+				if err != nil {
+					t.Fatalf("Destroy() error = %v", err)
+				}
+				if len(*destroyed) != 1 || (*destroyed)[0] != objectID {
+					t.Errorf("destroyed = %v, want [%s]", *destroyed, objectID)
+				}
+				return
+			}
+
+			var linked *deployer.LinkedDomainError
+			if !errors.As(err, &linked) {
+				t.Fatalf("Destroy() error = %v, want *LinkedDomainError", err)
+			}
+			if fmt.Sprint(linked.Domains) != fmt.Sprint(tt.wantDomains) || linked.ObjectID != objectID {
+				t.Errorf("LinkedDomainError = %+v, want domains %v", linked, tt.wantDomains)
+			}
+			if !strings.Contains(err.Error(), "--force") {
+				t.Errorf("error should mention --force: %v", err)
+			}
+			if len(*destroyed) != 0 {
+				t.Errorf("site-builder destroy ran despite linked domain: %v", *destroyed)
+			}
+		})
+	}
+}
+
+// walrusInfoFailure is a site-builder publish failure on mainnet where walrus
+// ran `info` instead of storing the site's files, as DeploySite reports it.
+const walrusInfoFailure = `deployment failed: exit status 1
+
+Command: /home/user/.local/bin/site-builder --context mainnet publish /home/user/blog/public --epochs 5
+Builder: /home/user/.local/bin/site-builder
+Walrus: /home/user/.local/bin/walrus
+Context: mainnet
+Stdout: Walrus system information
+
+Epochs and storage duration
+Current epoch: 14
+Start time: 2025-06-10 13:00:00.000 UTC
+End time: 2025-06-24 13:00:00.000 UTC
+Epoch duration: 14days
+Blobs can be stored for at most 53 epochs in the future.
+
+Storage nodes
+Number of storage nodes: 103
+Number of shards: 1000
+
+Storage prices per epoch
+(Conversion rate: 1 WAL = 1,000,000,000 FROST)
+Price per encoded storage unit: 0.0001 WAL
+Additional price for each write: 0.00002 WAL
+Stderr: Error: failed to parse the output of the Walrus binary
+
+Caused by:
+    expected value at line 1 column 1`
+
+func TestClassifyDeployError(t *testing.T) {
+	origVersion := toolVersion
+	t.Cleanup(func() { toolVersion = origVersion })
+	toolVersion = func(name string) (string, error) {
+		if name == "walrus" {
+			return "walrus 1.29.1", nil
+		}
+		return "", errors.New("not installed")
+	}
+
+	cause := errors.New(walrusInfoFailure)
+	err := classifyDeployError(cause, "mainnet")
+
+	var incompatible *deployer.IncompatibleWalrusError
+	if !errors.As(err, &incompatible) {
+		t.Fatalf("classifyDeployError() = %v, want *deployer.IncompatibleWalrusError", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("the site-builder failure should stay in the error chain")
+	}
+	if incompatible.WalrusVersion != "walrus 1.29.1" || incompatible.SiteBuilderVersion != "" {
+		t.Errorf("versions = %q, %q", incompatible.WalrusVersion, incompatible.SiteBuilderVersion)
+	}
+	for _, want := range []string{"walrus CLI (walrus 1.29.1) is not compatible with site-builder", "suiup install walrus@mainnet", "suiup install site-builder@mainnet"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q:\n%s", want, err)
+		}
+	}
+}
+
+func TestClassifyDeployErrorOtherFailures(t *testing.T) {
+	for _, cause := range []error{
+		errors.New("deployment failed: exit status 1\nStderr: Error: insufficient funds for gas"),
+		errors.New("update failed: context deadline exceeded"),
+	} {
+		if err := classifyDeployError(cause, "testnet"); err != cause {
+			t.Errorf("classifyDeployError(%q) = %v, want it unchanged", cause, err)
+		}
+	}
+	if err := classifyDeployError(nil, "testnet"); err != nil {
+		t.Errorf("classifyDeployError(nil) = %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package deployment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// CanaryStateFile holds the latest canary deploy, under .walgo in the site root.
+const CanaryStateFile = "canary.json"
+
+// canaryVerifyTimeout bounds the verification of a canary.
+const canaryVerifyTimeout = 5 * time.Minute
+
+// CanaryStage is how far a canary has got. A canary starts CanaryDeployed,
+// becomes CanaryVerified or CanaryFailed once checked, and only a verified
+// canary can become CanaryPromoted.
+type CanaryStage string
+
+const (
+	CanaryDeployed CanaryStage = "deployed" // Uploaded, not yet verified
+	CanaryVerified CanaryStage = "verified"
+	CanaryFailed   CanaryStage = "failed" // Verification failed; deploy a new canary
+	CanaryPromoted CanaryStage = "promoted"
+)
+
+var (
+	// ErrNoCanary is returned by PromoteCanary when no canary was deployed.
+	ErrNoCanary = errors.New("no canary deployment; run 'walgo deploy --canary' first")
+	// ErrCanaryNotVerified is returned when promoting a canary that did not
+	// pass verification.
+	ErrCanaryNotVerified = errors.New("canary has not passed verification")
+	// ErrCanaryStale is returned when the publish directory changed after
+	// the canary was deployed, so production would not get what was checked.
+	ErrCanaryStale = errors.New("publish directory changed since the canary was deployed")
+	// ErrCanaryPromoted is returned when promoting a canary a second time.
+	ErrCanaryPromoted = errors.New("canary was already promoted")
+)
+
+// CanaryState records a canary deploy in CanaryStateFile.
+type CanaryState struct {
+	ObjectID   string      `json:"object_id"`
+	URL        string      `json:"url,omitempty"`
+	BuildHash  string      `json:"build_hash"` // PublishDirHash of the uploaded directory
+	Stage      CanaryStage `json:"stage"`
+	Error      string      `json:"error,omitempty"` // Why verification failed
+	DeployedAt time.Time   `json:"deployed_at"`
+	PromotedTo string      `json:"promoted_to,omitempty"` // Production object, once promoted
+}
+
+// CanaryVerifier checks a freshly deployed canary.
+type CanaryVerifier func(ctx context.Context, opts VerifyOptions) error
+
+// VerifyCanary is the CanaryVerifier used by walgo deploy --canary: the
+// checks of VerifyDeployment, bounded by a timeout.
+func VerifyCanary(ctx context.Context, opts VerifyOptions) error {
+	ctx, cancel := context.WithTimeout(ctx, canaryVerifyTimeout)
+	defer cancel()
+	_, err := VerifyDeployment(ctx, opts)
+	return err
+}
+
+// CanaryStatePath returns where the canary state of the site at sitePath is kept.
+func CanaryStatePath(sitePath string) string {
+	return filepath.Join(sitePath, ".walgo", CanaryStateFile)
+}
+
+// LoadCanaryState reads the canary state of the site at sitePath. It returns
+// ErrNoCanary when there is none.
+func LoadCanaryState(sitePath string) (*CanaryState, error) {
+	data, err := os.ReadFile(CanaryStatePath(sitePath)) // #nosec G304 - path is inside the site
+	if os.IsNotExist(err) {
+		return nil, ErrNoCanary
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read canary state: %w", err)
+	}
+	var state CanaryState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid canary state %s: %w", CanaryStatePath(sitePath), err)
+	}
+	return &state, nil
+}
+
+// SaveCanaryState writes state for the site at sitePath.
+func SaveCanaryState(sitePath string, state *CanaryState) error {
+	p := CanaryStatePath(sitePath)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(p), err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(p, append(data, '\n'), 0644); err != nil { // #nosec G306 - not secret
+		return fmt.Errorf("failed to save canary state: %w", err)
+	}
+	return nil
+}
+
+// PublishDirHash returns a digest of every file path and content in
+// publishDir, so a promote can tell whether it is uploading the build the
+// canary was made from.
+func PublishDirHash(publishDir string) (string, error) {
+	h := sha256.New()
+	err := walkPublishDir(publishDir, func(rel, sum string) {
+		fmt.Fprintf(h, "%s\x00%s\n", rel, sum)
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// PublishDirHashes returns the SHA-256 of every file in publishDir, keyed by
+// its slash-separated path relative to publishDir.
+func PublishDirHashes(publishDir string) (map[string]string, error) {
+	hashes := make(map[string]string)
+	err := walkPublishDir(publishDir, func(rel, sum string) {
+		hashes[rel] = sum
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// DiffPublishDirHashes returns the sorted paths whose hash differs between
+// two PublishDirHashes results, including paths present in only one of them.
+func DiffPublishDirHashes(a, b map[string]string) []string {
+	var diff []string
+	for p, h := range a {
+		if b[p] != h {
+			diff = append(diff, p)
+		}
+	}
+	for p := range b {
+		if _, ok := a[p]; !ok {
+			diff = append(diff, p)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// walkPublishDir calls fn with the slash-separated relative path and hex
+// SHA-256 of every file in publishDir, in lexical walk order.
+func walkPublishDir(publishDir string, fn func(rel, sum string)) error {
+	err := filepath.Walk(publishDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(publishDir, p)
+		if err != nil {
+			return err
+		}
+		sum, err := hashFile(p, sha256.New(), hex.EncodeToString)
+		if err != nil {
+			return err
+		}
+		fn(filepath.ToSlash(rel), sum)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hash %s: %w", publishDir, err)
+	}
+	return nil
+}
+
+// DeployCanary publishes opts.PublishDir as a new site without touching the
+// production object, runs verify against it and records the outcome in
+// CanaryStateFile. The state is returned even when verification fails, so
+// the canary URL can still be inspected.
+func DeployCanary(ctx context.Context, opts DeploymentOptions, verify CanaryVerifier) (*CanaryState, *DeploymentResult, error) {
+	buildHash, err := PublishDirHash(opts.PublishDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts.Canary = true
+	opts.SaveProject = false
+	result, err := PerformDeployment(ctx, opts)
+	if err != nil {
+		return nil, result, err
+	}
+
+	state := &CanaryState{
+		ObjectID:   result.ObjectID,
+		URL:        result.PortalURL,
+		BuildHash:  buildHash,
+		Stage:      CanaryDeployed,
+		DeployedAt: time.Now(),
+	}
+	// Saved before verifying so an interrupted check still leaves a record
+	if err := SaveCanaryState(opts.SitePath, state); err != nil {
+		return state, result, err
+	}
+
+	var verifyErr error
+	if verify != nil {
+		verifyErr = verify(ctx, VerifyOptions{ObjectID: state.ObjectID, PublishDir: opts.PublishDir, PortalURL: state.URL})
+		if verifyErr != nil {
+			state.Stage = CanaryFailed
+			state.Error = verifyErr.Error()
+		} else {
+			state.Stage = CanaryVerified
+		}
+	}
+	if err := SaveCanaryState(opts.SitePath, state); err != nil {
+		return state, result, err
+	}
+	if verifyErr != nil {
+		return state, result, fmt.Errorf("canary verification failed: %w", verifyErr)
+	}
+	return state, result, nil
+}
+
+// PromoteCanary deploys opts.PublishDir to the production site, as a plain
+// PerformDeployment would, once the site's canary has been verified and the
+// publish directory still holds the build it was made from.
+func PromoteCanary(ctx context.Context, opts DeploymentOptions) (*CanaryState, *DeploymentResult, error) {
+	state, err := LoadCanaryState(opts.SitePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch state.Stage {
+	case CanaryVerified:
+	case CanaryPromoted:
+		return state, nil, fmt.Errorf("%w to %s", ErrCanaryPromoted, state.PromotedTo)
+	case CanaryFailed:
+		return state, nil, fmt.Errorf("%w: %s", ErrCanaryNotVerified, state.Error)
+	default:
+		return state, nil, ErrCanaryNotVerified
+	}
+
+	buildHash, err := PublishDirHash(opts.PublishDir)
+	if err != nil {
+		return state, nil, err
+	}
+	if buildHash != state.BuildHash {
+		return state, nil, ErrCanaryStale
+	}
+
+	opts.Canary = false
+	result, err := PerformDeployment(ctx, opts)
+	if err != nil {
+		return state, result, err
+	}
+
+	state.Stage = CanaryPromoted
+	state.PromotedTo = result.ObjectID
+	if err := SaveCanaryState(opts.SitePath, state); err != nil {
+		return state, result, err
+	}
+	return state, result, nil
+}
@@ -0,0 +1,202 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployer"
+)
+
+const canaryWSResources = "{\n  \"object_id\": \"0xprod\"\n}\n"
+
+// setupCanarySite returns options for a site whose production object is
+// 0xprod, deployed through a fake deployer that publishes new sites as
+// 0xcanary.
+func setupCanarySite(t *testing.T) (DeploymentOptions, *MockDeployer) {
+	t.Helper()
+	tempDir, cleanup := createTestSiteDir(t)
+	t.Cleanup(cleanup)
+	t.Setenv("HOME", tempDir)
+	publicDir := filepath.Join(tempDir, "public")
+	if err := os.WriteFile(filepath.Join(publicDir, "ws-resources.json"), []byte(canaryWSResources), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &MockDeployer{
+		DeployFunc: func(ctx context.Context, siteDir string, opts deployer.DeployOptions) (*deployer.Result, error) {
+			return &deployer.Result{Success: true, ObjectID: "0xcanary", BrowseURLs: []string{"https://canary.example"}}, nil
+		},
+		UpdateFunc: func(ctx context.Context, siteDir string, objectID string, opts deployer.DeployOptions) (*deployer.Result, error) {
+			return &deployer.Result{Success: true, ObjectID: objectID}, nil
+		},
+	}
+	cfg := config.NewDefaultWalgoConfig()
+	cfg.WalrusConfig.ProjectID = "0xprod"
+	return DeploymentOptions{
+		SitePath:    tempDir,
+		PublishDir:  publicDir,
+		Epochs:      1,
+		WalgoCfg:    &cfg,
+		Quiet:       true,
+		ProjectName: "canary-test",
+		Deployer:    mock,
+	}, mock
+}
+
+func passCanary(context.Context, VerifyOptions) error { return nil }
+
+func TestDeployCanaryThenPromote(t *testing.T) {
+	opts, mock := setupCanarySite(t)
+
+	var verified VerifyOptions
+	state, result, err := DeployCanary(context.Background(), opts, func(_ context.Context, v VerifyOptions) error {
+		verified = v
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DeployCanary() error = %v", err)
+	}
+	if !mock.DeployCalled || mock.UpdateCalled {
+		t.Fatal("a canary must be a new site, not an update of production")
+	}
+	if result.ObjectID != "0xcanary" || state.ObjectID != "0xcanary" || state.URL != "https://canary.example" {
+		t.Errorf("state = %+v, result object = %s", state, result.ObjectID)
+	}
+	if verified.ObjectID != "0xcanary" || verified.PortalURL != "https://canary.example" || verified.PublishDir != opts.PublishDir {
+		t.Errorf("verifier got %+v, want the canary", verified)
+	}
+	if state.Stage != CanaryVerified {
+		t.Errorf("Stage = %q, want %q", state.Stage, CanaryVerified)
+	}
+	ws, err := os.ReadFile(filepath.Join(opts.PublishDir, "ws-resources.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(ws) != canaryWSResources {
+		t.Errorf("ws-resources.json should still describe production after a canary:\n%s", ws)
+	}
+	if saved, err := LoadCanaryState(opts.SitePath); err != nil || saved.Stage != CanaryVerified {
+		t.Fatalf("LoadCanaryState() = %+v, %v", saved, err)
+	}
+
+	state, result, err = PromoteCanary(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("PromoteCanary() error = %v", err)
+	}
+	if !mock.UpdateCalled || mock.LastObjectID != "0xprod" {
+		t.Errorf("promote should update production 0xprod, updated %q", mock.LastObjectID)
+	}
+	if !result.IsUpdate || state.Stage != CanaryPromoted || state.PromotedTo != "0xprod" {
+		t.Errorf("state = %+v, IsUpdate = %v", state, result.IsUpdate)
+	}
+
+	if _, _, err := PromoteCanary(context.Background(), opts); !errors.Is(err, ErrCanaryPromoted) {
+		t.Errorf("second PromoteCanary() error = %v, want ErrCanaryPromoted", err)
+	}
+}
+
+func TestDeployCanaryFailedVerification(t *testing.T) {
+	opts, mock := setupCanarySite(t)
+
+	state, _, err := DeployCanary(context.Background(), opts, func(context.Context, VerifyOptions) error {
+		return errors.New("entrypoint returned HTTP 404")
+	})
+	if err == nil {
+		t.Fatal("DeployCanary() should report the failed verification")
+	}
+	if state == nil || state.Stage != CanaryFailed || state.Error == "" || state.URL == "" {
+		t.Fatalf("state = %+v, want a failed canary that can still be inspected", state)
+	}
+
+	if _, _, err := PromoteCanary(context.Background(), opts); !errors.Is(err, ErrCanaryNotVerified) {
+		t.Errorf("PromoteCanary() error = %v, want ErrCanaryNotVerified", err)
+	}
+	if mock.UpdateCalled {
+		t.Error("a failed canary must not reach production")
+	}
+}
+
+func TestPromoteCanaryStaleBuild(t *testing.T) {
+	opts, mock := setupCanarySite(t)
+	if _, _, err := DeployCanary(context.Background(), opts, passCanary); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(opts.PublishDir, "index.html"), []byte("<html>changed</html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := PromoteCanary(context.Background(), opts); !errors.Is(err, ErrCanaryStale) {
+		t.Errorf("PromoteCanary() error = %v, want ErrCanaryStale", err)
+	}
+	if mock.UpdateCalled {
+		t.Error("a changed build must not be promoted")
+	}
+}
+
+func TestPromoteCanaryWithoutCanary(t *testing.T) {
+	opts, mock := setupCanarySite(t)
+	if _, _, err := PromoteCanary(context.Background(), opts); !errors.Is(err, ErrNoCanary) {
+		t.Errorf("PromoteCanary() error = %v, want ErrNoCanary", err)
+	}
+	if mock.DeployCalled || mock.UpdateCalled {
+		t.Error("nothing should be deployed without a canary")
+	}
+}
+
+func TestPublishDirHash(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.html"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	first, err := PublishDirHash(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := PublishDirHash(dir); again != first {
+		t.Error("hash should be stable")
+	}
+	if err := os.Rename(filepath.Join(dir, "a.html"), filepath.Join(dir, "b.html")); err != nil {
+		t.Fatal(err)
+	}
+	if renamed, _ := PublishDirHash(dir); renamed == first {
+		t.Error("renaming a file should change the hash")
+	}
+}
+
+func TestPublishDirHashes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "css"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"index.html": "a", "css/site.css": "a"} {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hashes, err := PublishDirHashes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const sumOfA = "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"
+	want := map[string]string{"index.html": sumOfA, "css/site.css": sumOfA}
+	if !reflect.DeepEqual(hashes, want) {
+		t.Errorf("PublishDirHashes() = %v, want %v", hashes, want)
+	}
+}
+
+func TestDiffPublishDirHashes(t *testing.T) {
+	a := map[string]string{"index.html": "1", "same.css": "2", "gone.js": "3"}
+	b := map[string]string{"index.html": "9", "same.css": "2", "new.js": "4"}
+	want := []string{"gone.js", "index.html", "new.js"}
+	if got := DiffPublishDirHashes(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffPublishDirHashes() = %v, want %v", got, want)
+	}
+	if got := DiffPublishDirHashes(a, a); len(got) != 0 {
+		t.Errorf("DiffPublishDirHashes(a, a) = %v, want none", got)
+	}
+}
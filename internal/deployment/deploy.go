@@ -2,6 +2,7 @@ package deployment
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -18,6 +19,10 @@ import (
 	"github.com/selimozten/walgo/internal/walrus"
 )
 
+// ErrRestoreFailed is wrapped by the error of an interrupted deployment that
+// could not put ws-resources.json back as it was.
+var ErrRestoreFailed = errors.New("failed to restore ws-resources.json")
+
 // DeploymentOptions contains all options for deployment
 type DeploymentOptions struct {
 	SitePath    string
@@ -36,6 +41,30 @@ type DeploymentOptions struct {
 	// Metadata for ws-resources.json (displayed on wallets/explorers)
 	Description string
 	ImageURL    string
+	// AllowCustomCategory accepts a Category outside compress.KnownCategories
+	AllowCustomCategory bool
+	// SkipMetadata leaves site_name and metadata in ws-resources.json as they are
+	SkipMetadata bool
+	// Deployer overrides the backend used to publish the site (defaults to site-builder)
+	Deployer deployer.WalrusDeployer
+	// NotFoundPage is served for unknown paths (see compress.SetNotFoundPage)
+	NotFoundPage string
+	// TargetDir marks PublishDir as a standalone site (walgo deploy
+	// --target-dir): only its ws-resources.json identifies the site, and the
+	// object ID is not looked up in or written to walgo.yaml or the projects
+	// database entry for SitePath
+	TargetDir bool
+	// OutputLine receives the deploy tool's output live, line by line (optional)
+	OutputLine func(line string)
+	// SEOStrict warns when the publish directory has no robots.txt or sitemap.xml
+	SEOStrict bool
+	// Canary publishes PublishDir as a new throwaway site (see DeployCanary):
+	// ws-resources.json is restored afterwards, and walgo.yaml, the deploy
+	// cache and the projects database are left alone
+	Canary bool
+	// Deletable stores the site's blobs as deletable so their storage can be
+	// reclaimed before the epochs run out; recorded on the project
+	Deletable bool
 }
 
 // DeploymentResult contains the result of a deployment
@@ -49,19 +78,45 @@ type DeploymentResult struct {
 	ActualGasSUI      float64 // Actual SUI gas cost from blockchain
 	ActualWAL         float64 // Actual WAL spent from blockchain balance changes
 	TransactionDigest string  // Transaction digest for reference
+	// Report fields used by deploy summaries
+	Network       string    // Network the site was deployed to
+	Epochs        int       // Storage epochs requested, or as reported by site-builder
+	PortalURL     string    // First browse URL reported by site-builder (if any)
+	TotalFiles    int       // Files in the publish directory
+	FilesUploaded int       // Files added or modified since the last deploy
+	FilesSkipped  int       // Files unchanged since the last deploy
+	EstimatedCost string    // Estimated WAL/SUI cost for the deploy
+	CompletedAt   time.Time // When the deployment finished
+	DedupedFiles  int       // Duplicate files that reused another file's blob
+	DedupedBytes  int64     // Bytes not uploaded thanks to deduplication
+	Concurrency   int       // Upload concurrency the deployer settled on (HTTP blobs mode)
+	// Timings records how long each deploy phase took, keyed by the Phase*
+	// constants. Phases that did not run (e.g. hashing without a cache) are absent.
+	Timings map[string]time.Duration
 }
 
 // PerformDeployment handles the complete site deployment workflow
 func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*DeploymentResult, error) {
 	icons := ui.GetIcons()
-	result := &DeploymentResult{}
+	result := &DeploymentResult{Timings: make(map[string]time.Duration)}
+
+	// A site without its entrypoint serves nothing at its root
+	var entrypoint string
+	if opts.WalgoCfg != nil {
+		entrypoint = opts.WalgoCfg.WalrusConfig.Entrypoint
+	}
+	if err := compress.CheckEntrypointFile(opts.PublishDir, entrypoint); err != nil {
+		return nil, err
+	}
 
 	if !opts.Quiet {
 		fmt.Printf("%s Ensuring production URLs...\n", icons.Spinner)
 	}
 
 	var siteSize int64
+	var fileCount int
 	var walkErrors []string
+	sizeStart := time.Now()
 	_ = filepath.Walk(opts.PublishDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			walkErrors = append(walkErrors, fmt.Sprintf("%s: %v", path, err))
@@ -69,9 +124,11 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 		}
 		if !info.IsDir() {
 			siteSize += info.Size()
+			fileCount++
 		}
 		return nil
 	})
+	result.Timings[PhaseSizeCalc] = time.Since(sizeStart)
 
 	if len(walkErrors) > 0 && !opts.Quiet {
 		fmt.Fprintf(os.Stderr, "%s Warning: Encountered errors while calculating site size:\n", icons.Warning)
@@ -82,6 +139,9 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 	}
 
 	result.SiteSize = siteSize
+	result.TotalFiles = fileCount
+	result.FilesUploaded = fileCount
+	result.Epochs = opts.Epochs
 
 	if !opts.Quiet {
 		fmt.Printf("  %s Site ready: %s (%.2f MB)\n", icons.Check, opts.PublishDir, float64(siteSize)/(1024*1024))
@@ -114,12 +174,19 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 		if !opts.Quiet {
 			fmt.Println("  [2/5] Analyzing changes...")
 		}
+		hashStart := time.Now()
 		plan, err := cacheHelper.PrepareDeployment(opts.PublishDir)
+		result.Timings[PhaseHashing] += time.Since(hashStart)
 		if err != nil {
 			if !opts.Quiet {
 				fmt.Fprintf(os.Stderr, "%s Warning: Failed to analyze changes: %v\n", icons.Warning, err)
 			}
 		} else {
+			if plan.IsIncremental && plan.ChangeSet != nil {
+				result.FilesUploaded = len(plan.ChangeSet.Added) + len(plan.ChangeSet.Modified)
+				result.FilesSkipped = len(plan.ChangeSet.Unchanged)
+			}
+
 			if opts.Verbose {
 				plan.PrintVerboseSummary()
 			} else if !opts.Quiet {
@@ -128,6 +195,9 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 
 			if opts.DryRun {
 				if !opts.Quiet {
+					if plan.IsIncremental {
+						printIncrementalCost(cacheHelper, plan, opts)
+					}
 					fmt.Printf("\n%s Dry-run mode: No files will be uploaded\n", icons.Info)
 					fmt.Printf("%s Deployment plan complete!\n", icons.Check)
 					fmt.Printf("\n%s To actually deploy, run without --dry-run flag\n", icons.Lightbulb)
@@ -143,7 +213,7 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 	var isUpdate bool
 
 	// Check 1: walgo.yaml projectID
-	if opts.WalgoCfg.WalrusConfig.ProjectID != "" && opts.WalgoCfg.WalrusConfig.ProjectID != "YOUR_WALRUS_PROJECT_ID" {
+	if !opts.TargetDir && !config.IsPlaceholderProjectID(opts.WalgoCfg.WalrusConfig.ProjectID) {
 		existingObjectID = opts.WalgoCfg.WalrusConfig.ProjectID
 		if !opts.Quiet {
 			fmt.Printf("  %s Found objectID in walgo.yaml: %s\n", icons.Info, existingObjectID)
@@ -162,7 +232,7 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 	}
 
 	// Check 3: Database for existing project
-	if existingObjectID == "" {
+	if existingObjectID == "" && !opts.TargetDir {
 		pm, err := projects.NewManager()
 		if err == nil {
 			defer func() {
@@ -179,7 +249,7 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 		}
 	}
 
-	if existingObjectID != "" && !opts.ForceNew {
+	if existingObjectID != "" && !opts.ForceNew && !opts.Canary {
 		isUpdate = true
 		result.IsUpdate = true
 		if !opts.Quiet {
@@ -197,23 +267,57 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 		fmt.Printf("  [%d/5] Preparing metadata...\n", stepNum)
 	}
 
+	if err := ctx.Err(); err != nil {
+		result.Error = fmt.Errorf("deployment aborted: %w", err)
+		return result, result.Error
+	}
+
+	metadataStart := time.Now()
 	wsResourcesPath := filepath.Join(opts.PublishDir, "ws-resources.json")
+	// Snapshot ws-resources.json so an aborted deploy can put it back
+	originalWSResources, readErr := os.ReadFile(wsResourcesPath) // #nosec G304 - path is inside the publish directory
+	hadWSResources := readErr == nil
+
 	metadataOpts := compress.MetadataOptions{
 		SiteName:    opts.ProjectName,
 		Description: opts.Description,
 		ImageURL:    opts.ImageURL,
 		Category:    opts.Category,
+
+		AllowCustomCategory: opts.AllowCustomCategory,
 	}
 	// For updates, preserve existing objectID
 	if isUpdate && existingObjectID != "" {
 		metadataOpts.ObjectID = existingObjectID
 	}
-	if err := compress.UpdateMetadata(wsResourcesPath, metadataOpts); err != nil {
-		result.Error = fmt.Errorf("failed to prepare ws-resources.json metadata: %w", err)
+	// A failed step puts back whatever the earlier steps wrote
+	failMetadata := func(err error) (*DeploymentResult, error) {
+		if restoreErr := restoreFile(wsResourcesPath, originalWSResources, hadWSResources); restoreErr != nil {
+			fmt.Fprintf(os.Stderr, "%s Warning: Failed to restore ws-resources.json: %v\n", icons.Warning, restoreErr)
+		}
+		result.Error = err
 		return result, result.Error
 	}
+	if !opts.SkipMetadata {
+		if err := compress.UpdateMetadata(wsResourcesPath, metadataOpts); err != nil {
+			return failMetadata(fmt.Errorf("failed to prepare ws-resources.json metadata: %w", err))
+		}
+	}
+	if opts.NotFoundPage != "" {
+		if err := compress.SetNotFoundPage(wsResourcesPath, opts.NotFoundPage); err != nil {
+			return failMetadata(fmt.Errorf("failed to configure 404 page: %w", err))
+		}
+	}
+	if err := applySEOHeaders(wsResourcesPath, opts.SEOStrict, os.Stderr); err != nil {
+		return failMetadata(err)
+	}
+	result.Timings[PhaseMetadata] = time.Since(metadataStart)
 	if !opts.Quiet {
-		fmt.Printf("%s Metadata prepared in ws-resources.json\n", icons.Check)
+		if opts.SkipMetadata {
+			fmt.Printf("%s Metadata left unchanged in ws-resources.json\n", icons.Info)
+		} else {
+			fmt.Printf("%s Metadata prepared in ws-resources.json\n", icons.Check)
+		}
 	}
 
 	// Deploy or update the site
@@ -226,28 +330,40 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 		}
 	}
 
-	d := sb.New()
+	var d deployer.WalrusDeployer = sb.New()
+	if opts.Deployer != nil {
+		d = opts.Deployer
+	}
 	uploadStart := time.Now()
 
 	var output *deployer.Result
 
+	deployOpts := deployer.DeployOptions{
+		Epochs:     opts.Epochs,
+		Verbose:    opts.Verbose && !opts.Quiet,
+		WalrusCfg:  opts.WalgoCfg.WalrusConfig,
+		Deletable:  opts.Deletable,
+		OutputLine: opts.OutputLine,
+	}
+
 	if isUpdate {
 		// Update existing site
-		output, err = d.Update(ctx, opts.PublishDir, existingObjectID, deployer.DeployOptions{
-			Epochs:    opts.Epochs,
-			Verbose:   opts.Verbose && !opts.Quiet,
-			WalrusCfg: opts.WalgoCfg.WalrusConfig,
-		})
+		output, err = d.Update(ctx, opts.PublishDir, existingObjectID, deployOpts)
 	} else {
 		// Deploy new site
-		output, err = d.Deploy(ctx, opts.PublishDir, deployer.DeployOptions{
-			Epochs:    opts.Epochs,
-			Verbose:   opts.Verbose && !opts.Quiet,
-			WalrusCfg: opts.WalgoCfg.WalrusConfig,
-		})
+		output, err = d.Deploy(ctx, opts.PublishDir, deployOpts)
 	}
+	result.Timings[PhaseUpload] = time.Since(uploadStart)
 
 	if err != nil {
+		if ctx.Err() != nil {
+			// Interrupted mid-upload: leave the publish directory as we found it
+			result.Error = fmt.Errorf("deployment aborted: %w", ctx.Err())
+			if restoreErr := restoreFile(wsResourcesPath, originalWSResources, hadWSResources); restoreErr != nil {
+				result.Error = fmt.Errorf("deployment aborted: %w; %w: %v", ctx.Err(), ErrRestoreFailed, restoreErr)
+			}
+			return result, result.Error
+		}
 		result.Error = err
 		return result, fmt.Errorf("deployment failed: %w", err)
 	}
@@ -264,6 +380,12 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 
 	result.Success = true
 	result.ObjectID = output.ObjectID
+	result.DedupedFiles = output.DedupedFiles
+	result.DedupedBytes = output.DedupedBytes
+	result.Concurrency = output.Concurrency
+	if len(output.BrowseURLs) > 0 {
+		result.PortalURL = output.BrowseURLs[0]
+	}
 
 	// Get wallet address and network for gas query
 	queryWalletAddr := opts.WalletAddr
@@ -274,17 +396,32 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 	if queryNetwork == "" {
 		queryNetwork, _ = sui.GetActiveEnv()
 	}
+	result.Network = queryNetwork
+	if queryNetwork != "" {
+		result.EstimatedCost = projects.EstimateGasFeeWithEpochs(queryNetwork, siteSize, opts.Epochs)
+	}
 
-	// Query actual SUI gas cost from blockchain
-	if queryWalletAddr != "" && queryNetwork != "" {
+	// Prefer what site-builder reported storing and spending
+	if output.StorageEpochs > 0 {
+		result.Epochs = output.StorageEpochs
+	}
+	result.ActualWAL = output.CostWAL
+	result.ActualGasSUI = output.GasSUI
+
+	// Otherwise query actual costs from the blockchain
+	if (result.ActualWAL == 0 || result.ActualGasSUI == 0) && queryWalletAddr != "" && queryNetwork != "" {
 		gasInfo, err := walrus.GetLatestTransactionGas(queryWalletAddr, queryNetwork)
 		if err != nil {
 			if !opts.Quiet {
 				fmt.Fprintf(os.Stderr, "%s Warning: Could not fetch actual gas cost: %v\n", icons.Warning, err)
 			}
 		} else {
-			result.ActualGasSUI = gasInfo.TotalGasSUI
-			result.ActualWAL = gasInfo.TotalWAL
+			if result.ActualGasSUI == 0 {
+				result.ActualGasSUI = gasInfo.TotalGasSUI
+			}
+			if result.ActualWAL == 0 {
+				result.ActualWAL = gasInfo.TotalWAL
+			}
 			result.TransactionDigest = gasInfo.Digest
 		}
 	}
@@ -292,11 +429,24 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 	if !opts.Quiet {
 		fmt.Printf("  %s Deployment completed in %v\n", icons.Check, time.Since(uploadStart).Round(time.Second))
 		// Display actual costs
-		if result.ActualWAL > 0 && result.ActualGasSUI > 0 {
-			fmt.Printf("  %s Cost: %.6f WAL + %.6f SUI (tx: %s)\n", icons.Info, result.ActualWAL, result.ActualGasSUI, result.TransactionDigest)
-		} else if result.ActualGasSUI > 0 {
-			fmt.Printf("  %s Gas cost: %.6f SUI (tx: %s)\n", icons.Info, result.ActualGasSUI, result.TransactionDigest)
+		if cost := projects.FormatCost(result.ActualWAL, result.ActualGasSUI); cost != "" {
+			if result.TransactionDigest != "" {
+				fmt.Printf("  %s Cost: %s (tx: %s)\n", icons.Info, cost, result.TransactionDigest)
+			} else {
+				fmt.Printf("  %s Cost: %s\n", icons.Info, cost)
+			}
+		}
+	}
+
+	// A canary is not the site: put ws-resources.json back so it still
+	// describes the production object
+	if opts.Canary {
+		if err := restoreFile(wsResourcesPath, originalWSResources, hadWSResources); err != nil {
+			result.Error = fmt.Errorf("failed to restore ws-resources.json: %w", err)
+			return result, result.Error
 		}
+		result.CompletedAt = time.Now()
+		return result, nil
 	}
 
 	// Update cache with deployment info
@@ -305,7 +455,9 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 		if !opts.Quiet {
 			fmt.Printf("  [%d/5] Updating cache...\n", stepNum)
 		}
+		hashStart := time.Now()
 		err := cacheHelper.FinalizeDeployment(opts.PublishDir, output.ObjectID, output.ObjectID, output.FileToBlobID)
+		result.Timings[PhaseHashing] += time.Since(hashStart)
 		if err != nil {
 			if !opts.Quiet {
 				fmt.Fprintf(os.Stderr, "%s Warning: Failed to update cache: %v\n", icons.Warning, err)
@@ -322,6 +474,7 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 	}
 
 	// Update local ws-resources.json with object_id
+	objectWriteStart := time.Now()
 	if err := compress.UpdateObjectID(wsResourcesPath, output.ObjectID); err != nil {
 		result.Error = fmt.Errorf("failed to save object_id to ws-resources.json: %w", err)
 		return result, result.Error
@@ -331,14 +484,19 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 	}
 
 	// Update walgo.yaml with projectID
-	if err := config.UpdateWalgoYAMLProjectID(opts.SitePath, output.ObjectID); err != nil {
-		result.Error = fmt.Errorf("failed to update walgo.yaml with Object ID: %w", err)
-		return result, result.Error
-	}
-	if !opts.Quiet {
-		fmt.Printf("%s Updated walgo.yaml with Object ID\n", icons.Check)
+	if !opts.TargetDir {
+		if err := config.UpdateWalgoYAMLProjectID(opts.SitePath, output.ObjectID); err != nil {
+			result.Error = fmt.Errorf("failed to update walgo.yaml with Object ID: %w", err)
+			return result, result.Error
+		}
+		if !opts.Quiet {
+			fmt.Printf("%s Updated walgo.yaml with Object ID\n", icons.Check)
+		}
 	}
 
+	result.Timings[PhaseObjectWrite] = time.Since(objectWriteStart)
+	result.CompletedAt = time.Now()
+
 	// Optionally save to projects database
 	if opts.SaveProject && !opts.Quiet {
 		fmt.Printf("\n%s Saving project...\n", icons.Database)
@@ -398,28 +556,22 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 					}
 				}
 
+				// A new site takes this deploy's blob policy; an update leaves
+				// the project deletable once any of its blobs were stored so
+				if existingProj.ObjectID != output.ObjectID {
+					existingProj.Deletable = opts.Deletable
+				} else if opts.Deletable {
+					existingProj.Deletable = true
+				}
+
 				existingProj.ObjectID = output.ObjectID
 				existingProj.Network = network
 				existingProj.WalletAddr = walletAddr
-				existingProj.Epochs = opts.Epochs
+				existingProj.Epochs = result.Epochs
 				existingProj.LastDeployAt = time.Now()
 
-				// Use actual costs from blockchain query (balance changes)
-				// Format: "X.XXXXXX WAL + Y.YYYYYY SUI"
-				var gasFee string
-				if result.ActualGasSUI > 0 || result.ActualWAL > 0 {
-					// We have actual costs from blockchain
-					if result.ActualWAL > 0 && result.ActualGasSUI > 0 {
-						gasFee = fmt.Sprintf("%.6f WAL + %.6f SUI", result.ActualWAL, result.ActualGasSUI)
-					} else if result.ActualWAL > 0 {
-						gasFee = fmt.Sprintf("%.6f WAL", result.ActualWAL)
-					} else {
-						gasFee = fmt.Sprintf("%.6f SUI", result.ActualGasSUI)
-					}
-				} else {
-					// Fallback to estimated gas fee
-					gasFee = projects.EstimateGasFeeWithEpochs(network, siteSize, opts.Epochs)
-				}
+				// Actual costs when site-builder or the chain reported them, else the estimate
+				gasFee, costActual := projects.DeployCostFee(result.ActualWAL, result.ActualGasSUI, network, siteSize, result.Epochs)
 				existingProj.GasFee = gasFee
 
 				// Update metadata if provided
@@ -435,12 +587,16 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 				} else {
 					// Record the deployment with actual or estimated cost
 					deployment := &projects.DeploymentRecord{
-						ProjectID: existingProj.ID,
-						ObjectID:  output.ObjectID,
-						Network:   network,
-						Epochs:    opts.Epochs,
-						GasFee:    gasFee,
-						Success:   true,
+						ProjectID:    existingProj.ID,
+						ObjectID:     output.ObjectID,
+						Network:      network,
+						Epochs:       result.Epochs,
+						GasFee:       gasFee,
+						Success:      true,
+						FileToBlobID: output.FileToBlobID,
+						CostWAL:      result.ActualWAL,
+						CostSUI:      result.ActualGasSUI,
+						CostActual:   costActual,
 					}
 					if err := pm.RecordDeployment(deployment); err != nil {
 						fmt.Fprintf(os.Stderr, "%s Warning: Failed to record deployment history: %v\n", icons.Warning, err)
@@ -464,22 +620,8 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 					category = "website"
 				}
 
-				// Use actual costs from blockchain query (balance changes)
-				// Format: "X.XXXXXX WAL + Y.YYYYYY SUI"
-				var gasFee string
-				if result.ActualGasSUI > 0 || result.ActualWAL > 0 {
-					// We have actual costs from blockchain
-					if result.ActualWAL > 0 && result.ActualGasSUI > 0 {
-						gasFee = fmt.Sprintf("%.6f WAL + %.6f SUI", result.ActualWAL, result.ActualGasSUI)
-					} else if result.ActualWAL > 0 {
-						gasFee = fmt.Sprintf("%.6f WAL", result.ActualWAL)
-					} else {
-						gasFee = fmt.Sprintf("%.6f SUI", result.ActualGasSUI)
-					}
-				} else {
-					// Fallback to estimated gas fee
-					gasFee = projects.EstimateGasFeeWithEpochs(network, siteSize, opts.Epochs)
-				}
+				// Actual costs when site-builder or the chain reported them, else the estimate
+				gasFee, costActual := projects.DeployCostFee(result.ActualWAL, result.ActualGasSUI, network, siteSize, result.Epochs)
 
 				project := &projects.Project{
 					Name:        projectName,
@@ -487,11 +629,12 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 					Network:     network,
 					ObjectID:    output.ObjectID,
 					WalletAddr:  walletAddr,
-					Epochs:      opts.Epochs,
+					Epochs:      result.Epochs,
 					GasFee:      gasFee,
 					SitePath:    opts.SitePath,
 					Description: opts.Description,
 					ImageURL:    opts.ImageURL,
+					Deletable:   opts.Deletable,
 				}
 
 				if err := pm.CreateProject(project); err != nil {
@@ -499,12 +642,16 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 				} else {
 					// Record the deployment with actual or estimated cost
 					deployment := &projects.DeploymentRecord{
-						ProjectID: project.ID,
-						ObjectID:  output.ObjectID,
-						Network:   network,
-						Epochs:    opts.Epochs,
-						GasFee:    gasFee,
-						Success:   true,
+						ProjectID:    project.ID,
+						ObjectID:     output.ObjectID,
+						Network:      network,
+						Epochs:       result.Epochs,
+						GasFee:       gasFee,
+						Success:      true,
+						FileToBlobID: output.FileToBlobID,
+						CostWAL:      result.ActualWAL,
+						CostSUI:      result.ActualGasSUI,
+						CostActual:   costActual,
 					}
 					if err := pm.RecordDeployment(deployment); err != nil {
 						fmt.Fprintf(os.Stderr, "%s Warning: Failed to record deployment history: %v\n", icons.Warning, err)
@@ -519,3 +666,100 @@ func PerformDeployment(ctx context.Context, opts DeploymentOptions) (*Deployment
 
 	return result, nil
 }
+
+// restoreFile puts back a file's original content, or removes it if it did not exist before.
+func restoreFile(path string, original []byte, existed bool) error {
+	if !existed {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	// #nosec G306 - ws-resources.json is published with the site
+	return os.WriteFile(path, original, 0644)
+}
+
+// printIncrementalCost prints what an incremental deploy would pay: files
+// whose content is already stored under an unexpired blob from a previous
+// deploy are free to keep, everything else is priced like a fresh upload.
+func printIncrementalCost(cacheHelper *cache.DeployHelper, plan *cache.DeploymentPlan, opts DeploymentOptions) {
+	icons := ui.GetIcons()
+	est, err := incrementalCost(cacheHelper, plan, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s Warning: Could not estimate update cost: %v\n", icons.Warning, err)
+		return
+	}
+
+	fmt.Printf("\n%s Update cost: %d file(s) to store (%.2f MB), %d already stored (%.2f MB)\n",
+		icons.Chart, est.UploadFiles, float64(est.UploadSize)/(1024*1024),
+		est.ReusedFiles, float64(est.ReusedSize)/(1024*1024))
+	if est.ExpiredFiles > 0 {
+		fmt.Printf("  %s %d unchanged file(s) are re-stored because their blob expired\n", icons.Info, est.ExpiredFiles)
+	}
+	fmt.Printf("  %s\n", walrus.FormatCostSummary(est.TotalWAL, est.GasCostSUI, est.UploadFiles, opts.Epochs))
+}
+
+// incrementalCost estimates an incremental deploy of plan. Each stored blob
+// expires at the end of the longest deployment recorded for it in the
+// projects database, so unchanged files whose blob has expired are priced as
+// uploads.
+func incrementalCost(cacheHelper *cache.DeployHelper, plan *cache.DeploymentPlan, opts DeploymentOptions) (*walrus.UpdateCostEstimate, error) {
+	stored, err := cacheHelper.StoredFiles()
+	if err != nil {
+		return nil, fmt.Errorf("could not read stored blobs: %w", err)
+	}
+
+	info, err := epochInfoSource()
+	if err != nil {
+		info = &walrus.StorageInfo{} // Expiry is not checked
+	}
+	endEpochs := blobEndEpochs(opts.SitePath, info)
+
+	prev := make(map[string]walrus.BlobInfo, len(stored))
+	for path, record := range stored {
+		prev[path] = walrus.BlobInfo{BlobID: record.BlobID, Hash: record.Hash, EndEpoch: endEpochs[record.BlobID]}
+	}
+	current := make([]walrus.SiteFile, 0, len(plan.Files))
+	for _, f := range plan.Files {
+		current = append(current, walrus.SiteFile{Path: f.Path, Hash: f.Hash, Size: f.Size})
+	}
+
+	network := opts.Network
+	if network == "" && opts.WalgoCfg != nil {
+		network = opts.WalgoCfg.WalrusConfig.Network
+	}
+	return walrus.EstimateIncrementalCost(prev, current, info.CurrentEpoch, walrus.CostOptions{Epochs: opts.Epochs, Network: network})
+}
+
+// blobEndEpochs returns, per blob ID, the first epoch the blob is no longer
+// stored, from the deployments of the project at sitePath. Blobs whose end
+// cannot be worked out are left out.
+func blobEndEpochs(sitePath string, info *walrus.StorageInfo) map[string]int {
+	ends := make(map[string]int)
+	pm, err := projects.NewManager()
+	if err != nil {
+		return ends
+	}
+	defer pm.Close()
+
+	proj, err := pm.GetProjectBySitePath(sitePath)
+	if err != nil || proj == nil {
+		return ends
+	}
+	leases, err := pm.GetBlobLeases(proj.ID)
+	if err != nil {
+		return ends
+	}
+	for blobID, list := range leases {
+		for _, lease := range list {
+			start := info.EpochAt(lease.StoredAt)
+			if start == 0 {
+				continue
+			}
+			if end := start + lease.Epochs; end > ends[blobID] {
+				ends[blobID] = end
+			}
+		}
+	}
+	return ends
+}
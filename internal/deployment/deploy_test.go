@@ -3,15 +3,19 @@ package deployment
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/selimozten/walgo/internal/cache"
 	"github.com/selimozten/walgo/internal/compress"
 	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/deployer"
 	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/walrus"
 )
 
 // MockDeployer is a mock implementation of deployer.WalrusDeployer
@@ -71,7 +75,7 @@ func (m *MockDeployer) Status(ctx context.Context, objectID string, opts deploye
 	}, nil
 }
 
-func (m *MockDeployer) Destroy(ctx context.Context, objectID string) error {
+func (m *MockDeployer) Destroy(ctx context.Context, objectID string, opts deployer.DestroyOptions) error {
 	m.DestroyCalled = true
 	m.LastObjectID = objectID
 	if m.DestroyFunc != nil {
@@ -2460,3 +2464,523 @@ func TestDeploymentUpdateVsNew(t *testing.T) {
 		})
 	}
 }
+
+// TestPerformDeploymentCancellation verifies that cancelling the context stops
+// the deployer promptly and restores ws-resources.json to its original state.
+func TestPerformDeploymentCancellation(t *testing.T) {
+	tempDir, cleanup := createTestSiteDir(t)
+	defer cleanup()
+	t.Setenv("HOME", tempDir)
+
+	publicDir := filepath.Join(tempDir, "public")
+	wsResourcesPath := filepath.Join(publicDir, "ws-resources.json")
+	original := []byte("{\n  \"headers\": {}\n}\n")
+	if err := os.WriteFile(wsResourcesPath, original, 0644); err != nil {
+		t.Fatalf("Failed to write ws-resources.json: %v", err)
+	}
+
+	cfgBefore, err := os.ReadFile(filepath.Join(tempDir, "walgo.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read walgo.yaml: %v", err)
+	}
+
+	started := make(chan struct{})
+	mock := &MockDeployer{
+		DeployFunc: func(ctx context.Context, siteDir string, opts deployer.DeployOptions) (*deployer.Result, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	cfg := config.NewDefaultWalgoConfig()
+	opts := DeploymentOptions{
+		SitePath:    tempDir,
+		PublishDir:  publicDir,
+		Epochs:      1,
+		WalgoCfg:    &cfg,
+		Quiet:       true,
+		ProjectName: "cancel-test",
+		Description: "changed during deploy",
+		Deployer:    mock,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := PerformDeployment(ctx, opts)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Expected error from cancelled deployment")
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("PerformDeployment did not return promptly after cancellation")
+	}
+
+	if !mock.DeployCalled {
+		t.Error("Expected deployer to be called")
+	}
+
+	data, err := os.ReadFile(wsResourcesPath)
+	if err != nil {
+		t.Fatalf("Failed to read ws-resources.json: %v", err)
+	}
+	if string(data) != string(original) {
+		t.Errorf("ws-resources.json was not restored:\n%s", data)
+	}
+
+	cfgAfter, err := os.ReadFile(filepath.Join(tempDir, "walgo.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read walgo.yaml: %v", err)
+	}
+	if string(cfgAfter) != string(cfgBefore) {
+		t.Error("walgo.yaml was modified by a cancelled deploy")
+	}
+}
+
+// TestPerformDeploymentCancellationRestoreFails verifies that an interrupted
+// deployment reports a ws-resources.json it could not put back.
+func TestPerformDeploymentCancellationRestoreFails(t *testing.T) {
+	tempDir, cleanup := createTestSiteDir(t)
+	defer cleanup()
+	t.Setenv("HOME", tempDir)
+
+	publicDir := filepath.Join(tempDir, "public")
+	if err := os.WriteFile(filepath.Join(publicDir, "ws-resources.json"), []byte("{}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write ws-resources.json: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock := &MockDeployer{
+		DeployFunc: func(ctx context.Context, siteDir string, opts deployer.DeployOptions) (*deployer.Result, error) {
+			// The publish directory is replaced by a file, so nothing can be
+			// written back into it
+			if err := os.RemoveAll(publicDir); err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(publicDir, []byte("not a directory"), 0644); err != nil {
+				return nil, err
+			}
+			cancel()
+			return nil, ctx.Err()
+		},
+	}
+
+	cfg := config.NewDefaultWalgoConfig()
+	_, err := PerformDeployment(ctx, DeploymentOptions{
+		SitePath:    tempDir,
+		PublishDir:  publicDir,
+		Epochs:      1,
+		WalgoCfg:    &cfg,
+		Quiet:       true,
+		ProjectName: "cancel-test",
+		Deployer:    mock,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if !errors.Is(err, ErrRestoreFailed) {
+		t.Errorf("Expected ErrRestoreFailed, got %v", err)
+	}
+}
+
+// TestPerformDeploymentAlreadyCancelled verifies no upload starts once the context is done.
+func TestPerformDeploymentAlreadyCancelled(t *testing.T) {
+	tempDir, cleanup := createTestSiteDir(t)
+	defer cleanup()
+	t.Setenv("HOME", tempDir)
+
+	mock := &MockDeployer{}
+	cfg := config.NewDefaultWalgoConfig()
+	opts := DeploymentOptions{
+		SitePath:   tempDir,
+		PublishDir: filepath.Join(tempDir, "public"),
+		Epochs:     1,
+		WalgoCfg:   &cfg,
+		Quiet:      true,
+		Deployer:   mock,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := PerformDeployment(ctx, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if mock.DeployCalled || mock.UpdateCalled {
+		t.Error("Deployer should not be called with a cancelled context")
+	}
+}
+
+// deployAndCaptureWSResources runs PerformDeployment as an update of the site
+// in ws-resources.json and returns the file as the deployer saw it.
+func deployAndCaptureWSResources(t *testing.T, opts DeploymentOptions) []byte {
+	t.Helper()
+	wsResourcesPath := filepath.Join(opts.PublishDir, "ws-resources.json")
+	var uploaded []byte
+	mock := &MockDeployer{
+		UpdateFunc: func(ctx context.Context, siteDir, objectID string, _ deployer.DeployOptions) (*deployer.Result, error) {
+			data, err := os.ReadFile(wsResourcesPath)
+			if err != nil {
+				return nil, err
+			}
+			uploaded = data
+			return &deployer.Result{Success: true, ObjectID: objectID}, nil
+		},
+	}
+	opts.Deployer = mock
+	if _, err := PerformDeployment(context.Background(), opts); err != nil {
+		t.Fatalf("PerformDeployment failed: %v", err)
+	}
+	if !mock.UpdateCalled {
+		t.Fatal("expected the site to be updated")
+	}
+	return uploaded
+}
+
+func TestPerformDeploymentMetadataWrites(t *testing.T) {
+	// Hand-formatted, so any rewrite shows up as a diff
+	original := `{"site_name": "meta-test", "object_id": "0xsite",
+  "metadata": {"description": "Same", "link": "` + compress.DefaultLink + `", "project_url": "` + compress.DefaultProjectURL + `",
+    "image_url": "` + compress.DefaultImageURL + `", "creator": "` + compress.DefaultCreator + `", "category": "blog"}}
+`
+	setup := func(t *testing.T) DeploymentOptions {
+		tempDir, cleanup := createTestSiteDir(t)
+		t.Cleanup(cleanup)
+		t.Setenv("HOME", tempDir)
+
+		publicDir := filepath.Join(tempDir, "public")
+		if err := os.WriteFile(filepath.Join(publicDir, "ws-resources.json"), []byte(original), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg := config.NewDefaultWalgoConfig()
+		return DeploymentOptions{
+			SitePath:    tempDir,
+			PublishDir:  publicDir,
+			Epochs:      1,
+			WalgoCfg:    &cfg,
+			Quiet:       true,
+			ProjectName: "meta-test",
+			Description: "Same",
+		}
+	}
+
+	t.Run("unchanged metadata is not rewritten", func(t *testing.T) {
+		opts := setup(t)
+		if uploaded := deployAndCaptureWSResources(t, opts); string(uploaded) != original {
+			t.Errorf("ws-resources.json was rewritten:\n%s", uploaded)
+		}
+		data, _ := os.ReadFile(filepath.Join(opts.PublishDir, "ws-resources.json"))
+		if string(data) != original {
+			t.Errorf("ws-resources.json changed after deploy:\n%s", data)
+		}
+	})
+
+	t.Run("changed metadata is written", func(t *testing.T) {
+		opts := setup(t)
+		opts.Description = "Different"
+		if uploaded := deployAndCaptureWSResources(t, opts); !strings.Contains(string(uploaded), `"description": "Different"`) {
+			t.Errorf("new description not written:\n%s", uploaded)
+		}
+	})
+
+	t.Run("skip metadata leaves different metadata alone", func(t *testing.T) {
+		opts := setup(t)
+		opts.ProjectName = "renamed"
+		opts.Description = "Different"
+		opts.ImageURL = "https://example.com/new.png"
+		opts.SkipMetadata = true
+		if uploaded := deployAndCaptureWSResources(t, opts); string(uploaded) != original {
+			t.Errorf("--skip-metadata rewrote ws-resources.json:\n%s", uploaded)
+		}
+	})
+}
+
+func TestPerformDeploymentRecordsReportedCosts(t *testing.T) {
+	tempDir, cleanup := createTestSiteDir(t)
+	defer cleanup()
+	t.Setenv("HOME", tempDir)
+
+	publicDir := filepath.Join(tempDir, "public")
+	if err := os.WriteFile(filepath.Join(publicDir, "ws-resources.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.NewDefaultWalgoConfig()
+	opts := DeploymentOptions{
+		SitePath:    tempDir,
+		PublishDir:  publicDir,
+		Epochs:      2,
+		WalgoCfg:    &cfg,
+		SaveProject: true, // Only saved when not quiet
+		ProjectName: "cost-test",
+		Network:     "testnet",
+		WalletAddr:  "0xwallet",
+		Deployer: &MockDeployer{
+			DeployFunc: func(ctx context.Context, siteDir string, _ deployer.DeployOptions) (*deployer.Result, error) {
+				return &deployer.Result{Success: true, ObjectID: "0xsite", StorageEpochs: 3, CostWAL: 0.05, GasSUI: 0.004}, nil
+			},
+		},
+	}
+
+	result, err := PerformDeployment(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("PerformDeployment failed: %v", err)
+	}
+	if result.Epochs != 3 || result.ActualWAL != 0.05 || result.ActualGasSUI != 0.004 {
+		t.Errorf("result = %d epochs, %v WAL, %v SUI; want the reported 3, 0.05, 0.004", result.Epochs, result.ActualWAL, result.ActualGasSUI)
+	}
+
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+	proj, err := pm.GetProjectBySitePath(tempDir)
+	if err != nil || proj == nil {
+		t.Fatalf("project not saved: %v", err)
+	}
+	deployments, err := pm.GetProjectDeployments(proj.ID)
+	if err != nil || len(deployments) != 1 {
+		t.Fatalf("deployments = %v, %v", deployments, err)
+	}
+	d := deployments[0]
+	if !d.CostActual || d.CostWAL != 0.05 || d.CostSUI != 0.004 || d.Epochs != 3 {
+		t.Errorf("recorded deployment = %+v, want the reported actuals", d)
+	}
+	if d.GasFee != "0.050000 WAL + 0.004000 SUI" {
+		t.Errorf("GasFee = %q", d.GasFee)
+	}
+}
+
+func TestPerformDeploymentDeletable(t *testing.T) {
+	tempDir, cleanup := createTestSiteDir(t)
+	defer cleanup()
+	t.Setenv("HOME", tempDir)
+
+	publicDir := filepath.Join(tempDir, "public")
+	if err := os.WriteFile(filepath.Join(publicDir, "ws-resources.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.NewDefaultWalgoConfig()
+	mock := &MockDeployer{
+		DeployFunc: func(ctx context.Context, siteDir string, _ deployer.DeployOptions) (*deployer.Result, error) {
+			return &deployer.Result{Success: true, ObjectID: "0xsite"}, nil
+		},
+	}
+	opts := DeploymentOptions{
+		SitePath:    tempDir,
+		PublishDir:  publicDir,
+		Epochs:      1,
+		WalgoCfg:    &cfg,
+		SaveProject: true,
+		ProjectName: "ephemeral",
+		Network:     "testnet",
+		WalletAddr:  "0xwallet",
+		Deployer:    mock,
+		Deletable:   true,
+	}
+
+	if _, err := PerformDeployment(context.Background(), opts); err != nil {
+		t.Fatalf("PerformDeployment failed: %v", err)
+	}
+	if !mock.LastOpts.Deletable {
+		t.Error("Deletable was not passed to the deployer")
+	}
+
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+	proj, err := pm.GetProjectBySitePath(tempDir)
+	if err != nil || proj == nil {
+		t.Fatalf("project not saved: %v", err)
+	}
+	if !proj.Deletable {
+		t.Error("project not recorded as deletable")
+	}
+
+	// Updating the same site without the flag keeps the record deletable,
+	// since the blobs from the first deploy still are
+	opts.Deletable = false
+	if _, err := PerformDeployment(context.Background(), opts); err != nil {
+		t.Fatalf("second PerformDeployment failed: %v", err)
+	}
+	if !mock.UpdateCalled {
+		t.Fatal("second deploy did not update the site")
+	}
+	if mock.LastOpts.Deletable {
+		t.Error("Deletable passed to the deployer without the option")
+	}
+	if proj, err = pm.GetProject(proj.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !proj.Deletable {
+		t.Error("update without Deletable cleared the project's deletable flag")
+	}
+}
+
+func TestPerformDeploymentTargetDir(t *testing.T) {
+	tempDir, cleanup := createTestSiteDir(t)
+	defer cleanup()
+	t.Setenv("HOME", tempDir)
+
+	walgoYAML := filepath.Join(tempDir, "walgo.yaml")
+	yamlContent := "walrus:\n  projectID: 0xmain\n"
+	if err := os.WriteFile(walgoYAML, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	targetDir := filepath.Join(tempDir, "dist", "siteB")
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(targetDir, "index.html"), []byte("<html>B</html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(targetDir, "ws-resources.json"), []byte(`{"object_id":"0xsiteB"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.NewDefaultWalgoConfig()
+	cfg.WalrusConfig.ProjectID = "0xmain"
+	mock := &MockDeployer{
+		UpdateFunc: func(ctx context.Context, siteDir, objectID string, _ deployer.DeployOptions) (*deployer.Result, error) {
+			return &deployer.Result{Success: true, ObjectID: objectID}, nil
+		},
+	}
+	result, err := PerformDeployment(context.Background(), DeploymentOptions{
+		SitePath:   tempDir,
+		PublishDir: targetDir,
+		Epochs:     1,
+		WalgoCfg:   &cfg,
+		Quiet:      true,
+		TargetDir:  true,
+		Deployer:   mock,
+	})
+	if err != nil {
+		t.Fatalf("PerformDeployment failed: %v", err)
+	}
+
+	if !mock.UpdateCalled || mock.LastObjectID != "0xsiteB" || mock.LastSiteDir != targetDir {
+		t.Errorf("update of %q in %s, want the target's own site 0xsiteB", mock.LastObjectID, mock.LastSiteDir)
+	}
+	if result.ObjectID != "0xsiteB" {
+		t.Errorf("ObjectID = %q, want 0xsiteB", result.ObjectID)
+	}
+	if data, _ := os.ReadFile(walgoYAML); string(data) != yamlContent {
+		t.Errorf("walgo.yaml changed:\n%s", data)
+	}
+}
+
+func TestPerformDeploymentRequiresEntrypoint(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	publicDir := filepath.Join(tempDir, "public")
+	if err := os.MkdirAll(publicDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(publicDir, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.NewDefaultWalgoConfig()
+	cfg.WalrusConfig.Entrypoint = "home.html"
+	mock := &MockDeployer{}
+	_, err := PerformDeployment(context.Background(), DeploymentOptions{
+		SitePath:   tempDir,
+		PublishDir: publicDir,
+		Epochs:     1,
+		WalgoCfg:   &cfg,
+		Quiet:      true,
+		Deployer:   mock,
+	})
+	if !errors.Is(err, compress.ErrEntrypointMissing) {
+		t.Fatalf("PerformDeployment() error = %v, want ErrEntrypointMissing", err)
+	}
+	if mock.DeployCalled || mock.UpdateCalled {
+		t.Error("deployer was called for a publish dir without its entrypoint")
+	}
+}
+
+// TestIncrementalCostChargesExpiredBlobs verifies that an unchanged file whose
+// blob outlived the deployment that stored it is priced as an upload, while a
+// file with a live blob is free to keep.
+func TestIncrementalCostChargesExpiredBlobs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	sitePath := t.TempDir()
+	publishDir := filepath.Join(sitePath, "public")
+	if err := os.MkdirAll(publishDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"old.html": "old", "live.html": "live"} {
+		if err := os.WriteFile(filepath.Join(publishDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	helper, err := cache.NewDeployHelper(sitePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer helper.Close()
+	if err := helper.FinalizeDeployment(publishDir, "0xsite", "0xsite", map[string]string{"old.html": "blob-old", "live.html": "blob-live"}); err != nil {
+		t.Fatal(err)
+	}
+
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj := &projects.Project{Name: "leases", Network: "testnet", ObjectID: "0xsite", SitePath: sitePath}
+	if err := pm.CreateProject(proj); err != nil {
+		t.Fatal(err)
+	}
+	for blobID, epochs := range map[string]int{"blob-old": 5, "blob-live": 50} {
+		record := &projects.DeploymentRecord{ProjectID: proj.ID, ObjectID: "0xsite", Network: "testnet", Epochs: epochs, Success: true,
+			FileToBlobID: map[string]string{blobID: blobID}}
+		if err := pm.RecordDeployment(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pm.Close()
+
+	// Twenty daily epochs have passed since the deployments: blob-old was
+	// stored for 5 of them and has expired, blob-live for 50.
+	stubEpochInfo(t, &walrus.StorageInfo{
+		CurrentEpoch:      100,
+		CurrentEpochStart: time.Now().Add(20 * 24 * time.Hour),
+		EpochDuration:     24 * 60 * 60,
+	})
+
+	plan, err := helper.PrepareDeployment(publishDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	est, err := incrementalCost(helper, plan, DeploymentOptions{SitePath: sitePath, Network: "testnet", Epochs: 3})
+	if err != nil {
+		t.Fatalf("incrementalCost() error = %v", err)
+	}
+	if est.ExpiredFiles != 1 || est.UploadFiles != 1 || est.ReusedFiles != 1 {
+		t.Errorf("expired/upload/reused = %d/%d/%d, want 1/1/1", est.ExpiredFiles, est.UploadFiles, est.ReusedFiles)
+	}
+	if est.TotalWAL <= 0 {
+		t.Errorf("TotalWAL = %v, want the expired blob charged", est.TotalWAL)
+	}
+}
@@ -0,0 +1,168 @@
+package deployment
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultBuildManifest is the manifest read by --verify-build-manifest when
+// hugo.buildManifest is not set, relative to the site root.
+const DefaultBuildManifest = "build-manifest.json"
+
+// ErrBuildManifestMismatch is returned when the publish directory does not
+// match the build manifest.
+var ErrBuildManifestMismatch = errors.New("publish directory does not match the build manifest")
+
+// ManifestMismatch is a file whose content differs from the build manifest.
+type ManifestMismatch struct {
+	Path string
+	Want string // Hash recorded in the manifest
+	Got  string // Hash of the file, in the same format
+}
+
+// ManifestReport is the outcome of checking a publish directory against a
+// build manifest.
+type ManifestReport struct {
+	Checked    int
+	Missing    []string           // Listed in the manifest but not in the publish directory
+	Mismatched []ManifestMismatch // Present but with different content
+}
+
+// OK reports whether every file in the manifest was found with the recorded
+// content.
+func (r *ManifestReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Mismatched) == 0
+}
+
+// Err returns ErrBuildManifestMismatch listing the offending files, or nil
+// when the report is OK.
+func (r *ManifestReport) Err() error {
+	if r.OK() {
+		return nil
+	}
+	var b strings.Builder
+	for _, p := range r.Missing {
+		fmt.Fprintf(&b, "\n  missing:  %s", p)
+	}
+	for _, m := range r.Mismatched {
+		fmt.Fprintf(&b, "\n  modified: %s", m.Path)
+	}
+	return fmt.Errorf("%w (%d missing, %d modified):%s", ErrBuildManifestMismatch, len(r.Missing), len(r.Mismatched), b.String())
+}
+
+// LoadBuildManifest reads a build manifest: a JSON object mapping file paths,
+// relative to the publish directory, to their hashes. The map may also be
+// nested under a "files" key. Hashes are either Subresource Integrity values
+// such as Hugo's .Data.Integrity ("sha256-<base64>", also sha384 and sha512)
+// or hex digests, optionally prefixed with "sha256:".
+func LoadBuildManifest(manifestPath string) (map[string]string, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build manifest: %w", err)
+	}
+
+	var nested struct {
+		Files map[string]string `json:"files"`
+	}
+	if err := json.Unmarshal(data, &nested); err == nil && nested.Files != nil {
+		return nested.Files, nil
+	}
+	var files map[string]string
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("failed to parse build manifest %s: %w", manifestPath, err)
+	}
+	return files, nil
+}
+
+// VerifyBuildManifest hashes every file listed in manifest under publishDir
+// and reports the files that are missing or differ. Files not listed in the
+// manifest are not checked.
+func VerifyBuildManifest(publishDir string, manifest map[string]string) (*ManifestReport, error) {
+	report := &ManifestReport{}
+
+	paths := make([]string, 0, len(manifest))
+	for p := range manifest {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		rel := path.Clean(strings.TrimPrefix(filepath.ToSlash(p), "/"))
+		if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+			return nil, fmt.Errorf("build manifest path %q is outside the publish directory", p)
+		}
+		newHash, encode, want, err := manifestHashFormat(strings.TrimSpace(manifest[p]))
+		if err != nil {
+			return nil, fmt.Errorf("build manifest entry %q: %w", p, err)
+		}
+
+		report.Checked++
+		got, err := hashFile(filepath.Join(publishDir, filepath.FromSlash(rel)), newHash(), encode)
+		if os.IsNotExist(err) {
+			report.Missing = append(report.Missing, rel)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if got != want {
+			report.Mismatched = append(report.Mismatched, ManifestMismatch{Path: rel, Want: want, Got: got})
+		}
+	}
+	return report, nil
+}
+
+// manifestHashFormat returns the hash function a manifest value was made
+// with, how to encode a digest for comparison, and the value in that same
+// encoding.
+func manifestHashFormat(value string) (func() hash.Hash, func([]byte) string, string, error) {
+	sri := map[string]func() hash.Hash{
+		"sha256": sha256.New,
+		"sha384": sha512.New384,
+		"sha512": sha512.New,
+	}
+	if algo, digest, ok := strings.Cut(value, "-"); ok {
+		if newHash, known := sri[strings.ToLower(algo)]; known {
+			if _, err := base64.StdEncoding.DecodeString(digest); err != nil {
+				return nil, nil, "", fmt.Errorf("invalid integrity value %q", value)
+			}
+			prefix := strings.ToLower(algo) + "-"
+			return newHash, func(sum []byte) string { return prefix + base64.StdEncoding.EncodeToString(sum) }, prefix + digest, nil
+		}
+	}
+
+	digest := strings.ToLower(value)
+	digest = strings.TrimPrefix(digest, "sha256:")
+	hexSizes := map[int]func() hash.Hash{64: sha256.New, 96: sha512.New384, 128: sha512.New}
+	if _, err := hex.DecodeString(digest); err == nil {
+		if newHash, ok := hexSizes[len(digest)]; ok {
+			return newHash, hex.EncodeToString, digest, nil
+		}
+	}
+	return nil, nil, "", fmt.Errorf("unrecognized hash %q (want sha256-<base64> or a hex digest)", value)
+}
+
+// hashFile returns the encoded digest of the file at p.
+func hashFile(p string, h hash.Hash, encode func([]byte) string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", p, err)
+	}
+	return encode(h.Sum(nil)), nil
+}
@@ -0,0 +1,142 @@
+package deployment
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writePublishDir creates files under a temp publish dir.
+func writePublishDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func sriSHA256(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestVerifyBuildManifest_Match(t *testing.T) {
+	dir := writePublishDir(t, map[string]string{
+		"index.html":          "<html>home</html>",
+		"css/main.abc123.css": "body{}",
+		"js/app.js":           "console.log(1)",
+		"unlisted.txt":        "not in the manifest",
+	})
+	sha384 := sha512.Sum384([]byte("console.log(1)"))
+	hexSum := sha256.Sum256([]byte("<html>home</html>"))
+
+	report, err := VerifyBuildManifest(dir, map[string]string{
+		"/css/main.abc123.css": sriSHA256("body{}"),
+		"js/app.js":            "sha384-" + base64.StdEncoding.EncodeToString(sha384[:]),
+		"index.html":           "sha256:" + strings.ToUpper(hex.EncodeToString(hexSum[:])),
+	})
+	if err != nil {
+		t.Fatalf("VerifyBuildManifest() error = %v", err)
+	}
+	if !report.OK() || report.Err() != nil {
+		t.Fatalf("report = %+v, want a match", report)
+	}
+	if report.Checked != 3 {
+		t.Errorf("Checked = %d, want 3", report.Checked)
+	}
+}
+
+func TestVerifyBuildManifest_Mismatch(t *testing.T) {
+	dir := writePublishDir(t, map[string]string{
+		"index.html":   "<html>tampered</html>",
+		"css/main.css": "body{}",
+	})
+
+	report, err := VerifyBuildManifest(dir, map[string]string{
+		"index.html":   sriSHA256("<html>home</html>"),
+		"css/main.css": sriSHA256("body{}"),
+		"js/app.js":    sriSHA256("console.log(1)"),
+	})
+	if err != nil {
+		t.Fatalf("VerifyBuildManifest() error = %v", err)
+	}
+	if report.OK() {
+		t.Fatal("expected a mismatch")
+	}
+	if strings.Join(report.Missing, ",") != "js/app.js" {
+		t.Errorf("Missing = %v, want js/app.js", report.Missing)
+	}
+	if len(report.Mismatched) != 1 || report.Mismatched[0].Path != "index.html" {
+		t.Fatalf("Mismatched = %+v, want index.html", report.Mismatched)
+	}
+	if report.Mismatched[0].Got != sriSHA256("<html>tampered</html>") {
+		t.Errorf("Got = %s, want the tampered file's hash", report.Mismatched[0].Got)
+	}
+
+	err = report.Err()
+	if !errors.Is(err, ErrBuildManifestMismatch) {
+		t.Fatalf("Err() = %v, want ErrBuildManifestMismatch", err)
+	}
+	for _, want := range []string{"missing:  js/app.js", "modified: index.html", "1 missing, 1 modified"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Err() = %q, want it to contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "css/main.css") {
+		t.Errorf("Err() lists a matching file: %q", err)
+	}
+}
+
+func TestVerifyBuildManifest_InvalidEntries(t *testing.T) {
+	dir := writePublishDir(t, map[string]string{"index.html": "x"})
+
+	for name, manifest := range map[string]map[string]string{
+		"escaping path":  {"../secret": sriSHA256("x")},
+		"unknown hash":   {"index.html": "md5-abc"},
+		"bad hex length": {"index.html": "abcd"},
+		"bad base64":     {"index.html": "sha256-!!!"},
+	} {
+		if _, err := VerifyBuildManifest(dir, manifest); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestLoadBuildManifest(t *testing.T) {
+	dir := t.TempDir()
+	flat := filepath.Join(dir, "flat.json")
+	nested := filepath.Join(dir, "nested.json")
+	broken := filepath.Join(dir, "broken.json")
+	_ = os.WriteFile(flat, []byte(`{"index.html": "sha256-abc="}`), 0644)
+	_ = os.WriteFile(nested, []byte(`{"version": 1, "files": {"index.html": "sha256-abc="}}`), 0644)
+	_ = os.WriteFile(broken, []byte(`{`), 0644)
+
+	for _, path := range []string{flat, nested} {
+		files, err := LoadBuildManifest(path)
+		if err != nil {
+			t.Fatalf("LoadBuildManifest(%s) error = %v", filepath.Base(path), err)
+		}
+		if files["index.html"] != "sha256-abc=" {
+			t.Errorf("LoadBuildManifest(%s) = %v", filepath.Base(path), files)
+		}
+	}
+	if _, err := LoadBuildManifest(broken); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	if _, err := LoadBuildManifest(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for a missing manifest")
+	}
+}
@@ -0,0 +1,107 @@
+package deployment
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/projects"
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// epochInfoSource reads the current epoch's start and length; replaced in tests.
+var epochInfoSource = func() (*walrus.StorageInfo, error) {
+	return walrus.GetStorageInfo("")
+}
+
+// PrometheusLabels identify the site a Prometheus report describes.
+type PrometheusLabels struct {
+	Project string
+	Network string
+}
+
+// PrometheusMetrics renders a deployment result in the Prometheus text
+// exposition format, as read by node_exporter's textfile collector.
+// walgo_deploy_cost_wal is left out when the WAL spent is unknown, and
+// walgo_site_expiry_timestamp when the storage epochs are.
+func PrometheusMetrics(result *DeploymentResult, labels PrometheusLabels) string {
+	var b strings.Builder
+	labelSet := fmt.Sprintf(`{project="%s",network="%s"}`, escapeLabelValue(labels.Project), escapeLabelValue(labels.Network))
+
+	writeGauge := func(name, help, value string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(&b, "%s%s %s\n", name, labelSet, value)
+	}
+
+	writeGauge("walgo_site_size_bytes", "Size of the deployed site in bytes.", fmt.Sprintf("%d", result.SiteSize))
+	if result.ActualWAL > 0 {
+		writeGauge("walgo_deploy_cost_wal", "WAL spent by the last deploy.", fmt.Sprintf("%g", result.ActualWAL))
+	}
+	if expiresAt, ok := deployExpiry(result, labels.Network); ok {
+		writeGauge("walgo_site_expiry_timestamp", "Unix time at which the site's storage expires.", fmt.Sprintf("%d", expiresAt.Unix()))
+	}
+	return b.String()
+}
+
+// deployExpiry returns when the storage bought by a deploy runs out. Storage
+// ends at an epoch boundary, so its epochs are counted from the start of the
+// epoch the deploy completed in. When walrus info is unavailable they are
+// counted from the completion time instead, which can be up to an epoch late.
+func deployExpiry(result *DeploymentResult, network string) (time.Time, bool) {
+	if result.Epochs <= 0 {
+		return time.Time{}, false
+	}
+	completedAt := result.CompletedAt
+	if completedAt.IsZero() {
+		completedAt = time.Now()
+	}
+
+	if info, err := epochInfoSource(); err == nil && !info.CurrentEpochStart.IsZero() && info.EpochDuration > 0 {
+		length := time.Duration(info.EpochDuration) * time.Second
+		start := info.CurrentEpochStart
+		// The epoch may have advanced since the deploy completed.
+		for start.After(completedAt) {
+			start = start.Add(-length)
+		}
+		return start.Add(time.Duration(result.Epochs) * length), true
+	}
+	return completedAt.Add(time.Duration(result.Epochs) * projects.EpochLength(network)), true
+}
+
+// escapeLabelValue escapes a label value for the text exposition format.
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// WritePrometheusReport writes the metrics for result to path. The file is
+// written next to path and renamed into place, so the textfile collector
+// never reads a partial file.
+func WritePrometheusReport(path string, result *DeploymentResult, labels PrometheusLabels) error {
+	if path == "" {
+		return fmt.Errorf("prometheus report path cannot be empty")
+	}
+	if result == nil {
+		return fmt.Errorf("no deployment result to report")
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		// #nosec G301 - report directory needs standard permissions
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+
+	tmp := path + ".tmp"
+	// #nosec G306 - metrics are a non-sensitive report
+	if err := os.WriteFile(tmp, []byte(PrometheusMetrics(result, labels)), 0644); err != nil {
+		return fmt.Errorf("failed to write prometheus report: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write prometheus report: %w", err)
+	}
+	return nil
+}
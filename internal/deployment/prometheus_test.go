@@ -0,0 +1,127 @@
+package deployment
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// stubEpochInfo makes deployExpiry and incrementalCost see info, or fail to
+// read it when info is nil.
+func stubEpochInfo(t *testing.T, info *walrus.StorageInfo) {
+	t.Helper()
+	orig := epochInfoSource
+	epochInfoSource = func() (*walrus.StorageInfo, error) {
+		if info == nil {
+			return nil, errors.New("walrus not installed")
+		}
+		return info, nil
+	}
+	t.Cleanup(func() { epochInfoSource = orig })
+}
+
+func TestWritePrometheusReport(t *testing.T) {
+	stubEpochInfo(t, nil)
+	result := mockSummaryResult()
+	result.ActualWAL = 0.0125
+	path := filepath.Join(t.TempDir(), "textfile", "walgo.prom")
+
+	labels := PrometheusLabels{Project: "my-blog", Network: "testnet"}
+	if err := WritePrometheusReport(path, result, labels); err != nil {
+		t.Fatalf("WritePrometheusReport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	content := string(data)
+
+	// Without walrus info: 5 testnet epochs (1 day each) from 2025-01-02T03:04:05Z
+	for _, want := range []string{
+		"# TYPE walgo_site_size_bytes gauge\n",
+		`walgo_site_size_bytes{project="my-blog",network="testnet"} 2097152` + "\n",
+		"# TYPE walgo_deploy_cost_wal gauge\n",
+		`walgo_deploy_cost_wal{project="my-blog",network="testnet"} 0.0125` + "\n",
+		"# TYPE walgo_site_expiry_timestamp gauge\n",
+		`walgo_site_expiry_timestamp{project="my-blog",network="testnet"} 1736219045` + "\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Report missing %q:\n%s", want, content)
+		}
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("temporary file should be renamed into place")
+	}
+}
+
+func TestPrometheusMetrics_MainnetExpiry(t *testing.T) {
+	stubEpochInfo(t, nil)
+	result := mockSummaryResult()
+	result.Epochs = 2
+
+	content := PrometheusMetrics(result, PrometheusLabels{Project: "site", Network: "mainnet"})
+	// 2 mainnet epochs (2 weeks each) from 2025-01-02T03:04:05Z
+	if want := `walgo_site_expiry_timestamp{project="site",network="mainnet"} 1738206245`; !strings.Contains(content, want) {
+		t.Errorf("Report missing %q:\n%s", want, content)
+	}
+}
+
+func TestPrometheusMetrics_ExpiryFromEpochStart(t *testing.T) {
+	tests := []struct {
+		name       string
+		epochStart time.Time
+	}{
+		{"deploy in the current epoch", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"epoch advanced since the deploy", time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubEpochInfo(t, &walrus.StorageInfo{CurrentEpochStart: tt.epochStart, EpochDuration: 86400})
+
+			// The deploy completed at 2025-01-02T03:04:05Z, in the epoch that
+			// started 2025-01-01T12:00:00Z; 5 epochs later is 2025-01-06T12:00:00Z.
+			content := PrometheusMetrics(mockSummaryResult(), PrometheusLabels{Project: "site", Network: "testnet"})
+			if want := `walgo_site_expiry_timestamp{project="site",network="testnet"} 1736164800`; !strings.Contains(content, want) {
+				t.Errorf("Report missing %q:\n%s", want, content)
+			}
+		})
+	}
+}
+
+func TestPrometheusMetrics_OmitsUnknownValues(t *testing.T) {
+	result := mockSummaryResult()
+	result.ActualWAL = 0
+	result.Epochs = 0
+
+	content := PrometheusMetrics(result, PrometheusLabels{Project: "site", Network: "testnet"})
+	if !strings.Contains(content, "walgo_site_size_bytes{") {
+		t.Errorf("size metric missing:\n%s", content)
+	}
+	for _, metric := range []string{"walgo_deploy_cost_wal", "walgo_site_expiry_timestamp"} {
+		if strings.Contains(content, metric) {
+			t.Errorf("%s should be omitted when unknown:\n%s", metric, content)
+		}
+	}
+}
+
+func TestPrometheusMetrics_EscapesLabels(t *testing.T) {
+	content := PrometheusMetrics(mockSummaryResult(), PrometheusLabels{Project: `my "site"\n`, Network: "testnet"})
+	if want := `project="my \"site\"\\n"`; !strings.Contains(content, want) {
+		t.Errorf("Report missing escaped label %q:\n%s", want, content)
+	}
+}
+
+func TestWritePrometheusReportErrors(t *testing.T) {
+	if err := WritePrometheusReport("", mockSummaryResult(), PrometheusLabels{}); err == nil {
+		t.Error("Expected error for empty path")
+	}
+	if err := WritePrometheusReport(filepath.Join(t.TempDir(), "walgo.prom"), nil, PrometheusLabels{}); err == nil {
+		t.Error("Expected error for nil result")
+	}
+}
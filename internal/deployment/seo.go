@@ -0,0 +1,26 @@
+package deployment
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// applySEOHeaders sets crawler-friendly headers on robots.txt and sitemap.xml
+// in ws-resources.json. With strict set, a missing root robots.txt or
+// sitemap.xml is reported to warn; the deploy goes ahead either way.
+func applySEOHeaders(wsResourcesPath string, strict bool, warn io.Writer) error {
+	_, missing, err := compress.ApplySEOHeaders(wsResourcesPath)
+	if err != nil {
+		return fmt.Errorf("failed to set robots.txt/sitemap.xml headers: %w", err)
+	}
+	if strict && len(missing) > 0 {
+		icons := ui.GetIcons()
+		fmt.Fprintf(warn, "%s Warning: %s missing from the publish directory; search engines will not find it\n",
+			icons.Warning, strings.Join(missing, " and "))
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/deployer"
+)
+
+func TestApplySEOHeadersWarnsWhenStrict(t *testing.T) {
+	publishDir := t.TempDir()
+	wsPath := filepath.Join(publishDir, "ws-resources.json")
+	if err := os.WriteFile(wsPath, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(publishDir, "sitemap.xml"), []byte("<urlset/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var warn bytes.Buffer
+	if err := applySEOHeaders(wsPath, false, &warn); err != nil {
+		t.Fatalf("applySEOHeaders() error = %v", err)
+	}
+	if warn.Len() != 0 {
+		t.Errorf("no warning expected without strict mode, got %q", warn.String())
+	}
+
+	if err := applySEOHeaders(wsPath, true, &warn); err != nil {
+		t.Fatalf("applySEOHeaders() error = %v", err)
+	}
+	if !strings.Contains(warn.String(), "/robots.txt missing") || strings.Contains(warn.String(), "sitemap") {
+		t.Errorf("warning = %q, want only robots.txt reported", warn.String())
+	}
+
+	headers, err := compress.ListHeaders(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers["/sitemap.xml"]["Cache-Control"] != compress.SEOCacheControl {
+		t.Errorf("sitemap.xml headers = %v", headers["/sitemap.xml"])
+	}
+}
+
+func TestPerformDeploymentSkipMetadataWithoutWSResources(t *testing.T) {
+	opts, mock := setupCanarySite(t)
+	wsPath := filepath.Join(opts.PublishDir, "ws-resources.json")
+	if err := os.Remove(wsPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(opts.PublishDir, "robots.txt"), []byte("User-agent: *\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opts.SkipMetadata = true
+	opts.WalgoCfg.WalrusConfig.ProjectID = ""
+	var hadWSResources bool
+	deploy := mock.DeployFunc
+	mock.DeployFunc = func(ctx context.Context, siteDir string, dopts deployer.DeployOptions) (*deployer.Result, error) {
+		_, err := os.Stat(wsPath)
+		hadWSResources = err == nil
+		// site-builder writes the object ID of a new site to ws-resources.json
+		if err := os.WriteFile(wsPath, []byte("{}\n"), 0644); err != nil {
+			return nil, err
+		}
+		return deploy(ctx, siteDir, dopts)
+	}
+
+	if _, err := PerformDeployment(context.Background(), opts); err != nil {
+		t.Fatalf("PerformDeployment() error = %v", err)
+	}
+	if !mock.DeployCalled {
+		t.Error("the site should still be uploaded")
+	}
+	if hadWSResources {
+		t.Error("ws-resources.json should not be created before the upload")
+	}
+}
+
+func TestPerformDeploymentRestoresWSResourcesOnMetadataError(t *testing.T) {
+	opts, mock := setupCanarySite(t)
+	opts.NotFoundPage = "missing.html"
+
+	if _, err := PerformDeployment(context.Background(), opts); err == nil {
+		t.Fatal("PerformDeployment() should fail on a missing 404 page")
+	}
+	if mock.DeployCalled || mock.UpdateCalled {
+		t.Error("nothing should be uploaded")
+	}
+	ws, err := os.ReadFile(filepath.Join(opts.PublishDir, "ws-resources.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(ws) != canaryWSResources {
+		t.Errorf("ws-resources.json should be restored after the failed step:\n%s", ws)
+	}
+}
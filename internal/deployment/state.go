@@ -0,0 +1,207 @@
+package deployment
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/projects"
+)
+
+// StateIssueKind names a way ws-resources.json can be left inconsistent by a
+// deploy that failed part way.
+type StateIssueKind string
+
+const (
+	// StateObjectIDMismatch: object_id differs from walgo.yaml's projectID.
+	// A deploy writes ws-resources.json first, so a crash before walgo.yaml
+	// is updated leaves the two apart.
+	StateObjectIDMismatch StateIssueKind = "object_id_mismatch"
+	// StateObjectIDUnconfirmed: the project tracking the site has no
+	// successful deployment to object_id.
+	StateObjectIDUnconfirmed StateIssueKind = "object_id_unconfirmed"
+	// StateMissingRouteTarget: a route points at a file that is not in the
+	// publish directory.
+	StateMissingRouteTarget StateIssueKind = "missing_route_target"
+)
+
+// StateIssue is one inconsistency found in ws-resources.json.
+type StateIssue struct {
+	Kind   StateIssueKind
+	Route  string // Route pattern, for StateMissingRouteTarget
+	Detail string
+}
+
+// SiteState is what is known about a site outside ws-resources.json.
+type SiteState struct {
+	ConfigObjectID  string                       // walgo.yaml projectID, "" when unset
+	Tracked         bool                         // A project tracks the site, so Deployments is its full history
+	ProjectObjectID string                       // Site object that project records
+	Deployments     []*projects.DeploymentRecord // Deployment history of that project
+}
+
+// TrustedObjectID is the site object a repair keeps: walgo.yaml's projectID,
+// else the object of the latest successful deployment, else the object the
+// project records, else none.
+func (s SiteState) TrustedObjectID() string {
+	if s.ConfigObjectID != "" {
+		return s.ConfigObjectID
+	}
+	var latest *projects.DeploymentRecord
+	for _, d := range s.Deployments {
+		if d.Success && d.ObjectID != "" && (latest == nil || d.CreatedAt.After(latest.CreatedAt)) {
+			latest = d
+		}
+	}
+	if latest == nil {
+		return s.ProjectObjectID
+	}
+	return latest.ObjectID
+}
+
+// StateReport is the outcome of checking ws-resources.json for leftovers of
+// a failed deploy.
+type StateReport struct {
+	ObjectID string // object_id found in ws-resources.json
+	Issues   []StateIssue
+}
+
+// OK reports whether no inconsistency was found.
+func (r *StateReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// HasObjectIDIssue reports whether object_id cannot be trusted.
+func (r *StateReport) HasObjectIDIssue() bool {
+	for _, issue := range r.Issues {
+		if issue.Kind != StateMissingRouteTarget {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckWSResourcesState looks for signs that the publish directory's
+// ws-resources.json was left behind by a failed deploy: an object_id that
+// walgo.yaml or the project's deployment history do not back up, and routes
+// to files that do not exist. A missing ws-resources.json is consistent.
+func CheckWSResourcesState(publishDir string, state SiteState) (*StateReport, error) {
+	wsResourcesPath := filepath.Join(publishDir, compress.WSResourcesFile)
+	if _, err := os.Stat(wsResourcesPath); os.IsNotExist(err) {
+		return &StateReport{}, nil
+	}
+	wsConfig, err := compress.ReadWSResourcesConfig(wsResourcesPath)
+	if err != nil {
+		return nil, fmt.Errorf("ws-resources.json is unreadable, possibly left by a failed deploy: %w", err)
+	}
+
+	report := &StateReport{ObjectID: strings.TrimSpace(wsConfig.ObjectID)}
+	if issue, ok := objectIDIssue(report.ObjectID, state); ok {
+		report.Issues = append(report.Issues, issue)
+	}
+
+	patterns := make([]string, 0, len(wsConfig.Routes))
+	for pattern := range wsConfig.Routes {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		target := wsConfig.Routes[pattern]
+		if !strings.HasPrefix(target, "/") {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(publishDir, filepath.FromSlash(strings.TrimPrefix(target, "/"))))
+		if err == nil && !info.IsDir() {
+			continue
+		}
+		report.Issues = append(report.Issues, StateIssue{
+			Kind:   StateMissingRouteTarget,
+			Route:  pattern,
+			Detail: fmt.Sprintf("route %s points at %s, which is not in the publish directory", pattern, target),
+		})
+	}
+	return report, nil
+}
+
+// objectIDIssue checks objectID against walgo.yaml and, when walgo.yaml has
+// none, against the tracking project. For an untracked site without a
+// projectID there is nothing to check against.
+func objectIDIssue(objectID string, state SiteState) (StateIssue, bool) {
+	if objectID == "" {
+		return StateIssue{}, false
+	}
+	if state.ConfigObjectID != "" {
+		if state.ConfigObjectID == objectID {
+			return StateIssue{}, false
+		}
+		return StateIssue{
+			Kind:   StateObjectIDMismatch,
+			Detail: fmt.Sprintf("object_id %s does not match walgo.yaml projectID %s", objectID, state.ConfigObjectID),
+		}, true
+	}
+	if !state.Tracked || state.ProjectObjectID == objectID {
+		return StateIssue{}, false
+	}
+	for _, d := range state.Deployments {
+		if d.Success && d.ObjectID == objectID {
+			return StateIssue{}, false
+		}
+	}
+	return StateIssue{
+		Kind:   StateObjectIDUnconfirmed,
+		Detail: fmt.Sprintf("object_id %s has no successful deployment in the project history", objectID),
+	}, true
+}
+
+// RepairWSResourcesState fixes the issues in report: routes to missing files
+// are removed and, when object_id is in doubt, it is set to objectID (removed
+// when objectID is empty, so the next deploy creates a new site).
+func RepairWSResourcesState(publishDir string, report *StateReport, objectID string) error {
+	wsResourcesPath := filepath.Join(publishDir, compress.WSResourcesFile)
+
+	var stale []string
+	for _, issue := range report.Issues {
+		if issue.Kind == StateMissingRouteTarget {
+			stale = append(stale, issue.Route)
+		}
+	}
+	if err := compress.RemoveRoutes(wsResourcesPath, stale); err != nil {
+		return fmt.Errorf("failed to remove stale routes: %w", err)
+	}
+
+	if report.HasObjectIDIssue() {
+		if err := compress.UpdateObjectID(wsResourcesPath, objectID); err != nil {
+			return fmt.Errorf("failed to reset object_id: %w", err)
+		}
+	}
+	return nil
+}
+
+// UnsyncedObjectID returns the object_id of the publish directory's
+// ws-resources.json when walgo.yaml still has no projectID, so the two can be
+// reconciled by writing it into walgo.yaml. It returns "" when walgo.yaml
+// already has a projectID or ws-resources.json has no object_id.
+func UnsyncedObjectID(publishDir string, walgoCfg *config.WalgoConfig) string {
+	if walgoCfg == nil || !config.IsPlaceholderProjectID(walgoCfg.WalrusConfig.ProjectID) {
+		return ""
+	}
+	wsConfig, err := compress.ReadWSResourcesConfig(filepath.Join(publishDir, compress.WSResourcesFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(wsConfig.ObjectID)
+}
+
+// SyncConfigObjectID writes objectID into walgo.yaml's projectID, keeping
+// the rest of the file, and updates walgoCfg to match.
+func SyncConfigObjectID(sitePath string, walgoCfg *config.WalgoConfig, objectID string) error {
+	if err := config.UpdateWalgoYAMLProjectID(sitePath, objectID); err != nil {
+		return err
+	}
+	walgoCfg.WalrusConfig.ProjectID = objectID
+	return nil
+}
@@ -0,0 +1,244 @@
+package deployment
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/projects"
+)
+
+// writePartialSite builds a publish directory whose ws-resources.json looks
+// like a deploy died after writing it: object_id 0xpartial and a route to a
+// page that was never built.
+func writePartialSite(t *testing.T) string {
+	t.Helper()
+	publishDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(publishDir, "about"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"index.html", "about/index.html"} {
+		if err := os.WriteFile(filepath.Join(publishDir, name), []byte("<html></html>"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ws := `{
+  "headers": {"/index.html": {"Content-Type": "text/html; charset=utf-8"}},
+  "routes": {"/about": "/about/index.html", "/blog/*": "/blog/index.html", "/ext": "https://example.com"},
+  "site_name": "Partial",
+  "object_id": "0xpartial"
+}`
+	if err := os.WriteFile(filepath.Join(publishDir, compress.WSResourcesFile), []byte(ws), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return publishDir
+}
+
+func issueKinds(report *StateReport) []StateIssueKind {
+	kinds := make([]StateIssueKind, len(report.Issues))
+	for i, issue := range report.Issues {
+		kinds[i] = issue.Kind
+	}
+	return kinds
+}
+
+func TestCheckWSResourcesState(t *testing.T) {
+	success := &projects.DeploymentRecord{ObjectID: "0xpartial", Success: true, CreatedAt: time.Now()}
+	failed := &projects.DeploymentRecord{ObjectID: "0xpartial", Success: false, CreatedAt: time.Now()}
+
+	tests := []struct {
+		name         string
+		state        SiteState
+		wantObjectID StateIssueKind // "" when object_id is consistent
+	}{
+		{"walgo.yaml agrees", SiteState{ConfigObjectID: "0xpartial"}, ""},
+		{"walgo.yaml disagrees", SiteState{ConfigObjectID: "0xold"}, StateObjectIDMismatch},
+		{"tracked with a successful deploy", SiteState{Tracked: true, Deployments: []*projects.DeploymentRecord{success}}, ""},
+		{"tracked with only a failed deploy", SiteState{Tracked: true, Deployments: []*projects.DeploymentRecord{failed}}, StateObjectIDUnconfirmed},
+		{"tracked without history", SiteState{Tracked: true}, StateObjectIDUnconfirmed},
+		{"project records the object", SiteState{Tracked: true, ProjectObjectID: "0xpartial"}, ""},
+		{"untracked without projectID", SiteState{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := CheckWSResourcesState(writePartialSite(t), tt.state)
+			if err != nil {
+				t.Fatalf("CheckWSResourcesState() error = %v", err)
+			}
+			if report.ObjectID != "0xpartial" {
+				t.Errorf("ObjectID = %q", report.ObjectID)
+			}
+
+			want := []StateIssueKind{}
+			if tt.wantObjectID != "" {
+				want = append(want, tt.wantObjectID)
+			}
+			want = append(want, StateMissingRouteTarget)
+			got := issueKinds(report)
+			if len(got) != len(want) {
+				t.Fatalf("issues = %+v, want kinds %v", report.Issues, want)
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("issue %d = %s, want %s", i, got[i], want[i])
+				}
+			}
+			if last := report.Issues[len(report.Issues)-1]; last.Route != "/blog/*" {
+				t.Errorf("missing route = %q, want /blog/*", last.Route)
+			}
+			if report.HasObjectIDIssue() != (tt.wantObjectID != "") {
+				t.Errorf("HasObjectIDIssue() = %v", report.HasObjectIDIssue())
+			}
+		})
+	}
+}
+
+func TestCheckWSResourcesState_NoFile(t *testing.T) {
+	report, err := CheckWSResourcesState(t.TempDir(), SiteState{Tracked: true})
+	if err != nil || !report.OK() {
+		t.Errorf("report = %+v, err = %v; want a missing file to be consistent", report, err)
+	}
+}
+
+func TestCheckWSResourcesState_Truncated(t *testing.T) {
+	publishDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(publishDir, compress.WSResourcesFile), []byte(`{"routes": {"/a": "/a.ht`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CheckWSResourcesState(publishDir, SiteState{}); err == nil {
+		t.Error("CheckWSResourcesState() should fail on a truncated file")
+	}
+}
+
+func TestRepairWSResourcesState(t *testing.T) {
+	publishDir := writePartialSite(t)
+	state := SiteState{Tracked: true, Deployments: []*projects.DeploymentRecord{
+		{ObjectID: "0xolder", Success: true, CreatedAt: time.Now().Add(-48 * time.Hour)},
+		{ObjectID: "0xgood", Success: true, CreatedAt: time.Now().Add(-time.Hour)},
+		{ObjectID: "0xpartial", Success: false, CreatedAt: time.Now()},
+	}}
+
+	report, err := CheckWSResourcesState(publishDir, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := state.TrustedObjectID(); got != "0xgood" {
+		t.Fatalf("TrustedObjectID() = %q, want the latest successful deploy", got)
+	}
+	if err := RepairWSResourcesState(publishDir, report, state.TrustedObjectID()); err != nil {
+		t.Fatalf("RepairWSResourcesState() error = %v", err)
+	}
+
+	cfg, err := compress.ReadWSResourcesConfig(filepath.Join(publishDir, compress.WSResourcesFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ObjectID != "0xgood" {
+		t.Errorf("object_id = %q, want 0xgood", cfg.ObjectID)
+	}
+	if _, ok := cfg.Routes["/blog/*"]; ok {
+		t.Error("route to a missing file should be removed")
+	}
+	if cfg.Routes["/about"] != "/about/index.html" || cfg.Routes["/ext"] != "https://example.com" || cfg.SiteName != "Partial" {
+		t.Errorf("unrelated content should be kept: %+v", cfg)
+	}
+
+	report, err = CheckWSResourcesState(publishDir, state)
+	if err != nil || !report.OK() {
+		t.Errorf("after repair: issues = %+v, err = %v", report.Issues, err)
+	}
+}
+
+func TestRepairWSResourcesState_ClearsObjectID(t *testing.T) {
+	publishDir := writePartialSite(t)
+	state := SiteState{Tracked: true}
+	report, err := CheckWSResourcesState(publishDir, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RepairWSResourcesState(publishDir, report, state.TrustedObjectID()); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := compress.ReadWSResourcesConfig(filepath.Join(publishDir, compress.WSResourcesFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ObjectID != "" {
+		t.Errorf("object_id = %q, want it removed when nothing backs it up", cfg.ObjectID)
+	}
+}
+
+func TestUnsyncedObjectID(t *testing.T) {
+	publishDir := writePartialSite(t)
+	emptyDir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		publishDir string
+		projectID  string
+		want       string
+	}{
+		{"placeholder projectID", publishDir, "YOUR_WALRUS_PROJECT_ID", "0xpartial"},
+		{"empty projectID", publishDir, "", "0xpartial"},
+		{"projectID already set", publishDir, "0xother", ""},
+		{"no ws-resources.json", emptyDir, "YOUR_WALRUS_PROJECT_ID", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewDefaultWalgoConfig()
+			cfg.WalrusConfig.ProjectID = tt.projectID
+			if got := UnsyncedObjectID(tt.publishDir, &cfg); got != tt.want {
+				t.Errorf("UnsyncedObjectID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncConfigObjectID(t *testing.T) {
+	sitePath := t.TempDir()
+	initial := `# Walgo site configuration
+hugo:
+  publishDir: public # built site
+walrus:
+  # Set after the first deploy
+  projectID: YOUR_WALRUS_PROJECT_ID
+  entrypoint: index.html
+  suinsDomain: myblog.sui
+`
+	if err := os.WriteFile(filepath.Join(sitePath, "walgo.yaml"), []byte(initial), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfigFrom(sitePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SyncConfigObjectID(sitePath, cfg, "0xpartial"); err != nil {
+		t.Fatalf("SyncConfigObjectID() error = %v", err)
+	}
+	if cfg.WalrusConfig.ProjectID != "0xpartial" {
+		t.Errorf("in-memory projectID = %q", cfg.WalrusConfig.ProjectID)
+	}
+
+	data, err := os.ReadFile(filepath.Join(sitePath, "walgo.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(initial, "YOUR_WALRUS_PROJECT_ID", "0xpartial", 1)
+	if string(data) != want {
+		t.Errorf("walgo.yaml =\n%s\nwant\n%s", data, want)
+	}
+
+	reloaded, err := config.LoadConfigFrom(sitePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.WalrusConfig.ProjectID != "0xpartial" || reloaded.WalrusConfig.SuiNSDomain != "myblog.sui" || reloaded.HugoConfig.PublishDir != "public" {
+		t.Errorf("reloaded config = %+v", reloaded.WalrusConfig)
+	}
+}
@@ -0,0 +1,137 @@
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DeploySummary is the on-disk report of a completed deployment.
+type DeploySummary struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Network       string    `json:"network"`
+	ObjectID      string    `json:"object_id"`
+	PortalURL     string    `json:"portal_url,omitempty"`
+	IsUpdate      bool      `json:"is_update"`
+	Epochs        int       `json:"epochs"`
+	TotalFiles    int       `json:"total_files"`
+	FilesUploaded int       `json:"files_uploaded"`
+	FilesSkipped  int       `json:"files_skipped"`
+	SiteSize      int64     `json:"site_size_bytes"`
+	EstimatedCost string    `json:"estimated_cost,omitempty"`
+	ActualWAL     float64   `json:"actual_wal,omitempty"`
+	ActualGasSUI  float64   `json:"actual_gas_sui,omitempty"`
+	Transaction   string    `json:"transaction_digest,omitempty"`
+	DedupedFiles  int       `json:"deduped_files,omitempty"`
+	DedupedBytes  int64     `json:"deduped_bytes,omitempty"`
+}
+
+// NewDeploySummary builds a summary from a deployment result.
+func NewDeploySummary(result *DeploymentResult) *DeploySummary {
+	timestamp := result.CompletedAt
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return &DeploySummary{
+		Timestamp:     timestamp.UTC(),
+		Network:       result.Network,
+		ObjectID:      result.ObjectID,
+		PortalURL:     result.PortalURL,
+		IsUpdate:      result.IsUpdate,
+		Epochs:        result.Epochs,
+		TotalFiles:    result.TotalFiles,
+		FilesUploaded: result.FilesUploaded,
+		FilesSkipped:  result.FilesSkipped,
+		SiteSize:      result.SiteSize,
+		EstimatedCost: result.EstimatedCost,
+		ActualWAL:     result.ActualWAL,
+		ActualGasSUI:  result.ActualGasSUI,
+		Transaction:   result.TransactionDigest,
+		DedupedFiles:  result.DedupedFiles,
+		DedupedBytes:  result.DedupedBytes,
+	}
+}
+
+// Markdown renders the summary as a Markdown document.
+func (s *DeploySummary) Markdown() string {
+	var b strings.Builder
+
+	action := "Deploy"
+	if s.IsUpdate {
+		action = "Update"
+	}
+
+	fmt.Fprintf(&b, "# Walgo %s Summary\n\n", action)
+	fmt.Fprintf(&b, "| Field | Value |\n")
+	fmt.Fprintf(&b, "|-------|-------|\n")
+	fmt.Fprintf(&b, "| Timestamp | %s |\n", s.Timestamp.Format(time.RFC3339))
+	fmt.Fprintf(&b, "| Network | %s |\n", valueOrDash(s.Network))
+	fmt.Fprintf(&b, "| Object ID | `%s` |\n", s.ObjectID)
+	fmt.Fprintf(&b, "| Portal URL | %s |\n", valueOrDash(s.PortalURL))
+	fmt.Fprintf(&b, "| Epochs | %d |\n", s.Epochs)
+	fmt.Fprintf(&b, "| Total files | %d |\n", s.TotalFiles)
+	fmt.Fprintf(&b, "| Files uploaded | %d |\n", s.FilesUploaded)
+	fmt.Fprintf(&b, "| Files skipped | %d |\n", s.FilesSkipped)
+	fmt.Fprintf(&b, "| Site size | %.2f MB |\n", float64(s.SiteSize)/(1024*1024))
+	fmt.Fprintf(&b, "| Estimated cost | %s |\n", valueOrDash(s.EstimatedCost))
+	if s.ActualWAL > 0 || s.ActualGasSUI > 0 {
+		fmt.Fprintf(&b, "| Actual cost | %.6f WAL + %.6f SUI |\n", s.ActualWAL, s.ActualGasSUI)
+	}
+	if s.Transaction != "" {
+		fmt.Fprintf(&b, "| Transaction | `%s` |\n", s.Transaction)
+	}
+	if s.DedupedFiles > 0 {
+		fmt.Fprintf(&b, "| Deduplicated | %d files (%.2f MB not uploaded) |\n", s.DedupedFiles, float64(s.DedupedBytes)/(1024*1024))
+	}
+
+	return b.String()
+}
+
+// WriteSummary writes a deployment summary to path.
+// The format is JSON when path ends in .json and Markdown otherwise.
+func WriteSummary(path string, result *DeploymentResult) error {
+	if path == "" {
+		return fmt.Errorf("summary path cannot be empty")
+	}
+	if result == nil {
+		return fmt.Errorf("no deployment result to summarize")
+	}
+
+	summary := NewDeploySummary(result)
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		encoded, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode summary: %w", err)
+		}
+		data = append(encoded, '\n')
+	} else {
+		data = []byte(summary.Markdown())
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		// #nosec G301 - summary directory needs standard permissions
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create summary directory: %w", err)
+		}
+	}
+
+	// #nosec G306 - summary is a non-sensitive report
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	return nil
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package deployment
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mockSummaryResult() *DeploymentResult {
+	return &DeploymentResult{
+		Success:       true,
+		ObjectID:      "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+		IsUpdate:      true,
+		SiteSize:      2 * 1024 * 1024,
+		Network:       "testnet",
+		Epochs:        5,
+		PortalURL:     "https://example.wal.app",
+		TotalFiles:    42,
+		FilesUploaded: 7,
+		FilesSkipped:  35,
+		EstimatedCost: "~0.0100 WAL + ~0.0020 SUI",
+		CompletedAt:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestWriteSummaryMarkdown(t *testing.T) {
+	result := mockSummaryResult()
+	path := filepath.Join(t.TempDir(), "reports", "deploy-summary.md")
+
+	if err := WriteSummary(path, result); err != nil {
+		t.Fatalf("WriteSummary failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read summary: %v", err)
+	}
+	content := string(data)
+
+	for _, want := range []string{
+		result.ObjectID,
+		"| Total files | 42 |",
+		"| Files uploaded | 7 |",
+		"| Files skipped | 35 |",
+		"| Network | testnet |",
+		"2025-01-02T03:04:05Z",
+		"https://example.wal.app",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Summary missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestWriteSummaryJSON(t *testing.T) {
+	result := mockSummaryResult()
+	path := filepath.Join(t.TempDir(), "deploy-summary.json")
+
+	if err := WriteSummary(path, result); err != nil {
+		t.Fatalf("WriteSummary failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read summary: %v", err)
+	}
+
+	var summary DeploySummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("Summary is not valid JSON: %v", err)
+	}
+
+	if summary.ObjectID != result.ObjectID {
+		t.Errorf("ObjectID = %q, want %q", summary.ObjectID, result.ObjectID)
+	}
+	if summary.FilesUploaded != 7 || summary.FilesSkipped != 35 || summary.TotalFiles != 42 {
+		t.Errorf("File counts = %d/%d/%d, want 7/35/42", summary.FilesUploaded, summary.FilesSkipped, summary.TotalFiles)
+	}
+	if summary.SiteSize != result.SiteSize {
+		t.Errorf("SiteSize = %d, want %d", summary.SiteSize, result.SiteSize)
+	}
+}
+
+func TestWriteSummaryErrors(t *testing.T) {
+	if err := WriteSummary("", mockSummaryResult()); err == nil {
+		t.Error("Expected error for empty path")
+	}
+	if err := WriteSummary(filepath.Join(t.TempDir(), "s.md"), nil); err == nil {
+		t.Error("Expected error for nil result")
+	}
+}
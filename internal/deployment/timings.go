@@ -0,0 +1,43 @@
+package deployment
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Deploy phases recorded in DeploymentResult.Timings.
+const (
+	PhaseSizeCalc    = "size_calc"    // Walking the publish directory for its size
+	PhaseHashing     = "hashing"      // Hashing files for the incremental deploy cache
+	PhaseMetadata    = "metadata"     // Preparing ws-resources.json metadata
+	PhaseUpload      = "upload"       // Running the deployer (upload and on-chain writes)
+	PhaseObjectWrite = "object_write" // Saving the object ID to ws-resources.json and walgo.yaml
+)
+
+// TimingPhases lists the deploy phases in the order they run.
+var TimingPhases = []string{PhaseSizeCalc, PhaseHashing, PhaseMetadata, PhaseUpload, PhaseObjectWrite}
+
+// PrintTimings writes a per-phase timing breakdown of a deploy to w, skipping
+// phases that did not run.
+func PrintTimings(w io.Writer, timings map[string]time.Duration) {
+	var total time.Duration
+	fmt.Fprintln(w, "Deploy timings:")
+	for _, phase := range TimingPhases {
+		d, ok := timings[phase]
+		if !ok {
+			continue
+		}
+		total += d
+		fmt.Fprintf(w, "  %-13s %10s\n", phase, formatPhaseDuration(d))
+	}
+	fmt.Fprintf(w, "  %-13s %10s\n", "total", formatPhaseDuration(total))
+}
+
+// formatPhaseDuration rounds d to a readable precision.
+func formatPhaseDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,75 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployer"
+)
+
+func TestPerformDeploymentRecordsTimings(t *testing.T) {
+	tempDir, cleanup := createTestSiteDir(t)
+	defer cleanup()
+	t.Setenv("HOME", tempDir)
+	publicDir := filepath.Join(tempDir, "public")
+	if err := os.WriteFile(filepath.Join(publicDir, "ws-resources.json"), []byte("{}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write ws-resources.json: %v", err)
+	}
+
+	mock := &MockDeployer{
+		DeployFunc: func(ctx context.Context, siteDir string, opts deployer.DeployOptions) (*deployer.Result, error) {
+			time.Sleep(5 * time.Millisecond)
+			return &deployer.Result{Success: true, ObjectID: "0xtimings"}, nil
+		},
+	}
+	cfg := config.NewDefaultWalgoConfig()
+	result, err := PerformDeployment(context.Background(), DeploymentOptions{
+		SitePath:    tempDir,
+		PublishDir:  publicDir,
+		Epochs:      1,
+		WalgoCfg:    &cfg,
+		Quiet:       true,
+		ForceNew:    true,
+		ProjectName: "timings-test",
+		Deployer:    mock,
+	})
+	if err != nil {
+		t.Fatalf("PerformDeployment failed: %v", err)
+	}
+
+	for _, phase := range TimingPhases {
+		if _, ok := result.Timings[phase]; !ok {
+			t.Errorf("Timings missing phase %q: %v", phase, result.Timings)
+		}
+	}
+	if result.Timings[PhaseUpload] < 5*time.Millisecond {
+		t.Errorf("upload = %v, want at least the deployer's 5ms", result.Timings[PhaseUpload])
+	}
+}
+
+func TestPrintTimings(t *testing.T) {
+	var out bytes.Buffer
+	PrintTimings(&out, map[string]time.Duration{
+		PhaseSizeCalc: 2 * time.Millisecond,
+		PhaseUpload:   3 * time.Second,
+	})
+
+	got := out.String()
+	for _, want := range []string{"size_calc", "2ms", "upload", "3s", "total", "3.002s"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, PhaseHashing) {
+		t.Errorf("phases that did not run should be skipped:\n%s", got)
+	}
+	if strings.Index(got, "size_calc") > strings.Index(got, "upload") {
+		t.Errorf("phases should print in pipeline order:\n%s", got)
+	}
+}
@@ -0,0 +1,139 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// siteResources is a test hook for fetching the on-chain resources of a site.
+var siteResources = walrus.GetResourceList
+
+// defaultVerifyTimeout bounds the entrypoint reachability request.
+const defaultVerifyTimeout = 30 * time.Second
+
+// VerifyOptions configures a post-deploy verification.
+type VerifyOptions struct {
+	ObjectID   string
+	PublishDir string       // Directory that was uploaded
+	PortalURL  string       // URL the entrypoint is expected to be served from
+	HTTPClient *http.Client // Defaults to a client with defaultVerifyTimeout
+}
+
+// VerifyReport describes the outcome of a post-deploy verification.
+type VerifyReport struct {
+	ExpectedResources int
+	ActualResources   int
+	EntrypointURL     string
+	EntrypointStatus  int
+}
+
+// ExpectedResourceCount returns how many resources a deploy of publishDir
+// should create. ws-resources.json configures the site and is not stored as
+// a resource, and neither are the files its ignore patterns exclude.
+func ExpectedResourceCount(publishDir string) (int, error) {
+	ignore, err := compress.ReadIgnorePatterns(publishDir)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = filepath.Walk(publishDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(publishDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == compress.WSResourcesFile || compress.IsIgnoredResource("/"+rel, ignore) {
+			return nil
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count files in %s: %w", publishDir, err)
+	}
+	return count, nil
+}
+
+// CompareResourceCount returns an error when the deployed site does not hold
+// exactly the expected number of resources.
+func CompareResourceCount(expected, actual int) error {
+	switch {
+	case actual < expected:
+		return fmt.Errorf("site has %d resources but %d files were uploaded (%d missing)", actual, expected, expected-actual)
+	case actual > expected:
+		return fmt.Errorf("site has %d resources but %d files were uploaded (%d unexpected)", actual, expected, actual-expected)
+	}
+	return nil
+}
+
+// CheckEntrypoint requests url and returns the HTTP status code. Any status
+// other than 200 is reported as an error.
+func CheckEntrypoint(ctx context.Context, client *http.Client, url string) (int, error) {
+	if url == "" {
+		return 0, fmt.Errorf("no portal URL to check")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: defaultVerifyTimeout}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("invalid portal URL %q: %w", url, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("entrypoint unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("entrypoint %s returned HTTP %d", url, resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// VerifyDeployment fetches the deployed site's resource list and checks that its
+// resource count matches the uploaded files and that the entrypoint is served
+// by the portal. The report is returned even when verification fails.
+func VerifyDeployment(ctx context.Context, opts VerifyOptions) (*VerifyReport, error) {
+	report := &VerifyReport{EntrypointURL: opts.PortalURL}
+
+	expected, err := ExpectedResourceCount(opts.PublishDir)
+	if err != nil {
+		return report, err
+	}
+	report.ExpectedResources = expected
+
+	resources, err := siteResources(ctx, opts.ObjectID, "")
+	if err != nil {
+		return report, fmt.Errorf("failed to fetch site resources: %w", err)
+	}
+	report.ActualResources = len(resources)
+
+	if err := CompareResourceCount(expected, report.ActualResources); err != nil {
+		return report, err
+	}
+
+	code, err := CheckEntrypoint(ctx, opts.HTTPClient, opts.PortalURL)
+	report.EntrypointStatus = code
+	if err != nil {
+		return report, err
+	}
+	return report, nil
+}
@@ -0,0 +1,191 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/compress"
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+func TestCompareResourceCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected int
+		actual   int
+		wantErr  string
+	}{
+		{name: "match", expected: 5, actual: 5},
+		{name: "empty site", expected: 0, actual: 0},
+		{name: "missing resources", expected: 5, actual: 3, wantErr: "2 missing"},
+		{name: "unexpected resources", expected: 3, actual: 4, wantErr: "1 unexpected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CompareResourceCount(tt.expected, tt.actual)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExpectedResourceCount(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"index.html", "ws-resources.json", "css/style.css", "posts/a/index.html"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count, err := ExpectedResourceCount(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 resources (ws-resources.json excluded), got %d", count)
+	}
+}
+
+func TestExpectedResourceCountSkipsIgnoredFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"index.html", "app.js.map", ".DS_Store", "css/style.css"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &compress.WSResourcesConfig{Ignore: compress.DefaultIgnorePatterns()}
+	if err := compress.WriteWSResourcesConfig(cfg, filepath.Join(dir, compress.WSResourcesFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := ExpectedResourceCount(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 resources (.map and .DS_Store ignored), got %d", count)
+	}
+}
+
+func TestCheckEntrypoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "reachable", status: http.StatusOK},
+		{name: "not found", status: http.StatusNotFound, wantErr: true},
+		{name: "server error", status: http.StatusBadGateway, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			code, err := CheckEntrypoint(context.Background(), server.Client(), server.URL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckEntrypoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, code)
+			}
+		})
+	}
+}
+
+func TestCheckEntrypointNoURL(t *testing.T) {
+	if _, err := CheckEntrypoint(context.Background(), nil, ""); err == nil {
+		t.Fatal("expected error for empty URL")
+	}
+}
+
+func TestVerifyDeployment(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"index.html", "about.html"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ok.Close()
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+
+	twoResources := []walrus.Resource{{Path: "/index.html", BlobID: "a"}, {Path: "/about.html", BlobID: "b"}}
+
+	tests := []struct {
+		name      string
+		resources []walrus.Resource
+		statusErr error
+		portalURL string
+		wantErr   string
+	}{
+		{name: "healthy deploy", resources: twoResources, portalURL: ok.URL},
+		{name: "missing resource", resources: twoResources[:1], portalURL: ok.URL, wantErr: "1 missing"},
+		{name: "entrypoint 404", resources: twoResources, portalURL: missing.URL, wantErr: "HTTP 404"},
+		{name: "status failure", statusErr: errors.New("rpc down"), portalURL: ok.URL, wantErr: "rpc down"},
+	}
+
+	orig := siteResources
+	defer func() { siteResources = orig }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			siteResources = func(ctx context.Context, objectID, network string) ([]walrus.Resource, error) {
+				if tt.statusErr != nil {
+					return nil, tt.statusErr
+				}
+				return tt.resources, nil
+			}
+
+			report, err := VerifyDeployment(context.Background(), VerifyOptions{
+				ObjectID:   "0x123",
+				PublishDir: dir,
+				PortalURL:  tt.portalURL,
+			})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if report.ExpectedResources != 2 || report.ActualResources != 2 || report.EntrypointStatus != http.StatusOK {
+					t.Errorf("unexpected report: %+v", report)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if report == nil {
+				t.Fatal("expected report even on failure")
+			}
+		})
+	}
+}
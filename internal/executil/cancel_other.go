@@ -0,0 +1,17 @@
+//go:build !windows
+
+package executil
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setGracefulCancel makes context cancellation send SIGTERM so the child can
+// clean up; it is killed if it has not exited after the grace period.
+func setGracefulCancel(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = cancelGracePeriod
+}
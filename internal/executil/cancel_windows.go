@@ -0,0 +1,12 @@
+//go:build windows
+
+package executil
+
+import "os/exec"
+
+// setGracefulCancel bounds how long Wait blocks after the process is killed.
+// Windows has no SIGTERM equivalent for console-less children, so the default
+// Kill-on-cancel behavior is kept.
+func setGracefulCancel(cmd *exec.Cmd) {
+	cmd.WaitDelay = cancelGracePeriod
+}
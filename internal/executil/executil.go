@@ -6,8 +6,13 @@ package executil
 import (
 	"context"
 	"os/exec"
+	"time"
 )
 
+// cancelGracePeriod is how long a cancelled child process may take to exit
+// before it is forcibly killed.
+const cancelGracePeriod = 5 * time.Second
+
 // Command creates an exec.Cmd with platform-appropriate settings.
 // On Windows, it sets CREATE_NO_WINDOW to prevent console window flashing.
 func Command(name string, args ...string) *exec.Cmd {
@@ -18,8 +23,10 @@ func Command(name string, args ...string) *exec.Cmd {
 
 // CommandContext creates a context-aware exec.Cmd with platform-appropriate settings.
 // On Windows, it sets CREATE_NO_WINDOW to prevent console window flashing.
+// When ctx is cancelled the process is asked to terminate and killed after a grace period.
 func CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
 	cmd := exec.CommandContext(ctx, name, args...)
 	hideWindow(cmd)
+	setGracefulCancel(cmd)
 	return cmd
 }
@@ -0,0 +1,706 @@
+package hugo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"gopkg.in/yaml.v3"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+// Frontmatter formats accepted by ConvertFrontmatter.
+const (
+	FrontmatterYAML = "yaml"
+	FrontmatterTOML = "toml"
+	FrontmatterJSON = "json"
+)
+
+// frontmatterFormats maps the delimiters reported by ai.SplitFrontmatter to
+// their format.
+var frontmatterFormats = map[string]string{
+	"---": FrontmatterYAML,
+	"+++": FrontmatterTOML,
+	"{":   FrontmatterJSON,
+}
+
+// FrontmatterConvertReport lists what ConvertContentFrontmatter did.
+type FrontmatterConvertReport struct {
+	Converted []string // Content files rewritten (or, with dry run, to be rewritten)
+	Skipped   []string // Content files already in the target format
+	Failed    []string // "path: error" for files whose frontmatter could not be converted
+}
+
+// ConvertContentFrontmatter rewrites the frontmatter of every Markdown file
+// under contentDir in format. Files without frontmatter are left alone, and
+// a file that cannot be converted is reported without stopping the others.
+// Paths in the report are relative to contentDir and slash-separated.
+func ConvertContentFrontmatter(contentDir, format string, dryRun bool) (*FrontmatterConvertReport, error) {
+	if err := validateFrontmatterFormat(format); err != nil {
+		return nil, err
+	}
+	pages, err := contentPages(contentDir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(pages)
+
+	report := &FrontmatterConvertReport{}
+	for _, page := range pages {
+		rel, err := filepath.Rel(contentDir, page)
+		if err != nil {
+			rel = page
+		}
+		rel = filepath.ToSlash(rel)
+
+		data, err := os.ReadFile(page) // #nosec G304 - page is a content file under contentDir
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", rel, err)
+		}
+		delim, _, _ := ai.SplitFrontmatter(string(data))
+		if delim == "" {
+			continue
+		}
+		if frontmatterFormats[delim] == format {
+			report.Skipped = append(report.Skipped, rel)
+			continue
+		}
+
+		converted, _, err := ConvertFrontmatter(string(data), format)
+		if err != nil {
+			report.Failed = append(report.Failed, fmt.Sprintf("%s: %v", rel, err))
+			continue
+		}
+		if !dryRun {
+			info, err := os.Stat(page)
+			if err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(page, []byte(converted), info.Mode().Perm()); err != nil {
+				return nil, fmt.Errorf("writing %s: %w", rel, err)
+			}
+		}
+		report.Converted = append(report.Converted, rel)
+	}
+	return report, nil
+}
+
+// ConvertFrontmatter rewrites content's frontmatter in format, keeping field
+// order, values and the body. It reports false, returning content unchanged,
+// when there is no frontmatter or it is already in format.
+//
+// TOML has no null, so null fields are dropped when converting to TOML, and
+// nested tables follow a table's plain keys as TOML requires.
+func ConvertFrontmatter(content, format string) (string, bool, error) {
+	if err := validateFrontmatterFormat(format); err != nil {
+		return "", false, err
+	}
+	delim, frontmatter, body := ai.SplitFrontmatter(content)
+	from := frontmatterFormats[delim]
+	if from == "" || from == format {
+		return content, false, nil
+	}
+
+	var fields *fmTable
+	var err error
+	switch from {
+	case FrontmatterYAML:
+		fields, err = parseYAMLFrontmatter(frontmatter)
+	case FrontmatterTOML:
+		fields, err = parseTOMLFrontmatter(frontmatter)
+	case FrontmatterJSON:
+		fields, err = parseJSONFrontmatter(frontmatter)
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("parsing %s frontmatter: %w", from, err)
+	}
+
+	switch format {
+	case FrontmatterYAML:
+		out, err := encodeYAMLFrontmatter(fields)
+		if err != nil {
+			return "", false, err
+		}
+		return "---\n" + out + "---\n" + body, true, nil
+	case FrontmatterTOML:
+		var b strings.Builder
+		writeTOMLTable(&b, fields, nil)
+		return "+++\n" + b.String() + "+++\n" + body, true, nil
+	default:
+		out, err := encodeJSONFrontmatter(fields)
+		if err != nil {
+			return "", false, err
+		}
+		return out + "\n" + body, true, nil
+	}
+}
+
+func validateFrontmatterFormat(format string) error {
+	switch format {
+	case FrontmatterYAML, FrontmatterTOML, FrontmatterJSON:
+		return nil
+	}
+	return fmt.Errorf("unknown frontmatter format %q: use yaml, toml or json", format)
+}
+
+// fmTable is a frontmatter mapping that keeps its keys in source order.
+// Values are string, bool, int64, float64, time.Time, the go-toml local date
+// and time types, nil, []interface{} or *fmTable.
+type fmTable struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newFMTable() *fmTable {
+	return &fmTable{values: make(map[string]interface{})}
+}
+
+func (t *fmTable) set(key string, value interface{}) {
+	if _, ok := t.values[key]; !ok {
+		t.keys = append(t.keys, key)
+	}
+	t.values[key] = value
+}
+
+// parseYAMLFrontmatter reads YAML frontmatter in document order.
+func parseYAMLFrontmatter(frontmatter string) (*fmTable, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(frontmatter), &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return newFMTable(), nil
+	}
+	value, err := yamlNodeValue(doc.Content[0])
+	if err != nil {
+		return nil, err
+	}
+	table, ok := value.(*fmTable)
+	if !ok {
+		return nil, fmt.Errorf("frontmatter is not a mapping")
+	}
+	return table, nil
+}
+
+func yamlNodeValue(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlNodeValue(node.Alias)
+	case yaml.MappingNode:
+		table := newFMTable()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := yamlNodeValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			table.set(node.Content[i].Value, value)
+		}
+		return table, nil
+	case yaml.SequenceNode:
+		items := make([]interface{}, 0, len(node.Content))
+		for _, child := range node.Content {
+			value, err := yamlNodeValue(child)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	}
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case uint64:
+		return v, nil
+	case time.Time:
+		// A bare date stays a date rather than becoming midnight UTC
+		if len(strings.TrimSpace(node.Value)) == len("2006-01-02") {
+			return toml.LocalDate{Year: v.Year(), Month: int(v.Month()), Day: v.Day()}, nil
+		}
+		return v, nil
+	}
+	return value, nil
+}
+
+// parseTOMLFrontmatter decodes TOML frontmatter, then orders each table's
+// keys as they first appear in the source.
+func parseTOMLFrontmatter(frontmatter string) (*fmTable, error) {
+	var fields map[string]interface{}
+	if err := toml.Unmarshal([]byte(frontmatter), &fields); err != nil {
+		return nil, err
+	}
+	order, err := tomlKeyOrder([]byte(frontmatter))
+	if err != nil {
+		return nil, err
+	}
+	return orderedTable(fields, "", order), nil
+}
+
+// tomlKeyOrder maps each table path (keys joined with "\x00"; array tables
+// share one path for all their elements) to its keys in source order.
+func tomlKeyOrder(data []byte) (map[string][]string, error) {
+	order := make(map[string][]string)
+	seen := make(map[string]bool)
+	addPath := func(base string, parts []string) string {
+		path := base
+		for _, part := range parts {
+			if id := path + "\x01" + part; !seen[id] {
+				seen[id] = true
+				order[path] = append(order[path], part)
+			}
+			if path == "" {
+				path = part
+			} else {
+				path += "\x00" + part
+			}
+		}
+		return path
+	}
+
+	var collect func(table string, node *unstable.Node)
+	collect = func(table string, node *unstable.Node) {
+		path := addPath(table, tomlKeyParts(node.Key()))
+		collectValue(path, node.Value(), collect)
+	}
+
+	var p unstable.Parser
+	p.Reset(data)
+	table := ""
+	for p.NextExpression() {
+		expr := p.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			table = addPath("", tomlKeyParts(expr.Key()))
+		case unstable.KeyValue:
+			collect(table, expr)
+		}
+	}
+	return order, p.Error()
+}
+
+// collectValue records the keys of inline tables in value, which is stored
+// at path.
+func collectValue(path string, value *unstable.Node, collect func(string, *unstable.Node)) {
+	switch value.Kind {
+	case unstable.InlineTable:
+		children := value.Children()
+		for children.Next() {
+			collect(path, children.Node())
+		}
+	case unstable.Array:
+		children := value.Children()
+		for children.Next() {
+			collectValue(path, children.Node(), collect)
+		}
+	}
+}
+
+func tomlKeyParts(it unstable.Iterator) []string {
+	var parts []string
+	for it.Next() {
+		parts = append(parts, string(it.Node().Data))
+	}
+	return parts
+}
+
+// orderedTable converts a decoded TOML table to an fmTable, ordering keys by
+// order and putting any it does not list last, sorted.
+func orderedTable(fields map[string]interface{}, path string, order map[string][]string) *fmTable {
+	table := newFMTable()
+	for _, key := range order[path] {
+		if value, ok := fields[key]; ok {
+			table.set(key, orderedValue(value, childPath(path, key), order))
+		}
+	}
+	rest := make([]string, 0, len(fields))
+	for key := range fields {
+		if _, ok := table.values[key]; !ok {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	for _, key := range rest {
+		table.set(key, orderedValue(fields[key], childPath(path, key), order))
+	}
+	return table
+}
+
+func orderedValue(value interface{}, path string, order map[string][]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return orderedTable(v, path, order)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = orderedValue(item, path, order)
+		}
+		return items
+	}
+	return value
+}
+
+func childPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "\x00" + key
+}
+
+// parseJSONFrontmatter reads JSON frontmatter in document order. Whole
+// numbers stay integers.
+func parseJSONFrontmatter(frontmatter string) (*fmTable, error) {
+	dec := json.NewDecoder(strings.NewReader(frontmatter))
+	dec.UseNumber()
+	value, err := jsonValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	table, ok := value.(*fmTable)
+	if !ok {
+		return nil, fmt.Errorf("frontmatter is not an object")
+	}
+	return table, nil
+}
+
+func jsonValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			table := newFMTable()
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := jsonValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				table.set(keyTok.(string), value)
+			}
+			_, err := dec.Token()
+			return table, err
+		case '[':
+			items := []interface{}{}
+			for dec.More() {
+				value, err := jsonValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, value)
+			}
+			_, err := dec.Token()
+			return items, err
+		}
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	}
+	return tok, nil
+}
+
+// encodeYAMLFrontmatter renders fields as YAML with two-space indentation.
+func encodeYAMLFrontmatter(fields *fmTable) (string, error) {
+	if len(fields.keys) == 0 {
+		return "", nil
+	}
+	node, err := yamlNode(fields)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func yamlNode(value interface{}) (*yaml.Node, error) {
+	switch v := value.(type) {
+	case *fmTable:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, key := range v.keys {
+			child, err := yamlNode(v.values[key])
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+		}
+		return node, nil
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range v {
+			child, err := yamlNode(item)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		return node, nil
+	case toml.LocalDate, toml.LocalDateTime, toml.LocalTime:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v)}, nil
+	case float64:
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: formatFloat(v)}, nil
+		}
+	}
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// encodeJSONFrontmatter renders fields as indented JSON without a trailing
+// newline.
+func encodeJSONFrontmatter(fields *fmTable) (string, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, fields); err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func writeJSON(w *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case *fmTable:
+		w.WriteByte('{')
+		for i, key := range v.keys {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := writeJSONScalar(w, key); err != nil {
+				return err
+			}
+			w.WriteByte(':')
+			if err := writeJSON(w, v.values[key]); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('}')
+		return nil
+	case []interface{}:
+		w.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := writeJSON(w, item); err != nil {
+				return err
+			}
+		}
+		w.WriteByte(']')
+		return nil
+	case float64:
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			w.WriteString(formatFloat(v))
+			return nil
+		}
+	}
+	return writeJSONScalar(w, value)
+}
+
+// writeJSONScalar encodes value without escaping <, > and &.
+func writeJSONScalar(w io.Writer, value interface{}) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return err
+	}
+	_, err := w.Write(bytes.TrimRight(buf.Bytes(), "\n"))
+	return err
+}
+
+// writeTOMLTable writes table's plain keys, then its sub-tables and arrays
+// of tables, each under a header naming its full path.
+func writeTOMLTable(b *strings.Builder, table *fmTable, path []string) {
+	for _, key := range table.keys {
+		value := table.values[key]
+		if value == nil || isTOMLTable(value) || isTOMLArrayOfTables(value) {
+			continue
+		}
+		fmt.Fprintf(b, "%s = %s\n", tomlKey(key), tomlValue(value))
+	}
+	for _, key := range table.keys {
+		childPath := append(append([]string(nil), path...), key)
+		switch v := table.values[key].(type) {
+		case *fmTable:
+			fmt.Fprintf(b, "\n[%s]\n", tomlKeyPath(childPath))
+			writeTOMLTable(b, v, childPath)
+		case []interface{}:
+			if !isTOMLArrayOfTables(v) {
+				continue
+			}
+			for _, item := range v {
+				fmt.Fprintf(b, "\n[[%s]]\n", tomlKeyPath(childPath))
+				writeTOMLTable(b, item.(*fmTable), childPath)
+			}
+		}
+	}
+}
+
+func isTOMLTable(value interface{}) bool {
+	_, ok := value.(*fmTable)
+	return ok
+}
+
+// isTOMLArrayOfTables reports whether value is a non-empty array holding
+// only tables.
+func isTOMLArrayOfTables(value interface{}) bool {
+	items, ok := value.([]interface{})
+	if !ok || len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if !isTOMLTable(item) {
+			return false
+		}
+	}
+	return true
+}
+
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func tomlKey(key string) string {
+	if tomlBareKey.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+func tomlKeyPath(path []string) string {
+	parts := make([]string, len(path))
+	for i, key := range path {
+		parts[i] = tomlKey(key)
+	}
+	return strings.Join(parts, ".")
+}
+
+// tomlValue renders value inline. Nulls inside arrays and inline tables are
+// dropped.
+func tomlValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return tomlString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return tomlFloat(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case toml.LocalDate, toml.LocalDateTime, toml.LocalTime:
+		return fmt.Sprint(v)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if item != nil {
+				items = append(items, tomlValue(item))
+			}
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case *fmTable:
+		pairs := make([]string, 0, len(v.keys))
+		for _, key := range v.keys {
+			if v.values[key] != nil {
+				pairs = append(pairs, tomlKey(key)+" = "+tomlValue(v.values[key]))
+			}
+		}
+		if len(pairs) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(pairs, ", ") + " }"
+	}
+	return tomlString(fmt.Sprint(value))
+}
+
+func tomlFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	return formatFloat(f)
+}
+
+// formatFloat renders a finite f so it reads back as a float, not an
+// integer, in YAML, TOML and JSON.
+func formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// tomlString quotes s as a TOML basic string.
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,253 @@
+package hugo
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+const yamlFrontmatterPage = `---
+title: "Hello: World"
+date: 2024-03-01
+draft: false
+weight: 10
+ratio: 1.0
+tags:
+  - go
+  - walrus
+params:
+  author: Jordan
+  featured: true
+---
+# Body
+
+Some *text* with +++ and --- inside.
+`
+
+func TestConvertFrontmatterYAMLToTOML(t *testing.T) {
+	out, changed, err := ConvertFrontmatter(yamlFrontmatterPage, FrontmatterTOML)
+	if err != nil {
+		t.Fatalf("ConvertFrontmatter failed: %v", err)
+	}
+	if !changed {
+		t.Fatal("ConvertFrontmatter reported no change")
+	}
+
+	want := `+++
+title = "Hello: World"
+date = 2024-03-01
+draft = false
+weight = 10
+ratio = 1.0
+tags = ["go", "walrus"]
+
+[params]
+author = "Jordan"
+featured = true
++++
+# Body
+
+Some *text* with +++ and --- inside.
+`
+	if out != want {
+		t.Errorf("converted page:\n%s\nwant:\n%s", out, want)
+	}
+
+	_, _, origBody := ai.SplitFrontmatter(yamlFrontmatterPage)
+	delim, _, body := ai.SplitFrontmatter(out)
+	if delim != "+++" || body != origBody {
+		t.Errorf("body changed: delim %q, body %q", delim, body)
+	}
+
+	orig := ai.ParseFrontmatterFields(yamlFrontmatterPage)
+	got := ai.ParseFrontmatterFields(out)
+	for _, key := range []string{"title", "draft", "tags", "params"} {
+		if !reflect.DeepEqual(normalizeFrontmatterValue(orig[key]), normalizeFrontmatterValue(got[key])) {
+			t.Errorf("%s = %#v, want %#v", key, got[key], orig[key])
+		}
+	}
+	if got["weight"] != int64(10) || got["ratio"] != 1.0 {
+		t.Errorf("weight = %#v, ratio = %#v", got["weight"], got["ratio"])
+	}
+}
+
+// normalizeFrontmatterValue makes YAML and TOML decodings comparable.
+func normalizeFrontmatterValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = normalizeFrontmatterValue(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = normalizeFrontmatterValue(item)
+		}
+		return out
+	case int:
+		return int64(v)
+	}
+	return v
+}
+
+func TestConvertFrontmatterRoundTrips(t *testing.T) {
+	tomlPage, _, err := ConvertFrontmatter(yamlFrontmatterPage, FrontmatterTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonPage, _, err := ConvertFrontmatter(tomlPage, FrontmatterJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(jsonPage, "{\n  \"title\": \"Hello: World\",\n  \"date\": \"2024-03-01\",") {
+		t.Errorf("JSON frontmatter lost order:\n%s", jsonPage)
+	}
+	yamlPage, _, err := ConvertFrontmatter(jsonPage, FrontmatterYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `---
+title: 'Hello: World'
+date: "2024-03-01"
+draft: false
+weight: 10
+ratio: 1.0
+tags:
+  - go
+  - walrus
+params:
+  author: Jordan
+  featured: true
+---
+# Body
+
+Some *text* with +++ and --- inside.
+`
+	if yamlPage != want {
+		t.Errorf("YAML -> TOML -> JSON -> YAML:\n%s\nwant:\n%s", yamlPage, want)
+	}
+}
+
+func TestConvertFrontmatterTOMLKeepsOrder(t *testing.T) {
+	page := `+++
+zeta = "last alphabetically"
+alpha = 1
+menu = { main = { weight = 2, name = "Docs" } }
+
+[[cascade]]
+type = "docs"
+build = "always"
+
+[[cascade]]
+type = "blog"
++++
+Body
+`
+	out, changed, err := ConvertFrontmatter(page, FrontmatterYAML)
+	if err != nil || !changed {
+		t.Fatalf("ConvertFrontmatter = %v, %v", changed, err)
+	}
+	want := `---
+zeta: last alphabetically
+alpha: 1
+menu:
+  main:
+    weight: 2
+    name: Docs
+cascade:
+  - type: docs
+    build: always
+  - type: blog
+---
+Body
+`
+	if out != want {
+		t.Errorf("converted page:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestConvertFrontmatterSkips(t *testing.T) {
+	for _, page := range []string{"# No frontmatter\n", "+++\ntitle = \"x\"\n+++\nbody\n"} {
+		out, changed, err := ConvertFrontmatter(page, FrontmatterTOML)
+		if err != nil || changed || out != page {
+			t.Errorf("ConvertFrontmatter(%q) = %q, %v, %v; want it unchanged", page, out, changed, err)
+		}
+	}
+	if _, _, err := ConvertFrontmatter(yamlFrontmatterPage, "xml"); err == nil {
+		t.Error("unknown format should fail")
+	}
+	if _, _, err := ConvertFrontmatter("---\n- a list\n---\n", FrontmatterTOML); err == nil {
+		t.Error("non-mapping frontmatter should fail")
+	}
+}
+
+func TestConvertFrontmatterTOMLEscapesAndNulls(t *testing.T) {
+	page := "---\ntitle: \"Say \\\"hi\\\"\\n\"\n\"odd key\": 1\nempty:\n---\n"
+	out, _, err := ConvertFrontmatter(page, FrontmatterTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "+++\ntitle = \"Say \\\"hi\\\"\\n\"\n\"odd key\" = 1\n+++\n"
+	if out != want {
+		t.Errorf("converted page:\n%q\nwant:\n%q", out, want)
+	}
+}
+
+func TestConvertContentFrontmatter(t *testing.T) {
+	contentDir := t.TempDir()
+	files := map[string]string{
+		"posts/first.md":  yamlFrontmatterPage,
+		"posts/second.md": "+++\ntitle = \"Already TOML\"\n+++\n",
+		"about.md":        "{\n  \"title\": \"About\"\n}\nAbout page\n",
+		"plain.md":        "No frontmatter\n",
+		"broken.md":       "---\ntitle: [unclosed\n---\n",
+		"notes.txt":       "---\ntitle: ignored\n---\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(contentDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := ConvertContentFrontmatter(contentDir, FrontmatterTOML, true)
+	if err != nil {
+		t.Fatalf("ConvertContentFrontmatter failed: %v", err)
+	}
+	if !reflect.DeepEqual(report.Converted, []string{"about.md", "posts/first.md"}) {
+		t.Errorf("Converted = %v", report.Converted)
+	}
+	if !reflect.DeepEqual(report.Skipped, []string{"posts/second.md"}) {
+		t.Errorf("Skipped = %v", report.Skipped)
+	}
+	if len(report.Failed) != 1 || !strings.HasPrefix(report.Failed[0], "broken.md: ") {
+		t.Errorf("Failed = %v", report.Failed)
+	}
+	if data, _ := os.ReadFile(filepath.Join(contentDir, "posts", "first.md")); string(data) != yamlFrontmatterPage {
+		t.Error("dry run rewrote a file")
+	}
+
+	if _, err := ConvertContentFrontmatter(contentDir, FrontmatterTOML, false); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(contentDir, "about.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "+++\ntitle = \"About\"\n+++\nAbout page\n" {
+		t.Errorf("about.md = %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(contentDir, "notes.txt")); string(data) != files["notes.txt"] {
+		t.Error("non-Markdown file was rewritten")
+	}
+}
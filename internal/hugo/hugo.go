@@ -5,12 +5,10 @@ import (
 	"embed"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/selimozten/walgo/internal/ai"
 	"github.com/selimozten/walgo/internal/compress"
@@ -176,11 +174,69 @@ func cleanPublicDir(publicDir string) error {
 	return err
 }
 
+// BuildOptions controls how BuildSiteWithOptions invokes Hugo.
+type BuildOptions struct {
+	// HugoMinify passes --minify to Hugo and verifies the HTML output was minified.
+	HugoMinify bool
+	// IncludeFuture builds pages whose publishDate is in the future.
+	IncludeFuture bool
+	// IncludeExpired builds pages whose expiryDate has passed.
+	IncludeExpired bool
+	// IncludeDrafts builds pages marked draft: true.
+	IncludeDrafts bool
+	// DefaultLanguage aliases "/" and root-relative routes to this language's
+	// pages in ws-resources.json, for sites that publish it under /<lang>/.
+	DefaultLanguage string
+	// HugoPath is the hugo binary to run, e.g. a pinned version from the
+	// walgo cache (see ResolveHugoBinary). Empty uses the hugo on PATH.
+	HugoPath string
+	// Reproducible pins the build clock and page dates to SourceDateEpoch,
+	// keeps source file times and modes out of the output and stamps every
+	// built file with that time, so identical input builds to identical
+	// files. See prepareReproducibleBuild.
+	Reproducible bool
+	// VerifyOutput, when set, is called with the publish directory after Hugo
+	// renders the site and before walgo's optimizer rewrites it. An error
+	// aborts the build.
+	VerifyOutput func(publishDir string) error
+}
+
+// DefaultBuildOptions returns the options used by BuildSite.
+func DefaultBuildOptions() BuildOptions {
+	return BuildOptions{HugoMinify: true}
+}
+
+// hugoBuildArgs returns the arguments passed to the hugo binary for a build.
+// Draft and scheduling flags are always passed explicitly so a buildDrafts,
+// buildFuture or buildExpired setting in the site config cannot publish
+// drafts, pages scheduled for later or expired ones.
+func hugoBuildArgs(opts BuildOptions) []string {
+	args := []string{"build", "--environment", "production"}
+	if opts.HugoMinify {
+		args = append(args, "--minify")
+	}
+	args = append(args,
+		fmt.Sprintf("--buildDrafts=%t", opts.IncludeDrafts),
+		fmt.Sprintf("--buildFuture=%t", opts.IncludeFuture),
+		fmt.Sprintf("--buildExpired=%t", opts.IncludeExpired),
+	)
+	if opts.Reproducible {
+		args = append(args, reproducibleHugoArgs...)
+	}
+	return append(args, "--gc", "--cleanDestinationDir")
+}
+
 // BuildSite runs the Hugo build process in the given site path.
 func BuildSite(sitePath string) error {
-	hugoPath, err := deps.LookPath("hugo")
-	if err != nil {
-		return fmt.Errorf("hugo is not installed or not found in PATH")
+	return BuildSiteWithOptions(sitePath, DefaultBuildOptions())
+}
+
+// BuildSiteWithOptions runs the Hugo build process in the given site path.
+func BuildSiteWithOptions(sitePath string, opts BuildOptions) error {
+	if opts.DefaultLanguage != "" {
+		if err := ValidateLanguageCode(opts.DefaultLanguage); err != nil {
+			return err
+		}
 	}
 
 	walgoCfg := filepath.Join(sitePath, "walgo.yaml")
@@ -198,6 +254,14 @@ func BuildSite(sitePath string) error {
 		return fmt.Errorf("failed to unmarshal walgo.yaml: %w", err)
 	}
 
+	// Every build runs the Hugo release the site pins, not just walgo build
+	hugoPath := opts.HugoPath
+	if hugoPath == "" {
+		if hugoPath, err = pinnedHugoBinary(walgoCfgData.HugoConfig.Version, os.Stdout); err != nil {
+			return err
+		}
+	}
+
 	configFile := filepath.Join(sitePath, "hugo.toml")
 	if _, err := os.Stat(configFile); os.IsNotExist(err) {
 		configFile = filepath.Join(sitePath, "config.toml")
@@ -220,7 +284,19 @@ func BuildSite(sitePath string) error {
 		}
 	}
 
-	cmd := executil.Command(hugoPath, "build", "--environment", "production", "--minify", "--gc", "--cleanDestinationDir")
+	reportUnpublishedPages(sitePath, opts)
+
+	args := hugoBuildArgs(opts)
+	var reproducible *reproducibleBuild
+	if opts.Reproducible {
+		if reproducible, err = prepareReproducibleBuild(sitePath, configFile); err != nil {
+			return err
+		}
+		defer reproducible.cleanup()
+		args = append(args, reproducible.args...)
+	}
+
+	cmd := executil.Command(hugoPath, args...)
 	cmd.Dir = sitePath
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -238,9 +314,34 @@ func BuildSite(sitePath string) error {
 	publicDir := filepath.Join(sitePath, "public")
 	fmt.Printf("Static files generated in: %s\n", publicDir)
 
-	if walgoCfgData.OptimizerConfig.Enabled {
+	if opts.VerifyOutput != nil {
+		if err := opts.VerifyOutput(publicDir); err != nil {
+			return err
+		}
+	}
+
+	optimizerCfg := walgoCfgData.OptimizerConfig
+	if opts.HugoMinify {
+		report, err := VerifyMinifiedHTML(publicDir, minifySampleSize)
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Warning: could not verify Hugo minification: %v\n", err)
+		case report.Sampled == 0:
+			// No HTML output to check
+		case !report.Verified():
+			fmt.Fprintf(os.Stderr, "Warning: Hugo --minify was requested but %d of %d sampled HTML files are not minified.\n",
+				report.Sampled-report.Minified, report.Sampled)
+			fmt.Fprintf(os.Stderr, "  The theme or site config may disable it (check [minify] disableHTML in your Hugo config).\n")
+		case optimizerCfg.Enabled && optimizerCfg.HTML.Enabled:
+			// Hugo already minified every rendered page; don't process HTML twice
+			fmt.Printf("HTML already minified by Hugo, skipping walgo HTML optimization.\n")
+			optimizerCfg.HTML.Enabled = false
+		}
+	}
+
+	if optimizerCfg.Enabled {
 		fmt.Printf("Optimizing assets...\n")
-		optimizerEngine := optimizer.NewEngine(walgoCfgData.OptimizerConfig)
+		optimizerEngine := optimizer.NewEngine(optimizerCfg)
 		stats, err := optimizerEngine.OptimizeDirectory(publicDir)
 
 		if err != nil {
@@ -274,6 +375,7 @@ func BuildSite(sitePath string) error {
 			CacheConfig:  cacheConfig,
 			CustomRoutes: walgoCfgData.CompressConfig.CustomRoutes,
 			CustomIgnore: walgoCfgData.CompressConfig.IgnorePatterns,
+			DirHeaders:   walgoCfgData.CompressConfig.DirHeaders,
 		}
 
 		// Try to get project metadata (non-fatal if not found)
@@ -340,7 +442,10 @@ func BuildSite(sitePath string) error {
 		fmt.Printf("Generated ws-resources.json (%d resources)\n", len(wsConfig.Headers))
 
 		// Generate and merge routes
-		routes, err := compress.GenerateRoutesFromPublic(publicDir)
+		routes, err := compress.GenerateRoutesFromPublicWithOptions(publicDir, compress.RouteOptions{
+			DefaultLanguage: opts.DefaultLanguage,
+			ExplicitRoutes:  walgoCfgData.CompressConfig.CustomRoutes,
+		})
 		if err != nil {
 			return fmt.Errorf("failed to generate routes: %w", err)
 		}
@@ -355,19 +460,38 @@ func BuildSite(sitePath string) error {
 		fmt.Printf("Warning: failed to clean public directory: %v\n", err)
 	}
 
+	if reproducible != nil {
+		if err := NormalizeModTimes(publicDir, reproducible.buildTime); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// ServeSite starts the Hugo development server
+// ServeSite starts the Hugo development server on localhost, including
+// drafts and future content.
 func ServeSite(sitePath string) error {
-	hugoPath, err := deps.LookPath("hugo")
+	return ServeSiteWithOptions(sitePath, ServeOptions{Drafts: true, Future: true})
+}
+
+// ServeSiteWithOptions starts the Hugo development server with opts.
+// opts.Bind must already be validated with ParseBindAddress.
+func ServeSiteWithOptions(sitePath string, opts ServeOptions) error {
+	hugoPath, err := SiteHugoBinary(sitePath, os.Stderr)
 	if err != nil {
-		return fmt.Errorf("hugo is not installed or not found in PATH")
+		return err
 	}
 
-	fmt.Printf("Starting Hugo development server...\n")
-	cmd := executil.Command(hugoPath, "server", "--environment", "development", "--bind", "0.0.0.0",
-		"--port", "1313", "--buildDrafts", "--buildFuture", "--disableFastRender", "--noHTTPCache")
+	cleanup, err := PrepareTLS(&opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	fmt.Printf("Starting Hugo development server at %s...\n", ServeURL(opts))
+	args := append(ServerArgs(opts), "--environment", "development", "--disableFastRender", "--noHTTPCache")
+	cmd := executil.Command(hugoPath, args...)
 	cmd.Dir = sitePath
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -493,6 +617,12 @@ func GetThemeInfo(siteType SiteType) ThemeInfo {
 // in ai.DefaultThemes. If the download fails (no network, timeout, etc.),
 // it falls back to scaffolding a blank theme with `hugo new theme`.
 func InstallTheme(sitePath string, siteType SiteType) error {
+	return InstallThemeWithOptions(sitePath, siteType, ThemeDownloadOptions{})
+}
+
+// InstallThemeWithOptions is InstallTheme with control over the downloaded
+// ref and the theme archive cache.
+func InstallThemeWithOptions(sitePath string, siteType SiteType, opts ThemeDownloadOptions) error {
 	theme := GetThemeInfo(siteType)
 	themePath := filepath.Join(sitePath, "themes", theme.DirName)
 
@@ -505,7 +635,7 @@ func InstallTheme(sitePath string, siteType SiteType) error {
 	aiTheme := ai.GetDefaultTheme(ai.SiteType(siteType))
 	if aiTheme.RepoURL != "" {
 		fmt.Printf("Downloading theme %s from %s...\n", theme.Name, aiTheme.RepoURL)
-		if err := downloadThemeZip(theme.DirName, aiTheme.RepoURL, themePath); err != nil {
+		if err := downloadThemeZip(theme.DirName, aiTheme.RepoURL, themePath, opts); err != nil {
 			fmt.Printf("Warning: Failed to download theme: %v\n", err)
 			fmt.Printf("Falling back to blank theme scaffold...\n")
 		} else {
@@ -763,75 +893,19 @@ func CreateTheme(sitePath, themeName string) error {
 	return nil
 }
 
-// downloadThemeZip downloads a theme as a ZIP archive from GitHub and extracts it
-func downloadThemeZip(themeName, gitURL, themePath string) error {
+// downloadThemeZip downloads a theme as a ZIP archive from GitHub and extracts it.
+// Pinned refs are served from the theme cache unless opts.NoCache is set.
+func downloadThemeZip(themeName, gitURL, themePath string, opts ThemeDownloadOptions) error {
 	// Convert git URL to ZIP download URL
 	// Example: https://github.com/theNewDynamic/gohugo-theme-ananke.git
 	//       -> https://github.com/theNewDynamic/gohugo-theme-ananke/archive/refs/heads/master.zip
 	repoURL := strings.TrimSuffix(gitURL, ".git")
 
-	// Try main branch first, then master (GitHub default branch varies)
-	branches := []string{"main", "master"}
-	var zipURL string
-	var resp *http.Response
-	var err error
-
-	downloadTimeout := 2 * time.Minute
-	if envTimeout := os.Getenv("WALGO_THEME_DOWNLOAD_TIMEOUT"); envTimeout != "" {
-		if d, err := time.ParseDuration(envTimeout); err == nil && d > 0 {
-			downloadTimeout = d
-		}
-	}
-	client := &http.Client{Timeout: downloadTimeout}
-
-	for _, branch := range branches {
-		zipURL = repoURL + "/archive/refs/heads/" + branch + ".zip"
-		fmt.Printf("Trying to download theme from %s branch...\n", branch)
-
-		req, err := http.NewRequest(http.MethodGet, zipURL, nil)
-		if err != nil {
-			continue
-		}
-		req.Header.Set("User-Agent", "walgo-theme-installer")
-
-		resp, err = client.Do(req)
-		if err != nil {
-			// resp may be non-nil even on error; close body if present
-			if resp != nil {
-				resp.Body.Close()
-				resp = nil
-			}
-			continue
-		}
-
-		if resp.StatusCode == http.StatusOK {
-			// Found the correct branch
-			fmt.Printf("Found theme on %s branch\n", branch)
-			break
-		}
-		resp.Body.Close()
-		resp = nil
-	}
-
-	if resp == nil || resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download theme from any branch (tried: %v)", branches)
-	}
-	defer resp.Body.Close()
-
-	// Create temporary file for download
-	tmpFile, err := os.CreateTemp("", "hugo-theme-*.zip")
+	tmpName, cleanup, err := fetchThemeZip(repoURL, opts)
 	if err != nil {
-		return fmt.Errorf("creating temp file: %w", err)
-	}
-	tmpName := tmpFile.Name()
-	defer os.Remove(tmpName)
-
-	// Write response body directly to temp file (no close-reopen)
-	if _, err := io.Copy(tmpFile, resp.Body); err != nil {
-		tmpFile.Close()
-		return fmt.Errorf("saving theme download: %w", err)
+		return err
 	}
-	tmpFile.Close()
+	defer cleanup()
 
 	// Extract ZIP archive
 	fmt.Printf("Extracting theme to %s...\n", themePath)
@@ -939,6 +1013,12 @@ func downloadThemeZip(themeName, gitURL, themePath string) error {
 // After installation, it tries to build the site
 // If build fails, it restores the old theme and removes the new one
 func InstallThemeFromURL(sitePath, githubURL string) (string, error) {
+	return InstallThemeFromURLWithOptions(sitePath, githubURL, ThemeDownloadOptions{})
+}
+
+// InstallThemeFromURLWithOptions is InstallThemeFromURL with control over the
+// downloaded ref and the theme archive cache.
+func InstallThemeFromURLWithOptions(sitePath, githubURL string, opts ThemeDownloadOptions) (string, error) {
 	// Validate URL
 	if githubURL == "" {
 		return "", fmt.Errorf("github URL is required")
@@ -1070,7 +1150,7 @@ func InstallThemeFromURL(sitePath, githubURL string) (string, error) {
 	fmt.Printf("Installing theme: %s\n", themeName)
 	themePath := filepath.Join(themesDir, themeName)
 
-	if err := downloadThemeZip(themeName, githubURL+".git", themePath); err != nil {
+	if err := downloadThemeZip(themeName, githubURL+".git", themePath, opts); err != nil {
 		if restoreErr := restoreBackup(); restoreErr != nil {
 			return "", fmt.Errorf("failed to download theme: %w (additionally, restore failed: %v)", err, restoreErr)
 		}
@@ -1,6 +1,7 @@
 package hugo
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -596,3 +597,26 @@ func TestConcurrentExecution(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildSiteWithOptionsVerifyOutput(t *testing.T) {
+	sitePath, hugoPath := writeFixtureSite(t)
+	checkErr := errors.New("manifest mismatch")
+
+	var checked string
+	err := BuildSiteWithOptions(sitePath, BuildOptions{
+		HugoPath: hugoPath,
+		VerifyOutput: func(publishDir string) error {
+			checked = publishDir
+			if _, err := os.Stat(filepath.Join(publishDir, "index.html")); err != nil {
+				t.Errorf("VerifyOutput ran before Hugo rendered the site: %v", err)
+			}
+			return checkErr
+		},
+	})
+	if !errors.Is(err, checkErr) {
+		t.Fatalf("BuildSiteWithOptions() error = %v, want the VerifyOutput error", err)
+	}
+	if checked != filepath.Join(sitePath, "public") {
+		t.Errorf("VerifyOutput got %q, want the publish directory", checked)
+	}
+}
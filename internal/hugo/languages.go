@@ -0,0 +1,150 @@
+package hugo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// languageCodePattern accepts Hugo language keys such as "fr", "pt-br" or "zh-Hans".
+var languageCodePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}([-_][a-zA-Z0-9]{2,8})*$`)
+
+// ValidateLanguageCode checks that code looks like a language tag Hugo accepts.
+func ValidateLanguageCode(code string) error {
+	if !languageCodePattern.MatchString(code) {
+		return fmt.Errorf("invalid language code %q: use a code such as fr, de or pt-br", code)
+	}
+	return nil
+}
+
+// LanguageLayout describes how a multilingual site organizes its translations.
+// Hugo supports two layouts: translation by filename (post.fr.md next to
+// post.md) and translation by content directory (a contentDir per language).
+type LanguageLayout struct {
+	DefaultLanguage string            // defaultContentLanguage, "en" when unset
+	Languages       []string          // Language keys from [languages], sorted
+	ContentDirs     map[string]string // Language -> contentDir relative to the site, when set
+}
+
+// ByDirectory reports whether the site translates by content directory.
+func (l *LanguageLayout) ByDirectory() bool {
+	return len(l.ContentDirs) > 0
+}
+
+// HasLanguage reports whether lang is configured, ignoring case as Hugo does.
+func (l *LanguageLayout) HasLanguage(lang string) bool {
+	for _, configured := range l.Languages {
+		if strings.EqualFold(configured, lang) {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectLanguageLayout reads defaultContentLanguage and [languages] from the
+// site's Hugo config. A site without a config file or languages is treated as
+// a single-language site that translates by filename.
+func DetectLanguageLayout(sitePath string) (*LanguageLayout, error) {
+	layout := &LanguageLayout{DefaultLanguage: "en", ContentDirs: map[string]string{}}
+
+	path, err := FindConfigFile(sitePath)
+	if err != nil {
+		return layout, nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304 - fixed config file names in the site directory
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+	}
+
+	var cfg map[string]interface{}
+	if strings.HasSuffix(path, ".toml") {
+		err = toml.Unmarshal(data, &cfg)
+	} else {
+		err = yaml.Unmarshal(data, &cfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+	}
+
+	if lang, ok := lookup(cfg, "defaultContentLanguage"); ok {
+		if s, ok := lang.(string); ok && s != "" {
+			layout.DefaultLanguage = s
+		}
+	}
+
+	raw, _ := lookup(cfg, "languages")
+	languages, _ := raw.(map[string]interface{})
+	for lang, settings := range languages {
+		layout.Languages = append(layout.Languages, lang)
+		m, _ := settings.(map[string]interface{})
+		if dir, ok := lookup(m, "contentDir"); ok {
+			if s, ok := dir.(string); ok && s != "" {
+				layout.ContentDirs[lang] = filepath.Clean(filepath.FromSlash(s))
+			}
+		}
+	}
+	sort.Strings(layout.Languages)
+
+	return layout, nil
+}
+
+// TranslationPath returns where the lang translation of sourcePath belongs.
+// By filename, content/posts/hello.md becomes content/posts/hello.<lang>.md
+// (an existing language suffix is replaced). By directory, the path relative
+// to the source's content directory is placed under lang's contentDir, or
+// content/<lang> when lang has none configured.
+func (l *LanguageLayout) TranslationPath(sitePath, sourcePath, lang string) (string, error) {
+	if err := ValidateLanguageCode(lang); err != nil {
+		return "", err
+	}
+
+	if !l.ByDirectory() {
+		dir, name := filepath.Split(sourcePath)
+		ext := filepath.Ext(name)
+		stem := strings.TrimSuffix(name, ext)
+		if i := strings.LastIndex(stem, "."); i >= 0 && l.isLanguageSuffix(stem[i+1:]) {
+			stem = stem[:i]
+		}
+		return filepath.Join(dir, stem+"."+lang+ext), nil
+	}
+
+	absSource, err := filepath.Abs(sourcePath)
+	if err != nil {
+		return "", err
+	}
+	sourceDirs := []string{"content"}
+	for _, dir := range l.ContentDirs {
+		sourceDirs = append(sourceDirs, dir)
+	}
+	// Match the deepest directory first, so content/fr wins over content
+	sort.Slice(sourceDirs, func(i, j int) bool { return len(sourceDirs[i]) > len(sourceDirs[j]) })
+
+	for _, dir := range sourceDirs {
+		absDir, err := filepath.Abs(filepath.Join(sitePath, dir))
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(absDir, absSource)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		target := l.ContentDirs[lang]
+		if target == "" {
+			target = filepath.Join("content", lang)
+		}
+		return filepath.Join(sitePath, target, rel), nil
+	}
+	return "", fmt.Errorf("%s is not inside a content directory of %s", sourcePath, sitePath)
+}
+
+// isLanguageSuffix reports whether a filename suffix names a language rather
+// than being part of the name, e.g. "fr" in post.fr.md.
+func (l *LanguageLayout) isLanguageSuffix(s string) bool {
+	return l.HasLanguage(s) || strings.EqualFold(s, l.DefaultLanguage)
+}
@@ -0,0 +1,128 @@
+package hugo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeLanguageConfig(t *testing.T, config string) string {
+	t.Helper()
+	sitePath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(sitePath, "hugo.toml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return sitePath
+}
+
+func TestValidateLanguageCode(t *testing.T) {
+	for _, code := range []string{"fr", "de", "pt-br", "zh-Hans", "es_MX"} {
+		if err := ValidateLanguageCode(code); err != nil {
+			t.Errorf("ValidateLanguageCode(%q) error = %v", code, err)
+		}
+	}
+	for _, code := range []string{"", "f", "french-language-x", "../fr", "fr/", "fr.md"} {
+		if err := ValidateLanguageCode(code); err == nil {
+			t.Errorf("ValidateLanguageCode(%q) accepted an invalid code", code)
+		}
+	}
+}
+
+func TestBuildSiteRejectsInvalidDefaultLanguage(t *testing.T) {
+	err := BuildSiteWithOptions(t.TempDir(), BuildOptions{DefaultLanguage: "../en"})
+	if err == nil || !strings.Contains(err.Error(), "invalid language code") {
+		t.Errorf("BuildSiteWithOptions() error = %v, want an invalid language code error", err)
+	}
+}
+
+func TestDetectLanguageLayoutNoConfig(t *testing.T) {
+	layout, err := DetectLanguageLayout(t.TempDir())
+	if err != nil {
+		t.Fatalf("DetectLanguageLayout() error = %v", err)
+	}
+	if layout.DefaultLanguage != "en" || layout.ByDirectory() || len(layout.Languages) != 0 {
+		t.Errorf("layout = %+v, want single-language en by filename", layout)
+	}
+}
+
+func TestTranslationPathByFilename(t *testing.T) {
+	sitePath := writeLanguageConfig(t, `defaultContentLanguage = "en"
+
+[languages.en]
+weight = 1
+
+[languages.fr]
+weight = 2
+`)
+	layout, err := DetectLanguageLayout(sitePath)
+	if err != nil {
+		t.Fatalf("DetectLanguageLayout() error = %v", err)
+	}
+	if layout.ByDirectory() {
+		t.Fatal("expected translation by filename")
+	}
+	if !layout.HasLanguage("fr") || layout.HasLanguage("de") {
+		t.Errorf("Languages = %v, want en and fr", layout.Languages)
+	}
+
+	dir := filepath.Join(sitePath, "content", "posts")
+	tests := map[string]string{
+		"hello.md":    "hello.fr.md",
+		"hello.en.md": "hello.fr.md",
+		"v1.2.md":     "v1.2.fr.md",
+	}
+	for source, want := range tests {
+		got, err := layout.TranslationPath(sitePath, filepath.Join(dir, source), "fr")
+		if err != nil {
+			t.Fatalf("TranslationPath(%s) error = %v", source, err)
+		}
+		if got != filepath.Join(dir, want) {
+			t.Errorf("TranslationPath(%s) = %s, want %s", source, got, filepath.Join(dir, want))
+		}
+	}
+
+	if _, err := layout.TranslationPath(sitePath, filepath.Join(dir, "hello.md"), "../fr"); err == nil {
+		t.Error("expected an error for an invalid language code")
+	}
+}
+
+func TestTranslationPathByDirectory(t *testing.T) {
+	sitePath := writeLanguageConfig(t, `defaultContentLanguage = "en"
+
+[languages.en]
+contentDir = "content/en"
+
+[languages.fr]
+contentDir = "content/fr"
+`)
+	layout, err := DetectLanguageLayout(sitePath)
+	if err != nil {
+		t.Fatalf("DetectLanguageLayout() error = %v", err)
+	}
+	if !layout.ByDirectory() {
+		t.Fatal("expected translation by content directory")
+	}
+
+	source := filepath.Join(sitePath, "content", "en", "posts", "hello.md")
+	got, err := layout.TranslationPath(sitePath, source, "fr")
+	if err != nil {
+		t.Fatalf("TranslationPath() error = %v", err)
+	}
+	if want := filepath.Join(sitePath, "content", "fr", "posts", "hello.md"); got != want {
+		t.Errorf("TranslationPath() = %s, want %s", got, want)
+	}
+
+	// A language without a configured contentDir goes under content/<lang>
+	got, err = layout.TranslationPath(sitePath, source, "de")
+	if err != nil {
+		t.Fatalf("TranslationPath() error = %v", err)
+	}
+	if want := filepath.Join(sitePath, "content", "de", "posts", "hello.md"); got != want {
+		t.Errorf("TranslationPath() = %s, want %s", got, want)
+	}
+
+	if _, err := layout.TranslationPath(sitePath, filepath.Join(sitePath, "static", "x.md"), "fr"); err == nil {
+		t.Error("expected an error for a file outside the content directories")
+	}
+}
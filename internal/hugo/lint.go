@@ -0,0 +1,477 @@
+package hugo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// Config Lint
+// =============================================================================
+//
+// Catches common hugo.toml / hugo.yaml mistakes before a build turns them into
+// cryptic Hugo errors.
+
+// LintSeverity ranks a config issue.
+type LintSeverity string
+
+const (
+	// LintError means the build will fail or the site will be broken.
+	LintError LintSeverity = "error"
+	// LintWarning means the config is likely wrong but Hugo will still build.
+	LintWarning LintSeverity = "warning"
+)
+
+// ConfigIssue is one problem found in a site config.
+type ConfigIssue struct {
+	Severity LintSeverity `json:"severity"`
+	Line     int          `json:"line,omitempty"` // 1-based, 0 when unknown
+	Message  string       `json:"message"`
+	Context  string       `json:"context,omitempty"` // The offending config line
+}
+
+// ConfigLintResult lists the issues found in a site's config file.
+type ConfigLintResult struct {
+	File   string        `json:"file"`
+	Issues []ConfigIssue `json:"issues"`
+}
+
+// HasErrors reports whether any issue is an error.
+func (r *ConfigLintResult) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+// configFileNames are the site config files walgo understands, in lookup order.
+var configFileNames = []string{"hugo.toml", "hugo.yaml", "hugo.yml", "config.toml", "config.yaml", "config.yml"}
+
+// FindConfigFile returns the path of the site's Hugo config file.
+func FindConfigFile(sitePath string) (string, error) {
+	for _, name := range configFileNames {
+		path := filepath.Join(sitePath, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no Hugo config file found in %s (looked for %s)", sitePath, strings.Join(configFileNames, ", "))
+}
+
+// LintConfig checks the site's Hugo config for syntax errors, duplicate keys,
+// a missing baseURL, themes that are not installed, and menu entries pointing
+// at pages that do not exist.
+func LintConfig(sitePath string) (*ConfigLintResult, error) {
+	path, err := FindConfigFile(sitePath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 - fixed config file names in the site directory
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+	}
+
+	l := &configLinter{
+		sitePath: sitePath,
+		lines:    strings.Split(string(data), "\n"),
+		result:   &ConfigLintResult{File: filepath.Base(path)},
+	}
+
+	var cfg map[string]interface{}
+	if strings.HasSuffix(path, ".toml") {
+		cfg = l.parseTOML(data)
+	} else {
+		cfg = l.parseYAML(data)
+	}
+	if cfg != nil {
+		l.checkBaseURL(cfg)
+		l.checkTheme(cfg)
+		l.checkMenus(cfg)
+	}
+
+	sort.SliceStable(l.result.Issues, func(i, j int) bool {
+		return l.result.Issues[i].Line < l.result.Issues[j].Line
+	})
+	return l.result, nil
+}
+
+type configLinter struct {
+	sitePath string
+	lines    []string
+	result   *ConfigLintResult
+}
+
+func (l *configLinter) add(severity LintSeverity, line int, format string, args ...interface{}) {
+	issue := ConfigIssue{Severity: severity, Line: line, Message: fmt.Sprintf(format, args...)}
+	if line > 0 && line <= len(l.lines) {
+		issue.Context = strings.TrimRight(l.lines[line-1], " \t\r")
+	}
+	l.result.Issues = append(l.result.Issues, issue)
+}
+
+// parseTOML reports duplicate keys and syntax errors. It returns nil when the
+// file cannot be parsed.
+func (l *configLinter) parseTOML(data []byte) map[string]interface{} {
+	duplicates := l.checkTOMLDuplicates()
+
+	var cfg map[string]interface{}
+	err := toml.Unmarshal(data, &cfg)
+	if err == nil {
+		return cfg
+	}
+	// go-toml reports duplicates without a position; they are already listed.
+	if duplicates && strings.Contains(err.Error(), "already defined") {
+		return nil
+	}
+	var decodeErr *toml.DecodeError
+	if errors.As(err, &decodeErr) {
+		row, _ := decodeErr.Position()
+		l.add(LintError, row, "syntax error: %s", strings.TrimPrefix(decodeErr.Error(), "toml: "))
+		return nil
+	}
+	l.add(LintError, 0, "syntax error: %s", strings.TrimPrefix(err.Error(), "toml: "))
+	return nil
+}
+
+// checkTOMLDuplicates scans for keys and tables defined twice in the same
+// table. Hugo config keys are case-insensitive, so baseURL and baseurl clash.
+func (l *configLinter) checkTOMLDuplicates() bool {
+	found := false
+	tables := make(map[string]int) // table header -> line
+	keys := make(map[string]int)   // current table's keys -> line
+	inMultiline := false
+	arrayDepth := 0 // Open brackets of a multi-line array value
+
+	for i, raw := range l.lines {
+		lineNo := i + 1
+		line := strings.TrimSpace(raw)
+
+		if arrayDepth > 0 {
+			arrayDepth += bracketDepth(line)
+			continue
+		}
+		if inMultiline {
+			if strings.Contains(line, `"""`) || strings.Contains(line, "'''") {
+				inMultiline = false
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") {
+			// Each array-of-tables entry starts a fresh key scope and may
+			// define its own sub-tables, such as [menus.main.params]
+			header := strings.ToLower(strings.TrimSpace(strings.Trim(stripTOMLComment(line), "[] ")))
+			for table := range tables {
+				if strings.HasPrefix(table, header+".") {
+					delete(tables, table)
+				}
+			}
+			keys = make(map[string]int)
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			header := strings.ToLower(strings.TrimSpace(strings.Trim(stripTOMLComment(line), "[] ")))
+			if first, ok := tables[header]; ok {
+				l.add(LintError, lineNo, "duplicate table [%s] (first defined on line %d)", header, first)
+				found = true
+			} else {
+				tables[header] = lineNo
+			}
+			keys = make(map[string]int)
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		value = strings.TrimSpace(value)
+		if (strings.HasPrefix(value, `"""`) && strings.Count(value, `"""`) == 1) ||
+			(strings.HasPrefix(value, "'''") && strings.Count(value, "'''") == 1) {
+			inMultiline = true
+		}
+		if strings.HasPrefix(value, "[") {
+			arrayDepth = bracketDepth(value)
+		}
+
+		lower := strings.ToLower(key)
+		if first, ok := keys[lower]; ok {
+			l.add(LintError, lineNo, "duplicate key %q (first defined on line %d; Hugo keys are case-insensitive)", key, first)
+			found = true
+			continue
+		}
+		keys[lower] = lineNo
+	}
+	return found
+}
+
+// bracketDepth returns how many more '[' than ']' appear in line, ignoring
+// brackets inside quoted strings and comments.
+func bracketDepth(line string) int {
+	depth := 0
+	var quote rune
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return depth
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		}
+	}
+	return depth
+}
+
+func stripTOMLComment(line string) string {
+	if i := strings.Index(line, "#"); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+var yamlLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// parseYAML reports duplicate keys and syntax errors. It returns nil when the
+// file cannot be parsed.
+func (l *configLinter) parseYAML(data []byte) map[string]interface{} {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		line := 0
+		if m := yamlLinePattern.FindStringSubmatch(err.Error()); m != nil {
+			line, _ = strconv.Atoi(m[1])
+		}
+		l.add(LintError, line, "syntax error: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+		return nil
+	}
+
+	if l.checkYAMLDuplicates(&doc) {
+		return nil
+	}
+
+	var cfg map[string]interface{}
+	if err := doc.Decode(&cfg); err != nil {
+		l.add(LintError, 0, "invalid config: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+		return nil
+	}
+	return cfg
+}
+
+// checkYAMLDuplicates reports mapping keys defined twice, ignoring case.
+func (l *configLinter) checkYAMLDuplicates(node *yaml.Node) bool {
+	found := false
+	if node.Kind == yaml.MappingNode {
+		seen := make(map[string]int)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			lower := strings.ToLower(key.Value)
+			if first, ok := seen[lower]; ok {
+				l.add(LintError, key.Line, "duplicate key %q (first defined on line %d; Hugo keys are case-insensitive)", key.Value, first)
+				found = true
+				continue
+			}
+			seen[lower] = key.Line
+		}
+	}
+	for _, child := range node.Content {
+		if l.checkYAMLDuplicates(child) {
+			found = true
+		}
+	}
+	return found
+}
+
+// lookup returns the value of key in m, matching case-insensitively as Hugo does.
+func lookup(m map[string]interface{}, key string) (interface{}, bool) {
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// findLine returns the first non-comment line containing all needles, or 0.
+func (l *configLinter) findLine(needles ...string) int {
+	for i, raw := range l.lines {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		match := true
+		for _, needle := range needles {
+			if !strings.Contains(strings.ToLower(line), strings.ToLower(needle)) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (l *configLinter) checkBaseURL(cfg map[string]interface{}) {
+	value, ok := lookup(cfg, "baseURL")
+	if !ok {
+		l.add(LintWarning, 0, "baseURL is not set; absolute links and the sitemap will use the wrong address")
+		return
+	}
+	if s, _ := value.(string); strings.TrimSpace(s) == "" {
+		l.add(LintWarning, l.findLine("baseurl"), "baseURL is empty; absolute links and the sitemap will use the wrong address")
+	}
+}
+
+func (l *configLinter) checkTheme(cfg map[string]interface{}) {
+	value, ok := lookup(cfg, "theme")
+	if !ok {
+		return
+	}
+
+	var themes []string
+	switch v := value.(type) {
+	case string:
+		themes = []string{v}
+	case []interface{}:
+		for _, t := range v {
+			if s, ok := t.(string); ok {
+				themes = append(themes, s)
+			}
+		}
+	}
+
+	themesDir := filepath.Join(l.sitePath, "themes")
+	if dir, ok := lookup(cfg, "themesDir"); ok {
+		if s, _ := dir.(string); s != "" {
+			themesDir = s
+			if !filepath.IsAbs(s) {
+				themesDir = filepath.Join(l.sitePath, s)
+			}
+		}
+	}
+
+	for _, theme := range themes {
+		theme = strings.TrimSpace(theme)
+		if theme == "" {
+			l.add(LintError, l.findLine("theme"), "theme is empty")
+			continue
+		}
+		// Hugo Modules themes (e.g. github.com/owner/theme) are fetched at build time
+		if strings.Contains(theme, "/") {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(themesDir, theme)); err != nil || !info.IsDir() {
+			l.add(LintError, l.findLine("theme", theme), "theme %q is not installed in %s (install it with: walgo theme install <github-url>)",
+				theme, filepath.Base(themesDir)+string(filepath.Separator))
+		}
+	}
+}
+
+// checkMenus reports menu entries whose pageRef or site-relative url points
+// at no content.
+func (l *configLinter) checkMenus(cfg map[string]interface{}) {
+	menus, ok := lookup(cfg, "menus")
+	if !ok {
+		menus, ok = lookup(cfg, "menu")
+	}
+	menuMap, _ := menus.(map[string]interface{})
+	if !ok || menuMap == nil {
+		return
+	}
+
+	taxonomies := map[string]bool{"tags": true, "categories": true}
+	if t, ok := lookup(cfg, "taxonomies"); ok {
+		if tm, ok := t.(map[string]interface{}); ok {
+			taxonomies = make(map[string]bool)
+			for _, plural := range tm {
+				if s, ok := plural.(string); ok {
+					taxonomies[strings.ToLower(s)] = true
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(menuMap))
+	for name := range menuMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, menuName := range names {
+		entries, _ := menuMap[menuName].([]interface{})
+		for _, e := range entries {
+			entry, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			label, _ := lookup(entry, "name")
+			for _, field := range []string{"pageRef", "url"} {
+				v, ok := lookup(entry, field)
+				ref, _ := v.(string)
+				if !ok || ref == "" {
+					continue
+				}
+				if field == "url" && !strings.HasPrefix(ref, "/") {
+					continue // External links and relative URLs are not checked
+				}
+				if !l.pageExists(ref, taxonomies) {
+					l.add(LintWarning, l.findLine(ref),
+						"menu %q entry %v: %s %q does not match any page in content/", menuName, label, field, ref)
+				}
+			}
+		}
+	}
+}
+
+// pageExists reports whether a site path such as "/about/" resolves to
+// content, a taxonomy list, or a static file.
+func (l *configLinter) pageExists(ref string, taxonomies map[string]bool) bool {
+	ref, _, _ = strings.Cut(ref, "#")
+	ref, _, _ = strings.Cut(ref, "?")
+	ref = strings.Trim(ref, "/")
+	if ref == "" {
+		return true
+	}
+	if first, _, _ := strings.Cut(ref, "/"); taxonomies[strings.ToLower(first)] {
+		return true
+	}
+
+	rel := ref
+	if ext := filepath.Ext(ref); ext == ".html" || ext == ".htm" {
+		rel = strings.TrimSuffix(ref, ext)
+	}
+	rel = filepath.FromSlash(rel)
+	content := filepath.Join(l.sitePath, "content")
+	for _, candidate := range []string{
+		filepath.Join(content, rel+".md"),
+		filepath.Join(content, rel),
+		filepath.Join(l.sitePath, "static", filepath.FromSlash(ref)),
+	} {
+		if _, err := os.Stat(candidate); err == nil {
+			return true
+		}
+	}
+	return false
+}
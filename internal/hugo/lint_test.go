@@ -0,0 +1,205 @@
+package hugo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeLintSite creates a site with the given config file and content pages.
+func writeLintSite(t *testing.T, configName, config string, pages ...string) string {
+	t.Helper()
+	site := t.TempDir()
+	if err := os.WriteFile(filepath.Join(site, configName), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, page := range pages {
+		path := filepath.Join(site, "content", filepath.FromSlash(page))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("---\ntitle: x\n---\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return site
+}
+
+// findIssue returns the first issue whose message contains substr.
+func findIssue(result *ConfigLintResult, substr string) *ConfigIssue {
+	for i := range result.Issues {
+		if strings.Contains(result.Issues[i].Message, substr) {
+			return &result.Issues[i]
+		}
+	}
+	return nil
+}
+
+func TestLintConfigMissingBaseURL(t *testing.T) {
+	site := writeLintSite(t, "hugo.toml", "title = \"My Site\"\nlanguageCode = \"en-us\"\n")
+
+	result, err := LintConfig(site)
+	if err != nil {
+		t.Fatalf("LintConfig() error = %v", err)
+	}
+	issue := findIssue(result, "baseURL is not set")
+	if issue == nil {
+		t.Fatalf("expected missing baseURL issue, got %+v", result.Issues)
+	}
+	if issue.Severity != LintWarning {
+		t.Errorf("severity = %s, want warning", issue.Severity)
+	}
+	if result.HasErrors() {
+		t.Errorf("missing baseURL alone should not be an error: %+v", result.Issues)
+	}
+}
+
+func TestLintConfigThemeNotInstalled(t *testing.T) {
+	config := "baseURL = \"/\"\ntitle = \"My Site\"\ntheme = \"ananke\"\n"
+	site := writeLintSite(t, "hugo.toml", config)
+
+	result, err := LintConfig(site)
+	if err != nil {
+		t.Fatalf("LintConfig() error = %v", err)
+	}
+	issue := findIssue(result, `theme "ananke" is not installed`)
+	if issue == nil {
+		t.Fatalf("expected theme issue, got %+v", result.Issues)
+	}
+	if issue.Severity != LintError || issue.Line != 3 || issue.Context != `theme = "ananke"` {
+		t.Errorf("issue = %+v, want error on line 3 with context", issue)
+	}
+	if !result.HasErrors() {
+		t.Error("HasErrors() = false, want true")
+	}
+
+	if err := os.MkdirAll(filepath.Join(site, "themes", "ananke"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	result, _ = LintConfig(site)
+	if len(result.Issues) != 0 {
+		t.Errorf("installed theme should lint clean, got %+v", result.Issues)
+	}
+}
+
+func TestLintConfigDuplicateKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		configName string
+		config     string
+		wantLine   int
+	}{
+		{"toml case-insensitive", "hugo.toml", "baseURL = \"/\"\ntitle = \"A\"\nbaseurl = \"/x/\"\n", 3},
+		{"toml duplicate table", "hugo.toml", "baseURL = \"/\"\n[params]\na = 1\n[params]\nb = 2\n", 4},
+		{"yaml nested", "hugo.yaml", "baseURL: /\nparams:\n  author: A\n  Author: B\n", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := writeLintSite(t, tt.configName, tt.config)
+			result, err := LintConfig(site)
+			if err != nil {
+				t.Fatalf("LintConfig() error = %v", err)
+			}
+			issue := findIssue(result, "duplicate")
+			if issue == nil || issue.Line != tt.wantLine || issue.Severity != LintError {
+				t.Errorf("duplicate issue = %+v, want error on line %d (all: %+v)", issue, tt.wantLine, result.Issues)
+			}
+		})
+	}
+}
+
+func TestLintConfigArraysAreNotDuplicates(t *testing.T) {
+	config := `baseURL = "/"
+[menu]
+  [[menu.main]]
+    name = "About"
+    pageRef = "/about/"
+  [[menu.main]]
+    name = "Posts"
+    pageRef = "/posts/"
+[params]
+  social = [
+    { name = "x", url = "https://x.com" },
+    { name = "gh", url = "https://github.com" },
+  ]
+`
+	site := writeLintSite(t, "hugo.toml", config, "about.md", "posts/_index.md")
+	result, err := LintConfig(site)
+	if err != nil {
+		t.Fatalf("LintConfig() error = %v", err)
+	}
+	if len(result.Issues) != 0 {
+		t.Errorf("expected no issues, got %+v", result.Issues)
+	}
+}
+
+func TestLintConfigArrayEntrySubTables(t *testing.T) {
+	config := `baseURL = "/"
+[[menus.main]]
+  name = "About"
+  pageRef = "/about/"
+  [menus.main.params]
+    icon = "user"
+[[menus.main]]
+  name = "Posts"
+  pageRef = "/posts/"
+  [menus.main.params]
+    icon = "book"
+`
+	site := writeLintSite(t, "hugo.toml", config, "about.md", "posts/_index.md")
+	result, err := LintConfig(site)
+	if err != nil {
+		t.Fatalf("LintConfig() error = %v", err)
+	}
+	if issue := findIssue(result, "duplicate"); issue != nil {
+		t.Errorf("unexpected duplicate issue %+v", issue)
+	}
+	if result.HasErrors() {
+		t.Errorf("expected no errors, got %+v", result.Issues)
+	}
+}
+
+func TestLintConfigMenuMissingPage(t *testing.T) {
+	config := `baseURL: /
+menus:
+  main:
+    - name: About
+      pageRef: /about/
+    - name: Tags
+      url: /tags/
+    - name: GitHub
+      url: https://github.com/example
+`
+	site := writeLintSite(t, "hugo.yaml", config)
+	result, err := LintConfig(site)
+	if err != nil {
+		t.Fatalf("LintConfig() error = %v", err)
+	}
+	if len(result.Issues) != 1 {
+		t.Fatalf("issues = %+v, want only the /about/ entry", result.Issues)
+	}
+	issue := result.Issues[0]
+	if !strings.Contains(issue.Message, `pageRef "/about/"`) || issue.Line != 5 || issue.Severity != LintWarning {
+		t.Errorf("issue = %+v", issue)
+	}
+}
+
+func TestLintConfigSyntaxError(t *testing.T) {
+	site := writeLintSite(t, "hugo.toml", "baseURL = \"/\"\ntitle = = \"x\"\n")
+	result, err := LintConfig(site)
+	if err != nil {
+		t.Fatalf("LintConfig() error = %v", err)
+	}
+	issue := findIssue(result, "syntax error")
+	if issue == nil || issue.Line != 2 {
+		t.Errorf("syntax issue = %+v, want line 2", issue)
+	}
+}
+
+func TestLintConfigNoConfig(t *testing.T) {
+	if _, err := LintConfig(t.TempDir()); err == nil {
+		t.Error("expected error when no config file exists")
+	}
+}
@@ -0,0 +1,152 @@
+package hugo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHugoBuildArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts BuildOptions
+		want []string
+	}{
+		{
+			name: "minify requested",
+			opts: BuildOptions{HugoMinify: true},
+			want: []string{"build", "--environment", "production", "--minify", "--buildDrafts=false", "--buildFuture=false", "--buildExpired=false", "--gc", "--cleanDestinationDir"},
+		},
+		{
+			name: "minify not requested",
+			opts: BuildOptions{},
+			want: []string{"build", "--environment", "production", "--buildDrafts=false", "--buildFuture=false", "--buildExpired=false", "--gc", "--cleanDestinationDir"},
+		},
+		{
+			name: "scheduling overrides",
+			opts: BuildOptions{IncludeFuture: true, IncludeExpired: true},
+			want: []string{"build", "--environment", "production", "--buildDrafts=false", "--buildFuture=true", "--buildExpired=true", "--gc", "--cleanDestinationDir"},
+		},
+		{
+			name: "drafts included",
+			opts: BuildOptions{IncludeDrafts: true},
+			want: []string{"build", "--environment", "production", "--buildDrafts=true", "--buildFuture=false", "--buildExpired=false", "--gc", "--cleanDestinationDir"},
+		},
+		{
+			name: "reproducible",
+			opts: BuildOptions{Reproducible: true},
+			want: []string{"build", "--environment", "production", "--buildDrafts=false", "--buildFuture=false", "--buildExpired=false", "--noTimes", "--noChmod", "--gc", "--cleanDestinationDir"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hugoBuildArgs(tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hugoBuildArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultBuildOptionsMinify(t *testing.T) {
+	if !DefaultBuildOptions().HugoMinify {
+		t.Error("BuildSite should pass --minify to Hugo by default")
+	}
+}
+
+const indentedHTML = `<!DOCTYPE html>
+<html>
+  <head>
+    <title>Test</title>
+  </head>
+  <body>
+    <main>
+      <p>Hello</p>
+    </main>
+  </body>
+</html>
+`
+
+func TestLooksMinified(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{name: "single line", html: `<!doctype html><html><head><title>Test</title></head><body><p>Hello</p></body></html>`, want: true},
+		{name: "indented", html: indentedHTML, want: false},
+		{name: "pre block survives", html: "<html><body><pre>\nline 1\nline 2\nline 3\nline 4\nline 5\nline 6\n</pre></body></html>", want: true},
+		{name: "empty", html: "", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := looksMinified([]byte(tt.html)); got != tt.want {
+				t.Errorf("looksMinified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyMinifiedHTML(t *testing.T) {
+	minified := `<!doctype html><html><body><p>Hi</p></body></html>`
+
+	t.Run("all minified", func(t *testing.T) {
+		dir := t.TempDir()
+		for i := 0; i < 8; i++ {
+			writeTestFile(t, filepath.Join(dir, fmt.Sprintf("page%d", i), "index.html"), minified)
+		}
+		writeTestFile(t, filepath.Join(dir, "style.css"), "body {\n  color: red;\n}\n")
+
+		report, err := VerifyMinifiedHTML(dir, 5)
+		if err != nil {
+			t.Fatalf("VerifyMinifiedHTML() error = %v", err)
+		}
+		if report.Sampled != 5 {
+			t.Errorf("Sampled = %d, want 5", report.Sampled)
+		}
+		if !report.Verified() {
+			t.Errorf("expected minification verified, got %+v", report)
+		}
+	})
+
+	t.Run("theme disabled minify", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "index.html"), minified)
+		writeTestFile(t, filepath.Join(dir, "about", "index.html"), indentedHTML)
+
+		report, err := VerifyMinifiedHTML(dir, 5)
+		if err != nil {
+			t.Fatalf("VerifyMinifiedHTML() error = %v", err)
+		}
+		if report.Verified() {
+			t.Error("expected verification to fail")
+		}
+		if len(report.Unminified) != 1 || !strings.HasSuffix(report.Unminified[0], filepath.Join("about", "index.html")) {
+			t.Errorf("Unminified = %v", report.Unminified)
+		}
+	})
+
+	t.Run("no html", func(t *testing.T) {
+		report, err := VerifyMinifiedHTML(t.TempDir(), 5)
+		if err != nil {
+			t.Fatalf("VerifyMinifiedHTML() error = %v", err)
+		}
+		if report.Sampled != 0 || report.Verified() {
+			t.Errorf("expected nothing sampled, got %+v", report)
+		}
+	})
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
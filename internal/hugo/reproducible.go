@@ -0,0 +1,171 @@
+package hugo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/selimozten/walgo/internal/executil"
+)
+
+// ReproducibleEpoch is the modification time given to every built file in a
+// reproducible build when neither SOURCE_DATE_EPOCH nor a git commit time is
+// known. It is the earliest time zip archives can store.
+var ReproducibleEpoch = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// reproducibleHugoArgs keep Hugo from copying modification times and file
+// modes from the source tree into the publish directory.
+var reproducibleHugoArgs = []string{"--noTimes", "--noChmod"}
+
+// SourceDateEpoch returns the time a reproducible build of sitePath is
+// pinned to: SOURCE_DATE_EPOCH (https://reproducible-builds.org/specs/source-date-epoch/)
+// when set, else the time of the site's last git commit. pinned is false,
+// and the time ReproducibleEpoch, when neither is known.
+func SourceDateEpoch(sitePath string) (t time.Time, pinned bool, err error) {
+	if value := strings.TrimSpace(os.Getenv("SOURCE_DATE_EPOCH")); value != "" {
+		secs, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || secs < 0 {
+			return time.Time{}, false, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: want seconds since the Unix epoch", value)
+		}
+		return time.Unix(secs, 0).UTC(), true, nil
+	}
+
+	cmd := executil.Command("git", "-C", sitePath, "log", "-1", "--format=%ct")
+	if out, err := cmd.Output(); err == nil {
+		if secs, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64); err == nil {
+			return time.Unix(secs, 0).UTC(), true, nil
+		}
+	}
+	return ReproducibleEpoch, false, nil
+}
+
+// reproducibleBuild holds what a reproducible build adds to a Hugo run
+// beyond reproducibleHugoArgs.
+type reproducibleBuild struct {
+	args      []string  // Extra hugo arguments
+	buildTime time.Time // Modification time stamped on every built file
+	cleanup   func()    // Removes the temporary config, if one was written
+}
+
+// prepareReproducibleBuild pins what Hugo would otherwise take from the
+// machine, besides the file times and modes reproducibleHugoArgs keep out:
+// the clock templates see through now and that decides future and expired
+// pages (--clock), and page dates taken from source file times, which configFile's [frontmatter]
+// settings may ask for with ":fileModTime". Without a pinned time --clock is
+// left out and a warning printed, as pinning it to ReproducibleEpoch would
+// hide every dated page as scheduled.
+func prepareReproducibleBuild(sitePath, configFile string) (*reproducibleBuild, error) {
+	buildTime, pinned, err := SourceDateEpoch(sitePath)
+	if err != nil {
+		return nil, err
+	}
+	b := &reproducibleBuild{
+		buildTime: buildTime,
+		cleanup:   func() {},
+	}
+	if pinned {
+		b.args = append(b.args, "--clock", buildTime.Format(time.RFC3339))
+	} else {
+		fmt.Fprintf(os.Stderr, "Warning: SOURCE_DATE_EPOCH is not set and %s is not in a git repository; templates that print the current time will differ between builds.\n", sitePath)
+	}
+
+	config, err := withoutFileModTime(configFile)
+	if err != nil {
+		return nil, err
+	}
+	if config != nil {
+		f, err := os.CreateTemp("", "walgo-reproducible-*.toml")
+		if err != nil {
+			return nil, fmt.Errorf("failed to write reproducible Hugo config: %w", err)
+		}
+		_, werr := f.Write(config)
+		if cerr := f.Close(); werr == nil {
+			werr = cerr
+		}
+		if werr != nil {
+			_ = os.Remove(f.Name())
+			return nil, fmt.Errorf("failed to write reproducible Hugo config: %w", werr)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: page dates from :fileModTime are ignored in a reproducible build; set date or lastmod in the frontmatter instead.\n")
+		b.args = append(b.args, "--config", f.Name())
+		b.cleanup = func() { _ = os.Remove(f.Name()) }
+	}
+	return b, nil
+}
+
+// withoutFileModTime returns the TOML Hugo config in configFile with
+// ":fileModTime" dropped from every [frontmatter] date setting, or nil when
+// none uses it.
+func withoutFileModTime(configFile string) ([]byte, error) {
+	data, err := os.ReadFile(configFile) // #nosec G304 - the site's Hugo config
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", configFile, err)
+	}
+	var cfg map[string]any
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configFile, err)
+	}
+
+	dropped := false
+	for key, value := range cfg {
+		frontmatter, ok := value.(map[string]any)
+		if !ok || !strings.EqualFold(key, "frontmatter") {
+			continue
+		}
+		for name, setting := range frontmatter {
+			sources, ok := setting.([]any)
+			if !ok {
+				continue
+			}
+			kept := make([]any, 0, len(sources))
+			for _, src := range sources {
+				if s, ok := src.(string); ok && strings.EqualFold(s, ":fileModTime") {
+					dropped = true
+					continue
+				}
+				kept = append(kept, src)
+			}
+			frontmatter[name] = kept
+		}
+	}
+	if !dropped {
+		return nil, nil
+	}
+	out, err := toml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode reproducible Hugo config: %w", err)
+	}
+	return out, nil
+}
+
+// NormalizeModTimes sets the modification time of every file and directory
+// in dir to t, so archives and file listings of the build do not depend on
+// when it ran.
+func NormalizeModTimes(dir string, t time.Time) error {
+	var dirs []string
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// Touching a file updates its directory, so directories go last
+			dirs = append(dirs, p)
+			return nil
+		}
+		return os.Chtimes(p, t, t)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to normalize timestamps in %s: %w", dir, err)
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chtimes(dirs[i], t, t); err != nil {
+			return fmt.Errorf("failed to normalize timestamps in %s: %w", dir, err)
+		}
+	}
+	return nil
+}
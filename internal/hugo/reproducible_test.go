@@ -0,0 +1,261 @@
+package hugo
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/selimozten/walgo/internal/deployment"
+)
+
+// writeFixtureSite creates a site whose hugo is a script that renders the
+// fixture content into public/ and records its arguments in hugo-args.
+func writeFixtureSite(t *testing.T) (sitePath, hugoPath string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake hugo is a shell script")
+	}
+	sitePath = t.TempDir()
+	files := map[string]string{
+		"walgo.yaml":            "hugo:\n  publishDir: public\n",
+		"hugo.toml":             "title = 'Fixture'\n",
+		"content/posts/post.md": "---\ntitle: Post\n---\nHello\n",
+		"static/css/site.css":   "body { color: black; }\n",
+	}
+	for rel, content := range files {
+		p := filepath.Join(sitePath, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hugoPath = filepath.Join(t.TempDir(), "hugo")
+	script := `#!/bin/sh
+echo "$@" > hugo-args
+rm -rf public
+mkdir -p public/posts/post public/css
+cp static/css/site.css public/css/site.css
+printf '<html><body>%s</body></html>' "$(cat content/posts/post.md)" > public/posts/post/index.html
+printf '<html><body>Fixture</body></html>' > public/index.html
+`
+	if err := os.WriteFile(hugoPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return sitePath, hugoPath
+}
+
+func TestReproducibleBuildTwice(t *testing.T) {
+	sitePath, hugoPath := writeFixtureSite(t)
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	publicDir := filepath.Join(sitePath, "public")
+	opts := BuildOptions{HugoPath: hugoPath, Reproducible: true}
+
+	if err := BuildSiteWithOptions(sitePath, opts); err != nil {
+		t.Fatalf("first build: %v", err)
+	}
+	first, err := deployment.PublishDirHashes(publicDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A later build must not differ just because time has passed
+	time.Sleep(10 * time.Millisecond)
+	if err := BuildSiteWithOptions(sitePath, opts); err != nil {
+		t.Fatalf("second build: %v", err)
+	}
+	second, err := deployment.PublishDirHashes(publicDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first) != 3 {
+		t.Fatalf("first build hashed %d files, want 3: %v", len(first), first)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("builds differ in %v", deployment.DiffPublishDirHashes(first, second))
+	}
+
+	info, err := os.Stat(filepath.Join(publicDir, "posts", "post", "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(ReproducibleEpoch) {
+		t.Errorf("ModTime = %v, want %v", info.ModTime(), ReproducibleEpoch)
+	}
+	args, err := os.ReadFile(filepath.Join(sitePath, "hugo-args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(args), "--noTimes --noChmod") {
+		t.Errorf("hugo args = %q, want the reproducible flags", args)
+	}
+	if strings.Contains(string(args), "--clock") {
+		t.Errorf("hugo args = %q, want no --clock without a pinned time", args)
+	}
+}
+
+func TestReproducibleBuildPinsClock(t *testing.T) {
+	sitePath, hugoPath := writeFixtureSite(t)
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+	if err := BuildSiteWithOptions(sitePath, BuildOptions{HugoPath: hugoPath, Reproducible: true}); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	args, err := os.ReadFile(filepath.Join(sitePath, "hugo-args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(args), "--clock 2023-11-14T22:13:20Z") {
+		t.Errorf("hugo args = %q, want --clock at SOURCE_DATE_EPOCH", args)
+	}
+	info, err := os.Stat(filepath.Join(sitePath, "public", "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("ModTime = %v, want SOURCE_DATE_EPOCH", info.ModTime())
+	}
+}
+
+// TestReproducibleBuildRealHugo builds a site whose output depends on the
+// clock and on source file times twice with the hugo on PATH.
+func TestReproducibleBuildRealHugo(t *testing.T) {
+	hugoPath, err := exec.LookPath("hugo")
+	if err != nil {
+		t.Skip("hugo not installed")
+	}
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	sitePath := t.TempDir()
+	files := map[string]string{
+		"walgo.yaml":                   "hugo:\n  publishDir: public\n",
+		"hugo.toml":                    "baseURL = 'https://example.com/'\ntitle = 'Fixture'\n[frontmatter]\nlastmod = ['lastmod', ':fileModTime']\n",
+		"layouts/index.html":           "<html><body>{{ now.Unix }}{{ range .Site.RegularPages }} {{ .Title }} {{ .Lastmod.Unix }}{{ end }}</body></html>\n",
+		"layouts/_default/single.html": "<html><body>{{ .Title }} {{ .Lastmod.Unix }}</body></html>\n",
+		"layouts/_default/list.html":   "<html><body>{{ .Title }}</body></html>\n",
+		"content/posts/post.md":        "---\ntitle: Post\ndate: 2020-01-01\n---\nHello\n",
+	}
+	for rel, content := range files {
+		p := filepath.Join(sitePath, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	post := filepath.Join(sitePath, "content", "posts", "post.md")
+	publicDir := filepath.Join(sitePath, "public")
+	opts := BuildOptions{HugoPath: hugoPath, Reproducible: true}
+
+	build := func(modTime time.Time) map[string]string {
+		t.Helper()
+		if err := os.Chtimes(post, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		if err := BuildSiteWithOptions(sitePath, opts); err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		hashes, err := deployment.PublishDirHashes(publicDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hashes
+	}
+	first := build(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	// now would tick over a second and the post's file time has changed
+	time.Sleep(1100 * time.Millisecond)
+	second := build(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	if _, ok := first["posts/post/index.html"]; !ok {
+		t.Fatalf("post not built: %v", first)
+	}
+	if diff := deployment.DiffPublishDirHashes(first, second); len(diff) != 0 {
+		t.Errorf("builds differ in %v", diff)
+	}
+}
+
+func TestSourceDateEpoch(t *testing.T) {
+	sitePath := t.TempDir()
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	got, pinned, err := SourceDateEpoch(sitePath)
+	if err != nil || !pinned || !got.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("SourceDateEpoch() = %v, %t, %v", got, pinned, err)
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	if got, pinned, _ := SourceDateEpoch(sitePath); pinned || !got.Equal(ReproducibleEpoch) {
+		t.Errorf("SourceDateEpoch() unset = %v, %t, want ReproducibleEpoch", got, pinned)
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+	if _, _, err := SourceDateEpoch(sitePath); err == nil {
+		t.Error("expected an error for a non-numeric SOURCE_DATE_EPOCH")
+	}
+}
+
+func TestSourceDateEpochFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	t.Setenv("GIT_COMMITTER_DATE", "1600000000 +0000")
+	t.Setenv("GIT_AUTHOR_DATE", "1600000000 +0000")
+	sitePath := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=walgo", "-c", "user.email=walgo@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = sitePath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	got, pinned, err := SourceDateEpoch(sitePath)
+	if err != nil || !pinned || !got.Equal(time.Unix(1600000000, 0)) {
+		t.Errorf("SourceDateEpoch() = %v, %t, %v, want the commit time", got, pinned, err)
+	}
+}
+
+func TestWithoutFileModTime(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "hugo.toml")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("title = 'Site'\n[frontmatter]\nlastmod = ['lastmod', ':git']\n")
+	if got, err := withoutFileModTime(configFile); err != nil || got != nil {
+		t.Errorf("withoutFileModTime() = %q, %v, want nil", got, err)
+	}
+
+	write("title = 'Site'\n[frontMatter]\ndate = ['date', ':fileModTime']\nlastmod = [':fileModTime']\n")
+	got, err := withoutFileModTime(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg map[string]any
+	if err := toml.Unmarshal(got, &cfg); err != nil {
+		t.Fatalf("config %q: %v", got, err)
+	}
+	want := map[string]any{
+		"title":       "Site",
+		"frontMatter": map[string]any{"date": []any{"date"}, "lastmod": []any{}},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("config = %v, want %v", cfg, want)
+	}
+}
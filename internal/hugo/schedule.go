@@ -0,0 +1,162 @@
+package hugo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+// Frontmatter keys Hugo reads for scheduling, in priority order (Hugo's
+// default [frontmatter] configuration).
+var (
+	publishDateKeys = []string{"publishdate", "pubdate", "published", "date"}
+	expiryDateKeys  = []string{"expirydate", "unpublishdate"}
+)
+
+// frontmatterDateLayouts are the date formats accepted in frontmatter strings.
+// Dates without a zone are taken as UTC, Hugo's default site timezone.
+var frontmatterDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// PageState is the scheduling outcome for a content page.
+type PageState string
+
+const (
+	PagePublished PageState = "published"
+	PageScheduled PageState = "scheduled" // publishDate is in the future
+	PageExpired   PageState = "expired"   // expiryDate has passed
+)
+
+// SchedulePage decides whether a page with the given dates is published at
+// now. Zero dates are ignored. Expiry wins over a future publish date, as a
+// page that has already expired will never go live.
+func SchedulePage(publishDate, expiryDate, now time.Time, opts BuildOptions) PageState {
+	if !expiryDate.IsZero() && !expiryDate.After(now) && !opts.IncludeExpired {
+		return PageExpired
+	}
+	if !publishDate.IsZero() && publishDate.After(now) && !opts.IncludeFuture {
+		return PageScheduled
+	}
+	return PagePublished
+}
+
+// ScheduledPage is a content file held back from the build.
+type ScheduledPage struct {
+	Path  string    // Relative to the content directory
+	State PageState // PageScheduled or PageExpired
+	Date  time.Time // The publish or expiry date that decided State
+}
+
+// FindUnpublishedPages lists the Markdown pages under contentDir that a build
+// with opts will leave out because they are scheduled or expired.
+func FindUnpublishedPages(contentDir string, now time.Time, opts BuildOptions) ([]ScheduledPage, error) {
+	var pages []ScheduledPage
+	err := filepath.Walk(contentDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path) // #nosec G304 - path is a content file under contentDir
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		fields := ai.ParseFrontmatterFields(string(data))
+		publish := frontmatterDate(fields, publishDateKeys)
+		expiry := frontmatterDate(fields, expiryDateKeys)
+
+		state := SchedulePage(publish, expiry, now, opts)
+		if state == PagePublished {
+			return nil
+		}
+
+		rel, err := filepath.Rel(contentDir, path)
+		if err != nil {
+			rel = path
+		}
+		page := ScheduledPage{Path: filepath.ToSlash(rel), State: state, Date: publish}
+		if state == PageExpired {
+			page.Date = expiry
+		}
+		pages = append(pages, page)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pages, func(i, j int) bool { return pages[i].Path < pages[j].Path })
+	return pages, nil
+}
+
+// frontmatterDate returns the first parseable date among keys (matched
+// case-insensitively), or the zero time.
+func frontmatterDate(fields map[string]interface{}, keys []string) time.Time {
+	lower := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		lower[strings.ToLower(k)] = v
+	}
+	for _, key := range keys {
+		if v, ok := lower[key]; ok {
+			if t, ok := parseFrontmatterDate(v); ok {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// parseFrontmatterDate converts a decoded frontmatter value to a time. YAML
+// yields time.Time, TOML local dates stringify to ISO formats, and JSON
+// yields strings.
+func parseFrontmatterDate(v interface{}) (time.Time, bool) {
+	if t, ok := v.(time.Time); ok {
+		return t, true
+	}
+	if v == nil {
+		return time.Time{}, false
+	}
+	s := strings.TrimSpace(fmt.Sprint(v))
+	for _, layout := range frontmatterDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// reportUnpublishedPages prints the pages a build leaves out, so a scheduled
+// post that is missing from the site is not a surprise.
+func reportUnpublishedPages(sitePath string, opts BuildOptions) {
+	contentDir := filepath.Join(sitePath, "content")
+	if _, err := os.Stat(contentDir); err != nil {
+		return
+	}
+
+	pages, err := FindUnpublishedPages(contentDir, time.Now(), opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not check content schedule: %v\n", err)
+		return
+	}
+
+	for _, p := range pages {
+		switch p.State {
+		case PageScheduled:
+			fmt.Printf("Skipping scheduled page %s (publishes %s; use --future to build it)\n", p.Path, p.Date.Format("2006-01-02 15:04 MST"))
+		case PageExpired:
+			fmt.Printf("Skipping expired page %s (expired %s; use --expired to build it)\n", p.Path, p.Date.Format("2006-01-02 15:04 MST"))
+		}
+	}
+}
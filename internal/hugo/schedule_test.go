@@ -0,0 +1,97 @@
+package hugo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSchedulePage(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-24 * time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		publish time.Time
+		expiry  time.Time
+		opts    BuildOptions
+		want    PageState
+	}{
+		{name: "no dates", want: PagePublished},
+		{name: "published in the past", publish: past, want: PagePublished},
+		{name: "publishes exactly now", publish: now, want: PagePublished},
+		{name: "future publish date", publish: future, want: PageScheduled},
+		{name: "future included", publish: future, opts: BuildOptions{IncludeFuture: true}, want: PagePublished},
+		{name: "expiry in the future", expiry: future, want: PagePublished},
+		{name: "expires exactly now", expiry: now, want: PageExpired},
+		{name: "expired", publish: past, expiry: past, want: PageExpired},
+		{name: "expired included", expiry: past, opts: BuildOptions{IncludeExpired: true}, want: PagePublished},
+		{name: "expired wins over scheduled", publish: future, expiry: past, want: PageExpired},
+		{name: "expired included but still scheduled", publish: future, expiry: past, opts: BuildOptions{IncludeExpired: true}, want: PageScheduled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SchedulePage(tt.publish, tt.expiry, now, tt.opts); got != tt.want {
+				t.Errorf("SchedulePage() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindUnpublishedPages(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"posts/live.md":           "---\ntitle: Live\ndate: 2025-01-01\n---\n",
+		"posts/scheduled.md":      "---\ntitle: Soon\npublishDate: 2025-12-24T09:00:00Z\n---\n",
+		"posts/date-future.md":    "+++\ntitle = \"Dated\"\ndate = 2026-01-01\n+++\n",
+		"posts/expired.md":        "---\ntitle: Old\nexpiryDate: \"2025-02-01\"\n---\n",
+		"posts/unpublish.md":      "{\"title\": \"JSON\", \"unpublishdate\": \"2025-03-01 08:00:00\"}\n",
+		"posts/pub-over-date.md":  "---\ndate: 2030-01-01\npublishDate: 2025-01-01\n---\n",
+		"posts/no-frontmatter.md": "Just text\n",
+	}
+	for rel, body := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	pages, err := FindUnpublishedPages(dir, now, BuildOptions{})
+	if err != nil {
+		t.Fatalf("FindUnpublishedPages() error = %v", err)
+	}
+	got := make(map[string]PageState)
+	for _, p := range pages {
+		got[p.Path] = p.State
+	}
+	want := map[string]PageState{
+		"posts/scheduled.md":   PageScheduled,
+		"posts/date-future.md": PageScheduled,
+		"posts/expired.md":     PageExpired,
+		"posts/unpublish.md":   PageExpired,
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for path, state := range want {
+		if got[path] != state {
+			t.Errorf("%s = %q, want %q", path, got[path], state)
+		}
+	}
+
+	pages, err = FindUnpublishedPages(dir, now, BuildOptions{IncludeFuture: true, IncludeExpired: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 0 {
+		t.Errorf("with overrides, got %v, want none", pages)
+	}
+}
@@ -0,0 +1,138 @@
+package hugo
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/ai"
+)
+
+// MaxSeriesParts caps the number of parts NewSeries creates.
+const MaxSeriesParts = 100
+
+// SeriesOptions configures NewSeries.
+type SeriesOptions struct {
+	Title string
+	Parts int
+	// Fields are extra frontmatter fields the theme expects (see
+	// ai.GetDynamicFrontmatterFields). Fields NewSeries sets itself are skipped.
+	Fields []string
+	Draft  bool
+	Date   time.Time // Default: now
+}
+
+// seriesOwnFields are the frontmatter fields NewSeries always writes.
+var seriesOwnFields = []string{"title", "date", "draft", "weight", "series", "part", "parts", "prev", "next"}
+
+// NewSeries scaffolds a multi-part series under content/<section>: a branch
+// bundle (_index.md) plus one leaf bundle per part (part-N/index.md). Parts
+// are weighted in order, share a series taxonomy term and link to their
+// neighbours through prev/next frontmatter. It fails without writing
+// anything if any of the files already exist, and returns the files created.
+func NewSeries(sitePath, section string, opts SeriesOptions) ([]string, error) {
+	section = strings.Trim(filepath.ToSlash(section), "/")
+	if section == "" || strings.Contains(section, "..") || filepath.IsAbs(section) {
+		return nil, fmt.Errorf("invalid section %q: must be a relative path without '..'", section)
+	}
+	if strings.TrimSpace(opts.Title) == "" {
+		return nil, fmt.Errorf("series title cannot be empty")
+	}
+	if opts.Parts < 1 || opts.Parts > MaxSeriesParts {
+		return nil, fmt.Errorf("parts must be between 1 and %d", MaxSeriesParts)
+	}
+	if opts.Date.IsZero() {
+		opts.Date = time.Now()
+	}
+
+	sectionDir := filepath.Join(sitePath, "content", filepath.FromSlash(section))
+	files := map[string]string{
+		filepath.Join(sectionDir, "_index.md"): renderSeriesIndex(opts),
+	}
+	order := []string{filepath.Join(sectionDir, "_index.md")}
+	for n := 1; n <= opts.Parts; n++ {
+		p := filepath.Join(sectionDir, seriesPartDir(n), "index.md")
+		files[p] = renderSeriesPart(section, n, opts)
+		order = append(order, p)
+	}
+
+	for _, p := range order {
+		if _, err := os.Stat(p); err == nil {
+			return nil, fmt.Errorf("%s already exists", p)
+		}
+	}
+	for _, p := range order {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return nil, fmt.Errorf("creating %s: %w", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte(files[p]), 0644); err != nil {
+			return nil, fmt.Errorf("writing %s: %w", p, err)
+		}
+	}
+	return order, nil
+}
+
+// seriesPartDir is the bundle directory of part n.
+func seriesPartDir(n int) string {
+	return fmt.Sprintf("part-%d", n)
+}
+
+// seriesPartURL is the URL of part n of the series in section.
+func seriesPartURL(section string, n int) string {
+	return "/" + path.Join(section, seriesPartDir(n)) + "/"
+}
+
+func renderSeriesIndex(opts SeriesOptions) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %q\n", opts.Title)
+	fmt.Fprintf(&b, "date: %s\n", opts.Date.Format(time.RFC3339))
+	fmt.Fprintf(&b, "draft: %t\n", opts.Draft)
+	fmt.Fprintf(&b, "series: [%q]\n", opts.Title)
+	fmt.Fprintf(&b, "parts: %d\n", opts.Parts)
+	b.WriteString("---\n\n")
+	fmt.Fprintf(&b, "An introduction to the %d-part series %s.\n", opts.Parts, opts.Title)
+	return b.String()
+}
+
+func renderSeriesPart(section string, n int, opts SeriesOptions) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %q\n", fmt.Sprintf("%s: Part %d", opts.Title, n))
+	fmt.Fprintf(&b, "date: %s\n", opts.Date.Format(time.RFC3339))
+	fmt.Fprintf(&b, "draft: %t\n", opts.Draft)
+	fmt.Fprintf(&b, "weight: %d\n", n)
+	fmt.Fprintf(&b, "series: [%q]\n", opts.Title)
+	fmt.Fprintf(&b, "part: %d\n", n)
+	fmt.Fprintf(&b, "parts: %d\n", opts.Parts)
+	if n > 1 {
+		fmt.Fprintf(&b, "prev: %q\n", seriesPartURL(section, n-1))
+	}
+	if n < opts.Parts {
+		fmt.Fprintf(&b, "next: %q\n", seriesPartURL(section, n+1))
+	}
+	written := append([]string{}, seriesOwnFields...)
+	for _, field := range opts.Fields {
+		if containsFold(written, field) {
+			continue
+		}
+		written = append(written, field)
+		fmt.Fprintf(&b, "%s: %s\n", field, ai.FrontmatterFieldDefault(field))
+	}
+	b.WriteString("---\n\n")
+	fmt.Fprintf(&b, "Part %d of %d of %s.\n", n, opts.Parts, opts.Title)
+	return b.String()
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package hugo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readFrontmatter parses the YAML frontmatter of a generated file.
+func readFrontmatter(t *testing.T, path string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.SplitN(string(data), "---\n", 3)
+	if len(parts) != 3 || parts[0] != "" {
+		t.Fatalf("%s has no YAML frontmatter:\n%s", path, data)
+	}
+	fm := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(parts[1]), &fm); err != nil {
+		t.Fatalf("%s frontmatter: %v", path, err)
+	}
+	return fm
+}
+
+func TestNewSeries(t *testing.T) {
+	site := t.TempDir()
+	opts := SeriesOptions{
+		Title:  `Go "Basics"`,
+		Parts:  3,
+		Fields: []string{"title", "description", "tags", "Weight", "bookToc", "tags"},
+		Date:   time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	created, err := NewSeries(site, "tutorials/go-basics/", opts)
+	if err != nil {
+		t.Fatalf("NewSeries() error = %v", err)
+	}
+
+	dir := filepath.Join(site, "content", "tutorials", "go-basics")
+	want := []string{
+		filepath.Join(dir, "_index.md"),
+		filepath.Join(dir, "part-1", "index.md"),
+		filepath.Join(dir, "part-2", "index.md"),
+		filepath.Join(dir, "part-3", "index.md"),
+	}
+	if strings.Join(created, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("created = %v, want %v", created, want)
+	}
+
+	index := readFrontmatter(t, want[0])
+	if index["title"] != opts.Title || index["parts"] != 3 || index["draft"] != false {
+		t.Errorf("_index.md frontmatter = %v", index)
+	}
+
+	links := []struct{ prev, next interface{} }{
+		{nil, "/tutorials/go-basics/part-2/"},
+		{"/tutorials/go-basics/part-1/", "/tutorials/go-basics/part-3/"},
+		{"/tutorials/go-basics/part-2/", nil},
+	}
+	for i, l := range links {
+		n := i + 1
+		fm := readFrontmatter(t, want[n])
+		if fm["weight"] != n || fm["part"] != n || fm["parts"] != 3 {
+			t.Errorf("part %d: weight/part/parts = %v/%v/%v", n, fm["weight"], fm["part"], fm["parts"])
+		}
+		if fm["title"] != fmt.Sprintf(`Go "Basics": Part %d`, n) {
+			t.Errorf("part %d: title = %v", n, fm["title"])
+		}
+		series, _ := fm["series"].([]interface{})
+		if len(series) != 1 || series[0] != opts.Title {
+			t.Errorf("part %d: series = %v", n, fm["series"])
+		}
+		if fm["prev"] != l.prev || fm["next"] != l.next {
+			t.Errorf("part %d: prev/next = %v/%v, want %v/%v", n, fm["prev"], fm["next"], l.prev, l.next)
+		}
+		// Theme fields are added once, without overriding the series fields
+		if _, ok := fm["description"]; !ok {
+			t.Errorf("part %d: missing theme field description", n)
+		}
+		if _, ok := fm["bookToc"]; !ok {
+			t.Errorf("part %d: missing theme field bookToc", n)
+		}
+		if _, ok := fm["Weight"]; ok {
+			t.Errorf("part %d: theme field Weight duplicates weight", n)
+		}
+	}
+}
+
+func TestNewSeriesRefusesExisting(t *testing.T) {
+	site := t.TempDir()
+	existing := filepath.Join(site, "content", "guides", "part-2", "index.md")
+	if err := os.MkdirAll(filepath.Dir(existing), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewSeries(site, "guides", SeriesOptions{Title: "Guide", Parts: 3})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("NewSeries() error = %v, want already exists", err)
+	}
+	if _, err := os.Stat(filepath.Join(site, "content", "guides", "_index.md")); !os.IsNotExist(err) {
+		t.Error("NewSeries wrote files despite the conflict")
+	}
+}
+
+func TestNewSeriesValidation(t *testing.T) {
+	site := t.TempDir()
+	tests := []struct {
+		name    string
+		section string
+		opts    SeriesOptions
+	}{
+		{"empty section", "", SeriesOptions{Title: "T", Parts: 2}},
+		{"escaping section", "../outside", SeriesOptions{Title: "T", Parts: 2}},
+		{"empty title", "s", SeriesOptions{Title: " ", Parts: 2}},
+		{"zero parts", "s", SeriesOptions{Title: "T"}},
+		{"too many parts", "s", SeriesOptions{Title: "T", Parts: MaxSeriesParts + 1}},
+	}
+	for _, tt := range tests {
+		if _, err := NewSeries(site, tt.section, tt.opts); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
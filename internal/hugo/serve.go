@@ -0,0 +1,240 @@
+package hugo
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultServeBind keeps the preview server reachable from this machine only.
+	DefaultServeBind = "127.0.0.1"
+	// DefaultServePort is Hugo's default development server port.
+	DefaultServePort = 1313
+)
+
+// ServeOptions controls the flags passed to 'hugo server'.
+type ServeOptions struct {
+	// Bind is the interface to listen on; empty means DefaultServeBind.
+	Bind string
+	// Port to listen on; 0 means DefaultServePort.
+	Port int
+	// TLS serves over HTTPS using CertFile and KeyFile.
+	TLS      bool
+	CertFile string
+	KeyFile  string
+	// Drafts, Expired and Future include the matching content (-D, -E, -F).
+	Drafts  bool
+	Expired bool
+	Future  bool
+}
+
+// ParseBindAddress validates bind and returns it as an IP literal.
+// An empty bind yields DefaultServeBind and "localhost" yields 127.0.0.1.
+func ParseBindAddress(bind string) (string, error) {
+	switch bind {
+	case "":
+		return DefaultServeBind, nil
+	case "localhost":
+		return "127.0.0.1", nil
+	}
+	ip := net.ParseIP(bind)
+	if ip == nil {
+		return "", fmt.Errorf("invalid bind address %q: use an IP address such as 127.0.0.1 or 0.0.0.0", bind)
+	}
+	return ip.String(), nil
+}
+
+// IsExposedBind reports whether a server bound to bind is reachable from
+// other machines.
+func IsExposedBind(bind string) bool {
+	ip := net.ParseIP(bind)
+	return ip != nil && !ip.IsLoopback()
+}
+
+// LANAddresses returns the non-loopback IPv4 addresses of this machine's
+// active network interfaces.
+func LANAddresses() []string {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+
+	var addrs []string
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		ifAddrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range ifAddrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			if ip4 := ipNet.IP.To4(); ip4 != nil && !ip4.IsLinkLocalUnicast() {
+				addrs = append(addrs, ip4.String())
+			}
+		}
+	}
+	return addrs
+}
+
+// serveHost is the host other devices should use to reach a server on bind.
+func serveHost(bind string) string {
+	ip := net.ParseIP(bind)
+	switch {
+	case ip == nil || ip.IsLoopback():
+		return "localhost"
+	case ip.IsUnspecified():
+		if lan := LANAddresses(); len(lan) > 0 {
+			return lan[0]
+		}
+		return "localhost"
+	default:
+		return bind
+	}
+}
+
+// ServeURL returns the URL the server started with opts is reachable at.
+func ServeURL(opts ServeOptions) string {
+	scheme := "http"
+	if opts.TLS {
+		scheme = "https"
+	}
+	port := opts.Port
+	if port == 0 {
+		port = DefaultServePort
+	}
+	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(serveHost(opts.Bind), strconv.Itoa(port)))
+}
+
+// ServerArgs returns the 'hugo server' arguments for opts. When the server is
+// exposed beyond localhost, the base URL is set to the LAN host so links work
+// from other devices.
+func ServerArgs(opts ServeOptions) []string {
+	bind := opts.Bind
+	if bind == "" {
+		bind = DefaultServeBind
+	}
+	port := opts.Port
+	if port == 0 {
+		port = DefaultServePort
+	}
+
+	args := []string{"server", "--bind", bind, "--port", strconv.Itoa(port)}
+	if opts.Drafts {
+		args = append(args, "-D")
+	}
+	if opts.Expired {
+		args = append(args, "-E")
+	}
+	if opts.Future {
+		args = append(args, "-F")
+	}
+	if opts.TLS {
+		args = append(args, "--tlsCertFile", opts.CertFile, "--tlsKeyFile", opts.KeyFile)
+	}
+	if IsExposedBind(bind) || opts.TLS {
+		scheme := "http"
+		if opts.TLS {
+			scheme = "https"
+		}
+		args = append(args, "--baseURL", fmt.Sprintf("%s://%s/", scheme, serveHost(bind)))
+	}
+	return args
+}
+
+// GenerateSelfSignedCert writes a short-lived self-signed certificate and key
+// for localhost and hosts into dir, returning their paths. It is meant for
+// previewing over HTTPS on a LAN; browsers will still ask to trust it.
+func GenerateSelfSignedCert(dir string, hosts []string) (certFile, keyFile string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("generating key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", fmt.Errorf("generating serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"walgo serve"}, CommonName: "localhost"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(30 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			if !ip.IsUnspecified() && !ip.IsLoopback() {
+				template.IPAddresses = append(template.IPAddresses, ip)
+			}
+		} else if host != "" && host != "localhost" {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", fmt.Errorf("creating certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", "", fmt.Errorf("encoding key: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", "", fmt.Errorf("creating certificate directory: %w", err)
+	}
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		return "", "", fmt.Errorf("writing certificate: %w", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return "", "", fmt.Errorf("writing key: %w", err)
+	}
+	return certFile, keyFile, nil
+}
+
+// PrepareTLS generates a throwaway certificate covering opts.Bind and the LAN
+// addresses when opts.TLS is set without a certificate, and fills in
+// CertFile and KeyFile. The returned cleanup removes the generated files.
+func PrepareTLS(opts *ServeOptions) (cleanup func(), err error) {
+	cleanup = func() {}
+	if !opts.TLS || opts.CertFile != "" {
+		return cleanup, nil
+	}
+
+	dir, err := os.MkdirTemp("", "walgo-serve-tls-")
+	if err != nil {
+		return cleanup, fmt.Errorf("creating certificate directory: %w", err)
+	}
+
+	hosts := append([]string{opts.Bind}, LANAddresses()...)
+	opts.CertFile, opts.KeyFile, err = GenerateSelfSignedCert(dir, hosts)
+	if err != nil {
+		os.RemoveAll(dir)
+		return cleanup, err
+	}
+	return func() { os.RemoveAll(dir) }, nil
+}
@@ -0,0 +1,181 @@
+package hugo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseBindAddress(t *testing.T) {
+	tests := []struct {
+		bind    string
+		want    string
+		wantErr bool
+	}{
+		{"", DefaultServeBind, false},
+		{"localhost", "127.0.0.1", false},
+		{"127.0.0.1", "127.0.0.1", false},
+		{"0.0.0.0", "0.0.0.0", false},
+		{"192.168.1.20", "192.168.1.20", false},
+		{"::1", "::1", false},
+		{"example.com", "", true},
+		{"300.1.1.1", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBindAddress(tt.bind)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseBindAddress(%q) error = %v, wantErr %v", tt.bind, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseBindAddress(%q) = %q, want %q", tt.bind, got, tt.want)
+		}
+	}
+}
+
+func TestIsExposedBind(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1":    false,
+		"::1":          false,
+		"0.0.0.0":      true,
+		"192.168.1.20": true,
+		"":             false,
+	}
+	for bind, want := range tests {
+		if got := IsExposedBind(bind); got != want {
+			t.Errorf("IsExposedBind(%q) = %v, want %v", bind, got, want)
+		}
+	}
+}
+
+func TestServerArgs(t *testing.T) {
+	t.Run("defaults to localhost", func(t *testing.T) {
+		args := strings.Join(ServerArgs(ServeOptions{}), " ")
+		if !strings.Contains(args, "server --bind 127.0.0.1 --port 1313") {
+			t.Errorf("ServerArgs() = %q, want default bind and port", args)
+		}
+		if strings.Contains(args, "--baseURL") {
+			t.Errorf("ServerArgs() = %q, should not override baseURL on localhost", args)
+		}
+	})
+
+	t.Run("content flags", func(t *testing.T) {
+		args := strings.Join(ServerArgs(ServeOptions{Drafts: true, Expired: true, Future: true}), " ")
+		for _, flag := range []string{" -D", " -E", " -F"} {
+			if !strings.Contains(args, flag) {
+				t.Errorf("ServerArgs() = %q, missing %q", args, flag)
+			}
+		}
+	})
+
+	t.Run("exposed bind sets baseURL", func(t *testing.T) {
+		args := strings.Join(ServerArgs(ServeOptions{Bind: "192.168.1.20", Port: 8080}), " ")
+		if !strings.Contains(args, "--bind 192.168.1.20 --port 8080") {
+			t.Errorf("ServerArgs() = %q, want custom bind and port", args)
+		}
+		if !strings.Contains(args, "--baseURL http://192.168.1.20/") {
+			t.Errorf("ServerArgs() = %q, want LAN baseURL", args)
+		}
+	})
+
+	t.Run("tls passes certificate", func(t *testing.T) {
+		args := strings.Join(ServerArgs(ServeOptions{TLS: true, CertFile: "c.pem", KeyFile: "k.pem"}), " ")
+		if !strings.Contains(args, "--tlsCertFile c.pem --tlsKeyFile k.pem") {
+			t.Errorf("ServerArgs() = %q, want TLS flags", args)
+		}
+		if !strings.Contains(args, "--baseURL https://localhost/") {
+			t.Errorf("ServerArgs() = %q, want https baseURL", args)
+		}
+	})
+}
+
+func TestServeURL(t *testing.T) {
+	if got := ServeURL(ServeOptions{}); got != "http://localhost:1313" {
+		t.Errorf("ServeURL() = %q, want http://localhost:1313", got)
+	}
+	if got := ServeURL(ServeOptions{Bind: "10.0.0.5", Port: 8443, TLS: true}); got != "https://10.0.0.5:8443" {
+		t.Errorf("ServeURL() = %q, want https://10.0.0.5:8443", got)
+	}
+}
+
+func TestGenerateSelfSignedCert(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, err := GenerateSelfSignedCert(dir, []string{"192.168.1.20", "0.0.0.0", "preview.local"})
+	if err != nil {
+		t.Fatalf("GenerateSelfSignedCert() error = %v", err)
+	}
+
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		t.Fatalf("generated pair is not usable: %v", err)
+	}
+
+	info, err := os.Stat(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("key file mode = %o, want 600", perm)
+	}
+
+	data, err := os.ReadFile(certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatal("certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+
+	if err := cert.VerifyHostname("192.168.1.20"); err != nil {
+		t.Errorf("certificate should cover the LAN address: %v", err)
+	}
+	if err := cert.VerifyHostname("localhost"); err != nil {
+		t.Errorf("certificate should cover localhost: %v", err)
+	}
+	if err := cert.VerifyHostname("preview.local"); err != nil {
+		t.Errorf("certificate should cover preview.local: %v", err)
+	}
+	for _, ip := range cert.IPAddresses {
+		if ip.Equal(net.IPv4zero) {
+			t.Error("certificate should not include the unspecified address")
+		}
+	}
+}
+
+func TestPrepareTLS(t *testing.T) {
+	t.Run("no-op without tls", func(t *testing.T) {
+		opts := ServeOptions{}
+		cleanup, err := PrepareTLS(&opts)
+		if err != nil {
+			t.Fatalf("PrepareTLS() error = %v", err)
+		}
+		cleanup()
+		if opts.CertFile != "" {
+			t.Errorf("CertFile = %q, want empty", opts.CertFile)
+		}
+	})
+
+	t.Run("generates and cleans up", func(t *testing.T) {
+		opts := ServeOptions{TLS: true, Bind: "127.0.0.1"}
+		cleanup, err := PrepareTLS(&opts)
+		if err != nil {
+			t.Fatalf("PrepareTLS() error = %v", err)
+		}
+		if _, err := os.Stat(opts.CertFile); err != nil {
+			t.Fatalf("certificate not written: %v", err)
+		}
+		cleanup()
+		if _, err := os.Stat(opts.CertFile); !os.IsNotExist(err) {
+			t.Errorf("certificate should be removed after cleanup, stat err = %v", err)
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package hugo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ThemeDownloadOptions controls how a theme archive is fetched from GitHub.
+type ThemeDownloadOptions struct {
+	// Ref is the branch, tag, or commit to download. Empty tries the main
+	// and master branches in turn.
+	Ref string
+	// NoCache skips reading and writing the local theme archive cache.
+	NoCache bool
+}
+
+var (
+	commitRefPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+	tagRefPattern    = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+)*([-+][0-9A-Za-z.-]+)?$`)
+	cacheKeyReplacer = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// errThemeArchiveNotFound is returned by the fetcher when the requested ref
+// has no archive, so the caller can move on to the next candidate.
+var errThemeArchiveNotFound = fmt.Errorf("theme archive not found")
+
+// fetchThemeArchive is a test hook that opens a theme ZIP archive URL.
+var fetchThemeArchive = httpFetchThemeArchive
+
+// httpFetchThemeArchive downloads url over HTTP. The timeout can be tuned
+// with WALGO_THEME_DOWNLOAD_TIMEOUT.
+func httpFetchThemeArchive(url string) (io.ReadCloser, error) {
+	downloadTimeout := 2 * time.Minute
+	if envTimeout := os.Getenv("WALGO_THEME_DOWNLOAD_TIMEOUT"); envTimeout != "" {
+		if d, err := time.ParseDuration(envTimeout); err == nil && d > 0 {
+			downloadTimeout = d
+		}
+	}
+	client := &http.Client{Timeout: downloadTimeout}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "walgo-theme-installer")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, errThemeArchiveNotFound
+		}
+		return nil, fmt.Errorf("unexpected HTTP status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// IsPinnedRef reports whether ref names an immutable revision — a commit
+// SHA or a version tag — whose archive can safely be reused from the cache.
+// Branch names move, so they are always downloaded again.
+func IsPinnedRef(ref string) bool {
+	return commitRefPattern.MatchString(ref) || tagRefPattern.MatchString(ref)
+}
+
+// ThemeCacheDir returns the directory holding cached theme archives:
+// $XDG_CACHE_HOME/walgo/themes, or ~/.cache/walgo/themes when unset.
+func ThemeCacheDir() (string, error) {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "walgo", "themes"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".cache", "walgo", "themes"), nil
+}
+
+// themeCacheKey returns the archive file name for repoURL at ref. The repo
+// name and ref keep it readable; the hash keeps forks and odd refs apart.
+func themeCacheKey(repoURL, ref string) string {
+	repoURL = strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	sum := sha256.Sum256([]byte(repoURL + "@" + ref))
+	name := cacheKeyReplacer.ReplaceAllString(filepath.Base(repoURL)+"_"+ref, "_")
+	return name + "-" + hex.EncodeToString(sum[:])[:16] + ".zip"
+}
+
+// themeArchiveURLs lists the archive URLs to try, in order, for ref.
+func themeArchiveURLs(repoURL, ref string) []string {
+	if ref == "" {
+		return []string{
+			repoURL + "/archive/refs/heads/main.zip",
+			repoURL + "/archive/refs/heads/master.zip",
+		}
+	}
+	return []string{repoURL + "/archive/" + ref + ".zip"}
+}
+
+// fetchThemeZip returns the path to a local ZIP archive of the theme and a
+// cleanup function for it. Pinned refs are served from and stored in the
+// theme cache unless opts.NoCache is set.
+func fetchThemeZip(repoURL string, opts ThemeDownloadOptions) (string, func(), error) {
+	noop := func() {}
+
+	var cachePath string
+	if !opts.NoCache && IsPinnedRef(opts.Ref) {
+		if dir, err := ThemeCacheDir(); err == nil {
+			cachePath = filepath.Join(dir, themeCacheKey(repoURL, opts.Ref))
+			if info, err := os.Stat(cachePath); err == nil && info.Size() > 0 {
+				fmt.Printf("Using cached theme archive for %s\n", opts.Ref)
+				return cachePath, noop, nil
+			}
+		}
+	}
+
+	urls := themeArchiveURLs(repoURL, opts.Ref)
+	var body io.ReadCloser
+	var lastErr error
+	for _, url := range urls {
+		fmt.Printf("Trying to download theme from %s...\n", url)
+		rc, err := fetchThemeArchive(url)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		body = rc
+		break
+	}
+	if body == nil {
+		if opts.Ref != "" {
+			return "", noop, fmt.Errorf("failed to download theme at ref %q: %w", opts.Ref, lastErr)
+		}
+		return "", noop, fmt.Errorf("failed to download theme from any branch (tried: [main master]): %w", lastErr)
+	}
+	defer body.Close()
+
+	tmpFile, err := os.CreateTemp("", "hugo-theme-*.zip")
+	if err != nil {
+		return "", noop, fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpName := tmpFile.Name()
+	cleanup := func() { os.Remove(tmpName) }
+
+	if _, err := io.Copy(tmpFile, body); err != nil {
+		tmpFile.Close()
+		cleanup()
+		return "", noop, fmt.Errorf("saving theme download: %w", err)
+	}
+	tmpFile.Close()
+
+	if cachePath != "" {
+		if err := storeThemeArchive(tmpName, cachePath); err != nil {
+			fmt.Printf("Warning: Could not cache theme archive: %v\n", err)
+		}
+	}
+
+	return tmpName, cleanup, nil
+}
+
+// storeThemeArchive copies the downloaded archive into the cache, writing to
+// a temporary file first so a partial copy is never mistaken for a hit.
+func storeThemeArchive(src, cachePath string) error {
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	tmpPath := cachePath + ".tmp"
+	if err := copyFileContents(src, tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, cachePath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("moving archive into cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package hugo
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// themeArchive builds a GitHub-style theme ZIP with a single root directory.
+func themeArchive(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := map[string]string{
+		"mytheme-1.0.0/theme.toml":              "name = \"mytheme\"\n",
+		"mytheme-1.0.0/layouts/_default/a.html": "<html></html>\n",
+	}
+	if _, err := zw.Create("mytheme-1.0.0/"); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// countingFetcher replaces fetchThemeArchive for the duration of the test and
+// records each URL requested. URLs containing a missing substring return
+// errThemeArchiveNotFound.
+func countingFetcher(t *testing.T, archive []byte, missing ...string) *[]string {
+	t.Helper()
+	var calls []string
+	orig := fetchThemeArchive
+	fetchThemeArchive = func(url string) (io.ReadCloser, error) {
+		calls = append(calls, url)
+		for _, m := range missing {
+			if strings.Contains(url, m) {
+				return nil, errThemeArchiveNotFound
+			}
+		}
+		return io.NopCloser(bytes.NewReader(archive)), nil
+	}
+	t.Cleanup(func() { fetchThemeArchive = orig })
+	return &calls
+}
+
+func TestIsPinnedRef(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{"", false},
+		{"main", false},
+		{"master", false},
+		{"1.x", false},
+		{"feature/new-layout", false},
+		{"v1.2.3", true},
+		{"2.9.0", true},
+		{"v13", true},
+		{"v1.0.0-rc.1", true},
+		{"a1b2c3d", true},
+		{"0123456789abcdef0123456789abcdef01234567", true},
+	}
+	for _, tt := range tests {
+		if got := IsPinnedRef(tt.ref); got != tt.want {
+			t.Errorf("IsPinnedRef(%q) = %v, want %v", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestThemeCacheDir(t *testing.T) {
+	t.Run("uses XDG_CACHE_HOME", func(t *testing.T) {
+		xdg := t.TempDir()
+		t.Setenv("XDG_CACHE_HOME", xdg)
+		dir, err := ThemeCacheDir()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(xdg, "walgo", "themes"); dir != want {
+			t.Errorf("ThemeCacheDir() = %q, want %q", dir, want)
+		}
+	})
+
+	t.Run("falls back to home cache", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("XDG_CACHE_HOME", "")
+		t.Setenv("HOME", home)
+		dir, err := ThemeCacheDir()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(home, ".cache", "walgo", "themes"); dir != want {
+			t.Errorf("ThemeCacheDir() = %q, want %q", dir, want)
+		}
+	})
+}
+
+func TestThemeCacheKey(t *testing.T) {
+	a := themeCacheKey("https://github.com/owner/theme", "v1.0.0")
+	if a != themeCacheKey("https://github.com/owner/theme.git", "v1.0.0") {
+		t.Error("key should ignore a .git suffix")
+	}
+	if a == themeCacheKey("https://github.com/fork/theme", "v1.0.0") {
+		t.Error("forks should not share a cache key")
+	}
+	if a == themeCacheKey("https://github.com/owner/theme", "v1.0.1") {
+		t.Error("refs should not share a cache key")
+	}
+	if !strings.HasPrefix(a, "theme_v1.0.0-") || !strings.HasSuffix(a, ".zip") {
+		t.Errorf("unexpected key format: %q", a)
+	}
+}
+
+func TestDownloadThemeZip_PinnedRefUsesCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	calls := countingFetcher(t, themeArchive(t))
+	opts := ThemeDownloadOptions{Ref: "v1.0.0"}
+
+	for i := 0; i < 2; i++ {
+		themePath := filepath.Join(t.TempDir(), "themes", "mytheme")
+		if err := downloadThemeZip("mytheme", "https://github.com/owner/mytheme.git", themePath, opts); err != nil {
+			t.Fatalf("install %d: %v", i+1, err)
+		}
+		if _, err := os.Stat(filepath.Join(themePath, "theme.toml")); err != nil {
+			t.Fatalf("install %d: theme.toml not extracted: %v", i+1, err)
+		}
+	}
+
+	if len(*calls) != 1 {
+		t.Fatalf("network calls = %d, want 1 (second install should hit the cache): %v", len(*calls), *calls)
+	}
+	if want := "https://github.com/owner/mytheme/archive/v1.0.0.zip"; (*calls)[0] != want {
+		t.Errorf("fetched %q, want %q", (*calls)[0], want)
+	}
+}
+
+func TestDownloadThemeZip_NoCacheBypassesCache(t *testing.T) {
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+	calls := countingFetcher(t, themeArchive(t))
+	opts := ThemeDownloadOptions{Ref: "v1.0.0", NoCache: true}
+
+	for i := 0; i < 2; i++ {
+		themePath := filepath.Join(t.TempDir(), "mytheme")
+		if err := downloadThemeZip("mytheme", "https://github.com/owner/mytheme", themePath, opts); err != nil {
+			t.Fatalf("install %d: %v", i+1, err)
+		}
+	}
+
+	if len(*calls) != 2 {
+		t.Errorf("network calls = %d, want 2", len(*calls))
+	}
+	if _, err := os.Stat(filepath.Join(cacheHome, "walgo", "themes")); !os.IsNotExist(err) {
+		t.Errorf("--no-cache should not populate the cache, stat err = %v", err)
+	}
+}
+
+func TestDownloadThemeZip_BranchIsNotCached(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	calls := countingFetcher(t, themeArchive(t), "/main.zip")
+
+	for i := 0; i < 2; i++ {
+		themePath := filepath.Join(t.TempDir(), "mytheme")
+		if err := downloadThemeZip("mytheme", "https://github.com/owner/mytheme", themePath, ThemeDownloadOptions{}); err != nil {
+			t.Fatalf("install %d: %v", i+1, err)
+		}
+	}
+
+	// Each install tries main (missing) then master.
+	if len(*calls) != 4 {
+		t.Fatalf("network calls = %d, want 4: %v", len(*calls), *calls)
+	}
+	if !strings.HasSuffix((*calls)[1], "/archive/refs/heads/master.zip") {
+		t.Errorf("expected fallback to master, got %q", (*calls)[1])
+	}
+}
+
+func TestDownloadThemeZip_MissingRef(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	countingFetcher(t, themeArchive(t), "v9.9.9")
+
+	err := downloadThemeZip("mytheme", "https://github.com/owner/mytheme", filepath.Join(t.TempDir(), "mytheme"), ThemeDownloadOptions{Ref: "v9.9.9"})
+	if err == nil {
+		t.Fatal("expected error for missing ref")
+	}
+	if !strings.Contains(err.Error(), `ref "v9.9.9"`) {
+		t.Errorf("error should name the ref, got: %v", err)
+	}
+}
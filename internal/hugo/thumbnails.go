@@ -0,0 +1,486 @@
+package hugo
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/deps"
+	"github.com/selimozten/walgo/internal/executil"
+)
+
+// DefaultThumbnailWidths are the variant widths GenerateThumbnails creates
+// when none are given.
+var DefaultThumbnailWidths = []int{400, 800, 1200}
+
+// DefaultThumbnailQuality is the encoder quality used for webp and JPEG
+// variants when none is given.
+const DefaultThumbnailQuality = 80
+
+// Thumbnail output formats.
+const (
+	ThumbnailFormatWebP     = "webp"     // Encoded with cwebp
+	ThumbnailFormatOriginal = "original" // Same format as the source image
+)
+
+// thumbnailImageFields are the frontmatter fields themes read a page's
+// image from.
+var thumbnailImageFields = []string{"image", "cover", "featured_image", "thumbnail"}
+
+// thumbnailSourceExts are the image types variants are generated from.
+var thumbnailSourceExts = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true}
+
+var (
+	markdownImagePattern = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^\s)>]+)`)
+	imgTagPattern        = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	imgSrcPattern        = regexp.MustCompile(`(?i)(\s)src\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	imgSrcsetPattern     = regexp.MustCompile(`(?i)(\s)srcset\s*=\s*(?:"[^"]*"|'[^']*')`)
+	variantNamePattern   = regexp.MustCompile(`-\d+w\.[A-Za-z]+$`)
+)
+
+// ThumbnailOptions configures GenerateThumbnails.
+type ThumbnailOptions struct {
+	Widths  []int  // Default: DefaultThumbnailWidths
+	Format  string // ThumbnailFormatWebP (default) or ThumbnailFormatOriginal
+	Quality int    // 1-100, default: DefaultThumbnailQuality
+	DryRun  bool   // Report what would change without writing anything
+}
+
+// ThumbnailReport lists what GenerateThumbnails did. Paths are
+// slash-separated and relative to the site.
+type ThumbnailReport struct {
+	Images  int      // Distinct local images referenced from content
+	Created []string // Variants written (or, with DryRun, to be written)
+	Skipped []string // Images narrower than the smallest width
+	Missing []string // "page: reference" for images that do not exist
+	Updated []string // Content files whose srcset or frontmatter changed
+}
+
+// thumbnailVariant is one generated width of an image.
+type thumbnailVariant struct {
+	Width int
+	Name  string // File name, in the source image's directory
+}
+
+// webpEncode is a test hook that writes a width pixels wide webp of src to
+// dst.
+var webpEncode = func(src, dst string, width, quality int) error {
+	cwebp, err := deps.LookPath("cwebp")
+	if err != nil {
+		return fmt.Errorf("cwebp not found: install libwebp (https://developers.google.com/speed/webp/download) or use --format original")
+	}
+	output, err := executil.Command(cwebp, "-quiet", "-q", strconv.Itoa(quality),
+		"-resize", strconv.Itoa(width), "0", src, "-o", dst).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("cwebp failed for %s: %w: %s", src, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// GenerateThumbnails creates responsive variants of the local images
+// referenced from content/: markdown images, <img> tags and the image,
+// cover, featured_image and thumbnail frontmatter fields. Variants are
+// written next to the source image as <name>-<width>w.<ext>, only for
+// widths no larger than the image; images narrower than the smallest width
+// are skipped. <img> tags get a srcset listing the variants, and frontmatter
+// image fields get a matching <field>_srcset field. Markdown images keep
+// their syntax; a render-image hook can build their srcset from the variant
+// names. Variants newer than their source are not regenerated.
+func GenerateThumbnails(sitePath string, opts ThumbnailOptions) (*ThumbnailReport, error) {
+	widths, err := normalizeThumbnailWidths(opts.Widths)
+	if err != nil {
+		return nil, err
+	}
+	opts.Widths = widths
+	switch opts.Format {
+	case "":
+		opts.Format = ThumbnailFormatWebP
+	case ThumbnailFormatWebP, ThumbnailFormatOriginal:
+	default:
+		return nil, fmt.Errorf("unknown format %q: use %s or %s", opts.Format, ThumbnailFormatWebP, ThumbnailFormatOriginal)
+	}
+	if opts.Quality == 0 {
+		opts.Quality = DefaultThumbnailQuality
+	}
+	if opts.Quality < 1 || opts.Quality > 100 {
+		return nil, fmt.Errorf("quality must be between 1 and 100")
+	}
+
+	pages, err := contentPages(filepath.Join(sitePath, "content"))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ThumbnailReport{}
+	variants := make(map[string][]thumbnailVariant)
+	seen := make(map[string]bool)
+	for _, page := range pages {
+		data, err := os.ReadFile(page) // #nosec G304 - path comes from walking content/
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", page, err)
+		}
+		content := string(data)
+		pageRel := siteRelPath(sitePath, page)
+
+		srcsets := make(map[string]string)
+		refs, fields := thumbnailRefs(content)
+		for _, ref := range refs {
+			src, ok := resolveThumbnailRef(sitePath, page, ref)
+			if !ok {
+				continue
+			}
+			if _, err := os.Stat(src); err != nil {
+				report.Missing = append(report.Missing, pageRel+": "+ref)
+				continue
+			}
+			if !seen[src] {
+				seen[src] = true
+				report.Images++
+				vs, err := generateVariants(sitePath, src, opts, report)
+				if err != nil {
+					return nil, err
+				}
+				variants[src] = vs
+			}
+			if vs := variants[src]; len(vs) > 0 {
+				srcsets[ref] = thumbnailSrcset(ref, vs)
+			}
+		}
+
+		updated := RewriteSrcset(content, srcsets)
+		for _, field := range thumbnailImageFields {
+			srcset := srcsets[fields[field]]
+			if srcset == "" {
+				continue
+			}
+			if updated, err = ai.SetFrontmatterString(updated, field+"_srcset", srcset); err != nil {
+				return nil, fmt.Errorf("%s: %w", pageRel, err)
+			}
+		}
+		if updated == content {
+			continue
+		}
+		report.Updated = append(report.Updated, pageRel)
+		if !opts.DryRun {
+			if err := os.WriteFile(page, []byte(updated), 0644); err != nil { // #nosec G306 - content files are not secret
+				return nil, fmt.Errorf("failed to write %s: %w", page, err)
+			}
+		}
+	}
+	return report, nil
+}
+
+// normalizeThumbnailWidths sorts and deduplicates widths, defaulting to
+// DefaultThumbnailWidths.
+func normalizeThumbnailWidths(widths []int) ([]int, error) {
+	if len(widths) == 0 {
+		widths = DefaultThumbnailWidths
+	}
+	set := make(map[int]bool, len(widths))
+	var result []int
+	for _, w := range widths {
+		if w <= 0 {
+			return nil, fmt.Errorf("invalid width %d: must be positive", w)
+		}
+		if !set[w] {
+			set[w] = true
+			result = append(result, w)
+		}
+	}
+	sort.Ints(result)
+	return result, nil
+}
+
+// contentPages returns the markdown files under contentDir.
+func contentPages(contentDir string) ([]string, error) {
+	var pages []string
+	err := filepath.Walk(contentDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.EqualFold(filepath.Ext(p), ".md") {
+			pages = append(pages, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan content: %w", err)
+	}
+	return pages, nil
+}
+
+// thumbnailRefs returns the distinct image references in content, in order,
+// and the value of each frontmatter image field that is set. Code fences are
+// skipped.
+func thumbnailRefs(content string) ([]string, map[string]string) {
+	var refs []string
+	seen := make(map[string]bool)
+	add := func(ref string) {
+		if ref != "" && !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	fields := make(map[string]string)
+	frontmatter := ai.ParseFrontmatterFields(content)
+	for _, field := range thumbnailImageFields {
+		if value, ok := frontmatter[field].(string); ok && value != "" {
+			fields[field] = value
+			add(value)
+		}
+	}
+
+	_, _, body := ai.SplitFrontmatter(content)
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		if isFenceLine(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, m := range markdownImagePattern.FindAllStringSubmatch(line, -1) {
+			add(m[1])
+		}
+		for _, tag := range imgTagPattern.FindAllString(line, -1) {
+			add(imgSrc(tag))
+		}
+	}
+	return refs, fields
+}
+
+// isFenceLine reports whether line opens or closes a fenced code block.
+func isFenceLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// imgSrc returns the src attribute of an <img> tag.
+func imgSrc(tag string) string {
+	m := imgSrcPattern.FindStringSubmatch(tag)
+	if m == nil {
+		return ""
+	}
+	return m[2] + m[3]
+}
+
+// resolveThumbnailRef maps an image reference on page to a file: root
+// relative references to static/, others to the page's directory. It
+// reports false for remote images, unsupported types and existing variants.
+func resolveThumbnailRef(sitePath, page, ref string) (string, bool) {
+	if strings.Contains(ref, "://") || strings.HasPrefix(ref, "//") || strings.HasPrefix(ref, "data:") {
+		return "", false
+	}
+	refPath := stripURLSuffix(ref)
+	if unescaped, err := url.PathUnescape(refPath); err == nil {
+		refPath = unescaped
+	}
+	if refPath == "" || strings.Contains(refPath, "..") {
+		return "", false
+	}
+	if !thumbnailSourceExts[strings.ToLower(filepath.Ext(refPath))] || variantNamePattern.MatchString(refPath) {
+		return "", false
+	}
+	if strings.HasPrefix(refPath, "/") {
+		return filepath.Join(sitePath, "static", filepath.FromSlash(refPath)), true
+	}
+	return filepath.Join(filepath.Dir(page), filepath.FromSlash(refPath)), true
+}
+
+// stripURLSuffix drops the query string and fragment of ref.
+func stripURLSuffix(ref string) string {
+	if i := strings.IndexAny(ref, "?#"); i >= 0 {
+		return ref[:i]
+	}
+	return ref
+}
+
+// generateVariants writes the variants of src for every width in opts no
+// larger than the image, recording them in report. Images narrower than the
+// smallest width get no variants.
+func generateVariants(sitePath, src string, opts ThumbnailOptions, report *ThumbnailReport) ([]thumbnailVariant, error) {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(src) // #nosec G304 - path comes from content references
+	if err != nil {
+		return nil, err
+	}
+	cfg, _, err := image.DecodeConfig(f)
+	f.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", siteRelPath(sitePath, src), err)
+	}
+	if cfg.Width < opts.Widths[0] {
+		report.Skipped = append(report.Skipped, siteRelPath(sitePath, src))
+		return nil, nil
+	}
+
+	ext := filepath.Ext(src)
+	if opts.Format == ThumbnailFormatWebP {
+		ext = ".webp"
+	}
+	base := strings.TrimSuffix(filepath.Base(src), filepath.Ext(src))
+
+	var decoded image.Image
+	var variants []thumbnailVariant
+	for _, width := range opts.Widths {
+		if width > cfg.Width {
+			break
+		}
+		v := thumbnailVariant{Width: width, Name: fmt.Sprintf("%s-%dw%s", base, width, ext)}
+		variants = append(variants, v)
+
+		dst := filepath.Join(filepath.Dir(src), v.Name)
+		if info, err := os.Stat(dst); err == nil && !info.ModTime().Before(srcInfo.ModTime()) {
+			continue
+		}
+		report.Created = append(report.Created, siteRelPath(sitePath, dst))
+		if opts.DryRun {
+			continue
+		}
+
+		if opts.Format == ThumbnailFormatWebP {
+			if err := webpEncode(src, dst, width, opts.Quality); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if decoded == nil {
+			if decoded, err = decodeImage(src); err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", siteRelPath(sitePath, src), err)
+			}
+		}
+		if err := encodeImage(dst, resizeImage(decoded, width), opts.Quality); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", siteRelPath(sitePath, dst), err)
+		}
+	}
+	return variants, nil
+}
+
+func decodeImage(path string) (image.Image, error) {
+	f, err := os.Open(path) // #nosec G304 - path comes from content references
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	return img, err
+}
+
+// encodeImage writes img to path in the format its extension names.
+func encodeImage(path string, img image.Image, quality int) error {
+	f, err := os.Create(path) // #nosec G304 - path is a variant next to its source
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		err = jpeg.Encode(f, img, &jpeg.Options{Quality: quality})
+	case ".gif":
+		err = gif.Encode(f, img, nil)
+	default:
+		err = png.Encode(f, img)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// resizeImage scales img down to width pixels wide, keeping its aspect
+// ratio, by averaging the source pixels under each destination pixel.
+func resizeImage(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	height := int(math.Round(float64(b.Dy()) * float64(width) / float64(b.Dx())))
+	if height < 1 {
+		height = 1
+	}
+	dst := image.NewRGBA64(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := b.Min.Y + y*b.Dy()/height
+		y1 := max(b.Min.Y+(y+1)*b.Dy()/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := b.Min.X + x*b.Dx()/width
+			x1 := max(b.Min.X+(x+1)*b.Dx()/width, x0+1)
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+// thumbnailSrcset builds a srcset value for ref from its variants, with
+// each variant in the same directory as ref.
+func thumbnailSrcset(ref string, variants []thumbnailVariant) string {
+	refPath := stripURLSuffix(ref)
+	dir := refPath[:strings.LastIndex(refPath, "/")+1]
+	candidates := make([]string, len(variants))
+	for i, v := range variants {
+		candidates[i] = fmt.Sprintf("%s%s %dw", dir, v.Name, v.Width)
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// RewriteSrcset sets the srcset attribute of each <img> tag in content
+// whose src is a key of srcsets, replacing any srcset already there. Tags
+// inside code fences are left alone.
+func RewriteSrcset(content string, srcsets map[string]string) string {
+	if len(srcsets) == 0 {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	inFence := false
+	for i, line := range lines {
+		if isFenceLine(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		lines[i] = imgTagPattern.ReplaceAllStringFunc(line, func(tag string) string {
+			srcset, ok := srcsets[imgSrc(tag)]
+			if !ok {
+				return tag
+			}
+			attr := ` srcset="` + srcset + `"`
+			if imgSrcsetPattern.MatchString(tag) {
+				return imgSrcsetPattern.ReplaceAllLiteralString(tag, attr)
+			}
+			loc := imgSrcPattern.FindStringIndex(tag)
+			return tag[:loc[1]] + attr + tag[loc[1]:]
+		})
+	}
+	return strings.Join(lines, "\n")
+}
+
+// siteRelPath returns p relative to sitePath with forward slashes.
+func siteRelPath(sitePath, p string) string {
+	if rel, err := filepath.Rel(sitePath, p); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return p
+}
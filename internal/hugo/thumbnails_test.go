@@ -0,0 +1,209 @@
+package hugo
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestPNG writes a width x height PNG to path.
+func writeTestPNG(t *testing.T, path string, width, height int) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateThumbnails(t *testing.T) {
+	site := t.TempDir()
+	bundle := filepath.Join(site, "content", "posts", "trip")
+	writeTestPNG(t, filepath.Join(bundle, "photo.png"), 1000, 500)
+	writeTestPNG(t, filepath.Join(bundle, "icon.png"), 300, 300)
+	writeTestPNG(t, filepath.Join(site, "static", "images", "cover.png"), 1300, 650)
+	page := filepath.Join(bundle, "index.md")
+	writeTestFile(t, page, `---
+title: Trip
+cover: /images/cover.png
+---
+<img src="photo.png" alt="Photo">
+
+![Icon](icon.png)
+
+`+"```html\n<img src=\"photo.png\">\n```\n")
+
+	report, err := GenerateThumbnails(site, ThumbnailOptions{Format: ThumbnailFormatOriginal})
+	if err != nil {
+		t.Fatalf("GenerateThumbnails() error = %v", err)
+	}
+	if report.Images != 3 {
+		t.Errorf("Images = %d, want 3", report.Images)
+	}
+	// photo.png gets 400 and 800, cover.png all three, icon.png none
+	if len(report.Created) != 5 {
+		t.Errorf("Created = %v, want 5 variants", report.Created)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0] != "content/posts/trip/icon.png" {
+		t.Errorf("Skipped = %v, want the icon", report.Skipped)
+	}
+
+	f, err := os.Open(filepath.Join(bundle, "photo-400w.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := png.DecodeConfig(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Width != 400 || cfg.Height != 200 {
+		t.Errorf("photo-400w.png is %dx%d, want 400x200", cfg.Width, cfg.Height)
+	}
+	if _, err := os.Stat(filepath.Join(bundle, "photo-1200w.png")); !os.IsNotExist(err) {
+		t.Error("images must not be upscaled")
+	}
+
+	data, err := os.ReadFile(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		`<img src="photo.png" srcset="photo-400w.png 400w, photo-800w.png 800w" alt="Photo">`,
+		`cover_srcset: "/images/cover-400w.png 400w, /images/cover-800w.png 800w, /images/cover-1200w.png 1200w"`,
+		"```html\n<img src=\"photo.png\">\n```",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("page missing %q:\n%s", want, got)
+		}
+	}
+
+	again, err := GenerateThumbnails(site, ThumbnailOptions{Format: ThumbnailFormatOriginal})
+	if err != nil {
+		t.Fatalf("second GenerateThumbnails() error = %v", err)
+	}
+	if len(again.Created) != 0 || len(again.Updated) != 0 {
+		t.Errorf("second run should change nothing, created %v updated %v", again.Created, again.Updated)
+	}
+}
+
+func TestGenerateThumbnailsWebP(t *testing.T) {
+	site := t.TempDir()
+	writeTestPNG(t, filepath.Join(site, "static", "hero.png"), 900, 300)
+	writeTestFile(t, filepath.Join(site, "content", "_index.md"), "# Home\n\n![Hero](/hero.png)\n")
+
+	orig := webpEncode
+	t.Cleanup(func() { webpEncode = orig })
+	var widths []int
+	webpEncode = func(src, dst string, width, quality int) error {
+		widths = append(widths, width)
+		if quality != 70 {
+			t.Errorf("quality = %d, want 70", quality)
+		}
+		return os.WriteFile(dst, nil, 0644)
+	}
+
+	report, err := GenerateThumbnails(site, ThumbnailOptions{Widths: []int{800, 400, 400}, Quality: 70})
+	if err != nil {
+		t.Fatalf("GenerateThumbnails() error = %v", err)
+	}
+	if len(widths) != 2 || widths[0] != 400 || widths[1] != 800 {
+		t.Errorf("encoded widths = %v, want [400 800]", widths)
+	}
+	if len(report.Created) != 2 || report.Created[0] != "static/hero-400w.webp" {
+		t.Errorf("Created = %v", report.Created)
+	}
+	if len(report.Updated) != 0 {
+		t.Errorf("markdown images should not be rewritten, updated %v", report.Updated)
+	}
+}
+
+func TestGenerateThumbnailsDryRunAndMissing(t *testing.T) {
+	site := t.TempDir()
+	writeTestPNG(t, filepath.Join(site, "static", "a.png"), 500, 500)
+	page := filepath.Join(site, "content", "page.md")
+	content := "<img src=\"/a.png\">\n<img src=\"/gone.png\">\n<img src=\"https://example.com/x.png\">\n"
+	writeTestFile(t, page, content)
+
+	report, err := GenerateThumbnails(site, ThumbnailOptions{Format: ThumbnailFormatOriginal, DryRun: true})
+	if err != nil {
+		t.Fatalf("GenerateThumbnails() error = %v", err)
+	}
+	if len(report.Created) != 1 || len(report.Updated) != 1 {
+		t.Errorf("Created = %v, Updated = %v", report.Created, report.Updated)
+	}
+	if len(report.Missing) != 1 || report.Missing[0] != "content/page.md: /gone.png" {
+		t.Errorf("Missing = %v", report.Missing)
+	}
+	if _, err := os.Stat(filepath.Join(site, "static", "a-400w.png")); !os.IsNotExist(err) {
+		t.Error("dry run must not write variants")
+	}
+	if data, _ := os.ReadFile(page); string(data) != content {
+		t.Error("dry run must not rewrite content")
+	}
+}
+
+func TestGenerateThumbnailsInvalidOptions(t *testing.T) {
+	site := t.TempDir()
+	for _, opts := range []ThumbnailOptions{
+		{Format: "avif"},
+		{Widths: []int{0}},
+		{Quality: 101},
+	} {
+		if _, err := GenerateThumbnails(site, opts); err == nil {
+			t.Errorf("GenerateThumbnails(%+v) should fail", opts)
+		}
+	}
+}
+
+func TestRewriteSrcset(t *testing.T) {
+	srcsets := map[string]string{"a.png": "a-400w.webp 400w, a-800w.webp 800w"}
+	tests := []struct {
+		name, in, want string
+	}{
+		{
+			name: "adds srcset after src",
+			in:   `<p><img class="wide" src="a.png" alt="A"></p>`,
+			want: `<p><img class="wide" src="a.png" srcset="a-400w.webp 400w, a-800w.webp 800w" alt="A"></p>`,
+		},
+		{
+			name: "replaces existing srcset",
+			in:   `<img srcset='old.png 1x' src="a.png">`,
+			want: `<img srcset="a-400w.webp 400w, a-800w.webp 800w" src="a.png">`,
+		},
+		{
+			name: "leaves other images",
+			in:   `<img src="b.png"> <img data-src="a.png">`,
+			want: `<img src="b.png"> <img data-src="a.png">`,
+		},
+		{
+			name: "skips code fences",
+			in:   "~~~\n<img src=\"a.png\">\n~~~",
+			want: "~~~\n<img src=\"a.png\">\n~~~",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RewriteSrcset(tt.in, srcsets); got != tt.want {
+				t.Errorf("RewriteSrcset() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+package hugo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deps"
+	"github.com/selimozten/walgo/internal/executil"
+	"github.com/selimozten/walgo/internal/selfupdate"
+	"gopkg.in/yaml.v3"
+)
+
+// HugoReleaseBaseURL is where Hugo release assets are downloaded from.
+const HugoReleaseBaseURL = "https://github.com/gohugoio/hugo/releases/download"
+
+// minAutoInstallVersion is the first Hugo release whose assets use the
+// <os>-<arch> names HugoArchiveName builds.
+const minAutoInstallVersion = "0.103.0"
+
+// maxHugoDownload caps a Hugo release download.
+const maxHugoDownload = 200 << 20
+
+var (
+	pinnedVersionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?$`)
+	hugoVersionPattern   = regexp.MustCompile(`v(\d+\.\d+(?:\.\d+)?)`)
+)
+
+// hugoReleaseURL is a test hook for the release download location.
+var hugoReleaseURL = HugoReleaseBaseURL
+
+// installedHugo is a test hook returning the hugo on PATH and its version.
+var installedHugo = func() (string, string, error) {
+	path, err := deps.LookPath("hugo")
+	if err != nil {
+		return "", "", err
+	}
+	version, err := hugoVersionOf(path)
+	return path, version, err
+}
+
+// hugoVersionOf runs "<path> version" and returns the version it reports.
+var hugoVersionOf = func(path string) (string, error) {
+	output, err := executil.Command(path, "version").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get hugo version: %w", err)
+	}
+	return ParseHugoVersion(string(output))
+}
+
+// HugoSource says where a build with a pinned Hugo version gets its binary.
+type HugoSource string
+
+const (
+	HugoFromPath     HugoSource = "path"     // The hugo on PATH is the pinned version
+	HugoFromCache    HugoSource = "cache"    // The pinned version is in the walgo cache
+	HugoDownload     HugoSource = "download" // The pinned version must be downloaded
+	HugoVersionWrong HugoSource = "mismatch" // Needs a download, but auto-install is off
+)
+
+// ParseHugoVersion extracts the version from "hugo version" output, e.g.
+// "0.125.0" from "hugo v0.125.0-2c6b5d5+extended linux/amd64 ...".
+func ParseHugoVersion(output string) (string, error) {
+	m := hugoVersionPattern.FindStringSubmatch(output)
+	if m == nil {
+		return "", fmt.Errorf("cannot find a version in %q", strings.TrimSpace(output))
+	}
+	return m[1], nil
+}
+
+// NormalizeHugoVersion validates a pinned version and returns it as
+// major.minor.patch without a "v" ("v0.125" becomes "0.125.0").
+func NormalizeHugoVersion(pinned string) (string, error) {
+	m := pinnedVersionPattern.FindStringSubmatch(strings.TrimSpace(pinned))
+	if m == nil {
+		return "", fmt.Errorf("invalid Hugo version %q: want a release like 0.125.0", pinned)
+	}
+	patch := m[3]
+	if patch == "" {
+		patch = "0"
+	}
+	return m[1] + "." + m[2] + "." + patch, nil
+}
+
+// HugoVersionSatisfies reports whether installed is the pinned release. A
+// pin without a patch number ("0.125") accepts any patch release of it.
+func HugoVersionSatisfies(installed, pinned string) bool {
+	want := pinnedVersionPattern.FindStringSubmatch(strings.TrimSpace(pinned))
+	got := pinnedVersionPattern.FindStringSubmatch(strings.TrimSpace(installed))
+	if want == nil || got == nil {
+		return false
+	}
+	if !sameNumber(want[1], got[1]) || !sameNumber(want[2], got[2]) {
+		return false
+	}
+	return want[3] == "" || sameNumber(want[3], orZero(got[3]))
+}
+
+func sameNumber(a, b string) bool {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	return errA == nil && errB == nil && x == y
+}
+
+func orZero(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
+
+// DecideHugoSource picks the Hugo binary for a build pinned to pinned, given
+// the version on PATH ("" when none), whether the pinned version is already
+// cached and whether downloading is allowed.
+func DecideHugoSource(pinned, installed string, cached, autoInstall bool) HugoSource {
+	switch {
+	case installed != "" && HugoVersionSatisfies(installed, pinned):
+		return HugoFromPath
+	case cached:
+		return HugoFromCache
+	case autoInstall:
+		return HugoDownload
+	default:
+		return HugoVersionWrong
+	}
+}
+
+// HugoCacheDir returns the directory holding downloaded Hugo releases:
+// $XDG_CACHE_HOME/walgo/hugo, or ~/.cache/walgo/hugo when unset.
+func HugoCacheDir() (string, error) {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "walgo", "hugo"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".cache", "walgo", "hugo"), nil
+}
+
+// CachedHugoPath returns where the Hugo binary for version lives in the
+// cache: <cache>/v<version>/hugo, with .exe on Windows.
+func CachedHugoPath(version, goos string) (string, error) {
+	version, err := NormalizeHugoVersion(version)
+	if err != nil {
+		return "", err
+	}
+	dir, err := HugoCacheDir()
+	if err != nil {
+		return "", err
+	}
+	name := "hugo"
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(dir, "v"+version, name), nil
+}
+
+// HugoArchiveName returns the release asset holding Hugo version for a
+// platform. The extended edition is used where Hugo publishes one.
+func HugoArchiveName(version, goos, goarch string) (string, error) {
+	version, err := NormalizeHugoVersion(version)
+	if err != nil {
+		return "", err
+	}
+	if compareVersionNumbers(version, minAutoInstallVersion) < 0 {
+		return "", fmt.Errorf("auto-install supports Hugo %s and later, not %s", minAutoInstallVersion, version)
+	}
+
+	edition := "hugo"
+	switch goos + "/" + goarch {
+	case "linux/amd64", "linux/arm64", "darwin/amd64", "darwin/arm64", "windows/amd64":
+		edition = "hugo_extended"
+	}
+	platform := goos + "-" + goarch
+	ext := ".tar.gz"
+	switch goos {
+	case "darwin":
+		platform = "darwin-universal"
+	case "windows":
+		ext = ".zip"
+	}
+	return fmt.Sprintf("%s_%s_%s%s", edition, version, platform, ext), nil
+}
+
+// compareVersionNumbers compares two major.minor.patch versions.
+func compareVersionNumbers(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < 3; i++ {
+		x, _ := strconv.Atoi(pa[i])
+		y, _ := strconv.Atoi(pb[i])
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// ResolveHugoBinary returns the Hugo binary a build pinned to pinned runs:
+// the hugo on PATH when it is that version, else the cached copy, else, with
+// autoInstall, a freshly downloaded and checksum-verified one. Progress is
+// written to out.
+func ResolveHugoBinary(ctx context.Context, pinned string, autoInstall bool, out io.Writer) (string, error) {
+	version, err := NormalizeHugoVersion(pinned)
+	if err != nil {
+		return "", err
+	}
+
+	installedPath, installed, err := installedHugo()
+	if err != nil {
+		installedPath, installed = "", ""
+	}
+	cachePath, err := CachedHugoPath(version, runtime.GOOS)
+	if err != nil {
+		return "", err
+	}
+	_, statErr := os.Stat(cachePath)
+
+	switch DecideHugoSource(pinned, installed, statErr == nil, autoInstall) {
+	case HugoFromPath:
+		return installedPath, nil
+	case HugoFromCache:
+		fmt.Fprintf(out, "Using cached Hugo %s: %s\n", version, cachePath)
+		return cachePath, nil
+	case HugoDownload:
+		fmt.Fprintf(out, "Downloading Hugo %s...\n", version)
+		if err := downloadHugo(ctx, version, cachePath); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(out, "Installed Hugo %s to %s\n", version, cachePath)
+		return cachePath, nil
+	default:
+		found := "no hugo on PATH"
+		if installed != "" {
+			found = "found " + installed
+		}
+		return "", fmt.Errorf("this site pins Hugo %s (%s); run 'walgo build --auto-install' to download it into the walgo cache", pinned, found)
+	}
+}
+
+// SiteHugoBinary returns the Hugo binary that builds and serves the site at
+// sitePath: the release pinned by hugo.version in its walgo.yaml, resolved
+// with ResolveHugoBinary but never downloaded, or the hugo on PATH when the
+// site pins none.
+func SiteHugoBinary(sitePath string, out io.Writer) (string, error) {
+	var pinned string
+	// #nosec G304 - walgo.yaml of the site being built
+	if data, err := os.ReadFile(filepath.Join(sitePath, "walgo.yaml")); err == nil {
+		var cfg config.WalgoConfig
+		if yaml.Unmarshal(data, &cfg) == nil {
+			pinned = cfg.HugoConfig.Version
+		}
+	}
+	return pinnedHugoBinary(pinned, out)
+}
+
+// pinnedHugoBinary resolves pinned from the cache or PATH, or returns the
+// hugo on PATH when pinned is empty.
+func pinnedHugoBinary(pinned string, out io.Writer) (string, error) {
+	if pinned = strings.TrimSpace(pinned); pinned != "" {
+		return ResolveHugoBinary(context.Background(), pinned, false, out)
+	}
+	hugoPath, err := deps.LookPath("hugo")
+	if err != nil {
+		return "", fmt.Errorf("hugo is not installed or not found in PATH")
+	}
+	return hugoPath, nil
+}
+
+// downloadHugo fetches the release archive for version, checks it against
+// the published checksums and installs its hugo binary at dest.
+func downloadHugo(ctx context.Context, version, dest string) error {
+	archiveName, err := HugoArchiveName(version, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 5 * time.Minute}
+	base := fmt.Sprintf("%s/v%s/", strings.TrimSuffix(hugoReleaseURL, "/"), version)
+
+	sumsData, err := fetchHugoAsset(ctx, client, base+fmt.Sprintf("hugo_%s_checksums.txt", version))
+	if err != nil {
+		return fmt.Errorf("failed to download Hugo checksums: %w", err)
+	}
+	sums, err := selfupdate.ParseChecksums(sumsData)
+	if err != nil {
+		return err
+	}
+	archive, err := fetchHugoAsset(ctx, client, base+archiveName)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", archiveName, err)
+	}
+	if err := selfupdate.VerifyChecksum(archive, archiveName, sums); err != nil {
+		return err
+	}
+	binary, err := selfupdate.ExtractExecutable(archive, archiveName, "hugo")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create Hugo cache: %w", err)
+	}
+	tmp := dest + ".tmp"
+	// #nosec G306 - the cached hugo binary must be executable
+	if err := os.WriteFile(tmp, binary, 0755); err != nil {
+		return fmt.Errorf("failed to write hugo binary: %w", err)
+	}
+	if got, err := hugoVersionOf(tmp); err != nil || !HugoVersionSatisfies(got, version) {
+		os.Remove(tmp)
+		if err == nil {
+			err = fmt.Errorf("reports version %s", got)
+		}
+		return fmt.Errorf("downloaded hugo does not run as Hugo %s: %w", version, err)
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to install hugo binary: %w", err)
+	}
+	return nil
+}
+
+// fetchHugoAsset GETs url, failing on non-200 responses.
+func fetchHugoAsset(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "walgo-hugo-installer")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxHugoDownload))
+}
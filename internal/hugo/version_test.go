@@ -0,0 +1,360 @@
+package hugo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseHugoVersion(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"hugo v0.125.0-2c6b5d5+extended linux/amd64 BuildDate=2024-04-16T15:04:33Z", "0.125.0"},
+		{"Hugo Static Site Generator v0.80.0/extended darwin/amd64", "0.80.0"},
+		{"hugo v0.121.2 windows/amd64", "0.121.2"},
+	}
+	for _, tt := range tests {
+		if got, err := ParseHugoVersion(tt.output); err != nil || got != tt.want {
+			t.Errorf("ParseHugoVersion(%q) = %q, %v, want %q", tt.output, got, err, tt.want)
+		}
+	}
+	if _, err := ParseHugoVersion("command not found"); err == nil {
+		t.Error("ParseHugoVersion() should fail without a version")
+	}
+}
+
+func TestNormalizeHugoVersion(t *testing.T) {
+	tests := map[string]string{
+		"0.125.0":   "0.125.0",
+		"v0.125.0":  "0.125.0",
+		"0.125":     "0.125.0",
+		" 0.121.2 ": "0.121.2",
+	}
+	for in, want := range tests {
+		if got, err := NormalizeHugoVersion(in); err != nil || got != want {
+			t.Errorf("NormalizeHugoVersion(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "latest", "0.125.0-extended", "1"} {
+		if _, err := NormalizeHugoVersion(bad); err == nil {
+			t.Errorf("NormalizeHugoVersion(%q) should fail", bad)
+		}
+	}
+}
+
+func TestHugoVersionSatisfies(t *testing.T) {
+	tests := []struct {
+		installed, pinned string
+		want              bool
+	}{
+		{"0.125.0", "0.125.0", true},
+		{"0.125.0", "v0.125.0", true},
+		{"0.125.4", "0.125", true},
+		{"0.125.4", "0.125.0", false},
+		{"0.124.1", "0.125", false},
+		{"1.125.0", "0.125.0", false},
+		{"", "0.125.0", false},
+	}
+	for _, tt := range tests {
+		if got := HugoVersionSatisfies(tt.installed, tt.pinned); got != tt.want {
+			t.Errorf("HugoVersionSatisfies(%q, %q) = %v, want %v", tt.installed, tt.pinned, got, tt.want)
+		}
+	}
+}
+
+func TestDecideHugoSource(t *testing.T) {
+	tests := []struct {
+		name        string
+		pinned      string
+		installed   string
+		cached      bool
+		autoInstall bool
+		want        HugoSource
+	}{
+		{"installed version satisfies pin", "0.125.0", "0.125.0", false, false, HugoFromPath},
+		{"patchless pin accepts installed patch", "0.125", "0.125.3", false, false, HugoFromPath},
+		{"installed wins over cache", "0.125.0", "0.125.0", true, true, HugoFromPath},
+		{"wrong version uses cache", "0.125.0", "0.110.0", true, false, HugoFromCache},
+		{"wrong version downloads", "0.125.0", "0.110.0", false, true, HugoDownload},
+		{"no hugo downloads", "0.125.0", "", false, true, HugoDownload},
+		{"wrong version without auto-install", "0.125.0", "0.110.0", false, false, HugoVersionWrong},
+		{"no hugo without auto-install", "0.125.0", "", false, false, HugoVersionWrong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DecideHugoSource(tt.pinned, tt.installed, tt.cached, tt.autoInstall); got != tt.want {
+				t.Errorf("DecideHugoSource() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCachedHugoPath(t *testing.T) {
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+
+	got, err := CachedHugoPath("v0.125", "linux")
+	if err != nil {
+		t.Fatalf("CachedHugoPath() error = %v", err)
+	}
+	if want := filepath.Join(cacheHome, "walgo", "hugo", "v0.125.0", "hugo"); got != want {
+		t.Errorf("CachedHugoPath() = %q, want %q", got, want)
+	}
+
+	got, err = CachedHugoPath("0.121.2", "windows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(cacheHome, "walgo", "hugo", "v0.121.2", "hugo.exe"); got != want {
+		t.Errorf("CachedHugoPath(windows) = %q, want %q", got, want)
+	}
+
+	if _, err := CachedHugoPath("latest", "linux"); err == nil {
+		t.Error("CachedHugoPath() should reject an invalid version")
+	}
+}
+
+func TestHugoCacheDir_DefaultsToHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("HOME", home)
+
+	got, err := HugoCacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(home, ".cache", "walgo", "hugo"); got != want {
+		t.Errorf("HugoCacheDir() = %q, want %q", got, want)
+	}
+}
+
+func TestHugoArchiveName(t *testing.T) {
+	tests := []struct {
+		goos, goarch string
+		want         string
+	}{
+		{"linux", "amd64", "hugo_extended_0.125.0_linux-amd64.tar.gz"},
+		{"linux", "arm64", "hugo_extended_0.125.0_linux-arm64.tar.gz"},
+		{"linux", "386", "hugo_0.125.0_linux-386.tar.gz"},
+		{"darwin", "arm64", "hugo_extended_0.125.0_darwin-universal.tar.gz"},
+		{"windows", "amd64", "hugo_extended_0.125.0_windows-amd64.zip"},
+		{"windows", "arm64", "hugo_0.125.0_windows-arm64.zip"},
+	}
+	for _, tt := range tests {
+		if got, err := HugoArchiveName("0.125.0", tt.goos, tt.goarch); err != nil || got != tt.want {
+			t.Errorf("HugoArchiveName(%s/%s) = %q, %v, want %q", tt.goos, tt.goarch, got, err, tt.want)
+		}
+	}
+	if _, err := HugoArchiveName("0.80.0", "linux", "amd64"); err == nil {
+		t.Error("HugoArchiveName() should reject releases before the current asset naming")
+	}
+}
+
+// stubInstalledHugo makes installedHugo report version ("" for no hugo on
+// PATH) for the duration of the test.
+func stubInstalledHugo(t *testing.T, version string) {
+	t.Helper()
+	orig := installedHugo
+	installedHugo = func() (string, string, error) {
+		if version == "" {
+			return "", "", errors.New("hugo not found")
+		}
+		return "/usr/bin/hugo", version, nil
+	}
+	t.Cleanup(func() { installedHugo = orig })
+}
+
+func TestResolveHugoBinary_UsesInstalledOrCached(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	stubInstalledHugo(t, "0.125.0")
+	if got, err := ResolveHugoBinary(context.Background(), "0.125.0", false, io.Discard); err != nil || got != "/usr/bin/hugo" {
+		t.Errorf("ResolveHugoBinary() = %q, %v, want the hugo on PATH", got, err)
+	}
+
+	stubInstalledHugo(t, "0.110.0")
+	if _, err := ResolveHugoBinary(context.Background(), "0.125.0", false, io.Discard); err == nil || !strings.Contains(err.Error(), "--auto-install") {
+		t.Errorf("ResolveHugoBinary() error = %v, want a hint to use --auto-install", err)
+	}
+
+	cachePath, err := CachedHugoPath("0.125.0", runtime.GOOS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cachePath, []byte("hugo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ResolveHugoBinary(context.Background(), "0.125.0", false, io.Discard); err != nil || got != cachePath {
+		t.Errorf("ResolveHugoBinary() = %q, %v, want the cached %q", got, err, cachePath)
+	}
+}
+
+func TestResolveHugoBinary_Downloads(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("release fixture is a tar.gz")
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	stubInstalledHugo(t, "")
+
+	const binary = "#!/bin/sh\necho hugo v0.125.0\n"
+	archiveName, err := HugoArchiveName("0.125.0", runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := hugoTarGz(t, binary)
+	sum := sha256.Sum256(archive)
+	checksums := fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), archiveName)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v0.125.0/hugo_0.125.0_checksums.txt":
+			_, _ = w.Write([]byte(checksums))
+		case "/v0.125.0/" + archiveName:
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	origURL, origVersionOf := hugoReleaseURL, hugoVersionOf
+	hugoReleaseURL = srv.URL
+	hugoVersionOf = func(path string) (string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return ParseHugoVersion(string(data))
+	}
+	t.Cleanup(func() { hugoReleaseURL, hugoVersionOf = origURL, origVersionOf })
+
+	var out bytes.Buffer
+	got, err := ResolveHugoBinary(context.Background(), "0.125", true, &out)
+	if err != nil {
+		t.Fatalf("ResolveHugoBinary() error = %v", err)
+	}
+	want, _ := CachedHugoPath("0.125.0", runtime.GOOS)
+	if got != want {
+		t.Errorf("ResolveHugoBinary() = %q, want %q", got, want)
+	}
+	if data, err := os.ReadFile(got); err != nil || string(data) != binary {
+		t.Errorf("installed binary = %q, %v", data, err)
+	}
+	if !strings.Contains(out.String(), "Installed Hugo 0.125.0") {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestResolveHugoBinary_RejectsBadChecksum(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("release fixture is a tar.gz")
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	stubInstalledHugo(t, "")
+
+	archiveName, err := HugoArchiveName("0.125.0", runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "_checksums.txt") {
+			_, _ = fmt.Fprintf(w, "%s  %s\n", strings.Repeat("0", 64), archiveName)
+			return
+		}
+		_, _ = w.Write(hugoTarGz(t, "tampered"))
+	}))
+	defer srv.Close()
+
+	orig := hugoReleaseURL
+	hugoReleaseURL = srv.URL
+	t.Cleanup(func() { hugoReleaseURL = orig })
+
+	if _, err := ResolveHugoBinary(context.Background(), "0.125.0", true, io.Discard); err == nil {
+		t.Fatal("ResolveHugoBinary() should fail on a checksum mismatch")
+	}
+	cachePath, _ := CachedHugoPath("0.125.0", runtime.GOOS)
+	if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+		t.Error("nothing should be cached when the checksum does not match")
+	}
+}
+
+// hugoTarGz builds a Hugo release archive holding binary as "hugo".
+func hugoTarGz(t *testing.T, binary string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	files := map[string]string{"LICENSE": "Apache-2.0", "hugo": binary}
+	for _, name := range []string{"LICENSE", "hugo"} {
+		content := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestBuildSiteWithOptions_UsesPinnedHugo(t *testing.T) {
+	sitePath, fakeHugo := writeFixtureSite(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	stubInstalledHugo(t, "0.110.0")
+	if err := os.WriteFile(filepath.Join(sitePath, "walgo.yaml"), []byte("hugo:\n  version: \"0.125.0\"\n  publishDir: public\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := BuildSiteWithOptions(sitePath, BuildOptions{}); err == nil || !strings.Contains(err.Error(), "pins Hugo 0.125.0") {
+		t.Fatalf("BuildSiteWithOptions() error = %v, want the pin reported when only 0.110.0 is installed", err)
+	}
+
+	cachePath, err := CachedHugoPath("0.125.0", runtime.GOOS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := os.ReadFile(fakeHugo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cachePath, script, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := SiteHugoBinary(sitePath, io.Discard); err != nil || got != cachePath {
+		t.Errorf("SiteHugoBinary() = %q, %v, want the cached %q", got, err, cachePath)
+	}
+	if err := BuildSiteWithOptions(sitePath, BuildOptions{}); err != nil {
+		t.Fatalf("BuildSiteWithOptions() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sitePath, "hugo-args")); err != nil {
+		t.Errorf("the cached pinned hugo should have run: %v", err)
+	}
+}
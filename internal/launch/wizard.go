@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/selimozten/walgo/internal/compress"
 	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/projects"
 	"github.com/selimozten/walgo/internal/sui"
@@ -210,10 +211,18 @@ func GetProjectDetails() (*ProjectDetails, error) {
 		break
 	}
 
-	// Category
-	category := readlineInputWithDefault("Category", DefaultCategory)
-	if category == "" {
-		category = DefaultCategory
+	// Category (must be a known category; custom ones need `walgo projects edit --allow-custom-category`)
+	var category string
+	for {
+		category = readlineInputWithDefault("Category", DefaultCategory)
+		if category == "" {
+			category = DefaultCategory
+		}
+		if err := (compress.MetadataOptions{Category: category}).Validate(); err != nil {
+			fmt.Printf("\n%s %v\n\n", icons.Warning, err)
+			continue
+		}
+		break
 	}
 
 	// Description (auto-generated, can be changed later via walgo projects)
@@ -224,9 +233,17 @@ func GetProjectDetails() (*ProjectDetails, error) {
 	}
 
 	// Image URL (defaults to Walgo logo, can be changed later via walgo projects)
-	imageURL := readlineInputWithDefault("Image URL", "Walgo logo")
-	if imageURL == "" || imageURL == "Walgo logo" {
-		imageURL = DefaultWalgoLogoURL
+	var imageURL string
+	for {
+		imageURL = readlineInputWithDefault("Image URL", "Walgo logo")
+		if imageURL == "" || imageURL == "Walgo logo" {
+			imageURL = DefaultWalgoLogoURL
+		}
+		if err := (compress.MetadataOptions{ImageURL: imageURL}).Validate(); err != nil {
+			fmt.Printf("\n%s %v\n\n", icons.Warning, err)
+			continue
+		}
+		break
 	}
 
 	return &ProjectDetails{
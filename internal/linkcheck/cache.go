@@ -0,0 +1,97 @@
+package linkcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long a working link is not probed again.
+const DefaultCacheTTL = 24 * time.Hour
+
+// Cache remembers external links that worked, so repeated checks do not hit
+// the same servers. Broken results are never cached: a fixed link or a
+// server that is back should show up on the next run.
+type Cache struct {
+	path    string
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]Result
+}
+
+// DefaultCachePath returns $XDG_CACHE_HOME/walgo/links.json, or
+// ~/.cache/walgo/links.json when unset.
+func DefaultCachePath() (string, error) {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "walgo", "links.json"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".cache", "walgo", "links.json"), nil
+}
+
+// LoadCache reads the cache at path. A missing or unreadable file gives an
+// empty cache.
+func LoadCache(path string, ttl time.Duration) *Cache {
+	c := &Cache{path: path, ttl: ttl, entries: make(map[string]Result)}
+	data, err := os.ReadFile(path) // #nosec G304 - path is the walgo cache file
+	if err != nil {
+		return c
+	}
+	var entries map[string]Result
+	if json.Unmarshal(data, &entries) == nil && entries != nil {
+		c.entries = entries
+	}
+	return c
+}
+
+// Get returns the cached result for url when it is younger than the TTL.
+func (c *Cache) Get(url string, now time.Time) (Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	res, ok := c.entries[url]
+	if !ok || now.Sub(res.CheckedAt) > c.ttl {
+		return Result{}, false
+	}
+	return res, true
+}
+
+// Put stores a working result and drops a broken one.
+func (c *Cache) Put(res Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if res.Skipped || res.Broken() {
+		delete(c.entries, res.URL)
+		return
+	}
+	c.entries[res.URL] = res
+}
+
+// Save writes the cache, leaving out expired entries.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for url, res := range c.entries {
+		if now.Sub(res.CheckedAt) > c.ttl {
+			delete(c.entries, url)
+		}
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding link cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("creating link cache directory: %w", err)
+	}
+	// #nosec G306 - the cache only holds public URLs and status codes
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("writing link cache: %w", err)
+	}
+	return nil
+}
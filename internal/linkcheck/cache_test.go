@@ -0,0 +1,57 @@
+package linkcheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "walgo", "links.json")
+	now := time.Now().UTC()
+
+	cache := LoadCache(path, time.Hour)
+	cache.Put(Result{URL: "https://ok.example.com", StatusCode: 200, CheckedAt: now})
+	cache.Put(Result{URL: "https://dead.example.com", StatusCode: 404, CheckedAt: now})
+	cache.Put(Result{URL: "https://old.example.com", StatusCode: 200, CheckedAt: now.Add(-2 * time.Hour)})
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded := LoadCache(path, time.Hour)
+	if res, ok := loaded.Get("https://ok.example.com", now); !ok || res.StatusCode != 200 {
+		t.Errorf("Get(ok) = %+v, %v", res, ok)
+	}
+	if _, ok := loaded.Get("https://dead.example.com", now); ok {
+		t.Error("broken results must not be cached")
+	}
+	if _, ok := loaded.Get("https://old.example.com", now); ok {
+		t.Error("expired results must not be returned")
+	}
+	if _, ok := loaded.Get("https://ok.example.com", now.Add(2*time.Hour)); ok {
+		t.Error("results older than the TTL must not be returned")
+	}
+}
+
+func TestLoadCache_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := LoadCache(path, time.Hour).Get("https://example.com", time.Now()); ok {
+		t.Error("a corrupt cache should load empty")
+	}
+}
+
+func TestDefaultCachePath(t *testing.T) {
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+	got, err := DefaultCachePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(cacheHome, "walgo", "links.json"); got != want {
+		t.Errorf("DefaultCachePath() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,225 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Defaults for ExternalOptions.
+const (
+	DefaultTimeout     = 10 * time.Second
+	DefaultConcurrency = 8
+	DefaultRate        = 5.0 // Requests per second across all hosts
+)
+
+// Limiter paces outgoing requests; *rate.Limiter satisfies it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// ExternalOptions configures CheckExternal.
+type ExternalOptions struct {
+	Client      *http.Client  // Defaults to a client with Timeout
+	Timeout     time.Duration // Per request, default DefaultTimeout
+	Concurrency int           // Requests in flight, default DefaultConcurrency
+	Rate        float64       // Requests per second, default DefaultRate
+	Limiter     Limiter       // Overrides Rate when set
+	SkipDomains []string      // Hosts not probed, subdomains included
+	Cache       *Cache        // Reuses recent successful results; nil disables
+}
+
+// Result is the outcome of probing one URL.
+type Result struct {
+	URL        string    `json:"url"`
+	StatusCode int       `json:"status,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checkedAt"`
+	Skipped    bool      `json:"-"` // Host is in SkipDomains
+	Cached     bool      `json:"-"` // Taken from the cache
+}
+
+// Broken reports whether the link is dead: the request failed or timed out,
+// or the server answered 4xx or 5xx. 429 Too Many Requests says nothing
+// about the page and is not counted.
+func (r Result) Broken() bool {
+	if r.Skipped {
+		return false
+	}
+	if r.Error != "" {
+		return true
+	}
+	return r.StatusCode >= 400 && r.StatusCode != http.StatusTooManyRequests
+}
+
+// Reason describes why a broken result is broken.
+func (r Result) Reason() string {
+	if r.Error != "" {
+		return r.Error
+	}
+	return fmt.Sprintf("HTTP %d", r.StatusCode)
+}
+
+// CheckExternal probes each distinct http(s) URL in links once, with at most
+// opts.Concurrency requests in flight and opts.Rate requests per second, and
+// returns the results by URL. Hosts in opts.SkipDomains are not contacted.
+func CheckExternal(ctx context.Context, links []Link, opts ExternalOptions) map[string]Result {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: opts.Timeout}
+	}
+	if opts.Limiter == nil {
+		r := opts.Rate
+		if r <= 0 {
+			r = DefaultRate
+		}
+		opts.Limiter = rate.NewLimiter(rate.Limit(r), 1)
+	}
+
+	results := make(map[string]Result)
+	var pending []string
+	for _, link := range links {
+		if !link.IsExternal() {
+			continue
+		}
+		if _, done := results[link.URL]; done {
+			continue
+		}
+		switch {
+		case skipDomain(link.URL, opts.SkipDomains):
+			results[link.URL] = Result{URL: link.URL, Skipped: true}
+		case opts.Cache != nil:
+			if cached, ok := opts.Cache.Get(link.URL, time.Now()); ok {
+				cached.Cached = true
+				results[link.URL] = cached
+				continue
+			}
+			fallthrough
+		default:
+			results[link.URL] = Result{URL: link.URL}
+			pending = append(pending, link.URL)
+		}
+	}
+	sort.Strings(pending)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	urls := make(chan string)
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range urls {
+				res := probe(ctx, opts, u)
+				mu.Lock()
+				results[u] = res
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, u := range pending {
+		urls <- u
+	}
+	close(urls)
+	wg.Wait()
+
+	if opts.Cache != nil {
+		for _, u := range pending {
+			opts.Cache.Put(results[u])
+		}
+	}
+	return results
+}
+
+// probe requests u with HEAD and, when the server refuses HEAD or answers
+// with an error other than 404/410, again with GET.
+func probe(ctx context.Context, opts ExternalOptions, u string) Result {
+	res := Result{URL: u}
+	code, err := request(ctx, opts, http.MethodHead, u)
+	if err == nil && code >= 400 && code != http.StatusNotFound && code != http.StatusGone {
+		code, err = request(ctx, opts, http.MethodGet, u)
+	}
+	res.CheckedAt = time.Now().UTC()
+	if err != nil {
+		res.Error = describeError(err)
+		return res
+	}
+	res.StatusCode = code
+	return res
+}
+
+// request waits for the limiter and sends one request, returning the status.
+func request(ctx context.Context, opts ExternalOptions, method, u string) (int, error) {
+	if err := opts.Limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, method, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "walgo-link-checker")
+	resp, err := opts.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	return resp.StatusCode, nil
+}
+
+// describeError shortens a request error for the report.
+func describeError(err error) string {
+	var urlErr *url.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &urlErr) && urlErr.Timeout()) {
+		return "timeout"
+	}
+	if errors.As(err, &urlErr) {
+		return urlErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// skipDomain reports whether u's host is one of domains or a subdomain of one.
+func skipDomain(u string, domains []string) bool {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "."))
+		if d != "" && (host == d || strings.HasSuffix(host, "."+d)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExternalFindings pairs each broken result with the links that use it.
+func ExternalFindings(links []Link, results map[string]Result) []Finding {
+	var findings []Finding
+	for _, link := range links {
+		if res, ok := results[link.URL]; ok && res.Broken() {
+			findings = append(findings, Finding{Link: link, Reason: res.Reason()})
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
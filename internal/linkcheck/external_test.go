@@ -0,0 +1,159 @@
+package linkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// linkServer serves /ok with 200, /missing with 404, /slow after a delay and
+// /no-head with 405 for HEAD and 200 for GET.
+func linkServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/slow":
+			select {
+			case <-time.After(2 * time.Second):
+			case <-r.Context().Done():
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// countingLimiter counts Wait calls without delaying them.
+type countingLimiter struct{ waits atomic.Int32 }
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return ctx.Err()
+}
+
+func TestCheckExternal(t *testing.T) {
+	srv := linkServer(t)
+	links := []Link{
+		{File: "content/a.md", Line: 1, URL: srv.URL + "/ok"},
+		{File: "content/a.md", Line: 2, URL: srv.URL + "/missing"},
+		{File: "content/b.md", Line: 4, URL: srv.URL + "/slow"},
+		{File: "content/b.md", Line: 5, URL: srv.URL + "/no-head"},
+		{File: "content/b.md", Line: 6, URL: srv.URL + "/missing"},
+		{File: "content/b.md", Line: 7, URL: "/internal/"},
+	}
+
+	limiter := &countingLimiter{}
+	results := CheckExternal(context.Background(), links, ExternalOptions{
+		Timeout: 200 * time.Millisecond,
+		Limiter: limiter,
+	})
+
+	if len(results) != 4 {
+		t.Fatalf("results = %+v, want one per distinct external URL", results)
+	}
+	if res := results[srv.URL+"/ok"]; res.Broken() || res.StatusCode != http.StatusOK {
+		t.Errorf("/ok = %+v, want a working 200", res)
+	}
+	if res := results[srv.URL+"/missing"]; !res.Broken() || res.Reason() != "HTTP 404" {
+		t.Errorf("/missing = %+v, want broken with HTTP 404", res)
+	}
+	if res := results[srv.URL+"/slow"]; !res.Broken() || res.Reason() != "timeout" {
+		t.Errorf("/slow = %+v, want broken with a timeout", res)
+	}
+	if res := results[srv.URL+"/no-head"]; res.Broken() {
+		t.Errorf("/no-head = %+v, want GET to be tried after HEAD is refused", res)
+	}
+
+	// One request each, plus the GET retry for /no-head
+	if got := limiter.waits.Load(); got != 5 {
+		t.Errorf("limiter waited %d times, want 5 (once per request)", got)
+	}
+
+	findings := ExternalFindings(links, results)
+	if len(findings) != 3 {
+		t.Fatalf("findings = %+v, want 3", findings)
+	}
+	if findings[0].File != "content/a.md" || findings[0].Line != 2 || findings[2].File != "content/b.md" || findings[2].Line != 6 {
+		t.Errorf("findings not reported per source file and line: %+v", findings)
+	}
+}
+
+func TestCheckExternal_RateLimited(t *testing.T) {
+	srv := linkServer(t)
+	var links []Link
+	for _, p := range []string{"/ok?a", "/ok?b", "/ok?c", "/ok?d"} {
+		links = append(links, Link{File: "content/a.md", Line: 1, URL: srv.URL + p})
+	}
+
+	start := time.Now()
+	results := CheckExternal(context.Background(), links, ExternalOptions{Rate: 20, Concurrency: 4})
+	elapsed := time.Since(start)
+
+	if len(results) != 4 {
+		t.Fatalf("results = %+v", results)
+	}
+	// 4 requests at 20/s with a burst of 1 take at least 3 intervals of 50ms
+	if elapsed < 140*time.Millisecond {
+		t.Errorf("4 requests took %v, the rate limit was not applied", elapsed)
+	}
+}
+
+func TestCheckExternal_SkipDomains(t *testing.T) {
+	limiter := &countingLimiter{}
+	links := []Link{
+		{File: "content/a.md", Line: 1, URL: "https://flaky.example.com/x"},
+		{File: "content/a.md", Line: 2, URL: "https://www.twitter.com/someone"},
+	}
+	results := CheckExternal(context.Background(), links, ExternalOptions{
+		Limiter:     limiter,
+		SkipDomains: []string{"flaky.example.com", ".twitter.com"},
+	})
+
+	for _, link := range links {
+		if res := results[link.URL]; !res.Skipped || res.Broken() {
+			t.Errorf("%s = %+v, want skipped", link.URL, res)
+		}
+	}
+	if limiter.waits.Load() != 0 {
+		t.Error("skipped domains must not be contacted")
+	}
+}
+
+func TestCheckExternal_UsesCache(t *testing.T) {
+	srv := linkServer(t)
+	cache := LoadCache(filepath.Join(t.TempDir(), "links.json"), time.Hour)
+	links := []Link{
+		{File: "content/a.md", Line: 1, URL: srv.URL + "/ok"},
+		{File: "content/a.md", Line: 2, URL: srv.URL + "/missing"},
+	}
+
+	first := &countingLimiter{}
+	CheckExternal(context.Background(), links, ExternalOptions{Limiter: first, Cache: cache})
+	if first.waits.Load() != 2 {
+		t.Fatalf("first run made %d requests, want 2", first.waits.Load())
+	}
+
+	second := &countingLimiter{}
+	results := CheckExternal(context.Background(), links, ExternalOptions{Limiter: second, Cache: cache})
+	if second.waits.Load() != 1 {
+		t.Errorf("second run made %d requests, want only the broken link re-probed", second.waits.Load())
+	}
+	if !results[srv.URL+"/ok"].Cached || !results[srv.URL+"/missing"].Broken() {
+		t.Errorf("results = %+v", results)
+	}
+}
@@ -0,0 +1,108 @@
+package linkcheck
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Finding is a link found to be broken.
+type Finding struct {
+	Link
+	Reason string
+}
+
+// SiteIndex holds the URL paths a built site serves: every file Hugo wrote
+// to its publish directory, and every directory holding an index.html. That
+// covers taxonomy and pagination pages, feeds, aliases, processed assets and
+// pages under custom permalinks.
+type SiteIndex struct {
+	paths map[string]bool
+}
+
+// ErrNotBuilt is returned by BuildSiteIndex when the publish directory does
+// not exist yet.
+var ErrNotBuilt = errors.New("site has not been built")
+
+// BuildSiteIndex indexes the files in publishDir.
+func BuildSiteIndex(publishDir string) (*SiteIndex, error) {
+	if info, err := os.Stat(publishDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s not found", ErrNotBuilt, publishDir)
+	}
+
+	idx := &SiteIndex{paths: make(map[string]bool)}
+	err := filepath.Walk(publishDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(publishDir, p)
+		if err != nil {
+			return err
+		}
+		idx.paths[normalizePath(filepath.ToSlash(rel))] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("indexing %s: %w", publishDir, err)
+	}
+	return idx, nil
+}
+
+// Has reports whether the site serves the root-relative link target.
+func (idx *SiteIndex) Has(target string) bool {
+	return idx.paths[normalizePath(target)]
+}
+
+// normalizePath reduces a URL path to the form the index compares:
+// unescaped, and without the query, fragment, surrounding slashes or a
+// trailing index.html.
+func normalizePath(p string) string {
+	if i := strings.IndexAny(p, "?#"); i >= 0 {
+		p = p[:i]
+	}
+	if unescaped, err := url.PathUnescape(p); err == nil {
+		p = unescaped
+	}
+	p = strings.Trim(p, "/")
+	if p == "index.html" || strings.HasSuffix(p, "/index.html") {
+		p = strings.TrimSuffix(p, "index.html")
+	}
+	return strings.Trim(p, "/")
+}
+
+// CheckInternal reports the root-relative links whose target idx does not
+// know. Links holding Hugo template code are skipped.
+func CheckInternal(idx *SiteIndex, links []Link) []Finding {
+	var findings []Finding
+	for _, link := range links {
+		if !link.IsInternal() || strings.Contains(link.URL, "{{") {
+			continue
+		}
+		if !idx.Has(link.URL) {
+			findings = append(findings, Finding{Link: link, Reason: "not in the built site"})
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
+
+// sortFindings orders findings by file, line and URL.
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.URL < b.URL
+	})
+}
@@ -0,0 +1,84 @@
+package linkcheck
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckInternal(t *testing.T) {
+	publishDir := t.TempDir()
+	for _, rel := range []string{
+		"index.html",
+		"index.xml",
+		"about/index.html",
+		"posts/index.html",
+		"posts/page/2/index.html",
+		"posts/first-post/index.html",
+		"posts/bundle/index.html",
+		"posts/bundle/diagram.png",
+		"tags/go/index.html",
+		"old/path/index.html",
+		"blog/2024/custom-permalink/index.html",
+		"css/main.min.3f2a.css",
+		"images/logo.png",
+		"legacy.html",
+		"files/My Report.pdf",
+	} {
+		writeSiteFile(t, publishDir, rel, "x")
+	}
+
+	idx, err := BuildSiteIndex(publishDir)
+	if err != nil {
+		t.Fatalf("BuildSiteIndex() error = %v", err)
+	}
+
+	ok := []string{
+		"/",
+		"/index.xml",
+		"/about/",
+		"/about",
+		"/about/index.html",
+		"/posts/",
+		"/posts/page/2/",
+		"/posts/first-post/#intro",
+		"/posts/bundle/diagram.png",
+		"/tags/go/",
+		"/old/path/",
+		"/blog/2024/custom-permalink/",
+		"/css/main.min.3f2a.css",
+		"/images/logo.png?v=2",
+		"/legacy.html",
+		"/files/My%20Report.pdf",
+		"/{{ .Site.BaseURL }}",
+	}
+	broken := []string{
+		"/About/",
+		"/missing/",
+		"/posts/page/3/",
+		"/images/missing.png",
+	}
+
+	var links []Link
+	for i, u := range append(append([]string{}, ok...), broken...) {
+		links = append(links, Link{File: "content/about.md", Line: i + 1, URL: u})
+	}
+	links = append(links, Link{File: "content/about.md", Line: 99, URL: "https://example.com/missing"})
+
+	findings := CheckInternal(idx, links)
+	if len(findings) != len(broken) {
+		t.Fatalf("findings = %+v, want %v", findings, broken)
+	}
+	for i, f := range findings {
+		if f.URL != broken[i] {
+			t.Errorf("finding %d = %q, want %q", i, f.URL, broken[i])
+		}
+	}
+}
+
+func TestBuildSiteIndex_NotBuilt(t *testing.T) {
+	_, err := BuildSiteIndex(filepath.Join(t.TempDir(), "public"))
+	if !errors.Is(err, ErrNotBuilt) {
+		t.Errorf("BuildSiteIndex() error = %v, want ErrNotBuilt", err)
+	}
+}
@@ -0,0 +1,155 @@
+// Package linkcheck finds broken links in a Hugo site's Markdown content:
+// internal links to pages or files the site does not have, and external
+// links whose servers answer with an error or not at all.
+package linkcheck
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Link is one link found in a content file.
+type Link struct {
+	File string // Content file, slash-separated and relative to the site root
+	Line int    // 1-based line number
+	URL  string
+}
+
+// IsExternal reports whether the link points at another site over HTTP(S).
+func (l Link) IsExternal() bool {
+	lower := strings.ToLower(l.URL)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// IsInternal reports whether the link is a root-relative path on this site.
+// Relative links depend on the page's URL and are not checked.
+func (l Link) IsInternal() bool {
+	return strings.HasPrefix(l.URL, "/") && !strings.HasPrefix(l.URL, "//")
+}
+
+var (
+	markdownLinkPattern = regexp.MustCompile(`\]\(\s*<?([^)\s>]+)>?(?:\s+["'][^"']*["'])?\s*\)`)
+	referenceDefPattern = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?([^\s>]+)>?`)
+	htmlAttrPattern     = regexp.MustCompile(`(?i)\b(?:href|src)\s*=\s*["']([^"']+)["']`)
+	bareURLPattern      = regexp.MustCompile(`https?://[^\s<>()"'\x60\]\[{}|\\^]+`)
+	inlineCodePattern   = regexp.MustCompile("`[^`]*`")
+)
+
+// ExtractLinks returns the links in a Markdown file, in order of appearance.
+// Markdown links, reference definitions, HTML href/src attributes and bare
+// http(s) URLs are found; fenced code blocks and inline code are skipped.
+// file is recorded on each link as given.
+func ExtractLinks(file string, data []byte) []Link {
+	var links []Link
+	inFence := false
+	fence := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		trimmed := strings.TrimSpace(line)
+		if marker := fenceMarker(trimmed); marker != "" {
+			switch {
+			case !inFence:
+				inFence, fence = true, marker
+				continue
+			case strings.HasPrefix(trimmed, fence):
+				inFence = false
+				continue
+			}
+		}
+		if inFence {
+			continue
+		}
+		line = inlineCodePattern.ReplaceAllString(line, "")
+
+		seen := make(map[string]bool)
+		add := func(url string) {
+			url = strings.TrimSpace(url)
+			if url == "" || seen[url] {
+				return
+			}
+			seen[url] = true
+			links = append(links, Link{File: file, Line: lineNum, URL: url})
+		}
+		for _, m := range markdownLinkPattern.FindAllStringSubmatch(line, -1) {
+			add(m[1])
+		}
+		if m := referenceDefPattern.FindStringSubmatch(line); m != nil {
+			add(m[1])
+		}
+		for _, m := range htmlAttrPattern.FindAllStringSubmatch(line, -1) {
+			add(m[1])
+		}
+		for _, url := range bareURLPattern.FindAllString(line, -1) {
+			add(strings.TrimRight(url, ".,;:!?*_~"))
+		}
+	}
+	return links
+}
+
+// fenceMarker returns the ``` or ~~~ opening a fenced code block line.
+func fenceMarker(trimmed string) string {
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// ExtractSiteLinks returns the links in every Markdown file under the site's
+// content directory. File names are relative to siteDir.
+func ExtractSiteLinks(siteDir string) ([]Link, error) {
+	contentDir := filepath.Join(siteDir, "content")
+	files, err := markdownFiles(contentDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []Link
+	for _, path := range files {
+		data, err := os.ReadFile(path) // #nosec G304 - path comes from walking the content directory
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		rel, err := filepath.Rel(siteDir, path)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, ExtractLinks(filepath.ToSlash(rel), data)...)
+	}
+	return links, nil
+}
+
+// markdownFiles returns the Markdown files under dir, sorted.
+func markdownFiles(dir string) ([]string, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("content directory not found: %w", err)
+	}
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".md") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", dir, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,94 @@
+package linkcheck
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	content := "---\n" +
+		"title: Post\n" +
+		"image: https://cdn.example.com/cover.png\n" +
+		"---\n" +
+		"See [the docs](https://docs.example.com/guide \"Guide\") and [about](/about/).\n" +
+		"Visit https://example.org/page. Or <https://auto.example.com/x>.\n" +
+		"```\n" +
+		"curl https://in-code-block.example.com\n" +
+		"```\n" +
+		"Inline `https://inline-code.example.com` is ignored.\n" +
+		"<a href=\"/contact/\">Contact</a> <img src='/images/logo.png'>\n" +
+		"[ref]: https://ref.example.com/path\n"
+
+	got := ExtractLinks("content/post.md", []byte(content))
+	want := []Link{
+		{File: "content/post.md", Line: 3, URL: "https://cdn.example.com/cover.png"},
+		{File: "content/post.md", Line: 5, URL: "https://docs.example.com/guide"},
+		{File: "content/post.md", Line: 5, URL: "/about/"},
+		{File: "content/post.md", Line: 6, URL: "https://example.org/page"},
+		{File: "content/post.md", Line: 6, URL: "https://auto.example.com/x"},
+		{File: "content/post.md", Line: 11, URL: "/contact/"},
+		{File: "content/post.md", Line: 11, URL: "/images/logo.png"},
+		{File: "content/post.md", Line: 12, URL: "https://ref.example.com/path"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractLinks() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestLinkKinds(t *testing.T) {
+	tests := []struct {
+		url      string
+		external bool
+		internal bool
+	}{
+		{"https://example.com", true, false},
+		{"HTTP://example.com", true, false},
+		{"/about/", false, true},
+		{"//cdn.example.com/x.js", false, false},
+		{"../sibling/", false, false},
+		{"mailto:me@example.com", false, false},
+	}
+	for _, tt := range tests {
+		l := Link{URL: tt.url}
+		if l.IsExternal() != tt.external || l.IsInternal() != tt.internal {
+			t.Errorf("%q: external = %v, internal = %v", tt.url, l.IsExternal(), l.IsInternal())
+		}
+	}
+}
+
+func TestExtractSiteLinks(t *testing.T) {
+	siteDir := t.TempDir()
+	writeSiteFile(t, siteDir, "content/posts/a.md", "[b](/posts/b/)\n")
+	writeSiteFile(t, siteDir, "content/_index.md", "https://example.com\n")
+	writeSiteFile(t, siteDir, "content/posts/notes.txt", "https://not-markdown.example.com\n")
+
+	links, err := ExtractSiteLinks(siteDir)
+	if err != nil {
+		t.Fatalf("ExtractSiteLinks() error = %v", err)
+	}
+	want := []Link{
+		{File: "content/_index.md", Line: 1, URL: "https://example.com"},
+		{File: "content/posts/a.md", Line: 1, URL: "/posts/b/"},
+	}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("ExtractSiteLinks() = %+v, want %+v", links, want)
+	}
+
+	if _, err := ExtractSiteLinks(t.TempDir()); err == nil {
+		t.Error("ExtractSiteLinks() should fail without a content directory")
+	}
+}
+
+// writeSiteFile writes content to rel under siteDir, creating directories.
+func writeSiteFile(t *testing.T, siteDir, rel, content string) {
+	t.Helper()
+	p := filepath.Join(siteDir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,56 @@
+// Package netcheck detects when the machine is offline, so commands that need
+// the network can fail fast with a clear message instead of a deep CLI error.
+package netcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// DefaultTimeout bounds a reachability probe.
+const DefaultTimeout = 3 * time.Second
+
+// SkipEnv turns the check off when set to 1 or true, for networks where the
+// probes are blocked but the Sui and Walrus CLIs still get through.
+const SkipEnv = "WALGO_SKIP_NETCHECK"
+
+// Timeout bounds each probe made by IsOnline and RequireOnline.
+var Timeout = DefaultTimeout
+
+// ErrOffline is returned by RequireOnline when no endpoint could be reached.
+var ErrOffline = errors.New("you appear to be offline")
+
+// IsOnline reports whether any of endpoints answers within Timeout.
+func IsOnline(ctx context.Context, endpoints []walrus.Endpoint) bool {
+	return RequireOnline(ctx, endpoints) == nil
+}
+
+// RequireOnline probes endpoints, the Sui RPC and Walrus services a command
+// is about to use, with walrus.CheckConnectivity. It returns an error
+// wrapping ErrOffline when every probe fails at the network level; an
+// endpoint that answers with an error still means the machine is online.
+// Nothing is probed when SkipEnv is set.
+func RequireOnline(ctx context.Context, endpoints []walrus.Endpoint) error {
+	if v := os.Getenv(SkipEnv); v == "1" || v == "true" {
+		return nil
+	}
+	if len(endpoints) == 0 {
+		return fmt.Errorf("%w: no endpoints to probe", ErrOffline)
+	}
+
+	report := walrus.CheckConnectivity(ctx, endpoints, walrus.ProbeOptions{Timeout: Timeout})
+	if !report.Offline() {
+		return nil
+	}
+	failures := make([]string, 0, len(report.Results))
+	for _, res := range report.Results {
+		failures = append(failures, fmt.Sprintf("%s unreachable", res.URL))
+	}
+	return fmt.Errorf("%w: %s", ErrOffline, strings.Join(failures, "; "))
+}
@@ -0,0 +1,118 @@
+package netcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/walrus"
+)
+
+// unreachableTarget returns the URL of a port nothing listens on.
+func unreachableTarget(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	return "http://" + addr
+}
+
+// useTimeout sets the probe timeout for one test.
+func useTimeout(t *testing.T, timeout time.Duration) {
+	t.Helper()
+	old := Timeout
+	Timeout = timeout
+	t.Cleanup(func() { Timeout = old })
+}
+
+// endpoints returns an RPC and an aggregator endpoint at the given URLs.
+func endpoints(rpcURL, aggregatorURL string) []walrus.Endpoint {
+	return []walrus.Endpoint{
+		{Name: "Sui RPC", Kind: walrus.EndpointSuiRPC, URL: rpcURL},
+		{Name: "Walrus aggregator", Kind: walrus.EndpointAggregator, URL: aggregatorURL},
+	}
+}
+
+func TestOnline(t *testing.T) {
+	// Any answer counts: an aggregator rejecting HEAD is still reachable.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer srv.Close()
+	useTimeout(t, time.Second)
+	eps := endpoints(srv.URL, srv.URL)
+
+	if !IsOnline(context.Background(), eps) {
+		t.Error("IsOnline() = false, want true")
+	}
+	if err := RequireOnline(context.Background(), eps); err != nil {
+		t.Errorf("RequireOnline() error = %v", err)
+	}
+}
+
+func TestOnlineWhenAnyEndpointAnswers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	useTimeout(t, time.Second)
+
+	if !IsOnline(context.Background(), endpoints(unreachableTarget(t), srv.URL)) {
+		t.Error("IsOnline() = false with one reachable endpoint")
+	}
+}
+
+func TestOffline(t *testing.T) {
+	target := unreachableTarget(t)
+	useTimeout(t, 200*time.Millisecond)
+	eps := endpoints(target, target)
+
+	start := time.Now()
+	if IsOnline(context.Background(), eps) {
+		t.Error("IsOnline() = true, want false")
+	}
+	err := RequireOnline(context.Background(), eps)
+	if !errors.Is(err, ErrOffline) {
+		t.Fatalf("RequireOnline() error = %v, want ErrOffline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("offline detection took %s, want it bounded by the timeout", elapsed)
+	}
+}
+
+func TestRequireOnlineTimesOut(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+	useTimeout(t, 100*time.Millisecond)
+
+	start := time.Now()
+	err := RequireOnline(context.Background(), endpoints(srv.URL, srv.URL))
+	if !errors.Is(err, ErrOffline) {
+		t.Fatalf("RequireOnline() error = %v, want ErrOffline", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("RequireOnline() took %s, want about 100ms", elapsed)
+	}
+}
+
+func TestRequireOnlineNoEndpoints(t *testing.T) {
+	if err := RequireOnline(context.Background(), nil); !errors.Is(err, ErrOffline) {
+		t.Errorf("RequireOnline(nil) error = %v, want ErrOffline", err)
+	}
+}
+
+func TestRequireOnlineSkipped(t *testing.T) {
+	t.Setenv(SkipEnv, "1")
+	if err := RequireOnline(context.Background(), endpoints(unreachableTarget(t), unreachableTarget(t))); err != nil {
+		t.Errorf("RequireOnline() with %s set error = %v, want nil", SkipEnv, err)
+	}
+}
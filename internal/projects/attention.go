@@ -0,0 +1,166 @@
+package projects
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/selimozten/walgo/internal/sui"
+)
+
+// siteObjectExists is a test hook for checking a site object on-chain. It is
+// false for objects that never existed or were deleted, such as a destroyed
+// site.
+var siteObjectExists = func(ctx context.Context, network, objectID string) (bool, error) {
+	_, err := sui.GetObject(sui.WithRPCURL(ctx, sui.ResolveRPCEndpoint(network, "")), objectID)
+	if errors.Is(err, sui.ErrObjectNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+const (
+	// attentionCheckTimeout bounds each on-chain site object lookup.
+	attentionCheckTimeout = 15 * time.Second
+	// attentionCheckConcurrency caps the site object lookups in flight.
+	attentionCheckConcurrency = 8
+)
+
+// AttentionReason says why a project needs attention.
+type AttentionReason string
+
+const (
+	AttentionExpired       AttentionReason = "expired"        // Storage has expired
+	AttentionExpiringSoon  AttentionReason = "expiring_soon"  // Storage expires within the window
+	AttentionDeployFailed  AttentionReason = "deploy_failed"  // The last deploy failed
+	AttentionObjectMissing AttentionReason = "object_missing" // The site object is not on-chain
+)
+
+// AttentionSeverity ranks attention items.
+type AttentionSeverity string
+
+const (
+	SeverityCritical AttentionSeverity = "critical" // The site is down or about to be
+	SeverityWarning  AttentionSeverity = "warning"  // The site works but needs action
+)
+
+// AttentionItem is one reason a project needs attention. A project with
+// several problems has one item per reason.
+type AttentionItem struct {
+	Project   *Project          `json:"project"`
+	Reason    AttentionReason   `json:"reason"`
+	Severity  AttentionSeverity `json:"severity"`
+	Message   string            `json:"message"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"` // For expiry reasons
+}
+
+// GetProjectsNeedingAttention returns the live projects that need action:
+// storage expired or expiring within the given window, a last deploy that
+// failed, or a site object that is no longer on-chain. Critical items come
+// first, then warnings, each ordered by project name. Site objects that
+// cannot be looked up (for example when offline) are not reported.
+func (m *Manager) GetProjectsNeedingAttention(within time.Duration) ([]AttentionItem, error) {
+	return m.projectsNeedingAttention(time.Now(), within)
+}
+
+func (m *Manager) projectsNeedingAttention(now time.Time, within time.Duration) ([]AttentionItem, error) {
+	list, err := m.ListProjectsWithFilter(ProjectFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	decisions, err := PlanExpiryActions(m, now, within, false)
+	if err != nil {
+		return nil, err
+	}
+	expiry := make(map[int64]ExpiryDecision, len(decisions))
+	for _, d := range decisions {
+		expiry[d.Project.ID] = d
+	}
+
+	missing := missingSiteObjects(list)
+
+	var items []AttentionItem
+	for _, proj := range list {
+		if proj.Status == "archived" || proj.Status == "destroyed" {
+			continue
+		}
+
+		if d, ok := expiry[proj.ID]; ok && d.Action != ExpiryNone {
+			expiresAt := d.ExpiresAt
+			item := AttentionItem{Project: proj, ExpiresAt: &expiresAt}
+			if d.Expired() {
+				item.Reason, item.Severity = AttentionExpired, SeverityCritical
+				item.Message = fmt.Sprintf("storage expired on %s", d.ExpiresAt.Format("2006-01-02"))
+			} else {
+				item.Reason, item.Severity = AttentionExpiringSoon, SeverityWarning
+				item.Message = fmt.Sprintf("storage expires on %s", d.ExpiresAt.Format("2006-01-02"))
+			}
+			items = append(items, item)
+		}
+
+		deployments, err := m.GetProjectDeployments(proj.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(deployments) > 0 && !deployments[0].Success {
+			msg := "last deploy failed"
+			if deployments[0].Error != "" {
+				msg += ": " + deployments[0].Error
+			}
+			items = append(items, AttentionItem{Project: proj, Reason: AttentionDeployFailed, Severity: SeverityWarning, Message: msg})
+		}
+
+		if missing[proj.ID] {
+			items = append(items, AttentionItem{
+				Project:  proj,
+				Reason:   AttentionObjectMissing,
+				Severity: SeverityCritical,
+				Message:  fmt.Sprintf("site object %s not found on %s", proj.ObjectID, proj.Network),
+			})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Severity != items[j].Severity {
+			return items[i].Severity == SeverityCritical
+		}
+		return items[i].Project.Name < items[j].Project.Name
+	})
+	return items, nil
+}
+
+// missingSiteObjects looks up the site objects of the active projects in list,
+// at most attentionCheckConcurrency at a time, and returns the IDs of the
+// projects whose object is gone. Lookups that fail are not reported.
+func missingSiteObjects(list []*Project) map[int64]bool {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		missing = make(map[int64]bool)
+		sem     = make(chan struct{}, attentionCheckConcurrency)
+	)
+	for _, proj := range list {
+		if proj.Status != "active" || proj.ObjectID == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(proj *Project) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), attentionCheckTimeout)
+			defer cancel()
+			if exists, err := siteObjectExists(ctx, proj.Network, proj.ObjectID); err == nil && !exists {
+				mu.Lock()
+				missing[proj.ID] = true
+				mu.Unlock()
+			}
+		}(proj)
+	}
+	wg.Wait()
+	return missing
+}
@@ -0,0 +1,153 @@
+package projects
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// seedAttentionProject creates a testnet project whose deploys are recorded
+// at the given times, backdated, with the given outcome.
+func seedAttentionProject(t *testing.T, m *Manager, name string, epochs int, deploys ...*DeploymentRecord) *Project {
+	t.Helper()
+	p := &Project{Name: name, Network: "testnet", ObjectID: "0x" + name, Epochs: epochs, SitePath: t.TempDir()}
+	if err := m.CreateProject(p); err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range deploys {
+		at := d.CreatedAt
+		d.ProjectID, d.Network, d.Epochs = p.ID, "testnet", epochs
+		if d.ObjectID == "" {
+			d.ObjectID = p.ObjectID
+		}
+		if err := m.RecordDeployment(d); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := m.db.Exec("UPDATE deployments SET created_at = ? WHERE id = ?", at, d.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return p
+}
+
+func TestProjectsNeedingAttention(t *testing.T) {
+	m := setupTestManager(t)
+	defer m.Close()
+
+	now := time.Now()
+	seedAttentionProject(t, m, "healthy", 30, &DeploymentRecord{Success: true, CreatedAt: now.Add(-time.Hour)})
+	seedAttentionProject(t, m, "soon", 2, &DeploymentRecord{Success: true, CreatedAt: now.Add(-36 * time.Hour)})
+	seedAttentionProject(t, m, "expired", 1, &DeploymentRecord{Success: true, CreatedAt: now.Add(-72 * time.Hour)})
+	seedAttentionProject(t, m, "failing", 30,
+		&DeploymentRecord{Success: true, CreatedAt: now.Add(-48 * time.Hour)},
+		&DeploymentRecord{Success: false, Error: "insufficient funds", CreatedAt: now.Add(-time.Hour)})
+	seedAttentionProject(t, m, "recovered", 30,
+		&DeploymentRecord{Success: false, Error: "timeout", CreatedAt: now.Add(-48 * time.Hour)},
+		&DeploymentRecord{Success: true, CreatedAt: now.Add(-time.Hour)})
+	seedAttentionProject(t, m, "missing", 30, &DeploymentRecord{Success: true, CreatedAt: now.Add(-time.Hour)})
+	seedAttentionProject(t, m, "offline", 30, &DeploymentRecord{Success: true, CreatedAt: now.Add(-time.Hour)})
+	archived := seedAttentionProject(t, m, "archived", 1, &DeploymentRecord{Success: false, CreatedAt: now.Add(-72 * time.Hour)})
+	if err := m.ArchiveProject(archived.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	orig := siteObjectExists
+	t.Cleanup(func() { siteObjectExists = orig })
+	siteObjectExists = func(_ context.Context, network, objectID string) (bool, error) {
+		switch objectID {
+		case "0xmissing":
+			return false, nil
+		case "0xoffline":
+			return false, errors.New("RPC request failed: no such host")
+		}
+		return true, nil
+	}
+
+	items, err := m.projectsNeedingAttention(now, 3*24*time.Hour)
+	if err != nil {
+		t.Fatalf("projectsNeedingAttention() error = %v", err)
+	}
+
+	type key struct {
+		name   string
+		reason AttentionReason
+	}
+	want := []struct {
+		key
+		severity AttentionSeverity
+	}{
+		{key{"expired", AttentionExpired}, SeverityCritical},
+		{key{"missing", AttentionObjectMissing}, SeverityCritical},
+		{key{"failing", AttentionDeployFailed}, SeverityWarning},
+		{key{"soon", AttentionExpiringSoon}, SeverityWarning},
+	}
+	if len(items) != len(want) {
+		for _, item := range items {
+			t.Logf("%s: %s (%s) %s", item.Project.Name, item.Reason, item.Severity, item.Message)
+		}
+		t.Fatalf("got %d items, want %d", len(items), len(want))
+	}
+	for i, w := range want {
+		item := items[i]
+		if item.Project.Name != w.name || item.Reason != w.reason || item.Severity != w.severity {
+			t.Errorf("item %d = %s/%s/%s, want %s/%s/%s", i, item.Project.Name, item.Reason, item.Severity, w.name, w.reason, w.severity)
+		}
+	}
+
+	if items[0].ExpiresAt == nil || !items[0].ExpiresAt.Before(now) {
+		t.Errorf("expired item ExpiresAt = %v, want a time in the past", items[0].ExpiresAt)
+	}
+	if items[2].Message != "last deploy failed: insufficient funds" {
+		t.Errorf("failed deploy message = %q", items[2].Message)
+	}
+}
+
+func TestGetProjectsNeedingAttentionEmpty(t *testing.T) {
+	m := setupTestManager(t)
+	defer m.Close()
+
+	items, err := m.GetProjectsNeedingAttention(7 * 24 * time.Hour)
+	if err != nil {
+		t.Fatalf("GetProjectsNeedingAttention() error = %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("got %d items for an empty database", len(items))
+	}
+}
+
+func TestMissingSiteObjectsBoundsConcurrency(t *testing.T) {
+	var (
+		mu              sync.Mutex
+		inFlight, maxIn int
+	)
+	orig := siteObjectExists
+	t.Cleanup(func() { siteObjectExists = orig })
+	siteObjectExists = func(_ context.Context, _, objectID string) (bool, error) {
+		mu.Lock()
+		inFlight++
+		maxIn = max(maxIn, inFlight)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return objectID != "0xgone", nil
+	}
+
+	var list []*Project
+	for i := int64(1); i <= 3*attentionCheckConcurrency; i++ {
+		list = append(list, &Project{ID: i, Status: "active", ObjectID: "0xlive"})
+	}
+	list[4].ObjectID = "0xgone"
+	list = append(list, &Project{ID: 100, Status: "archived", ObjectID: "0xgone"})
+
+	missing := missingSiteObjects(list)
+	if len(missing) != 1 || !missing[list[4].ID] {
+		t.Errorf("missing = %v, want only project %d", missing, list[4].ID)
+	}
+	if maxIn > attentionCheckConcurrency {
+		t.Errorf("%d lookups in flight, want at most %d", maxIn, attentionCheckConcurrency)
+	}
+}
@@ -0,0 +1,109 @@
+package projects
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/selimozten/walgo/internal/config"
+)
+
+// CadenceSafetyMargin is how many typical update gaps auto-picked epochs
+// cover, so a site survives one skipped or late update.
+const CadenceSafetyMargin = 2.0
+
+// EpochSuggestion is an epoch count picked from a project's deploy history.
+type EpochSuggestion struct {
+	Epochs  int           // Suggested number of epochs
+	Cadence time.Duration // Median gap between successful deploys
+	Deploys int           // Successful deploys the cadence is based on
+	Capped  bool          // Epochs was limited to the network maximum
+	Reason  string        // Human-readable explanation of the choice
+}
+
+// EpochLength returns the approximate wall-clock length of one epoch.
+func EpochLength(network string) time.Duration {
+	return config.EpochLength(network)
+}
+
+// EstimateUpdateCadence returns the median gap between consecutive
+// timestamps. It returns false when fewer than two timestamps are given or
+// all of them are identical.
+func EstimateUpdateCadence(times []time.Time) (time.Duration, bool) {
+	if len(times) < 2 {
+		return 0, false
+	}
+
+	sorted := make([]time.Time, len(times))
+	copy(sorted, times)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	gaps := make([]time.Duration, 0, len(sorted)-1)
+	for i := 1; i < len(sorted); i++ {
+		if gap := sorted[i].Sub(sorted[i-1]); gap > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	if len(gaps) == 0 {
+		return 0, false
+	}
+
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	mid := len(gaps) / 2
+	if len(gaps)%2 == 0 {
+		return (gaps[mid-1] + gaps[mid]) / 2, true
+	}
+	return gaps[mid], true
+}
+
+// SuggestEpochs picks enough epochs on network to cover CadenceSafetyMargin
+// typical gaps between the successful deployments. It returns false when the
+// history has fewer than two successful deployments to learn from.
+func SuggestEpochs(deployments []*DeploymentRecord, network string) (EpochSuggestion, bool) {
+	var times []time.Time
+	for _, d := range deployments {
+		if d.Success {
+			times = append(times, d.CreatedAt)
+		}
+	}
+
+	cadence, ok := EstimateUpdateCadence(times)
+	if !ok {
+		return EpochSuggestion{}, false
+	}
+
+	cover := time.Duration(float64(cadence) * CadenceSafetyMargin)
+	epochLength := EpochLength(network)
+	epochs := int(math.Ceil(float64(cover) / float64(epochLength)))
+	if epochs < 1 {
+		epochs = 1
+	}
+
+	suggestion := EpochSuggestion{Epochs: epochs, Cadence: cadence, Deploys: len(times)}
+	if maxEpochs := GetNetworkConfig(network).MaxEpochs; epochs > maxEpochs {
+		suggestion.Epochs = maxEpochs
+		suggestion.Capped = true
+	}
+
+	suggestion.Reason = fmt.Sprintf("%d deploys, typically %s apart; %d epochs (%s) cover %.0fx that gap",
+		suggestion.Deploys, formatCadence(cadence), suggestion.Epochs,
+		CalculateStorageDuration(suggestion.Epochs, network), CadenceSafetyMargin)
+	if suggestion.Capped {
+		suggestion.Reason += fmt.Sprintf(", capped at the network maximum of %d", suggestion.Epochs)
+	}
+
+	return suggestion, true
+}
+
+// formatCadence renders a gap in hours below two days and in days above.
+func formatCadence(d time.Duration) string {
+	if d < 48*time.Hour {
+		hours := int(math.Round(d.Hours()))
+		if hours <= 1 {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", hours)
+	}
+	return fmt.Sprintf("%d days", int(math.Round(d.Hours()/24)))
+}
@@ -0,0 +1,109 @@
+package projects
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// deploysEvery returns n successful deployments spaced gap apart, newest first
+// like GetProjectDeployments.
+func deploysEvery(n int, gap time.Duration) []*DeploymentRecord {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := make([]*DeploymentRecord, n)
+	for i := 0; i < n; i++ {
+		records[n-1-i] = &DeploymentRecord{Success: true, CreatedAt: start.Add(time.Duration(i) * gap)}
+	}
+	return records
+}
+
+func TestEstimateUpdateCadence(t *testing.T) {
+	day := 24 * time.Hour
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(days ...int) []time.Time {
+		times := make([]time.Time, len(days))
+		for i, d := range days {
+			times[i] = base.Add(time.Duration(d) * day)
+		}
+		return times
+	}
+
+	tests := []struct {
+		name   string
+		times  []time.Time
+		want   time.Duration
+		wantOK bool
+	}{
+		{"no deploys", nil, 0, false},
+		{"single deploy", at(0), 0, false},
+		{"same instant", at(3, 3), 0, false},
+		{"weekly", at(0, 7, 14, 21), 7 * day, true},
+		{"unsorted input", at(14, 0, 21, 7), 7 * day, true},
+		{"one long pause", at(0, 7, 14, 60, 67), 7 * day, true},
+		{"even number of gaps", at(0, 2, 6), 3 * day, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := EstimateUpdateCadence(tt.times)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("EstimateUpdateCadence() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSuggestEpochs(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name       string
+		records    []*DeploymentRecord
+		network    string
+		wantEpochs int
+		wantCapped bool
+	}{
+		{"weekly on testnet", deploysEvery(4, 7*day), "testnet", 14, false},
+		{"weekly on mainnet", deploysEvery(4, 7*day), "mainnet", 1, false},
+		{"monthly on mainnet", deploysEvery(3, 30*day), "mainnet", 5, false},
+		{"hourly rounds up to one epoch", deploysEvery(5, time.Hour), "testnet", 1, false},
+		{"yearly on testnet is capped", deploysEvery(3, 365*day), "testnet", 53, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := SuggestEpochs(tt.records, tt.network)
+			if !ok {
+				t.Fatal("SuggestEpochs() returned no suggestion")
+			}
+			if got.Epochs != tt.wantEpochs || got.Capped != tt.wantCapped {
+				t.Errorf("SuggestEpochs() = %d epochs (capped %v), want %d (capped %v)", got.Epochs, got.Capped, tt.wantEpochs, tt.wantCapped)
+			}
+			if got.Reason == "" {
+				t.Error("Reason should explain the choice")
+			}
+		})
+	}
+}
+
+func TestSuggestEpochsIgnoresFailedDeploys(t *testing.T) {
+	records := deploysEvery(3, 7*24*time.Hour)
+	records[0].Success = false
+	records[1].Success = false
+
+	if _, ok := SuggestEpochs(records, "testnet"); ok {
+		t.Error("one successful deploy should not produce a suggestion")
+	}
+	if _, ok := SuggestEpochs(nil, "testnet"); ok {
+		t.Error("empty history should not produce a suggestion")
+	}
+}
+
+func TestSuggestEpochsReason(t *testing.T) {
+	got, _ := SuggestEpochs(deploysEvery(4, 7*24*time.Hour), "testnet")
+	for _, want := range []string{"4 deploys", "7 days apart", "14 epochs", "2x"} {
+		if !strings.Contains(got.Reason, want) {
+			t.Errorf("Reason %q missing %q", got.Reason, want)
+		}
+	}
+}
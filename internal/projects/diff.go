@@ -0,0 +1,115 @@
+package projects
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldDiff is a field whose value differs between two projects.
+type FieldDiff struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// DiffProjects compares the configuration of projects a and b and their
+// latest deployments (nil when a project was never deployed). Only differing
+// fields are returned, in a fixed order. IDs and bookkeeping timestamps are
+// skipped since they always differ. Metadata is compared key by key, the
+// site size is read from each project's publish directory when it has been
+// built, and the deployed file count is taken from FileToBlobID when it is
+// loaded.
+func DiffProjects(a, b *Project, latestA, latestB *DeploymentRecord) []FieldDiff {
+	var diffs []FieldDiff
+	add := func(field, va, vb string) {
+		if va != vb {
+			diffs = append(diffs, FieldDiff{Field: field, A: va, B: vb})
+		}
+	}
+
+	add("name", a.Name, b.Name)
+	add("category", a.Category, b.Category)
+	add("network", a.Network, b.Network)
+	add("status", a.Status, b.Status)
+	add("object_id", a.ObjectID, b.ObjectID)
+	add("suins", a.SuiNS, b.SuiNS)
+	add("wallet_addr", a.WalletAddr, b.WalletAddr)
+	add("epochs", strconv.Itoa(a.Epochs), strconv.Itoa(b.Epochs))
+	add("gas_fee", a.GasFee, b.GasFee)
+	add("site_path", a.SitePath, b.SitePath)
+	add("description", a.Description, b.Description)
+	add("image_url", a.ImageURL, b.ImageURL)
+	add("tags", strings.Join(a.Tags, ", "), strings.Join(b.Tags, ", "))
+	add("deletable", strconv.FormatBool(a.Deletable), strconv.FormatBool(b.Deletable))
+	for _, key := range metadataKeys(a.Metadata, b.Metadata) {
+		add("metadata."+key, a.Metadata[key], b.Metadata[key])
+	}
+	add("site_size_bytes", siteSizeDiffValue(a), siteSizeDiffValue(b))
+	add("deploy_count", strconv.Itoa(a.DeployCount), strconv.Itoa(b.DeployCount))
+	add("last_deploy_at", formatDiffTime(a.LastDeployAt), formatDiffTime(b.LastDeployAt))
+
+	fa, fb := deploymentDiffFields(latestA), deploymentDiffFields(latestB)
+	for _, field := range deploymentDiffOrder {
+		add("latest_deployment."+field, fa[field], fb[field])
+	}
+
+	return diffs
+}
+
+// metadataKeys returns the keys of a and b, sorted.
+func metadataKeys(a, b map[string]string) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// siteSizeDiffValue renders the size of p's built site, or "" when unknown.
+func siteSizeDiffValue(p *Project) string {
+	size, ok := p.SiteSize()
+	if !ok {
+		return ""
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+// deploymentDiffOrder is the order deployment fields are compared in.
+var deploymentDiffOrder = []string{"object_id", "network", "epochs", "gas_fee", "version", "success", "error", "files", "created_at"}
+
+// deploymentDiffFields flattens d for comparison; a nil record yields no values.
+func deploymentDiffFields(d *DeploymentRecord) map[string]string {
+	if d == nil {
+		return map[string]string{}
+	}
+	fields := map[string]string{
+		"object_id":  d.ObjectID,
+		"network":    d.Network,
+		"epochs":     strconv.Itoa(d.Epochs),
+		"gas_fee":    d.GasFee,
+		"version":    d.Version,
+		"success":    strconv.FormatBool(d.Success),
+		"error":      d.Error,
+		"created_at": formatDiffTime(d.CreatedAt),
+	}
+	if len(d.FileToBlobID) > 0 {
+		fields["files"] = strconv.Itoa(len(d.FileToBlobID))
+	}
+	return fields
+}
+
+// formatDiffTime renders t to the minute, or "" for the zero time.
+func formatDiffTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04")
+}
@@ -0,0 +1,149 @@
+package projects
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDiffProjects(t *testing.T) {
+	deployed := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	a := &Project{
+		ID: 1, Name: "blog", Category: "blog", Network: "testnet", ObjectID: "0xa",
+		WalletAddr: "0xwallet", Epochs: 5, SitePath: "/sites/blog", Status: "active",
+		Description: "My blog", Tags: []string{"personal"}, DeployCount: 3, LastDeployAt: deployed,
+		CreatedAt: deployed.Add(-time.Hour),
+	}
+	b := &Project{
+		ID: 2, Name: "blog-fork", Category: "blog", Network: "mainnet", ObjectID: "0xb",
+		WalletAddr: "0xwallet", Epochs: 5, SitePath: "/sites/blog-fork", Status: "active",
+		Description: "My blog", Tags: []string{"personal", "fork"}, DeployCount: 3, LastDeployAt: deployed,
+		CreatedAt: deployed,
+	}
+	latestA := &DeploymentRecord{
+		ObjectID: "0xa", Network: "testnet", Epochs: 5, GasFee: "0.1 SUI", Success: true, CreatedAt: deployed,
+		FileToBlobID: map[string]string{"/index.html": "b1", "/style.css": "b2"},
+	}
+	latestB := &DeploymentRecord{
+		ObjectID: "0xb", Network: "mainnet", Epochs: 5, GasFee: "0.1 SUI", Success: true, CreatedAt: deployed,
+		FileToBlobID: map[string]string{"/index.html": "b1", "/style.css": "b2", "/about.html": "b3"},
+	}
+
+	diffs := DiffProjects(a, b, latestA, latestB)
+
+	want := map[string][2]string{
+		"name":                        {"blog", "blog-fork"},
+		"network":                     {"testnet", "mainnet"},
+		"object_id":                   {"0xa", "0xb"},
+		"site_path":                   {"/sites/blog", "/sites/blog-fork"},
+		"tags":                        {"personal", "personal, fork"},
+		"latest_deployment.object_id": {"0xa", "0xb"},
+		"latest_deployment.network":   {"testnet", "mainnet"},
+		"latest_deployment.files":     {"2", "3"},
+	}
+	if len(diffs) != len(want) {
+		t.Errorf("got %d differences, want %d: %+v", len(diffs), len(want), diffs)
+	}
+	for _, d := range diffs {
+		w, ok := want[d.Field]
+		if !ok {
+			t.Errorf("unexpected difference %q: %q vs %q", d.Field, d.A, d.B)
+			continue
+		}
+		if d.A != w[0] || d.B != w[1] {
+			t.Errorf("%s = %q vs %q, want %q vs %q", d.Field, d.A, d.B, w[0], w[1])
+		}
+	}
+}
+
+// writeBuiltSite creates a site whose publish directory holds files of the
+// given sizes and returns its path.
+func writeBuiltSite(t *testing.T, sizes ...int) string {
+	t.Helper()
+	sitePath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(sitePath, "walgo.yaml"), []byte("hugo:\n  publishDir: dist\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(sitePath, "dist", "css"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i, size := range sizes {
+		name := filepath.Join(sitePath, "dist", "css", fmt.Sprintf("f%d.css", i))
+		if err := os.WriteFile(name, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return sitePath
+}
+
+func TestDiffProjects_MetadataAndSize(t *testing.T) {
+	a := &Project{
+		Name: "site", Network: "testnet", SitePath: writeBuiltSite(t, 100, 50),
+		Metadata: map[string]string{"cadence": "weekly", "owner": "ops"},
+	}
+	b := &Project{
+		Name: "site", Network: "testnet", SitePath: writeBuiltSite(t, 100, 75), Deletable: true,
+		Metadata: map[string]string{"cadence": "monthly", "owner": "ops", "client": "acme"},
+	}
+
+	diffs := DiffProjects(a, b, nil, nil)
+
+	want := []FieldDiff{
+		{Field: "site_path", A: a.SitePath, B: b.SitePath},
+		{Field: "deletable", A: "false", B: "true"},
+		{Field: "metadata.cadence", A: "weekly", B: "monthly"},
+		{Field: "metadata.client", A: "", B: "acme"},
+		{Field: "site_size_bytes", A: "150", B: "175"},
+	}
+	if len(diffs) != len(want) {
+		t.Fatalf("got %+v, want %+v", diffs, want)
+	}
+	for i := range want {
+		if diffs[i] != want[i] {
+			t.Errorf("difference %d = %+v, want %+v", i, diffs[i], want[i])
+		}
+	}
+}
+
+func TestDiffProjects_SameSize(t *testing.T) {
+	a := &Project{Name: "site", SitePath: writeBuiltSite(t, 10, 20)}
+	b := &Project{Name: "site", SitePath: writeBuiltSite(t, 20, 10)}
+	for _, d := range DiffProjects(a, b, nil, nil) {
+		if d.Field == "site_size_bytes" {
+			t.Errorf("equal sizes should not be listed: %+v", d)
+		}
+	}
+
+	// An unbuilt site has no size to compare
+	unbuilt := &Project{Name: "site", SitePath: t.TempDir()}
+	if got := siteSizeDiffValue(unbuilt); got != "" {
+		t.Errorf("siteSizeDiffValue(unbuilt) = %q, want empty", got)
+	}
+}
+
+func TestDiffProjects_Identical(t *testing.T) {
+	p := &Project{Name: "same", Network: "testnet", Epochs: 1}
+	d := &DeploymentRecord{Network: "testnet", Epochs: 1, Success: true}
+	if diffs := DiffProjects(p, p, d, d); len(diffs) != 0 {
+		t.Errorf("identical projects should not differ: %+v", diffs)
+	}
+}
+
+func TestDiffProjects_NeverDeployed(t *testing.T) {
+	p := &Project{Name: "same", Network: "testnet"}
+	d := &DeploymentRecord{Network: "testnet", Epochs: 2, Success: true}
+
+	diffs := DiffProjects(p, p, d, nil)
+	got := make(map[string]FieldDiff)
+	for _, diff := range diffs {
+		got[diff.Field] = diff
+	}
+	if got["latest_deployment.epochs"].B != "" || got["latest_deployment.epochs"].A != "2" {
+		t.Errorf("epochs diff = %+v, want 2 vs empty", got["latest_deployment.epochs"])
+	}
+	if _, ok := got["name"]; ok {
+		t.Error("equal project fields should not be listed")
+	}
+}
@@ -0,0 +1,23 @@
+package projects
+
+import "github.com/selimozten/walgo/internal/sui"
+
+// OrphanSiteObjects returns the sites that no project in known refers to,
+// in their original order. Object IDs are compared ignoring case and zero
+// padding, since the CLI and site-builder format them differently.
+func OrphanSiteObjects(sites []sui.SiteObjectSummary, known []*Project) []sui.SiteObjectSummary {
+	tracked := make(map[string]bool, len(known))
+	for _, p := range known {
+		if p.ObjectID != "" {
+			tracked[sui.NormalizeAddress(p.ObjectID)] = true
+		}
+	}
+
+	var orphans []sui.SiteObjectSummary
+	for _, site := range sites {
+		if !tracked[sui.NormalizeAddress(site.ObjectID)] {
+			orphans = append(orphans, site)
+		}
+	}
+	return orphans
+}
@@ -0,0 +1,33 @@
+package projects
+
+import (
+	"testing"
+
+	"github.com/selimozten/walgo/internal/sui"
+)
+
+func TestOrphanSiteObjects(t *testing.T) {
+	sites := []sui.SiteObjectSummary{
+		{ObjectID: "0x00aa", Name: "tracked"},
+		{ObjectID: "0xbb", Name: "orphan"},
+		{ObjectID: "0xCC", Name: "tracked upper case"},
+		{ObjectID: "0xdd", Name: "second orphan"},
+	}
+	known := []*Project{
+		{Name: "a", ObjectID: "0xAA"},
+		{Name: "c", ObjectID: "0x0000cc"},
+		{Name: "draft"}, // not deployed yet
+	}
+
+	orphans := OrphanSiteObjects(sites, known)
+	if len(orphans) != 2 || orphans[0].ObjectID != "0xbb" || orphans[1].ObjectID != "0xdd" {
+		t.Errorf("OrphanSiteObjects() = %+v, want 0xbb and 0xdd in order", orphans)
+	}
+
+	if got := OrphanSiteObjects(sites, nil); len(got) != len(sites) {
+		t.Errorf("with no projects every site is an orphan, got %+v", got)
+	}
+	if got := OrphanSiteObjects(nil, known); len(got) != 0 {
+		t.Errorf("with no sites there are no orphans, got %+v", got)
+	}
+}
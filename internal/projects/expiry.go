@@ -0,0 +1,97 @@
+package projects
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ExpirySource is the part of Manager an expiry check reads; tests pass a fake.
+type ExpirySource interface {
+	ListProjectsWithFilter(filter ProjectFilter) ([]*Project, error)
+	GetEpochInfo(projectID int64) (*EpochInfo, error)
+}
+
+// ExpiryAction is what an expiry check decided to do about a project.
+type ExpiryAction string
+
+const (
+	ExpiryNone   ExpiryAction = "none"   // Storage lasts beyond the threshold
+	ExpiryNotify ExpiryAction = "notify" // Expiring soon, or already expired
+	ExpiryRenew  ExpiryAction = "renew"  // Expiring soon and auto-renew is on
+)
+
+// ExpiryDecision is the outcome of an expiry check for one project.
+type ExpiryDecision struct {
+	Project   *Project
+	ExpiresAt time.Time
+	Remaining time.Duration // Negative once the storage has expired
+	Action    ExpiryAction
+}
+
+// Expired reports whether the project's storage had already expired.
+func (d ExpiryDecision) Expired() bool {
+	return d.Remaining <= 0
+}
+
+// StorageExpiry returns when a project's storage expires: the epochs of all
+// successful deploys counted from the first one, or the project's own epochs
+// counted from its last deploy when there is no deploy history. It returns
+// false when neither is known.
+func StorageExpiry(proj *Project, info *EpochInfo) (time.Time, bool) {
+	if info != nil && info.TotalEpochs > 0 && !info.FirstDeploymentAt.IsZero() {
+		return info.FirstDeploymentAt.Add(time.Duration(info.TotalEpochs) * EpochLength(proj.Network)), true
+	}
+	if proj.Epochs > 0 && !proj.LastDeployAt.IsZero() {
+		return proj.LastDeployAt.Add(time.Duration(proj.Epochs) * EpochLength(proj.Network)), true
+	}
+	return time.Time{}, false
+}
+
+// PlanExpiryActions checks every active, deployed project and decides what to
+// do about it at now. Projects expiring within threshold are renewed when
+// autoRenew is set and notified otherwise; expired projects are only
+// notified, since their blobs can no longer be extended. Projects with an
+// unknown expiry are skipped. Decisions are sorted by expiry, soonest first.
+func PlanExpiryActions(src ExpirySource, now time.Time, threshold time.Duration, autoRenew bool) ([]ExpiryDecision, error) {
+	list, err := src.ListProjectsWithFilter(ProjectFilter{Status: "active"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	var decisions []ExpiryDecision
+	for _, proj := range list {
+		if proj.ObjectID == "" {
+			continue
+		}
+		info, err := src.GetEpochInfo(proj.ID)
+		if err != nil {
+			info = nil
+		}
+		expiresAt, ok := StorageExpiry(proj, info)
+		if !ok {
+			continue
+		}
+
+		d := ExpiryDecision{
+			Project:   proj,
+			ExpiresAt: expiresAt,
+			Remaining: expiresAt.Sub(now),
+			Action:    ExpiryNone,
+		}
+		switch {
+		case d.Expired():
+			d.Action = ExpiryNotify
+		case d.Remaining <= threshold && autoRenew:
+			d.Action = ExpiryRenew
+		case d.Remaining <= threshold:
+			d.Action = ExpiryNotify
+		}
+		decisions = append(decisions, d)
+	}
+
+	sort.SliceStable(decisions, func(i, j int) bool {
+		return decisions[i].ExpiresAt.Before(decisions[j].ExpiresAt)
+	})
+	return decisions, nil
+}
@@ -0,0 +1,136 @@
+package projects
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeExpirySource serves projects and epoch info from memory.
+type fakeExpirySource struct {
+	projects []*Project
+	epochs   map[int64]*EpochInfo
+	listErr  error
+	filter   ProjectFilter
+}
+
+func (f *fakeExpirySource) ListProjectsWithFilter(filter ProjectFilter) ([]*Project, error) {
+	f.filter = filter
+	return f.projects, f.listErr
+}
+
+func (f *fakeExpirySource) GetEpochInfo(projectID int64) (*EpochInfo, error) {
+	if info, ok := f.epochs[projectID]; ok {
+		return info, nil
+	}
+	return nil, errors.New("no deployments")
+}
+
+func TestStorageExpiry(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		proj *Project
+		info *EpochInfo
+		want time.Time
+		ok   bool
+	}{
+		{
+			name: "deploy history on mainnet",
+			proj: &Project{Network: "mainnet", Epochs: 1, LastDeployAt: start.Add(48 * time.Hour)},
+			info: &EpochInfo{TotalEpochs: 3, FirstDeploymentAt: start},
+			want: start.Add(42 * 24 * time.Hour),
+			ok:   true,
+		},
+		{
+			name: "falls back to last deploy",
+			proj: &Project{Network: "testnet", Epochs: 5, LastDeployAt: start},
+			info: &EpochInfo{},
+			want: start.Add(5 * 24 * time.Hour),
+			ok:   true,
+		},
+		{
+			name: "unknown",
+			proj: &Project{Network: "testnet"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := StorageExpiry(tt.proj, tt.info)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("StorageExpiry() = %v, %v; want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPlanExpiryActions(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	// Testnet epochs are one day, so a site deployed for N epochs at now-X
+	// expires N days after that.
+	src := &fakeExpirySource{
+		projects: []*Project{
+			{ID: 1, Name: "healthy", ObjectID: "0x1", Network: "testnet"},
+			{ID: 2, Name: "soon", ObjectID: "0x2", Network: "testnet"},
+			{ID: 3, Name: "expired", ObjectID: "0x3", Network: "testnet"},
+			{ID: 4, Name: "draft", Network: "testnet"},
+			{ID: 5, Name: "no-history", ObjectID: "0x5", Network: "testnet"},
+			{ID: 6, Name: "soonest", ObjectID: "0x6", Network: "testnet", Epochs: 2, LastDeployAt: now.Add(-47 * time.Hour)},
+		},
+		epochs: map[int64]*EpochInfo{
+			1: {TotalEpochs: 30, FirstDeploymentAt: now.Add(-10 * day)},
+			2: {TotalEpochs: 5, FirstDeploymentAt: now.Add(-3 * day)},
+			3: {TotalEpochs: 2, FirstDeploymentAt: now.Add(-3 * day)},
+			4: {TotalEpochs: 5, FirstDeploymentAt: now},
+		},
+	}
+
+	for _, autoRenew := range []bool{false, true} {
+		decisions, err := PlanExpiryActions(src, now, 3*day, autoRenew)
+		if err != nil {
+			t.Fatalf("PlanExpiryActions() error = %v", err)
+		}
+		if src.filter.Status != "active" {
+			t.Errorf("listed projects with status %q, want active only", src.filter.Status)
+		}
+
+		soonAction := ExpiryNotify
+		if autoRenew {
+			soonAction = ExpiryRenew
+		}
+		want := []struct {
+			name      string
+			action    ExpiryAction
+			remaining time.Duration
+		}{
+			{"expired", ExpiryNotify, -day},
+			{"soonest", soonAction, time.Hour},
+			{"soon", soonAction, 2 * day},
+			{"healthy", ExpiryNone, 20 * day},
+		}
+		if len(decisions) != len(want) {
+			t.Fatalf("autoRenew=%v: got %d decisions, want %d", autoRenew, len(decisions), len(want))
+		}
+		for i, w := range want {
+			d := decisions[i]
+			if d.Project.Name != w.name || d.Action != w.action || d.Remaining != w.remaining {
+				t.Errorf("autoRenew=%v: decision %d = %s/%s/%v, want %s/%s/%v",
+					autoRenew, i, d.Project.Name, d.Action, d.Remaining, w.name, w.action, w.remaining)
+			}
+		}
+		if !decisions[0].Expired() || decisions[1].Expired() {
+			t.Errorf("Expired() wrong for %s or %s", decisions[0].Project.Name, decisions[1].Project.Name)
+		}
+	}
+}
+
+func TestPlanExpiryActionsListError(t *testing.T) {
+	src := &fakeExpirySource{listErr: errors.New("database is locked")}
+	if _, err := PlanExpiryActions(src, time.Now(), time.Hour, false); err == nil {
+		t.Fatal("expected error when listing fails")
+	}
+}
@@ -0,0 +1,217 @@
+package projects
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/config"
+)
+
+// DefaultImportDepth is how many directory levels below the root
+// FindWalgoSites looks by default.
+const DefaultImportDepth = 4
+
+// importSkipDirs are never searched for walgo sites.
+var importSkipDirs = map[string]bool{
+	"node_modules": true,
+	".git":         true,
+}
+
+// ImportOutcome says what importing a site did to the projects database.
+type ImportOutcome string
+
+const (
+	ImportAdded     ImportOutcome = "added"
+	ImportUpdated   ImportOutcome = "updated"
+	ImportUnchanged ImportOutcome = "unchanged"
+	ImportFailed    ImportOutcome = "failed"
+)
+
+// SiteImport is the result of importing one site folder.
+type SiteImport struct {
+	SitePath string
+	Outcome  ImportOutcome
+	Project  *Project // nil when the import failed
+	Err      error
+}
+
+// FindWalgoSites returns the directories under root, root included, that
+// contain a walgo.yaml, searching at most maxDepth levels below root (no
+// limit when maxDepth is negative). node_modules and .git directories are
+// skipped. Paths are absolute and sorted.
+func FindWalgoSites(root string, maxDepth int) ([]string, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", root, err)
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	var sites []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are skipped rather than ending the scan
+			if d != nil && d.IsDir() && path != root {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && importSkipDirs[d.Name()] {
+			return fs.SkipDir
+		}
+
+		if _, err := os.Stat(filepath.Join(path, config.DefaultConfigFileName)); err == nil {
+			sites = append(sites, path)
+		}
+
+		if maxDepth >= 0 && scanDepth(root, path) >= maxDepth {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	sort.Strings(sites)
+	return sites, nil
+}
+
+// scanDepth is the number of directory levels between root and path.
+func scanDepth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
+
+// ImportSite registers the walgo site at sitePath as a project, or brings
+// the project already tracking it up to date with its walgo.yaml. A project
+// tracks the site when it has the same site path or, for a site that was
+// moved, the same object ID. New projects are named after the folder.
+func (m *Manager) ImportSite(sitePath string) *SiteImport {
+	res := &SiteImport{SitePath: sitePath, Outcome: ImportFailed}
+
+	cfg, err := config.LoadConfigFrom(sitePath)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	objectID := strings.TrimSpace(cfg.WalrusConfig.ProjectID)
+	if config.IsPlaceholderProjectID(objectID) {
+		objectID = ""
+	}
+
+	project, err := m.GetProjectBySitePath(sitePath)
+	if err == nil && project == nil {
+		project, err = m.GetProjectByObjectID(objectID)
+		// A project whose own folder still exists is a copy, not a move
+		if project != nil && project.SitePath != "" && siteExists(project.SitePath) {
+			project = nil
+		}
+	}
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	if project == nil {
+		name, err := m.UniqueProjectName(filepath.Base(sitePath))
+		if err != nil {
+			res.Err = err
+			return res
+		}
+		if objectID == "" {
+			if err := m.CreateDraftProject(name, sitePath); err != nil {
+				res.Err = err
+				return res
+			}
+			project, err = m.GetProjectByName(name)
+			if err != nil {
+				res.Err = err
+				return res
+			}
+		} else {
+			project = &Project{SitePath: sitePath, Name: name, Category: "website"}
+			applySiteConfig(project, cfg, objectID)
+			if err := m.CreateProject(project); err != nil {
+				res.Err = err
+				return res
+			}
+		}
+		res.Outcome, res.Project = ImportAdded, project
+		return res
+	}
+
+	before := importedFields(project)
+	project.SitePath = sitePath
+	applySiteConfig(project, cfg, objectID)
+	res.Project = project
+	if importedFields(project) == before {
+		res.Outcome = ImportUnchanged
+		return res
+	}
+	if err := m.UpdateProject(project); err != nil {
+		res.Err = err
+		return res
+	}
+	res.Outcome = ImportUpdated
+	return res
+}
+
+// applySiteConfig copies the site settings found in walgo.yaml to project,
+// leaving fields the file does not set as they are.
+func applySiteConfig(project *Project, cfg *config.WalgoConfig, objectID string) {
+	if objectID != "" {
+		project.ObjectID = objectID
+	}
+	if network := strings.TrimSpace(cfg.WalrusConfig.Network); network != "" {
+		project.Network = network
+	}
+	if domain := strings.TrimSpace(cfg.WalrusConfig.SuiNSDomain); domain != "" {
+		project.SuiNS = domain
+	}
+	if category := strings.TrimSpace(cfg.WalrusConfig.Category); category != "" {
+		project.Category = category
+	}
+}
+
+// importedFields are the project fields ImportSite sets.
+func importedFields(p *Project) [5]string {
+	return [5]string{p.SitePath, p.ObjectID, p.Network, p.SuiNS, p.Category}
+}
+
+// siteExists reports whether sitePath still holds a walgo.yaml.
+func siteExists(sitePath string) bool {
+	_, err := os.Stat(filepath.Join(sitePath, config.DefaultConfigFileName))
+	return err == nil
+}
+
+// UniqueProjectName returns base, or base with the first free numeric suffix
+// ("blog-2", "blog-3", ...) when a live project already has that name.
+func (m *Manager) UniqueProjectName(base string) (string, error) {
+	name := base
+	for i := 2; ; i++ {
+		exists, err := m.ProjectNameExists(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to check project name: %w", err)
+		}
+		if !exists {
+			return name, nil
+		}
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+}
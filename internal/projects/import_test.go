@@ -0,0 +1,158 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// importFixture builds a monorepo with a site two levels down, another four
+// levels down, decoy walgo.yaml files inside node_modules and .git, and one
+// site deeper than DefaultImportDepth.
+func importFixture(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"sites/blog/walgo.yaml":                  "walrus:\n  projectID: \"0xb10g\"\n  network: testnet\n",
+		"apps/web/docs/site/walgo.yaml":          "walrus:\n  projectID: YOUR_WALRUS_PROJECT_ID\n",
+		"apps/web/docs/site/content/_index.md":   "# Docs",
+		"node_modules/theme-pkg/walgo.yaml":      "walrus:\n  projectID: \"0xdec0y\"\n",
+		".git/walgo.yaml":                        "walrus: {}\n",
+		"deep/a/b/c/too-deep/walgo.yaml":         "walrus: {}\n",
+		"README.md":                              "monorepo",
+		"sites/blog/node_modules/x/walgo.yaml":   "walrus: {}\n",
+		"apps/web/docs/site/public/index.html":   "<html></html>",
+		"apps/web/docs/site/themes/t/theme.toml": "name = 't'",
+	}
+	for rel, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestFindWalgoSites(t *testing.T) {
+	root := importFixture(t)
+	blog := filepath.Join(root, "sites", "blog")
+	docs := filepath.Join(root, "apps", "web", "docs", "site")
+
+	sites, err := FindWalgoSites(root, DefaultImportDepth)
+	if err != nil {
+		t.Fatalf("FindWalgoSites() error = %v", err)
+	}
+	if want := []string{docs, blog}; !reflect.DeepEqual(sites, want) {
+		t.Errorf("FindWalgoSites() = %v, want %v", sites, want)
+	}
+
+	sites, err = FindWalgoSites(root, 2)
+	if err != nil {
+		t.Fatalf("FindWalgoSites() error = %v", err)
+	}
+	if want := []string{blog}; !reflect.DeepEqual(sites, want) {
+		t.Errorf("FindWalgoSites(depth 2) = %v, want only the shallow site", sites)
+	}
+
+	sites, err = FindWalgoSites(root, -1)
+	if err != nil {
+		t.Fatalf("FindWalgoSites() error = %v", err)
+	}
+	if len(sites) != 3 {
+		t.Errorf("FindWalgoSites(no limit) = %v, want the deep site too but no decoys", sites)
+	}
+
+	if sites, err := FindWalgoSites(blog, 0); err != nil || len(sites) != 1 || sites[0] != blog {
+		t.Errorf("FindWalgoSites(site root) = %v, %v; want the root itself", sites, err)
+	}
+	if _, err := FindWalgoSites(filepath.Join(root, "README.md"), 1); err == nil {
+		t.Error("expected error for a file root")
+	}
+}
+
+func TestImportSite(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	root := importFixture(t)
+	blog := filepath.Join(root, "sites", "blog")
+	docs := filepath.Join(root, "apps", "web", "docs", "site")
+
+	res := manager.ImportSite(blog)
+	if res.Outcome != ImportAdded || res.Err != nil {
+		t.Fatalf("first import of blog = %s, %v; want added", res.Outcome, res.Err)
+	}
+	if p := res.Project; p.Name != "blog" || p.ObjectID != "0xb10g" || p.Network != "testnet" || p.Status != "active" || p.SitePath != blog {
+		t.Errorf("blog project = %+v", p)
+	}
+
+	res = manager.ImportSite(docs)
+	if res.Outcome != ImportAdded {
+		t.Fatalf("first import of docs = %s, %v; want added", res.Outcome, res.Err)
+	}
+	if p := res.Project; p.Name != "site" || p.ObjectID != "" || p.Status != "draft" {
+		t.Errorf("undeployed site should be a draft without object ID, got %+v", p)
+	}
+
+	// Importing again changes nothing
+	if res := manager.ImportSite(blog); res.Outcome != ImportUnchanged {
+		t.Errorf("re-import = %s, %v; want unchanged", res.Outcome, res.Err)
+	}
+
+	// walgo.yaml changes are picked up
+	if err := os.WriteFile(filepath.Join(blog, "walgo.yaml"), []byte("walrus:\n  projectID: \"0xb10g\"\n  network: mainnet\n  suinsDomain: blog.sui\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	res = manager.ImportSite(blog)
+	if res.Outcome != ImportUpdated {
+		t.Fatalf("import after edit = %s, %v; want updated", res.Outcome, res.Err)
+	}
+	stored, err := manager.GetProjectBySitePath(blog)
+	if err != nil || stored == nil || stored.Network != "mainnet" || stored.SuiNS != "blog.sui" {
+		t.Errorf("stored project = %+v, %v; want mainnet and blog.sui", stored, err)
+	}
+
+	// A moved site keeps its project
+	moved := filepath.Join(root, "sites", "journal")
+	if err := os.Rename(blog, moved); err != nil {
+		t.Fatal(err)
+	}
+	res = manager.ImportSite(moved)
+	if res.Outcome != ImportUpdated || res.Project.ID != stored.ID || res.Project.SitePath != moved {
+		t.Errorf("moved site = %s %+v, want project %d updated with the new path", res.Outcome, res.Project, stored.ID)
+	}
+
+	// A copy with the same object ID is a project of its own
+	if err := os.MkdirAll(blog, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(blog, "walgo.yaml"), []byte("walrus:\n  projectID: \"0xb10g\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if res := manager.ImportSite(blog); res.Outcome != ImportAdded || res.Project.ID == stored.ID {
+		t.Errorf("copied site = %s %+v, want a new project", res.Outcome, res.Project)
+	}
+
+	if res := manager.ImportSite(filepath.Join(root, "README.md")); res.Outcome != ImportFailed || res.Err == nil {
+		t.Errorf("import of a non-site = %s, %v; want failed", res.Outcome, res.Err)
+	}
+}
+
+func TestUniqueProjectName(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	for _, want := range []string{"blog", "blog-2", "blog-3"} {
+		name, err := manager.UniqueProjectName("blog")
+		if err != nil || name != want {
+			t.Fatalf("UniqueProjectName() = %q, %v; want %q", name, err, want)
+		}
+		if err := manager.CreateDraftProject(name, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -2,12 +2,15 @@ package projects
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/selimozten/walgo/internal/config"
+
 	_ "modernc.org/sqlite" // SQLite driver
 )
 
@@ -85,7 +88,13 @@ func (m *Manager) Close() error {
 // Increment this value when adding new migrations:
 // Version 1: Initial schema with projects and deployments tables
 // Version 2: Added description and image_url columns to projects table
-const schemaVersion = 2
+// Version 3: Added deployment_blobs table for per-deploy file to blob maps
+// Version 4: Added project_tags table for freeform project labels
+// Version 5: Added deleted_at column to projects table for soft deletes
+// Version 6: Added cost_wal, cost_sui and cost_actual columns to deployments table
+// Version 7: Added metadata column (JSON object) to projects table
+// Version 8: Added deletable column to projects table
+const schemaVersion = 8
 
 // initSchema creates database tables and applies pending migrations.
 func (m *Manager) initSchema() error {
@@ -118,6 +127,42 @@ func (m *Manager) initSchema() error {
 		}
 	}
 
+	if dbVersion < 3 && schemaVersion >= 3 {
+		if err := m.applyMigration3(); err != nil {
+			return fmt.Errorf("failed to apply migration 3: %w", err)
+		}
+	}
+
+	if dbVersion < 4 && schemaVersion >= 4 {
+		if err := m.applyMigration4(); err != nil {
+			return fmt.Errorf("failed to apply migration 4: %w", err)
+		}
+	}
+
+	if dbVersion < 5 && schemaVersion >= 5 {
+		if err := m.applyMigration5(); err != nil {
+			return fmt.Errorf("failed to apply migration 5: %w", err)
+		}
+	}
+
+	if dbVersion < 6 && schemaVersion >= 6 {
+		if err := m.applyMigration6(); err != nil {
+			return fmt.Errorf("failed to apply migration 6: %w", err)
+		}
+	}
+
+	if dbVersion < 7 && schemaVersion >= 7 {
+		if err := m.applyMigration7(); err != nil {
+			return fmt.Errorf("failed to apply migration 7: %w", err)
+		}
+	}
+
+	if dbVersion < 8 && schemaVersion >= 8 {
+		if err := m.applyMigration8(); err != nil {
+			return fmt.Errorf("failed to apply migration 8: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -190,8 +235,10 @@ func (m *Manager) applyMigration1() error {
 
 // allowedTables is a whitelist of table names that can be used in PRAGMA queries.
 var allowedTables = map[string]bool{
-	"projects":    true,
-	"deployments": true,
+	"projects":         true,
+	"deployments":      true,
+	"deployment_blobs": true,
+	"project_tags":     true,
 }
 
 // columnExists checks if a column exists in a table
@@ -272,6 +319,226 @@ func (m *Manager) applyMigration2() error {
 	return nil
 }
 
+// applyMigration3 adds the deployment_blobs table (version 3).
+func (m *Manager) applyMigration3() error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS deployment_blobs (
+			deployment_id INTEGER NOT NULL,
+			path TEXT NOT NULL,
+			blob_id TEXT NOT NULL,
+			PRIMARY KEY (deployment_id, path),
+			FOREIGN KEY (deployment_id) REFERENCES deployments(id)
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create deployment_blobs table: %w", err)
+	}
+
+	// Record migration version (OR IGNORE for idempotency if concurrent connections race)
+	if _, err := tx.Exec("INSERT OR IGNORE INTO schema_version (version, applied_at) VALUES (?, ?)", 3, time.Now()); err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+
+	committed = true
+	return nil
+}
+
+// applyMigration4 adds the project_tags table (version 4).
+func (m *Manager) applyMigration4() error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS project_tags (
+			project_id INTEGER NOT NULL,
+			tag TEXT NOT NULL,
+			PRIMARY KEY (project_id, tag),
+			FOREIGN KEY (project_id) REFERENCES projects(id)
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create project_tags table: %w", err)
+	}
+
+	if _, err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_project_tags_tag ON project_tags(tag)"); err != nil {
+		return fmt.Errorf("failed to create project_tags index: %w", err)
+	}
+
+	// Record migration version (OR IGNORE for idempotency if concurrent connections race)
+	if _, err := tx.Exec("INSERT OR IGNORE INTO schema_version (version, applied_at) VALUES (?, ?)", 4, time.Now()); err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+
+	committed = true
+	return nil
+}
+
+// applyMigration5 adds the deleted_at column to the projects table (version 5).
+func (m *Manager) applyMigration5() error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if !m.columnExists(tx, "projects", "deleted_at") {
+		if _, err := tx.Exec("ALTER TABLE projects ADD COLUMN deleted_at DATETIME"); err != nil {
+			return fmt.Errorf("failed to add deleted_at column: %w", err)
+		}
+	}
+
+	// Record migration version (OR IGNORE for idempotency if concurrent connections race)
+	if _, err := tx.Exec("INSERT OR IGNORE INTO schema_version (version, applied_at) VALUES (?, ?)", 5, time.Now()); err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+
+	committed = true
+	return nil
+}
+
+// applyMigration6 adds the cost columns to the deployments table (version 6).
+func (m *Manager) applyMigration6() error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	columns := []struct{ name, def string }{
+		{"cost_wal", "REAL DEFAULT 0"},
+		{"cost_sui", "REAL DEFAULT 0"},
+		{"cost_actual", "BOOLEAN DEFAULT 0"},
+	}
+	for _, c := range columns {
+		if !m.columnExists(tx, "deployments", c.name) {
+			if _, err := tx.Exec("ALTER TABLE deployments ADD COLUMN " + c.name + " " + c.def); err != nil {
+				return fmt.Errorf("failed to add %s column: %w", c.name, err)
+			}
+		}
+	}
+
+	// Record migration version (OR IGNORE for idempotency if concurrent connections race)
+	if _, err := tx.Exec("INSERT OR IGNORE INTO schema_version (version, applied_at) VALUES (?, ?)", 6, time.Now()); err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+
+	committed = true
+	return nil
+}
+
+// applyMigration7 adds the metadata column to the projects table (version 7).
+func (m *Manager) applyMigration7() error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if !m.columnExists(tx, "projects", "metadata") {
+		if _, err := tx.Exec("ALTER TABLE projects ADD COLUMN metadata TEXT DEFAULT ''"); err != nil {
+			return fmt.Errorf("failed to add metadata column: %w", err)
+		}
+	}
+
+	// Record migration version (OR IGNORE for idempotency if concurrent connections race)
+	if _, err := tx.Exec("INSERT OR IGNORE INTO schema_version (version, applied_at) VALUES (?, ?)", 7, time.Now()); err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+
+	committed = true
+	return nil
+}
+
+// applyMigration8 adds the deletable column to the projects table (version 8).
+func (m *Manager) applyMigration8() error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if !m.columnExists(tx, "projects", "deletable") {
+		if _, err := tx.Exec("ALTER TABLE projects ADD COLUMN deletable BOOLEAN DEFAULT 0"); err != nil {
+			return fmt.Errorf("failed to add deletable column: %w", err)
+		}
+	}
+
+	// Record migration version (OR IGNORE for idempotency if concurrent connections race)
+	if _, err := tx.Exec("INSERT OR IGNORE INTO schema_version (version, applied_at) VALUES (?, ?)", 8, time.Now()); err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+
+	committed = true
+	return nil
+}
+
 // CreateProject creates a new project record in the database.
 func (m *Manager) CreateProject(project *Project) error {
 	now := time.Now()
@@ -281,10 +548,15 @@ func (m *Manager) CreateProject(project *Project) error {
 	project.DeployCount = 1
 	project.Status = "active"
 
+	metadata, err := encodeMetadata(project.Metadata)
+	if err != nil {
+		return err
+	}
+
 	result, err := m.db.Exec(`
-		INSERT INTO projects (name, category, network, object_id, suins, wallet_addr, epochs, gas_fee, site_path, created_at, updated_at, last_deploy_at, deploy_count, status, description, image_url)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, project.Name, project.Category, project.Network, project.ObjectID, project.SuiNS, project.WalletAddr, project.Epochs, project.GasFee, project.SitePath, project.CreatedAt, project.UpdatedAt, project.LastDeployAt, project.DeployCount, project.Status, project.Description, project.ImageURL)
+		INSERT INTO projects (name, category, network, object_id, suins, wallet_addr, epochs, gas_fee, site_path, created_at, updated_at, last_deploy_at, deploy_count, status, description, image_url, deletable, metadata)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, project.Name, project.Category, project.Network, project.ObjectID, project.SuiNS, project.WalletAddr, project.Epochs, project.GasFee, project.SitePath, project.CreatedAt, project.UpdatedAt, project.LastDeployAt, project.DeployCount, project.Status, project.Description, project.ImageURL, project.Deletable, metadata)
 
 	if err != nil {
 		return fmt.Errorf("failed to create project: %w", err)
@@ -332,14 +604,103 @@ func (m *Manager) CreateDraftProject(name, sitePath string) error {
 	return nil
 }
 
+// CloneProject creates a new draft project that copies the category, network,
+// epochs, and site metadata of an existing project. The clone has no object ID
+// or SuiNS domain, so its first deployment creates a fresh site.
+func (m *Manager) CloneProject(sourceID int64, name, sitePath string) (*Project, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("project name cannot be empty")
+	}
+
+	source, err := m.GetProject(sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source project: %w", err)
+	}
+
+	exists, err := m.ProjectNameExists(name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("project name '%s' already exists", name)
+	}
+
+	now := time.Now()
+	clone := &Project{
+		Name:        name,
+		Category:    source.Category,
+		Network:     source.Network,
+		WalletAddr:  source.WalletAddr,
+		Epochs:      source.Epochs,
+		SitePath:    sitePath,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Status:      "draft",
+		Description: source.Description,
+		ImageURL:    source.ImageURL,
+	}
+
+	result, err := m.db.Exec(`
+		INSERT INTO projects (name, category, network, object_id, suins, wallet_addr, epochs, gas_fee, site_path, created_at, updated_at, last_deploy_at, deploy_count, status, description, image_url)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, clone.Name, clone.Category, clone.Network, clone.ObjectID, clone.SuiNS, clone.WalletAddr, clone.Epochs, clone.GasFee, clone.SitePath, clone.CreatedAt, clone.UpdatedAt, clone.LastDeployAt, clone.DeployCount, clone.Status, clone.Description, clone.ImageURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloned project: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project ID: %w", err)
+	}
+
+	clone.ID = id
+	return clone, nil
+}
+
+// projectColumns lists the projects table columns read by scanProject, in order.
+const projectColumns = "id, name, category, network, object_id, suins, wallet_addr, epochs, gas_fee, site_path, created_at, updated_at, last_deploy_at, deploy_count, status, description, image_url, deleted_at, metadata, deletable"
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanProject reads a project selected with projectColumns. When only the
+// metadata cannot be parsed, the rest of the project is returned with an
+// error wrapping errInvalidMetadata.
+func scanProject(row rowScanner) (*Project, error) {
+	project := &Project{}
+	var deletedAt sql.NullTime
+	var metadata sql.NullString
+	err := row.Scan(&project.ID, &project.Name, &project.Category, &project.Network, &project.ObjectID, &project.SuiNS, &project.WalletAddr, &project.Epochs, &project.GasFee, &project.SitePath, &project.CreatedAt, &project.UpdatedAt, &project.LastDeployAt, &project.DeployCount, &project.Status, &project.Description, &project.ImageURL, &deletedAt, &metadata, &project.Deletable)
+	if err != nil {
+		return nil, err
+	}
+	if deletedAt.Valid {
+		project.DeletedAt = &deletedAt.Time
+	}
+	if project.Metadata, err = decodeMetadata(metadata.String); err != nil {
+		return project, err
+	}
+	return project, nil
+}
+
 // GetProject retrieves a project record by its unique identifier.
+// Soft-deleted projects are not found.
 func (m *Manager) GetProject(id int64) (*Project, error) {
-	project := &Project{}
+	return m.getProject(id, false)
+}
 
-	err := m.db.QueryRow(`
-		SELECT id, name, category, network, object_id, suins, wallet_addr, epochs, gas_fee, site_path, created_at, updated_at, last_deploy_at, deploy_count, status, description, image_url
-		FROM projects WHERE id = ?
-	`, id).Scan(&project.ID, &project.Name, &project.Category, &project.Network, &project.ObjectID, &project.SuiNS, &project.WalletAddr, &project.Epochs, &project.GasFee, &project.SitePath, &project.CreatedAt, &project.UpdatedAt, &project.LastDeployAt, &project.DeployCount, &project.Status, &project.Description, &project.ImageURL)
+// getProject retrieves a project record by ID, including soft-deleted
+// projects when includeDeleted is set.
+func (m *Manager) getProject(id int64, includeDeleted bool) (*Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = ?`
+	if !includeDeleted {
+		query += ` AND deleted_at IS NULL`
+	}
+	project, err := scanProject(m.db.QueryRow(query, id))
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("project not found")
@@ -348,17 +709,19 @@ func (m *Manager) GetProject(id int64) (*Project, error) {
 		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
+	if project.Tags, err = m.GetTags(project.ID); err != nil {
+		return nil, err
+	}
+
 	return project, nil
 }
 
 // GetProjectByName retrieves the most recent project record by name.
 func (m *Manager) GetProjectByName(name string) (*Project, error) {
-	project := &Project{}
-
-	err := m.db.QueryRow(`
-		SELECT id, name, category, network, object_id, suins, wallet_addr, epochs, gas_fee, site_path, created_at, updated_at, last_deploy_at, deploy_count, status, description, image_url
-		FROM projects WHERE name = ? ORDER BY created_at DESC LIMIT 1
-	`, name).Scan(&project.ID, &project.Name, &project.Category, &project.Network, &project.ObjectID, &project.SuiNS, &project.WalletAddr, &project.Epochs, &project.GasFee, &project.SitePath, &project.CreatedAt, &project.UpdatedAt, &project.LastDeployAt, &project.DeployCount, &project.Status, &project.Description, &project.ImageURL)
+	project, err := scanProject(m.db.QueryRow(`
+		SELECT `+projectColumns+`
+		FROM projects WHERE deleted_at IS NULL AND name = ? ORDER BY created_at DESC LIMIT 1
+	`, name))
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("project not found")
@@ -367,13 +730,17 @@ func (m *Manager) GetProjectByName(name string) (*Project, error) {
 		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
+	if project.Tags, err = m.GetTags(project.ID); err != nil {
+		return nil, err
+	}
+
 	return project, nil
 }
 
-// ProjectNameExists checks if a project with the given name already exists
+// ProjectNameExists checks if a live (not soft-deleted) project with the given name exists
 func (m *Manager) ProjectNameExists(name string) (bool, error) {
 	var count int
-	err := m.db.QueryRow("SELECT COUNT(*) FROM projects WHERE name = ?", name).Scan(&count)
+	err := m.db.QueryRow("SELECT COUNT(*) FROM projects WHERE name = ? AND deleted_at IS NULL", name).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check project name: %w", err)
 	}
@@ -382,12 +749,10 @@ func (m *Manager) ProjectNameExists(name string) (bool, error) {
 
 // GetProjectBySitePath retrieves a project record by its site path.
 func (m *Manager) GetProjectBySitePath(sitePath string) (*Project, error) {
-	project := &Project{}
-
-	err := m.db.QueryRow(`
-		SELECT id, name, category, network, object_id, suins, wallet_addr, epochs, gas_fee, site_path, created_at, updated_at, last_deploy_at, deploy_count, status, description, image_url
-		FROM projects WHERE site_path = ? ORDER BY created_at DESC LIMIT 1
-	`, sitePath).Scan(&project.ID, &project.Name, &project.Category, &project.Network, &project.ObjectID, &project.SuiNS, &project.WalletAddr, &project.Epochs, &project.GasFee, &project.SitePath, &project.CreatedAt, &project.UpdatedAt, &project.LastDeployAt, &project.DeployCount, &project.Status, &project.Description, &project.ImageURL)
+	project, err := scanProject(m.db.QueryRow(`
+		SELECT `+projectColumns+`
+		FROM projects WHERE deleted_at IS NULL AND site_path = ? ORDER BY created_at DESC LIMIT 1
+	`, sitePath))
 
 	if err == sql.ErrNoRows {
 		return nil, nil // Return nil, nil if not found (not an error)
@@ -399,23 +764,99 @@ func (m *Manager) GetProjectBySitePath(sitePath string) (*Project, error) {
 	return project, nil
 }
 
+// GetProjectByObjectID retrieves the most recent project record by its site object ID.
+// Returns nil, nil if no project matches or objectID is empty or the config placeholder.
+func (m *Manager) GetProjectByObjectID(objectID string) (*Project, error) {
+	objectID = strings.TrimSpace(objectID)
+	if config.IsPlaceholderProjectID(objectID) {
+		return nil, nil
+	}
+
+	project, err := scanProject(m.db.QueryRow(`
+		SELECT `+projectColumns+`
+		FROM projects WHERE deleted_at IS NULL AND object_id = ? ORDER BY created_at DESC LIMIT 1
+	`, objectID))
+
+	if err == sql.ErrNoRows {
+		return nil, nil // Return nil, nil if not found (not an error)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project by object ID: %w", err)
+	}
+
+	return project, nil
+}
+
 // ListProjects retrieves all projects with optional network and status filters.
 func (m *Manager) ListProjects(network string, status string) ([]*Project, error) {
-	query := `SELECT id, name, category, network, object_id, suins, wallet_addr, epochs, gas_fee, site_path, created_at, updated_at, last_deploy_at, deploy_count, status, description, image_url FROM projects WHERE 1=1`
+	return m.ListProjectsWithFilter(ProjectFilter{Network: network, Status: status})
+}
+
+// ListProjectsWithFilter retrieves projects matching every non-empty field of
+// filter. A project must carry all of filter.Tags to match. Soft-deleted
+// projects are only returned when filter.Deleted is set.
+func (m *Manager) ListProjectsWithFilter(filter ProjectFilter) ([]*Project, error) {
+	where, args := projectFilterWhere(filter)
+	return m.queryProjects(`SELECT `+projectColumns+` FROM projects`+where+` ORDER BY last_deploy_at DESC`, args...)
+}
+
+// ListProjectsPage returns up to limit projects matching filter, skipping the
+// first offset, in ListProjectsWithFilter order, along with the total number
+// of matching projects. An offset past the end yields an empty page.
+func (m *Manager) ListProjectsPage(filter ProjectFilter, offset, limit int) ([]*Project, int, error) {
+	if err := validatePage(offset, limit); err != nil {
+		return nil, 0, err
+	}
+	where, args := projectFilterWhere(filter)
+
+	var total int
+	if err := m.db.QueryRow(`SELECT COUNT(*) FROM projects`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count projects: %w", err)
+	}
+
+	// id breaks ties so pages neither repeat nor skip projects
+	query := `SELECT ` + projectColumns + ` FROM projects` + where + ` ORDER BY last_deploy_at DESC, id DESC LIMIT ? OFFSET ?`
+	projects, err := m.queryProjects(query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return projects, total, nil
+}
+
+// projectFilterWhere builds the WHERE clause selecting the projects that
+// match filter.
+func projectFilterWhere(filter ProjectFilter) (string, []interface{}) {
+	where := " WHERE 1=1"
 	args := []interface{}{}
 
-	if network != "" {
-		query += " AND network = ?"
-		args = append(args, network)
+	if filter.Deleted {
+		where += " AND deleted_at IS NOT NULL"
+	} else {
+		where += " AND deleted_at IS NULL"
+	}
+
+	if filter.Network != "" {
+		where += " AND network = ?"
+		args = append(args, filter.Network)
 	}
 
-	if status != "" {
-		query += " AND status = ?"
-		args = append(args, status)
+	if filter.Status != "" {
+		where += " AND status = ?"
+		args = append(args, filter.Status)
 	}
 
-	query += " ORDER BY last_deploy_at DESC"
+	for _, tag := range filter.Tags {
+		where += " AND id IN (SELECT project_id FROM project_tags WHERE tag = ?)"
+		args = append(args, NormalizeTag(tag))
+	}
+	return where, args
+}
 
+// queryProjects runs a query selecting projectColumns and loads each
+// project's tags. Projects whose metadata cannot be parsed are kept with
+// empty Metadata and the parse error in MetadataError, so one bad row does
+// not hide the others and page totals still match.
+func (m *Manager) queryProjects(query string, args ...interface{}) ([]*Project, error) {
 	rows, err := m.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list projects: %w", err)
@@ -424,25 +865,48 @@ func (m *Manager) ListProjects(network string, status string) ([]*Project, error
 
 	var projects []*Project
 	for rows.Next() {
-		project := &Project{}
-		err := rows.Scan(&project.ID, &project.Name, &project.Category, &project.Network, &project.ObjectID, &project.SuiNS, &project.WalletAddr, &project.Epochs, &project.GasFee, &project.SitePath, &project.CreatedAt, &project.UpdatedAt, &project.LastDeployAt, &project.DeployCount, &project.Status, &project.Description, &project.ImageURL)
+		project, err := scanProject(rows)
+		if errors.Is(err, errInvalidMetadata) {
+			project.MetadataError = err.Error()
+			err = nil
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan project: %w", err)
 		}
 		projects = append(projects, project)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	for _, project := range projects {
+		if project.Tags, err = m.GetTags(project.ID); err != nil {
+			return nil, err
+		}
+	}
 
 	return projects, nil
 }
 
+// validatePage checks the offset and limit of a page request.
+func validatePage(offset, limit int) error {
+	if offset < 0 {
+		return fmt.Errorf("offset must not be negative, got %d", offset)
+	}
+	if limit < 1 {
+		return fmt.Errorf("limit must be at least 1, got %d", limit)
+	}
+	return nil
+}
+
 // UpdateProject modifies an existing project record in the database.
 func (m *Manager) UpdateProject(project *Project) error {
 	project.UpdatedAt = time.Now()
 
 	_, err := m.db.Exec(`
-		UPDATE projects SET name = ?, category = ?, network = ?, object_id = ?, suins = ?, wallet_addr = ?, epochs = ?, gas_fee = ?, site_path = ?, updated_at = ?, last_deploy_at = ?, deploy_count = ?, status = ?, description = ?, image_url = ?
+		UPDATE projects SET name = ?, category = ?, network = ?, object_id = ?, suins = ?, wallet_addr = ?, epochs = ?, gas_fee = ?, site_path = ?, updated_at = ?, last_deploy_at = ?, deploy_count = ?, status = ?, description = ?, image_url = ?, deletable = ?
 		WHERE id = ?
-	`, project.Name, project.Category, project.Network, project.ObjectID, project.SuiNS, project.WalletAddr, project.Epochs, project.GasFee, project.SitePath, project.UpdatedAt, project.LastDeployAt, project.DeployCount, project.Status, project.Description, project.ImageURL, project.ID)
+	`, project.Name, project.Category, project.Network, project.ObjectID, project.SuiNS, project.WalletAddr, project.Epochs, project.GasFee, project.SitePath, project.UpdatedAt, project.LastDeployAt, project.DeployCount, project.Status, project.Description, project.ImageURL, project.Deletable, project.ID)
 
 	if err != nil {
 		return fmt.Errorf("failed to update project: %w", err)
@@ -494,9 +958,9 @@ func (m *Manager) RecordDeployment(deployment *DeploymentRecord) error {
 	}()
 
 	result, err := tx.Exec(`
-		INSERT INTO deployments (project_id, object_id, network, epochs, gas_fee, version, notes, success, error, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, deployment.ProjectID, deployment.ObjectID, deployment.Network, deployment.Epochs, deployment.GasFee, deployment.Version, deployment.Notes, deployment.Success, deployment.Error, deployment.CreatedAt)
+		INSERT INTO deployments (project_id, object_id, network, epochs, gas_fee, version, notes, success, error, created_at, cost_wal, cost_sui, cost_actual)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, deployment.ProjectID, deployment.ObjectID, deployment.Network, deployment.Epochs, deployment.GasFee, deployment.Version, deployment.Notes, deployment.Success, deployment.Error, deployment.CreatedAt, deployment.CostWAL, deployment.CostSUI, deployment.CostActual)
 
 	if err != nil {
 		return fmt.Errorf("failed to record deployment: %w", err)
@@ -509,6 +973,16 @@ func (m *Manager) RecordDeployment(deployment *DeploymentRecord) error {
 
 	deployment.ID = id
 
+	// Persist the file to blob map so the deploy can be verified or restored later
+	for path, blobID := range deployment.FileToBlobID {
+		_, err = tx.Exec(`
+			INSERT INTO deployment_blobs (deployment_id, path, blob_id) VALUES (?, ?, ?)
+		`, id, path, blobID)
+		if err != nil {
+			return fmt.Errorf("failed to record deployment blobs: %w", err)
+		}
+	}
+
 	// Update project's last deploy time and deploy count
 	_, err = tx.Exec(`
 		UPDATE projects SET last_deploy_at = ?, deploy_count = deploy_count + 1, object_id = ? WHERE id = ?
@@ -529,7 +1003,7 @@ func (m *Manager) RecordDeployment(deployment *DeploymentRecord) error {
 // GetProjectDeployments retrieves all deployment records for a specified project.
 func (m *Manager) GetProjectDeployments(projectID int64) ([]*DeploymentRecord, error) {
 	rows, err := m.db.Query(`
-		SELECT id, project_id, object_id, network, epochs, gas_fee, version, notes, success, error, created_at
+		SELECT `+deploymentColumns+`
 		FROM deployments WHERE project_id = ? ORDER BY created_at DESC
 	`, projectID)
 	if err != nil {
@@ -537,11 +1011,52 @@ func (m *Manager) GetProjectDeployments(projectID int64) ([]*DeploymentRecord, e
 	}
 	defer rows.Close()
 
+	return scanDeployments(rows)
+}
+
+// GetProjectDeploymentsPage returns up to limit of a project's deployments,
+// newest first, skipping the first offset, along with the project's total
+// number of deployments. An offset past the end yields an empty page.
+func (m *Manager) GetProjectDeploymentsPage(projectID int64, offset, limit int) ([]*DeploymentRecord, int, error) {
+	if err := validatePage(offset, limit); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := m.db.QueryRow("SELECT COUNT(*) FROM deployments WHERE project_id = ?", projectID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count deployments: %w", err)
+	}
+
+	// id breaks ties so pages neither repeat nor skip deployments
+	rows, err := m.db.Query(`
+		SELECT `+deploymentColumns+`
+		FROM deployments WHERE project_id = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?
+	`, projectID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get deployments: %w", err)
+	}
+	defer rows.Close()
+
+	deployments, err := scanDeployments(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deployments, total, nil
+}
+
+// deploymentColumns lists the deployments table columns read by
+// scanDeployments, in order.
+const deploymentColumns = `id, project_id, object_id, network, epochs, gas_fee, version, notes, success, error, created_at,
+			COALESCE(cost_wal, 0), COALESCE(cost_sui, 0), COALESCE(cost_actual, 0)`
+
+// scanDeployments reads deployments selected with deploymentColumns.
+func scanDeployments(rows *sql.Rows) ([]*DeploymentRecord, error) {
 	var deployments []*DeploymentRecord
 	for rows.Next() {
 		d := &DeploymentRecord{}
 		var version, notes, gasErr sql.NullString
-		err := rows.Scan(&d.ID, &d.ProjectID, &d.ObjectID, &d.Network, &d.Epochs, &d.GasFee, &version, &notes, &d.Success, &gasErr, &d.CreatedAt)
+		err := rows.Scan(&d.ID, &d.ProjectID, &d.ObjectID, &d.Network, &d.Epochs, &d.GasFee, &version, &notes, &d.Success, &gasErr, &d.CreatedAt,
+			&d.CostWAL, &d.CostSUI, &d.CostActual)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan deployment: %w", err)
 		}
@@ -560,6 +1075,71 @@ func (m *Manager) GetProjectDeployments(projectID int64) ([]*DeploymentRecord, e
 	return deployments, nil
 }
 
+// GetDeploymentBlobs returns the file to blob ID map stored with a deployment
+// record. Deployments recorded without blob information return an empty map.
+func (m *Manager) GetDeploymentBlobs(recordID int64) (map[string]string, error) {
+	var exists int
+	err := m.db.QueryRow("SELECT COUNT(*) FROM deployments WHERE id = ?", recordID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if exists == 0 {
+		return nil, fmt.Errorf("deployment %d not found", recordID)
+	}
+
+	rows, err := m.db.Query(`
+		SELECT path, blob_id FROM deployment_blobs WHERE deployment_id = ?
+	`, recordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment blobs: %w", err)
+	}
+	defer rows.Close()
+
+	blobs := make(map[string]string)
+	for rows.Next() {
+		var path, blobID string
+		if err := rows.Scan(&path, &blobID); err != nil {
+			return nil, fmt.Errorf("failed to scan deployment blob: %w", err)
+		}
+		blobs[path] = blobID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read deployment blobs: %w", err)
+	}
+
+	return blobs, nil
+}
+
+// GetBlobLeases returns, per blob ID, the successful deployments of a project
+// that recorded storing the blob. Deployments recorded without blob
+// information are not included.
+func (m *Manager) GetBlobLeases(projectID int64) (map[string][]BlobLease, error) {
+	rows, err := m.db.Query(`
+		SELECT b.blob_id, d.created_at, d.epochs
+		FROM deployment_blobs b JOIN deployments d ON d.id = b.deployment_id
+		WHERE d.project_id = ? AND d.success = 1
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob leases: %w", err)
+	}
+	defer rows.Close()
+
+	leases := make(map[string][]BlobLease)
+	for rows.Next() {
+		var blobID string
+		var lease BlobLease
+		if err := rows.Scan(&blobID, &lease.StoredAt, &lease.Epochs); err != nil {
+			return nil, fmt.Errorf("failed to scan blob lease: %w", err)
+		}
+		leases[blobID] = append(leases[blobID], lease)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read blob leases: %w", err)
+	}
+
+	return leases, nil
+}
+
 // GetProjectStats computes deployment statistics for a specified project.
 func (m *Manager) GetProjectStats(projectID int64) (*ProjectStats, error) {
 	stats := &ProjectStats{}
@@ -577,6 +1157,19 @@ func (m *Manager) GetProjectStats(projectID int64) (*ProjectStats, error) {
 		return nil, fmt.Errorf("failed to get deployment stats: %w", err)
 	}
 
+	// Sum what successful deploys actually spent; estimates are only counted
+	err = m.db.QueryRow(`
+		SELECT
+			COALESCE(SUM(CASE WHEN cost_actual = 1 THEN cost_wal ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN cost_actual = 1 THEN cost_sui ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN cost_actual = 1 THEN 0 ELSE 1 END), 0)
+		FROM deployments WHERE project_id = ? AND success = 1
+	`, projectID).Scan(&stats.TotalWAL, &stats.TotalSUI, &stats.EstimatedDeploys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment costs: %w", err)
+	}
+	stats.TotalGasSpent = FormatCost(stats.TotalWAL, stats.TotalSUI)
+
 	// Get project info
 	project, err := m.GetProject(projectID)
 	if err != nil {
@@ -615,7 +1208,7 @@ func (m *Manager) DeleteProject(id int64) error {
 // DeleteProjectWithOptions removes a project with options to control site folder deletion.
 func (m *Manager) DeleteProjectWithOptions(id int64, deleteSiteFolder bool) error {
 	// Get project details before deleting (need site path)
-	project, err := m.GetProject(id)
+	project, err := m.getProject(id, true)
 	if err != nil {
 		return fmt.Errorf("failed to get project details: %w", err)
 	}
@@ -631,12 +1224,22 @@ func (m *Manager) DeleteProjectWithOptions(id int64, deleteSiteFolder bool) erro
 		}
 	}()
 
-	// Delete deployments first
+	// Delete deployment blob maps and deployments first
+	_, err = tx.Exec("DELETE FROM deployment_blobs WHERE deployment_id IN (SELECT id FROM deployments WHERE project_id = ?)", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete deployment blobs: %w", err)
+	}
+
 	_, err = tx.Exec("DELETE FROM deployments WHERE project_id = ?", id)
 	if err != nil {
 		return fmt.Errorf("failed to delete deployments: %w", err)
 	}
 
+	_, err = tx.Exec("DELETE FROM project_tags WHERE project_id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete project tags: %w", err)
+	}
+
 	// Delete project
 	_, err = tx.Exec("DELETE FROM projects WHERE id = ?", id)
 	if err != nil {
@@ -665,6 +1268,75 @@ func (m *Manager) DeleteProjectWithOptions(id int64, deleteSiteFolder bool) erro
 	return nil
 }
 
+// DefaultRecoveryWindow is how long a soft-deleted project can be restored
+// before PruneDeletedProjects removes it for good.
+const DefaultRecoveryWindow = 30 * 24 * time.Hour
+
+// SoftDeleteProject marks a project as deleted without removing its records or
+// site folder. The project is hidden from listings and lookups by name, path or
+// object ID until it is restored or pruned.
+func (m *Manager) SoftDeleteProject(id int64) error {
+	result, err := m.db.Exec("UPDATE projects SET deleted_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL", time.Now(), time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("project not found or already deleted")
+	}
+	return nil
+}
+
+// RestoreDeletedProject undoes SoftDeleteProject if the project was deleted
+// less than window ago and no live project has since taken its name.
+func (m *Manager) RestoreDeletedProject(id int64, window time.Duration) error {
+	project, err := m.getProject(id, true)
+	if err != nil {
+		return err
+	}
+	if project.DeletedAt == nil {
+		return fmt.Errorf("project %q is not deleted", project.Name)
+	}
+	if time.Since(*project.DeletedAt) > window {
+		return fmt.Errorf("project %q was deleted on %s and is past its recovery window", project.Name, project.DeletedAt.Format("2006-01-02"))
+	}
+
+	exists, err := m.ProjectNameExists(project.Name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("a project named %q already exists", project.Name)
+	}
+
+	if _, err := m.db.Exec("UPDATE projects SET deleted_at = NULL, updated_at = ? WHERE id = ?", time.Now(), id); err != nil {
+		return fmt.Errorf("failed to restore project: %w", err)
+	}
+	return nil
+}
+
+// PruneDeletedProjects permanently removes projects soft-deleted more than
+// window ago, along with their deployment records. Site folders are kept.
+// It returns the number of projects removed.
+func (m *Manager) PruneDeletedProjects(window time.Duration) (int, error) {
+	deleted, err := m.ListProjectsWithFilter(ProjectFilter{Deleted: true})
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-window)
+	pruned := 0
+	for _, project := range deleted {
+		if project.DeletedAt.After(cutoff) {
+			continue
+		}
+		if err := m.DeleteProjectWithOptions(project.ID, false); err != nil {
+			return pruned, fmt.Errorf("failed to prune project %q: %w", project.Name, err)
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
 // ArchiveProject marks a project record as archived in the database.
 func (m *Manager) ArchiveProject(id int64) error {
 	_, err := m.db.Exec("UPDATE projects SET status = 'archived', updated_at = ? WHERE id = ?", time.Now(), id)
@@ -674,6 +1346,15 @@ func (m *Manager) ArchiveProject(id int64) error {
 	return nil
 }
 
+// MarkDestroyed records that a project's site object was destroyed on-chain.
+func (m *Manager) MarkDestroyed(id int64) error {
+	_, err := m.db.Exec("UPDATE projects SET status = 'destroyed', updated_at = ? WHERE id = ?", time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark project destroyed: %w", err)
+	}
+	return nil
+}
+
 // RestoreProject marks a previously archived project as active.
 func (m *Manager) RestoreProject(id int64) error {
 	_, err := m.db.Exec("UPDATE projects SET status = 'active', updated_at = ? WHERE id = ?", time.Now(), id)
@@ -727,73 +1408,91 @@ func (m *Manager) GetEpochInfo(projectID int64) (*EpochInfo, error) {
 		return nil, fmt.Errorf("failed to get deployment dates: %w", err)
 	}
 
-	// Parse datetime strings - try multiple formats
-	parseTime := func(s string) (time.Time, error) {
-		if s == "" {
-			return time.Time{}, fmt.Errorf("empty time string")
-		}
+	// Parse deployment dates — non-fatal if parsing fails (use zero time)
+	if t, err := parseDeploymentTime(firstDeployStr); err == nil {
+		info.FirstDeploymentAt = t
+	}
+	if t, err := parseDeploymentTime(lastDeployStr); err == nil {
+		info.LastDeploymentAt = t
+	}
 
-		// Strip Go's monotonic clock reading (e.g., " m=+63.651456751")
-		if idx := strings.Index(s, " m="); idx != -1 {
-			s = s[:idx]
-		}
+	return info, nil
+}
 
-		// Handle dual timezone format (e.g., "+0300 +03")
-		// Keep only the first timezone offset
-		parts := strings.Fields(s)
-		if len(parts) >= 3 {
-			// Check if last two parts look like timezone offsets
-			last := parts[len(parts)-1]
-			secondLast := parts[len(parts)-2]
-			if len(last) <= 4 && (strings.HasPrefix(last, "+") || strings.HasPrefix(last, "-")) {
-				if len(secondLast) >= 5 && (strings.HasPrefix(secondLast, "+") || strings.HasPrefix(secondLast, "-")) {
-					// Remove the shorter timezone
-					s = strings.Join(parts[:len(parts)-1], " ")
-				}
+// parseDeploymentTime parses a deployment timestamp as SQLite returns it,
+// trying multiple formats. Go time strings are stored with their monotonic
+// clock reading and a second zone, either an offset ("+0300 +03") or an
+// abbreviation ("+0000 UTC"); both are dropped.
+func parseDeploymentTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty time string")
+	}
+
+	// Strip Go's monotonic clock reading (e.g., " m=+63.651456751")
+	if idx := strings.Index(s, " m="); idx != -1 {
+		s = s[:idx]
+	}
+
+	// Handle dual timezone format (e.g., "+0300 +03" or "+0000 UTC")
+	// Keep only the first timezone offset
+	parts := strings.Fields(s)
+	if len(parts) >= 3 {
+		// Check if last two parts look like timezone offsets
+		last := parts[len(parts)-1]
+		secondLast := parts[len(parts)-2]
+		if len(last) <= 4 && (strings.HasPrefix(last, "+") || strings.HasPrefix(last, "-") || isZoneAbbreviation(last)) {
+			if len(secondLast) >= 5 && (strings.HasPrefix(secondLast, "+") || strings.HasPrefix(secondLast, "-")) {
+				// Remove the shorter timezone
+				s = strings.Join(parts[:len(parts)-1], " ")
 			}
 		}
+	}
 
-		formats := []string{
-			time.RFC3339,
-			"2006-01-02 15:04:05.999999999 -0700",
-			"2006-01-02 15:04:05.999999 -0700",
-			"2006-01-02 15:04:05 -0700",
-			"2006-01-02T15:04:05Z07:00",
-			"2006-01-02 15:04:05.999999999-07:00",
-			"2006-01-02 15:04:05.999999999",
-			"2006-01-02 15:04:05",
-			"2006-01-02T15:04:05",
-		}
-		for _, format := range formats {
-			if t, err := time.Parse(format, s); err == nil {
-				return t, nil
-			}
+	formats := []string{
+		time.RFC3339,
+		"2006-01-02 15:04:05.999999999 -0700",
+		"2006-01-02 15:04:05.999999 -0700",
+		"2006-01-02 15:04:05 -0700",
+		"2006-01-02T15:04:05Z07:00",
+		"2006-01-02 15:04:05.999999999-07:00",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05",
+	}
+	for _, format := range formats {
+		if t, err := time.Parse(format, s); err == nil {
+			return t, nil
 		}
-		return time.Time{}, fmt.Errorf("unable to parse time %q with any known format", s)
 	}
+	return time.Time{}, fmt.Errorf("unable to parse time %q with any known format", s)
+}
 
-	// Parse deployment dates — non-fatal if parsing fails (use zero time)
-	if t, err := parseTime(firstDeployStr); err == nil {
-		info.FirstDeploymentAt = t
+// isZoneAbbreviation reports whether s looks like a time zone abbreviation
+// such as UTC or CEST.
+func isZoneAbbreviation(s string) bool {
+	if len(s) < 3 {
+		return false
 	}
-	if t, err := parseTime(lastDeployStr); err == nil {
-		info.LastDeploymentAt = t
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
 	}
-
-	return info, nil
+	return true
 }
 
 // SetStatus sets the status of a project to the specified value.
 func (m *Manager) SetStatus(id int64, status string) error {
 	// Validate status
 	validStatuses := map[string]bool{
-		"draft":    true,
-		"active":   true,
-		"archived": true,
+		"draft":     true,
+		"active":    true,
+		"archived":  true,
+		"destroyed": true,
 	}
 
 	if !validStatuses[status] {
-		return fmt.Errorf("invalid status: %s (must be draft, active, or archived)", status)
+		return fmt.Errorf("invalid status: %s (must be draft, active, archived, or destroyed)", status)
 	}
 
 	_, err := m.db.Exec("UPDATE projects SET status = ?, updated_at = ? WHERE id = ?", status, time.Now(), id)
@@ -6,8 +6,11 @@
 package projects
 
 import (
+	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -270,6 +273,117 @@ func TestListProjectsFiltering(t *testing.T) {
 	}
 }
 
+// TestListProjectsPage verifies offset, limit and total across pages
+func TestListProjectsPage(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	var all []string
+	for i := 0; i < 5; i++ {
+		network := "testnet"
+		if i%2 == 1 {
+			network = "mainnet"
+		}
+		p := &Project{Name: fmt.Sprintf("page-%d", i), Network: network, SitePath: fmt.Sprintf("/tmp/page-%d", i)}
+		if err := manager.CreateProject(p); err != nil {
+			t.Fatal(err)
+		}
+		all = append(all, p.Name)
+	}
+
+	full, err := manager.ListProjectsWithFilter(ProjectFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []string
+	for _, p := range full {
+		want = append(want, p.Name)
+	}
+
+	var got []string
+	for offset := 0; offset < 6; offset += 2 {
+		page, total, err := manager.ListProjectsPage(ProjectFilter{}, offset, 2)
+		if err != nil {
+			t.Fatalf("ListProjectsPage(%d, 2) failed: %v", offset, err)
+		}
+		if total != len(all) {
+			t.Errorf("ListProjectsPage(%d, 2) total = %d, want %d", offset, total, len(all))
+		}
+		wantLen := min(2, len(all)-offset)
+		if len(page) != wantLen {
+			t.Errorf("ListProjectsPage(%d, 2) returned %d projects, want %d", offset, len(page), wantLen)
+		}
+		for _, p := range page {
+			got = append(got, p.Name)
+		}
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("paged projects = %v, want %v", got, want)
+	}
+
+	page, total, err := manager.ListProjectsPage(ProjectFilter{}, 10, 2)
+	if err != nil || len(page) != 0 || total != len(all) {
+		t.Errorf("offset past the end = %d projects, total %d, %v; want none, %d", len(page), total, err, len(all))
+	}
+
+	page, total, err = manager.ListProjectsPage(ProjectFilter{Network: "mainnet"}, 1, 10)
+	if err != nil || len(page) != 1 || total != 2 || page[0].Network != "mainnet" {
+		t.Errorf("filtered page = %v, total %d, %v; want 1 mainnet project of 2", page, total, err)
+	}
+
+	for _, bad := range [][2]int{{-1, 2}, {0, 0}} {
+		if _, _, err := manager.ListProjectsPage(ProjectFilter{}, bad[0], bad[1]); err == nil {
+			t.Errorf("ListProjectsPage(%d, %d) should fail", bad[0], bad[1])
+		}
+	}
+}
+
+// TestGetProjectDeploymentsPage verifies deployments are paged newest first
+func TestGetProjectDeploymentsPage(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	project := &Project{Name: "paged-deploys", Network: "testnet", SitePath: "/tmp/paged"}
+	if err := manager.CreateProject(project); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		d := &DeploymentRecord{ProjectID: project.ID, ObjectID: fmt.Sprintf("0x%d", i), Network: "testnet", Epochs: 1, Success: true}
+		if err := manager.RecordDeployment(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, total, err := manager.GetProjectDeploymentsPage(project.ID, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 5 {
+		t.Errorf("total = %d, want 5", total)
+	}
+	var ids []string
+	for _, d := range page {
+		ids = append(ids, d.ObjectID)
+	}
+	if strings.Join(ids, ",") != "0x3,0x2,0x1" {
+		t.Errorf("page = %v, want 0x3,0x2,0x1", ids)
+	}
+
+	page, total, err = manager.GetProjectDeploymentsPage(project.ID, 5, 3)
+	if err != nil || len(page) != 0 || total != 5 {
+		t.Errorf("offset past the end = %d deployments, total %d, %v; want none, 5", len(page), total, err)
+	}
+
+	page, total, err = manager.GetProjectDeploymentsPage(9999, 0, 3)
+	if err != nil || len(page) != 0 || total != 0 {
+		t.Errorf("unknown project = %d deployments, total %d, %v; want none, 0", len(page), total, err)
+	}
+
+	if _, _, err := manager.GetProjectDeploymentsPage(project.ID, 0, -1); err == nil {
+		t.Error("negative limit should fail")
+	}
+}
+
 // TestArchiveAndRestore verifies archive/restore status changes
 func TestArchiveAndRestore(t *testing.T) {
 	manager := setupTestManager(t)
@@ -423,6 +537,71 @@ func TestProjectStats(t *testing.T) {
 	}
 }
 
+// TestDeploymentCosts verifies actual costs are stored and summed, and
+// estimated ones are only counted
+func TestDeploymentCosts(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	project := &Project{Name: "cost-test", Network: "testnet", ObjectID: "0x123", Epochs: 1, SitePath: "/tmp/site"}
+	if err := manager.CreateProject(project); err != nil {
+		t.Fatal(err)
+	}
+
+	records := []*DeploymentRecord{
+		{GasFee: "0.040000 WAL + 0.003000 SUI", CostWAL: 0.04, CostSUI: 0.003, CostActual: true, Success: true},
+		{GasFee: "0.010000 WAL", CostWAL: 0.01, CostActual: true, Success: true},
+		{GasFee: "~0.0500 WAL + ~0.0040 SUI", Success: true},
+		{CostWAL: 9, CostActual: true, Success: false},
+	}
+	for _, d := range records {
+		d.ProjectID = project.ID
+		d.Network = "testnet"
+		d.Epochs = 5
+		if err := manager.RecordDeployment(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deployments, err := manager.GetProjectDeployments(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byFee := map[string]*DeploymentRecord{}
+	for _, d := range deployments {
+		byFee[d.GasFee] = d
+	}
+	if d := byFee["0.040000 WAL + 0.003000 SUI"]; d == nil || d.CostWAL != 0.04 || d.CostSUI != 0.003 || !d.CostActual {
+		t.Errorf("actual cost not stored: %+v", d)
+	}
+	if d := byFee["~0.0500 WAL + ~0.0040 SUI"]; d == nil || d.CostActual {
+		t.Errorf("estimated cost stored as actual: %+v", d)
+	}
+
+	stats, err := manager.GetProjectStats(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(stats.TotalWAL-0.05) > 1e-9 || math.Abs(stats.TotalSUI-0.003) > 1e-9 {
+		t.Errorf("totals = %v WAL, %v SUI; want 0.05 WAL, 0.003 SUI", stats.TotalWAL, stats.TotalSUI)
+	}
+	if stats.TotalGasSpent != "0.050000 WAL + 0.003000 SUI" {
+		t.Errorf("TotalGasSpent = %q", stats.TotalGasSpent)
+	}
+	if stats.EstimatedDeploys != 1 {
+		t.Errorf("EstimatedDeploys = %d, want 1", stats.EstimatedDeploys)
+	}
+}
+
+func TestDeployCostFee(t *testing.T) {
+	if fee, actual := DeployCostFee(0.02, 0.001, "testnet", 1024, 1); fee != "0.020000 WAL + 0.001000 SUI" || !actual {
+		t.Errorf("DeployCostFee() = %q, %v", fee, actual)
+	}
+	if fee, actual := DeployCostFee(0, 0.001, "testnet", 1024, 1); fee != "0.001000 SUI" || !actual {
+		t.Errorf("DeployCostFee() = %q, %v", fee, actual)
+	}
+}
+
 // TestSchemaMigration verifies database migrations work correctly
 func TestSchemaMigration(t *testing.T) {
 	manager := setupTestManager(t)
@@ -466,6 +645,51 @@ func TestSchemaMigration(t *testing.T) {
 	}
 }
 
+// TestDeletablePersisted verifies the deletable flag survives create, update
+// and reopening the database
+func TestDeletablePersisted(t *testing.T) {
+	manager := setupTestManager(t)
+
+	project := &Project{Name: "ephemeral", Network: "testnet", ObjectID: "0xeph", Epochs: 1, SitePath: "/tmp/eph", Deletable: true}
+	if err := manager.CreateProject(project); err != nil {
+		t.Fatal(err)
+	}
+	other := &Project{Name: "permanent", Network: "testnet", ObjectID: "0xperm", Epochs: 1, SitePath: "/tmp/perm"}
+	if err := manager.CreateProject(other); err != nil {
+		t.Fatal(err)
+	}
+	manager.Close()
+
+	reopened, err := NewManager()
+	if err != nil {
+		t.Fatalf("Failed to reopen manager: %v", err)
+	}
+	defer reopened.Close()
+
+	got, err := reopened.GetProject(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Deletable {
+		t.Error("Deletable not preserved after reopen")
+	}
+	if got, err := reopened.GetProject(other.ID); err != nil || got.Deletable {
+		t.Errorf("project created without Deletable: got %v, %v", got, err)
+	}
+
+	got.Deletable = false
+	if err := reopened.UpdateProject(got); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := reopened.GetProjectByObjectID("0xeph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated == nil || updated.Deletable {
+		t.Errorf("UpdateProject did not store Deletable = false: %+v", updated)
+	}
+}
+
 // TestDeleteCascadesDeployments verifies deleting a project removes its deployments
 func TestDeleteCascadesDeployments(t *testing.T) {
 	manager := setupTestManager(t)
@@ -519,7 +743,258 @@ func TestDeleteCascadesDeployments(t *testing.T) {
 	}
 }
 
+// TestDeploymentBlobs verifies file to blob maps are stored with deployments
+func TestGetBlobLeases(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	project := &Project{Name: "leases", Network: "testnet", ObjectID: "0x123", SitePath: "/tmp/leases"}
+	if err := manager.CreateProject(project); err != nil {
+		t.Fatal(err)
+	}
+
+	records := []*DeploymentRecord{
+		{Epochs: 5, Success: true, FileToBlobID: map[string]string{"index.html": "blob-index", "app.js": "blob-app"}},
+		{Epochs: 10, Success: true, FileToBlobID: map[string]string{"index.html": "blob-index"}},
+		{Epochs: 20, Success: false, FileToBlobID: map[string]string{"app.js": "blob-failed"}},
+	}
+	for _, record := range records {
+		record.ProjectID = project.ID
+		record.ObjectID = "0xsite"
+		record.Network = "testnet"
+		if err := manager.RecordDeployment(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	leases, err := manager.GetBlobLeases(project.ID)
+	if err != nil {
+		t.Fatalf("GetBlobLeases() error = %v", err)
+	}
+	if len(leases) != 2 {
+		t.Fatalf("GetBlobLeases() = %v, want blob-index and blob-app", leases)
+	}
+	if got := leases["blob-index"]; len(got) != 2 || got[0].Epochs+got[1].Epochs != 15 {
+		t.Errorf("blob-index leases = %v, want the 5 and 10 epoch deployments", got)
+	}
+	if got := leases["blob-app"]; len(got) != 1 || got[0].Epochs != 5 || got[0].StoredAt.IsZero() {
+		t.Errorf("blob-app leases = %v, want one 5 epoch deployment", got)
+	}
+}
+
+func TestDeploymentBlobs(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	project := &Project{
+		Name:       "blobs-test",
+		Network:    "testnet",
+		ObjectID:   "0x123",
+		WalletAddr: "0xwallet",
+		Epochs:     1,
+		SitePath:   "/tmp/site",
+	}
+	if err := manager.CreateProject(project); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		blobs map[string]string
+	}{
+		{
+			name: "blob map",
+			blobs: map[string]string{
+				"index.html":          "blob-index",
+				"css/style.css":       "blob-css",
+				"images/hero one.png": "blob-hero",
+			},
+		},
+		{name: "empty map", blobs: map[string]string{}},
+		{name: "nil map", blobs: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &DeploymentRecord{
+				ProjectID:    project.ID,
+				ObjectID:     "0xsite",
+				Network:      "testnet",
+				Epochs:       1,
+				Success:      true,
+				FileToBlobID: tt.blobs,
+			}
+			if err := manager.RecordDeployment(deployment); err != nil {
+				t.Fatalf("RecordDeployment failed: %v", err)
+			}
+
+			got, err := manager.GetDeploymentBlobs(deployment.ID)
+			if err != nil {
+				t.Fatalf("GetDeploymentBlobs failed: %v", err)
+			}
+			if got == nil {
+				t.Fatal("GetDeploymentBlobs should return a non-nil map")
+			}
+			if len(got) != len(tt.blobs) {
+				t.Fatalf("expected %d blobs, got %d: %v", len(tt.blobs), len(got), got)
+			}
+			for path, blobID := range tt.blobs {
+				if got[path] != blobID {
+					t.Errorf("blob for %s = %q, want %q", path, got[path], blobID)
+				}
+			}
+		})
+	}
+
+	if _, err := manager.GetDeploymentBlobs(99999); err == nil {
+		t.Error("expected error for nonexistent deployment")
+	}
+
+	// Blob maps are removed along with the project
+	if err := manager.DeleteProjectWithOptions(project.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	var remaining int
+	if err := manager.db.QueryRow("SELECT COUNT(*) FROM deployment_blobs").Scan(&remaining); err != nil {
+		t.Fatal(err)
+	}
+	if remaining != 0 {
+		t.Errorf("expected deployment blobs to be deleted, %d remaining", remaining)
+	}
+}
+
+// TestGetProjectByObjectID verifies lookup by site object ID
+func TestGetProjectByObjectID(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	project := &Project{
+		Name:       "objectid-test",
+		Network:    "testnet",
+		ObjectID:   "0xabc123",
+		WalletAddr: "0xwallet",
+		Epochs:     1,
+		SitePath:   "/tmp/site",
+	}
+	if err := manager.CreateProject(project); err != nil {
+		t.Fatal(err)
+	}
+
+	// Draft projects have an empty object ID and must never match
+	if err := manager.CreateDraftProject("draft-site", "/tmp/draft"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("match", func(t *testing.T) {
+		retrieved, err := manager.GetProjectByObjectID("0xabc123")
+		if err != nil {
+			t.Fatalf("GetProjectByObjectID failed: %v", err)
+		}
+		if retrieved == nil {
+			t.Fatal("Expected project, got nil")
+		}
+		if retrieved.ID != project.ID {
+			t.Errorf("ID mismatch: got %d, want %d", retrieved.ID, project.ID)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		retrieved, err := manager.GetProjectByObjectID("0xdoesnotexist")
+		if err != nil {
+			t.Fatalf("GetProjectByObjectID failed: %v", err)
+		}
+		if retrieved != nil {
+			t.Errorf("Expected nil for unknown object ID, got project %d", retrieved.ID)
+		}
+	})
+
+	for _, input := range []string{"", "   ", "YOUR_WALRUS_PROJECT_ID"} {
+		retrieved, err := manager.GetProjectByObjectID(input)
+		if err != nil {
+			t.Errorf("GetProjectByObjectID(%q) returned error: %v", input, err)
+		}
+		if retrieved != nil {
+			t.Errorf("GetProjectByObjectID(%q) should return nil, got project %d", input, retrieved.ID)
+		}
+	}
+}
+
 // setupTestManager creates a manager with a temp database for testing
+func TestCloneProject(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	source := &Project{
+		Name:        "microsite-a",
+		Category:    "portfolio",
+		Network:     "mainnet",
+		ObjectID:    "0xsource",
+		SuiNS:       "microsite-a.sui",
+		WalletAddr:  "0xwallet",
+		Epochs:      12,
+		GasFee:      "0.5 SUI",
+		SitePath:    "/tmp/microsite-a",
+		Description: "First microsite",
+		ImageURL:    "https://example.com/logo.png",
+	}
+	if err := manager.CreateProject(source); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := manager.CloneProject(source.ID, "microsite-b", "/tmp/microsite-b")
+	if err != nil {
+		t.Fatalf("CloneProject failed: %v", err)
+	}
+
+	if clone.ID == 0 || clone.ID == source.ID {
+		t.Errorf("clone ID = %d, want a new ID (source %d)", clone.ID, source.ID)
+	}
+	if clone.ObjectID != "" {
+		t.Errorf("clone ObjectID = %q, want empty", clone.ObjectID)
+	}
+	if clone.SuiNS != "" {
+		t.Errorf("clone SuiNS = %q, want empty", clone.SuiNS)
+	}
+
+	stored, err := manager.GetProject(clone.ID)
+	if err != nil {
+		t.Fatalf("GetProject(clone) failed: %v", err)
+	}
+	if stored.Name != "microsite-b" || stored.SitePath != "/tmp/microsite-b" {
+		t.Errorf("stored clone = %q at %q, want microsite-b at /tmp/microsite-b", stored.Name, stored.SitePath)
+	}
+	if stored.ObjectID != "" {
+		t.Errorf("stored ObjectID = %q, want empty", stored.ObjectID)
+	}
+	if stored.Category != source.Category || stored.Network != source.Network || stored.Epochs != source.Epochs {
+		t.Errorf("config not copied: got %s/%s/%d", stored.Category, stored.Network, stored.Epochs)
+	}
+	if stored.Description != source.Description || stored.ImageURL != source.ImageURL {
+		t.Errorf("metadata not copied: got %q / %q", stored.Description, stored.ImageURL)
+	}
+	if stored.Status != "draft" || stored.DeployCount != 0 {
+		t.Errorf("clone status = %s with %d deploys, want draft with 0", stored.Status, stored.DeployCount)
+	}
+
+	t.Run("duplicate name is rejected", func(t *testing.T) {
+		if _, err := manager.CloneProject(source.ID, "microsite-a", "/tmp/other"); err == nil {
+			t.Error("Expected error for duplicate project name")
+		}
+	})
+
+	t.Run("empty name is rejected", func(t *testing.T) {
+		if _, err := manager.CloneProject(source.ID, "  ", "/tmp/other"); err == nil {
+			t.Error("Expected error for empty project name")
+		}
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		if _, err := manager.CloneProject(99999, "microsite-c", "/tmp/other"); err == nil {
+			t.Error("Expected error for missing source project")
+		}
+	})
+}
+
 func setupTestManager(t *testing.T) *Manager {
 	t.Helper()
 
@@ -535,3 +1010,239 @@ func setupTestManager(t *testing.T) *Manager {
 
 	return manager
 }
+
+func TestMarkDestroyed(t *testing.T) {
+	manager := setupTestManager(t)
+
+	project := &Project{Name: "doomed", Network: "mainnet", ObjectID: "0xdoomed", SitePath: t.TempDir()}
+	if err := manager.CreateProject(project); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.MarkDestroyed(project.ID); err != nil {
+		t.Fatalf("MarkDestroyed() error = %v", err)
+	}
+
+	retrieved, err := manager.GetProject(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved.Status != "destroyed" {
+		t.Errorf("Status after destroy should be 'destroyed', got %q", retrieved.Status)
+	}
+	if err := manager.SetStatus(project.ID, "destroyed"); err != nil {
+		t.Errorf("SetStatus(destroyed) error = %v", err)
+	}
+}
+
+func TestLinkedSuiNSDomain(t *testing.T) {
+	siteDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(siteDir, "walgo.yaml"), []byte("walrus:\n  suinsDomain: fromconfig.sui\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := (&Project{SuiNS: "project.sui", SitePath: siteDir}).LinkedSuiNSDomain(); got != "project.sui" {
+		t.Errorf("project domain = %q, want project.sui", got)
+	}
+	if got := (&Project{SitePath: siteDir}).LinkedSuiNSDomain(); got != "fromconfig.sui" {
+		t.Errorf("config domain = %q, want fromconfig.sui", got)
+	}
+	if got := (&Project{SitePath: t.TempDir()}).LinkedSuiNSDomain(); got != "" {
+		t.Errorf("unlinked domain = %q, want empty", got)
+	}
+}
+
+func TestPortalURL(t *testing.T) {
+	siteDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(siteDir, "walgo.yaml"), []byte("walrus:\n  suinsDomain: fromconfig.sui\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		project Project
+		want    string
+	}{
+		{"project domain", Project{Network: "mainnet", SuiNS: "myblog.sui", SitePath: siteDir}, "https://myblog.wal.app"},
+		{"config domain", Project{Network: "mainnet", SitePath: siteDir}, "https://fromconfig.wal.app"},
+		{"testnet", Project{Network: "testnet", SuiNS: "myblog.sui"}, ""},
+		{"no domain", Project{Network: "mainnet"}, ""},
+		{"invalid domain", Project{Network: "mainnet", SuiNS: "my-blog.sui"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.project.PortalURL(); got != tt.want {
+				t.Errorf("PortalURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	manager := setupTestManager(t)
+
+	siteDir := t.TempDir()
+	project := &Project{Name: "oops", Network: "testnet", ObjectID: "0xoops", SitePath: siteDir}
+	if err := manager.CreateProject(project); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.SoftDeleteProject(project.ID); err != nil {
+		t.Fatalf("SoftDeleteProject() error = %v", err)
+	}
+	if err := manager.SoftDeleteProject(project.ID); err == nil {
+		t.Error("deleting an already deleted project should fail")
+	}
+	if _, err := os.Stat(siteDir); err != nil {
+		t.Errorf("soft delete should keep the site folder: %v", err)
+	}
+
+	if _, err := manager.GetProject(project.ID); err == nil {
+		t.Error("GetProject() should not find a soft-deleted project")
+	}
+	retrieved, err := manager.getProject(project.ID, true)
+	if err != nil {
+		t.Fatalf("getProject() should still find a soft-deleted project: %v", err)
+	}
+	if retrieved.DeletedAt == nil {
+		t.Fatal("DeletedAt should be set after soft delete")
+	}
+
+	if err := manager.RestoreDeletedProject(project.ID, DefaultRecoveryWindow); err != nil {
+		t.Fatalf("RestoreDeletedProject() error = %v", err)
+	}
+	retrieved, err = manager.GetProjectByName("oops")
+	if err != nil {
+		t.Fatalf("restored project should be found by name: %v", err)
+	}
+	if retrieved.DeletedAt != nil {
+		t.Errorf("DeletedAt should be cleared after restore, got %v", retrieved.DeletedAt)
+	}
+	if err := manager.RestoreDeletedProject(project.ID, DefaultRecoveryWindow); err == nil {
+		t.Error("restoring a live project should fail")
+	}
+}
+
+func TestSoftDeletedExcludedFromListings(t *testing.T) {
+	manager := setupTestManager(t)
+
+	kept := &Project{Name: "kept", Network: "testnet", ObjectID: "0xkept", SitePath: "/tmp/kept"}
+	gone := &Project{Name: "gone", Network: "testnet", ObjectID: "0xgone", SitePath: "/tmp/gone"}
+	for _, p := range []*Project{kept, gone} {
+		if err := manager.CreateProject(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := manager.SoftDeleteProject(gone.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := manager.ListProjects("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Name != "kept" {
+		t.Errorf("ListProjects() = %v, want only kept", list)
+	}
+
+	deleted, err := manager.ListProjectsWithFilter(ProjectFilter{Deleted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 1 || deleted[0].Name != "gone" {
+		t.Errorf("deleted listing = %v, want only gone", deleted)
+	}
+
+	if p, err := manager.GetProjectByName("gone"); err == nil {
+		t.Errorf("GetProjectByName() returned soft-deleted project %v", p)
+	}
+	if p, _ := manager.GetProjectByObjectID("0xgone"); p != nil {
+		t.Errorf("GetProjectByObjectID() returned soft-deleted project %v", p)
+	}
+	if p, _ := manager.GetProjectBySitePath("/tmp/gone"); p != nil {
+		t.Errorf("GetProjectBySitePath() returned soft-deleted project %v", p)
+	}
+	if exists, _ := manager.ProjectNameExists("gone"); exists {
+		t.Error("ProjectNameExists() should ignore soft-deleted projects")
+	}
+}
+
+func TestPruneDeletedProjects(t *testing.T) {
+	manager := setupTestManager(t)
+
+	siteDir := t.TempDir()
+	expired := &Project{Name: "expired", Network: "testnet", ObjectID: "0xexpired", SitePath: siteDir}
+	recent := &Project{Name: "recent", Network: "testnet", ObjectID: "0xrecent", SitePath: "/tmp/recent"}
+	for _, p := range []*Project{expired, recent} {
+		if err := manager.CreateProject(p); err != nil {
+			t.Fatal(err)
+		}
+		if err := manager.SoftDeleteProject(p.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := manager.RecordDeployment(&DeploymentRecord{ProjectID: expired.ID, ObjectID: "0xexpired", Network: "testnet", Epochs: 1, Success: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Backdate the first deletion past the recovery window
+	old := time.Now().Add(-DefaultRecoveryWindow - time.Hour)
+	if _, err := manager.db.Exec("UPDATE projects SET deleted_at = ? WHERE id = ?", old, expired.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.RestoreDeletedProject(expired.ID, DefaultRecoveryWindow); err == nil {
+		t.Error("restoring past the recovery window should fail")
+	}
+
+	pruned, err := manager.PruneDeletedProjects(DefaultRecoveryWindow)
+	if err != nil {
+		t.Fatalf("PruneDeletedProjects() error = %v", err)
+	}
+	if pruned != 1 {
+		t.Errorf("pruned = %d, want 1", pruned)
+	}
+
+	if _, err := manager.getProject(expired.ID, true); err == nil {
+		t.Error("expired project should be removed by prune")
+	}
+	deployments, err := manager.GetProjectDeployments(expired.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deployments) != 0 {
+		t.Errorf("prune should remove deployments, got %d", len(deployments))
+	}
+	if _, err := os.Stat(siteDir); err != nil {
+		t.Errorf("prune should keep the site folder: %v", err)
+	}
+	if _, err := manager.getProject(recent.ID, true); err != nil {
+		t.Errorf("recently deleted project should survive prune: %v", err)
+	}
+}
+
+func TestParseDeploymentTime(t *testing.T) {
+	want := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []string{
+		"2025-01-02T03:04:05Z",
+		"2025-01-02 03:04:05 +0000 UTC",
+		"2025-01-02 03:04:05 +0000 UTC m=+63.651456751",
+		"2025-01-02 06:04:05 +0300 +03",
+		"2025-01-02 03:04:05",
+	}
+	for _, s := range tests {
+		got, err := parseDeploymentTime(s)
+		if err != nil {
+			t.Errorf("parseDeploymentTime(%q) error = %v", s, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("parseDeploymentTime(%q) = %v, want %v", s, got, want)
+		}
+	}
+
+	for _, s := range []string{"", "yesterday"} {
+		if _, err := parseDeploymentTime(s); err == nil {
+			t.Errorf("parseDeploymentTime(%q) should fail", s)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package projects
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrObjectIDMismatch is returned by MergeProjects when the two projects
+// track different site objects and force is not set.
+var ErrObjectIDMismatch = errors.New("projects track different site objects")
+
+// MergeProjects folds project mergeID into keepID: mergeID's deployment
+// records and tags move to keepID, and mergeID is soft-deleted so it can
+// still be restored. Records keep their timestamps, so the combined history
+// stays in chronological order. keepID's deploy count becomes the sum of
+// both, and its last deploy time and object ID follow the newest deployment,
+// as after RecordDeployment. Projects whose object IDs differ are only merged
+// with force. It returns the number of deployment records moved.
+func (m *Manager) MergeProjects(keepID, mergeID int64, force bool) (int, error) {
+	if keepID == mergeID {
+		return 0, fmt.Errorf("cannot merge a project into itself")
+	}
+	keep, err := m.GetProject(keepID)
+	if err != nil {
+		return 0, err
+	}
+	merge, err := m.GetProject(mergeID)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range []*Project{keep, merge} {
+		if p.DeletedAt != nil {
+			return 0, fmt.Errorf("project %q is deleted; restore it first", p.Name)
+		}
+	}
+	if keep.ObjectID != "" && merge.ObjectID != "" && keep.ObjectID != merge.ObjectID && !force {
+		return 0, fmt.Errorf("%w: %q is %s, %q is %s (use --force to merge anyway)",
+			ErrObjectIDMismatch, keep.Name, keep.ObjectID, merge.Name, merge.ObjectID)
+	}
+
+	tx, err := m.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	result, err := tx.Exec("UPDATE deployments SET project_id = ? WHERE project_id = ?", keepID, mergeID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to move deployments: %w", err)
+	}
+	moved, _ := result.RowsAffected()
+
+	_, err = tx.Exec("INSERT OR IGNORE INTO project_tags (project_id, tag) SELECT ?, tag FROM project_tags WHERE project_id = ?", keepID, mergeID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to copy tags: %w", err)
+	}
+
+	var count int
+	if err = tx.QueryRow("SELECT COUNT(*) FROM deployments WHERE project_id = ?", keepID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count deployments: %w", err)
+	}
+
+	now := time.Now()
+	lastDeployAt, objectID := keep.LastDeployAt, keep.ObjectID
+	if merge.LastDeployAt.After(lastDeployAt) {
+		lastDeployAt = merge.LastDeployAt
+	}
+	if count > 0 {
+		var latestAt time.Time
+		var latestObjectID string
+		err = tx.QueryRow("SELECT created_at, object_id FROM deployments WHERE project_id = ? ORDER BY created_at DESC LIMIT 1", keepID).
+			Scan(&latestAt, &latestObjectID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read latest deployment: %w", err)
+		}
+		if latestAt.After(lastDeployAt) {
+			lastDeployAt = latestAt
+		}
+		if latestObjectID != "" {
+			objectID = latestObjectID
+		}
+	}
+	if objectID == "" {
+		objectID = merge.ObjectID
+	}
+
+	_, err = tx.Exec("UPDATE projects SET deploy_count = ?, last_deploy_at = ?, object_id = ?, updated_at = ? WHERE id = ?",
+		keep.DeployCount+merge.DeployCount, lastDeployAt, objectID, now, keepID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	_, err = tx.Exec("UPDATE projects SET deleted_at = ?, updated_at = ? WHERE id = ?", now, now, mergeID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete merged project: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return int(moved), nil
+}
@@ -0,0 +1,152 @@
+package projects
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// recordDeploymentAt records a deployment and backdates it, and the
+// project's last deploy time, to at.
+func recordDeploymentAt(t *testing.T, m *Manager, projectID int64, objectID, version string, at time.Time) {
+	t.Helper()
+	d := &DeploymentRecord{ProjectID: projectID, ObjectID: objectID, Network: "testnet", Epochs: 1, Version: version, Success: true}
+	if err := m.RecordDeployment(d); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.db.Exec("UPDATE deployments SET created_at = ? WHERE id = ?", at, d.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.db.Exec("UPDATE projects SET last_deploy_at = ? WHERE id = ?", at, projectID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMergeProjects(t *testing.T) {
+	m := setupTestManager(t)
+	defer m.Close()
+
+	keep := &Project{Name: "site-laptop", Network: "testnet", ObjectID: "0xsite", SitePath: t.TempDir()}
+	merge := &Project{Name: "site-desktop", Network: "testnet", ObjectID: "0xsite", SitePath: t.TempDir()}
+	for _, p := range []*Project{keep, merge} {
+		if err := m.CreateProject(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.AddTag(merge.ID, "desktop"); err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	recordDeploymentAt(t, m, keep.ID, "0xsite", "v1", base)
+	recordDeploymentAt(t, m, merge.ID, "0xsite", "v2", base.Add(24*time.Hour))
+	recordDeploymentAt(t, m, keep.ID, "0xsite", "v3", base.Add(48*time.Hour))
+	recordDeploymentAt(t, m, merge.ID, "0xsite", "v4", base.Add(72*time.Hour))
+
+	before := 0
+	for _, id := range []int64{keep.ID, merge.ID} {
+		p, err := m.GetProject(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before += p.DeployCount
+	}
+
+	moved, err := m.MergeProjects(keep.ID, merge.ID, false)
+	if err != nil {
+		t.Fatalf("MergeProjects() error = %v", err)
+	}
+	if moved != 2 {
+		t.Errorf("moved = %d, want 2", moved)
+	}
+
+	history, err := m.GetProjectDeployments(keep.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versions []string
+	for _, d := range history {
+		versions = append(versions, d.Version)
+	}
+	if got := versions; len(got) != 4 || got[0] != "v4" || got[1] != "v3" || got[2] != "v2" || got[3] != "v1" {
+		t.Errorf("history = %v, want v4 v3 v2 v1 (newest first)", got)
+	}
+
+	merged, err := m.GetProject(keep.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.DeployCount != before {
+		t.Errorf("DeployCount = %d, want %d (both projects' counts)", merged.DeployCount, before)
+	}
+	if !merged.LastDeployAt.Equal(base.Add(72 * time.Hour)) {
+		t.Errorf("LastDeployAt = %v, want the newest merged deployment", merged.LastDeployAt)
+	}
+	if tags, _ := m.GetTags(keep.ID); len(tags) != 1 || tags[0] != "desktop" {
+		t.Errorf("tags = %v, want the merged project's tags", tags)
+	}
+
+	gone, err := m.getProject(merge.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gone.DeletedAt == nil {
+		t.Error("merged project should be soft-deleted")
+	}
+	if remaining, _ := m.GetProjectDeployments(merge.ID); len(remaining) != 0 {
+		t.Errorf("merged project still has %d deployments", len(remaining))
+	}
+}
+
+func TestMergeProjects_DifferentObjectIDs(t *testing.T) {
+	m := setupTestManager(t)
+	defer m.Close()
+
+	keep := &Project{Name: "a", Network: "testnet", ObjectID: "0xaaa", SitePath: t.TempDir()}
+	merge := &Project{Name: "b", Network: "testnet", ObjectID: "0xbbb", SitePath: t.TempDir()}
+	for _, p := range []*Project{keep, merge} {
+		if err := m.CreateProject(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	recordDeploymentAt(t, m, keep.ID, "0xaaa", "a1", base)
+	recordDeploymentAt(t, m, merge.ID, "0xbbb", "b1", base.Add(time.Hour))
+
+	if _, err := m.MergeProjects(keep.ID, merge.ID, false); !errors.Is(err, ErrObjectIDMismatch) {
+		t.Fatalf("MergeProjects() error = %v, want ErrObjectIDMismatch", err)
+	}
+	if history, _ := m.GetProjectDeployments(merge.ID); len(history) != 1 {
+		t.Error("a refused merge must not move deployments")
+	}
+	if p, _ := m.GetProject(merge.ID); p.DeletedAt != nil {
+		t.Error("a refused merge must not delete the project")
+	}
+
+	if _, err := m.MergeProjects(keep.ID, merge.ID, true); err != nil {
+		t.Fatalf("MergeProjects(force) error = %v", err)
+	}
+	merged, err := m.GetProject(keep.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.ObjectID != "0xbbb" {
+		t.Errorf("ObjectID = %q, want the newest deployment's object", merged.ObjectID)
+	}
+}
+
+func TestMergeProjects_Invalid(t *testing.T) {
+	m := setupTestManager(t)
+	defer m.Close()
+
+	p := &Project{Name: "solo", Network: "testnet", SitePath: t.TempDir()}
+	if err := m.CreateProject(p); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.MergeProjects(p.ID, p.ID, false); err == nil {
+		t.Error("merging a project into itself should fail")
+	}
+	if _, err := m.MergeProjects(p.ID, 9999, false); err == nil {
+		t.Error("merging a missing project should fail")
+	}
+}
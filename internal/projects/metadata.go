@@ -0,0 +1,134 @@
+package projects
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Bounds on project metadata entries.
+const (
+	maxMetadataKeyLength   = 64
+	maxMetadataValueLength = 1024
+)
+
+// metadataKeyPattern allows letters, digits, and . _ - separators.
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateMetadataKey checks that key is a single, reasonably short name.
+func ValidateMetadataKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("metadata key cannot be empty")
+	}
+	if len(key) > maxMetadataKeyLength {
+		return fmt.Errorf("metadata key %q is longer than %d characters", key, maxMetadataKeyLength)
+	}
+	if !metadataKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid metadata key %q: use letters, digits, '.', '_' or '-'", key)
+	}
+	return nil
+}
+
+// SetMetadata sets a project's metadata key to value, replacing any value it
+// had. An empty value deletes the key.
+func (m *Manager) SetMetadata(projectID int64, key, value string) error {
+	key = strings.TrimSpace(key)
+	if err := ValidateMetadataKey(key); err != nil {
+		return err
+	}
+	if len(value) > maxMetadataValueLength {
+		return fmt.Errorf("value of %q is longer than %d characters", key, maxMetadataValueLength)
+	}
+
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var raw sql.NullString
+	err = tx.QueryRow("SELECT metadata FROM projects WHERE id = ?", projectID).Scan(&raw)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("project not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get metadata: %w", err)
+	}
+	metadata, err := decodeMetadata(raw.String)
+	if err != nil {
+		return err
+	}
+
+	if value == "" {
+		if _, ok := metadata[key]; !ok {
+			return fmt.Errorf("project %d has no metadata key %q", projectID, key)
+		}
+		delete(metadata, key)
+	} else {
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata[key] = value
+	}
+
+	encoded, err := encodeMetadata(metadata)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE projects SET metadata = ?, updated_at = ? WHERE id = ?", encoded, time.Now(), projectID); err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+	return nil
+}
+
+// GetMetadata returns a project's metadata; nil when it has none.
+func (m *Manager) GetMetadata(projectID int64) (map[string]string, error) {
+	var raw sql.NullString
+	err := m.db.QueryRow("SELECT metadata FROM projects WHERE id = ?", projectID).Scan(&raw)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("project not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata: %w", err)
+	}
+	return decodeMetadata(raw.String)
+}
+
+// errInvalidMetadata marks a metadata column that is not a JSON object of
+// strings.
+var errInvalidMetadata = errors.New("failed to parse project metadata")
+
+// decodeMetadata parses the metadata column; empty means no metadata.
+func decodeMetadata(raw string) (map[string]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	var metadata map[string]string
+	if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidMetadata, err)
+	}
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	return metadata, nil
+}
+
+// encodeMetadata serializes metadata for the metadata column, storing no
+// metadata as an empty string.
+func encodeMetadata(metadata map[string]string) (string, error) {
+	if len(metadata) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode project metadata: %w", err)
+	}
+	return string(data), nil
+}
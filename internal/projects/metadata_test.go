@@ -0,0 +1,178 @@
+package projects
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSetMetadata verifies keys can be set, overwritten and deleted
+func TestSetMetadata(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	project := createTagTestProject(t, manager, "annotated", "testnet")
+
+	if got, err := manager.GetMetadata(project.ID); err != nil || got != nil {
+		t.Fatalf("GetMetadata() on a new project = %v, %v; want nil", got, err)
+	}
+
+	steps := []struct {
+		key, value string
+		want       map[string]string
+	}{
+		{"cadence", "client prefers weekly updates", map[string]string{"cadence": "client prefers weekly updates"}},
+		{"contact", "ops@example.com", map[string]string{"cadence": "client prefers weekly updates", "contact": "ops@example.com"}},
+		{"cadence", "monthly", map[string]string{"cadence": "monthly", "contact": "ops@example.com"}},
+		{"contact", "", map[string]string{"cadence": "monthly"}},
+		{"cadence", "", nil},
+	}
+	for _, step := range steps {
+		if err := manager.SetMetadata(project.ID, step.key, step.value); err != nil {
+			t.Fatalf("SetMetadata(%q, %q) failed: %v", step.key, step.value, err)
+		}
+		got, err := manager.GetMetadata(project.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, step.want) {
+			t.Errorf("after SetMetadata(%q, %q): metadata = %v, want %v", step.key, step.value, got, step.want)
+		}
+	}
+
+	if err := manager.SetMetadata(project.ID, "cadence", ""); err == nil {
+		t.Error("deleting a missing key should fail")
+	}
+}
+
+// TestSetMetadataValidation rejects bad keys and unknown projects
+func TestSetMetadataValidation(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	project := createTagTestProject(t, manager, "checked", "testnet")
+
+	for _, key := range []string{"", "has space", "a=b", "-leading"} {
+		if err := manager.SetMetadata(project.ID, key, "v"); err == nil {
+			t.Errorf("SetMetadata(%q) should fail", key)
+		}
+	}
+	if err := manager.SetMetadata(9999, "key", "v"); err == nil {
+		t.Error("SetMetadata on a missing project should fail")
+	}
+}
+
+// TestMetadataRoundTrip verifies metadata survives reopening the database
+// and is loaded with the project, and that UpdateProject keeps it.
+func TestMetadataRoundTrip(t *testing.T) {
+	manager := setupTestManager(t)
+
+	project := createTagTestProject(t, manager, "persisted", "mainnet")
+	want := map[string]string{"cadence": "weekly", "owner": "Jordan"}
+	for k, v := range want {
+		if err := manager.SetMetadata(project.ID, k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loaded, err := manager.GetProject(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded.Description = "edited"
+	if err := manager.UpdateProject(loaded); err != nil {
+		t.Fatal(err)
+	}
+	manager.Close()
+
+	reopened, err := NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+
+	got, err := reopened.GetProject(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Metadata, want) {
+		t.Errorf("GetProject().Metadata = %v, want %v", got.Metadata, want)
+	}
+
+	list, err := reopened.ListProjects("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("ListProjects() returned %d projects, want 1", len(list))
+	}
+	if !reflect.DeepEqual(list[0].Metadata, want) {
+		t.Errorf("ListProjects() metadata = %v, want %v", list[0].Metadata, want)
+	}
+}
+
+// TestCreateProjectSavesMetadata verifies metadata set before CreateProject
+// is stored with the new project.
+func TestCreateProjectSavesMetadata(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	want := map[string]string{"owner": "Jordan"}
+	project := &Project{Name: "seeded", Network: "testnet", ObjectID: "0xseeded", SitePath: "/tmp/seeded", Metadata: want}
+	if err := manager.CreateProject(project); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := manager.GetMetadata(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMetadata() = %v, want %v", got, want)
+	}
+}
+
+// TestListProjectsKeepsInvalidMetadata verifies a project whose metadata
+// column is corrupt is listed with the parse error instead of failing the
+// listing, and still counts toward the page total.
+func TestListProjectsKeepsInvalidMetadata(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	good := createTagTestProject(t, manager, "good", "testnet")
+	bad := createTagTestProject(t, manager, "bad", "testnet")
+	if _, err := manager.db.Exec(`UPDATE projects SET metadata = '{bad' WHERE id = ?`, bad.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := manager.ListProjects("", "")
+	if err != nil {
+		t.Fatalf("ListProjects() failed: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("ListProjects() returned %d projects, want 2", len(list))
+	}
+	for _, p := range list {
+		switch p.ID {
+		case good.ID:
+			if p.MetadataError != "" {
+				t.Errorf("project %d MetadataError = %q, want empty", p.ID, p.MetadataError)
+			}
+		case bad.ID:
+			if p.MetadataError == "" || p.Metadata != nil {
+				t.Errorf("project %d MetadataError = %q, Metadata = %v; want a parse error and no metadata", p.ID, p.MetadataError, p.Metadata)
+			}
+		}
+	}
+
+	page, total, err := manager.ListProjectsPage(ProjectFilter{}, 0, 10)
+	if err != nil {
+		t.Fatalf("ListProjectsPage() failed: %v", err)
+	}
+	if total != len(page) {
+		t.Errorf("ListProjectsPage() total = %d, but page has %d projects", total, len(page))
+	}
+
+	if _, err := manager.GetProject(bad.ID); err == nil {
+		t.Error("GetProject() on a project with corrupt metadata should fail")
+	}
+}
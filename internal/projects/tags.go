@@ -0,0 +1,91 @@
+package projects
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxTagLength bounds the length of a project tag.
+const maxTagLength = 64
+
+// tagPattern allows lowercase letters, digits, and . _ - separators.
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// NormalizeTag trims and lowercases a tag so "Client-Acme" and "client-acme"
+// are the same label.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// ValidateTag checks that a normalized tag is a single, reasonably short label.
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag cannot be empty")
+	}
+	if len(tag) > maxTagLength {
+		return fmt.Errorf("tag %q is longer than %d characters", tag, maxTagLength)
+	}
+	if !tagPattern.MatchString(tag) {
+		return fmt.Errorf("invalid tag %q: use letters, digits, '.', '_' or '-'", tag)
+	}
+	return nil
+}
+
+// AddTag labels a project. Adding a tag the project already has is a no-op.
+func (m *Manager) AddTag(projectID int64, tag string) error {
+	tag = NormalizeTag(tag)
+	if err := ValidateTag(tag); err != nil {
+		return err
+	}
+
+	if _, err := m.GetProject(projectID); err != nil {
+		return err
+	}
+
+	if _, err := m.db.Exec("INSERT OR IGNORE INTO project_tags (project_id, tag) VALUES (?, ?)", projectID, tag); err != nil {
+		return fmt.Errorf("failed to add tag: %w", err)
+	}
+	return nil
+}
+
+// RemoveTag removes a label from a project.
+func (m *Manager) RemoveTag(projectID int64, tag string) error {
+	tag = NormalizeTag(tag)
+
+	res, err := m.db.Exec("DELETE FROM project_tags WHERE project_id = ? AND tag = ?", projectID, tag)
+	if err != nil {
+		return fmt.Errorf("failed to remove tag: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("project %d has no tag %q", projectID, tag)
+	}
+	return nil
+}
+
+// GetTags returns a project's tags in alphabetical order.
+func (m *Manager) GetTags(projectID int64) ([]string, error) {
+	rows, err := m.db.Query("SELECT tag FROM project_tags WHERE project_id = ? ORDER BY tag", projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	return tags, nil
+}
+
+// ListByTag returns all projects carrying tag.
+func (m *Manager) ListByTag(tag string) ([]*Project, error) {
+	return m.ListProjectsWithFilter(ProjectFilter{Tags: []string{tag}})
+}
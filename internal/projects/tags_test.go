@@ -0,0 +1,190 @@
+package projects
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func createTagTestProject(t *testing.T, m *Manager, name, network string) *Project {
+	t.Helper()
+	p := &Project{
+		Name:       name,
+		Network:    network,
+		ObjectID:   "0x" + name,
+		WalletAddr: "0xwallet",
+		Epochs:     1,
+		SitePath:   "/tmp/" + name,
+	}
+	if err := m.CreateProject(p); err != nil {
+		t.Fatalf("Failed to create project %s: %v", name, err)
+	}
+	return p
+}
+
+// TestAddAndRemoveTags verifies tags can be added, listed and removed
+func TestAddAndRemoveTags(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	project := createTagTestProject(t, manager, "tagged", "testnet")
+
+	for _, tag := range []string{"client-acme", " Archive-2024 "} {
+		if err := manager.AddTag(project.ID, tag); err != nil {
+			t.Fatalf("AddTag(%q) failed: %v", tag, err)
+		}
+	}
+
+	got, err := manager.GetProject(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"archive-2024", "client-acme"}; !reflect.DeepEqual(got.Tags, want) {
+		t.Errorf("Tags = %v, want %v", got.Tags, want)
+	}
+
+	if err := manager.RemoveTag(project.ID, "ARCHIVE-2024"); err != nil {
+		t.Fatalf("RemoveTag failed: %v", err)
+	}
+	tags, err := manager.GetTags(project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"client-acme"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("Tags after remove = %v, want %v", tags, want)
+	}
+
+	if err := manager.RemoveTag(project.ID, "missing"); err == nil {
+		t.Error("Expected error removing a tag the project does not have")
+	}
+}
+
+// TestTagUniquenessPerProject verifies a tag is stored once per project but can be shared
+func TestTagUniquenessPerProject(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	first := createTagTestProject(t, manager, "first", "testnet")
+	second := createTagTestProject(t, manager, "second", "testnet")
+
+	for i := 0; i < 3; i++ {
+		if err := manager.AddTag(first.ID, "client-acme"); err != nil {
+			t.Fatalf("AddTag failed on attempt %d: %v", i+1, err)
+		}
+	}
+	if err := manager.AddTag(first.ID, "Client-Acme"); err != nil {
+		t.Fatalf("AddTag with different case failed: %v", err)
+	}
+	if err := manager.AddTag(second.ID, "client-acme"); err != nil {
+		t.Fatalf("AddTag on second project failed: %v", err)
+	}
+
+	tags, err := manager.GetTags(first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 1 {
+		t.Errorf("Expected tag stored once, got %v", tags)
+	}
+
+	tagged, err := manager.ListByTag("client-acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tagged) != 2 {
+		t.Errorf("Expected tag shared by 2 projects, got %d", len(tagged))
+	}
+}
+
+// TestAddTagValidation verifies malformed tags and unknown projects are rejected
+func TestAddTagValidation(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	project := createTagTestProject(t, manager, "validate", "testnet")
+
+	for _, tag := range []string{"", "   ", "two words", "-leading", "semi;colon"} {
+		if err := manager.AddTag(project.ID, tag); err == nil {
+			t.Errorf("AddTag(%q) should fail", tag)
+		}
+	}
+
+	if err := manager.AddTag(project.ID+100, "client-acme"); err == nil {
+		t.Error("AddTag should fail for a missing project")
+	}
+}
+
+// TestListProjectsByTag verifies tag filters combine with network and status filters
+func TestListProjectsByTag(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	acmeTest := createTagTestProject(t, manager, "acme-test", "testnet")
+	acmeMain := createTagTestProject(t, manager, "acme-main", "mainnet")
+	acmeOld := createTagTestProject(t, manager, "acme-old", "testnet")
+	createTagTestProject(t, manager, "untagged", "testnet")
+
+	for _, p := range []*Project{acmeTest, acmeMain, acmeOld} {
+		if err := manager.AddTag(p.ID, "client-acme"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := manager.AddTag(acmeOld.ID, "archive-2024"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.ArchiveProject(acmeOld.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter ProjectFilter
+		want   []string
+	}{
+		{"tag only", ProjectFilter{Tags: []string{"client-acme"}}, []string{"acme-main", "acme-old", "acme-test"}},
+		{"tag and network", ProjectFilter{Network: "testnet", Tags: []string{"client-acme"}}, []string{"acme-old", "acme-test"}},
+		{"tag and status", ProjectFilter{Status: "active", Tags: []string{"client-acme"}}, []string{"acme-main", "acme-test"}},
+		{"all tags required", ProjectFilter{Tags: []string{"client-acme", "archive-2024"}}, []string{"acme-old"}},
+		{"tag normalized", ProjectFilter{Tags: []string{"CLIENT-ACME"}, Network: "mainnet"}, []string{"acme-main"}},
+		{"unknown tag", ProjectFilter{Tags: []string{"nope"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := manager.ListProjectsWithFilter(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, p := range list {
+				names = append(names, p.Name)
+			}
+			sort.Strings(names)
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("got %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+// TestDeleteProjectRemovesTags verifies tags are removed with their project
+func TestDeleteProjectRemovesTags(t *testing.T) {
+	manager := setupTestManager(t)
+	defer manager.Close()
+
+	project := createTagTestProject(t, manager, "doomed", "testnet")
+	if err := manager.AddTag(project.ID, "client-acme"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.DeleteProjectWithOptions(project.ID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+	if err := manager.db.QueryRow("SELECT COUNT(*) FROM project_tags WHERE project_id = ?", project.ID).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("Expected tags to be deleted with project, found %d", count)
+	}
+}
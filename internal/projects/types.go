@@ -2,8 +2,12 @@ package projects
 
 import (
 	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/walrus"
 )
 
@@ -23,10 +27,81 @@ type Project struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 	LastDeployAt time.Time `json:"last_deploy_at"`
 	DeployCount  int       `json:"deploy_count"` // Number of times deployed
-	Status       string    `json:"status"`       // active, archived, etc.
+	Status       string    `json:"status"`       // draft, active, archived, or destroyed
+	Deletable    bool      `json:"deletable"`    // Blobs were stored as deletable
 	// Metadata for ws-resources.json (displayed on wallets/explorers)
 	Description string `json:"description"` // Site description
 	ImageURL    string `json:"image_url"`   // Site logo/image URL
+	// Freeform labels such as "client-acme" or "archive-2024"
+	Tags []string `json:"tags,omitempty"`
+	// Operational notes such as "cadence": "weekly updates"
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Why Metadata could not be read; empty when it parsed
+	MetadataError string `json:"metadata_error,omitempty"`
+	// Set when the project was soft-deleted; nil for live projects
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// LinkedSuiNSDomain returns the SuiNS domain recorded for the project's site,
+// from the project itself or, failing that, walrus.suinsDomain in its walgo.yaml.
+func (p *Project) LinkedSuiNSDomain() string {
+	if domain := strings.TrimSpace(p.SuiNS); domain != "" {
+		return domain
+	}
+	if p.SitePath == "" {
+		return ""
+	}
+	cfg, err := config.LoadConfigFrom(p.SitePath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(cfg.WalrusConfig.SuiNSDomain)
+}
+
+// SiteSize returns the total size in bytes of the files in the project's
+// publish directory (hugo.publishDir of its walgo.yaml). ok is false when the
+// site has no walgo.yaml or has not been built.
+func (p *Project) SiteSize() (size int64, ok bool) {
+	if p.SitePath == "" {
+		return 0, false
+	}
+	cfg, err := config.LoadConfigFrom(p.SitePath)
+	if err != nil {
+		return 0, false
+	}
+	err = filepath.WalkDir(filepath.Join(p.SitePath, cfg.HugoConfig.PublishDir), func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, false
+	}
+	return size, true
+}
+
+// PortalURL returns the public wal.app URL of the project's site, taken from
+// its linked SuiNS domain. Empty when no valid domain is recorded or the site
+// is not on mainnet, the only network wal.app serves.
+func (p *Project) PortalURL() string {
+	if p.Network != "mainnet" {
+		return ""
+	}
+	return walrus.SuiNSPortalURL(p.LinkedSuiNSDomain())
+}
+
+// ProjectFilter narrows a project listing. Empty fields match everything.
+type ProjectFilter struct {
+	Network string
+	Status  string
+	Tags    []string // Projects must carry every tag
+	Deleted bool     // List soft-deleted projects instead of live ones
 }
 
 // DeploymentRecord represents a single deployment of a project
@@ -42,6 +117,21 @@ type DeploymentRecord struct {
 	Success   bool      `json:"success"`
 	Error     string    `json:"error"` // Error message if failed
 	CreatedAt time.Time `json:"created_at"`
+	// FileToBlobID optionally maps each deployed file to its blob ID.
+	// Stored by RecordDeployment; load it with GetDeploymentBlobs.
+	FileToBlobID map[string]string `json:"file_to_blob_id,omitempty"`
+	// CostWAL and CostSUI are what the deployment spent. CostActual is true
+	// when they were reported by site-builder or read from the chain, and
+	// false when GasFee is only an estimate.
+	CostWAL    float64 `json:"cost_wal,omitempty"`
+	CostSUI    float64 `json:"cost_sui,omitempty"`
+	CostActual bool    `json:"cost_actual,omitempty"`
+}
+
+// BlobLease is a successful deployment's storage of a blob.
+type BlobLease struct {
+	StoredAt time.Time // When the deployment was recorded
+	Epochs   int       // Epochs the deployment stored the blob for
 }
 
 // ProjectStats provides statistics about a project
@@ -49,7 +139,10 @@ type ProjectStats struct {
 	TotalDeployments  int
 	SuccessfulDeploys int
 	FailedDeploys     int
-	TotalGasSpent     string
+	TotalGasSpent     string  // Sum of the actual costs, e.g. "0.120000 WAL + 0.010000 SUI"
+	TotalWAL          float64 // WAL spent by successful deploys with actual costs
+	TotalSUI          float64 // SUI spent by successful deploys with actual costs
+	EstimatedDeploys  int     // Successful deploys recorded with only an estimate
 	FirstDeployment   time.Time
 	LastDeployment    time.Time
 	CurrentNetwork    string
@@ -70,19 +163,19 @@ func GetNetworkConfig(network string) NetworkConfig {
 		return NetworkConfig{
 			Name:          "mainnet",
 			EpochDuration: "2 weeks",
-			MaxEpochs:     53,
+			MaxEpochs:     config.MaxEpochs,
 		}
 	case "testnet":
 		return NetworkConfig{
 			Name:          "testnet",
 			EpochDuration: "1 day",
-			MaxEpochs:     53,
+			MaxEpochs:     config.MaxEpochs,
 		}
 	default:
 		return NetworkConfig{
 			Name:          "testnet",
 			EpochDuration: "1 day",
-			MaxEpochs:     53,
+			MaxEpochs:     config.MaxEpochs,
 		}
 	}
 }
@@ -121,6 +214,48 @@ type CostEstimate struct {
 	WALRange     string  // WAL cost range (e.g., "0.01 - 0.02")
 	SUICostRange string  // SUI cost range
 	Summary      string  // Human-readable summary
+
+	fileCount int
+	epochs    int
+}
+
+// UseDryRunGas replaces the heuristic SUI gas with a figure from a
+// transaction dry-run. The range allows for gas price movement before the
+// real transaction lands.
+func (c *CostEstimate) UseDryRunGas(sui float64) {
+	c.SUI = sui
+	c.SUICostRange = formatSmallRange(sui, sui*1.1, "SUI")
+	c.Summary = costSummary(c.WAL, sui, c.fileCount, c.epochs) + " (SUI from dry-run)"
+}
+
+// costSummary is the one-line summary shown for a cost estimate.
+func costSummary(wal, sui float64, fileCount, epochs int) string {
+	return fmt.Sprintf("~%s WAL + ~%.4f SUI for %d files (%d epochs)", formatSmallValue(wal), sui, fileCount, epochs)
+}
+
+// FormatCost formats an actual deploy cost the way GasFee records it, e.g.
+// "0.052000 WAL + 0.005100 SUI". Zero amounts are left out.
+func FormatCost(wal, sui float64) string {
+	switch {
+	case wal > 0 && sui > 0:
+		return fmt.Sprintf("%.6f WAL + %.6f SUI", wal, sui)
+	case wal > 0:
+		return fmt.Sprintf("%.6f WAL", wal)
+	case sui > 0:
+		return fmt.Sprintf("%.6f SUI", sui)
+	default:
+		return ""
+	}
+}
+
+// DeployCostFee returns the GasFee to record for a deploy: the actual wal and
+// sui spent when either is known, or an estimate for siteSize and epochs.
+// actual reports which one it is.
+func DeployCostFee(wal, sui float64, network string, siteSize int64, epochs int) (gasFee string, actual bool) {
+	if cost := FormatCost(wal, sui); cost != "" {
+		return cost, true
+	}
+	return EstimateGasFeeWithEpochs(network, siteSize, epochs), false
 }
 
 // EstimateGasFee provides a professional estimate of gas fees for deployment
@@ -188,8 +323,9 @@ func EstimateGasFeeDetailed(network string, siteSize int64, epochs int, fileCoun
 		SUI:          breakdown.GasCostSUI,
 		WALRange:     formatSmallRange(breakdown.MinTotalWAL, breakdown.MaxTotalWAL, "WAL"),
 		SUICostRange: formatSmallRange(breakdown.MinTotalSUI, breakdown.MaxTotalSUI, "SUI"),
-		Summary: fmt.Sprintf("~%s WAL + ~%.4f SUI for %d files (%d epochs)",
-			formatSmallValue(breakdown.TotalWAL), breakdown.GasCostSUI, breakdown.FileCount, epochs),
+		Summary:      costSummary(breakdown.TotalWAL, breakdown.GasCostSUI, breakdown.FileCount, epochs),
+		fileCount:    breakdown.FileCount,
+		epochs:       epochs,
 	}, nil
 }
 
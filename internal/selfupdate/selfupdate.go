@@ -0,0 +1,328 @@
+// Package selfupdate downloads walgo release binaries, verifies them against
+// the release checksums and replaces the running executable.
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LatestReleaseURL is the GitHub API endpoint for the latest walgo release.
+const LatestReleaseURL = "https://api.github.com/repos/selimozten/walgo/releases/latest"
+
+// maxDownloadSize caps a release download, archive or checksums file.
+const maxDownloadSize = 200 << 20
+
+var (
+	// ErrChecksumMismatch is returned when a download does not match its
+	// published checksum.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrChecksumMissing is returned when the checksums file has no entry
+	// for a download.
+	ErrChecksumMissing = errors.New("no checksum published")
+	// ErrNoAsset is returned when a release has no binary for the platform.
+	ErrNoAsset = errors.New("no release binary for this platform")
+)
+
+// Release is a published walgo release.
+type Release struct {
+	Version string // Without the leading "v"
+	URL     string // Release page
+	Assets  map[string]string
+}
+
+// FetchLatestRelease reads the latest release from the GitHub API at apiURL.
+func FetchLatestRelease(ctx context.Context, client *http.Client, apiURL string) (*Release, error) {
+	body, err := download(ctx, client, apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest release: %w", err)
+	}
+
+	var raw struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+		Assets  []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+	if raw.TagName == "" {
+		return nil, fmt.Errorf("release has no tag")
+	}
+
+	rel := &Release{
+		Version: strings.TrimPrefix(raw.TagName, "v"),
+		URL:     raw.HTMLURL,
+		Assets:  make(map[string]string, len(raw.Assets)),
+	}
+	for _, a := range raw.Assets {
+		rel.Assets[a.Name] = a.URL
+	}
+	return rel, nil
+}
+
+// ArchiveName is the release archive for a platform, as named by the
+// goreleaser configuration: walgo_<version>_<os>_<arch>.tar.gz, or .zip on
+// Windows.
+func ArchiveName(version, goos, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("walgo_%s_%s_%s%s", version, goos, goarch, ext)
+}
+
+// ChecksumsName is the checksums file published with a release.
+func ChecksumsName(version string) string {
+	return fmt.Sprintf("walgo_%s_checksums.txt", version)
+}
+
+// ParseChecksums reads a sha256sum-style file ("<hex>  <name>" per line).
+func ParseChecksums(data []byte) (map[string]string, error) {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed checksums line %q", line)
+		}
+		sum := strings.ToLower(fields[0])
+		if _, err := hex.DecodeString(sum); err != nil || len(sum) != sha256.Size*2 {
+			return nil, fmt.Errorf("malformed checksum for %s", fields[1])
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+// VerifyChecksum checks data against the checksum published for name.
+func VerifyChecksum(data []byte, name string, sums map[string]string) error {
+	want, ok := sums[name]
+	if !ok {
+		return fmt.Errorf("%w for %s", ErrChecksumMissing, name)
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("%w for %s: expected %s, got %s", ErrChecksumMismatch, name, want, got)
+	}
+	return nil
+}
+
+// DownloadVerified downloads the release archive for goos/goarch and its
+// checksums file, verifies the archive and returns the walgo binary in it.
+func DownloadVerified(ctx context.Context, client *http.Client, rel *Release, goos, goarch string) ([]byte, error) {
+	archiveName := ArchiveName(rel.Version, goos, goarch)
+	archiveURL, ok := rel.Assets[archiveName]
+	if !ok {
+		return nil, fmt.Errorf("%w (%s/%s): %s not in release %s", ErrNoAsset, goos, goarch, archiveName, rel.Version)
+	}
+	sumsURL, ok := rel.Assets[ChecksumsName(rel.Version)]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no checksums file; refusing to install an unverified binary", rel.Version)
+	}
+
+	sumsData, err := download(ctx, client, sumsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download checksums: %w", err)
+	}
+	sums, err := ParseChecksums(sumsData)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := download(ctx, client, archiveURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", archiveName, err)
+	}
+	if err := VerifyChecksum(archive, archiveName, sums); err != nil {
+		return nil, err
+	}
+	return ExtractBinary(archive, archiveName)
+}
+
+// ExtractBinary returns the walgo executable from a release archive.
+func ExtractBinary(archive []byte, archiveName string) ([]byte, error) {
+	return ExtractExecutable(archive, archiveName, "walgo")
+}
+
+// ExtractExecutable returns the executable called name (or name.exe) from a
+// .zip or .tar.gz archive, at any depth.
+func ExtractExecutable(archive []byte, archiveName, name string) ([]byte, error) {
+	if strings.HasSuffix(archiveName, ".zip") {
+		return extractFromZip(archive, name)
+	}
+	return extractFromTarGz(archive, name)
+}
+
+func isExecutableNamed(file, name string) bool {
+	base := path.Base(filepath.ToSlash(file))
+	return base == name || base == name+".exe"
+}
+
+func extractFromTarGz(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && isExecutableNamed(hdr.Name, name) {
+			return io.ReadAll(io.LimitReader(tr, maxDownloadSize))
+		}
+	}
+	return nil, fmt.Errorf("%s binary not found in archive", name)
+}
+
+func extractFromZip(archive []byte, name string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isExecutableNamed(f.Name, name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		defer rc.Close()
+		return io.ReadAll(io.LimitReader(rc, maxDownloadSize))
+	}
+	return nil, fmt.Errorf("%s binary not found in archive", name)
+}
+
+// ReplaceExecutable swaps exePath for binary. The new binary is written next
+// to exePath and renamed into place, so exePath is never half-written. The
+// old binary is kept as exePath+".old" until check, when given, accepts the
+// new one; if anything fails the old binary is put back.
+func ReplaceExecutable(exePath string, binary []byte, check func(path string) error) (err error) {
+	info, err := os.Stat(exePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", exePath, err)
+	}
+	dir := filepath.Dir(exePath)
+
+	tmp, err := os.CreateTemp(dir, ".walgo-update-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0o111); err != nil {
+		return fmt.Errorf("failed to make new binary executable: %w", err)
+	}
+
+	oldPath := exePath + ".old"
+	_ = os.Remove(oldPath)
+	if err := os.Rename(exePath, oldPath); err != nil {
+		return fmt.Errorf("failed to move current binary aside: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(exePath)
+			if rbErr := os.Rename(oldPath, exePath); rbErr != nil {
+				err = fmt.Errorf("%w; rollback failed, previous binary is at %s: %v", err, oldPath, rbErr)
+			}
+		}
+	}()
+
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		return fmt.Errorf("failed to install new binary: %w", err)
+	}
+	if check != nil {
+		if err := check(exePath); err != nil {
+			return fmt.Errorf("new binary failed to run: %w", err)
+		}
+	}
+
+	// A running executable cannot be deleted on Windows; the .old file is
+	// removed by the next update instead
+	_ = os.Remove(oldPath)
+	return nil
+}
+
+// packageManagerPaths are path fragments of installs owned by a package
+// manager, which should update walgo instead.
+var packageManagerPaths = []struct{ fragment, manager string }{
+	{"/Cellar/", "Homebrew"},
+	{"/homebrew/", "Homebrew"},
+	{"/linuxbrew/", "Homebrew"},
+	{"/nix/store/", "Nix"},
+	{"/snap/", "Snap"},
+	{"/scoop/", "Scoop"},
+	{"/chocolatey/", "Chocolatey"},
+	{"/usr/bin/", "the system package manager"},
+}
+
+// PackageManager reports the package manager that owns the executable at
+// exePath (with symlinks resolved), if any.
+func PackageManager(exePath string) (string, bool) {
+	p := filepath.ToSlash(exePath)
+	for _, pm := range packageManagerPaths {
+		if strings.Contains(strings.ToLower(p), strings.ToLower(pm.fragment)) {
+			return pm.manager, true
+		}
+	}
+	return "", false
+}
+
+// download GETs url and returns the body, failing on non-200 responses.
+func download(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "walgo-self-update")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize))
+}
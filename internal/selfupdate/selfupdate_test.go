@@ -0,0 +1,269 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const fixtureBinary = "#!/bin/sh\necho walgo v9.9.9\n"
+
+// tarGzFixture builds a release archive holding files.
+func tarGzFixture(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zipFixture(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestArchiveNames(t *testing.T) {
+	if got := ArchiveName("1.2.0", "linux", "amd64"); got != "walgo_1.2.0_linux_amd64.tar.gz" {
+		t.Errorf("ArchiveName(linux) = %q", got)
+	}
+	if got := ArchiveName("1.2.0", "windows", "arm64"); got != "walgo_1.2.0_windows_arm64.zip" {
+		t.Errorf("ArchiveName(windows) = %q", got)
+	}
+	if got := ChecksumsName("1.2.0"); got != "walgo_1.2.0_checksums.txt" {
+		t.Errorf("ChecksumsName() = %q", got)
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	a := strings.Repeat("ab", 32)
+	b := strings.Repeat("CD", 32)
+	sums, err := ParseChecksums([]byte(a + "  walgo_1.0.0_linux_amd64.tar.gz\n\n" + b + " *walgo_1.0.0_windows_amd64.zip\n"))
+	if err != nil {
+		t.Fatalf("ParseChecksums() error = %v", err)
+	}
+	if sums["walgo_1.0.0_linux_amd64.tar.gz"] != a || sums["walgo_1.0.0_windows_amd64.zip"] != strings.ToLower(b) {
+		t.Errorf("ParseChecksums() = %v", sums)
+	}
+
+	for _, bad := range []string{"nothex  file.tar.gz\n", "abcd  file.tar.gz\n", a + "\n"} {
+		if _, err := ParseChecksums([]byte(bad)); err == nil {
+			t.Errorf("ParseChecksums(%q) accepted malformed input", bad)
+		}
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	archive := tarGzFixture(t, map[string]string{"walgo": fixtureBinary})
+	name := "walgo_1.0.0_linux_amd64.tar.gz"
+	sums := map[string]string{name: sha256Hex(archive)}
+
+	if err := VerifyChecksum(archive, name, sums); err != nil {
+		t.Errorf("good download rejected: %v", err)
+	}
+
+	tampered := append([]byte{}, archive...)
+	tampered[len(tampered)-1] ^= 0xff
+	if err := VerifyChecksum(tampered, name, sums); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("tampered download: error = %v, want ErrChecksumMismatch", err)
+	}
+
+	if err := VerifyChecksum(archive, "walgo_1.0.0_darwin_arm64.tar.gz", sums); !errors.Is(err, ErrChecksumMissing) {
+		t.Errorf("unlisted download: error = %v, want ErrChecksumMissing", err)
+	}
+}
+
+func TestExtractBinary(t *testing.T) {
+	tgz := tarGzFixture(t, map[string]string{"README.md": "docs", "walgo": fixtureBinary})
+	if got, err := ExtractBinary(tgz, "walgo_1.0.0_linux_amd64.tar.gz"); err != nil || string(got) != fixtureBinary {
+		t.Errorf("ExtractBinary(tar.gz) = %q, %v", got, err)
+	}
+
+	zipped := zipFixture(t, map[string]string{"LICENSE": "MIT", "walgo.exe": fixtureBinary})
+	if got, err := ExtractBinary(zipped, "walgo_1.0.0_windows_amd64.zip"); err != nil || string(got) != fixtureBinary {
+		t.Errorf("ExtractBinary(zip) = %q, %v", got, err)
+	}
+
+	empty := tarGzFixture(t, map[string]string{"README.md": "docs"})
+	if _, err := ExtractBinary(empty, "walgo_1.0.0_linux_amd64.tar.gz"); err == nil {
+		t.Error("expected error for an archive without walgo")
+	}
+}
+
+// releaseServer serves a release whose archive is archive and whose
+// checksums file lists sum for it.
+func releaseServer(t *testing.T, archive []byte, sum string) (*httptest.Server, *Release) {
+	t.Helper()
+	archiveName := ArchiveName("2.0.0", "linux", "amd64")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"tag_name":"v2.0.0","html_url":"https://example.com/v2.0.0","assets":[
+			{"name":%q,"browser_download_url":"http://%s/archive"},
+			{"name":%q,"browser_download_url":"http://%s/sums"}]}`,
+			archiveName, r.Host, ChecksumsName("2.0.0"), r.Host)
+	})
+	mux.HandleFunc("/archive", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(archive) })
+	mux.HandleFunc("/sums", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  %s\n", sum, archiveName)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	rel, err := FetchLatestRelease(context.Background(), server.Client(), server.URL+"/latest")
+	if err != nil {
+		t.Fatalf("FetchLatestRelease() error = %v", err)
+	}
+	return server, rel
+}
+
+func TestDownloadVerified(t *testing.T) {
+	archive := tarGzFixture(t, map[string]string{"walgo": fixtureBinary})
+
+	t.Run("good download", func(t *testing.T) {
+		server, rel := releaseServer(t, archive, sha256Hex(archive))
+		if rel.Version != "2.0.0" || rel.URL != "https://example.com/v2.0.0" {
+			t.Errorf("release = %+v", rel)
+		}
+		binary, err := DownloadVerified(context.Background(), server.Client(), rel, "linux", "amd64")
+		if err != nil {
+			t.Fatalf("DownloadVerified() error = %v", err)
+		}
+		if string(binary) != fixtureBinary {
+			t.Errorf("binary = %q", binary)
+		}
+	})
+
+	t.Run("tampered download", func(t *testing.T) {
+		tampered := tarGzFixture(t, map[string]string{"walgo": "#!/bin/sh\necho pwned\n"})
+		server, rel := releaseServer(t, tampered, sha256Hex(archive))
+		_, err := DownloadVerified(context.Background(), server.Client(), rel, "linux", "amd64")
+		if !errors.Is(err, ErrChecksumMismatch) {
+			t.Fatalf("DownloadVerified() error = %v, want ErrChecksumMismatch", err)
+		}
+	})
+
+	t.Run("no binary for platform", func(t *testing.T) {
+		server, rel := releaseServer(t, archive, sha256Hex(archive))
+		_, err := DownloadVerified(context.Background(), server.Client(), rel, "plan9", "386")
+		if !errors.Is(err, ErrNoAsset) {
+			t.Fatalf("DownloadVerified() error = %v, want ErrNoAsset", err)
+		}
+	})
+
+	t.Run("no checksums file", func(t *testing.T) {
+		server, rel := releaseServer(t, archive, sha256Hex(archive))
+		delete(rel.Assets, ChecksumsName(rel.Version))
+		if _, err := DownloadVerified(context.Background(), server.Client(), rel, "linux", "amd64"); err == nil {
+			t.Fatal("expected error without a checksums file")
+		}
+	})
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "walgo")
+	if err := os.WriteFile(exe, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ReplaceExecutable(exe, []byte("new"), func(string) error { return nil }); err != nil {
+		t.Fatalf("ReplaceExecutable() error = %v", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "new" {
+		t.Errorf("binary = %q, want new", data)
+	}
+	if runtime.GOOS != "windows" {
+		if info, _ := os.Stat(exe); info.Mode().Perm()&0o111 == 0 {
+			t.Errorf("new binary mode = %v, want executable", info.Mode())
+		}
+	}
+	if _, err := os.Stat(exe + ".old"); !os.IsNotExist(err) {
+		t.Error("old binary left behind")
+	}
+}
+
+func TestReplaceExecutableRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "walgo")
+	if err := os.WriteFile(exe, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	err := ReplaceExecutable(exe, []byte("broken"), func(string) error { return errors.New("exec format error") })
+	if err == nil || !strings.Contains(err.Error(), "exec format error") {
+		t.Fatalf("ReplaceExecutable() error = %v, want the check failure", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old" {
+		t.Errorf("binary = %q, want the old one restored", data)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("leftover files after rollback: %v", entries)
+	}
+}
+
+func TestPackageManager(t *testing.T) {
+	tests := []struct {
+		path    string
+		manager string
+	}{
+		{"/opt/homebrew/Cellar/walgo/1.0.0/bin/walgo", "Homebrew"},
+		{"/home/linuxbrew/.linuxbrew/bin/walgo", "Homebrew"},
+		{"/nix/store/abc-walgo-1.0.0/bin/walgo", "Nix"},
+		{"/snap/walgo/12/bin/walgo", "Snap"},
+		{"C:/Users/me/scoop/apps/walgo/current/walgo.exe", "Scoop"},
+		{"/usr/bin/walgo", "the system package manager"},
+		{"/usr/local/bin/walgo", ""},
+		{"/home/me/.local/bin/walgo", ""},
+	}
+	for _, tt := range tests {
+		manager, ok := PackageManager(filepath.FromSlash(tt.path))
+		if manager != tt.manager || ok != (tt.manager != "") {
+			t.Errorf("PackageManager(%q) = %q, %v; want %q", tt.path, manager, ok, tt.manager)
+		}
+	}
+}
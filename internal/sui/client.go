@@ -2,7 +2,9 @@
 package sui
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -18,12 +20,17 @@ func getSuiPath() (string, error) {
 // runCommand executes a sui command and returns the output
 // It filters out warning messages from stderr
 func runCommand(args ...string) (string, error) {
+	return runCommandContext(context.Background(), args...)
+}
+
+// runCommandContext is runCommand, killing the sui process if ctx is cancelled.
+func runCommandContext(ctx context.Context, args ...string) (string, error) {
 	suiPath, err := getSuiPath()
 	if err != nil {
 		return "", fmt.Errorf("sui CLI not found: %w", err)
 	}
 
-	cmd := executil.Command(suiPath, args...)
+	cmd := executil.CommandContext(ctx, suiPath, args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return string(output), fmt.Errorf("sui command failed: %w\nOutput: %s", err, string(output))
@@ -54,8 +61,13 @@ func filterWarnings(output string) string {
 // runCommandJSON executes a sui command with --json flag and returns only the JSON output
 // It strips any warning messages or non-JSON content that may precede the JSON
 func runCommandJSON(args ...string) (string, error) {
+	return runCommandJSONContext(context.Background(), args...)
+}
+
+// runCommandJSONContext is runCommandJSON, honoring ctx cancellation.
+func runCommandJSONContext(ctx context.Context, args ...string) (string, error) {
 	args = append(args, "--json")
-	output, err := runCommand(args...)
+	output, err := runCommandContext(ctx, args...)
 	if err != nil {
 		return output, err
 	}
@@ -99,7 +111,11 @@ func extractJSON(output string) string {
 
 // GetActiveEnv returns the current active Sui environment (testnet/mainnet)
 func GetActiveEnv() (string, error) {
-	return runCommand("client", "active-env")
+	return getActiveEnv(context.Background())
+}
+
+func getActiveEnv(ctx context.Context) (string, error) {
+	return runCommandContext(ctx, "client", "active-env")
 }
 
 // GetActiveAddress returns the current active wallet address
@@ -107,6 +123,35 @@ func GetActiveAddress() (string, error) {
 	return runCommand("client", "active-address")
 }
 
+// GetEnvs returns the aliases of the environments configured in the Sui client
+func GetEnvs() ([]string, error) {
+	output, err := runCommandJSON("client", "envs")
+	if err != nil {
+		return nil, err
+	}
+	return parseEnvsJSON(output)
+}
+
+// parseEnvsJSON parses the JSON output from `sui client envs --json`:
+// [[{"alias":"testnet","rpc":"..."}, ...], "<active alias>"]
+func parseEnvsJSON(jsonOutput string) ([]string, error) {
+	var result []json.RawMessage
+	if err := json.Unmarshal([]byte(jsonOutput), &result); err != nil || len(result) == 0 {
+		return nil, fmt.Errorf("failed to parse envs: unexpected output %q", jsonOutput)
+	}
+	var envs []struct {
+		Alias string `json:"alias"`
+	}
+	if err := json.Unmarshal(result[0], &envs); err != nil {
+		return nil, fmt.Errorf("failed to parse envs: %w", err)
+	}
+	aliases := make([]string, 0, len(envs))
+	for _, env := range envs {
+		aliases = append(aliases, env.Alias)
+	}
+	return aliases, nil
+}
+
 // SwitchEnv switches to the specified network environment
 func SwitchEnv(network string) error {
 	_, err := runCommand("client", "switch", "--env", network)
@@ -127,7 +172,11 @@ type AddressesResponse struct {
 
 // GetAddresses returns all wallet addresses
 func GetAddresses() (*AddressesResponse, error) {
-	output, err := runCommandJSON("client", "addresses")
+	return getAddresses(context.Background())
+}
+
+func getAddresses(ctx context.Context) (*AddressesResponse, error) {
+	output, err := runCommandJSONContext(ctx, "client", "addresses")
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +213,11 @@ type BalanceInfo struct {
 
 // GetBalance returns the balance for the active address
 func GetBalance() (*BalanceInfo, error) {
-	output, err := runCommandJSON("client", "balance")
+	return getBalance(context.Background())
+}
+
+func getBalance(ctx context.Context) (*BalanceInfo, error) {
+	output, err := runCommandJSONContext(ctx, "client", "balance")
 	if err != nil {
 		return nil, err
 	}
@@ -314,10 +367,37 @@ type CreateAddressResult struct {
 	RecoveryPhrase string
 }
 
-// CreateAddressWithDetails creates a new address and returns full details including recovery phrase
+// KeySchemes are the key schemes `sui client new-address` accepts.
+var KeySchemes = []string{"ed25519", "secp256k1", "secp256r1"}
+
+// ErrInvalidKeyScheme is returned for a key scheme not in KeySchemes.
+var ErrInvalidKeyScheme = errors.New("invalid key scheme")
+
+// newAddressSource runs `sui client new-address`; replaced in tests.
+var newAddressSource = runCommandJSON
+
+// ValidateKeyScheme returns keyScheme in lower case, ed25519 when empty, or
+// an ErrInvalidKeyScheme error listing the valid schemes.
+func ValidateKeyScheme(keyScheme string) (string, error) {
+	scheme := strings.ToLower(strings.TrimSpace(keyScheme))
+	if scheme == "" {
+		return "ed25519", nil
+	}
+	for _, valid := range KeySchemes {
+		if scheme == valid {
+			return scheme, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q: must be one of %s", ErrInvalidKeyScheme, keyScheme, strings.Join(KeySchemes, ", "))
+}
+
+// CreateAddressWithDetails creates a new address and returns full details including recovery phrase.
+// The key scheme defaults to ed25519; any other value outside KeySchemes is
+// rejected before the sui CLI is run.
 func CreateAddressWithDetails(keyScheme string, alias string) (*CreateAddressResult, error) {
-	if keyScheme == "" {
-		keyScheme = "ed25519"
+	keyScheme, err := ValidateKeyScheme(keyScheme)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build command arguments
@@ -327,7 +407,7 @@ func CreateAddressWithDetails(keyScheme string, alias string) (*CreateAddressRes
 		args = append(args, alias)
 	}
 
-	output, err := runCommandJSON(args...)
+	output, err := newAddressSource(args...)
 	if err != nil {
 		return nil, err
 	}
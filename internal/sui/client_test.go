@@ -3,6 +3,8 @@ package sui
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -405,6 +407,24 @@ func TestParseBalanceJSON(t *testing.T) {
 	}
 }
 
+func TestParseEnvsJSON(t *testing.T) {
+	output := `[[{"alias":"testnet","rpc":"https://fullnode.testnet.sui.io:443","ws":null,"basic_auth":null},` +
+		`{"alias":"mainnet","rpc":"https://fullnode.mainnet.sui.io:443","ws":null,"basic_auth":null}],"testnet"]`
+	envs, err := parseEnvsJSON(output)
+	if err != nil {
+		t.Fatalf("parseEnvsJSON() error = %v", err)
+	}
+	if len(envs) != 2 || envs[0] != "testnet" || envs[1] != "mainnet" {
+		t.Errorf("parseEnvsJSON() = %v, want [testnet mainnet]", envs)
+	}
+
+	for _, bad := range []string{"", "{}", "[]", `["testnet"]`} {
+		if _, err := parseEnvsJSON(bad); err == nil {
+			t.Errorf("parseEnvsJSON(%q) should fail", bad)
+		}
+	}
+}
+
 func TestGetExplorerURL(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -793,6 +813,67 @@ func TestCreateAddressResult(t *testing.T) {
 	}
 }
 
+// stubNewAddress makes newAddressSource record its arguments and report a
+// new address.
+func stubNewAddress(t *testing.T) *[]string {
+	t.Helper()
+	orig := newAddressSource
+	t.Cleanup(func() { newAddressSource = orig })
+
+	var called []string
+	newAddressSource = func(args ...string) (string, error) {
+		called = args
+		return `{"alias":"fresh","address":"0xabc","keyScheme":"` + args[2] + `","recoveryPhrase":"one two"}`, nil
+	}
+	return &called
+}
+
+func TestCreateAddressWithDetailsKeySchemes(t *testing.T) {
+	tests := []struct {
+		scheme string
+		want   string
+	}{
+		{"", "ed25519"},
+		{"ed25519", "ed25519"},
+		{"secp256k1", "secp256k1"},
+		{"secp256r1", "secp256r1"},
+		{"Secp256K1", "secp256k1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want+"/"+tt.scheme, func(t *testing.T) {
+			called := stubNewAddress(t)
+
+			result, err := CreateAddressWithDetails(tt.scheme, "fresh")
+			if err != nil {
+				t.Fatalf("CreateAddressWithDetails(%q) error = %v", tt.scheme, err)
+			}
+			if got := strings.Join(*called, " "); got != "client new-address "+tt.want+" fresh" {
+				t.Errorf("sui called with %q", got)
+			}
+			if result.Address != "0xabc" || result.KeyScheme != tt.want {
+				t.Errorf("result = %+v", result)
+			}
+		})
+	}
+}
+
+func TestCreateAddressWithDetailsInvalidScheme(t *testing.T) {
+	called := stubNewAddress(t)
+
+	_, err := CreateAddressWithDetails("rsa", "")
+	if !errors.Is(err, ErrInvalidKeyScheme) {
+		t.Fatalf("error = %v, want ErrInvalidKeyScheme", err)
+	}
+	for _, scheme := range KeySchemes {
+		if !strings.Contains(err.Error(), scheme) {
+			t.Errorf("error %q does not list %s", err, scheme)
+		}
+	}
+	if *called != nil {
+		t.Errorf("sui was called with %v for an invalid scheme", *called)
+	}
+}
+
 func TestImportMethod(t *testing.T) {
 	// Test ImportMethod constants
 	if ImportFromMnemonic != "mnemonic" {
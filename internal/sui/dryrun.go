@@ -0,0 +1,137 @@
+package sui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxDryRunResources caps the resources added in a dry-run transaction to keep
+// the command line and the PTB within CLI limits. Gas for larger sites is
+// extrapolated linearly, which slightly overstates the fixed site-creation part.
+const maxDryRunResources = 100
+
+// SiteTransaction describes the Walrus Sites publish transaction to dry-run.
+type SiteTransaction struct {
+	PackageID string // Walrus Sites package on the active network
+	SiteName  string
+	Resources int // Files the site will contain
+}
+
+// Sources used by DryRunSiteTransaction; replaced in tests.
+var (
+	dryRunSource = func(ctx context.Context, args ...string) (string, error) {
+		return runCommandJSONContext(ctx, args...)
+	}
+	dryRunSender = func(ctx context.Context) (string, error) {
+		return runCommandContext(ctx, "client", "active-address")
+	}
+)
+
+// DryRunSiteTransaction dry-runs a transaction equivalent to the one
+// site-builder sends for a new site with tx.Resources files, on the active
+// network, and returns the net gas used in MIST (computation plus storage,
+// minus the storage rebate).
+func DryRunSiteTransaction(ctx context.Context, tx SiteTransaction) (uint64, error) {
+	if !objectIDPattern.MatchString(tx.PackageID) {
+		return 0, fmt.Errorf("invalid package ID: %s", tx.PackageID)
+	}
+
+	sender, err := dryRunSender(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get active address: %w", err)
+	}
+	sender = strings.TrimSpace(sender)
+	if sender == "" {
+		return 0, fmt.Errorf("no active address configured")
+	}
+
+	resources := tx.Resources
+	if resources > maxDryRunResources {
+		resources = maxDryRunResources
+	}
+
+	output, err := dryRunSource(ctx, siteTransactionPTB(tx.PackageID, tx.SiteName, resources, sender)...)
+	if err != nil {
+		return 0, fmt.Errorf("dry-run failed: %w", err)
+	}
+
+	gasUsed, err := parseDryRunGas(output)
+	if err != nil {
+		return 0, err
+	}
+	if resources > 0 && tx.Resources > resources {
+		gasUsed = gasUsed * uint64(tx.Resources) / uint64(resources)
+	}
+	return gasUsed, nil
+}
+
+// siteTransactionPTB builds `sui client ptb` arguments that create a site with
+// the given number of placeholder resources and transfer it to sender.
+func siteTransactionPTB(packageID, siteName string, resources int, sender string) []string {
+	args := []string{
+		"client", "ptb",
+		"--move-call", packageID + "::metadata::new_metadata", "none", "none", "none", "none", "none",
+		"--assign", "metadata",
+		"--move-call", packageID + "::site::new_site", strconv.Quote(siteName), "metadata",
+		"--assign", "site",
+	}
+	for i := 0; i < resources; i++ {
+		name := fmt.Sprintf("r%d", i)
+		args = append(args,
+			"--move-call", packageID+"::site::new_resource", strconv.Quote(fmt.Sprintf("/resource-%d.html", i)), "0u256", "0u256", "none",
+			"--assign", name,
+			"--move-call", packageID+"::site::add_resource", "site", name,
+		)
+	}
+	return append(args, "--transfer-objects", "[site]", "@"+sender, "--dry-run")
+}
+
+// parseDryRunGas reads the net gas used from a dry-run response.
+func parseDryRunGas(jsonOutput string) (uint64, error) {
+	var resp struct {
+		Effects struct {
+			Status struct {
+				Status string `json:"status"`
+				Error  string `json:"error"`
+			} `json:"status"`
+			GasUsed struct {
+				ComputationCost json.Number `json:"computationCost"`
+				StorageCost     json.Number `json:"storageCost"`
+				StorageRebate   json.Number `json:"storageRebate"`
+			} `json:"gasUsed"`
+		} `json:"effects"`
+	}
+	if err := json.Unmarshal([]byte(jsonOutput), &resp); err != nil {
+		return 0, fmt.Errorf("failed to parse dry-run output: %w", err)
+	}
+
+	status := resp.Effects.Status
+	if status.Status != "" && status.Status != "success" {
+		return 0, fmt.Errorf("dry-run transaction failed: %s", status.Error)
+	}
+
+	gas := resp.Effects.GasUsed
+	if gas.ComputationCost == "" {
+		return 0, fmt.Errorf("dry-run output has no gas usage")
+	}
+	var costs [3]uint64
+	for i, n := range []json.Number{gas.ComputationCost, gas.StorageCost, gas.StorageRebate} {
+		if n == "" {
+			continue
+		}
+		v, err := strconv.ParseUint(n.String(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid gas value %q: %w", n, err)
+		}
+		costs[i] = v
+	}
+
+	total := costs[0] + costs[1]
+	if costs[2] >= total {
+		return 0, nil
+	}
+	return total - costs[2], nil
+}
@@ -0,0 +1,138 @@
+package sui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testSitesPackage = "0x26eb7ee8688da02c5f671679524e379f0b837a12f1d1d799f255b7eea260ad27"
+
+func dryRunJSON(status, computation, storage, rebate string) string {
+	return `{"effects":{"status":{"status":"` + status + `","error":"MoveAbort"},"gasUsed":{` +
+		`"computationCost":"` + computation + `","storageCost":"` + storage + `",` +
+		`"storageRebate":"` + rebate + `","nonRefundableStorageFee":"0"}}}`
+}
+
+// stubDryRun replaces the dry-run sources for one test and records the PTB arguments.
+func stubDryRun(t *testing.T, output string, runErr error) *[]string {
+	t.Helper()
+	origSource, origSender := dryRunSource, dryRunSender
+	t.Cleanup(func() { dryRunSource, dryRunSender = origSource, origSender })
+
+	var got []string
+	dryRunSource = func(_ context.Context, args ...string) (string, error) {
+		got = args
+		return output, runErr
+	}
+	dryRunSender = func(context.Context) (string, error) { return "0xabc\n", nil }
+	return &got
+}
+
+func TestDryRunSiteTransaction(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources int
+		output    string
+		runErr    error
+		want      uint64
+		wantErr   string
+	}{
+		{
+			name:      "net gas is computation plus storage minus rebate",
+			resources: 3,
+			output:    dryRunJSON("success", "1000000", "5000000", "1000000"),
+			want:      5000000,
+		},
+		{
+			name:      "large sites are extrapolated",
+			resources: 250,
+			output:    dryRunJSON("success", "1000000", "9000000", "0"),
+			want:      25000000,
+		},
+		{
+			name:      "rebate above cost floors at zero",
+			resources: 1,
+			output:    dryRunJSON("success", "100", "100", "500"),
+			want:      0,
+		},
+		{
+			name:      "failed transaction",
+			resources: 1,
+			output:    dryRunJSON("failure", "100", "0", "0"),
+			wantErr:   "MoveAbort",
+		},
+		{
+			name:      "cli error",
+			resources: 1,
+			runErr:    errors.New("cannot connect to RPC"),
+			wantErr:   "dry-run failed",
+		},
+		{
+			name:      "no gas in output",
+			resources: 1,
+			output:    `{"effects":{}}`,
+			wantErr:   "no gas usage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubDryRun(t, tt.output, tt.runErr)
+
+			got, err := DryRunSiteTransaction(context.Background(), SiteTransaction{
+				PackageID: testSitesPackage,
+				SiteName:  "blog",
+				Resources: tt.resources,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DryRunSiteTransaction() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("gas = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDryRunSiteTransactionPTB(t *testing.T) {
+	args := stubDryRun(t, dryRunJSON("success", "1", "0", "0"), nil)
+
+	if _, err := DryRunSiteTransaction(context.Background(), SiteTransaction{
+		PackageID: testSitesPackage,
+		SiteName:  `my "site"`,
+		Resources: 2,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	joined := strings.Join(*args, " ")
+	for _, want := range []string{
+		"client ptb",
+		testSitesPackage + "::site::new_site " + `"my \"site\""`,
+		testSitesPackage + "::site::add_resource site r1",
+		"--transfer-objects [site] @0xabc",
+		"--dry-run",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("PTB args missing %q:\n%s", want, joined)
+		}
+	}
+	if n := strings.Count(joined, "::site::new_resource"); n != 2 {
+		t.Errorf("new_resource calls = %d, want 2", n)
+	}
+}
+
+func TestDryRunSiteTransactionRejectsBadPackage(t *testing.T) {
+	stubDryRun(t, "", nil)
+	if _, err := DryRunSiteTransaction(context.Background(), SiteTransaction{PackageID: "site"}); err == nil {
+		t.Fatal("expected error for invalid package ID")
+	}
+}
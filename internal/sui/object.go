@@ -0,0 +1,193 @@
+package sui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrObjectNotFound is returned when an object does not exist (or was deleted)
+// on the active network.
+var ErrObjectNotFound = errors.New("object not found")
+
+var objectIDPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{1,64}$`)
+
+// ObjectInfo is the subset of `sui client object --json` walgo relies on.
+type ObjectInfo struct {
+	ObjectID string
+	Version  string
+	Type     string
+	// OwnerKind is AddressOwner, ObjectOwner, ConsensusAddressOwner, Shared or Immutable.
+	OwnerKind string
+	// Owner is the owning address (or parent object) when there is one.
+	Owner string
+}
+
+// OwnedByAddress reports whether the object is owned by address.
+func (o *ObjectInfo) OwnedByAddress(address string) bool {
+	if o.OwnerKind != "AddressOwner" && o.OwnerKind != "ConsensusAddressOwner" {
+		return false
+	}
+	return SameAddress(o.Owner, address)
+}
+
+// SameAddress compares two Sui addresses, ignoring case and zero padding.
+func SameAddress(a, b string) bool {
+	return NormalizeAddress(a) == NormalizeAddress(b) && NormalizeAddress(a) != ""
+}
+
+// NormalizeAddress returns a canonical form of a Sui address or object ID,
+// for use as a map key: lower case, without the 0x prefix and zero padding.
+func NormalizeAddress(addr string) string {
+	addr = strings.ToLower(strings.TrimSpace(addr))
+	addr = strings.TrimPrefix(addr, "0x")
+	trimmed := strings.TrimLeft(addr, "0")
+	if trimmed == "" && addr != "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// GetObject looks up an object on the active network, or on the fullnode set
+// with WithRPCURL. A missing or deleted object yields an error wrapping
+// ErrObjectNotFound.
+func GetObject(ctx context.Context, objectID string) (*ObjectInfo, error) {
+	if !objectIDPattern.MatchString(objectID) {
+		return nil, fmt.Errorf("invalid object ID format: %s", objectID)
+	}
+	if rpcURL := rpcURLFrom(ctx); rpcURL != "" {
+		return getObjectRPC(ctx, rpcURL, objectID)
+	}
+
+	output, err := runCommandJSONContext(ctx, "client", "object", objectID)
+	if err != nil {
+		if isObjectMissingOutput(output) {
+			return nil, fmt.Errorf("%s: %w", objectID, ErrObjectNotFound)
+		}
+		return nil, err
+	}
+
+	info, err := parseObjectJSON(output)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("%s: %w", objectID, ErrObjectNotFound)
+	}
+	return info, nil
+}
+
+// getObjectRPC looks objectID up with sui_getObject on the fullnode at rpcURL.
+func getObjectRPC(ctx context.Context, rpcURL, objectID string) (*ObjectInfo, error) {
+	result, err := callRPC(ctx, rpcURL, "sui_getObject", []interface{}{
+		objectID,
+		map[string]bool{"showType": true, "showOwner": true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// data for a live object, error (code "notExists" or "deleted") otherwise
+	var resp struct {
+		Data  json.RawMessage `json:"data"`
+		Error *struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(result, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse object: %w", err)
+	}
+	if resp.Error != nil {
+		switch resp.Error.Code {
+		case "notExists", "deleted":
+			return nil, fmt.Errorf("%s: %w", objectID, ErrObjectNotFound)
+		default:
+			return nil, fmt.Errorf("RPC error: %s", resp.Error.Code)
+		}
+	}
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		return nil, fmt.Errorf("%s: %w", objectID, ErrObjectNotFound)
+	}
+
+	info, err := parseObjectJSON(string(resp.Data))
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("%s: %w", objectID, ErrObjectNotFound)
+	}
+	return info, nil
+}
+
+// objectMissingPattern matches the errors the Sui RPC and CLI report for an
+// object that never existed or was deleted: the ObjectNotExists and Deleted
+// errors, as Display text, variant names or JSON codes. Generic "not found"
+// errors, such as a missing binary or config file, do not match.
+var objectMissingPattern = regexp.MustCompile(`(?i)\bObject 0x[0-9a-f]+ does not exist\b|\bObject has been deleted\b|\b(Object)?NotExists\b|\bObjectDeleted\b|"code"\s*:\s*"(notExists|deleted)"`)
+
+// isObjectMissingOutput reports whether sui's error output says the object
+// does not exist.
+func isObjectMissingOutput(output string) bool {
+	return objectMissingPattern.MatchString(output)
+}
+
+// isObjectMissingStatus reports whether the status of an object response
+// says the object does not exist.
+func isObjectMissingStatus(status string) bool {
+	for _, missing := range []string{"NotExists", "ObjectNotExists", "Deleted", "ObjectDeleted"} {
+		if strings.EqualFold(status, missing) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseObjectJSON parses `sui client object --json` output. It returns nil
+// without error when the response reports the object as missing or deleted.
+func parseObjectJSON(jsonOutput string) (*ObjectInfo, error) {
+	var raw struct {
+		ObjectID string          `json:"objectId"`
+		Version  json.Number     `json:"version"`
+		Type     string          `json:"type"`
+		Owner    json.RawMessage `json:"owner"`
+		Status   string          `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(jsonOutput), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse object: %w", err)
+	}
+	if raw.ObjectID == "" || isObjectMissingStatus(raw.Status) {
+		return nil, nil
+	}
+
+	info := &ObjectInfo{ObjectID: raw.ObjectID, Version: raw.Version.String(), Type: raw.Type}
+
+	var kind string
+	if err := json.Unmarshal(raw.Owner, &kind); err == nil {
+		info.OwnerKind = kind // "Immutable"
+		return info, nil
+	}
+
+	var owner map[string]json.RawMessage
+	if err := json.Unmarshal(raw.Owner, &owner); err != nil {
+		return nil, fmt.Errorf("failed to parse object owner: %w", err)
+	}
+	for kind, value := range owner {
+		info.OwnerKind = kind
+		var addr string
+		if err := json.Unmarshal(value, &addr); err == nil {
+			info.Owner = addr
+			continue
+		}
+		// ConsensusAddressOwner: {"owner": "0x...", "start_version": N}
+		var nested struct {
+			Owner string `json:"owner"`
+		}
+		if err := json.Unmarshal(value, &nested); err == nil {
+			info.Owner = nested.Owner
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,152 @@
+package sui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseObjectJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantNil   bool
+		wantKind  string
+		wantOwner string
+		wantVer   string
+	}{
+		{
+			name:      "address owner",
+			input:     `{"objectId":"0xabc","version":"12","type":"0x1::site::Site","owner":{"AddressOwner":"0x00ff"}}`,
+			wantKind:  "AddressOwner",
+			wantOwner: "0x00ff",
+			wantVer:   "12",
+		},
+		{
+			name:      "numeric version and consensus owner",
+			input:     `{"objectId":"0xabc","version":7,"owner":{"ConsensusAddressOwner":{"owner":"0x1","start_version":3}}}`,
+			wantKind:  "ConsensusAddressOwner",
+			wantOwner: "0x1",
+			wantVer:   "7",
+		},
+		{
+			name:     "shared",
+			input:    `{"objectId":"0xabc","version":"1","owner":{"Shared":{"initial_shared_version":1}}}`,
+			wantKind: "Shared",
+			wantVer:  "1",
+		},
+		{
+			name:     "immutable",
+			input:    `{"objectId":"0xabc","version":"1","owner":"Immutable"}`,
+			wantKind: "Immutable",
+			wantVer:  "1",
+		},
+		{
+			name:    "not exists status",
+			input:   `{"status":"NotExists","objectId":"0xabc"}`,
+			wantNil: true,
+		},
+		{
+			name:    "no object",
+			input:   `{}`,
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := parseObjectJSON(tt.input)
+			if err != nil {
+				t.Fatalf("parseObjectJSON() error = %v", err)
+			}
+			if tt.wantNil {
+				if info != nil {
+					t.Errorf("got %+v, want nil", info)
+				}
+				return
+			}
+			if info.OwnerKind != tt.wantKind || info.Owner != tt.wantOwner || info.Version != tt.wantVer {
+				t.Errorf("got %+v, want kind=%s owner=%s version=%s", info, tt.wantKind, tt.wantOwner, tt.wantVer)
+			}
+		})
+	}
+}
+
+func TestObjectInfo_OwnedByAddress(t *testing.T) {
+	owned := &ObjectInfo{OwnerKind: "AddressOwner", Owner: "0x00AB"}
+	if !owned.OwnedByAddress("0xab") {
+		t.Error("addresses differing only in case and padding should match")
+	}
+	if owned.OwnedByAddress("0xac") {
+		t.Error("different address should not match")
+	}
+	shared := &ObjectInfo{OwnerKind: "Shared"}
+	if shared.OwnedByAddress("0xab") {
+		t.Error("shared object is not owned by an address")
+	}
+	if SameAddress("", "") {
+		t.Error("empty addresses should not match")
+	}
+}
+
+func TestGetObjectUsesRPCOverride(t *testing.T) {
+	responses := map[string]string{
+		"0xabc": `{"jsonrpc":"2.0","id":1,"result":{"data":{"objectId":"0xabc","version":"7","type":"0x1::site::Site","owner":{"AddressOwner":"0x00ff"}}}}`,
+		"0xdef": `{"jsonrpc":"2.0","id":1,"result":{"error":{"code":"deleted","object_id":"0xdef"}}}`,
+	}
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		for id, resp := range responses {
+			if strings.Contains(string(body), `"`+id+`"`) {
+				requests = append(requests, string(body))
+				_, _ = io.WriteString(w, resp)
+				return
+			}
+		}
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	ctx := WithRPCURL(context.Background(), srv.URL)
+	info, err := GetObject(ctx, "0xabc")
+	if err != nil {
+		t.Fatalf("GetObject() error = %v", err)
+	}
+	if info.Version != "7" || !info.OwnedByAddress("0xff") {
+		t.Errorf("GetObject() = %+v", info)
+	}
+
+	if _, err := GetObject(ctx, "0xdef"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("GetObject(deleted) error = %v, want ErrObjectNotFound", err)
+	}
+	if len(requests) != 2 || !strings.Contains(requests[0], `"sui_getObject"`) {
+		t.Errorf("requests = %v, want two sui_getObject calls", requests)
+	}
+}
+
+func TestIsObjectMissingOutput(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"Error executing command: Object 0xabc does not exist.", true},
+		{"Object has been deleted object_ref: (0xabc, SequenceNumber(9), o#abc)", true},
+		{`{"error":{"code":"notExists","object_id":"0xabc"}}`, true},
+		{`{"error":{"code":"deleted","object_id":"0xabc"}}`, true},
+		{"Error: ObjectNotExists { object_id: 0xabc }", true},
+		{"sui: command not found", false},
+		{"Config file not found: /home/me/.sui/sui_config/client.yaml", false},
+		{"Cannot find gas coin: coin 0xabc was deleted from the cache", false},
+		{"keystore path does not exist", false},
+	}
+	for _, tt := range tests {
+		if got := isObjectMissingOutput(tt.output); got != tt.want {
+			t.Errorf("isObjectMissingOutput(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package sui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Default fullnode RPC endpoints for Sui networks
+const (
+	TestnetRPC = "https://fullnode.testnet.sui.io:443"
+	MainnetRPC = "https://fullnode.mainnet.sui.io:443"
+)
+
+// DefaultRPCEndpoint returns the public fullnode for network (testnet when unknown).
+func DefaultRPCEndpoint(network string) string {
+	if strings.ToLower(network) == "mainnet" {
+		return MainnetRPC
+	}
+	return TestnetRPC
+}
+
+// ResolveRPCEndpoint returns override when set, otherwise the default RPC
+// endpoint for the network.
+func ResolveRPCEndpoint(network, override string) string {
+	if override != "" {
+		return override
+	}
+	return DefaultRPCEndpoint(network)
+}
+
+type rpcURLKey struct{}
+
+// WithRPCURL returns a copy of ctx whose lookups query the fullnode at rpcURL
+// (walrus.gateway.suiRPCURL) instead of the active sui environment's. An
+// empty rpcURL leaves ctx unchanged.
+func WithRPCURL(ctx context.Context, rpcURL string) context.Context {
+	if rpcURL == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, rpcURLKey{}, rpcURL)
+}
+
+// rpcURLFrom returns the endpoint set with WithRPCURL, if any.
+func rpcURLFrom(ctx context.Context) string {
+	rpcURL, _ := ctx.Value(rpcURLKey{}).(string)
+	return rpcURL
+}
+
+// callRPC sends a JSON-RPC request to the fullnode at rpcURL and returns its
+// raw result.
+func callRPC(ctx context.Context, rpcURL, method string, params []interface{}) (json.RawMessage, error) {
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("RPC request failed: HTTP %d", resp.StatusCode)
+	}
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+	return rpcResp.Result, nil
+}
@@ -0,0 +1,82 @@
+package sui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// siteTypeSuffix ends the Move type of every Walrus Site object, whatever
+// package version published it.
+const siteTypeSuffix = "::site::Site"
+
+// SiteObjectSummary is a Walrus Site object owned by an address.
+type SiteObjectSummary struct {
+	ObjectID string `json:"object_id"`
+	Version  string `json:"version"`
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"` // Site name, when the listing includes object content
+}
+
+// ownedObjectsSource lists the objects owned by an address; replaced in tests.
+var ownedObjectsSource = func(ctx context.Context, address string) (string, error) {
+	return runCommandJSONContext(ctx, "client", "objects", address)
+}
+
+// ListWalrusSiteObjects lists the Walrus Site objects owned by address on the
+// active network.
+func ListWalrusSiteObjects(ctx context.Context, address string) ([]SiteObjectSummary, error) {
+	if !objectIDPattern.MatchString(address) {
+		return nil, fmt.Errorf("invalid address format: %s", address)
+	}
+	output, err := ownedObjectsSource(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+	return parseSiteObjectsJSON(output)
+}
+
+// parseSiteObjectsJSON reads `sui client objects --json` output and keeps the
+// Walrus Site objects. Entries are either wrapped in "data" (current CLI) or
+// flat (older CLI versions).
+func parseSiteObjectsJSON(jsonOutput string) ([]SiteObjectSummary, error) {
+	type objectData struct {
+		ObjectID string      `json:"objectId"`
+		Version  json.Number `json:"version"`
+		Type     string      `json:"type"`
+		Content  struct {
+			Fields struct {
+				Name string `json:"name"`
+			} `json:"fields"`
+		} `json:"content"`
+	}
+	var entries []struct {
+		objectData
+		Data *objectData `json:"data"`
+	}
+	if strings.TrimSpace(jsonOutput) == "" {
+		return nil, nil
+	}
+	if err := json.Unmarshal([]byte(jsonOutput), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse object list: %w", err)
+	}
+
+	var sites []SiteObjectSummary
+	for _, entry := range entries {
+		obj := entry.objectData
+		if entry.Data != nil {
+			obj = *entry.Data
+		}
+		if obj.ObjectID == "" || !strings.HasSuffix(obj.Type, siteTypeSuffix) {
+			continue
+		}
+		sites = append(sites, SiteObjectSummary{
+			ObjectID: obj.ObjectID,
+			Version:  obj.Version.String(),
+			Type:     obj.Type,
+			Name:     obj.Content.Fields.Name,
+		})
+	}
+	return sites, nil
+}
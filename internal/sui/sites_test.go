@@ -0,0 +1,104 @@
+package sui
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+const sampleObjectsJSON = `[
+  {
+    "data": {
+      "objectId": "0x1111111111111111111111111111111111111111111111111111111111111111",
+      "version": "42",
+      "digest": "9Xr1",
+      "type": "0xf99aee9f21493e1590e7e5a9aea6f343a1f381031a04a732724871fc294be799::site::Site",
+      "owner": {"AddressOwner": "0xabc"},
+      "content": {
+        "dataType": "moveObject",
+        "type": "0xf99aee9f21493e1590e7e5a9aea6f343a1f381031a04a732724871fc294be799::site::Site",
+        "fields": {"id": {"id": "0x1111"}, "name": "My Blog"}
+      }
+    }
+  },
+  {
+    "data": {
+      "objectId": "0x2222",
+      "version": "7",
+      "type": "0x2::coin::Coin<0x2::sui::SUI>"
+    }
+  },
+  {
+    "objectId": "0x3333",
+    "version": 9,
+    "type": "0x26eb::site::Site"
+  },
+  {
+    "data": {
+      "objectId": "0x4444",
+      "version": "1",
+      "type": "0x26eb::site::SiteAdmin"
+    }
+  }
+]`
+
+func TestParseSiteObjectsJSON(t *testing.T) {
+	sites, err := parseSiteObjectsJSON(sampleObjectsJSON)
+	if err != nil {
+		t.Fatalf("parseSiteObjectsJSON() error = %v", err)
+	}
+	if len(sites) != 2 {
+		t.Fatalf("got %d sites, want 2: %+v", len(sites), sites)
+	}
+
+	if sites[0].ObjectID != "0x1111111111111111111111111111111111111111111111111111111111111111" ||
+		sites[0].Version != "42" || sites[0].Name != "My Blog" {
+		t.Errorf("sites[0] = %+v", sites[0])
+	}
+	// Flat entries from older CLI versions, with a numeric version and no content
+	if sites[1].ObjectID != "0x3333" || sites[1].Version != "9" || sites[1].Name != "" {
+		t.Errorf("sites[1] = %+v", sites[1])
+	}
+}
+
+func TestParseSiteObjectsJSONEmptyAndInvalid(t *testing.T) {
+	for _, input := range []string{"", "[]"} {
+		sites, err := parseSiteObjectsJSON(input)
+		if err != nil || len(sites) != 0 {
+			t.Errorf("parseSiteObjectsJSON(%q) = %v, %v; want no sites", input, sites, err)
+		}
+	}
+	if _, err := parseSiteObjectsJSON(`{"error": "boom"}`); err == nil {
+		t.Error("expected an error for a non-list response")
+	}
+}
+
+func TestListWalrusSiteObjects(t *testing.T) {
+	original := ownedObjectsSource
+	t.Cleanup(func() { ownedObjectsSource = original })
+
+	var gotAddress string
+	ownedObjectsSource = func(ctx context.Context, address string) (string, error) {
+		gotAddress = address
+		return sampleObjectsJSON, nil
+	}
+
+	sites, err := ListWalrusSiteObjects(context.Background(), "0xabc")
+	if err != nil {
+		t.Fatalf("ListWalrusSiteObjects() error = %v", err)
+	}
+	if gotAddress != "0xabc" || len(sites) != 2 {
+		t.Errorf("address = %q, sites = %+v", gotAddress, sites)
+	}
+
+	if _, err := ListWalrusSiteObjects(context.Background(), "not-an-address"); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+
+	ownedObjectsSource = func(ctx context.Context, address string) (string, error) {
+		return "", errors.New("rpc unavailable")
+	}
+	if _, err := ListWalrusSiteObjects(context.Background(), "0xabc"); err == nil {
+		t.Error("expected the listing error to be returned")
+	}
+}
@@ -0,0 +1,164 @@
+package sui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxTransactionsLimit is the most transactions a fullnode returns per query.
+const MaxTransactionsLimit = 50
+
+// TxSummary is one transaction sent by an address.
+type TxSummary struct {
+	Digest    string    `json:"digest"`
+	Kind      string    `json:"kind"`            // e.g. ProgrammableTransaction
+	Calls     []string  `json:"calls,omitempty"` // module::function of each Move call
+	Status    string    `json:"status"`          // success or failure
+	Error     string    `json:"error,omitempty"` // Abort reason of a failed transaction
+	GasSUI    float64   `json:"gasSui"`          // Net gas paid (computation + storage - rebate)
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Failed reports whether the transaction was executed but aborted.
+func (tx TxSummary) Failed() bool {
+	return tx.Status == "failure"
+}
+
+// transactionBlocksSource queries GetRecentTransactions' fullnode, which
+// transactionsRPC picks with activeEnvSource; replaced in tests.
+var transactionBlocksSource = queryTransactionBlocks
+
+// GetRecentTransactions returns up to limit transactions sent by address on
+// the active network (or the fullnode set with WithRPCURL), newest first. An
+// address with no activity returns an empty list.
+func GetRecentTransactions(ctx context.Context, address string, limit int) ([]TxSummary, error) {
+	if !objectIDPattern.MatchString(address) {
+		return nil, fmt.Errorf("invalid address format: %s", address)
+	}
+	if limit <= 0 || limit > MaxTransactionsLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", MaxTransactionsLimit, limit)
+	}
+
+	rpcURL, err := transactionsRPC(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active RPC endpoint: %w", err)
+	}
+	output, err := transactionBlocksSource(ctx, rpcURL, address, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transactions: %w", err)
+	}
+	return parseTransactionBlocks(output)
+}
+
+// transactionsRPC returns the fullnode to query: the one set with
+// WithRPCURL, otherwise the default for the active environment.
+func transactionsRPC(ctx context.Context) (string, error) {
+	override := rpcURLFrom(ctx)
+	if override != "" {
+		return override, nil
+	}
+	env, err := activeEnvSource(ctx)
+	if err != nil {
+		return "", err
+	}
+	return ResolveRPCEndpoint(strings.TrimSpace(env), override), nil
+}
+
+// queryTransactionBlocks asks the fullnode at rpcURL for the latest limit
+// transactions sent by address and returns the raw JSON-RPC result.
+func queryTransactionBlocks(ctx context.Context, rpcURL, address string, limit int) (string, error) {
+	result, err := callRPC(ctx, rpcURL, "suix_queryTransactionBlocks", []interface{}{
+		map[string]interface{}{
+			"filter":  map[string]string{"FromAddress": address},
+			"options": map[string]bool{"showInput": true, "showEffects": true},
+		},
+		nil,   // cursor
+		limit, // page size
+		true,  // descending order (newest first)
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+// parseTransactionBlocks reads a suix_queryTransactionBlocks result into
+// summaries, newest first.
+func parseTransactionBlocks(jsonOutput string) ([]TxSummary, error) {
+	var page struct {
+		Data []struct {
+			Digest      string `json:"digest"`
+			TimestampMs string `json:"timestampMs"`
+			Transaction struct {
+				Data struct {
+					Transaction struct {
+						Kind         string                       `json:"kind"`
+						Transactions []map[string]json.RawMessage `json:"transactions"`
+					} `json:"transaction"`
+				} `json:"data"`
+			} `json:"transaction"`
+			Effects struct {
+				Status struct {
+					Status string `json:"status"`
+					Error  string `json:"error"`
+				} `json:"status"`
+				GasUsed struct {
+					ComputationCost string `json:"computationCost"`
+					StorageCost     string `json:"storageCost"`
+					StorageRebate   string `json:"storageRebate"`
+				} `json:"gasUsed"`
+			} `json:"effects"`
+		} `json:"data"`
+	}
+	if strings.TrimSpace(jsonOutput) == "" || strings.TrimSpace(jsonOutput) == "null" {
+		return []TxSummary{}, nil
+	}
+	if err := json.Unmarshal([]byte(jsonOutput), &page); err != nil {
+		return nil, fmt.Errorf("failed to parse transactions: %w", err)
+	}
+
+	txs := make([]TxSummary, 0, len(page.Data))
+	for _, block := range page.Data {
+		tx := TxSummary{
+			Digest: block.Digest,
+			Kind:   block.Transaction.Data.Transaction.Kind,
+			Status: block.Effects.Status.Status,
+			Error:  block.Effects.Status.Error,
+		}
+		if ms, err := strconv.ParseInt(block.TimestampMs, 10, 64); err == nil {
+			tx.Timestamp = time.UnixMilli(ms).UTC()
+		}
+		gas := block.Effects.GasUsed
+		mist := parseMist(gas.ComputationCost) + parseMist(gas.StorageCost) - parseMist(gas.StorageRebate)
+		tx.GasSUI = float64(mist) / 1e9
+
+		for _, command := range block.Transaction.Data.Transaction.Transactions {
+			raw, ok := command["MoveCall"]
+			if !ok {
+				continue
+			}
+			var call struct {
+				Module   string `json:"module"`
+				Function string `json:"function"`
+			}
+			if err := json.Unmarshal(raw, &call); err == nil && call.Function != "" {
+				tx.Calls = append(tx.Calls, call.Module+"::"+call.Function)
+			}
+		}
+		txs = append(txs, tx)
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool { return txs[i].Timestamp.After(txs[j].Timestamp) })
+	return txs, nil
+}
+
+// parseMist reads a MIST amount the RPC encodes as a decimal string.
+func parseMist(s string) int64 {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
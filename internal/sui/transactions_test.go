@@ -0,0 +1,180 @@
+package sui
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleTransactionBlocksJSON = `{
+  "data": [
+    {
+      "digest": "7xFailedDigest",
+      "transaction": {
+        "data": {
+          "messageVersion": "v1",
+          "transaction": {
+            "kind": "ProgrammableTransaction",
+            "inputs": [],
+            "transactions": [
+              {"MoveCall": {"package": "0x26eb", "module": "site", "function": "new_site", "arguments": []}},
+              {"MoveCall": {"package": "0x26eb", "module": "site", "function": "add_resource", "arguments": []}}
+            ]
+          },
+          "sender": "0xabc"
+        }
+      },
+      "effects": {
+        "status": {"status": "failure", "error": "InsufficientGas"},
+        "gasUsed": {"computationCost": "1000000", "storageCost": "0", "storageRebate": "0"}
+      },
+      "timestampMs": "1760000100000",
+      "checkpoint": "200"
+    },
+    {
+      "digest": "9xTransferDigest",
+      "transaction": {
+        "data": {
+          "transaction": {
+            "kind": "ProgrammableTransaction",
+            "transactions": [
+              {"SplitCoins": ["GasCoin", [{"Input": 0}]]},
+              {"TransferObjects": [[{"Result": 0}], {"Input": 1}]}
+            ]
+          }
+        }
+      },
+      "effects": {
+        "status": {"status": "success"},
+        "gasUsed": {"computationCost": "1000000", "storageCost": "2000000", "storageRebate": "978120"}
+      },
+      "timestampMs": "1760000000000"
+    }
+  ],
+  "nextCursor": "9xTransferDigest",
+  "hasNextPage": true
+}`
+
+func TestParseTransactionBlocks(t *testing.T) {
+	txs, err := parseTransactionBlocks(sampleTransactionBlocksJSON)
+	if err != nil {
+		t.Fatalf("parseTransactionBlocks() error = %v", err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("got %d transactions, want 2: %+v", len(txs), txs)
+	}
+
+	failed := txs[0]
+	if failed.Digest != "7xFailedDigest" || !failed.Failed() || failed.Error != "InsufficientGas" {
+		t.Errorf("txs[0] = %+v, want the failed site transaction", failed)
+	}
+	if strings.Join(failed.Calls, ",") != "site::new_site,site::add_resource" {
+		t.Errorf("txs[0].Calls = %v", failed.Calls)
+	}
+	if !failed.Timestamp.Equal(time.UnixMilli(1760000100000)) {
+		t.Errorf("txs[0].Timestamp = %v", failed.Timestamp)
+	}
+
+	transfer := txs[1]
+	if transfer.Failed() || transfer.Kind != "ProgrammableTransaction" || len(transfer.Calls) != 0 {
+		t.Errorf("txs[1] = %+v, want a successful transfer without Move calls", transfer)
+	}
+	if transfer.GasSUI < 0.00202187 || transfer.GasSUI > 0.00202189 {
+		t.Errorf("txs[1].GasSUI = %v, want computation + storage - rebate", transfer.GasSUI)
+	}
+}
+
+func TestParseTransactionBlocks_Invalid(t *testing.T) {
+	if _, err := parseTransactionBlocks("{not json"); err == nil {
+		t.Error("expected an error for malformed output")
+	}
+}
+
+func TestGetRecentTransactions_NoActivity(t *testing.T) {
+	origEnv, origBlocks := activeEnvSource, transactionBlocksSource
+	t.Cleanup(func() { activeEnvSource, transactionBlocksSource = origEnv, origBlocks })
+	activeEnvSource = func(context.Context) (string, error) { return "testnet", nil }
+	transactionBlocksSource = func(_ context.Context, _, _ string, _ int) (string, error) {
+		return `{"data": [], "nextCursor": null, "hasNextPage": false}`, nil
+	}
+
+	txs, err := GetRecentTransactions(context.Background(), "0xabc", 10)
+	if err != nil {
+		t.Fatalf("GetRecentTransactions() error = %v", err)
+	}
+	if txs == nil || len(txs) != 0 {
+		t.Errorf("GetRecentTransactions() = %#v, want an empty list", txs)
+	}
+}
+
+func TestGetRecentTransactions_InvalidInput(t *testing.T) {
+	if _, err := GetRecentTransactions(context.Background(), "not-an-address", 10); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+	if _, err := GetRecentTransactions(context.Background(), "0xabc", MaxTransactionsLimit+1); err == nil {
+		t.Error("expected an error for a limit above the RPC page size")
+	}
+}
+
+func TestQueryTransactionBlocks(t *testing.T) {
+	var request struct {
+		Method string        `json:"method"`
+		Params []interface{} `json:"params"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &request)
+		_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":1,"result":{"data":[],"hasNextPage":false}}`)
+	}))
+	defer srv.Close()
+
+	result, err := queryTransactionBlocks(context.Background(), srv.URL, "0xabc", 5)
+	if err != nil {
+		t.Fatalf("queryTransactionBlocks() error = %v", err)
+	}
+	if !strings.Contains(result, `"data":[]`) {
+		t.Errorf("result = %s", result)
+	}
+	if request.Method != "suix_queryTransactionBlocks" || len(request.Params) != 4 || request.Params[2] != float64(5) {
+		t.Errorf("request = %+v", request)
+	}
+}
+
+func TestTransactionsRPC(t *testing.T) {
+	origEnv := activeEnvSource
+	t.Cleanup(func() { activeEnvSource = origEnv })
+	activeEnvSource = func(context.Context) (string, error) { return "mainnet\n", nil }
+
+	got, err := transactionsRPC(context.Background())
+	if err != nil {
+		t.Fatalf("transactionsRPC() error = %v", err)
+	}
+	if got != MainnetRPC {
+		t.Errorf("transactionsRPC() = %q, want %q", got, MainnetRPC)
+	}
+
+	got, err = transactionsRPC(WithRPCURL(context.Background(), "https://rpc.example.com"))
+	if err != nil {
+		t.Fatalf("transactionsRPC(override) error = %v", err)
+	}
+	if got != "https://rpc.example.com" {
+		t.Errorf("transactionsRPC(override) = %q, want the override", got)
+	}
+}
+
+func TestResolveRPCEndpoint(t *testing.T) {
+	if got := ResolveRPCEndpoint("mainnet", ""); got != MainnetRPC {
+		t.Errorf("ResolveRPCEndpoint(mainnet) = %q", got)
+	}
+	if got := ResolveRPCEndpoint("devnet", ""); got != TestnetRPC {
+		t.Errorf("ResolveRPCEndpoint(devnet) = %q, want testnet default", got)
+	}
+	if got := ResolveRPCEndpoint("mainnet", "https://rpc.example.com"); got != "https://rpc.example.com" {
+		t.Errorf("ResolveRPCEndpoint(override) = %q", got)
+	}
+}
@@ -0,0 +1,65 @@
+package sui
+
+import (
+	"context"
+	"fmt"
+)
+
+// WalletInfo describes the active Sui address and what it can spend.
+type WalletInfo struct {
+	Address    string  `json:"address"`
+	Alias      string  `json:"alias,omitempty"`
+	SuiBalance float64 `json:"suiBalance"`
+	WalBalance float64 `json:"walBalance"`
+	Network    string  `json:"network"`
+	Active     bool    `json:"active"`
+}
+
+// Sources queried by GetActiveAddressDetails; replaced in tests.
+var (
+	activeEnvSource = getActiveEnv
+	addressesSource = getAddresses
+	balanceSource   = getBalance
+)
+
+// GetActiveAddressDetails returns the active address with its alias, network
+// and SUI/WAL balances, so callers can show who is about to pay before
+// spending anything.
+func GetActiveAddressDetails(ctx context.Context) (WalletInfo, error) {
+	network, err := activeEnvSource(ctx)
+	if err != nil {
+		return WalletInfo{}, fmt.Errorf("failed to get active env: %w", err)
+	}
+
+	addresses, err := addressesSource(ctx)
+	if err != nil {
+		return WalletInfo{}, fmt.Errorf("failed to get active address: %w", err)
+	}
+	if addresses.ActiveAddress == "" {
+		return WalletInfo{}, fmt.Errorf("no active address configured (run 'sui client new-address ed25519')")
+	}
+
+	balance, err := balanceSource(ctx)
+	if err != nil {
+		return WalletInfo{}, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	return WalletInfo{
+		Address:    addresses.ActiveAddress,
+		Alias:      addressAlias(addresses, addresses.ActiveAddress),
+		SuiBalance: balance.SUI,
+		WalBalance: balance.WAL,
+		Network:    network,
+		Active:     true,
+	}, nil
+}
+
+// addressAlias returns the alias `sui client addresses` lists for address.
+func addressAlias(resp *AddressesResponse, address string) string {
+	for _, pair := range resp.Addresses {
+		if len(pair) >= 2 && pair[1] == address {
+			return pair[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package sui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubWalletSources replaces the sources behind GetActiveAddressDetails for one test.
+func stubWalletSources(t *testing.T, env string, envErr error, addrs *AddressesResponse, addrErr error, bal *BalanceInfo, balErr error) {
+	t.Helper()
+	origEnv, origAddrs, origBal := activeEnvSource, addressesSource, balanceSource
+	t.Cleanup(func() {
+		activeEnvSource, addressesSource, balanceSource = origEnv, origAddrs, origBal
+	})
+	activeEnvSource = func(context.Context) (string, error) { return env, envErr }
+	addressesSource = func(context.Context) (*AddressesResponse, error) { return addrs, addrErr }
+	balanceSource = func(context.Context) (*BalanceInfo, error) { return bal, balErr }
+}
+
+func TestGetActiveAddressDetails(t *testing.T) {
+	addrs := &AddressesResponse{
+		ActiveAddress: "0xbbb",
+		Addresses:     [][]string{{"personal", "0xaaa"}, {"deployer", "0xbbb"}},
+	}
+	stubWalletSources(t, "mainnet", nil, addrs, nil, &BalanceInfo{SUI: 1.5, WAL: 42}, nil)
+
+	info, err := GetActiveAddressDetails(context.Background())
+	if err != nil {
+		t.Fatalf("GetActiveAddressDetails() error = %v", err)
+	}
+	want := WalletInfo{Address: "0xbbb", Alias: "deployer", SuiBalance: 1.5, WalBalance: 42, Network: "mainnet", Active: true}
+	if info != want {
+		t.Errorf("GetActiveAddressDetails() = %+v, want %+v", info, want)
+	}
+}
+
+func TestGetActiveAddressDetails_NoAlias(t *testing.T) {
+	addrs := &AddressesResponse{ActiveAddress: "0xccc", Addresses: [][]string{{"other", "0xaaa"}}}
+	stubWalletSources(t, "testnet", nil, addrs, nil, &BalanceInfo{}, nil)
+
+	info, err := GetActiveAddressDetails(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Alias != "" || info.Address != "0xccc" {
+		t.Errorf("got %+v, want address 0xccc without alias", info)
+	}
+}
+
+func TestGetActiveAddressDetails_Errors(t *testing.T) {
+	addrs := &AddressesResponse{ActiveAddress: "0xaaa"}
+	boom := errors.New("boom")
+
+	tests := []struct {
+		name    string
+		envErr  error
+		addrs   *AddressesResponse
+		addrErr error
+		balErr  error
+		wantErr string
+	}{
+		{"env fails", boom, addrs, nil, nil, "active env"},
+		{"addresses fail", nil, nil, boom, nil, "active address"},
+		{"no active address", nil, &AddressesResponse{}, nil, nil, "no active address"},
+		{"balance fails", nil, addrs, nil, boom, "balance"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubWalletSources(t, "testnet", tt.envErr, tt.addrs, tt.addrErr, &BalanceInfo{}, tt.balErr)
+			_, err := GetActiveAddressDetails(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
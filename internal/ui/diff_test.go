@@ -0,0 +1,25 @@
+package ui
+
+import "testing"
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{"identical", "a\nb", "a\nb", ""},
+		{"changed line", "a\nb\nc", "a\nx\nc", "  a\n- b\n+ x\n  c\n"},
+		{"appended", "a", "a\nb", "  a\n+ b\n"},
+		{"removed", "a\nb", "b", "- a\n  b\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LineDiff(tt.old, tt.new); got != tt.want {
+				t.Errorf("LineDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
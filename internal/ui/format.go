@@ -4,9 +4,19 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 )
 
+// ansiRegex matches ANSI CSI escape sequences such as colors (\x1b[32m) and
+// cursor controls (\x1b[?25l) emitted by the walrus and site-builder CLIs.
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[a-zA-Z]`)
+
+// StripANSI removes ANSI escape sequences from s.
+func StripANSI(s string) string {
+	return ansiRegex.ReplaceAllString(s, "")
+}
+
 // PrintSuccess prints a success message with icon
 func PrintSuccess(message string) {
 	icons := GetIcons()
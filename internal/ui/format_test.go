@@ -779,3 +779,18 @@ func TestPrintFunctionsWithSpecialChars(t *testing.T) {
 		PrintTip(msg)
 	}
 }
+
+func TestStripANSI(t *testing.T) {
+	tests := map[string]string{
+		"plain":                          "plain",
+		"\x1b[32mgreen\x1b[0m":           "green",
+		"\x1b[1m\x1b[31mbold red\x1b[0m": "bold red",
+		"\x1b[?25lspinner\x1b[?25h":      "spinner",
+		"":                               "",
+	}
+	for input, want := range tests {
+		if got := StripANSI(input); got != want {
+			t.Errorf("StripANSI(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -194,7 +194,7 @@ func detectIconSupport() *Icons {
 	}
 
 	// Check if running in CI/CD environment
-	if isCI() {
+	if IsCI() {
 		return ASCIIIcons
 	}
 
@@ -245,8 +245,8 @@ func detectIconSupport() *Icons {
 	return ASCIIIcons
 }
 
-// isCI checks if running in a CI/CD environment
-func isCI() bool {
+// IsCI reports whether walgo is running in a CI/CD environment
+func IsCI() bool {
 	ciEnvVars := []string{
 		"CI",
 		"CONTINUOUS_INTEGRATION",
@@ -470,8 +470,8 @@ func TestIsCI(t *testing.T) {
 
 	t.Run("no CI env vars returns false", func(t *testing.T) {
 		clearAllCI()
-		if isCI() {
-			t.Error("isCI() should return false when no CI env vars are set")
+		if IsCI() {
+			t.Error("IsCI() should return false when no CI env vars are set")
 		}
 	})
 
@@ -480,8 +480,8 @@ func TestIsCI(t *testing.T) {
 		t.Run(envVar+" returns true", func(t *testing.T) {
 			clearAllCI()
 			os.Setenv(envVar, "true")
-			if !isCI() {
-				t.Errorf("isCI() should return true when %s is set", envVar)
+			if !IsCI() {
+				t.Errorf("IsCI() should return true when %s is set", envVar)
 			}
 		})
 	}
@@ -0,0 +1,109 @@
+package walrus
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DefaultMaxEpochsAhead is the maximum number of epochs Walrus allows storage
+// to be purchased for, used when live network info is unavailable.
+const DefaultMaxEpochsAhead = 53
+
+// EpochBudget is the result of fitting storage epochs to a WAL budget.
+type EpochBudget struct {
+	Epochs        int           // Maximum affordable epochs
+	BudgetWAL     float64       // Budget the epochs were computed for
+	TotalWAL      float64       // Estimated WAL cost for Epochs
+	PerEpochWAL   float64       // Marginal WAL cost of each additional epoch
+	EpochDuration time.Duration // Length of one epoch on the network
+	Capped        bool          // True when the network's max epochs limited the result
+}
+
+// Expiry returns the approximate time storage bought at the given start time runs out.
+func (b *EpochBudget) Expiry(start time.Time) time.Time {
+	return start.Add(time.Duration(b.Epochs) * b.EpochDuration)
+}
+
+// BudgetShortfallError is returned when a budget cannot cover even one epoch.
+type BudgetShortfallError struct {
+	BudgetWAL   float64
+	RequiredWAL float64
+}
+
+func (e *BudgetShortfallError) Error() string {
+	return fmt.Sprintf("budget of %.4f WAL is too low: 1 epoch costs ~%.4f WAL (short by %.4f WAL)",
+		e.BudgetWAL, e.RequiredWAL, e.Shortfall())
+}
+
+// Shortfall returns how much WAL is missing to afford a single epoch.
+func (e *BudgetShortfallError) Shortfall() float64 {
+	return e.RequiredWAL - e.BudgetWAL
+}
+
+// EpochsForBudget returns the maximum number of epochs a site of siteSize
+// bytes can be stored for without the WAL cost exceeding budgetWAL.
+// Cost follows the same model as CalculateCost: a one-time write and
+// metadata cost plus a storage cost per epoch.
+func EpochsForBudget(siteSize int64, budgetWAL float64, info *StorageInfo) (*EpochBudget, error) {
+	if siteSize <= 0 {
+		return nil, fmt.Errorf("site size must be positive")
+	}
+	if budgetWAL <= 0 {
+		return nil, fmt.Errorf("budget must be greater than 0")
+	}
+	if info == nil {
+		return nil, fmt.Errorf("storage pricing is required")
+	}
+
+	units := storageUnitsFor(encodedSizeFor(siteSize, info), info)
+	fixedFrost := metadataCostFor(info) + units*float64(info.WritePrice)
+	perEpochFrost := units * float64(info.StoragePrice)
+
+	costFor := func(epochs int) float64 {
+		return (fixedFrost + perEpochFrost*float64(epochs)) / 1e9
+	}
+
+	budgetFrost := budgetWAL * 1e9
+	if fixedFrost+perEpochFrost > budgetFrost {
+		return nil, &BudgetShortfallError{BudgetWAL: budgetWAL, RequiredWAL: costFor(1)}
+	}
+
+	maxEpochs := info.MaxEpochsAhead
+	if maxEpochs <= 0 {
+		maxEpochs = DefaultMaxEpochsAhead
+	}
+
+	epochs := maxEpochs
+	if perEpochFrost > 0 {
+		epochs = int(math.Floor((budgetFrost - fixedFrost) / perEpochFrost))
+	}
+
+	capped := false
+	if epochs > maxEpochs {
+		epochs = maxEpochs
+		capped = true
+	}
+
+	return &EpochBudget{
+		Epochs:        epochs,
+		BudgetWAL:     budgetWAL,
+		TotalWAL:      costFor(epochs),
+		PerEpochWAL:   perEpochFrost / 1e9,
+		EpochDuration: time.Duration(info.EpochDuration) * time.Second,
+		Capped:        capped,
+	}, nil
+}
+
+// EstimateEpochsForBudget fits epochs to a WAL budget using live pricing from
+// 'walrus info --json', falling back to the network's default pricing.
+func EstimateEpochsForBudget(siteSize int64, budgetWAL float64, network, walrusBin string) (*EpochBudget, error) {
+	info, err := GetStorageInfo(walrusBin)
+	if err != nil || info == nil {
+		if network == "" {
+			network = GetWalrusContext()
+		}
+		info = DefaultStorageInfo(network)
+	}
+	return EpochsForBudget(siteSize, budgetWAL, info)
+}
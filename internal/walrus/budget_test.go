@@ -0,0 +1,107 @@
+package walrus
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEpochsForBudget(t *testing.T) {
+	const oneMiB = 1024 * 1024
+
+	tests := []struct {
+		name       string
+		network    string
+		siteSize   int64
+		budget     float64
+		wantEpochs int
+		wantCapped bool
+		wantShort  float64 // expected shortfall in WAL, 0 when affordable
+	}{
+		// Testnet, 1 MiB site -> 8 storage units:
+		// fixed = 62,000 + 8*2,000 = 78,000 FROST; per epoch = 8*1,000 = 8,000 FROST
+		{name: "testnet partial epoch rounds down", network: "testnet", siteSize: oneMiB, budget: 0.0001, wantEpochs: 2},
+		{name: "testnet exact fit", network: "testnet", siteSize: oneMiB, budget: 0.000126, wantEpochs: 6},
+		{name: "testnet capped at max epochs", network: "testnet", siteSize: oneMiB, budget: 10, wantEpochs: DefaultMaxEpochsAhead, wantCapped: true},
+		{name: "testnet below one epoch", network: "testnet", siteSize: oneMiB, budget: 0.00008, wantShort: 0.000006},
+		// Mainnet, 1 MiB site -> 8 storage units:
+		// fixed = 682,000 + 8*20,000 = 842,000 FROST; per epoch = 8*11,000 = 88,000 FROST
+		{name: "mainnet single epoch", network: "mainnet", siteSize: oneMiB, budget: 0.001, wantEpochs: 1},
+		{name: "mainnet several epochs", network: "mainnet", siteSize: oneMiB, budget: 0.002, wantEpochs: 13},
+		{name: "mainnet below one epoch", network: "mainnet", siteSize: oneMiB, budget: 0.0005, wantShort: 0.00043},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := DefaultStorageInfo(tt.network)
+			got, err := EpochsForBudget(tt.siteSize, tt.budget, info)
+
+			if tt.wantShort > 0 {
+				var shortErr *BudgetShortfallError
+				if !errors.As(err, &shortErr) {
+					t.Fatalf("expected BudgetShortfallError, got %v", err)
+				}
+				if math.Abs(shortErr.Shortfall()-tt.wantShort) > 1e-9 {
+					t.Errorf("Shortfall() = %.9f, want %.9f", shortErr.Shortfall(), tt.wantShort)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("EpochsForBudget failed: %v", err)
+			}
+			if got.Epochs != tt.wantEpochs {
+				t.Errorf("Epochs = %d, want %d", got.Epochs, tt.wantEpochs)
+			}
+			if got.Capped != tt.wantCapped {
+				t.Errorf("Capped = %v, want %v", got.Capped, tt.wantCapped)
+			}
+			if got.TotalWAL > tt.budget+1e-12 {
+				t.Errorf("TotalWAL %.9f exceeds budget %.9f", got.TotalWAL, tt.budget)
+			}
+
+			// The result must agree with CalculateCost for the same inputs.
+			breakdown, err := CalculateCost(CostOptions{SiteSize: tt.siteSize, Epochs: got.Epochs, Network: tt.network, GasPrice: 1, WalrusBin: "/nonexistent/walrus"})
+			if err != nil {
+				t.Fatalf("CalculateCost failed: %v", err)
+			}
+			if math.Abs(breakdown.TotalWAL-got.TotalWAL) > 1e-12 {
+				t.Errorf("TotalWAL = %.9f, CalculateCost = %.9f", got.TotalWAL, breakdown.TotalWAL)
+			}
+		})
+	}
+}
+
+func TestEpochsForBudgetInvalidInput(t *testing.T) {
+	info := DefaultStorageInfo("testnet")
+	if _, err := EpochsForBudget(0, 1, info); err == nil {
+		t.Error("expected error for zero site size")
+	}
+	if _, err := EpochsForBudget(1024, 0, info); err == nil {
+		t.Error("expected error for zero budget")
+	}
+	if _, err := EpochsForBudget(1024, 1, nil); err == nil {
+		t.Error("expected error for missing pricing")
+	}
+}
+
+func TestEpochBudgetExpiry(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testnet, err := EpochsForBudget(1024*1024, 0.0001, DefaultStorageInfo("testnet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := testnet.Expiry(start), start.Add(2*24*time.Hour); !got.Equal(want) {
+		t.Errorf("testnet expiry = %v, want %v", got, want)
+	}
+
+	mainnet, err := EpochsForBudget(1024*1024, 0.001, DefaultStorageInfo("mainnet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mainnet.Expiry(start), start.Add(14*24*time.Hour); !got.Equal(want) {
+		t.Errorf("mainnet expiry = %v, want %v", got, want)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"sync"
 	"sync/atomic"
 
 	"github.com/selimozten/walgo/internal/deps"
@@ -54,7 +55,8 @@ func (s safeWriter) Write(p []byte) (int, error) {
 }
 
 // runCommandWithTimeout executes a command with a timeout context.
-// Returns stdout, stderr, and any error.
+// Returns stdout, stderr, and any error. Output is also streamed line by line
+// to the ctx's OutputHandler, if one is set.
 func runCommandWithTimeout(ctx context.Context, name string, args []string, streamOutput bool) (string, string, error) {
 	if ctx == nil {
 		var cancel context.CancelFunc
@@ -64,19 +66,34 @@ func runCommandWithTimeout(ctx context.Context, name string, args []string, stre
 
 	cmd := execCommandContext(ctx, name, args...)
 	var stdout, stderr bytes.Buffer
+	stdoutWriters := []io.Writer{&stdout}
+	stderrWriters := []io.Writer{&stderr}
 
 	if streamOutput {
 		// Use safeWriter for os.Stdout/os.Stderr to prevent errors when
 		// running from a GUI app (e.g. Wails) where standard handles may
 		// be invalid. The buffer always captures output reliably.
-		cmd.Stdout = io.MultiWriter(safeWriter{os.Stdout}, &stdout)
-		cmd.Stderr = io.MultiWriter(safeWriter{os.Stderr}, &stderr)
-	} else {
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
+		stdoutWriters = append(stdoutWriters, safeWriter{os.Stdout})
+		stderrWriters = append(stderrWriters, safeWriter{os.Stderr})
 	}
 
+	var stdoutLines, stderrLines *lineWriter
+	if handler := outputHandlerFrom(ctx); handler != nil {
+		var mu sync.Mutex
+		stdoutLines = newLineWriter(&mu, handler)
+		stderrLines = newLineWriter(&mu, handler)
+		stdoutWriters = append(stdoutWriters, stdoutLines)
+		stderrWriters = append(stderrWriters, stderrLines)
+	}
+
+	cmd.Stdout = io.MultiWriter(stdoutWriters...)
+	cmd.Stderr = io.MultiWriter(stderrWriters...)
+
 	err := cmd.Run()
+	if stdoutLines != nil {
+		stdoutLines.Flush()
+		stderrLines.Flush()
+	}
 
 	if ctx.Err() == context.DeadlineExceeded {
 		return stdout.String(), stderr.String(), fmt.Errorf("command timed out after %v - the operation took too long", DefaultCommandTimeout)
@@ -0,0 +1,237 @@
+package walrus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/selimozten/walgo/internal/config"
+)
+
+// Default Walrus HTTP endpoints for each network.
+// Mainnet has no public publisher, so only the aggregator is listed there.
+const (
+	WalrusTestnetAggregator = "https://aggregator.walrus-testnet.walrus.space"
+	WalrusTestnetPublisher  = "https://publisher.walrus-testnet.walrus.space"
+	WalrusMainnetAggregator = "https://aggregator.walrus-mainnet.walrus.space"
+)
+
+// Connectivity probe defaults.
+const (
+	DefaultProbeTimeout  = 10 * time.Second
+	DefaultSlowThreshold = 2 * time.Second
+)
+
+// EndpointKind identifies how an endpoint is probed.
+type EndpointKind string
+
+const (
+	EndpointSuiRPC     EndpointKind = "sui-rpc"
+	EndpointAggregator EndpointKind = "aggregator"
+	EndpointPublisher  EndpointKind = "publisher"
+)
+
+// Endpoint is a network service Walgo depends on.
+type Endpoint struct {
+	Name string
+	Kind EndpointKind
+	URL  string
+}
+
+// EndpointStatus is the result of probing a single endpoint.
+type EndpointStatus struct {
+	Endpoint
+	Reachable  bool
+	Slow       bool
+	Latency    time.Duration
+	StatusCode int
+	Err        error
+	// NetworkError is true when the request never reached an HTTP server
+	// (DNS failure, connection refused, timeout).
+	NetworkError bool
+}
+
+// ConnectivityReport summarizes a set of endpoint probes.
+type ConnectivityReport struct {
+	Results []EndpointStatus
+}
+
+// Offline reports whether every probe failed at the network level, which
+// points to a local connectivity problem rather than a single endpoint outage.
+func (r *ConnectivityReport) Offline() bool {
+	if len(r.Results) == 0 {
+		return false
+	}
+	for _, res := range r.Results {
+		if !res.NetworkError {
+			return false
+		}
+	}
+	return true
+}
+
+// Unreachable returns the endpoints that failed their probe.
+func (r *ConnectivityReport) Unreachable() []EndpointStatus {
+	var down []EndpointStatus
+	for _, res := range r.Results {
+		if !res.Reachable {
+			down = append(down, res)
+		}
+	}
+	return down
+}
+
+// ProbeOptions controls connectivity probing.
+type ProbeOptions struct {
+	Timeout       time.Duration // Per-endpoint timeout (default DefaultProbeTimeout)
+	SlowThreshold time.Duration // Latency above which a reachable endpoint is flagged slow (default DefaultSlowThreshold)
+	Client        *http.Client  // Optional HTTP client override
+}
+
+// NetworkEndpoints returns the endpoints used for the given network, with any
+// gateway overrides from walgo.yaml applied.
+func NetworkEndpoints(network string, gw config.GatewayConfig) []Endpoint {
+	aggregator, publisher := WalrusTestnetAggregator, WalrusTestnetPublisher
+	if strings.EqualFold(network, "mainnet") {
+		aggregator, publisher = WalrusMainnetAggregator, ""
+	}
+	if gw.AggregatorURL != "" {
+		aggregator = gw.AggregatorURL
+	}
+	if gw.PublisherURL != "" {
+		publisher = gw.PublisherURL
+	}
+
+	endpoints := []Endpoint{
+		{Name: "Sui RPC", Kind: EndpointSuiRPC, URL: ResolveRPCEndpoint(network, gw.SuiRPCURL)},
+		{Name: "Walrus aggregator", Kind: EndpointAggregator, URL: aggregator},
+	}
+	if publisher != "" {
+		endpoints = append(endpoints, Endpoint{Name: "Walrus publisher", Kind: EndpointPublisher, URL: publisher})
+	}
+	return endpoints
+}
+
+// CheckConnectivity probes all endpoints concurrently and returns the results
+// in the same order as endpoints.
+func CheckConnectivity(ctx context.Context, endpoints []Endpoint, opts ProbeOptions) *ConnectivityReport {
+	report := &ConnectivityReport{Results: make([]EndpointStatus, len(endpoints))}
+
+	var wg sync.WaitGroup
+	for i, ep := range endpoints {
+		wg.Add(1)
+		go func(i int, ep Endpoint) {
+			defer wg.Done()
+			report.Results[i] = ProbeEndpoint(ctx, ep, opts)
+		}(i, ep)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// ProbeEndpoint checks a single endpoint. Sui RPC endpoints receive a
+// JSON-RPC ping; Walrus endpoints receive an HTTP HEAD request.
+func ProbeEndpoint(ctx context.Context, ep Endpoint, opts ProbeOptions) EndpointStatus {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultProbeTimeout
+	}
+	if opts.SlowThreshold <= 0 {
+		opts.SlowThreshold = DefaultSlowThreshold
+	}
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	status := EndpointStatus{Endpoint: ep}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	req, err := newProbeRequest(ctx, ep)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	status.Latency = time.Since(start)
+	if err != nil {
+		status.Err = err
+		status.NetworkError = true
+		return status
+	}
+	defer resp.Body.Close()
+	status.StatusCode = resp.StatusCode
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		status.Err = fmt.Errorf("server returned %s", resp.Status)
+		return status
+	}
+
+	if ep.Kind == EndpointSuiRPC {
+		if err := checkRPCResponse(resp); err != nil {
+			status.Err = err
+			return status
+		}
+	}
+
+	status.Reachable = true
+	status.Slow = status.Latency > opts.SlowThreshold
+	return status
+}
+
+func newProbeRequest(ctx context.Context, ep Endpoint) (*http.Request, error) {
+	if ep.URL == "" {
+		return nil, fmt.Errorf("no URL configured")
+	}
+
+	if ep.Kind != EndpointSuiRPC {
+		return http.NewRequestWithContext(ctx, http.MethodHead, ep.URL, nil)
+	}
+
+	body, err := json.Marshal(SuiRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "sui_getChainIdentifier",
+		Params:  []interface{}{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+func checkRPCResponse(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("RPC returned %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var rpcResp SuiRPCResponse
+	if err := json.Unmarshal(data, &rpcResp); err != nil {
+		return fmt.Errorf("not a JSON-RPC endpoint: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package walrus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/config"
+)
+
+func newRPCServer(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("RPC probe method = %s, want POST", r.Method)
+		}
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"4c78adac"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newStatusServer(t *testing.T, status int, delay time.Duration) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// closedURL returns the URL of a server that is no longer listening.
+func closedURL(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+	return url
+}
+
+func TestProbeEndpoint(t *testing.T) {
+	opts := ProbeOptions{Timeout: time.Second, SlowThreshold: 100 * time.Millisecond}
+
+	tests := []struct {
+		name          string
+		endpoint      Endpoint
+		wantReachable bool
+		wantSlow      bool
+		wantNetErr    bool
+	}{
+		{
+			name:          "reachable RPC",
+			endpoint:      Endpoint{Name: "Sui RPC", Kind: EndpointSuiRPC, URL: newRPCServer(t, 0).URL},
+			wantReachable: true,
+		},
+		{
+			name:          "slow RPC",
+			endpoint:      Endpoint{Name: "Sui RPC", Kind: EndpointSuiRPC, URL: newRPCServer(t, 200*time.Millisecond).URL},
+			wantReachable: true,
+			wantSlow:      true,
+		},
+		{
+			name:          "reachable aggregator returning 404",
+			endpoint:      Endpoint{Name: "Walrus aggregator", Kind: EndpointAggregator, URL: newStatusServer(t, http.StatusNotFound, 0).URL},
+			wantReachable: true,
+		},
+		{
+			name:     "publisher returning 503",
+			endpoint: Endpoint{Name: "Walrus publisher", Kind: EndpointPublisher, URL: newStatusServer(t, http.StatusServiceUnavailable, 0).URL},
+		},
+		{
+			name:     "RPC that is not JSON-RPC",
+			endpoint: Endpoint{Name: "Sui RPC", Kind: EndpointSuiRPC, URL: newStatusServer(t, http.StatusOK, 0).URL},
+		},
+		{
+			name:       "connection refused",
+			endpoint:   Endpoint{Name: "Walrus aggregator", Kind: EndpointAggregator, URL: closedURL(t)},
+			wantNetErr: true,
+		},
+		{
+			name:       "timeout",
+			endpoint:   Endpoint{Name: "Walrus aggregator", Kind: EndpointAggregator, URL: newStatusServer(t, http.StatusOK, 2*time.Second).URL},
+			wantNetErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProbeEndpoint(context.Background(), tt.endpoint, opts)
+			if got.Reachable != tt.wantReachable {
+				t.Errorf("Reachable = %v, want %v (err: %v)", got.Reachable, tt.wantReachable, got.Err)
+			}
+			if got.Slow != tt.wantSlow {
+				t.Errorf("Slow = %v, want %v (latency %s)", got.Slow, tt.wantSlow, got.Latency)
+			}
+			if got.NetworkError != tt.wantNetErr {
+				t.Errorf("NetworkError = %v, want %v", got.NetworkError, tt.wantNetErr)
+			}
+			if !tt.wantReachable && got.Err == nil {
+				t.Error("Expected an error for unreachable endpoint")
+			}
+		})
+	}
+}
+
+func TestCheckConnectivity(t *testing.T) {
+	opts := ProbeOptions{Timeout: time.Second}
+
+	t.Run("one endpoint down", func(t *testing.T) {
+		endpoints := []Endpoint{
+			{Name: "Sui RPC", Kind: EndpointSuiRPC, URL: newRPCServer(t, 0).URL},
+			{Name: "Walrus aggregator", Kind: EndpointAggregator, URL: closedURL(t)},
+		}
+		report := CheckConnectivity(context.Background(), endpoints, opts)
+
+		if len(report.Results) != 2 || report.Results[0].Name != "Sui RPC" {
+			t.Fatalf("Results not in endpoint order: %+v", report.Results)
+		}
+		if report.Offline() {
+			t.Error("Offline() = true, want false when one endpoint responds")
+		}
+		down := report.Unreachable()
+		if len(down) != 1 || down[0].Name != "Walrus aggregator" {
+			t.Errorf("Unreachable() = %+v, want only the aggregator", down)
+		}
+	})
+
+	t.Run("all endpoints unreachable", func(t *testing.T) {
+		endpoints := []Endpoint{
+			{Name: "Sui RPC", Kind: EndpointSuiRPC, URL: closedURL(t)},
+			{Name: "Walrus aggregator", Kind: EndpointAggregator, URL: closedURL(t)},
+		}
+		report := CheckConnectivity(context.Background(), endpoints, opts)
+		if !report.Offline() {
+			t.Error("Offline() = false, want true when no endpoint responds")
+		}
+	})
+
+	t.Run("server errors are not offline", func(t *testing.T) {
+		endpoints := []Endpoint{
+			{Name: "Walrus publisher", Kind: EndpointPublisher, URL: newStatusServer(t, http.StatusBadGateway, 0).URL},
+		}
+		report := CheckConnectivity(context.Background(), endpoints, opts)
+		if report.Offline() {
+			t.Error("Offline() = true, want false for an HTTP 502 response")
+		}
+	})
+}
+
+func TestNetworkEndpoints(t *testing.T) {
+	t.Run("testnet defaults", func(t *testing.T) {
+		eps := NetworkEndpoints("testnet", config.GatewayConfig{})
+		if len(eps) != 3 {
+			t.Fatalf("got %d endpoints, want 3", len(eps))
+		}
+		if eps[0].URL != SuiTestnetRPC || eps[1].URL != WalrusTestnetAggregator || eps[2].URL != WalrusTestnetPublisher {
+			t.Errorf("unexpected endpoints: %+v", eps)
+		}
+	})
+
+	t.Run("mainnet has no public publisher", func(t *testing.T) {
+		eps := NetworkEndpoints("mainnet", config.GatewayConfig{})
+		for _, ep := range eps {
+			if ep.Kind == EndpointPublisher {
+				t.Errorf("unexpected publisher endpoint on mainnet: %s", ep.URL)
+			}
+			if !strings.Contains(ep.URL, "mainnet") {
+				t.Errorf("endpoint %s is not a mainnet URL", ep.URL)
+			}
+		}
+	})
+
+	t.Run("gateway overrides", func(t *testing.T) {
+		gw := config.GatewayConfig{
+			SuiRPCURL:     "https://rpc.example.com",
+			AggregatorURL: "https://agg.example.com",
+			PublisherURL:  "https://pub.example.com",
+		}
+		eps := NetworkEndpoints("mainnet", gw)
+		if len(eps) != 3 {
+			t.Fatalf("got %d endpoints, want 3", len(eps))
+		}
+		if eps[0].URL != gw.SuiRPCURL || eps[1].URL != gw.AggregatorURL || eps[2].URL != gw.PublisherURL {
+			t.Errorf("overrides not applied: %+v", eps)
+		}
+	})
+}
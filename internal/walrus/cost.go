@@ -9,19 +9,19 @@ import (
 	"io"
 	"math"
 	"net/http"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/selimozten/walgo/internal/deps"
 	"github.com/selimozten/walgo/internal/sui"
+	"github.com/selimozten/walgo/internal/ui"
 )
 
 // Default RPC endpoints for Sui networks
 const (
-	SuiTestnetRPC = "https://fullnode.testnet.sui.io:443"
-	SuiMainnetRPC = "https://fullnode.mainnet.sui.io:443"
+	SuiTestnetRPC = sui.TestnetRPC
+	SuiMainnetRPC = sui.MainnetRPC
 )
 
 // GetWalrusContext returns the walrus context based on the active Sui environment
@@ -101,17 +101,35 @@ type WalrusInfoJSON struct {
 
 // StorageInfo contains parsed walrus info storage parameters
 type StorageInfo struct {
-	CurrentEpoch       int     `json:"current_epoch"`
-	EpochDuration      int     `json:"epoch_duration_secs"` // Duration in seconds
-	StoragePrice       uint64  `json:"storage_price"`       // Price per encoded MiB per epoch in FROST
-	WritePrice         uint64  `json:"write_price"`         // Write price per encoded MiB in FROST
-	MetadataPrice      uint64  `json:"metadata_price"`      // Fixed metadata cost in FROST
-	MarginalPrice      uint64  `json:"marginal_price"`      // Per unencoded MiB cost in FROST
-	MaxBlobSize        int64   `json:"max_blob_size"`       // Maximum blob size in bytes
-	StorageUnitSize    int64   `json:"storage_unit_size"`   // Storage unit size (1 MiB)
-	NumShards          int     `json:"num_shards"`          // Number of storage shards
-	MaxEpochsAhead     int     `json:"max_epochs_ahead"`    // Max epochs for storage
-	EncodingMultiplier float64 `json:"encoding_multiplier"` // Encoding expansion factor (~5-8x depending on size)
+	CurrentEpoch       int       `json:"current_epoch"`
+	CurrentEpochStart  time.Time `json:"current_epoch_start"` // Zero when walrus info omits it
+	EpochDuration      int       `json:"epoch_duration_secs"` // Duration in seconds
+	StoragePrice       uint64    `json:"storage_price"`       // Price per encoded MiB per epoch in FROST
+	WritePrice         uint64    `json:"write_price"`         // Write price per encoded MiB in FROST
+	MetadataPrice      uint64    `json:"metadata_price"`      // Fixed metadata cost in FROST
+	MarginalPrice      uint64    `json:"marginal_price"`      // Per unencoded MiB cost in FROST
+	MaxBlobSize        int64     `json:"max_blob_size"`       // Maximum blob size in bytes
+	StorageUnitSize    int64     `json:"storage_unit_size"`   // Storage unit size (1 MiB)
+	NumShards          int       `json:"num_shards"`          // Number of storage shards
+	MaxEpochsAhead     int       `json:"max_epochs_ahead"`    // Max epochs for storage
+	EncodingMultiplier float64   `json:"encoding_multiplier"` // Encoding expansion factor (~5-8x depending on size)
+}
+
+// EpochAt returns the epoch that was current at t, or 0 when walrus info did
+// not report the current epoch's start and length.
+func (info *StorageInfo) EpochAt(t time.Time) int {
+	if info.CurrentEpochStart.IsZero() || info.EpochDuration <= 0 {
+		return 0
+	}
+	if !t.Before(info.CurrentEpochStart) {
+		return info.CurrentEpoch
+	}
+	length := time.Duration(info.EpochDuration) * time.Second
+	back := int((info.CurrentEpochStart.Sub(t) + length - 1) / length)
+	if back >= info.CurrentEpoch {
+		return 0
+	}
+	return info.CurrentEpoch - back
 }
 
 // CostBreakdown provides detailed cost breakdown for storage operations
@@ -241,12 +259,9 @@ func GetStorageInfo(walrusBin string) (*StorageInfo, error) {
 }
 
 // stripANSI removes ANSI escape codes (color codes) from byte slice
-// ANSI codes follow pattern: ESC [ <params> <letter>
 // Example: \x1b[32m (green), \x1b[0m (reset)
-var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
-
 func stripANSI(data []byte) []byte {
-	return ansiRegex.ReplaceAll(data, []byte{})
+	return []byte(ui.StripANSI(string(data)))
 }
 
 // ParseStorageInfoJSON parses the walrus info JSON output
@@ -291,6 +306,9 @@ func ParseStorageInfoJSON(output []byte) (*StorageInfo, error) {
 		MaxEpochsAhead:     walrusInfo.EpochInfo.MaxEpochsAhead,
 		EncodingMultiplier: 5.0, // Default, will be calculated more accurately per-blob
 	}
+	if start, err := time.Parse(time.RFC3339, walrusInfo.EpochInfo.StartOfCurrentEpoch.DateTime); err == nil {
+		info.CurrentEpochStart = start
+	}
 
 	// Get encoding-specific pricing (RS2 encoding)
 	if len(walrusInfo.PriceInfo.EncodingDependentPriceInfo) > 0 {
@@ -391,16 +409,14 @@ func GetEncodedSizeFromDryRun(filePath string, walrusBin string) (int64, error)
 	return result.EncodedSize, nil
 }
 
+// ResolveRPCEndpoint returns override when set, otherwise the default RPC endpoint for the network.
+func ResolveRPCEndpoint(network, override string) string {
+	return sui.ResolveRPCEndpoint(network, override)
+}
+
 // GetRPCEndpoint returns the appropriate RPC endpoint for the network
 func GetRPCEndpoint(network string) string {
-	switch strings.ToLower(network) {
-	case "mainnet":
-		return SuiMainnetRPC
-	case "testnet":
-		return SuiTestnetRPC
-	default:
-		return SuiTestnetRPC
-	}
+	return sui.DefaultRPCEndpoint(network)
 }
 
 // DefaultGasPrice returns the fallback gas price for the network
@@ -415,6 +431,65 @@ func DefaultGasPrice(network string) uint64 {
 	}
 }
 
+// DefaultStorageInfo returns fallback Walrus pricing for a network, used when
+// live pricing from 'walrus info --json' is unavailable.
+// Mainnet is ~10-11x more expensive than testnet (Dec 2025 pricing).
+func DefaultStorageInfo(network string) *StorageInfo {
+	if strings.ToLower(network) == "mainnet" {
+		return &StorageInfo{
+			StoragePrice:       11000,   // 11,000 FROST per MiB per epoch
+			WritePrice:         20000,   // 20,000 FROST per MiB (one-time)
+			MetadataPrice:      682000,  // Fixed metadata cost in FROST
+			MarginalPrice:      66000,   // Per unencoded MiB cost in FROST
+			StorageUnitSize:    1048576, // 1 MiB
+			EpochDuration:      1209600, // 14 days
+			MaxEpochsAhead:     DefaultMaxEpochsAhead,
+			EncodingMultiplier: 8.0, // Reed-Solomon ~8x expansion for small sites
+		}
+	}
+	return &StorageInfo{
+		StoragePrice:       1000,    // 1,000 FROST per MiB per epoch
+		WritePrice:         2000,    // 2,000 FROST per MiB (one-time)
+		MetadataPrice:      62000,   // Fixed metadata cost in FROST
+		MarginalPrice:      6000,    // Per unencoded MiB cost in FROST
+		StorageUnitSize:    1048576, // 1 MiB
+		EpochDuration:      86400,   // 1 day
+		MaxEpochsAhead:     DefaultMaxEpochsAhead,
+		EncodingMultiplier: 8.0, // Reed-Solomon ~8x expansion for small sites
+	}
+}
+
+// metadataCostFor returns the fixed per-blob metadata cost in FROST.
+func metadataCostFor(info *StorageInfo) float64 {
+	if info.MetadataPrice == 0 {
+		return 62000 // Default from walrus info
+	}
+	return float64(info.MetadataPrice)
+}
+
+// encodedSizeFor returns the encoded blob size for siteSize. It uses the live
+// encoding multiplier from walrus info when available, otherwise the
+// size-based heuristic.
+func encodedSizeFor(siteSize int64, info *StorageInfo) int64 {
+	if info.EncodingMultiplier > 0 {
+		return calculateEncodedSizeWithMultiplier(siteSize, info.EncodingMultiplier)
+	}
+	return CalculateEncodedSize(siteSize)
+}
+
+// storageUnitsFor converts an encoded size into billable storage units (minimum 1).
+func storageUnitsFor(encodedSize int64, info *StorageInfo) float64 {
+	storageUnitSize := info.StorageUnitSize
+	if storageUnitSize <= 0 {
+		storageUnitSize = 1048576 // Default 1 MiB
+	}
+	units := math.Ceil(float64(encodedSize) / float64(storageUnitSize))
+	if units < 1 {
+		units = 1 // Minimum 1 storage unit
+	}
+	return units
+}
+
 // CalculateCost calculates the full cost for deploying a site
 // Based on Sui gas docs: https://docs.sui.io/concepts/tokenomics/gas-in-sui
 // And Walrus cost docs: https://docs.wal.app/docs/dev-guide/costs
@@ -451,56 +526,15 @@ func CalculateCost(options CostOptions) (*CostBreakdown, error) {
 		fmt.Printf("   Note: Could not get live storage pricing (%v), using defaults\n", storageErr)
 	}
 	if storageInfo == nil {
-		// Use defaults based on actual Walrus pricing (Dec 2025)
-		// Mainnet is ~10-11x more expensive than testnet
-		// From `walrus info --json`: 1 storage unit = 1 MiB
 		network := options.Network
 		if network == "" {
 			network = GetWalrusContext()
 		}
-
-		if strings.ToLower(network) == "mainnet" {
-			// Mainnet pricing (Dec 2025)
-			storageInfo = &StorageInfo{
-				StoragePrice:       11000,   // 11,000 FROST per MiB per epoch
-				WritePrice:         20000,   // 20,000 FROST per MiB (one-time)
-				MetadataPrice:      682000,  // Fixed metadata cost in FROST
-				MarginalPrice:      66000,   // Per unencoded MiB cost in FROST
-				StorageUnitSize:    1048576, // 1 MiB
-				EpochDuration:      1209600, // 14 days
-				EncodingMultiplier: 8.0,     // Reed-Solomon ~8x expansion for small sites
-			}
-		} else {
-			// Testnet pricing (Dec 2025)
-			storageInfo = &StorageInfo{
-				StoragePrice:       1000,    // 1,000 FROST per MiB per epoch
-				WritePrice:         2000,    // 2,000 FROST per MiB (one-time)
-				MetadataPrice:      62000,   // Fixed metadata cost in FROST
-				MarginalPrice:      6000,    // Per unencoded MiB cost in FROST
-				StorageUnitSize:    1048576, // 1 MiB
-				EpochDuration:      86400,   // 1 day
-				EncodingMultiplier: 8.0,     // Reed-Solomon ~8x expansion for small sites
-			}
-		}
+		storageInfo = DefaultStorageInfo(network)
 	}
 
-	// Calculate encoded size in MiB (storage units)
-	// Use live encoding multiplier from walrus info when available,
-	// otherwise fall back to the size-based heuristic.
-	var encodedSizeBytes int64
-	if storageInfo.EncodingMultiplier > 0 {
-		encodedSizeBytes = calculateEncodedSizeWithMultiplier(options.SiteSize, storageInfo.EncodingMultiplier)
-	} else {
-		encodedSizeBytes = CalculateEncodedSize(options.SiteSize)
-	}
-	storageUnitSize := storageInfo.StorageUnitSize
-	if storageUnitSize <= 0 {
-		storageUnitSize = 1048576 // Default 1 MiB
-	}
-	encodedSizeMiB := math.Ceil(float64(encodedSizeBytes) / float64(storageUnitSize))
-	if encodedSizeMiB < 1 {
-		encodedSizeMiB = 1 // Minimum 1 storage unit
-	}
+	encodedSizeBytes := encodedSizeFor(options.SiteSize, storageInfo)
+	encodedSizeMiB := storageUnitsFor(encodedSizeBytes, storageInfo)
 
 	// Estimate file count if not provided
 	fileCount := options.FileCount
@@ -515,10 +549,7 @@ func CalculateCost(options CostOptions) (*CostBreakdown, error) {
 	// Calculate WAL storage cost (per walrus info --json pricing)
 	// Formula: metadata_price + (encoded_storage_units × storage_price × epochs) + (encoded_storage_units × write_price)
 	// 1 WAL = 1,000,000,000 FROST
-	metadataCostFrost := float64(storageInfo.MetadataPrice)
-	if metadataCostFrost == 0 {
-		metadataCostFrost = 62000 // Default from walrus info
-	}
+	metadataCostFrost := metadataCostFor(storageInfo)
 	storageCostFrost := encodedSizeMiB * float64(storageInfo.StoragePrice) * float64(options.Epochs)
 	writeCostFrost := encodedSizeMiB * float64(storageInfo.WritePrice)
 
@@ -584,22 +615,7 @@ func CalculateCost(options CostOptions) (*CostBreakdown, error) {
 func CalculateUpdateCost(changedSize int64, newFiles int, epochs int, network string) (*CostBreakdown, error) {
 	if changedSize <= 0 && newFiles <= 0 {
 		// No changes, just metadata update
-		rpcURL := GetRPCEndpoint(network)
-		gasPrice, err := GetReferenceGasPrice(rpcURL)
-		if err != nil {
-			gasPrice = DefaultGasPrice(network)
-		}
-
-		gasUnits := uint64(50000) // Single transaction
-		gasCostSUI := float64(gasUnits) * float64(gasPrice) / 1e9
-
-		return &CostBreakdown{
-			GasUnits:    gasUnits,
-			GasPrice:    gasPrice,
-			GasCostSUI:  gasCostSUI,
-			MinTotalSUI: gasCostSUI * 0.7,
-			MaxTotalSUI: gasCostSUI * 1.5,
-		}, nil
+		return metadataUpdateCost(CostOptions{Network: network}), nil
 	}
 
 	// Updates only pay for new/changed content
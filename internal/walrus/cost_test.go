@@ -4,6 +4,7 @@ import (
 	"math"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestEncodingMultiplierForSize(t *testing.T) {
@@ -408,6 +409,9 @@ func TestParseStorageInfoJSON(t *testing.T) {
 				if info.CurrentEpoch != 42 {
 					t.Errorf("CurrentEpoch = %d, want 42", info.CurrentEpoch)
 				}
+				if want := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); !info.CurrentEpochStart.Equal(want) {
+					t.Errorf("CurrentEpochStart = %v, want %v", info.CurrentEpochStart, want)
+				}
 				if info.EpochDuration != 86400 {
 					t.Errorf("EpochDuration = %d, want 86400", info.EpochDuration)
 				}
@@ -972,3 +976,30 @@ func TestEncodedSizeMinimum(t *testing.T) {
 		t.Errorf("TotalWAL = %v, should be positive even for 1 byte", breakdown.TotalWAL)
 	}
 }
+
+func TestStorageInfoEpochAt(t *testing.T) {
+	start := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	info := &StorageInfo{CurrentEpoch: 50, CurrentEpochStart: start, EpochDuration: 24 * 60 * 60}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want int
+	}{
+		{"during the current epoch", start.Add(3 * time.Hour), 50},
+		{"at the current epoch's start", start, 50},
+		{"just before it", start.Add(-time.Second), 49},
+		{"start of the previous epoch", start.Add(-24 * time.Hour), 49},
+		{"three epochs back", start.Add(-60 * time.Hour), 47},
+		{"before epoch 1", start.Add(-60 * 24 * time.Hour), 0},
+	}
+	for _, tt := range tests {
+		if got := info.EpochAt(tt.at); got != tt.want {
+			t.Errorf("%s: EpochAt() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	if got := (&StorageInfo{CurrentEpoch: 50}).EpochAt(start); got != 0 {
+		t.Errorf("EpochAt() without the epoch start = %d, want 0", got)
+	}
+}
@@ -14,7 +14,9 @@ import (
 // DeploySite manages the site deployment to Walrus decentralized storage.
 // Executes the `site-builder deploy` command which auto-detects new vs update.
 // Context parameter enables cancellation and timeout control for the operation.
-func DeploySite(ctx context.Context, deployDir string, walrusCfg config.WalrusConfig, epochs int) (*SiteBuilderOutput, error) {
+// With deletable set the site's blobs are stored as deletable, so their storage
+// can be reclaimed before the epochs run out.
+func DeploySite(ctx context.Context, deployDir string, walrusCfg config.WalrusConfig, epochs int, deletable bool) (*SiteBuilderOutput, error) {
 	if epochs <= 0 {
 		return nil, fmt.Errorf("epochs must be greater than 0, got %d", epochs)
 	}
@@ -52,7 +54,7 @@ func DeploySite(ctx context.Context, deployDir string, walrusCfg config.WalrusCo
 			Epochs:    epochs,
 			Network:   network,
 			FileCount: fileCount,
-			RPCURL:    "",
+			RPCURL:    walrusCfg.Gateway.SuiRPCURL,
 		}
 
 		breakdown, err := CalculateCost(options)
@@ -85,12 +87,13 @@ func DeploySite(ctx context.Context, deployDir string, walrusCfg config.WalrusCo
 	}
 
 	siteBuilderContext := GetWalrusContext()
-	args := []string{
-		"--context", siteBuilderContext,
-		"--walrus-binary", walrusPath,
+	args := append(siteBuilderGlobalArgs(walrusCfg, walrusPath),
 		"publish",
 		deployDir,
 		"--epochs", fmt.Sprintf("%d", epochs),
+	)
+	if deletable {
+		args = append(args, "--deletable")
 	}
 
 	if isVerbose() {
@@ -105,7 +108,7 @@ func DeploySite(ctx context.Context, deployDir string, walrusCfg config.WalrusCo
 	fmt.Printf("   (timeout: %v)\n", DefaultCommandTimeout)
 	fmt.Println()
 
-	stdoutStr, stderrStr, err := runCommandWithTimeout(ctx, builderPath, args, true)
+	stdoutStr, stderrStr, jsonMode, err := runSiteBuilder(ctx, builderPath, "publish", args, true)
 	if err != nil {
 		// Build detailed error with full command and output for debugging
 		debugInfo := fmt.Sprintf("\n\nCommand: %s %s\nBuilder: %s\nWalrus: %s\nContext: %s",
@@ -121,9 +124,7 @@ func DeploySite(ctx context.Context, deployDir string, walrusCfg config.WalrusCo
 
 	fmt.Printf("\n%s Site deployment command executed successfully.\n", icons.Success)
 
-	combinedOutput := stdoutStr + "\n" + stderrStr
-	output := parseSiteBuilderOutput(combinedOutput)
-	output.Success = true
+	output := parseDeployResult(stdoutStr, stderrStr, jsonMode)
 
 	if output.ObjectID != "" {
 		fmt.Printf("\n%s Deployment successful!\n", icons.Celebrate)
@@ -146,3 +147,16 @@ func DeploySite(ctx context.Context, deployDir string, walrusCfg config.WalrusCo
 
 	return output, nil
 }
+
+// siteBuilderGlobalArgs returns the site-builder flags that precede the subcommand,
+// including gateway overrides from walgo.yaml when set.
+func siteBuilderGlobalArgs(walrusCfg config.WalrusConfig, walrusPath string) []string {
+	args := []string{
+		"--context", GetWalrusContext(),
+		"--walrus-binary", walrusPath,
+	}
+	if rpcURL := walrusCfg.Gateway.SuiRPCURL; rpcURL != "" {
+		args = append(args, "--rpc-url", rpcURL)
+	}
+	return args
+}
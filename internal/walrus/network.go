@@ -0,0 +1,242 @@
+package walrus
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/sui"
+)
+
+// Sui client lookups and switches used by SetNetwork; replaced in tests.
+var (
+	suiActiveEnv = sui.GetActiveEnv
+	suiEnvs      = sui.GetEnvs
+	suiSwitchEnv = sui.SwitchEnv
+)
+
+// NetworkState is the network each part of the deploy setup points at. An
+// empty field means it could not be read.
+type NetworkState struct {
+	WalgoYAML    string `json:"walgoYaml"`    // walrus.network in walgo.yaml
+	SitesConfig  string `json:"sitesConfig"`  // default_context in sites-config.yaml
+	SuiActiveEnv string `json:"suiActiveEnv"` // sui client active-env
+}
+
+// Consistent reports whether every part points at network.
+func (s *NetworkState) Consistent(network string) bool {
+	return s.WalgoYAML == network && s.SitesConfig == network && s.SuiActiveEnv == network
+}
+
+// networkStep is one change made by SetNetwork and how to undo it.
+type networkStep struct {
+	name     string
+	apply    func() error
+	rollback func() error
+}
+
+// SetNetwork points walgo.yaml in sitePath, site-builder's sites-config.yaml
+// and the Sui client's active environment at network. It first checks that
+// sui, walrus and site-builder are installed and that sites-config.yaml and
+// the Sui client know network, then makes the changes in that order. If one
+// fails, the changes already made are undone. It returns the state read
+// back afterwards.
+func SetNetwork(sitePath, network string) (*NetworkState, error) {
+	sitesPath, err := SitesConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNetworkTarget(sitePath, sitesPath, network); err != nil {
+		return nil, err
+	}
+
+	walgoPath := filepath.Join(sitePath, "walgo.yaml")
+	walgoData, err := os.ReadFile(walgoPath) // #nosec G304 - walgo.yaml of the site
+	if err != nil {
+		return nil, fmt.Errorf("failed to read walgo.yaml: %w", err)
+	}
+	sitesData, err := os.ReadFile(sitesPath) // #nosec G304 - standard sites-config location
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", sitesPath, err)
+	}
+	previousEnv, err := suiActiveEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the active Sui environment: %w", err)
+	}
+
+	steps := []networkStep{
+		{
+			name:     "walgo.yaml",
+			apply:    func() error { return config.UpdateWalgoYAMLNetwork(sitePath, network) },
+			rollback: func() error { return restoreConfigFile(walgoPath, walgoData) },
+		},
+		{
+			name:     "sites-config.yaml",
+			apply:    func() error { return setSitesDefaultContext(sitesPath, network) },
+			rollback: func() error { return restoreConfigFile(sitesPath, sitesData) },
+		},
+		{
+			name:     "sui active env",
+			apply:    func() error { return suiSwitchEnv(network) },
+			rollback: func() error { return suiSwitchEnv(strings.TrimSpace(previousEnv)) },
+		},
+	}
+	if err := runNetworkSteps(steps); err != nil {
+		return nil, err
+	}
+	return CurrentNetworkState(sitePath), nil
+}
+
+// runNetworkSteps applies steps in order. When one fails, the steps already
+// applied are rolled back in reverse order and the error says whether the
+// rollback worked.
+func runNetworkSteps(steps []networkStep) error {
+	for i, step := range steps {
+		err := step.apply()
+		if err == nil {
+			continue
+		}
+		var rollbackErrs []string
+		for j := i - 1; j >= 0; j-- {
+			if rbErr := steps[j].rollback(); rbErr != nil {
+				rollbackErrs = append(rollbackErrs, fmt.Sprintf("%s: %v", steps[j].name, rbErr))
+			}
+		}
+		if len(rollbackErrs) > 0 {
+			return fmt.Errorf("failed to update %s: %w; rolling back also failed, fix by hand: %s",
+				step.name, err, strings.Join(rollbackErrs, "; "))
+		}
+		return fmt.Errorf("failed to update %s: %w (earlier changes were rolled back)", step.name, err)
+	}
+	return nil
+}
+
+// checkNetworkTarget reports what is missing to deploy to network.
+func checkNetworkTarget(sitePath, sitesPath, network string) error {
+	defaults, ok := siteNetworks[network]
+	if !ok {
+		return fmt.Errorf("unsupported network: %s. Use 'mainnet', 'testnet'", network)
+	}
+	if _, err := os.Stat(filepath.Join(sitePath, "walgo.yaml")); err != nil {
+		return fmt.Errorf("walgo.yaml not found in %s: run this in a walgo site", sitePath)
+	}
+
+	var missing []string
+	for _, tool := range []string{"sui", "walrus", siteBuilderCmd} {
+		if _, err := execLookPath(tool); err != nil {
+			missing = append(missing, tool)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing tools: %s (install them with: walgo setup-deps)", strings.Join(missing, ", "))
+	}
+
+	sc, err := readSitesConfig(sitesPath)
+	if err != nil {
+		return fmt.Errorf("%w (create it with: walgo setup %s)", err, network)
+	}
+	if _, ok := sc.Contexts[network]; !ok {
+		return fmt.Errorf("%s has no %s context (recreate it with: walgo setup %s --force)", sitesPath, network, network)
+	}
+
+	envs, err := suiEnvs()
+	if err != nil {
+		return fmt.Errorf("failed to list Sui environments: %w", err)
+	}
+	for _, env := range envs {
+		if env == network {
+			return nil
+		}
+	}
+	return fmt.Errorf("the Sui client has no %s environment (add it with: sui client new-env --alias %s --rpc %s)",
+		network, network, defaults.RPCURL)
+}
+
+// CurrentNetworkState reads the network walgo.yaml in sitePath,
+// sites-config.yaml and the Sui client point at.
+func CurrentNetworkState(sitePath string) *NetworkState {
+	state := &NetworkState{}
+	if cfg, err := config.LoadConfigFrom(sitePath); err == nil {
+		state.WalgoYAML = cfg.WalrusConfig.Network
+	}
+	if sitesPath, err := SitesConfigPath(); err == nil {
+		if sc, err := readSitesConfig(sitesPath); err == nil {
+			state.SitesConfig = sc.DefaultContext
+		}
+	}
+	if env, err := suiActiveEnv(); err == nil {
+		state.SuiActiveEnv = strings.TrimSpace(env)
+	}
+	return state
+}
+
+// readSitesConfig parses the sites-config.yaml at path.
+func readSitesConfig(path string) (*SitesConfig, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is the chosen config location
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var sc SitesConfig
+	if err := yaml.Unmarshal(data, &sc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &sc, nil
+}
+
+// setSitesDefaultContext sets default_context in the sites-config.yaml at
+// path, keeping the rest of the file, comments included.
+func setSitesDefaultContext(path, network string) error {
+	data, err := os.ReadFile(path) // #nosec G304 - path is the chosen config location
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("%s is not a YAML mapping", path)
+	}
+
+	root := doc.Content[0]
+	found := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "default_context" {
+			root.Content[i+1].SetString(network)
+			found = true
+		}
+	}
+	if !found {
+		key := &yaml.Node{}
+		key.SetString("default_context")
+		value := &yaml.Node{}
+		value.SetString(network)
+		root.Content = append(root.Content, key, value)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	// #nosec G306 - config file needs to be readable for site-builder
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// restoreConfigFile writes a config file's earlier contents back.
+func restoreConfigFile(path string, data []byte) error {
+	// #nosec G306 - config files need to be readable by the tools using them
+	return os.WriteFile(path, data, 0644)
+}
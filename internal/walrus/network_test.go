@@ -0,0 +1,242 @@
+package walrus
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSitesConfig = `# site-builder configuration
+contexts:
+  testnet:
+    package: 0xtest
+    general:
+      rpc_url: https://fullnode.testnet.sui.io:443
+  mainnet:
+    package: 0xmain
+    general:
+      rpc_url: https://fullnode.mainnet.sui.io:443
+default_context: testnet
+`
+
+// fakeSui stands in for the Sui client: its active env and how switching
+// to an env behaves.
+type fakeSui struct {
+	active    string
+	envs      []string
+	switchErr map[string]error
+	switches  []string
+}
+
+// setupNetworkTest creates a site on testnet with a sites-config under a
+// fake HOME and installs s as the Sui client.
+func setupNetworkTest(t *testing.T, s *fakeSui) (sitePath, sitesPath string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	sitePath = t.TempDir()
+	if err := os.WriteFile(filepath.Join(sitePath, "walgo.yaml"), []byte("walrus:\n  network: testnet\n  projectID: 0xsite\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sitesPath = filepath.Join(home, ".config", "walrus", "sites-config.yaml")
+	if err := os.MkdirAll(filepath.Dir(sitesPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(sitesPath, []byte(testSitesConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	origLookPath, origActive, origEnvs, origSwitch := execLookPath, suiActiveEnv, suiEnvs, suiSwitchEnv
+	t.Cleanup(func() {
+		execLookPath, suiActiveEnv, suiEnvs, suiSwitchEnv = origLookPath, origActive, origEnvs, origSwitch
+	})
+	execLookPath = func(name string) (string, error) { return "/opt/bin/" + name, nil }
+	suiActiveEnv = func() (string, error) { return s.active + "\n", nil }
+	suiEnvs = func() ([]string, error) { return s.envs, nil }
+	suiSwitchEnv = func(env string) error {
+		s.switches = append(s.switches, env)
+		if err := s.switchErr[env]; err != nil {
+			return err
+		}
+		s.active = env
+		return nil
+	}
+	return sitePath, sitesPath
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSetNetwork(t *testing.T) {
+	s := &fakeSui{active: "testnet", envs: []string{"testnet", "mainnet"}}
+	sitePath, sitesPath := setupNetworkTest(t, s)
+
+	state, err := SetNetwork(sitePath, "mainnet")
+	if err != nil {
+		t.Fatalf("SetNetwork() error = %v", err)
+	}
+	if !state.Consistent("mainnet") {
+		t.Errorf("state = %+v, want everything on mainnet", state)
+	}
+
+	walgo := readFile(t, filepath.Join(sitePath, "walgo.yaml"))
+	if !strings.Contains(walgo, "network: mainnet") || !strings.Contains(walgo, "projectID: 0xsite") {
+		t.Errorf("walgo.yaml should switch network and keep the rest:\n%s", walgo)
+	}
+	sites := readFile(t, sitesPath)
+	if !strings.Contains(sites, "default_context: mainnet") || !strings.Contains(sites, "# site-builder configuration") {
+		t.Errorf("sites-config.yaml should switch default_context and keep comments:\n%s", sites)
+	}
+	if len(s.switches) != 1 || s.active != "mainnet" {
+		t.Errorf("sui switches = %v, want [mainnet]", s.switches)
+	}
+}
+
+func TestSetNetworkRollsBackOnFailure(t *testing.T) {
+	s := &fakeSui{
+		active:    "testnet",
+		envs:      []string{"testnet", "mainnet"},
+		switchErr: map[string]error{"mainnet": errors.New("sui command failed: exit status 1")},
+	}
+	sitePath, sitesPath := setupNetworkTest(t, s)
+	walgoBefore := readFile(t, filepath.Join(sitePath, "walgo.yaml"))
+
+	_, err := SetNetwork(sitePath, "mainnet")
+	if err == nil {
+		t.Fatal("SetNetwork() should fail when the Sui env cannot be switched")
+	}
+	if !strings.Contains(err.Error(), "sui active env") || !strings.Contains(err.Error(), "rolled back") {
+		t.Errorf("error = %v, want the failed step and the rollback", err)
+	}
+	if got := readFile(t, filepath.Join(sitePath, "walgo.yaml")); got != walgoBefore {
+		t.Errorf("walgo.yaml not restored:\n%s", got)
+	}
+	if got := readFile(t, sitesPath); got != testSitesConfig {
+		t.Errorf("sites-config.yaml not restored:\n%s", got)
+	}
+	if state := CurrentNetworkState(sitePath); !state.Consistent("testnet") {
+		t.Errorf("state after rollback = %+v, want everything on testnet", state)
+	}
+}
+
+func TestRunNetworkSteps(t *testing.T) {
+	var log []string
+	step := func(name string, applyErr, rollbackErr error) networkStep {
+		return networkStep{
+			name: name,
+			apply: func() error {
+				log = append(log, "apply "+name)
+				return applyErr
+			},
+			rollback: func() error {
+				log = append(log, "rollback "+name)
+				return rollbackErr
+			},
+		}
+	}
+
+	t.Run("mid-step failure rolls back in reverse", func(t *testing.T) {
+		log = nil
+		err := runNetworkSteps([]networkStep{
+			step("a", nil, nil),
+			step("b", nil, nil),
+			step("c", errors.New("boom"), nil),
+			step("d", nil, nil),
+		})
+		if err == nil || !strings.Contains(err.Error(), "failed to update c: boom") {
+			t.Fatalf("runNetworkSteps() error = %v", err)
+		}
+		want := "apply a,apply b,apply c,rollback b,rollback a"
+		if got := strings.Join(log, ","); got != want {
+			t.Errorf("steps = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("rollback failure is reported", func(t *testing.T) {
+		log = nil
+		err := runNetworkSteps([]networkStep{
+			step("a", nil, errors.New("read-only")),
+			step("b", errors.New("boom"), nil),
+		})
+		if err == nil || !strings.Contains(err.Error(), "rolling back also failed") || !strings.Contains(err.Error(), "a: read-only") {
+			t.Errorf("runNetworkSteps() error = %v", err)
+		}
+	})
+
+	t.Run("success applies every step", func(t *testing.T) {
+		log = nil
+		if err := runNetworkSteps([]networkStep{step("a", nil, nil), step("b", nil, nil)}); err != nil {
+			t.Fatalf("runNetworkSteps() error = %v", err)
+		}
+		if got := strings.Join(log, ","); got != "apply a,apply b" {
+			t.Errorf("steps = %s", got)
+		}
+	})
+}
+
+func TestSetNetworkValidatesTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		network string
+		envs    []string
+		prepare func(t *testing.T, sitePath, sitesPath string)
+		want    string
+	}{
+		{name: "unknown network", network: "devnet", envs: []string{"testnet"}, want: "unsupported network"},
+		{name: "no sui env", network: "mainnet", envs: []string{"testnet"}, want: "sui client new-env --alias mainnet"},
+		{
+			name: "missing tool", network: "mainnet", envs: []string{"testnet", "mainnet"},
+			prepare: func(t *testing.T, _, _ string) {
+				execLookPath = func(name string) (string, error) {
+					if name == "site-builder" {
+						return "", errors.New("not found")
+					}
+					return "/opt/bin/" + name, nil
+				}
+			},
+			want: "missing tools: site-builder",
+		},
+		{
+			name: "no sites-config context", network: "mainnet", envs: []string{"testnet", "mainnet"},
+			prepare: func(t *testing.T, _, sitesPath string) {
+				if err := os.WriteFile(sitesPath, []byte("contexts:\n  testnet:\n    package: 0xtest\ndefault_context: testnet\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "walgo setup mainnet --force",
+		},
+		{
+			name: "no walgo.yaml", network: "mainnet", envs: []string{"testnet", "mainnet"},
+			prepare: func(t *testing.T, sitePath, _ string) {
+				if err := os.Remove(filepath.Join(sitePath, "walgo.yaml")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "walgo.yaml not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &fakeSui{active: "testnet", envs: tt.envs}
+			sitePath, sitesPath := setupNetworkTest(t, s)
+			if tt.prepare != nil {
+				tt.prepare(t, sitePath, sitesPath)
+			}
+			_, err := SetNetwork(sitePath, tt.network)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("SetNetwork() error = %v, want it to mention %q", err, tt.want)
+			}
+			if len(s.switches) != 0 {
+				t.Errorf("nothing should change when validation fails, sui switches %v", s.switches)
+			}
+		})
+	}
+}
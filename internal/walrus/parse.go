@@ -1,8 +1,21 @@
 package walrus
 
 import (
+	"errors"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+var (
+	// costLineRegex matches an amount spent, e.g. "Cost (excluding gas): 0.05 WAL"
+	// or "Total gas cost: 1234567 MIST".
+	costLineRegex = regexp.MustCompile(`(?i)\b(?:cost|spent|paid)\b[^:]*:\s*([0-9][0-9,]*(?:\.[0-9]+)?)\s*(WAL|FROST|SUI|MIST)\b`)
+	// storageEpochsRegex matches the storage duration, e.g. "Stored for 5 epochs"
+	// or "Storage epochs: 5".
+	storageEpochsRegex = regexp.MustCompile(`(?i)\bstor(?:ed|ing|age)\b.*?(?:\b([0-9]+)\s+epochs?\b|\bepochs?\s*[:=]\s*([0-9]+))`)
 )
 
 // parseSiteBuilderOutput extracts key information from site-builder command output.
@@ -57,7 +70,8 @@ func parseSiteBuilderOutput(output string) *SiteBuilderOutput {
 // parseSitemapOutput extracts resources from sitemap command output.
 func parseSitemapOutput(output string) *SiteBuilderOutput {
 	result := &SiteBuilderOutput{
-		Resources: make([]Resource, 0),
+		Resources:    make([]Resource, 0),
+		FileToBlobID: make(map[string]string),
 	}
 
 	lines := strings.Split(output, "\n")
@@ -82,9 +96,84 @@ func parseSitemapOutput(output string) *SiteBuilderOutput {
 					Path:   path,
 					BlobID: blobID,
 				})
+				result.FileToBlobID[strings.TrimPrefix(path, "/")] = blobID
 			}
 		}
 	}
 
 	return result
 }
+
+// ErrNoCostLines means site-builder output had no line parseDeployCosts
+// recognizes as an amount spent, usually because its wording changed.
+var ErrNoCostLines = errors.New("no cost lines found in site-builder output")
+
+// parseDeployCosts reads the storage epochs and the WAL and SUI spent from
+// site-builder output into out, leaving fields it already has alone. Amounts
+// on a "total" line win; otherwise every cost line of a unit is summed, since
+// site-builder reports one per batch of blobs. It returns ErrNoCostLines when
+// no line reports an amount.
+func parseDeployCosts(output string, out *SiteBuilderOutput) error {
+	var wal, sui, totalWAL, totalSUI float64
+	epochs := 0
+	costLines := 0
+
+	for _, line := range strings.Split(ui.StripANSI(output), "\n") {
+		if m := storageEpochsRegex.FindStringSubmatch(line); m != nil {
+			if n, err := strconv.Atoi(firstNonEmpty(m[1], m[2])); err == nil {
+				epochs = n
+			}
+		}
+
+		m := costLineRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		costLines++
+		total := strings.Contains(strings.ToLower(line), "total")
+		switch strings.ToUpper(m[2]) {
+		case "FROST":
+			amount /= 1e9
+			fallthrough
+		case "WAL":
+			if total {
+				totalWAL = amount
+			} else {
+				wal += amount
+			}
+		case "MIST":
+			amount /= 1e9
+			fallthrough
+		case "SUI":
+			if total {
+				totalSUI = amount
+			} else {
+				sui += amount
+			}
+		}
+	}
+
+	if totalWAL > 0 {
+		wal = totalWAL
+	}
+	if totalSUI > 0 {
+		sui = totalSUI
+	}
+	if out.StorageEpochs == 0 {
+		out.StorageEpochs = epochs
+	}
+	if out.CostWAL == 0 {
+		out.CostWAL = wal
+	}
+	if out.GasSUI == 0 {
+		out.GasSUI = sui
+	}
+	if costLines == 0 {
+		return ErrNoCostLines
+	}
+	return nil
+}
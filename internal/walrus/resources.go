@@ -0,0 +1,102 @@
+package walrus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrSiteNotFound is returned when no site object exists at an object ID on
+// the active network.
+var ErrSiteNotFound = errors.New("site object not found")
+
+// resourceListTimeout bounds a sitemap run when ctx has no earlier deadline.
+const resourceListTimeout = 2 * time.Minute
+
+// notFoundMarkers are what site-builder and the Sui RPC print for a missing
+// object.
+var notFoundMarkers = []string{
+	"objectnotfound",
+	"object not found",
+	"does not exist",
+	"could not find the referenced object",
+}
+
+// deletedStatusPattern matches the status the Sui RPC reports for a deleted
+// object: its ObjectDeleted or Deleted { .. } variant, or the "deleted" JSON
+// error code. A bare "deleted" may be part of a resource path.
+var deletedStatusPattern = regexp.MustCompile(`\bObjectDeleted\b|\bDeleted \{|"code"\s*:\s*"deleted"`)
+
+// GetResourceList returns the resources of the site at objectID on network
+// ("testnet" or "mainnet"; empty uses the active Sui environment), sorted by
+// path. It reads only the sitemap and prints nothing, so callers that compare
+// paths and blob IDs do not have to re-parse status text. A missing site is
+// reported as ErrSiteNotFound.
+func GetResourceList(ctx context.Context, objectID, network string) ([]Resource, error) {
+	if err := validateObjectID(objectID); err != nil {
+		return nil, fmt.Errorf("invalid object ID: %w", err)
+	}
+
+	builderPath, err := execLookPath(siteBuilderCmd)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' CLI not found. Please install it and ensure it's in your PATH", siteBuilderCmd)
+	}
+	walrusPath, err := execLookPath("walrus")
+	if err != nil {
+		return nil, fmt.Errorf("'walrus' CLI not found in PATH")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, resourceListTimeout)
+	defer cancel()
+
+	if network == "" {
+		network = GetWalrusContext()
+	}
+	args := []string{
+		"--context", network,
+		"--walrus-binary", walrusPath,
+		"sitemap",
+		objectID,
+	}
+	stdout, stderr, jsonMode, err := runSiteBuilder(ctx, builderPath, "sitemap", args, false)
+	if err != nil {
+		if isNotFoundOutput(stderr + "\n" + stdout) {
+			return nil, fmt.Errorf("%w: %s", ErrSiteNotFound, objectID)
+		}
+		if stderr != "" {
+			return nil, fmt.Errorf("failed to execute %s: %w\nstderr:\n%s", siteBuilderCmd, err, stderr)
+		}
+		return nil, fmt.Errorf("failed to execute %s: %w", siteBuilderCmd, err)
+	}
+
+	return parseResourceList(stdout, jsonMode), nil
+}
+
+// parseResourceList extracts the resources from sitemap output, sorted by
+// path.
+func parseResourceList(stdout string, jsonMode bool) []Resource {
+	resources := parseSitemapResult(stdout, jsonMode).Resources
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Path < resources[j].Path
+	})
+	return resources
+}
+
+// isNotFoundOutput reports whether site-builder output says the object does
+// not exist.
+func isNotFoundOutput(output string) bool {
+	lower := strings.ToLower(output)
+	for _, marker := range notFoundMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return deletedStatusPattern.MatchString(output)
+}
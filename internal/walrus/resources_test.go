@@ -0,0 +1,160 @@
+package walrus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+const sampleSitemapText = `Pages in site at object id: 0x1111111111111111111111111111111111111111111111111111111111111111
+  resource /index.html blob ID blobA
+  resource /css/site.css blob ID blobB
+  resource /about/index.html blob ID blobC
+`
+
+func TestParseResourceList(t *testing.T) {
+	want := []Resource{
+		{Path: "/about/index.html", BlobID: "blobC"},
+		{Path: "/css/site.css", BlobID: "blobB"},
+		{Path: "/index.html", BlobID: "blobA"},
+	}
+	if got := parseResourceList(sampleSitemapText, false); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseResourceList(text) = %+v, want %+v", got, want)
+	}
+
+	want = []Resource{
+		{Path: "/css/site.css", BlobID: "blobB"},
+		{Path: "/index.html", BlobID: "blobA"},
+	}
+	if got := parseResourceList(sampleJSONResult, true); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseResourceList(json) = %+v, want %+v", got, want)
+	}
+
+	if got := parseResourceList("Pages in site at object id: 0x1\n", false); len(got) != 0 {
+		t.Errorf("parseResourceList(empty site) = %+v, want none", got)
+	}
+}
+
+// TestFakeSitemapSiteBuilder is not a real test: GetResourceList tests run
+// the test binary with TEST_FAKE_SITEMAP set to act as site-builder. "list"
+// prints a sitemap; "missing" fails like a sitemap of a nonexistent object.
+func TestFakeSitemapSiteBuilder(t *testing.T) {
+	switch os.Getenv("TEST_FAKE_SITEMAP") {
+	case "list":
+		fmt.Fprint(os.Stdout, sampleSitemapText)
+		os.Exit(0)
+	case "missing":
+		fmt.Fprint(os.Stderr, "Error: could not retrieve the site object: ObjectNotFound { object_id: 0x2222 }\n")
+		os.Exit(1)
+	}
+}
+
+// useFakeSitemap makes GetResourceList run the fake site-builder in mode.
+func useFakeSitemap(t *testing.T, mode string) {
+	t.Helper()
+	origExec, origVersion, origLookPath := execCommandContext, siteBuilderVersion, execLookPath
+	execCommandContext = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, os.Args[0], append([]string{"-test.run=^TestFakeSitemapSiteBuilder$", "--"}, args...)...)
+		cmd.Env = append(os.Environ(), "TEST_FAKE_SITEMAP="+mode)
+		return cmd
+	}
+	siteBuilderVersion = func() (string, error) { return "1.0.0", nil }
+	execLookPath = func(name string) (string, error) { return "/opt/bin/" + name, nil }
+	t.Cleanup(func() { execCommandContext, siteBuilderVersion, execLookPath = origExec, origVersion, origLookPath })
+}
+
+func TestGetResourceList(t *testing.T) {
+	useFakeSitemap(t, "list")
+
+	resources, err := GetResourceList(context.Background(), fakeSiteObjectID, "")
+	if err != nil {
+		t.Fatalf("GetResourceList() error = %v", err)
+	}
+	if len(resources) != 3 || resources[0].Path != "/about/index.html" || resources[2].BlobID != "blobA" {
+		t.Errorf("GetResourceList() = %+v", resources)
+	}
+}
+
+func TestGetResourceList_NotFound(t *testing.T) {
+	useFakeSitemap(t, "missing")
+
+	const missing = "0x2222222222222222222222222222222222222222222222222222222222222222"
+	_, err := GetResourceList(context.Background(), missing, "")
+	if !errors.Is(err, ErrSiteNotFound) {
+		t.Fatalf("GetResourceList() error = %v, want ErrSiteNotFound", err)
+	}
+}
+
+func TestGetResourceList_InvalidObjectID(t *testing.T) {
+	if _, err := GetResourceList(context.Background(), "not-an-object", ""); err == nil {
+		t.Error("GetResourceList() should reject an invalid object ID")
+	}
+}
+
+func TestQuerySiteStatusUsesResourceList(t *testing.T) {
+	useFakeSitemap(t, "list")
+	var gotArgs []string
+	fake := execCommandContext
+	execCommandContext = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		gotArgs = args
+		return fake(ctx, name, args...)
+	}
+
+	out, err := QuerySiteStatus(context.Background(), fakeSiteObjectID, "mainnet")
+	if err != nil {
+		t.Fatalf("QuerySiteStatus() error = %v", err)
+	}
+	if !out.Success || out.ObjectID != fakeSiteObjectID || len(out.Resources) != 3 || out.Resources[0].Path != "/about/index.html" {
+		t.Errorf("QuerySiteStatus() = %+v", out)
+	}
+	if len(gotArgs) < 2 || gotArgs[0] != "--context" || gotArgs[1] != "mainnet" {
+		t.Errorf("site-builder args = %v, want --context mainnet", gotArgs)
+	}
+
+	useFakeSitemap(t, "missing")
+	const missing = "0x2222222222222222222222222222222222222222222222222222222222222222"
+	if _, err := QuerySiteStatus(context.Background(), missing, "mainnet"); !errors.Is(err, ErrSiteNotFound) {
+		t.Errorf("QuerySiteStatus() error = %v, want ErrSiteNotFound", err)
+	}
+}
+
+func TestIsNotFoundOutput(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"Error: could not retrieve the site object: ObjectNotFound { object_id: 0x2222 }", true},
+		{"Error: ObjectDeleted { object_ref: (0x2222, SequenceNumber(9), o#abc) }", true},
+		{"object response error: Deleted { object_id: 0x2222, version: 9 }", true},
+		{`{"error":{"code":"deleted","object_id":"0x2222"}}`, true},
+		{"Error: failed to fetch blob for resource /posts/deleted-scenes/index.html", false},
+		{"Error: quilt patch for /deleted.html is missing", false},
+	}
+	for _, tt := range tests {
+		if got := isNotFoundOutput(tt.output); got != tt.want {
+			t.Errorf("isNotFoundOutput(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestGetSiteStatusUsesResourceList(t *testing.T) {
+	useFakeSitemap(t, "list")
+	origStat := osStat
+	osStat = func(string) (os.FileInfo, error) { return nil, nil }
+	t.Cleanup(func() { osStat = origStat })
+
+	out, err := GetSiteStatus(fakeSiteObjectID)
+	if err != nil {
+		t.Fatalf("GetSiteStatus() error = %v", err)
+	}
+	if !out.Success || len(out.Resources) != 3 || out.Resources[0].Path != "/about/index.html" {
+		t.Errorf("GetSiteStatus() resources = %+v", out.Resources)
+	}
+	if out.FileToBlobID["css/site.css"] != "blobB" {
+		t.Errorf("GetSiteStatus() FileToBlobID = %v", out.FileToBlobID)
+	}
+}
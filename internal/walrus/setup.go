@@ -9,6 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"gopkg.in/yaml.v3"
+
+	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/ui"
 )
 
@@ -184,50 +187,21 @@ func downloadConfig(url, destPath string) error {
 	return nil
 }
 
-// createSiteBuilderConfigFromTemplate creates a config file from hardcoded template.
+// createSiteBuilderConfigFromTemplate creates a config file from walgo's known defaults.
 // This is a fallback when downloading the official config fails.
 func createSiteBuilderConfigFromTemplate(configPath, network string) error {
-	var packageID string
-	var rpcURL string
-
-	switch network {
-	case "mainnet":
-		packageID = "0x26eb7ee8688da02c5f671679524e379f0b837a12f1d1d799f255b7eea260ad27"
-		rpcURL = "https://fullnode.mainnet.sui.io:443"
-	case "testnet":
-		packageID = "0xf99aee9f21493e1590e7e5a9aea6f343a1f381031a04a732724871fc294be799"
-		rpcURL = "https://fullnode.testnet.sui.io:443"
-	default:
-		return fmt.Errorf("unsupported network: %s", network)
-	}
-
-	homeDir, err := os.UserHomeDir()
+	sc, err := NewSitesConfig(network, config.GatewayConfig{})
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
 
-	walletPath := filepath.Join(homeDir, ".sui", "sui_config", "client.yaml")
-	walrusConfig := filepath.Join(homeDir, ".config", "walrus", "client_config.yaml")
-	walrusBinary := "walrus"
-	if path, err := execLookPath("walrus"); err == nil {
-		walrusBinary = path
+	configContent, err := yaml.Marshal(sc)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
 	}
 
-	configContent := fmt.Sprintf(`contexts:
-  %s:
-    package: %s
-    general:
-      rpc_url: %s
-      wallet: %s
-      walrus_binary: %s
-      walrus_config: %s
-      gas_budget: 500000000
-
-default_context: %s
-`, network, packageID, rpcURL, walletPath, walrusBinary, walrusConfig, network)
-
 	// #nosec G306 - config file needs to be readable for site-builder
-	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+	if err := os.WriteFile(configPath, configContent, 0644); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
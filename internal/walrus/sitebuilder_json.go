@@ -0,0 +1,172 @@
+package walrus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/ui"
+	"github.com/selimozten/walgo/internal/version"
+)
+
+// SiteBuilderJSONMinVersion is the first site-builder release that prints its
+// results as JSON when given --json. Older releases only print text, which is
+// parsed with parseSiteBuilderOutput and parseSitemapOutput instead.
+const SiteBuilderJSONMinVersion = "2.0.0"
+
+// siteBuilderVersion is a test hook returning the installed site-builder version.
+var siteBuilderVersion = func() (string, error) {
+	return version.GetCurrentVersion("site-builder")
+}
+
+// SupportsJSONOutput reports whether the given site-builder version accepts --json.
+func SupportsJSONOutput(siteBuilderVersion string) bool {
+	if siteBuilderVersion == "" {
+		return false
+	}
+	return version.CompareVersions(siteBuilderVersion, SiteBuilderJSONMinVersion) >= 0
+}
+
+// siteBuilderHelp is a test hook returning the --help text of a site-builder
+// subcommand.
+var siteBuilderHelp = func(ctx context.Context, builderPath, subcommand string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	// #nosec G204 - builderPath is the resolved site-builder binary
+	out, err := execCommandContext(ctx, builderPath, subcommand, "--help").CombinedOutput()
+	return string(out), err
+}
+
+// siteBuilderJSONEnabled probes the installed site-builder before anything is
+// run: the version must be new enough and the subcommand's help must list
+// --json. A failed probe means text output.
+func siteBuilderJSONEnabled(ctx context.Context, builderPath, subcommand string) bool {
+	v, err := siteBuilderVersion()
+	if err != nil || !SupportsJSONOutput(v) {
+		return false
+	}
+	help, err := siteBuilderHelp(ctx, builderPath, subcommand)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(help, "--json")
+}
+
+// runSiteBuilder runs site-builder with args once, adding --json when the
+// probe shows subcommand supports it. jsonMode reports whether the returned
+// output was requested as JSON.
+func runSiteBuilder(ctx context.Context, builderPath, subcommand string, args []string, streamOutput bool) (stdout, stderr string, jsonMode bool, err error) {
+	if siteBuilderJSONEnabled(ctx, builderPath, subcommand) {
+		args = append(append([]string{}, args...), "--json")
+		jsonMode = true
+	}
+	stdout, stderr, err = runCommandWithTimeout(ctx, builderPath, args, streamOutput)
+	return stdout, stderr, jsonMode, err
+}
+
+// siteBuilderJSONResult is the structured result site-builder prints with
+// --json. Only the site object, status and resource blob IDs are read; costs
+// still come from the progress output.
+type siteBuilderJSONResult struct {
+	Status    string                    `json:"status"`
+	ObjectID  string                    `json:"object_id"`
+	Resources []siteBuilderJSONResource `json:"resources"`
+}
+
+// siteBuilderJSONResource is one site resource in a JSON result.
+type siteBuilderJSONResource struct {
+	Path   string `json:"path"`
+	BlobID string `json:"blob_id"`
+}
+
+// parseSiteBuilderJSON reads a JSON result from site-builder output. The
+// result is the whole output or, when progress lines come first, its last
+// line that holds a JSON object. Returns false when no result is found.
+func parseSiteBuilderJSON(output string) (*SiteBuilderOutput, bool) {
+	raw, ok := findJSONResult(output)
+	if !ok {
+		return nil, false
+	}
+	var res siteBuilderJSONResult
+	if err := json.Unmarshal([]byte(raw), &res); err != nil {
+		return nil, false
+	}
+
+	result := &SiteBuilderOutput{
+		ObjectID:     res.ObjectID,
+		BrowseURLs:   make([]string, 0),
+		Resources:    make([]Resource, 0, len(res.Resources)),
+		FileToBlobID: make(map[string]string, len(res.Resources)),
+		Success:      res.Status == "" || strings.EqualFold(res.Status, "success") || strings.EqualFold(res.Status, "ok"),
+	}
+
+	for _, r := range res.Resources {
+		if r.Path == "" || r.BlobID == "" {
+			continue
+		}
+		result.Resources = append(result.Resources, Resource{Path: r.Path, BlobID: r.BlobID})
+		result.FileToBlobID[strings.TrimPrefix(r.Path, "/")] = r.BlobID
+	}
+	return result, true
+}
+
+// findJSONResult returns the JSON object in output.
+func findJSONResult(output string) (string, bool) {
+	trimmed := strings.TrimSpace(output)
+	if strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+		return trimmed, true
+	}
+	lines := strings.Split(trimmed, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "{") && json.Valid([]byte(line)) {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// parseDeployResult parses the output of a publish or update run, preferring
+// the JSON result and falling back to the text output. Costs and storage
+// epochs are always read from the progress output; a warning is printed when
+// it reports none.
+func parseDeployResult(stdout, stderr string, jsonMode bool) *SiteBuilderOutput {
+	var out *SiteBuilderOutput
+	if jsonMode {
+		out, _ = parseSiteBuilderJSON(stdout)
+	}
+	if out == nil {
+		out = parseSiteBuilderOutput(stdout + "\n" + stderr)
+		out.Success = true
+	}
+	if err := parseDeployCosts(stdout+"\n"+stderr, out); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Warning: %v; the deploy cost is read from the chain or estimated instead\n", ui.GetIcons().Warning, err)
+	}
+	return out
+}
+
+// parseSitemapResult parses the output of a sitemap run, preferring the JSON
+// result and falling back to the text output.
+func parseSitemapResult(stdout string, jsonMode bool) *SiteBuilderOutput {
+	if jsonMode {
+		if out, ok := parseSiteBuilderJSON(stdout); ok {
+			return out
+		}
+	}
+	out := parseSitemapOutput(stdout)
+	out.Success = true
+	return out
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
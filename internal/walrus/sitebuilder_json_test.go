@@ -0,0 +1,292 @@
+package walrus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleJSONResult = `{"status":"success","object_id":"` + fakeSiteObjectID + `","resources":[{"path":"/index.html","blob_id":"blobA"},{"path":"/css/site.css","blob_id":"blobB"}]}`
+
+func TestSupportsJSONOutput(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"", false},
+		{"1.0.5", false},
+		{SiteBuilderJSONMinVersion, true},
+		{"2.3.1", true},
+	}
+	for _, tt := range tests {
+		if got := SupportsJSONOutput(tt.version); got != tt.want {
+			t.Errorf("SupportsJSONOutput(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseSiteBuilderJSON(t *testing.T) {
+	wantBlobs := map[string]string{"index.html": "blobA", "css/site.css": "blobB"}
+
+	tests := []struct {
+		name   string
+		output string
+	}{
+		{"whole output", sampleJSONResult},
+		{"after progress lines", "Uploading 2 files\nprogress 100%\n" + sampleJSONResult + "\n"},
+		{"pretty printed", "{\n  \"status\": \"success\",\n  \"object_id\": \"" + fakeSiteObjectID + "\",\n  \"resources\": [{\"path\": \"/index.html\", \"blob_id\": \"blobA\"}, {\"path\": \"/css/site.css\", \"blob_id\": \"blobB\"}]\n}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, ok := parseSiteBuilderJSON(tt.output)
+			if !ok {
+				t.Fatal("parseSiteBuilderJSON() found no result")
+			}
+			if !out.Success {
+				t.Error("Success = false, want true")
+			}
+			if out.ObjectID != fakeSiteObjectID {
+				t.Errorf("ObjectID = %q, want %q", out.ObjectID, fakeSiteObjectID)
+			}
+			if len(out.Resources) != 2 || out.Resources[0].Path != "/index.html" {
+				t.Errorf("Resources = %+v", out.Resources)
+			}
+			if !reflect.DeepEqual(out.FileToBlobID, wantBlobs) {
+				t.Errorf("FileToBlobID = %v, want %v", out.FileToBlobID, wantBlobs)
+			}
+		})
+	}
+}
+
+func TestParseSiteBuilderJSONStatus(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{`{"status":"failed","object_id":"0x1"}`, false},
+		{`{"status":"OK"}`, true},
+		{`{"object_id":"0x1"}`, true},
+	}
+	for _, tt := range tests {
+		out, ok := parseSiteBuilderJSON(tt.output)
+		if !ok {
+			t.Fatalf("parseSiteBuilderJSON(%s) found no result", tt.output)
+		}
+		if out.Success != tt.want {
+			t.Errorf("parseSiteBuilderJSON(%s).Success = %v, want %v", tt.output, out.Success, tt.want)
+		}
+	}
+}
+
+func TestParseSiteBuilderJSONNoResult(t *testing.T) {
+	for _, output := range []string{"", "New site object ID: " + fakeSiteObjectID, "{not json", "[1,2,3]"} {
+		if _, ok := parseSiteBuilderJSON(output); ok {
+			t.Errorf("parseSiteBuilderJSON(%q) reported a result", output)
+		}
+	}
+}
+
+func TestParseDeployResultFallsBackToText(t *testing.T) {
+	text := "Uploading files\nNew site object ID: " + fakeSiteObjectID + "\nBrowse at http://2ql9wtro.localhost:3000\n"
+
+	for _, jsonMode := range []bool{true, false} {
+		out := parseDeployResult(text, "", jsonMode)
+		if !out.Success || out.ObjectID != fakeSiteObjectID {
+			t.Errorf("jsonMode=%v: result = %+v, want the text result", jsonMode, out)
+		}
+	}
+
+	// JSON output is only trusted when it was requested.
+	if out := parseDeployResult(sampleJSONResult, "", false); out.ObjectID != "" || len(out.FileToBlobID) != 0 {
+		t.Errorf("text mode parsed JSON: %+v", out)
+	}
+	if out := parseDeployResult(sampleJSONResult, "", true); len(out.FileToBlobID) != 2 {
+		t.Errorf("json mode FileToBlobID = %v", out.FileToBlobID)
+	}
+}
+
+func TestParseSitemapResultFallsBackToText(t *testing.T) {
+	text := "resource /index.html blob ID blobA\nresource /about/index.html blob ID blobC\n"
+	out := parseSitemapResult(text, true)
+	want := map[string]string{"index.html": "blobA", "about/index.html": "blobC"}
+	if !out.Success || !reflect.DeepEqual(out.FileToBlobID, want) {
+		t.Errorf("parseSitemapResult() = %+v, want FileToBlobID %v", out, want)
+	}
+
+	out = parseSitemapResult(sampleJSONResult, true)
+	if len(out.Resources) != 2 || out.FileToBlobID["css/site.css"] != "blobB" {
+		t.Errorf("parseSitemapResult(json) = %+v", out)
+	}
+}
+
+// TestFakeJSONSiteBuilder is not a real test: runSiteBuilder tests run the
+// test binary with TEST_FAKE_JSON_SITE_BUILDER set to act as site-builder.
+// "json" lists --json in its help and prints a JSON result for it; "old"
+// leaves --json out of its help and rejects it like a release without JSON
+// output.
+func TestFakeJSONSiteBuilder(t *testing.T) {
+	mode := os.Getenv("TEST_FAKE_JSON_SITE_BUILDER")
+	if mode == "" {
+		return
+	}
+	hasJSON, hasHelp := false, false
+	for _, arg := range os.Args {
+		switch arg {
+		case "--json":
+			hasJSON = true
+		case "--help":
+			hasHelp = true
+		}
+	}
+	switch {
+	case hasHelp && mode == "old":
+		fmt.Fprint(os.Stdout, "Options:\n      --context <CONTEXT>\n  -h, --help\n")
+	case hasHelp:
+		fmt.Fprint(os.Stdout, "Options:\n      --context <CONTEXT>\n      --json  Print the result as JSON\n  -h, --help\n")
+	case hasJSON && mode == "old":
+		fmt.Fprint(os.Stderr, "error: unexpected argument '--json' found\n")
+		os.Exit(2)
+	case hasJSON:
+		fmt.Fprint(os.Stdout, sampleJSONResult+"\n")
+	default:
+		fmt.Fprint(os.Stdout, "New site object ID: "+fakeSiteObjectID+"\n")
+	}
+	os.Exit(0)
+}
+
+// useFakeJSONSiteBuilder runs the fake site-builder in mode, reporting
+// version, and records the arguments of each run.
+func useFakeJSONSiteBuilder(t *testing.T, mode, version string) *[][]string {
+	t.Helper()
+	var calls [][]string
+	origExec, origVersion := execCommandContext, siteBuilderVersion
+	execCommandContext = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls = append(calls, args)
+		cmd := exec.CommandContext(ctx, os.Args[0], append([]string{"-test.run=^TestFakeJSONSiteBuilder$", "--"}, args...)...)
+		cmd.Env = append(os.Environ(), "TEST_FAKE_JSON_SITE_BUILDER="+mode)
+		return cmd
+	}
+	siteBuilderVersion = func() (string, error) { return version, nil }
+	t.Cleanup(func() { execCommandContext, siteBuilderVersion = origExec, origVersion })
+	return &calls
+}
+
+func TestRunSiteBuilder(t *testing.T) {
+	args := []string{"sitemap", fakeSiteObjectID}
+
+	// runs returns the calls that ran the command, leaving out help probes.
+	runs := func(calls [][]string) [][]string {
+		var out [][]string
+		for _, c := range calls {
+			if c[len(c)-1] != "--help" {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+
+	t.Run("json supported", func(t *testing.T) {
+		calls := useFakeJSONSiteBuilder(t, "json", SiteBuilderJSONMinVersion)
+		stdout, stderr, jsonMode, err := runSiteBuilder(context.Background(), "site-builder", "sitemap", args, false)
+		if err != nil {
+			t.Fatalf("runSiteBuilder() error = %v", err)
+		}
+		ran := runs(*calls)
+		if !jsonMode || len(ran) != 1 || ran[0][len(ran[0])-1] != "--json" {
+			t.Fatalf("jsonMode = %v, calls = %v", jsonMode, *calls)
+		}
+		if out := parseSitemapResult(stdout, jsonMode); out.FileToBlobID["index.html"] != "blobA" {
+			t.Errorf("parsed %+v from stdout %q stderr %q", out, stdout, stderr)
+		}
+	})
+
+	t.Run("old version uses text", func(t *testing.T) {
+		calls := useFakeJSONSiteBuilder(t, "json", "1.0.5")
+		stdout, stderr, jsonMode, err := runSiteBuilder(context.Background(), "site-builder", "sitemap", args, false)
+		if err != nil || jsonMode || len(*calls) != 1 {
+			t.Fatalf("err = %v, jsonMode = %v, calls = %v", err, jsonMode, *calls)
+		}
+		if out := parseDeployResult(stdout, stderr, jsonMode); out.ObjectID != fakeSiteObjectID {
+			t.Errorf("ObjectID = %q", out.ObjectID)
+		}
+	})
+
+	t.Run("help without json runs once as text", func(t *testing.T) {
+		calls := useFakeJSONSiteBuilder(t, "old", SiteBuilderJSONMinVersion)
+		stdout, stderr, jsonMode, err := runSiteBuilder(context.Background(), "site-builder", "sitemap", args, false)
+		if err != nil {
+			t.Fatalf("runSiteBuilder() error = %v", err)
+		}
+		ran := runs(*calls)
+		if jsonMode || len(ran) != 1 || strings.Contains(strings.Join(ran[0], " "), "--json") {
+			t.Fatalf("jsonMode = %v, calls = %v", jsonMode, *calls)
+		}
+		if out := parseDeployResult(stdout, stderr, jsonMode); out.ObjectID != fakeSiteObjectID {
+			t.Errorf("ObjectID = %q", out.ObjectID)
+		}
+	})
+}
+
+func TestParseDeployCosts(t *testing.T) {
+	sample := "Parsing the directory ./public and locally computing blob IDs.\n" +
+		"Storing resources on Walrus: batch 1 of 2\n" +
+		"\x1b[32mCost (excluding gas): 0.0400 WAL\x1b[0m (storage was purchased, and a new blob object was registered)\n" +
+		"Stored for 5 epochs (expiry epoch: 120)\n" +
+		"Storing resources on Walrus: batch 2 of 2\n" +
+		"Cost (excluding gas): 12,000,000 FROST (storage was purchased)\n" +
+		"Gas cost: 0.0031 SUI\n" +
+		"Gas cost: 2000000 MIST\n" +
+		"Created new site: blog\n" +
+		"New site object ID: " + fakeSiteObjectID + "\n"
+
+	tests := []struct {
+		name       string
+		output     string
+		wantEpochs int
+		wantWAL    float64
+		wantSUI    float64
+	}{
+		{"per batch lines are summed", sample, 5, 0.052, 0.0051},
+		{"total line wins", sample + "Total cost: 0.06 WAL\nTotal gas spent: 0.007 SUI\n", 5, 0.06, 0.007},
+		{"storage epochs field", "Storage epochs: 12\n", 12, 0, 0},
+		{"no cost lines", "New site object ID: " + fakeSiteObjectID + "\n", 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := parseDeployResult(tt.output, "", false)
+			if out.StorageEpochs != tt.wantEpochs {
+				t.Errorf("StorageEpochs = %d, want %d", out.StorageEpochs, tt.wantEpochs)
+			}
+			if math.Abs(out.CostWAL-tt.wantWAL) > 1e-9 {
+				t.Errorf("CostWAL = %v, want %v", out.CostWAL, tt.wantWAL)
+			}
+			if math.Abs(out.GasSUI-tt.wantSUI) > 1e-9 {
+				t.Errorf("GasSUI = %v, want %v", out.GasSUI, tt.wantSUI)
+			}
+		})
+	}
+}
+
+func TestParseDeployCostsNoCostLines(t *testing.T) {
+	var out SiteBuilderOutput
+	if err := parseDeployCosts("Stored for 5 epochs\nNew site object ID: "+fakeSiteObjectID+"\n", &out); !errors.Is(err, ErrNoCostLines) {
+		t.Errorf("parseDeployCosts() error = %v, want ErrNoCostLines", err)
+	}
+	if err := parseDeployCosts("Gas cost: 0.0031 SUI\n", &out); err != nil {
+		t.Errorf("parseDeployCosts() error = %v, want nil", err)
+	}
+}
+
+func TestParseDeployCostsJSON(t *testing.T) {
+	// Costs are read from the progress output next to the JSON result
+	out := parseDeployResult(sampleJSONResult, "Stored for 2 epochs\nCost (excluding gas): 0.01 WAL\nGas cost: 0.002 SUI\n", true)
+	if out.ObjectID != fakeSiteObjectID || out.StorageEpochs != 2 || out.CostWAL != 0.01 || out.GasSUI != 0.002 {
+		t.Errorf("result = %s/%d/%v/%v, want %s/2/0.01/0.002", out.ObjectID, out.StorageEpochs, out.CostWAL, out.GasSUI, fakeSiteObjectID)
+	}
+}
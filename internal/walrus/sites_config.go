@@ -0,0 +1,153 @@
+package walrus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/selimozten/walgo/internal/config"
+)
+
+// DefaultGasBudget is the gas budget written to generated site-builder configs.
+const DefaultGasBudget = 500000000
+
+// siteNetworkDefaults are the published Walrus Sites settings for a network.
+type siteNetworkDefaults struct {
+	Package string
+	RPCURL  string
+	// Portal is the host site-builder prints browse URLs for. Testnet has no
+	// public portal, so testnet sites are browsed through a local one.
+	Portal string
+}
+
+var siteNetworks = map[string]siteNetworkDefaults{
+	"mainnet": {
+		Package: "0x26eb7ee8688da02c5f671679524e379f0b837a12f1d1d799f255b7eea260ad27",
+		RPCURL:  SuiMainnetRPC,
+		Portal:  "wal.app",
+	},
+	"testnet": {
+		Package: "0xf99aee9f21493e1590e7e5a9aea6f343a1f381031a04a732724871fc294be799",
+		RPCURL:  SuiTestnetRPC,
+		Portal:  "localhost:3000",
+	},
+}
+
+// SitesPackageID returns the Walrus Sites package ID walgo knows for network.
+func SitesPackageID(network string) (string, error) {
+	defaults, ok := siteNetworks[network]
+	if !ok {
+		return "", fmt.Errorf("unsupported network: %s. Use 'mainnet', 'testnet'", network)
+	}
+	return defaults.Package, nil
+}
+
+// SitesConfig mirrors site-builder's sites-config.yaml.
+type SitesConfig struct {
+	Contexts       map[string]SitesContext `yaml:"contexts"`
+	DefaultContext string                  `yaml:"default_context"`
+}
+
+// SitesContext is one network entry in sites-config.yaml.
+type SitesContext struct {
+	Portal  string       `yaml:"portal,omitempty"`
+	Package string       `yaml:"package"`
+	General SitesGeneral `yaml:"general"`
+}
+
+// SitesGeneral holds the per-context tool and wallet settings.
+type SitesGeneral struct {
+	RPCURL        string `yaml:"rpc_url"`
+	Wallet        string `yaml:"wallet"`
+	WalrusBinary  string `yaml:"walrus_binary"`
+	WalrusConfig  string `yaml:"walrus_config"`
+	WalrusContext string `yaml:"walrus_context,omitempty"`
+	GasBudget     int    `yaml:"gas_budget"`
+}
+
+// SitesConfigPath returns the standard sites-config.yaml location,
+// ~/.config/walrus/sites-config.yaml.
+func SitesConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "walrus", "sites-config.yaml"), nil
+}
+
+// NewSitesConfig builds a sites-config for network from walgo's known
+// defaults. A gateway Sui RPC override replaces the default rpc_url.
+func NewSitesConfig(network string, gw config.GatewayConfig) (*SitesConfig, error) {
+	defaults, ok := siteNetworks[network]
+	if !ok {
+		return nil, fmt.Errorf("unsupported network: %s. Use 'mainnet', 'testnet'", network)
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	walrusBinary := "walrus"
+	if path, err := execLookPath("walrus"); err == nil {
+		walrusBinary = path
+	}
+
+	rpcURL := defaults.RPCURL
+	if gw.SuiRPCURL != "" {
+		rpcURL = gw.SuiRPCURL
+	}
+
+	return &SitesConfig{
+		Contexts: map[string]SitesContext{
+			network: {
+				Portal:  defaults.Portal,
+				Package: defaults.Package,
+				General: SitesGeneral{
+					RPCURL:        rpcURL,
+					Wallet:        filepath.Join(homeDir, ".sui", "sui_config", "client.yaml"),
+					WalrusBinary:  walrusBinary,
+					WalrusConfig:  filepath.Join(homeDir, ".config", "walrus", "client_config.yaml"),
+					WalrusContext: network,
+					GasBudget:     DefaultGasBudget,
+				},
+			},
+		},
+		DefaultContext: network,
+	}, nil
+}
+
+// WriteSitesConfig writes sc to path. An existing file is first copied to a
+// timestamped backup next to it, whose path is returned ("" when there was
+// nothing to back up).
+func WriteSitesConfig(path string, sc *SitesConfig) (string, error) {
+	data, err := yaml.Marshal(sc)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode sites-config: %w", err)
+	}
+
+	// #nosec G301 - config directory needs standard permissions
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	var backupPath string
+	if existing, err := os.ReadFile(path); err == nil { // #nosec G304 - path is the chosen config location
+		backupPath = path + ".bak-" + time.Now().Format("20060102-150405")
+		// #nosec G306 - backup keeps the config's readable permissions
+		if err := os.WriteFile(backupPath, existing, 0644); err != nil {
+			return "", fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	// #nosec G306 - config file needs to be readable for site-builder
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return backupPath, fmt.Errorf("failed to write config: %w", err)
+	}
+	return backupPath, nil
+}
@@ -0,0 +1,159 @@
+package walrus
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/selimozten/walgo/internal/config"
+)
+
+func TestNewSitesConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	origLookPath := execLookPath
+	defer func() { execLookPath = origLookPath }()
+	execLookPath = func(string) (string, error) { return "/opt/bin/walrus", nil }
+
+	tests := []struct {
+		name        string
+		network     string
+		gw          config.GatewayConfig
+		wantPackage string
+		wantRPC     string
+		wantPortal  string
+		wantErr     bool
+	}{
+		{
+			name:        "testnet defaults",
+			network:     "testnet",
+			wantPackage: siteNetworks["testnet"].Package,
+			wantRPC:     SuiTestnetRPC,
+			wantPortal:  "localhost:3000",
+		},
+		{
+			name:        "mainnet defaults",
+			network:     "mainnet",
+			wantPackage: siteNetworks["mainnet"].Package,
+			wantRPC:     SuiMainnetRPC,
+			wantPortal:  "wal.app",
+		},
+		{
+			name:        "gateway RPC override",
+			network:     "mainnet",
+			gw:          config.GatewayConfig{SuiRPCURL: "https://rpc.corp.example"},
+			wantPackage: siteNetworks["mainnet"].Package,
+			wantRPC:     "https://rpc.corp.example",
+			wantPortal:  "wal.app",
+		},
+		{name: "unknown network", network: "devnet", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := NewSitesConfig(tt.network, tt.gw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSitesConfig() error = %v", err)
+			}
+
+			// Round-trip through YAML to check what site-builder will read.
+			data, err := yaml.Marshal(sc)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var parsed SitesConfig
+			if err := yaml.Unmarshal(data, &parsed); err != nil {
+				t.Fatalf("generated YAML does not parse: %v\n%s", err, data)
+			}
+
+			if parsed.DefaultContext != tt.network {
+				t.Errorf("default_context = %q, want %q", parsed.DefaultContext, tt.network)
+			}
+			ctx, ok := parsed.Contexts[tt.network]
+			if !ok {
+				t.Fatalf("missing %s context:\n%s", tt.network, data)
+			}
+			if ctx.Package != tt.wantPackage {
+				t.Errorf("package = %q, want %q", ctx.Package, tt.wantPackage)
+			}
+			if ctx.Portal != tt.wantPortal {
+				t.Errorf("portal = %q, want %q", ctx.Portal, tt.wantPortal)
+			}
+			if ctx.General.RPCURL != tt.wantRPC {
+				t.Errorf("rpc_url = %q, want %q", ctx.General.RPCURL, tt.wantRPC)
+			}
+			if ctx.General.WalrusBinary != "/opt/bin/walrus" {
+				t.Errorf("walrus_binary = %q", ctx.General.WalrusBinary)
+			}
+			if ctx.General.WalrusContext != tt.network {
+				t.Errorf("walrus_context = %q, want %q", ctx.General.WalrusContext, tt.network)
+			}
+			if ctx.General.GasBudget != DefaultGasBudget {
+				t.Errorf("gas_budget = %d, want %d", ctx.General.GasBudget, DefaultGasBudget)
+			}
+			if strings.Contains(ctx.General.Wallet, "~") {
+				t.Errorf("wallet path should be absolute, got %q", ctx.General.Wallet)
+			}
+		})
+	}
+}
+
+func TestWriteSitesConfigBacksUpExisting(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	origLookPath := execLookPath
+	defer func() { execLookPath = origLookPath }()
+	execLookPath = func(string) (string, error) { return "", errors.New("not found") }
+
+	path := filepath.Join(t.TempDir(), "walrus", "sites-config.yaml")
+	sc, err := NewSitesConfig("testnet", config.GatewayConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := WriteSitesConfig(path, sc)
+	if err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+	if backup != "" {
+		t.Errorf("expected no backup for a new file, got %s", backup)
+	}
+
+	if err := os.WriteFile(path, []byte("custom: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	backup, err = WriteSitesConfig(path, sc)
+	if err != nil {
+		t.Fatalf("second write: %v", err)
+	}
+	if backup == "" {
+		t.Fatal("expected the existing file to be backed up")
+	}
+	saved, err := os.ReadFile(backup)
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+	if string(saved) != "custom: true\n" {
+		t.Errorf("backup content = %q", saved)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed SitesConfig
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("written config does not parse: %v", err)
+	}
+	if parsed.Contexts["testnet"].General.WalrusBinary != "walrus" {
+		t.Errorf("walrus_binary = %q, want fallback \"walrus\"", parsed.Contexts["testnet"].General.WalrusBinary)
+	}
+}
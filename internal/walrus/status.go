@@ -3,13 +3,14 @@ package walrus
 import (
 	"context"
 	"fmt"
-	"time"
+	"strings"
 
 	"github.com/selimozten/walgo/internal/ui"
 )
 
-// GetSiteStatus checks the status of a Walrus site.
-// Note: The site-builder doesn't have a direct "status" command, but we can use sitemap.
+// GetSiteStatus checks the status of a Walrus site and prints its resources.
+// Note: The site-builder doesn't have a direct "status" command, so this reads
+// the sitemap through GetResourceList.
 func GetSiteStatus(objectID string) (*SiteBuilderOutput, error) {
 	if err := validateObjectID(objectID); err != nil {
 		return nil, fmt.Errorf("invalid object ID: %w", err)
@@ -19,57 +20,42 @@ func GetSiteStatus(objectID string) (*SiteBuilderOutput, error) {
 		return nil, fmt.Errorf("site-builder setup issue: %w\n\nRun 'walgo setup' to configure site-builder", err)
 	}
 
-	builderPath, err := execLookPath(siteBuilderCmd)
-	if err != nil {
-		return nil, fmt.Errorf("'%s' CLI not found. Please install it and ensure it's in your PATH", siteBuilderCmd)
-	}
-
-	// Find walrus binary path to pass to site-builder
-	walrusPath, err := execLookPath("walrus")
-	if err != nil {
-		return nil, fmt.Errorf("'walrus' CLI not found in PATH. Please install it using:\n  suiup install walrus@mainnet\n  Or run: walgo setup-deps")
-	}
-
-	siteBuilderContext := GetWalrusContext()
-	args := []string{
-		"--context", siteBuilderContext,
-		"--walrus-binary", walrusPath,
-		"sitemap",
-		objectID,
-	}
-
 	icons := ui.GetIcons()
-	fmt.Printf("%s Executing: %s %s\n", icons.Info, builderPath, args)
+	fmt.Printf("%s Reading sitemap of %s...\n", icons.Info, objectID)
 
-	statusTimeout := 2 * time.Minute
-	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
-	defer cancel()
-
-	stdoutStr, stderrStr, err := runCommandWithTimeout(ctx, builderPath, args, false)
+	resources, err := GetResourceList(context.Background(), objectID, "")
 	if err != nil {
-		errorMsg := fmt.Sprintf("failed to execute %s: %v", siteBuilderCmd, err)
-		if stderrStr != "" {
-			errorMsg += fmt.Sprintf("\nstderr:\n%s", stderrStr)
-		}
-		if stdoutStr != "" {
-			errorMsg += fmt.Sprintf("\nstdout:\n%s", stdoutStr)
-		}
-		return nil, fmt.Errorf("%s", errorMsg)
+		return nil, err
 	}
 
 	fmt.Println("Site status retrieved successfully.")
 
-	output := parseSitemapOutput(stdoutStr)
-	output.Success = true
-	output.ObjectID = objectID
-
-	if stdoutStr != "" {
-		fmt.Printf("Site resources:\n%s\n", stdoutStr)
+	output := &SiteBuilderOutput{
+		ObjectID:     objectID,
+		Resources:    resources,
+		Success:      true,
+		FileToBlobID: make(map[string]string, len(resources)),
 	}
-
-	if stderrStr != "" {
-		fmt.Printf("Stderr from %s:\n%s\n", siteBuilderCmd, stderrStr)
+	if len(resources) > 0 {
+		fmt.Println("Site resources:")
+	}
+	for _, r := range resources {
+		output.FileToBlobID[strings.TrimPrefix(r.Path, "/")] = r.BlobID
+		fmt.Printf("  %s  %s\n", r.Path, r.BlobID)
 	}
 
 	return output, nil
 }
+
+// QuerySiteStatus reads a site's resources on the given network ("testnet" or
+// "mainnet"; empty uses the active Sui environment) through GetResourceList,
+// without printing progress. Callers are expected to have run
+// CheckSiteBuilderSetup once beforehand, which lets many sites be queried
+// concurrently.
+func QuerySiteStatus(ctx context.Context, objectID, network string) (*SiteBuilderOutput, error) {
+	resources, err := GetResourceList(ctx, objectID, network)
+	if err != nil {
+		return nil, err
+	}
+	return &SiteBuilderOutput{ObjectID: objectID, Resources: resources, Success: true}, nil
+}
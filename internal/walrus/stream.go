@@ -0,0 +1,77 @@
+package walrus
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// OutputHandler receives CLI output one line at a time, as it is printed,
+// with ANSI escape sequences removed.
+type OutputHandler func(line string)
+
+type outputHandlerKey struct{}
+
+// WithOutputHandler returns a copy of ctx that streams the stdout and stderr
+// of CLI commands run with it to handler. Output is still buffered in full
+// for parsing, so handler only observes it.
+func WithOutputHandler(ctx context.Context, handler OutputHandler) context.Context {
+	return context.WithValue(ctx, outputHandlerKey{}, handler)
+}
+
+// outputHandlerFrom returns the handler set with WithOutputHandler, if any.
+func outputHandlerFrom(ctx context.Context) OutputHandler {
+	handler, _ := ctx.Value(outputHandlerKey{}).(OutputHandler)
+	return handler
+}
+
+// lineWriter splits written bytes into lines for an OutputHandler. stdout and
+// stderr each get their own lineWriter sharing mu, so handler is never called
+// concurrently.
+type lineWriter struct {
+	mu      *sync.Mutex
+	handler OutputHandler
+	pending []byte
+}
+
+func newLineWriter(mu *sync.Mutex, handler OutputHandler) *lineWriter {
+	return &lineWriter{mu: mu, handler: handler}
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(w.pending[:i])
+		w.pending = w.pending[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush emits a final line that was not newline-terminated.
+func (w *lineWriter) Flush() {
+	if len(w.pending) > 0 {
+		w.emit(w.pending)
+		w.pending = nil
+	}
+}
+
+// emit sends one line to the handler. Progress output that redraws a line
+// with carriage returns is reduced to its final state.
+func (w *lineWriter) emit(raw []byte) {
+	line := strings.TrimRight(string(raw), "\r")
+	if i := strings.LastIndexByte(line, '\r'); i >= 0 {
+		line = line[i+1:]
+	}
+	line = ui.StripANSI(line)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handler(line)
+}
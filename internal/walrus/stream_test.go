@@ -0,0 +1,95 @@
+package walrus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSiteObjectID is the object ID printed by the fake site-builder.
+const fakeSiteObjectID = "0x1111111111111111111111111111111111111111111111111111111111111111"
+
+// TestFakeSiteBuilder is not a real test: runCommandWithTimeout runs the test
+// binary with TEST_FAKE_SITE_BUILDER=1 to get a command that prints colored
+// lines on stdout and stderr over time.
+func TestFakeSiteBuilder(t *testing.T) {
+	if os.Getenv("TEST_FAKE_SITE_BUILDER") != "1" {
+		return
+	}
+	fmt.Fprint(os.Stdout, "\x1b[32mUploading 3 files\x1b[0m\n")
+	time.Sleep(20 * time.Millisecond)
+	fmt.Fprint(os.Stderr, "warning: slow network\n")
+	time.Sleep(20 * time.Millisecond)
+	fmt.Fprint(os.Stdout, "progress 10%\rprogress 100%\n")
+	time.Sleep(20 * time.Millisecond)
+	fmt.Fprint(os.Stdout, "New site object ID: "+fakeSiteObjectID)
+	os.Exit(0)
+}
+
+func TestRunCommandWithTimeout_StreamsLines(t *testing.T) {
+	original := execCommandContext
+	defer func() { execCommandContext = original }()
+	execCommandContext = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, os.Args[0], "-test.run=^TestFakeSiteBuilder$")
+		cmd.Env = append(os.Environ(), "TEST_FAKE_SITE_BUILDER=1")
+		return cmd
+	}
+
+	var mu sync.Mutex
+	var lines []string
+	var times []time.Time
+	ctx := WithOutputHandler(context.Background(), func(line string) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines = append(lines, line)
+		times = append(times, time.Now())
+	})
+
+	stdout, stderr, err := runCommandWithTimeout(ctx, "site-builder", nil, false)
+	if err != nil {
+		t.Fatalf("runCommandWithTimeout() error = %v", err)
+	}
+
+	want := []string{"Uploading 3 files", "warning: slow network", "progress 100%", "New site object ID: " + fakeSiteObjectID}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("streamed lines = %q, want %q", lines, want)
+	}
+	if len(times) == len(want) && !times[len(times)-1].After(times[0]) {
+		t.Error("lines should arrive as they are printed, not all at once")
+	}
+
+	// The buffers keep the raw output for parsing
+	if !strings.Contains(stdout, "\x1b[32mUploading 3 files") || !strings.Contains(stdout, fakeSiteObjectID) {
+		t.Errorf("stdout buffer missing output: %q", stdout)
+	}
+	if parseSiteBuilderOutput(stdout).ObjectID != fakeSiteObjectID {
+		t.Errorf("stdout buffer should still parse, got %q", stdout)
+	}
+	if stderr != "warning: slow network\n" {
+		t.Errorf("stderr buffer = %q", stderr)
+	}
+}
+
+func TestRunCommandWithTimeout_NoHandler(t *testing.T) {
+	original := execCommandContext
+	defer func() { execCommandContext = original }()
+	execCommandContext = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, os.Args[0], "-test.run=^TestFakeSiteBuilder$")
+		cmd.Env = append(os.Environ(), "TEST_FAKE_SITE_BUILDER=1")
+		return cmd
+	}
+
+	stdout, _, err := runCommandWithTimeout(context.Background(), "site-builder", nil, false)
+	if err != nil {
+		t.Fatalf("runCommandWithTimeout() error = %v", err)
+	}
+	if !strings.Contains(stdout, fakeSiteObjectID) {
+		t.Errorf("stdout buffer missing output: %q", stdout)
+	}
+}
@@ -0,0 +1,119 @@
+package walrus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/sui"
+)
+
+// suinsLabelPattern matches the name part of a SuiNS domain. Hyphens and other
+// characters are rejected: the wal.app portal uses hyphens to address subnames,
+// so only letters and digits map to a site URL unambiguously.
+var suinsLabelPattern = regexp.MustCompile(`^[a-z0-9]{3,63}$`)
+
+// suinsPortalDomain serves Walrus Sites linked to SuiNS names on mainnet.
+const suinsPortalDomain = "wal.app"
+
+// NormalizeSuiNSName validates a SuiNS domain and returns it as "name.sui".
+// The ".sui" suffix is optional and letter case is ignored.
+func NormalizeSuiNSName(name string) (string, error) {
+	label := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".sui")
+	if label == "" {
+		return "", fmt.Errorf("SuiNS name cannot be empty")
+	}
+	if !suinsLabelPattern.MatchString(label) {
+		return "", fmt.Errorf("invalid SuiNS name %q: use 3-63 letters and numbers followed by .sui (e.g. myblog.sui)", name)
+	}
+	return label + ".sui", nil
+}
+
+// SuiNSPortalURL returns the wal.app URL that serves the site linked to a
+// SuiNS name, or "" when name is not a valid SuiNS name.
+func SuiNSPortalURL(name string) string {
+	normalized, err := NormalizeSuiNSName(name)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("https://%s.%s", strings.TrimSuffix(normalized, ".sui"), suinsPortalDomain)
+}
+
+// SuiNSNameTargets reports whether the SuiNS name resolves to objectID on the
+// given network. The portal serves the site whose object ID is the name's
+// target address, so a name that resolves elsewhere does not serve this site.
+func SuiNSNameTargets(ctx context.Context, network, name, objectID string) (bool, error) {
+	return suinsNameTargets(ctx, GetRPCEndpoint(network), name, objectID)
+}
+
+func suinsNameTargets(ctx context.Context, rpcURL, name, objectID string) (bool, error) {
+	if err := validateObjectID(objectID); err != nil {
+		return false, fmt.Errorf("invalid object ID: %w", err)
+	}
+	address, err := resolveSuiNSAddress(ctx, rpcURL, name)
+	if err != nil {
+		return false, err
+	}
+	return address != "" && sui.NormalizeAddress(address) == sui.NormalizeAddress(objectID), nil
+}
+
+// ResolveSuiNSAddress returns the address a SuiNS name points to on the given
+// network, or "" when the name is not registered or has no target address.
+func ResolveSuiNSAddress(ctx context.Context, network, name string) (string, error) {
+	return resolveSuiNSAddress(ctx, GetRPCEndpoint(network), name)
+}
+
+func resolveSuiNSAddress(ctx context.Context, rpcURL, name string) (string, error) {
+	normalized, err := NormalizeSuiNSName(name)
+	if err != nil {
+		return "", err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	reqBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "suix_resolveNameServiceAddress",
+		Params:  []interface{}{normalized},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("RPC request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return "", fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+
+	var address *string
+	if err := json.Unmarshal(rpcResp.Result, &address); err != nil {
+		return "", fmt.Errorf("failed to parse result: %w", err)
+	}
+	if address == nil {
+		return "", nil
+	}
+	return *address, nil
+}
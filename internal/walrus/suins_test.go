@@ -0,0 +1,136 @@
+package walrus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNormalizeSuiNSName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"with suffix", "myblog.sui", "myblog.sui", false},
+		{"without suffix", "myblog", "myblog.sui", false},
+		{"mixed case and spaces", "  MyBlog2.SUI ", "myblog2.sui", false},
+		{"digits only", "2024", "2024.sui", false},
+		{"hyphen", "my-blog.sui", "", true},
+		{"underscore", "my_blog.sui", "", true},
+		{"subname", "docs.myblog.sui", "", true},
+		{"special characters", "my$blog.sui", "", true},
+		{"too short", "ab.sui", "", true},
+		{"empty", "", "", true},
+		{"suffix only", ".sui", "", true},
+		{"other tld", "myblog.eth", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeSuiNSName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeSuiNSName(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeSuiNSName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuiNSPortalURL(t *testing.T) {
+	if got := SuiNSPortalURL("MyBlog.sui"); got != "https://myblog.wal.app" {
+		t.Errorf("SuiNSPortalURL() = %q, want https://myblog.wal.app", got)
+	}
+	if got := SuiNSPortalURL("my-blog.sui"); got != "" {
+		t.Errorf("SuiNSPortalURL() of an invalid name = %q, want empty", got)
+	}
+}
+
+func TestResolveSuiNSAddress(t *testing.T) {
+	const owner = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+
+	newServer := func(t *testing.T, result string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req rpcRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decoding request: %v", err)
+			}
+			if req.Method != "suix_resolveNameServiceAddress" {
+				t.Errorf("method = %q", req.Method)
+			}
+			if params, _ := req.Params.([]interface{}); len(params) != 1 || params[0] != "myblog.sui" {
+				t.Errorf("params = %v, want [myblog.sui]", req.Params)
+			}
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+		}))
+	}
+
+	t.Run("registered", func(t *testing.T) {
+		srv := newServer(t, `"`+owner+`"`)
+		defer srv.Close()
+		got, err := resolveSuiNSAddress(context.Background(), srv.URL, "MyBlog")
+		if err != nil {
+			t.Fatalf("resolveSuiNSAddress() error = %v", err)
+		}
+		if got != owner {
+			t.Errorf("resolveSuiNSAddress() = %q, want %q", got, owner)
+		}
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+		srv := newServer(t, `null`)
+		defer srv.Close()
+		got, err := resolveSuiNSAddress(context.Background(), srv.URL, "myblog.sui")
+		if err != nil {
+			t.Fatalf("resolveSuiNSAddress() error = %v", err)
+		}
+		if got != "" {
+			t.Errorf("resolveSuiNSAddress() = %q, want empty", got)
+		}
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		if _, err := resolveSuiNSAddress(context.Background(), "http://127.0.0.1:0", "my-blog.sui"); err == nil {
+			t.Error("expected an error for an invalid name")
+		}
+	})
+}
+
+func TestSuiNSNameTargets(t *testing.T) {
+	const site = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+
+	newServer := func(result string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+		}))
+	}
+
+	tests := []struct {
+		name   string
+		result string
+		want   bool
+	}{
+		{"points at site", `"` + site + `"`, true},
+		{"short form of site", `"0xAA"`, true},
+		{"points elsewhere", `"0x00000000000000000000000000000000000000000000000000000000000000bb"`, false},
+		{"not registered", `null`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newServer(tt.result)
+			defer srv.Close()
+			got, err := suinsNameTargets(context.Background(), srv.URL, "myblog.sui", site)
+			if err != nil {
+				t.Fatalf("suinsNameTargets() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("suinsNameTargets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
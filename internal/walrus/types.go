@@ -16,10 +16,22 @@ type SiteBuilderOutput struct {
 	Resources  []Resource
 	Base36ID   string
 	Success    bool
+	// FileToBlobID maps each resource path, relative to the site root, to
+	// its blob ID when site-builder reports resources.
+	FileToBlobID map[string]string
+	// StorageEpochs, CostWAL and GasSUI are what site-builder reported the
+	// run stored and spent; zero when its output does not say.
+	StorageEpochs int
+	CostWAL       float64
+	GasSUI        float64
 }
 
 // Resource represents a deployed site resource.
 type Resource struct {
 	Path   string
 	BlobID string
+	// ContentType and Size are not in site-builder output; they are empty
+	// and zero unless filled in from the local site.
+	ContentType string
+	Size        int64
 }
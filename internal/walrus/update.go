@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/ui"
 )
 
@@ -14,6 +15,25 @@ import (
 // It executes the `site-builder deploy` command which auto-detects updates via ws-resources.json.
 // The context can be used to cancel or timeout the operation.
 func UpdateSite(ctx context.Context, deployDir, objectID string, epochs int) (*SiteBuilderOutput, error) {
+	return UpdateSiteWithConfig(ctx, deployDir, objectID, epochs, config.WalrusConfig{}, false)
+}
+
+// UpdateSiteWithConfig is UpdateSite with walgo.yaml settings such as gateway overrides applied.
+// With deletable set, newly uploaded blobs are stored as deletable.
+func UpdateSiteWithConfig(ctx context.Context, deployDir, objectID string, epochs int, walrusCfg config.WalrusConfig, deletable bool) (*SiteBuilderOutput, error) {
+	return updateSite(ctx, deployDir, objectID, epochs, walrusCfg, deletable, false)
+}
+
+// ExtendSiteWithConfig is UpdateSiteWithConfig run with --check-extend, so
+// site-builder also extends every blob the update leaves unchanged to last
+// epochs from now. It renews a site's storage.
+func ExtendSiteWithConfig(ctx context.Context, deployDir, objectID string, epochs int, walrusCfg config.WalrusConfig, deletable bool) (*SiteBuilderOutput, error) {
+	return updateSite(ctx, deployDir, objectID, epochs, walrusCfg, deletable, true)
+}
+
+// updateSite runs site-builder update for UpdateSiteWithConfig and
+// ExtendSiteWithConfig.
+func updateSite(ctx context.Context, deployDir, objectID string, epochs int, walrusCfg config.WalrusConfig, deletable, checkExtend bool) (*SiteBuilderOutput, error) {
 	if err := validateObjectID(objectID); err != nil {
 		return nil, fmt.Errorf("invalid object ID: %w", err)
 	}
@@ -38,14 +58,17 @@ func UpdateSite(ctx context.Context, deployDir, objectID string, epochs int) (*S
 	}
 
 	siteBuilderContext := GetWalrusContext()
-	args := []string{
-		"--context", siteBuilderContext,
-		"--walrus-binary", walrusPath,
+	args := append(siteBuilderGlobalArgs(walrusCfg, walrusPath),
 		"update",
 		"--epochs", fmt.Sprintf("%d", epochs),
-		deployDir,
-		objectID,
+	)
+	if deletable {
+		args = append(args, "--deletable")
+	}
+	if checkExtend {
+		args = append(args, "--check-extend")
 	}
+	args = append(args, deployDir, objectID)
 
 	icons := ui.GetIcons()
 	fmt.Printf("%s Executing: %s %s\n", icons.Info, builderPath, strings.Join(args, " "))
@@ -77,7 +100,7 @@ func UpdateSite(ctx context.Context, deployDir, objectID string, epochs int) (*S
 	fmt.Printf("   (timeout: %v)\n", DefaultCommandTimeout)
 	fmt.Println()
 
-	stdoutStr, stderrStr, err := runCommandWithTimeout(ctx, builderPath, args, true)
+	stdoutStr, stderrStr, jsonMode, err := runSiteBuilder(ctx, builderPath, "update", args, true)
 	if err != nil {
 		debugInfo := fmt.Sprintf("\n\nCommand: %s %s\nBuilder: %s\nWalrus: %s\nContext: %s",
 			builderPath, strings.Join(args, " "), builderPath, walrusPath, siteBuilderContext)
@@ -92,9 +115,7 @@ func UpdateSite(ctx context.Context, deployDir, objectID string, epochs int) (*S
 
 	fmt.Printf("\n%s Site update command executed successfully.\n", icons.Success)
 
-	combinedOutput := stdoutStr + "\n" + stderrStr
-	output := parseSiteBuilderOutput(combinedOutput)
-	output.Success = true
+	output := parseDeployResult(stdoutStr, stderrStr, jsonMode)
 	output.ObjectID = objectID
 
 	fmt.Printf("\n%s Site updated successfully!\n", icons.Success)
@@ -0,0 +1,110 @@
+package walrus
+
+import "fmt"
+
+// BlobInfo is what a previous deploy recorded about a file's stored blob.
+type BlobInfo struct {
+	BlobID   string
+	Hash     string // Content hash of the file when it was stored
+	EndEpoch int    // First epoch the blob is no longer stored (0 if unknown)
+}
+
+// SiteFile is a file of the site about to be deployed.
+type SiteFile struct {
+	Path string
+	Hash string
+	Size int64
+}
+
+// UpdateCostEstimate is the incremental cost of updating a site: only blobs
+// that are new, changed, or expired are paid for.
+type UpdateCostEstimate struct {
+	*CostBreakdown // Cost of the blobs that must be stored
+
+	ReusedFiles   int   // Files whose blob is already stored and unexpired
+	UploadFiles   int   // Files that need a new blob
+	ExpiredFiles  int   // Unchanged files re-stored because their blob expired (part of UploadFiles)
+	UploadSize    int64 // Bytes that need to be stored
+	ReusedSize    int64 // Bytes already stored
+	CurrentEpoch  int   // Epoch expiry was checked against (0 if unknown)
+	ExpiryChecked bool  // False when the current epoch could not be determined
+}
+
+// EstimateIncrementalCost estimates what updating a site costs given the blobs a
+// previous deploy stored (prev, keyed by file path). A file whose content hash
+// matches a stored blob that is unexpired at currentEpoch costs nothing to
+// keep, even if it moved; everything else is priced like a fresh upload. A
+// currentEpoch of 0 (unknown) treats every stored blob as unexpired. opts
+// supplies the epochs, network, and any pricing overrides; its size and file
+// count are computed here.
+func EstimateIncrementalCost(prev map[string]BlobInfo, current []SiteFile, currentEpoch int, opts CostOptions) (*UpdateCostEstimate, error) {
+	if opts.Epochs <= 0 {
+		return nil, fmt.Errorf("epochs must be greater than 0")
+	}
+
+	live := make(map[string]bool, len(prev))   // hashes with an unexpired blob
+	stored := make(map[string]bool, len(prev)) // hashes with any blob
+	for _, blob := range prev {
+		if blob.Hash == "" || blob.BlobID == "" {
+			continue
+		}
+		stored[blob.Hash] = true
+		if currentEpoch == 0 || blob.EndEpoch == 0 || blob.EndEpoch > currentEpoch {
+			live[blob.Hash] = true
+		}
+	}
+
+	est := &UpdateCostEstimate{CurrentEpoch: currentEpoch, ExpiryChecked: currentEpoch > 0}
+	for _, f := range current {
+		switch {
+		case f.Hash != "" && live[f.Hash]:
+			est.ReusedFiles++
+			est.ReusedSize += f.Size
+			continue
+		case f.Hash != "" && stored[f.Hash]:
+			est.ExpiredFiles++
+		}
+		est.UploadFiles++
+		est.UploadSize += f.Size
+	}
+
+	if est.UploadFiles == 0 {
+		est.CostBreakdown = metadataUpdateCost(opts)
+		return est, nil
+	}
+
+	opts.SiteSize = est.UploadSize
+	if opts.SiteSize <= 0 {
+		opts.SiteSize = 1 // Empty files still cost a blob
+	}
+	opts.FileCount = est.UploadFiles
+	breakdown, err := CalculateCost(opts)
+	if err != nil {
+		return nil, err
+	}
+	est.CostBreakdown = breakdown
+	return est, nil
+}
+
+// metadataUpdateCost is the gas for an update that stores no new blobs.
+func metadataUpdateCost(opts CostOptions) *CostBreakdown {
+	gasPrice := opts.GasPrice
+	if gasPrice == 0 {
+		var err error
+		gasPrice, err = GetReferenceGasPrice(ResolveRPCEndpoint(opts.Network, opts.RPCURL))
+		if err != nil {
+			gasPrice = DefaultGasPrice(opts.Network)
+		}
+	}
+
+	gasUnits := uint64(50000) // Single transaction
+	gasCostSUI := float64(gasUnits) * float64(gasPrice) / 1e9
+	return &CostBreakdown{
+		GasUnits:    gasUnits,
+		GasPrice:    gasPrice,
+		GasCostSUI:  gasCostSUI,
+		Epochs:      opts.Epochs,
+		MinTotalSUI: gasCostSUI * 0.7,
+		MaxTotalSUI: gasCostSUI * 1.5,
+	}
+}
@@ -0,0 +1,146 @@
+package walrus
+
+import (
+	"fmt"
+	"testing"
+)
+
+// testCostOptions prices with fallback pricing and no network calls.
+func testCostOptions(epochs int) CostOptions {
+	return CostOptions{
+		Epochs:    epochs,
+		GasPrice:  1000,
+		Network:   "testnet",
+		WalrusBin: "/nonexistent/walrus-for-test",
+	}
+}
+
+// testSite returns n files of 200 KiB each and the blob map of a deploy that
+// stored them, expiring at endEpoch.
+func testSite(n, endEpoch int) ([]SiteFile, map[string]BlobInfo) {
+	files := make([]SiteFile, n)
+	prev := make(map[string]BlobInfo, n)
+	for i := range files {
+		path := fmt.Sprintf("page-%d.html", i)
+		hash := fmt.Sprintf("hash-%d", i)
+		files[i] = SiteFile{Path: path, Hash: hash, Size: 200 * 1024}
+		prev[path] = BlobInfo{BlobID: "blob-" + hash, Hash: hash, EndEpoch: endEpoch}
+	}
+	return files, prev
+}
+
+func TestEstimateUpdateCostIncremental(t *testing.T) {
+	files, prev := testSite(50, 120)
+
+	full, err := EstimateIncrementalCost(nil, files, 100, testCostOptions(5))
+	if err != nil {
+		t.Fatalf("full estimate error = %v", err)
+	}
+	if full.UploadFiles != 50 || full.ReusedFiles != 0 {
+		t.Fatalf("full deploy: upload=%d reused=%d, want 50/0", full.UploadFiles, full.ReusedFiles)
+	}
+
+	// Change two files and add one; the other 48 keep their blobs.
+	current := append([]SiteFile(nil), files...)
+	current[0].Hash = "hash-0-edited"
+	current[1].Hash = "hash-1-edited"
+	current = append(current, SiteFile{Path: "new.html", Hash: "hash-new", Size: 200 * 1024})
+
+	update, err := EstimateIncrementalCost(prev, current, 100, testCostOptions(5))
+	if err != nil {
+		t.Fatalf("update estimate error = %v", err)
+	}
+	if update.UploadFiles != 3 || update.ReusedFiles != 48 {
+		t.Errorf("update: upload=%d reused=%d, want 3/48", update.UploadFiles, update.ReusedFiles)
+	}
+	if update.UploadSize != 3*200*1024 {
+		t.Errorf("UploadSize = %d, want %d", update.UploadSize, 3*200*1024)
+	}
+	if update.TotalWAL >= full.TotalWAL {
+		t.Errorf("incremental WAL %.6f should be below full %.6f", update.TotalWAL, full.TotalWAL)
+	}
+	if update.GasCostSUI >= full.GasCostSUI {
+		t.Errorf("incremental SUI %.6f should be below full %.6f", update.GasCostSUI, full.GasCostSUI)
+	}
+}
+
+func TestEstimateUpdateCostExpiry(t *testing.T) {
+	files, prev := testSite(10, 120)
+	// Three blobs have expired.
+	for _, i := range []int{0, 1, 2} {
+		path := files[i].Path
+		blob := prev[path]
+		blob.EndEpoch = 90
+		prev[path] = blob
+	}
+
+	tests := []struct {
+		name         string
+		currentEpoch int
+		wantUpload   int
+		wantExpired  int
+		wantChecked  bool
+	}{
+		{"expired blobs are re-stored", 100, 3, 3, true},
+		{"blob expiring this epoch is gone", 120, 10, 10, true},
+		{"all alive before expiry", 80, 0, 0, true},
+		{"unknown epoch assumes alive", 0, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est, err := EstimateIncrementalCost(prev, files, tt.currentEpoch, testCostOptions(3))
+			if err != nil {
+				t.Fatalf("EstimateIncrementalCost() error = %v", err)
+			}
+			if est.UploadFiles != tt.wantUpload || est.ExpiredFiles != tt.wantExpired {
+				t.Errorf("upload=%d expired=%d, want %d/%d", est.UploadFiles, est.ExpiredFiles, tt.wantUpload, tt.wantExpired)
+			}
+			if est.ReusedFiles != len(files)-tt.wantUpload {
+				t.Errorf("ReusedFiles = %d, want %d", est.ReusedFiles, len(files)-tt.wantUpload)
+			}
+			if est.ExpiryChecked != tt.wantChecked {
+				t.Errorf("ExpiryChecked = %v, want %v", est.ExpiryChecked, tt.wantChecked)
+			}
+		})
+	}
+}
+
+func TestEstimateUpdateCostNoChanges(t *testing.T) {
+	files, prev := testSite(5, 0)
+
+	est, err := EstimateIncrementalCost(prev, files, 100, testCostOptions(2))
+	if err != nil {
+		t.Fatalf("EstimateIncrementalCost() error = %v", err)
+	}
+	if est.UploadFiles != 0 || est.ReusedFiles != 5 {
+		t.Errorf("upload=%d reused=%d, want 0/5", est.UploadFiles, est.ReusedFiles)
+	}
+	if est.TotalWAL != 0 {
+		t.Errorf("TotalWAL = %f, want 0 for a metadata-only update", est.TotalWAL)
+	}
+	if est.GasCostSUI <= 0 {
+		t.Error("metadata-only update should still cost gas")
+	}
+}
+
+func TestEstimateUpdateCostMovedAndUnhashed(t *testing.T) {
+	files, prev := testSite(2, 0)
+	current := []SiteFile{
+		{Path: "renamed.html", Hash: files[0].Hash, Size: files[0].Size}, // same content, new path
+		{Path: "unhashed.html", Size: 100},                               // no hash: cannot be matched
+	}
+
+	est, err := EstimateIncrementalCost(prev, current, 100, testCostOptions(1))
+	if err != nil {
+		t.Fatalf("EstimateIncrementalCost() error = %v", err)
+	}
+	if est.ReusedFiles != 1 || est.UploadFiles != 1 {
+		t.Errorf("upload=%d reused=%d, want 1/1", est.UploadFiles, est.ReusedFiles)
+	}
+}
+
+func TestEstimateUpdateCostInvalidEpochs(t *testing.T) {
+	if _, err := EstimateIncrementalCost(nil, nil, 0, testCostOptions(0)); err == nil {
+		t.Error("expected error for zero epochs")
+	}
+}
@@ -21,10 +21,12 @@ func TestDeploySite(t *testing.T) {
 		deployDir        string
 		walrusCfg        config.WalrusConfig
 		epochs           int
+		deletable        bool
 		siteBuilderFound bool
 		configExists     bool
 		expectedError    bool
 		expectedInArgs   []string
+		unexpectedInArgs []string
 	}{
 		{
 			name:      "Successful deployment setup",
@@ -37,6 +39,32 @@ func TestDeploySite(t *testing.T) {
 			configExists:     true,
 			expectedError:    false,
 			expectedInArgs:   []string{"--walrus-binary", "publish", "/path/to/public", "--epochs", "5"},
+			unexpectedInArgs: []string{"--deletable"},
+		},
+		{
+			name:      "Deletable blobs are requested from site-builder",
+			deployDir: "/path/to/public",
+			walrusCfg: config.WalrusConfig{
+				ProjectID: "test-project-id",
+			},
+			epochs:           5,
+			deletable:        true,
+			siteBuilderFound: true,
+			configExists:     true,
+			expectedInArgs:   []string{"publish", "/path/to/public", "--epochs", "5", "--deletable"},
+		},
+		{
+			name:      "Gateway RPC override is passed to site-builder",
+			deployDir: "/path/to/public",
+			walrusCfg: config.WalrusConfig{
+				ProjectID: "test-project-id",
+				Gateway:   config.GatewayConfig{SuiRPCURL: "http://127.0.0.1:1"},
+			},
+			epochs:           5,
+			siteBuilderFound: true,
+			configExists:     true,
+			expectedError:    false,
+			expectedInArgs:   []string{"--rpc-url", "http://127.0.0.1:1", "publish"},
 		},
 		{
 			name:      "Zero epochs - should fail validation",
@@ -131,7 +159,7 @@ func TestDeploySite(t *testing.T) {
 				osStat = originalOsStat
 			}()
 
-			output, err := DeploySite(context.Background(), tt.deployDir, tt.walrusCfg, tt.epochs)
+			output, err := DeploySite(context.Background(), tt.deployDir, tt.walrusCfg, tt.epochs, tt.deletable)
 
 			if tt.expectedError && err == nil {
 				t.Errorf("DeploySite() expected error but got none")
@@ -163,11 +191,50 @@ func TestDeploySite(t *testing.T) {
 						t.Errorf("DeploySite() missing expected argument: %s in %v", expectedArg, capturedArgs)
 					}
 				}
+				for _, unexpectedArg := range tt.unexpectedInArgs {
+					for _, capturedArg := range capturedArgs {
+						if capturedArg == unexpectedArg {
+							t.Errorf("DeploySite() unexpected argument: %s in %v", unexpectedArg, capturedArgs)
+						}
+					}
+				}
 			}
 		})
 	}
 }
 
+func TestSiteBuilderGlobalArgs(t *testing.T) {
+	t.Run("defaults without gateway", func(t *testing.T) {
+		args := siteBuilderGlobalArgs(config.WalrusConfig{}, "/usr/bin/walrus")
+		for _, arg := range args {
+			if arg == "--rpc-url" {
+				t.Errorf("unexpected --rpc-url in %v", args)
+			}
+		}
+		if len(args) != 4 || args[0] != "--context" || args[2] != "--walrus-binary" || args[3] != "/usr/bin/walrus" {
+			t.Errorf("unexpected default args: %v", args)
+		}
+	})
+
+	t.Run("rpc override", func(t *testing.T) {
+		cfg := config.WalrusConfig{Gateway: config.GatewayConfig{SuiRPCURL: "https://rpc.corp.example"}}
+		args := siteBuilderGlobalArgs(cfg, "/usr/bin/walrus")
+		got := strings.Join(args, " ")
+		if !strings.Contains(got, "--rpc-url https://rpc.corp.example") {
+			t.Errorf("expected rpc override in args, got %v", args)
+		}
+	})
+}
+
+func TestResolveRPCEndpoint(t *testing.T) {
+	if got := ResolveRPCEndpoint("mainnet", ""); got != SuiMainnetRPC {
+		t.Errorf("ResolveRPCEndpoint(mainnet, \"\") = %q, want %q", got, SuiMainnetRPC)
+	}
+	if got := ResolveRPCEndpoint("mainnet", "https://rpc.corp.example"); got != "https://rpc.corp.example" {
+		t.Errorf("ResolveRPCEndpoint override = %q", got)
+	}
+}
+
 func TestUpdateSite(t *testing.T) {
 
 	tests := []struct {
@@ -175,10 +242,13 @@ func TestUpdateSite(t *testing.T) {
 		deployDir        string
 		objectID         string
 		epochs           int
+		deletable        bool
+		checkExtend      bool
 		siteBuilderFound bool
 		configExists     bool
 		expectedError    bool
 		expectedInArgs   []string
+		unexpectedInArgs []string
 	}{
 		{
 			name:             "Successful update setup",
@@ -189,6 +259,27 @@ func TestUpdateSite(t *testing.T) {
 			configExists:     true,
 			expectedError:    false,
 			expectedInArgs:   []string{"--walrus-binary", "update", "--epochs", "3", "/path/to/public"},
+			unexpectedInArgs: []string{"--deletable", "--check-extend"},
+		},
+		{
+			name:             "Deletable update",
+			deployDir:        "/path/to/public",
+			objectID:         "0xe674c144119a37a0ed9cef26a962c3fdfbdbfd86a3b3db562ee81d5542a4eccf",
+			epochs:           3,
+			deletable:        true,
+			siteBuilderFound: true,
+			configExists:     true,
+			expectedInArgs:   []string{"update", "--epochs", "3", "--deletable", "/path/to/public"},
+		},
+		{
+			name:             "Update extending every blob",
+			deployDir:        "/path/to/public",
+			objectID:         "0xe674c144119a37a0ed9cef26a962c3fdfbdbfd86a3b3db562ee81d5542a4eccf",
+			epochs:           5,
+			checkExtend:      true,
+			siteBuilderFound: true,
+			configExists:     true,
+			expectedInArgs:   []string{"update", "--epochs", "5", "--check-extend", "/path/to/public"},
 		},
 		{
 			name:             "Zero epochs - should fail validation",
@@ -273,7 +364,11 @@ func TestUpdateSite(t *testing.T) {
 				osStat = originalOsStat
 			}()
 
-			output, err := UpdateSite(context.Background(), tt.deployDir, tt.objectID, tt.epochs)
+			update := UpdateSiteWithConfig
+			if tt.checkExtend {
+				update = ExtendSiteWithConfig
+			}
+			output, err := update(context.Background(), tt.deployDir, tt.objectID, tt.epochs, config.WalrusConfig{}, tt.deletable)
 
 			if tt.expectedError && err == nil {
 				t.Errorf("UpdateSite() expected error but got none")
@@ -304,6 +399,13 @@ func TestUpdateSite(t *testing.T) {
 						t.Errorf("UpdateSite() missing expected argument: %s in %v", expectedArg, capturedArgs)
 					}
 				}
+				for _, unexpectedArg := range tt.unexpectedInArgs {
+					for _, capturedArg := range capturedArgs {
+						if capturedArg == unexpectedArg {
+							t.Errorf("UpdateSite() unexpected argument: %s in %v", unexpectedArg, capturedArgs)
+						}
+					}
+				}
 			}
 		})
 	}
@@ -514,7 +616,7 @@ func TestConfigValidation(t *testing.T) {
 			}()
 
 			cfg := config.WalrusConfig{ProjectID: tt.projectID}
-			_, err := DeploySite(context.Background(), "/test", cfg, 1)
+			_, err := DeploySite(context.Background(), "/test", cfg, 1, false)
 
 			// We expect the command to fail at execution (not validation)
 			if err == nil {
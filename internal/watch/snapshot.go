@@ -0,0 +1,154 @@
+package watch
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fileStamp is what a scan records per file to detect modifications.
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// Snapshot maps slash-separated file paths (relative to the site) to their
+// size and modification time.
+type Snapshot map[string]fileStamp
+
+// Scan walks dirs under root and records every regular file. Missing
+// directories are skipped so a site without static/ can still be watched.
+func Scan(root string, dirs []string) (Snapshot, error) {
+	snap := Snapshot{}
+	for _, dir := range dirs {
+		base := filepath.Join(root, dir)
+		if _, err := os.Stat(base); os.IsNotExist(err) {
+			continue
+		}
+		err := filepath.WalkDir(base, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			snap[filepath.ToSlash(rel)] = fileStamp{size: info.Size(), modTime: info.ModTime()}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", base, err)
+		}
+	}
+	return snap, nil
+}
+
+// ChangeKind describes how a file changed between two snapshots.
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota
+	Modified
+	Removed
+)
+
+// Changes is the set of files that changed, grouped by kind and sorted.
+type Changes struct {
+	Added    []string
+	Modified []string
+	Removed  []string
+}
+
+// Empty reports whether nothing changed.
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Modified) == 0 && len(c.Removed) == 0
+}
+
+// Count returns the number of changed files.
+func (c Changes) Count() int {
+	return len(c.Added) + len(c.Modified) + len(c.Removed)
+}
+
+// String renders the changes one per line, prefixed with +, ~ or -.
+func (c Changes) String() string {
+	var b strings.Builder
+	for _, group := range []struct {
+		prefix string
+		paths  []string
+	}{{"+", c.Added}, {"~", c.Modified}, {"-", c.Removed}} {
+		for _, p := range group.paths {
+			fmt.Fprintf(&b, "%s %s\n", group.prefix, p)
+		}
+	}
+	return b.String()
+}
+
+// diff returns how each path changed from before to after.
+func diff(before, after Snapshot) map[string]ChangeKind {
+	changes := map[string]ChangeKind{}
+	for path, stamp := range after {
+		old, ok := before[path]
+		switch {
+		case !ok:
+			changes[path] = Added
+		case old.size != stamp.size || !old.modTime.Equal(stamp.modTime):
+			changes[path] = Modified
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changes[path] = Removed
+		}
+	}
+	return changes
+}
+
+// mergeChange folds a new change for a path into a pending one, so a file
+// added and then removed before a deploy does not show up at all.
+func mergeChange(pending map[string]ChangeKind, path string, kind ChangeKind) {
+	prev, ok := pending[path]
+	if !ok {
+		pending[path] = kind
+		return
+	}
+	switch {
+	case prev == Added && kind == Removed:
+		delete(pending, path)
+	case prev == Added:
+		// Still new as far as the last deploy is concerned.
+	case prev == Removed && kind == Added:
+		pending[path] = Modified
+	default:
+		pending[path] = kind
+	}
+}
+
+// toChanges groups a pending change map into sorted Changes.
+func toChanges(pending map[string]ChangeKind) Changes {
+	var c Changes
+	for path, kind := range pending {
+		switch kind {
+		case Added:
+			c.Added = append(c.Added, path)
+		case Modified:
+			c.Modified = append(c.Modified, path)
+		case Removed:
+			c.Removed = append(c.Removed, path)
+		}
+	}
+	sort.Strings(c.Added)
+	sort.Strings(c.Modified)
+	sort.Strings(c.Removed)
+	return c
+}
@@ -0,0 +1,185 @@
+// Package watch rebuilds and redeploys a site when its source files change.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/selimozten/walgo/internal/ui"
+)
+
+// Defaults for Options fields left at zero.
+const (
+	DefaultPollInterval = time.Second
+	DefaultDebounce     = 2 * time.Second
+	DefaultMinInterval  = 5 * time.Minute
+)
+
+// DefaultDirs are the site directories watched when Options.Dirs is empty.
+var DefaultDirs = []string{"content", "static"}
+
+// Clock abstracts time so the gating logic can be tested.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// CycleReport is what a deploy cycle reports back for logging.
+type CycleReport struct {
+	ObjectID      string
+	FilesUploaded int
+	WAL           float64 // WAL spent by the deploy
+	SUI           float64 // SUI gas spent by the deploy
+}
+
+// CycleFunc rebuilds and deploys the site for a batch of changes.
+type CycleFunc func(ctx context.Context, changes Changes) (CycleReport, error)
+
+// Options configures a Watcher.
+type Options struct {
+	SitePath     string
+	Dirs         []string      // Relative to SitePath (default: DefaultDirs)
+	PollInterval time.Duration // How often to scan for changes
+	Debounce     time.Duration // Quiet period after the last change before deploying
+	MinInterval  time.Duration // Minimum time between the starts of two deploys
+	Cycle        CycleFunc
+	Out          io.Writer // Log output (default: os.Stdout)
+
+	// Clock and Scan are replaced in tests.
+	Clock Clock
+	Scan  func(root string, dirs []string) (Snapshot, error)
+}
+
+// Watcher batches file changes and runs a deploy cycle once they settle.
+type Watcher struct {
+	opts       Options
+	current    Snapshot
+	pending    map[string]ChangeKind
+	lastChange time.Time
+	lastCycle  time.Time
+	deferred   bool // "waiting for min interval" was already logged
+	cycles     int
+}
+
+// New validates opts, fills in defaults and takes the baseline snapshot.
+// Files that exist when watching starts are not deployed until they change.
+func New(opts Options) (*Watcher, error) {
+	if opts.Cycle == nil {
+		return nil, errors.New("watch: no deploy cycle configured")
+	}
+	if opts.Debounce < 0 || opts.MinInterval < 0 || opts.PollInterval < 0 {
+		return nil, errors.New("watch: intervals must not be negative")
+	}
+	if len(opts.Dirs) == 0 {
+		opts.Dirs = DefaultDirs
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	if opts.Clock == nil {
+		opts.Clock = realClock{}
+	}
+	if opts.Scan == nil {
+		opts.Scan = Scan
+	}
+
+	baseline, err := opts.Scan(opts.SitePath, opts.Dirs)
+	if err != nil {
+		return nil, err
+	}
+	return &Watcher{opts: opts, current: baseline, pending: map[string]ChangeKind{}}, nil
+}
+
+// Cycles returns the number of deploy cycles started so far.
+func (w *Watcher) Cycles() int {
+	return w.cycles
+}
+
+// Poll scans once and runs a deploy cycle when the pending changes have been
+// quiet for Debounce and at least MinInterval has passed since the previous
+// cycle started. It reports whether a cycle ran. A failed cycle drops its
+// changes; the next edit triggers a new attempt.
+func (w *Watcher) Poll(ctx context.Context) (bool, error) {
+	snap, err := w.opts.Scan(w.opts.SitePath, w.opts.Dirs)
+	if err != nil {
+		return false, err
+	}
+
+	now := w.opts.Clock.Now()
+	changed := diff(w.current, snap)
+	for path, kind := range changed {
+		mergeChange(w.pending, path, kind)
+	}
+	w.current = snap
+	if len(changed) > 0 {
+		w.lastChange = now
+	}
+
+	if len(w.pending) == 0 || now.Sub(w.lastChange) < w.opts.Debounce {
+		return false, nil
+	}
+
+	icons := ui.GetIcons()
+	if !w.lastCycle.IsZero() {
+		if wait := w.opts.MinInterval - now.Sub(w.lastCycle); wait > 0 {
+			if !w.deferred {
+				fmt.Fprintf(w.opts.Out, "%s %d change(s) pending; next deploy in %s (--min-interval)\n",
+					icons.Hourglass, len(w.pending), wait.Round(time.Second))
+				w.deferred = true
+			}
+			return false, nil
+		}
+	}
+
+	changes := toChanges(w.pending)
+	w.pending = map[string]ChangeKind{}
+	w.lastCycle = now
+	w.deferred = false
+	w.cycles++
+
+	fmt.Fprintf(w.opts.Out, "\n%s Cycle %d: %d file(s) changed\n", icons.Spinner, w.cycles, changes.Count())
+	for _, line := range strings.Split(strings.TrimRight(changes.String(), "\n"), "\n") {
+		fmt.Fprintf(w.opts.Out, "    %s\n", line)
+	}
+
+	report, err := w.opts.Cycle(ctx, changes)
+	if err != nil {
+		return true, fmt.Errorf("cycle %d failed: %w", w.cycles, err)
+	}
+	fmt.Fprintf(w.opts.Out, "%s Cycle %d deployed %s: %d file(s) uploaded, cost %.6f WAL + %.6f SUI\n",
+		icons.Check, w.cycles, report.ObjectID, report.FilesUploaded, report.WAL, report.SUI)
+	return true, nil
+}
+
+// Run polls until ctx is cancelled (Ctrl-C). Scan and cycle errors are logged
+// and watching continues.
+func (w *Watcher) Run(ctx context.Context) error {
+	icons := ui.GetIcons()
+	fmt.Fprintf(w.opts.Out, "%s Watching %s for changes (Ctrl+C to stop)\n", icons.Search, strings.Join(w.opts.Dirs, ", "))
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Fprintf(w.opts.Out, "\n%s Stopped watching after %d deploy cycle(s)\n", icons.Info, w.cycles)
+			return nil
+		case <-w.opts.Clock.After(w.opts.PollInterval):
+		}
+
+		if _, err := w.Poll(ctx); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(w.opts.Out, "%s Warning: %v\n", icons.Warning, err)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeClock is advanced by hand; After is unused because tests call Poll.
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time                       { return c.now }
+func (c *fakeClock) After(time.Duration) <-chan time.Time { return make(chan time.Time) }
+func (c *fakeClock) advance(d time.Duration)              { c.now = c.now.Add(d) }
+
+// fakeFS is a mutable snapshot returned by the scan hook.
+type fakeFS struct {
+	files Snapshot
+	tick  int
+}
+
+func (f *fakeFS) scan(string, []string) (Snapshot, error) {
+	snap := Snapshot{}
+	for k, v := range f.files {
+		snap[k] = v
+	}
+	return snap, nil
+}
+
+func (f *fakeFS) write(path string) {
+	f.tick++
+	f.files[path] = fileStamp{size: int64(f.tick), modTime: time.Unix(int64(f.tick), 0)}
+}
+
+// fakeDeployer records the changes each cycle was asked to deploy.
+type fakeDeployer struct {
+	calls []Changes
+	err   error
+}
+
+func (d *fakeDeployer) cycle(_ context.Context, changes Changes) (CycleReport, error) {
+	d.calls = append(d.calls, changes)
+	return CycleReport{ObjectID: "0xsite", FilesUploaded: changes.Count(), WAL: 0.5, SUI: 0.01}, d.err
+}
+
+func newTestWatcher(t *testing.T, fs *fakeFS, dep *fakeDeployer, clock *fakeClock) (*Watcher, *bytes.Buffer) {
+	t.Helper()
+	var out bytes.Buffer
+	w, err := New(Options{
+		Debounce:    2 * time.Second,
+		MinInterval: time.Minute,
+		Cycle:       dep.cycle,
+		Out:         &out,
+		Clock:       clock,
+		Scan:        fs.scan,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return w, &out
+}
+
+func mustPoll(t *testing.T, w *Watcher) bool {
+	t.Helper()
+	ran, err := w.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	return ran
+}
+
+func TestWatcherDebounce(t *testing.T) {
+	fs := &fakeFS{files: Snapshot{}}
+	fs.write("content/existing.md")
+	dep := &fakeDeployer{}
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	w, out := newTestWatcher(t, fs, dep, clock)
+
+	if mustPoll(t, w) {
+		t.Fatal("no cycle expected without changes")
+	}
+
+	// A burst of edits keeps pushing the deploy back.
+	fs.write("content/post.md")
+	mustPoll(t, w)
+	clock.advance(time.Second)
+	fs.write("content/post.md")
+	if mustPoll(t, w) {
+		t.Fatal("cycle ran during the debounce window")
+	}
+	clock.advance(time.Second)
+	fs.write("static/logo.png")
+	if mustPoll(t, w) {
+		t.Fatal("cycle ran during the debounce window")
+	}
+
+	clock.advance(2 * time.Second)
+	if !mustPoll(t, w) {
+		t.Fatal("expected a cycle once changes settled")
+	}
+	if len(dep.calls) != 1 {
+		t.Fatalf("deploys = %d, want 1", len(dep.calls))
+	}
+	want := Changes{Added: []string{"content/post.md", "static/logo.png"}}
+	if !reflect.DeepEqual(dep.calls[0], want) {
+		t.Errorf("changes = %+v, want %+v", dep.calls[0], want)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("+ content/post.md")) || !bytes.Contains(out.Bytes(), []byte("0.500000 WAL")) {
+		t.Errorf("log should list the diff and cost, got:\n%s", out.String())
+	}
+}
+
+func TestWatcherMinInterval(t *testing.T) {
+	fs := &fakeFS{files: Snapshot{}}
+	dep := &fakeDeployer{}
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	w, out := newTestWatcher(t, fs, dep, clock)
+
+	fs.write("content/a.md")
+	mustPoll(t, w)
+	clock.advance(2 * time.Second)
+	if !mustPoll(t, w) {
+		t.Fatal("first cycle should run after the debounce")
+	}
+
+	// Changes after a deploy wait for the minimum interval even once settled.
+	clock.advance(10 * time.Second)
+	fs.write("content/a.md")
+	fs.write("content/b.md")
+	mustPoll(t, w)
+	clock.advance(5 * time.Second)
+	if mustPoll(t, w) {
+		t.Fatal("cycle ran before --min-interval elapsed")
+	}
+	clock.advance(30 * time.Second)
+	if mustPoll(t, w) {
+		t.Fatal("cycle ran before --min-interval elapsed")
+	}
+	if bytes.Count(out.Bytes(), []byte("pending")) != 1 {
+		t.Errorf("the pending notice should be logged once, got:\n%s", out.String())
+	}
+
+	clock.advance(15 * time.Second) // 60s since the first cycle started
+	if !mustPoll(t, w) {
+		t.Fatal("expected a cycle once --min-interval elapsed")
+	}
+	want := Changes{Added: []string{"content/b.md"}, Modified: []string{"content/a.md"}}
+	if len(dep.calls) != 2 || !reflect.DeepEqual(dep.calls[1], want) {
+		t.Errorf("deploys = %+v, want second with %+v", dep.calls, want)
+	}
+}
+
+func TestWatcherFailedCycleWaitsForNextChange(t *testing.T) {
+	fs := &fakeFS{files: Snapshot{}}
+	dep := &fakeDeployer{err: errors.New("build failed")}
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	w, _ := newTestWatcher(t, fs, dep, clock)
+
+	fs.write("content/a.md")
+	mustPoll(t, w)
+	clock.advance(2 * time.Second)
+	ran, err := w.Poll(context.Background())
+	if !ran || err == nil {
+		t.Fatalf("Poll() = %v, %v; want a failed cycle", ran, err)
+	}
+
+	clock.advance(2 * time.Minute)
+	if mustPoll(t, w) {
+		t.Fatal("a failed cycle must not be retried without new changes")
+	}
+}
+
+func TestMergeChange(t *testing.T) {
+	tests := []struct {
+		name  string
+		kinds []ChangeKind
+		want  Changes
+	}{
+		{"added then modified stays added", []ChangeKind{Added, Modified}, Changes{Added: []string{"f"}}},
+		{"added then removed cancels out", []ChangeKind{Added, Removed}, Changes{}},
+		{"removed then added is a modification", []ChangeKind{Removed, Added}, Changes{Modified: []string{"f"}}},
+		{"modified then removed is removed", []ChangeKind{Modified, Removed}, Changes{Removed: []string{"f"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending := map[string]ChangeKind{}
+			for _, k := range tt.kinds {
+				mergeChange(pending, "f", k)
+			}
+			if got := toChanges(pending); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "content", "posts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "content", "posts", "a.md"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "config.toml"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := Scan(root, []string{"content", "static"})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if _, ok := snap["content/posts/a.md"]; !ok || len(snap) != 1 {
+		t.Errorf("snapshot = %v, want only content/posts/a.md", snap)
+	}
+}
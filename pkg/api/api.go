@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,9 +18,9 @@ import (
 	"github.com/selimozten/walgo/internal/config"
 	"github.com/selimozten/walgo/internal/deployer"
 	sb "github.com/selimozten/walgo/internal/deployer/sitebuilder"
-	"github.com/selimozten/walgo/internal/deployment"
 	"github.com/selimozten/walgo/internal/deps"
 	"github.com/selimozten/walgo/internal/hugo"
+	"github.com/selimozten/walgo/internal/netcheck"
 	"github.com/selimozten/walgo/internal/obsidian"
 	"github.com/selimozten/walgo/internal/projects"
 	"github.com/selimozten/walgo/internal/sui"
@@ -99,54 +100,29 @@ func saveDraftProject(siteName, sitePath string) error {
 // =============================================================================
 
 // WalletInfo holds wallet information
-type WalletInfo struct {
-	Address    string  `json:"address"`
-	SuiBalance float64 `json:"suiBalance"`
-	WalBalance float64 `json:"walBalance"`
-	Network    string  `json:"network"`
-	Active     bool    `json:"active"`
-}
+type WalletInfo = sui.WalletInfo
 
 // GetWalletInfo returns current wallet information
 func GetWalletInfo() (*WalletInfo, error) {
-	// Get active network
-	network, err := sui.GetActiveEnv()
+	info, err := sui.GetActiveAddressDetails(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active env: %w", err)
+		return nil, err
 	}
-
-	// Get active address
-	address, err := sui.GetActiveAddress()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get active address: %w", err)
-	}
-
-	// Get balance
-	balance, err := sui.GetBalance()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get balance: %w", err)
-	}
-
-	return &WalletInfo{
-		Address:    address,
-		SuiBalance: balance.SUI,
-		WalBalance: balance.WAL,
-		Network:    network,
-		Active:     true,
-	}, nil
+	return &info, nil
 }
 
 // AddressListResult holds list of addresses
 type AddressListResult struct {
-	Addresses []string `json:"addresses"`
-	Error     string   `json:"error"`
+	Addresses []string  `json:"addresses"`
+	Error     string    `json:"error"`
+	Code      ErrorCode `json:"code,omitempty"`
 }
 
 // GetAddressList returns list of all wallet addresses
 func GetAddressList() AddressListResult {
 	addresses, err := sui.GetAddressList()
 	if err != nil {
-		return AddressListResult{Error: fmt.Sprintf("failed to get addresses: %v", err)}
+		return AddressListResult{Error: fmt.Sprintf("failed to get addresses: %v", err), Code: errorCode(err)}
 	}
 	return AddressListResult{Addresses: addresses}
 }
@@ -158,16 +134,17 @@ type SwitchAddressParams struct {
 
 // SwitchAddressResult holds result of switching address
 type SwitchAddressResult struct {
-	Success bool   `json:"success"`
-	Address string `json:"address"`
-	Error   string `json:"error"`
+	Success bool      `json:"success"`
+	Address string    `json:"address"`
+	Error   string    `json:"error"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
 
 // SwitchAddress switches to a different wallet address
 func SwitchAddress(address string) SwitchAddressResult {
 	err := sui.SwitchAddress(address)
 	if err != nil {
-		return SwitchAddressResult{Error: fmt.Sprintf("failed to switch address: %v", err)}
+		return SwitchAddressResult{Error: fmt.Sprintf("failed to switch address: %v", err), Code: errorCode(err)}
 	}
 	return SwitchAddressResult{Success: true, Address: address}
 }
@@ -179,11 +156,12 @@ type CreateAddressParams struct {
 
 // CreateAddressResult holds result of creating address
 type CreateAddressResult struct {
-	Success        bool   `json:"success"`
-	Address        string `json:"address"`
-	Alias          string `json:"alias"`
-	RecoveryPhrase string `json:"recoveryPhrase"`
-	Error          string `json:"error"`
+	Success        bool      `json:"success"`
+	Address        string    `json:"address"`
+	Alias          string    `json:"alias"`
+	RecoveryPhrase string    `json:"recoveryPhrase"`
+	Error          string    `json:"error"`
+	Code           ErrorCode `json:"code,omitempty"`
 }
 
 // CreateAddress creates a new wallet address
@@ -196,7 +174,7 @@ func CreateAddress(keyScheme string, alias string) CreateAddressResult {
 
 	result, err := sui.CreateAddressWithDetails(keyScheme, alias)
 	if err != nil {
-		return CreateAddressResult{Error: fmt.Sprintf("failed to create address: %v", err)}
+		return CreateAddressResult{Error: fmt.Sprintf("failed to create address: %v", err), Code: errorCode(err)}
 	}
 
 	return CreateAddressResult{
@@ -216,9 +194,10 @@ type ImportAddressParams struct {
 
 // ImportAddressResult holds import result
 type ImportAddressResult struct {
-	Success bool   `json:"success"`
-	Address string `json:"address"`
-	Error   string `json:"error"`
+	Success bool      `json:"success"`
+	Address string    `json:"address"`
+	Error   string    `json:"error"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
 
 // ImportAddress imports a wallet address with provided mnemonic or private key
@@ -227,6 +206,7 @@ func ImportAddress(params ImportAddressParams) ImportAddressResult {
 		return ImportAddressResult{
 			Success: false,
 			Error:   "Input (mnemonic or private key) is required",
+			Code:    CodeValidation,
 		}
 	}
 
@@ -245,6 +225,7 @@ func ImportAddress(params ImportAddressParams) ImportAddressResult {
 		return ImportAddressResult{
 			Success: false,
 			Error:   fmt.Sprintf("failed to import address: %v", err),
+			Code:    errorCode(err),
 		}
 	}
 
@@ -256,16 +237,17 @@ func ImportAddress(params ImportAddressParams) ImportAddressResult {
 
 // SwitchNetworkResult holds result of switching network
 type SwitchNetworkResult struct {
-	Success bool   `json:"success"`
-	Network string `json:"network"`
-	Error   string `json:"error"`
+	Success bool      `json:"success"`
+	Network string    `json:"network"`
+	Error   string    `json:"error"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
 
 // SwitchNetwork switches to a different network (testnet/mainnet)
 func SwitchNetwork(network string) SwitchNetworkResult {
 	err := sui.SwitchEnv(network)
 	if err != nil {
-		return SwitchNetworkResult{Error: fmt.Sprintf("failed to switch network: %v", err)}
+		return SwitchNetworkResult{Error: fmt.Sprintf("failed to switch network: %v", err), Code: errorCode(err)}
 	}
 	return SwitchNetworkResult{Success: true, Network: network}
 }
@@ -276,9 +258,10 @@ func SwitchNetwork(network string) SwitchNetworkResult {
 
 // InitSiteResult holds init site result
 type InitSiteResult struct {
-	Success  bool   `json:"success"`
-	SitePath string `json:"sitePath"`
-	Error    string `json:"error"`
+	Success  bool      `json:"success"`
+	SitePath string    `json:"sitePath"`
+	Error    string    `json:"error"`
+	Code     ErrorCode `json:"code,omitempty"`
 }
 
 // InitSite initializes a new Hugo site in current directory (like 'walgo init')
@@ -296,6 +279,7 @@ func InitSite(parentDir string, siteName string) InitSiteResult {
 			return InitSiteResult{
 				Success: false,
 				Error:   fmt.Sprintf("cannot determine walgo-sites directory: %v", err),
+				Code:    errorCode(err),
 			}
 		}
 		parentDir = defaultDir
@@ -307,6 +291,7 @@ func InitSite(parentDir string, siteName string) InitSiteResult {
 		return InitSiteResult{
 			Success: false,
 			Error:   fmt.Sprintf("invalid site path: %v", err),
+			Code:    CodeValidation,
 		}
 	}
 
@@ -321,6 +306,7 @@ func InitSite(parentDir string, siteName string) InitSiteResult {
 		return InitSiteResult{
 			Success: false,
 			Error:   fmt.Sprintf("failed to create site directory: %v", err),
+			Code:    errorCode(err),
 		}
 	}
 
@@ -337,6 +323,7 @@ func InitSite(parentDir string, siteName string) InitSiteResult {
 		return InitSiteResult{
 			Success: false,
 			Error:   fmt.Sprintf("failed to initialize Hugo site: %v", err),
+			Code:    errorCode(err),
 		}
 	}
 
@@ -345,6 +332,7 @@ func InitSite(parentDir string, siteName string) InitSiteResult {
 		return InitSiteResult{
 			Success: false,
 			Error:   fmt.Sprintf("failed to create walgo.yaml: %v", err),
+			Code:    errorCode(err),
 		}
 	}
 
@@ -352,6 +340,7 @@ func InitSite(parentDir string, siteName string) InitSiteResult {
 		return InitSiteResult{
 			Success: false,
 			Error:   fmt.Sprintf("failed to build site: %v", err),
+			Code:    errorCode(err),
 		}
 	}
 
@@ -360,6 +349,7 @@ func InitSite(parentDir string, siteName string) InitSiteResult {
 		return InitSiteResult{
 			Success: false,
 			Error:   fmt.Sprintf("failed to save draft project: %v", err),
+			Code:    errorCode(err),
 		}
 	}
 
@@ -395,14 +385,17 @@ func BuildSite(sitePath string) error {
 type InstallThemeParams struct {
 	SitePath  string `json:"sitePath"`
 	GithubURL string `json:"githubUrl"`
+	Ref       string `json:"ref,omitempty"`
+	NoCache   bool   `json:"noCache,omitempty"`
 }
 
 // InstallThemeResult holds the result of theme installation
 type InstallThemeResult struct {
-	Success       bool     `json:"success"`
-	ThemeName     string   `json:"themeName"`
-	RemovedThemes []string `json:"removedThemes,omitempty"`
-	Error         string   `json:"error,omitempty"`
+	Success       bool      `json:"success"`
+	ThemeName     string    `json:"themeName"`
+	RemovedThemes []string  `json:"removedThemes,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	Code          ErrorCode `json:"code,omitempty"`
 }
 
 // InstallTheme installs a Hugo theme from a GitHub URL
@@ -413,11 +406,13 @@ func InstallTheme(params InstallThemeParams) InstallThemeResult {
 	sitePath := params.SitePath
 	if sitePath == "" {
 		result.Error = "site path is required"
+		result.Code = CodeValidation
 		return result
 	}
 
 	if params.GithubURL == "" {
 		result.Error = "github URL is required"
+		result.Code = CodeValidation
 		return result
 	}
 
@@ -426,9 +421,13 @@ func InstallTheme(params InstallThemeParams) InstallThemeResult {
 	result.RemovedThemes = existingThemes
 
 	// Install theme
-	themeName, err := hugo.InstallThemeFromURL(sitePath, params.GithubURL)
+	themeName, err := hugo.InstallThemeFromURLWithOptions(sitePath, params.GithubURL, hugo.ThemeDownloadOptions{
+		Ref:     params.Ref,
+		NoCache: params.NoCache,
+	})
 	if err != nil {
 		result.Error = err.Error()
+		result.Code = errorCode(err)
 		return result
 	}
 
@@ -439,9 +438,10 @@ func InstallTheme(params InstallThemeParams) InstallThemeResult {
 
 // GetInstalledThemesResult holds the list of installed themes
 type GetInstalledThemesResult struct {
-	Success bool     `json:"success"`
-	Themes  []string `json:"themes"`
-	Error   string   `json:"error,omitempty"`
+	Success bool      `json:"success"`
+	Themes  []string  `json:"themes"`
+	Error   string    `json:"error,omitempty"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
 
 // GetInstalledThemes returns the list of installed themes
@@ -450,12 +450,14 @@ func GetInstalledThemes(sitePath string) GetInstalledThemesResult {
 
 	if sitePath == "" {
 		result.Error = "site path is required"
+		result.Code = CodeValidation
 		return result
 	}
 
 	themes, err := hugo.GetInstalledThemes(sitePath)
 	if err != nil {
 		result.Error = err.Error()
+		result.Code = errorCode(err)
 		return result
 	}
 
@@ -475,10 +477,11 @@ type NewContentParams struct {
 
 // NewContentResult holds the result of creating new content
 type NewContentResult struct {
-	Success  bool   `json:"success"`
-	Path     string `json:"path"`
-	FilePath string `json:"filePath"` // Alias for Path (frontend compatibility)
-	Error    string `json:"error"`
+	Success  bool      `json:"success"`
+	Path     string    `json:"path"`
+	FilePath string    `json:"filePath"` // Alias for Path (frontend compatibility)
+	Error    string    `json:"error"`
+	Code     ErrorCode `json:"code,omitempty"`
 }
 
 // NewContent creates new content in Hugo site
@@ -488,7 +491,7 @@ func NewContent(params NewContentParams) NewContentResult {
 		var err error
 		sitePath, err = os.Getwd()
 		if err != nil {
-			return NewContentResult{Error: fmt.Sprintf("cannot determine current directory: %v", err)}
+			return NewContentResult{Error: fmt.Sprintf("cannot determine current directory: %v", err), Code: errorCode(err)}
 		}
 	}
 
@@ -504,12 +507,12 @@ func NewContent(params NewContentParams) NewContentResult {
 	// Get slug
 	slug := params.Slug
 	if slug == "" {
-		return NewContentResult{Error: "slug is required"}
+		return NewContentResult{Error: "slug is required", Code: CodeValidation}
 	}
 
 	// Validate slug
 	if !isValidSlug(slug) {
-		return NewContentResult{Error: "invalid slug: use only letters, numbers, hyphens, and underscores"}
+		return NewContentResult{Error: "invalid slug: use only letters, numbers, hyphens, and underscores", Code: CodeValidation}
 	}
 
 	// Ensure .md extension
@@ -522,13 +525,13 @@ func NewContent(params NewContentParams) NewContentResult {
 
 	// Create content using Hugo
 	if err := hugo.CreateContent(sitePath, contentPath); err != nil {
-		return NewContentResult{Error: fmt.Sprintf("failed to create content: %v", err)}
+		return NewContentResult{Error: fmt.Sprintf("failed to create content: %v", err), Code: errorCode(err)}
 	}
 
 	createdFilePath := filepath.Join(sitePath, "content", contentPath)
 
 	if err := BuildSite(sitePath); err != nil {
-		return NewContentResult{Error: fmt.Sprintf("failed to build site: %v", err)}
+		return NewContentResult{Error: fmt.Sprintf("failed to build site: %v", err), Code: errorCode(err)}
 	}
 
 	return NewContentResult{
@@ -624,43 +627,6 @@ type SystemHealth struct {
 	Message         string `json:"message"`
 }
 
-// checkNetworkConnectivity checks internet connectivity using multiple endpoints
-// Returns true if at least one endpoint is reachable
-func checkNetworkConnectivity() bool {
-	// Use multiple reliable endpoints to avoid rate limiting and false negatives
-	endpoints := []string{
-		"https://www.google.com",
-		"https://1.1.1.1", // Cloudflare DNS
-		"https://8.8.8.8", // Google DNS
-	}
-
-	client := &http.Client{
-		Timeout: 3 * time.Second, // Short timeout to avoid blocking
-	}
-
-	// Check endpoints in parallel; return true on first success
-	type result struct{ ok bool }
-	ch := make(chan result, len(endpoints))
-	for _, endpoint := range endpoints {
-		go func(url string) {
-			resp, err := client.Get(url)
-			if err == nil {
-				resp.Body.Close()
-				ch <- result{ok: resp.StatusCode >= 200 && resp.StatusCode < 500}
-				return
-			}
-			ch <- result{ok: false}
-		}(endpoint)
-	}
-
-	for range endpoints {
-		if r := <-ch; r.ok {
-			return true
-		}
-	}
-	return false
-}
-
 // =============================================================================
 // QuickStart
 // =============================================================================
@@ -676,16 +642,17 @@ type QuickStartParams struct {
 
 // QuickStartResult holds quickstart result
 type QuickStartResult struct {
-	Success  bool   `json:"success"`
-	SitePath string `json:"sitePath"`
-	Error    string `json:"error"`
+	Success  bool      `json:"success"`
+	SitePath string    `json:"sitePath"`
+	Error    string    `json:"error"`
+	Code     ErrorCode `json:"code,omitempty"`
 }
 
 // QuickStart creates a new Hugo site with quickstart flow
 func QuickStart(params QuickStartParams) QuickStartResult {
 	// Check Hugo dependency first
 	if _, err := deps.LookPath("hugo"); err != nil {
-		return QuickStartResult{Error: "hugo is not installed or not found in PATH"}
+		return QuickStartResult{Error: "hugo is not installed or not found in PATH", Code: CodeToolMissing}
 	}
 
 	siteName := params.SiteName
@@ -695,7 +662,7 @@ func QuickStart(params QuickStartParams) QuickStartResult {
 
 	// Validate site name before proceeding
 	if !utils.IsValidSiteName(siteName) {
-		return QuickStartResult{Error: "invalid site name: use only letters, numbers, hyphens and underscores"}
+		return QuickStartResult{Error: "invalid site name: use only letters, numbers, hyphens and underscores", Code: CodeValidation}
 	}
 
 	parentDir := params.ParentDir
@@ -703,7 +670,7 @@ func QuickStart(params QuickStartParams) QuickStartResult {
 		// Use default walgo-sites directory in home
 		defaultDir, err := GetDefaultSitesDir()
 		if err != nil {
-			return QuickStartResult{Error: fmt.Sprintf("cannot determine walgo-sites directory: %v", err)}
+			return QuickStartResult{Error: fmt.Sprintf("cannot determine walgo-sites directory: %v", err), Code: errorCode(err)}
 		}
 		parentDir = defaultDir
 	}
@@ -729,7 +696,7 @@ func QuickStart(params QuickStartParams) QuickStartResult {
 	// Create site directory using sanitized name
 	sitePath, err := filepath.Abs(filepath.Join(parentDir, sanitizedDirName))
 	if err != nil {
-		return QuickStartResult{Error: fmt.Sprintf("invalid site path: %v", err)}
+		return QuickStartResult{Error: fmt.Sprintf("invalid site path: %v", err), Code: CodeValidation}
 	}
 
 	// Check if directory already exists before creating
@@ -739,7 +706,7 @@ func QuickStart(params QuickStartParams) QuickStartResult {
 	}
 
 	if err := os.MkdirAll(sitePath, 0755); err != nil {
-		return QuickStartResult{Error: fmt.Sprintf("failed to create site directory: %v", err)}
+		return QuickStartResult{Error: fmt.Sprintf("failed to create site directory: %v", err), Code: errorCode(err)}
 	}
 
 	// Setup cleanup on failure
@@ -752,12 +719,12 @@ func QuickStart(params QuickStartParams) QuickStartResult {
 
 	// Initialize Hugo site
 	if err := hugo.InitializeSite(sitePath); err != nil {
-		return QuickStartResult{Error: fmt.Sprintf("failed to initialize Hugo site: %v", err)}
+		return QuickStartResult{Error: fmt.Sprintf("failed to initialize Hugo site: %v", err), Code: errorCode(err)}
 	}
 
 	// Create walgo.yaml config
 	if err := config.CreateDefaultWalgoConfig(sitePath); err != nil {
-		return QuickStartResult{Error: fmt.Sprintf("failed to create walgo.yaml: %v", err)}
+		return QuickStartResult{Error: fmt.Sprintf("failed to create walgo.yaml: %v", err), Code: errorCode(err)}
 	}
 
 	// Setup site - use ORIGINAL name for Hugo config
@@ -766,22 +733,22 @@ func QuickStart(params QuickStartParams) QuickStartResult {
 	if siteType == hugo.SiteTypeBlog {
 		// Blog: use our embedded TOML template + archetypes
 		if err := hugo.SetupSiteConfigWithName(sitePath, siteType, originalSiteName); err != nil {
-			return QuickStartResult{Error: fmt.Sprintf("failed to set up config: %v", err)}
+			return QuickStartResult{Error: fmt.Sprintf("failed to set up config: %v", err), Code: errorCode(err)}
 		}
 
 		if err := hugo.SetupArchetypes(sitePath, themeInfo.DirName); err != nil {
-			return QuickStartResult{Error: fmt.Sprintf("failed to set up archetypes: %v", err)}
+			return QuickStartResult{Error: fmt.Sprintf("failed to set up archetypes: %v", err), Code: errorCode(err)}
 		}
 	}
 
 	// Setup favicon (theme-aware placement)
 	if err := hugo.SetupFaviconForTheme(sitePath, themeInfo.DirName); err != nil {
-		return QuickStartResult{Error: fmt.Sprintf("failed to set up favicon: %v", err)}
+		return QuickStartResult{Error: fmt.Sprintf("failed to set up favicon: %v", err), Code: errorCode(err)}
 	}
 
 	// Install theme
 	if err := hugo.InstallTheme(sitePath, siteType); err != nil {
-		return QuickStartResult{Error: fmt.Sprintf("failed to install theme: %v", err)}
+		return QuickStartResult{Error: fmt.Sprintf("failed to install theme: %v", err), Code: errorCode(err)}
 	}
 
 	// Docs theme overrides
@@ -797,7 +764,7 @@ func QuickStart(params QuickStartParams) QuickStartResult {
 		// Blog: use inline quickstart content
 		contentDir := filepath.Join(sitePath, "content")
 		if err := os.MkdirAll(contentDir, 0755); err != nil {
-			return QuickStartResult{Error: fmt.Sprintf("failed to create content directory: %v", err)}
+			return QuickStartResult{Error: fmt.Sprintf("failed to create content directory: %v", err), Code: errorCode(err)}
 		}
 
 		indexPath := filepath.Join(contentDir, "_index.md")
@@ -847,7 +814,7 @@ func QuickStart(params QuickStartParams) QuickStartResult {
 			"---\n\n" +
 			"**Ready to build the decentralized web?** Start editing this file and make it your own!\n"
 		if err := os.WriteFile(indexPath, []byte(indexContent), 0644); err != nil {
-			return QuickStartResult{Error: fmt.Sprintf("failed to create homepage: %v", err)}
+			return QuickStartResult{Error: fmt.Sprintf("failed to create homepage: %v", err), Code: errorCode(err)}
 		}
 
 	default:
@@ -866,13 +833,13 @@ func QuickStart(params QuickStartParams) QuickStartResult {
 
 	if !params.SkipBuild {
 		if err := BuildSite(sitePath); err != nil {
-			return QuickStartResult{Error: fmt.Sprintf("failed to build site: %v", err)}
+			return QuickStartResult{Error: fmt.Sprintf("failed to build site: %v", err), Code: errorCode(err)}
 		}
 	}
 
 	// Save as draft project for later deployment - use ORIGINAL name
 	if err := saveDraftProject(originalSiteName, sitePath); err != nil {
-		return QuickStartResult{Error: fmt.Sprintf("failed to save draft project: %v", err)}
+		return QuickStartResult{Error: fmt.Sprintf("failed to save draft project: %v", err), Code: errorCode(err)}
 	}
 
 	success = true
@@ -893,33 +860,91 @@ type ServeParams struct {
 	Drafts   bool   `json:"drafts"`
 	Expired  bool   `json:"expired"` // Include expired content
 	Future   bool   `json:"future"`
+	Bind     string `json:"bind,omitempty"` // Interface to listen on (default 127.0.0.1)
+	TLS      bool   `json:"tls"`            // Serve over HTTPS with a self-signed certificate
 }
 
 // ServeResult holds serve result
 type ServeResult struct {
-	Success bool   `json:"success"`
-	URL     string `json:"url"`
-	Error   string `json:"error"`
+	Success bool      `json:"success"`
+	URL     string    `json:"url"`
+	Warning string    `json:"warning,omitempty"` // Set when the server is reachable from the network
+	Error   string    `json:"error"`
+	Code    ErrorCode `json:"code,omitempty"`
+}
+
+// serveOptions validates params and converts them to hugo server options.
+func serveOptions(params ServeParams) (hugo.ServeOptions, error) {
+	bind, err := hugo.ParseBindAddress(params.Bind)
+	if err != nil {
+		return hugo.ServeOptions{}, err
+	}
+	if params.Port < 0 || params.Port > 65535 {
+		return hugo.ServeOptions{}, fmt.Errorf("invalid port %d: must be between 1 and 65535", params.Port)
+	}
+	return hugo.ServeOptions{
+		Bind:    bind,
+		Port:    params.Port,
+		TLS:     params.TLS,
+		Drafts:  params.Drafts,
+		Expired: params.Expired,
+		Future:  params.Future,
+	}, nil
+}
+
+// serveWarning returns the warning shown when opts exposes the server beyond localhost.
+func serveWarning(opts hugo.ServeOptions) string {
+	if !hugo.IsExposedBind(opts.Bind) {
+		return ""
+	}
+	return fmt.Sprintf("listening on %s: anyone on your network can reach this preview", opts.Bind)
+}
+
+// ServeCommandArgs returns the 'hugo server' arguments and preview URL for
+// params, generating a self-signed certificate when params.TLS is set. Call
+// cleanup once the server has stopped.
+func ServeCommandArgs(params ServeParams) (args []string, url, warning string, cleanup func(), err error) {
+	opts, err := serveOptions(params)
+	if err != nil {
+		return nil, "", "", func() {}, err
+	}
+	cleanup, err = hugo.PrepareTLS(&opts)
+	if err != nil {
+		return nil, "", "", cleanup, fmt.Errorf("failed to prepare TLS certificate: %w", err)
+	}
+	return hugo.ServerArgs(opts), hugo.ServeURL(opts), serveWarning(opts), cleanup, nil
+}
+
+// HugoBinary returns the Hugo binary that serves the site at sitePath: the
+// release its walgo.yaml pins, or the hugo on PATH.
+func HugoBinary(sitePath string) (string, error) {
+	return hugo.SiteHugoBinary(sitePath, io.Discard)
 }
 
 // Serve starts local Hugo development server
 func Serve(params ServeParams) ServeResult {
 	sitePath := params.SitePath
 	if sitePath == "" {
-		return ServeResult{Error: "site path is required"}
+		return ServeResult{Error: "site path is required", Code: CodeValidation}
 	}
 
-	if _, err := deps.LookPath("hugo"); err != nil {
-		return ServeResult{Error: "hugo is not installed or not found in PATH"}
+	opts, err := serveOptions(params)
+	if err != nil {
+		return ServeResult{Error: err.Error(), Code: CodeValidation}
 	}
 
-	if err := hugo.ServeSite(sitePath); err != nil {
-		return ServeResult{Error: fmt.Sprintf("failed to serve site: %v", err)}
+	if _, err := HugoBinary(sitePath); err != nil {
+		return ServeResult{Error: err.Error(), Code: CodeToolMissing}
+	}
+
+	if err := hugo.ServeSiteWithOptions(sitePath, opts); err != nil {
+		return ServeResult{Error: fmt.Sprintf("failed to serve site: %v", err), Code: errorCode(err)}
 	}
 
 	return ServeResult{
 		Success: true,
-		URL:     "http://localhost:1313",
+		URL:     hugo.ServeURL(opts),
+		Warning: serveWarning(opts),
 	}
 }
 
@@ -933,12 +958,14 @@ type Project struct {
 	Name          string             `json:"name"`
 	Description   string             `json:"description"`
 	Category      string             `json:"category"`
+	Tags          []string           `json:"tags,omitempty"`
 	ObjectID      string             `json:"objectId"`
 	Network       string             `json:"network"`
 	WalletAddr    string             `json:"wallet"`
 	SitePath      string             `json:"sitePath"`
 	ImageURL      string             `json:"imageUrl"`
 	SuiNS         string             `json:"suins"`
+	URL           string             `json:"url,omitempty"` // wal.app URL from the SuiNS domain (mainnet)
 	CreatedAt     string             `json:"createdAt"`
 	UpdatedAt     string             `json:"updatedAt"`
 	LastDeployAt  string             `json:"lastDeployAt"`
@@ -988,6 +1015,91 @@ func ListProjects() ([]Project, error) {
 		return nil, fmt.Errorf("failed to list projects: %w", err)
 	}
 
+	return listedProjects(pm, projs), nil
+}
+
+// ProjectListFilter narrows ListProjectsPage. Empty fields match every project.
+type ProjectListFilter struct {
+	Network string   `json:"network,omitempty"`
+	Status  string   `json:"status,omitempty"`
+	Tags    []string `json:"tags,omitempty"` // Projects must carry every tag
+}
+
+// ProjectPage is one page of projects plus the number of projects matching
+// the filter across all pages.
+type ProjectPage struct {
+	Projects []Project `json:"projects"`
+	Total    int       `json:"total"`
+	Offset   int       `json:"offset"`
+	Limit    int       `json:"limit"`
+}
+
+// ListProjectsPage returns up to limit projects matching filter, skipping the
+// first offset, in ListProjects order. An offset past the end yields an
+// empty page with the total still set.
+func ListProjectsPage(offset, limit int, filter ProjectListFilter) (*ProjectPage, error) {
+	pm, err := projects.NewManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project manager: %w", err)
+	}
+	defer pm.Close()
+
+	projs, total, err := pm.ListProjectsPage(projects.ProjectFilter{
+		Network: filter.Network,
+		Status:  filter.Status,
+		Tags:    filter.Tags,
+	}, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	return &ProjectPage{
+		Projects: listedProjects(pm, projs),
+		Total:    total,
+		Offset:   offset,
+		Limit:    limit,
+	}, nil
+}
+
+// DeploymentPage is one page of a project's deployments plus the project's
+// total number of deployments.
+type DeploymentPage struct {
+	Deployments []DeploymentRecord `json:"deployments"`
+	Total       int                `json:"total"`
+	Offset      int                `json:"offset"`
+	Limit       int                `json:"limit"`
+}
+
+// ListDeployments returns up to limit of a project's deployments, newest
+// first, skipping the first offset. An offset past the end yields an empty
+// page with the total still set.
+func ListDeployments(projectID int64, offset, limit int) (*DeploymentPage, error) {
+	pm, err := projects.NewManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project manager: %w", err)
+	}
+	defer pm.Close()
+
+	records, total, err := pm.GetProjectDeploymentsPage(projectID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	deployments := make([]DeploymentRecord, 0, len(records))
+	for _, dr := range records {
+		deployments = append(deployments, deploymentRecordFrom(dr))
+	}
+	return &DeploymentPage{
+		Deployments: deployments,
+		Total:       total,
+		Offset:      offset,
+		Limit:       limit,
+	}, nil
+}
+
+// listedProjects converts projects to the API type with their expiry and
+// the local tool status filled in.
+func listedProjects(pm *projects.Manager, projs []*projects.Project) []Project {
 	// Check tool status once (outside loop for performance)
 	suiReady, _ := deps.LookPath("sui")
 	walrusReady, _ := deps.LookPath("walrus")
@@ -1027,12 +1139,14 @@ func ListProjects() ([]Project, error) {
 			Name:          p.Name,
 			Description:   p.Description,
 			Category:      p.Category,
+			Tags:          p.Tags,
 			ObjectID:      p.ObjectID,
 			Network:       p.Network,
 			WalletAddr:    p.WalletAddr,
 			SitePath:      p.SitePath,
 			ImageURL:      p.ImageURL,
 			SuiNS:         p.SuiNS,
+			URL:           p.PortalURL(),
 			CreatedAt:     p.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:     p.UpdatedAt.Format(time.RFC3339),
 			LastDeployAt:  p.LastDeployAt.Format(time.RFC3339),
@@ -1052,7 +1166,24 @@ func ListProjects() ([]Project, error) {
 		}
 	}
 
-	return result, nil
+	return result
+}
+
+// deploymentRecordFrom converts a stored deployment record to the API type.
+func deploymentRecordFrom(dr *projects.DeploymentRecord) DeploymentRecord {
+	return DeploymentRecord{
+		ID:        dr.ID,
+		ProjectID: dr.ProjectID,
+		ObjectID:  dr.ObjectID,
+		Network:   dr.Network,
+		Epochs:    dr.Epochs,
+		GasFee:    dr.GasFee,
+		Version:   dr.Version,
+		Notes:     dr.Notes,
+		Success:   dr.Success,
+		Error:     dr.Error,
+		CreatedAt: dr.CreatedAt.Format(time.RFC3339),
+	}
 }
 
 // GetProject returns a single project by ID with deployment history
@@ -1081,19 +1212,7 @@ func GetProject(projectID int64) (*Project, error) {
 	// Convert deployment records to API format
 	deployments := make([]DeploymentRecord, 0, len(deploymentRecords))
 	for _, dr := range deploymentRecords {
-		deployments = append(deployments, DeploymentRecord{
-			ID:        dr.ID,
-			ProjectID: dr.ProjectID,
-			ObjectID:  dr.ObjectID,
-			Network:   dr.Network,
-			Epochs:    dr.Epochs,
-			GasFee:    dr.GasFee,
-			Version:   dr.Version,
-			Notes:     dr.Notes,
-			Success:   dr.Success,
-			Error:     dr.Error,
-			CreatedAt: dr.CreatedAt.Format(time.RFC3339),
-		})
+		deployments = append(deployments, deploymentRecordFrom(dr))
 	}
 
 	// Get epoch info for accurate expiry calculation
@@ -1141,12 +1260,14 @@ func GetProject(projectID int64) (*Project, error) {
 		Name:          proj.Name,
 		Description:   proj.Description,
 		Category:      proj.Category,
+		Tags:          proj.Tags,
 		ObjectID:      proj.ObjectID,
 		Network:       proj.Network,
 		WalletAddr:    proj.WalletAddr,
 		SitePath:      proj.SitePath,
 		ImageURL:      proj.ImageURL,
 		SuiNS:         proj.SuiNS,
+		URL:           proj.PortalURL(),
 		CreatedAt:     proj.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:     proj.UpdatedAt.Format(time.RFC3339),
 		LastDeployAt:  proj.LastDeployAt.Format(time.RFC3339),
@@ -1229,12 +1350,13 @@ type UpdateSiteParams struct {
 
 // UpdateSiteResult holds update site result
 type UpdateSiteResult struct {
-	Success  bool     `json:"success"`
-	ObjectID string   `json:"objectId"`
-	GasFee   string   `json:"gasFee"`
-	Message  string   `json:"message"`
-	Error    string   `json:"error"`
-	Logs     []string `json:"logs,omitempty"`
+	Success  bool      `json:"success"`
+	ObjectID string    `json:"objectId"`
+	GasFee   string    `json:"gasFee"`
+	Message  string    `json:"message"`
+	Error    string    `json:"error"`
+	Code     ErrorCode `json:"code,omitempty"`
+	Logs     []string  `json:"logs,omitempty"`
 }
 
 // UpdateSite updates an existing project's site on Walrus (re-deploy)
@@ -1246,6 +1368,7 @@ func UpdateSite(params UpdateSiteParams) UpdateSiteResult {
 	pm, err := projects.NewManager()
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to create project manager: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 	defer pm.Close()
@@ -1254,6 +1377,7 @@ func UpdateSite(params UpdateSiteParams) UpdateSiteResult {
 	proj, err := pm.GetProject(params.ProjectID)
 	if err != nil || proj == nil {
 		result.Error = "project not found"
+		result.Code = CodeNotFound
 		return result
 	}
 
@@ -1274,6 +1398,7 @@ func UpdateSite(params UpdateSiteParams) UpdateSiteResult {
 	walgoCfg, err := config.LoadConfigFrom(proj.SitePath)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to load config: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
@@ -1281,12 +1406,14 @@ func UpdateSite(params UpdateSiteParams) UpdateSiteResult {
 	result.Logs = append(result.Logs, "🔨 Building site...")
 	if err := hugo.BuildSite(proj.SitePath); err != nil {
 		result.Error = fmt.Sprintf("failed to build site: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
 	publishDir := filepath.Join(proj.SitePath, walgoCfg.HugoConfig.PublishDir)
 	if _, err := os.Stat(publishDir); os.IsNotExist(err) {
 		result.Error = "publish directory not found"
+		result.Code = CodeNotFound
 		return result
 	}
 
@@ -1302,6 +1429,7 @@ func UpdateSite(params UpdateSiteParams) UpdateSiteResult {
 		return nil
 	}); walkErr != nil {
 		result.Error = fmt.Sprintf("failed to calculate site size: %v", walkErr)
+		result.Code = errorCode(walkErr)
 		return result
 	}
 
@@ -1330,29 +1458,32 @@ func UpdateSite(params UpdateSiteParams) UpdateSiteResult {
 		}
 		_ = pm.RecordDeployment(deployment)
 		result.Error = fmt.Sprintf("update failed: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
 	if !output.Success {
 		result.Error = "update failed: operation unsuccessful"
+		result.Code = CodeInternal
 		return result
 	}
 
-	// Try to get actual gas from blockchain, fallback to estimate
-	var gasFee string
-	gasInfo, err := walrus.GetLatestTransactionGas(proj.WalletAddr, proj.Network)
-	if err == nil && gasInfo != nil {
-		if gasInfo.TotalWAL > 0 && gasInfo.TotalGasSUI > 0 {
-			gasFee = fmt.Sprintf("%.6f WAL + %.6f SUI", gasInfo.TotalWAL, gasInfo.TotalGasSUI)
-		} else if gasInfo.TotalWAL > 0 {
-			gasFee = fmt.Sprintf("%.6f WAL", gasInfo.TotalWAL)
-		} else if gasInfo.TotalGasSUI > 0 {
-			gasFee = fmt.Sprintf("%.6f SUI", gasInfo.TotalGasSUI)
-		}
+	// Prefer the costs site-builder reported, then the chain, then an estimate
+	if output.StorageEpochs > 0 {
+		epochs = output.StorageEpochs
 	}
-	if gasFee == "" {
-		gasFee = projects.EstimateGasFeeWithEpochs(proj.Network, siteSize, epochs)
+	costWAL, costSUI := output.CostWAL, output.GasSUI
+	if costWAL == 0 || costSUI == 0 {
+		if gasInfo, err := walrus.GetLatestTransactionGas(proj.WalletAddr, proj.Network); err == nil && gasInfo != nil {
+			if costWAL == 0 {
+				costWAL = gasInfo.TotalWAL
+			}
+			if costSUI == 0 {
+				costSUI = gasInfo.TotalGasSUI
+			}
+		}
 	}
+	gasFee, costActual := projects.DeployCostFee(costWAL, costSUI, proj.Network, siteSize, epochs)
 
 	// Update project
 	proj.Epochs = epochs
@@ -1361,17 +1492,21 @@ func UpdateSite(params UpdateSiteParams) UpdateSiteResult {
 
 	if err := pm.UpdateProject(proj); err != nil {
 		result.Error = fmt.Sprintf("failed to update project record: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
 	// Record successful deployment
 	deployment := &projects.DeploymentRecord{
-		ProjectID: proj.ID,
-		ObjectID:  proj.ObjectID,
-		Network:   proj.Network,
-		Epochs:    epochs,
-		GasFee:    gasFee,
-		Success:   true,
+		ProjectID:  proj.ID,
+		ObjectID:   proj.ObjectID,
+		Network:    proj.Network,
+		Epochs:     epochs,
+		GasFee:     gasFee,
+		Success:    true,
+		CostWAL:    costWAL,
+		CostSUI:    costSUI,
+		CostActual: costActual,
 	}
 	if err := pm.RecordDeployment(deployment); err != nil {
 		result.Logs = append(result.Logs, fmt.Sprintf("⚠️  Warning: Failed to record deployment: %v", err))
@@ -1393,15 +1528,18 @@ func UpdateSite(params UpdateSiteParams) UpdateSiteResult {
 // DeleteProjectParams holds delete project parameters
 type DeleteProjectParams struct {
 	ProjectID int64 `json:"projectId"`
+	Force     bool  `json:"force"` // Destroy even if a SuiNS domain points at the site
 }
 
 // DeleteProjectResult holds delete result
 type DeleteProjectResult struct {
-	Success          bool   `json:"success"`
-	Message          string `json:"message"`
-	Error            string `json:"error"`
-	OnChainDestroyed bool   `json:"onChainDestroyed"`
-	EstimatedGasCost string `json:"estimatedGasCost,omitempty"`
+	Success          bool      `json:"success"`
+	Message          string    `json:"message"`
+	Error            string    `json:"error"`
+	Code             ErrorCode `json:"code,omitempty"`
+	OnChainDestroyed bool      `json:"onChainDestroyed"`
+	EstimatedGasCost string    `json:"estimatedGasCost,omitempty"`
+	LinkedDomains    []string  `json:"linkedDomains,omitempty"` // SuiNS domains that blocked the delete; retry with Force to delete anyway
 }
 
 // DeleteProject deletes a project by ID (includes on-chain destruction if objectId exists)
@@ -1413,6 +1551,7 @@ func DeleteProject(params DeleteProjectParams) DeleteProjectResult {
 	pm, err := projects.NewManager()
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to create project manager: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 	defer pm.Close()
@@ -1421,6 +1560,7 @@ func DeleteProject(params DeleteProjectParams) DeleteProjectResult {
 	proj, err := pm.GetProject(params.ProjectID)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to get project: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
@@ -1435,25 +1575,41 @@ func DeleteProject(params DeleteProjectParams) DeleteProjectResult {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 		defer cancel()
 
-		if err := d.Destroy(ctx, proj.ObjectID); err != nil {
+		err := d.Destroy(ctx, proj.ObjectID, deployer.DestroyOptions{
+			Network:     proj.Network,
+			SuiNSDomain: proj.LinkedSuiNSDomain(),
+			Force:       params.Force,
+		})
+		var linked *deployer.LinkedDomainError
+		switch {
+		case errors.As(err, &linked):
+			// Keep the project so the user can unlink the domain or retry with Force
+			result.Error = fmt.Sprintf("site is linked to SuiNS domain %s; deleting it would break the domain",
+				strings.Join(linked.Domains, ", "))
+			result.Code = CodeValidation
+			result.LinkedDomains = linked.Domains
+			return result
+		case err != nil:
 			// Log warning but continue with local deletion
 			result.Message = fmt.Sprintf("Warning: Failed to destroy site on-chain: %v. Continuing with local deletion.", err)
-		} else {
+		default:
 			result.OnChainDestroyed = true
+			_ = pm.MarkDestroyed(proj.ID)
 		}
 	}
 
-	// Delete from local database and site folder
-	if err := pm.DeleteProject(params.ProjectID); err != nil {
+	// Soft-delete the local record; it can be restored within the recovery window
+	if err := pm.SoftDeleteProject(params.ProjectID); err != nil {
 		result.Error = fmt.Sprintf("failed to delete project: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
 	result.Success = true
 	if result.OnChainDestroyed {
-		result.Message = "Project deleted successfully (including on-chain destruction and site folder)"
+		result.Message = "Project deleted successfully (including on-chain destruction)"
 	} else {
-		result.Message = "Project deleted successfully (including site folder)"
+		result.Message = "Project deleted successfully"
 	}
 
 	return result
@@ -1483,26 +1639,29 @@ type EditProjectParams struct {
 	Description string `json:"description"`
 	ImageURL    string `json:"imageUrl"`
 	SuiNS       string `json:"suins"`
+	// AllowCustomCategory accepts a Category outside compress.KnownCategories
+	AllowCustomCategory bool `json:"allowCustomCategory,omitempty"`
 }
 
 // EditProjectResult holds edit result
 type EditProjectResult struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Error   string `json:"error"`
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Error   string    `json:"error"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
 
 // EditProject updates project metadata
 func EditProject(params EditProjectParams) EditProjectResult {
 	pm, err := projects.NewManager()
 	if err != nil {
-		return EditProjectResult{Error: fmt.Sprintf("failed to create project manager: %v", err)}
+		return EditProjectResult{Error: fmt.Sprintf("failed to create project manager: %v", err), Code: errorCode(err)}
 	}
 	defer pm.Close()
 
 	proj, err := pm.GetProject(params.ProjectID)
 	if err != nil || proj == nil {
-		return EditProjectResult{Error: "project not found"}
+		return EditProjectResult{Error: "project not found", Code: CodeNotFound}
 	}
 
 	// Update fields if provided
@@ -1519,11 +1678,21 @@ func EditProject(params EditProjectParams) EditProjectResult {
 		proj.ImageURL = params.ImageURL
 	}
 	if params.SuiNS != "" {
-		proj.SuiNS = params.SuiNS
+		domain, err := walrus.NormalizeSuiNSName(params.SuiNS)
+		if err != nil {
+			return EditProjectResult{Error: err.Error(), Code: CodeValidation}
+		}
+		proj.SuiNS = domain
+	}
+
+	// Reject what ws-resources.json would refuse before saving anything
+	metadataCheck := compress.MetadataOptions{ImageURL: proj.ImageURL, Category: proj.Category, AllowCustomCategory: params.AllowCustomCategory}
+	if err := metadataCheck.Validate(); err != nil {
+		return EditProjectResult{Error: err.Error(), Code: CodeValidation}
 	}
 
 	if err := pm.UpdateProject(proj); err != nil {
-		return EditProjectResult{Error: fmt.Sprintf("failed to update project: %v", err)}
+		return EditProjectResult{Error: fmt.Sprintf("failed to update project: %v", err), Code: errorCode(err)}
 	}
 
 	// Update ws-resources.json in the publish directory (if it exists)
@@ -1557,6 +1726,8 @@ func EditProject(params EditProjectParams) EditProjectResult {
 		Creator:     compress.DefaultCreator,
 		Link:        compress.DefaultLink,
 		Category:    proj.Category,
+
+		AllowCustomCategory: params.AllowCustomCategory,
 	}
 
 	// Preserve existing ObjectID
@@ -1580,21 +1751,22 @@ func EditProject(params EditProjectParams) EditProjectResult {
 
 // ArchiveProjectResult holds archive result
 type ArchiveProjectResult struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Error   string `json:"error"`
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Error   string    `json:"error"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
 
 // ArchiveProject archives a project
 func ArchiveProject(projectID int64) ArchiveProjectResult {
 	pm, err := projects.NewManager()
 	if err != nil {
-		return ArchiveProjectResult{Error: fmt.Sprintf("failed to create project manager: %v", err)}
+		return ArchiveProjectResult{Error: fmt.Sprintf("failed to create project manager: %v", err), Code: errorCode(err)}
 	}
 	defer pm.Close()
 
 	if err := pm.ArchiveProject(projectID); err != nil {
-		return ArchiveProjectResult{Error: fmt.Sprintf("failed to archive project: %v", err)}
+		return ArchiveProjectResult{Error: fmt.Sprintf("failed to archive project: %v", err), Code: errorCode(err)}
 	}
 
 	return ArchiveProjectResult{
@@ -1603,6 +1775,33 @@ func ArchiveProject(projectID int64) ArchiveProjectResult {
 	}
 }
 
+// RestoreProjectResult holds restore result
+type RestoreProjectResult struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Error   string    `json:"error"`
+	Code    ErrorCode `json:"code,omitempty"`
+}
+
+// RestoreDeletedProject brings back a project deleted with DeleteProject
+// within its recovery window. A site destroyed on-chain is not recreated.
+func RestoreDeletedProject(projectID int64) RestoreProjectResult {
+	pm, err := projects.NewManager()
+	if err != nil {
+		return RestoreProjectResult{Error: fmt.Sprintf("failed to create project manager: %v", err), Code: errorCode(err)}
+	}
+	defer pm.Close()
+
+	if err := pm.RestoreDeletedProject(projectID, projects.DefaultRecoveryWindow); err != nil {
+		return RestoreProjectResult{Error: fmt.Sprintf("failed to restore project: %v", err), Code: errorCode(err)}
+	}
+
+	return RestoreProjectResult{
+		Success: true,
+		Message: "project restored successfully",
+	}
+}
+
 // SetStatusParams holds parameters for setting project status
 type SetStatusParams struct {
 	ProjectID int64  `json:"projectId"`
@@ -1611,21 +1810,22 @@ type SetStatusParams struct {
 
 // SetStatusResult holds the result of setting project status
 type SetStatusResult struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Error   string `json:"error"`
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Error   string    `json:"error"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
 
 // SetStatus sets the status of a project
 func SetStatus(params SetStatusParams) SetStatusResult {
 	pm, err := projects.NewManager()
 	if err != nil {
-		return SetStatusResult{Error: fmt.Sprintf("failed to create project manager: %v", err)}
+		return SetStatusResult{Error: fmt.Sprintf("failed to create project manager: %v", err), Code: errorCode(err)}
 	}
 	defer pm.Close()
 
 	if err := pm.SetStatus(params.ProjectID, params.Status); err != nil {
-		return SetStatusResult{Error: fmt.Sprintf("failed to set status: %v", err)}
+		return SetStatusResult{Error: fmt.Sprintf("failed to set status: %v", err), Code: errorCode(err)}
 	}
 
 	return SetStatusResult{
@@ -1652,10 +1852,11 @@ type ImportObsidianParams struct {
 
 // ImportObsidianResult holds import results
 type ImportObsidianResult struct {
-	Success       bool   `json:"success"`
-	FilesImported int    `json:"filesImported"`
-	SitePath      string `json:"sitePath"` // Path to created site
-	Error         string `json:"error"`
+	Success       bool      `json:"success"`
+	FilesImported int       `json:"filesImported"`
+	SitePath      string    `json:"sitePath"` // Path to created site
+	Error         string    `json:"error"`
+	Code          ErrorCode `json:"code,omitempty"`
 }
 
 // ImportObsidian creates a new Hugo site and imports content from Obsidian vault
@@ -1663,13 +1864,13 @@ func ImportObsidian(params ImportObsidianParams) ImportObsidianResult {
 	// Validate vault path
 	absVaultPath, err := filepath.Abs(params.VaultPath)
 	if err != nil {
-		return ImportObsidianResult{Error: fmt.Sprintf("invalid vault path: %v", err)}
+		return ImportObsidianResult{Error: fmt.Sprintf("invalid vault path: %v", err), Code: CodeValidation}
 	}
 	params.VaultPath = filepath.Clean(absVaultPath)
 
 	// Verify vault exists
 	if _, err := os.Stat(params.VaultPath); os.IsNotExist(err) {
-		return ImportObsidianResult{Error: fmt.Sprintf("vault path does not exist: %s", params.VaultPath)}
+		return ImportObsidianResult{Error: fmt.Sprintf("vault path does not exist: %s", params.VaultPath), Code: CodeNotFound}
 	}
 
 	// Determine site name
@@ -1689,7 +1890,7 @@ func ImportObsidian(params ImportObsidianParams) ImportObsidianResult {
 	if parentDir == "" {
 		wd, err := os.Getwd()
 		if err != nil {
-			return ImportObsidianResult{Error: fmt.Sprintf("cannot determine current directory: %v", err)}
+			return ImportObsidianResult{Error: fmt.Sprintf("cannot determine current directory: %v", err), Code: errorCode(err)}
 		}
 		parentDir = wd
 	}
@@ -1699,12 +1900,12 @@ func ImportObsidian(params ImportObsidianParams) ImportObsidianResult {
 
 	// Check if site already exists
 	if _, err := os.Stat(sitePath); err == nil {
-		return ImportObsidianResult{Error: fmt.Sprintf("site directory already exists: %s", sitePath)}
+		return ImportObsidianResult{Error: fmt.Sprintf("site directory already exists: %s", sitePath), Code: CodeValidation}
 	}
 
 	// Step 1: Create site directory
 	if err := os.MkdirAll(sitePath, 0755); err != nil {
-		return ImportObsidianResult{Error: fmt.Sprintf("failed to create site directory: %v", err)}
+		return ImportObsidianResult{Error: fmt.Sprintf("failed to create site directory: %v", err), Code: errorCode(err)}
 	}
 
 	// Setup cleanup on failure - we know directory didn't exist before because we checked above
@@ -1718,18 +1919,18 @@ func ImportObsidian(params ImportObsidianParams) ImportObsidianResult {
 
 	// Step 2: Initialize Hugo site
 	if err := hugo.InitializeSite(sitePath); err != nil {
-		return ImportObsidianResult{Error: fmt.Sprintf("failed to initialize Hugo site: %v", err)}
+		return ImportObsidianResult{Error: fmt.Sprintf("failed to initialize Hugo site: %v", err), Code: errorCode(err)}
 	}
 
 	// Step 3: Create walgo.yaml
 	if err := config.CreateDefaultWalgoConfig(sitePath); err != nil {
-		return ImportObsidianResult{Error: fmt.Sprintf("failed to create walgo.yaml: %v", err)}
+		return ImportObsidianResult{Error: fmt.Sprintf("failed to create walgo.yaml: %v", err), Code: errorCode(err)}
 	}
 
 	// Step 4: Import vault
 	cfg, err := config.LoadConfigFrom(sitePath)
 	if err != nil {
-		return ImportObsidianResult{Error: fmt.Sprintf("error loading config: %v", err)}
+		return ImportObsidianResult{Error: fmt.Sprintf("error loading config: %v", err), Code: errorCode(err)}
 	}
 
 	hugoContentDir := filepath.Join(sitePath, cfg.HugoConfig.ContentDir)
@@ -1752,22 +1953,22 @@ func ImportObsidian(params ImportObsidianParams) ImportObsidianResult {
 	// Import vault content
 	stats, err := obsidian.ImportVault(params.VaultPath, hugoContentDir, obsidianCfg)
 	if err != nil {
-		return ImportObsidianResult{Error: fmt.Sprintf("import failed: %v", err)}
+		return ImportObsidianResult{Error: fmt.Sprintf("import failed: %v", err), Code: errorCode(err)}
 	}
 
 	if err := BuildSite(sitePath); err != nil {
-		return ImportObsidianResult{Error: fmt.Sprintf("failed to build site: %v", err)}
+		return ImportObsidianResult{Error: fmt.Sprintf("failed to build site: %v", err), Code: errorCode(err)}
 	}
 
 	// Step 5: Create draft project using ORIGINAL name
 	manager, err := projects.NewManager()
 	if err != nil {
-		return ImportObsidianResult{Error: fmt.Sprintf("failed to create project manager: %v", err)}
+		return ImportObsidianResult{Error: fmt.Sprintf("failed to create project manager: %v", err), Code: errorCode(err)}
 	}
 	defer manager.Close()
 
 	if err := manager.CreateDraftProject(originalSiteName, sitePath); err != nil {
-		return ImportObsidianResult{Error: fmt.Sprintf("failed to create draft project: %v", err)}
+		return ImportObsidianResult{Error: fmt.Sprintf("failed to create draft project: %v", err), Code: errorCode(err)}
 	}
 
 	success = true
@@ -1788,19 +1989,21 @@ type AIConfigureParams struct {
 	APIKey   string `json:"apiKey"`
 	BaseURL  string `json:"baseURL,omitempty"`
 	Model    string `json:"model,omitempty"`
+	Force    bool   `json:"force,omitempty"` // Accept models missing from the provider's allowlist
 }
 
 // AIConfigResult holds the result of AI configuration
 type AIConfigResult struct {
-	Configured          bool     `json:"configured"`
-	Enabled             bool     `json:"enabled"`
-	Provider            string   `json:"provider,omitempty"`
-	CurrentProvider     string   `json:"currentProvider,omitempty"`
-	Model               string   `json:"model,omitempty"`
-	CurrentModel        string   `json:"currentModel,omitempty"`
-	ConfiguredProviders []string `json:"configuredProviders,omitempty"`
-	Success             bool     `json:"success"`
-	Error               string   `json:"error,omitempty"`
+	Configured          bool      `json:"configured"`
+	Enabled             bool      `json:"enabled"`
+	Provider            string    `json:"provider,omitempty"`
+	CurrentProvider     string    `json:"currentProvider,omitempty"`
+	Model               string    `json:"model,omitempty"`
+	CurrentModel        string    `json:"currentModel,omitempty"`
+	ConfiguredProviders []string  `json:"configuredProviders,omitempty"`
+	Success             bool      `json:"success"`
+	Error               string    `json:"error,omitempty"`
+	Code                ErrorCode `json:"code,omitempty"`
 }
 
 // GetAIConfig returns the current AI configuration
@@ -1813,6 +2016,7 @@ func GetAIConfig() (*AIConfigResult, error) {
 			Enabled:    false,
 			Success:    false,
 			Error:      err.Error(),
+			Code:       errorCode(err),
 		}, nil
 	}
 
@@ -1857,6 +2061,15 @@ func UpdateAIConfig(params AIConfigureParams) error {
 	if params.Provider == "" {
 		return fmt.Errorf("provider is required")
 	}
+	if params.Model != "" {
+		if err := ai.ValidateModel(params.Provider, params.Model, params.Force); err != nil {
+			var unknown *ai.UnknownModelError
+			if errors.As(err, &unknown) {
+				return fmt.Errorf("%w; allow unlisted models to save it anyway", err)
+			}
+			return err
+		}
+	}
 
 	// When saving new provider credentials, remove all other providers first
 	if params.Provider != "" {
@@ -1884,6 +2097,7 @@ func GetProviderCredentialsAPI(provider string) (*ProviderCredentialsResult, err
 		return &ProviderCredentialsResult{
 			Success: false,
 			Error:   err.Error(),
+			Code:    errorCode(err),
 		}, nil
 	}
 
@@ -1897,11 +2111,12 @@ func GetProviderCredentialsAPI(provider string) (*ProviderCredentialsResult, err
 
 // ProviderCredentialsResult holds provider credentials
 type ProviderCredentialsResult struct {
-	Success bool   `json:"success"`
-	APIKey  string `json:"apiKey"`
-	BaseURL string `json:"baseURL"`
-	Model   string `json:"model"`
-	Error   string `json:"error,omitempty"`
+	Success bool      `json:"success"`
+	APIKey  string    `json:"apiKey"`
+	BaseURL string    `json:"baseURL"`
+	Model   string    `json:"model"`
+	Error   string    `json:"error,omitempty"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
 
 // =============================================================================
@@ -1920,10 +2135,11 @@ type GenerateContentParams struct {
 
 // GenerateContentResult holds the result of content generation
 type GenerateContentResult struct {
-	Success  bool   `json:"success"`
-	Content  string `json:"content"`
-	FilePath string `json:"filePath"`
-	Error    string `json:"error"`
+	Success  bool      `json:"success"`
+	Content  string    `json:"content"`
+	FilePath string    `json:"filePath"`
+	Error    string    `json:"error"`
+	Code     ErrorCode `json:"code,omitempty"`
 }
 
 // ContentStructure holds information about the content directory structure
@@ -1949,7 +2165,7 @@ func GetContentStructure(sitePath string) ContentStructure {
 func GenerateContent(params GenerateContentParams) GenerateContentResult {
 	client, _, _, err := ai.LoadClient(ai.LongRequestTimeout)
 	if err != nil {
-		return GenerateContentResult{Error: fmt.Sprintf("failed to load AI client: %v", err)}
+		return GenerateContentResult{Error: fmt.Sprintf("failed to load AI client: %v", err), Code: errorCode(err)}
 	}
 
 	if params.Instructions != "" {
@@ -1965,6 +2181,7 @@ func GenerateContent(params GenerateContentParams) GenerateContentResult {
 			return GenerateContentResult{
 				Success: false,
 				Error:   result.ErrorMessage,
+				Code:    CodeInternal,
 			}
 		}
 
@@ -1978,7 +2195,7 @@ func GenerateContent(params GenerateContentParams) GenerateContentResult {
 		}
 
 		if err := BuildSite(params.SitePath); err != nil {
-			return GenerateContentResult{Error: fmt.Sprintf("failed to build site: %v", err)}
+			return GenerateContentResult{Error: fmt.Sprintf("failed to build site: %v", err), Code: errorCode(err)}
 		}
 
 		return GenerateContentResult{
@@ -2002,17 +2219,17 @@ func GenerateContent(params GenerateContentParams) GenerateContentResult {
 
 	content, err := client.GenerateContent(systemPrompt, userPrompt)
 	if err != nil {
-		return GenerateContentResult{Error: fmt.Sprintf("generating content: %v", err)}
+		return GenerateContentResult{Error: fmt.Sprintf("generating content: %v", err), Code: errorCode(err)}
 	}
 
 	// Apply content fixer to ensure YAML frontmatter is correct (reuse themeName from above)
 	fixer := ai.NewContentFixerWithTheme(params.SitePath, hugo.DetectSiteType(params.SitePath), themeName)
 	if err := fixer.FixAll(); err != nil {
-		return GenerateContentResult{Error: fmt.Sprintf("failed to fix YAML frontmatter: %v", err)}
+		return GenerateContentResult{Error: fmt.Sprintf("failed to fix YAML frontmatter: %v", err), Code: errorCode(err)}
 	}
 
 	if err := BuildSite(params.SitePath); err != nil {
-		return GenerateContentResult{Error: fmt.Sprintf("failed to build site: %v", err)}
+		return GenerateContentResult{Error: fmt.Sprintf("failed to build site: %v", err), Code: errorCode(err)}
 	}
 
 	return GenerateContentResult{
@@ -2030,34 +2247,35 @@ type UpdateContentParams struct {
 
 // UpdateContentResult holds the result of content update
 type UpdateContentResult struct {
-	Success        bool   `json:"success"`
-	UpdatedContent string `json:"updatedContent"`
-	Error          string `json:"error"`
+	Success        bool      `json:"success"`
+	UpdatedContent string    `json:"updatedContent"`
+	Error          string    `json:"error"`
+	Code           ErrorCode `json:"code,omitempty"`
 }
 
 // UpdateContent updates existing content using AI
 func UpdateContent(params UpdateContentParams) UpdateContentResult {
 	client, _, _, err := ai.LoadClient(ai.LongRequestTimeout)
 	if err != nil {
-		return UpdateContentResult{Error: fmt.Sprintf("failed to load AI client: %v", err)}
+		return UpdateContentResult{Error: fmt.Sprintf("failed to load AI client: %v", err), Code: errorCode(err)}
 	}
 
 	existingContent, err := os.ReadFile(params.FilePath)
 	if err != nil {
-		return UpdateContentResult{Error: fmt.Sprintf("reading file: %v", err)}
+		return UpdateContentResult{Error: fmt.Sprintf("reading file: %v", err), Code: errorCode(err)}
 	}
 
 	userPrompt := ai.BuildUpdatePrompt(params.Instructions, string(existingContent))
 
 	updatedContent, err := client.GenerateContent(ai.SystemPromptContentUpdate, userPrompt)
 	if err != nil {
-		return UpdateContentResult{Error: fmt.Sprintf("updating content: %v", err)}
+		return UpdateContentResult{Error: fmt.Sprintf("updating content: %v", err), Code: errorCode(err)}
 	}
 
 	updatedContent = ai.CleanGeneratedContent(updatedContent)
 
 	if err := os.WriteFile(params.FilePath, []byte(updatedContent), 0644); err != nil {
-		return UpdateContentResult{Error: fmt.Sprintf("saving file: %v", err)}
+		return UpdateContentResult{Error: fmt.Sprintf("saving file: %v", err), Code: errorCode(err)}
 	}
 
 	// Apply content fixer to ensure YAML frontmatter is correct
@@ -2066,12 +2284,12 @@ func UpdateContent(params UpdateContentParams) UpdateContentResult {
 		themeName := hugo.GetThemeName(params.SitePath)
 		fixer := ai.NewContentFixerWithTheme(params.SitePath, siteType, themeName)
 		if err := fixer.FixAll(); err != nil {
-			return UpdateContentResult{Error: fmt.Sprintf("failed to fix YAML frontmatter: %v", err)}
+			return UpdateContentResult{Error: fmt.Sprintf("failed to fix YAML frontmatter: %v", err), Code: errorCode(err)}
 		}
 	}
 
 	if err := BuildSite(params.SitePath); err != nil {
-		return UpdateContentResult{Error: fmt.Sprintf("failed to build site: %v", err)}
+		return UpdateContentResult{Error: fmt.Sprintf("failed to build site: %v", err), Code: errorCode(err)}
 	}
 
 	return UpdateContentResult{
@@ -2101,10 +2319,36 @@ type GasEstimateResult struct {
 	Summary   string  `json:"summary"`
 	SiteSize  int64   `json:"siteSize"`
 	FileCount int     `json:"fileCount"`
-	Error     string  `json:"error,omitempty"`
+	// GasDryRun is true when SUI comes from a transaction dry-run rather than the heuristic
+	GasDryRun bool      `json:"gasDryRun"`
+	Error     string    `json:"error,omitempty"`
+	Code      ErrorCode `json:"code,omitempty"`
+}
+
+// siteGasDryRun returns the SUI gas for publishing a site with fileCount files
+// by dry-running it on the active Sui environment; replaced in tests.
+var siteGasDryRun = func(ctx context.Context, network, siteName string, fileCount int) (float64, error) {
+	env, err := sui.GetActiveEnv()
+	if err != nil {
+		return 0, err
+	}
+	if env != network {
+		return 0, fmt.Errorf("active Sui environment is %s, not %s", env, network)
+	}
+	packageID, err := walrus.SitesPackageID(network)
+	if err != nil {
+		return 0, err
+	}
+	mist, err := sui.DryRunSiteTransaction(ctx, sui.SiteTransaction{PackageID: packageID, SiteName: siteName, Resources: fileCount})
+	if err != nil {
+		return 0, err
+	}
+	return float64(mist) / 1e9, nil
 }
 
-// EstimateGasFee estimates the gas fee for deploying a site
+// EstimateGasFee estimates the gas fee for deploying a site. The SUI gas comes
+// from a dry-run of the site transaction when the sui CLI can reach the
+// network, and from the heuristic otherwise.
 func EstimateGasFee(params GasEstimateParams) GasEstimateResult {
 	result := GasEstimateResult{
 		Success: false,
@@ -2114,6 +2358,7 @@ func EstimateGasFee(params GasEstimateParams) GasEstimateResult {
 	walgoCfg, err := config.LoadConfigFrom(params.SitePath)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to load config: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
@@ -2122,6 +2367,7 @@ func EstimateGasFee(params GasEstimateParams) GasEstimateResult {
 	// Check if publish directory exists
 	if _, err := os.Stat(publishDir); os.IsNotExist(err) {
 		result.Error = "publish directory not found. Please build the site first."
+		result.Code = CodeNotFound
 		return result
 	}
 
@@ -2140,6 +2386,7 @@ func EstimateGasFee(params GasEstimateParams) GasEstimateResult {
 		return nil
 	}); err != nil {
 		result.Error = fmt.Sprintf("failed to calculate site size: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
@@ -2147,9 +2394,17 @@ func EstimateGasFee(params GasEstimateParams) GasEstimateResult {
 	costEstimate, err := projects.EstimateGasFeeDetailed(params.Network, siteSize, params.Epochs, fileCount)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to estimate gas: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if gasSUI, err := siteGasDryRun(ctx, params.Network, filepath.Base(params.SitePath), fileCount); err == nil {
+		costEstimate.UseDryRunGas(gasSUI)
+		result.GasDryRun = true
+	}
+
 	result.Success = true
 	result.WAL = costEstimate.WAL
 	result.SUI = costEstimate.SUI
@@ -2184,6 +2439,8 @@ type LaunchWizardParams struct {
 	ImageURL    string `json:"imageUrl"`
 	Epochs      int    `json:"epochs"`
 	SkipConfirm bool   `json:"skipConfirm"`
+	// AllowCustomCategory accepts a Category outside compress.KnownCategories
+	AllowCustomCategory bool `json:"allowCustomCategory,omitempty"`
 }
 
 // LaunchWizardResult holds launch wizard result
@@ -2192,74 +2449,41 @@ type LaunchWizardResult struct {
 	ObjectID string       `json:"objectId"`
 	Steps    []LaunchStep `json:"steps"`
 	Error    string       `json:"error"`
+	Code     ErrorCode    `json:"code,omitempty"`
 }
 
 // LaunchWizard executes full launch wizard flow
 func LaunchWizard(params LaunchWizardParams) LaunchWizardResult {
-	result := LaunchWizardResult{
-		Steps: []LaunchStep{},
-	}
-
-	sitePath := params.SitePath
-	if sitePath == "" {
-		result.Error = "site path is required"
-		return result
-	}
-
-	walgoCfg, err := config.LoadConfigFrom(sitePath)
-	if err != nil {
-		result.Error = fmt.Sprintf("failed to load config: %v", err)
-		return result
-	}
-
-	if err := BuildSite(sitePath); err != nil {
-		result.Error = fmt.Sprintf("failed to build site: %v", err)
-		return result
-	}
-
-	publishDir := filepath.Join(sitePath, walgoCfg.HugoConfig.PublishDir)
-	if _, err := os.Stat(publishDir); os.IsNotExist(err) {
-		result.Error = "publish directory not found. Please build first."
-		return result
-	}
-
-	// Prepare deployment options
-	opts := deployment.DeploymentOptions{
-		SitePath:    sitePath,
-		PublishDir:  publishDir,
-		Epochs:      params.Epochs,
-		WalgoCfg:    walgoCfg,
-		Quiet:       false,
-		Verbose:     true,
-		ForceNew:    false,
-		DryRun:      false,
-		SaveProject: true,
-		ProjectName: params.ProjectName,
-		Category:    params.Category,
-		Network:     params.Network,
-		Description: params.Description,
-		ImageURL:    params.ImageURL,
-	}
-
-	// Perform deployment
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
-	defer cancel()
-
-	deployResult, err := deployment.PerformDeployment(ctx, opts)
-	if err != nil {
-		result.Error = fmt.Sprintf("deployment failed: %v", err)
-		return result
-	}
+	return LaunchWizardWithOutput(params, nil)
+}
+
+// LaunchWizardWithOutput is LaunchWizard with the deploy tool's output
+// streamed to output line by line while it runs. output may be nil. It
+// deploys through DeployWithProgress and records the site as a project.
+func LaunchWizardWithOutput(params LaunchWizardParams, output func(line string)) LaunchWizardResult {
+	deployed := DeployWithProgress(context.Background(), DeployParams{
+		SitePath:            params.SitePath,
+		Epochs:              params.Epochs,
+		Network:             params.Network,
+		ProjectName:         params.ProjectName,
+		Category:            params.Category,
+		Description:         params.Description,
+		ImageURL:            params.ImageURL,
+		SaveProject:         true,
+		AllowCustomCategory: params.AllowCustomCategory,
+	}, func(e ProgressEvent) {
+		if output != nil && e.EventType == "output" {
+			output(e.Message)
+		}
+	})
 
-	if !deployResult.Success {
-		result.Error = "deployment failed"
-		return result
+	return LaunchWizardResult{
+		Success:  deployed.Success,
+		ObjectID: deployed.ObjectID,
+		Steps:    []LaunchStep{},
+		Error:    deployed.Error,
+		Code:     deployed.Code,
 	}
-
-	result.Success = true
-	result.ObjectID = deployResult.ObjectID
-
-	return result
 }
 
 // =============================================================================
@@ -2273,6 +2497,7 @@ type AICreateSiteParams struct {
 	SiteType    string `json:"siteType"` // "blog", "docs"
 	Description string `json:"description,omitempty"`
 	Audience    string `json:"audience,omitempty"`
+	Planner     string `json:"planner,omitempty"` // "ai" (default) or "template"
 }
 
 // AICreateSiteResult holds AI site creation result
@@ -2283,22 +2508,31 @@ type AICreateSiteResult struct {
 	FilesCreated int          `json:"filesCreated"`
 	Steps        []LaunchStep `json:"steps"`
 	Error        string       `json:"error"`
+	Code         ErrorCode    `json:"code,omitempty"`
 }
 
 // AICreateSiteWithProgress creates a site with a custom progress handler (for desktop app).
 // The provided context allows the caller to cancel the pipeline (e.g. on app shutdown).
 func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, progressHandler ProgressHandler) AICreateSiteResult {
+	return AICreateSiteWithPlanner(ctx, params, nil, progressHandler)
+}
+
+// AICreateSiteWithPlanner creates a site whose pages are chosen by planner.
+// A nil planner selects one by params.Planner.
+func AICreateSiteWithPlanner(ctx context.Context, params AICreateSiteParams, planner ai.ContentPlanner, progressHandler ProgressHandler) AICreateSiteResult {
 	result := AICreateSiteResult{}
 
 	// Check Hugo dependency first
 	if _, err := deps.LookPath("hugo"); err != nil {
 		result.Error = "hugo is not installed or not found in PATH"
+		result.Code = CodeToolMissing
 		return result
 	}
 
 	// Validate site name before proceeding
 	if !utils.IsValidSiteName(params.SiteName) {
 		result.Error = "invalid site name: use only letters, numbers, hyphens and underscores"
+		result.Code = CodeValidation
 		return result
 	}
 
@@ -2317,6 +2551,7 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 		defaultDir, err := GetDefaultSitesDir()
 		if err != nil {
 			result.Error = fmt.Sprintf("cannot determine walgo-sites directory: %v", err)
+			result.Code = errorCode(err)
 			return result
 		}
 		parentDir = defaultDir
@@ -2326,6 +2561,7 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 	sitePath, err := filepath.Abs(filepath.Join(parentDir, sanitizedDirName))
 	if err != nil {
 		result.Error = fmt.Sprintf("invalid site path: %v", err)
+		result.Code = CodeValidation
 		return result
 	}
 
@@ -2338,6 +2574,7 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 	// Create the site directory if it doesn't exist
 	if err := os.MkdirAll(sitePath, 0755); err != nil {
 		result.Error = fmt.Sprintf("failed to create site directory: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
@@ -2352,9 +2589,20 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 	client, _, _, err := ai.LoadClient(ai.LongRequestTimeout)
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to load AI client: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
+	pipelineConfig := sitePipelineConfig(sitePath, progressHandler != nil)
+	if planner == nil {
+		planner, err = ai.NewContentPlanner(params.Planner, client, pipelineConfig)
+		if err != nil {
+			result.Error = err.Error()
+			result.Code = errorCode(err)
+			return result
+		}
+	}
+
 	// Map site type string to ai.SiteType
 	var siteType ai.SiteType
 	switch params.SiteType {
@@ -2371,6 +2619,7 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 		fmt.Printf("\nwalgo.yaml not found, creating default configuration...\n")
 		if err := config.CreateDefaultWalgoConfig(sitePath); err != nil {
 			result.Error = fmt.Sprintf("failed to create walgo.yaml: %v", err)
+			result.Code = errorCode(err)
 			return result
 		}
 		fmt.Printf("   Created walgo.yaml configuration\n")
@@ -2382,63 +2631,33 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 
 	if err := hugo.SetupSiteConfigWithName(sitePath, hugoSiteType, originalSiteName); err != nil {
 		result.Error = fmt.Sprintf("failed to set up config: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
 	if err := hugo.SetupArchetypes(sitePath, themeInfo.DirName); err != nil {
 		result.Error = fmt.Sprintf("failed to set up archetypes: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
 	if err := hugo.SetupFaviconForTheme(sitePath, themeInfo.DirName); err != nil {
 		result.Error = fmt.Sprintf("failed to set up favicon: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 	if err := hugo.InstallTheme(sitePath, hugoSiteType); err != nil {
 		result.Error = fmt.Sprintf("failed to install theme: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
 	if hugoSiteType == hugo.SiteTypeDocs {
 		if err := hugo.SetupDocsThemeOverrides(sitePath); err != nil {
-			return AICreateSiteResult{Error: fmt.Sprintf("failed to set up theme overrides: %v", err)}
+			return AICreateSiteResult{Error: fmt.Sprintf("failed to set up theme overrides: %v", err), Code: errorCode(err)}
 		}
 	}
 
-	// Run AI pipeline with absolute paths to ensure content is created in the correct location
-	pipelineConfig := ai.DefaultPipelineConfig()
-	pipelineConfig.ContentDir = filepath.Join(sitePath, "content")
-	pipelineConfig.PlanPath = filepath.Join(sitePath, ".walgo", "plan.json")
-
-	// Desktop app (progressHandler != nil) uses sequential mode for reliable
-	// progress tracking and to avoid rate-limit storms on typical API keys.
-	if progressHandler != nil {
-		pipelineConfig.ParallelMode = ai.ParallelModeSequential
-	}
-
-	pipeline := ai.NewPipeline(client, pipelineConfig)
-
-	// Use custom progress handler if provided, otherwise use console handler
-	if progressHandler != nil {
-		// Convert our public ProgressHandler to internal ai.ProgressHandler
-		internalHandler := func(event ai.ProgressEvent) {
-			// Convert internal event to public event
-			publicEvent := ProgressEvent{
-				Phase:     string(event.Phase),
-				EventType: string(event.EventType),
-				Message:   event.Message,
-				PagePath:  event.PagePath,
-				Progress:  event.Progress,
-				Current:   event.Current,
-				Total:     event.Total,
-			}
-			progressHandler(publicEvent)
-		}
-		pipeline.SetProgressHandler(internalHandler)
-	} else {
-		pipeline.SetProgressHandler(ai.ConsoleProgressHandler(false))
-	}
-
 	input := &ai.PlannerInput{
 		SiteName:    originalSiteName, // Use original name for AI content
 		SiteType:    siteType,
@@ -2448,23 +2667,14 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 		SitePath:    sitePath, // For dynamic theme analysis
 	}
 
-	pipelineResult, err := pipeline.Run(ctx, input)
+	pipelineResult, err := runContentPipeline(ctx, client, pipelineConfig, planner, input, progressHandler)
 	if err != nil {
-		result.Error = fmt.Sprintf("pipeline error: %v", err)
+		result.Error = err.Error()
+		result.Code = errorCode(err)
 		return result
 	}
 
 	if pipelineResult.Plan != nil {
-		hugoTomlPath := filepath.Join(sitePath, "hugo.toml")
-		if _, err := os.Stat(hugoTomlPath); os.IsNotExist(err) {
-			hugoTomlPath = filepath.Join(sitePath, "config.toml")
-		}
-		if _, err := os.Stat(hugoTomlPath); err == nil {
-			fmt.Printf("Updating Hugo menu configuration...\n")
-			if err := hugo.ApplyMenuFromSitePlan(pipelineResult.Plan, hugoTomlPath); err != nil {
-				return AICreateSiteResult{Error: fmt.Sprintf("failed to apply menu: %v", err)}
-			}
-		}
 		// Get theme name for dynamic theme support
 		themeName := pipelineResult.Plan.Theme
 		if themeName == "" {
@@ -2476,7 +2686,7 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 			fmt.Printf("\nValidating and fixing content for theme...\n")
 			fixer := ai.NewContentFixerWithTheme(sitePath, siteType, themeName)
 			if err := fixer.FixAll(); err != nil {
-				return AICreateSiteResult{Error: fmt.Sprintf("failed to fix content: %v", err)}
+				return AICreateSiteResult{Error: fmt.Sprintf("failed to fix content: %v", err), Code: errorCode(err)}
 			} else {
 				fmt.Printf("Content validated and fixed\n")
 			}
@@ -2491,13 +2701,13 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 
 		case ai.SiteTypeDocs:
 			if err := hugo.UpdateDocsParams(sitePath, pipelineResult.Plan.Description); err != nil {
-				return AICreateSiteResult{Error: fmt.Sprintf("failed to update docs params: %v", err)}
+				return AICreateSiteResult{Error: fmt.Sprintf("failed to update docs params: %v", err), Code: errorCode(err)}
 			}
 
 			fmt.Printf("\nValidating and fixing content for theme...\n")
 			fixer := ai.NewContentFixerWithTheme(sitePath, siteType, themeName)
 			if err := fixer.FixAll(); err != nil {
-				return AICreateSiteResult{Error: fmt.Sprintf("failed to fix content: %v", err)}
+				return AICreateSiteResult{Error: fmt.Sprintf("failed to fix content: %v", err), Code: errorCode(err)}
 			} else {
 				fmt.Printf("Content validated and fixed\n")
 			}
@@ -2514,7 +2724,7 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 	}
 
 	if err := BuildSite(sitePath); err != nil {
-		return AICreateSiteResult{Error: fmt.Sprintf("failed to build site: %v", err)}
+		return AICreateSiteResult{Error: fmt.Sprintf("failed to build site: %v", err), Code: errorCode(err)}
 	}
 
 	result.Success = true
@@ -2529,6 +2739,7 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 	// Save as draft project using ORIGINAL name
 	if err := saveDraftProject(originalSiteName, sitePath); err != nil {
 		result.Error = fmt.Sprintf("failed to save draft project: %v", err)
+		result.Code = errorCode(err)
 		return result
 	}
 
@@ -2536,6 +2747,69 @@ func AICreateSiteWithProgress(ctx context.Context, params AICreateSiteParams, pr
 	return result
 }
 
+// sitePipelineConfig returns the pipeline configuration for a site, using
+// absolute paths so content is created in the correct location.
+func sitePipelineConfig(sitePath string, sequential bool) ai.PipelineConfig {
+	pipelineConfig := ai.DefaultPipelineConfig()
+	pipelineConfig.ContentDir = filepath.Join(sitePath, "content")
+	pipelineConfig.PlanPath = filepath.Join(sitePath, ".walgo", "plan.json")
+
+	// Desktop app uses sequential mode for reliable progress tracking
+	// and to avoid rate-limit storms on typical API keys.
+	if sequential {
+		pipelineConfig.ParallelMode = ai.ParallelModeSequential
+	}
+	return pipelineConfig
+}
+
+// runContentPipeline plans the site with planner, generates every planned page,
+// and adds the planned pages to the Hugo menu.
+func runContentPipeline(ctx context.Context, client *ai.Client, pipelineConfig ai.PipelineConfig, planner ai.ContentPlanner, input *ai.PlannerInput, progressHandler ProgressHandler) (*ai.PipelineResult, error) {
+	pipeline := ai.NewPipeline(client, pipelineConfig)
+	pipeline.SetContentPlanner(planner)
+
+	// Use custom progress handler if provided, otherwise use console handler
+	if progressHandler != nil {
+		// Convert our public ProgressHandler to internal ai.ProgressHandler
+		internalHandler := func(event ai.ProgressEvent) {
+			// Convert internal event to public event
+			publicEvent := ProgressEvent{
+				Phase:     string(event.Phase),
+				EventType: string(event.EventType),
+				Message:   event.Message,
+				PagePath:  event.PagePath,
+				Progress:  event.Progress,
+				Current:   event.Current,
+				Total:     event.Total,
+			}
+			progressHandler(publicEvent)
+		}
+		pipeline.SetProgressHandler(internalHandler)
+	} else {
+		pipeline.SetProgressHandler(ai.ConsoleProgressHandler(false))
+	}
+
+	pipelineResult, err := pipeline.Run(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline error: %w", err)
+	}
+
+	if pipelineResult.Plan != nil {
+		hugoTomlPath := filepath.Join(input.SitePath, "hugo.toml")
+		if _, err := os.Stat(hugoTomlPath); os.IsNotExist(err) {
+			hugoTomlPath = filepath.Join(input.SitePath, "config.toml")
+		}
+		if _, err := os.Stat(hugoTomlPath); err == nil {
+			fmt.Printf("Updating Hugo menu configuration...\n")
+			if err := hugo.ApplyMenuFromSitePlan(pipelineResult.Plan, hugoTomlPath); err != nil {
+				return nil, fmt.Errorf("failed to apply menu: %w", err)
+			}
+		}
+	}
+
+	return pipelineResult, nil
+}
+
 // AICreateSite creates a complete Hugo site with AI-generated content (CLI version with console output)
 func AICreateSite(params AICreateSiteParams) AICreateSiteResult {
 	return AICreateSiteWithProgress(context.Background(), params, nil)
@@ -2547,9 +2821,10 @@ func AICreateSite(params AICreateSiteParams) AICreateSiteResult {
 
 // SetupDepsResult holds setup dependencies result
 type SetupDepsResult struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Error   string `json:"error"`
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Error   string    `json:"error"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
 
 // CheckSetupDeps checks if all required dependencies are installed
@@ -2563,6 +2838,7 @@ func CheckSetupDeps() SetupDepsResult {
 		result.Success = false
 		result.Message = fmt.Sprintf("missing required tools: %s", strings.Join(missingTools, ", "))
 		result.Error = deps.InstallInstructions("testnet")
+		result.Code = CodeToolMissing
 		return result
 	}
 
@@ -2582,9 +2858,12 @@ func GetSystemHealth() SystemHealth {
 		Message:         "Checking...",
 	}
 
-	// Check network connectivity with timeout
-	// Use multiple endpoints to avoid rate limiting and increase reliability
-	health.NetOnline = checkNetworkConnectivity()
+	// Check that the active network's Sui RPC and Walrus endpoints answer
+	network, _ := sui.GetActiveEnv()
+	if network == "" {
+		network = "testnet"
+	}
+	health.NetOnline = netcheck.IsOnline(context.Background(), walrus.NetworkEndpoints(network, config.GatewayConfig{}))
 
 	// Check Sui CLI
 	if _, err := deps.LookPath("sui"); err == nil {
@@ -2649,6 +2928,7 @@ type CheckToolVersionsResult struct {
 	Tools   []ToolVersionInfo `json:"tools"`
 	Message string            `json:"message"`
 	Error   string            `json:"error,omitempty"`
+	Code    ErrorCode         `json:"code,omitempty"`
 }
 
 // CheckToolVersions checks if installed tools have updates available
@@ -2664,6 +2944,7 @@ func CheckToolVersions() CheckToolVersionsResult {
 	if err != nil {
 		result.Success = false
 		result.Error = err.Error()
+		result.Code = errorCode(err)
 		result.Message = "Failed to check versions"
 		return result
 	}
@@ -1,10 +1,19 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/selimozten/walgo/internal/ai"
+	"github.com/selimozten/walgo/internal/projects"
 )
 
 // =============================================================================
@@ -177,6 +186,47 @@ func TestInstallTheme_Validation(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// UpdateAIConfig Model Validation Tests
+// =============================================================================
+
+func TestUpdateAIConfig_ModelValidation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name    string
+		params  AIConfigureParams
+		wantErr bool
+	}{
+		{
+			name:   "known model",
+			params: AIConfigureParams{Provider: "openai", APIKey: "test-key", Model: "gpt-4o"},
+		},
+		{
+			name:    "unknown model without force",
+			params:  AIConfigureParams{Provider: "openai", APIKey: "test-key", Model: "gpt-99"},
+			wantErr: true,
+		},
+		{
+			name:   "unknown model with force",
+			params: AIConfigureParams{Provider: "openai", APIKey: "test-key", Model: "gpt-99", Force: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := UpdateAIConfig(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateAIConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// Library and desktop callers have no --force flag to point at
+			if err != nil && (strings.Contains(err.Error(), "--force") || !strings.Contains(err.Error(), "allow unlisted models")) {
+				t.Errorf("expected error to suggest allowing unlisted models, got %q", err.Error())
+			}
+		})
+	}
+}
+
 // =============================================================================
 // GetInstalledThemes Validation Tests
 // =============================================================================
@@ -262,6 +312,9 @@ func TestNewContent_Validation(t *testing.T) {
 			if result.Error != tt.wantError {
 				t.Errorf("NewContent() error = %q, want %q", result.Error, tt.wantError)
 			}
+			if result.Code != CodeValidation {
+				t.Errorf("NewContent() code = %q, want %q", result.Code, CodeValidation)
+			}
 		})
 	}
 }
@@ -1145,9 +1198,46 @@ func TestServe_Validation(t *testing.T) {
 		if result.Error != "site path is required" {
 			t.Errorf("Serve() error = %q, want %q", result.Error, "site path is required")
 		}
+		if result.Code != CodeValidation {
+			t.Errorf("Serve() code = %q, want %q", result.Code, CodeValidation)
+		}
+	})
+
+	t.Run("invalid bind returns validation", func(t *testing.T) {
+		result := Serve(ServeParams{SitePath: t.TempDir(), Bind: "not-an-ip"})
+		if result.Success {
+			t.Fatal("expected failure for invalid bind, got success")
+		}
+		if result.Code != CodeValidation {
+			t.Errorf("Serve() code = %q, want %q (error: %s)", result.Code, CodeValidation, result.Error)
+		}
+	})
+
+	t.Run("missing hugo returns tool_missing", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		t.Setenv("PATH", t.TempDir())
+		result := Serve(ServeParams{SitePath: t.TempDir()})
+		if result.Success {
+			t.Fatal("expected failure without hugo, got success")
+		}
+		if result.Code != CodeToolMissing {
+			t.Errorf("Serve() code = %q, want %q (error: %s)", result.Code, CodeToolMissing, result.Error)
+		}
 	})
 }
 
+func TestQuickStart_ToolMissing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("PATH", t.TempDir())
+	result := QuickStart(QuickStartParams{SiteName: "my-site"})
+	if result.Success {
+		t.Fatal("expected failure without hugo, got success")
+	}
+	if result.Code != CodeToolMissing {
+		t.Errorf("QuickStart() code = %q, want %q (error: %s)", result.Code, CodeToolMissing, result.Error)
+	}
+}
+
 // =============================================================================
 // LaunchWizard Validation Tests
 // =============================================================================
@@ -1889,3 +1979,276 @@ func TestLaunchWizardParams_JSON(t *testing.T) {
 		t.Error("SkipConfirm should be true")
 	}
 }
+
+// =============================================================================
+// AI Create Site Content Planner Tests
+// =============================================================================
+
+// fakeContentPlanner returns a fixed page list without calling an AI provider.
+type fakeContentPlanner struct {
+	pages []ai.PlannedPage
+}
+
+func (f fakeContentPlanner) Plan(ctx context.Context, spec ai.SiteSpec) ([]ai.PlannedPage, error) {
+	return f.pages, nil
+}
+
+func TestRunContentPipeline_UsesPlannerOutput(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"---\ntitle: Generated\ndraft: false\n---\nBody"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	sitePath := t.TempDir()
+	hugoToml := filepath.Join(sitePath, "hugo.toml")
+	if err := os.WriteFile(hugoToml, []byte("title = \"Demo\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	planner := fakeContentPlanner{pages: []ai.PlannedPage{
+		{ID: "home", Path: "content/_index.md", Title: "Home"},
+		{ID: "about", Path: "content/about.md", Title: "About"},
+		{ID: "posts", Path: "content/posts/_index.md", Title: "Posts"},
+		{ID: "hello", Path: "content/posts/hello/index.md", Title: "Hello"},
+	}}
+	input := &ai.PlannerInput{SiteName: "Demo", SiteType: ai.SiteTypeBlog, SitePath: sitePath}
+	client := ai.NewClient("openai", "test-key", server.URL, "gpt-4")
+
+	result, err := runContentPipeline(context.Background(), client, sitePipelineConfig(sitePath, true), planner, input, func(ProgressEvent) {})
+	if err != nil {
+		t.Fatalf("runContentPipeline failed: %v", err)
+	}
+	if len(result.Plan.Pages) != 4 {
+		t.Fatalf("expected the planner's 4 pages, got %d", len(result.Plan.Pages))
+	}
+
+	for _, rel := range []string{"_index.md", "about.md", filepath.Join("posts", "hello", "index.md")} {
+		if _, err := os.Stat(filepath.Join(sitePath, "content", rel)); err != nil {
+			t.Errorf("expected planned page %s to be generated: %v", rel, err)
+		}
+	}
+
+	menu, err := os.ReadFile(hugoToml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range []string{"/posts", "/about"} {
+		if !strings.Contains(string(menu), ref) {
+			t.Errorf("expected menu entry for %s in hugo.toml:\n%s", ref, menu)
+		}
+	}
+}
+
+// gasEstimateSite creates a built site with two files for EstimateGasFee.
+func gasEstimateSite(t *testing.T) string {
+	t.Helper()
+	sitePath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(sitePath, "walgo.yaml"), []byte("hugo:\n  publishDir: public\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	publicDir := filepath.Join(sitePath, "public")
+	if err := os.MkdirAll(publicDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"index.html", "style.css"} {
+		if err := os.WriteFile(filepath.Join(publicDir, name), []byte(strings.Repeat("x", 2048)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return sitePath
+}
+
+func TestEstimateGasFee_DryRunGas(t *testing.T) {
+	sitePath := gasEstimateSite(t)
+	orig := siteGasDryRun
+	defer func() { siteGasDryRun = orig }()
+
+	var gotFiles int
+	siteGasDryRun = func(_ context.Context, network, _ string, fileCount int) (float64, error) {
+		gotFiles = fileCount
+		return 0.0042, nil
+	}
+
+	result := EstimateGasFee(GasEstimateParams{SitePath: sitePath, Network: "testnet", Epochs: 1})
+	if !result.Success {
+		t.Fatalf("EstimateGasFee() error = %s", result.Error)
+	}
+	if !result.GasDryRun || result.SUI != 0.0042 {
+		t.Errorf("SUI = %v (dry-run %v), want 0.0042 from dry-run", result.SUI, result.GasDryRun)
+	}
+	if gotFiles != 2 {
+		t.Errorf("dry-run file count = %d, want 2", gotFiles)
+	}
+	if !strings.Contains(result.Summary, "dry-run") {
+		t.Errorf("summary should mention the dry-run, got %q", result.Summary)
+	}
+}
+
+func TestEstimateGasFee_OfflineFallback(t *testing.T) {
+	sitePath := gasEstimateSite(t)
+	orig := siteGasDryRun
+	defer func() { siteGasDryRun = orig }()
+	siteGasDryRun = func(context.Context, string, string, int) (float64, error) {
+		return 0, fmt.Errorf("sui not reachable")
+	}
+
+	result := EstimateGasFee(GasEstimateParams{SitePath: sitePath, Network: "testnet", Epochs: 1})
+	if !result.Success {
+		t.Fatalf("EstimateGasFee() error = %s", result.Error)
+	}
+	if result.GasDryRun {
+		t.Error("GasDryRun should be false when the dry-run fails")
+	}
+	if result.SUI <= 0 {
+		t.Errorf("SUI = %v, want the heuristic estimate", result.SUI)
+	}
+}
+
+// =============================================================================
+// Pagination Tests
+// =============================================================================
+
+// seedProjects creates n projects in a fresh projects database and records
+// deployments deploys for the first one, returning that project's ID.
+func seedProjects(t *testing.T, n, deploys int) int64 {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	pm, err := projects.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Close()
+
+	var firstID int64
+	for i := 0; i < n; i++ {
+		network := "testnet"
+		if i%2 == 1 {
+			network = "mainnet"
+		}
+		p := &projects.Project{Name: fmt.Sprintf("site-%d", i), Network: network, SitePath: fmt.Sprintf("/tmp/site-%d", i)}
+		if err := pm.CreateProject(p); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			firstID = p.ID
+		}
+	}
+	for i := 0; i < deploys; i++ {
+		d := &projects.DeploymentRecord{ProjectID: firstID, ObjectID: fmt.Sprintf("0x%d", i), Network: "testnet", Epochs: 1, Success: true}
+		if err := pm.RecordDeployment(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return firstID
+}
+
+func TestListProjectsPage(t *testing.T) {
+	seedProjects(t, 5, 0)
+
+	all, err := ListProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paged []string
+	for offset := 0; offset < len(all); offset += 2 {
+		page, err := ListProjectsPage(offset, 2, ProjectListFilter{})
+		if err != nil {
+			t.Fatalf("ListProjectsPage(%d, 2) failed: %v", offset, err)
+		}
+		if page.Total != 5 || page.Offset != offset || page.Limit != 2 {
+			t.Errorf("page at %d = total %d, offset %d, limit %d", offset, page.Total, page.Offset, page.Limit)
+		}
+		if want := min(2, len(all)-offset); len(page.Projects) != want {
+			t.Errorf("page at %d has %d projects, want %d", offset, len(page.Projects), want)
+		}
+		for _, p := range page.Projects {
+			paged = append(paged, p.Name)
+		}
+	}
+	var want []string
+	for _, p := range all {
+		want = append(want, p.Name)
+	}
+	if strings.Join(paged, ",") != strings.Join(want, ",") {
+		t.Errorf("paged projects = %v, want ListProjects order %v", paged, want)
+	}
+
+	page, err := ListProjectsPage(5, 2, ProjectListFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Projects) != 0 || page.Total != 5 {
+		t.Errorf("offset past the end = %d projects, total %d; want none, 5", len(page.Projects), page.Total)
+	}
+	if data, _ := json.Marshal(page); !strings.Contains(string(data), `"projects":[]`) {
+		t.Errorf("empty page should encode an empty list, got %s", data)
+	}
+
+	page, err = ListProjectsPage(0, 10, ProjectListFilter{Network: "mainnet"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 2 || len(page.Projects) != 2 {
+		t.Errorf("mainnet page = %d projects, total %d; want 2, 2", len(page.Projects), page.Total)
+	}
+
+	if _, err := ListProjectsPage(0, 0, ProjectListFilter{}); err == nil {
+		t.Error("zero limit should fail")
+	}
+}
+
+func TestListDeployments(t *testing.T) {
+	projectID := seedProjects(t, 1, 4)
+
+	page, err := ListDeployments(projectID, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 4 || len(page.Deployments) != 3 {
+		t.Fatalf("first page = %d deployments, total %d; want 3, 4", len(page.Deployments), page.Total)
+	}
+	if page.Deployments[0].ObjectID != "0x3" || page.Deployments[0].ProjectID != projectID {
+		t.Errorf("first deployment = %+v, want the newest (0x3)", page.Deployments[0])
+	}
+
+	page, err = ListDeployments(projectID, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Deployments) != 1 || page.Deployments[0].ObjectID != "0x0" {
+		t.Errorf("last page = %+v, want only the oldest (0x0)", page.Deployments)
+	}
+
+	page, err = ListDeployments(projectID, 10, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Deployments) != 0 || page.Total != 4 {
+		t.Errorf("offset past the end = %d deployments, total %d; want none, 4", len(page.Deployments), page.Total)
+	}
+
+	if _, err := ListDeployments(projectID, -1, 3); err == nil {
+		t.Error("negative offset should fail")
+	}
+}
+
+func TestEditProjectCustomCategory(t *testing.T) {
+	id := seedProjects(t, 1, 0)
+
+	res := EditProject(EditProjectParams{ProjectID: id, Category: "Photography Portfolio"})
+	if res.Success || res.Code != CodeValidation {
+		t.Errorf("unknown category without AllowCustomCategory = %+v, want a validation error", res)
+	}
+
+	res = EditProject(EditProjectParams{ProjectID: id, Category: "Photography Portfolio", AllowCustomCategory: true})
+	if !res.Success {
+		t.Fatalf("EditProject() = %+v", res)
+	}
+	got, err := GetProject(id)
+	if err != nil || got.Category != "Photography Portfolio" {
+		t.Errorf("category not saved: %+v, %v", got, err)
+	}
+}
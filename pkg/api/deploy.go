@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/selimozten/walgo/internal/config"
+	"github.com/selimozten/walgo/internal/deployment"
+	"github.com/selimozten/walgo/internal/hugo"
+)
+
+// Test hooks for the build and deploy steps of Deploy.
+var (
+	deployBuildSite         = hugo.BuildSite
+	deployPerformDeployment = deployment.PerformDeployment
+)
+
+// Deploy progress phases reported through ProgressHandler.
+const (
+	DeployPhaseBuild    = "build"
+	DeployPhaseDeploy   = "deploy"
+	DeployPhaseComplete = "complete"
+)
+
+// DeployParams holds deploy parameters
+type DeployParams struct {
+	SitePath    string `json:"sitePath"`
+	Epochs      int    `json:"epochs,omitempty"`  // Default: 1
+	Network     string `json:"network,omitempty"` // Default: the active Sui network
+	ProjectName string `json:"projectName,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"imageUrl,omitempty"`
+	ForceNew    bool   `json:"forceNew,omitempty"`    // Deploy a new site even if one exists
+	DryRun      bool   `json:"dryRun,omitempty"`      // Plan the deploy without uploading
+	SaveProject bool   `json:"saveProject,omitempty"` // Record the deploy in the projects database
+	SkipBuild   bool   `json:"skipBuild,omitempty"`   // Deploy the publish directory as it is
+	// AllowCustomCategory accepts a Category outside compress.KnownCategories
+	AllowCustomCategory bool `json:"allowCustomCategory,omitempty"`
+}
+
+// DeployResult holds deploy result
+type DeployResult struct {
+	Success           bool             `json:"success"`
+	ObjectID          string           `json:"objectId"`
+	IsUpdate          bool             `json:"isUpdate"`
+	IsNewProject      bool             `json:"isNewProject"`
+	DryRun            bool             `json:"dryRun,omitempty"`
+	Network           string           `json:"network,omitempty"`
+	PortalURL         string           `json:"portalUrl,omitempty"`
+	Epochs            int              `json:"epochs"`
+	SiteSize          int64            `json:"siteSize"`
+	TotalFiles        int              `json:"totalFiles"`
+	FilesUploaded     int              `json:"filesUploaded"`
+	FilesSkipped      int              `json:"filesSkipped"`
+	CostWAL           float64          `json:"costWal,omitempty"`
+	CostSUI           float64          `json:"costSui,omitempty"`
+	EstimatedCost     string           `json:"estimatedCost,omitempty"`
+	TransactionDigest string           `json:"transactionDigest,omitempty"`
+	TimingsMs         map[string]int64 `json:"timingsMs,omitempty"` // Per deploy phase, see deployment.TimingPhases
+	CompletedAt       string           `json:"completedAt,omitempty"`
+	Error             string           `json:"error"`
+	Code              ErrorCode        `json:"code,omitempty"`
+}
+
+// Deploy builds the site at params.SitePath and deploys it to Walrus Sites,
+// updating the existing site when one is recorded.
+func Deploy(params DeployParams) DeployResult {
+	return DeployWithProgress(context.Background(), params, nil)
+}
+
+// DeployWithProgress is Deploy with progress reported to progress, which may
+// be nil: a start event for each phase, and the deploy tool's output line by
+// line as "output" events of the deploy phase. The deploy tool's output is not
+// printed to stdout, but the Hugo build still prints its own progress there.
+// The deploy runs until it finishes or ctx is done.
+func DeployWithProgress(ctx context.Context, params DeployParams, progress ProgressHandler) DeployResult {
+	var result DeployResult
+	emit := func(phase, eventType, message string, pct float64) {
+		if progress != nil {
+			progress(ProgressEvent{Phase: phase, EventType: eventType, Message: message, Progress: pct})
+		}
+	}
+
+	if params.SitePath == "" {
+		result.Error = "site path is required"
+		result.Code = CodeValidation
+		return result
+	}
+	if params.Epochs < 0 || params.Epochs > config.MaxEpochs {
+		result.Error = fmt.Sprintf("epochs must be between 1 and %d", config.MaxEpochs)
+		result.Code = CodeValidation
+		return result
+	}
+
+	walgoCfg, err := config.LoadConfigFrom(params.SitePath)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to load config: %v", err)
+		result.Code = errorCode(err)
+		return result
+	}
+
+	epochs := params.Epochs
+	if epochs == 0 {
+		epochs = 1
+	}
+
+	if !params.SkipBuild {
+		emit(DeployPhaseBuild, "start", "Building site", 0)
+		if err := deployBuildSite(params.SitePath); err != nil {
+			result.Error = fmt.Sprintf("failed to build site: %v", err)
+			result.Code = errorCode(err)
+			return result
+		}
+	}
+
+	publishDir := filepath.Join(params.SitePath, walgoCfg.HugoConfig.PublishDir)
+	if _, err := os.Stat(publishDir); os.IsNotExist(err) {
+		result.Error = "publish directory not found. Please build first."
+		result.Code = CodeNotFound
+		return result
+	}
+
+	emit(DeployPhaseDeploy, "start", "Deploying to Walrus Sites", 0.1)
+	opts := deployment.DeploymentOptions{
+		SitePath:    params.SitePath,
+		PublishDir:  publishDir,
+		Epochs:      epochs,
+		WalgoCfg:    walgoCfg,
+		Quiet:       true,
+		ForceNew:    params.ForceNew,
+		DryRun:      params.DryRun,
+		SaveProject: params.SaveProject,
+		ProjectName: params.ProjectName,
+		Category:    params.Category,
+		Network:     params.Network,
+		Description: params.Description,
+		ImageURL:    params.ImageURL,
+		OutputLine: func(line string) {
+			emit(DeployPhaseDeploy, "output", line, 0.5)
+		},
+		AllowCustomCategory: params.AllowCustomCategory,
+	}
+
+	deployResult, err := deployPerformDeployment(ctx, opts)
+	if err != nil {
+		result.Error = fmt.Sprintf("deployment failed: %v", err)
+		result.Code = errorCode(err)
+		return result
+	}
+	if deployResult == nil || !deployResult.Success {
+		result.Error = "deployment failed"
+		result.Code = CodeInternal
+		return result
+	}
+
+	result = deployResultFrom(deployResult)
+	result.DryRun = params.DryRun
+	emit(DeployPhaseComplete, "complete", "Deployment complete", 1)
+	return result
+}
+
+// deployResultFrom maps a successful deployment to its JSON-friendly result.
+func deployResultFrom(r *deployment.DeploymentResult) DeployResult {
+	result := DeployResult{
+		Success:           r.Success,
+		ObjectID:          r.ObjectID,
+		IsUpdate:          r.IsUpdate,
+		IsNewProject:      r.IsNewProject,
+		Network:           r.Network,
+		PortalURL:         r.PortalURL,
+		Epochs:            r.Epochs,
+		SiteSize:          r.SiteSize,
+		TotalFiles:        r.TotalFiles,
+		FilesUploaded:     r.FilesUploaded,
+		FilesSkipped:      r.FilesSkipped,
+		CostWAL:           r.ActualWAL,
+		CostSUI:           r.ActualGasSUI,
+		EstimatedCost:     r.EstimatedCost,
+		TransactionDigest: r.TransactionDigest,
+	}
+	if len(r.Timings) > 0 {
+		result.TimingsMs = make(map[string]int64, len(r.Timings))
+		for phase, d := range r.Timings {
+			result.TimingsMs[phase] = d.Milliseconds()
+		}
+	}
+	if !r.CompletedAt.IsZero() {
+		result.CompletedAt = r.CompletedAt.Format(time.RFC3339)
+	}
+	return result
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selimozten/walgo/internal/deployment"
+)
+
+// writeDeploySite creates a built site publishing from public/.
+func writeDeploySite(t *testing.T) string {
+	t.Helper()
+	sitePath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(sitePath, "public"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "hugo:\n  publishDir: public\n"
+	if err := os.WriteFile(filepath.Join(sitePath, "walgo.yaml"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return sitePath
+}
+
+func stubDeployment(t *testing.T, perform func(context.Context, deployment.DeploymentOptions) (*deployment.DeploymentResult, error)) {
+	t.Helper()
+	origBuild, origPerform := deployBuildSite, deployPerformDeployment
+	t.Cleanup(func() { deployBuildSite, deployPerformDeployment = origBuild, origPerform })
+	deployBuildSite = func(string) error { return nil }
+	deployPerformDeployment = perform
+}
+
+func TestDeploy_Validation(t *testing.T) {
+	stubDeployment(t, func(context.Context, deployment.DeploymentOptions) (*deployment.DeploymentResult, error) {
+		t.Fatal("nothing should be deployed for invalid params")
+		return nil, nil
+	})
+
+	result := Deploy(DeployParams{})
+	if result.Success || result.Error != "site path is required" || result.Code != CodeValidation {
+		t.Errorf("Deploy() without site path = %+v", result)
+	}
+
+	result = Deploy(DeployParams{SitePath: writeDeploySite(t), Epochs: 54})
+	if result.Success || result.Code != CodeValidation {
+		t.Errorf("Deploy() with 54 epochs = %+v, want a validation error", result)
+	}
+}
+
+func TestDeploy_MapsResult(t *testing.T) {
+	sitePath := writeDeploySite(t)
+	completed := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	var got deployment.DeploymentOptions
+	stubDeployment(t, func(ctx context.Context, opts deployment.DeploymentOptions) (*deployment.DeploymentResult, error) {
+		got = opts
+		opts.OutputLine("Uploading 3 files")
+		return &deployment.DeploymentResult{
+			Success:           true,
+			ObjectID:          "0xsite",
+			IsUpdate:          true,
+			Network:           "testnet",
+			PortalURL:         "https://site.example",
+			Epochs:            3,
+			SiteSize:          2048,
+			TotalFiles:        3,
+			FilesUploaded:     1,
+			FilesSkipped:      2,
+			ActualWAL:         0.25,
+			ActualGasSUI:      0.01,
+			TransactionDigest: "7xDigest",
+			CompletedAt:       completed,
+			Timings:           map[string]time.Duration{deployment.PhaseUpload: 1500 * time.Millisecond},
+		}, nil
+	})
+
+	var events []ProgressEvent
+	result := DeployWithProgress(context.Background(), DeployParams{SitePath: sitePath, ProjectName: "blog", Category: "blog"}, func(e ProgressEvent) {
+		events = append(events, e)
+	})
+	if !result.Success {
+		t.Fatalf("Deploy() = %+v", result)
+	}
+
+	if got.PublishDir != filepath.Join(sitePath, "public") || got.Epochs != 1 || got.Category != "blog" || got.ProjectName != "blog" {
+		t.Errorf("options = %+v, want the params and one epoch", got)
+	}
+	if !got.Quiet {
+		t.Error("library deploys should not print to stdout")
+	}
+	if result.ObjectID != "0xsite" || !result.IsUpdate || result.PortalURL != "https://site.example" || result.Network != "testnet" {
+		t.Errorf("result = %+v", result)
+	}
+	if result.SiteSize != 2048 || result.TotalFiles != 3 || result.FilesUploaded != 1 || result.FilesSkipped != 2 {
+		t.Errorf("file counts = %+v", result)
+	}
+	if result.CostWAL != 0.25 || result.CostSUI != 0.01 || result.TransactionDigest != "7xDigest" {
+		t.Errorf("costs = %+v", result)
+	}
+	if result.TimingsMs[deployment.PhaseUpload] != 1500 {
+		t.Errorf("TimingsMs = %v", result.TimingsMs)
+	}
+	if result.CompletedAt != "2026-10-15T12:00:00Z" {
+		t.Errorf("CompletedAt = %q", result.CompletedAt)
+	}
+
+	var phases []string
+	for _, e := range events {
+		phases = append(phases, e.Phase+"/"+e.EventType)
+	}
+	want := []string{"build/start", "deploy/start", "deploy/output", "complete/complete"}
+	if len(phases) != len(want) {
+		t.Fatalf("events = %v, want %v", phases, want)
+	}
+	for i := range want {
+		if phases[i] != want[i] {
+			t.Errorf("events = %v, want %v", phases, want)
+			break
+		}
+	}
+	if events[2].Message != "Uploading 3 files" {
+		t.Errorf("output event = %+v", events[2])
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["objectId"] != "0xsite" || decoded["filesUploaded"] != float64(1) {
+		t.Errorf("JSON = %s", data)
+	}
+}
+
+func TestDeploy_Failure(t *testing.T) {
+	sitePath := writeDeploySite(t)
+	stubDeployment(t, func(context.Context, deployment.DeploymentOptions) (*deployment.DeploymentResult, error) {
+		return &deployment.DeploymentResult{}, errors.New("site-builder: insufficient funds")
+	})
+
+	result := Deploy(DeployParams{SitePath: sitePath, SkipBuild: true})
+	if result.Success || result.Code != CodeInsufficientFunds || result.ObjectID != "" {
+		t.Errorf("Deploy() = %+v, want an insufficient funds failure", result)
+	}
+}
+
+func TestDeploy_UsesCallerContext(t *testing.T) {
+	sitePath := writeDeploySite(t)
+	stubDeployment(t, func(ctx context.Context, _ deployment.DeploymentOptions) (*deployment.DeploymentResult, error) {
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("the deploy should not get a deadline the caller did not set")
+		}
+		return nil, ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := DeployWithProgress(ctx, DeployParams{SitePath: sitePath, SkipBuild: true}, nil)
+	if result.Success || !strings.Contains(result.Error, context.Canceled.Error()) {
+		t.Errorf("DeployWithProgress() with a cancelled context = %+v", result)
+	}
+}
+
+func TestLaunchWizardWithOutput_DelegatesToDeploy(t *testing.T) {
+	sitePath := writeDeploySite(t)
+	var got deployment.DeploymentOptions
+	stubDeployment(t, func(ctx context.Context, opts deployment.DeploymentOptions) (*deployment.DeploymentResult, error) {
+		got = opts
+		opts.OutputLine("Uploading 3 files")
+		return &deployment.DeploymentResult{Success: true, ObjectID: "0xsite"}, nil
+	})
+
+	var lines []string
+	result := LaunchWizardWithOutput(LaunchWizardParams{
+		SitePath:            sitePath,
+		Epochs:              3,
+		ProjectName:         "blog",
+		Category:            "Photography Portfolio",
+		AllowCustomCategory: true,
+	}, func(line string) { lines = append(lines, line) })
+
+	if !result.Success || result.ObjectID != "0xsite" {
+		t.Fatalf("LaunchWizardWithOutput() = %+v", result)
+	}
+	if !got.SaveProject || !got.Quiet || !got.AllowCustomCategory || got.Category != "Photography Portfolio" || got.Epochs != 3 {
+		t.Errorf("options = %+v", got)
+	}
+	if len(lines) != 1 || lines[0] != "Uploading 3 files" {
+		t.Errorf("output lines = %v, want only the deploy output", lines)
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/selimozten/walgo/internal/sui"
+)
+
+// ErrorCode classifies a failed call so the frontend can react to the kind of
+// failure without parsing the human-readable Error message.
+type ErrorCode string
+
+const (
+	// CodeValidation means the caller's input was missing or malformed.
+	CodeValidation ErrorCode = "validation"
+	// CodeNotFound means a project, file or directory does not exist.
+	CodeNotFound ErrorCode = "not_found"
+	// CodeNetwork means an RPC, HTTP or Walrus request failed or timed out.
+	CodeNetwork ErrorCode = "network"
+	// CodeInsufficientFunds means the wallet cannot pay for the transaction.
+	CodeInsufficientFunds ErrorCode = "insufficient_funds"
+	// CodeToolMissing means a required CLI (hugo, sui, walrus, site-builder) is not installed.
+	CodeToolMissing ErrorCode = "tool_missing"
+	// CodeInternal covers every other failure.
+	CodeInternal ErrorCode = "internal"
+)
+
+// errorCode classifies err, checking wrapped error types first and falling
+// back to the wording of CLI output, which is often all that survives from a
+// failed subprocess.
+func errorCode(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+
+	if errors.Is(err, sui.ErrInvalidKeyScheme) {
+		return CodeValidation
+	}
+	var execErr *exec.Error
+	if errors.As(err, &execErr) || errors.Is(err, exec.ErrNotFound) {
+		return CodeToolMissing
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return CodeNetwork
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case containsAny(msg, "insufficient funds", "insufficientgas", "insufficient sui", "insufficient wal",
+		"insufficientcoinbalance", "not enough wal", "not enough sui", "no gas coins"):
+		return CodeInsufficientFunds
+	case containsAny(msg, "not installed", "not found in path", "executable file not found", "cli not found"):
+		return CodeToolMissing
+	case containsAny(msg, "connection refused", "no such host", "timeout", "timed out", "rate limit",
+		"request rejected `429`", "network is unreachable", "could not retrieve enough confirmations"):
+		return CodeNetwork
+	}
+
+	if errors.Is(err, os.ErrNotExist) || strings.Contains(msg, "not found") {
+		return CodeNotFound
+	}
+	return CodeInternal
+}
+
+// containsAny reports whether s contains any of substrs.
+func containsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
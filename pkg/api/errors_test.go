@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/selimozten/walgo/internal/sui"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{"nil", nil, ""},
+		{"missing executable", &exec.Error{Name: "hugo", Err: exec.ErrNotFound}, CodeToolMissing},
+		{"wrapped missing executable", fmt.Errorf("failed to build site: %w", &exec.Error{Name: "hugo", Err: exec.ErrNotFound}), CodeToolMissing},
+		{"tool message", errors.New("walrus CLI not found in PATH"), CodeToolMissing},
+		{"net error", fmt.Errorf("fetching: %w", &net.DNSError{Err: "no such host", Name: "example.invalid"}), CodeNetwork},
+		{"deadline", fmt.Errorf("deploy: %w", context.DeadlineExceeded), CodeNetwork},
+		{"rate limited", errors.New("Request rejected `429`"), CodeNetwork},
+		{"insufficient gas", errors.New("Error: InsufficientGas"), CodeInsufficientFunds},
+		{"insufficient funds", errors.New("site-builder: insufficient funds for transaction"), CodeInsufficientFunds},
+		{"missing file", fmt.Errorf("reading file: %w", os.ErrNotExist), CodeNotFound},
+		{"project not found", errors.New("project not found"), CodeNotFound},
+		{"invalid key scheme", fmt.Errorf("failed to create address: %w", sui.ErrInvalidKeyScheme), CodeValidation},
+		{"other", errors.New("failed to parse walgo.yaml"), CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorCode(tt.err); got != tt.want {
+				t.Errorf("errorCode(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}